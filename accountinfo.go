@@ -0,0 +1,101 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// AllEnabledExchangeAccounts holds all enabled accounts info
+type AllEnabledExchangeAccounts struct {
+	Data []exchange.AccountInfo `json:"data"`
+}
+
+// accountInfoSnapshot stores a cached exchange account info response
+type accountInfoSnapshot struct {
+	Info        exchange.AccountInfo
+	LastUpdated time.Time
+}
+
+var (
+	accountInfoCache    = make(map[string]accountInfoSnapshot)
+	accountInfoCacheMtx sync.Mutex
+)
+
+// getAccountInfoCacheTTL returns the configured account info cache duration
+// for an exchange
+func getAccountInfoCacheTTL(exchName string) time.Duration {
+	if bot.config == nil {
+		return 0
+	}
+
+	exchCfg, err := bot.config.GetExchangeConfig(exchName)
+	if err != nil {
+		return 0
+	}
+	return exchCfg.AccountInfoCacheTTL
+}
+
+// GetExchangeAccountInfo returns the account info for an exchange. A cached
+// snapshot is returned if it is still within the exchanges configured TTL,
+// unless forceRefresh is set
+func GetExchangeAccountInfo(exch exchange.IBotExchange, forceRefresh bool) (exchange.AccountInfo, error) {
+	exchName := exch.GetName()
+
+	if !forceRefresh {
+		accountInfoCacheMtx.Lock()
+		snapshot, ok := accountInfoCache[exchName]
+		accountInfoCacheMtx.Unlock()
+		if ok && clock.Since(snapshot.LastUpdated) < getAccountInfoCacheTTL(exchName) {
+			return snapshot.Info, nil
+		}
+	}
+
+	info, err := exch.GetAccountInfo()
+	if err != nil {
+		return exchange.AccountInfo{}, err
+	}
+	info.Sandbox = exch.IsSandbox()
+
+	accountInfoCacheMtx.Lock()
+	accountInfoCache[exchName] = accountInfoSnapshot{
+		Info:        info,
+		LastUpdated: clock.Now(),
+	}
+	accountInfoCacheMtx.Unlock()
+	return info, nil
+}
+
+// InvalidateExchangeAccountInfo removes a cached account snapshot so the next
+// request fetches fresh data from the exchange
+func InvalidateExchangeAccountInfo(exchName string) {
+	accountInfoCacheMtx.Lock()
+	delete(accountInfoCache, exchName)
+	accountInfoCacheMtx.Unlock()
+}
+
+// GetAllEnabledExchangeAccountInfo returns all the current enabled exchanges
+// account info, using cached snapshots unless forceRefresh is set
+func GetAllEnabledExchangeAccountInfo(forceRefresh bool) AllEnabledExchangeAccounts {
+	var response AllEnabledExchangeAccounts
+	exchanges := GetExchanges()
+	for _, individualBot := range exchanges {
+		if individualBot != nil && individualBot.IsEnabled() {
+			if !individualBot.GetAuthenticatedAPISupport() {
+				log.Warnf("GetAllEnabledExchangeAccountInfo: Skippping %s due to disabled authenticated API support.", individualBot.GetName())
+				continue
+			}
+			individualExchange, err := GetExchangeAccountInfo(individualBot, forceRefresh)
+			if err != nil {
+				log.Errorf("Error encountered retrieving exchange account info for %s. Error %s",
+					individualBot.GetName(), err)
+				continue
+			}
+			response.Data = append(response.Data, individualExchange)
+		}
+	}
+	return response
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type accountInfoTestExchange struct {
+	exchange.IBotExchange
+	name  string
+	calls int
+}
+
+func (a *accountInfoTestExchange) GetName() string {
+	return a.name
+}
+
+func (a *accountInfoTestExchange) IsSandbox() bool {
+	return false
+}
+
+func (a *accountInfoTestExchange) GetAccountInfo() (exchange.AccountInfo, error) {
+	a.calls++
+	return exchange.AccountInfo{Exchange: a.name}, nil
+}
+
+func setupAccountInfoTest(t *testing.T, ttl time.Duration) *accountInfoTestExchange {
+	if !testSetup {
+		bot.config = &config.Cfg
+		err := bot.config.LoadConfig("./testdata/configtest.json")
+		if err != nil {
+			t.Fatalf("Test failed. SetupTest: Failed to load config: %s", err)
+		}
+		testSetup = true
+	}
+
+	exchCfg, err := bot.config.GetExchangeConfig("Bitstamp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchCfg.AccountInfoCacheTTL = ttl
+	exchCfg.BalanceReserves = nil
+	err = bot.config.UpdateExchangeConfig(&exchCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	InvalidateExchangeAccountInfo("Bitstamp")
+	return &accountInfoTestExchange{name: "Bitstamp"}
+}
+
+func TestGetExchangeAccountInfo(t *testing.T) {
+	exch := setupAccountInfoTest(t, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		_, err := GetExchangeAccountInfo(exch, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if exch.calls != 1 {
+		t.Errorf("Test failed. Expected 1 exchange request, got %d", exch.calls)
+	}
+
+	_, err := GetExchangeAccountInfo(exch, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exch.calls != 2 {
+		t.Errorf("Test failed. Expected forced refresh to query exchange, got %d requests", exch.calls)
+	}
+
+	InvalidateExchangeAccountInfo(exch.GetName())
+	_, err = GetExchangeAccountInfo(exch, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exch.calls != 3 {
+		t.Errorf("Test failed. Expected invalidated cache to query exchange, got %d requests", exch.calls)
+	}
+}
+
+func TestGetExchangeAccountInfoExpired(t *testing.T) {
+	exch := setupAccountInfoTest(t, time.Nanosecond)
+
+	for i := 0; i < 2; i++ {
+		_, err := GetExchangeAccountInfo(exch, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if exch.calls != 2 {
+		t.Errorf("Test failed. Expected expired cache to query exchange, got %d requests", exch.calls)
+	}
+}
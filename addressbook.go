@@ -0,0 +1,308 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	addressBookEventType    = "ADDRESS_BOOK"
+	addressBookSyncInterval = time.Hour
+
+	// AddressNotWhitelisted is a local address book entry missing from an
+	// exchanges withdrawal whitelist, withdrawals to it are blocked
+	AddressNotWhitelisted = "NOT_WHITELISTED"
+	// AddressNotInAddressBook is an address whitelisted on an exchange which
+	// is missing from the local address book
+	AddressNotInAddressBook = "NOT_IN_ADDRESS_BOOK"
+)
+
+// AddressBookMismatch is a withdrawal address held by only one of the local
+// address book and an exchanges withdrawal whitelist
+type AddressBookMismatch struct {
+	Type     string `json:"type"`
+	Currency string `json:"currency"`
+	Address  string `json:"address"`
+	Tag      string `json:"tag,omitempty"`
+	Network  string `json:"network,omitempty"`
+	Label    string `json:"label,omitempty"`
+}
+
+// key returns the key a mismatch is notified by
+func (a *AddressBookMismatch) key() string {
+	return a.Type + "|" + a.Currency + "|" + a.Address + "|" + a.Tag
+}
+
+// AddressBookSync is the withdrawal whitelist last fetched from an exchange
+// and its mismatches with the local address book
+type AddressBookSync struct {
+	Exchange   string                        `json:"exchange"`
+	Whitelist  []exchange.WhitelistedAddress `json:"whitelist"`
+	Mismatches []AddressBookMismatch         `json:"mismatches"`
+	Synced     time.Time                     `json:"synced"`
+}
+
+// addressBookMonitor holds the last synced withdrawal whitelist of each
+// exchange keyed by exchange name
+type addressBookMonitor struct {
+	books map[string]AddressBookSync
+	m     sync.Mutex
+}
+
+var addressBooks addressBookMonitor
+
+// Update records an exchanges synced withdrawal whitelist. It returns the
+// mismatches which weren't present at the previous sync
+func (a *addressBookMonitor) Update(book AddressBookSync) []AddressBookMismatch {
+	a.m.Lock()
+	defer a.m.Unlock()
+	if a.books == nil {
+		a.books = make(map[string]AddressBookSync)
+	}
+
+	previous := make(map[string]bool)
+	for x := range a.books[book.Exchange].Mismatches {
+		previous[a.books[book.Exchange].Mismatches[x].key()] = true
+	}
+	a.books[book.Exchange] = book
+
+	var resp []AddressBookMismatch
+	for x := range book.Mismatches {
+		if !previous[book.Mismatches[x].key()] {
+			resp = append(resp, book.Mismatches[x])
+		}
+	}
+	return resp
+}
+
+// Get returns the last synced withdrawal whitelist of an exchange
+func (a *addressBookMonitor) Get(exchName string) (AddressBookSync, bool) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	book, ok := a.books[exchName]
+	return book, ok
+}
+
+// GetAll returns the last synced withdrawal whitelist of each exchange ordered
+// by exchange
+func (a *addressBookMonitor) GetAll() []AddressBookSync {
+	a.m.Lock()
+	defer a.m.Unlock()
+	resp := make([]AddressBookSync, 0, len(a.books))
+	for _, book := range a.books {
+		resp = append(resp, book)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Exchange < resp[j].Exchange
+	})
+	return resp
+}
+
+// isWhitelisted returns whether an address and tag of a currency is in a
+// withdrawal whitelist. Networks are only compared when both are known
+func isWhitelisted(whitelist []exchange.WhitelistedAddress, code, address, tag, network string) bool {
+	for x := range whitelist {
+		if whitelist[x].Currency.Upper().String() != strings.ToUpper(code) ||
+			!strings.EqualFold(whitelist[x].Address, address) ||
+			whitelist[x].Tag != tag {
+			continue
+		}
+		if network != "" && whitelist[x].Network != "" &&
+			!strings.EqualFold(whitelist[x].Network, network) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// reconcileAddressBook returns the mismatches between the local address book
+// entries of an exchange and its withdrawal whitelist. An empty whitelist
+// means the exchange doesn't restrict withdrawals so nothing mismatches
+func reconcileAddressBook(exchName string, local []config.WithdrawalAddressConfig, whitelist []exchange.WhitelistedAddress) []AddressBookMismatch {
+	if len(whitelist) == 0 {
+		return nil
+	}
+
+	var entries []config.WithdrawalAddressConfig
+	for x := range local {
+		if local[x].Exchange == "" || strings.EqualFold(local[x].Exchange, exchName) {
+			entries = append(entries, local[x])
+		}
+	}
+
+	var resp []AddressBookMismatch
+	for x := range entries {
+		if isWhitelisted(whitelist, entries[x].Currency, entries[x].Address,
+			entries[x].Tag, entries[x].Network) {
+			continue
+		}
+		resp = append(resp, AddressBookMismatch{
+			Type:     AddressNotWhitelisted,
+			Currency: entries[x].Currency,
+			Address:  entries[x].Address,
+			Tag:      entries[x].Tag,
+			Network:  entries[x].Network,
+			Label:    entries[x].Label,
+		})
+	}
+
+	for x := range whitelist {
+		var found bool
+		for y := range entries {
+			if isWhitelisted(whitelist[x:x+1], entries[y].Currency, entries[y].Address,
+				entries[y].Tag, entries[y].Network) {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		resp = append(resp, AddressBookMismatch{
+			Type:     AddressNotInAddressBook,
+			Currency: whitelist[x].Currency.Upper().String(),
+			Address:  whitelist[x].Address,
+			Tag:      whitelist[x].Tag,
+			Network:  whitelist[x].Network,
+			Label:    whitelist[x].Label,
+		})
+	}
+	return resp
+}
+
+// AddressBookRoutine periodically fetches the withdrawal whitelists of
+// exchanges with authenticated API support and reconciles them with the local
+// address book
+func AddressBookRoutine() {
+	log.Debugln("Starting address book routine.")
+	for {
+		exchanges := GetExchanges()
+		for x := range exchanges {
+			if exchanges[x] == nil || !exchanges[x].IsEnabled() ||
+				!exchanges[x].GetAuthenticatedAPISupport() {
+				continue
+			}
+			err := syncAddressBook(exchanges[x], bot.config.WithdrawalAddresses,
+				clock.Now())
+			if err != nil {
+				log.Debugf("%s failed to get withdrawal whitelist. Error: %s",
+					exchanges[x].GetName(), err)
+			}
+		}
+		clock.Sleep(addressBookSyncInterval)
+	}
+}
+
+// syncAddressBook fetches an exchanges withdrawal whitelist and reconciles it
+// with the local address book, notifying mismatches not seen at the previous
+// sync. Exchanges which don't expose a whitelist are ignored
+func syncAddressBook(exch exchange.IBotExchange, local []config.WithdrawalAddressConfig, now time.Time) error {
+	lister, ok := exch.(exchange.IWithdrawalWhitelistExchange)
+	if !ok {
+		return nil
+	}
+
+	whitelist, err := lister.GetWithdrawalWhitelist()
+	if err != nil {
+		return err
+	}
+
+	book := AddressBookSync{
+		Exchange:   exch.GetName(),
+		Whitelist:  whitelist,
+		Mismatches: reconcileAddressBook(exch.GetName(), local, whitelist),
+		Synced:     now,
+	}
+	mismatches := addressBooks.Update(book)
+	for x := range mismatches {
+		notifyAddressBookMismatch(exch.GetName(), &mismatches[x])
+	}
+	return nil
+}
+
+// notifyAddressBookMismatch logs and pushes an address book mismatch through
+// the communications package
+func notifyAddressBookMismatch(exchName string, mismatch *AddressBookMismatch) {
+	format := i18n.MessageAddressBookMissing
+	if mismatch.Type == AddressNotWhitelisted {
+		format = i18n.MessageWhitelistMissing
+	}
+	message := i18n.T(format, exchName, mismatch.Currency, mismatch.Address)
+	log.Warn(message)
+	pushEvent(addressBookEventType, message)
+}
+
+// checkWithdrawalWhitelisted returns an error when a withdrawal is to an
+// address missing from the exchanges withdrawal whitelist, as the exchange
+// would reject it. The whitelist is synced first if it hasn't been yet and
+// withdrawals are allowed when it can't be fetched
+func checkWithdrawalWhitelisted(exch exchange.IBotExchange, withdrawRequest *exchange.WithdrawRequest) error {
+	if _, ok := exch.(exchange.IWithdrawalWhitelistExchange); !ok {
+		return nil
+	}
+
+	book, ok := addressBooks.Get(exch.GetName())
+	if !ok {
+		var local []config.WithdrawalAddressConfig
+		if bot.config != nil {
+			local = bot.config.WithdrawalAddresses
+		}
+		err := syncAddressBook(exch, local, clock.Now())
+		if err != nil {
+			log.Debugf("%s failed to get withdrawal whitelist. Error: %s",
+				exch.GetName(), err)
+			return nil
+		}
+		book, _ = addressBooks.Get(exch.GetName())
+	}
+
+	if len(book.Whitelist) == 0 ||
+		isWhitelisted(book.Whitelist, withdrawRequest.Currency.String(),
+			withdrawRequest.Address, withdrawRequest.AddressTag, withdrawRequest.Network) {
+		return nil
+	}
+	return fmt.Errorf("%s %s withdrawal address %s is not whitelisted on the exchange",
+		exch.GetName(), withdrawRequest.Currency, withdrawRequest.Address)
+}
+
+// GetAddressBookSyncs returns the last synced withdrawal whitelist of each
+// exchange and its mismatches with the local address book
+func GetAddressBookSyncs() []AddressBookSync {
+	return addressBooks.GetAll()
+}
+
+// setWithdrawalCredentials fills the trade password and one-time password of
+// a withdrawal from the exchange config when they aren't supplied, generating
+// the one-time password from the configured TOTP secret
+func setWithdrawalCredentials(exchName string, withdrawRequest *exchange.WithdrawRequest) error {
+	if bot.config == nil {
+		return nil
+	}
+	exchCfg, err := bot.config.GetExchangeConfig(exchName)
+	if err != nil {
+		return nil
+	}
+
+	if withdrawRequest.TradePassword == "" {
+		withdrawRequest.TradePassword = exchCfg.TradePassword
+	}
+	if withdrawRequest.OneTimePassword == 0 && exchCfg.OTPSecret != "" {
+		withdrawRequest.OneTimePassword, err = common.GenerateTOTP(exchCfg.OTPSecret, clock.Now())
+		if err != nil {
+			return fmt.Errorf("%s unable to generate withdrawal one-time password: %s",
+				exchName, err)
+		}
+	}
+	return nil
+}
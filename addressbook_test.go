@@ -0,0 +1,173 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type addressBookTestExchange struct {
+	riskTestExchange
+	whitelist []exchange.WhitelistedAddress
+	syncs     int
+}
+
+func (a *addressBookTestExchange) GetWithdrawalWhitelist() ([]exchange.WhitelistedAddress, error) {
+	a.syncs++
+	return a.whitelist, nil
+}
+
+func TestReconcileAddressBook(t *testing.T) {
+	local := []config.WithdrawalAddressConfig{
+		{Label: "cold", Currency: "BTC", Address: "1abc"},
+		{Currency: "XRP", Address: "rabc", Tag: "1"},
+		{Exchange: "Kraken", Currency: "ETH", Address: "0xabc"},
+		{Currency: "LTC", Address: "Labc", Network: "LTC"},
+	}
+	whitelist := []exchange.WhitelistedAddress{
+		{Currency: currency.BTC, Address: "1abc", Network: "BTC"},
+		{Currency: currency.XRP, Address: "rabc", Tag: "2"},
+		{Currency: currency.ETH, Address: "0xdef", Label: "hot"},
+	}
+
+	mismatches := reconcileAddressBook("Binance", local, whitelist)
+	if len(mismatches) != 4 {
+		t.Fatalf("Test failed. Expected 4 mismatches, got %+v", mismatches)
+	}
+	if mismatches[0].Type != AddressNotWhitelisted || mismatches[0].Address != "rabc" ||
+		mismatches[1].Type != AddressNotWhitelisted || mismatches[1].Address != "Labc" {
+		t.Errorf("Test failed. Expected the local addresses missing from the whitelist, got %+v",
+			mismatches[:2])
+	}
+	if mismatches[2].Type != AddressNotInAddressBook || mismatches[2].Tag != "2" ||
+		mismatches[3].Type != AddressNotInAddressBook || mismatches[3].Label != "hot" {
+		t.Errorf("Test failed. Expected the whitelisted addresses missing locally, got %+v",
+			mismatches[2:])
+	}
+
+	if len(reconcileAddressBook("Binance", local, nil)) != 0 {
+		t.Error("Test failed. Expected no mismatches without a whitelist")
+	}
+}
+
+func TestSyncAddressBook(t *testing.T) {
+	addressBooks = addressBookMonitor{}
+	defer func() { addressBooks = addressBookMonitor{} }()
+
+	exch := &addressBookTestExchange{
+		riskTestExchange: riskTestExchange{
+			accountInfoTestExchange: accountInfoTestExchange{name: "AddressBookTest"},
+		},
+		whitelist: []exchange.WhitelistedAddress{{Currency: currency.BTC, Address: "1abc"}},
+	}
+	local := []config.WithdrawalAddressConfig{{Currency: "BTC", Address: "1def"}}
+	now := time.Unix(1000, 0)
+	if err := syncAddressBook(exch, local, now); err != nil {
+		t.Fatal(err)
+	}
+
+	books := GetAddressBookSyncs()
+	if len(books) != 1 || len(books[0].Whitelist) != 1 || len(books[0].Mismatches) != 2 ||
+		!books[0].Synced.Equal(now) {
+		t.Errorf("Test failed. Unexpected address books %+v", books)
+	}
+
+	if len(addressBooks.Update(books[0])) != 0 {
+		t.Error("Test failed. Expected known mismatches not to be notified again")
+	}
+
+	// Exchanges which don't expose a whitelist are not tracked
+	err := syncAddressBook(&accountInfoTestExchange{name: "Unpublished"}, local, now)
+	if err != nil || len(GetAddressBookSyncs()) != 1 {
+		t.Error("Test failed. Expected exchanges without a whitelist to be ignored")
+	}
+}
+
+func TestWithdrawExchangeCryptocurrencyFundsWhitelist(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+
+	addressBooks = addressBookMonitor{}
+	defer func() { addressBooks = addressBookMonitor{} }()
+
+	exch := &addressBookTestExchange{
+		riskTestExchange: *setupRiskTest(t),
+		whitelist: []exchange.WhitelistedAddress{
+			{Currency: currency.LTC, Address: "Labc"},
+		},
+	}
+
+	_, err := WithdrawExchangeCryptocurrencyFunds(exch, &exchange.WithdrawRequest{
+		Currency: currency.LTC,
+		Amount:   1,
+		Address:  "Ldef",
+	})
+	if err == nil {
+		t.Error("Test failed. Expected withdrawal to an address not whitelisted to be rejected")
+	}
+
+	_, err = WithdrawExchangeCryptocurrencyFunds(exch, &exchange.WithdrawRequest{
+		Currency: currency.LTC,
+		Amount:   1,
+		Address:  "Labc",
+	})
+	if err != nil {
+		t.Errorf("Test failed. Expected withdrawal to a whitelisted address to be submitted: %s", err)
+	}
+
+	if exch.withdrawals != 1 || exch.syncs != 1 {
+		t.Errorf("Test failed. Expected 1 withdrawal after a single whitelist sync, got %d %d",
+			exch.withdrawals, exch.syncs)
+	}
+}
+
+func TestSetWithdrawalCredentials(t *testing.T) {
+	exch := setupAccountInfoTest(t, time.Minute)
+	exchCfg, err := bot.config.GetExchangeConfig(exch.GetName())
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchCfg.TradePassword = "hunter2"
+	exchCfg.OTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	err = bot.config.UpdateExchangeConfig(&exchCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		exchCfg.TradePassword = ""
+		exchCfg.OTPSecret = ""
+		bot.config.UpdateExchangeConfig(&exchCfg)
+	}()
+
+	var withdrawRequest exchange.WithdrawRequest
+	err = setWithdrawalCredentials(exch.GetName(), &withdrawRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withdrawRequest.TradePassword != "hunter2" || withdrawRequest.OneTimePassword == 0 {
+		t.Errorf("Test failed. Expected the configured credentials, got %+v", withdrawRequest)
+	}
+
+	withdrawRequest = exchange.WithdrawRequest{TradePassword: "supplied", OneTimePassword: 123456}
+	err = setWithdrawalCredentials(exch.GetName(), &withdrawRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withdrawRequest.TradePassword != "supplied" || withdrawRequest.OneTimePassword != 123456 {
+		t.Errorf("Test failed. Expected supplied credentials to be kept, got %+v", withdrawRequest)
+	}
+
+	exchCfg.OTPSecret = "not base32!"
+	err = bot.config.UpdateExchangeConfig(&exchCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = setWithdrawalCredentials(exch.GetName(), &exchange.WithdrawRequest{})
+	if err == nil {
+		t.Error("Test failed. Expected an invalid OTP secret to error")
+	}
+}
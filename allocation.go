@@ -0,0 +1,276 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/decimal"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+var errNoStrategyAllocation = errors.New("strategy has no allocation on exchange")
+
+// StrategyBalance is the virtual balance of a currency held by a strategy.
+// PnL is the change in balance since it was allocated
+type StrategyBalance struct {
+	Currency  string  `json:"currency"`
+	Allocated float64 `json:"allocated"`
+	Balance   float64 `json:"balance"`
+	PnL       float64 `json:"pnl"`
+}
+
+// StrategyAllocation is the virtual balances a strategy holds on an exchange
+// and the number of orders it has submitted there
+type StrategyAllocation struct {
+	Strategy string            `json:"strategy"`
+	Exchange string            `json:"exchange"`
+	Orders   int               `json:"orders"`
+	Balances []StrategyBalance `json:"balances"`
+}
+
+// strategyAllocation holds a strategies allocated and current balances on an
+// exchange, keyed by upper case currency code
+type strategyAllocation struct {
+	strategy  string
+	exchange  string
+	allocated map[string]decimal.Decimal
+	balances  map[string]decimal.Decimal
+	orders    int
+}
+
+// allocationManager holds the strategy allocations in the order they were
+// configured
+type allocationManager struct {
+	allocations []*strategyAllocation
+	m           sync.Mutex
+}
+
+var strategyAllocations allocationManager
+
+// Load replaces the allocations with those configured, resetting every
+// strategies balances and PnL
+func (a *allocationManager) Load(cfgs []config.StrategyAllocationConfig) {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	a.allocations = nil
+	for x := range cfgs {
+		s := &strategyAllocation{
+			strategy:  common.StringToLower(cfgs[x].Strategy),
+			exchange:  cfgs[x].Exchange,
+			allocated: make(map[string]decimal.Decimal),
+			balances:  make(map[string]decimal.Decimal),
+		}
+		for code, amount := range cfgs[x].Balances {
+			code = common.StringToUpper(code)
+			s.allocated[code] = decimal.NewFromFloat(amount)
+			s.balances[code] = s.allocated[code]
+		}
+		a.allocations = append(a.allocations, s)
+	}
+}
+
+// find returns the allocation of a strategy on an exchange and whether the
+// strategy has an allocation on any exchange. Must be called with the lock held
+func (a *allocationManager) find(strategy, exchName string) (*strategyAllocation, bool) {
+	var configured bool
+	for x := range a.allocations {
+		if a.allocations[x].strategy != common.StringToLower(strategy) {
+			continue
+		}
+		configured = true
+		if strings.EqualFold(a.allocations[x].exchange, exchName) {
+			return a.allocations[x], true
+		}
+	}
+	return nil, configured
+}
+
+// IsLimited returns whether a strategy has an allocation on any exchange
+func (a *allocationManager) IsLimited(strategy string) bool {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	_, configured := a.find(strategy, "")
+	return configured
+}
+
+// Reserve debits an amount of a currency from a strategies balance on an
+// exchange, returning an error if it would exceed the balance or the strategy
+// has no allocation on the exchange. Strategies without any allocation aren't
+// limited
+func (a *allocationManager) Reserve(strategy, exchName string, c currency.Code, amount decimal.Decimal) error {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	s, configured := a.find(strategy, exchName)
+	if !configured {
+		return nil
+	}
+	if s == nil {
+		return fmt.Errorf("%s %s %s", strategy, errNoStrategyAllocation, exchName)
+	}
+
+	code := c.Upper().String()
+	balance := s.balances[code]
+	if balance.Cmp(amount) < 0 {
+		return fmt.Errorf("strategy %s %s %s allocation exceeded: spending %s of %s available",
+			strategy, exchName, code, amount, balance)
+	}
+	s.balances[code] = balance.Sub(amount)
+	return nil
+}
+
+// Adjust adds a signed amount of a currency to a strategies balance on an
+// exchange
+func (a *allocationManager) Adjust(strategy, exchName string, c currency.Code, amount decimal.Decimal) {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	s, _ := a.find(strategy, exchName)
+	if s == nil {
+		return
+	}
+	code := c.Upper().String()
+	s.balances[code] = s.balances[code].Add(amount)
+}
+
+// AddOrder counts an order submitted by a strategy on an exchange
+func (a *allocationManager) AddOrder(strategy, exchName string) {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	if s, _ := a.find(strategy, exchName); s != nil {
+		s.orders++
+	}
+}
+
+// Get returns the strategy allocations with their balances sorted by currency
+func (a *allocationManager) Get() []StrategyAllocation {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	resp := make([]StrategyAllocation, 0, len(a.allocations))
+	for x := range a.allocations {
+		s := a.allocations[x]
+		allocation := StrategyAllocation{
+			Strategy: s.strategy,
+			Exchange: s.exchange,
+			Orders:   s.orders,
+		}
+		for code, balance := range s.balances {
+			allocated := s.allocated[code]
+			allocation.Balances = append(allocation.Balances, StrategyBalance{
+				Currency:  code,
+				Allocated: allocated.Float64(),
+				Balance:   balance.Float64(),
+				PnL:       balance.Sub(allocated).Float64(),
+			})
+		}
+		sort.Slice(allocation.Balances, func(i, j int) bool {
+			return allocation.Balances[i].Currency < allocation.Balances[j].Currency
+		})
+		resp = append(resp, allocation)
+	}
+	return resp
+}
+
+// GetStrategyAllocations returns each strategies virtual balances and PnL
+func GetStrategyAllocations() []StrategyAllocation {
+	return strategyAllocations.Get()
+}
+
+// SubmitStrategyOrderRequest validates a requested order and submits it on
+// behalf of a strategy
+func SubmitStrategyOrderRequest(strategy, exchName, currencyPair, side, orderType string, amount, price float64, tags OrderTags) (exchange.SubmitOrderResponse, error) {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return exchange.SubmitOrderResponse{}, errors.New(exchange.ErrExchangeNotFound)
+	}
+
+	s, t, err := parseOrderRequest(side, orderType, amount)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+
+	return SubmitStrategyOrder(strategy, exch, currency.NewPairFromString(currencyPair),
+		s, t, amount, price, "", tags)
+}
+
+// getOrderFlows returns the currency and amount an order spends and the
+// currency and amount it receives once filled. Market orders are valued at the
+// last ticker price
+func getOrderFlows(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, amount, price float64) (spend currency.Code, spent decimal.Decimal, receive currency.Code, received decimal.Decimal, err error) {
+	if price <= 0 {
+		t, err := ticker.GetTicker(exch.GetName(), p, ticker.Spot)
+		if err != nil || t.Last <= 0 {
+			return spend, spent, receive, received,
+				fmt.Errorf("unable to value %s %s order", exch.GetName(), p)
+		}
+		price = t.Last
+	}
+
+	base := decimal.NewFromFloat(amount)
+	quote := base.Mul(decimal.NewFromFloat(price))
+	switch side {
+	case exchange.SellOrderSide, exchange.AskOrderSide:
+		return p.Base, base, p.Quote, quote, nil
+	case exchange.BuyOrderSide, exchange.BidOrderSide:
+		return p.Quote, quote, p.Base, base, nil
+	}
+	return spend, spent, receive, received,
+		fmt.Errorf("unsupported order side %s", side)
+}
+
+// SubmitStrategyOrder submits an order on behalf of a strategy, rejecting it
+// if it would spend more than the strategies allocation on the exchange. The
+// strategies balances are updated as if the order filled at its price, fees
+// are not deducted. Strategies without any allocation are submitted unchanged.
+// Orders are tagged with the strategy and rejected while the circuit breaker
+// suspends automated orders on the pair
+func SubmitStrategyOrder(strategy string, exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string, tags OrderTags) (exchange.SubmitOrderResponse, error) {
+	err := checkCircuitBreaker(exch.GetName(), p)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+
+	tags = tags.With(OrderTagStrategy, strategy)
+	if !strategyAllocations.IsLimited(strategy) {
+		return SubmitTaggedOrder(exch, p, side, orderType, amount, price, clientID, tags)
+	}
+
+	amount, price, err = normaliseOrderAmounts(exch, p, amount, price)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+
+	spend, spent, receive, received, err := getOrderFlows(exch, p, side, amount, price)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+
+	err = strategyAllocations.Reserve(strategy, exch.GetName(), spend, spent)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+
+	resp, err := SubmitTaggedOrder(exch, p, side, orderType, amount, price, clientID, tags)
+	if err != nil {
+		strategyAllocations.Adjust(strategy, exch.GetName(), spend, spent)
+		return resp, err
+	}
+
+	strategyAllocations.Adjust(strategy, exch.GetName(), receive, received)
+	strategyAllocations.AddOrder(strategy, exch.GetName())
+	log.Debugf("Strategy %s %s order %s spent %s %s for %s %s",
+		strategy, exch.GetName(), resp.OrderID, spent, spend, received, receive)
+	return resp, nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type allocationTestExchange struct {
+	riskTestExchange
+	fail bool
+}
+
+func (a *allocationTestExchange) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if a.fail {
+		return exchange.SubmitOrderResponse{}, errors.New("order rejected")
+	}
+	return a.riskTestExchange.SubmitOrder(p, side, orderType, amount, price, clientID)
+}
+
+func getStrategyAllocation(t *testing.T, strategy string) StrategyAllocation {
+	t.Helper()
+	for _, a := range GetStrategyAllocations() {
+		if a.Strategy == strategy {
+			return a
+		}
+	}
+	t.Fatalf("Test failed. Strategy %s allocation not found", strategy)
+	return StrategyAllocation{}
+}
+
+func getStrategyBalance(a StrategyAllocation, c string) StrategyBalance {
+	for x := range a.Balances {
+		if a.Balances[x].Currency == c {
+			return a.Balances[x]
+		}
+	}
+	return StrategyBalance{Currency: c}
+}
+
+func TestSubmitStrategyOrder(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+
+	exch := &allocationTestExchange{
+		riskTestExchange: riskTestExchange{
+			accountInfoTestExchange: *setupAccountInfoTest(t, time.Minute),
+		},
+	}
+	strategyAllocations.Load([]config.StrategyAllocationConfig{
+		{Strategy: "grid", Exchange: "bitstamp", Balances: map[string]float64{"USD": 500}},
+		{Strategy: "grid", Exchange: "Kraken", Balances: map[string]float64{"USD": 500}},
+		{Strategy: "momentum", Exchange: "Bitstamp", Balances: map[string]float64{"USD": 100}},
+	})
+	defer strategyAllocations.Load(nil)
+	p := currency.NewPairFromStrings("BTC", "USD")
+
+	_, err := SubmitStrategyOrder("grid", exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 0.1, 4000, "", nil)
+	if err != nil {
+		t.Fatalf("Test failed. Expected order within allocation to be placed: %s", err)
+	}
+
+	_, err = SubmitStrategyOrder("grid", exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 0.1, 4000, "", nil)
+	if err == nil {
+		t.Error("Test failed. Expected order exceeding the allocation to be rejected")
+	}
+
+	_, err = SubmitStrategyOrder("grid", exch, p, exchange.SellOrderSide,
+		exchange.LimitOrderType, 0.05, 5000, "", nil)
+	if err != nil {
+		t.Fatalf("Test failed. Expected sell of bought BTC to be placed: %s", err)
+	}
+
+	_, err = SubmitStrategyOrder("momentum", exch, p, exchange.SellOrderSide,
+		exchange.LimitOrderType, 0.05, 5000, "", nil)
+	if err == nil {
+		t.Error("Test failed. Expected strategy without BTC to be rejected")
+	}
+
+	exch.fail = true
+	_, err = SubmitStrategyOrder("momentum", exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 0.01, 5000, "", nil)
+	if err == nil {
+		t.Error("Test failed. Expected the exchange error to be returned")
+	}
+	exch.fail = false
+
+	_, err = SubmitStrategyOrder("unallocated", exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 1, 5000, "", nil)
+	if err != nil {
+		t.Errorf("Test failed. Expected strategy without allocations to be unlimited: %s", err)
+	}
+
+	exch.name = "Bitfinex"
+	_, err = SubmitStrategyOrder("momentum", exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 0.01, 5000, "", nil)
+	if err == nil {
+		t.Error("Test failed. Expected strategy without an allocation on the exchange to be rejected")
+	}
+
+	if exch.orders != 3 {
+		t.Errorf("Test failed. Expected 3 orders to reach the exchange, got %d", exch.orders)
+	}
+
+	grid := getStrategyAllocation(t, "grid")
+	usd := getStrategyBalance(grid, "USD")
+	btc := getStrategyBalance(grid, "BTC")
+	if grid.Orders != 2 || usd.Balance != 350 || usd.PnL != -150 ||
+		btc.Balance != 0.05 || btc.PnL != 0.05 {
+		t.Errorf("Test failed. Unexpected grid allocation %+v", grid)
+	}
+
+	momentum := getStrategyAllocation(t, "momentum")
+	if momentum.Orders != 0 || getStrategyBalance(momentum, "USD").Balance != 100 {
+		t.Errorf("Test failed. Expected failed orders to be refunded, got %+v", momentum)
+	}
+}
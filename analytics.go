@@ -0,0 +1,365 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	analyticsEventType = "ANALYTICS"
+
+	// minCorrelationSamples is the number of common candle returns two series
+	// require before their correlation is reported
+	minCorrelationSamples = 10
+)
+
+// AnalyticsSeries is a stored candle series included in the analytics
+type AnalyticsSeries struct {
+	Exchange string        `json:"exchange"`
+	Pair     currency.Pair `json:"pair"`
+}
+
+// PairAnalytics holds the rolling correlation and relative strength between
+// the candle series of enabled pairs. Returns is the percentage return of each
+// series over the window and RelativeStrength[i][j] the percentage points
+// series i outperformed series j. Correlations are of candle returns at common
+// open times, series sharing fewer than minCorrelationSamples have a zero
+// correlation with their Samples showing why. OpenInterest holds the tracked
+// derivatives contracts
+type PairAnalytics struct {
+	Generated        time.Time              `json:"generated"`
+	CandleInterval   time.Duration          `json:"candleInterval"`
+	Window           int                    `json:"window"`
+	Series           []AnalyticsSeries      `json:"series"`
+	Returns          []float64              `json:"returns"`
+	Correlation      [][]float64            `json:"correlation"`
+	Samples          [][]int                `json:"samples"`
+	RelativeStrength [][]float64            `json:"relativeStrength"`
+	OpenInterest     []ContractOpenInterest `json:"openInterest,omitempty"`
+}
+
+// ContractOpenInterest is the open interest of a tracked derivatives contract
+// and its percentage Change since the previous update, zero when there was no
+// previous open interest to compare against
+type ContractOpenInterest struct {
+	Exchange  string        `json:"exchange"`
+	Pair      currency.Pair `json:"pair"`
+	AssetType string        `json:"assetType"`
+	Amount    float64       `json:"amount"`
+	Value     float64       `json:"value"`
+	Change    float64       `json:"change"`
+	Timestamp time.Time     `json:"timestamp"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// getCandleReturns returns the fractional returns between consecutive candles
+// of the last window candles, keyed by the open time of the later candle, and
+// the percentage return over the window. Returns across missing candles are
+// skipped
+func getCandleReturns(candles []kline.Candle, interval time.Duration, window int) (map[int64]float64, float64) {
+	if len(candles) > window {
+		candles = candles[len(candles)-window:]
+	}
+
+	returns := make(map[int64]float64)
+	for x := 1; x < len(candles); x++ {
+		if candles[x-1].Close <= 0 ||
+			candles[x].Time.Sub(candles[x-1].Time) != interval {
+			continue
+		}
+		returns[candles[x].Time.Unix()] = (candles[x].Close - candles[x-1].Close) /
+			candles[x-1].Close
+	}
+
+	var windowReturn float64
+	if first := candles[0].Close; first > 0 {
+		windowReturn = (candles[len(candles)-1].Close - first) / first * 100
+	}
+	return returns, windowReturn
+}
+
+// getCorrelation returns the Pearson correlation of two return series over
+// their common open times and the number of common returns. Zero is returned
+// when a series doesn't vary
+func getCorrelation(a, b map[int64]float64) (float64, int) {
+	var xs, ys []float64
+	for t, x := range a {
+		if y, ok := b[t]; ok {
+			xs = append(xs, x)
+			ys = append(ys, y)
+		}
+	}
+	n := len(xs)
+	if n < 2 {
+		return 0, n
+	}
+
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= float64(n)
+	meanY /= float64(n)
+
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0, n
+	}
+	return cov / math.Sqrt(varX*varY), n
+}
+
+// getPairAnalytics computes the correlation and relative strength matrices of
+// candle series over their last window candles
+func getPairAnalytics(items []kline.Item, interval time.Duration, window int, now time.Time) PairAnalytics {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Exchange != items[j].Exchange {
+			return items[i].Exchange < items[j].Exchange
+		}
+		return items[i].Pair.String() < items[j].Pair.String()
+	})
+
+	resp := PairAnalytics{
+		Generated:      now,
+		CandleInterval: interval,
+		Window:         window,
+	}
+	var returns []map[int64]float64
+	for x := range items {
+		if len(items[x].Candles) < 2 {
+			continue
+		}
+		r, windowReturn := getCandleReturns(items[x].Candles, interval, window)
+		returns = append(returns, r)
+		resp.Returns = append(resp.Returns, windowReturn)
+		resp.Series = append(resp.Series, AnalyticsSeries{
+			Exchange: items[x].Exchange,
+			Pair:     items[x].Pair,
+		})
+	}
+
+	n := len(resp.Series)
+	resp.Correlation = make([][]float64, n)
+	resp.Samples = make([][]int, n)
+	resp.RelativeStrength = make([][]float64, n)
+	for i := 0; i < n; i++ {
+		resp.Correlation[i] = make([]float64, n)
+		resp.Samples[i] = make([]int, n)
+		resp.RelativeStrength[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		resp.Correlation[i][i] = 1
+		resp.Samples[i][i] = len(returns[i])
+		for j := i + 1; j < n; j++ {
+			correlation, samples := getCorrelation(returns[i], returns[j])
+			if samples < minCorrelationSamples {
+				correlation = 0
+			}
+			resp.Correlation[i][j], resp.Correlation[j][i] = correlation, correlation
+			resp.Samples[i][j], resp.Samples[j][i] = samples, samples
+			resp.RelativeStrength[i][j] = resp.Returns[i] - resp.Returns[j]
+			resp.RelativeStrength[j][i] = resp.Returns[j] - resp.Returns[i]
+		}
+	}
+	return resp
+}
+
+// getContractOpenInterest fetches the open interest of each alerts contract in
+// order, with its change against the previous open interest. Contracts that
+// can't be fetched are returned with their error
+func getContractOpenInterest(alerts []config.OpenInterestAlertConfig, previous []ContractOpenInterest, now time.Time) []ContractOpenInterest {
+	resp := make([]ContractOpenInterest, len(alerts))
+	for x := range alerts {
+		resp[x] = ContractOpenInterest{
+			Exchange:  alerts[x].Exchange,
+			Pair:      alerts[x].Pair,
+			Timestamp: now,
+		}
+		derivatives, ok := GetExchangeByName(alerts[x].Exchange).(exchange.IDerivativesExchange)
+		if !ok {
+			resp[x].Error = alerts[x].Exchange + " does not support derivatives"
+			continue
+		}
+		oi, err := derivatives.GetOpenInterest(alerts[x].Pair)
+		if err != nil {
+			resp[x].Error = err.Error()
+			continue
+		}
+		resp[x].AssetType = oi.AssetType
+		resp[x].Amount = oi.Amount
+		resp[x].Value = oi.Value
+		if !oi.Timestamp.IsZero() {
+			resp[x].Timestamp = oi.Timestamp
+		}
+
+		for y := range previous {
+			if previous[y].Error == "" && previous[y].Amount > 0 &&
+				strings.EqualFold(previous[y].Exchange, alerts[x].Exchange) &&
+				previous[y].Pair.Equal(alerts[x].Pair) {
+				resp[x].Change = (oi.Amount - previous[y].Amount) /
+					previous[y].Amount * 100
+				break
+			}
+		}
+	}
+	return resp
+}
+
+// findSeries returns the index of an exchange pair series
+func (p *PairAnalytics) findSeries(exchName string, pair currency.Pair) (int, bool) {
+	for x := range p.Series {
+		if strings.EqualFold(p.Series[x].Exchange, exchName) &&
+			p.Series[x].Pair.Equal(pair) {
+			return x, true
+		}
+	}
+	return 0, false
+}
+
+// analyticsMonitor holds the latest analytics and whether each correlation
+// alert is below its threshold, keyed by exchange and pairs
+type analyticsMonitor struct {
+	latest PairAnalytics
+	below  map[string]bool
+	m      sync.Mutex
+}
+
+var pairAnalytics analyticsMonitor
+
+// Update replaces the latest analytics
+func (a *analyticsMonitor) Update(analytics PairAnalytics) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	a.latest = analytics
+}
+
+// Get returns the latest analytics
+func (a *analyticsMonitor) Get() PairAnalytics {
+	a.m.Lock()
+	defer a.m.Unlock()
+	return a.latest
+}
+
+// CheckAlert returns the correlation of an alerts pairs and whether it crossed
+// the alert threshold in either direction. Alerts whose pairs lack enough
+// samples are not checked
+func (a *analyticsMonitor) CheckAlert(alert *config.CorrelationAlertConfig) (correlation float64, below, changed bool) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	i, ok := a.latest.findSeries(alert.Exchange, alert.PairA)
+	if !ok {
+		return 0, false, false
+	}
+	j, ok := a.latest.findSeries(alert.Exchange, alert.PairB)
+	if !ok || a.latest.Samples[i][j] < minCorrelationSamples {
+		return 0, false, false
+	}
+
+	if a.below == nil {
+		a.below = make(map[string]bool)
+	}
+	key := strings.ToLower(alert.Exchange) + " " + alert.PairA.Upper().String() +
+		" " + alert.PairB.Upper().String()
+	correlation = a.latest.Correlation[i][j]
+	below = correlation < alert.Threshold
+	changed = below != a.below[key]
+	a.below[key] = below
+	return correlation, below, changed
+}
+
+// AnalyticsRoutine periodically computes the pair analytics from the candle
+// store and checks the correlation alerts
+func AnalyticsRoutine() {
+	log.Debugln("Starting analytics routine.")
+	for {
+		cfg := bot.config.Analytics
+		updatePairAnalytics(&cfg, clock.Now())
+		clock.Sleep(cfg.UpdateInterval)
+	}
+}
+
+// updatePairAnalytics computes the analytics of the stored candle series of
+// enabled pairs and the open interest of tracked contracts, notifying
+// correlation alerts crossing their threshold and open interest changing by
+// its alerts change percent
+func updatePairAnalytics(cfg *config.AnalyticsConfig, now time.Time) {
+	var items []kline.Item
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		if exchanges[x] == nil || !exchanges[x].IsEnabled() {
+			continue
+		}
+		enabled := exchanges[x].GetEnabledCurrencies()
+		series := kline.GetByExchange(exchanges[x].GetName())
+		for y := range series {
+			if series[y].Interval == cfg.CandleInterval &&
+				enabled.Contains(series[y].Pair, true) {
+				items = append(items, series[y])
+			}
+		}
+	}
+	analytics := getPairAnalytics(items, cfg.CandleInterval, cfg.Window, now)
+	analytics.OpenInterest = getContractOpenInterest(cfg.OpenInterestAlerts,
+		pairAnalytics.Get().OpenInterest, now)
+	pairAnalytics.Update(analytics)
+
+	for x := range cfg.CorrelationAlerts {
+		alert := &cfg.CorrelationAlerts[x]
+		correlation, below, changed := pairAnalytics.CheckAlert(alert)
+		if !changed {
+			continue
+		}
+
+		var message string
+		if below {
+			message = i18n.T(i18n.MessageCorrelationBelow, alert.Exchange,
+				alert.PairA, alert.PairB, correlation, alert.Threshold)
+			log.Warn(message)
+		} else {
+			message = i18n.T(i18n.MessageCorrelationRestored, alert.Exchange,
+				alert.PairA, alert.PairB, correlation)
+			log.Info(message)
+		}
+		pushEvent(analyticsEventType, message)
+	}
+
+	for x := range cfg.OpenInterestAlerts {
+		alert := &cfg.OpenInterestAlerts[x]
+		oi := &analytics.OpenInterest[x]
+		if oi.Error != "" {
+			log.Warnf("Analytics failed to get %s %s open interest: %s",
+				alert.Exchange, alert.Pair, oi.Error)
+			continue
+		}
+		if alert.ChangePercent <= 0 || math.Abs(oi.Change) < alert.ChangePercent {
+			continue
+		}
+		message := i18n.T(i18n.MessageOpenInterestChanged, alert.Exchange,
+			alert.Pair, oi.Change, oi.Amount, alert.ChangePercent)
+		log.Warn(message)
+		pushEvent(analyticsEventType, message)
+	}
+}
+
+// GetPairAnalytics returns the latest correlation and relative strength
+// matrices
+func GetPairAnalytics() PairAnalytics {
+	return pairAnalytics.Get()
+}
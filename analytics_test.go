@@ -0,0 +1,190 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+type openInterestTestExchange struct {
+	accountInfoTestExchange
+	amount float64
+}
+
+func (o *openInterestTestExchange) IsEnabled() bool {
+	return false
+}
+
+func (o *openInterestTestExchange) GetOpenInterest(p currency.Pair) (exchange.OpenInterest, error) {
+	return exchange.OpenInterest{
+		Exchange:  o.name,
+		Pair:      p,
+		AssetType: ticker.Futures,
+		Amount:    o.amount,
+	}, nil
+}
+
+// analyticsTestSeries builds a candle series whose returns are the given
+// multiple of a repeating pattern
+func analyticsTestSeries(p currency.Pair, start time.Time, count int, multiple float64) kline.Item {
+	item := kline.Item{Exchange: "Bitstamp", Pair: p, Interval: time.Hour}
+	price := 100.0
+	for x := 0; x < count; x++ {
+		if x > 0 {
+			price *= 1 + multiple*0.01*float64(x%3-1)
+		}
+		item.Candles = append(item.Candles, kline.Candle{
+			Time:  start.Add(time.Hour * time.Duration(x)),
+			Close: price,
+		})
+	}
+	return item
+}
+
+func TestGetPairAnalytics(t *testing.T) {
+	start := time.Unix(0, 0)
+	btc := currency.NewPairFromStrings("BTC", "USD")
+	eth := currency.NewPairFromStrings("ETH", "USD")
+	ltc := currency.NewPairFromStrings("LTC", "USD")
+	items := []kline.Item{
+		analyticsTestSeries(ltc, start, 30, -1),
+		analyticsTestSeries(btc, start, 30, 1),
+		analyticsTestSeries(eth, start, 30, 2),
+		{Exchange: "Bitstamp", Pair: currency.NewPairFromStrings("XRP", "USD"),
+			Interval: time.Hour, Candles: []kline.Candle{{Time: start, Close: 1}}},
+	}
+
+	analytics := getPairAnalytics(items, time.Hour, 20, start)
+	if len(analytics.Series) != 3 || !analytics.Series[0].Pair.Equal(btc) {
+		t.Fatalf("Test failed. Unexpected series %v", analytics.Series)
+	}
+	if analytics.Samples[0][1] != 19 {
+		t.Errorf("Test failed. Expected 19 returns within the window, got %d",
+			analytics.Samples[0][1])
+	}
+	if math.Abs(analytics.Correlation[0][1]-1) > 0.01 {
+		t.Errorf("Test failed. Expected BTC and ETH to be correlated, got %f",
+			analytics.Correlation[0][1])
+	}
+	if math.Abs(analytics.Correlation[0][2]+1) > 0.01 {
+		t.Errorf("Test failed. Expected BTC and LTC to be inversely correlated, got %f",
+			analytics.Correlation[0][2])
+	}
+	if analytics.RelativeStrength[0][1] != analytics.Returns[0]-analytics.Returns[1] ||
+		analytics.RelativeStrength[1][0] != -analytics.RelativeStrength[0][1] {
+		t.Errorf("Test failed. Unexpected relative strength %v",
+			analytics.RelativeStrength)
+	}
+}
+
+func TestCheckCorrelationAlert(t *testing.T) {
+	start := time.Unix(0, 0)
+	btc := currency.NewPairFromStrings("BTC", "USD")
+	eth := currency.NewPairFromStrings("ETH", "USD")
+	alert := config.CorrelationAlertConfig{
+		Exchange:  "bitstamp",
+		PairA:     btc,
+		PairB:     eth,
+		Threshold: 0.5,
+	}
+
+	var a analyticsMonitor
+	a.Update(getPairAnalytics([]kline.Item{
+		analyticsTestSeries(btc, start, 30, 1),
+		analyticsTestSeries(eth, start, 30, 2),
+	}, time.Hour, 20, start))
+	if _, _, changed := a.CheckAlert(&alert); changed {
+		t.Error("Test failed. Expected correlated pairs not to alert")
+	}
+
+	a.Update(getPairAnalytics([]kline.Item{
+		analyticsTestSeries(btc, start, 30, 1),
+		analyticsTestSeries(eth, start, 30, -1),
+	}, time.Hour, 20, start))
+	correlation, below, changed := a.CheckAlert(&alert)
+	if !changed || !below || correlation > 0 {
+		t.Errorf("Test failed. Expected the correlation drop to alert, got %f %v %v",
+			correlation, below, changed)
+	}
+	if _, _, changed = a.CheckAlert(&alert); changed {
+		t.Error("Test failed. Expected the alert to only trigger once")
+	}
+
+	// Series without enough samples are not checked
+	a.Update(getPairAnalytics([]kline.Item{
+		analyticsTestSeries(btc, start, 5, 1),
+		analyticsTestSeries(eth, start, 5, 1),
+	}, time.Hour, 20, start))
+	if _, _, changed = a.CheckAlert(&alert); changed {
+		t.Error("Test failed. Expected too few samples not to change the alert")
+	}
+}
+
+func TestUpdatePairAnalyticsOpenInterest(t *testing.T) {
+	exch := &openInterestTestExchange{
+		accountInfoTestExchange: accountInfoTestExchange{name: "OpenInterestTest"},
+		amount:                  1000,
+	}
+	exchanges := bot.exchanges
+	bot.exchanges = []exchange.IBotExchange{exch}
+	defer func() { bot.exchanges = exchanges }()
+	defer pairAnalytics.Update(PairAnalytics{})
+	pairAnalytics.Update(PairAnalytics{})
+
+	p := currency.NewPairFromStrings("XBT", "USD")
+	cfg := config.AnalyticsConfig{
+		CandleInterval: time.Hour,
+		Window:         20,
+		OpenInterestAlerts: []config.OpenInterestAlertConfig{
+			{Exchange: exch.GetName(), Pair: p, ChangePercent: 10},
+			{Exchange: "Unloaded", Pair: p},
+		},
+	}
+
+	countEvents := func(start time.Time) int {
+		var count int
+		events := notableEvents.Between(start, clock.Now())
+		for x := range events {
+			if events[x].Type == analyticsEventType &&
+				strings.Contains(events[x].Message, exch.GetName()) {
+				count++
+			}
+		}
+		return count
+	}
+
+	start := clock.Now()
+	updatePairAnalytics(&cfg, start)
+	oi := GetPairAnalytics().OpenInterest
+	if len(oi) != 2 || oi[0].Amount != 1000 || oi[0].Change != 0 ||
+		oi[0].AssetType != ticker.Futures {
+		t.Fatalf("Test failed. Expected the tracked open interest, got %+v", oi)
+	}
+	if oi[1].Error == "" {
+		t.Error("Test failed. Expected an error for an unloaded exchange")
+	}
+
+	exch.amount = 1050
+	updatePairAnalytics(&cfg, start)
+	if count := countEvents(start); count != 0 {
+		t.Errorf("Test failed. Expected a change within the threshold not to alert, got %d", count)
+	}
+
+	exch.amount = 1260
+	updatePairAnalytics(&cfg, start)
+	oi = GetPairAnalytics().OpenInterest
+	if math.Abs(oi[0].Change-20) > 1e-9 {
+		t.Errorf("Test failed. Expected a 20%% change, got %f", oi[0].Change)
+	}
+	if count := countEvents(start); count != 1 {
+		t.Errorf("Test failed. Expected the open interest change to alert once, got %d", count)
+	}
+}
@@ -0,0 +1,393 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// arbitrageStrategy is the strategy triangular arbitrage orders are submitted
+// as, limiting them to its allocation when one is configured
+const arbitrageStrategy = "arbitrage"
+
+// ArbitrageLeg holds a trade of a triangular arbitrage path. Price is the
+// average fill price, AmountOut is received after the fee which is in the To
+// currency. BaseAmount is the amount of the pairs base currency traded
+type ArbitrageLeg struct {
+	Pair       currency.Pair      `json:"pair"`
+	Side       exchange.OrderSide `json:"side"`
+	From       currency.Code      `json:"from"`
+	To         currency.Code      `json:"to"`
+	Price      float64            `json:"price"`
+	BaseAmount float64            `json:"baseAmount"`
+	AmountIn   float64            `json:"amountIn"`
+	AmountOut  float64            `json:"amountOut"`
+	Fee        float64            `json:"fee"`
+}
+
+// TriangularArbitrage holds a path trading a start currency through two other
+// currencies on one exchange and back. Profit is the fee adjusted percentage
+// gained. Paths without depth checks are priced at the top of the book
+type TriangularArbitrage struct {
+	Exchange     string          `json:"exchange"`
+	Path         []currency.Code `json:"path"`
+	Legs         []ArbitrageLeg  `json:"legs"`
+	StartAmount  float64         `json:"startAmount"`
+	EndAmount    float64         `json:"endAmount"`
+	Profit       float64         `json:"profit"`
+	DepthChecked bool            `json:"depthChecked"`
+	Executed     bool            `json:"executed"`
+	Timestamp    time.Time       `json:"timestamp"`
+}
+
+// key identifies the path of an opportunity
+func (t *TriangularArbitrage) key() string {
+	path := make([]string, len(t.Path))
+	for x := range t.Path {
+		path[x] = t.Path[x].Upper().String()
+	}
+	return t.Exchange + strings.Join(path, "-")
+}
+
+// pathString returns the currencies traded through joined by arrows
+func (t *TriangularArbitrage) pathString() string {
+	path := make([]string, len(t.Path))
+	for x := range t.Path {
+		path[x] = t.Path[x].String()
+	}
+	return strings.Join(path, "->")
+}
+
+// arbitrageBook holds an orderbook with bids sorted descending and asks
+// ascending
+type arbitrageBook struct {
+	pair currency.Pair
+	bids []orderbook.Item
+	asks []orderbook.Item
+}
+
+// arbitrageEdge converts one currency into another through an orderbook
+type arbitrageEdge struct {
+	book *arbitrageBook
+	sell bool
+	from currency.Code
+	to   currency.Code
+}
+
+// arbitrageTracker holds the profitable paths found by the last scan of each
+// exchange
+type arbitrageTracker struct {
+	opportunities map[string][]TriangularArbitrage
+	m             sync.Mutex
+}
+
+var triangularArbitrage = arbitrageTracker{
+	opportunities: make(map[string][]TriangularArbitrage),
+}
+
+// Update replaces the opportunities of an exchange, returning those which were
+// not profitable in its previous scan
+func (a *arbitrageTracker) Update(exchName string, opportunities []TriangularArbitrage) []TriangularArbitrage {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	previous := make(map[string]bool)
+	for x := range a.opportunities[exchName] {
+		previous[a.opportunities[exchName][x].key()] = true
+	}
+	a.opportunities[exchName] = opportunities
+
+	var resp []TriangularArbitrage
+	for x := range opportunities {
+		if !previous[opportunities[x].key()] {
+			resp = append(resp, opportunities[x])
+		}
+	}
+	return resp
+}
+
+// MarkExecuted flags an opportunity as executed
+func (a *arbitrageTracker) MarkExecuted(t *TriangularArbitrage) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	for x := range a.opportunities[t.Exchange] {
+		if a.opportunities[t.Exchange][x].key() == t.key() {
+			a.opportunities[t.Exchange][x].Executed = true
+		}
+	}
+}
+
+// Restore replaces the tracked opportunities with previously saved
+// opportunities, preventing them from being alerted on again
+func (a *arbitrageTracker) Restore(opportunities []TriangularArbitrage) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	a.opportunities = make(map[string][]TriangularArbitrage)
+	for x := range opportunities {
+		exchName := opportunities[x].Exchange
+		a.opportunities[exchName] = append(a.opportunities[exchName],
+			opportunities[x])
+	}
+}
+
+// GetAll returns all current opportunities ordered by profit
+func (a *arbitrageTracker) GetAll() []TriangularArbitrage {
+	a.m.Lock()
+	defer a.m.Unlock()
+	var resp []TriangularArbitrage
+	for _, opportunities := range a.opportunities {
+		resp = append(resp, opportunities...)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Profit > resp[j].Profit
+	})
+	return resp
+}
+
+// TriangularArbitrageRoutine periodically scans the live orderbooks of every
+// enabled exchange for profitable triangular paths
+func TriangularArbitrageRoutine() {
+	log.Debugln("Starting triangular arbitrage routine.")
+	for {
+		cfg := bot.config.Arbitrage
+		exchanges := GetExchanges()
+		for x := range exchanges {
+			if exchanges[x] == nil || !exchanges[x].IsEnabled() {
+				continue
+			}
+			checkTriangularArbitrage(exchanges[x], &cfg, clock.Now())
+		}
+		clock.Sleep(cfg.ScanInterval)
+	}
+}
+
+// checkTriangularArbitrage scans an exchange, alerting on new opportunities
+// and executing them when auto execution is enabled
+func checkTriangularArbitrage(exch exchange.IBotExchange, cfg *config.ArbitrageConfig, now time.Time) {
+	opportunities := scanTriangularArbitrage(exch, cfg, now)
+	found := triangularArbitrage.Update(exch.GetName(), opportunities)
+	for x := range found {
+		notifyTriangularArbitrage(&found[x])
+		if !cfg.AutoExecute || !found[x].DepthChecked {
+			continue
+		}
+
+		err := executeTriangularArbitrage(exch, &found[x])
+		if err != nil {
+			log.Errorf("Triangular arbitrage %s execution failed: %s",
+				found[x].key(), err)
+			continue
+		}
+		triangularArbitrage.MarkExecuted(&found[x])
+	}
+}
+
+// scanTriangularArbitrage returns the paths through an exchanges enabled
+// pairs which start and end in a configured start currency with a fee adjusted
+// profit of at least the configured minimum
+func scanTriangularArbitrage(exch exchange.IBotExchange, cfg *config.ArbitrageConfig, now time.Time) []TriangularArbitrage {
+	edges := make(map[*currency.Item][]arbitrageEdge)
+	pairs := exch.GetEnabledCurrencies()
+	for x := range pairs {
+		ob, err := orderbook.Get(exch.GetName(), pairs[x], orderbook.Spot)
+		if err != nil || ob.Stale || len(ob.Bids) == 0 || len(ob.Asks) == 0 {
+			continue
+		}
+
+		book := &arbitrageBook{
+			pair: pairs[x],
+			bids: append([]orderbook.Item(nil), ob.Bids...),
+			asks: append([]orderbook.Item(nil), ob.Asks...),
+		}
+		sort.Slice(book.bids, func(i, j int) bool {
+			return book.bids[i].Price > book.bids[j].Price
+		})
+		sort.Slice(book.asks, func(i, j int) bool {
+			return book.asks[i].Price < book.asks[j].Price
+		})
+
+		base, quote := pairs[x].Base, pairs[x].Quote
+		edges[base.Item] = append(edges[base.Item],
+			arbitrageEdge{book: book, sell: true, from: base, to: quote})
+		edges[quote.Item] = append(edges[quote.Item],
+			arbitrageEdge{book: book, from: quote, to: base})
+	}
+
+	feeRates := make(map[*arbitrageBook]float64)
+	var resp []TriangularArbitrage
+	for _, start := range cfg.StartCurrencies {
+		amount, depthChecked := cfg.TradeAmounts[start.Upper().String()]
+		if !depthChecked {
+			amount = 1
+		}
+
+		for _, first := range edges[start.Item] {
+			for _, second := range edges[first.to.Item] {
+				if second.book == first.book || second.to.Match(start) {
+					continue
+				}
+				for _, third := range edges[second.to.Item] {
+					if !third.to.Match(start) {
+						continue
+					}
+
+					path := []arbitrageEdge{first, second, third}
+					opportunity, ok := evaluateTriangularPath(exch, path, amount,
+						depthChecked, feeRates)
+					if !ok || opportunity.Profit < cfg.MinProfit {
+						continue
+					}
+					opportunity.Timestamp = now
+					resp = append(resp, opportunity)
+				}
+			}
+		}
+	}
+	return resp
+}
+
+// evaluateTriangularPath trades an amount through a path, returning false when
+// a leg lacks the liquidity or fee information to be priced
+func evaluateTriangularPath(exch exchange.IBotExchange, path []arbitrageEdge, amount float64, depthChecked bool, feeRates map[*arbitrageBook]float64) (TriangularArbitrage, bool) {
+	resp := TriangularArbitrage{
+		Exchange:     exch.GetName(),
+		Path:         []currency.Code{path[0].from},
+		StartAmount:  amount,
+		DepthChecked: depthChecked,
+	}
+
+	for x := range path {
+		rate, ok := feeRates[path[x].book]
+		if !ok {
+			fee, err := exch.GetFeeByType(&exchange.FeeBuilder{
+				FeeType:       exchange.CryptocurrencyTradeFee,
+				PurchasePrice: 1,
+				Amount:        1,
+				Pair:          path[x].book.pair,
+			})
+			rate = fee
+			if err != nil {
+				rate = -1
+			}
+			feeRates[path[x].book] = rate
+		}
+		if rate < 0 {
+			return resp, false
+		}
+
+		leg, ok := convertArbitrageLeg(&path[x], amount, depthChecked)
+		if !ok {
+			return resp, false
+		}
+		leg.Fee = leg.AmountOut * rate
+		leg.AmountOut -= leg.Fee
+		amount = leg.AmountOut
+
+		resp.Legs = append(resp.Legs, leg)
+		resp.Path = append(resp.Path, path[x].to)
+	}
+
+	resp.EndAmount = amount
+	resp.Profit = (resp.EndAmount - resp.StartAmount) / resp.StartAmount * 100
+	return resp, true
+}
+
+// convertArbitrageLeg converts an amount through an orderbook before fees.
+// Without depth checks the best price is used for the whole amount
+func convertArbitrageLeg(edge *arbitrageEdge, amount float64, depthChecked bool) (ArbitrageLeg, bool) {
+	leg := ArbitrageLeg{
+		Pair:     edge.book.pair,
+		Side:     exchange.BuyOrderSide,
+		From:     edge.from,
+		To:       edge.to,
+		AmountIn: amount,
+	}
+	levels := edge.book.asks
+	if edge.sell {
+		leg.Side = exchange.SellOrderSide
+		levels = edge.book.bids
+	}
+
+	if !depthChecked {
+		levels = levels[:1]
+	}
+
+	remaining := amount
+	for x := range levels {
+		if remaining <= 0 {
+			break
+		}
+		if levels[x].Price <= 0 || levels[x].Amount <= 0 {
+			continue
+		}
+
+		if edge.sell {
+			filled := remaining
+			if depthChecked && filled > levels[x].Amount {
+				filled = levels[x].Amount
+			}
+			leg.BaseAmount += filled
+			leg.AmountOut += filled * levels[x].Price
+			remaining -= filled
+			continue
+		}
+
+		cost := remaining
+		if depthChecked && cost > levels[x].Amount*levels[x].Price {
+			cost = levels[x].Amount * levels[x].Price
+		}
+		leg.BaseAmount += cost / levels[x].Price
+		leg.AmountOut += cost / levels[x].Price
+		remaining -= cost
+	}
+
+	if remaining > 0 || leg.BaseAmount == 0 {
+		return leg, false
+	}
+
+	if edge.sell {
+		leg.Price = leg.AmountOut / leg.BaseAmount
+	} else {
+		leg.Price = amount / leg.BaseAmount
+	}
+	return leg, true
+}
+
+// executeTriangularArbitrage places a market order for each leg of a path,
+// stopping at the first failure. Orders are subject to balance reserves and
+// the arbitrage strategies allocation, and are tagged with the path traded
+func executeTriangularArbitrage(exch exchange.IBotExchange, t *TriangularArbitrage) error {
+	tags := OrderTags{OrderTagReason: "triangular arbitrage " + t.pathString()}
+	for x := range t.Legs {
+		_, err := SubmitStrategyOrder(arbitrageStrategy, exch, t.Legs[x].Pair, t.Legs[x].Side,
+			exchange.MarketOrderType, t.Legs[x].BaseAmount, t.Legs[x].Price, "",
+			tags)
+		if err != nil {
+			return fmt.Errorf("leg %d %s %s failed: %s", x+1, t.Legs[x].Side,
+				t.Legs[x].Pair, err)
+		}
+	}
+	t.Executed = true
+	log.Infof("Triangular arbitrage %s executed", t.key())
+	return nil
+}
+
+// notifyTriangularArbitrage logs and pushes a newly found opportunity through
+// the communications package
+func notifyTriangularArbitrage(t *TriangularArbitrage) {
+	message := i18n.T(i18n.MessageTriangularArb, t.Exchange, t.pathString(), t.StartAmount, t.Path[0],
+		t.EndAmount, t.Profit)
+	log.Info(message)
+
+	pushEvent("ARBITRAGE", message)
+}
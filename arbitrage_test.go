@@ -0,0 +1,165 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+type arbitrageTestExchange struct {
+	*riskTestExchange
+}
+
+func (a *arbitrageTestExchange) GetEnabledCurrencies() currency.Pairs {
+	return currency.Pairs{
+		currency.NewPairFromStrings("ETH", "BTC"),
+		currency.NewPairFromStrings("ETH", "USDT"),
+		currency.NewPairFromStrings("BTC", "USDT"),
+	}
+}
+
+func (a *arbitrageTestExchange) GetFeeByType(feeBuilder *exchange.FeeBuilder) (float64, error) {
+	return feeBuilder.PurchasePrice * feeBuilder.Amount * 0.001, nil
+}
+
+func setupArbitrageTest(t *testing.T) *arbitrageTestExchange {
+	exch := &arbitrageTestExchange{setupRiskTest(t)}
+	books := []orderbook.Base{
+		{
+			Pair: currency.NewPairFromStrings("ETH", "BTC"),
+			Bids: []orderbook.Item{{Price: 0.0199, Amount: 100}},
+			Asks: []orderbook.Item{{Price: 0.021, Amount: 100}, {Price: 0.02, Amount: 10}},
+		},
+		{
+			Pair: currency.NewPairFromStrings("ETH", "USDT"),
+			Bids: []orderbook.Item{{Price: 210, Amount: 100}},
+			Asks: []orderbook.Item{{Price: 211, Amount: 100}},
+		},
+		{
+			Pair: currency.NewPairFromStrings("BTC", "USDT"),
+			Bids: []orderbook.Item{{Price: 9999, Amount: 10}},
+			Asks: []orderbook.Item{{Price: 10000, Amount: 10}},
+		},
+	}
+	for x := range books {
+		books[x].ExchangeName = exch.GetName()
+		books[x].AssetType = orderbook.Spot
+		err := books[x].Process()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return exch
+}
+
+func TestScanTriangularArbitrage(t *testing.T) {
+	exch := setupArbitrageTest(t)
+	cfg := config.ArbitrageConfig{
+		StartCurrencies: currency.Currencies{currency.BTC},
+		MinProfit:       1,
+	}
+
+	resp := scanTriangularArbitrage(exch, &cfg, time.Now())
+	if len(resp) != 1 {
+		t.Fatalf("Test failed. Expected 1 opportunity, got %d", len(resp))
+	}
+	if resp[0].key() != exch.GetName()+"BTC-ETH-USDT-BTC" || resp[0].DepthChecked {
+		t.Errorf("Test failed. Unexpected opportunity %+v", resp[0])
+	}
+	expected := 1.05 * 0.999 * 0.999 * 0.999
+	if diff := resp[0].EndAmount - expected; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("Test failed. Expected end amount %f, got %f", expected,
+			resp[0].EndAmount)
+	}
+
+	cfg.MinProfit = 5
+	if resp = scanTriangularArbitrage(exch, &cfg, time.Now()); len(resp) != 0 {
+		t.Errorf("Test failed. Expected no opportunities above 5%%, got %d", len(resp))
+	}
+
+	// 0.3 BTC exhausts the best ETH ask and fills the rest at 0.021
+	cfg.MinProfit = 0
+	cfg.TradeAmounts = map[string]float64{"BTC": 0.3}
+	resp = scanTriangularArbitrage(exch, &cfg, time.Now())
+	if len(resp) != 1 || !resp[0].DepthChecked {
+		t.Fatalf("Test failed. Expected 1 depth checked opportunity, got %+v", resp)
+	}
+	if diff := resp[0].Legs[0].BaseAmount - (10 + 0.1/0.021); diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("Test failed. Expected the first leg to walk the book, got %f",
+			resp[0].Legs[0].BaseAmount)
+	}
+
+	cfg.TradeAmounts = map[string]float64{"BTC": 5}
+	if resp = scanTriangularArbitrage(exch, &cfg, time.Now()); len(resp) != 0 {
+		t.Errorf("Test failed. Expected insufficient depth to skip paths, got %d",
+			len(resp))
+	}
+}
+
+func TestArbitrageTrackerUpdate(t *testing.T) {
+	tracker := arbitrageTracker{
+		opportunities: make(map[string][]TriangularArbitrage),
+	}
+	a := TriangularArbitrage{
+		Exchange: "test",
+		Path:     []currency.Code{currency.BTC, currency.ETH, currency.USDT, currency.BTC},
+		Profit:   1,
+	}
+	b := TriangularArbitrage{
+		Exchange: "test",
+		Path:     []currency.Code{currency.USDT, currency.BTC, currency.ETH, currency.USDT},
+		Profit:   2,
+	}
+
+	if found := tracker.Update("test", []TriangularArbitrage{a}); len(found) != 1 {
+		t.Errorf("Test failed. Expected 1 new opportunity, got %d", len(found))
+	}
+	if found := tracker.Update("test", []TriangularArbitrage{a, b}); len(found) != 1 ||
+		found[0].key() != b.key() {
+		t.Errorf("Test failed. Expected only the new opportunity, got %+v", found)
+	}
+
+	all := tracker.GetAll()
+	if len(all) != 2 || all[0].Profit != 2 {
+		t.Errorf("Test failed. Expected opportunities ordered by profit, got %+v", all)
+	}
+}
+
+func TestExecuteTriangularArbitrage(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+
+	exch := setupArbitrageTest(t)
+	cfg := config.ArbitrageConfig{
+		StartCurrencies: currency.Currencies{currency.BTC},
+		MinProfit:       1,
+		TradeAmounts:    map[string]float64{"BTC": 0.45},
+	}
+
+	resp := scanTriangularArbitrage(exch, &cfg, time.Now())
+	if len(resp) != 1 {
+		t.Fatalf("Test failed. Expected 1 opportunity, got %d", len(resp))
+	}
+	err := executeTriangularArbitrage(exch, &resp[0])
+	if err == nil || exch.orders != 0 {
+		t.Error("Test failed. Expected execution breaching the BTC reserve to be rejected")
+	}
+
+	cfg.TradeAmounts["BTC"] = 0.1
+	resp = scanTriangularArbitrage(exch, &cfg, time.Now())
+	if len(resp) != 1 {
+		t.Fatalf("Test failed. Expected 1 opportunity, got %d", len(resp))
+	}
+	err = executeTriangularArbitrage(exch, &resp[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exch.orders != 3 || !resp[0].Executed {
+		t.Errorf("Test failed. Expected 3 orders to be placed, got %d", exch.orders)
+	}
+}
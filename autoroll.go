@@ -0,0 +1,243 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	futuresRollEventType = "FUTURES_ROLL"
+	futuresRollReason    = "futures roll"
+	futuresRollStrategy  = "futuresroll"
+	maxFuturesRolls      = 100
+)
+
+// FuturesRoll is a futures position rolled from an expiring contract into the
+// next contract. Cost is estimated from the orderbooks of both contracts before
+// the orders are placed, as the quote amount paid buying less the amount
+// received selling including fees, and is only set when Estimated
+type FuturesRoll struct {
+	Exchange     string             `json:"exchange"`
+	From         string             `json:"from"`
+	To           string             `json:"to,omitempty"`
+	Side         exchange.OrderSide `json:"side"`
+	Amount       float64            `json:"amount"`
+	ClosePrice   float64            `json:"closePrice"`
+	OpenPrice    float64            `json:"openPrice"`
+	Fees         float64            `json:"fees"`
+	Cost         float64            `json:"cost"`
+	Estimated    bool               `json:"estimated"`
+	CloseOrderID string             `json:"closeOrderId,omitempty"`
+	OpenOrderID  string             `json:"openOrderId,omitempty"`
+	Time         time.Time          `json:"time"`
+	Error        string             `json:"error,omitempty"`
+}
+
+// futuresRollLog holds the most recent rolls and the expiry of each contract
+// position a roll has been attempted for, so failed rolls aren't retried on
+// every check
+type futuresRollLog struct {
+	rolls     []FuturesRoll
+	attempted map[string]time.Time
+	m         sync.Mutex
+}
+
+var futuresRolls = futuresRollLog{
+	attempted: make(map[string]time.Time),
+}
+
+// Attempt returns true the first time a contract position is rolled. Attempts
+// on contracts which have expired are dropped
+func (f *futuresRollLog) Attempt(exchName, symbol string, side exchange.OrderSide, expiry, now time.Time) bool {
+	f.m.Lock()
+	defer f.m.Unlock()
+	for key, expires := range f.attempted {
+		if !expires.After(now) {
+			delete(f.attempted, key)
+		}
+	}
+
+	key := common.StringToLower(exchName) + "|" + symbol + "|" + string(side)
+	if _, ok := f.attempted[key]; ok {
+		return false
+	}
+	f.attempted[key] = expiry
+	return true
+}
+
+// Add records a roll, dropping the oldest once the log is full
+func (f *futuresRollLog) Add(roll *FuturesRoll) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	f.rolls = append(f.rolls, *roll)
+	if len(f.rolls) > maxFuturesRolls {
+		f.rolls = f.rolls[len(f.rolls)-maxFuturesRolls:]
+	}
+}
+
+// GetAll returns the recorded rolls, oldest first
+func (f *futuresRollLog) GetAll() []FuturesRoll {
+	f.m.Lock()
+	defer f.m.Unlock()
+	return append([]FuturesRoll(nil), f.rolls...)
+}
+
+// rollExpiringPositions rolls the futures positions of the configured
+// exchanges whose contract expires within the lead time into the next
+// contract, reporting each roll through the communications package
+func rollExpiringPositions(exchanges []exchange.IBotExchange, cfg *config.AutoRollConfig, now time.Time) {
+	for _, exch := range exchanges {
+		if exch == nil || !exch.IsEnabled() || !exch.GetAuthenticatedAPISupport() {
+			continue
+		}
+		if len(cfg.Exchanges) > 0 &&
+			!common.StringDataCompareInsensitive(cfg.Exchanges, exch.GetName()) {
+			continue
+		}
+		positioner, ok := exch.(exchange.IFuturesPositionsExchange)
+		if !ok {
+			continue
+		}
+
+		positions, err := positioner.GetFuturesPositions()
+		if err != nil {
+			log.Debugf("%s failed to get futures positions. Error: %s",
+				exch.GetName(), err)
+			continue
+		}
+
+		for x := range positions {
+			contract, ok := expiries.Get(exch.GetName(), positions[x].Symbol)
+			if !ok || !contract.Expiry.After(now) ||
+				contract.Expiry.After(now.Add(cfg.LeadTime)) {
+				continue
+			}
+			err = checkFuturesRollCircuitBreaker(exch.GetName(), &positions[x], &contract)
+			if err != nil {
+				log.Warnf("%s %s roll deferred: %s", exch.GetName(),
+					positions[x].Symbol, err)
+				continue
+			}
+			if !futuresRolls.Attempt(exch.GetName(), positions[x].Symbol,
+				positions[x].Side, contract.Expiry, now) {
+				continue
+			}
+
+			roll := rollFuturesPosition(exch, positioner, &positions[x], &contract, now)
+			futuresRolls.Add(&roll)
+			notifyFuturesRoll(&roll)
+		}
+	}
+}
+
+// checkFuturesRollCircuitBreaker returns an error when the circuit breaker
+// suspends automated orders on the expiring or the next contract, so a roll
+// is deferred rather than leaving the position closed
+func checkFuturesRollCircuitBreaker(exchName string, position *exchange.FuturesPosition, contract *ContractExpiry) error {
+	err := checkCircuitBreaker(exchName, position.Pair)
+	if err != nil {
+		return err
+	}
+	next, ok := expiries.Next(exchName, contract.Underlying, contract.Expiry)
+	if !ok {
+		return nil
+	}
+	return checkCircuitBreaker(exchName, next.Pair)
+}
+
+// rollFuturesPosition closes a position in an expiring contract then opens
+// the same position in the next contract with market orders, quoting both
+// legs with the order router first. Nothing is submitted when the router
+// reports either contract halted, and the next contract isn't opened when the
+// position fails to close. The reopening order is submitted as an automated
+// strategy order so it is subject to the circuit breaker
+func rollFuturesPosition(exch exchange.IBotExchange, positioner exchange.IFuturesPositionsExchange, position *exchange.FuturesPosition, contract *ContractExpiry, now time.Time) FuturesRoll {
+	roll := FuturesRoll{
+		Exchange: exch.GetName(),
+		From:     position.Symbol,
+		Side:     position.Side,
+		Amount:   position.Amount,
+		Time:     now,
+	}
+
+	next, ok := expiries.Next(exch.GetName(), contract.Underlying, contract.Expiry)
+	if !ok {
+		roll.Error = "no later contract listed"
+		return roll
+	}
+	roll.To = next.Symbol
+
+	closeSide := exchange.SellOrderSide
+	if position.Side == exchange.SellOrderSide {
+		closeSide = exchange.BuyOrderSide
+	}
+	closeQuote := quoteAssetVenue(exch, position.Pair, orderbook.Futures, closeSide,
+		position.Amount, now)
+	openQuote := quoteAssetVenue(exch, next.Pair, orderbook.Futures, position.Side,
+		position.Amount, now)
+	for _, quote := range []VenueQuote{closeQuote, openQuote} {
+		if quote.Halted {
+			roll.Error = quote.Error
+			return roll
+		}
+	}
+	if closeQuote.Error == "" && openQuote.Error == "" {
+		roll.Estimated = true
+		roll.ClosePrice = closeQuote.AverageFillPrice
+		roll.OpenPrice = openQuote.AverageFillPrice
+		roll.Fees = closeQuote.Fee + openQuote.Fee
+		roll.Cost = openQuote.EffectiveCost - closeQuote.EffectiveCost
+		if position.Side == exchange.SellOrderSide {
+			roll.Cost = -roll.Cost
+		}
+	}
+
+	resp, err := CloseExchangeFuturesPosition(positioner, exch.GetName(),
+		position.Pair, position.Side, exchange.MarketOrderType, position.Amount, 0)
+	if err != nil {
+		roll.Error = err.Error()
+		return roll
+	}
+	roll.CloseOrderID = resp.OrderID
+
+	resp, err = SubmitStrategyOrder(futuresRollStrategy, exch, next.Pair,
+		position.Side, exchange.MarketOrderType, position.Amount, 0, "",
+		OrderTags{OrderTagReason: futuresRollReason})
+	if err != nil {
+		roll.Error = err.Error()
+		return roll
+	}
+	roll.OpenOrderID = resp.OrderID
+	return roll
+}
+
+// notifyFuturesRoll logs and pushes a roll through the communications package
+func notifyFuturesRoll(roll *FuturesRoll) {
+	position := "long"
+	if roll.Side == exchange.SellOrderSide {
+		position = "short"
+	}
+	if roll.Error != "" {
+		message := i18n.T(i18n.MessageFuturesRollFailed, roll.Exchange, position,
+			roll.Amount, roll.From, roll.Error)
+		log.Error(message)
+		pushEvent(futuresRollEventType, message)
+		return
+	}
+	message := i18n.T(i18n.MessageFuturesRolled, roll.Exchange, position,
+		roll.Amount, roll.From, roll.To, roll.Cost)
+	log.Info(message)
+	pushEvent(futuresRollEventType, message)
+}
+
+// GetFuturesRolls returns the most recent futures rolls, oldest first
+func GetFuturesRolls() []FuturesRoll {
+	return futuresRolls.GetAll()
+}
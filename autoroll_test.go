@@ -0,0 +1,218 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+type autoRollTestExchange struct {
+	routeTestExchange
+	positions []exchange.FuturesPosition
+	closed    []exchange.FuturesPosition
+	opened    []exchange.FuturesPosition
+	closeErr  error
+}
+
+func (a *autoRollTestExchange) IsSandbox() bool {
+	return false
+}
+
+func (a *autoRollTestExchange) GetFuturesPositions() ([]exchange.FuturesPosition, error) {
+	return a.positions, nil
+}
+
+func (a *autoRollTestExchange) CloseFuturesPosition(p currency.Pair, positionSide exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if a.closeErr != nil {
+		return exchange.SubmitOrderResponse{}, a.closeErr
+	}
+	a.closed = append(a.closed, exchange.FuturesPosition{Pair: p, Side: positionSide, Amount: amount})
+	return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "close"}, nil
+}
+
+func (a *autoRollTestExchange) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	a.opened = append(a.opened, exchange.FuturesPosition{Pair: p, Side: side, Amount: amount})
+	return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "open"}, nil
+}
+
+func processAutoRollTestOrderbook(t *testing.T, exchName string, p currency.Pair, bid, ask float64) {
+	ob := orderbook.Base{
+		Pair:         p,
+		ExchangeName: exchName,
+		AssetType:    orderbook.Futures,
+		Bids:         []orderbook.Item{{Price: bid, Amount: 100}},
+		Asks:         []orderbook.Item{{Price: ask, Amount: 100}},
+	}
+	err := ob.Process()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRollExpiringPositions(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+	now := time.Date(2019, 6, 7, 7, 0, 0, 0, time.UTC)
+	underlying := currency.NewPairWithDelimiter("BTC", "USD", "-")
+	expiring := currency.NewPairWithDelimiter("BTC-USD", "190607", "_")
+	next := currency.NewPairWithDelimiter("BTC-USD", "190614", "_")
+	exch := &autoRollTestExchange{
+		routeTestExchange: routeTestExchange{name: "AutoRollTest"},
+		positions: []exchange.FuturesPosition{
+			{Symbol: "BTC-USD-190607", Pair: expiring, Side: exchange.BuyOrderSide, Amount: 10},
+			{Symbol: "BTC-USD-190614", Pair: next, Side: exchange.SellOrderSide, Amount: 5},
+		},
+	}
+	expiries.Update(exch.GetName(), []exchange.FuturesContract{
+		{Symbol: "BTC-USD-190607", Pair: expiring, Underlying: underlying,
+			Expiry: now.Add(time.Hour)},
+		{Symbol: "BTC-USD-190614", Pair: next, Underlying: underlying,
+			Expiry: now.Add(time.Hour * 169)},
+	})
+	processAutoRollTestOrderbook(t, exch.GetName(), expiring, 8000, 8001)
+	processAutoRollTestOrderbook(t, exch.GetName(), next, 8050, 8052)
+
+	cfg := config.AutoRollConfig{LeadTime: time.Hour * 2}
+	rollExpiringPositions([]exchange.IBotExchange{exch}, &cfg, now)
+	if len(exch.closed) != 1 || exch.closed[0].Pair.String() != expiring.String() ||
+		exch.closed[0].Side != exchange.BuyOrderSide || exch.closed[0].Amount != 10 {
+		t.Fatalf("Test failed. Expected the expiring long to be closed, got %+v", exch.closed)
+	}
+	if len(exch.opened) != 1 || exch.opened[0].Pair.String() != next.String() ||
+		exch.opened[0].Side != exchange.BuyOrderSide || exch.opened[0].Amount != 10 {
+		t.Fatalf("Test failed. Expected a long opened in the next contract, got %+v", exch.opened)
+	}
+
+	rolls := GetFuturesRolls()
+	roll := rolls[len(rolls)-1]
+	if roll.Error != "" || roll.From != "BTC-USD-190607" || roll.To != "BTC-USD-190614" ||
+		roll.CloseOrderID != "close" || roll.OpenOrderID != "open" {
+		t.Errorf("Test failed. Unexpected roll %+v", roll)
+	}
+	// Sells at 8000 and buys at 8052 with 0.2% fees on both legs
+	fees := (8000*10 + 8052*10) * 0.002
+	if !roll.Estimated || roll.ClosePrice != 8000 || roll.OpenPrice != 8052 ||
+		roll.Cost < 520+fees-1e-6 || roll.Cost > 520+fees+1e-6 {
+		t.Errorf("Test failed. Unexpected roll cost %+v", roll)
+	}
+
+	rollExpiringPositions([]exchange.IBotExchange{exch}, &cfg, now)
+	if len(exch.closed) != 1 {
+		t.Error("Test failed. Expected a position to only be rolled once")
+	}
+}
+
+func TestRollFuturesPositionFailure(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+	now := time.Date(2019, 6, 7, 7, 0, 0, 0, time.UTC)
+	underlying := currency.NewPairWithDelimiter("ETH", "USD", "-")
+	expiring := currency.NewPairWithDelimiter("ETH-USD", "190607", "_")
+	exch := &autoRollTestExchange{
+		routeTestExchange: routeTestExchange{name: "AutoRollFailureTest"},
+		closeErr:          errors.New("rejected"),
+	}
+	expiries.Update(exch.GetName(), []exchange.FuturesContract{
+		{Symbol: "ETH-USD-190607", Pair: expiring, Underlying: underlying,
+			Expiry: now.Add(time.Hour)},
+	})
+	contract, _ := expiries.Get(exch.GetName(), "ETH-USD-190607")
+	position := exchange.FuturesPosition{Symbol: "ETH-USD-190607", Pair: expiring,
+		Side: exchange.SellOrderSide, Amount: 3}
+
+	roll := rollFuturesPosition(exch, exch, &position, &contract, now)
+	if roll.Error == "" || roll.To != "" {
+		t.Errorf("Test failed. Expected no later contract to roll into, got %+v", roll)
+	}
+
+	expiries.Update(exch.GetName(), []exchange.FuturesContract{
+		{Symbol: "ETH-USD-190607", Pair: expiring, Underlying: underlying,
+			Expiry: now.Add(time.Hour)},
+		{Symbol: "ETH-USD-190614", Pair: currency.NewPairWithDelimiter("ETH-USD", "190614", "_"),
+			Underlying: underlying, Expiry: now.Add(time.Hour * 169)},
+	})
+	roll = rollFuturesPosition(exch, exch, &position, &contract, now)
+	if roll.Error != "rejected" || roll.Estimated {
+		t.Errorf("Test failed. Expected the close error without an estimate, got %+v", roll)
+	}
+	if len(exch.opened) != 0 {
+		t.Error("Test failed. Expected nothing opened when the close failed")
+	}
+}
+
+func TestFuturesRollAttempt(t *testing.T) {
+	rolls := futuresRollLog{attempted: make(map[string]time.Time)}
+	now := time.Unix(1000, 0)
+	expiry := now.Add(time.Hour)
+	if !rolls.Attempt("Test", "BTC-USD-190607", exchange.BuyOrderSide, expiry, now) {
+		t.Fatal("Test failed. Expected the first roll to be attempted")
+	}
+	if rolls.Attempt("test", "BTC-USD-190607", exchange.BuyOrderSide, expiry, now) {
+		t.Error("Test failed. Expected a contract position to only be attempted once")
+	}
+	if !rolls.Attempt("Test", "BTC-USD-190607", exchange.SellOrderSide, expiry, now) {
+		t.Error("Test failed. Expected the other side to be attempted")
+	}
+
+	rolls.Attempt("Test", "BTC-USD-190614", exchange.BuyOrderSide,
+		expiry.Add(time.Hour*168), expiry)
+	if len(rolls.attempted) != 1 {
+		t.Errorf("Test failed. Expected attempts on expired contracts to be dropped, got %v",
+			rolls.attempted)
+	}
+}
+
+func TestRollExpiringPositionsCircuitBreaker(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+	now := time.Date(2019, 6, 7, 7, 0, 0, 0, time.UTC)
+	underlying := currency.NewPairWithDelimiter("LTC", "USD", "-")
+	expiring := currency.NewPairWithDelimiter("LTC-USD", "190607", "_")
+	next := currency.NewPairWithDelimiter("LTC-USD", "190614", "_")
+	exch := &autoRollTestExchange{
+		routeTestExchange: routeTestExchange{name: "AutoRollBreakerTest"},
+		positions: []exchange.FuturesPosition{
+			{Symbol: "LTC-USD-190607", Pair: expiring, Side: exchange.BuyOrderSide, Amount: 10},
+		},
+	}
+	expiries.Update(exch.GetName(), []exchange.FuturesContract{
+		{Symbol: "LTC-USD-190607", Pair: expiring, Underlying: underlying,
+			Expiry: now.Add(time.Hour)},
+		{Symbol: "LTC-USD-190614", Pair: next, Underlying: underlying,
+			Expiry: now.Add(time.Hour * 169)},
+	})
+
+	cfg := bot.config
+	bot.config = &config.Config{CircuitBreaker: config.CircuitBreakerConfig{
+		Enabled:      true,
+		Window:       time.Minute,
+		MaxPriceMove: 5,
+		Cooldown:     time.Hour,
+	}}
+	defer func() { bot.config = cfg }()
+	tripped := clock.Now()
+	circuitBreaker.Observe(&bot.config.CircuitBreaker, exch.GetName(), next, 100, 0, 0, tripped)
+	circuitBreaker.Observe(&bot.config.CircuitBreaker, exch.GetName(), next, 110, 0, 0, tripped)
+	defer circuitBreaker.Reset(tripped.Add(time.Hour))
+
+	rollExpiringPositions([]exchange.IBotExchange{exch}, &config.AutoRollConfig{
+		LeadTime: time.Hour * 2,
+	}, now)
+	if len(exch.closed) != 0 || len(exch.opened) != 0 {
+		t.Errorf("Test failed. Expected the roll to be deferred while the next contract is suspended, closed %v opened %v",
+			exch.closed, exch.opened)
+	}
+	if !futuresRolls.Attempt(exch.GetName(), "LTC-USD-190607", exchange.BuyOrderSide,
+		now.Add(time.Hour), now) {
+		t.Error("Test failed. Expected a deferred roll to be attempted again")
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+const (
+	balanceSnapshotDeadline = time.Second * 10
+	balanceSnapshotCacheTTL = time.Second * 5
+)
+
+// ExchangeBalanceSnapshot is the outcome of querying an exchanges account info
+// for a balance snapshot
+type ExchangeBalanceSnapshot struct {
+	Exchange string                `json:"exchange"`
+	Success  bool                  `json:"success"`
+	Error    string                `json:"error,omitempty"`
+	Info     *exchange.AccountInfo `json:"info,omitempty"`
+	Received time.Time             `json:"received,omitempty"`
+}
+
+// BalanceSnapshot is the account info of every enabled exchange queried
+// concurrently. Exchanges which failed or didn't respond before the deadline
+// are included with their error
+type BalanceSnapshot struct {
+	Started   time.Time                 `json:"started"`
+	Completed time.Time                 `json:"completed"`
+	Complete  bool                      `json:"complete"`
+	Cached    bool                      `json:"cached"`
+	Exchanges []ExchangeBalanceSnapshot `json:"exchanges"`
+}
+
+// balanceSnapshotCache holds the last balance snapshot. The lock is held while
+// a snapshot is taken so concurrent queries share its result
+type balanceSnapshotCache struct {
+	snapshot *BalanceSnapshot
+	m        sync.Mutex
+}
+
+var balanceSnapshots balanceSnapshotCache
+
+// takeBalanceSnapshot queries the account info of exchanges concurrently,
+// waiting until the deadline for their responses
+func takeBalanceSnapshot(exchanges []exchange.IBotExchange, deadline time.Duration) BalanceSnapshot {
+	snapshot := BalanceSnapshot{Started: clock.Now(), Complete: true}
+	results := make(chan ExchangeBalanceSnapshot, len(exchanges))
+	var pending []string
+	for x := range exchanges {
+		if exchanges[x] == nil || !exchanges[x].IsEnabled() {
+			continue
+		}
+
+		exchName := exchanges[x].GetName()
+		if !exchanges[x].GetAuthenticatedAPISupport() {
+			snapshot.Exchanges = append(snapshot.Exchanges, ExchangeBalanceSnapshot{
+				Exchange: exchName,
+				Error:    "authenticated API support disabled",
+			})
+			continue
+		}
+
+		pending = append(pending, exchName)
+		go func(exch exchange.IBotExchange) {
+			result := ExchangeBalanceSnapshot{Exchange: exch.GetName()}
+			info, err := GetExchangeAccountInfo(exch, true)
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Success = true
+				result.Info = &info
+			}
+			result.Received = clock.Now()
+			results <- result
+		}(exchanges[x])
+	}
+
+	timeout := clock.After(deadline)
+	received := make(map[string]bool)
+	for expired := false; !expired && len(received) < len(pending); {
+		select {
+		case result := <-results:
+			received[result.Exchange] = true
+			snapshot.Exchanges = append(snapshot.Exchanges, result)
+		case <-timeout:
+			expired = true
+		}
+	}
+
+	for x := range pending {
+		if received[pending[x]] {
+			continue
+		}
+		snapshot.Exchanges = append(snapshot.Exchanges, ExchangeBalanceSnapshot{
+			Exchange: pending[x],
+			Error:    "account info request exceeded deadline of " + deadline.String(),
+		})
+	}
+
+	for x := range snapshot.Exchanges {
+		if !snapshot.Exchanges[x].Success {
+			snapshot.Complete = false
+		}
+	}
+	sort.Slice(snapshot.Exchanges, func(i, j int) bool {
+		return snapshot.Exchanges[i].Exchange < snapshot.Exchanges[j].Exchange
+	})
+	snapshot.Completed = clock.Now()
+	return snapshot
+}
+
+// Get returns the cached balance snapshot while it is within the cache TTL,
+// otherwise it takes a new snapshot of exchanges
+func (b *balanceSnapshotCache) Get(exchanges []exchange.IBotExchange, forceRefresh bool) BalanceSnapshot {
+	b.m.Lock()
+	defer b.m.Unlock()
+	if !forceRefresh && b.snapshot != nil &&
+		clock.Since(b.snapshot.Completed) < balanceSnapshotCacheTTL {
+		resp := *b.snapshot
+		resp.Cached = true
+		return resp
+	}
+
+	snapshot := takeBalanceSnapshot(exchanges, balanceSnapshotDeadline)
+	b.snapshot = &snapshot
+	return snapshot
+}
+
+// GetBalanceSnapshot returns a consolidated snapshot of the account info of
+// every enabled exchange, queried concurrently. Snapshots are cached briefly
+// so rapid queries don't hit the exchanges, unless forceRefresh is set
+func GetBalanceSnapshot(forceRefresh bool) BalanceSnapshot {
+	return balanceSnapshots.Get(GetExchanges(), forceRefresh)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type balanceSnapshotTestExchange struct {
+	exchange.IBotExchange
+	name    string
+	authAPI bool
+	err     error
+	block   chan struct{}
+	calls   int
+}
+
+func (b *balanceSnapshotTestExchange) GetName() string {
+	return b.name
+}
+
+func (b *balanceSnapshotTestExchange) IsEnabled() bool {
+	return true
+}
+
+func (b *balanceSnapshotTestExchange) IsSandbox() bool {
+	return false
+}
+
+func (b *balanceSnapshotTestExchange) GetAuthenticatedAPISupport() bool {
+	return b.authAPI
+}
+
+func (b *balanceSnapshotTestExchange) GetAccountInfo() (exchange.AccountInfo, error) {
+	b.calls++
+	if b.block != nil {
+		<-b.block
+	}
+	if b.err != nil {
+		return exchange.AccountInfo{}, b.err
+	}
+	return exchange.AccountInfo{Exchange: b.name}, nil
+}
+
+func TestTakeBalanceSnapshot(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	exchanges := []exchange.IBotExchange{
+		&balanceSnapshotTestExchange{name: "SnapshotC", authAPI: true, block: block},
+		&balanceSnapshotTestExchange{name: "SnapshotA", authAPI: true},
+		&balanceSnapshotTestExchange{name: "SnapshotB", authAPI: true, err: errors.New("rate limited")},
+		&balanceSnapshotTestExchange{name: "SnapshotD"},
+	}
+
+	snapshot := takeBalanceSnapshot(exchanges, time.Millisecond*50)
+	if snapshot.Complete {
+		t.Error("Test failed. Expected incomplete snapshot")
+	}
+	if len(snapshot.Exchanges) != 4 {
+		t.Fatalf("Test failed. Expected 4 exchange results, got %d", len(snapshot.Exchanges))
+	}
+
+	expected := []struct {
+		name    string
+		success bool
+	}{
+		{"SnapshotA", true},
+		{"SnapshotB", false},
+		{"SnapshotC", false},
+		{"SnapshotD", false},
+	}
+	for x := range expected {
+		result := snapshot.Exchanges[x]
+		if result.Exchange != expected[x].name || result.Success != expected[x].success {
+			t.Errorf("Test failed. Unexpected result for %s: %+v", expected[x].name, result)
+		}
+		if !result.Success && result.Error == "" {
+			t.Errorf("Test failed. Expected error for %s", result.Exchange)
+		}
+	}
+	if snapshot.Exchanges[0].Info == nil || snapshot.Exchanges[0].Received.IsZero() {
+		t.Error("Test failed. Expected account info and received time for successful exchange")
+	}
+}
+
+func TestBalanceSnapshotCache(t *testing.T) {
+	exch := &balanceSnapshotTestExchange{name: "SnapshotCache", authAPI: true}
+	exchanges := []exchange.IBotExchange{exch}
+	var cache balanceSnapshotCache
+
+	snapshot := cache.Get(exchanges, false)
+	if snapshot.Cached || !snapshot.Complete {
+		t.Errorf("Test failed. Unexpected initial snapshot %+v", snapshot)
+	}
+
+	snapshot = cache.Get(exchanges, false)
+	if !snapshot.Cached {
+		t.Error("Test failed. Expected cached snapshot")
+	}
+	if exch.calls != 1 {
+		t.Errorf("Test failed. Expected 1 exchange request, got %d", exch.calls)
+	}
+
+	snapshot = cache.Get(exchanges, true)
+	if snapshot.Cached {
+		t.Error("Test failed. Expected forced refresh to bypass cache")
+	}
+	if exch.calls != 2 {
+		t.Errorf("Test failed. Expected 2 exchange requests, got %d", exch.calls)
+	}
+}
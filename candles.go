@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// CandleSeries is a range of a stored candle series, Interval being that of
+// the returned candles once downsampled
+type CandleSeries struct {
+	Exchange  string         `json:"exchange"`
+	Pair      string         `json:"pair"`
+	AssetType string         `json:"assetType"`
+	Interval  time.Duration  `json:"interval"`
+	Candles   []kline.Candle `json:"candles"`
+}
+
+// GetCandles returns the stored candles of an exchange pair opening between
+// start and end, a zero start or end leaving that side unbounded. Candles are
+// downsampled server side to at most points candles when points is positive.
+// The asset type defaults to spot
+func GetCandles(exchName, currencyPair, assetType, interval string, start, end time.Time, points int) (CandleSeries, error) {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return CandleSeries{}, errors.New(exchange.ErrExchangeNotFound)
+	}
+
+	duration, err := kline.ParseInterval(interval)
+	if err != nil {
+		return CandleSeries{}, err
+	}
+	if assetType == "" {
+		assetType = ticker.Spot
+	}
+
+	item, err := kline.Query(exch.GetName(), currency.NewPairFromString(currencyPair),
+		assetType, duration, start, end, points)
+	if err != nil {
+		return CandleSeries{}, err
+	}
+	return CandleSeries{
+		Exchange:  item.Exchange,
+		Pair:      item.Pair.String(),
+		AssetType: item.AssetType,
+		Interval:  item.Interval,
+		Candles:   item.Candles,
+	}, nil
+}
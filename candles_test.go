@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+func TestGetCandles(t *testing.T) {
+	SetupTest(t)
+	start := time.Unix(1546300800, 0)
+	var candles []kline.Candle
+	for x := 0; x < 48; x++ {
+		candles = append(candles, kline.Candle{
+			Time:  start.Add(time.Hour * time.Duration(x)),
+			Close: float64(x),
+		})
+	}
+	err := kline.Process("Bitfinex", currency.NewPairFromStrings("BTC", "USD"),
+		ticker.Spot, time.Hour, candles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	series, err := GetCandles("bitfinex", "BTCUSD", "", "1h", start,
+		time.Time{}, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series.Candles) != 12 || series.Interval != time.Hour*4 ||
+		series.Candles[11].Close != 47 {
+		t.Errorf("Test failed. Expected 12 downsampled candles, got %v %d",
+			series.Interval, len(series.Candles))
+	}
+
+	if _, err = GetCandles("Bitfinex", "BTCUSD", "", "3x", start, time.Time{}, 0); err == nil {
+		t.Error("Test failed. Expected an invalid interval to be rejected")
+	}
+	if _, err = GetCandles("NotAnExchange", "BTCUSD", "", "1h", start, time.Time{}, 0); err == nil {
+		t.Error("Test failed. Expected an unknown exchange to be rejected")
+	}
+}
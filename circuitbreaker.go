@@ -0,0 +1,229 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const circuitBreakerEventType = "CIRCUIT_BREAKER"
+
+var errCircuitBreakerTripped = errors.New("circuit breaker tripped, automated orders suspended")
+
+// CircuitBreakerTrip is a pair which tripped the circuit breaker. Global trips
+// suspend automated orders on every pair until Resumes
+type CircuitBreakerTrip struct {
+	Exchange string    `json:"exchange"`
+	Pair     string    `json:"pair"`
+	Global   bool      `json:"global"`
+	Reason   string    `json:"reason"`
+	Tripped  time.Time `json:"tripped"`
+	Resumes  time.Time `json:"resumes"`
+}
+
+// circuitBreakerPrice is a price observed at a point in time
+type circuitBreakerPrice struct {
+	price float64
+	time  time.Time
+}
+
+// circuitBreakerMonitor holds the prices observed within the window of each
+// exchange pair and the active trips, keyed by exchange and pair. A global trip
+// is keyed by an empty string
+type circuitBreakerMonitor struct {
+	prices map[string][]circuitBreakerPrice
+	trips  map[string]CircuitBreakerTrip
+	m      sync.Mutex
+}
+
+var circuitBreaker circuitBreakerMonitor
+
+// circuitBreakerKey returns the key of an exchange pair
+func circuitBreakerKey(exchName string, p currency.Pair) string {
+	return exchName + " " + p.String()
+}
+
+// Observe records a price and spread of an exchange pair, tripping the
+// breaker when the price moved too far within the window or the spread is too
+// wide. The bid and ask are ignored when either is not positive. The message
+// of a new trip is returned so it can be notified
+func (c *circuitBreakerMonitor) Observe(cfg *config.CircuitBreakerConfig, exchName string, p currency.Pair, last, bid, ask float64, now time.Time) (string, bool) {
+	if last <= 0 {
+		return "", false
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.prices == nil {
+		c.prices = make(map[string][]circuitBreakerPrice)
+	}
+	if c.trips == nil {
+		c.trips = make(map[string]CircuitBreakerTrip)
+	}
+
+	key := circuitBreakerKey(exchName, p)
+	var prices []circuitBreakerPrice
+	for _, observed := range c.prices[key] {
+		if now.Sub(observed.time) <= cfg.Window {
+			prices = append(prices, observed)
+		}
+	}
+	prices = append(prices, circuitBreakerPrice{price: last, time: now})
+	c.prices[key] = prices
+
+	if _, ok := c.trips[key]; ok {
+		return "", false
+	}
+	if _, ok := c.trips[""]; ok && cfg.Global {
+		return "", false
+	}
+
+	resumes := now.Add(cfg.Cooldown)
+	until := resumes.UTC().Format(time.RFC3339)
+	var message string
+	if cfg.MaxPriceMove > 0 {
+		if move := getPriceMove(prices); move > cfg.MaxPriceMove {
+			message = i18n.T(i18n.MessageCircuitBreakerMove, exchName, p, move,
+				cfg.Window, cfg.MaxPriceMove, until)
+		}
+	}
+	if message == "" && cfg.MaxSpread > 0 && bid > 0 && ask > 0 {
+		if spread := (ask - bid) / ((ask + bid) / 2) * 100; spread > cfg.MaxSpread {
+			message = i18n.T(i18n.MessageCircuitBreakerSpread, exchName, p, spread,
+				cfg.MaxSpread, until)
+		}
+	}
+	if message == "" {
+		return "", false
+	}
+
+	trip := CircuitBreakerTrip{
+		Exchange: exchName,
+		Pair:     p.String(),
+		Global:   cfg.Global,
+		Reason:   message,
+		Tripped:  now,
+		Resumes:  resumes,
+	}
+	if cfg.Global {
+		key = ""
+	}
+	c.trips[key] = trip
+	// Forget the prices which tripped the breaker so it isn't tripped again
+	// as soon as it resumes
+	delete(c.prices, circuitBreakerKey(exchName, p))
+	return message, true
+}
+
+// getPriceMove returns the largest percentage move from an earlier price to
+// the latest price
+func getPriceMove(prices []circuitBreakerPrice) float64 {
+	last := prices[len(prices)-1].price
+	var move float64
+	for x := range prices[:len(prices)-1] {
+		change := (last - prices[x].price) / prices[x].price * 100
+		if change < 0 {
+			change = -change
+		}
+		if change > move {
+			move = change
+		}
+	}
+	return move
+}
+
+// Reset removes the trips whose cooldown has passed, returning them so their
+// resumption can be notified
+func (c *circuitBreakerMonitor) Reset(now time.Time) []CircuitBreakerTrip {
+	c.m.Lock()
+	defer c.m.Unlock()
+	var resumed []CircuitBreakerTrip
+	for key, trip := range c.trips {
+		if !now.Before(trip.Resumes) {
+			resumed = append(resumed, trip)
+			delete(c.trips, key)
+		}
+	}
+	return resumed
+}
+
+// Check returns an error when automated orders on an exchange pair are
+// suspended by a trip which has not yet resumed
+func (c *circuitBreakerMonitor) Check(exchName string, p currency.Pair, now time.Time) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	for _, key := range []string{circuitBreakerKey(exchName, p), ""} {
+		if trip, ok := c.trips[key]; ok && now.Before(trip.Resumes) {
+			return fmt.Errorf("%s %s %s until %s: %s", exchName, p,
+				errCircuitBreakerTripped, trip.Resumes.UTC().Format(time.RFC3339),
+				trip.Reason)
+		}
+	}
+	return nil
+}
+
+// GetAll returns the active trips ordered by when they resume
+func (c *circuitBreakerMonitor) GetAll() []CircuitBreakerTrip {
+	c.m.Lock()
+	defer c.m.Unlock()
+	resp := make([]CircuitBreakerTrip, 0, len(c.trips))
+	for _, trip := range c.trips {
+		resp = append(resp, trip)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Resumes.Before(resp[j].Resumes)
+	})
+	return resp
+}
+
+// GetCircuitBreakerTrips returns the pairs whose automated orders are
+// suspended
+func GetCircuitBreakerTrips() []CircuitBreakerTrip {
+	resetCircuitBreaker(clock.Now())
+	return circuitBreaker.GetAll()
+}
+
+// observeCircuitBreaker feeds a ticker price to the circuit breaker when it is
+// enabled, notifying trips and the resumption of expired trips
+func observeCircuitBreaker(exchName string, p currency.Pair, last, bid, ask float64) {
+	if bot.config == nil || !bot.config.CircuitBreaker.Enabled {
+		return
+	}
+
+	now := clock.Now()
+	resetCircuitBreaker(now)
+	message, tripped := circuitBreaker.Observe(&bot.config.CircuitBreaker,
+		exchName, p, last, bid, ask, now)
+	if tripped {
+		log.Warn(message)
+		pushEvent(circuitBreakerEventType, message)
+	}
+}
+
+// resetCircuitBreaker notifies the resumption of trips whose cooldown passed
+func resetCircuitBreaker(now time.Time) {
+	for _, trip := range circuitBreaker.Reset(now) {
+		message := i18n.T(i18n.MessageCircuitBreakerReset, trip.Exchange, trip.Pair)
+		log.Info(message)
+		pushEvent(circuitBreakerEventType, message)
+	}
+}
+
+// checkCircuitBreaker returns an error when automated orders on an exchange
+// pair are suspended
+func checkCircuitBreaker(exchName string, p currency.Pair) error {
+	if bot.config == nil || !bot.config.CircuitBreaker.Enabled {
+		return nil
+	}
+	now := clock.Now()
+	resetCircuitBreaker(now)
+	return circuitBreaker.Check(exchName, p, now)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+func TestCircuitBreakerPriceMove(t *testing.T) {
+	var c circuitBreakerMonitor
+	cfg := config.CircuitBreakerConfig{
+		Window:       time.Minute,
+		MaxPriceMove: 5,
+		Cooldown:     time.Minute * 10,
+	}
+	p := currency.NewPairFromStrings("BTC", "USD")
+	other := currency.NewPairFromStrings("ETH", "USD")
+	start := time.Unix(1000, 0)
+
+	if _, tripped := c.Observe(&cfg, "Bitstamp", p, 100, 0, 0, start); tripped {
+		t.Fatal("Test failed. Expected the first price not to trip the breaker")
+	}
+	if _, tripped := c.Observe(&cfg, "Bitstamp", p, 104, 0, 0, start.Add(time.Second*30)); tripped {
+		t.Fatal("Test failed. Expected a move within the limit not to trip the breaker")
+	}
+	// The 100 price has left the window
+	if _, tripped := c.Observe(&cfg, "Bitstamp", p, 108, 0, 0, start.Add(time.Second*70)); tripped {
+		t.Fatal("Test failed. Expected prices outside the window to be ignored")
+	}
+
+	now := start.Add(time.Second * 80)
+	message, tripped := c.Observe(&cfg, "Bitstamp", p, 98, 0, 0, now)
+	if !tripped || message == "" {
+		t.Fatal("Test failed. Expected a move beyond the limit to trip the breaker")
+	}
+
+	if err := c.Check("Bitstamp", p, now); err == nil {
+		t.Error("Test failed. Expected orders on the tripped pair to be suspended")
+	}
+	if err := c.Check("Bitstamp", other, now); err != nil {
+		t.Errorf("Test failed. Expected other pairs to be unaffected: %s", err)
+	}
+
+	if resumed := c.Reset(now.Add(time.Minute)); len(resumed) != 0 {
+		t.Error("Test failed. Expected the trip to last until its cooldown passed")
+	}
+	resumed := c.Reset(now.Add(cfg.Cooldown))
+	if len(resumed) != 1 || resumed[0].Pair != "BTCUSD" {
+		t.Fatalf("Test failed. Expected the trip to resume, got %v", resumed)
+	}
+	if err := c.Check("Bitstamp", p, now.Add(cfg.Cooldown)); err != nil {
+		t.Errorf("Test failed. Expected orders to resume: %s", err)
+	}
+}
+
+func TestCircuitBreakerSpreadGlobal(t *testing.T) {
+	var c circuitBreakerMonitor
+	cfg := config.CircuitBreakerConfig{
+		Global:    true,
+		Window:    time.Minute,
+		MaxSpread: 1,
+		Cooldown:  time.Minute,
+	}
+	p := currency.NewPairFromStrings("BTC", "USD")
+	now := time.Unix(1000, 0)
+
+	if _, tripped := c.Observe(&cfg, "Bitstamp", p, 100, 99.9, 100.1, now); tripped {
+		t.Fatal("Test failed. Expected a narrow spread not to trip the breaker")
+	}
+	if _, tripped := c.Observe(&cfg, "Bitstamp", p, 100, 98, 102, now); !tripped {
+		t.Fatal("Test failed. Expected a wide spread to trip the breaker")
+	}
+
+	err := c.Check("Kraken", currency.NewPairFromStrings("ETH", "EUR"), now)
+	if err == nil {
+		t.Error("Test failed. Expected a global trip to suspend every pair")
+	}
+
+	trips := c.GetAll()
+	if len(trips) != 1 || !trips[0].Global || trips[0].Exchange != "Bitstamp" {
+		t.Errorf("Test failed. Unexpected trips %v", trips)
+	}
+}
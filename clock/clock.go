@@ -0,0 +1,162 @@
+// Package clock provides the time source of the time dependent subsystems,
+// such as rate limiters, schedulers, nonces and candle maintenance. Tests and
+// the backtester replace it with a simulated clock to control time
+// deterministically.
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is a source of time
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+	After(d time.Duration) <-chan time.Time
+}
+
+// Real is the clock of the system
+type Real struct{}
+
+// Now returns the current system time
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep pauses the calling goroutine for a duration
+func (Real) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// After returns a channel which receives the time once a duration has elapsed
+func (Real) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// waiter is a goroutine waiting for a simulated time
+type waiter struct {
+	until time.Time
+	c     chan time.Time
+}
+
+// Simulated is a clock which only moves when it is advanced or set. Sleeping
+// goroutines are woken once the clock reaches their wake up time
+type Simulated struct {
+	now     time.Time
+	waiters []waiter
+	m       sync.Mutex
+}
+
+// NewSimulated returns a simulated clock starting at a time
+func NewSimulated(start time.Time) *Simulated {
+	return &Simulated{now: start}
+}
+
+// Now returns the simulated time
+func (s *Simulated) Now() time.Time {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.now
+}
+
+// Sleep blocks until the simulated clock has advanced by a duration
+func (s *Simulated) Sleep(d time.Duration) {
+	<-s.After(d)
+}
+
+// After returns a channel which receives the simulated time once the clock has
+// advanced by a duration
+func (s *Simulated) After(d time.Duration) <-chan time.Time {
+	s.m.Lock()
+	defer s.m.Unlock()
+	c := make(chan time.Time, 1)
+	if d <= 0 {
+		c <- s.now
+		return c
+	}
+	s.waiters = append(s.waiters, waiter{until: s.now.Add(d), c: c})
+	return c
+}
+
+// Advance moves the simulated clock forward by a duration
+func (s *Simulated) Advance(d time.Duration) {
+	s.Set(s.Now().Add(d))
+}
+
+// Set moves the simulated clock to a time, waking the goroutines waiting for
+// it in the order of their wake up times. The clock never moves backwards
+func (s *Simulated) Set(t time.Time) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if t.Before(s.now) {
+		return
+	}
+	s.now = t
+
+	sort.SliceStable(s.waiters, func(i, j int) bool {
+		return s.waiters[i].until.Before(s.waiters[j].until)
+	})
+	var remaining []waiter
+	for x := range s.waiters {
+		if s.waiters[x].until.After(t) {
+			remaining = append(remaining, s.waiters[x])
+			continue
+		}
+		s.waiters[x].c <- t
+	}
+	s.waiters = remaining
+}
+
+// Waiters returns the number of goroutines waiting for the simulated clock,
+// letting a test advance it once a subsystem is sleeping
+func (s *Simulated) Waiters() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return len(s.waiters)
+}
+
+var current = struct {
+	c Clock
+	m sync.RWMutex
+}{c: Real{}}
+
+// Set replaces the clock used by the time dependent subsystems, a nil clock
+// restores the system clock
+func Set(c Clock) {
+	if c == nil {
+		c = Real{}
+	}
+	current.m.Lock()
+	current.c = c
+	current.m.Unlock()
+}
+
+// Get returns the clock in use
+func Get() Clock {
+	current.m.RLock()
+	defer current.m.RUnlock()
+	return current.c
+}
+
+// Now returns the current time of the clock in use
+func Now() time.Time {
+	return Get().Now()
+}
+
+// Since returns the time elapsed since t on the clock in use
+func Since(t time.Time) time.Duration {
+	return Get().Now().Sub(t)
+}
+
+// Sleep pauses the calling goroutine for a duration on the clock in use
+func Sleep(d time.Duration) {
+	Get().Sleep(d)
+}
+
+// After returns a channel which receives the time once a duration has elapsed
+// on the clock in use
+func After(d time.Duration) <-chan time.Time {
+	return Get().After(d)
+}
@@ -0,0 +1,93 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimulated(t *testing.T) {
+	start := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	s := NewSimulated(start)
+	if !s.Now().Equal(start) {
+		t.Fatalf("expected %s, got %s", start, s.Now())
+	}
+
+	select {
+	case <-s.After(0):
+	default:
+		t.Error("expected a zero duration to fire immediately")
+	}
+
+	late := s.After(time.Minute)
+	early := s.After(time.Second)
+	if s.Waiters() != 2 {
+		t.Fatalf("expected 2 waiters, got %d", s.Waiters())
+	}
+
+	s.Advance(time.Second * 30)
+	select {
+	case now := <-early:
+		if !now.Equal(start.Add(time.Second * 30)) {
+			t.Errorf("unexpected wake up time %s", now)
+		}
+	default:
+		t.Error("expected the early waiter to be woken")
+	}
+	select {
+	case <-late:
+		t.Error("late waiter woken early")
+	default:
+	}
+
+	s.Set(start)
+	if !s.Now().Equal(start.Add(time.Second * 30)) {
+		t.Error("simulated clock moved backwards")
+	}
+
+	s.Advance(time.Second * 30)
+	<-late
+	if s.Waiters() != 0 {
+		t.Errorf("expected no waiters, got %d", s.Waiters())
+	}
+}
+
+func TestSimulatedSleep(t *testing.T) {
+	s := NewSimulated(time.Unix(0, 0))
+	done := make(chan struct{})
+	go func() {
+		s.Sleep(time.Hour)
+		close(done)
+	}()
+
+	for s.Waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	s.Advance(time.Hour)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sleeping goroutine not woken")
+	}
+}
+
+func TestSet(t *testing.T) {
+	defer Set(nil)
+
+	s := NewSimulated(time.Unix(1000, 0))
+	Set(s)
+	if Get() != s {
+		t.Fatal("expected the simulated clock to be in use")
+	}
+	if Now().Unix() != 1000 {
+		t.Errorf("expected 1000, got %d", Now().Unix())
+	}
+	s.Advance(time.Second * 5)
+	if Since(time.Unix(1000, 0)) != time.Second*5 {
+		t.Errorf("expected 5s, got %s", Since(time.Unix(1000, 0)))
+	}
+
+	Set(nil)
+	if _, ok := Get().(Real); !ok {
+		t.Error("expected the system clock to be restored")
+	}
+}
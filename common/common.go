@@ -7,7 +7,9 @@ import (
 	"crypto/sha1" // nolint:gosec
 	"crypto/sha256"
 	"crypto/sha512"
+	"encoding/base32"
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/csv"
 	"encoding/hex"
 	"encoding/json"
@@ -55,6 +57,10 @@ const (
 	SatoshisPerBTC = 100000000
 	SatoshisPerLTC = 100000000
 	WeiPerEther    = 1000000000000000000
+
+	// TOTPPeriod is the interval a time-based one-time password is valid for
+	TOTPPeriod = 30 * time.Second
+	totpDigits = 1000000
 )
 
 func initialiseHTTPClient() {
@@ -160,6 +166,27 @@ func Base64Encode(input []byte) string {
 	return base64.StdEncoding.EncodeToString(input)
 }
 
+// GenerateTOTP returns the six digit RFC 6238 time-based one-time password of
+// a base32 encoded secret, as used by authenticator apps for 2FA, at a time
+func GenerateTOTP(secret string, t time.Time) (int64, error) {
+	secret = strings.ToUpper(strings.Replace(strings.TrimSpace(secret), " ", "", -1))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).
+		DecodeString(strings.TrimRight(secret, "="))
+	if err != nil {
+		return 0, err
+	}
+	if len(key) == 0 {
+		return 0, errors.New("empty TOTP secret")
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(t.Unix()/int64(TOTPPeriod/time.Second)))
+	sum := GetHMAC(HashSHA1, counter, key)
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return int64(code % totpDigits), nil
+}
+
 // StringSliceDifference concatenates slices together based on its index and
 // returns an individual string array
 func StringSliceDifference(slice1, slice2 []string) []string {
@@ -296,9 +323,9 @@ func IsEnabled(isEnabled bool) string {
 func IsValidCryptoAddress(address, crypto string) (bool, error) {
 	switch StringToLower(crypto) {
 	case "btc":
-		return regexp.MatchString("^[13][a-km-zA-HJ-NP-Z1-9]{25,34}$", address)
+		return regexp.MatchString("^([13][a-km-zA-HJ-NP-Z1-9]{25,34}|bc1[ac-hj-np-z02-9]{11,71})$", address)
 	case "ltc":
-		return regexp.MatchString("^[L3M][a-km-zA-HJ-NP-Z1-9]{25,34}$", address)
+		return regexp.MatchString("^([L3M][a-km-zA-HJ-NP-Z1-9]{25,34}|ltc1[ac-hj-np-z02-9]{11,71})$", address)
 	case "eth":
 		return regexp.MatchString("^0x[a-km-z0-9]{40}$", address)
 	default:
@@ -35,6 +35,14 @@ func TestIsValidCryptoAddress(t *testing.T) {
 	if err != nil && !b {
 		t.Errorf("Test Failed - Common IsValidCryptoAddress error: %s", err)
 	}
+	b, err = IsValidCryptoAddress("bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", "btc")
+	if err != nil || !b {
+		t.Errorf("Test Failed - Common IsValidCryptoAddress error: %s", err)
+	}
+	b, err = IsValidCryptoAddress("bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyb", "btc")
+	if err == nil && b {
+		t.Error("Test Failed - Common IsValidCryptoAddress error")
+	}
 	b, err = IsValidCryptoAddress("0Mz7153HMuxXTuR2R1t78mGSdzaAtNbBWX", "btc")
 	if err == nil && b {
 		t.Error("Test Failed - Common IsValidCryptoAddress error")
@@ -1098,3 +1106,34 @@ func TestChangePerm(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateTOTP(t *testing.T) {
+	t.Parallel()
+	// RFC 6238 SHA1 test vectors, secret "12345678901234567890", last six digits
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	vectors := map[int64]int64{
+		59:          287082,
+		1111111109:  81804,
+		1111111111:  50471,
+		1234567890:  5924,
+		2000000000:  279037,
+		20000000000: 353130,
+	}
+	for unix, expected := range vectors {
+		code, err := GenerateTOTP(secret, time.Unix(unix, 0))
+		if err != nil {
+			t.Fatalf("Test failed. GenerateTOTP error: %s", err)
+		}
+		if code != expected {
+			t.Errorf("Test failed. GenerateTOTP at %d expected %06d, got %06d",
+				unix, expected, code)
+		}
+	}
+
+	if _, err := GenerateTOTP("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", time.Unix(59, 0)); err != nil {
+		t.Errorf("Test failed. GenerateTOTP expected lower case spaced secrets to decode: %s", err)
+	}
+	if _, err := GenerateTOTP("not base32!", time.Unix(59, 0)); err == nil {
+		t.Error("Test failed. GenerateTOTP expected an invalid secret to error")
+	}
+}
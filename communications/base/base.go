@@ -18,6 +18,10 @@ var (
 	SettingsStaged  Settings
 	ServiceStarted  time.Time
 	m               sync.Mutex
+
+	// ExchangeCredentialsHandler brings an exchange online with credentials
+	// supplied through a communication medium and returns a reply
+	ExchangeCredentialsHandler func(exchName, apiKey, apiSecret, clientID string) string
 )
 
 // Orderbook holds the minimal orderbook details to be sent to a communication
@@ -65,6 +69,12 @@ type Event struct {
 	TradeDetails string
 }
 
+// String returns the event formatted for the communication mediums
+func (e *Event) String() string {
+	return fmt.Sprintf("Type: %s Details: %s GainOrLoss: %s", e.Type,
+		e.TradeDetails, e.GainLoss)
+}
+
 // IsEnabled returns if the comms package has been enabled in the configuration
 func (b *Base) IsEnabled() bool {
 	return b.Enabled
@@ -82,3 +82,10 @@ func TestPushEvent(t *testing.T) {
 func TestGetEnabledCommunicationMediums(t *testing.T) {
 	i.GetEnabledCommunicationMediums()
 }
+
+func TestEventString(t *testing.T) {
+	e := Event{Type: "TEST", TradeDetails: "details", GainLoss: "gain"}
+	if s := e.String(); s != "Type: TEST Details: details GainOrLoss: gain" {
+		t.Errorf("test failed - base Event String() error, got %s", s)
+	}
+}
@@ -73,8 +73,8 @@ func (s *Slack) Connect() error {
 }
 
 // PushEvent pushes an event to either a slack channel or specific client
-func (s *Slack) PushEvent(base.Event) error {
-	return common.ErrNotYetImplemented
+func (s *Slack) PushEvent(event base.Event) error {
+	return s.WebsocketSend("message", event.String())
 }
 
 // BuildURL returns an appended token string with the SlackURL
@@ -3,6 +3,7 @@ package smtpservice
 import (
 	"errors"
 	"fmt"
+	"html"
 	"net/smtp"
 
 	"github.com/thrasher-/gocryptotrader/common"
@@ -45,8 +46,9 @@ func (s *SMTPservice) Connect() error {
 }
 
 // PushEvent sends an event to supplied recipient list via SMTP
-func (s *SMTPservice) PushEvent(base.Event) error {
-	return common.ErrNotYetImplemented
+func (s *SMTPservice) PushEvent(event base.Event) error {
+	return s.Send("GoCryptoTrader "+event.Type,
+		"<pre>"+html.EscapeString(event.String())+"</pre>")
 }
 
 // Send sends an email template to the recipient list via your SMTP host when
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/communications/base"
@@ -29,6 +30,7 @@ const (
 	cmdTicker    = "/ticker"
 	cmdPortfolio = "/portfolio"
 	cmdOrders    = "/orderbooks"
+	cmdCreds     = "/credentials"
 
 	cmdHelpReply = `GoCryptoTrader TelegramBot, thank you for using this service!
 	Current commands are:
@@ -38,7 +40,8 @@ const (
 	/settings 	- Displays current bot settings
 	/ticker 		- Displays current ANX ticker data
 	/portfolio	- Displays your current portfolio
-	/orderbooks - Displays current orderbooks for ANX`
+	/orderbooks - Displays current orderbooks for ANX
+	/credentials <exchange> <apiKey> <apiSecret> [clientID] - Brings an exchange online`
 
 	talkRoot = "GoCryptoTrader bot"
 )
@@ -57,6 +60,7 @@ func (t *Telegram) Setup(cfg *config.CommunicationsConfig) {
 	t.Enabled = cfg.TelegramConfig.Enabled
 	t.Token = cfg.TelegramConfig.VerificationToken
 	t.Verbose = cfg.TelegramConfig.Verbose
+	t.AuthorisedClients = cfg.TelegramConfig.AuthorisedClients
 }
 
 // Connect starts an initial connection
@@ -72,8 +76,7 @@ func (t *Telegram) Connect() error {
 // PushEvent sends an event to a supplied recipient list via telegram
 func (t *Telegram) PushEvent(event base.Event) error {
 	for i := range t.AuthorisedClients {
-		err := t.SendMessage(fmt.Sprintf("Type: %s Details: %s GainOrLoss: %s",
-			event.Type, event.TradeDetails, event.GainLoss), t.AuthorisedClients[i])
+		err := t.SendMessage(event.String(), t.AuthorisedClients[i])
 		if err != nil {
 			return err
 		}
@@ -139,6 +142,9 @@ func (t *Telegram) InitialConnect() {
 // HandleMessages handles incoming message from the long polling routine
 func (t *Telegram) HandleMessages(text string, chatID int64) error {
 	switch {
+	case common.StringContains(text, cmdCreds):
+		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, t.SetCredentials(text, chatID)), chatID)
+
 	case common.StringContains(text, cmdHelp):
 		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, cmdHelpReply), chatID)
 
@@ -165,6 +171,37 @@ func (t *Telegram) HandleMessages(text string, chatID int64) error {
 	}
 }
 
+// IsAuthorised returns if a chat ID is an authorised client
+func (t *Telegram) IsAuthorised(chatID int64) bool {
+	for i := range t.AuthorisedClients {
+		if t.AuthorisedClients[i] == chatID {
+			return true
+		}
+	}
+	return false
+}
+
+// SetCredentials brings an exchange online with the credentials in a
+// credentials command from an authorised client and returns the reply
+func (t *Telegram) SetCredentials(text string, chatID int64) string {
+	if !t.IsAuthorised(chatID) {
+		return "not authorised to supply exchange credentials"
+	}
+	if base.ExchangeCredentialsHandler == nil {
+		return "exchange credentials can't be supplied"
+	}
+	fields := strings.Fields(text)
+	if len(fields) < 4 || len(fields) > 5 {
+		return "usage: /credentials <exchange> <apiKey> <apiSecret> [clientID]"
+	}
+	var clientID string
+	if len(fields) == 5 {
+		clientID = fields[4]
+	}
+	return base.ExchangeCredentialsHandler(fields[1], fields[2], fields[3], clientID) +
+		". Please delete the message containing your credentials"
+}
+
 // GetUpdates gets new updates via a long poll connection
 func (t *Telegram) GetUpdates() (GetUpdateResponse, error) {
 	var newUpdates GetUpdateResponse
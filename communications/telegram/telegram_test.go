@@ -90,6 +90,35 @@ func TestHandleMessages(t *testing.T) {
 	}
 }
 
+func TestSetCredentials(t *testing.T) {
+	tg := Telegram{AuthorisedClients: []int64{1337}}
+	var supplied []string
+	base.ExchangeCredentialsHandler = func(exchName, apiKey, apiSecret, clientID string) string {
+		supplied = []string{exchName, apiKey, apiSecret, clientID}
+		return exchName + " is online"
+	}
+	defer func() { base.ExchangeCredentialsHandler = nil }()
+
+	reply := tg.SetCredentials(cmdCreds+" Bitstamp key secret", 1)
+	if reply != "not authorised to supply exchange credentials" || supplied != nil {
+		t.Errorf("test failed - telegram SetCredentials() accepted an unauthorised client: %s",
+			reply)
+	}
+
+	reply = tg.SetCredentials(cmdCreds+" Bitstamp key", 1337)
+	if supplied != nil {
+		t.Errorf("test failed - telegram SetCredentials() accepted missing credentials: %s",
+			reply)
+	}
+
+	tg.SetCredentials(cmdCreds+" Bitstamp key secret client", 1337)
+	if len(supplied) != 4 || supplied[0] != "Bitstamp" || supplied[1] != "key" ||
+		supplied[2] != "secret" || supplied[3] != "client" {
+		t.Errorf("test failed - telegram SetCredentials() unexpected credentials %v",
+			supplied)
+	}
+}
+
 func TestGetUpdates(t *testing.T) {
 	t.Parallel()
 	_, err := T.GetUpdates()
@@ -7,6 +7,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -17,10 +18,15 @@ import (
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/connchecker"
+	"github.com/thrasher-/gocryptotrader/cron"
 	"github.com/thrasher-/gocryptotrader/currency"
 	"github.com/thrasher-/gocryptotrader/currency/forexprovider"
 	"github.com/thrasher-/gocryptotrader/currency/forexprovider/base"
+	"github.com/thrasher-/gocryptotrader/database"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/i18n"
 	log "github.com/thrasher-/gocryptotrader/logger"
+	"github.com/thrasher-/gocryptotrader/news"
 	"github.com/thrasher-/gocryptotrader/portfolio"
 )
 
@@ -35,9 +41,58 @@ const (
 	configFileEncryptionDisabled           = -1
 	configPairsLastUpdatedWarningThreshold = 30 // 30 days
 	configDefaultHTTPTimeout               = time.Second * 15
+	configDefaultAccountInfoCacheTTL       = time.Second * 30
+	configDefaultTickerPollingInterval     = time.Second * 10
+	configDefaultOrderbookPollingInterval  = time.Second * 10
+	configDefaultAccountPollingInterval    = time.Minute
+	configMinPairUpdateInterval            = time.Minute * 5
 	configMaxAuthFailres                   = 3
 	defaultNTPAllowedDifference            = 50000000
 	defaultNTPAllowedNegativeDifference    = 50000000
+	defaultStablecoinDeviationThreshold    = 0.5
+	defaultStablecoinCheckInterval         = time.Minute
+	defaultArbitrageScanInterval           = time.Second * 10
+	defaultHeartbeatInterval               = time.Minute
+	defaultStateSaveInterval               = time.Minute
+	defaultStateMaxAge                     = time.Hour
+	defaultTokenListUpdateInterval         = time.Hour * 24
+	defaultSummaryReportTopMovers          = 5
+	defaultSummaryReportMaxEvents          = 10
+	defaultSummaryReportMinFeeSaving       = 25
+	defaultOrderQueueExpiry                = time.Hour
+	defaultOrderQueueCheckInterval         = time.Second * 30
+	defaultCircuitBreakerWindow            = time.Minute * 5
+	defaultCircuitBreakerCooldown          = time.Minute * 15
+	defaultAnalyticsCandleInterval         = time.Hour
+	defaultAnalyticsWindow                 = 168
+	defaultAnalyticsUpdateInterval         = time.Minute * 15
+	defaultDataRetentionPruneInterval      = time.Hour * 24
+	defaultTaskQueueCapacity               = 10000
+	defaultTaskQueueWorkers                = 4
+	defaultTaskQueueMaxTaskAge             = time.Second * 30
+	defaultOrderbookHistorySnapshots       = time.Minute * 5
+	defaultExpiryCalendarAlertLeadTime     = time.Hour * 24
+	defaultExpiryCalendarRefreshInterval   = time.Hour
+	defaultAutoRollLeadTime                = time.Hour * 2
+	defaultFuturesBasisCheckInterval       = time.Minute
+	defaultFuturesBasisThreshold           = 20
+	defaultExposureLimitsCheckInterval     = time.Minute * 5
+	defaultVWAPWindow                      = time.Hour * 24
+	defaultOrderManagerPollInterval        = time.Second * 30
+	defaultStartupBudget                   = time.Minute
+	defaultMarketMakerRefreshInterval      = time.Second * 10
+	defaultMarketMakerRequoteThreshold     = 0.1
+	defaultDatabaseSyncInterval            = time.Minute * 5
+	defaultDatabaseSQLite3File             = "gocryptotrader.db"
+	defaultDatabasePostgresHost            = "localhost"
+	defaultDatabasePostgresPort            = 5432
+	defaultDatabasePostgresSSLMode         = "disable"
+	defaultLatencyMonitorCheckInterval     = time.Minute
+	defaultLatencyMonitorWindow            = time.Minute * 15
+	defaultLatencyMonitorMinSamples        = 20
+	defaultLatencyMonitorP95               = time.Second * 2
+	defaultLatencyMonitorP99               = time.Second * 5
+	defaultLiquidationAlertMinValue       = 100000
 )
 
 // Constants here hold some messages
@@ -54,10 +109,27 @@ const (
 	ErrSavingConfigBytesMismatch               = "config file %q bytes comparison doesn't match, read %s expected %s"
 	WarningWebserverCredentialValuesEmpty      = "webserver support disabled due to empty Username/Password values"
 	WarningWebserverListenAddressInvalid       = "webserver support disabled due to invalid listen address"
-	WarningExchangeAuthAPIDefaultOrEmptyValues = "exchange %s authenticated API support disabled due to default/empty APIKey/Secret/ClientID values"
+	WarningExchangeAuthAPIDefaultOrEmptyValues = "exchange %s authenticated API support disabled due to default/empty APIKey/Secret/ClientID/Passphrase values"
+	WarningExchangeOTPSecretInvalid            = "exchange %s OTP secret is not valid base32 and has been ignored, withdrawals will need a one-time password. Error: %s"
 	WarningPairsLastUpdatedThresholdExceeded   = "exchange %s last manual update of available currency pairs has exceeded %d days. Manual update required!"
 )
 
+// Order funding modes define how a buy order is funded when the account lacks
+// its quote currency but holds an equivalent currency
+const (
+	OrderFundingNone    = ""
+	OrderFundingPair    = "pair"
+	OrderFundingConvert = "convert"
+)
+
+// Strategy feed types define the market data a strategy can depend on, and
+// StrategyFeedAnyExchange lets the feed use the first venue trading its pair
+const (
+	StrategyFeedTicker      = "ticker"
+	StrategyFeedOrderbook   = "orderbook"
+	StrategyFeedAnyExchange = "any"
+)
+
 // Constants here define unset default values displayed in the config.json
 // file
 const (
@@ -75,6 +147,12 @@ var (
 	IsInitialSetup bool
 	testBypass     bool
 	m              sync.Mutex
+
+	defaultStablecoinPairs    = []string{"USDT-USD", "USDC-USD", "DAI-USD"}
+	defaultArbitrageStart     = []string{"BTC", "USDT"}
+	defaultFundingEquivalents = map[string][]string{"USD": {"USDT", "USDC"}}
+
+	exchangesRequiringAPIPassphrase = []string{"CoinbasePro", "OKEX", "OKCOIN International"}
 )
 
 // WebserverConfig struct holds the prestart variables for the webserver.
@@ -85,6 +163,7 @@ type WebserverConfig struct {
 	ListenAddress                string `json:"listenAddress"`
 	WebsocketConnectionLimit     int    `json:"websocketConnectionLimit"`
 	WebsocketMaxAuthFailures     int    `json:"websocketMaxAuthFailures"`
+	WebsocketRequestRateLimit    int    `json:"websocketRequestRateLimit"`
 	WebsocketAllowInsecureOrigin bool   `json:"websocketAllowInsecureOrigin"`
 }
 
@@ -108,7 +187,9 @@ type Config struct {
 	Name              string                  `json:"name"`
 	EncryptConfig     int                     `json:"encryptConfig"`
 	GlobalHTTPTimeout time.Duration           `json:"globalHTTPTimeout"`
+	Locale            string                  `json:"locale"`
 	Logging           log.Logging             `json:"logging"`
+	Debug             DebugConfig             `json:"debug"`
 	Profiler          ProfilerConfig          `json:"profiler"`
 	NTPClient         NTPClientConfig         `json:"ntpclient"`
 	Currency          CurrencyConfig          `json:"currencyConfig"`
@@ -118,6 +199,38 @@ type Config struct {
 	Exchanges         []ExchangeConfig        `json:"exchanges"`
 	BankAccounts      []BankAccount           `json:"bankAccounts"`
 	ConnectionMonitor ConnectionMonitorConfig `json:"connectionMonitor"`
+	KlineStorage      KlineStorageConfig      `json:"klineStorage"`
+	VWAP              VWAPConfig              `json:"vwap"`
+	News              NewsConfig              `json:"news"`
+	StablecoinMonitor StablecoinMonitorConfig `json:"stablecoinMonitor"`
+	DustSweep         DustSweepConfig         `json:"dustSweep"`
+	Arbitrage         ArbitrageConfig         `json:"arbitrage"`
+	Heartbeat         HeartbeatConfig         `json:"heartbeat"`
+	StatePersistence  StatePersistenceConfig  `json:"statePersistence"`
+	TokenRegistry     TokenRegistryConfig     `json:"tokenRegistry"`
+	Scheduler         SchedulerConfig         `json:"scheduler"`
+	SummaryReport     SummaryReportConfig     `json:"summaryReport"`
+	OrderQueue        OrderQueueConfig        `json:"orderQueue"`
+	CircuitBreaker    CircuitBreakerConfig    `json:"circuitBreaker"`
+	Analytics         AnalyticsConfig         `json:"analytics"`
+	DataRetention     DataRetentionConfig     `json:"dataRetention"`
+	TaskQueue         TaskQueueConfig         `json:"taskQueue"`
+	OrderbookHistory  OrderbookHistoryConfig  `json:"orderbookHistory"`
+	ExpiryCalendar    ExpiryCalendarConfig    `json:"expiryCalendar"`
+	AutoRoll          AutoRollConfig          `json:"autoRoll"`
+	FuturesBasis      FuturesBasisConfig      `json:"futuresBasis"`
+	ExposureLimits    ExposureLimitsConfig    `json:"exposureLimits"`
+	OrderManager      OrderManagerConfig      `json:"orderManager"`
+	Startup           StartupConfig           `json:"startup"`
+	MarketMaker       MarketMakerConfig       `json:"marketMaker"`
+	Database          DatabaseConfig          `json:"database"`
+	LatencyMonitor    LatencyMonitorConfig    `json:"latencyMonitor"`
+	LiquidationAlerts LiquidationAlertConfig  `json:"liquidationAlerts"`
+
+	StrategyAllocations []StrategyAllocationConfig `json:"strategyAllocations,omitempty"`
+	WithdrawalAddresses []WithdrawalAddressConfig  `json:"withdrawalAddresses,omitempty"`
+	StrategyFeeds       []StrategyFeedConfig       `json:"strategyFeeds,omitempty"`
+	OrderMirrors        []OrderMirrorConfig        `json:"orderMirrors,omitempty"`
 
 	// Deprecated config settings, will be removed at a future date
 	CurrencyPairFormat  *CurrencyPairFormatConfig `json:"currencyPairFormat,omitempty"`
@@ -134,6 +247,409 @@ type ConnectionMonitorConfig struct {
 	CheckInterval    time.Duration `json:"checkInterval"`
 }
 
+// KlineStorageConfig defines the maintenance interval and retention policies
+// for stored candle data. TradeIntervals are the candle intervals built from
+// the trade stream of exchanges without kline endpoints or websocket candles
+type KlineStorageConfig struct {
+	MaintenanceInterval time.Duration           `json:"maintenanceInterval"`
+	RetentionPolicies   []kline.RetentionPolicy `json:"retentionPolicies"`
+	TradeIntervals      []time.Duration         `json:"tradeIntervals,omitempty"`
+}
+
+// VWAPConfig defines the rolling window of the VWAP computed from websocket
+// trades for tickers of exchanges which don't supply it
+type VWAPConfig struct {
+	Window time.Duration `json:"window"`
+}
+
+// NewsConfig defines the exchange announcement feeds to poll for listing,
+// delisting and maintenance notices
+type NewsConfig struct {
+	Enabled      bool          `json:"enabled"`
+	PollInterval time.Duration `json:"pollInterval"`
+	Feeds        []news.Feed   `json:"feeds"`
+}
+
+// StablecoinMonitorConfig defines the stablecoin pairs to monitor for
+// deviations from parity across exchanges. DeviationThreshold is a percentage
+type StablecoinMonitorConfig struct {
+	Enabled                  bool           `json:"enabled"`
+	Pairs                    currency.Pairs `json:"pairs"`
+	DeviationThreshold       float64        `json:"deviationThreshold"`
+	CheckInterval            time.Duration  `json:"checkInterval"`
+	AdjustPortfolioValuation bool           `json:"adjustPortfolioValuation"`
+}
+
+// DustSweepConfig defines the currency dust balances are converted into.
+// Balances worth less than ValueThreshold in the target currency are swept
+// along with balances below the exchanges minimum order size
+type DustSweepConfig struct {
+	TargetCurrency currency.Code `json:"targetCurrency"`
+	ValueThreshold float64       `json:"valueThreshold"`
+}
+
+// ArbitrageConfig defines the triangular arbitrage scanner. Paths start and
+// end in one of the start currencies and are reported when their fee adjusted
+// profit percentage reaches MinProfit. TradeAmounts sets the amount of a start
+// currency evaluated against orderbook depth, which is required for
+// AutoExecute to trade a path
+type ArbitrageConfig struct {
+	Enabled         bool                `json:"enabled"`
+	ScanInterval    time.Duration       `json:"scanInterval"`
+	StartCurrencies currency.Currencies `json:"startCurrencies"`
+	MinProfit       float64             `json:"minProfit"`
+	TradeAmounts    map[string]float64  `json:"tradeAmounts,omitempty"`
+	AutoExecute     bool                `json:"autoExecute"`
+}
+
+// HeartbeatConfig defines the external uptime monitor URLs which are sent the
+// bot status every interval, so a bot which stops running can be alerted on
+type HeartbeatConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+	URLs     []string      `json:"urls"`
+}
+
+// StatePersistenceConfig defines how often the engine runtime state is saved
+// to the data directory. Saved state older than MaxAge is discarded on start
+type StatePersistenceConfig struct {
+	Enabled      bool          `json:"enabled"`
+	SaveInterval time.Duration `json:"saveInterval"`
+	MaxAge       time.Duration `json:"maxAge"`
+}
+
+// OrderQueueConfig defines whether orders submitted while an exchange is under
+// maintenance or rate limiting requests are held and submitted once it
+// recovers. Queued orders not submitted within Expiry are dropped
+type OrderQueueConfig struct {
+	Enabled       bool          `json:"enabled"`
+	Expiry        time.Duration `json:"expiry"`
+	CheckInterval time.Duration `json:"checkInterval"`
+}
+
+// TaskQueueConfig defines the bounded queue between market data producers
+// and the consumers processing their data. Capacity bounds the pending tasks,
+// Workers is the number of consumers and market data tasks pending longer than
+// MaxTaskAge are dropped as stale
+type TaskQueueConfig struct {
+	Capacity   int           `json:"capacity"`
+	Workers    int           `json:"workers"`
+	MaxTaskAge time.Duration `json:"maxTaskAge"`
+}
+
+// OrderbookHistoryConfig defines the recording of the websocket orderbook
+// updates of Exchanges so past orderbooks can be reconstructed. A complete
+// snapshot of each orderbook is recorded every SnapshotInterval, limiting the
+// deltas replayed to reconstruct it
+type OrderbookHistoryConfig struct {
+	Enabled          bool          `json:"enabled"`
+	Exchanges        []string      `json:"exchanges"`
+	SnapshotInterval time.Duration `json:"snapshotInterval"`
+}
+
+// ExpiryCalendarConfig defines the calendar of futures contract expiries
+// built from the instruments of Exchanges. An alert is sent AlertLeadTime
+// before each contract expires so positions can be rolled
+type ExpiryCalendarConfig struct {
+	Enabled         bool          `json:"enabled"`
+	AlertLeadTime   time.Duration `json:"alertLeadTime"`
+	RefreshInterval time.Duration `json:"refreshInterval"`
+}
+
+// AutoRollConfig defines the rolling of futures positions into the next
+// contract LeadTime before their contract expires. Positions on every exchange
+// are rolled when Exchanges is empty
+type AutoRollConfig struct {
+	Enabled   bool          `json:"enabled"`
+	LeadTime  time.Duration `json:"leadTime"`
+	Exchanges []string      `json:"exchanges"`
+}
+
+// DebugConfig holds the verbose output settings of the engine or an exchange.
+// Subsystems limits the engine output to the named subsystems, payloads are
+// truncated to MaxPayloadSize bytes, SampleRate is the fraction of verbose
+// messages logged and secrets are redacted from payloads unless ShowSecrets
+// is set
+type DebugConfig struct {
+	Enabled        bool     `json:"enabled"`
+	Subsystems     []string `json:"subsystems,omitempty"`
+	MaxPayloadSize int      `json:"maxPayloadSize"`
+	SampleRate     float64  `json:"sampleRate"`
+	ShowSecrets    bool     `json:"showSecrets,omitempty"`
+}
+
+// SubsystemEnabled returns whether verbose output is enabled for an engine
+// subsystem
+func (d *DebugConfig) SubsystemEnabled(name string) bool {
+	return d.Enabled && (len(d.Subsystems) == 0 ||
+		common.StringDataCompareInsensitive(d.Subsystems, name))
+}
+
+// FuturesBasisConfig defines the alerting of futures contracts trading at an
+// annualised basis to the spot index above AnnualisedThreshold percent, the
+// opportunities for a cash-and-carry trade
+type FuturesBasisConfig struct {
+	Enabled             bool          `json:"enabled"`
+	AnnualisedThreshold float64       `json:"annualisedThreshold"`
+	CheckInterval       time.Duration `json:"checkInterval"`
+}
+
+// ExposureLimitsConfig defines the maximum portfolio exposure to currencies.
+// The portfolio is valued in Currency every CheckInterval, alerting when a
+// limit is breached and blocking orders which would accumulate more of it
+type ExposureLimitsConfig struct {
+	Enabled       bool                  `json:"enabled"`
+	Currency      currency.Code         `json:"currency"`
+	CheckInterval time.Duration         `json:"checkInterval"`
+	Limits        []ExposureLimitConfig `json:"limits"`
+}
+
+// OrderManagerConfig defines whether the status of the orders tracked by the
+// order manager is polled from their exchanges every PollInterval
+type OrderManagerConfig struct {
+	Enabled      bool          `json:"enabled"`
+	PollInterval time.Duration `json:"pollInterval"`
+}
+
+// StartupConfig defines how long engine startup is expected to take, a
+// warning naming the unfinished startup stages is logged once it runs over
+// Budget
+type StartupConfig struct {
+	Budget time.Duration `json:"budget"`
+}
+
+// MarketMakerConfig defines the market making strategy, which quotes a bid and
+// an ask around the reference price of each enabled pair every
+// RefreshInterval. Quotes are tracked through the order manager, which is
+// enabled along with it
+type MarketMakerConfig struct {
+	Enabled         bool                    `json:"enabled"`
+	RefreshInterval time.Duration           `json:"refreshInterval"`
+	Pairs           []MarketMakerPairConfig `json:"pairs"`
+}
+
+// MarketMakerPairConfig defines the quotes of an exchange pair. The bid and ask
+// are SpreadPercent of the reference price apart, each for OrderAmount. As the
+// base currency inventory moves from TargetInventory towards
+// TargetInventory +/- MaxInventory both quotes are shifted by up to
+// SkewPercent of the reference price and the side adding inventory shrinks,
+// until it isn't quoted at all at the limit. Quotes are only replaced once the
+// price they should be at moves more than RequoteThreshold percent
+type MarketMakerPairConfig struct {
+	Enabled          bool          `json:"enabled"`
+	Exchange         string        `json:"exchange"`
+	Pair             currency.Pair `json:"pair"`
+	SpreadPercent    float64       `json:"spreadPercent"`
+	OrderAmount      float64       `json:"orderAmount"`
+	TargetInventory  float64       `json:"targetInventory"`
+	MaxInventory     float64       `json:"maxInventory"`
+	SkewPercent      float64       `json:"skewPercent"`
+	RequoteThreshold float64       `json:"requoteThreshold"`
+}
+
+// DatabaseConfig defines the database order and trade history and balance
+// snapshots are synced to every SyncInterval. Driver is sqlite3 or postgres,
+// for sqlite3 Database is the database file which is relative to the data
+// directory unless absolute and the connection details are unused
+type DatabaseConfig struct {
+	Enabled      bool          `json:"enabled"`
+	Driver       string        `json:"driver"`
+	Host         string        `json:"host"`
+	Port         uint16        `json:"port"`
+	Username     string        `json:"username"`
+	Password     string        `json:"password"`
+	Database     string        `json:"database"`
+	SSLMode      string        `json:"sslMode"`
+	SyncInterval time.Duration `json:"syncInterval"`
+}
+
+// LatencyMonitorConfig defines the endpoint latency monitor, which every
+// CheckInterval compares the latency percentiles of each exchange endpoint
+// over the last Window against their thresholds. Endpoints with fewer than
+// MinSamples requests aren't checked. P95 and P99 are the thresholds of
+// endpoints without one of their own
+type LatencyMonitorConfig struct {
+	Enabled       bool                     `json:"enabled"`
+	CheckInterval time.Duration            `json:"checkInterval"`
+	Window        time.Duration            `json:"window"`
+	MinSamples    int64                    `json:"minSamples"`
+	P95           time.Duration            `json:"p95"`
+	P99           time.Duration            `json:"p99"`
+	Thresholds    []LatencyThresholdConfig `json:"thresholds,omitempty"`
+}
+
+// LiquidationAlertConfig holds the liquidation notional value, in the quote
+// currency of the liquidated contract, from which websocket liquidations are
+// pushed through the communication mediums. Every liquidation is relayed to
+// websocket clients regardless
+type LiquidationAlertConfig struct {
+	Enabled  bool    `json:"enabled"`
+	MinValue float64 `json:"minValue"`
+}
+
+// LatencyThresholdConfig is the latency threshold of the endpoints of an
+// exchange whose path contains Endpoint, of every endpoint when empty. A zero
+// percentile isn't checked
+type LatencyThresholdConfig struct {
+	Exchange string        `json:"exchange"`
+	Endpoint string        `json:"endpoint,omitempty"`
+	P50      time.Duration `json:"p50,omitempty"`
+	P95      time.Duration `json:"p95,omitempty"`
+	P99      time.Duration `json:"p99,omitempty"`
+}
+
+// ExposureLimitConfig is the maximum exposure to a currency as a value in the
+// valuation currency and/or a percentage of the portfolio value. A zero
+// maximum is unlimited
+type ExposureLimitConfig struct {
+	Currency   currency.Code `json:"currency"`
+	MaxValue   float64       `json:"maxValue,omitempty"`
+	MaxPercent float64       `json:"maxPercent,omitempty"`
+}
+
+// CircuitBreakerConfig defines when automated order submission is suspended
+// for abnormal market conditions. A pair trips the breaker when its price moves
+// more than MaxPriceMove percent within Window or its spread exceeds MaxSpread
+// percent, a zero threshold disables that check. Orders on the pair, or every
+// pair when Global is set, are suspended until Cooldown has passed
+type CircuitBreakerConfig struct {
+	Enabled      bool          `json:"enabled"`
+	Global       bool          `json:"global"`
+	Window       time.Duration `json:"window"`
+	MaxPriceMove float64       `json:"maxPriceMove"`
+	MaxSpread    float64       `json:"maxSpread"`
+	Cooldown     time.Duration `json:"cooldown"`
+}
+
+// AnalyticsConfig defines the rolling correlation and relative strength
+// analytics computed between enabled pairs from their stored candles of
+// CandleInterval, over the last Window candles, every UpdateInterval. The open
+// interest of OpenInterestAlerts contracts is tracked alongside
+type AnalyticsConfig struct {
+	Enabled            bool                      `json:"enabled"`
+	CandleInterval     time.Duration             `json:"candleInterval"`
+	Window             int                       `json:"window"`
+	UpdateInterval     time.Duration             `json:"updateInterval"`
+	CorrelationAlerts  []CorrelationAlertConfig  `json:"correlationAlerts,omitempty"`
+	OpenInterestAlerts []OpenInterestAlertConfig `json:"openInterestAlerts,omitempty"`
+}
+
+// CorrelationAlertConfig alerts when the correlation of two pairs on an
+// exchange drops below Threshold, and again when it recovers
+type CorrelationAlertConfig struct {
+	Exchange  string        `json:"exchange"`
+	PairA     currency.Pair `json:"pairA"`
+	PairB     currency.Pair `json:"pairB"`
+	Threshold float64       `json:"threshold"`
+}
+
+// OpenInterestAlertConfig tracks the open interest of a derivatives contract on
+// an exchange, alerting when it changes by ChangePercent or more between
+// updates. A zero ChangePercent tracks the contract without alerting
+type OpenInterestAlertConfig struct {
+	Exchange      string        `json:"exchange"`
+	Pair          currency.Pair `json:"pair"`
+	ChangePercent float64       `json:"changePercent"`
+}
+
+// DataRetentionConfig defines how many days of each type of stored data are
+// kept by the pruning job run every PruneInterval. Zero keeps that type of data
+// indefinitely
+type DataRetentionConfig struct {
+	Enabled       bool          `json:"enabled"`
+	PruneInterval time.Duration `json:"pruneInterval"`
+	CandleDays    int           `json:"candleDays"`
+	TradeDays     int           `json:"tradeDays"`
+	LogDays       int           `json:"logDays"`
+}
+
+// StrategyAllocationConfig assigns a strategy virtual balances on an exchange
+// account shared with other strategies. Balances are keyed by currency code and
+// the strategy can't spend more than its balance of a currency
+type StrategyAllocationConfig struct {
+	Strategy string             `json:"strategy"`
+	Exchange string             `json:"exchange"`
+	Balances map[string]float64 `json:"balances"`
+}
+
+// StrategyFeedConfig declares a data feed a strategy depends on. Feed is
+// ticker or orderbook and Exchange is the preferred venue, or any to use the
+// first venue trading the pair. When the venue goes down the feed fails over
+// to the Fallbacks in order, then to any other venue trading the pair
+type StrategyFeedConfig struct {
+	Strategy  string        `json:"strategy"`
+	Feed      string        `json:"feed"`
+	Pair      currency.Pair `json:"pair"`
+	Exchange  string        `json:"exchange"`
+	Fallbacks []string      `json:"fallbacks,omitempty"`
+}
+
+// OrderMirrorConfig duplicates the orders submitted on a Primary exchange onto
+// each of its Mirrors
+type OrderMirrorConfig struct {
+	Primary string                    `json:"primary"`
+	Mirrors []OrderMirrorTargetConfig `json:"mirrors"`
+}
+
+// OrderMirrorTargetConfig is an exchange orders are mirrored onto, with the
+// mirrored order amount being the primary order amount multiplied by Scale
+type OrderMirrorTargetConfig struct {
+	Exchange string  `json:"exchange"`
+	Scale    float64 `json:"scale"`
+}
+
+// WithdrawalAddressConfig is an entry of the local withdrawal address book.
+// Addresses are reconciled with the withdrawal whitelists of every exchange
+// unless Exchange limits them to one
+type WithdrawalAddressConfig struct {
+	Label    string `json:"label,omitempty"`
+	Exchange string `json:"exchange,omitempty"`
+	Currency string `json:"currency"`
+	Address  string `json:"address"`
+	Tag      string `json:"tag,omitempty"`
+	Network  string `json:"network,omitempty"`
+}
+
+// SchedulerConfig defines the tasks run at fixed schedules
+type SchedulerConfig struct {
+	Enabled bool                  `json:"enabled"`
+	Tasks   []ScheduledTaskConfig `json:"tasks"`
+}
+
+// ScheduledTaskConfig defines a task run at a schedule given as a five field
+// cron expression, a descriptor such as @daily or an interval such as
+// @every 1h. Schedules use the local time zone. Exchange limits exchange tasks
+// to one exchange, all enabled exchanges are used when it is empty. Name
+// identifies the task and defaults to the task
+type ScheduledTaskConfig struct {
+	Name     string `json:"name"`
+	Task     string `json:"task"`
+	Schedule string `json:"schedule"`
+	Exchange string `json:"exchange,omitempty"`
+}
+
+// SummaryReportConfig defines the summary report sent to the communication
+// mediums by the summary_report scheduled task. Values are reported in
+// Currency, TopMovers and MaxEvents limit the pairs and events listed.
+// Cheaper withdrawal routes are suggested when they would have saved at least
+// MinWithdrawalFeeSaving percent of the average fee paid
+type SummaryReportConfig struct {
+	Currency               currency.Code `json:"currency"`
+	TopMovers              int           `json:"topMovers"`
+	MaxEvents              int           `json:"maxEvents"`
+	MinWithdrawalFeeSaving float64       `json:"minWithdrawalFeeSaving"`
+}
+
+// TokenRegistryConfig defines the ERC-20 tokens whose balances are included
+// for watched Ethereum addresses. Tokens from the remote token list at
+// RemoteListURL are added every UpdateInterval, configured tokens take
+// precedence over them
+type TokenRegistryConfig struct {
+	Tokens         []portfolio.Token `json:"tokens"`
+	RemoteListURL  string            `json:"remoteListUrl,omitempty"`
+	UpdateInterval time.Duration     `json:"updateInterval"`
+}
+
 // ProfilerConfig defines the profiler configuration to enable pprof
 type ProfilerConfig struct {
 	Enabled bool `json:"enabled"`
@@ -148,38 +664,170 @@ type NTPClientConfig struct {
 	AllowedNegativeDifference *time.Duration `json:"allowedNegativeDifference"`
 }
 
+// PollingConfig holds the REST polling intervals for an exchange. Pairs can be
+// given a priority which divides the ticker and orderbook intervals, a pair
+// with priority 4 is polled four times as often as the configured interval
+type PollingConfig struct {
+	TickerInterval    time.Duration  `json:"tickerInterval"`
+	OrderbookInterval time.Duration  `json:"orderbookInterval"`
+	AccountInterval   time.Duration  `json:"accountInterval"`
+	PairPriorities    map[string]int `json:"pairPriorities,omitempty"`
+}
+
+// GetPairInterval returns the polling interval for a currency pair after
+// applying its priority
+func (p *PollingConfig) GetPairInterval(interval time.Duration, pair currency.Pair) time.Duration {
+	priority, ok := p.PairPriorities[pair.Upper().String()]
+	if !ok || priority <= 1 {
+		return interval
+	}
+	return interval / time.Duration(priority)
+}
+
 // ExchangeConfig holds all the information needed for each enabled Exchange.
 type ExchangeConfig struct {
 	Name                      string                    `json:"name"`
 	Enabled                   bool                      `json:"enabled"`
-	Verbose                   bool                      `json:"verbose"`
+	Verbose                   bool                      `json:"verbose,omitempty"`
+	Debug                     DebugConfig               `json:"debug"`
+	FaultInjection            *FaultInjectionConfig     `json:"faultInjection,omitempty"`
 	Websocket                 bool                      `json:"websocket"`
+	WebsocketCapture          bool                      `json:"websocketCapture,omitempty"`
 	UseSandbox                bool                      `json:"useSandbox"`
+	ReadOnly                  bool                      `json:"readOnly,omitempty"`
 	RESTPollingDelay          time.Duration             `json:"restPollingDelay"`
 	HTTPTimeout               time.Duration             `json:"httpTimeout"`
 	HTTPUserAgent             string                    `json:"httpUserAgent"`
+	HTTPHeaders               map[string]string         `json:"httpHeaders,omitempty"`
 	HTTPDebugging             bool                      `json:"httpDebugging"`
+	AccountInfoCacheTTL       time.Duration             `json:"accountInfoCacheTTL"`
+	Polling                   PollingConfig             `json:"polling"`
 	AuthenticatedAPISupport   bool                      `json:"authenticatedApiSupport"`
 	APIKey                    string                    `json:"apiKey"`
 	APISecret                 string                    `json:"apiSecret"`
 	APIAuthPEMKeySupport      bool                      `json:"apiAuthPemKeySupport,omitempty"`
 	APIAuthPEMKey             string                    `json:"apiAuthPemKey,omitempty"`
+	TradePassword             string                    `json:"tradePassword,omitempty"`
+	OTPSecret                 string                    `json:"otpSecret,omitempty"`
+	APIPassphrase             string                    `json:"apiPassphrase,omitempty"`
 	APIURL                    string                    `json:"apiUrl"`
 	APIURLSecondary           string                    `json:"apiUrlSecondary"`
 	ProxyAddress              string                    `json:"proxyAddress"`
 	WebsocketURL              string                    `json:"websocketUrl"`
+	FallbackToDefaultURLs     bool                      `json:"fallbackToDefaultUrls,omitempty"`
 	ClientID                  string                    `json:"clientId,omitempty"`
 	AvailablePairs            currency.Pairs            `json:"availablePairs"`
 	EnabledPairs              currency.Pairs            `json:"enabledPairs"`
+	PairWhitelist             currency.Pairs            `json:"pairWhitelist,omitempty"`
+	PairBlacklist             currency.Pairs            `json:"pairBlacklist,omitempty"`
+	BalanceReserves           map[string]float64        `json:"balanceReserves,omitempty"`
+	FundingMode               string                    `json:"fundingMode,omitempty"`
+	FundingEquivalents        map[string][]string       `json:"fundingEquivalents,omitempty"`
 	BaseCurrencies            currency.Currencies       `json:"baseCurrencies"`
 	AssetTypes                string                    `json:"assetTypes"`
 	SupportsAutoPairUpdates   bool                      `json:"supportsAutoPairUpdates"`
 	PairsLastUpdated          int64                     `json:"pairsLastUpdated,omitempty"`
+	PairUpdateInterval        time.Duration             `json:"pairUpdateInterval,omitempty"`
 	ConfigCurrencyPairFormat  *CurrencyPairFormatConfig `json:"configCurrencyPairFormat"`
 	RequestCurrencyPairFormat *CurrencyPairFormatConfig `json:"requestCurrencyPairFormat"`
 	BankAccounts              []BankAccount             `json:"bankAccounts"`
 }
 
+// FaultInjectionConfig defines the simulated network faults injected into the
+// requests and websocket connection of an exchange for resilience testing.
+// Faults are only injected in builds with the faultinjection tag, see the
+// exchanges/faults package
+type FaultInjectionConfig struct {
+	Enabled     bool          `json:"enabled"`
+	Seed        int64         `json:"seed"`
+	MaxLatency  time.Duration `json:"maxLatency"`
+	DropRate    float64       `json:"dropRate"`
+	ErrorRate   float64       `json:"errorRate"`
+	BurstLength int           `json:"burstLength"`
+	StatusCodes []int         `json:"statusCodes,omitempty"`
+}
+
+// CheckFaultInjection disables fault injection with invalid rates or latency
+func (e *ExchangeConfig) CheckFaultInjection() {
+	f := e.FaultInjection
+	if f == nil || !f.Enabled {
+		return
+	}
+	if f.DropRate < 0 || f.DropRate > 1 || f.ErrorRate < 0 || f.ErrorRate > 1 ||
+		f.MaxLatency < 0 {
+		log.Warnf("Exchange %s fault injection rates must be between 0 and 1 with a positive latency, disabling",
+			e.Name)
+		f.Enabled = false
+		return
+	}
+	if f.BurstLength <= 0 {
+		f.BurstLength = 1
+	}
+	for x := range f.StatusCodes {
+		if f.StatusCodes[x] < 400 || f.StatusCodes[x] > 599 {
+			log.Warnf("Exchange %s fault injection status code %d is not an error, disabling",
+				e.Name, f.StatusCodes[x])
+			f.Enabled = false
+			return
+		}
+	}
+}
+
+// CheckURLOverrides validates the API and websocket URLs overridden in config
+func (e *ExchangeConfig) CheckURLOverrides() error {
+	if e.APIURL != APIURLNonDefaultMessage {
+		err := ValidateURL(e.APIURL, "http", "https")
+		if err != nil {
+			return fmt.Errorf("exchange %s apiUrl: %s", e.Name, err)
+		}
+	}
+	if e.APIURLSecondary != APIURLNonDefaultMessage {
+		err := ValidateURL(e.APIURLSecondary, "http", "https")
+		if err != nil {
+			return fmt.Errorf("exchange %s apiUrlSecondary: %s", e.Name, err)
+		}
+	}
+	if e.WebsocketURL != WebsocketURLNonDefaultMessage {
+		err := ValidateURL(e.WebsocketURL, "ws", "wss")
+		if err != nil {
+			return fmt.Errorf("exchange %s websocketUrl: %s", e.Name, err)
+		}
+	}
+	return nil
+}
+
+// ValidateURL checks that a URL is absolute, has a host and uses one of the
+// allowed schemes
+func ValidateURL(rawURL string, schemes ...string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %s", rawURL, err)
+	}
+	if !common.StringDataCompareInsensitive(schemes, u.Scheme) {
+		return fmt.Errorf("invalid URL %q: scheme must be one of %s",
+			rawURL, strings.Join(schemes, ", "))
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("invalid URL %q: missing host", rawURL)
+	}
+	return nil
+}
+
+// FilterPairs splits pairs into those allowed by the exchanges pair
+// whitelist and blacklist and those excluded by them. An empty whitelist
+// allows all pairs not blacklisted
+func (e *ExchangeConfig) FilterPairs(pairs currency.Pairs) (allowed, excluded currency.Pairs) {
+	for x := range pairs {
+		if (len(e.PairWhitelist) > 0 && !e.PairWhitelist.Contains(pairs[x], true)) ||
+			e.PairBlacklist.Contains(pairs[x], true) {
+			excluded = append(excluded, pairs[x])
+			continue
+		}
+		allowed = append(allowed, pairs[x])
+	}
+	return allowed, excluded
+}
+
 // BankAccount holds differing bank account details by supported funding
 // currency
 type BankAccount struct {
@@ -272,10 +920,11 @@ type SMTPConfig struct {
 
 // TelegramConfig holds all variables to start and run the Telegram package
 type TelegramConfig struct {
-	Name              string `json:"name"`
-	Enabled           bool   `json:"enabled"`
-	Verbose           bool   `json:"verbose"`
-	VerificationToken string `json:"verificationToken"`
+	Name              string  `json:"name"`
+	Enabled           bool    `json:"enabled"`
+	Verbose           bool    `json:"verbose"`
+	VerificationToken string  `json:"verificationToken"`
+	AuthorisedClients []int64 `json:"authorisedClients,omitempty"`
 }
 
 // GetCurrencyConfig returns currency configurations
@@ -554,6 +1203,215 @@ func (c *Config) CheckCommunicationsConfig() {
 	}
 }
 
+// CheckPairUpdateInterval disables scheduled pair updates for exchanges
+// without auto pair update support and raises intervals below the minimum
+func (e *ExchangeConfig) CheckPairUpdateInterval() {
+	if e.PairUpdateInterval <= 0 {
+		e.PairUpdateInterval = 0
+		return
+	}
+	if !e.SupportsAutoPairUpdates {
+		log.Warnf("Exchange %s does not support auto pair updates, disabling scheduled pair updates",
+			e.Name)
+		e.PairUpdateInterval = 0
+		return
+	}
+	if e.PairUpdateInterval < configMinPairUpdateInterval {
+		log.Warnf("Exchange %s pair update interval %s below minimum, using %s",
+			e.Name, e.PairUpdateInterval, configMinPairUpdateInterval)
+		e.PairUpdateInterval = configMinPairUpdateInterval
+	}
+}
+
+// RequiresAPIPassphrase returns whether the exchange signs authenticated
+// requests with an API passphrase
+func (e *ExchangeConfig) RequiresAPIPassphrase() bool {
+	return common.StringDataCompare(exchangesRequiringAPIPassphrase, e.Name)
+}
+
+// CheckAPIPassphrase returns whether an exchange requiring an API passphrase
+// has one set. A passphrase previously stored as the clientId is moved to the
+// apiPassphrase
+func (e *ExchangeConfig) CheckAPIPassphrase() bool {
+	if !e.RequiresAPIPassphrase() {
+		return true
+	}
+	if e.APIPassphrase == "" && e.ClientID != "" && e.ClientID != "ClientID" {
+		log.Warnf("Exchange %s API passphrase moved from clientId to apiPassphrase",
+			e.Name)
+		e.APIPassphrase = e.ClientID
+		e.ClientID = ""
+	}
+	return e.APIPassphrase != ""
+}
+
+// CheckHTTPHeaders removes custom HTTP headers with an invalid name or value
+func (e *ExchangeConfig) CheckHTTPHeaders() {
+	for k, v := range e.HTTPHeaders {
+		if !isValidHTTPHeader(k, v) {
+			log.Warnf("Exchange %s HTTP header %q is invalid and has been ignored",
+				e.Name, k)
+			delete(e.HTTPHeaders, k)
+		}
+	}
+}
+
+// isValidHTTPHeader returns whether a header name is a token and its value
+// holds no control characters
+func isValidHTTPHeader(name, value string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r <= ' ' || r >= 0x7f || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r) {
+			return false
+		}
+	}
+	for _, r := range value {
+		if (r < ' ' && r != '\t') || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckPollingConfig assigns default polling intervals where unset. Exchanges
+// with a legacy RESTPollingDelay, in seconds, use it for ticker and orderbook
+// polling
+func (e *ExchangeConfig) CheckPollingConfig() {
+	tickerInterval := configDefaultTickerPollingInterval
+	orderbookInterval := configDefaultOrderbookPollingInterval
+	if e.RESTPollingDelay > 0 {
+		tickerInterval = e.RESTPollingDelay * time.Second
+		orderbookInterval = e.RESTPollingDelay * time.Second
+	}
+
+	if e.Polling.TickerInterval <= 0 {
+		e.Polling.TickerInterval = tickerInterval
+	}
+
+	if e.Polling.OrderbookInterval <= 0 {
+		e.Polling.OrderbookInterval = orderbookInterval
+	}
+
+	if e.Polling.AccountInterval <= 0 {
+		e.Polling.AccountInterval = configDefaultAccountPollingInterval
+	}
+
+	for pair, priority := range e.Polling.PairPriorities {
+		if priority < 1 {
+			log.Warnf("Exchange %s pair %s polling priority %d invalid, using default",
+				e.Name, pair, priority)
+			delete(e.Polling.PairPriorities, pair)
+		}
+	}
+}
+
+// CheckBalanceReserves normalises the exchanges balance reserve currency codes
+// and removes invalid reserve amounts
+func (e *ExchangeConfig) CheckBalanceReserves() {
+	if len(e.BalanceReserves) == 0 {
+		return
+	}
+
+	reserves := make(map[string]float64, len(e.BalanceReserves))
+	for code, amount := range e.BalanceReserves {
+		if amount < 0 {
+			log.Warnf("Exchange %s %s balance reserve %f invalid, removing",
+				e.Name, code, amount)
+			continue
+		}
+		reserves[common.StringToUpper(code)] = amount
+	}
+	e.BalanceReserves = reserves
+}
+
+// GetBalanceReserve returns the balance of a currency which must be kept on
+// the exchange
+func (e *ExchangeConfig) GetBalanceReserve(c currency.Code) float64 {
+	return e.BalanceReserves[c.Upper().String()]
+}
+
+// CheckOrderFunding validates the exchanges order funding mode and normalises
+// its equivalent currency codes. Equivalents default to common USD stablecoins
+// when a funding mode is set without them
+func (e *ExchangeConfig) CheckOrderFunding() {
+	e.FundingMode = common.StringToLower(e.FundingMode)
+	switch e.FundingMode {
+	case OrderFundingNone:
+		return
+	case OrderFundingPair, OrderFundingConvert:
+	default:
+		log.Warnf("Exchange %s funding mode %s invalid, disabling", e.Name,
+			e.FundingMode)
+		e.FundingMode = OrderFundingNone
+		return
+	}
+
+	configured := e.FundingEquivalents
+	if len(configured) == 0 {
+		configured = defaultFundingEquivalents
+	}
+
+	equivalents := make(map[string][]string, len(configured))
+	for code, codes := range configured {
+		upper := make([]string, len(codes))
+		for x := range codes {
+			upper[x] = common.StringToUpper(codes[x])
+		}
+		equivalents[common.StringToUpper(code)] = upper
+	}
+	e.FundingEquivalents = equivalents
+}
+
+// GetFundingEquivalents returns the currencies which can fund orders quoted in
+// a currency. Each equivalents entry forms a group of interchangeable codes
+func (e *ExchangeConfig) GetFundingEquivalents(c currency.Code) currency.Currencies {
+	var resp currency.Currencies
+	code := c.Upper().String()
+	for key, codes := range e.FundingEquivalents {
+		group := append([]string{key}, codes...)
+		if !common.StringDataCompare(group, code) {
+			continue
+		}
+		for x := range group {
+			equivalent := currency.NewCode(group[x])
+			if group[x] == code || resp.Contains(equivalent) {
+				continue
+			}
+			resp = append(resp, equivalent)
+		}
+	}
+	return resp
+}
+
+// checkExchangePairFilters removes pairs excluded by the exchanges pair
+// whitelist and blacklist from its available and enabled pairs
+func (c *Config) checkExchangePairFilters(exch *ExchangeConfig) {
+	if len(exch.PairWhitelist) == 0 && len(exch.PairBlacklist) == 0 {
+		return
+	}
+
+	var excluded currency.Pairs
+	exch.AvailablePairs, excluded = exch.FilterPairs(exch.AvailablePairs)
+	if len(excluded) > 0 {
+		log.Warnf("Exchange %s: Removed available pairs excluded by the pair whitelist or blacklist: %s\n",
+			exch.Name, excluded)
+	}
+
+	exch.EnabledPairs, excluded = exch.FilterPairs(exch.EnabledPairs)
+	if len(excluded) > 0 {
+		log.Warnf("Exchange %s: Removed enabled pairs excluded by the pair whitelist or blacklist: %s\n",
+			exch.Name, excluded)
+	}
+
+	if len(exch.EnabledPairs) == 0 && len(exch.AvailablePairs) > 0 {
+		exch.EnabledPairs = currency.Pairs{exch.AvailablePairs.GetRandomPair()}
+		log.Warnf("Exchange %s: No enabled pairs allowed by the pair whitelist or blacklist, randomly added %v\n",
+			exch.Name, exch.EnabledPairs)
+	}
+}
+
 // CheckPairConsistency checks to see if the enabled pair exists in the
 // available pairs list
 func (c *Config) CheckPairConsistency(exchName string) error {
@@ -789,6 +1647,11 @@ func (c *Config) CheckExchangeConfigValues() error {
 			if c.Exchanges[i].Name == "" {
 				return fmt.Errorf(ErrExchangeNameEmpty, i)
 			}
+			err := c.Exchanges[i].CheckURLOverrides()
+			if err != nil {
+				return err
+			}
+			c.checkExchangePairFilters(&c.Exchanges[i])
 			if len(c.Exchanges[i].AvailablePairs) == 0 {
 				return fmt.Errorf(ErrExchangeAvailablePairsEmpty, c.Exchanges[i].Name)
 			}
@@ -804,11 +1667,21 @@ func (c *Config) CheckExchangeConfigValues() error {
 					c.Exchanges[i].APISecret == DefaultUnsetAPISecret {
 					c.Exchanges[i].AuthenticatedAPISupport = false
 					log.Warnf(WarningExchangeAuthAPIDefaultOrEmptyValues, c.Exchanges[i].Name)
-				} else if c.Exchanges[i].Name == "ITBIT" || c.Exchanges[i].Name == "Bitstamp" || c.Exchanges[i].Name == "COINUT" || c.Exchanges[i].Name == "CoinbasePro" {
+				} else if c.Exchanges[i].Name == "ITBIT" || c.Exchanges[i].Name == "Bitstamp" || c.Exchanges[i].Name == "COINUT" {
 					if c.Exchanges[i].ClientID == "" || c.Exchanges[i].ClientID == "ClientID" {
 						c.Exchanges[i].AuthenticatedAPISupport = false
 						log.Warnf(WarningExchangeAuthAPIDefaultOrEmptyValues, c.Exchanges[i].Name)
 					}
+				} else if !c.Exchanges[i].CheckAPIPassphrase() {
+					c.Exchanges[i].AuthenticatedAPISupport = false
+					log.Warnf(WarningExchangeAuthAPIDefaultOrEmptyValues, c.Exchanges[i].Name)
+				}
+				if c.Exchanges[i].OTPSecret != "" {
+					_, err := common.GenerateTOTP(c.Exchanges[i].OTPSecret, time.Now())
+					if err != nil {
+						log.Warnf(WarningExchangeOTPSecretInvalid, c.Exchanges[i].Name, err)
+						c.Exchanges[i].OTPSecret = ""
+					}
 				}
 			}
 			if !c.Exchanges[i].SupportsAutoPairUpdates {
@@ -824,7 +1697,18 @@ func (c *Config) CheckExchangeConfigValues() error {
 				c.Exchanges[i].HTTPTimeout = configDefaultHTTPTimeout
 			}
 
-			err := c.CheckPairConsistency(c.Exchanges[i].Name)
+			if c.Exchanges[i].AccountInfoCacheTTL <= 0 {
+				c.Exchanges[i].AccountInfoCacheTTL = configDefaultAccountInfoCacheTTL
+			}
+
+			c.Exchanges[i].CheckPollingConfig()
+			c.Exchanges[i].CheckPairUpdateInterval()
+			c.Exchanges[i].CheckHTTPHeaders()
+			c.Exchanges[i].CheckFaultInjection()
+			c.Exchanges[i].CheckBalanceReserves()
+			c.Exchanges[i].CheckOrderFunding()
+
+			err = c.CheckPairConsistency(c.Exchanges[i].Name)
 			if err != nil {
 				log.Errorf("Exchange %s: CheckPairConsistency error: %s", c.Exchanges[i].Name, err)
 			}
@@ -900,6 +1784,10 @@ func (c *Config) CheckWebserverConfigValues() error {
 		c.Webserver.WebsocketMaxAuthFailures = 3
 	}
 
+	if c.Webserver.WebsocketRequestRateLimit <= 0 {
+		c.Webserver.WebsocketRequestRateLimit = 10
+	}
+
 	return nil
 }
 
@@ -1187,63 +2075,933 @@ func (c *Config) CheckConnectionMonitorConfig() {
 	}
 }
 
-// GetFilePath returns the desired config file or the default config file name
-// based on if the application is being run under test or normal mode.
-func GetFilePath(file string) (string, error) {
-	if file != "" {
-		return file, nil
-	}
-
-	if flag.Lookup("test.v") != nil && !testBypass {
-		return ConfigTestFile, nil
-	}
+// CheckKlineStorageConfig checks and if zero value assigns default values
+func (c *Config) CheckKlineStorageConfig() {
+	m.Lock()
+	defer m.Unlock()
 
-	exePath, err := common.GetExecutablePath()
-	if err != nil {
-		return "", err
+	if c.KlineStorage.MaintenanceInterval <= 0 {
+		c.KlineStorage.MaintenanceInterval = kline.DefaultMaintenanceInterval
 	}
 
-	oldDir := exePath + common.GetOSPathSlash()
-	oldDirs := []string{oldDir + ConfigFile, oldDir + EncryptedConfigFile}
-
-	newDir := common.GetDefaultDataDir(runtime.GOOS) + common.GetOSPathSlash()
-	err = common.CreateDir(newDir)
-	if err != nil {
-		return "", err
+	if len(c.KlineStorage.RetentionPolicies) == 0 {
+		c.KlineStorage.RetentionPolicies = kline.DefaultRetentionPolicies
 	}
-	newDirs := []string{newDir + ConfigFile, newDir + EncryptedConfigFile}
 
-	// First upgrade the old dir config file if it exists to the corresponding new one
-	for x := range oldDirs {
-		_, err := os.Stat(oldDirs[x])
-		if os.IsNotExist(err) {
+	var intervals []time.Duration
+	for _, interval := range c.KlineStorage.TradeIntervals {
+		if interval <= 0 {
+			log.Warnf("Kline storage trade interval %s invalid, removing", interval)
 			continue
 		}
-		if filepath.Ext(oldDirs[x]) == ".json" {
-			err = os.Rename(oldDirs[x], newDirs[0])
-			if err != nil {
-				return "", err
-			}
-			log.Debugf("Renamed old config file %s to %s", oldDirs[x], newDirs[0])
-		} else {
-			err = os.Rename(oldDirs[x], newDirs[1])
-			if err != nil {
-				return "", err
+		duplicate := false
+		for x := range intervals {
+			if intervals[x] == interval {
+				duplicate = true
+				break
 			}
-			log.Debugf("Renamed old config file %s to %s", oldDirs[x], newDirs[1])
+		}
+		if !duplicate {
+			intervals = append(intervals, interval)
 		}
 	}
+	c.KlineStorage.TradeIntervals = intervals
+}
 
-	// Secondly check to see if the new config file extension is correct or not
-	for x := range newDirs {
-		_, err := os.Stat(newDirs[x])
-		if os.IsNotExist(err) {
-			continue
-		}
+// CheckNewsConfig checks and if zero value assigns default values, disabling
+// any invalid feeds
+func (c *Config) CheckNewsConfig() {
+	m.Lock()
+	defer m.Unlock()
 
-		data, err := common.ReadFile(newDirs[x])
+	if c.News.PollInterval <= 0 {
+		c.News.PollInterval = news.DefaultPollInterval
+	}
+
+	var feeds []news.Feed
+	for x := range c.News.Feeds {
+		err := c.News.Feeds[x].Validate()
 		if err != nil {
-			return "", err
+			log.Warnf("News feed disabled: %s", err)
+			continue
+		}
+		feeds = append(feeds, c.News.Feeds[x])
+	}
+	c.News.Feeds = feeds
+
+	if c.News.Enabled && len(c.News.Feeds) == 0 {
+		log.Warnf("News enabled with no valid feeds configured, disabling")
+		c.News.Enabled = false
+	}
+}
+
+// CheckStablecoinMonitorConfig checks and if zero value assigns default values
+func (c *Config) CheckStablecoinMonitorConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if len(c.StablecoinMonitor.Pairs) == 0 {
+		c.StablecoinMonitor.Pairs = currency.NewPairsFromStrings(defaultStablecoinPairs)
+	}
+
+	if c.StablecoinMonitor.DeviationThreshold <= 0 {
+		c.StablecoinMonitor.DeviationThreshold = defaultStablecoinDeviationThreshold
+	}
+
+	if c.StablecoinMonitor.CheckInterval <= 0 {
+		c.StablecoinMonitor.CheckInterval = defaultStablecoinCheckInterval
+	}
+}
+
+// CheckDustSweepConfig checks and if zero value assigns default values
+func (c *Config) CheckDustSweepConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.DustSweep.TargetCurrency.IsEmpty() {
+		c.DustSweep.TargetCurrency = currency.BTC
+	}
+
+	if c.DustSweep.ValueThreshold < 0 {
+		c.DustSweep.ValueThreshold = 0
+	}
+}
+
+// CheckArbitrageConfig checks and if zero value assigns default values
+func (c *Config) CheckArbitrageConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.Arbitrage.ScanInterval <= 0 {
+		c.Arbitrage.ScanInterval = defaultArbitrageScanInterval
+	}
+
+	if len(c.Arbitrage.StartCurrencies) == 0 {
+		c.Arbitrage.StartCurrencies = currency.NewCurrenciesFromStringArray(defaultArbitrageStart)
+	}
+
+	if c.Arbitrage.MinProfit < 0 {
+		c.Arbitrage.MinProfit = 0
+	}
+
+	if len(c.Arbitrage.TradeAmounts) == 0 {
+		return
+	}
+
+	amounts := make(map[string]float64, len(c.Arbitrage.TradeAmounts))
+	for code, amount := range c.Arbitrage.TradeAmounts {
+		if amount <= 0 {
+			log.Warnf("Arbitrage %s trade amount %f invalid, removing", code, amount)
+			continue
+		}
+		amounts[common.StringToUpper(code)] = amount
+	}
+	c.Arbitrage.TradeAmounts = amounts
+}
+
+// CheckHeartbeatConfig checks and if zero value assigns default values,
+// removing any invalid URLs
+func (c *Config) CheckHeartbeatConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.Heartbeat.Interval <= 0 {
+		c.Heartbeat.Interval = defaultHeartbeatInterval
+	}
+
+	var urls []string
+	for x := range c.Heartbeat.URLs {
+		u, err := url.Parse(c.Heartbeat.URLs[x])
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			log.Warnf("Heartbeat URL %s invalid, removing", c.Heartbeat.URLs[x])
+			continue
+		}
+		urls = append(urls, c.Heartbeat.URLs[x])
+	}
+	c.Heartbeat.URLs = urls
+
+	if c.Heartbeat.Enabled && len(c.Heartbeat.URLs) == 0 {
+		log.Warnf("Heartbeat enabled with no valid URLs configured, disabling")
+		c.Heartbeat.Enabled = false
+	}
+}
+
+// CheckStatePersistenceConfig checks and if zero value assigns default values
+func (c *Config) CheckStatePersistenceConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.StatePersistence.SaveInterval <= 0 {
+		c.StatePersistence.SaveInterval = defaultStateSaveInterval
+	}
+
+	if c.StatePersistence.MaxAge <= 0 {
+		c.StatePersistence.MaxAge = defaultStateMaxAge
+	}
+}
+
+// CheckSchedulerConfig removes scheduled tasks with a missing task, invalid
+// schedule or duplicate name
+func (c *Config) CheckSchedulerConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	var tasks []ScheduledTaskConfig
+	names := make(map[string]bool)
+	for x := range c.Scheduler.Tasks {
+		task := c.Scheduler.Tasks[x]
+		task.Task = common.StringToLower(task.Task)
+		if task.Task == "" {
+			log.Warnf("Scheduled task #%d has no task set, removing", x)
+			continue
+		}
+		if task.Name == "" {
+			task.Name = task.Task
+		}
+		if _, err := cron.Parse(task.Schedule); err != nil {
+			log.Warnf("Scheduled task %s %s, removing", task.Name, err)
+			continue
+		}
+		if names[common.StringToLower(task.Name)] {
+			log.Warnf("Scheduled task %s is a duplicate, removing", task.Name)
+			continue
+		}
+		names[common.StringToLower(task.Name)] = true
+		tasks = append(tasks, task)
+	}
+	c.Scheduler.Tasks = tasks
+
+	if c.Scheduler.Enabled && len(c.Scheduler.Tasks) == 0 {
+		log.Warnf("Scheduler enabled with no valid tasks configured, disabling")
+		c.Scheduler.Enabled = false
+	}
+}
+
+// CheckSummaryReportConfig checks and if zero value assigns default values,
+// reporting in the fiat display currency unless a currency is set
+func (c *Config) CheckSummaryReportConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.SummaryReport.Currency.IsEmpty() {
+		c.SummaryReport.Currency = c.Currency.FiatDisplayCurrency
+	}
+
+	if c.SummaryReport.Currency.IsEmpty() {
+		c.SummaryReport.Currency = currency.USD
+	}
+
+	if c.SummaryReport.TopMovers <= 0 {
+		c.SummaryReport.TopMovers = defaultSummaryReportTopMovers
+	}
+
+	if c.SummaryReport.MaxEvents <= 0 {
+		c.SummaryReport.MaxEvents = defaultSummaryReportMaxEvents
+	}
+
+	if c.SummaryReport.MinWithdrawalFeeSaving <= 0 ||
+		c.SummaryReport.MinWithdrawalFeeSaving > 100 {
+		c.SummaryReport.MinWithdrawalFeeSaving = defaultSummaryReportMinFeeSaving
+	}
+}
+
+// CheckOrderQueueConfig checks and if zero value assigns default values
+func (c *Config) CheckOrderQueueConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.OrderQueue.Expiry <= 0 {
+		c.OrderQueue.Expiry = defaultOrderQueueExpiry
+	}
+
+	if c.OrderQueue.CheckInterval <= 0 {
+		c.OrderQueue.CheckInterval = defaultOrderQueueCheckInterval
+	}
+}
+
+// CheckTaskQueueConfig checks and if zero value assigns default values
+func (c *Config) CheckTaskQueueConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.TaskQueue.Capacity <= 0 {
+		c.TaskQueue.Capacity = defaultTaskQueueCapacity
+	}
+
+	if c.TaskQueue.Workers <= 0 {
+		c.TaskQueue.Workers = defaultTaskQueueWorkers
+	}
+
+	if c.TaskQueue.MaxTaskAge <= 0 {
+		c.TaskQueue.MaxTaskAge = defaultTaskQueueMaxTaskAge
+	}
+}
+
+// CheckOrderbookHistoryConfig checks and if zero value assigns default values
+func (c *Config) CheckOrderbookHistoryConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.OrderbookHistory.SnapshotInterval <= 0 {
+		c.OrderbookHistory.SnapshotInterval = defaultOrderbookHistorySnapshots
+	}
+}
+
+// CheckExpiryCalendarConfig checks and if zero value assigns default values
+func (c *Config) CheckExpiryCalendarConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.ExpiryCalendar.AlertLeadTime <= 0 {
+		c.ExpiryCalendar.AlertLeadTime = defaultExpiryCalendarAlertLeadTime
+	}
+	if c.ExpiryCalendar.RefreshInterval <= 0 {
+		c.ExpiryCalendar.RefreshInterval = defaultExpiryCalendarRefreshInterval
+	}
+}
+
+// CheckAutoRollConfig checks and if zero value assigns default values
+func (c *Config) CheckAutoRollConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.AutoRoll.LeadTime <= 0 {
+		c.AutoRoll.LeadTime = defaultAutoRollLeadTime
+	}
+}
+
+// CheckFuturesBasisConfig checks and if zero value assigns default values
+func (c *Config) CheckFuturesBasisConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.FuturesBasis.AnnualisedThreshold <= 0 {
+		c.FuturesBasis.AnnualisedThreshold = defaultFuturesBasisThreshold
+	}
+	if c.FuturesBasis.CheckInterval <= 0 {
+		c.FuturesBasis.CheckInterval = defaultFuturesBasisCheckInterval
+	}
+}
+
+// CheckVWAPConfig checks and if zero value assigns default values
+func (c *Config) CheckVWAPConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.VWAP.Window <= 0 {
+		c.VWAP.Window = defaultVWAPWindow
+	}
+}
+
+// CheckExposureLimitsConfig checks and if zero value assigns default values,
+// removing invalid and duplicate limits
+func (c *Config) CheckExposureLimitsConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.ExposureLimits.Currency.IsEmpty() {
+		c.ExposureLimits.Currency = c.Currency.FiatDisplayCurrency
+	}
+
+	if c.ExposureLimits.Currency.IsEmpty() {
+		c.ExposureLimits.Currency = currency.USD
+	}
+
+	if c.ExposureLimits.CheckInterval <= 0 {
+		c.ExposureLimits.CheckInterval = defaultExposureLimitsCheckInterval
+	}
+
+	var limits []ExposureLimitConfig
+	for x := range c.ExposureLimits.Limits {
+		l := c.ExposureLimits.Limits[x]
+		if l.Currency.IsEmpty() {
+			log.Warnf("Exposure limit #%d has no currency set, removing", x)
+			continue
+		}
+		if l.MaxValue < 0 || l.MaxPercent < 0 || l.MaxPercent > 100 ||
+			(l.MaxValue == 0 && l.MaxPercent == 0) {
+			log.Warnf("Exposure limit of %s has an invalid maximum, removing", l.Currency)
+			continue
+		}
+		var duplicate bool
+		for y := range limits {
+			if limits[y].Currency.Match(l.Currency) {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			log.Warnf("Exposure limit of %s is a duplicate, removing", l.Currency)
+			continue
+		}
+		limits = append(limits, l)
+	}
+	c.ExposureLimits.Limits = limits
+}
+
+// CheckMarketMakerConfig checks and if zero value assigns default values,
+// removing invalid and duplicate pairs. The order manager is enabled along with
+// the market maker as quotes are tracked through it
+func (c *Config) CheckMarketMakerConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.MarketMaker.RefreshInterval <= 0 {
+		c.MarketMaker.RefreshInterval = defaultMarketMakerRefreshInterval
+	}
+
+	var pairs []MarketMakerPairConfig
+	seen := make(map[string]bool)
+	for x := range c.MarketMaker.Pairs {
+		pair := c.MarketMaker.Pairs[x]
+		if pair.Exchange == "" || pair.Pair.IsEmpty() {
+			log.Warnf("Market maker pair #%d has no exchange or pair set, removing", x)
+			continue
+		}
+		key := common.StringToLower(pair.Exchange) + "|" + pair.Pair.Upper().String()
+		if seen[key] {
+			log.Warnf("Market maker %s %s pair is a duplicate, removing",
+				pair.Exchange, pair.Pair)
+			continue
+		}
+		if pair.SpreadPercent <= 0 || pair.OrderAmount <= 0 {
+			log.Warnf("Market maker %s %s pair spread and order amount must be positive, removing",
+				pair.Exchange, pair.Pair)
+			continue
+		}
+		if pair.MaxInventory < 0 || pair.SkewPercent < 0 {
+			log.Warnf("Market maker %s %s pair max inventory and skew cannot be negative, removing",
+				pair.Exchange, pair.Pair)
+			continue
+		}
+		if pair.RequoteThreshold <= 0 {
+			pair.RequoteThreshold = defaultMarketMakerRequoteThreshold
+		}
+		seen[key] = true
+		pairs = append(pairs, pair)
+	}
+	c.MarketMaker.Pairs = pairs
+
+	if c.MarketMaker.Enabled && !c.OrderManager.Enabled {
+		log.Warnf("Market maker requires the order manager, enabling it")
+		c.OrderManager.Enabled = true
+	}
+}
+
+// CheckDatabaseConfig checks and if zero value assigns default values,
+// disabling the database when its driver isn't supported
+func (c *Config) CheckDatabaseConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.Database.SyncInterval <= 0 {
+		c.Database.SyncInterval = defaultDatabaseSyncInterval
+	}
+
+	c.Database.Driver = common.StringToLower(c.Database.Driver)
+	switch c.Database.Driver {
+	case "":
+		c.Database.Driver = database.DBSQLite3
+		fallthrough
+	case database.DBSQLite3:
+		if c.Database.Database == "" {
+			c.Database.Database = defaultDatabaseSQLite3File
+		}
+	case database.DBPostgreSQL:
+		if c.Database.Host == "" {
+			c.Database.Host = defaultDatabasePostgresHost
+		}
+		if c.Database.Port == 0 {
+			c.Database.Port = defaultDatabasePostgresPort
+		}
+		if c.Database.SSLMode == "" {
+			c.Database.SSLMode = defaultDatabasePostgresSSLMode
+		}
+		if c.Database.Enabled && c.Database.Database == "" {
+			log.Warnf("Database enabled with no PostgreSQL database set, disabling")
+			c.Database.Enabled = false
+		}
+	default:
+		if c.Database.Enabled {
+			log.Warnf("Database driver %s unsupported, disabling", c.Database.Driver)
+			c.Database.Enabled = false
+		}
+	}
+}
+
+// CheckLatencyMonitorConfig checks and if zero value assigns default values,
+// removing thresholds without an exchange or any percentile set
+func (c *Config) CheckLatencyMonitorConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.LatencyMonitor.CheckInterval <= 0 {
+		c.LatencyMonitor.CheckInterval = defaultLatencyMonitorCheckInterval
+	}
+	if c.LatencyMonitor.Window <= 0 {
+		c.LatencyMonitor.Window = defaultLatencyMonitorWindow
+	}
+	if c.LatencyMonitor.MinSamples <= 0 {
+		c.LatencyMonitor.MinSamples = defaultLatencyMonitorMinSamples
+	}
+	if c.LatencyMonitor.P95 <= 0 {
+		c.LatencyMonitor.P95 = defaultLatencyMonitorP95
+	}
+	if c.LatencyMonitor.P99 <= 0 {
+		c.LatencyMonitor.P99 = defaultLatencyMonitorP99
+	}
+
+	var thresholds []LatencyThresholdConfig
+	for x := range c.LatencyMonitor.Thresholds {
+		threshold := c.LatencyMonitor.Thresholds[x]
+		if threshold.Exchange == "" {
+			log.Warnf("Latency threshold #%d has no exchange set, removing", x)
+			continue
+		}
+		if threshold.P50 < 0 || threshold.P95 < 0 || threshold.P99 < 0 {
+			log.Warnf("Latency threshold #%d percentiles cannot be negative, removing", x)
+			continue
+		}
+		if threshold.P50 == 0 && threshold.P95 == 0 && threshold.P99 == 0 {
+			log.Warnf("Latency threshold #%d has no percentile set, removing", x)
+			continue
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	c.LatencyMonitor.Thresholds = thresholds
+}
+
+// CheckLiquidationAlertsConfig checks and if zero value assigns default values
+func (c *Config) CheckLiquidationAlertsConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.LiquidationAlerts.MinValue <= 0 {
+		c.LiquidationAlerts.MinValue = defaultLiquidationAlertMinValue
+	}
+}
+
+// CheckStartupConfig checks and if zero value assigns default values
+func (c *Config) CheckStartupConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.Startup.Budget <= 0 {
+		c.Startup.Budget = defaultStartupBudget
+	}
+}
+
+// CheckOrderManagerConfig checks and if zero value assigns default values
+func (c *Config) CheckOrderManagerConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.OrderManager.PollInterval <= 0 {
+		c.OrderManager.PollInterval = defaultOrderManagerPollInterval
+	}
+}
+
+// CheckCircuitBreakerConfig checks and if zero value assigns default values,
+// disabling the circuit breaker when it has no thresholds set
+func (c *Config) CheckCircuitBreakerConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.CircuitBreaker.Window <= 0 {
+		c.CircuitBreaker.Window = defaultCircuitBreakerWindow
+	}
+
+	if c.CircuitBreaker.Cooldown <= 0 {
+		c.CircuitBreaker.Cooldown = defaultCircuitBreakerCooldown
+	}
+
+	if c.CircuitBreaker.MaxPriceMove < 0 {
+		c.CircuitBreaker.MaxPriceMove = 0
+	}
+
+	if c.CircuitBreaker.MaxSpread < 0 {
+		c.CircuitBreaker.MaxSpread = 0
+	}
+
+	if c.CircuitBreaker.Enabled && c.CircuitBreaker.MaxPriceMove == 0 &&
+		c.CircuitBreaker.MaxSpread == 0 {
+		log.Warnf("Circuit breaker enabled with no price move or spread threshold, disabling")
+		c.CircuitBreaker.Enabled = false
+	}
+}
+
+// CheckAnalyticsConfig checks and if zero value assigns default values,
+// removing invalid correlation alerts
+func (c *Config) CheckAnalyticsConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.Analytics.CandleInterval <= 0 {
+		c.Analytics.CandleInterval = defaultAnalyticsCandleInterval
+	}
+
+	if c.Analytics.Window < 2 {
+		c.Analytics.Window = defaultAnalyticsWindow
+	}
+
+	if c.Analytics.UpdateInterval <= 0 {
+		c.Analytics.UpdateInterval = defaultAnalyticsUpdateInterval
+	}
+
+	var alerts []CorrelationAlertConfig
+	for x := range c.Analytics.CorrelationAlerts {
+		alert := c.Analytics.CorrelationAlerts[x]
+		if alert.Exchange == "" || alert.PairA.IsEmpty() || alert.PairB.IsEmpty() ||
+			alert.PairA.Equal(alert.PairB) {
+			log.Warnf("Analytics correlation alert #%d requires an exchange and two pairs, removing", x)
+			continue
+		}
+		if alert.Threshold < -1 || alert.Threshold > 1 {
+			log.Warnf("Analytics correlation alert %s %s %s threshold %f outside -1 to 1, removing",
+				alert.Exchange, alert.PairA, alert.PairB, alert.Threshold)
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+	c.Analytics.CorrelationAlerts = alerts
+
+	var openInterestAlerts []OpenInterestAlertConfig
+	for x := range c.Analytics.OpenInterestAlerts {
+		alert := c.Analytics.OpenInterestAlerts[x]
+		if alert.Exchange == "" || alert.Pair.IsEmpty() {
+			log.Warnf("Analytics open interest alert #%d requires an exchange and pair, removing", x)
+			continue
+		}
+		if alert.ChangePercent < 0 {
+			log.Warnf("Analytics open interest alert %s %s change percent %f is negative, removing",
+				alert.Exchange, alert.Pair, alert.ChangePercent)
+			continue
+		}
+		openInterestAlerts = append(openInterestAlerts, alert)
+	}
+	c.Analytics.OpenInterestAlerts = openInterestAlerts
+}
+
+// CheckDataRetentionConfig checks and if zero value assigns default values,
+// negative retention days are treated as keeping data indefinitely
+func (c *Config) CheckDataRetentionConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.DataRetention.PruneInterval <= 0 {
+		c.DataRetention.PruneInterval = defaultDataRetentionPruneInterval
+	}
+
+	for _, days := range []*int{
+		&c.DataRetention.CandleDays,
+		&c.DataRetention.TradeDays,
+		&c.DataRetention.LogDays,
+	} {
+		if *days < 0 {
+			log.Warnf("Data retention days %d invalid, keeping data indefinitely", *days)
+			*days = 0
+		}
+	}
+}
+
+// CheckStrategyAllocationConfig removes strategy allocations with a missing
+// strategy or exchange and duplicates, and normalises their currency codes
+// dropping negative balances
+func (c *Config) CheckStrategyAllocationConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	var allocations []StrategyAllocationConfig
+	seen := make(map[string]bool)
+	for x := range c.StrategyAllocations {
+		a := c.StrategyAllocations[x]
+		a.Strategy = common.StringToLower(a.Strategy)
+		if a.Strategy == "" || a.Exchange == "" {
+			log.Warnf("Strategy allocation #%d has no strategy or exchange set, removing", x)
+			continue
+		}
+		key := a.Strategy + "|" + common.StringToLower(a.Exchange)
+		if seen[key] {
+			log.Warnf("Strategy %s allocation on %s is a duplicate, removing",
+				a.Strategy, a.Exchange)
+			continue
+		}
+		seen[key] = true
+
+		balances := make(map[string]float64, len(a.Balances))
+		for code, amount := range a.Balances {
+			if amount < 0 {
+				log.Warnf("Strategy %s %s allocation on %s is negative, removing",
+					a.Strategy, code, a.Exchange)
+				continue
+			}
+			balances[common.StringToUpper(code)] += amount
+		}
+		a.Balances = balances
+		allocations = append(allocations, a)
+	}
+	c.StrategyAllocations = allocations
+}
+
+// CheckStrategyFeedConfig removes strategy feeds with a missing strategy, pair
+// or exchange, an unsupported feed type and duplicates, and normalises their
+// strategy and feed names
+func (c *Config) CheckStrategyFeedConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	var feeds []StrategyFeedConfig
+	seen := make(map[string]bool)
+	for x := range c.StrategyFeeds {
+		f := c.StrategyFeeds[x]
+		f.Strategy = common.StringToLower(f.Strategy)
+		f.Feed = common.StringToLower(f.Feed)
+		if f.Strategy == "" || f.Exchange == "" || f.Pair.IsEmpty() {
+			log.Warnf("Strategy feed #%d has no strategy, pair or exchange set, removing", x)
+			continue
+		}
+		if f.Feed != StrategyFeedTicker && f.Feed != StrategyFeedOrderbook {
+			log.Warnf("Strategy %s feed type %s invalid, removing", f.Strategy, f.Feed)
+			continue
+		}
+		key := f.Strategy + "|" + f.Feed + "|" + f.Pair.Upper().String()
+		if seen[key] {
+			log.Warnf("Strategy %s %s %s feed is a duplicate, removing",
+				f.Strategy, f.Pair, f.Feed)
+			continue
+		}
+		seen[key] = true
+		feeds = append(feeds, f)
+	}
+	c.StrategyFeeds = feeds
+}
+
+// CheckOrderMirrorConfig removes order mirrors with a missing primary exchange
+// and duplicate primaries, along with mirror targets which are the primary,
+// duplicates or have no positive scale. Mirrors left without targets are
+// removed
+func (c *Config) CheckOrderMirrorConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	var mirrors []OrderMirrorConfig
+	seen := make(map[string]bool)
+	for x := range c.OrderMirrors {
+		mirror := c.OrderMirrors[x]
+		primary := common.StringToLower(mirror.Primary)
+		if primary == "" {
+			log.Warnf("Order mirror #%d has no primary exchange set, removing", x)
+			continue
+		}
+		if seen[primary] {
+			log.Warnf("Order mirror of %s is a duplicate, removing", mirror.Primary)
+			continue
+		}
+
+		var targets []OrderMirrorTargetConfig
+		seenTargets := make(map[string]bool)
+		for y := range mirror.Mirrors {
+			target := mirror.Mirrors[y]
+			name := common.StringToLower(target.Exchange)
+			if name == "" || name == primary || seenTargets[name] {
+				log.Warnf("Order mirror of %s target %q invalid or duplicate, removing",
+					mirror.Primary, target.Exchange)
+				continue
+			}
+			if target.Scale <= 0 {
+				log.Warnf("Order mirror of %s onto %s scale %v must be positive, removing",
+					mirror.Primary, target.Exchange, target.Scale)
+				continue
+			}
+			seenTargets[name] = true
+			targets = append(targets, target)
+		}
+		if len(targets) == 0 {
+			log.Warnf("Order mirror of %s has no valid targets, removing", mirror.Primary)
+			continue
+		}
+		seen[primary] = true
+		mirror.Mirrors = targets
+		mirrors = append(mirrors, mirror)
+	}
+	c.OrderMirrors = mirrors
+}
+
+// CheckWithdrawalAddressConfig removes withdrawal address book entries with a
+// missing currency or address and duplicates, and normalises their currency
+// codes
+func (c *Config) CheckWithdrawalAddressConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	var addresses []WithdrawalAddressConfig
+	seen := make(map[string]bool)
+	for x := range c.WithdrawalAddresses {
+		a := c.WithdrawalAddresses[x]
+		a.Currency = common.StringToUpper(a.Currency)
+		if a.Currency == "" || a.Address == "" {
+			log.Warnf("Withdrawal address #%d has no currency or address set, removing", x)
+			continue
+		}
+		key := common.StringToLower(a.Exchange) + "|" + a.Currency + "|" +
+			a.Address + "|" + a.Tag
+		if seen[key] {
+			log.Warnf("Withdrawal address %s %s is a duplicate, removing",
+				a.Currency, a.Address)
+			continue
+		}
+		seen[key] = true
+		addresses = append(addresses, a)
+	}
+	c.WithdrawalAddresses = addresses
+}
+
+// CheckLocaleConfig defaults the locale user facing messages are translated
+// into. Catalogs in the data directory are loaded after the config so
+// unsupported locales are reported when the locale is selected
+func (c *Config) CheckLocaleConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	c.Locale = common.StringToLower(c.Locale)
+	if c.Locale == "" {
+		c.Locale = i18n.DefaultLocale
+	}
+}
+
+// CheckDebugConfig defaults the payload size limit and sampling rate of the
+// engine and exchange verbose output. The deprecated exchange verbose flag
+// enables debug output and mirrors it for exchanges reading the flag
+func (c *Config) CheckDebugConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	checkDebugConfig(&c.Debug)
+	for x := range c.Exchanges {
+		if c.Exchanges[x].Verbose {
+			c.Exchanges[x].Debug.Enabled = true
+		}
+		checkDebugConfig(&c.Exchanges[x].Debug)
+		c.Exchanges[x].Verbose = c.Exchanges[x].Debug.Enabled
+	}
+}
+
+// checkDebugConfig defaults invalid debug settings
+func checkDebugConfig(d *DebugConfig) {
+	if d.MaxPayloadSize <= 0 {
+		d.MaxPayloadSize = log.DefaultMaxPayloadSize
+	}
+	if d.SampleRate <= 0 || d.SampleRate > 1 {
+		d.SampleRate = 1
+	}
+}
+
+// CheckPortfolioConfig removes invalid portfolio extended keys and defaults
+// their gap limits
+func (c *Config) CheckPortfolioConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	var keys []portfolio.ExtendedKey
+	for x := range c.Portfolio.ExtendedKeys {
+		k := c.Portfolio.ExtendedKeys[x]
+		if err := k.Validate(); err != nil {
+			log.Warnf("Portfolio %s, removing", err)
+			continue
+		}
+		keys = append(keys, k)
+	}
+	c.Portfolio.ExtendedKeys = keys
+}
+
+// CheckTokenRegistryConfig checks and if zero value assigns default values,
+// removing invalid tokens and an invalid remote token list URL
+func (c *Config) CheckTokenRegistryConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.TokenRegistry.UpdateInterval <= 0 {
+		c.TokenRegistry.UpdateInterval = defaultTokenListUpdateInterval
+	}
+
+	var tokens []portfolio.Token
+	for x := range c.TokenRegistry.Tokens {
+		t := c.TokenRegistry.Tokens[x]
+		if err := t.Validate(); err != nil {
+			log.Warnf("Token registry %s, removing", err)
+			continue
+		}
+		tokens = append(tokens, t)
+	}
+	c.TokenRegistry.Tokens = tokens
+
+	if c.TokenRegistry.RemoteListURL != "" {
+		u, err := url.Parse(c.TokenRegistry.RemoteListURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			log.Warnf("Token registry remote list URL %s invalid, removing",
+				c.TokenRegistry.RemoteListURL)
+			c.TokenRegistry.RemoteListURL = ""
+		}
+	}
+}
+
+// GetFilePath returns the desired config file or the default config file name
+// based on if the application is being run under test or normal mode.
+func GetFilePath(file string) (string, error) {
+	if file != "" {
+		return file, nil
+	}
+
+	if flag.Lookup("test.v") != nil && !testBypass {
+		return ConfigTestFile, nil
+	}
+
+	exePath, err := common.GetExecutablePath()
+	if err != nil {
+		return "", err
+	}
+
+	oldDir := exePath + common.GetOSPathSlash()
+	oldDirs := []string{oldDir + ConfigFile, oldDir + EncryptedConfigFile}
+
+	newDir := common.GetDefaultDataDir(runtime.GOOS) + common.GetOSPathSlash()
+	err = common.CreateDir(newDir)
+	if err != nil {
+		return "", err
+	}
+	newDirs := []string{newDir + ConfigFile, newDir + EncryptedConfigFile}
+
+	// First upgrade the old dir config file if it exists to the corresponding new one
+	for x := range oldDirs {
+		_, err := os.Stat(oldDirs[x])
+		if os.IsNotExist(err) {
+			continue
+		}
+		if filepath.Ext(oldDirs[x]) == ".json" {
+			err = os.Rename(oldDirs[x], newDirs[0])
+			if err != nil {
+				return "", err
+			}
+			log.Debugf("Renamed old config file %s to %s", oldDirs[x], newDirs[0])
+		} else {
+			err = os.Rename(oldDirs[x], newDirs[1])
+			if err != nil {
+				return "", err
+			}
+			log.Debugf("Renamed old config file %s to %s", oldDirs[x], newDirs[1])
+		}
+	}
+
+	// Secondly check to see if the new config file extension is correct or not
+	for x := range newDirs {
+		_, err := os.Stat(newDirs[x])
+		if os.IsNotExist(err) {
+			continue
+		}
+
+		data, err := common.ReadFile(newDirs[x])
+		if err != nil {
+			return "", err
 		}
 
 		if ConfirmECS(data) {
@@ -1379,7 +3137,40 @@ func (c *Config) CheckConfig() error {
 		return fmt.Errorf(ErrCheckingConfigValues, err)
 	}
 
+	c.CheckLocaleConfig()
+	c.CheckDebugConfig()
 	c.CheckConnectionMonitorConfig()
+	c.CheckKlineStorageConfig()
+	c.CheckNewsConfig()
+	c.CheckStablecoinMonitorConfig()
+	c.CheckDustSweepConfig()
+	c.CheckArbitrageConfig()
+	c.CheckHeartbeatConfig()
+	c.CheckStatePersistenceConfig()
+	c.CheckSchedulerConfig()
+	c.CheckOrderQueueConfig()
+	c.CheckTaskQueueConfig()
+	c.CheckOrderbookHistoryConfig()
+	c.CheckExpiryCalendarConfig()
+	c.CheckAutoRollConfig()
+	c.CheckFuturesBasisConfig()
+	c.CheckExposureLimitsConfig()
+	c.CheckOrderManagerConfig()
+	c.CheckMarketMakerConfig()
+	c.CheckDatabaseConfig()
+	c.CheckLatencyMonitorConfig()
+	c.CheckLiquidationAlertsConfig()
+	c.CheckStartupConfig()
+	c.CheckVWAPConfig()
+	c.CheckCircuitBreakerConfig()
+	c.CheckAnalyticsConfig()
+	c.CheckDataRetentionConfig()
+	c.CheckStrategyAllocationConfig()
+	c.CheckWithdrawalAddressConfig()
+	c.CheckStrategyFeedConfig()
+	c.CheckOrderMirrorConfig()
+	c.CheckPortfolioConfig()
+	c.CheckTokenRegistryConfig()
 	c.CheckCommunicationsConfig()
 
 	if c.Webserver.Enabled {
@@ -1395,6 +3186,9 @@ func (c *Config) CheckConfig() error {
 		return err
 	}
 
+	// Defaults to the fiat display currency so is checked after it
+	c.CheckSummaryReportConfig()
+
 	if c.GlobalHTTPTimeout <= 0 {
 		log.Warnf("Global HTTP Timeout value not set, defaulting to %v.", configDefaultHTTPTimeout)
 		c.GlobalHTTPTimeout = configDefaultHTTPTimeout
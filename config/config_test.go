@@ -1,13 +1,20 @@
 package config
 
 import (
+	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/database"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/i18n"
 	log "github.com/thrasher-/gocryptotrader/logger"
+	"github.com/thrasher-/gocryptotrader/news"
 	"github.com/thrasher-/gocryptotrader/ntpclient"
+	"github.com/thrasher-/gocryptotrader/portfolio"
 )
 
 const (
@@ -291,6 +298,94 @@ func TestCheckCommunicationsConfig(t *testing.T) {
 	}
 }
 
+func TestValidateURL(t *testing.T) {
+	err := ValidateURL("https://api.bitstamp.net", "http", "https")
+	if err != nil {
+		t.Error("Test failed. ValidateURL error:", err)
+	}
+
+	for _, u := range []string{
+		"api.bitstamp.net",
+		"wss://api.bitstamp.net",
+		"https://",
+		"https://api.bitstamp.net:port",
+	} {
+		if ValidateURL(u, "http", "https") == nil {
+			t.Errorf("Test failed. ValidateURL accepted invalid URL %s", u)
+		}
+	}
+}
+
+func TestCheckURLOverrides(t *testing.T) {
+	exch := ExchangeConfig{
+		Name:            "Bitstamp",
+		APIURL:          APIURLNonDefaultMessage,
+		APIURLSecondary: APIURLNonDefaultMessage,
+		WebsocketURL:    WebsocketURLNonDefaultMessage,
+	}
+	err := exch.CheckURLOverrides()
+	if err != nil {
+		t.Error("Test failed. CheckURLOverrides error with default URLs:", err)
+	}
+
+	exch.WebsocketURL = "https://ws.bitstamp.net"
+	err = exch.CheckURLOverrides()
+	if err == nil {
+		t.Error("Test failed. CheckURLOverrides accepted a websocket URL with a HTTP scheme")
+	}
+
+	exch.WebsocketURL = "wss://ws.bitstamp.net"
+	exch.APIURL = "https://www.bitstamp.net/api"
+	err = exch.CheckURLOverrides()
+	if err != nil {
+		t.Error("Test failed. CheckURLOverrides error:", err)
+	}
+}
+
+func TestFilterPairs(t *testing.T) {
+	exch := ExchangeConfig{
+		PairBlacklist: currency.NewPairsFromStrings([]string{"DOGE_USD"}),
+	}
+	pairs := currency.NewPairsFromStrings([]string{"BTC_USD", "DOGE_USD", "LTC_USD"})
+
+	allowed, excluded := exch.FilterPairs(pairs)
+	if len(allowed) != 2 || len(excluded) != 1 ||
+		excluded[0].String() != "DOGE_USD" {
+		t.Errorf("Test failed. FilterPairs blacklist returned %s %s",
+			allowed, excluded)
+	}
+
+	exch.PairWhitelist = currency.NewPairsFromStrings([]string{"BTC_USD", "DOGE_USD"})
+	allowed, excluded = exch.FilterPairs(pairs)
+	if len(allowed) != 1 || allowed[0].String() != "BTC_USD" ||
+		len(excluded) != 2 {
+		t.Errorf("Test failed. FilterPairs whitelist returned %s %s",
+			allowed, excluded)
+	}
+}
+
+func TestCheckExchangePairFilters(t *testing.T) {
+	var c Config
+	exch := ExchangeConfig{
+		Name:           "TestExchange",
+		AvailablePairs: currency.NewPairsFromStrings([]string{"BTC_USD", "DOGE_USD", "LTC_USD"}),
+		EnabledPairs:   currency.NewPairsFromStrings([]string{"DOGE_USD"}),
+		PairBlacklist:  currency.NewPairsFromStrings([]string{"DOGE_USD"}),
+	}
+
+	c.checkExchangePairFilters(&exch)
+	if len(exch.AvailablePairs) != 2 ||
+		exch.AvailablePairs.Contains(currency.NewPairFromString("DOGE_USD"), true) {
+		t.Errorf("Test failed. Expected blacklisted pair to be removed from available pairs, got %s",
+			exch.AvailablePairs)
+	}
+	if len(exch.EnabledPairs) != 1 ||
+		!exch.AvailablePairs.Contains(exch.EnabledPairs[0], true) {
+		t.Errorf("Test failed. Expected an allowed pair to be enabled, got %s",
+			exch.EnabledPairs)
+	}
+}
+
 func TestCheckPairConsistency(t *testing.T) {
 	cfg := GetConfig()
 	err := cfg.LoadConfig(ConfigTestFile)
@@ -670,6 +765,12 @@ func TestCheckExchangeConfigValues(t *testing.T) {
 		t.Fatalf("Test failed. Expected exchange %s to have updated HTTPTimeout value", checkExchangeConfigValues.Exchanges[0].Name)
 	}
 
+	checkExchangeConfigValues.Exchanges[0].AccountInfoCacheTTL = 0
+	checkExchangeConfigValues.CheckExchangeConfigValues()
+	if checkExchangeConfigValues.Exchanges[0].AccountInfoCacheTTL != configDefaultAccountInfoCacheTTL {
+		t.Fatalf("Test failed. Expected exchange %s to have updated AccountInfoCacheTTL value", checkExchangeConfigValues.Exchanges[0].Name)
+	}
+
 	checkExchangeConfigValues.Exchanges[0].APIKey = "Key"
 	checkExchangeConfigValues.Exchanges[0].APISecret = "Secret"
 	checkExchangeConfigValues.Exchanges[0].AuthenticatedAPISupport = true
@@ -781,6 +882,14 @@ func TestCheckWebserverConfigValues(t *testing.T) {
 		)
 	}
 
+	checkWebserverConfigValues.Webserver.WebsocketRequestRateLimit = 0
+	checkWebserverConfigValues.CheckWebserverConfigValues()
+	if checkWebserverConfigValues.Webserver.WebsocketRequestRateLimit != 10 {
+		t.Error(
+			"Test failed. checkWebserverConfigValues.CheckWebserverConfigValues error",
+		)
+	}
+
 	checkWebserverConfigValues.Webserver.ListenAddress = ":0"
 	err = checkWebserverConfigValues.CheckWebserverConfigValues()
 	if err == nil {
@@ -1027,3 +1136,908 @@ func TestCheckNTPConfig(t *testing.T) {
 		t.Error("ntpclient with nil allowednegativedifference should default to sane value")
 	}
 }
+
+func TestCheckKlineStorageConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.KlineStorage.MaintenanceInterval = 0
+	c.KlineStorage.RetentionPolicies = nil
+
+	c.CheckKlineStorageConfig()
+	if c.KlineStorage.MaintenanceInterval != kline.DefaultMaintenanceInterval {
+		t.Error("kline storage with no maintenance interval should default to sane value")
+	}
+
+	if len(c.KlineStorage.RetentionPolicies) != len(kline.DefaultRetentionPolicies) {
+		t.Error("kline storage with no retention policies should default to sane values")
+	}
+
+	c.KlineStorage.TradeIntervals = []time.Duration{time.Minute, 0, time.Minute, time.Hour}
+	c.CheckKlineStorageConfig()
+	if len(c.KlineStorage.TradeIntervals) != 2 ||
+		c.KlineStorage.TradeIntervals[1] != time.Hour {
+		t.Error("kline storage trade intervals should drop invalid and duplicate intervals")
+	}
+}
+
+func TestCheckNewsConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.News.Enabled = true
+	c.News.PollInterval = 0
+	c.News.Feeds = []news.Feed{{Exchange: "Binance"}}
+
+	c.CheckNewsConfig()
+	if c.News.PollInterval != news.DefaultPollInterval {
+		t.Error("news with no poll interval should default to sane value")
+	}
+
+	if len(c.News.Feeds) != 0 || c.News.Enabled {
+		t.Error("news with no valid feeds should be disabled")
+	}
+}
+
+func TestCheckStablecoinMonitorConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.StablecoinMonitor.Pairs = nil
+	c.StablecoinMonitor.DeviationThreshold = 0
+	c.StablecoinMonitor.CheckInterval = 0
+
+	c.CheckStablecoinMonitorConfig()
+	if len(c.StablecoinMonitor.Pairs) != len(defaultStablecoinPairs) {
+		t.Error("stablecoin monitor with no pairs should default to sane values")
+	}
+
+	if c.StablecoinMonitor.DeviationThreshold != defaultStablecoinDeviationThreshold {
+		t.Error("stablecoin monitor with no deviation threshold should default to sane value")
+	}
+
+	if c.StablecoinMonitor.CheckInterval != defaultStablecoinCheckInterval {
+		t.Error("stablecoin monitor with no check interval should default to sane value")
+	}
+}
+
+func TestCheckDustSweepConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.DustSweep = DustSweepConfig{ValueThreshold: -1}
+	c.CheckDustSweepConfig()
+	if !c.DustSweep.TargetCurrency.Match(currency.BTC) {
+		t.Error("dust sweep with no target currency should default to sane value")
+	}
+
+	if c.DustSweep.ValueThreshold != 0 {
+		t.Error("dust sweep with negative value threshold should default to sane value")
+	}
+}
+
+func TestCheckArbitrageConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.Arbitrage = ArbitrageConfig{
+		MinProfit:    -1,
+		TradeAmounts: map[string]float64{"btc": 0.1, "ETH": 0},
+	}
+	c.CheckArbitrageConfig()
+	if c.Arbitrage.ScanInterval != defaultArbitrageScanInterval {
+		t.Error("arbitrage with no scan interval should default to sane value")
+	}
+
+	if len(c.Arbitrage.StartCurrencies) != len(defaultArbitrageStart) {
+		t.Error("arbitrage with no start currencies should default to sane values")
+	}
+
+	if c.Arbitrage.MinProfit != 0 {
+		t.Error("arbitrage with negative minimum profit should default to sane value")
+	}
+
+	if len(c.Arbitrage.TradeAmounts) != 1 || c.Arbitrage.TradeAmounts["BTC"] != 0.1 {
+		t.Errorf("arbitrage trade amounts should be normalised, got %v",
+			c.Arbitrage.TradeAmounts)
+	}
+}
+
+func TestCheckHeartbeatConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.Heartbeat = HeartbeatConfig{
+		Enabled: true,
+		URLs:    []string{"https://hc-ping.com/uuid", "ftp://host", "invalid"},
+	}
+	c.CheckHeartbeatConfig()
+	if c.Heartbeat.Interval != defaultHeartbeatInterval {
+		t.Error("heartbeat with no interval should default to sane value")
+	}
+
+	if len(c.Heartbeat.URLs) != 1 || c.Heartbeat.URLs[0] != "https://hc-ping.com/uuid" {
+		t.Errorf("heartbeat invalid URLs should be removed, got %v", c.Heartbeat.URLs)
+	}
+
+	if !c.Heartbeat.Enabled {
+		t.Error("heartbeat with a valid URL should remain enabled")
+	}
+
+	c.Heartbeat.URLs = []string{"invalid"}
+	c.CheckHeartbeatConfig()
+	if c.Heartbeat.Enabled {
+		t.Error("heartbeat with no valid URLs should be disabled")
+	}
+}
+
+func TestCheckStatePersistenceConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.StatePersistence = StatePersistenceConfig{SaveInterval: -1}
+	c.CheckStatePersistenceConfig()
+	if c.StatePersistence.SaveInterval != defaultStateSaveInterval {
+		t.Error("state persistence with invalid save interval should default to sane value")
+	}
+
+	if c.StatePersistence.MaxAge != defaultStateMaxAge {
+		t.Error("state persistence with no max age should default to sane value")
+	}
+}
+
+func TestCheckOrderQueueConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.OrderQueue = OrderQueueConfig{Enabled: true, Expiry: -1}
+	c.CheckOrderQueueConfig()
+	if c.OrderQueue.Expiry != defaultOrderQueueExpiry {
+		t.Error("order queue with invalid expiry should default to sane value")
+	}
+
+	if c.OrderQueue.CheckInterval != defaultOrderQueueCheckInterval {
+		t.Error("order queue with no check interval should default to sane value")
+	}
+}
+
+func TestCheckTaskQueueConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.TaskQueue = TaskQueueConfig{Capacity: -1, Workers: 2}
+	c.CheckTaskQueueConfig()
+	if c.TaskQueue.Capacity != defaultTaskQueueCapacity ||
+		c.TaskQueue.MaxTaskAge != defaultTaskQueueMaxTaskAge {
+		t.Error("task queue with invalid capacity or no max task age should default to sane values")
+	}
+
+	if c.TaskQueue.Workers != 2 {
+		t.Error("task queue workers should be left unchanged")
+	}
+}
+
+func TestCheckOrderbookHistoryConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.OrderbookHistory = OrderbookHistoryConfig{SnapshotInterval: -1}
+	c.CheckOrderbookHistoryConfig()
+	if c.OrderbookHistory.SnapshotInterval != defaultOrderbookHistorySnapshots {
+		t.Error("orderbook history with invalid snapshot interval should default to sane value")
+	}
+}
+
+func TestCheckExpiryCalendarConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.ExpiryCalendar = ExpiryCalendarConfig{AlertLeadTime: -1}
+	c.CheckExpiryCalendarConfig()
+	if c.ExpiryCalendar.AlertLeadTime != defaultExpiryCalendarAlertLeadTime ||
+		c.ExpiryCalendar.RefreshInterval != defaultExpiryCalendarRefreshInterval {
+		t.Error("expiry calendar with invalid durations should default to sane values")
+	}
+}
+
+func TestCheckAutoRollConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.AutoRoll = AutoRollConfig{LeadTime: -1}
+	c.CheckAutoRollConfig()
+	if c.AutoRoll.LeadTime != defaultAutoRollLeadTime {
+		t.Error("auto roll with invalid lead time should default to sane value")
+	}
+}
+
+func TestCheckFuturesBasisConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.FuturesBasis = FuturesBasisConfig{AnnualisedThreshold: -1}
+	c.CheckFuturesBasisConfig()
+	if c.FuturesBasis.AnnualisedThreshold != defaultFuturesBasisThreshold ||
+		c.FuturesBasis.CheckInterval != defaultFuturesBasisCheckInterval {
+		t.Error("futures basis with invalid values should default to sane values")
+	}
+}
+
+func TestCheckVWAPConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.VWAP = VWAPConfig{Window: -1}
+	c.CheckVWAPConfig()
+	if c.VWAP.Window != defaultVWAPWindow {
+		t.Error("vwap with invalid values should default to sane values")
+	}
+}
+
+func TestCheckExposureLimitsConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.ExposureLimits = ExposureLimitsConfig{
+		Limits: []ExposureLimitConfig{
+			{Currency: currency.BTC, MaxPercent: 50},
+			{Currency: currency.BTC, MaxValue: 1000},
+			{Currency: currency.ETH},
+			{Currency: currency.LTC, MaxPercent: 101},
+			{MaxValue: 1000},
+		},
+	}
+	c.CheckExposureLimitsConfig()
+	if c.ExposureLimits.Currency.IsEmpty() ||
+		c.ExposureLimits.CheckInterval != defaultExposureLimitsCheckInterval {
+		t.Error("exposure limits with invalid values should default to sane values")
+	}
+	if len(c.ExposureLimits.Limits) != 1 || c.ExposureLimits.Limits[0].MaxPercent != 50 {
+		t.Errorf("invalid and duplicate exposure limits should be removed, got %+v",
+			c.ExposureLimits.Limits)
+	}
+}
+
+func TestCheckOrderManagerConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.OrderManager = OrderManagerConfig{Enabled: true, PollInterval: -1}
+	c.CheckOrderManagerConfig()
+	if c.OrderManager.PollInterval != defaultOrderManagerPollInterval {
+		t.Error("order manager with invalid poll interval should default to sane value")
+	}
+}
+
+func TestCheckStartupConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.Startup = StartupConfig{Budget: -1}
+	c.CheckStartupConfig()
+	if c.Startup.Budget != defaultStartupBudget {
+		t.Error("startup with invalid budget should default to sane value")
+	}
+}
+
+func TestCheckMarketMakerConfig(t *testing.T) {
+	c := GetConfig()
+
+	p := currency.NewPairFromStrings("BTC", "USD")
+	c.OrderManager.Enabled = false
+	c.MarketMaker = MarketMakerConfig{
+		Enabled: true,
+		Pairs: []MarketMakerPairConfig{
+			{Exchange: "Bitstamp", Pair: p, SpreadPercent: 0.5, OrderAmount: 0.01},
+			{Exchange: "bitstamp", Pair: p, SpreadPercent: 1, OrderAmount: 0.01},
+			{Exchange: "Bitfinex", Pair: p, OrderAmount: 0.01},
+			{Exchange: "Kraken", Pair: p, SpreadPercent: 0.5, OrderAmount: 0.01, SkewPercent: -1},
+			{Pair: p, SpreadPercent: 0.5, OrderAmount: 0.01},
+		},
+	}
+	c.CheckMarketMakerConfig()
+	if c.MarketMaker.RefreshInterval != defaultMarketMakerRefreshInterval {
+		t.Error("market maker with no refresh interval should default to sane value")
+	}
+	if len(c.MarketMaker.Pairs) != 1 || c.MarketMaker.Pairs[0].SpreadPercent != 0.5 ||
+		c.MarketMaker.Pairs[0].RequoteThreshold != defaultMarketMakerRequoteThreshold {
+		t.Errorf("invalid and duplicate market maker pairs should be removed, got %+v",
+			c.MarketMaker.Pairs)
+	}
+	if !c.OrderManager.Enabled {
+		t.Error("market maker should enable the order manager")
+	}
+	c.OrderManager.Enabled = false
+	c.MarketMaker = MarketMakerConfig{}
+}
+
+func TestCheckDatabaseConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.Database = DatabaseConfig{Enabled: true}
+	c.CheckDatabaseConfig()
+	if c.Database.SyncInterval != defaultDatabaseSyncInterval ||
+		c.Database.Driver != database.DBSQLite3 ||
+		c.Database.Database != defaultDatabaseSQLite3File {
+		t.Errorf("database with no driver should default to SQLite, got %+v", c.Database)
+	}
+
+	c.Database = DatabaseConfig{Enabled: true, Driver: "Postgres", Database: "gct"}
+	c.CheckDatabaseConfig()
+	if !c.Database.Enabled || c.Database.Driver != database.DBPostgreSQL ||
+		c.Database.Port != defaultDatabasePostgresPort ||
+		c.Database.Host != defaultDatabasePostgresHost {
+		t.Errorf("postgres database should default to sane values, got %+v", c.Database)
+	}
+
+	c.Database = DatabaseConfig{Enabled: true, Driver: database.DBPostgreSQL}
+	c.CheckDatabaseConfig()
+	if c.Database.Enabled {
+		t.Error("postgres database with no database name should be disabled")
+	}
+
+	c.Database = DatabaseConfig{Enabled: true, Driver: "mysql"}
+	c.CheckDatabaseConfig()
+	if c.Database.Enabled {
+		t.Error("database with an unsupported driver should be disabled")
+	}
+	c.Database = DatabaseConfig{}
+}
+
+func TestCheckLiquidationAlertsConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.LiquidationAlerts = LiquidationAlertConfig{Enabled: true}
+	c.CheckLiquidationAlertsConfig()
+	if c.LiquidationAlerts.MinValue != defaultLiquidationAlertMinValue {
+		t.Errorf("liquidation alerts should default to a sane value, got %v",
+			c.LiquidationAlerts.MinValue)
+	}
+	c.LiquidationAlerts = LiquidationAlertConfig{}
+}
+
+func TestCheckLatencyMonitorConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.LatencyMonitor = LatencyMonitorConfig{
+		Enabled: true,
+		Thresholds: []LatencyThresholdConfig{
+			{Exchange: "Bitstamp", Endpoint: "/api/v2/order", P95: time.Second},
+			{Endpoint: "/api/v2/order", P95: time.Second},
+			{Exchange: "Bitstamp", P95: -time.Second},
+			{Exchange: "Bitstamp"},
+		},
+	}
+	c.CheckLatencyMonitorConfig()
+	if c.LatencyMonitor.CheckInterval != defaultLatencyMonitorCheckInterval ||
+		c.LatencyMonitor.Window != defaultLatencyMonitorWindow ||
+		c.LatencyMonitor.MinSamples != defaultLatencyMonitorMinSamples ||
+		c.LatencyMonitor.P95 != defaultLatencyMonitorP95 ||
+		c.LatencyMonitor.P99 != defaultLatencyMonitorP99 {
+		t.Errorf("latency monitor should default to sane values, got %+v", c.LatencyMonitor)
+	}
+	if len(c.LatencyMonitor.Thresholds) != 1 ||
+		c.LatencyMonitor.Thresholds[0].Endpoint != "/api/v2/order" {
+		t.Errorf("invalid latency thresholds should be removed, got %+v",
+			c.LatencyMonitor.Thresholds)
+	}
+	c.LatencyMonitor = LatencyMonitorConfig{}
+}
+
+func TestCheckDebugConfig(t *testing.T) {
+	c := GetConfig()
+	c.LoadConfig(ConfigTestFile)
+
+	c.Debug = DebugConfig{SampleRate: 2}
+	c.Exchanges[0].Verbose = true
+	c.Exchanges[0].Debug = DebugConfig{MaxPayloadSize: -1}
+	c.CheckDebugConfig()
+	if c.Debug.MaxPayloadSize != log.DefaultMaxPayloadSize || c.Debug.SampleRate != 1 {
+		t.Error("debug config with invalid values should default to sane values")
+	}
+	if !c.Exchanges[0].Debug.Enabled || !c.Exchanges[0].Verbose ||
+		c.Exchanges[0].Debug.MaxPayloadSize != log.DefaultMaxPayloadSize {
+		t.Error("exchange verbose flag should enable exchange debug output")
+	}
+
+	c.Debug.Enabled = true
+	c.Debug.Subsystems = []string{"websocket"}
+	if !c.Debug.SubsystemEnabled("WEBSOCKET") || c.Debug.SubsystemEnabled("currency") {
+		t.Error("debug output should be limited to the configured subsystems")
+	}
+}
+
+func TestCheckExchangeConfigValuesOTPSecret(t *testing.T) {
+	c := GetConfig()
+	err := c.LoadConfig(ConfigTestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Exchanges[0].Enabled = true
+	c.Exchanges[0].AuthenticatedAPISupport = true
+	c.Exchanges[0].APIKey = "key"
+	c.Exchanges[0].APISecret = "secret"
+	c.Exchanges[0].ClientID = "clientID"
+	c.Exchanges[0].OTPSecret = "GEZDGNBVGY3TQOJQ"
+	err = c.CheckExchangeConfigValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Exchanges[0].OTPSecret == "" {
+		t.Error("a valid OTP secret should be kept")
+	}
+
+	c.Exchanges[0].OTPSecret = "not base32!"
+	err = c.CheckExchangeConfigValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Exchanges[0].OTPSecret != "" {
+		t.Error("an invalid OTP secret should be ignored")
+	}
+}
+
+func TestCheckCircuitBreakerConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.CircuitBreaker = CircuitBreakerConfig{Enabled: true, MaxPriceMove: 5, MaxSpread: -1}
+	c.CheckCircuitBreakerConfig()
+	if c.CircuitBreaker.Window != defaultCircuitBreakerWindow ||
+		c.CircuitBreaker.Cooldown != defaultCircuitBreakerCooldown {
+		t.Error("circuit breaker with no window or cooldown should default to sane values")
+	}
+
+	if c.CircuitBreaker.MaxSpread != 0 || !c.CircuitBreaker.Enabled {
+		t.Error("circuit breaker negative threshold should be disabled, leaving the breaker enabled")
+	}
+
+	c.CircuitBreaker.MaxPriceMove = 0
+	c.CheckCircuitBreakerConfig()
+	if c.CircuitBreaker.Enabled {
+		t.Error("circuit breaker with no thresholds should be disabled")
+	}
+}
+
+func TestCheckAnalyticsConfig(t *testing.T) {
+	c := GetConfig()
+
+	btc := currency.NewPairFromStrings("BTC", "USD")
+	eth := currency.NewPairFromStrings("ETH", "USD")
+	c.Analytics = AnalyticsConfig{
+		Window: 1,
+		CorrelationAlerts: []CorrelationAlertConfig{
+			{Exchange: "Bitfinex", PairA: btc, PairB: eth, Threshold: 0.5},
+			{Exchange: "Bitfinex", PairA: btc, PairB: btc, Threshold: 0.5},
+			{PairA: btc, PairB: eth},
+			{Exchange: "Bitfinex", PairA: btc, PairB: eth, Threshold: 2},
+		},
+		OpenInterestAlerts: []OpenInterestAlertConfig{
+			{Exchange: "Bitmex", Pair: btc, ChangePercent: 5},
+			{Exchange: "Bitmex", Pair: eth},
+			{Pair: btc, ChangePercent: 5},
+			{Exchange: "Bitmex", Pair: btc, ChangePercent: -1},
+		},
+	}
+	c.CheckAnalyticsConfig()
+	if c.Analytics.CandleInterval != defaultAnalyticsCandleInterval ||
+		c.Analytics.Window != defaultAnalyticsWindow ||
+		c.Analytics.UpdateInterval != defaultAnalyticsUpdateInterval {
+		t.Error("analytics with zero values should default to sane values")
+	}
+
+	if len(c.Analytics.CorrelationAlerts) != 1 {
+		t.Errorf("analytics should remove invalid correlation alerts, got %v",
+			c.Analytics.CorrelationAlerts)
+	}
+
+	if len(c.Analytics.OpenInterestAlerts) != 2 {
+		t.Errorf("analytics should remove invalid open interest alerts, got %v",
+			c.Analytics.OpenInterestAlerts)
+	}
+}
+
+func TestCheckDataRetentionConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.DataRetention = DataRetentionConfig{CandleDays: 30, TradeDays: -1}
+	c.CheckDataRetentionConfig()
+	if c.DataRetention.PruneInterval != defaultDataRetentionPruneInterval {
+		t.Error("data retention with zero values should default to sane values")
+	}
+	if c.DataRetention.CandleDays != 30 || c.DataRetention.TradeDays != 0 {
+		t.Errorf("data retention should reset negative days, got %+v",
+			c.DataRetention)
+	}
+}
+
+func TestCheckStrategyAllocationConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.StrategyAllocations = []StrategyAllocationConfig{
+		{Strategy: "Arbitrage", Exchange: "Bitfinex", Balances: map[string]float64{"usd": 100, "btc": -1}},
+		{Strategy: "arbitrage", Exchange: "bitfinex"},
+		{Exchange: "Bitfinex"},
+		{Strategy: "grid"},
+	}
+	c.CheckStrategyAllocationConfig()
+	if len(c.StrategyAllocations) != 1 {
+		t.Fatalf("invalid and duplicate strategy allocations should be removed, got %v",
+			c.StrategyAllocations)
+	}
+
+	a := c.StrategyAllocations[0]
+	if a.Strategy != "arbitrage" {
+		t.Errorf("strategy name should be lower case, got %s", a.Strategy)
+	}
+
+	if a.Balances["USD"] != 100 || len(a.Balances) != 1 {
+		t.Errorf("strategy balances should be upper case without negatives, got %v",
+			a.Balances)
+	}
+}
+
+func TestCheckWithdrawalAddressConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.WithdrawalAddresses = []WithdrawalAddressConfig{
+		{Label: "cold", Currency: "btc", Address: "1abc"},
+		{Currency: "BTC", Address: "1abc"},
+		{Exchange: "Binance", Currency: "BTC", Address: "1abc"},
+		{Currency: "XRP", Address: "rabc", Tag: "1"},
+		{Currency: "XRP", Address: "rabc", Tag: "2"},
+		{Address: "1def"},
+		{Currency: "ETH"},
+	}
+	c.CheckWithdrawalAddressConfig()
+	if len(c.WithdrawalAddresses) != 4 {
+		t.Fatalf("invalid and duplicate withdrawal addresses should be removed, got %v",
+			c.WithdrawalAddresses)
+	}
+
+	if c.WithdrawalAddresses[0].Currency != "BTC" || c.WithdrawalAddresses[0].Label != "cold" {
+		t.Errorf("withdrawal address currency should be upper case, got %v",
+			c.WithdrawalAddresses[0])
+	}
+	c.WithdrawalAddresses = nil
+}
+
+func TestCheckStrategyFeedConfig(t *testing.T) {
+	c := GetConfig()
+
+	p := currency.NewPairFromStrings("BTC", "USD")
+	c.StrategyFeeds = []StrategyFeedConfig{
+		{Strategy: "Grid", Feed: "Ticker", Pair: p, Exchange: "Bitfinex", Fallbacks: []string{"Bitstamp"}},
+		{Strategy: "grid", Feed: "ticker", Pair: p, Exchange: "any"},
+		{Strategy: "grid", Feed: "orderbook", Pair: p, Exchange: "any"},
+		{Strategy: "grid", Feed: "trades", Pair: p, Exchange: "any"},
+		{Strategy: "grid", Feed: "ticker", Exchange: "any"},
+		{Feed: "ticker", Pair: p, Exchange: "any"},
+	}
+	c.CheckStrategyFeedConfig()
+	if len(c.StrategyFeeds) != 2 {
+		t.Fatalf("invalid and duplicate strategy feeds should be removed, got %v",
+			c.StrategyFeeds)
+	}
+
+	if c.StrategyFeeds[0].Strategy != "grid" || c.StrategyFeeds[0].Feed != StrategyFeedTicker {
+		t.Errorf("strategy feed names should be lower case, got %v", c.StrategyFeeds[0])
+	}
+	c.StrategyFeeds = nil
+}
+
+func TestCheckOrderMirrorConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.OrderMirrors = []OrderMirrorConfig{
+		{Primary: "Bitfinex", Mirrors: []OrderMirrorTargetConfig{
+			{Exchange: "Bitstamp", Scale: 0.5},
+			{Exchange: "bitstamp", Scale: 1},
+			{Exchange: "bitfinex", Scale: 1},
+			{Exchange: "Kraken", Scale: 0},
+		}},
+		{Primary: "bitfinex", Mirrors: []OrderMirrorTargetConfig{
+			{Exchange: "Kraken", Scale: 1},
+		}},
+		{Primary: "Kraken", Mirrors: []OrderMirrorTargetConfig{
+			{Exchange: "Kraken", Scale: 1},
+		}},
+		{Mirrors: []OrderMirrorTargetConfig{{Exchange: "Kraken", Scale: 1}}},
+	}
+	c.CheckOrderMirrorConfig()
+	if len(c.OrderMirrors) != 1 {
+		t.Fatalf("invalid and duplicate order mirrors should be removed, got %v",
+			c.OrderMirrors)
+	}
+
+	if len(c.OrderMirrors[0].Mirrors) != 1 || c.OrderMirrors[0].Mirrors[0].Scale != 0.5 {
+		t.Errorf("invalid and duplicate order mirror targets should be removed, got %v",
+			c.OrderMirrors[0].Mirrors)
+	}
+	c.OrderMirrors = nil
+}
+
+func TestCheckSchedulerConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.Scheduler = SchedulerConfig{
+		Enabled: true,
+		Tasks: []ScheduledTaskConfig{
+			{Task: "DUST_SWEEP", Schedule: "0 * * * *"},
+			{Task: "trade_export", Schedule: "61 * * * *"},
+			{Schedule: "@daily"},
+			{Name: "dust_sweep", Task: "portfolio_report", Schedule: "@daily"},
+			{Name: "report", Task: "portfolio_report", Schedule: "@every 1h"},
+		},
+	}
+	c.CheckSchedulerConfig()
+	if len(c.Scheduler.Tasks) != 2 {
+		t.Fatalf("scheduler invalid and duplicate tasks should be removed, got %v",
+			c.Scheduler.Tasks)
+	}
+
+	if c.Scheduler.Tasks[0].Name != "dust_sweep" || c.Scheduler.Tasks[0].Task != "dust_sweep" {
+		t.Errorf("scheduled task name should default to its lower case task, got %v",
+			c.Scheduler.Tasks[0])
+	}
+
+	if !c.Scheduler.Enabled {
+		t.Error("scheduler with valid tasks should remain enabled")
+	}
+
+	c.Scheduler.Tasks = []ScheduledTaskConfig{{Task: "dust_sweep", Schedule: "invalid"}}
+	c.CheckSchedulerConfig()
+	if c.Scheduler.Enabled {
+		t.Error("scheduler with no valid tasks should be disabled")
+	}
+}
+
+func TestCheckSummaryReportConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.Currency.FiatDisplayCurrency = currency.AUD
+	c.SummaryReport = SummaryReportConfig{TopMovers: -1}
+	c.CheckSummaryReportConfig()
+	if c.SummaryReport.Currency != currency.AUD {
+		t.Errorf("summary report currency should default to the fiat display currency, got %s",
+			c.SummaryReport.Currency)
+	}
+
+	if c.SummaryReport.TopMovers != defaultSummaryReportTopMovers ||
+		c.SummaryReport.MaxEvents != defaultSummaryReportMaxEvents ||
+		c.SummaryReport.MinWithdrawalFeeSaving != defaultSummaryReportMinFeeSaving {
+		t.Error("summary report with invalid limits should default to sane values")
+	}
+
+	c.SummaryReport.Currency = currency.EUR
+	c.CheckSummaryReportConfig()
+	if c.SummaryReport.Currency != currency.EUR {
+		t.Error("summary report currency should not be changed when set")
+	}
+}
+
+func TestCheckLocaleConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.Locale = ""
+	c.CheckLocaleConfig()
+	if c.Locale != i18n.DefaultLocale {
+		t.Error("locale not set should default to sane value")
+	}
+
+	c.Locale = "KO"
+	c.CheckLocaleConfig()
+	if c.Locale != "ko" {
+		t.Errorf("locale should be normalised to lower case, got %s", c.Locale)
+	}
+	c.Locale = i18n.DefaultLocale
+}
+
+func TestCheckPortfolioConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.Portfolio.ExtendedKeys = []portfolio.ExtendedKey{
+		{Key: "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs",
+			CoinType: currency.BTC},
+		{Key: "zpub-invalid", CoinType: currency.BTC},
+	}
+	c.CheckPortfolioConfig()
+	if len(c.Portfolio.ExtendedKeys) != 1 {
+		t.Errorf("portfolio invalid extended keys should be removed, got %d keys",
+			len(c.Portfolio.ExtendedKeys))
+	}
+
+	if c.Portfolio.ExtendedKeys[0].GapLimit != portfolio.DefaultGapLimit {
+		t.Error("portfolio extended key with no gap limit should default to sane value")
+	}
+	c.Portfolio.ExtendedKeys = nil
+}
+
+func TestCheckTokenRegistryConfig(t *testing.T) {
+	c := GetConfig()
+
+	c.TokenRegistry = TokenRegistryConfig{
+		Tokens: []portfolio.Token{
+			{Symbol: "usdc", Contract: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Decimals: 6},
+			{Symbol: "BAD", Contract: "0x1234", Decimals: 18},
+		},
+		RemoteListURL: "ftp://tokens.example.com",
+	}
+	c.CheckTokenRegistryConfig()
+	if c.TokenRegistry.UpdateInterval != defaultTokenListUpdateInterval {
+		t.Error("token registry with no update interval should default to sane value")
+	}
+
+	if len(c.TokenRegistry.Tokens) != 1 ||
+		c.TokenRegistry.Tokens[0].Symbol != "USDC" ||
+		c.TokenRegistry.Tokens[0].Contract != "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48" {
+		t.Errorf("token registry invalid tokens should be removed and valid tokens normalised, got %v",
+			c.TokenRegistry.Tokens)
+	}
+
+	if c.TokenRegistry.RemoteListURL != "" {
+		t.Error("token registry invalid remote list URL should be removed")
+	}
+}
+
+func TestCheckFaultInjection(t *testing.T) {
+	e := ExchangeConfig{
+		Name:           "Bitstamp",
+		FaultInjection: &FaultInjectionConfig{Enabled: true, ErrorRate: 0.1},
+	}
+	e.CheckFaultInjection()
+	if !e.FaultInjection.Enabled || e.FaultInjection.BurstLength != 1 {
+		t.Error("fault injection burst length should default to sane value")
+	}
+
+	e.FaultInjection.StatusCodes = []int{http.StatusOK}
+	e.CheckFaultInjection()
+	if e.FaultInjection.Enabled {
+		t.Error("fault injection with a successful status code should be disabled")
+	}
+
+	e.FaultInjection = &FaultInjectionConfig{Enabled: true, DropRate: 1.5}
+	e.CheckFaultInjection()
+	if e.FaultInjection.Enabled {
+		t.Error("fault injection with an invalid rate should be disabled")
+	}
+}
+
+func TestCheckPairUpdateInterval(t *testing.T) {
+	e := ExchangeConfig{
+		Name:                    "Bitstamp",
+		SupportsAutoPairUpdates: true,
+		PairUpdateInterval:      time.Second,
+	}
+	e.CheckPairUpdateInterval()
+	if e.PairUpdateInterval != configMinPairUpdateInterval {
+		t.Error("pair update interval should be raised to the minimum")
+	}
+
+	e.PairUpdateInterval = time.Hour
+	e.CheckPairUpdateInterval()
+	if e.PairUpdateInterval != time.Hour {
+		t.Error("valid pair update interval should be kept")
+	}
+
+	e.SupportsAutoPairUpdates = false
+	e.CheckPairUpdateInterval()
+	if e.PairUpdateInterval != 0 {
+		t.Error("pair updates should be disabled without auto pair update support")
+	}
+}
+
+func TestCheckAPIPassphrase(t *testing.T) {
+	e := ExchangeConfig{Name: "Bitstamp"}
+	if !e.CheckAPIPassphrase() {
+		t.Error("exchanges without passphrase auth should not require a passphrase")
+	}
+
+	e.Name = "CoinbasePro"
+	if e.CheckAPIPassphrase() {
+		t.Error("a missing passphrase should fail the check")
+	}
+
+	e.ClientID = "passphrase"
+	if !e.CheckAPIPassphrase() || e.APIPassphrase != "passphrase" || e.ClientID != "" {
+		t.Error("a passphrase stored as the clientId should be moved to apiPassphrase")
+	}
+}
+
+func TestCheckHTTPHeaders(t *testing.T) {
+	e := ExchangeConfig{
+		Name: "Bitstamp",
+		HTTPHeaders: map[string]string{
+			"X-Custom":    "value",
+			"Bad Header":  "value",
+			"X-Injection": "value\r\nX-Other: value",
+			"":            "value",
+		},
+	}
+	e.CheckHTTPHeaders()
+	if len(e.HTTPHeaders) != 1 || e.HTTPHeaders["X-Custom"] != "value" {
+		t.Errorf("only valid HTTP headers should be kept, got %v", e.HTTPHeaders)
+	}
+}
+
+func TestCheckPollingConfig(t *testing.T) {
+	e := ExchangeConfig{
+		Name:             "Bitstamp",
+		RESTPollingDelay: 5,
+		Polling: PollingConfig{
+			PairPriorities: map[string]int{
+				"BTC-USD": 5,
+				"LTC-USD": 0,
+			},
+		},
+	}
+
+	e.CheckPollingConfig()
+	if e.Polling.TickerInterval != time.Second*5 ||
+		e.Polling.OrderbookInterval != time.Second*5 {
+		t.Error("polling intervals should default to the legacy polling delay")
+	}
+
+	if e.Polling.AccountInterval != configDefaultAccountPollingInterval {
+		t.Error("account polling interval should default to sane value")
+	}
+
+	if _, ok := e.Polling.PairPriorities["LTC-USD"]; ok {
+		t.Error("invalid pair priority should be removed")
+	}
+
+	interval := e.Polling.GetPairInterval(e.Polling.TickerInterval,
+		currency.NewPairWithDelimiter("btc", "usd", "-"))
+	if interval != time.Second {
+		t.Errorf("pair priority should divide polling interval, got %s", interval)
+	}
+
+	interval = e.Polling.GetPairInterval(e.Polling.TickerInterval,
+		currency.NewPairWithDelimiter("ETH", "USD", "-"))
+	if interval != time.Second*5 {
+		t.Errorf("pair without priority should use polling interval, got %s", interval)
+	}
+}
+
+func TestCheckOrderFunding(t *testing.T) {
+	e := ExchangeConfig{
+		Name:        "Bitstamp",
+		FundingMode: "Pair",
+		FundingEquivalents: map[string][]string{
+			"usd": {"usdt", "USDC"},
+		},
+	}
+
+	e.CheckOrderFunding()
+	if e.FundingMode != OrderFundingPair {
+		t.Errorf("funding mode should be normalised, got %s", e.FundingMode)
+	}
+
+	equivalents := e.GetFundingEquivalents(currency.USDT)
+	if len(equivalents) != 2 || !equivalents.Contains(currency.USD) ||
+		!equivalents.Contains(currency.NewCode("USDC")) {
+		t.Errorf("funding equivalents should include the group, got %v", equivalents)
+	}
+
+	if equivalents = e.GetFundingEquivalents(currency.EUR); len(equivalents) != 0 {
+		t.Errorf("currency without equivalents should return none, got %v", equivalents)
+	}
+
+	e.FundingMode = "swap"
+	e.CheckOrderFunding()
+	if e.FundingMode != OrderFundingNone {
+		t.Error("invalid funding mode should be disabled")
+	}
+
+	e = ExchangeConfig{FundingMode: OrderFundingConvert}
+	e.CheckOrderFunding()
+	if len(e.GetFundingEquivalents(currency.USD)) != len(defaultFundingEquivalents["USD"]) {
+		t.Error("funding equivalents should default to sane values")
+	}
+}
+
+func TestCheckBalanceReserves(t *testing.T) {
+	e := ExchangeConfig{
+		Name: "Bitstamp",
+		BalanceReserves: map[string]float64{
+			"btc": 0.01,
+			"LTC": -1,
+		},
+	}
+
+	e.CheckBalanceReserves()
+	if len(e.BalanceReserves) != 1 {
+		t.Fatalf("invalid balance reserve should be removed, got %v", e.BalanceReserves)
+	}
+
+	if reserve := e.GetBalanceReserve(currency.BTC); reserve != 0.01 {
+		t.Errorf("balance reserve code should be normalised, got %f", reserve)
+	}
+
+	if reserve := e.GetBalanceReserve(currency.ETH); reserve != 0 {
+		t.Errorf("currency without reserve should return 0, got %f", reserve)
+	}
+}
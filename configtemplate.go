@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+const (
+	configTemplateName    = "Skynet"
+	configTemplateComment = "Generated by %s from the defaults of every supported exchange. " +
+		"Replace the Key and Secret placeholders before enabling authenticated API support. " +
+		"_comment fields are ignored when the config is loaded"
+)
+
+// configTemplate is a config with a comment describing it and each exchange,
+// JSON has no comment syntax so the comments are ignored fields
+type configTemplate struct {
+	Comment string `json:"_comment"`
+	*config.Config
+	Exchanges []exchangeConfigTemplate `json:"exchanges"`
+}
+
+// exchangeConfigTemplate is an exchange config commented with the features
+// the exchange supports
+type exchangeConfigTemplate struct {
+	Comment string `json:"_comment"`
+	config.ExchangeConfig
+}
+
+// getExchangeFeatures returns the features an exchange supports
+func getExchangeFeatures(exch exchange.IBotExchange) []string {
+	var features []string
+	if exch.SupportsAutoPairUpdates() {
+		features = append(features, "automatic pair updates")
+	}
+	if exch.SupportsRESTTickerBatchUpdates() {
+		features = append(features, "REST ticker batching")
+	}
+	if exch.SupportsHistoricCandles() {
+		features = append(features, "historic candles")
+	}
+	if ws, err := exch.GetWebsocket(); err == nil && ws != nil &&
+		ws.GetFunctionality() != 0 {
+		features = append(features, "websocket ("+ws.FormatFunctionality()+")")
+	}
+	if _, ok := exch.(exchange.IFeeTierExchange); ok {
+		features = append(features, "account fee tiers")
+	}
+	if _, ok := exch.(exchange.IOrderLimitsExchange); ok {
+		features = append(features, "order limits")
+	}
+	if _, ok := exch.(exchange.ITradeHistoryExchange); ok {
+		features = append(features, "account trade history")
+	}
+	if _, ok := exch.(exchange.ICredentialPermissionsExchange); ok {
+		features = append(features, "credential permissions")
+	}
+	if _, ok := exch.(exchange.ITransferNetworksExchange); ok {
+		features = append(features, "transfer networks")
+	}
+	if _, ok := exch.(exchange.IDustConversionExchange); ok {
+		features = append(features, "dust conversion")
+	}
+	if _, ok := exch.(exchange.IDerivativesExchange); ok {
+		features = append(features, "derivatives")
+	}
+	if _, ok := exch.(exchange.IMarketMetadataExchange); ok {
+		features = append(features, "market metadata")
+	}
+	if _, ok := exch.(exchange.ITradingStatusExchange); ok {
+		features = append(features, "trading status")
+	}
+	if _, ok := exch.(exchange.IWithdrawalWhitelistExchange); ok {
+		features = append(features, "withdrawal whitelist")
+	}
+	if _, ok := exch.(exchange.IWebsocketReplayExchange); ok {
+		features = append(features, "websocket capture")
+	}
+	features = append(features, "withdrawals: "+exch.FormatWithdrawPermissions())
+	return features
+}
+
+// generateConfigTemplate returns a config holding the defaults of every
+// registered exchange. Exchange pairs are not part of the defaults, they are
+// taken from the exchanges of pairsCfg which also decides which exchanges are
+// enabled. No other settings are copied, keeping credentials out of the
+// template
+func generateConfigTemplate(pairsCfg *config.Config) (*configTemplate, error) {
+	cfg := &config.Config{Name: configTemplateName}
+	var comments []string
+	for _, registration := range exchange.GetRegistrations() {
+		exch := registration.Creator()
+		exch.SetDefaults()
+		exchCfg, err := exch.GetDefaultConfig()
+		if err != nil {
+			return nil, fmt.Errorf("exchange %s: %s", registration.Name, err)
+		}
+
+		if current, err := pairsCfg.GetExchangeConfig(exchCfg.Name); err == nil {
+			if len(exchCfg.AvailablePairs) == 0 {
+				exchCfg.AvailablePairs = current.AvailablePairs
+				exchCfg.PairsLastUpdated = current.PairsLastUpdated
+			}
+			if len(exchCfg.EnabledPairs) == 0 {
+				exchCfg.EnabledPairs = current.EnabledPairs
+			}
+			if len(exchCfg.BaseCurrencies) == 0 {
+				exchCfg.BaseCurrencies = current.BaseCurrencies
+			}
+			exchCfg.Enabled = current.Enabled &&
+				len(exchCfg.AvailablePairs) > 0 &&
+				len(exchCfg.EnabledPairs) > 0 &&
+				len(exchCfg.BaseCurrencies) > 0
+		}
+
+		cfg.Exchanges = append(cfg.Exchanges, *exchCfg)
+		comments = append(comments, fmt.Sprintf("%s supports %s", exchCfg.Name,
+			strings.Join(getExchangeFeatures(exch), ", ")))
+	}
+
+	err := cfg.CheckConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := &configTemplate{
+		Comment: fmt.Sprintf(configTemplateComment,
+			fmt.Sprintf("GoCryptoTrader v%s.%s", MajorVersion, MinorVersion)),
+		Config: cfg,
+	}
+	for x := range cfg.Exchanges {
+		tmpl.Exchanges = append(tmpl.Exchanges, exchangeConfigTemplate{
+			Comment:        comments[x],
+			ExchangeConfig: cfg.Exchanges[x],
+		})
+	}
+	return tmpl, nil
+}
+
+// writeConfigTemplate writes a config template with the defaults of every
+// registered exchange to a file
+func writeConfigTemplate(path string, pairsCfg *config.Config) error {
+	tmpl, err := generateConfigTemplate(pairsCfg)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.MarshalIndent(tmpl, "", " ")
+	if err != nil {
+		return err
+	}
+	return common.WriteFile(path, payload)
+}
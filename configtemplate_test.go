@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+func TestGenerateConfigTemplate(t *testing.T) {
+	var pairsCfg config.Config
+	err := pairsCfg.LoadConfig("./testdata/configtest.json")
+	if err != nil {
+		t.Fatalf("Test failed. TestGenerateConfigTemplate: %s", err)
+	}
+
+	tmpl, err := generateConfigTemplate(&pairsCfg)
+	if err != nil {
+		t.Fatalf("Test failed. TestGenerateConfigTemplate: %s", err)
+	}
+	if len(tmpl.Exchanges) != len(exchange.GetRegistrations()) {
+		t.Fatalf("Test failed. TestGenerateConfigTemplate: expected %d exchanges, got %d",
+			len(exchange.GetRegistrations()), len(tmpl.Exchanges))
+	}
+
+	for x := range tmpl.Exchanges {
+		e := tmpl.Exchanges[x]
+		if !strings.HasPrefix(e.Comment, e.Name+" supports ") {
+			t.Errorf("Test failed. TestGenerateConfigTemplate: %s unexpected comment %s",
+				e.Name, e.Comment)
+		}
+		if e.APIKey != config.DefaultUnsetAPIKey ||
+			e.APISecret != config.DefaultUnsetAPISecret {
+			t.Errorf("Test failed. TestGenerateConfigTemplate: %s credentials set",
+				e.Name)
+		}
+		if e.Name == "Bitfinex" && (!e.Enabled || len(e.EnabledPairs) == 0) {
+			t.Error("Test failed. TestGenerateConfigTemplate: Bitfinex pairs not taken from config")
+		}
+	}
+
+	payload, err := json.Marshal(tmpl)
+	if err != nil {
+		t.Fatalf("Test failed. TestGenerateConfigTemplate: %s", err)
+	}
+	var loaded config.Config
+	err = json.Unmarshal(payload, &loaded)
+	if err != nil {
+		t.Fatalf("Test failed. TestGenerateConfigTemplate: %s", err)
+	}
+	if loaded.Name != configTemplateName ||
+		len(loaded.Exchanges) != len(tmpl.Exchanges) {
+		t.Error("Test failed. TestGenerateConfigTemplate: template does not load as a config")
+	}
+	err = loaded.CheckConfig()
+	if err != nil {
+		t.Errorf("Test failed. TestGenerateConfigTemplate: %s", err)
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// CredentialValidation holds the result of validating an exchanges stored
+// API credentials
+type CredentialValidation struct {
+	Exchange    string   `json:"exchange"`
+	Valid       bool     `json:"valid"`
+	Error       string   `json:"error,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// ExchangeCredentials holds API credentials supplied at runtime to bring an
+// exchange online
+type ExchangeCredentials struct {
+	APIKey        string `json:"apiKey"`
+	APISecret     string `json:"apiSecret"`
+	ClientID      string `json:"clientId,omitempty"`
+	TradePassword string `json:"tradePassword,omitempty"`
+	OTPSecret     string `json:"otpSecret,omitempty"`
+	APIPassphrase string `json:"apiPassphrase,omitempty"`
+}
+
+// errCredentialsMissing is returned when runtime credentials lack an API key
+// or secret
+var errCredentialsMissing = errors.New("an API key and secret are required")
+
+// ValidateExchangeCredentials performs a harmless authenticated request to
+// check whether an exchanges stored API credentials work
+func ValidateExchangeCredentials(exchName string) (CredentialValidation, error) {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return CredentialValidation{}, fmt.Errorf("exchange %s not found", exchName)
+	}
+	return validateCredentials(exch), nil
+}
+
+// validateCredentials requests the credential permissions where the exchange
+// reports them, otherwise a fresh copy of the account info
+func validateCredentials(exch exchange.IBotExchange) CredentialValidation {
+	resp := CredentialValidation{Exchange: exch.GetName()}
+	if !exch.GetAuthenticatedAPISupport() {
+		resp.Error = "authenticated API support disabled, check the apiKey, apiSecret, clientId and apiPassphrase are set"
+		return resp
+	}
+
+	if p, ok := exch.(exchange.ICredentialPermissionsExchange); ok {
+		permissions, err := p.GetCredentialPermissions()
+		if err != nil {
+			resp.Error = err.Error()
+			return resp
+		}
+		resp.Valid = true
+		resp.Permissions = permissions
+		return resp
+	}
+
+	_, err := GetExchangeAccountInfo(exch, true)
+	if err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+	resp.Valid = true
+	resp.Permissions = []string{exchange.CredentialPermissionRead}
+	return resp
+}
+
+// applyExchangeCredentials sets credentials on an exchange config and enables
+// the exchange with authenticated API support
+func applyExchangeCredentials(exchCfg *config.ExchangeConfig, creds *ExchangeCredentials) {
+	exchCfg.Enabled = true
+	exchCfg.AuthenticatedAPISupport = true
+	exchCfg.APIKey = creds.APIKey
+	exchCfg.APISecret = creds.APISecret
+	if creds.ClientID != "" {
+		exchCfg.ClientID = creds.ClientID
+	}
+	if creds.TradePassword != "" {
+		exchCfg.TradePassword = creds.TradePassword
+	}
+	if creds.OTPSecret != "" {
+		exchCfg.OTPSecret = creds.OTPSecret
+	}
+	if creds.APIPassphrase != "" {
+		exchCfg.APIPassphrase = creds.APIPassphrase
+	}
+}
+
+// EnableExchangeWithCredentials stores API credentials for an exchange and
+// brings it online without a restart, loading it when it's disabled. The
+// previous exchange config is restored when the credentials don't validate,
+// otherwise the config is saved
+func EnableExchangeWithCredentials(exchName string, creds *ExchangeCredentials) (CredentialValidation, error) {
+	if creds.APIKey == "" || creds.APISecret == "" {
+		return CredentialValidation{}, errCredentialsMissing
+	}
+
+	previous, err := bot.config.GetExchangeConfig(exchName)
+	if err != nil {
+		return CredentialValidation{}, err
+	}
+	exchCfg := previous
+	applyExchangeCredentials(&exchCfg, creds)
+	err = bot.config.UpdateExchangeConfig(&exchCfg)
+	if err != nil {
+		return CredentialValidation{}, err
+	}
+
+	// Config checks such as credential and OTP secret validation are applied
+	// before the exchange is set up
+	err = bot.config.CheckExchangeConfigValues()
+	if err != nil {
+		restoreExchangeConfig(&previous, false)
+		return CredentialValidation{}, err
+	}
+
+	loaded := GetExchangeByName(exchName) != nil
+	if loaded {
+		err = ReloadExchange(exchName)
+	} else {
+		err = LoadExchange(exchName, false, nil)
+	}
+	if err != nil {
+		restoreExchangeConfig(&previous, loaded)
+		return CredentialValidation{}, err
+	}
+
+	exch := GetExchangeByName(exchName)
+	InvalidateExchangeAccountInfo(exch.GetName())
+	resp := validateCredentials(exch)
+	if !resp.Valid {
+		restoreExchangeConfig(&previous, loaded)
+		return resp, nil
+	}
+
+	if !loaded {
+		go connectExchangeWebsocket(exch,
+			bot.config.Debug.SubsystemEnabled(debugSubsystemWebsocket))
+	}
+	log.Infof("%s credentials validated, exchange is online with authenticated API support.",
+		exch.GetName())
+
+	if !bot.dryRun {
+		err = bot.config.SaveConfig(bot.configFile)
+		if err != nil {
+			return resp, fmt.Errorf("%s is online but the config failed to save: %s",
+				exch.GetName(), err)
+		}
+	}
+	return resp, nil
+}
+
+// restoreExchangeConfig reverts an exchange to its config before credentials
+// were supplied, unloading it when it wasn't loaded
+func restoreExchangeConfig(previous *config.ExchangeConfig, loaded bool) {
+	if GetExchangeByName(previous.Name) != nil {
+		if loaded {
+			err := bot.config.UpdateExchangeConfig(previous)
+			if err == nil {
+				err = ReloadExchange(previous.Name)
+			}
+			if err != nil {
+				log.Errorf("%s failed to restore exchange config. Error: %s",
+					previous.Name, err)
+			}
+			return
+		}
+		err := UnloadExchange(previous.Name)
+		if err != nil {
+			log.Errorf("%s failed to unload exchange. Error: %s", previous.Name, err)
+		}
+	}
+	err := bot.config.UpdateExchangeConfig(previous)
+	if err != nil {
+		log.Errorf("%s failed to restore exchange config. Error: %s", previous.Name, err)
+	}
+}
+
+// handleCommsExchangeCredentials brings an exchange online with credentials
+// supplied through a communication medium and returns the reply
+func handleCommsExchangeCredentials(exchName, apiKey, apiSecret, clientID string) string {
+	resp, err := EnableExchangeWithCredentials(exchName, &ExchangeCredentials{
+		APIKey:    apiKey,
+		APISecret: apiSecret,
+		ClientID:  clientID,
+	})
+	if err != nil {
+		return fmt.Sprintf("%s credentials not applied: %s", exchName, err)
+	}
+	if !resp.Valid {
+		return fmt.Sprintf("%s credentials rejected: %s", exchName, resp.Error)
+	}
+	return fmt.Sprintf("%s is online with authenticated API support", resp.Exchange)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type credentialsTestExchange struct {
+	exchange.IBotExchange
+	authenticated bool
+	err           error
+}
+
+func (c *credentialsTestExchange) GetName() string {
+	return "Bitstamp"
+}
+
+func (c *credentialsTestExchange) GetAuthenticatedAPISupport() bool {
+	return c.authenticated
+}
+
+func (c *credentialsTestExchange) IsSandbox() bool {
+	return false
+}
+
+func (c *credentialsTestExchange) GetAccountInfo() (exchange.AccountInfo, error) {
+	return exchange.AccountInfo{Exchange: "Bitstamp"}, c.err
+}
+
+type credentialPermissionsTestExchange struct {
+	credentialsTestExchange
+}
+
+func (c *credentialPermissionsTestExchange) GetCredentialPermissions() ([]string, error) {
+	return []string{exchange.CredentialPermissionRead,
+		exchange.CredentialPermissionTrade}, c.err
+}
+
+func TestValidateCredentials(t *testing.T) {
+	setupAccountInfoTest(t, time.Minute)
+
+	resp := validateCredentials(&credentialsTestExchange{})
+	if resp.Valid || resp.Error == "" {
+		t.Error("Test failed. Expected credentials to be invalid without authenticated API support")
+	}
+
+	resp = validateCredentials(&credentialsTestExchange{authenticated: true})
+	if !resp.Valid || len(resp.Permissions) != 1 ||
+		resp.Permissions[0] != exchange.CredentialPermissionRead {
+		t.Errorf("Test failed. Expected valid read only credentials, got %+v", resp)
+	}
+
+	resp = validateCredentials(&credentialsTestExchange{
+		authenticated: true,
+		err:           errors.New("invalid API key"),
+	})
+	if resp.Valid || resp.Error != "invalid API key" {
+		t.Errorf("Test failed. Expected invalid credentials, got %+v", resp)
+	}
+
+	resp = validateCredentials(&credentialPermissionsTestExchange{
+		credentialsTestExchange{authenticated: true},
+	})
+	if !resp.Valid || len(resp.Permissions) != 2 {
+		t.Errorf("Test failed. Expected exchange reported permissions, got %+v", resp)
+	}
+
+	_, err := ValidateExchangeCredentials("Asdsad")
+	if err == nil {
+		t.Error("Test failed. Expected error for a non-existent exchange")
+	}
+}
+
+func TestApplyExchangeCredentials(t *testing.T) {
+	exchCfg := config.ExchangeConfig{ClientID: "client"}
+	applyExchangeCredentials(&exchCfg, &ExchangeCredentials{
+		APIKey:        "key",
+		APISecret:     "secret",
+		TradePassword: "password",
+		APIPassphrase: "passphrase",
+	})
+	if !exchCfg.Enabled || !exchCfg.AuthenticatedAPISupport ||
+		exchCfg.APIKey != "key" || exchCfg.APISecret != "secret" ||
+		exchCfg.ClientID != "client" || exchCfg.TradePassword != "password" ||
+		exchCfg.APIPassphrase != "passphrase" {
+		t.Errorf("Test failed. Unexpected exchange config %+v", exchCfg)
+	}
+}
+
+func TestEnableExchangeWithCredentials(t *testing.T) {
+	setupAccountInfoTest(t, time.Minute)
+
+	_, err := EnableExchangeWithCredentials("Bitstamp", &ExchangeCredentials{APIKey: "key"})
+	if err != errCredentialsMissing {
+		t.Errorf("Test failed. Expected missing credentials error, got %v", err)
+	}
+
+	_, err = EnableExchangeWithCredentials("Asdsad", &ExchangeCredentials{
+		APIKey:    "key",
+		APISecret: "secret",
+	})
+	if err == nil {
+		t.Error("Test failed. Expected error for a non-existent exchange")
+	}
+}
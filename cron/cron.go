@@ -0,0 +1,216 @@
+// Package cron parses cron schedule expressions and calculates the times they
+// next fire. Schedules use the five field minute, hour, day of month, month
+// and day of week format, or one of the descriptors:
+//
+//	@yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly
+//	@every <duration>, such as @every 90m
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule returns the time a schedule next fires after a time
+type Schedule interface {
+	Next(t time.Time) time.Time
+}
+
+// field bounds of the five field format
+var bounds = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse returns the schedule of a cron expression
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, errors.New("empty schedule")
+	}
+
+	if strings.HasPrefix(spec, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(spec[len("@every "):]))
+		if err != nil {
+			return nil, fmt.Errorf("schedule %s: %s", spec, err)
+		}
+		if d < time.Second {
+			return nil, fmt.Errorf("schedule %s: interval must be at least one second", spec)
+		}
+		return Every(d), nil
+	}
+
+	if expr, ok := descriptors[strings.ToLower(spec)]; ok {
+		spec = expr
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(bounds) {
+		return nil, fmt.Errorf("schedule %s: expected %d fields, got %d",
+			spec, len(bounds), len(fields))
+	}
+
+	var values [5]uint64
+	for x := range fields {
+		v, err := parseField(fields[x], bounds[x].min, bounds[x].max)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %s: %s %s", spec, bounds[x].name, err)
+		}
+		values[x] = v
+	}
+
+	// Sunday can be given as 0 or 7
+	if values[4]&(1<<7) != 0 {
+		values[4] |= 1
+	}
+
+	return &specSchedule{
+		minute:        values[0],
+		hour:          values[1],
+		dayOfMonth:    values[2],
+		month:         values[3],
+		dayOfWeek:     values[4],
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+	}, nil
+}
+
+// parseField returns the values of a comma separated list of values, ranges
+// and steps as a bit set
+func parseField(field string, min, max int) (uint64, error) {
+	var resp uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %s", part[i+1:])
+			}
+			part = part[:i]
+		}
+
+		start, end := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			i := strings.Index(part, "-")
+			var err error
+			start, err = parseValue(part[:i], min, max)
+			if err != nil {
+				return 0, err
+			}
+			end, err = parseValue(part[i+1:], min, max)
+			if err != nil {
+				return 0, err
+			}
+			if end < start {
+				return 0, fmt.Errorf("invalid range %s", part)
+			}
+		default:
+			var err error
+			start, err = parseValue(part, min, max)
+			if err != nil {
+				return 0, err
+			}
+			if step == 1 {
+				end = start
+			}
+		}
+
+		for x := start; x <= end; x += step {
+			resp |= 1 << uint(x)
+		}
+	}
+	return resp, nil
+}
+
+// parseValue returns a field value within its bounds
+func parseValue(s string, min, max int) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %s", s)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("value %d outside of %d-%d", v, min, max)
+	}
+	return v, nil
+}
+
+// specSchedule is a five field schedule with each field held as a bit set
+type specSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	anyDayOfMonth, anyDayOfWeek                bool
+}
+
+// maxSearch limits the search for a schedules next time, schedules such as
+// 30 February never fire
+const maxSearch = time.Hour * 24 * 366 * 5
+
+// Next returns the first minute after t matching the schedule in the location
+// of t, or the zero time when the schedule never fires
+func (s *specSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).
+		Add(time.Minute)
+	limit := t.Add(maxSearch)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchesDay returns whether a day matches the schedule. As with cron, a day
+// matches either day field when both are restricted
+func (s *specSchedule) matchesDay(t time.Time) bool {
+	dom := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dow := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Every is a schedule firing at a fixed interval
+type Every time.Duration
+
+// Next returns t advanced by the interval
+func (e Every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
@@ -0,0 +1,84 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	valid := []string{
+		"* * * * *",
+		"*/15 0-6,18 1 */2 1-5",
+		"0 0 * * 7",
+		"@daily",
+		"@HOURLY",
+		"@every 90m",
+	}
+	for x := range valid {
+		if _, err := Parse(valid[x]); err != nil {
+			t.Errorf("unexpected error parsing %s: %s", valid[x], err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@every 10ms",
+		"@every daily",
+		"@fortnightly",
+	}
+	for x := range invalid {
+		if _, err := Parse(invalid[x]); err == nil {
+			t.Errorf("expected an error parsing %s", invalid[x])
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	// Saturday
+	from := time.Date(2019, 6, 1, 10, 30, 45, 0, time.UTC)
+	tests := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2019, 6, 1, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2019, 6, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2019, 6, 2, 9, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2019, 6, 1, 11, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2019, 6, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2019, 6, 2, 0, 0, 0, 0, time.UTC)},
+		{"30 8 * * 1-5", time.Date(2019, 6, 3, 8, 30, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Either day field matches when both are restricted
+		{"0 0 15 * 1", time.Date(2019, 6, 3, 0, 0, 0, 0, time.UTC)},
+		{"@every 1h30m", from.Add(time.Minute * 90)},
+	}
+
+	for x := range tests {
+		s, err := Parse(tests[x].spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next := s.Next(from); !next.Equal(tests[x].expected) {
+			t.Errorf("%s: expected %s, got %s", tests[x].spec,
+				tests[x].expected, next)
+		}
+	}
+}
+
+func TestNextNever(t *testing.T) {
+	s, err := Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := s.Next(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)); !next.IsZero() {
+		t.Errorf("expected the schedule to never fire, got %s", next)
+	}
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/database"
+	// Registers the supported database drivers
+	_ "github.com/thrasher-/gocryptotrader/database/drivers/postgres"
+	_ "github.com/thrasher-/gocryptotrader/database/drivers/sqlite3"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// databaseSyncLookback is how far back order history is first synced, later
+// syncs overlap the previous one by a sync interval so orders updated while
+// it ran aren't missed
+const databaseSyncLookback = time.Hour * 24
+
+// databaseSyncs holds when each exchanges order history was last synced
+var databaseSyncs = struct {
+	synced map[string]time.Time
+	m      sync.Mutex
+}{
+	synced: make(map[string]time.Time),
+}
+
+// getDatabaseDataSourceName returns the data source name of the configured
+// database, resolving relative SQLite database files against the data
+// directory
+func getDatabaseDataSourceName(cfg *config.DatabaseConfig, dataDir string) string {
+	if cfg.Driver == database.DBSQLite3 {
+		if filepath.IsAbs(cfg.Database) {
+			return cfg.Database
+		}
+		return filepath.Join(dataDir, cfg.Database)
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		Host:     cfg.Host + ":" + strconv.Itoa(int(cfg.Port)),
+		Path:     cfg.Database,
+		RawQuery: url.Values{"sslmode": []string{cfg.SSLMode}}.Encode(),
+	}
+	if cfg.Username != "" {
+		dsn.User = url.UserPassword(cfg.Username, cfg.Password)
+	}
+	return dsn.String()
+}
+
+// setupDatabase connects the configured database when enabled
+func setupDatabase() error {
+	if !bot.config.Database.Enabled {
+		log.Debugln("Database support disabled.")
+		return nil
+	}
+
+	db, err := database.Connect(bot.config.Database.Driver,
+		getDatabaseDataSourceName(&bot.config.Database, bot.dataDir))
+	if err != nil {
+		return err
+	}
+	database.SetConnected(db)
+	log.Debugf("Database connected using the %s driver.", bot.config.Database.Driver)
+	return nil
+}
+
+// closeDatabase closes the connected database
+func closeDatabase() {
+	db, err := database.GetConnected()
+	if err != nil {
+		return
+	}
+	database.SetConnected(nil)
+	err = db.Close()
+	if err != nil {
+		log.Warnf("Unable to close database: %s", err)
+	}
+}
+
+// newStoredOrder returns the stored form of an exchange order
+func newStoredOrder(exchName string, detail *exchange.OrderDetail, status exchange.OrderStatus, open bool, now time.Time) database.Order {
+	created := detail.OrderDate
+	if created.IsZero() {
+		created = now
+	}
+	return database.Order{
+		Exchange:       exchName,
+		OrderID:        detail.ID,
+		Base:           detail.CurrencyPair.Base.Upper().String(),
+		Quote:          detail.CurrencyPair.Quote.Upper().String(),
+		Side:           string(detail.OrderSide),
+		OrderType:      string(detail.OrderType),
+		Status:         string(status),
+		Open:           open,
+		Price:          detail.Price,
+		Amount:         detail.Amount,
+		ExecutedAmount: detail.ExecutedAmount,
+		Created:        created,
+		Updated:        now,
+	}
+}
+
+// getStoredTrades returns the stored form of an orders trades. Trades without
+// an ID are identified by their execution time
+func getStoredTrades(exchName string, detail *exchange.OrderDetail) []database.Trade {
+	trades := make([]database.Trade, 0, len(detail.Trades))
+	for x := range detail.Trades {
+		t := &detail.Trades[x]
+		tradeID := strconv.FormatInt(t.TID, 10)
+		if t.TID == 0 {
+			tradeID = strconv.FormatInt(t.Timestamp.UnixNano(), 10)
+		}
+		trades = append(trades, database.Trade{
+			Exchange:    exchName,
+			OrderID:     detail.ID,
+			TradeID:     tradeID,
+			Base:        detail.CurrencyPair.Base.Upper().String(),
+			Quote:       detail.CurrencyPair.Quote.Upper().String(),
+			Side:        string(detail.OrderSide),
+			Price:       t.Price,
+			Amount:      t.Amount,
+			Fee:         t.Fee,
+			FeeCurrency: t.FeeAsset.Upper().String(),
+			Executed:    t.Timestamp,
+		})
+	}
+	return trades
+}
+
+// storeSubmittedOrder stores an order submitted by the bot as open, so it's
+// known to the database before the next sync
+func storeSubmittedOrder(exchName, orderID string, p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64) {
+	db, err := database.GetConnected()
+	if err != nil || orderID == "" {
+		return
+	}
+	now := clock.Now()
+	err = db.UpsertOrders([]database.Order{newStoredOrder(exchName,
+		&exchange.OrderDetail{
+			ID:           orderID,
+			CurrencyPair: p,
+			OrderSide:    side,
+			OrderType:    orderType,
+			Price:        price,
+			Amount:       amount,
+		}, exchange.NewOrderStatus, true, now)})
+	if err != nil {
+		log.Errorf("Failed to store %s order %s. Error: %s", exchName, orderID, err)
+	}
+}
+
+// syncExchangeOrders stores an exchanges open orders and its order history
+// since a time along with their trades. Stored open orders created before the
+// settle time which are no longer listed open and aren't in the history are
+// closed with an unknown status. Order history is only stored when the open
+// orders are listed, as the history doesn't reliably say which are open
+func syncExchangeOrders(db *database.Database, exch exchange.IBotExchange, since, now time.Time) error {
+	pairs := exch.GetEnabledCurrencies()
+	active, err := exch.GetActiveOrders(&exchange.GetOrdersRequest{
+		OrderType:  exchange.AnyOrderType,
+		OrderSide:  exchange.AnyOrderSide,
+		Currencies: pairs,
+	})
+	if err != nil {
+		return err
+	}
+
+	var orders []database.Order
+	var trades []database.Trade
+	listed := make(map[string]bool, len(active))
+	for x := range active {
+		if active[x].ID == "" {
+			continue
+		}
+		active[x].SetFillAmounts()
+		listed[active[x].ID] = true
+		status := exchange.ActiveOrderStatus
+		if active[x].ExecutedAmount > 0 {
+			status = exchange.PartiallyFilledOrderStatus
+		}
+		orders = append(orders, newStoredOrder(exch.GetName(), &active[x], status, true, now))
+		trades = append(trades, getStoredTrades(exch.GetName(), &active[x])...)
+	}
+
+	history, err := exch.GetOrderHistory(&exchange.GetOrdersRequest{
+		OrderType:  exchange.AnyOrderType,
+		OrderSide:  exchange.AnyOrderSide,
+		StartTicks: since,
+		EndTicks:   now,
+		Currencies: pairs,
+	})
+	if err != nil {
+		log.Debugf("%s failed to get order history for the database. Error: %s",
+			exch.GetName(), err)
+	}
+	for x := range history {
+		if history[x].ID == "" || listed[history[x].ID] {
+			continue
+		}
+		history[x].SetFillAmounts()
+		orders = append(orders, newStoredOrder(exch.GetName(), &history[x],
+			getClosedOrderStatus(&history[x]), false, now))
+		trades = append(trades, getStoredTrades(exch.GetName(), &history[x])...)
+	}
+
+	err = db.UpsertOrders(orders)
+	if err != nil {
+		return err
+	}
+	err = db.InsertTrades(trades)
+	if err != nil {
+		return err
+	}
+	return db.CloseOrders(exch.GetName(), listed, now.Add(-orderManagerSettleTime),
+		string(exchange.UnknownOrderStatus), now)
+}
+
+// storeBalanceSnapshot stores a snapshot of an exchanges account balances
+func storeBalanceSnapshot(db *database.Database, exch exchange.IBotExchange, now time.Time) error {
+	info, err := GetExchangeAccountInfo(exch, false)
+	if err != nil {
+		return err
+	}
+
+	var balances []database.Balance
+	for x := range info.Accounts {
+		for y := range info.Accounts[x].Currencies {
+			c := &info.Accounts[x].Currencies[y]
+			balances = append(balances, database.Balance{
+				Exchange: exch.GetName(),
+				Account:  info.Accounts[x].ID,
+				Currency: c.CurrencyName.Upper().String(),
+				Total:    c.TotalValue,
+				Hold:     c.Hold,
+				Taken:    now,
+			})
+		}
+	}
+	return db.InsertBalances(balances)
+}
+
+// syncDatabase stores the orders, trades and balances of every enabled
+// exchange with authenticated API support
+func syncDatabase(db *database.Database, interval time.Duration, now time.Time) {
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		exch := exchanges[x]
+		if exch == nil || !exch.IsEnabled() || !exch.GetAuthenticatedAPISupport() {
+			continue
+		}
+
+		databaseSyncs.m.Lock()
+		since, ok := databaseSyncs.synced[exch.GetName()]
+		databaseSyncs.m.Unlock()
+		if ok {
+			since = since.Add(-interval)
+		} else {
+			since = now.Add(-databaseSyncLookback)
+		}
+
+		err := syncExchangeOrders(db, exch, since, now)
+		if err != nil {
+			log.Errorf("%s failed to sync orders to the database. Error: %s",
+				exch.GetName(), err)
+		} else {
+			databaseSyncs.m.Lock()
+			databaseSyncs.synced[exch.GetName()] = now
+			databaseSyncs.m.Unlock()
+		}
+
+		err = storeBalanceSnapshot(db, exch, now)
+		if err != nil {
+			log.Errorf("%s failed to store balance snapshot. Error: %s",
+				exch.GetName(), err)
+		}
+	}
+}
+
+// maintainDatabase removes duplicate balance snapshots and compacts those
+// older than each retention policies max age to one per its larger interval.
+// The policies source interval is ignored as snapshots are taken at the sync
+// interval
+func maintainDatabase(db *database.Database, policies []kline.RetentionPolicy, now time.Time) {
+	removed, err := db.DeduplicateBalances()
+	if err != nil {
+		log.Errorf("Failed to deduplicate database balance snapshots. Error: %s", err)
+		return
+	}
+
+	var compacted int64
+	for x := range policies {
+		n, err := db.CompactBalances(policies[x].CompactTo, now.Add(-policies[x].MaxAge))
+		if err != nil {
+			log.Errorf("Failed to compact database balance snapshots. Error: %s", err)
+			return
+		}
+		compacted += n
+	}
+	log.Debugf("Database maintenance removed %d duplicate and compacted %d old balance snapshots.",
+		removed, compacted)
+}
+
+// DatabaseRoutine periodically syncs order and trade history and balance
+// snapshots to the database
+func DatabaseRoutine() {
+	log.Debugln("Starting database sync routine.")
+	for {
+		db, err := database.GetConnected()
+		if err != nil {
+			return
+		}
+		syncDatabase(db, bot.config.Database.SyncInterval, clock.Now())
+		clock.Sleep(bot.config.Database.SyncInterval)
+	}
+}
+
+// GetStoredTrades returns the trades stored in the database matching a query
+func GetStoredTrades(q *database.Query) ([]database.Trade, error) {
+	db, err := database.GetConnected()
+	if err != nil {
+		return nil, err
+	}
+	return db.QueryTrades(q)
+}
+
+// GetStoredOrders returns the orders stored in the database matching a query,
+// only those open when last synced when openOnly is set
+func GetStoredOrders(q *database.Query, openOnly bool) ([]database.Order, error) {
+	db, err := database.GetConnected()
+	if err != nil {
+		return nil, err
+	}
+	return db.QueryOrders(q, openOnly)
+}
+
+// GetStoredBalances returns the balance snapshots stored in the database
+// matching a query, only the balances of a currency when set
+func GetStoredBalances(q *database.Query, currencyCode string) ([]database.Balance, error) {
+	db, err := database.GetConnected()
+	if err != nil {
+		return nil, err
+	}
+	return db.QueryBalances(q, currencyCode)
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// Balance is a stored snapshot of a currency balance of an exchange account
+type Balance struct {
+	ID       int64     `json:"id"`
+	Exchange string    `json:"exchange"`
+	Account  string    `json:"account"`
+	Currency string    `json:"currency"`
+	Total    float64   `json:"total"`
+	Hold     float64   `json:"hold"`
+	Taken    time.Time `json:"taken"`
+}
+
+const balanceColumns = "id, exchange, account, currency, total, hold, taken_at"
+
+// InsertBalances stores a balance snapshot
+func (d *Database) InsertBalances(balances []Balance) error {
+	query := d.rebind(`INSERT INTO balances (exchange, account, currency, total,
+		hold, taken_at) VALUES (?, ?, ?, ?, ?, ?)`)
+	return d.withTx(func(tx *sql.Tx) error {
+		for x := range balances {
+			b := &balances[x]
+			_, err := tx.Exec(query, b.Exchange, b.Account, b.Currency, b.Total,
+				b.Hold, b.Taken.UnixNano())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// QueryBalances returns the stored balance snapshots matching a query by the
+// time they were taken, only the balances of a currency when set. The query
+// pair is ignored
+func (d *Database) QueryBalances(q *Query, currencyCode string) ([]Balance, error) {
+	filter := *q
+	filter.Base, filter.Quote = "", ""
+	var conditions []string
+	var args []interface{}
+	if currencyCode != "" {
+		conditions = append(conditions, "currency = ?")
+		args = append(args, common.StringToUpper(currencyCode))
+	}
+	query, args := d.selectQuery("balances", balanceColumns, "taken_at", &filter,
+		conditions, args)
+	rows, err := d.sql.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var balances []Balance
+	for rows.Next() {
+		var b Balance
+		var taken int64
+		err = rows.Scan(&b.ID, &b.Exchange, &b.Account, &b.Currency, &b.Total,
+			&b.Hold, &taken)
+		if err != nil {
+			return nil, err
+		}
+		b.Taken = time.Unix(0, taken)
+		balances = append(balances, b)
+	}
+	return balances, rows.Err()
+}
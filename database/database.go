@@ -0,0 +1,262 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// Supported database drivers
+const (
+	DBSQLite3    = "sqlite3"
+	DBPostgreSQL = "postgres"
+)
+
+// Driver describes the SQL dialect of a database/sql driver registered with
+// RegisterDriver
+type Driver struct {
+	Name string
+	// Placeholder returns the bind parameter at a 1 based position
+	Placeholder func(n int) string
+	// PrimaryKey is the column definition of an auto incrementing primary key
+	PrimaryKey string
+	// MaxOpenConns limits the open connections, zero is unlimited
+	MaxOpenConns int
+}
+
+// Database is a connection to a database storing trade and order history and
+// balance snapshots
+type Database struct {
+	sql    *sql.DB
+	driver Driver
+}
+
+var (
+	drivers = struct {
+		drivers map[string]Driver
+		m       sync.Mutex
+	}{
+		drivers: make(map[string]Driver),
+	}
+
+	connected = struct {
+		db *Database
+		m  sync.RWMutex
+	}{}
+
+	// ErrNotConnected is returned when no database is connected
+	ErrNotConnected = errors.New("database not connected")
+)
+
+// RegisterDriver registers the dialect of a database/sql driver so databases
+// can be connected with it
+func RegisterDriver(d Driver) {
+	drivers.m.Lock()
+	drivers.drivers[d.Name] = d
+	drivers.m.Unlock()
+}
+
+// Connect opens a database with a registered driver, creating its tables if
+// they don't exist
+func Connect(driverName, dataSourceName string) (*Database, error) {
+	drivers.m.Lock()
+	driver, ok := drivers.drivers[driverName]
+	drivers.m.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("database driver %s not registered", driverName)
+	}
+
+	conn, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	if driver.MaxOpenConns > 0 {
+		conn.SetMaxOpenConns(driver.MaxOpenConns)
+	}
+	err = conn.Ping()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	db := &Database{sql: conn, driver: driver}
+	err = db.createTables()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Close closes the database
+func (d *Database) Close() error {
+	return d.sql.Close()
+}
+
+// SetConnected sets the database shared with packages which aren't passed
+// one, such as the exchange wrappers. A nil database disconnects it
+func SetConnected(db *Database) {
+	connected.m.Lock()
+	connected.db = db
+	connected.m.Unlock()
+}
+
+// GetConnected returns the shared database, ErrNotConnected when none is set
+func GetConnected() (*Database, error) {
+	connected.m.RLock()
+	defer connected.m.RUnlock()
+	if connected.db == nil {
+		return nil, ErrNotConnected
+	}
+	return connected.db, nil
+}
+
+// rebind replaces the ? bind parameters of a query with the drivers
+// placeholders
+func (d *Database) rebind(query string) string {
+	if d.driver.Placeholder == nil {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+		n++
+		b.WriteString(d.driver.Placeholder(n))
+	}
+	return b.String()
+}
+
+// createTables creates the tables and indexes if they don't exist
+func (d *Database) createTables() error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS orders (
+			id ` + d.driver.PrimaryKey + `,
+			exchange TEXT NOT NULL,
+			order_id TEXT NOT NULL,
+			base TEXT NOT NULL,
+			quote TEXT NOT NULL,
+			side TEXT NOT NULL,
+			order_type TEXT NOT NULL,
+			status TEXT NOT NULL,
+			is_open BOOLEAN NOT NULL,
+			price DOUBLE PRECISION NOT NULL,
+			amount DOUBLE PRECISION NOT NULL,
+			executed_amount DOUBLE PRECISION NOT NULL,
+			created_at BIGINT NOT NULL,
+			updated_at BIGINT NOT NULL,
+			UNIQUE (exchange, order_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS trades (
+			id ` + d.driver.PrimaryKey + `,
+			exchange TEXT NOT NULL,
+			order_id TEXT NOT NULL,
+			trade_id TEXT NOT NULL,
+			base TEXT NOT NULL,
+			quote TEXT NOT NULL,
+			side TEXT NOT NULL,
+			price DOUBLE PRECISION NOT NULL,
+			amount DOUBLE PRECISION NOT NULL,
+			fee DOUBLE PRECISION NOT NULL,
+			fee_currency TEXT NOT NULL,
+			executed_at BIGINT NOT NULL,
+			UNIQUE (exchange, order_id, trade_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS balances (
+			id ` + d.driver.PrimaryKey + `,
+			exchange TEXT NOT NULL,
+			account TEXT NOT NULL,
+			currency TEXT NOT NULL,
+			total DOUBLE PRECISION NOT NULL,
+			hold DOUBLE PRECISION NOT NULL,
+			taken_at BIGINT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS trades_executed_at ON trades (exchange, executed_at)`,
+		`CREATE INDEX IF NOT EXISTS orders_created_at ON orders (exchange, created_at)`,
+		`CREATE INDEX IF NOT EXISTS balances_taken_at ON balances (exchange, taken_at)`,
+	}
+	for _, statement := range statements {
+		_, err := d.sql.Exec(statement)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Query filters stored records, zero fields match every record. Base and
+// Quote are matched case insensitively, records are returned oldest first
+// and Limit keeps the most recent
+type Query struct {
+	Exchange string
+	Base     string
+	Quote    string
+	Start    time.Time
+	End      time.Time
+	Limit    int
+}
+
+// where returns the conditions and arguments filtering a tables records by
+// the query, timeColumn holding the time records are filtered on
+func (q *Query) where(timeColumn string, conditions []string, args []interface{}) (string, []interface{}) {
+	if q.Exchange != "" {
+		conditions = append(conditions, "LOWER(exchange) = ?")
+		args = append(args, common.StringToLower(q.Exchange))
+	}
+	if q.Base != "" {
+		conditions = append(conditions, "base = ?")
+		args = append(args, common.StringToUpper(q.Base))
+	}
+	if q.Quote != "" {
+		conditions = append(conditions, "quote = ?")
+		args = append(args, common.StringToUpper(q.Quote))
+	}
+	if !q.Start.IsZero() {
+		conditions = append(conditions, timeColumn+" >= ?")
+		args = append(args, q.Start.UnixNano())
+	}
+	if !q.End.IsZero() {
+		conditions = append(conditions, timeColumn+" < ?")
+		args = append(args, q.End.UnixNano())
+	}
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// selectQuery returns a select of a tables columns filtered by the query,
+// limited to the most recent records and ordered oldest first
+func (d *Database) selectQuery(table, columns, timeColumn string, q *Query, conditions []string, args []interface{}) (string, []interface{}) {
+	where, args := q.where(timeColumn, conditions, args)
+	query := "SELECT " + columns + " FROM " + table + where +
+		" ORDER BY " + timeColumn + " DESC, id DESC"
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+	return d.rebind("SELECT * FROM (" + query + ") AS recent ORDER BY " +
+		timeColumn + ", id"), args
+}
+
+// withTx runs fn in a transaction, committing it if fn succeeds
+func (d *Database) withTx(fn func(tx *sql.Tx) error) error {
+	tx, err := d.sql.Begin()
+	if err != nil {
+		return err
+	}
+	err = fn(tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRebind(t *testing.T) {
+	db := Database{driver: Driver{
+		Name:        DBPostgreSQL,
+		Placeholder: func(n int) string { return "$" + strconv.Itoa(n) },
+	}}
+	query := db.rebind("SELECT id FROM orders WHERE exchange = ? AND created_at < ?")
+	if query != "SELECT id FROM orders WHERE exchange = $1 AND created_at < $2" {
+		t.Errorf("Test failed. Unexpected rebound query %s", query)
+	}
+}
+
+func TestQueryWhere(t *testing.T) {
+	q := Query{Exchange: "Bitstamp", Base: "btc", Start: time.Unix(1, 0)}
+	where, args := q.where("executed_at", []string{"is_open = ?"}, []interface{}{true})
+	if where != " WHERE is_open = ? AND LOWER(exchange) = ? AND base = ? AND executed_at >= ?" ||
+		len(args) != 4 || args[1] != "bitstamp" || args[2] != "BTC" ||
+		args[3] != int64(1000000000) {
+		t.Errorf("Test failed. Unexpected conditions %s %v", where, args)
+	}
+
+	q = Query{}
+	if where, _ = q.where("executed_at", nil, nil); where != "" {
+		t.Errorf("Test failed. Expected an empty query to match everything, got %s", where)
+	}
+}
+
+func TestConnect(t *testing.T) {
+	_, err := Connect("mysql", "")
+	if err == nil {
+		t.Error("Test failed. Expected an unregistered driver to error")
+	}
+
+	SetConnected(nil)
+	if _, err = GetConnected(); err != ErrNotConnected {
+		t.Errorf("Test failed. Expected %v, got %v", ErrNotConnected, err)
+	}
+}
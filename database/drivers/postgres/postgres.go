@@ -0,0 +1,18 @@
+// Package postgres registers the PostgreSQL database driver
+package postgres
+
+import (
+	"strconv"
+
+	// Registers the postgres database/sql driver
+	_ "github.com/lib/pq"
+	"github.com/thrasher-/gocryptotrader/database"
+)
+
+func init() {
+	database.RegisterDriver(database.Driver{
+		Name:        database.DBPostgreSQL,
+		Placeholder: func(n int) string { return "$" + strconv.Itoa(n) },
+		PrimaryKey:  "BIGSERIAL PRIMARY KEY",
+	})
+}
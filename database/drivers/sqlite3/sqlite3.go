@@ -0,0 +1,19 @@
+// Package sqlite3 registers the SQLite database driver. SQLite databases are
+// limited to a single connection so writes from the sync routine and API
+// queries don't contend for the database lock
+package sqlite3
+
+import (
+	// Registers the sqlite3 database/sql driver
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/thrasher-/gocryptotrader/database"
+)
+
+func init() {
+	database.RegisterDriver(database.Driver{
+		Name:         database.DBSQLite3,
+		Placeholder:  func(int) string { return "?" },
+		PrimaryKey:   "INTEGER PRIMARY KEY AUTOINCREMENT",
+		MaxOpenConns: 1,
+	})
+}
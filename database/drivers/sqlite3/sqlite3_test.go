@@ -0,0 +1,201 @@
+package sqlite3
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/database"
+)
+
+func setupTestDatabase(t *testing.T) (*database.Database, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "gct-sqlite3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := database.Connect(database.DBSQLite3, filepath.Join(dir, "test.db"))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestOrders(t *testing.T) {
+	db, cleanup := setupTestDatabase(t)
+	defer cleanup()
+
+	start := time.Unix(1500000000, 0)
+	orders := []database.Order{
+		{Exchange: "Bitstamp", OrderID: "1", Base: "BTC", Quote: "USD", Side: "BUY",
+			OrderType: "LIMIT", Status: "NEW", Open: true, Price: 100, Amount: 1,
+			Created: start, Updated: start},
+		{Exchange: "Bitstamp", OrderID: "2", Base: "LTC", Quote: "USD", Side: "SELL",
+			OrderType: "LIMIT", Status: "NEW", Open: true, Price: 50, Amount: 2,
+			Created: start.Add(time.Minute), Updated: start.Add(time.Minute)},
+		{Exchange: "Kraken", OrderID: "1", Base: "BTC", Quote: "EUR", Status: "FILLED",
+			Created: start.Add(time.Hour), Updated: start.Add(time.Hour)},
+	}
+	err := db.UpsertOrders(orders)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.UpsertOrders([]database.Order{{Exchange: "Bitstamp", OrderID: "1",
+		Base: "BTC", Quote: "USD", Status: "PARTIALLY_FILLED", Open: true,
+		ExecutedAmount: 0.5, Created: start, Updated: start.Add(time.Hour)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := db.QueryOrders(&database.Query{Exchange: "bitstamp", Base: "btc"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 1 || stored[0].Status != "PARTIALLY_FILLED" ||
+		stored[0].Price != 100 || stored[0].Side != "BUY" ||
+		stored[0].ExecutedAmount != 0.5 || !stored[0].Updated.Equal(start.Add(time.Hour)) {
+		t.Errorf("Test failed. Expected order to be updated keeping its details, got %+v",
+			stored)
+	}
+
+	err = db.CloseOrders("Bitstamp", map[string]bool{"1": true}, start.Add(time.Hour),
+		"UNKNOWN", start.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err = db.QueryOrders(&database.Query{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 1 || stored[0].OrderID != "1" || stored[0].Exchange != "Bitstamp" {
+		t.Errorf("Test failed. Expected unlisted open orders to be closed, got %+v", stored)
+	}
+
+	stored, err = db.QueryOrders(&database.Query{Limit: 2}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 2 || stored[0].OrderID != "2" || stored[1].Exchange != "Kraken" {
+		t.Errorf("Test failed. Expected the most recent orders oldest first, got %+v", stored)
+	}
+}
+
+func TestTrades(t *testing.T) {
+	db, cleanup := setupTestDatabase(t)
+	defer cleanup()
+
+	start := time.Unix(1500000000, 0)
+	trades := []database.Trade{
+		{Exchange: "Bitstamp", OrderID: "1", TradeID: "1", Base: "BTC", Quote: "USD",
+			Side: "BUY", Price: 100, Amount: 0.5, Fee: 0.1, FeeCurrency: "USD",
+			Executed: start},
+		{Exchange: "Bitstamp", OrderID: "1", TradeID: "2", Base: "BTC", Quote: "USD",
+			Side: "BUY", Price: 101, Amount: 0.5, Executed: start.Add(time.Minute)},
+	}
+	for i := 0; i < 2; i++ {
+		err := db.InsertTrades(trades)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stored, err := db.QueryTrades(&database.Query{Start: start.Add(time.Second)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 1 || stored[0].TradeID != "2" || stored[0].Price != 101 {
+		t.Errorf("Test failed. Unexpected trades %+v", stored)
+	}
+	stored, err = db.QueryTrades(&database.Query{End: start.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 2 {
+		t.Errorf("Test failed. Expected duplicate trades to be ignored, got %d", len(stored))
+	}
+}
+
+func TestBalances(t *testing.T) {
+	db, cleanup := setupTestDatabase(t)
+	defer cleanup()
+
+	start := time.Unix(1500000000, 0)
+	for i := 0; i < 3; i++ {
+		err := db.InsertBalances([]database.Balance{
+			{Exchange: "Bitstamp", Currency: "BTC", Total: float64(i), Taken: start.Add(time.Duration(i) * time.Hour)},
+			{Exchange: "Bitstamp", Currency: "USD", Total: 1000, Taken: start.Add(time.Duration(i) * time.Hour)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stored, err := db.QueryBalances(&database.Query{Limit: 2}, "btc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 2 || stored[0].Total != 1 || stored[1].Total != 2 {
+		t.Errorf("Test failed. Unexpected balances %+v", stored)
+	}
+}
+
+func TestBalanceMaintenance(t *testing.T) {
+	db, cleanup := setupTestDatabase(t)
+	defer cleanup()
+
+	start := time.Unix(1500000000, 0).Truncate(time.Hour)
+	var balances []database.Balance
+	for i := 0; i < 4; i++ {
+		balances = append(balances, database.Balance{Exchange: "Bitstamp",
+			Currency: "BTC", Total: float64(i),
+			Taken: start.Add(time.Duration(i) * time.Minute * 20)})
+	}
+	for i := 0; i < 2; i++ {
+		err := db.InsertBalances(balances)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removed, err := db.DeduplicateBalances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 4 {
+		t.Errorf("Test failed. Expected 4 duplicate snapshots removed, got %d", removed)
+	}
+
+	compacted, err := db.CompactBalances(time.Hour, start.Add(time.Hour+time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if compacted != 2 {
+		t.Errorf("Test failed. Expected 2 snapshots compacted, got %d", compacted)
+	}
+
+	stored, err := db.QueryBalances(&database.Query{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 2 || stored[0].Total != 2 || stored[1].Total != 3 {
+		t.Errorf("Test failed. Expected the last snapshot of the complete hour and the incomplete hour kept, got %+v",
+			stored)
+	}
+
+	usage, err := db.GetUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usage) != 3 || usage[0].Rows != 0 || !usage[0].First.IsZero() ||
+		usage[2].Table != "balances" || usage[2].Rows != 2 ||
+		!usage[2].First.Equal(start.Add(time.Minute*40)) ||
+		!usage[2].Last.Equal(start.Add(time.Hour)) {
+		t.Errorf("Test failed. Unexpected usage %+v", usage)
+	}
+}
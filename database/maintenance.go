@@ -0,0 +1,83 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// TableUsage holds the storage statistics of a table, First and Last are the
+// times of its oldest and newest records
+type TableUsage struct {
+	Table string    `json:"table"`
+	Rows  int64     `json:"rows"`
+	First time.Time `json:"first"`
+	Last  time.Time `json:"last"`
+}
+
+// timedTables holds each table with the column its records are timed by
+var timedTables = []struct {
+	table      string
+	timeColumn string
+}{
+	{"orders", "created_at"},
+	{"trades", "executed_at"},
+	{"balances", "taken_at"},
+}
+
+// GetUsage returns the storage statistics of each table
+func (d *Database) GetUsage() ([]TableUsage, error) {
+	resp := make([]TableUsage, 0, len(timedTables))
+	for x := range timedTables {
+		u := TableUsage{Table: timedTables[x].table}
+		column := timedTables[x].timeColumn
+		query := "SELECT COUNT(*), MIN(" + column + "), MAX(" + column + ") FROM " + u.Table
+		var first, last sql.NullInt64
+		err := d.sql.QueryRow(query).Scan(&u.Rows, &first, &last)
+		if err != nil {
+			return nil, err
+		}
+		if first.Valid {
+			u.First = time.Unix(0, first.Int64)
+		}
+		if last.Valid {
+			u.Last = time.Unix(0, last.Int64)
+		}
+		resp = append(resp, u)
+	}
+	return resp, nil
+}
+
+// DeduplicateBalances removes balance snapshots of an account currency taken
+// at the same time, which overlapping syncs store more than once, keeping the
+// last stored. It returns the number of snapshots removed. Orders and trades
+// need no deduplication as their unique constraints ignore overlapping syncs
+func (d *Database) DeduplicateBalances() (int64, error) {
+	res, err := d.sql.Exec(`DELETE FROM balances WHERE id NOT IN (
+		SELECT MAX(id) FROM balances
+		GROUP BY exchange, account, currency, taken_at)`)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// CompactBalances keeps only the last stored balance snapshot of each account
+// currency per interval for snapshots taken before the cutoff, reducing them
+// from the sync resolution to the interval. It returns the number of
+// snapshots removed. The cutoff is truncated to the interval so only complete
+// intervals are compacted
+func (d *Database) CompactBalances(interval time.Duration, cutoff time.Time) (int64, error) {
+	if interval <= 0 {
+		return 0, nil
+	}
+	before := cutoff.Truncate(interval).UnixNano()
+	res, err := d.sql.Exec(d.rebind(`DELETE FROM balances WHERE taken_at < ?
+		AND id NOT IN (
+			SELECT MAX(id) FROM balances WHERE taken_at < ?
+			GROUP BY exchange, account, currency, taken_at / ?)`),
+		before, before, int64(interval))
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Order is a stored order. Open orders were listed open on their exchange
+// when last synced
+type Order struct {
+	ID             int64     `json:"id"`
+	Exchange       string    `json:"exchange"`
+	OrderID        string    `json:"orderId"`
+	Base           string    `json:"base"`
+	Quote          string    `json:"quote"`
+	Side           string    `json:"side"`
+	OrderType      string    `json:"orderType"`
+	Status         string    `json:"status"`
+	Open           bool      `json:"open"`
+	Price          float64   `json:"price"`
+	Amount         float64   `json:"amount"`
+	ExecutedAmount float64   `json:"executedAmount"`
+	Created        time.Time `json:"created"`
+	Updated        time.Time `json:"updated"`
+}
+
+const orderColumns = "id, exchange, order_id, base, quote, side, order_type, status, is_open, " +
+	"price, amount, executed_amount, created_at, updated_at"
+
+// UpsertOrders stores orders, updating the status, executed amount and update
+// time of orders already stored. The price, amount, side and type of stored
+// orders are only replaced when set
+func (d *Database) UpsertOrders(orders []Order) error {
+	query := d.rebind(`INSERT INTO orders (exchange, order_id, base, quote, side,
+		order_type, status, is_open, price, amount, executed_amount, created_at,
+		updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (exchange, order_id) DO UPDATE SET
+		side = CASE WHEN excluded.side = '' THEN orders.side ELSE excluded.side END,
+		order_type = CASE WHEN excluded.order_type = '' THEN orders.order_type ELSE excluded.order_type END,
+		price = CASE WHEN excluded.price = 0 THEN orders.price ELSE excluded.price END,
+		amount = CASE WHEN excluded.amount = 0 THEN orders.amount ELSE excluded.amount END,
+		status = excluded.status, is_open = excluded.is_open,
+		executed_amount = excluded.executed_amount, updated_at = excluded.updated_at`)
+	return d.withTx(func(tx *sql.Tx) error {
+		for x := range orders {
+			o := &orders[x]
+			_, err := tx.Exec(query, o.Exchange, o.OrderID, o.Base, o.Quote,
+				o.Side, o.OrderType, o.Status, o.Open, o.Price, o.Amount,
+				o.ExecutedAmount, o.Created.UnixNano(), o.Updated.UnixNano())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CloseOrders closes the open orders of an exchange created before a time
+// which aren't listed as still open, setting their status
+func (d *Database) CloseOrders(exchange string, stillOpen map[string]bool, before time.Time, status string, now time.Time) error {
+	return d.withTx(func(tx *sql.Tx) error {
+		rows, err := tx.Query(d.rebind(`SELECT order_id FROM orders
+			WHERE exchange = ? AND is_open = ? AND created_at < ?`),
+			exchange, true, before.UnixNano())
+		if err != nil {
+			return err
+		}
+		var closed []string
+		for rows.Next() {
+			var orderID string
+			err = rows.Scan(&orderID)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			if !stillOpen[orderID] {
+				closed = append(closed, orderID)
+			}
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return err
+		}
+
+		update := d.rebind(`UPDATE orders SET is_open = ?, status = ?, updated_at = ?
+			WHERE exchange = ? AND order_id = ?`)
+		for x := range closed {
+			_, err = tx.Exec(update, false, status, now.UnixNano(), exchange,
+				closed[x])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// QueryOrders returns the stored orders matching a query by their creation
+// time, only the open orders when openOnly is set
+func (d *Database) QueryOrders(q *Query, openOnly bool) ([]Order, error) {
+	var conditions []string
+	var args []interface{}
+	if openOnly {
+		conditions = append(conditions, "is_open = ?")
+		args = append(args, true)
+	}
+	query, args := d.selectQuery("orders", orderColumns, "created_at", q,
+		conditions, args)
+	rows, err := d.sql.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []Order
+	for rows.Next() {
+		var o Order
+		var created, updated int64
+		err = rows.Scan(&o.ID, &o.Exchange, &o.OrderID, &o.Base, &o.Quote,
+			&o.Side, &o.OrderType, &o.Status, &o.Open, &o.Price, &o.Amount,
+			&o.ExecutedAmount, &created, &updated)
+		if err != nil {
+			return nil, err
+		}
+		o.Created = time.Unix(0, created)
+		o.Updated = time.Unix(0, updated)
+		orders = append(orders, o)
+	}
+	return orders, rows.Err()
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Trade is a stored execution of an order
+type Trade struct {
+	ID          int64     `json:"id"`
+	Exchange    string    `json:"exchange"`
+	OrderID     string    `json:"orderId"`
+	TradeID     string    `json:"tradeId"`
+	Base        string    `json:"base"`
+	Quote       string    `json:"quote"`
+	Side        string    `json:"side"`
+	Price       float64   `json:"price"`
+	Amount      float64   `json:"amount"`
+	Fee         float64   `json:"fee"`
+	FeeCurrency string    `json:"feeCurrency"`
+	Executed    time.Time `json:"executed"`
+}
+
+const tradeColumns = "id, exchange, order_id, trade_id, base, quote, side, price, " +
+	"amount, fee, fee_currency, executed_at"
+
+// InsertTrades stores trades, ignoring those already stored
+func (d *Database) InsertTrades(trades []Trade) error {
+	query := d.rebind(`INSERT INTO trades (exchange, order_id, trade_id, base,
+		quote, side, price, amount, fee, fee_currency, executed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (exchange, order_id, trade_id) DO NOTHING`)
+	return d.withTx(func(tx *sql.Tx) error {
+		for x := range trades {
+			t := &trades[x]
+			_, err := tx.Exec(query, t.Exchange, t.OrderID, t.TradeID, t.Base,
+				t.Quote, t.Side, t.Price, t.Amount, t.Fee, t.FeeCurrency,
+				t.Executed.UnixNano())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// QueryTrades returns the stored trades matching a query by their execution
+// time
+func (d *Database) QueryTrades(q *Query) ([]Trade, error) {
+	query, args := d.selectQuery("trades", tradeColumns, "executed_at", q, nil, nil)
+	rows, err := d.sql.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trades []Trade
+	for rows.Next() {
+		var t Trade
+		var executed int64
+		err = rows.Scan(&t.ID, &t.Exchange, &t.OrderID, &t.TradeID, &t.Base,
+			&t.Quote, &t.Side, &t.Price, &t.Amount, &t.Fee, &t.FeeCurrency,
+			&executed)
+		if err != nil {
+			return nil, err
+		}
+		t.Executed = time.Unix(0, executed)
+		trades = append(trades, t)
+	}
+	return trades, rows.Err()
+}
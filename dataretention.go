@@ -0,0 +1,275 @@
+package main
+
+import (
+	"encoding/csv"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// Data types pruned by the data retention job
+const (
+	DataTypeCandles = "candles"
+	DataTypeTrades  = "trades"
+	DataTypeLogs    = "logs"
+)
+
+// candleSize is the in memory size of a stored candle, used to estimate the
+// candle store footprint
+const candleSize = int64(unsafe.Sizeof(kline.Candle{}))
+
+// DataRetentionUsage holds the footprint of a type of stored data after the
+// last prune and what the prune removed. Records are candles for candles,
+// exported rows for trades and files for logs
+type DataRetentionUsage struct {
+	DataType       string    `json:"dataType"`
+	RetentionDays  int       `json:"retentionDays"`
+	Records        int       `json:"records"`
+	Bytes          int64     `json:"bytes"`
+	PrunedRecords  int       `json:"prunedRecords"`
+	ReclaimedBytes int64     `json:"reclaimedBytes"`
+	Pruned         time.Time `json:"pruned"`
+}
+
+// dataRetentionMonitor holds the usage recorded by the last prune of each data
+// type
+type dataRetentionMonitor struct {
+	usage []DataRetentionUsage
+	m     sync.Mutex
+}
+
+var dataRetention dataRetentionMonitor
+
+// Update replaces the recorded usage
+func (d *dataRetentionMonitor) Update(usage []DataRetentionUsage) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.usage = usage
+}
+
+// GetAll returns the usage recorded by the last prune
+func (d *dataRetentionMonitor) GetAll() []DataRetentionUsage {
+	d.m.Lock()
+	defer d.m.Unlock()
+	return append([]DataRetentionUsage(nil), d.usage...)
+}
+
+// DataRetentionRoutine periodically prunes stored data older than its
+// configured retention
+func DataRetentionRoutine() {
+	log.Debugln("Starting data retention routine.")
+	for {
+		cfg := bot.config.DataRetention
+		pruneData(&cfg, filepath.Join(bot.dataDir, "trades"), log.LogPath,
+			clock.Now())
+		clock.Sleep(cfg.PruneInterval)
+	}
+}
+
+// pruneData prunes each type of stored data older than its retention and
+// records the footprint remaining
+func pruneData(cfg *config.DataRetentionConfig, tradeDir, logDir string, now time.Time) []DataRetentionUsage {
+	usage := []DataRetentionUsage{
+		pruneCandles(cfg.CandleDays, now),
+		pruneTradeExports(tradeDir, cfg.TradeDays, now),
+		pruneLogs(logDir, cfg.LogDays, now),
+	}
+	for x := range usage {
+		if usage[x].PrunedRecords > 0 {
+			log.Infof("Data retention pruned %d %s records, reclaiming %d bytes.",
+				usage[x].PrunedRecords, usage[x].DataType, usage[x].ReclaimedBytes)
+		}
+	}
+	dataRetention.Update(usage)
+	return usage
+}
+
+// retentionCutoff returns the time before which data is pruned, zero when
+// data is kept indefinitely
+func retentionCutoff(days int, now time.Time) time.Time {
+	if days <= 0 {
+		return time.Time{}
+	}
+	return now.AddDate(0, 0, -days)
+}
+
+// pruneCandles removes stored candles older than the retention
+func pruneCandles(days int, now time.Time) DataRetentionUsage {
+	usage := DataRetentionUsage{
+		DataType:      DataTypeCandles,
+		RetentionDays: days,
+		Pruned:        now,
+	}
+	if cutoff := retentionCutoff(days, now); !cutoff.IsZero() {
+		usage.PrunedRecords = kline.Prune(cutoff)
+		usage.ReclaimedBytes = int64(usage.PrunedRecords) * candleSize
+	}
+
+	series := kline.GetUsage()
+	for x := range series {
+		usage.Records += series[x].Candles
+	}
+	usage.Bytes = int64(usage.Records) * candleSize
+	return usage
+}
+
+// pruneTradeExports removes exported trades and orders older than the
+// retention from the trade export files in dir. The last record of each file
+// is always kept so exports resume after it
+func pruneTradeExports(dir string, days int, now time.Time) DataRetentionUsage {
+	usage := DataRetentionUsage{
+		DataType:      DataTypeTrades,
+		RetentionDays: days,
+		Pruned:        now,
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.csv"))
+	if err != nil {
+		return usage
+	}
+
+	tradeExportMtx.Lock()
+	defer tradeExportMtx.Unlock()
+	cutoff := retentionCutoff(days, now)
+	for x := range files {
+		records, pruned, reclaimed, err := pruneExportFile(files[x], cutoff)
+		if err != nil {
+			log.Errorf("Data retention failed to prune %s. Error: %s", files[x], err)
+		}
+		usage.Records += records
+		usage.PrunedRecords += pruned
+		usage.ReclaimedBytes += reclaimed
+		if info, err := os.Stat(files[x]); err == nil {
+			usage.Bytes += info.Size()
+		}
+	}
+	return usage
+}
+
+// pruneExportFile rewrites a trade or order export without its records older
+// than cutoff, returning the records kept and pruned and the bytes reclaimed.
+// Files which aren't trade or order exports are left untouched
+func pruneExportFile(path string, cutoff time.Time) (records, pruned int, reclaimed int64, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return 0, 0, 0, err
+	}
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	all, err := r.ReadAll()
+	f.Close()
+	if err != nil || len(all) == 0 {
+		return 0, 0, 0, err
+	}
+
+	var timestampField int
+	switch {
+	case len(all[0]) == len(tradeExportHeader) && all[0][0] == tradeExportHeader[0] &&
+		all[0][3] == tradeExportHeader[3]:
+		timestampField = 3
+	case len(all[0]) == len(orderExportHeader) && all[0][0] == orderExportHeader[0] &&
+		all[0][2] == orderExportHeader[2]:
+		timestampField = 2
+	default:
+		return 0, 0, 0, nil
+	}
+
+	rows := all[1:]
+	if cutoff.IsZero() {
+		return len(rows), 0, 0, nil
+	}
+
+	keep := [][]string{all[0]}
+	for x := range rows {
+		t, err := time.Parse(time.RFC3339Nano, rows[x][timestampField])
+		if err == nil && t.Before(cutoff) && x != len(rows)-1 {
+			pruned++
+			continue
+		}
+		keep = append(keep, rows[x])
+	}
+	if pruned == 0 {
+		return len(rows), 0, 0, nil
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path))
+	if err != nil {
+		return len(rows), 0, 0, err
+	}
+	w := csv.NewWriter(tmp)
+	err = w.WriteAll(keep)
+	tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return len(rows), 0, 0, err
+	}
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		os.Remove(tmp.Name())
+		return len(rows), 0, 0, err
+	}
+
+	if newInfo, err := os.Stat(path); err == nil {
+		reclaimed = info.Size() - newInfo.Size()
+	}
+	return len(keep) - 1, pruned, reclaimed, nil
+}
+
+// pruneLogs removes rotated log files in dir last written before the
+// retention, the active log file is never removed
+func pruneLogs(dir string, days int, now time.Time) DataRetentionUsage {
+	usage := DataRetentionUsage{
+		DataType:      DataTypeLogs,
+		RetentionDays: days,
+		Pruned:        now,
+	}
+	if dir == "" || log.Logger == nil || log.Logger.File == "" {
+		return usage
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return usage
+	}
+
+	cutoff := retentionCutoff(days, now)
+	for _, info := range files {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), log.Logger.File) {
+			continue
+		}
+		rotated := info.Name() != log.Logger.File
+		if rotated && !cutoff.IsZero() && info.ModTime().Before(cutoff) {
+			err = os.Remove(filepath.Join(dir, info.Name()))
+			if err == nil {
+				usage.PrunedRecords++
+				usage.ReclaimedBytes += info.Size()
+				continue
+			}
+			log.Errorf("Data retention failed to remove log %s. Error: %s",
+				info.Name(), err)
+		}
+		usage.Records++
+		usage.Bytes += info.Size()
+	}
+	return usage
+}
+
+// GetDataRetentionUsage returns the footprint of each type of stored data and
+// the space reclaimed by the last prune
+func GetDataRetentionUsage() []DataRetentionUsage {
+	return dataRetention.GetAll()
+}
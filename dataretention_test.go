@@ -0,0 +1,106 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+func TestPruneData(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dataretention")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tradeDir := filepath.Join(dir, "trades")
+	logDir := filepath.Join(dir, "logs")
+	for _, d := range []string{tradeDir, logDir} {
+		if err = os.Mkdir(d, 0750); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, 0, -10).Format(time.RFC3339Nano)
+	recent := now.AddDate(0, 0, -1).Format(time.RFC3339Nano)
+	trades := strings.Join(tradeExportHeader, ",") + "\n" +
+		"Bitstamp,BTCUSD,1," + old + ",BUY,100,1,0,,\n" +
+		"Bitstamp,BTCUSD,2," + recent + ",BUY,100,1,0,,\n" +
+		"Bitstamp,BTCUSD,3," + old + ",BUY,100,1,0,,\n"
+	files := map[string]string{
+		filepath.Join(tradeDir, "Bitstamp_BTCUSD.csv"): trades,
+		filepath.Join(tradeDir, "notes.csv"):           "note\n" + old + "\n",
+		filepath.Join(logDir, "log.txt"):               "active",
+		filepath.Join(logDir, "2019-05-01 log.txt"):    "rotated",
+	}
+	for path, data := range files {
+		if err = ioutil.WriteFile(path, []byte(data), 0640); err != nil {
+			t.Fatal(err)
+		}
+	}
+	oldTime := now.AddDate(0, 0, -40)
+	for _, name := range []string{"log.txt", "2019-05-01 log.txt"} {
+		err = os.Chtimes(filepath.Join(logDir, name), oldTime, oldTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	logFile := log.Logger.File
+	log.Logger.File = "log.txt"
+	defer func() { log.Logger.File = logFile }()
+
+	p := currency.NewPairFromStrings("BTC", "USD")
+	err = kline.Process("RetentionTest", p, ticker.Spot, time.Hour, []kline.Candle{
+		{Time: now.AddDate(0, 0, -400)}, {Time: now.Add(-time.Hour)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	usage := pruneData(&config.DataRetentionConfig{CandleDays: 365, TradeDays: 5, LogDays: 30},
+		tradeDir, logDir, now)
+	if len(usage) != 3 {
+		t.Fatalf("Test failed. Expected usage of 3 data types, got %d", len(usage))
+	}
+
+	if usage[0].DataType != DataTypeCandles || usage[0].PrunedRecords != 1 ||
+		usage[0].ReclaimedBytes != candleSize {
+		t.Errorf("Test failed. Unexpected candle usage %+v", usage[0])
+	}
+
+	if usage[1].PrunedRecords != 1 || usage[1].Records != 2 || usage[1].ReclaimedBytes <= 0 {
+		t.Errorf("Test failed. Unexpected trade usage %+v", usage[1])
+	}
+	data, err := ioutil.ReadFile(filepath.Join(tradeDir, "Bitstamp_BTCUSD.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "Bitstamp,BTCUSD,1,") ||
+		!strings.Contains(string(data), "Bitstamp,BTCUSD,3,") {
+		t.Errorf("Test failed. Expected old trades but the last to be pruned, got %s", data)
+	}
+	if id, err := getLastExportedTradeID(filepath.Join(tradeDir, "Bitstamp_BTCUSD.csv")); err != nil || id != 3 {
+		t.Errorf("Test failed. Expected the export to resume from trade 3, got %d %v", id, err)
+	}
+
+	if usage[2].PrunedRecords != 1 || usage[2].Records != 1 {
+		t.Errorf("Test failed. Expected the rotated log to be pruned, got %+v", usage[2])
+	}
+	if _, err = os.Stat(filepath.Join(logDir, "log.txt")); err != nil {
+		t.Error("Test failed. Expected the active log to be kept")
+	}
+
+	if len(GetDataRetentionUsage()) != 3 {
+		t.Error("Test failed. Expected the usage to be recorded")
+	}
+}
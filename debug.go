@@ -0,0 +1,34 @@
+package main
+
+import (
+	"github.com/thrasher-/gocryptotrader/config"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	debugSubsystemCurrency  = "currency"
+	debugSubsystemWebsocket = "websocket"
+)
+
+// debugSubsystems are the engine subsystems with verbose output
+var debugSubsystems = []string{debugSubsystemCurrency, debugSubsystemWebsocket}
+
+// getDebugSettings converts debug config into the logger settings of an
+// exchange or subsystem
+func getDebugSettings(cfg *config.DebugConfig, enabled bool) log.DebugSettings {
+	return log.DebugSettings{
+		Enabled:        enabled,
+		MaxPayloadSize: cfg.MaxPayloadSize,
+		SampleRate:     cfg.SampleRate,
+		ShowSecrets:    cfg.ShowSecrets,
+	}
+}
+
+// setupDebugSettings applies the engine debug config to each subsystem with
+// verbose output
+func setupDebugSettings(cfg *config.DebugConfig) {
+	for _, subsystem := range debugSubsystems {
+		log.SetDebugSettings(subsystem,
+			getDebugSettings(cfg, cfg.SubsystemEnabled(subsystem)))
+	}
+}
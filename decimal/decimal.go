@@ -0,0 +1,268 @@
+// Package decimal provides an exact base 10 number for prices, amounts, fees
+// and balances. Float64 values are converted using their shortest decimal
+// representation, so 0.1 is exactly 0.1 and sums such as 0.1 + 0.2 do not
+// carry binary rounding errors into order submissions.
+//
+// Exchange wrappers keep float64 at their boundary: SubmitOrder, GetFeeByType
+// and AccountCurrencyInfo take and return float64. Decimal is used for the
+// arithmetic on either side of it, normalising order amounts and prices before
+// submission, calculating trading fees and deriving or summing balances, and
+// results are converted back with Float64.
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact base 10 number, the value is unscaled * 10^-scale. The
+// zero value is zero
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+var (
+	errInvalidDecimal = errors.New("invalid decimal")
+	bigTen            = big.NewInt(10)
+)
+
+// New returns value * 10^-scale, New(125, 2) is 1.25
+func New(value int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{
+			unscaled: new(big.Int).Mul(big.NewInt(value), pow10(-scale)),
+		}
+	}
+	return Decimal{unscaled: big.NewInt(value), scale: scale}
+}
+
+// NewFromFloat returns the shortest decimal which converts back to f. NaN and
+// infinite values return zero
+func NewFromFloat(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}
+	}
+	d, _ := NewFromString(strconv.FormatFloat(f, 'f', -1, 64))
+	return d
+}
+
+// NewFromString parses a decimal such as "-12.5" or "1.5e-8"
+func NewFromString(s string) (Decimal, error) {
+	mantissa := s
+	var exp int64
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		exp, err = strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("%s %q", errInvalidDecimal, s)
+		}
+		mantissa = s[:i]
+	}
+
+	digits := mantissa
+	var scale int64
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		digits = mantissa[:i] + mantissa[i+1:]
+		scale = int64(len(mantissa) - i - 1)
+	}
+	if digits == "" || digits == "-" || digits == "+" ||
+		strings.ContainsAny(digits[1:], "+-") {
+		return Decimal{}, fmt.Errorf("%s %q", errInvalidDecimal, s)
+	}
+
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("%s %q", errInvalidDecimal, s)
+	}
+
+	scale -= exp
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(int32(-scale)))
+		scale = 0
+	}
+	if scale > math.MaxInt32 {
+		return Decimal{}, fmt.Errorf("%s %q", errInvalidDecimal, s)
+	}
+	return Decimal{unscaled: unscaled, scale: int32(scale)}, nil
+}
+
+// pow10 returns 10^n
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// value returns the unscaled value, which is nil for the zero value
+func (d Decimal) value() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// rescale returns the unscaled value of d at a larger scale
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale <= d.scale {
+		return d.value()
+	}
+	return new(big.Int).Mul(d.value(), pow10(scale-d.scale))
+}
+
+// align returns the unscaled values of d and o at their common scale
+func (d Decimal) align(o Decimal) (a, b *big.Int, scale int32) {
+	scale = d.scale
+	if o.scale > scale {
+		scale = o.scale
+	}
+	return d.rescale(scale), o.rescale(scale), scale
+}
+
+// Add returns d + o
+func (d Decimal) Add(o Decimal) Decimal {
+	a, b, scale := d.align(o)
+	return Decimal{unscaled: new(big.Int).Add(a, b), scale: scale}
+}
+
+// Sub returns d - o
+func (d Decimal) Sub(o Decimal) Decimal {
+	a, b, scale := d.align(o)
+	return Decimal{unscaled: new(big.Int).Sub(a, b), scale: scale}
+}
+
+// Mul returns d * o
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{
+		unscaled: new(big.Int).Mul(d.value(), o.value()),
+		scale:    d.scale + o.scale,
+	}
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.value()), scale: d.scale}
+}
+
+// Cmp returns -1, 0 or 1 when d is less than, equal to or greater than o
+func (d Decimal) Cmp(o Decimal) int {
+	a, b, _ := d.align(o)
+	return a.Cmp(b)
+}
+
+// Sign returns -1, 0 or 1 when d is negative, zero or positive
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+// IsZero returns whether d is zero
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Round rounds d half away from zero to a number of decimal places
+func (d Decimal) Round(places int32) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	if d.scale <= places {
+		return d
+	}
+	divisor := pow10(d.scale - places)
+	q, r := new(big.Int).QuoRem(d.value(), divisor, new(big.Int))
+	if r.Abs(r).Lsh(r, 1).Cmp(divisor) >= 0 {
+		q.Add(q, big.NewInt(int64(d.Sign())))
+	}
+	return Decimal{unscaled: q, scale: places}
+}
+
+// Truncate drops the digits of d past a number of decimal places, rounding
+// toward zero
+func (d Decimal) Truncate(places int32) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	if d.scale <= places {
+		return d
+	}
+	return Decimal{
+		unscaled: new(big.Int).Quo(d.value(), pow10(d.scale-places)),
+		scale:    places,
+	}
+}
+
+// FloorToStep returns the largest multiple of step which is not greater than
+// d, such as an order amount rounded down to an exchanges lot size. A step
+// which is not positive returns d unchanged
+func (d Decimal) FloorToStep(step Decimal) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	a, b, scale := d.align(step)
+	// Div is Euclidean so rounds toward negative infinity for a positive step
+	q := new(big.Int).Div(a, b)
+	return Decimal{unscaled: q.Mul(q, b), scale: scale}
+}
+
+// RoundToStep returns the multiple of step nearest to d, rounding half away
+// from zero, such as a price rounded to an exchanges tick size. A step which
+// is not positive returns d unchanged
+func (d Decimal) RoundToStep(step Decimal) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	a, b, scale := d.align(step)
+	q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+	if r.Abs(r).Lsh(r, 1).Cmp(b) >= 0 {
+		q.Add(q, big.NewInt(int64(a.Sign())))
+	}
+	return Decimal{unscaled: q.Mul(q, b), scale: scale}
+}
+
+// Float64 returns the nearest float64 to d
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String returns d without trailing zeros, such as "-0.125"
+func (d Decimal) String() string {
+	v := d.value()
+	digits := new(big.Int).Abs(v).String()
+	if d.scale > 0 {
+		if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		point := len(digits) - int(d.scale)
+		digits = strings.TrimRight(digits[:point]+"."+digits[point:], "0")
+		digits = strings.TrimSuffix(digits, ".")
+	}
+	if v.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// MarshalJSON encodes d as a string so no precision is lost
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON decodes a decimal from a string or number
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	v, err := NewFromString(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
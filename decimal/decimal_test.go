@@ -0,0 +1,136 @@
+package decimal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func mustParse(t *testing.T, s string) Decimal {
+	t.Helper()
+	d, err := NewFromString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestNewFromString(t *testing.T) {
+	tests := map[string]string{
+		"0":        "0",
+		"-12.50":   "-12.5",
+		"+1.5":     "1.5",
+		".25":      "0.25",
+		"-.5":      "-0.5",
+		"1.5e-8":   "0.000000015",
+		"1.5E3":    "1500",
+		"100":      "100",
+		"0.000100": "0.0001",
+	}
+	for input, expected := range tests {
+		if s := mustParse(t, input).String(); s != expected {
+			t.Errorf("expected %s to parse as %s, got %s", input, expected, s)
+		}
+	}
+
+	for _, input := range []string{"", ".", "-", "1.2.3", "--1", "1-2", "abc", "1e", "1ex"} {
+		if _, err := NewFromString(input); err == nil {
+			t.Errorf("expected an error parsing %q", input)
+		}
+	}
+}
+
+func TestNewFromFloat(t *testing.T) {
+	if s := NewFromFloat(0.1).String(); s != "0.1" {
+		t.Errorf("expected 0.1, got %s", s)
+	}
+	sum := NewFromFloat(0.1).Add(NewFromFloat(0.2))
+	if sum.Cmp(NewFromFloat(0.3)) != 0 || sum.Float64() != 0.3 {
+		t.Errorf("expected 0.1 + 0.2 to equal 0.3, got %s", sum)
+	}
+	if s := New(125, 2).String(); s != "1.25" {
+		t.Errorf("expected 1.25, got %s", s)
+	}
+	if s := New(5, -2).String(); s != "500" {
+		t.Errorf("expected 500, got %s", s)
+	}
+	var zero Decimal
+	if !zero.IsZero() || zero.String() != "0" || zero.Add(New(1, 0)).String() != "1" {
+		t.Error("expected the zero value to be zero")
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a := mustParse(t, "1.005")
+	b := mustParse(t, "0.3")
+	if s := a.Add(b).String(); s != "1.305" {
+		t.Errorf("unexpected sum %s", s)
+	}
+	if s := b.Sub(a).String(); s != "-0.705" {
+		t.Errorf("unexpected difference %s", s)
+	}
+	if s := a.Mul(b).String(); s != "0.3015" {
+		t.Errorf("unexpected product %s", s)
+	}
+	if s := a.Neg().String(); s != "-1.005" {
+		t.Errorf("unexpected negation %s", s)
+	}
+	if a.Cmp(b) != 1 || b.Cmp(a) != -1 || a.Cmp(mustParse(t, "1.00500")) != 0 {
+		t.Error("unexpected comparison")
+	}
+	if b.Sign() != 1 || b.Neg().Sign() != -1 {
+		t.Error("unexpected sign")
+	}
+}
+
+func TestRounding(t *testing.T) {
+	tests := []struct {
+		value, expected string
+		round           func(Decimal) Decimal
+	}{
+		{"1.005", "1.01", func(d Decimal) Decimal { return d.Round(2) }},
+		{"-1.005", "-1.01", func(d Decimal) Decimal { return d.Round(2) }},
+		{"1.004", "1", func(d Decimal) Decimal { return d.Round(2) }},
+		{"1.5", "1.5", func(d Decimal) Decimal { return d.Round(4) }},
+		{"1.999", "1.99", func(d Decimal) Decimal { return d.Truncate(2) }},
+		{"-1.999", "-1.99", func(d Decimal) Decimal { return d.Truncate(2) }},
+		{"0.123456", "0.1234", func(d Decimal) Decimal { return d.FloorToStep(New(1, 4)) }},
+		{"1.26", "1.25", func(d Decimal) Decimal { return d.FloorToStep(New(5, 2)) }},
+		{"-1.26", "-1.3", func(d Decimal) Decimal { return d.FloorToStep(New(5, 2)) }},
+		{"17", "15", func(d Decimal) Decimal { return d.FloorToStep(New(5, 0)) }},
+		{"1.26", "1.25", func(d Decimal) Decimal { return d.RoundToStep(New(5, 2)) }},
+		{"1.275", "1.3", func(d Decimal) Decimal { return d.RoundToStep(New(5, 2)) }},
+		{"1.26", "1.26", func(d Decimal) Decimal { return d.RoundToStep(Decimal{}) }},
+	}
+	for x := range tests {
+		if s := tests[x].round(mustParse(t, tests[x].value)).String(); s != tests[x].expected {
+			t.Errorf("test %d: expected %s to round to %s, got %s", x,
+				tests[x].value, tests[x].expected, s)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var v struct {
+		Price  Decimal `json:"price"`
+		Amount Decimal `json:"amount"`
+	}
+	err := json.Unmarshal([]byte(`{"price":"8000.10","amount":0.25}`), &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Price.String() != "8000.1" || v.Amount.String() != "0.25" {
+		t.Errorf("unexpected decoded values %s %s", v.Price, v.Amount)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"price":"8000.1","amount":"0.25"}` {
+		t.Errorf("unexpected encoding %s", data)
+	}
+
+	if err = json.Unmarshal([]byte(`{"price":"abc"}`), &v); err == nil {
+		t.Error("expected an error decoding an invalid decimal")
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"path/filepath"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/mock"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	demoConfigName      = "GoCryptoTrader Demo"
+	demoConfigFile      = "demo_config.json"
+	demoCredential      = "demo"
+	demoListenAddress   = "localhost:9050"
+	demoUpdateInterval  = time.Second * 5
+	demoVolatility      = 0.002
+	demoOrderbookLevels = 10
+	demoOrderbookDepth  = 5
+)
+
+// demoVenue is a simulated exchange run in demo mode. Spread is the fraction
+// of the price between its best bid and ask
+type demoVenue struct {
+	name    string
+	spread  float64
+	feeRate float64
+}
+
+// demoMarket is a simulated currency pair and the price it starts trading at
+type demoMarket struct {
+	pair  string
+	price float64
+}
+
+var (
+	demoVenues = []demoVenue{
+		{"DemoAlpha", 0.0005, 0.001},
+		{"DemoBeta", 0.001, 0.002},
+	}
+	demoMarkets = []demoMarket{
+		{"BTC-USD", 10000},
+		{"ETH-USD", 250},
+		{"LTC-USD", 90},
+		{"ETH-BTC", 0.025},
+	}
+	demoBalances = map[currency.Code]float64{
+		currency.BTC: 10,
+		currency.ETH: 200,
+		currency.LTC: 500,
+		currency.USD: 100000,
+	}
+)
+
+// demoSimulator random walks the prices of the demo markets and publishes them
+// to the mock exchanges as tickers and orderbooks
+type demoSimulator struct {
+	exchanges []*mock.Exchange
+	spreads   []float64
+	prices    []float64
+	rand      *rand.Rand
+}
+
+// registerDemoExchanges registers a mock exchange for each demo venue, funded
+// with the demo balances and holding the starting market data
+func registerDemoExchanges(seed int64) *demoSimulator {
+	sim := &demoSimulator{rand: rand.New(rand.NewSource(seed))}
+	for x := range demoMarkets {
+		sim.prices = append(sim.prices, demoMarkets[x].price)
+	}
+
+	for x := range demoVenues {
+		m := mock.New(demoVenues[x].name)
+		m.SetFeeRate(demoVenues[x].feeRate)
+
+		var balances []exchange.AccountCurrencyInfo
+		for code, amount := range demoBalances {
+			balances = append(balances,
+				exchange.NewAccountCurrencyInfoFromTotal(code, amount, 0))
+			m.SetDepositAddress(code, demoVenues[x].name+"-"+code.String())
+		}
+		m.SetAccountInfo(exchange.AccountInfo{
+			Accounts: []exchange.Account{{Currencies: balances}},
+		})
+
+		exchange.Register(exchange.Registration{
+			Name: demoVenues[x].name,
+			Creator: func() exchange.IBotExchange {
+				return m
+			},
+		})
+		sim.exchanges = append(sim.exchanges, m)
+		sim.spreads = append(sim.spreads, demoVenues[x].spread)
+	}
+	sim.publish()
+	return sim
+}
+
+// generateDemoConfig returns a config trading the demo markets on every demo
+// venue with the webserver enabled, so the REST and websocket servers can be
+// explored without any exchange accounts
+func generateDemoConfig() (*config.Config, error) {
+	pairs := make(currency.Pairs, 0, len(demoMarkets))
+	for x := range demoMarkets {
+		pairs = append(pairs, currency.NewPairDelimiter(demoMarkets[x].pair, "-"))
+	}
+
+	cfg := &config.Config{
+		Name:          demoConfigName,
+		EncryptConfig: -1,
+		Webserver: config.WebserverConfig{
+			Enabled:                      true,
+			AdminUsername:                demoCredential,
+			AdminPassword:                demoCredential,
+			ListenAddress:                demoListenAddress,
+			WebsocketAllowInsecureOrigin: true,
+		},
+	}
+	cfg.NTPClient.Level = -1
+	cfg.Currency.FiatDisplayCurrency = currency.USD
+
+	for x := range demoVenues {
+		exchCfg, err := mock.New(demoVenues[x].name).GetDefaultConfig()
+		if err != nil {
+			return nil, err
+		}
+		exchCfg.Enabled = true
+		exchCfg.AuthenticatedAPISupport = true
+		exchCfg.APIKey = demoCredential
+		exchCfg.APISecret = demoCredential
+		exchCfg.AvailablePairs = pairs
+		exchCfg.EnabledPairs = pairs
+		exchCfg.BaseCurrencies = currency.Currencies{currency.USD}
+		exchCfg.PairsLastUpdated = clock.Now().Unix()
+		cfg.Exchanges = append(cfg.Exchanges, *exchCfg)
+	}
+
+	err := cfg.CheckConfig()
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// setupDemo registers the demo exchanges and writes the demo config to the
+// data directory, returning the config path and the market simulator
+func setupDemo(dataDir string) (string, *demoSimulator, error) {
+	sim := registerDemoExchanges(clock.Now().UnixNano())
+	cfg, err := generateDemoConfig()
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = common.CreateDir(dataDir)
+	if err != nil {
+		return "", nil, err
+	}
+	path := filepath.Join(dataDir, demoConfigFile)
+	return path, sim, cfg.SaveConfig(path)
+}
+
+// step moves every demo market price by a random walk step
+func (d *demoSimulator) step() {
+	for x := range d.prices {
+		d.prices[x] *= math.Exp(d.rand.NormFloat64() * demoVolatility)
+	}
+	d.publish()
+}
+
+// publish sets the tickers and orderbooks of the demo markets on every mock
+// exchange, each venue quoting its own spread around the market price
+func (d *demoSimulator) publish() {
+	for x := range d.exchanges {
+		for y := range demoMarkets {
+			p := currency.NewPairDelimiter(demoMarkets[y].pair, "-")
+			halfSpread := d.prices[y] * d.spreads[x] / 2
+			bid := d.prices[y] - halfSpread
+			ask := d.prices[y] + halfSpread
+
+			ob := orderbook.Base{Pair: p}
+			for z := 0; z < demoOrderbookLevels; z++ {
+				offset := float64(z) * halfSpread
+				amount := demoOrderbookDepth * (1 + d.rand.Float64())
+				ob.Bids = append(ob.Bids, orderbook.Item{Price: bid - offset, Amount: amount})
+				ob.Asks = append(ob.Asks, orderbook.Item{Price: ask + offset, Amount: amount})
+			}
+			d.exchanges[x].SetOrderbook(ob)
+			d.exchanges[x].SetTicker(ticker.Price{
+				Pair:   p,
+				Last:   d.prices[y],
+				Bid:    bid,
+				Ask:    ask,
+				High:   d.prices[y] * (1 + demoVolatility),
+				Low:    d.prices[y] * (1 - demoVolatility),
+				Volume: demoOrderbookDepth * demoOrderbookLevels,
+			})
+		}
+	}
+}
+
+// DemoRoutine periodically moves the prices of the demo markets
+func DemoRoutine(sim *demoSimulator) {
+	log.Debugln("Starting demo market simulation routine.")
+	for {
+		clock.Sleep(demoUpdateInterval)
+		sim.step()
+	}
+}
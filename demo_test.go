@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+func TestGenerateDemoConfig(t *testing.T) {
+	cfg, err := generateDemoConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cfg.Webserver.Enabled || cfg.CountEnabledExchanges() != len(demoVenues) {
+		t.Errorf("Test failed. Expected the webserver and %d demo exchanges enabled, got %v %d",
+			len(demoVenues), cfg.Webserver.Enabled, cfg.CountEnabledExchanges())
+	}
+	for x := range cfg.Exchanges {
+		if !cfg.Exchanges[x].AuthenticatedAPISupport ||
+			len(cfg.Exchanges[x].EnabledPairs) != len(demoMarkets) {
+			t.Errorf("Test failed. Unexpected demo exchange config %+v", cfg.Exchanges[x])
+		}
+	}
+}
+
+func TestDemoSimulator(t *testing.T) {
+	sim := registerDemoExchanges(1)
+	for x := range demoVenues {
+		defer exchange.Deregister(demoVenues[x].name)
+	}
+
+	registration, ok := exchange.GetRegistration(demoVenues[0].name)
+	if !ok {
+		t.Fatal("Test failed. Expected the demo exchanges to be registered")
+	}
+	exch := registration.Creator()
+	p := currency.NewPairDelimiter(demoMarkets[0].pair, "-")
+	before, err := exch.UpdateTicker(p, ticker.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.Last != demoMarkets[0].price || before.Bid >= before.Ask {
+		t.Errorf("Test failed. Unexpected starting ticker %+v", before)
+	}
+
+	sim.step()
+	after, err := exch.UpdateTicker(p, ticker.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Last == before.Last {
+		t.Error("Test failed. Expected the simulated price to move")
+	}
+
+	info, err := exch.GetAccountInfo()
+	if err != nil || len(info.Accounts) != 1 ||
+		len(info.Accounts[0].Currencies) != len(demoBalances) {
+		t.Errorf("Test failed. Expected the demo exchange to be funded, got %+v %v",
+			info, err)
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// DustBalance holds a balance identified as dust. Value is in the target
+// currency of the market the balance is traded against
+type DustBalance struct {
+	Currency     currency.Code `json:"currency"`
+	Pair         currency.Pair `json:"pair"`
+	Amount       float64       `json:"amount"`
+	Value        float64       `json:"value"`
+	BelowMinimum bool          `json:"belowMinimum"`
+}
+
+// DustSweep holds the result of converting an exchanges dust balances into the
+// target currency. Received is the amount returned by the exchanges dust
+// conversion, market orders placed for the remaining balances are in Orders
+type DustSweep struct {
+	Exchange    string        `json:"exchange"`
+	Target      currency.Code `json:"target"`
+	Converted   []DustBalance `json:"converted"`
+	Unconverted []DustBalance `json:"unconverted"`
+	Received    float64       `json:"received"`
+	Orders      []string      `json:"orders"`
+	Errors      []string      `json:"errors,omitempty"`
+}
+
+// GetDustBalances returns the dust balances of an exchange against the
+// configured dust sweep target currency
+func GetDustBalances(exchName string) ([]DustBalance, error) {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return nil, errors.New(exchange.ErrExchangeNotFound)
+	}
+	return findDustBalances(exch, bot.config.DustSweep.TargetCurrency,
+		bot.config.DustSweep.ValueThreshold)
+}
+
+// SweepDust converts the dust balances of an exchange into the configured
+// dust sweep target currency
+func SweepDust(exchName string) (DustSweep, error) {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return DustSweep{}, errors.New(exchange.ErrExchangeNotFound)
+	}
+	return sweepDust(exch, bot.config.DustSweep.TargetCurrency,
+		bot.config.DustSweep.ValueThreshold)
+}
+
+// findDustBalances returns the balances which are below the minimum order size
+// of their market against the target currency, or worth less than the value
+// threshold. Balances without a market against the target are ignored
+func findDustBalances(exch exchange.IBotExchange, target currency.Code, threshold float64) ([]DustBalance, error) {
+	limiter, ok := exch.(exchange.IOrderLimitsExchange)
+	if !ok {
+		return nil, fmt.Errorf("exchange %s does not report minimum order sizes",
+			exch.GetName())
+	}
+
+	info, err := GetExchangeAccountInfo(exch, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var codes []currency.Code
+	for x := range info.Accounts {
+		for y := range info.Accounts[x].Currencies {
+			c := info.Accounts[x].Currencies[y].CurrencyName
+			if c.Match(target) || currency.Currencies(codes).Contains(c) {
+				continue
+			}
+			codes = append(codes, c)
+		}
+	}
+
+	var dust []DustBalance
+	pairs := exch.GetAvailableCurrencies()
+	for x := range codes {
+		amount := getAvailableBalance(&info, codes[x])
+		if amount <= 0 {
+			continue
+		}
+
+		var p currency.Pair
+		for y := range pairs {
+			if pairs[y].Base.Match(codes[x]) && pairs[y].Quote.Match(target) {
+				p = pairs[y]
+				break
+			}
+		}
+		if p.IsEmpty() {
+			continue
+		}
+
+		limits, err := limiter.GetOrderLimits(p)
+		if err != nil {
+			log.Warnf("Unable to check %s %s minimum order size: %s",
+				exch.GetName(), p, err)
+			continue
+		}
+
+		price, err := exch.GetTickerPrice(p, ticker.Spot)
+		if err != nil {
+			log.Warnf("Unable to value %s %s dust: %s", exch.GetName(), p, err)
+			continue
+		}
+
+		balance := DustBalance{
+			Currency: codes[x],
+			Pair:     p,
+			Amount:   amount,
+			Value:    amount * price.Last,
+		}
+		balance.BelowMinimum = amount < limits.MinAmount ||
+			balance.Value < limits.MinNotional
+		if balance.BelowMinimum || balance.Value < threshold {
+			dust = append(dust, balance)
+		}
+	}
+	return dust, nil
+}
+
+// sweepDust converts balances below the minimum order size using the
+// exchanges dust conversion when it converts into the target currency, and
+// sells the remaining dust with market orders. Balance reserves are respected
+func sweepDust(exch exchange.IBotExchange, target currency.Code, threshold float64) (DustSweep, error) {
+	resp := DustSweep{
+		Exchange: exch.GetName(),
+		Target:   target,
+	}
+
+	dust, err := findDustBalances(exch, target, threshold)
+	if err != nil {
+		return resp, err
+	}
+
+	converter, ok := exch.(exchange.IDustConversionExchange)
+	canConvert := ok && converter.GetDustConversionCurrency().Match(target)
+
+	var convert []DustBalance
+	for x := range dust {
+		err = checkBalanceReserve(exch, dust[x].Currency, dust[x].Amount)
+		if err != nil {
+			resp.Unconverted = append(resp.Unconverted, dust[x])
+			resp.Errors = append(resp.Errors, err.Error())
+			continue
+		}
+
+		if dust[x].BelowMinimum {
+			if canConvert {
+				convert = append(convert, dust[x])
+			} else {
+				resp.Unconverted = append(resp.Unconverted, dust[x])
+			}
+			continue
+		}
+
+		order, err := SubmitTaggedOrder(exch, dust[x].Pair, exchange.SellOrderSide,
+			exchange.MarketOrderType, dust[x].Amount, 0, "",
+			OrderTags{OrderTagReason: "dust sweep"})
+		if err != nil {
+			resp.Unconverted = append(resp.Unconverted, dust[x])
+			resp.Errors = append(resp.Errors, err.Error())
+			continue
+		}
+		resp.Converted = append(resp.Converted, dust[x])
+		resp.Orders = append(resp.Orders, order.OrderID)
+	}
+
+	if len(convert) == 0 {
+		return resp, nil
+	}
+
+	codes := make([]currency.Code, len(convert))
+	for x := range convert {
+		codes[x] = convert[x].Currency
+	}
+	resp.Received, err = convertExchangeDust(converter, codes)
+	if err != nil {
+		resp.Unconverted = append(resp.Unconverted, convert...)
+		resp.Errors = append(resp.Errors, err.Error())
+		return resp, nil
+	}
+	resp.Converted = append(resp.Converted, convert...)
+	log.Debugf("%s converted %d dust balances into %f %s", exch.GetName(),
+		len(convert), resp.Received, target)
+	InvalidateExchangeAccountInfo(exch.GetName())
+	return resp, nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+type dustTestExchange struct {
+	accountInfoTestExchange
+	orders    []currency.Pair
+	converted []currency.Code
+}
+
+func (d *dustTestExchange) GetAccountInfo() (exchange.AccountInfo, error) {
+	return exchange.AccountInfo{
+		Exchange: d.name,
+		Accounts: []exchange.Account{{
+			Currencies: []exchange.AccountCurrencyInfo{
+				exchange.NewAccountCurrencyInfoFromTotal(currency.BTC, 1, 0),
+				exchange.NewAccountCurrencyInfoFromTotal(currency.LTC, 0.005, 0),
+				exchange.NewAccountCurrencyInfoFromTotal(currency.ETH, 0.05, 0),
+				exchange.NewAccountCurrencyInfoFromTotal(currency.XRP, 5, 0),
+				exchange.NewAccountCurrencyInfoFromTotal(currency.DOGE, 1, 0),
+				exchange.NewAccountCurrencyInfoFromTotal(currency.NEO, 100, 0),
+			},
+		}},
+	}, nil
+}
+
+func (d *dustTestExchange) GetAvailableCurrencies() currency.Pairs {
+	return currency.Pairs{
+		currency.NewPairFromStrings("LTC", "BTC"),
+		currency.NewPairFromStrings("ETH", "BTC"),
+		currency.NewPairFromStrings("XRP", "BTC"),
+		currency.NewPairFromStrings("NEO", "BTC"),
+	}
+}
+
+func (d *dustTestExchange) GetOrderLimits(p currency.Pair) (exchange.OrderLimits, error) {
+	return exchange.OrderLimits{MinAmount: 0.01, MinNotional: 0.001}, nil
+}
+
+func (d *dustTestExchange) GetTickerPrice(p currency.Pair, assetType string) (ticker.Price, error) {
+	switch {
+	case p.Base.Match(currency.LTC):
+		return ticker.Price{Last: 0.01}, nil
+	case p.Base.Match(currency.ETH):
+		return ticker.Price{Last: 0.03}, nil
+	case p.Base.Match(currency.XRP):
+		return ticker.Price{Last: 0.0001}, nil
+	}
+	return ticker.Price{Last: 0.002}, nil
+}
+
+func (d *dustTestExchange) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	d.orders = append(d.orders, p)
+	return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "1"}, nil
+}
+
+func (d *dustTestExchange) GetDustConversionCurrency() currency.Code {
+	return currency.BTC
+}
+
+func (d *dustTestExchange) ConvertDust(currencies []currency.Code) (float64, error) {
+	if len(d.converted) > 0 {
+		return 0, errors.New("conversion already requested")
+	}
+	d.converted = currencies
+	return 0.0005, nil
+}
+
+func setupDustTest(t *testing.T) *dustTestExchange {
+	exch := setupAccountInfoTest(t, time.Minute)
+	exchCfg, err := bot.config.GetExchangeConfig(exch.GetName())
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchCfg.BalanceReserves = map[string]float64{"XRP": 1}
+	err = bot.config.UpdateExchangeConfig(&exchCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dustTestExchange{accountInfoTestExchange: *exch}
+}
+
+func TestFindDustBalances(t *testing.T) {
+	exch := setupDustTest(t)
+
+	dust, err := findDustBalances(exch, currency.BTC, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dust) != 2 || !dust[0].Currency.Match(currency.LTC) ||
+		!dust[1].Currency.Match(currency.XRP) || !dust[0].BelowMinimum {
+		t.Fatalf("Test failed. Expected LTC and XRP dust below the minimum, got %+v", dust)
+	}
+
+	dust, err = findDustBalances(exch, currency.BTC, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dust) != 3 || !dust[1].Currency.Match(currency.ETH) || dust[1].BelowMinimum {
+		t.Errorf("Test failed. Expected ETH dust under the value threshold, got %+v", dust)
+	}
+
+	_, err = findDustBalances(&accountInfoTestExchange{name: "Bitstamp"}, currency.BTC, 0)
+	if err == nil {
+		t.Error("Test failed. Expected error for an exchange without order limits")
+	}
+}
+
+func TestSweepDust(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+
+	exch := setupDustTest(t)
+
+	resp, err := sweepDust(exch, currency.BTC, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exch.converted) != 1 || !exch.converted[0].Match(currency.LTC) ||
+		resp.Received != 0.0005 {
+		t.Errorf("Test failed. Expected LTC dust conversion, got %v", exch.converted)
+	}
+	if len(exch.orders) != 1 || !exch.orders[0].Base.Match(currency.ETH) {
+		t.Errorf("Test failed. Expected ETH market order, got %v", exch.orders)
+	}
+	if len(resp.Converted) != 2 {
+		t.Errorf("Test failed. Expected 2 converted balances, got %+v", resp.Converted)
+	}
+	if len(resp.Unconverted) != 1 || !resp.Unconverted[0].Currency.Match(currency.XRP) ||
+		len(resp.Errors) != 1 {
+		t.Errorf("Test failed. Expected reserved XRP to be left unconverted, got %+v", resp)
+	}
+
+	resp, err = sweepDust(exch, currency.ETH, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Converted) != 0 {
+		t.Errorf("Test failed. Expected no conversion into an unsupported target, got %+v", resp)
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// Endpoint health thresholds
+const (
+	endpointDegradedThreshold = 3
+	endpointBackoffBase       = time.Second * 30
+	endpointBackoffMax        = time.Minute * 10
+
+	// endpointGeoRestrictedBackoff is how long geo-restricted endpoints are
+	// skipped, retrying them sooner risks the IP address being banned
+	endpointGeoRestrictedBackoff = time.Hour * 24
+)
+
+// EndpointHealth holds the health of an exchanges REST endpoint. Geo-restricted
+// endpoints rejected the request because of the location of the IP address
+type EndpointHealth struct {
+	Exchange            string    `json:"exchange"`
+	Endpoint            string    `json:"endpoint"`
+	Degraded            bool      `json:"degraded"`
+	GeoRestricted       bool      `json:"geoRestricted"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	LastError           string    `json:"lastError,omitempty"`
+	LastFailure         time.Time `json:"lastFailure,omitempty"`
+	LastSuccess         time.Time `json:"lastSuccess,omitempty"`
+	BackoffUntil        time.Time `json:"backoffUntil,omitempty"`
+}
+
+// endpointHealthTracker tracks consecutive endpoint failures so persistently
+// failing endpoints can be skipped with backoff while others keep updating
+type endpointHealthTracker struct {
+	endpoints map[string]*EndpointHealth
+	m         sync.Mutex
+}
+
+var endpointHealth = endpointHealthTracker{
+	endpoints: make(map[string]*EndpointHealth),
+}
+
+func (e *endpointHealthTracker) get(exchName, endpoint string) *EndpointHealth {
+	key := exchName + endpoint
+	h, ok := e.endpoints[key]
+	if !ok {
+		h = &EndpointHealth{Exchange: exchName, Endpoint: endpoint}
+		e.endpoints[key] = h
+	}
+	return h
+}
+
+// ShouldSkip returns whether a degraded endpoint is still backing off
+func (e *endpointHealthTracker) ShouldSkip(exchName, endpoint string, now time.Time) bool {
+	e.m.Lock()
+	defer e.m.Unlock()
+	h := e.get(exchName, endpoint)
+	return h.Degraded && now.Before(h.BackoffUntil)
+}
+
+// IsDegraded returns whether an endpoint is degraded, whether or not it is
+// still backing off
+func (e *endpointHealthTracker) IsDegraded(exchName, endpoint string) bool {
+	e.m.Lock()
+	defer e.m.Unlock()
+	h, ok := e.endpoints[exchName+endpoint]
+	return ok && h.Degraded
+}
+
+// Record records the result of an endpoint request. It returns true when the
+// endpoint becomes degraded or recovers so the caller can surface the change.
+// Geo-restricted endpoints are degraded immediately and not retried until the
+// geo-restriction backoff passes
+func (e *endpointHealthTracker) Record(exchName, endpoint string, err error, now time.Time) (changed bool) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	h := e.get(exchName, endpoint)
+
+	if err == nil {
+		changed = h.Degraded
+		h.Degraded = false
+		h.GeoRestricted = false
+		h.ConsecutiveFailures = 0
+		h.LastError = ""
+		h.BackoffUntil = time.Time{}
+		h.LastSuccess = now
+		return changed
+	}
+
+	h.ConsecutiveFailures++
+	h.LastError = err.Error()
+	h.LastFailure = now
+	if request.IsGeoRestricted(err) {
+		changed = !h.GeoRestricted
+		h.Degraded = true
+		h.GeoRestricted = true
+		h.BackoffUntil = now.Add(endpointGeoRestrictedBackoff)
+		return changed
+	}
+	if h.ConsecutiveFailures < endpointDegradedThreshold {
+		return false
+	}
+
+	backoff := endpointBackoffBase
+	for i := endpointDegradedThreshold; i < h.ConsecutiveFailures && backoff < endpointBackoffMax; i++ {
+		backoff *= 2
+	}
+	if backoff > endpointBackoffMax {
+		backoff = endpointBackoffMax
+	}
+	h.BackoffUntil = now.Add(backoff)
+
+	changed = !h.Degraded
+	h.Degraded = true
+	return changed
+}
+
+// Restore replaces the tracked endpoints with previously saved endpoint health
+func (e *endpointHealthTracker) Restore(endpoints []EndpointHealth) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	e.endpoints = make(map[string]*EndpointHealth)
+	for x := range endpoints {
+		h := endpoints[x]
+		e.endpoints[h.Exchange+h.Endpoint] = &h
+	}
+}
+
+// GetAll returns the health of all tracked endpoints
+func (e *endpointHealthTracker) GetAll() []EndpointHealth {
+	e.m.Lock()
+	defer e.m.Unlock()
+	var resp []EndpointHealth
+	for _, h := range e.endpoints {
+		resp = append(resp, *h)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].Exchange == resp[j].Exchange {
+			return resp[i].Endpoint < resp[j].Endpoint
+		}
+		return resp[i].Exchange < resp[j].Exchange
+	})
+	return resp
+}
+
+// notifyEndpointHealth logs and pushes an endpoint degradation or recovery
+// through the communications package
+func notifyEndpointHealth(exchName, endpoint string, err error) {
+	var message string
+	switch {
+	case request.IsGeoRestricted(err):
+		message = i18n.T(i18n.MessageEndpointRestricted, exchName, endpoint,
+			endpointGeoRestrictedBackoff)
+		log.Warn(message)
+	case err != nil:
+		message = i18n.T(i18n.MessageEndpointDegraded, exchName, endpoint, err)
+		log.Warn(message)
+	default:
+		message = i18n.T(i18n.MessageEndpointRecovered, exchName, endpoint)
+		log.Info(message)
+	}
+
+	pushEvent("ENDPOINT_HEALTH", message)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+)
+
+func TestEndpointHealthTracker(t *testing.T) {
+	e := endpointHealthTracker{endpoints: make(map[string]*EndpointHealth)}
+	now := time.Now()
+	errTest := errors.New("HTTP status code 502")
+
+	for i := 1; i < endpointDegradedThreshold; i++ {
+		if e.Record("Bitstamp", pollOrderbook, errTest, now) {
+			t.Fatal("Test failed. Endpoint degraded before reaching threshold")
+		}
+	}
+	if !e.Record("Bitstamp", pollOrderbook, errTest, now) {
+		t.Fatal("Test failed. Expected endpoint to become degraded")
+	}
+	if e.Record("Bitstamp", pollTicker, nil, now) {
+		t.Error("Test failed. Healthy endpoint should not report a change")
+	}
+
+	if !e.ShouldSkip("Bitstamp", pollOrderbook, now) {
+		t.Error("Test failed. Expected degraded endpoint to be skipped")
+	}
+	if e.ShouldSkip("Bitstamp", pollTicker, now) {
+		t.Error("Test failed. Expected healthy endpoint not to be skipped")
+	}
+	if e.ShouldSkip("Bitstamp", pollOrderbook, now.Add(endpointBackoffBase)) {
+		t.Error("Test failed. Expected endpoint to be retried after backoff")
+	}
+
+	if e.Record("Bitstamp", pollOrderbook, errTest, now) {
+		t.Error("Test failed. Already degraded endpoint should not report a change")
+	}
+	if !e.ShouldSkip("Bitstamp", pollOrderbook, now.Add(endpointBackoffBase)) {
+		t.Error("Test failed. Expected backoff to increase with further failures")
+	}
+
+	if !e.Record("Bitstamp", pollOrderbook, nil, now) {
+		t.Error("Test failed. Expected endpoint recovery to report a change")
+	}
+
+	health := e.GetAll()
+	if len(health) != 2 || health[0].Endpoint != pollOrderbook || health[0].Degraded {
+		t.Errorf("Test failed. Unexpected endpoint health %v", health)
+	}
+}
+
+func TestEndpointHealthGeoRestricted(t *testing.T) {
+	e := endpointHealthTracker{endpoints: make(map[string]*EndpointHealth)}
+	now := time.Now()
+	errRestricted := request.NewError("OKEX", http.MethodGet, "/api/spot/v3/instruments",
+		request.ErrGeoRestricted)
+
+	if !e.Record("OKEX", pollTicker, errRestricted, now) {
+		t.Fatal("Test failed. Expected a geo-restricted endpoint to degrade immediately")
+	}
+	if e.Record("OKEX", pollTicker, errRestricted, now) {
+		t.Error("Test failed. Already geo-restricted endpoint should not report a change")
+	}
+	if !e.ShouldSkip("OKEX", pollTicker, now.Add(endpointBackoffMax)) {
+		t.Error("Test failed. Expected a geo-restricted endpoint not to be retried")
+	}
+	if e.ShouldSkip("OKEX", pollTicker, now.Add(endpointGeoRestrictedBackoff)) {
+		t.Error("Test failed. Expected a geo-restricted endpoint to be retried after its backoff")
+	}
+
+	if !e.Record("OKEX", pollTicker, nil, now) {
+		t.Error("Test failed. Expected endpoint recovery to report a change")
+	}
+	if health := e.GetAll(); health[0].GeoRestricted || health[0].Degraded {
+		t.Errorf("Test failed. Expected the geo-restriction to be cleared, got %+v", health[0])
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// EndpointLatencyStatus holds the latency percentiles of an exchange endpoint
+// and whether they exceeded its thresholds when last checked. It is tracked
+// separately from endpoint health, slow endpoints keep being requested
+type EndpointLatencyStatus struct {
+	request.EndpointLatency
+	Slow bool `json:"slow"`
+}
+
+// slowEndpoints holds the endpoints whose latency exceeded their thresholds
+// when last checked
+var slowEndpoints = struct {
+	endpoints map[string]bool
+	m         sync.Mutex
+}{
+	endpoints: make(map[string]bool),
+}
+
+func getEndpointLatencyKey(l *request.EndpointLatency) string {
+	return l.Exchange + " " + l.Method + " " + l.Endpoint
+}
+
+// getLatencyThreshold returns the latency threshold of an endpoint, the first
+// configured for its path over the first configured for its exchange, falling
+// back to the default percentiles
+func getLatencyThreshold(cfg *config.LatencyMonitorConfig, l *request.EndpointLatency) config.LatencyThresholdConfig {
+	threshold := config.LatencyThresholdConfig{
+		Exchange: l.Exchange,
+		P95:      cfg.P95,
+		P99:      cfg.P99,
+	}
+	var exchangeMatched bool
+	for x := range cfg.Thresholds {
+		t := cfg.Thresholds[x]
+		if !strings.EqualFold(t.Exchange, l.Exchange) {
+			continue
+		}
+		if t.Endpoint == "" {
+			if !exchangeMatched {
+				threshold = t
+				exchangeMatched = true
+			}
+			continue
+		}
+		if common.StringContains(common.StringToLower(l.Endpoint),
+			common.StringToLower(t.Endpoint)) {
+			return t
+		}
+	}
+	return threshold
+}
+
+// getSlowPercentile returns the highest percentile of an endpoint exceeding its
+// threshold along with its latency and threshold, ok is false when none do
+func getSlowPercentile(threshold *config.LatencyThresholdConfig, l *request.EndpointLatency) (percentile int, latency, limit time.Duration, ok bool) {
+	switch {
+	case threshold.P99 > 0 && l.P99 > threshold.P99:
+		return 99, l.P99, threshold.P99, true
+	case threshold.P95 > 0 && l.P95 > threshold.P95:
+		return 95, l.P95, threshold.P95, true
+	case threshold.P50 > 0 && l.P50 > threshold.P50:
+		return 50, l.P50, threshold.P50, true
+	}
+	return 0, 0, 0, false
+}
+
+// checkEndpointLatencies compares the latency percentiles of every endpoint
+// with enough requests against their thresholds, notifying when an endpoint
+// becomes slow or recovers. Endpoints without enough requests keep their
+// state, those no longer requested are forgotten
+func checkEndpointLatencies(cfg *config.LatencyMonitorConfig, now time.Time) {
+	latencies := request.GetLatencies(now)
+
+	slowEndpoints.m.Lock()
+	defer slowEndpoints.m.Unlock()
+	requested := make(map[string]bool, len(latencies))
+	for x := range latencies {
+		l := &latencies[x]
+		key := getEndpointLatencyKey(l)
+		requested[key] = true
+		if l.Requests < cfg.MinSamples {
+			continue
+		}
+
+		threshold := getLatencyThreshold(cfg, l)
+		percentile, latency, limit, slow := getSlowPercentile(&threshold, l)
+		switch {
+		case slow && !slowEndpoints.endpoints[key]:
+			notifyEndpointLatency(i18n.T(i18n.MessageEndpointSlow, l.Exchange,
+				l.Method, l.Endpoint, percentile, latency, limit), true)
+		case !slow && slowEndpoints.endpoints[key]:
+			notifyEndpointLatency(i18n.T(i18n.MessageEndpointFast, l.Exchange,
+				l.Method, l.Endpoint, l.P95, l.P99), false)
+		}
+		slowEndpoints.endpoints[key] = slow
+	}
+	for key := range slowEndpoints.endpoints {
+		if !requested[key] {
+			delete(slowEndpoints.endpoints, key)
+		}
+	}
+}
+
+// notifyEndpointLatency logs and pushes an endpoint slowdown or recovery
+// through the communications package
+func notifyEndpointLatency(message string, slow bool) {
+	if slow {
+		log.Warn(message)
+	} else {
+		log.Info(message)
+	}
+	pushEvent("ENDPOINT_LATENCY", message)
+}
+
+// LatencyMonitorRoutine periodically checks the latency of every exchange
+// endpoint against its thresholds
+func LatencyMonitorRoutine() {
+	log.Debugln("Starting endpoint latency monitor routine.")
+	for {
+		checkEndpointLatencies(&bot.config.LatencyMonitor, clock.Now())
+		clock.Sleep(bot.config.LatencyMonitor.CheckInterval)
+	}
+}
+
+// GetEndpointLatencies returns the latency percentiles of every requested
+// exchange endpoint and whether they were slow when last checked
+func GetEndpointLatencies() []EndpointLatencyStatus {
+	latencies := request.GetLatencies(clock.Now())
+
+	slowEndpoints.m.Lock()
+	defer slowEndpoints.m.Unlock()
+	resp := make([]EndpointLatencyStatus, len(latencies))
+	for x := range latencies {
+		resp[x] = EndpointLatencyStatus{
+			EndpointLatency: latencies[x],
+			Slow:            slowEndpoints.endpoints[getEndpointLatencyKey(&latencies[x])],
+		}
+	}
+	return resp
+}
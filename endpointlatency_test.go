@@ -0,0 +1,96 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+)
+
+func TestGetLatencyThreshold(t *testing.T) {
+	cfg := config.LatencyMonitorConfig{
+		P95: time.Second * 2,
+		P99: time.Second * 5,
+		Thresholds: []config.LatencyThresholdConfig{
+			{Exchange: "bitstamp", P99: time.Second * 3},
+			{Exchange: "Bitstamp", Endpoint: "/Order", P95: time.Millisecond * 500},
+		},
+	}
+
+	threshold := getLatencyThreshold(&cfg, &request.EndpointLatency{
+		Exchange: "Bitstamp", Endpoint: "/api/v2/buy/:id/order"})
+	if threshold.P95 != time.Millisecond*500 || threshold.P99 != 0 {
+		t.Errorf("Test failed. Expected the endpoint threshold, got %+v", threshold)
+	}
+	threshold = getLatencyThreshold(&cfg, &request.EndpointLatency{
+		Exchange: "Bitstamp", Endpoint: "/api/v2/ticker"})
+	if threshold.P95 != 0 || threshold.P99 != time.Second*3 {
+		t.Errorf("Test failed. Expected the exchange threshold, got %+v", threshold)
+	}
+	threshold = getLatencyThreshold(&cfg, &request.EndpointLatency{
+		Exchange: "Kraken", Endpoint: "/0/public/Ticker"})
+	if threshold.P95 != cfg.P95 || threshold.P99 != cfg.P99 {
+		t.Errorf("Test failed. Expected the default threshold, got %+v", threshold)
+	}
+}
+
+func TestCheckEndpointLatencies(t *testing.T) {
+	request.SetLatencyWindow(time.Minute)
+	defer request.SetLatencyWindow(request.DefaultLatencyWindow)
+	defer func() {
+		slowEndpoints.m.Lock()
+		slowEndpoints.endpoints = make(map[string]bool)
+		slowEndpoints.m.Unlock()
+	}()
+
+	cfg := config.LatencyMonitorConfig{
+		MinSamples: 10,
+		P95:        time.Second,
+		P99:        time.Second * 5,
+	}
+	now := time.Unix(1000, 0)
+	sim := clock.NewSimulated(now)
+	clock.Set(sim)
+	defer clock.Set(nil)
+	for x := 0; x < 10; x++ {
+		request.RecordLatency("Bitstamp", http.MethodGet, "/api/v2/ticker/btcusd",
+			time.Millisecond*50, now)
+		request.RecordLatency("Bitstamp", http.MethodPost, "/api/v2/buy/btcusd",
+			time.Second*3, now)
+	}
+	request.RecordLatency("Bitstamp", http.MethodGet, "/api/v2/order_book/btcusd",
+		time.Second*10, now)
+
+	checkEndpointLatencies(&cfg, now)
+	slow := make(map[string]bool)
+	for _, l := range GetEndpointLatencies() {
+		slow[l.Method+" "+l.Endpoint] = l.Slow
+	}
+	if len(slow) != 3 || slow["GET /api/v2/ticker/btcusd"] ||
+		!slow["POST /api/v2/buy/btcusd"] || slow["GET /api/v2/order_book/btcusd"] {
+		t.Errorf("Test failed. Expected only the buy endpoint to be slow, got %v", slow)
+	}
+
+	// The slow requests age out with the previous window, leaving the buy
+	// endpoint fast again
+	for x := 0; x < 10; x++ {
+		request.RecordLatency("Bitstamp", http.MethodPost, "/api/v2/buy/btcusd",
+			time.Millisecond*50, now.Add(time.Minute*3))
+	}
+	sim.Set(now.Add(time.Minute * 3))
+	checkEndpointLatencies(&cfg, now.Add(time.Minute*3))
+	for _, l := range GetEndpointLatencies() {
+		if l.Slow {
+			t.Errorf("Test failed. Expected %s to recover", l.Endpoint)
+		}
+	}
+	slowEndpoints.m.Lock()
+	tracked := len(slowEndpoints.endpoints)
+	slowEndpoints.m.Unlock()
+	if tracked != 1 {
+		t.Errorf("Test failed. Expected endpoints no longer requested to be forgotten, got %d", tracked)
+	}
+}
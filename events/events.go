@@ -11,6 +11,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/i18n"
 	log "github.com/thrasher-/gocryptotrader/logger"
 )
 
@@ -114,7 +115,7 @@ func (e *Event) ExecuteAction() bool {
 	if common.StringContains(e.Action, ",") {
 		action := common.SplitStrings(e.Action, ",")
 		if action[0] == actionSMSNotify {
-			message := fmt.Sprintf("Event triggered: %s", e.String())
+			message := i18n.T(i18n.MessageEventTriggered, e.String())
 			if action[1] == "ALL" {
 				comms.PushEvent(base.Event{TradeDetails: message})
 			}
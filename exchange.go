@@ -4,37 +4,14 @@ import (
 	"errors"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/thrasher-/gocryptotrader/clock"
 	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
-	"github.com/thrasher-/gocryptotrader/exchanges/anx"
-	"github.com/thrasher-/gocryptotrader/exchanges/binance"
-	"github.com/thrasher-/gocryptotrader/exchanges/bitfinex"
-	"github.com/thrasher-/gocryptotrader/exchanges/bitflyer"
-	"github.com/thrasher-/gocryptotrader/exchanges/bithumb"
-	"github.com/thrasher-/gocryptotrader/exchanges/bitmex"
-	"github.com/thrasher-/gocryptotrader/exchanges/bitstamp"
-	"github.com/thrasher-/gocryptotrader/exchanges/bittrex"
-	"github.com/thrasher-/gocryptotrader/exchanges/btcc"
-	"github.com/thrasher-/gocryptotrader/exchanges/btcmarkets"
-	"github.com/thrasher-/gocryptotrader/exchanges/btse"
-	"github.com/thrasher-/gocryptotrader/exchanges/coinbasepro"
-	"github.com/thrasher-/gocryptotrader/exchanges/coinut"
-	"github.com/thrasher-/gocryptotrader/exchanges/exmo"
-	"github.com/thrasher-/gocryptotrader/exchanges/gateio"
-	"github.com/thrasher-/gocryptotrader/exchanges/gemini"
-	"github.com/thrasher-/gocryptotrader/exchanges/hitbtc"
-	"github.com/thrasher-/gocryptotrader/exchanges/huobi"
-	"github.com/thrasher-/gocryptotrader/exchanges/huobihadax"
-	"github.com/thrasher-/gocryptotrader/exchanges/itbit"
-	"github.com/thrasher-/gocryptotrader/exchanges/kraken"
-	"github.com/thrasher-/gocryptotrader/exchanges/lakebtc"
-	"github.com/thrasher-/gocryptotrader/exchanges/localbitcoins"
-	"github.com/thrasher-/gocryptotrader/exchanges/okcoin"
-	"github.com/thrasher-/gocryptotrader/exchanges/okex"
-	"github.com/thrasher-/gocryptotrader/exchanges/poloniex"
-	"github.com/thrasher-/gocryptotrader/exchanges/yobit"
-	"github.com/thrasher-/gocryptotrader/exchanges/zb"
+	// Registers the supported exchanges
+	_ "github.com/thrasher-/gocryptotrader/exchanges/all"
 	log "github.com/thrasher-/gocryptotrader/logger"
 )
 
@@ -44,21 +21,29 @@ var (
 	ErrExchangeNotFound      = errors.New("exchange not found")
 	ErrExchangeAlreadyLoaded = errors.New("exchange already loaded")
 	ErrExchangeFailedToLoad  = errors.New("exchange failed to load")
+	ErrSandboxNotSupported   = errors.New("exchange does not support a sandbox environment")
 )
 
+// GetExchanges returns a snapshot of the loaded exchanges which is safe to
+// range over while exchanges are loaded and unloaded
+func GetExchanges() []exchange.IBotExchange {
+	bot.exchangesMtx.RLock()
+	defer bot.exchangesMtx.RUnlock()
+	exchanges := make([]exchange.IBotExchange, len(bot.exchanges))
+	copy(exchanges, bot.exchanges)
+	return exchanges
+}
+
 // CheckExchangeExists returns true whether or not an exchange has already
 // been loaded
 func CheckExchangeExists(exchName string) bool {
-	for x := range bot.exchanges {
-		if strings.EqualFold(bot.exchanges[x].GetName(), exchName) {
-			return true
-		}
-	}
-	return false
+	return GetExchangeByName(exchName) != nil
 }
 
 // GetExchangeByName returns an exchange given an exchange name
 func GetExchangeByName(exchName string) exchange.IBotExchange {
+	bot.exchangesMtx.RLock()
+	defer bot.exchangesMtx.RUnlock()
 	for x := range bot.exchanges {
 		if strings.EqualFold(bot.exchanges[x].GetName(), exchName) {
 			return bot.exchanges[x]
@@ -67,13 +52,43 @@ func GetExchangeByName(exchName string) exchange.IBotExchange {
 	return nil
 }
 
+// addExchange adds a set up exchange to the loaded exchanges and creates its
+// polling jobs
+func addExchange(exch exchange.IBotExchange) error {
+	bot.exchangesMtx.Lock()
+	for x := range bot.exchanges {
+		if strings.EqualFold(bot.exchanges[x].GetName(), exch.GetName()) {
+			bot.exchangesMtx.Unlock()
+			return ErrExchangeAlreadyLoaded
+		}
+	}
+	bot.exchanges = append(bot.exchanges, exch)
+	bot.exchangesMtx.Unlock()
+
+	markets.Invalidate()
+	pollJobs.addExchange(exch, clock.Now())
+	return nil
+}
+
+// isPublicDataOnly returns whether none of the loaded exchanges have
+// authenticated API support, in which case only public data is gathered
+func isPublicDataOnly(exchanges []exchange.IBotExchange) bool {
+	for x := range exchanges {
+		if exchanges[x] != nil && exchanges[x].GetAuthenticatedAPISupport() {
+			return false
+		}
+	}
+	return true
+}
+
 // ReloadExchange loads an exchange config by name
 func ReloadExchange(name string) error {
-	if len(bot.exchanges) == 0 {
+	if len(GetExchanges()) == 0 {
 		return ErrNoExchangesLoaded
 	}
 
-	if !CheckExchangeExists(name) {
+	e := GetExchangeByName(name)
+	if e == nil {
 		return ErrExchangeNotFound
 	}
 
@@ -82,15 +97,16 @@ func ReloadExchange(name string) error {
 		return err
 	}
 
-	e := GetExchangeByName(name)
 	e.Setup(&exchCfg)
+	// Enabled pairs and authenticated API support may have changed
+	pollJobs.addExchange(e, clock.Now())
 	log.Debugf("%s exchange reloaded successfully.\n", name)
 	return nil
 }
 
 // UnloadExchange unloads an exchange by name
 func UnloadExchange(name string) error {
-	if len(bot.exchanges) == 0 {
+	if len(GetExchanges()) == 0 {
 		return ErrNoExchangesLoaded
 	}
 
@@ -109,10 +125,14 @@ func UnloadExchange(name string) error {
 		return err
 	}
 
+	bot.exchangesMtx.Lock()
+	defer bot.exchangesMtx.Unlock()
 	for x := range bot.exchanges {
 		if strings.EqualFold(bot.exchanges[x].GetName(), name) {
 			bot.exchanges[x].SetEnabled(false)
+			pollJobs.removeExchange(bot.exchanges[x].GetName())
 			bot.exchanges = append(bot.exchanges[:x], bot.exchanges[x+1:]...)
+			markets.Invalidate()
 			return nil
 		}
 	}
@@ -120,91 +140,64 @@ func UnloadExchange(name string) error {
 	return ErrExchangeNotFound
 }
 
-// LoadExchange loads an exchange by name
-func LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
-	nameLower := common.StringToLower(name)
-	var exch exchange.IBotExchange
-
-	if len(bot.exchanges) > 0 {
-		if CheckExchangeExists(name) {
-			return ErrExchangeAlreadyLoaded
-		}
-	}
-
-	switch nameLower {
-	case "anx":
-		exch = new(anx.ANX)
-	case "binance":
-		exch = new(binance.Binance)
-	case "bitfinex":
-		exch = new(bitfinex.Bitfinex)
-	case "bitflyer":
-		exch = new(bitflyer.Bitflyer)
-	case "bithumb":
-		exch = new(bithumb.Bithumb)
-	case "bitmex":
-		exch = new(bitmex.Bitmex)
-	case "bitstamp":
-		exch = new(bitstamp.Bitstamp)
-	case "bittrex":
-		exch = new(bittrex.Bittrex)
-	case "btcc":
-		exch = new(btcc.BTCC)
-	case "btc markets":
-		exch = new(btcmarkets.BTCMarkets)
-	case "btse":
-		exch = new(btse.BTSE)
-	case "coinut":
-		exch = new(coinut.COINUT)
-	case "exmo":
-		exch = new(exmo.EXMO)
-	case "coinbasepro":
-		exch = new(coinbasepro.CoinbasePro)
-	case "gateio":
-		exch = new(gateio.Gateio)
-	case "gemini":
-		exch = new(gemini.Gemini)
-	case "hitbtc":
-		exch = new(hitbtc.HitBTC)
-	case "huobi":
-		exch = new(huobi.HUOBI)
-	case "huobihadax":
-		exch = new(huobihadax.HUOBIHADAX)
-	case "itbit":
-		exch = new(itbit.ItBit)
-	case "kraken":
-		exch = new(kraken.Kraken)
-	case "lakebtc":
-		exch = new(lakebtc.LakeBTC)
-	case "localbitcoins":
-		exch = new(localbitcoins.LocalBitcoins)
-	case "okcoin international":
-		exch = new(okcoin.OKCoin)
-	case "okex":
-		exch = new(okex.OKEX)
-	case "poloniex":
-		exch = new(poloniex.Poloniex)
-	case "yobit":
-		exch = new(yobit.Yobit)
-	case "zb":
-		exch = new(zb.ZB)
-	default:
-		return ErrExchangeNotFound
+// newExchange creates an exchange by name and sets it up with its config,
+// probing any URL overrides. The exchange is neither added to the bot nor
+// started
+func newExchange(name string) (exchange.IBotExchange, error) {
+	registration, ok := exchange.GetRegistration(name)
+	if !ok {
+		return nil, ErrExchangeNotFound
 	}
-
+	exch := registration.Creator()
 	if exch == nil {
-		return ErrExchangeFailedToLoad
+		return nil, ErrExchangeFailedToLoad
 	}
 
 	exch.SetDefaults()
-	bot.exchanges = append(bot.exchanges, exch)
 	exchCfg, err := bot.config.GetExchangeConfig(name)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	err = checkURLOverrides(&exchCfg)
+	if err != nil {
+		return nil, err
 	}
 
 	exchCfg.Enabled = true
 	exch.Setup(&exchCfg)
+	log.SetDebugSettings(name, getDebugSettings(&exchCfg.Debug, exchCfg.Debug.Enabled))
+	setupFaultInjection(name, exchCfg.FaultInjection)
+
+	// Refuse to silently fall back to live endpoints when a sandbox is wanted
+	if exchCfg.UseSandbox && !exch.IsSandbox() {
+		return nil, ErrSandboxNotSupported
+	}
+	if exch.IsSandbox() {
+		log.Warnf("%s is using its sandbox environment, data and orders are not live.",
+			name)
+	}
+	if exch.IsReadOnly() {
+		log.Warnf("%s is read-only, order placement, cancellation and withdrawals are disabled.",
+			name)
+	}
+	return exch, nil
+}
+
+// LoadExchange loads an exchange by name
+func LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
+	if CheckExchangeExists(name) {
+		return ErrExchangeAlreadyLoaded
+	}
+
+	exch, err := newExchange(name)
+	if err != nil {
+		return err
+	}
+	err = addExchange(exch)
+	if err != nil {
+		return err
+	}
 
 	if useWG {
 		exch.Start(wg)
@@ -216,9 +209,12 @@ func LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
 	return nil
 }
 
-// SetupExchanges sets up the exchanges used by the bot
+// SetupExchanges sets up the exchanges used by the bot. Enabled exchanges not
+// yet loaded are set up concurrently and added in config order once all are
+// set up
 func SetupExchanges() {
 	var wg sync.WaitGroup
+	var cfgs []*config.ExchangeConfig
 	for x := range bot.config.Exchanges {
 		exch := &bot.config.Exchanges[x]
 		if CheckExchangeExists(exch.Name) {
@@ -245,16 +241,41 @@ func SetupExchanges() {
 			log.Debugf("%s: Exchange support: Disabled", exch.Name)
 			continue
 		}
-		err := LoadExchange(exch.Name, true, &wg)
+		cfgs = append(cfgs, exch)
+	}
+
+	exchs := make([]exchange.IBotExchange, len(cfgs))
+	errs := make([]error, len(cfgs))
+	durations := make([]time.Duration, len(cfgs))
+	var setupWG sync.WaitGroup
+	for x := range cfgs {
+		setupWG.Add(1)
+		go func(x int) {
+			defer setupWG.Done()
+			start := clock.Now()
+			exchs[x], errs[x] = newExchange(cfgs[x].Name)
+			durations[x] = clock.Since(start)
+		}(x)
+	}
+	setupWG.Wait()
+
+	for x := range cfgs {
+		if errs[x] != nil {
+			log.Errorf("LoadExchange %s failed: %s", cfgs[x].Name, errs[x])
+			continue
+		}
+		err := addExchange(exchs[x])
 		if err != nil {
-			log.Errorf("LoadExchange %s failed: %s", exch.Name, err)
+			log.Errorf("LoadExchange %s failed: %s", cfgs[x].Name, err)
 			continue
 		}
+		exchs[x].Start(&wg)
 		log.Debugf(
-			"%s: Exchange support: Enabled (Authenticated API support: %s - Verbose mode: %s).\n",
-			exch.Name,
-			common.IsEnabled(exch.AuthenticatedAPISupport),
-			common.IsEnabled(exch.Verbose),
+			"%s: Exchange support: Enabled (Authenticated API support: %s - Verbose mode: %s) set up in %s.\n",
+			cfgs[x].Name,
+			common.IsEnabled(cfgs[x].AuthenticatedAPISupport),
+			common.IsEnabled(cfgs[x].Debug.Enabled),
+			durations[x],
 		)
 	}
 	wg.Wait()
@@ -2,13 +2,29 @@ package main
 
 import (
 	"testing"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/mock"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 )
 
 var testSetup = false
 
+// skipUnregistered skips a test relying on exchanges left out of the build by
+// its exchange build tags
+func skipUnregistered(t *testing.T, exchanges ...string) {
+	for x := range exchanges {
+		if _, ok := exchange.GetRegistration(exchanges[x]); !ok {
+			t.Skipf("%s is not included in this build", exchanges[x])
+		}
+	}
+}
+
 func SetupTest(t *testing.T) {
+	skipUnregistered(t, "Bitfinex")
 	if !testSetup {
 		bot.config = &config.Cfg
 		err := bot.config.LoadConfig("./testdata/configtest.json")
@@ -107,6 +123,54 @@ func TestReloadExchange(t *testing.T) {
 	}
 }
 
+func TestLoadExchangeSandbox(t *testing.T) {
+	SetupTest(t)
+	skipUnregistered(t, "Bitstamp")
+
+	exchCfg, err := bot.config.GetExchangeConfig("Bitstamp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchCfg.UseSandbox = true
+	err = bot.config.UpdateExchangeConfig(&exchCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		exchCfg.UseSandbox = false
+		bot.config.UpdateExchangeConfig(&exchCfg)
+	}()
+
+	err = LoadExchange("Bitstamp", false, nil)
+	if err != ErrSandboxNotSupported {
+		t.Errorf("Test failed. Expected %s, got %v", ErrSandboxNotSupported, err)
+	}
+	if CheckExchangeExists("Bitstamp") {
+		t.Error("Test failed. Exchange without sandbox support should not be loaded")
+	}
+}
+
+func TestPublicDataOnly(t *testing.T) {
+	SetupTest(t)
+
+	exch := GetExchangeByName("Bitfinex")
+	if exch.GetAuthenticatedAPISupport() {
+		t.Skip("Test skipped. Bitfinex has API credentials set")
+	}
+	if !isPublicDataOnly(bot.exchanges) {
+		t.Error("Test failed. Expected exchanges without credentials to be public data-only")
+	}
+
+	_, err := exch.GetAccountInfo()
+	if err != exchange.ErrCredentialsNotSet {
+		t.Errorf("Test failed. Expected %s, got %v", exchange.ErrCredentialsNotSet, err)
+	}
+
+	if isPublicDataOnly([]exchange.IBotExchange{&credentialsTestExchange{authenticated: true}}) {
+		t.Error("Test failed. Expected an authenticated exchange to disable public data-only mode")
+	}
+}
+
 func TestUnloadExchange(t *testing.T) {
 	SetupTest(t)
 
@@ -131,8 +195,114 @@ func TestUnloadExchange(t *testing.T) {
 	CleanupTest(t)
 }
 
+func countPollJobs(exchName string) int {
+	pollJobs.m.Lock()
+	defer pollJobs.m.Unlock()
+	var count int
+	for x := range pollJobs.jobs {
+		if pollJobs.jobs[x].exch.GetName() == exchName {
+			count++
+		}
+	}
+	return count
+}
+
+func TestExchangePollJobs(t *testing.T) {
+	SetupTest(t)
+	expected := countPollJobs("Bitfinex")
+	if expected < len(GetExchangeByName("Bitfinex").GetEnabledCurrencies())*2 {
+		t.Error("Test failed. Expected a loaded exchange to be polled")
+	}
+
+	err := ReloadExchange("Bitfinex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count := countPollJobs("Bitfinex"); count != expected {
+		t.Errorf("Test failed. Expected a reloaded exchanges jobs to be rebuilt, got %d jobs",
+			count)
+	}
+
+	CleanupTest(t)
+	if countPollJobs("Bitfinex") != 0 {
+		t.Error("Test failed. Expected an unloaded exchange not to be polled")
+	}
+}
+
 func TestSetupExchanges(t *testing.T) {
 	SetupTest(t)
 	SetupExchanges()
 	CleanupTest(t)
 }
+
+func TestLoadRegisteredExchange(t *testing.T) {
+	SetupTest(t)
+
+	// The mock exchange configs are dropped once the test is done
+	exchCfgs := bot.config.Exchanges
+	bot.config.Exchanges = append([]config.ExchangeConfig(nil), exchCfgs...)
+	defer func() { bot.config.Exchanges = exchCfgs }()
+
+	p := currency.NewPairFromStrings("BTC", "USD")
+	venues := []struct {
+		name     string
+		ask, fee float64
+	}{
+		{"MockVenueCheap", 10000, 0.004},
+		{"MockVenueLowFee", 10010, 0.001},
+	}
+	for x := range venues {
+		bot.config.Exchanges = append(bot.config.Exchanges, config.ExchangeConfig{
+			Name:                    venues[x].name,
+			Enabled:                 true,
+			AuthenticatedAPISupport: true,
+			AvailablePairs:          currency.Pairs{p},
+			EnabledPairs:            currency.Pairs{p},
+		})
+
+		m := mock.New(venues[x].name)
+		m.SetFeeRate(venues[x].fee)
+		m.SetOrderbook(orderbook.Base{
+			Pair: p,
+			Bids: []orderbook.Item{{Price: venues[x].ask - 1, Amount: 10}},
+			Asks: []orderbook.Item{{Price: venues[x].ask, Amount: 10}},
+		})
+		exchange.Register(exchange.Registration{
+			Name: venues[x].name,
+			Creator: func() exchange.IBotExchange {
+				return m
+			},
+		})
+		defer exchange.Deregister(venues[x].name)
+
+		err := LoadExchange(venues[x].name, false, nil)
+		if err != nil {
+			t.Fatalf("Test failed. TestLoadRegisteredExchange: %s", err)
+		}
+		_, err = GetExchangeByName(venues[x].name).UpdateOrderbook(p,
+			orderbook.Spot)
+		if err != nil {
+			t.Fatalf("Test failed. TestLoadRegisteredExchange: %s", err)
+		}
+	}
+
+	exchanges := []exchange.IBotExchange{
+		GetExchangeByName("MockVenueCheap"),
+		GetExchangeByName("MockVenueLowFee"),
+	}
+	route, err := routeOrder(exchanges, p, "buy", 1, time.Now())
+	if err != nil {
+		t.Fatalf("Test failed. TestLoadRegisteredExchange: %s", err)
+	}
+	if route.Best == nil || route.Best.Exchange != "MockVenueLowFee" {
+		t.Errorf("Test failed. TestLoadRegisteredExchange: unexpected route %+v",
+			route.Best)
+	}
+
+	for x := range venues {
+		err = UnloadExchange(venues[x].name)
+		if err != nil {
+			t.Errorf("Test failed. TestLoadRegisteredExchange: %s", err)
+		}
+	}
+}
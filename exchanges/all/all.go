@@ -0,0 +1,11 @@
+// Package all imports every supported exchange package so each registers its
+// exchange. An exchange is left out of a build with its no_<package> build
+// tag, such as:
+//
+//	go build -tags "no_bithumb no_yobit"
+//
+// The exchange_select tag only includes the exchanges named with their
+// exchange_<package> build tags, such as:
+//
+//	go build -tags "exchange_select exchange_bitfinex"
+package all
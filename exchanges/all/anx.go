@@ -0,0 +1,9 @@
+//go:build (!no_anx && !exchange_select) || exchange_anx
+// +build !no_anx,!exchange_select exchange_anx
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/anx"
+)
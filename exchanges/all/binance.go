@@ -0,0 +1,9 @@
+//go:build (!no_binance && !exchange_select) || exchange_binance
+// +build !no_binance,!exchange_select exchange_binance
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/binance"
+)
@@ -0,0 +1,9 @@
+//go:build (!no_bitfinex && !exchange_select) || exchange_bitfinex
+// +build !no_bitfinex,!exchange_select exchange_bitfinex
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/bitfinex"
+)
@@ -0,0 +1,9 @@
+//go:build (!no_bitflyer && !exchange_select) || exchange_bitflyer
+// +build !no_bitflyer,!exchange_select exchange_bitflyer
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/bitflyer"
+)
@@ -0,0 +1,9 @@
+//go:build (!no_bithumb && !exchange_select) || exchange_bithumb
+// +build !no_bithumb,!exchange_select exchange_bithumb
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/bithumb"
+)
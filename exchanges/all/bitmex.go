@@ -0,0 +1,9 @@
+//go:build (!no_bitmex && !exchange_select) || exchange_bitmex
+// +build !no_bitmex,!exchange_select exchange_bitmex
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/bitmex"
+)
@@ -0,0 +1,9 @@
+//go:build (!no_bitstamp && !exchange_select) || exchange_bitstamp
+// +build !no_bitstamp,!exchange_select exchange_bitstamp
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/bitstamp"
+)
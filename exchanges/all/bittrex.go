@@ -0,0 +1,9 @@
+//go:build (!no_bittrex && !exchange_select) || exchange_bittrex
+// +build !no_bittrex,!exchange_select exchange_bittrex
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/bittrex"
+)
@@ -0,0 +1,9 @@
+//go:build (!no_btcc && !exchange_select) || exchange_btcc
+// +build !no_btcc,!exchange_select exchange_btcc
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/btcc"
+)
@@ -0,0 +1,9 @@
+//go:build (!no_btcmarkets && !exchange_select) || exchange_btcmarkets
+// +build !no_btcmarkets,!exchange_select exchange_btcmarkets
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/btcmarkets"
+)
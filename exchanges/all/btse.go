@@ -0,0 +1,9 @@
+//go:build (!no_btse && !exchange_select) || exchange_btse
+// +build !no_btse,!exchange_select exchange_btse
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/btse"
+)
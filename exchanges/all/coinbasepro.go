@@ -0,0 +1,9 @@
+//go:build (!no_coinbasepro && !exchange_select) || exchange_coinbasepro
+// +build !no_coinbasepro,!exchange_select exchange_coinbasepro
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/coinbasepro"
+)
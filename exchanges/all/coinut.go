@@ -0,0 +1,9 @@
+//go:build (!no_coinut && !exchange_select) || exchange_coinut
+// +build !no_coinut,!exchange_select exchange_coinut
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/coinut"
+)
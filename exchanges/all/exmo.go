@@ -0,0 +1,9 @@
+//go:build (!no_exmo && !exchange_select) || exchange_exmo
+// +build !no_exmo,!exchange_select exchange_exmo
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/exmo"
+)
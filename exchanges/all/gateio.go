@@ -0,0 +1,9 @@
+//go:build (!no_gateio && !exchange_select) || exchange_gateio
+// +build !no_gateio,!exchange_select exchange_gateio
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/gateio"
+)
@@ -0,0 +1,9 @@
+//go:build (!no_gemini && !exchange_select) || exchange_gemini
+// +build !no_gemini,!exchange_select exchange_gemini
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/gemini"
+)
@@ -0,0 +1,9 @@
+//go:build (!no_hitbtc && !exchange_select) || exchange_hitbtc
+// +build !no_hitbtc,!exchange_select exchange_hitbtc
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/hitbtc"
+)
@@ -0,0 +1,9 @@
+//go:build (!no_huobi && !exchange_select) || exchange_huobi
+// +build !no_huobi,!exchange_select exchange_huobi
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/huobi"
+)
@@ -0,0 +1,9 @@
+//go:build (!no_huobihadax && !exchange_select) || exchange_huobihadax
+// +build !no_huobihadax,!exchange_select exchange_huobihadax
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/huobihadax"
+)
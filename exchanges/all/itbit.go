@@ -0,0 +1,9 @@
+//go:build (!no_itbit && !exchange_select) || exchange_itbit
+// +build !no_itbit,!exchange_select exchange_itbit
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/itbit"
+)
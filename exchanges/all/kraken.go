@@ -0,0 +1,9 @@
+//go:build (!no_kraken && !exchange_select) || exchange_kraken
+// +build !no_kraken,!exchange_select exchange_kraken
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/kraken"
+)
@@ -0,0 +1,9 @@
+//go:build (!no_lakebtc && !exchange_select) || exchange_lakebtc
+// +build !no_lakebtc,!exchange_select exchange_lakebtc
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/lakebtc"
+)
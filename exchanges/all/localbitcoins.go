@@ -0,0 +1,9 @@
+//go:build (!no_localbitcoins && !exchange_select) || exchange_localbitcoins
+// +build !no_localbitcoins,!exchange_select exchange_localbitcoins
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/localbitcoins"
+)
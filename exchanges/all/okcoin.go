@@ -0,0 +1,9 @@
+//go:build (!no_okcoin && !exchange_select) || exchange_okcoin
+// +build !no_okcoin,!exchange_select exchange_okcoin
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/okcoin"
+)
@@ -0,0 +1,9 @@
+//go:build (!no_okex && !exchange_select) || exchange_okex
+// +build !no_okex,!exchange_select exchange_okex
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/okex"
+)
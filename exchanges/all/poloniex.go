@@ -0,0 +1,9 @@
+//go:build (!no_poloniex && !exchange_select) || exchange_poloniex
+// +build !no_poloniex,!exchange_select exchange_poloniex
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/poloniex"
+)
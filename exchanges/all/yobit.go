@@ -0,0 +1,9 @@
+//go:build (!no_yobit && !exchange_select) || exchange_yobit
+// +build !no_yobit,!exchange_select exchange_yobit
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/yobit"
+)
@@ -0,0 +1,9 @@
+//go:build (!no_zb && !exchange_select) || exchange_zb
+// +build !no_zb,!exchange_select exchange_zb
+
+package all
+
+import (
+	// Registers the exchange
+	_ "github.com/thrasher-/gocryptotrader/exchanges/zb"
+)
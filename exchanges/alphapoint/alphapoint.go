@@ -540,7 +540,7 @@ func (a *Alphapoint) SendHTTPRequest(method, path string, data map[string]interf
 // SendAuthenticatedHTTPRequest sends an authenticated request
 func (a *Alphapoint) SendAuthenticatedHTTPRequest(method, path string, data map[string]interface{}, result interface{}) error {
 	if !a.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, a.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	n := a.Requester.GetNonce(true)
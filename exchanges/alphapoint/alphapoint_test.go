@@ -610,8 +610,8 @@ func TestCancelAllExchangeOrders(t *testing.T) {
 		t.Errorf("Withdraw failed to be placed: %v", err)
 	}
 
-	if len(resp.OrderStatus) > 0 {
-		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
+	if len(resp.Failed()) > 0 {
+		t.Errorf("%v orders failed to cancel", len(resp.Failed()))
 	}
 }
 
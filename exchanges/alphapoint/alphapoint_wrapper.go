@@ -9,6 +9,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -25,12 +26,10 @@ func (a *Alphapoint) GetAccountInfo() (exchange.AccountInfo, error) {
 
 	var currencies []exchange.AccountCurrencyInfo
 	for i := 0; i < len(account.Currencies); i++ {
-		var exchangeCurrency exchange.AccountCurrencyInfo
-		exchangeCurrency.CurrencyName = currency.NewCode(account.Currencies[i].Name)
-		exchangeCurrency.TotalValue = float64(account.Currencies[i].Balance)
-		exchangeCurrency.Hold = float64(account.Currencies[i].Hold)
-
-		currencies = append(currencies, exchangeCurrency)
+		currencies = append(currencies, exchange.NewAccountCurrencyInfoFromTotal(
+			currency.NewCode(account.Currencies[i].Name),
+			float64(account.Currencies[i].Balance),
+			float64(account.Currencies[i].Hold)))
 	}
 
 	response.Accounts = append(response.Accounts, exchange.Account{
@@ -40,6 +39,12 @@ func (a *Alphapoint) GetAccountInfo() (exchange.AccountInfo, error) {
 	return response, nil
 }
 
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (a *Alphapoint) UpdateTradablePairs(forceUpdate bool) error {
+	return common.ErrFunctionNotSupported
+}
+
 // UpdateTicker updates and returns the ticker for a currency pair
 func (a *Alphapoint) UpdateTicker(p currency.Pair, assetType string) (ticker.Price, error) {
 	var tickerPrice ticker.Price
@@ -129,9 +134,22 @@ func (a *Alphapoint) GetExchangeHistory(p currency.Pair, assetType string) ([]ex
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive
+func (a *Alphapoint) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (a *Alphapoint) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order and returns a true value when
 // successfully submitted
 func (a *Alphapoint) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if a.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 
 	response, err := a.CreateOrder(p.String(),
@@ -153,11 +171,17 @@ func (a *Alphapoint) SubmitOrder(p currency.Pair, side exchange.OrderSide, order
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (a *Alphapoint) ModifyOrder(_ *exchange.ModifyOrder) (string, error) {
+	if a.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrNotYetImplemented
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (a *Alphapoint) CancelOrder(order *exchange.OrderCancellation) error {
+	if a.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 	if err != nil {
 		return err
@@ -170,6 +194,9 @@ func (a *Alphapoint) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders for a given account
 func (a *Alphapoint) CancelAllOrders(orderCancellation *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if a.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
+	}
 	return exchange.CancelAllOrdersResponse{}, a.CancelAllExistingOrders(orderCancellation.AccountID)
 }
 
@@ -208,17 +235,26 @@ func (a *Alphapoint) GetDepositAddress(cryptocurrency currency.Code, _ string) (
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (a *Alphapoint) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if a.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a withdrawal is submitted
 func (a *Alphapoint) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if a.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a withdrawal is
 // submitted
 func (a *Alphapoint) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if a.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrNotYetImplemented
 }
 
@@ -267,6 +303,7 @@ func (a *Alphapoint) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest
 		}
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
@@ -310,6 +347,7 @@ func (a *Alphapoint) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest
 		}
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
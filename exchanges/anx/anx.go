@@ -45,6 +45,16 @@ type ANX struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "ANX",
+		Creator: func() exchange.IBotExchange {
+			return new(ANX)
+		},
+		Capabilities: exchange.SpotCapability,
+	})
+}
+
 // SetDefaults sets current default settings
 func (a *ANX) SetDefaults() {
 	a.Name = "ANX"
@@ -85,8 +95,10 @@ func (a *ANX) Setup(exch *config.ExchangeConfig) {
 		a.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		a.SetHTTPClientTimeout(exch.HTTPTimeout)
 		a.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		a.SetHTTPHeaders(exch.HTTPHeaders)
 		a.RESTPollingDelay = exch.RESTPollingDelay
 		a.Verbose = exch.Verbose
+		a.ReadOnly = exch.ReadOnly
 		a.HTTPDebugging = exch.HTTPDebugging
 		a.BaseCurrencies = exch.BaseCurrencies
 		a.AvailablePairs = exch.AvailablePairs
@@ -409,7 +421,7 @@ func (a *ANX) SendHTTPRequest(path string, result interface{}) error {
 // SendAuthenticatedHTTPRequest sends a authenticated HTTP request
 func (a *ANX) SendAuthenticatedHTTPRequest(path string, params map[string]interface{}, result interface{}) error {
 	if !a.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, a.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	n := a.Requester.GetNonce(true)
@@ -427,7 +439,7 @@ func (a *ANX) SendAuthenticatedHTTPRequest(path string, params map[string]interf
 	}
 
 	if a.Verbose {
-		log.Debugf("Request JSON: %s\n", PayloadJSON)
+		log.Debugf("Request JSON: %s\n", log.DebugPayload(a.Name, PayloadJSON))
 	}
 
 	hmac := common.GetHMAC(common.HashSHA512, []byte(path+string("\x00")+string(PayloadJSON)), []byte(a.APISecret))
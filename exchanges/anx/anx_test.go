@@ -350,8 +350,8 @@ func TestCancelAllExchangeOrders(t *testing.T) {
 		t.Error("Expecting an error when no keys are set")
 	}
 
-	if len(resp.OrderStatus) > 0 {
-		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
+	if len(resp.Failed()) > 0 {
+		t.Errorf("%v orders failed to cancel", len(resp.Failed()))
 	}
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -31,44 +32,50 @@ func (a *ANX) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", a.GetName(), len(a.EnabledPairs), a.EnabledPairs)
 	}
 
-	tradablePairs, err := a.GetTradablePairs()
-	if err != nil {
-		log.Debugf("%s Failed to get available symbols.\n", a.GetName())
-	} else {
-		forceUpgrade := false
-		if !common.StringDataContains(a.EnabledPairs.Strings(), "_") ||
-			!common.StringDataContains(a.AvailablePairs.Strings(), "_") {
-			forceUpgrade = true
-		}
-
-		if forceUpgrade {
-			newPairs := []string{"BTC_USD,BTC_HKD,BTC_EUR,BTC_CAD,BTC_AUD,BTC_SGD,BTC_JPY,BTC_GBP,BTC_NZD,LTC_BTC,DOG_EBTC,STR_BTC,XRP_BTC"}
-
-			var enabledPairs currency.Pairs
-			for _, p := range newPairs {
-				enabledPairs = append(enabledPairs,
-					currency.NewPairDelimiter(p, "_"))
-			}
-
-			log.Warn("Enabled pairs for ANX reset due to config upgrade, please enable the ones you would like again.")
+	forceUpgrade := false
+	if !common.StringDataContains(a.EnabledPairs.Strings(), "_") ||
+		!common.StringDataContains(a.AvailablePairs.Strings(), "_") {
+		forceUpgrade = true
+	}
 
-			err = a.UpdateCurrencies(enabledPairs, true, true)
-			if err != nil {
-				log.Errorf("%s Failed to get config.\n", a.GetName())
-			}
-		}
+	if forceUpgrade {
+		newPairs := []string{"BTC_USD,BTC_HKD,BTC_EUR,BTC_CAD,BTC_AUD,BTC_SGD,BTC_JPY,BTC_GBP,BTC_NZD,LTC_BTC,DOG_EBTC,STR_BTC,XRP_BTC"}
 
-		var exchangeProducts currency.Pairs
-		for _, p := range tradablePairs {
-			exchangeProducts = append(exchangeProducts,
+		var enabledPairs currency.Pairs
+		for _, p := range newPairs {
+			enabledPairs = append(enabledPairs,
 				currency.NewPairDelimiter(p, "_"))
 		}
 
-		err = a.UpdateCurrencies(exchangeProducts, false, forceUpgrade)
+		log.Warn("Enabled pairs for ANX reset due to config upgrade, please enable the ones you would like again.")
+
+		err := a.UpdateCurrencies(enabledPairs, true, true)
 		if err != nil {
 			log.Errorf("%s Failed to get config.\n", a.GetName())
 		}
 	}
+
+	err := a.UpdateTradablePairs(forceUpgrade)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", a.Name, err)
+	}
+}
+
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (a *ANX) UpdateTradablePairs(forceUpdate bool) error {
+	tradablePairs, err := a.GetTradablePairs()
+	if err != nil {
+		return err
+	}
+
+	var exchangeProducts currency.Pairs
+	for _, p := range tradablePairs {
+		exchangeProducts = append(exchangeProducts,
+			currency.NewPairDelimiter(p, "_"))
+	}
+
+	return a.UpdateCurrencies(exchangeProducts, false, forceUpdate)
 }
 
 // GetTradablePairs returns a list of available
@@ -221,11 +228,10 @@ func (a *ANX) GetAccountInfo() (exchange.AccountInfo, error) {
 
 	var balance []exchange.AccountCurrencyInfo
 	for c := range raw.Wallets {
-		balance = append(balance, exchange.AccountCurrencyInfo{
-			CurrencyName: currency.NewCode(c),
-			TotalValue:   raw.Wallets[c].AvailableBalance.Value,
-			Hold:         raw.Wallets[c].Balance.Value,
-		})
+		balance = append(balance, exchange.NewAccountCurrencyInfoFromTotalAvailable(
+			currency.NewCode(c),
+			raw.Wallets[c].Balance.Value,
+			raw.Wallets[c].AvailableBalance.Value))
 	}
 
 	info.Exchange = a.GetName()
@@ -250,8 +256,21 @@ func (a *ANX) GetExchangeHistory(p currency.Pair, assetType string) ([]exchange.
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive
+func (a *ANX) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (a *ANX) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order
 func (a *ANX) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if a.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 
 	var isBuying bool
@@ -290,11 +309,17 @@ func (a *ANX) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType ex
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (a *ANX) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if a.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (a *ANX) CancelOrder(order *exchange.OrderCancellation) error {
+	if a.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDs := []string{order.OrderID}
 	_, err := a.CancelOrderByIDs(orderIDs)
 	return err
@@ -302,9 +327,10 @@ func (a *ANX) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (a *ANX) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if a.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	placedOrders, err := a.GetOrderList(true)
 	if err != nil {
 		return cancelAllOrdersResponse, err
@@ -321,9 +347,12 @@ func (a *ANX) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAll
 	}
 
 	for _, order := range resp.OrderCancellationResponses {
-		if order.Error != CancelRequestSubmitted {
-			cancelAllOrdersResponse.OrderStatus[order.UUID] = order.Error
+		if order.Error == CancelRequestSubmitted {
+			cancelAllOrdersResponse.Add(order.UUID, nil)
+			continue
 		}
+		cancelAllOrdersResponse.AddFailure(order.UUID, order.Error,
+			"order cancellation rejected")
 	}
 
 	return cancelAllOrdersResponse, err
@@ -343,12 +372,18 @@ func (a *ANX) GetDepositAddress(cryptocurrency currency.Code, _ string) (string,
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (a *ANX) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if a.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return a.Send(withdrawRequest.Currency.String(), withdrawRequest.Address, "", fmt.Sprintf("%v", withdrawRequest.Amount))
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (a *ANX) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if a.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	// Fiat withdrawals available via website
 	return "", common.ErrFunctionNotSupported
 }
@@ -356,6 +391,9 @@ func (a *ANX) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (stri
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a withdrawal is
 // submitted
 func (a *ANX) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if a.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	// Fiat withdrawals available via website
 	return "", common.ErrFunctionNotSupported
 }
@@ -401,6 +439,7 @@ func (a *ANX) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([]ex
 		orders = append(orders, orderDetail)
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
@@ -438,6 +477,7 @@ func (a *ANX) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) ([]ex
 		orders = append(orders, orderDetail)
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
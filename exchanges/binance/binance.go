@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -24,16 +25,22 @@ import (
 type Binance struct {
 	exchange.Base
 	WebsocketConn *websocket.Conn
+	timeSync      exchange.TimeSync
 
 	// Valid string list that is required by the exchange
 	validLimits    []int
 	validIntervals []TimeInterval
+
+	orderLimits        map[string]exchange.OrderLimits
+	orderLimitsUpdated time.Time
+	orderLimitsMtx     sync.Mutex
 }
 
 const (
 	apiURL = "https://api.binance.com"
 
 	// Public endpoints
+	serverTime       = "/api/v1/time"
 	exchangeInfo     = "/api/v1/exchangeInfo"
 	orderBookDepth   = "/api/v1/depth"
 	recentTrades     = "/api/v1/trades"
@@ -53,6 +60,7 @@ const (
 	queryOrder   = "/api/v3/order"
 	openOrders   = "/api/v3/openOrders"
 	allOrders    = "/api/v3/allOrders"
+	myTrades     = "/api/v3/myTrades"
 
 	// Withdraw API endpoints
 	withdraw          = "/wapi/v3/withdraw.html"
@@ -62,6 +70,10 @@ const (
 	accountStatus     = "/wapi/v3/accountStatus.html"
 	systemStatus      = "/wapi/v3/systemStatus.html"
 	dustLog           = "/wapi/v3/userAssetDribbletLog.html"
+	dustTransfer      = "/sapi/v1/asset/dust"
+	allCoinsInfo      = "/sapi/v1/capital/config/getall"
+	depositAddressNet = "/sapi/v1/capital/deposit/address"
+	withdrawAddresses = "/sapi/v1/capital/withdraw/address/list"
 	tradeFee          = "/wapi/v3/tradeFee.html"
 	assetDetail       = "/wapi/v3/assetDetail.html"
 
@@ -69,8 +81,45 @@ const (
 	// to-do
 	binanceAuthRate   = 0
 	binanceUnauthRate = 0
+
+	binanceAccountTradesLimit = 1000
+
+	// binanceAggregatedTradesLimit is the most aggregated trades returned for
+	// a time range, which must be under an hour long
+	binanceAggregatedTradesLimit = 1000
+	binanceAggregatedTradesRange = time.Hour - time.Millisecond
+
+	// binanceOrderLimitsCacheTTL is how long symbol order filters are reused
+	// before the exchange info is fetched again
+	binanceOrderLimitsCacheTTL = time.Hour
+
+	// binanceTradeURL is the web trading page of a symbol, formatted with the
+	// base and quote currencies
+	binanceTradeURL = "https://www.binance.com/en/trade/%s_%s"
+
+	// binanceSymbolTrading is the status of symbols open for trading
+	binanceSymbolTrading = "TRADING"
+
+	// binanceErrTimestampOutsideRecvWindow is returned when a signed request
+	// timestamp is ahead of the server time or older than the recvWindow
+	binanceErrTimestampOutsideRecvWindow = "-1021"
+
+	// binanceSystemMaintenance is the system status while the platform is
+	// under maintenance
+	binanceSystemMaintenance = 1
 )
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "Binance",
+		Creator: func() exchange.IBotExchange {
+			return new(Binance)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability,
+	})
+}
+
 // SetDefaults sets the basic defaults for Binance
 func (b *Binance) SetDefaults() {
 	b.Name = "Binance"
@@ -84,6 +133,8 @@ func (b *Binance) SetDefaults() {
 	b.AssetTypes = []string{ticker.Spot}
 	b.SupportsAutoPairUpdating = true
 	b.SupportsRESTTickerBatching = true
+	b.SupportsKlines = true
+	b.SupportsTradeHistory = true
 	b.APIWithdrawPermissions = exchange.AutoWithdrawCrypto |
 		exchange.NoFiatWithdrawals
 	b.SetValues()
@@ -111,8 +162,10 @@ func (b *Binance) Setup(exch *config.ExchangeConfig) {
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		b.SetHTTPClientTimeout(exch.HTTPTimeout)
 		b.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		b.SetHTTPHeaders(exch.HTTPHeaders)
 		b.RESTPollingDelay = exch.RESTPollingDelay
 		b.Verbose = exch.Verbose
+		b.ReadOnly = exch.ReadOnly
 		b.HTTPDebugging = exch.HTTPDebugging
 		b.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		b.BaseCurrencies = exch.BaseCurrencies
@@ -149,6 +202,9 @@ func (b *Binance) Setup(exch *config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		b.Websocket.Orderbook.SetResync(func(p currency.Pair, _ string) error {
+			return b.SeedLocalCache(p)
+		})
 	}
 }
 
@@ -170,6 +226,20 @@ func (b *Binance) GetExchangeValidCurrencyPairs() ([]string, error) {
 	return validCurrencyPairs, nil
 }
 
+// GetServerTime returns the Binance server time
+func (b *Binance) GetServerTime() (time.Time, error) {
+	var resp struct {
+		ServerTime int64 `json:"serverTime"`
+	}
+	path := b.APIUrl + serverTime
+
+	err := b.SendHTTPRequest(path, &resp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, resp.ServerTime*int64(time.Millisecond)), nil
+}
+
 // GetExchangeInfo returns exchange information. Check binance_types for more
 // information
 func (b *Binance) GetExchangeInfo() (ExchangeInfo, error) {
@@ -179,6 +249,15 @@ func (b *Binance) GetExchangeInfo() (ExchangeInfo, error) {
 	return resp, b.SendHTTPRequest(path, &resp)
 }
 
+// QuerySystemStatus returns whether the platform is operating normally or
+// under system maintenance
+func (b *Binance) QuerySystemStatus() (SystemStatus, error) {
+	var resp SystemStatus
+	path := b.APIUrl + systemStatus
+
+	return resp, b.SendHTTPRequest(path, &resp)
+}
+
 // GetOrderBook returns full orderbook information
 //
 // OrderBookDataRequestParams contains the following members
@@ -276,23 +355,30 @@ func (b *Binance) GetHistoricalTrades(symbol string, limit int, fromID int64) ([
 	return resp, b.SendHTTPRequest(path, &resp)
 }
 
-// GetAggregatedTrades returns aggregated trade activity
-//
-// symbol: string of currency pair
-// limit: Optional. Default 500; max 1000.
-func (b *Binance) GetAggregatedTrades(symbol string, limit int) ([]AggregatedTrade, error) {
+// GetAggregatedTrades returns aggregated trade activity, the most recent
+// unless a trade ID or time range is set
+func (b *Binance) GetAggregatedTrades(arg *AggregatedTradeRequestParams) ([]AggregatedTrade, error) {
 	var resp []AggregatedTrade
 
-	if err := b.CheckLimit(limit); err != nil {
+	if err := b.CheckLimit(arg.Limit); err != nil {
 		return resp, err
 	}
-	if err := b.CheckSymbol(symbol); err != nil {
+	if err := b.CheckSymbol(arg.Symbol); err != nil {
 		return resp, err
 	}
 
 	params := url.Values{}
-	params.Set("symbol", common.StringToUpper(symbol))
-	params.Set("limit", strconv.Itoa(limit))
+	params.Set("symbol", common.StringToUpper(arg.Symbol))
+	params.Set("limit", strconv.Itoa(arg.Limit))
+	if arg.FromID != 0 {
+		params.Set("fromId", strconv.FormatInt(arg.FromID, 10))
+	}
+	if arg.StartTime != 0 {
+		params.Set("startTime", strconv.FormatInt(arg.StartTime, 10))
+	}
+	if arg.EndTime != 0 {
+		params.Set("endTime", strconv.FormatInt(arg.EndTime, 10))
+	}
 
 	path := fmt.Sprintf("%s%s?%s", b.APIUrl, aggregatedTrades, params.Encode())
 
@@ -452,8 +538,13 @@ func (b *Binance) NewOrder(o *NewOrderRequest) (NewOrderResponse, error) {
 	params.Set("symbol", o.Symbol)
 	params.Set("side", string(o.Side))
 	params.Set("type", string(o.TradeType))
-	params.Set("quantity", strconv.FormatFloat(o.Quantity, 'f', -1, 64))
-	if o.TradeType == "LIMIT" {
+	if o.QuoteOrderQty > 0 {
+		params.Set("quoteOrderQty", strconv.FormatFloat(o.QuoteOrderQty, 'f', -1, 64))
+	} else {
+		params.Set("quantity", strconv.FormatFloat(o.Quantity, 'f', -1, 64))
+	}
+	if o.TradeType == BinanceRequestParamsOrderLimit ||
+		o.TradeType == BinanceRequestParamsOrderLimitMarker {
 		params.Set("price", strconv.FormatFloat(o.Price, 'f', -1, 64))
 	}
 	if o.TimeInForce != "" {
@@ -461,7 +552,7 @@ func (b *Binance) NewOrder(o *NewOrderRequest) (NewOrderResponse, error) {
 	}
 
 	if o.NewClientOrderID != "" {
-		params.Set("newClientOrderID", o.NewClientOrderID)
+		params.Set("newClientOrderId", o.NewClientOrderID)
 	}
 
 	if o.StopPrice != 0 {
@@ -525,6 +616,23 @@ func (b *Binance) OpenOrders(symbol string) ([]QueryOrderData, error) {
 	return resp, nil
 }
 
+// GetAccountTrades returns account trades for a symbol in ascending order
+// starting from the trade ID fromID
+// limit optional param, default 500; max 1000
+func (b *Binance) GetAccountTrades(symbol string, fromID int64, limit int) ([]AccountTrade, error) {
+	var resp []AccountTrade
+	path := fmt.Sprintf("%s%s", b.APIUrl, myTrades)
+
+	params := url.Values{}
+	params.Set("symbol", common.StringToUpper(symbol))
+	params.Set("fromId", strconv.FormatInt(fromID, 10))
+	if limit != 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	return resp, b.SendAuthHTTPRequest(http.MethodGet, path, params, &resp)
+}
+
 // AllOrders Get all account orders; active, canceled, or filled.
 // orderId optional param
 // limit optional param, default 500; max 500
@@ -601,17 +709,31 @@ func (b *Binance) SendHTTPRequest(path string, result interface{}) error {
 	return b.SendPayload(http.MethodGet, path, nil, nil, result, false, false, b.Verbose, b.HTTPDebugging)
 }
 
-// SendAuthHTTPRequest sends an authenticated HTTP request
+// SendAuthHTTPRequest sends an authenticated HTTP request. Requests rejected
+// due to clock skew are retried once after re-measuring the server time
 func (b *Binance) SendAuthHTTPRequest(method, path string, params url.Values, result interface{}) error {
 	if !b.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, b.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
+	return b.timeSync.RetryOnTimeSkew(b.Name, isTimeSkewError, b.GetServerTime, func() error {
+		return b.sendAuthHTTPRequest(method, path, params, result)
+	})
+}
+
+// isTimeSkewError returns whether a request was rejected because its
+// timestamp is outside the recvWindow
+func isTimeSkewError(err error) bool {
+	reqErr, ok := err.(*request.Error)
+	return ok && reqErr.Code == binanceErrTimestampOutsideRecvWindow
+}
+
+func (b *Binance) sendAuthHTTPRequest(method, path string, params url.Values, result interface{}) error {
 	if params == nil {
 		params = url.Values{}
 	}
 	params.Set("recvWindow", strconv.FormatInt(common.RecvWindow(5*time.Second), 10))
-	params.Set("timestamp", strconv.FormatInt(time.Now().Unix()*1000, 10))
+	params.Set("timestamp", strconv.FormatInt(b.timeSync.Now().UnixNano()/int64(time.Millisecond), 10))
 
 	signature := params.Encode()
 	hmacSigned := common.GetHMAC(common.HashSHA256, []byte(signature), []byte(b.APISecret))
@@ -641,7 +763,7 @@ func (b *Binance) SendAuthHTTPRequest(method, path string, params url.Values, re
 
 	if err := common.JSONDecode(interim, &errCap); err == nil {
 		if !errCap.Success && errCap.Message != "" {
-			return errors.New(errCap.Message)
+			return request.NewError(b.Name, method, path, errors.New(errCap.Message))
 		}
 	}
 
@@ -745,7 +867,7 @@ func (b *Binance) getMultiplier(isMaker bool) (float64, error) {
 
 // calculateTradingFee returns the fee for trading any currency on Bittrex
 func calculateTradingFee(purchasePrice, amount, multiplier float64) float64 {
-	return (multiplier / 100) * purchasePrice * amount
+	return exchange.CalculateTradingFee(multiplier/100, purchasePrice, amount)
 }
 
 // getCryptocurrencyWithdrawalFee returns the fee for withdrawing from the exchange
@@ -753,8 +875,9 @@ func getCryptocurrencyWithdrawalFee(c currency.Code) float64 {
 	return WithdrawalFees[c]
 }
 
-// WithdrawCrypto sends cryptocurrency to the address of your choosing
-func (b *Binance) WithdrawCrypto(asset, address, addressTag, name, amount string) (int64, error) {
+// WithdrawCrypto sends cryptocurrency to the address of your choosing, an empty
+// network uses the assets default network
+func (b *Binance) WithdrawCrypto(asset, address, addressTag, network, name, amount string) (int64, error) {
 	var resp WithdrawResponse
 	path := fmt.Sprintf("%s%s", b.APIUrl, withdraw)
 
@@ -768,6 +891,9 @@ func (b *Binance) WithdrawCrypto(asset, address, addressTag, name, amount string
 	if len(addressTag) > 0 {
 		params.Set("addressTag", addressTag)
 	}
+	if len(network) > 0 {
+		params.Set("network", network)
+	}
 
 	if err := b.SendAuthHTTPRequest(http.MethodPost, path, params, &resp); err != nil {
 		return -1, err
@@ -780,6 +906,55 @@ func (b *Binance) WithdrawCrypto(asset, address, addressTag, name, amount string
 	return resp.ID, nil
 }
 
+// ConvertDustToBNB converts balances of the given assets which are below the
+// minimum order size into BNB
+func (b *Binance) ConvertDustToBNB(assets []string) (DustTransfer, error) {
+	var resp DustTransfer
+	if len(assets) == 0 {
+		return resp, errors.New("no assets to convert")
+	}
+
+	path := fmt.Sprintf("%s%s", b.APIUrl, dustTransfer)
+	params := url.Values{}
+	for x := range assets {
+		params.Add("asset", common.StringToUpper(assets[x]))
+	}
+
+	return resp, b.SendAuthHTTPRequest(http.MethodPost, path, params, &resp)
+}
+
+// GetAllCoinsInfo returns the deposit and withdrawal networks of all assets
+func (b *Binance) GetAllCoinsInfo() ([]CoinInfo, error) {
+	var resp []CoinInfo
+	path := fmt.Sprintf("%s%s", b.APIUrl, allCoinsInfo)
+
+	return resp, b.SendAuthHTTPRequest(http.MethodGet, path, url.Values{}, &resp)
+}
+
+// GetDepositAddressForNetwork retrieves the deposit address of an asset on a
+// network, an empty network uses the assets default network
+func (b *Binance) GetDepositAddressForNetwork(asset, network string) (NetworkDepositAddress, error) {
+	var resp NetworkDepositAddress
+	path := fmt.Sprintf("%s%s", b.APIUrl, depositAddressNet)
+
+	params := url.Values{}
+	params.Set("coin", common.StringToUpper(asset))
+	if network != "" {
+		params.Set("network", network)
+	}
+
+	return resp, b.SendAuthHTTPRequest(http.MethodGet, path, params, &resp)
+}
+
+// GetWithdrawAddresses returns the withdrawal addresses saved to the account
+// address book, WhiteStatus is set on whitelisted addresses
+func (b *Binance) GetWithdrawAddresses() ([]WithdrawAddress, error) {
+	var resp []WithdrawAddress
+	path := fmt.Sprintf("%s%s", b.APIUrl, withdrawAddresses)
+
+	return resp, b.SendAuthHTTPRequest(http.MethodGet, path, url.Values{}, &resp)
+}
+
 // GetDepositAddressForCurrency retrieves the wallet address for a given currency
 func (b *Binance) GetDepositAddressForCurrency(currency string) (string, error) {
 	path := fmt.Sprintf("%s%s", b.APIUrl, depositAddress)
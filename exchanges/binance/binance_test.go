@@ -7,6 +7,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
 )
 
 // Please supply your own keys here for due diligence testing
@@ -36,6 +37,53 @@ func TestSetup(t *testing.T) {
 	b.Setup(&binanceConfig)
 }
 
+func TestGetServerTime(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetServerTime()
+	if err != nil {
+		t.Error("Test Failed - Binance GetServerTime() error", err)
+	}
+}
+
+func TestQuerySystemStatus(t *testing.T) {
+	t.Parallel()
+	_, err := b.QuerySystemStatus()
+	if err != nil {
+		t.Error("Test Failed - Binance QuerySystemStatus() error", err)
+	}
+}
+
+func TestGetRateLimits(t *testing.T) {
+	t.Parallel()
+	limits, err := b.GetRateLimits()
+	if err != nil {
+		t.Error("Test Failed - Binance GetRateLimits() error", err)
+	}
+	for x := range limits {
+		if limits[x].Limit <= 0 || limits[x].Interval <= 0 {
+			t.Errorf("Test Failed - Binance GetRateLimits() invalid limit %+v",
+				limits[x])
+		}
+	}
+}
+
+func TestIsTimeSkewError(t *testing.T) {
+	t.Parallel()
+	if !isTimeSkewError(&request.Error{
+		StatusCode: 400,
+		Code:       "-1021",
+	}) {
+		t.Error("Test Failed - Binance isTimeSkewError() expected -1021 to be a skew error")
+	}
+
+	if isTimeSkewError(&request.Error{
+		StatusCode: 400,
+		Code:       "-2010",
+	}) {
+		t.Error("Test Failed - Binance isTimeSkewError() unexpected skew error")
+	}
+}
+
 func TestGetExchangeValidCurrencyPairs(t *testing.T) {
 	t.Parallel()
 	_, err := b.GetExchangeValidCurrencyPairs()
@@ -44,6 +92,43 @@ func TestGetExchangeValidCurrencyPairs(t *testing.T) {
 	}
 }
 
+func TestGetOrderLimits(t *testing.T) {
+	t.Parallel()
+	limits, err := b.GetOrderLimits(currency.NewPairFromStrings("BTC", "USDT"))
+	if err != nil {
+		t.Error("Test Failed - Binance GetOrderLimits() error", err)
+	}
+	if limits.MinAmount <= 0 {
+		t.Error("Test Failed - Binance GetOrderLimits() expected minimum amount")
+	}
+}
+
+func TestGetMarketMetadata(t *testing.T) {
+	t.Parallel()
+	p := currency.NewPairFromStrings("BTC", "USDT")
+	if url := b.GetCurrencyTradeURL(p); url != "https://www.binance.com/en/trade/BTC_USDT" {
+		t.Error("Test Failed - Binance GetCurrencyTradeURL() unexpected url", url)
+	}
+	metadata, err := b.GetMarketMetadata(p)
+	if err != nil {
+		t.Error("Test Failed - Binance GetMarketMetadata() error", err)
+	}
+	if metadata.Status == "" || metadata.TradeURL == "" {
+		t.Errorf("Test Failed - Binance GetMarketMetadata() unexpected metadata %+v", metadata)
+	}
+}
+
+func TestGetTradingStatus(t *testing.T) {
+	t.Parallel()
+	status, err := b.GetTradingStatus(currency.NewPairFromStrings("BTC", "USDT"))
+	if err != nil {
+		t.Error("Test Failed - Binance GetTradingStatus() error", err)
+	}
+	if status.Status == "" || status.Message == "" {
+		t.Errorf("Test Failed - Binance GetTradingStatus() unexpected status %+v", status)
+	}
+}
+
 func TestGetOrderBook(t *testing.T) {
 	t.Parallel()
 	_, err := b.GetOrderBook(OrderBookDataRequestParams{
@@ -79,7 +164,10 @@ func TestGetHistoricalTrades(t *testing.T) {
 
 func TestGetAggregatedTrades(t *testing.T) {
 	t.Parallel()
-	_, err := b.GetAggregatedTrades("BTCUSDT", 5)
+	_, err := b.GetAggregatedTrades(&AggregatedTradeRequestParams{
+		Symbol: "BTCUSDT",
+		Limit:  5,
+	})
 	if err != nil {
 		t.Error("Test Failed - Binance GetAggregatedTrades() error", err)
 	}
@@ -196,6 +284,51 @@ func TestOpenOrders(t *testing.T) {
 	}
 }
 
+func TestGetAccountTrades(t *testing.T) {
+	t.Parallel()
+
+	if apiKey == "" || apiSecret == "" {
+		t.Skip()
+	}
+
+	_, err := b.GetAccountTrades("BTCUSDT", 0, 10)
+	if err != nil {
+		t.Error("Test Failed - Binance GetAccountTrades() error", err)
+	}
+}
+
+func TestGetCredentialPermissions(t *testing.T) {
+	t.Parallel()
+
+	if apiKey == "" || apiSecret == "" {
+		t.Skip()
+	}
+
+	permissions, err := b.GetCredentialPermissions()
+	if err != nil {
+		t.Error("Test Failed - Binance GetCredentialPermissions() error", err)
+	}
+	if len(permissions) == 0 || permissions[0] != exchange.CredentialPermissionRead {
+		t.Error("Test Failed - Binance GetCredentialPermissions() expected read permission")
+	}
+}
+
+func TestGetTransferNetworks(t *testing.T) {
+	t.Parallel()
+
+	if apiKey == "" || apiSecret == "" {
+		t.Skip()
+	}
+
+	networks, err := b.GetTransferNetworks(currency.USDT)
+	if err != nil {
+		t.Error("Test Failed - Binance GetTransferNetworks() error", err)
+	}
+	if len(networks) == 0 {
+		t.Error("Test Failed - Binance GetTransferNetworks() expected networks")
+	}
+}
+
 func TestAllOrders(t *testing.T) {
 	t.Parallel()
 
@@ -390,6 +523,18 @@ func TestGetOrderHistory(t *testing.T) {
 	}
 }
 
+func TestGetWithdrawalWhitelist(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	_, err := b.GetWithdrawalWhitelist()
+	if areTestAPIKeysSet() && err != nil {
+		t.Errorf("Could not get withdrawal whitelist: %s", err)
+	} else if !areTestAPIKeysSet() && err == nil {
+		t.Error("Expecting an error when no keys are set")
+	}
+}
+
 // Any tests below this line have the ability to impact your orders on the exchange. Enable canManipulateRealOrders to run them
 // -----------------------------------------------------------------------------------------------------------------------------
 
@@ -418,6 +563,45 @@ func TestSubmitOrder(t *testing.T) {
 	}
 }
 
+func TestSubmitFlaggedOrder(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	p := currency.NewPair(currency.LTC, currency.BTC)
+	_, err := b.SubmitFlaggedOrder(p, exchange.BuyOrderSide, exchange.LimitOrderType,
+		1, 1, "clientId", exchange.OrderFlags{ReduceOnly: true})
+	if err != exchange.ErrReduceOnlyUnsupported {
+		t.Error("Expecting reduce-only orders to be rejected, got", err)
+	}
+
+	_, err = b.SubmitFlaggedOrder(p, exchange.BuyOrderSide, exchange.MarketOrderType,
+		1, 1, "clientId", exchange.OrderFlags{PostOnly: true})
+	if err != exchange.ErrPostOnlyMarketOrder {
+		t.Error("Expecting post-only market orders to be rejected, got", err)
+	}
+
+	response, err := b.SubmitFlaggedOrder(p, exchange.BuyOrderSide, exchange.LimitOrderType,
+		1, 1, "clientId", exchange.OrderFlags{PostOnly: true})
+	if areTestAPIKeysSet() && (err != nil || !response.IsOrderPlaced) {
+		t.Errorf("Order failed to be placed: %v", err)
+	} else if !areTestAPIKeysSet() && err == nil {
+		t.Error("Expecting an error when no keys are set")
+	}
+}
+
+func TestSubmitQuoteAmountOrder(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	p := currency.NewPair(currency.LTC, currency.BTC)
+	response, err := b.SubmitQuoteAmountOrder(p, exchange.BuyOrderSide, 0.01, "clientId")
+	if areTestAPIKeysSet() && (err != nil || !response.IsOrderPlaced) {
+		t.Errorf("Order failed to be placed: %v", err)
+	} else if !areTestAPIKeysSet() && err == nil {
+		t.Error("Expecting an error when no keys are set")
+	}
+}
+
 func TestCancelExchangeOrder(t *testing.T) {
 	b.SetDefaults()
 	TestSetup(t)
@@ -440,6 +624,19 @@ func TestCancelExchangeOrder(t *testing.T) {
 	}
 }
 
+func TestCancelOrderByClientID(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	err := b.CancelOrderByClientID("gct-test", currency.NewPair(currency.LTC, currency.BTC))
+	if !areTestAPIKeysSet() && err == nil {
+		t.Error("Expecting an error when no keys are set")
+	}
+	if areTestAPIKeysSet() && err != nil {
+		t.Errorf("Could not cancel order: %v", err)
+	}
+}
+
 func TestCancelAllExchangeOrders(t *testing.T) {
 	b.SetDefaults()
 	TestSetup(t)
@@ -462,8 +659,8 @@ func TestCancelAllExchangeOrders(t *testing.T) {
 		t.Errorf("Could not cancel order: %v", err)
 	}
 
-	if len(resp.OrderStatus) > 0 {
-		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
+	if len(resp.Failed()) > 0 {
+		t.Errorf("%v orders failed to cancel", len(resp.Failed()))
 	}
 }
 
@@ -556,3 +753,20 @@ func TestGetDepositAddress(t *testing.T) {
 		}
 	}
 }
+
+func TestConvertDust(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	if areTestAPIKeysSet() && !canManipulateRealOrders {
+		t.Skip("API keys set, canManipulateRealOrders false, skipping test")
+	}
+
+	_, err := b.ConvertDust([]currency.Code{currency.LTC})
+	if !areTestAPIKeysSet() && err == nil {
+		t.Error("Expecting an error when no keys are set")
+	}
+	if areTestAPIKeysSet() && err != nil {
+		t.Errorf("Dust conversion failed: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package binance
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/currency"
 )
@@ -21,6 +22,7 @@ type ExchangeInfo struct {
 	RateLimits []struct {
 		RateLimitType string `json:"rateLimitType"`
 		Interval      string `json:"interval"`
+		IntervalNum   int    `json:"intervalNum"`
 		Limit         int    `json:"limit"`
 	} `json:"rateLimits"`
 	ExchangeFilters interface{} `json:"exchangeFilters"`
@@ -107,6 +109,16 @@ type RecentTradeRequestParams struct {
 	Limit  int    `json:"limit"`  // Default 500; max 500.
 }
 
+// AggregatedTradeRequestParams holds aggregated trade request data, StartTime
+// and EndTime are in milliseconds and must be less than an hour apart
+type AggregatedTradeRequestParams struct {
+	Symbol    string // Required field; example LTCBTC, BTCUSDT
+	Limit     int    // Default 500; max 1000.
+	FromID    int64
+	StartTime int64
+	EndTime   int64
+}
+
 // RecentTrade holds recent trade data
 type RecentTrade struct {
 	Code         int     `json:"code"`
@@ -287,7 +299,10 @@ type NewOrderRequest struct {
 	// Examples are (Good Till Cancel (GTC), Immediate or Cancel (IOC) and Fill Or Kill (FOK))
 	TimeInForce RequestParamsTimeForceType
 	// Quantity
-	Quantity         float64
+	Quantity float64
+	// QuoteOrderQty sizes a market order by the quote currency amount to
+	// spend or receive in place of Quantity
+	QuoteOrderQty    float64
 	Price            float64
 	NewClientOrderID string
 	StopPrice        float64 // Used with STOP_LOSS, STOP_LOSS_LIMIT, TAKE_PROFIT, and TAKE_PROFIT_LIMIT orders.
@@ -336,6 +351,7 @@ type QueryOrderData struct {
 	Price         float64 `json:"price,string"`
 	OrigQty       float64 `json:"origQty,string"`
 	ExecutedQty   float64 `json:"executedQty,string"`
+	QuoteQty      float64 `json:"cummulativeQuoteQty,string"`
 	Status        string  `json:"status"`
 	TimeInForce   string  `json:"timeInForce"`
 	Type          string  `json:"type"`
@@ -346,6 +362,22 @@ type QueryOrderData struct {
 	IsWorking     bool    `json:"isWorking"`
 }
 
+// AccountTrade holds an account trade
+type AccountTrade struct {
+	Symbol          string  `json:"symbol"`
+	ID              int64   `json:"id"`
+	OrderID         int64   `json:"orderId"`
+	Price           float64 `json:"price,string"`
+	Qty             float64 `json:"qty,string"`
+	QuoteQty        float64 `json:"quoteQty,string"`
+	Commission      float64 `json:"commission,string"`
+	CommissionAsset string  `json:"commissionAsset"`
+	Time            int64   `json:"time"`
+	IsBuyer         bool    `json:"isBuyer"`
+	IsMaker         bool    `json:"isMaker"`
+	IsBestMatch     bool    `json:"isBestMatch"`
+}
+
 // Balance holds query order data
 type Balance struct {
 	Asset  string `json:"asset"`
@@ -448,6 +480,24 @@ var (
 	TimeIntervalMonth          = TimeInterval("1M")
 )
 
+// klineIntervals maps fixed length time intervals to their durations
+var klineIntervals = map[TimeInterval]time.Duration{
+	TimeIntervalMinute:         time.Minute,
+	TimeIntervalThreeMinutes:   time.Minute * 3,
+	TimeIntervalFiveMinutes:    time.Minute * 5,
+	TimeIntervalFifteenMinutes: time.Minute * 15,
+	TimeIntervalThirtyMinutes:  time.Minute * 30,
+	TimeIntervalHour:           time.Hour,
+	TimeIntervalTwoHours:       time.Hour * 2,
+	TimeIntervalFourHours:      time.Hour * 4,
+	TimeIntervalSixHours:       time.Hour * 6,
+	TimeIntervalEightHours:     time.Hour * 8,
+	TimeIntervalTwelveHours:    time.Hour * 12,
+	TimeIntervalDay:            time.Hour * 24,
+	TimeIntervalThreeDays:      time.Hour * 24 * 3,
+	TimeIntervalWeek:           time.Hour * 24 * 7,
+}
+
 // WithdrawalFees the large list of predefined withdrawal fees
 // Prone to change
 var WithdrawalFees = map[currency.Code]float64{
@@ -617,6 +667,63 @@ var WithdrawalFees = map[currency.Code]float64{
 	currency.PIVX:    0.02,
 }
 
+// DustTransfer holds the result of converting small balances to BNB
+type DustTransfer struct {
+	TotalServiceCharge float64 `json:"totalServiceCharge,string"`
+	TotalTransferred   float64 `json:"totalTransfered,string"`
+	TransferResult     []struct {
+		Amount              float64 `json:"amount,string"`
+		FromAsset           string  `json:"fromAsset"`
+		OperateTime         int64   `json:"operateTime"`
+		ServiceChargeAmount float64 `json:"serviceChargeAmount,string"`
+		TranID              int64   `json:"tranId"`
+		TransferredAmount   float64 `json:"transferedAmount,string"`
+	} `json:"transferResult"`
+}
+
+// CoinInfo holds the deposit and withdrawal networks of an asset
+type CoinInfo struct {
+	Coin        string `json:"coin"`
+	Name        string `json:"name"`
+	NetworkList []struct {
+		Network        string  `json:"network"`
+		Coin           string  `json:"coin"`
+		IsDefault      bool    `json:"isDefault"`
+		DepositEnable  bool    `json:"depositEnable"`
+		WithdrawEnable bool    `json:"withdrawEnable"`
+		WithdrawFee    float64 `json:"withdrawFee,string"`
+		WithdrawMin    float64 `json:"withdrawMin,string"`
+		MinConfirm     int     `json:"minConfirm"`
+	} `json:"networkList"`
+}
+
+// NetworkDepositAddress holds the deposit address of an asset on a network
+type NetworkDepositAddress struct {
+	Address string `json:"address"`
+	Coin    string `json:"coin"`
+	Tag     string `json:"tag"`
+	URL     string `json:"url"`
+}
+
+// WithdrawAddress holds a withdrawal address saved to the account address book
+type WithdrawAddress struct {
+	Address     string `json:"address"`
+	AddressTag  string `json:"addressTag"`
+	Coin        string `json:"coin"`
+	Name        string `json:"name"`
+	Network     string `json:"network"`
+	Origin      string `json:"origin"`
+	OriginType  string `json:"originType"`
+	WhiteStatus bool   `json:"whiteStatus"`
+}
+
+// SystemStatus holds whether the platform is operating normally or under
+// system maintenance
+type SystemStatus struct {
+	Status int    `json:"status"`
+	Msg    string `json:"msg"`
+}
+
 // WithdrawResponse contains status of withdrawal request
 type WithdrawResponse struct {
 	Success bool   `json:"success"`
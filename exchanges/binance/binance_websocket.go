@@ -7,7 +7,6 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -22,10 +21,8 @@ const (
 	binanceDefaultWebsocketURL = "wss://stream.binance.com:9443"
 )
 
-var lastUpdateID map[string]int64
-var m sync.Mutex
-
-// SeedLocalCache seeds depth data
+// SeedLocalCache seeds depth data, syncing the local orderbook from the REST
+// snapshot and any depth updates received since
 func (b *Binance) SeedLocalCache(p currency.Pair) error {
 	var newOrderBook orderbook.Base
 
@@ -41,14 +38,6 @@ func (b *Binance) SeedLocalCache(p currency.Pair) error {
 		return err
 	}
 
-	m.Lock()
-	if lastUpdateID == nil {
-		lastUpdateID = make(map[string]int64)
-	}
-
-	lastUpdateID[formattedPair.String()] = orderbookNew.LastUpdateID
-	m.Unlock()
-
 	for _, bids := range orderbookNew.Bids {
 		newOrderBook.Bids = append(newOrderBook.Bids,
 			orderbook.Item{Amount: bids.Quantity, Price: bids.Price})
@@ -60,28 +49,14 @@ func (b *Binance) SeedLocalCache(p currency.Pair) error {
 
 	newOrderBook.Pair = currency.NewPairFromString(formattedPair.String())
 	newOrderBook.AssetType = ticker.Spot
+	newOrderBook.ExchangeName = b.GetName()
 
-	return b.Websocket.Orderbook.LoadSnapshot(&newOrderBook, b.GetName(), false)
+	return b.Websocket.Orderbook.SyncSnapshot(&newOrderBook, orderbookNew.LastUpdateID)
 }
 
-// UpdateLocalCache updates and returns the most recent iteration of the orderbook
+// UpdateLocalCache applies a depth update to the local orderbook, updates
+// covered by the snapshot are skipped and a gap in the update IDs resyncs it
 func (b *Binance) UpdateLocalCache(ob *WebsocketDepthStream) error {
-	m.Lock()
-	ID, ok := lastUpdateID[ob.Pair]
-	if !ok {
-		m.Unlock()
-		return errors.New("binance_websocket.go - Unable to find lastUpdateID")
-	}
-
-	if ob.LastUpdateID+1 <= ID || ID >= ob.LastUpdateID+1 {
-		// Drop update, out of order
-		m.Unlock()
-		return nil
-	}
-
-	lastUpdateID[ob.Pair] = ob.LastUpdateID
-	m.Unlock()
-
 	var updateBid, updateAsk []orderbook.Item
 
 	for _, bidsToUpdate := range ob.UpdateBids {
@@ -110,15 +85,15 @@ func (b *Binance) UpdateLocalCache(ob *WebsocketDepthStream) error {
 		updateAsk = append(updateAsk, priceToBeUpdated)
 	}
 
-	updatedTime := time.Unix(ob.Timestamp, 0)
-	currencyPair := currency.NewPairFromString(ob.Pair)
-
-	return b.Websocket.Orderbook.Update(updateBid,
-		updateAsk,
-		currencyPair,
-		updatedTime,
-		b.GetName(),
-		"SPOT")
+	return b.Websocket.Orderbook.ApplyDelta(&exchange.WebsocketOrderbookDelta{
+		Pair:          currency.NewPairFromString(ob.Pair),
+		AssetType:     ticker.Spot,
+		Bids:          updateBid,
+		Asks:          updateAsk,
+		FirstUpdateID: ob.FirstUpdateID,
+		UpdateID:      ob.LastUpdateID,
+		Updated:       time.Unix(0, ob.Timestamp*int64(time.Millisecond)),
+	})
 }
 
 // WSConnect intiates a websocket connection
@@ -164,13 +139,6 @@ func (b *Binance) WSConnect() error {
 		Dialer.Proxy = http.ProxyURL(u)
 	}
 
-	for _, ePair := range b.GetEnabledCurrencies() {
-		err = b.SeedLocalCache(ePair)
-		if err != nil {
-			return err
-		}
-	}
-
 	b.WebsocketConn, _, err = Dialer.Dial(wsurl, http.Header{})
 	if err != nil {
 		return fmt.Errorf("binance_websocket.go - Unable to connect to Websocket. Error: %s",
@@ -179,6 +147,15 @@ func (b *Binance) WSConnect() error {
 
 	go b.WsHandleData()
 
+	// Snapshots are seeded once connected, depth updates received meanwhile
+	// are held and applied on top of them
+	for _, ePair := range b.GetEnabledCurrencies() {
+		err = b.SeedLocalCache(ePair)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -295,12 +272,12 @@ func (b *Binance) WsHandleData() {
 
 					var wsKline exchange.KlineData
 
-					wsKline.Timestamp = time.Unix(0, kline.EventTime)
+					wsKline.Timestamp = time.Unix(0, kline.EventTime*int64(time.Millisecond))
 					wsKline.Pair = currency.NewPairFromString(kline.Symbol)
 					wsKline.AssetType = ticker.Spot
 					wsKline.Exchange = b.GetName()
-					wsKline.StartTime = time.Unix(0, kline.Kline.StartTime)
-					wsKline.CloseTime = time.Unix(0, kline.Kline.CloseTime)
+					wsKline.StartTime = time.Unix(0, kline.Kline.StartTime*int64(time.Millisecond))
+					wsKline.CloseTime = time.Unix(0, kline.Kline.CloseTime*int64(time.Millisecond))
 					wsKline.Interval = kline.Kline.Interval
 					wsKline.OpenPrice, _ = strconv.ParseFloat(kline.Kline.OpenPrice, 64)
 					wsKline.ClosePrice, _ = strconv.ParseFloat(kline.Kline.ClosePrice, 64)
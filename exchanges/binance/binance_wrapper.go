@@ -11,6 +11,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -39,42 +40,48 @@ func (b *Binance) Run() {
 			b.EnabledPairs)
 	}
 
-	symbols, err := b.GetExchangeValidCurrencyPairs()
-	if err != nil {
-		log.Errorf("%s Failed to get exchange info.\n", b.GetName())
-	} else {
-		forceUpgrade := false
-		if !common.StringDataContains(b.EnabledPairs.Strings(), "-") ||
-			!common.StringDataContains(b.AvailablePairs.Strings(), "-") {
-			forceUpgrade = true
-		}
+	forceUpgrade := false
+	if !common.StringDataContains(b.EnabledPairs.Strings(), "-") ||
+		!common.StringDataContains(b.AvailablePairs.Strings(), "-") {
+		forceUpgrade = true
+	}
 
-		if forceUpgrade {
-			enabledPairs := currency.Pairs{currency.Pair{
-				Base:      currency.BTC,
-				Quote:     currency.USDT,
-				Delimiter: "-",
-			}}
+	if forceUpgrade {
+		enabledPairs := currency.Pairs{currency.Pair{
+			Base:      currency.BTC,
+			Quote:     currency.USDT,
+			Delimiter: "-",
+		}}
 
-			log.Warn("Available pairs for Binance reset due to config upgrade, please enable the ones you would like again")
+		log.Warn("Available pairs for Binance reset due to config upgrade, please enable the ones you would like again")
 
-			err = b.UpdateCurrencies(enabledPairs, true, true)
-			if err != nil {
-				log.Errorf("%s Failed to get config.\n", b.GetName())
-			}
-		}
-
-		var newSymbols currency.Pairs
-		for _, p := range symbols {
-			newSymbols = append(newSymbols,
-				currency.NewPairFromString(p))
-		}
-
-		err = b.UpdateCurrencies(newSymbols, false, forceUpgrade)
+		err := b.UpdateCurrencies(enabledPairs, true, true)
 		if err != nil {
 			log.Errorf("%s Failed to get config.\n", b.GetName())
 		}
 	}
+
+	err := b.UpdateTradablePairs(forceUpgrade)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", b.Name, err)
+	}
+}
+
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (b *Binance) UpdateTradablePairs(forceUpdate bool) error {
+	symbols, err := b.GetExchangeValidCurrencyPairs()
+	if err != nil {
+		return err
+	}
+
+	var newSymbols currency.Pairs
+	for _, p := range symbols {
+		newSymbols = append(newSymbols,
+			currency.NewPairFromString(p))
+	}
+
+	return b.UpdateCurrencies(newSymbols, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -173,11 +180,8 @@ func (b *Binance) GetAccountInfo() (exchange.AccountInfo, error) {
 			return info, err
 		}
 
-		currencyBalance = append(currencyBalance, exchange.AccountCurrencyInfo{
-			CurrencyName: currency.NewCode(balance.Asset),
-			TotalValue:   freeCurrency + lockedCurrency,
-			Hold:         freeCurrency,
-		})
+		currencyBalance = append(currencyBalance, exchange.NewAccountCurrencyInfo(
+			currency.NewCode(balance.Asset), freeCurrency, lockedCurrency))
 	}
 
 	info.Exchange = b.GetName()
@@ -202,9 +206,23 @@ func (b *Binance) GetExchangeHistory(p currency.Pair, assetType string) ([]excha
 }
 
 // SubmitOrder submits a new order
-func (b *Binance) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+func (b *Binance) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	return b.SubmitFlaggedOrder(p, side, orderType, amount, price, clientID, exchange.OrderFlags{})
+}
+
+// SubmitFlaggedOrder submits a new order with execution flags, post-only
+// orders are placed as LIMIT_MAKER orders. Reduce-only orders are rejected as
+// Binance spot has no positions
+func (b *Binance) SubmitFlaggedOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string, flags exchange.OrderFlags) (exchange.SubmitOrderResponse, error) {
+	if b.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 
+	if flags.ReduceOnly {
+		return submitOrderResponse, exchange.ErrReduceOnlyUnsupported
+	}
+
 	var sideType RequestParamsSideType
 	if side == exchange.BuyOrderSide {
 		sideType = BinanceRequestParamsSideBuy
@@ -213,10 +231,16 @@ func (b *Binance) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderTyp
 	}
 
 	var requestParamsOrderType RequestParamsOrderType
-	switch orderType {
-	case exchange.MarketOrderType:
+	timeInForce := BinanceRequestParamsTimeGTC
+	switch {
+	case flags.PostOnly && orderType != exchange.LimitOrderType:
+		return submitOrderResponse, exchange.ErrPostOnlyMarketOrder
+	case flags.PostOnly:
+		requestParamsOrderType = BinanceRequestParamsOrderLimitMarker
+		timeInForce = ""
+	case orderType == exchange.MarketOrderType:
 		requestParamsOrderType = BinanceRequestParamsOrderMarket
-	case exchange.LimitOrderType:
+	case orderType == exchange.LimitOrderType:
 		requestParamsOrderType = BinanceRequestParamsOrderLimit
 	default:
 		submitOrderResponse.IsOrderPlaced = false
@@ -224,12 +248,13 @@ func (b *Binance) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderTyp
 	}
 
 	var orderRequest = NewOrderRequest{
-		Symbol:      p.Base.String() + p.Quote.String(),
-		Side:        sideType,
-		Price:       price,
-		Quantity:    amount,
-		TradeType:   requestParamsOrderType,
-		TimeInForce: BinanceRequestParamsTimeGTC,
+		Symbol:           p.Base.String() + p.Quote.String(),
+		Side:             sideType,
+		Price:            price,
+		Quantity:         amount,
+		TradeType:        requestParamsOrderType,
+		TimeInForce:      timeInForce,
+		NewClientOrderID: clientID,
 	}
 
 	response, err := b.NewOrder(&orderRequest)
@@ -248,11 +273,46 @@ func (b *Binance) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderTyp
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Binance) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
+// SubmitQuoteAmountOrder submits a market order sized by the quote currency
+// amount to spend on a buy or receive from a sell
+func (b *Binance) SubmitQuoteAmountOrder(p currency.Pair, side exchange.OrderSide, quoteAmount float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if b.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
+	var submitOrderResponse exchange.SubmitOrderResponse
+
+	sideType := BinanceRequestParamsSideSell
+	if side == exchange.BuyOrderSide {
+		sideType = BinanceRequestParamsSideBuy
+	}
+
+	response, err := b.NewOrder(&NewOrderRequest{
+		Symbol:           p.Base.String() + p.Quote.String(),
+		Side:             sideType,
+		TradeType:        BinanceRequestParamsOrderMarket,
+		QuoteOrderQty:    quoteAmount,
+		NewClientOrderID: clientID,
+	})
+	if response.OrderID > 0 {
+		submitOrderResponse.OrderID = fmt.Sprintf("%v", response.OrderID)
+	}
+	if err == nil {
+		submitOrderResponse.IsOrderPlaced = true
+	}
+	return submitOrderResponse, err
+}
+
 // CancelOrder cancels an order by its corresponding ID number
 func (b *Binance) CancelOrder(order *exchange.OrderCancellation) error {
+	if b.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 	if err != nil {
 		return err
@@ -265,11 +325,24 @@ func (b *Binance) CancelOrder(order *exchange.OrderCancellation) error {
 	return err
 }
 
+// CancelOrderByClientID cancels an order by the client order ID it was
+// submitted with
+func (b *Binance) CancelOrderByClientID(clientID string, p currency.Pair) error {
+	if b.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
+	_, err := b.CancelExistingOrder(exchange.FormatExchangeCurrency(b.Name, p).String(),
+		0,
+		clientID)
+	return err
+}
+
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *Binance) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if b.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	openOrders, err := b.OpenOrders("")
 	if err != nil {
 		return cancelAllOrdersResponse, err
@@ -277,9 +350,7 @@ func (b *Binance) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.Cance
 
 	for i := range openOrders {
 		_, err = b.CancelExistingOrder(openOrders[i].Symbol, openOrders[i].OrderID, "")
-		if err != nil {
-			cancelAllOrdersResponse.OrderStatus[strconv.FormatInt(openOrders[i].OrderID, 10)] = err.Error()
-		}
+		cancelAllOrdersResponse.Add(strconv.FormatInt(openOrders[i].OrderID, 10), err)
 	}
 
 	return cancelAllOrdersResponse, nil
@@ -299,8 +370,11 @@ func (b *Binance) GetDepositAddress(cryptocurrency currency.Code, _ string) (str
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (b *Binance) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	amountStr := strconv.FormatFloat(withdrawRequest.Amount, 'f', -1, 64)
-	id, err := b.WithdrawCrypto(withdrawRequest.Currency.String(), withdrawRequest.Address, withdrawRequest.AddressTag, withdrawRequest.Description, amountStr)
+	id, err := b.WithdrawCrypto(withdrawRequest.Currency.String(), withdrawRequest.Address, withdrawRequest.AddressTag, withdrawRequest.Network, withdrawRequest.Description, amountStr)
 
 	return strconv.FormatInt(id, 10), err
 }
@@ -308,12 +382,18 @@ func (b *Binance) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.Withdraw
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Binance) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Binance) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -331,6 +411,37 @@ func (b *Binance) GetFeeByType(feeBuilder *exchange.FeeBuilder) (float64, error)
 	return b.GetFee(feeBuilder)
 }
 
+// GetAccountTradeHistory returns the next page of account trades for a
+// currency pair with an ID greater than fromID
+func (b *Binance) GetAccountTradeHistory(p currency.Pair, fromID int64) ([]exchange.TradeHistory, error) {
+	trades, err := b.GetAccountTrades(exchange.FormatExchangeCurrency(b.Name, p).String(),
+		fromID+1,
+		binanceAccountTradesLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]exchange.TradeHistory, len(trades))
+	for i := range trades {
+		side := exchange.SellOrderSide
+		if trades[i].IsBuyer {
+			side = exchange.BuyOrderSide
+		}
+		resp[i] = exchange.TradeHistory{
+			Timestamp:   time.Unix(0, trades[i].Time*int64(time.Millisecond)),
+			TID:         trades[i].ID,
+			Price:       trades[i].Price,
+			Amount:      trades[i].Qty,
+			Exchange:    b.Name,
+			Type:        side.ToString(),
+			Fee:         trades[i].Commission,
+			FeeAsset:    currency.NewCode(trades[i].CommissionAsset),
+			Description: strconv.FormatInt(trades[i].OrderID, 10),
+		}
+	}
+	return resp, nil
+}
+
 // GetActiveOrders retrieves any orders that are active/open
 func (b *Binance) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
 	if len(getOrdersRequest.Currencies) == 0 {
@@ -363,6 +474,7 @@ func (b *Binance) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) (
 		}
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
@@ -393,20 +505,29 @@ func (b *Binance) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) (
 				continue
 			}
 
+			var averagePrice float64
+			if resp[i].ExecutedQty > 0 {
+				averagePrice = resp[i].QuoteQty / resp[i].ExecutedQty
+			}
+
 			orders = append(orders, exchange.OrderDetail{
-				Amount:       resp[i].OrigQty,
-				OrderDate:    orderDate,
-				Exchange:     b.Name,
-				ID:           fmt.Sprintf("%v", resp[i].OrderID),
-				OrderSide:    orderSide,
-				OrderType:    orderType,
-				Price:        resp[i].Price,
-				CurrencyPair: currency.NewPairFromString(resp[i].Symbol),
-				Status:       resp[i].Status,
+				Amount:               resp[i].OrigQty,
+				ExecutedAmount:       resp[i].ExecutedQty,
+				RemainingAmount:      resp[i].OrigQty - resp[i].ExecutedQty,
+				AverageExecutedPrice: averagePrice,
+				OrderDate:            orderDate,
+				Exchange:             b.Name,
+				ID:                   fmt.Sprintf("%v", resp[i].OrderID),
+				OrderSide:            orderSide,
+				OrderType:            orderType,
+				Price:                resp[i].Price,
+				CurrencyPair:         currency.NewPairFromString(resp[i].Symbol),
+				Status:               resp[i].Status,
 			})
 		}
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
@@ -425,3 +546,367 @@ func (b *Binance) SubscribeToWebsocketChannels(channels []exchange.WebsocketChan
 func (b *Binance) UnsubscribeToWebsocketChannels(channels []exchange.WebsocketChannelSubscription) error {
 	return common.ErrFunctionNotSupported
 }
+
+// GetHistoricCandles returns candles between start and end inclusive, paging
+// through the kline endpoint as needed
+func (b *Binance) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	var timeInterval TimeInterval
+	for k, v := range klineIntervals {
+		if v == interval {
+			timeInterval = k
+			break
+		}
+	}
+	if timeInterval == "" {
+		return nil, fmt.Errorf("%s unsupported kline interval %s", b.Name, interval)
+	}
+
+	var candles []kline.Candle
+	for start.Before(end) || start.Equal(end) {
+		resp, err := b.GetSpotKline(KlinesRequestParams{
+			Symbol:    exchange.FormatExchangeCurrency(b.Name, p).String(),
+			Interval:  timeInterval,
+			Limit:     500,
+			StartTime: start.UnixNano() / int64(time.Millisecond),
+			EndTime:   end.UnixNano() / int64(time.Millisecond),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(resp) == 0 {
+			break
+		}
+
+		for x := range resp {
+			candles = append(candles, kline.Candle{
+				Time:   time.Unix(0, int64(resp[x].OpenTime)*int64(time.Millisecond)),
+				Open:   resp[x].Open,
+				High:   resp[x].High,
+				Low:    resp[x].Low,
+				Close:  resp[x].Close,
+				Volume: resp[x].Volume,
+			})
+		}
+		start = candles[len(candles)-1].Time.Add(interval)
+	}
+	return candles, nil
+}
+
+// GetHistoricTrades returns trades between start and end inclusive, oldest
+// first. The aggregated trade endpoint only accepts ranges under an hour long,
+// so hours without trades are skipped until trades are found
+func (b *Binance) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	symbol := exchange.FormatExchangeCurrency(b.Name, p).String()
+	for {
+		rangeEnd := start.Add(binanceAggregatedTradesRange)
+		if rangeEnd.After(end) {
+			rangeEnd = end
+		}
+		resp, err := b.GetAggregatedTrades(&AggregatedTradeRequestParams{
+			Symbol:    symbol,
+			Limit:     binanceAggregatedTradesLimit,
+			StartTime: start.UnixNano() / int64(time.Millisecond),
+			EndTime:   rangeEnd.UnixNano() / int64(time.Millisecond),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(resp) == 0 && rangeEnd.Before(end) {
+			start = rangeEnd
+			continue
+		}
+
+		trades := make([]exchange.TradeHistory, len(resp))
+		for x := range resp {
+			// Trades where the buyer is the maker were sold into the bid
+			side := exchange.BuyOrderSide
+			if resp[x].Maker {
+				side = exchange.SellOrderSide
+			}
+			trades[x] = exchange.TradeHistory{
+				Timestamp: time.Unix(0, resp[x].TimeStamp*int64(time.Millisecond)),
+				TID:       resp[x].ATradeID,
+				Price:     resp[x].Price,
+				Amount:    resp[x].Quantity,
+				Exchange:  b.Name,
+				Type:      side.ToString(),
+			}
+		}
+		return trades, nil
+	}
+}
+
+// GetCredentialPermissions returns the permissions granted to the API
+// credentials
+func (b *Binance) GetCredentialPermissions() ([]string, error) {
+	account, err := b.GetAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := []string{exchange.CredentialPermissionRead}
+	if account.CanTrade {
+		permissions = append(permissions, exchange.CredentialPermissionTrade)
+	}
+	if account.CanWithdraw {
+		permissions = append(permissions, exchange.CredentialPermissionWithdraw)
+	}
+	if account.CanDeposit {
+		permissions = append(permissions, exchange.CredentialPermissionDeposit)
+	}
+	return permissions, nil
+}
+
+// GetOrderLimits returns the minimum order size and the amount and price steps
+// of a currency pair
+func (b *Binance) GetOrderLimits(p currency.Pair) (exchange.OrderLimits, error) {
+	b.orderLimitsMtx.Lock()
+	defer b.orderLimitsMtx.Unlock()
+
+	if time.Since(b.orderLimitsUpdated) > binanceOrderLimitsCacheTTL {
+		info, err := b.GetExchangeInfo()
+		if err != nil {
+			return exchange.OrderLimits{}, err
+		}
+
+		b.orderLimits = make(map[string]exchange.OrderLimits, len(info.Symbols))
+		for x := range info.Symbols {
+			var limits exchange.OrderLimits
+			for y := range info.Symbols[x].Filters {
+				switch info.Symbols[x].Filters[y].FilterType {
+				case "LOT_SIZE":
+					limits.MinAmount = info.Symbols[x].Filters[y].MinQty
+					limits.AmountStep = info.Symbols[x].Filters[y].StepSize
+				case "PRICE_FILTER":
+					limits.PriceStep = info.Symbols[x].Filters[y].TickSize
+				case "MIN_NOTIONAL":
+					limits.MinNotional = info.Symbols[x].Filters[y].MinNotional
+				}
+			}
+			b.orderLimits[info.Symbols[x].Symbol] = limits
+		}
+		b.orderLimitsUpdated = time.Now()
+	}
+
+	limits, ok := b.orderLimits[exchange.FormatExchangeCurrency(b.Name, p).String()]
+	if !ok {
+		return limits, fmt.Errorf("%s order limits not found for %s", b.Name, p)
+	}
+	return limits, nil
+}
+
+// GetCurrencyTradeURL returns the web trading page of a currency pair
+func (b *Binance) GetCurrencyTradeURL(p currency.Pair) string {
+	return fmt.Sprintf(binanceTradeURL, p.Base.Upper(), p.Quote.Upper())
+}
+
+// GetMarketMetadata returns the trade URL and trading status of a currency
+// pair. Binance doesn't publish listing dates
+func (b *Binance) GetMarketMetadata(p currency.Pair) (exchange.MarketMetadata, error) {
+	info, err := b.GetExchangeInfo()
+	if err != nil {
+		return exchange.MarketMetadata{}, err
+	}
+
+	symbol := exchange.FormatExchangeCurrency(b.Name, p).String()
+	for x := range info.Symbols {
+		if info.Symbols[x].Symbol != symbol {
+			continue
+		}
+		return exchange.MarketMetadata{
+			Exchange: b.Name,
+			Pair:     p,
+			TradeURL: b.GetCurrencyTradeURL(p),
+			Status:   info.Symbols[x].Status,
+			Halted:   info.Symbols[x].Status != binanceSymbolTrading,
+		}, nil
+	}
+	return exchange.MarketMetadata{}, fmt.Errorf("%s market not found for %s", b.Name, p)
+}
+
+// GetTradingStatus returns the trading status of a currency pair. Symbols
+// outside of continuous trading, such as halted or on break, accept no orders
+func (b *Binance) GetTradingStatus(p currency.Pair) (exchange.TradingStatus, error) {
+	info, err := b.GetExchangeInfo()
+	if err != nil {
+		return exchange.TradingStatus{}, err
+	}
+
+	symbol := exchange.FormatExchangeCurrency(b.Name, p).String()
+	for x := range info.Symbols {
+		if info.Symbols[x].Symbol != symbol {
+			continue
+		}
+		status := exchange.TradingStatus{
+			Exchange: b.Name,
+			Pair:     p,
+			Status:   exchange.TradingStatusTrading,
+			Message:  info.Symbols[x].Status,
+		}
+		if info.Symbols[x].Status != binanceSymbolTrading {
+			status.Status = exchange.TradingStatusHalted
+		}
+		return status, nil
+	}
+	return exchange.TradingStatus{}, fmt.Errorf("%s market not found for %s", b.Name, p)
+}
+
+// GetDustConversionCurrency returns the currency dust balances are converted
+// into
+func (b *Binance) GetDustConversionCurrency() currency.Code {
+	return currency.BNB
+}
+
+// ConvertDust converts balances below the minimum order size into BNB,
+// returning the amount of BNB received
+func (b *Binance) ConvertDust(currencies []currency.Code) (float64, error) {
+	if b.IsReadOnly() {
+		return 0, exchange.ErrReadOnly
+	}
+	assets := make([]string, len(currencies))
+	for x := range currencies {
+		assets[x] = currencies[x].String()
+	}
+
+	resp, err := b.ConvertDustToBNB(assets)
+	if err != nil {
+		return 0, err
+	}
+	return resp.TotalTransferred, nil
+}
+
+// IsUnderMaintenance returns whether the platform is under system maintenance
+func (b *Binance) IsUnderMaintenance() (bool, error) {
+	status, err := b.QuerySystemStatus()
+	if err != nil {
+		return false, err
+	}
+	return status.Status == binanceSystemMaintenance, nil
+}
+
+// GetSystemStatus returns whether the platform is operational or under system
+// maintenance
+func (b *Binance) GetSystemStatus() (exchange.SystemStatus, error) {
+	status, err := b.QuerySystemStatus()
+	if err != nil {
+		return exchange.SystemStatus{}, err
+	}
+
+	resp := exchange.SystemStatus{
+		Status:  exchange.SystemStatusOperational,
+		Message: status.Msg,
+	}
+	if status.Status == binanceSystemMaintenance {
+		resp.Status = exchange.SystemStatusMaintenance
+	}
+	return resp, nil
+}
+
+// GetWithdrawalWhitelist returns the whitelisted addresses of the account
+// address book. Addresses saved without being whitelisted are left out
+func (b *Binance) GetWithdrawalWhitelist() ([]exchange.WhitelistedAddress, error) {
+	addresses, err := b.GetWithdrawAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []exchange.WhitelistedAddress
+	for x := range addresses {
+		if !addresses[x].WhiteStatus {
+			continue
+		}
+		resp = append(resp, exchange.WhitelistedAddress{
+			Currency: currency.NewCode(addresses[x].Coin),
+			Address:  addresses[x].Address,
+			Tag:      addresses[x].AddressTag,
+			Network:  addresses[x].Network,
+			Label:    addresses[x].Name,
+		})
+	}
+	return resp, nil
+}
+
+// GetRateLimits returns the request weight and order rate limits published in
+// the exchange information
+func (b *Binance) GetRateLimits() ([]exchange.RateLimit, error) {
+	info, err := b.GetExchangeInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []exchange.RateLimit
+	for x := range info.RateLimits {
+		var limitType string
+		switch info.RateLimits[x].RateLimitType {
+		case "REQUEST_WEIGHT", "REQUESTS", "RAW_REQUESTS":
+			limitType = exchange.RateLimitRequests
+		case "ORDERS":
+			limitType = exchange.RateLimitOrders
+		default:
+			continue
+		}
+
+		var interval time.Duration
+		switch info.RateLimits[x].Interval {
+		case "SECOND":
+			interval = time.Second
+		case "MINUTE":
+			interval = time.Minute
+		case "HOUR":
+			interval = time.Hour
+		case "DAY":
+			interval = time.Hour * 24
+		default:
+			continue
+		}
+		if info.RateLimits[x].IntervalNum > 1 {
+			interval *= time.Duration(info.RateLimits[x].IntervalNum)
+		}
+
+		resp = append(resp, exchange.RateLimit{
+			Type:     limitType,
+			Interval: interval,
+			Limit:    info.RateLimits[x].Limit,
+		})
+	}
+	return resp, nil
+}
+
+// GetTransferNetworks returns the networks a currency can be deposited and
+// withdrawn on
+func (b *Binance) GetTransferNetworks(c currency.Code) ([]exchange.TransferNetwork, error) {
+	coins, err := b.GetAllCoinsInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	for x := range coins {
+		if !strings.EqualFold(coins[x].Coin, c.String()) {
+			continue
+		}
+		networks := make([]exchange.TransferNetwork, len(coins[x].NetworkList))
+		for y := range coins[x].NetworkList {
+			networks[y] = exchange.TransferNetwork{
+				Network:         coins[x].NetworkList[y].Network,
+				Default:         coins[x].NetworkList[y].IsDefault,
+				DepositEnabled:  coins[x].NetworkList[y].DepositEnable,
+				WithdrawEnabled: coins[x].NetworkList[y].WithdrawEnable,
+				WithdrawalFee:   coins[x].NetworkList[y].WithdrawFee,
+				MinWithdrawal:   coins[x].NetworkList[y].WithdrawMin,
+				Confirmations:   coins[x].NetworkList[y].MinConfirm,
+			}
+		}
+		return networks, nil
+	}
+	return nil, fmt.Errorf("%s transfer networks not found for %s", b.Name, c)
+}
+
+// GetNetworkDepositAddress returns the deposit address of a currency on a
+// network
+func (b *Binance) GetNetworkDepositAddress(c currency.Code, network string) (exchange.DepositAddress, error) {
+	resp, err := b.GetDepositAddressForNetwork(c.String(), network)
+	if err != nil {
+		return exchange.DepositAddress{}, err
+	}
+	return exchange.DepositAddress{Address: resp.Address, Tag: resp.Tag}, nil
+}
@@ -32,6 +32,7 @@ const (
 	bitfinexOrderbook          = "book/"
 	bitfinexTrades             = "trades/"
 	bitfinexTradesV2           = "https://api.bitfinex.com/v2/trades/%s/hist?limit=1000&start=%s&end=%s"
+	bitfinexCandlesV2          = "candles"
 	bitfinexKeyPermissions     = "key_info"
 	bitfinexLends              = "lends/"
 	bitfinexSymbols            = "symbols/"
@@ -78,6 +79,18 @@ const (
 	// activity. Cancelling orders will be still possible.
 	bitfinexMaintenanceMode = 0
 	bitfinexOperativeMode   = 1
+
+	// bitfinexTotalVolumeCurrency is the account summary entry holding the
+	// total 30 day trading volume across all currencies
+	bitfinexTotalVolumeCurrency = "Total (USD)"
+
+	// bitfinexCandlesLimit is the maximum number of candles returned per
+	// request
+	bitfinexCandlesLimit = 5000
+
+	// bitfinexTradesV2Limit is the maximum number of trades returned per V2
+	// trades request, the newest of its range
+	bitfinexTradesV2Limit = 1000
 )
 
 // Bitfinex is the overarching type across the bitfinex package
@@ -89,6 +102,21 @@ type Bitfinex struct {
 	WebsocketConn         *websocket.Conn
 	WebsocketSubdChannels map[int]WebsocketChanInfo
 	wsRequestMtx          sync.Mutex
+	wsOrderMtx            sync.Mutex
+	wsOrderRequests       map[int64]chan WebsocketOrderNotification
+	wsOrderClientID       int64
+	wsAuthenticated       bool
+}
+
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "Bitfinex",
+		Creator: func() exchange.IBotExchange {
+			return new(Bitfinex)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability,
+	})
 }
 
 // SetDefaults sets the basic defaults for bitfinex
@@ -98,6 +126,7 @@ func (b *Bitfinex) SetDefaults() {
 	b.Verbose = false
 	b.RESTPollingDelay = 10
 	b.WebsocketSubdChannels = make(map[int]WebsocketChanInfo)
+	b.wsOrderRequests = make(map[int64]chan WebsocketOrderNotification)
 	b.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission |
 		exchange.AutoWithdrawFiatWithAPIPermission
 	b.RequestCurrencyPairFormat.Delimiter = ""
@@ -107,6 +136,8 @@ func (b *Bitfinex) SetDefaults() {
 	b.AssetTypes = []string{ticker.Spot}
 	b.SupportsAutoPairUpdating = true
 	b.SupportsRESTTickerBatching = true
+	b.SupportsKlines = true
+	b.SupportsTradeHistory = true
 	b.Requester = request.New(b.Name,
 		request.NewRateLimit(time.Second*60, bitfinexAuthRate),
 		request.NewRateLimit(time.Second*60, bitfinexUnauthRate),
@@ -118,7 +149,8 @@ func (b *Bitfinex) SetDefaults() {
 		exchange.WebsocketTradeDataSupported |
 		exchange.WebsocketOrderbookSupported |
 		exchange.WebsocketSubscribeSupported |
-		exchange.WebsocketUnsubscribeSupported
+		exchange.WebsocketUnsubscribeSupported |
+		exchange.WebsocketSubmitOrderSupported
 }
 
 // Setup takes in the supplied exchange configuration details and sets params
@@ -131,8 +163,10 @@ func (b *Bitfinex) Setup(exch *config.ExchangeConfig) {
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		b.SetHTTPClientTimeout(exch.HTTPTimeout)
 		b.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		b.SetHTTPHeaders(exch.HTTPHeaders)
 		b.RESTPollingDelay = exch.RESTPollingDelay
 		b.Verbose = exch.Verbose
+		b.ReadOnly = exch.ReadOnly
 		b.HTTPDebugging = exch.HTTPDebugging
 		b.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		b.BaseCurrencies = exch.BaseCurrencies
@@ -169,6 +203,7 @@ func (b *Bitfinex) Setup(exch *config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		b.Websocket.SetHeartbeat(b.wsHeartbeat())
 	}
 }
 
@@ -468,6 +503,51 @@ func (b *Bitfinex) GetTradesV2(currencyPair string, timestampStart, timestampEnd
 	return actualHistory, nil
 }
 
+// GetCandles uses the V2 API to get the candles of a currency pair opening
+// between timestampStart and timestampEnd in milliseconds, oldest first
+//
+// symbol e.g. "tBTCUSD"
+// timeFrame e.g. "1m", "1h", "1D"
+func (b *Bitfinex) GetCandles(symbol string, timeFrame TimeInterval, timestampStart, timestampEnd int64, limit int) ([]Candle, error) {
+	var resp [][]interface{}
+	var candles []Candle
+
+	v := url.Values{}
+	v.Set("start", strconv.FormatInt(timestampStart, 10))
+	v.Set("end", strconv.FormatInt(timestampEnd, 10))
+	v.Set("sort", "1")
+	if limit > 0 {
+		v.Set("limit", strconv.Itoa(limit))
+	}
+
+	path := common.EncodeURLValues(fmt.Sprintf("%s/v%s/%s/trade:%s:%s/hist",
+		b.APIUrl,
+		bitfinexAPIVersion2,
+		bitfinexCandlesV2,
+		timeFrame,
+		symbol), v)
+	err := b.SendHTTPRequest(path, &resp, b.Verbose)
+	if err != nil {
+		return nil, err
+	}
+
+	for x := range resp {
+		if len(resp[x]) < 6 {
+			return nil, fmt.Errorf("%s unexpected candle %v", b.Name, resp[x])
+		}
+		var candle Candle
+		ts, _ := resp[x][0].(float64)
+		candle.Timestamp = int64(ts)
+		candle.Open, _ = resp[x][1].(float64)
+		candle.Close, _ = resp[x][2].(float64)
+		candle.High, _ = resp[x][3].(float64)
+		candle.Low, _ = resp[x][4].(float64)
+		candle.Volume, _ = resp[x][5].(float64)
+		candles = append(candles, candle)
+	}
+	return candles, nil
+}
+
 // GetLendbook returns a list of the most recent funding data for the given
 // currency: total amount provided and Flash Return Rate (in % by 365 days) over
 // time
@@ -1016,8 +1096,7 @@ func (b *Bitfinex) SendHTTPRequest(path string, result interface{}, verbose bool
 // unmarshals result to a supplied variable
 func (b *Bitfinex) SendAuthenticatedHTTPRequest(method, path string, params map[string]interface{}, result interface{}) error {
 	if !b.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet,
-			b.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	n := b.Requester.GetNonce(true)
@@ -1036,7 +1115,7 @@ func (b *Bitfinex) SendAuthenticatedHTTPRequest(method, path string, params map[
 	}
 
 	if b.Verbose {
-		log.Debugf("Request JSON: %s\n", PayloadJSON)
+		log.Debugf("Request JSON: %s\n", log.DebugPayload(b.Name, PayloadJSON))
 	}
 
 	PayloadBase64 := common.Base64Encode(PayloadJSON)
@@ -56,6 +56,20 @@ func TestGetPlatformStatus(t *testing.T) {
 	}
 }
 
+func TestGetSystemStatus(t *testing.T) {
+	t.Parallel()
+
+	result, err := b.GetSystemStatus()
+	if err != nil {
+		t.Errorf("TestGetSystemStatus error: %s", err)
+	}
+
+	if result.Status != exchange.SystemStatusOperational &&
+		result.Status != exchange.SystemStatusMaintenance {
+		t.Errorf("TestGetSystemStatus unexpected status %s", result.Status)
+	}
+}
+
 func TestGetLatestSpotPrice(t *testing.T) {
 	t.Parallel()
 	_, err := b.GetLatestSpotPrice("BTCUSD")
@@ -274,6 +288,18 @@ func TestGetAccountSummary(t *testing.T) {
 	}
 }
 
+func TestGetFeeTier(t *testing.T) {
+	if b.APIKey == "" || b.APISecret == "" {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	_, err := b.GetFeeTier(currency.NewPair(currency.BTC, currency.USD))
+	if err == nil {
+		t.Error("Test Failed - GetFeeTier() error:")
+	}
+}
+
 func TestNewDeposit(t *testing.T) {
 	if b.APIKey == "" || b.APISecret == "" {
 		t.SkipNow()
@@ -831,8 +857,8 @@ func TestCancelAllExchangeOrdera(t *testing.T) {
 		t.Errorf("Could not cancel orders: %v", err)
 	}
 
-	if len(resp.OrderStatus) > 0 {
-		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
+	if len(resp.Failed()) > 0 {
+		t.Errorf("%v orders failed to cancel", len(resp.Failed()))
 	}
 }
 
@@ -951,3 +977,75 @@ func TestGetDepositAddress(t *testing.T) {
 		}
 	}
 }
+
+func TestReplayWebsocketMessage(t *testing.T) {
+	var r Bitfinex
+	r.SetDefaults()
+
+	messages := []exchange.WebsocketCaptureMessage{
+		{Raw: []byte(`[0,"on",[1234567890,"BTCUSD",0.5,0.5,"EXCHANGE LIMIT","ACTIVE",9000,0,"2019-06-01T00:00:00Z",0]]`)},
+		{Raw: []byte(`[0,"te",[42,"BTCUSD",1559347200,1234567890,0.25,9000]]`)},
+		{Raw: []byte(`[0,"on",[1234567890,"BTCUSD"]]`)},
+	}
+
+	results, err := exchange.ReplayWebsocketCapture(&r, messages)
+	if err != nil {
+		t.Fatalf("Test Failed - ReplayWebsocketCapture() error %s", err)
+	}
+
+	order, ok := results[0].Data[0].(WebsocketOrder)
+	if results[0].Error != nil || !ok || order.OrderID != 1234567890 || order.Price != 9000 {
+		t.Errorf("Test Failed - ReplayWebsocketCapture() expected order, got %+v",
+			results[0])
+	}
+
+	trade, ok := results[1].Data[0].(WebsocketTradeExecuted)
+	if results[1].Error != nil || !ok || trade.AmountExecuted != 0.25 {
+		t.Errorf("Test Failed - ReplayWebsocketCapture() expected executed trade, got %+v",
+			results[1])
+	}
+
+	if results[2].Error == nil {
+		t.Error("Test Failed - ReplayWebsocketCapture() expected malformed message to fail")
+	}
+}
+
+func TestWsOrderNotification(t *testing.T) {
+	var r Bitfinex
+	r.SetDefaults()
+	r.WsAddSubscriptionChannel(0, "account", "N/A")
+
+	if r.CanSubmitWsOrder() {
+		t.Error("Test Failed - CanSubmitWsOrder() expected false without a connection")
+	}
+
+	accepted := make(chan WebsocketOrderNotification, 1)
+	rejected := make(chan WebsocketOrderNotification, 1)
+	r.wsOrderRequests[1559347200000] = accepted
+	r.wsOrderRequests[1559347200001] = rejected
+
+	r.wsHandleData([]byte(`[0,"n",[1559347200000,"on-req",null,null,[1234567890,null,1559347200000,"tBTCUSD"],null,"SUCCESS","Submitting exchange limit buy order for 0.5 BTC."]]`))
+	r.wsHandleData([]byte(`[0,"n",[1559347200001,"on-req",null,null,[null,null,1559347200001,"tBTCUSD"],null,"ERROR","Invalid order: not enough exchange balance"]]`))
+	r.wsHandleData([]byte(`[0,"n",[1559347200002,"oc-req",null,null,[1234567890,null,1559347200000],null,"SUCCESS",""]]`))
+
+	select {
+	case notification := <-accepted:
+		if notification.OrderID != 1234567890 || notification.Status != bitfinexWebsocketOrderSuccess {
+			t.Errorf("Test Failed - wsHandleData() unexpected notification %+v", notification)
+		}
+	default:
+		t.Fatal("Test Failed - wsHandleData() expected the order notification")
+	}
+	if len(accepted) != 0 {
+		t.Error("Test Failed - wsHandleData() expected other notification types to be ignored")
+	}
+
+	select {
+	case notification := <-rejected:
+		if notification.Status != "ERROR" || notification.Text == "" {
+			t.Errorf("Test Failed - wsHandleData() unexpected notification %+v", notification)
+		}
+	default:
+		t.Fatal("Test Failed - wsHandleData() expected the rejected order notification")
+	}
+}
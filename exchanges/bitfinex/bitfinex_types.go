@@ -1,5 +1,7 @@
 package bitfinex
 
+import "time"
+
 // Ticker holds basic ticker information from the exchange
 type Ticker struct {
 	Mid       float64 `json:"mid,string"`
@@ -90,6 +92,17 @@ type TradeStructureV2 struct {
 	Type      string
 }
 
+// Candle holds a candle returned by the V2 API, Timestamp being its open time
+// in milliseconds
+type Candle struct {
+	Timestamp int64
+	Open      float64
+	Close     float64
+	High      float64
+	Low       float64
+	Volume    float64
+}
+
 // Lendbook holds most recent funding data for a relevant currency
 type Lendbook struct {
 	Bids []Book `json:"bids"`
@@ -438,6 +451,25 @@ type WebsocketOrder struct {
 	Notify     int
 }
 
+// WebsocketNewOrder is the order detail of a websocket new order request.
+// Amount is negative when selling
+type WebsocketNewOrder struct {
+	ClientID int64  `json:"cid"`
+	Type     string `json:"type"`
+	Symbol   string `json:"symbol"`
+	Amount   string `json:"amount"`
+	Price    string `json:"price"`
+}
+
+// WebsocketOrderNotification holds the outcome of a websocket order request
+type WebsocketOrderNotification struct {
+	Type     string
+	OrderID  int64
+	ClientID int64
+	Status   string
+	Text     string
+}
+
 // WebsocketTradeExecuted holds executed trade data
 type WebsocketTradeExecuted struct {
 	TradeID        int64
@@ -466,8 +498,23 @@ var (
 	TimeIntervalThreeHours     = TimeInterval("3h")
 	TimeIntervalSixHours       = TimeInterval("6h")
 	TimeIntervalTwelveHours    = TimeInterval("12h")
-	TimeIntervalDay            = TimeInterval("1d")
-	TimeIntervalSevenDays      = TimeInterval("7d")
-	TimeIntervalFourteenDays   = TimeInterval("14d")
+	TimeIntervalDay            = TimeInterval("1D")
+	TimeIntervalSevenDays      = TimeInterval("7D")
+	TimeIntervalFourteenDays   = TimeInterval("14D")
 	TimeIntervalMonth          = TimeInterval("1M")
 )
+
+// klineIntervals maps fixed length time intervals to their durations
+var klineIntervals = map[TimeInterval]time.Duration{
+	TimeIntervalMinute:         time.Minute,
+	TimeIntervalFiveMinutes:    time.Minute * 5,
+	TimeIntervalFifteenMinutes: time.Minute * 15,
+	TimeIntervalThirtyMinutes:  time.Minute * 30,
+	TimeIntervalHour:           time.Hour,
+	TimeIntervalThreeHours:     time.Hour * 3,
+	TimeIntervalSixHours:       time.Hour * 6,
+	TimeIntervalTwelveHours:    time.Hour * 12,
+	TimeIntervalDay:            time.Hour * 24,
+	TimeIntervalSevenDays:      time.Hour * 24 * 7,
+	TimeIntervalFourteenDays:   time.Hour * 24 * 14,
+}
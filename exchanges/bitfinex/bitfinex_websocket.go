@@ -31,6 +31,9 @@ const (
 	bitfinexWebsocketOrderUpdate        = "ou"
 	bitfinexWebsocketOrderCancel        = "oc"
 	bitfinexWebsocketTradeExecuted      = "te"
+	bitfinexWebsocketNotification       = "n"
+	bitfinexWebsocketOrderNewRequest    = "on-req"
+	bitfinexWebsocketOrderSuccess       = "SUCCESS"
 	bitfinexWebsocketHeartbeat          = "hb"
 	bitfinexWebsocketAlertRestarting    = "20051"
 	bitfinexWebsocketAlertRefreshing    = "20060"
@@ -40,6 +43,14 @@ const (
 	bitfinexWebsocketSubscriptionFailed = "10300"
 	bitfinexWebsocketAlreadySubscribed  = "10301"
 	bitfinexWebsocketUnknownChannel     = "10302"
+
+	// bitfinexWebsocketPingInterval keeps the connection alive, a connection
+	// without traffic for bitfinexWebsocketHeartbeatTimeout is reset
+	bitfinexWebsocketPingInterval     = time.Second * 30
+	bitfinexWebsocketHeartbeatTimeout = time.Minute
+	// bitfinexWebsocketOrderTimeout is how long an order request waits for its
+	// notification
+	bitfinexWebsocketOrderTimeout = time.Second * 10
 )
 
 // WebsocketHandshake defines the communication between the websocket API for
@@ -50,28 +61,38 @@ type WebsocketHandshake struct {
 	Version float64 `json:"version"`
 }
 
-var pongReceive chan struct{}
-
-// WsPingHandler sends a ping request to the websocket server
-func (b *Bitfinex) WsPingHandler() error {
-	req := make(map[string]string)
-	req["event"] = "ping"
-
-	return b.wsSend(req)
+// wsHeartbeat returns the heartbeat keeping the websocket connection alive,
+// Bitfinex replies to ping events with a pong event
+func (b *Bitfinex) wsHeartbeat() exchange.WebsocketHeartbeat {
+	return exchange.WebsocketHeartbeat{
+		Interval: bitfinexWebsocketPingInterval,
+		Timeout:  bitfinexWebsocketHeartbeatTimeout,
+		Payload:  []byte(`{"event":"ping"}`),
+		Send:     b.wsWrite,
+		IsPong: func(raw []byte) bool {
+			var event WebsocketHandshake
+			return common.JSONDecode(raw, &event) == nil && event.Event == "pong"
+		},
+	}
 }
 
 // WsSend sends data to the websocket server
 func (b *Bitfinex) wsSend(data interface{}) error {
-	b.wsRequestMtx.Lock()
-	defer b.wsRequestMtx.Unlock()
 	json, err := common.JSONEncode(data)
 	if err != nil {
 		return err
 	}
+	return b.wsWrite(json)
+}
+
+// wsWrite writes a raw message to the websocket server
+func (b *Bitfinex) wsWrite(payload []byte) error {
+	b.wsRequestMtx.Lock()
+	defer b.wsRequestMtx.Unlock()
 	if b.Verbose {
-		log.Debugf("%v sending message to websocket %v", b.Name, data)
+		log.Debugf("%v sending message to websocket %s", b.Name, log.DebugPayload(b.Name, payload))
 	}
-	return b.WebsocketConn.WriteMessage(websocket.TextMessage, json)
+	return b.WebsocketConn.WriteMessage(websocket.TextMessage, payload)
 }
 
 // WsSendAuth sends a autheticated event payload
@@ -156,6 +177,7 @@ func (b *Bitfinex) WsConnect() error {
 		}
 	}
 
+	b.setWsAuthenticated(false)
 	if b.AuthenticatedAPISupport {
 		err = b.WsSendAuth()
 		if err != nil {
@@ -163,8 +185,6 @@ func (b *Bitfinex) WsConnect() error {
 		}
 	}
 
-	pongReceive = make(chan struct{}, 1)
-
 	go b.WsDataHandler()
 
 	return nil
@@ -206,274 +226,407 @@ func (b *Bitfinex) WsDataHandler() {
 				return
 			}
 
-			if stream.Type == websocket.TextMessage {
-				var result interface{}
-				common.JSONDecode(stream.Raw, &result)
-				switch reflect.TypeOf(result).String() {
-				case "map[string]interface {}":
-					eventData := result.(map[string]interface{})
-					event := eventData["event"]
-					if b.Verbose {
-						log.Debugf("%v Received message. Type '%v' Message: %v", b.Name, event, eventData)
-					}
-					switch event {
-					case "subscribed":
-						b.WsAddSubscriptionChannel(int(eventData["chanId"].(float64)),
-							eventData["channel"].(string),
-							eventData["pair"].(string))
+			if stream.Type != websocket.TextMessage ||
+				b.Websocket.HandleHeartbeat(stream.Raw) {
+				continue
+			}
+			b.wsHandleData(stream.Raw)
+		}
+	}
+}
 
-					case "auth":
-						status := eventData["status"].(string)
+// wsHandleData parses a raw websocket text message
+func (b *Bitfinex) wsHandleData(raw []byte) {
+	var result interface{}
+	common.JSONDecode(raw, &result)
+	switch reflect.TypeOf(result).String() {
+	case "map[string]interface {}":
+		eventData := result.(map[string]interface{})
+		event := eventData["event"]
+		if b.Verbose {
+			log.Debugf("%v Received message. Type '%v' Message: %v", b.Name, event, eventData)
+		}
+		switch event {
+		case "subscribed":
+			b.WsAddSubscriptionChannel(int(eventData["chanId"].(float64)),
+				eventData["channel"].(string),
+				eventData["pair"].(string))
 
-						if status == "OK" {
-							b.WsAddSubscriptionChannel(0, "account", "N/A")
+		case "auth":
+			status := eventData["status"].(string)
 
-						} else if status == "fail" {
-							b.Websocket.DataHandler <- fmt.Errorf("bitfinex.go error - Websocket unable to AUTH. Error code: %s",
-								eventData["code"].(string))
+			if status == "OK" {
+				b.WsAddSubscriptionChannel(0, "account", "N/A")
+				b.setWsAuthenticated(true)
 
-							b.AuthenticatedAPISupport = false
-						}
-					}
+			} else if status == "fail" {
+				b.Websocket.DataHandler <- fmt.Errorf("bitfinex.go error - Websocket unable to AUTH. Error code: %s",
+					eventData["code"].(string))
+
+				b.AuthenticatedAPISupport = false
+			}
+		}
+
+	case "[]interface {}":
+		chanData := result.([]interface{})
+		chanID := int(chanData[0].(float64))
+
+		chanInfo, ok := b.WebsocketSubdChannels[chanID]
+		if !ok {
+			b.Websocket.DataHandler <- fmt.Errorf("bitfinex.go error - Unable to locate chanID: %d",
+				chanID)
+			return
+		}
+		if len(chanData) == 2 {
+			if reflect.TypeOf(chanData[1]).String() == "string" {
+				if chanData[1].(string) == bitfinexWebsocketHeartbeat {
+					return
+				}
+			}
+		}
+
+		switch chanInfo.Channel {
+		case "book":
+			var newOrderbook []WebsocketBook
+			switch len(chanData) {
+			case 2:
+				data := chanData[1].([]interface{})
+				for _, x := range data {
+					y := x.([]interface{})
+					newOrderbook = append(newOrderbook, WebsocketBook{
+						Price:  y[0].(float64),
+						Count:  int(y[1].(float64)),
+						Amount: y[2].(float64)})
+				}
+
+			case 4:
+				newOrderbook = append(newOrderbook, WebsocketBook{
+					Price:  chanData[1].(float64),
+					Count:  int(chanData[2].(float64)),
+					Amount: chanData[3].(float64)})
+			}
+
+			if len(newOrderbook) > 1 {
+				err := b.WsInsertSnapshot(currency.NewPairFromString(chanInfo.Pair),
+					"SPOT",
+					newOrderbook)
+
+				if err != nil {
+					b.Websocket.DataHandler <- fmt.Errorf("bitfinex_websocket.go inserting snapshot error: %s",
+						err)
+				}
+				return
+			}
+
+			err := b.WsUpdateOrderbook(currency.NewPairFromString(chanInfo.Pair),
+				"SPOT",
+				newOrderbook[0])
+
+			if err != nil {
+				b.Websocket.DataHandler <- fmt.Errorf("bitfinex_websocket.go updating orderbook error: %s",
+					err)
+			}
+
+		case "ticker":
+			b.Websocket.DataHandler <- exchange.TickerData{
+				Quantity:   chanData[8].(float64),
+				ClosePrice: chanData[7].(float64),
+				HighPrice:  chanData[9].(float64),
+				LowPrice:   chanData[10].(float64),
+				Pair:       currency.NewPairFromString(chanInfo.Pair),
+				Exchange:   b.GetName(),
+				AssetType:  "SPOT",
+			}
+
+		case "account":
+			switch chanData[1].(string) {
+			case bitfinexWebsocketOrderSnapshot,
+				bitfinexWebsocketOrderNew,
+				bitfinexWebsocketOrderUpdate,
+				bitfinexWebsocketOrderCancel,
+				bitfinexWebsocketTradeExecuted:
+				b.Websocket.CaptureMessage(raw)
+			}
 
-				case "[]interface {}":
-					chanData := result.([]interface{})
-					chanID := int(chanData[0].(float64))
+			switch chanData[1].(string) {
+			case bitfinexWebsocketPositionSnapshot:
+				var positionSnapshot []WebsocketPosition
+				data := chanData[2].([]interface{})
+				for _, x := range data {
+					y := x.([]interface{})
+					positionSnapshot = append(positionSnapshot,
+						WebsocketPosition{
+							Pair:              y[0].(string),
+							Status:            y[1].(string),
+							Amount:            y[2].(float64),
+							Price:             y[3].(float64),
+							MarginFunding:     y[4].(float64),
+							MarginFundingType: int(y[5].(float64))})
+				}
+
+				if len(positionSnapshot) == 0 {
+					return
+				}
+
+				b.Websocket.DataHandler <- positionSnapshot
+
+			case bitfinexWebsocketPositionNew, bitfinexWebsocketPositionUpdate, bitfinexWebsocketPositionClose:
+				data := chanData[2].([]interface{})
+				position := WebsocketPosition{
+					Pair:              data[0].(string),
+					Status:            data[1].(string),
+					Amount:            data[2].(float64),
+					Price:             data[3].(float64),
+					MarginFunding:     data[4].(float64),
+					MarginFundingType: int(data[5].(float64))}
+
+				b.Websocket.DataHandler <- position
+
+			case bitfinexWebsocketWalletSnapshot:
+				data := chanData[2].([]interface{})
+				var walletSnapshot []WebsocketWallet
+				for _, x := range data {
+					y := x.([]interface{})
+					walletSnapshot = append(walletSnapshot,
+						WebsocketWallet{
+							Name:              y[0].(string),
+							Currency:          y[1].(string),
+							Balance:           y[2].(float64),
+							UnsettledInterest: y[3].(float64)})
+				}
+
+				b.Websocket.DataHandler <- walletSnapshot
+
+			case bitfinexWebsocketWalletUpdate:
+				data := chanData[2].([]interface{})
+				wallet := WebsocketWallet{
+					Name:              data[0].(string),
+					Currency:          data[1].(string),
+					Balance:           data[2].(float64),
+					UnsettledInterest: data[3].(float64)}
+
+				b.Websocket.DataHandler <- wallet
+
+			case bitfinexWebsocketOrderSnapshot:
+				var orderSnapshot []WebsocketOrder
+				data := chanData[2].([]interface{})
+				for _, x := range data {
+					y := x.([]interface{})
+					orderSnapshot = append(orderSnapshot,
+						WebsocketOrder{
+							OrderID:    int64(y[0].(float64)),
+							Pair:       y[1].(string),
+							Amount:     y[2].(float64),
+							OrigAmount: y[3].(float64),
+							OrderType:  y[4].(string),
+							Status:     y[5].(string),
+							Price:      y[6].(float64),
+							PriceAvg:   y[7].(float64),
+							Timestamp:  y[8].(string)})
+				}
+
+				b.Websocket.DataHandler <- orderSnapshot
+
+			case bitfinexWebsocketOrderNew, bitfinexWebsocketOrderUpdate, bitfinexWebsocketOrderCancel:
+				data := chanData[2].([]interface{})
+				order := WebsocketOrder{
+					OrderID:    int64(data[0].(float64)),
+					Pair:       data[1].(string),
+					Amount:     data[2].(float64),
+					OrigAmount: data[3].(float64),
+					OrderType:  data[4].(string),
+					Status:     data[5].(string),
+					Price:      data[6].(float64),
+					PriceAvg:   data[7].(float64),
+					Timestamp:  data[8].(string),
+					Notify:     int(data[9].(float64))}
+
+				b.Websocket.DataHandler <- order
+
+			case bitfinexWebsocketTradeExecuted:
+				data := chanData[2].([]interface{})
+				trade := WebsocketTradeExecuted{
+					TradeID:        int64(data[0].(float64)),
+					Pair:           data[1].(string),
+					Timestamp:      int64(data[2].(float64)),
+					OrderID:        int64(data[3].(float64)),
+					AmountExecuted: data[4].(float64),
+					PriceExecuted:  data[5].(float64)}
+
+				b.Websocket.DataHandler <- trade
+
+			case bitfinexWebsocketNotification:
+				notification, ok := parseWsOrderNotification(chanData[2])
+				if !ok {
+					return
+				}
+				b.wsOrderMtx.Lock()
+				resp, ok := b.wsOrderRequests[notification.ClientID]
+				b.wsOrderMtx.Unlock()
+				if ok {
+					resp <- notification
+				}
+			}
 
-					chanInfo, ok := b.WebsocketSubdChannels[chanID]
-					if !ok {
-						b.Websocket.DataHandler <- fmt.Errorf("bitfinex.go error - Unable to locate chanID: %d",
-							chanID)
+		case "trades":
+			var trades []WebsocketTrade
+			switch len(chanData) {
+			case 2:
+				data := chanData[1].([]interface{})
+				for _, x := range data {
+					y := x.([]interface{})
+					if _, ok := y[0].(string); ok {
 						continue
 					}
-					if len(chanData) == 2 {
-						if reflect.TypeOf(chanData[1]).String() == "string" {
-							if chanData[1].(string) == bitfinexWebsocketHeartbeat {
-								continue
-							} else if chanData[1].(string) == "pong" {
-								pongReceive <- struct{}{}
-								continue
-							}
-						}
-					}
 
-					switch chanInfo.Channel {
-					case "book":
-						var newOrderbook []WebsocketBook
-						switch len(chanData) {
-						case 2:
-							data := chanData[1].([]interface{})
-							for _, x := range data {
-								y := x.([]interface{})
-								newOrderbook = append(newOrderbook, WebsocketBook{
-									Price:  y[0].(float64),
-									Count:  int(y[1].(float64)),
-									Amount: y[2].(float64)})
-							}
-
-						case 4:
-							newOrderbook = append(newOrderbook, WebsocketBook{
-								Price:  chanData[1].(float64),
-								Count:  int(chanData[2].(float64)),
-								Amount: chanData[3].(float64)})
-						}
-
-						if len(newOrderbook) > 1 {
-							err := b.WsInsertSnapshot(currency.NewPairFromString(chanInfo.Pair),
-								"SPOT",
-								newOrderbook)
-
-							if err != nil {
-								b.Websocket.DataHandler <- fmt.Errorf("bitfinex_websocket.go inserting snapshot error: %s",
-									err)
-							}
-							continue
-						}
-
-						err := b.WsUpdateOrderbook(currency.NewPairFromString(chanInfo.Pair),
-							"SPOT",
-							newOrderbook[0])
-
-						if err != nil {
-							b.Websocket.DataHandler <- fmt.Errorf("bitfinex_websocket.go updating orderbook error: %s",
-								err)
-						}
-
-					case "ticker":
-						b.Websocket.DataHandler <- exchange.TickerData{
-							Quantity:   chanData[8].(float64),
-							ClosePrice: chanData[7].(float64),
-							HighPrice:  chanData[9].(float64),
-							LowPrice:   chanData[10].(float64),
-							Pair:       currency.NewPairFromString(chanInfo.Pair),
-							Exchange:   b.GetName(),
-							AssetType:  "SPOT",
-						}
-
-					case "account":
-						switch chanData[1].(string) {
-						case bitfinexWebsocketPositionSnapshot:
-							var positionSnapshot []WebsocketPosition
-							data := chanData[2].([]interface{})
-							for _, x := range data {
-								y := x.([]interface{})
-								positionSnapshot = append(positionSnapshot,
-									WebsocketPosition{
-										Pair:              y[0].(string),
-										Status:            y[1].(string),
-										Amount:            y[2].(float64),
-										Price:             y[3].(float64),
-										MarginFunding:     y[4].(float64),
-										MarginFundingType: int(y[5].(float64))})
-							}
-
-							if len(positionSnapshot) == 0 {
-								continue
-							}
-
-							b.Websocket.DataHandler <- positionSnapshot
-
-						case bitfinexWebsocketPositionNew, bitfinexWebsocketPositionUpdate, bitfinexWebsocketPositionClose:
-							data := chanData[2].([]interface{})
-							position := WebsocketPosition{
-								Pair:              data[0].(string),
-								Status:            data[1].(string),
-								Amount:            data[2].(float64),
-								Price:             data[3].(float64),
-								MarginFunding:     data[4].(float64),
-								MarginFundingType: int(data[5].(float64))}
-
-							b.Websocket.DataHandler <- position
-
-						case bitfinexWebsocketWalletSnapshot:
-							data := chanData[2].([]interface{})
-							var walletSnapshot []WebsocketWallet
-							for _, x := range data {
-								y := x.([]interface{})
-								walletSnapshot = append(walletSnapshot,
-									WebsocketWallet{
-										Name:              y[0].(string),
-										Currency:          y[1].(string),
-										Balance:           y[2].(float64),
-										UnsettledInterest: y[3].(float64)})
-							}
-
-							b.Websocket.DataHandler <- walletSnapshot
-
-						case bitfinexWebsocketWalletUpdate:
-							data := chanData[2].([]interface{})
-							wallet := WebsocketWallet{
-								Name:              data[0].(string),
-								Currency:          data[1].(string),
-								Balance:           data[2].(float64),
-								UnsettledInterest: data[3].(float64)}
-
-							b.Websocket.DataHandler <- wallet
-
-						case bitfinexWebsocketOrderSnapshot:
-							var orderSnapshot []WebsocketOrder
-							data := chanData[2].([]interface{})
-							for _, x := range data {
-								y := x.([]interface{})
-								orderSnapshot = append(orderSnapshot,
-									WebsocketOrder{
-										OrderID:    int64(y[0].(float64)),
-										Pair:       y[1].(string),
-										Amount:     y[2].(float64),
-										OrigAmount: y[3].(float64),
-										OrderType:  y[4].(string),
-										Status:     y[5].(string),
-										Price:      y[6].(float64),
-										PriceAvg:   y[7].(float64),
-										Timestamp:  y[8].(string)})
-							}
-
-							b.Websocket.DataHandler <- orderSnapshot
-
-						case bitfinexWebsocketOrderNew, bitfinexWebsocketOrderUpdate, bitfinexWebsocketOrderCancel:
-							data := chanData[2].([]interface{})
-							order := WebsocketOrder{
-								OrderID:    int64(data[0].(float64)),
-								Pair:       data[1].(string),
-								Amount:     data[2].(float64),
-								OrigAmount: data[3].(float64),
-								OrderType:  data[4].(string),
-								Status:     data[5].(string),
-								Price:      data[6].(float64),
-								PriceAvg:   data[7].(float64),
-								Timestamp:  data[8].(string),
-								Notify:     int(data[9].(float64))}
-
-							b.Websocket.DataHandler <- order
-
-						case bitfinexWebsocketTradeExecuted:
-							data := chanData[2].([]interface{})
-							trade := WebsocketTradeExecuted{
-								TradeID:        int64(data[0].(float64)),
-								Pair:           data[1].(string),
-								Timestamp:      int64(data[2].(float64)),
-								OrderID:        int64(data[3].(float64)),
-								AmountExecuted: data[4].(float64),
-								PriceExecuted:  data[5].(float64)}
-
-							b.Websocket.DataHandler <- trade
-						}
-
-					case "trades":
-						var trades []WebsocketTrade
-						switch len(chanData) {
-						case 2:
-							data := chanData[1].([]interface{})
-							for _, x := range data {
-								y := x.([]interface{})
-								if _, ok := y[0].(string); ok {
-									continue
-								}
-
-								id, _ := y[0].(float64)
-
-								trades = append(trades,
-									WebsocketTrade{
-										ID:        int64(id),
-										Timestamp: int64(y[1].(float64)),
-										Price:     y[2].(float64),
-										Amount:    y[3].(float64)})
-							}
-
-						case 7:
-							trade := WebsocketTrade{
-								ID:        int64(chanData[3].(float64)),
-								Timestamp: int64(chanData[4].(float64)),
-								Price:     chanData[5].(float64),
-								Amount:    chanData[6].(float64)}
-							trades = append(trades, trade)
-						}
-
-						if len(trades) > 0 {
-							side := "BUY"
-							newAmount := trades[0].Amount
-							if newAmount < 0 {
-								side = "SELL"
-								newAmount *= -1
-							}
-
-							b.Websocket.DataHandler <- exchange.TradeData{
-								CurrencyPair: currency.NewPairFromString(chanInfo.Pair),
-								Timestamp:    time.Unix(trades[0].Timestamp, 0),
-								Price:        trades[0].Price,
-								Amount:       newAmount,
-								Exchange:     b.GetName(),
-								AssetType:    "SPOT",
-								Side:         side,
-							}
-						}
-					}
+					id, _ := y[0].(float64)
+
+					trades = append(trades,
+						WebsocketTrade{
+							ID:        int64(id),
+							Timestamp: int64(y[1].(float64)),
+							Price:     y[2].(float64),
+							Amount:    y[3].(float64)})
+				}
+
+			case 7:
+				trade := WebsocketTrade{
+					ID:        int64(chanData[3].(float64)),
+					Timestamp: int64(chanData[4].(float64)),
+					Price:     chanData[5].(float64),
+					Amount:    chanData[6].(float64)}
+				trades = append(trades, trade)
+			}
+
+			if len(trades) > 0 {
+				side := "BUY"
+				newAmount := trades[0].Amount
+				if newAmount < 0 {
+					side = "SELL"
+					newAmount *= -1
+				}
+
+				b.Websocket.DataHandler <- exchange.TradeData{
+					CurrencyPair: currency.NewPairFromString(chanInfo.Pair),
+					Timestamp:    time.Unix(trades[0].Timestamp, 0),
+					Price:        trades[0].Price,
+					Amount:       newAmount,
+					Exchange:     b.GetName(),
+					AssetType:    "SPOT",
+					Side:         side,
 				}
 			}
 		}
 	}
 }
 
+// setWsAuthenticated records whether the account channel is authenticated,
+// websocket orders are only sent on an authenticated connection
+func (b *Bitfinex) setWsAuthenticated(authenticated bool) {
+	b.wsOrderMtx.Lock()
+	b.wsAuthenticated = authenticated
+	b.wsOrderMtx.Unlock()
+}
+
+// CanSubmitWsOrder returns whether orders can be submitted over the websocket
+// connection
+func (b *Bitfinex) CanSubmitWsOrder() bool {
+	if !b.Websocket.IsConnected() {
+		return false
+	}
+	b.wsOrderMtx.Lock()
+	defer b.wsOrderMtx.Unlock()
+	return b.wsAuthenticated
+}
+
+// WsNewOrder submits a new order over the authenticated websocket connection
+// and waits for its notification, returning the order ID. Sent reports
+// whether the request was written, an order which wasn't sent can safely be
+// resubmitted over REST
+func (b *Bitfinex) WsNewOrder(currencyPair string, amount, price float64, buy bool, orderType string) (orderID int64, sent bool, err error) {
+	if !buy {
+		amount = -amount
+	}
+
+	b.wsOrderMtx.Lock()
+	clientID := time.Now().UnixNano() / int64(time.Millisecond)
+	if clientID <= b.wsOrderClientID {
+		clientID = b.wsOrderClientID + 1
+	}
+	b.wsOrderClientID = clientID
+	resp := make(chan WebsocketOrderNotification, 1)
+	b.wsOrderRequests[clientID] = resp
+	b.wsOrderMtx.Unlock()
+
+	defer func() {
+		b.wsOrderMtx.Lock()
+		delete(b.wsOrderRequests, clientID)
+		b.wsOrderMtx.Unlock()
+	}()
+
+	err = b.wsSend([]interface{}{0, bitfinexWebsocketOrderNew, nil, WebsocketNewOrder{
+		ClientID: clientID,
+		Type:     "EXCHANGE " + common.StringToUpper(orderType),
+		Symbol:   "t" + common.StringToUpper(currencyPair),
+		Amount:   strconv.FormatFloat(amount, 'f', -1, 64),
+		Price:    strconv.FormatFloat(price, 'f', -1, 64),
+	}})
+	if err != nil {
+		return 0, false, err
+	}
+
+	select {
+	case notification := <-resp:
+		if notification.Status != bitfinexWebsocketOrderSuccess {
+			return 0, true, fmt.Errorf("%s websocket order rejected. Status: %s %s",
+				b.Name, notification.Status, notification.Text)
+		}
+		return notification.OrderID, true, nil
+	case <-time.After(bitfinexWebsocketOrderTimeout):
+		return 0, true, fmt.Errorf("%s websocket order %d timed out awaiting notification",
+			b.Name, clientID)
+	}
+}
+
+// parseWsOrderNotification parses an order request notification in the form
+// [MTS, TYPE, MESSAGE_ID, null, [ID, GID, CID, ...], CODE, STATUS, TEXT]
+func parseWsOrderNotification(data interface{}) (WebsocketOrderNotification, bool) {
+	fields, ok := data.([]interface{})
+	if !ok || len(fields) < 8 {
+		return WebsocketOrderNotification{}, false
+	}
+	notificationType, _ := fields[1].(string)
+	if notificationType != bitfinexWebsocketOrderNewRequest {
+		return WebsocketOrderNotification{}, false
+	}
+	order, ok := fields[4].([]interface{})
+	if !ok || len(order) < 3 {
+		return WebsocketOrderNotification{}, false
+	}
+	orderID, _ := order[0].(float64)
+	clientID, _ := order[2].(float64)
+	status, _ := fields[6].(string)
+	text, _ := fields[7].(string)
+	return WebsocketOrderNotification{
+		Type:     notificationType,
+		OrderID:  int64(orderID),
+		ClientID: int64(clientID),
+		Status:   status,
+		Text:     text,
+	}, true
+}
+
+// ReplayWebsocketMessage feeds a captured raw websocket message back through
+// the websocket parsing code. Authenticated account messages are replayed on
+// the account channel
+func (b *Bitfinex) ReplayWebsocketMessage(raw []byte) error {
+	if _, ok := b.WebsocketSubdChannels[0]; !ok {
+		b.WsAddSubscriptionChannel(0, "account", "N/A")
+	}
+	b.wsHandleData(raw)
+	return nil
+}
+
 // WsInsertSnapshot add the initial orderbook snapshot when subscribed to a
 // channel
 func (b *Bitfinex) WsInsertSnapshot(p currency.Pair, assetType string, books []WebsocketBook) error {
@@ -12,6 +12,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -34,21 +35,27 @@ func (b *Bitfinex) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", b.GetName(), len(b.EnabledPairs), b.EnabledPairs)
 	}
 
+	err := b.UpdateTradablePairs(false)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", b.Name, err)
+	}
+}
+
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (b *Bitfinex) UpdateTradablePairs(forceUpdate bool) error {
 	exchangeProducts, err := b.GetSymbols()
 	if err != nil {
-		log.Errorf("%s Failed to get available symbols.\n", b.GetName())
-	} else {
-		var newExchangeProducts currency.Pairs
-		for _, p := range exchangeProducts {
-			newExchangeProducts = append(newExchangeProducts,
-				currency.NewPairFromString(p))
-		}
+		return err
+	}
 
-		err = b.UpdateCurrencies(newExchangeProducts, false, false)
-		if err != nil {
-			log.Errorf("%s Failed to update available symbols.\n", b.GetName())
-		}
+	var newExchangeProducts currency.Pairs
+	for _, p := range exchangeProducts {
+		newExchangeProducts = append(newExchangeProducts,
+			currency.NewPairFromString(p))
 	}
+
+	return b.UpdateCurrencies(newExchangeProducts, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -160,11 +167,8 @@ func (b *Bitfinex) GetAccountInfo() (exchange.AccountInfo, error) {
 		for i := range Accounts {
 			if Accounts[i].ID == bal.Type {
 				Accounts[i].Currencies = append(Accounts[i].Currencies,
-					exchange.AccountCurrencyInfo{
-						CurrencyName: currency.NewCode(bal.Currency),
-						TotalValue:   bal.Amount,
-						Hold:         bal.Amount - bal.Available,
-					})
+					exchange.NewAccountCurrencyInfoFromTotalAvailable(
+						currency.NewCode(bal.Currency), bal.Amount, bal.Available))
 			}
 		}
 	}
@@ -187,8 +191,92 @@ func (b *Bitfinex) GetExchangeHistory(p currency.Pair, assetType string) ([]exch
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive, paging
+// through the candles endpoint as needed
+func (b *Bitfinex) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	var timeFrame TimeInterval
+	for k, v := range klineIntervals {
+		if v == interval {
+			timeFrame = k
+			break
+		}
+	}
+	if timeFrame == "" {
+		return nil, fmt.Errorf("%s unsupported kline interval %s", b.Name, interval)
+	}
+
+	var candles []kline.Candle
+	for start.Before(end) || start.Equal(end) {
+		resp, err := b.GetCandles("t"+exchange.FormatExchangeCurrency(b.Name, p).String(),
+			timeFrame,
+			start.UnixNano()/int64(time.Millisecond),
+			end.UnixNano()/int64(time.Millisecond),
+			bitfinexCandlesLimit)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp) == 0 {
+			break
+		}
+
+		for x := range resp {
+			candles = append(candles, kline.Candle{
+				Time:   time.Unix(0, resp[x].Timestamp*int64(time.Millisecond)),
+				Open:   resp[x].Open,
+				High:   resp[x].High,
+				Low:    resp[x].Low,
+				Close:  resp[x].Close,
+				Volume: resp[x].Volume,
+			})
+		}
+		start = candles[len(candles)-1].Time.Add(interval)
+	}
+	return candles, nil
+}
+
+// GetHistoricTrades returns trades between start and end inclusive, oldest
+// first. The V2 trades endpoint returns the newest trades of a range, so the
+// range is halved until all of its trades are returned
+func (b *Bitfinex) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	symbol := "t" + exchange.FormatExchangeCurrency(b.Name, p).String()
+	rangeEnd := end
+	for {
+		resp, err := b.GetTradesV2(symbol,
+			start.UnixNano()/int64(time.Millisecond),
+			rangeEnd.UnixNano()/int64(time.Millisecond),
+			true)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp) >= bitfinexTradesV2Limit && rangeEnd.Sub(start) > time.Millisecond {
+			rangeEnd = start.Add(rangeEnd.Sub(start) / 2)
+			continue
+		}
+		if len(resp) == 0 && rangeEnd.Before(end) {
+			start, rangeEnd = rangeEnd, end
+			continue
+		}
+
+		trades := make([]exchange.TradeHistory, len(resp))
+		for x := range resp {
+			trades[x] = exchange.TradeHistory{
+				Timestamp: time.Unix(0, resp[x].Timestamp*int64(time.Millisecond)),
+				TID:       resp[x].TID,
+				Price:     resp[x].Price,
+				Amount:    resp[x].Amount,
+				Exchange:  b.Name,
+				Type:      resp[x].Type,
+			}
+		}
+		return trades, nil
+	}
+}
+
 // SubmitOrder submits a new order
 func (b *Bitfinex) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if b.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var isBuying bool
 
@@ -196,6 +284,23 @@ func (b *Bitfinex) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderTy
 		isBuying = true
 	}
 
+	if b.CanSubmitWsOrder() {
+		orderID, sent, err := b.WsNewOrder(p.String(),
+			amount,
+			price,
+			isBuying,
+			orderType.ToString())
+		if sent {
+			if orderID > 0 {
+				submitOrderResponse.OrderID = fmt.Sprintf("%v", orderID)
+			}
+			submitOrderResponse.IsOrderPlaced = err == nil
+			return submitOrderResponse, err
+		}
+		log.Warnf("%s websocket order entry failed, falling back to REST. Error: %s",
+			b.Name, err)
+	}
+
 	response, err := b.NewOrder(p.String(),
 		amount,
 		price,
@@ -217,11 +322,17 @@ func (b *Bitfinex) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderTy
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Bitfinex) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *Bitfinex) CancelOrder(order *exchange.OrderCancellation) error {
+	if b.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -235,6 +346,9 @@ func (b *Bitfinex) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *Bitfinex) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if b.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
+	}
 	_, err := b.CancelAllExistingOrders()
 	return exchange.CancelAllOrdersResponse{}, err
 }
@@ -262,6 +376,9 @@ func (b *Bitfinex) GetDepositAddress(cryptocurrency currency.Code, accountID str
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is submitted
 func (b *Bitfinex) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	withdrawalType := b.ConvertSymbolToWithdrawalType(withdrawRequest.Currency)
 	// Bitfinex has support for three types, exchange, margin and deposit
 	// As this is for trading, I've made the wrapper default 'exchange'
@@ -286,6 +403,9 @@ func (b *Bitfinex) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.Withdra
 // WithdrawFiatFunds returns a withdrawal ID when a withdrawal is submitted
 // Returns comma delimited withdrawal IDs
 func (b *Bitfinex) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	withdrawalType := "wire"
 	// Bitfinex has support for three types, exchange, margin and deposit
 	// As this is for trading, I've made the wrapper default 'exchange'
@@ -319,6 +439,9 @@ func (b *Bitfinex) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest)
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a withdrawal is submitted
 // Returns comma delimited withdrawal IDs
 func (b *Bitfinex) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return b.WithdrawFiatFunds(withdrawRequest)
 }
 
@@ -336,6 +459,71 @@ func (b *Bitfinex) GetFeeByType(feeBuilder *exchange.FeeBuilder) (float64, error
 	return b.GetFee(feeBuilder)
 }
 
+// GetFeeTier returns the maker and taker fees of the account based on its 30
+// day trading volume
+func (b *Bitfinex) GetFeeTier(_ currency.Pair) (exchange.FeeTier, error) {
+	summary, err := b.GetAccountSummary()
+	if err != nil {
+		return exchange.FeeTier{}, err
+	}
+
+	tier := exchange.FeeTier{
+		VolumeCurrency: currency.USD,
+		MakerFee:       summary.MakerFee / 100,
+		TakerFee:       summary.TakerFee / 100,
+	}
+	for x := range summary.TradeVolumePer30D {
+		if summary.TradeVolumePer30D[x].Currency == bitfinexTotalVolumeCurrency {
+			tier.Volume30D = summary.TradeVolumePer30D[x].Volume
+		}
+	}
+	return tier, nil
+}
+
+// IsUnderMaintenance returns whether the platform is in maintenance mode, in
+// which orders can be cancelled but not submitted
+func (b *Bitfinex) IsUnderMaintenance() (bool, error) {
+	status, err := b.GetPlatformStatus()
+	if err != nil {
+		return false, err
+	}
+	return status == bitfinexMaintenanceMode, nil
+}
+
+// GetSystemStatus returns whether the platform is operational or in
+// maintenance mode
+func (b *Bitfinex) GetSystemStatus() (exchange.SystemStatus, error) {
+	status, err := b.GetPlatformStatus()
+	if err != nil {
+		return exchange.SystemStatus{}, err
+	}
+	if status == bitfinexMaintenanceMode {
+		return exchange.SystemStatus{Status: exchange.SystemStatusMaintenance}, nil
+	}
+	return exchange.SystemStatus{Status: exchange.SystemStatusOperational}, nil
+}
+
+// GetWithdrawalFee returns the current withdrawal fee of a cryptocurrency
+func (b *Bitfinex) GetWithdrawalFee(c currency.Code) (float64, error) {
+	accountFees, err := b.GetAccountFees()
+	if err != nil {
+		return 0, err
+	}
+	if _, ok := accountFees.Withdraw[c.Upper().String()]; !ok {
+		return 0, fmt.Errorf("no withdrawal fee reported for %s", c)
+	}
+	return b.GetCryptocurrencyWithdrawalFee(c.Upper(), accountFees)
+}
+
+// GetDepositMethods returns the method a cryptocurrency is deposited with
+func (b *Bitfinex) GetDepositMethods(c currency.Code) ([]exchange.DepositMethod, error) {
+	method, err := b.ConvertSymbolToDepositMethod(c)
+	if err != nil {
+		return nil, err
+	}
+	return []exchange.DepositMethod{{Method: method, Enabled: true}}, nil
+}
+
 // GetActiveOrders retrieves any orders that are active/open
 func (b *Bitfinex) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
 	var orders []exchange.OrderDetail
@@ -387,6 +575,7 @@ func (b *Bitfinex) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest)
 		orders = append(orders, orderDetail)
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
@@ -447,6 +636,7 @@ func (b *Bitfinex) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest)
 		orders = append(orders, orderDetail)
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
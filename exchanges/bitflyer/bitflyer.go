@@ -76,6 +76,17 @@ type Bitflyer struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "Bitflyer",
+		Creator: func() exchange.IBotExchange {
+			return new(Bitflyer)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.DerivativesCapability,
+	})
+}
+
 // SetDefaults sets the basic defaults for Bitflyer
 func (b *Bitflyer) SetDefaults() {
 	b.Name = "Bitflyer"
@@ -112,8 +123,10 @@ func (b *Bitflyer) Setup(exch *config.ExchangeConfig) {
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		b.SetHTTPClientTimeout(exch.HTTPTimeout)
 		b.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		b.SetHTTPHeaders(exch.HTTPHeaders)
 		b.RESTPollingDelay = exch.RESTPollingDelay
 		b.Verbose = exch.Verbose
+		b.ReadOnly = exch.ReadOnly
 		b.HTTPDebugging = exch.HTTPDebugging
 		b.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		b.BaseCurrencies = exch.BaseCurrencies
@@ -267,9 +280,10 @@ func (b *Bitflyer) GetPermissions() {
 	// Needs to be updated
 }
 
-// GetAccountBalance returnsthe full list of account funds
-func (b *Bitflyer) GetAccountBalance() {
-	// Needs to be updated
+// GetAccountBalance returns the full list of account funds
+func (b *Bitflyer) GetAccountBalance() ([]AccountBalance, error) {
+	var resp []AccountBalance
+	return resp, b.SendAuthHTTPRequest(http.MethodGet, privGetBalance, &resp)
 }
 
 // GetMarginStatus returns current margin status
@@ -382,14 +396,33 @@ func (b *Bitflyer) SendHTTPRequest(path string, result interface{}) error {
 	return b.SendPayload(http.MethodGet, path, nil, nil, result, false, false, b.Verbose, b.HTTPDebugging)
 }
 
-// SendAuthHTTPRequest sends an authenticated HTTP request
-// Note: HTTP not done due to incorrect account privileges, please open a PR
-// if you have access and update the authenticated requests
-// TODO: Fill out this function once API access is obtained
-func (b *Bitflyer) SendAuthHTTPRequest() {
-	// headers := make(map[string]string)
-	// headers["ACCESS-KEY"] = b.APIKey
-	// headers["ACCESS-TIMESTAMP"] = strconv.FormatInt(time.Now().UnixNano(), 10)
+// SendAuthHTTPRequest sends an authenticated HTTP request, signing the
+// timestamp, method and versioned request path with the API secret
+func (b *Bitflyer) SendAuthHTTPRequest(method, path string, result interface{}) error {
+	if !b.AuthenticatedAPISupport {
+		return exchange.ErrCredentialsNotSet
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	hmac := common.GetHMAC(common.HashSHA256,
+		[]byte(timestamp+method+"/v1"+path),
+		[]byte(b.APISecret))
+
+	headers := make(map[string]string)
+	headers["ACCESS-KEY"] = b.APIKey
+	headers["ACCESS-TIMESTAMP"] = timestamp
+	headers["ACCESS-SIGN"] = common.HexEncodeToString(hmac)
+	headers["Content-Type"] = "application/json"
+
+	return b.SendPayload(method,
+		b.APIUrl+path,
+		headers,
+		nil,
+		result,
+		true,
+		false,
+		b.Verbose,
+		b.HTTPDebugging)
 }
 
 // GetFee returns an estimate of fee based on type of transaction
@@ -416,7 +449,7 @@ func (b *Bitflyer) GetFee(feeBuilder *exchange.FeeBuilder) (float64, error) {
 // calculateTradingFee returns fee when performing a trade
 func calculateTradingFee(price, amount float64) float64 {
 	// bitflyer has fee tiers, but does not disclose them via API, so the largest has to be assumed
-	return 0.0012 * price * amount
+	return exchange.CalculateTradingFee(0.0012, price, amount)
 }
 
 func getDepositFee(bankTransactionType exchange.InternationalBankTransactionType, c currency.Code) (fee float64) {
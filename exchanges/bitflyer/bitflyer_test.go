@@ -421,3 +421,14 @@ func TestWithdrawInternationalBank(t *testing.T) {
 		t.Errorf("Expected '%v', received: '%v'", common.ErrNotYetImplemented, err)
 	}
 }
+
+func TestGetAccountInfoNoCredentials(t *testing.T) {
+	var f Bitflyer
+	f.SetDefaults()
+	f.Enabled = true
+	_, err := f.GetAccountInfo()
+	if err != exchange.ErrCredentialsNotSet {
+		t.Errorf("Test failed - Bitflyer GetAccountInfo() expected %v, received %v",
+			exchange.ErrCredentialsNotSet, err)
+	}
+}
@@ -3,10 +3,12 @@ package bitflyer
 import (
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -48,6 +50,12 @@ func (b *Bitflyer) Run() {
 	*/
 }
 
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (b *Bitflyer) UpdateTradablePairs(forceUpdate bool) error {
+	return common.ErrFunctionNotSupported
+}
+
 // UpdateTicker updates and returns the ticker for a currency pair
 func (b *Bitflyer) UpdateTicker(p currency.Pair, assetType string) (ticker.Price, error) {
 	var tickerPrice ticker.Price
@@ -137,15 +145,27 @@ func (b *Bitflyer) UpdateOrderbook(p currency.Pair, assetType string) (orderbook
 func (b *Bitflyer) GetAccountInfo() (exchange.AccountInfo, error) {
 	var response exchange.AccountInfo
 	response.Exchange = b.GetName()
-	// accountBalance, err := b.GetAccountBalance()
-	// if err != nil {
-	// 	return response, err
-	// }
 	if !b.Enabled {
 		return response, errors.New("exchange not enabled")
 	}
 
-	// implement once authenticated requests are introduced
+	balances, err := b.GetAccountBalance()
+	if err != nil {
+		return response, err
+	}
+
+	var currencies []exchange.AccountCurrencyInfo
+	for x := range balances {
+		currencies = append(currencies,
+			exchange.NewAccountCurrencyInfoFromTotalAvailable(
+				currency.NewCode(balances[x].CurrencyCode),
+				balances[x].Amount,
+				balances[x].Available))
+	}
+
+	response.Accounts = append(response.Accounts, exchange.Account{
+		Currencies: currencies,
+	})
 
 	return response, nil
 }
@@ -164,8 +184,21 @@ func (b *Bitflyer) GetExchangeHistory(p currency.Pair, assetType string) ([]exch
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive
+func (b *Bitflyer) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (b *Bitflyer) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order
 func (b *Bitflyer) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if b.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 
 	return submitOrderResponse, common.ErrNotYetImplemented
@@ -174,16 +207,25 @@ func (b *Bitflyer) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderTy
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Bitflyer) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *Bitflyer) CancelOrder(order *exchange.OrderCancellation) error {
+	if b.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	return common.ErrNotYetImplemented
 }
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *Bitflyer) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if b.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
+	}
 	// TODO, implement BitFlyer API
 	b.CancelAllExistingOrders()
 	return exchange.CancelAllOrdersResponse{}, common.ErrNotYetImplemented
@@ -203,18 +245,27 @@ func (b *Bitflyer) GetDepositAddress(cryptocurrency currency.Code, accountID str
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (b *Bitflyer) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Bitflyer) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrNotYetImplemented
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Bitflyer) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrNotYetImplemented
 }
 
@@ -59,6 +59,16 @@ type Bithumb struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "Bithumb",
+		Creator: func() exchange.IBotExchange {
+			return new(Bithumb)
+		},
+		Capabilities: exchange.SpotCapability,
+	})
+}
+
 // SetDefaults sets the basic defaults for Bithumb
 func (b *Bithumb) SetDefaults() {
 	b.Name = "Bithumb"
@@ -94,8 +104,10 @@ func (b *Bithumb) Setup(exch *config.ExchangeConfig) {
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		b.SetHTTPClientTimeout(exch.HTTPTimeout)
 		b.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		b.SetHTTPHeaders(exch.HTTPHeaders)
 		b.RESTPollingDelay = exch.RESTPollingDelay
 		b.Verbose = exch.Verbose
+		b.ReadOnly = exch.ReadOnly
 		b.HTTPDebugging = exch.HTTPDebugging
 		b.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		b.BaseCurrencies = exch.BaseCurrencies
@@ -550,7 +562,7 @@ func (b *Bithumb) SendHTTPRequest(path string, result interface{}) error {
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request to bithumb
 func (b *Bithumb) SendAuthenticatedHTTPRequest(path string, params url.Values, result interface{}) error {
 	if !b.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, b.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	if params == nil {
@@ -629,7 +641,7 @@ func (b *Bithumb) GetFee(feeBuilder *exchange.FeeBuilder) (float64, error) {
 
 // calculateTradingFee returns fee when performing a trade
 func calculateTradingFee(purchasePrice, amount float64) float64 {
-	return 0.0025 * amount * purchasePrice
+	return exchange.CalculateTradingFee(0.0025, purchasePrice, amount)
 }
 
 // getDepositFee returns fee on a currency when depositing small amounts to bithumb
@@ -414,8 +414,8 @@ func TestCancelAllExchangeOrders(t *testing.T) {
 		t.Errorf("Could not cancel order: %v", err)
 	}
 
-	if len(resp.OrderStatus) > 0 {
-		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
+	if len(resp.Failed()) > 0 {
+		t.Errorf("%v orders failed to cancel", len(resp.Failed()))
 	}
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -33,21 +34,27 @@ func (b *Bithumb) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", b.GetName(), len(b.EnabledPairs), b.EnabledPairs)
 	}
 
+	err := b.UpdateTradablePairs(false)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", b.Name, err)
+	}
+}
+
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (b *Bithumb) UpdateTradablePairs(forceUpdate bool) error {
 	exchangeProducts, err := b.GetTradingPairs()
 	if err != nil {
-		log.Errorf("%s Failed to get available symbols.\n", b.GetName())
-	} else {
-		var newExchangeProducts currency.Pairs
-		for _, p := range exchangeProducts {
-			newExchangeProducts = append(newExchangeProducts,
-				currency.NewPairFromString(p))
-		}
+		return err
+	}
 
-		err = b.UpdateCurrencies(newExchangeProducts, false, false)
-		if err != nil {
-			log.Errorf("%s Failed to update available symbols.\n", b.GetName())
-		}
+	var newExchangeProducts currency.Pairs
+	for _, p := range exchangeProducts {
+		newExchangeProducts = append(newExchangeProducts,
+			currency.NewPairFromString(p))
 	}
+
+	return b.UpdateCurrencies(newExchangeProducts, false, forceUpdate)
 }
 
 // GetTradingPairs gets the available trading currencies
@@ -157,11 +164,9 @@ func (b *Bithumb) GetAccountInfo() (exchange.AccountInfo, error) {
 				key)
 		}
 
-		exchangeBalances = append(exchangeBalances, exchange.AccountCurrencyInfo{
-			CurrencyName: currency.NewCode(key),
-			TotalValue:   totalAmount,
-			Hold:         hold,
-		})
+		exchangeBalances = append(exchangeBalances,
+			exchange.NewAccountCurrencyInfoFromTotal(currency.NewCode(key),
+				totalAmount, hold))
 	}
 
 	info.Accounts = append(info.Accounts, exchange.Account{
@@ -186,9 +191,22 @@ func (b *Bithumb) GetExchangeHistory(p currency.Pair, assetType string) ([]excha
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive
+func (b *Bithumb) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (b *Bithumb) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order
 // TODO: Fill this out to support limit orders
 func (b *Bithumb) SubmitOrder(p currency.Pair, side exchange.OrderSide, _ exchange.OrderType, amount, _ float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if b.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var err error
 	var orderID string
@@ -216,6 +234,9 @@ func (b *Bithumb) SubmitOrder(p currency.Pair, side exchange.OrderSide, _ exchan
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Bithumb) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	order, err := b.ModifyTrade(action.OrderID,
 		action.CurrencyPair.Base.String(),
 		common.StringToLower(action.OrderSide.ToString()),
@@ -231,6 +252,9 @@ func (b *Bithumb) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *Bithumb) CancelOrder(order *exchange.OrderCancellation) error {
+	if b.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	_, err := b.CancelTrade(order.Side.ToString(),
 		order.OrderID,
 		order.CurrencyPair.Base.String())
@@ -239,9 +263,10 @@ func (b *Bithumb) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *Bithumb) CancelAllOrders(orderCancellation *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if b.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	var allOrders []OrderData
 
 	for _, currency := range b.GetEnabledCurrencies() {
@@ -260,9 +285,7 @@ func (b *Bithumb) CancelAllOrders(orderCancellation *exchange.OrderCancellation)
 		_, err := b.CancelTrade(orderCancellation.Side.ToString(),
 			allOrders[i].OrderID,
 			orderCancellation.CurrencyPair.Base.String())
-		if err != nil {
-			cancelAllOrdersResponse.OrderStatus[allOrders[i].OrderID] = err.Error()
-		}
+		cancelAllOrdersResponse.Add(allOrders[i].OrderID, err)
 	}
 
 	return cancelAllOrdersResponse, nil
@@ -287,6 +310,9 @@ func (b *Bithumb) GetDepositAddress(cryptocurrency currency.Code, _ string) (str
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (b *Bithumb) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	_, err := b.WithdrawCrypto(withdrawRequest.Address, withdrawRequest.AddressTag, withdrawRequest.Currency.String(), withdrawRequest.Amount)
 	return "", err
 }
@@ -294,6 +320,9 @@ func (b *Bithumb) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.Withdraw
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Bithumb) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	if math.Mod(withdrawRequest.Amount, 1) != 0 {
 		return "", errors.New("currency KRW does not support decimal places")
 	}
@@ -316,6 +345,9 @@ func (b *Bithumb) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (
 
 // WithdrawFiatFundsToInternationalBank is not supported as Bithumb only withdraws KRW to South Korean banks
 func (b *Bithumb) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -369,6 +401,7 @@ func (b *Bithumb) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) (
 		orders = append(orders, orderDetail)
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
@@ -413,6 +446,7 @@ func (b *Bithumb) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) (
 		orders = append(orders, orderDetail)
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
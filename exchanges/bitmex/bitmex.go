@@ -98,6 +98,32 @@ const (
 	bitmexEndpointUserWalletSummary     = "/user/walletSummary"
 	bitmexEndpointUserRequestWithdraw   = "/user/requestWithdrawal"
 
+	// bitmexTradeURL is the web trading page of a contract
+	bitmexTradeURL = "https://www.bitmex.com/app/trade/"
+
+	// bitmexInstrumentOpen is the state of instruments open for trading
+	bitmexInstrumentOpen = "Open"
+	// bitmexFuturesType is the instrument type of dated futures contracts
+	bitmexFuturesType = "FFCCSX"
+
+	// bitmexBucketLimit is the maximum number of trade buckets returned per
+	// request
+	bitmexBucketLimit = 1000
+
+	// bitmexTradeLimit is the maximum number of trades returned per request
+	bitmexTradeLimit = 1000
+
+	// bitmexWalletCurrency is the wallet currency of wallet history requests,
+	// amounts are in satoshis
+	bitmexWalletCurrency = "XBt"
+	bitmexSatoshisPerXBT = 1e8
+	// bitmexAffiliatePayout is the wallet transaction type of referral payouts
+	bitmexAffiliatePayout = "AffiliatePayout"
+
+	// Order execution instructions
+	bitmexExecInstPostOnly   = "ParticipateDoNotInitiate"
+	bitmexExecInstReduceOnly = "ReduceOnly"
+
 	// Rate limits - 150 requests per 5 minutes
 	bitmexUnauthRate = 30
 	// 300 requests per 5 minutes
@@ -113,6 +139,19 @@ const (
 	ContractUpsideProfit
 )
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "Bitmex",
+		Creator: func() exchange.IBotExchange {
+			return new(Bitmex)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.DerivativesCapability |
+			exchange.WebsocketCapability |
+			exchange.SandboxCapability,
+	})
+}
+
 // SetDefaults sets the basic defaults for Bitmex
 func (b *Bitmex) SetDefaults() {
 	b.Name = "Bitmex"
@@ -135,6 +174,8 @@ func (b *Bitmex) SetDefaults() {
 	b.APIUrlDefault = bitmexAPIURL
 	b.APIUrl = b.APIUrlDefault
 	b.SupportsAutoPairUpdating = true
+	b.SupportsKlines = true
+	b.SupportsTradeHistory = true
 	b.WebsocketInit()
 	b.Websocket.Functionality = exchange.WebsocketTradeDataSupported |
 		exchange.WebsocketOrderbookSupported |
@@ -150,8 +191,10 @@ func (b *Bitmex) Setup(exch *config.ExchangeConfig) {
 		b.Enabled = true
 		b.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
+		b.SetHTTPHeaders(exch.HTTPHeaders)
 		b.RESTPollingDelay = exch.RESTPollingDelay
 		b.Verbose = exch.Verbose
+		b.ReadOnly = exch.ReadOnly
 		b.HTTPDebugging = exch.HTTPDebugging
 		b.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		b.BaseCurrencies = exch.BaseCurrencies
@@ -173,21 +216,27 @@ func (b *Bitmex) Setup(exch *config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		b.SetSandbox(exch, bitmexAPItestnetURL)
 		err = b.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
 			log.Fatal(err)
 		}
+		wsURL := bitmexWSURL
+		if b.IsSandbox() {
+			wsURL = bitmexWSTestnetURL
+		}
 		err = b.WebsocketSetup(b.WsConnector,
 			b.Subscribe,
 			b.Unsubscribe,
 			exch.Name,
 			exch.Websocket,
 			exch.Verbose,
-			bitmexWSURL,
+			wsURL,
 			exch.WebsocketURL)
 		if err != nil {
 			log.Fatal(err)
 		}
+		b.Websocket.SetHeartbeat(b.wsHeartbeat())
 	}
 }
 
@@ -614,8 +663,8 @@ func (b *Bitmex) GetTrade(params *GenericRequestParams) ([]Trade, error) {
 }
 
 // GetPreviousTrades previous trade history in time buckets
-func (b *Bitmex) GetPreviousTrades(params *TradeGetBucketedParams) ([]Trade, error) {
-	var trade []Trade
+func (b *Bitmex) GetPreviousTrades(params *TradeGetBucketedParams) ([]TradeBucket, error) {
+	var trade []TradeBucket
 
 	return trade, b.SendHTTPRequest(bitmexEndpointTradeBucketed,
 		params,
@@ -875,8 +924,7 @@ func (b *Bitmex) SendHTTPRequest(path string, params Parameter, result interface
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request to bitmex
 func (b *Bitmex) SendAuthenticatedHTTPRequest(verb, path string, params Parameter, result interface{}) error {
 	if !b.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet,
-			b.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	timestamp := time.Now().Add(time.Second * 10).UnixNano()
@@ -972,5 +1020,5 @@ func calculateTradingFee(purchasePrice, amount float64, isMaker bool) float64 {
 		fee -= 0.000250
 	}
 
-	return fee * purchasePrice * amount
+	return exchange.CalculateTradingFee(fee, purchasePrice, amount)
 }
@@ -137,6 +137,66 @@ func TestGetInstruments(t *testing.T) {
 	}
 }
 
+func TestGetOpenInterest(t *testing.T) {
+	_, err := b.GetOpenInterest(currency.NewPairFromString("XBTUSD"))
+	if err != nil {
+		t.Error("test failed - GetOpenInterest() error", err)
+	}
+}
+
+func TestGetMarketMetadata(t *testing.T) {
+	p := currency.NewPairFromString("XBTUSD")
+	if url := b.GetCurrencyTradeURL(p); url != "https://www.bitmex.com/app/trade/XBTUSD" {
+		t.Error("test failed - GetCurrencyTradeURL() unexpected url", url)
+	}
+	metadata, err := b.GetMarketMetadata(p)
+	if err != nil {
+		t.Error("test failed - GetMarketMetadata() error", err)
+	}
+	if metadata.Listed.IsZero() {
+		t.Errorf("test failed - GetMarketMetadata() expected a listing date %+v", metadata)
+	}
+}
+
+func TestGetTradingStatus(t *testing.T) {
+	status, err := b.GetTradingStatus(currency.NewPairFromString("XBTUSD"))
+	if err != nil {
+		t.Error("test failed - GetTradingStatus() error", err)
+	}
+	if status.Message == "" {
+		t.Errorf("test failed - GetTradingStatus() expected the instrument state %+v", status)
+	}
+}
+
+func TestGetFuturesContracts(t *testing.T) {
+	contracts := getFuturesContracts("Bitmex", []Instrument{
+		{Symbol: "XBTUSD", Typ: "FFWCSX", Underlying: "XBT", QuoteCurrency: "USD"},
+		{Symbol: "XBTM19", Typ: bitmexFuturesType, Underlying: "XBT", QuoteCurrency: "USD",
+			Listing: "2018-12-14T12:00:00.000Z", Expiry: "2019-06-28T12:00:00.000Z"},
+	})
+	if len(contracts) != 1 {
+		t.Fatalf("test failed - getFuturesContracts() expected one dated contract, got %+v", contracts)
+	}
+	if contracts[0].Symbol != "XBTM19" || contracts[0].Underlying.String() != "XBTUSD" ||
+		!contracts[0].Expiry.Equal(time.Date(2019, 6, 28, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("test failed - getFuturesContracts() unexpected contract %+v", contracts[0])
+	}
+}
+
+func TestGetAffiliatePayouts(t *testing.T) {
+	history := []TransactionInfo{
+		{TransactID: "1", TransactType: "RealisedPNL", Amount: 5000, TransactTime: "2019-03-02T00:00:00.000Z"},
+		{TransactID: "2", TransactType: bitmexAffiliatePayout, Amount: 25000, TransactTime: "2019-03-02T00:00:00.000Z"},
+		{TransactID: "3", TransactType: bitmexAffiliatePayout, Amount: 25000, TransactTime: "2019-02-27T00:00:00.000Z"},
+	}
+	rebates := getAffiliatePayouts(history, time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC))
+	if len(rebates) != 1 || rebates[0].ID != "2" ||
+		rebates[0].Type != exchange.RebateTypeReferral ||
+		rebates[0].Amount != 0.00025 || !rebates[0].Currency.Match(currency.BTC) {
+		t.Errorf("test failed - getAffiliatePayouts() unexpected rebates %+v", rebates)
+	}
+}
+
 func TestGetActiveInstruments(t *testing.T) {
 	_, err := b.GetActiveInstruments(&GenericRequestParams{})
 	if err != nil {
@@ -532,6 +592,30 @@ func TestSubmitOrder(t *testing.T) {
 	}
 }
 
+func TestSubmitFlaggedOrder(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	if areTestAPIKeysSet() && !canManipulateRealOrders {
+		t.Skip("API keys set, canManipulateRealOrders false, skipping test")
+	}
+
+	p := currency.NewPair(currency.XBT, currency.USD)
+	_, err := b.SubmitFlaggedOrder(p, exchange.BuyOrderSide, exchange.MarketOrderType,
+		1, 1, "clientId", exchange.OrderFlags{PostOnly: true})
+	if err != exchange.ErrPostOnlyMarketOrder {
+		t.Error("Expecting post-only market orders to be rejected, got", err)
+	}
+
+	response, err := b.SubmitFlaggedOrder(p, exchange.SellOrderSide, exchange.LimitOrderType,
+		1, 1000000, "clientId", exchange.OrderFlags{PostOnly: true, ReduceOnly: true})
+	if areTestAPIKeysSet() && (err != nil || !response.IsOrderPlaced) {
+		t.Errorf("Order failed to be placed: %v", err)
+	} else if !areTestAPIKeysSet() && err == nil {
+		t.Error("Expecting an error when no keys are set")
+	}
+}
+
 func TestCancelExchangeOrder(t *testing.T) {
 	b.SetDefaults()
 	TestSetup(t)
@@ -558,6 +642,23 @@ func TestCancelExchangeOrder(t *testing.T) {
 	}
 }
 
+func TestCancelOrderByClientID(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	if areTestAPIKeysSet() && !canManipulateRealOrders {
+		t.Skip("API keys set, canManipulateRealOrders false, skipping test")
+	}
+
+	err := b.CancelOrderByClientID("gct-test", currency.NewPair(currency.LTC, currency.BTC))
+	if !areTestAPIKeysSet() && err == nil {
+		t.Error("Expecting an error when no keys are set")
+	}
+	if areTestAPIKeysSet() && err != nil {
+		t.Errorf("Could not cancel order: %v", err)
+	}
+}
+
 func TestCancelAllExchangeOrders(t *testing.T) {
 	b.SetDefaults()
 	TestSetup(t)
@@ -584,8 +685,8 @@ func TestCancelAllExchangeOrders(t *testing.T) {
 		t.Errorf("Could not cancel orders: %v", err)
 	}
 
-	if len(resp.OrderStatus) > 0 {
-		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
+	if len(resp.Failed()) > 0 {
+		t.Errorf("%v orders failed to cancel", len(resp.Failed()))
 	}
 }
 
@@ -679,3 +780,12 @@ func TestGetDepositAddress(t *testing.T) {
 		}
 	}
 }
+
+func TestGetContractsValue(t *testing.T) {
+	if v := getContractsValue("XBTUSD", 5000, 8000); v != 5000 {
+		t.Errorf("Test failed - expected inverse contracts valued at one USD each, got %f", v)
+	}
+	if v := getContractsValue("ADAZ19", 5000, 0.000005); v != 0.025 {
+		t.Errorf("Test failed - expected contracts valued at their price, got %f", v)
+	}
+}
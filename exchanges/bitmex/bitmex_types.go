@@ -1,6 +1,10 @@
 package bitmex
 
-import exchange "github.com/thrasher-/gocryptotrader/exchanges"
+import (
+	"time"
+
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
 
 // RequestError allows for a general error capture from requests
 type RequestError struct {
@@ -486,6 +490,32 @@ type Trade struct {
 	TrdMatchID      string  `json:"trdMatchID"`
 }
 
+// klineIntervals maps trade bucket sizes to their durations
+var klineIntervals = map[string]time.Duration{
+	"1m": time.Minute,
+	"5m": time.Minute * 5,
+	"1h": time.Hour,
+	"1d": time.Hour * 24,
+}
+
+// TradeBucket holds the trades of a contract bucketed by time, Timestamp being
+// the close time of the bucket
+type TradeBucket struct {
+	Timestamp       string  `json:"timestamp"`
+	Symbol          string  `json:"symbol"`
+	Open            float64 `json:"open"`
+	High            float64 `json:"high"`
+	Low             float64 `json:"low"`
+	Close           float64 `json:"close"`
+	Trades          int64   `json:"trades"`
+	Volume          float64 `json:"volume"`
+	VWAP            float64 `json:"vwap"`
+	LastSize        float64 `json:"lastSize"`
+	Turnover        float64 `json:"turnover"`
+	HomeNotional    float64 `json:"homeNotional"`
+	ForeignNotional float64 `json:"foreignNotional"`
+}
+
 // User Account Operations
 type User struct {
 	TFAEnabled   string          `json:"TFAEnabled"`
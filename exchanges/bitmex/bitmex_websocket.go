@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -13,11 +14,18 @@ import (
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
 )
 
 const (
-	bitmexWSURL = "wss://www.bitmex.com/realtime"
+	bitmexWSURL        = "wss://www.bitmex.com/realtime"
+	bitmexWSTestnetURL = "wss://testnet.bitmex.com/realtime"
+
+	// bitmexWSPingInterval keeps the connection alive, a connection without
+	// traffic for bitmexWSHeartbeatTimeout is reset
+	bitmexWSPingInterval     = time.Second * 5
+	bitmexWSHeartbeatTimeout = time.Second * 30
 
 	// Public Subscription Channels
 	bitmexWSAnnouncement        = "announcement"
@@ -58,10 +66,6 @@ const (
 	bitmexActionUpdateData  = "update"
 )
 
-var (
-	pongChan = make(chan int, 1)
-)
-
 // WsConnector initiates a new websocket connection
 func (b *Bitmex) WsConnector() error {
 	if !b.Websocket.IsEnabled() || !b.IsEnabled() {
@@ -149,20 +153,10 @@ func (b *Bitmex) wsHandleIncomingData() {
 				return
 			}
 
-			message := string(resp.Raw)
-			if common.StringContains(message, "pong") {
-				pongChan <- 1
+			if b.Websocket.HandleHeartbeat(resp.Raw) {
 				continue
 			}
 
-			if common.StringContains(message, "ping") {
-				err = b.wsSend("pong")
-				if err != nil {
-					b.Websocket.DataHandler <- err
-					continue
-				}
-			}
-
 			quickCapture := make(map[string]interface{})
 			err = common.JSONDecode(resp.Raw, &quickCapture)
 			if err != nil {
@@ -224,7 +218,7 @@ func (b *Bitmex) wsHandleIncomingData() {
 
 					p := currency.NewPairFromString(orderbooks.Data[0].Symbol)
 					// TODO: update this to support multiple asset types
-					err = b.processOrderbook(orderbooks.Data, orderbooks.Action, p, "CONTRACT")
+					err = b.processOrderbook(orderbooks.Data, orderbooks.Action, p, orderbook.Futures)
 					if err != nil {
 						b.Websocket.DataHandler <- err
 						continue
@@ -257,11 +251,40 @@ func (b *Bitmex) wsHandleIncomingData() {
 							Amount:       float64(trade.Size),
 							CurrencyPair: currency.NewPairFromString(trade.Symbol),
 							Exchange:     b.GetName(),
-							AssetType:    "CONTRACT",
+							AssetType:    ticker.Futures,
 							Side:         trade.Side,
 						}
 					}
 
+				case bitmexWSLiquidation:
+					var liquidations LiquidationData
+					err = common.JSONDecode(resp.Raw, &liquidations)
+					if err != nil {
+						b.Websocket.DataHandler <- err
+						continue
+					}
+
+					// Only newly placed liquidation orders are reported,
+					// updates and deletes reflect them being filled
+					if liquidations.Action != bitmexActionInsertData {
+						continue
+					}
+
+					for _, liquidation := range liquidations.Data {
+						// TODO: update this to support multiple asset types
+						b.Websocket.DataHandler <- exchange.LiquidationEvent{
+							Timestamp: time.Now(),
+							Pair:      currency.NewPairFromString(liquidation.Symbol),
+							AssetType: ticker.Futures,
+							Exchange:  b.GetName(),
+							Side:      liquidation.Side,
+							Price:     liquidation.Price,
+							Amount:    float64(liquidation.LeavesQty),
+							Value: getContractsValue(liquidation.Symbol,
+								float64(liquidation.LeavesQty), liquidation.Price),
+						}
+					}
+
 				case bitmexWSAnnouncement:
 					var announcement AnnouncementData
 
@@ -384,10 +407,20 @@ func (b *Bitmex) processOrderbook(data []OrderBookL2, action string, currencyPai
 	return nil
 }
 
+// getContractsValue returns the notional value of a number of contracts in
+// their quote currency. Inverse XBT contracts (XBTUSD, XBTZ19) are one USD
+// each, other contracts are one unit of the underlying valued at price
+func getContractsValue(symbol string, contracts, price float64) float64 {
+	if strings.HasPrefix(symbol, "XBT") {
+		return contracts
+	}
+	return contracts * price
+}
+
 // GenerateDefaultSubscriptions Adds default subscriptions to websocket to be handled by ManageSubscriptions()
 func (b *Bitmex) GenerateDefaultSubscriptions() {
 	contracts := b.GetEnabledCurrencies()
-	channels := []string{bitmexWSOrderbookL2, bitmexWSTrade}
+	channels := []string{bitmexWSOrderbookL2, bitmexWSTrade, bitmexWSLiquidation}
 	subscriptions := []exchange.WebsocketChannelSubscription{
 		{
 			Channel: bitmexWSAnnouncement,
@@ -438,12 +471,41 @@ func (b *Bitmex) websocketSendAuth() error {
 	return b.wsSend(sendAuth)
 }
 
+// wsHeartbeat returns the heartbeat keeping the websocket connection alive,
+// Bitmex replies to a "ping" message with "pong"
+func (b *Bitmex) wsHeartbeat() exchange.WebsocketHeartbeat {
+	return exchange.WebsocketHeartbeat{
+		Interval: bitmexWSPingInterval,
+		Timeout:  bitmexWSHeartbeatTimeout,
+		Payload:  []byte("ping"),
+		Send:     b.wsWrite,
+		IsPong: func(raw []byte) bool {
+			return string(raw) == "pong"
+		},
+		Reply: func(raw []byte) []byte {
+			if string(raw) != "ping" {
+				return nil
+			}
+			return []byte("pong")
+		},
+	}
+}
+
 // WsSend sends data to the websocket server
 func (b *Bitmex) wsSend(data interface{}) error {
+	json, err := common.JSONEncode(data)
+	if err != nil {
+		return err
+	}
+	return b.wsWrite(json)
+}
+
+// wsWrite writes a raw message to the websocket server
+func (b *Bitmex) wsWrite(payload []byte) error {
 	b.wsRequestMtx.Lock()
 	defer b.wsRequestMtx.Unlock()
 	if b.Verbose {
-		log.Debugf("%v sending message to websocket %v", b.Name, data)
+		log.Debugf("%v sending message to websocket %s", b.Name, log.DebugPayload(b.Name, payload))
 	}
-	return b.WebsocketConn.WriteJSON(data)
+	return b.WebsocketConn.WriteMessage(websocket.TextMessage, payload)
 }
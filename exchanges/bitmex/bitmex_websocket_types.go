@@ -65,6 +65,12 @@ type TradeData struct {
 	Action string  `json:"action"`
 }
 
+// LiquidationData contains liquidation resp data with action to be taken
+type LiquidationData struct {
+	Data   []Liquidation `json:"data"`
+	Action string        `json:"action"`
+}
+
 // AnnouncementData contains announcement resp data with action to be taken
 type AnnouncementData struct {
 	Data   []Announcement `json:"data"`
@@ -6,10 +6,12 @@ import (
 	"math"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -32,27 +34,27 @@ func (b *Bitmex) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", b.GetName(), len(b.EnabledPairs), b.EnabledPairs)
 	}
 
-	marketInfo, err := b.GetActiveInstruments(&GenericRequestParams{})
+	err := b.UpdateTradablePairs(false)
 	if err != nil {
-		log.Errorf("%s Failed to get available symbols.\n", b.GetName())
-
-	} else {
-		var exchangeProducts []string
-		for i := range marketInfo {
-			exchangeProducts = append(exchangeProducts, marketInfo[i].Symbol)
-		}
+		log.Errorf("%s failed to update tradable pairs. Err: %s", b.Name, err)
+	}
+}
 
-		var NewExchangeProducts currency.Pairs
-		for _, p := range exchangeProducts {
-			NewExchangeProducts = append(NewExchangeProducts,
-				currency.NewPairFromString(p))
-		}
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (b *Bitmex) UpdateTradablePairs(forceUpdate bool) error {
+	marketInfo, err := b.GetActiveInstruments(&GenericRequestParams{})
+	if err != nil {
+		return err
+	}
 
-		err = b.UpdateCurrencies(NewExchangeProducts, false, false)
-		if err != nil {
-			log.Errorf("%s Failed to update available currencies.\n", b.GetName())
-		}
+	var exchangeProducts currency.Pairs
+	for i := range marketInfo {
+		exchangeProducts = append(exchangeProducts,
+			currency.NewPairFromString(marketInfo[i].Symbol))
 	}
+
+	return b.UpdateCurrencies(exchangeProducts, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -142,13 +144,12 @@ func (b *Bitmex) GetAccountInfo() (exchange.AccountInfo, error) {
 		return info, err
 	}
 
-	// Need to update to add Margin/Liquidity availibilty
 	var balances []exchange.AccountCurrencyInfo
 	for i := range bal {
-		balances = append(balances, exchange.AccountCurrencyInfo{
-			CurrencyName: currency.NewCode(bal[i].Currency),
-			TotalValue:   float64(bal[i].WalletBalance),
-		})
+		balances = append(balances, exchange.NewAccountCurrencyInfoFromTotalAvailable(
+			currency.NewCode(bal[i].Currency),
+			float64(bal[i].WalletBalance),
+			float64(bal[i].AvailableMargin)))
 	}
 
 	info.Exchange = b.GetName()
@@ -173,7 +174,16 @@ func (b *Bitmex) GetExchangeHistory(p currency.Pair, assetType string) ([]exchan
 }
 
 // SubmitOrder submits a new order
-func (b *Bitmex) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+func (b *Bitmex) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	return b.SubmitFlaggedOrder(p, side, orderType, amount, price, clientID, exchange.OrderFlags{})
+}
+
+// SubmitFlaggedOrder submits a new order with execution flags, which are sent
+// as its execution instructions
+func (b *Bitmex) SubmitFlaggedOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string, flags exchange.OrderFlags) (exchange.SubmitOrderResponse, error) {
+	if b.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 
 	if math.Mod(amount, 1) != 0 {
@@ -181,11 +191,25 @@ func (b *Bitmex) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType
 			errors.New("contract amount can not have decimals")
 	}
 
+	if flags.PostOnly && orderType != exchange.LimitOrderType {
+		return submitOrderResponse, exchange.ErrPostOnlyMarketOrder
+	}
+
+	var execInst []string
+	if flags.PostOnly {
+		execInst = append(execInst, bitmexExecInstPostOnly)
+	}
+	if flags.ReduceOnly {
+		execInst = append(execInst, bitmexExecInstReduceOnly)
+	}
+
 	var orderNewParams = OrderNewParams{
 		OrdType:  side.ToString(),
 		Symbol:   p.String(),
 		OrderQty: amount,
 		Side:     side.ToString(),
+		ClOrdID:  clientID,
+		ExecInst: strings.Join(execInst, ","),
 	}
 
 	if orderType == exchange.LimitOrderType {
@@ -207,6 +231,9 @@ func (b *Bitmex) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Bitmex) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	var params OrderAmendParams
 
 	if math.Mod(action.Amount, 1) != 0 {
@@ -227,6 +254,9 @@ func (b *Bitmex) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *Bitmex) CancelOrder(order *exchange.OrderCancellation) error {
+	if b.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	var params = OrderCancelParams{
 		OrderID: order.OrderID,
 	}
@@ -235,11 +265,26 @@ func (b *Bitmex) CancelOrder(order *exchange.OrderCancellation) error {
 	return err
 }
 
+// CancelOrderByClientID cancels an order by the client order ID it was
+// submitted with
+func (b *Bitmex) CancelOrderByClientID(clientID string, _ currency.Pair) error {
+	if b.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
+	var params = OrderCancelParams{
+		ClOrdID: clientID,
+	}
+	_, err := b.CancelOrders(&params)
+
+	return err
+}
+
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *Bitmex) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if b.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	var emptyParams OrderCancelAllParams
 	orders, err := b.CancelAllExistingOrders(emptyParams)
 	if err != nil {
@@ -247,7 +292,15 @@ func (b *Bitmex) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.Cancel
 	}
 
 	for i := range orders {
-		cancelAllOrdersResponse.OrderStatus[orders[i].OrderID] = orders[i].OrdRejReason
+		if orders[i].OrdStatus == "Canceled" {
+			cancelAllOrdersResponse.Add(orders[i].OrderID, nil)
+			continue
+		}
+		reason := orders[i].OrdRejReason
+		if reason == "" {
+			reason = orders[i].Text
+		}
+		cancelAllOrdersResponse.AddFailure(orders[i].OrderID, "", reason)
 	}
 
 	return cancelAllOrdersResponse, nil
@@ -267,6 +320,9 @@ func (b *Bitmex) GetDepositAddress(cryptocurrency currency.Code, _ string) (stri
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (b *Bitmex) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	var request = UserRequestWithdrawalParams{
 		Address:  withdrawRequest.Address,
 		Amount:   withdrawRequest.Amount,
@@ -288,12 +344,18 @@ func (b *Bitmex) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawR
 // WithdrawFiatFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (b *Bitmex) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a withdrawal is
 // submitted
 func (b *Bitmex) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -331,13 +393,15 @@ func (b *Bitmex) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([
 		}
 
 		orderDetail := exchange.OrderDetail{
-			Price:     resp[i].Price,
-			Amount:    float64(resp[i].OrderQty),
-			Exchange:  b.Name,
-			ID:        resp[i].OrderID,
-			OrderSide: orderSide,
-			OrderType: orderType,
-			Status:    resp[i].OrdStatus,
+			Price:           resp[i].Price,
+			Amount:          float64(resp[i].OrderQty),
+			ExecutedAmount:  float64(resp[i].CumQty),
+			RemainingAmount: float64(resp[i].LeavesQty),
+			Exchange:        b.Name,
+			ID:              resp[i].OrderID,
+			OrderSide:       orderSide,
+			OrderType:       orderType,
+			Status:          resp[i].OrdStatus,
 			CurrencyPair: currency.NewPairWithDelimiter(resp[i].Symbol,
 				resp[i].SettlCurrency,
 				b.ConfigCurrencyPairFormat.Delimiter),
@@ -346,6 +410,7 @@ func (b *Bitmex) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([
 		orders = append(orders, orderDetail)
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
@@ -374,13 +439,15 @@ func (b *Bitmex) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) ([
 		}
 
 		orderDetail := exchange.OrderDetail{
-			Price:     resp[i].Price,
-			Amount:    float64(resp[i].OrderQty),
-			Exchange:  b.Name,
-			ID:        resp[i].OrderID,
-			OrderSide: orderSide,
-			OrderType: orderType,
-			Status:    resp[i].OrdStatus,
+			Price:           resp[i].Price,
+			Amount:          float64(resp[i].OrderQty),
+			ExecutedAmount:  float64(resp[i].CumQty),
+			RemainingAmount: float64(resp[i].LeavesQty),
+			Exchange:        b.Name,
+			ID:              resp[i].OrderID,
+			OrderSide:       orderSide,
+			OrderType:       orderType,
+			Status:          resp[i].OrdStatus,
 			CurrencyPair: currency.NewPairWithDelimiter(resp[i].Symbol,
 				resp[i].SettlCurrency,
 				b.ConfigCurrencyPairFormat.Delimiter),
@@ -389,6 +456,7 @@ func (b *Bitmex) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) ([
 		orders = append(orders, orderDetail)
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
@@ -410,3 +478,238 @@ func (b *Bitmex) UnsubscribeToWebsocketChannels(channels []exchange.WebsocketCha
 	b.Websocket.UnsubscribeToChannels(channels)
 	return nil
 }
+
+// GetCurrencyTradeURL returns the web trading page of a contract
+func (b *Bitmex) GetCurrencyTradeURL(p currency.Pair) string {
+	return bitmexTradeURL + exchange.FormatExchangeCurrency(b.Name, p).String()
+}
+
+// GetMarketMetadata returns the trade URL, state and listing date of a
+// contract
+func (b *Bitmex) GetMarketMetadata(p currency.Pair) (exchange.MarketMetadata, error) {
+	instruments, err := b.GetInstruments(&GenericRequestParams{
+		Symbol: exchange.FormatExchangeCurrency(b.Name, p).String(),
+	})
+	if err != nil {
+		return exchange.MarketMetadata{}, err
+	}
+
+	if len(instruments) == 0 {
+		return exchange.MarketMetadata{}, fmt.Errorf("%s REST error: no instrument returned for %s", b.Name, p)
+	}
+
+	listed, _ := time.Parse(time.RFC3339, instruments[0].Listing)
+	return exchange.MarketMetadata{
+		Exchange: b.Name,
+		Pair:     p,
+		TradeURL: b.GetCurrencyTradeURL(p),
+		Status:   instruments[0].State,
+		Listed:   listed,
+		Halted:   instruments[0].State != bitmexInstrumentOpen,
+	}, nil
+}
+
+// GetTradingStatus returns the trading status of a contract. Contracts which
+// are closed, settled or unlisted accept no orders
+func (b *Bitmex) GetTradingStatus(p currency.Pair) (exchange.TradingStatus, error) {
+	instruments, err := b.GetInstruments(&GenericRequestParams{
+		Symbol: exchange.FormatExchangeCurrency(b.Name, p).String(),
+	})
+	if err != nil {
+		return exchange.TradingStatus{}, err
+	}
+
+	if len(instruments) == 0 {
+		return exchange.TradingStatus{}, fmt.Errorf("%s REST error: no instrument returned for %s", b.Name, p)
+	}
+
+	status := exchange.TradingStatus{
+		Exchange: b.Name,
+		Pair:     p,
+		Status:   exchange.TradingStatusTrading,
+		Message:  instruments[0].State,
+	}
+	if instruments[0].State != bitmexInstrumentOpen {
+		status.Status = exchange.TradingStatusHalted
+	}
+	return status, nil
+}
+
+// GetHistoricCandles returns candles between start and end inclusive, paging
+// through the bucketed trades endpoint as needed
+func (b *Bitmex) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	var binSize string
+	for k, v := range klineIntervals {
+		if v == interval {
+			binSize = k
+			break
+		}
+	}
+	if binSize == "" {
+		return nil, fmt.Errorf("%s unsupported kline interval %s", b.Name, interval)
+	}
+
+	var candles []kline.Candle
+	for start.Before(end) || start.Equal(end) {
+		// Buckets are timestamped by their close time
+		resp, err := b.GetPreviousTrades(&TradeGetBucketedParams{
+			Symbol:    exchange.FormatExchangeCurrency(b.Name, p).String(),
+			BinSize:   binSize,
+			Count:     bitmexBucketLimit,
+			StartTime: start.Add(interval).UTC().Format(time.RFC3339),
+			EndTime:   end.Add(interval).UTC().Format(time.RFC3339),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(resp) == 0 {
+			break
+		}
+
+		for x := range resp {
+			closed, err := time.Parse(time.RFC3339, resp[x].Timestamp)
+			if err != nil {
+				return nil, err
+			}
+			candles = append(candles, kline.Candle{
+				Time:   closed.Add(-interval),
+				Open:   resp[x].Open,
+				High:   resp[x].High,
+				Low:    resp[x].Low,
+				Close:  resp[x].Close,
+				Volume: resp[x].Volume,
+			})
+		}
+		start = candles[len(candles)-1].Time.Add(interval)
+	}
+	return candles, nil
+}
+
+// GetHistoricTrades returns trades between start and end inclusive, oldest
+// first. Amounts are in contracts and trades are identified by their match ID
+// in the description
+func (b *Bitmex) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	resp, err := b.GetTrade(&GenericRequestParams{
+		Symbol:    exchange.FormatExchangeCurrency(b.Name, p).String(),
+		Count:     bitmexTradeLimit,
+		StartTime: start.UTC().Format(time.RFC3339Nano),
+		EndTime:   end.UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trades := make([]exchange.TradeHistory, len(resp))
+	for x := range resp {
+		timestamp, err := time.Parse(time.RFC3339, resp[x].Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		trades[x] = exchange.TradeHistory{
+			Timestamp:   timestamp,
+			Price:       resp[x].Price,
+			Amount:      float64(resp[x].Size),
+			Exchange:    b.Name,
+			Type:        strings.ToUpper(resp[x].Side),
+			Description: resp[x].TrdMatchID,
+		}
+	}
+	return trades, nil
+}
+
+// GetFuturesContracts returns the dated futures contracts currently listed
+func (b *Bitmex) GetFuturesContracts() ([]exchange.FuturesContract, error) {
+	instruments, err := b.GetActiveInstruments(&GenericRequestParams{})
+	if err != nil {
+		return nil, err
+	}
+	return getFuturesContracts(b.Name, instruments), nil
+}
+
+// getFuturesContracts returns the dated futures contracts of instruments
+func getFuturesContracts(exchName string, instruments []Instrument) []exchange.FuturesContract {
+	var contracts []exchange.FuturesContract
+	for x := range instruments {
+		if instruments[x].Typ != bitmexFuturesType {
+			continue
+		}
+		expiry, err := time.Parse(time.RFC3339, instruments[x].Expiry)
+		if err != nil {
+			continue
+		}
+		listing, _ := time.Parse(time.RFC3339, instruments[x].Listing)
+		contracts = append(contracts, exchange.FuturesContract{
+			Exchange: exchName,
+			Symbol:   instruments[x].Symbol,
+			Pair:     currency.NewPairFromString(instruments[x].Symbol),
+			Underlying: currency.NewPairFromStrings(instruments[x].Underlying,
+				instruments[x].QuoteCurrency),
+			Listing: listing,
+			Expiry:  expiry,
+		})
+	}
+	return contracts
+}
+
+// GetRebates returns the affiliate payouts credited to the account since a
+// time. Maker rebates are settled into realised PNL and aren't reported
+// separately
+func (b *Bitmex) GetRebates(since time.Time) ([]exchange.Rebate, error) {
+	history, err := b.GetWalletHistory(bitmexWalletCurrency)
+	if err != nil {
+		return nil, err
+	}
+	return getAffiliatePayouts(history, since), nil
+}
+
+// getAffiliatePayouts returns the affiliate payouts in wallet history made
+// since a time
+func getAffiliatePayouts(history []TransactionInfo, since time.Time) []exchange.Rebate {
+	var rebates []exchange.Rebate
+	for x := range history {
+		if history[x].TransactType != bitmexAffiliatePayout || history[x].Amount <= 0 {
+			continue
+		}
+		timestamp, err := time.Parse(time.RFC3339, history[x].TransactTime)
+		if err != nil || timestamp.Before(since) {
+			continue
+		}
+		rebates = append(rebates, exchange.Rebate{
+			ID:        history[x].TransactID,
+			Type:      exchange.RebateTypeReferral,
+			Currency:  currency.BTC,
+			Amount:    float64(history[x].Amount) / bitmexSatoshisPerXBT,
+			Timestamp: timestamp,
+		})
+	}
+	return rebates
+}
+
+// GetOpenInterest returns the open interest of a contract. The value is
+// returned in satoshis as provided by the exchange
+func (b *Bitmex) GetOpenInterest(p currency.Pair) (exchange.OpenInterest, error) {
+	instruments, err := b.GetInstruments(&GenericRequestParams{
+		Symbol: exchange.FormatExchangeCurrency(b.Name, p).String(),
+	})
+	if err != nil {
+		return exchange.OpenInterest{}, err
+	}
+
+	if len(instruments) == 0 {
+		return exchange.OpenInterest{}, fmt.Errorf("%s REST error: no instrument returned for %s", b.Name, p)
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, instruments[0].Timestamp)
+	if err != nil {
+		timestamp = time.Now()
+	}
+
+	return exchange.OpenInterest{
+		Exchange:  b.Name,
+		Pair:      p,
+		AssetType: ticker.Futures,
+		Amount:    float64(instruments[0].OpenInterest),
+		Value:     float64(instruments[0].OpenValue),
+		Timestamp: timestamp,
+	}, nil
+}
@@ -67,6 +67,17 @@ type Bitstamp struct {
 	wsRequestMtx  sync.Mutex
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "Bitstamp",
+		Creator: func() exchange.IBotExchange {
+			return new(Bitstamp)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability,
+	})
+}
+
 // SetDefaults sets default for Bitstamp
 func (b *Bitstamp) SetDefaults() {
 	b.Name = "Bitstamp"
@@ -105,8 +116,10 @@ func (b *Bitstamp) Setup(exch *config.ExchangeConfig) {
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, exch.ClientID, false)
 		b.SetHTTPClientTimeout(exch.HTTPTimeout)
 		b.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		b.SetHTTPHeaders(exch.HTTPHeaders)
 		b.RESTPollingDelay = exch.RESTPollingDelay
 		b.Verbose = exch.Verbose
+		b.ReadOnly = exch.ReadOnly
 		b.HTTPDebugging = exch.HTTPDebugging
 		b.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		b.BaseCurrencies = exch.BaseCurrencies
@@ -659,7 +672,7 @@ func (b *Bitstamp) SendHTTPRequest(path string, result interface{}) error {
 // SendAuthenticatedHTTPRequest sends an authenticated request
 func (b *Bitstamp) SendAuthenticatedHTTPRequest(path string, v2 bool, values url.Values, result interface{}) error {
 	if !b.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, b.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	n := b.Requester.GetNonce(true).String()
@@ -11,6 +11,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -33,30 +34,31 @@ func (b *Bitstamp) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", b.GetName(), len(b.EnabledPairs), b.EnabledPairs)
 	}
 
-	pairs, err := b.GetTradingPairs()
+	err := b.UpdateTradablePairs(false)
 	if err != nil {
-		log.Errorf("%s failed to get trading pairs. Err: %s", b.Name, err)
-	} else {
-		var currencies []string
-		for x := range pairs {
-			if pairs[x].Trading != "Enabled" {
-				continue
-			}
-			p := strings.Split(pairs[x].Name, "/")
-			currencies = append(currencies, p[0]+p[1])
-		}
+		log.Errorf("%s failed to update tradable pairs. Err: %s", b.Name, err)
+	}
+}
 
-		var newCurrencies currency.Pairs
-		for _, p := range currencies {
-			newCurrencies = append(newCurrencies,
-				currency.NewPairFromString(p))
-		}
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (b *Bitstamp) UpdateTradablePairs(forceUpdate bool) error {
+	pairs, err := b.GetTradingPairs()
+	if err != nil {
+		return err
+	}
 
-		err = b.UpdateCurrencies(newCurrencies, false, false)
-		if err != nil {
-			log.Errorf("%s Failed to update available currencies.\n", b.Name)
+	var newCurrencies currency.Pairs
+	for x := range pairs {
+		if pairs[x].Trading != "Enabled" {
+			continue
 		}
+		p := strings.Split(pairs[x].Name, "/")
+		newCurrencies = append(newCurrencies,
+			currency.NewPairFromString(p[0]+p[1]))
 	}
+
+	return b.UpdateCurrencies(newCurrencies, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -74,6 +76,7 @@ func (b *Bitstamp) UpdateTicker(p currency.Pair, assetType string) (ticker.Price
 	tickerPrice.Last = tick.Last
 	tickerPrice.Volume = tick.Volume
 	tickerPrice.High = tick.High
+	tickerPrice.VWAP = tick.Vwap
 
 	err = ticker.ProcessTicker(b.GetName(), &tickerPrice, assetType)
 	if err != nil {
@@ -152,26 +155,14 @@ func (b *Bitstamp) GetAccountInfo() (exchange.AccountInfo, error) {
 	}
 
 	var currencies = []exchange.AccountCurrencyInfo{
-		{
-			CurrencyName: currency.BTC,
-			TotalValue:   accountBalance.BTCAvailable,
-			Hold:         accountBalance.BTCReserved,
-		},
-		{
-			CurrencyName: currency.XRP,
-			TotalValue:   accountBalance.XRPAvailable,
-			Hold:         accountBalance.XRPReserved,
-		},
-		{
-			CurrencyName: currency.USD,
-			TotalValue:   accountBalance.USDAvailable,
-			Hold:         accountBalance.USDReserved,
-		},
-		{
-			CurrencyName: currency.EUR,
-			TotalValue:   accountBalance.EURAvailable,
-			Hold:         accountBalance.EURReserved,
-		},
+		exchange.NewAccountCurrencyInfo(currency.BTC,
+			accountBalance.BTCAvailable, accountBalance.BTCReserved),
+		exchange.NewAccountCurrencyInfo(currency.XRP,
+			accountBalance.XRPAvailable, accountBalance.XRPReserved),
+		exchange.NewAccountCurrencyInfo(currency.USD,
+			accountBalance.USDAvailable, accountBalance.USDReserved),
+		exchange.NewAccountCurrencyInfo(currency.EUR,
+			accountBalance.EURAvailable, accountBalance.EURReserved),
 	}
 	response.Accounts = append(response.Accounts, exchange.Account{
 		Currencies: currencies,
@@ -194,8 +185,21 @@ func (b *Bitstamp) GetExchangeHistory(p currency.Pair, assetType string) ([]exch
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive
+func (b *Bitstamp) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (b *Bitstamp) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order
 func (b *Bitstamp) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if b.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	buy := side == exchange.BuyOrderSide
 	market := orderType == exchange.MarketOrderType
@@ -215,11 +219,17 @@ func (b *Bitstamp) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderTy
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Bitstamp) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *Bitstamp) CancelOrder(order *exchange.OrderCancellation) error {
+	if b.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -232,6 +242,9 @@ func (b *Bitstamp) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *Bitstamp) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if b.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
+	}
 	isCancelAllSuccessful, err := b.CancelAllExistingOrders()
 	if !isCancelAllSuccessful {
 		err = errors.New("cancel all orders failed. Bitstamp provides no further information. Check order status to verify")
@@ -254,6 +267,9 @@ func (b *Bitstamp) GetDepositAddress(cryptocurrency currency.Code, _ string) (st
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (b *Bitstamp) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	resp, err := b.CryptoWithdrawal(withdrawRequest.Amount, withdrawRequest.Address, withdrawRequest.Currency.String(), withdrawRequest.AddressTag, true)
 	if err != nil {
 		return "", err
@@ -268,6 +284,9 @@ func (b *Bitstamp) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.Withdra
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Bitstamp) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	resp, err := b.OpenBankWithdrawal(withdrawRequest.Amount, withdrawRequest.Currency.String(),
 		withdrawRequest.BankAccountName, withdrawRequest.IBAN, withdrawRequest.SwiftCode, withdrawRequest.BankAddress,
 		withdrawRequest.BankPostalCode, withdrawRequest.BankCity, withdrawRequest.BankCountry,
@@ -285,6 +304,9 @@ func (b *Bitstamp) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest)
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Bitstamp) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	resp, err := b.OpenInternationalBankWithdrawal(withdrawRequest.Amount, withdrawRequest.Currency.String(),
 		withdrawRequest.BankAccountName, withdrawRequest.IBAN, withdrawRequest.SwiftCode, withdrawRequest.BankAddress,
 		withdrawRequest.BankPostalCode, withdrawRequest.BankCity, withdrawRequest.BankCountry,
@@ -334,6 +356,7 @@ func (b *Bitstamp) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest)
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
 	exchange.FilterOrdersByCurrencies(&orders, getOrdersRequest.Currencies)
 
@@ -393,6 +416,7 @@ func (b *Bitstamp) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest)
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 	exchange.FilterOrdersByCurrencies(&orders, getOrdersRequest.Currencies)
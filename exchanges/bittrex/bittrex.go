@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
@@ -64,6 +65,16 @@ type Bittrex struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "Bittrex",
+		Creator: func() exchange.IBotExchange {
+			return new(Bittrex)
+		},
+		Capabilities: exchange.SpotCapability,
+	})
+}
+
 // SetDefaults method assignes the default values for Bittrex
 func (b *Bittrex) SetDefaults() {
 	b.Name = "Bittrex"
@@ -98,8 +109,10 @@ func (b *Bittrex) Setup(exch *config.ExchangeConfig) {
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, exch.ClientID, false)
 		b.SetHTTPClientTimeout(exch.HTTPTimeout)
 		b.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		b.SetHTTPHeaders(exch.HTTPHeaders)
 		b.RESTPollingDelay = exch.RESTPollingDelay
 		b.Verbose = exch.Verbose
+		b.ReadOnly = exch.ReadOnly
 		b.HTTPDebugging = exch.HTTPDebugging
 		b.BaseCurrencies = exch.BaseCurrencies
 		b.AvailablePairs = exch.AvailablePairs
@@ -501,7 +514,7 @@ func (b *Bittrex) SendHTTPRequest(path string, result interface{}) error {
 // path
 func (b *Bittrex) SendAuthenticatedHTTPRequest(path string, values url.Values, result interface{}) (err error) {
 	if !b.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, b.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	n := b.Requester.GetNonce(true).String()
@@ -539,22 +552,20 @@ func (b *Bittrex) GetFee(feeBuilder *exchange.FeeBuilder) (float64, error) {
 
 // GetWithdrawalFee returns the fee for withdrawing from the exchange
 func (b *Bittrex) GetWithdrawalFee(c currency.Code) (float64, error) {
-	var fee float64
-
 	currencies, err := b.GetCurrencies()
 	if err != nil {
 		return 0, err
 	}
 	for _, result := range currencies.Result {
-		if result.Currency == c.String() {
-			fee = result.TxFee
+		if strings.EqualFold(result.Currency, c.String()) {
+			return result.TxFee, nil
 		}
 	}
-	return fee, nil
+	return 0, fmt.Errorf("currency %s not supported", c)
 }
 
 // calculateTradingFee returns the fee for trading any currency on Bittrex
 func calculateTradingFee(price, amount float64) float64 {
-	return 0.0025 * price * amount
+	return exchange.CalculateTradingFee(0.0025, price, amount)
 
 }
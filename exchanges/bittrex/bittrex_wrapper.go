@@ -10,6 +10,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -31,47 +32,49 @@ func (b *Bittrex) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", b.GetName(), len(b.EnabledPairs), b.EnabledPairs)
 	}
 
-	exchangeProducts, err := b.GetMarkets()
-	if err != nil {
-		log.Errorf("%s Failed to get available symbols.\n", b.GetName())
-	} else {
-		forceUpgrade := false
-		if !common.StringDataContains(b.EnabledPairs.Strings(), "-") ||
-			!common.StringDataContains(b.AvailablePairs.Strings(), "-") {
-			forceUpgrade = true
-		}
-		var currencies []string
-		for x := range exchangeProducts.Result {
-			if !exchangeProducts.Result[x].IsActive ||
-				exchangeProducts.Result[x].MarketName == "" {
-				continue
-			}
-			currencies = append(currencies, exchangeProducts.Result[x].MarketName)
-		}
+	forceUpgrade := false
+	if !common.StringDataContains(b.EnabledPairs.Strings(), "-") ||
+		!common.StringDataContains(b.AvailablePairs.Strings(), "-") {
+		forceUpgrade = true
+	}
 
-		if forceUpgrade {
-			enabledPairs := currency.Pairs{currency.Pair{Base: currency.USDT,
-				Quote: currency.BTC, Delimiter: "-"}}
+	if forceUpgrade {
+		enabledPairs := currency.Pairs{currency.Pair{Base: currency.USDT,
+			Quote: currency.BTC, Delimiter: "-"}}
 
-			log.Warn("Available pairs for Bittrex reset due to config upgrade, please enable the ones you would like again")
+		log.Warn("Available pairs for Bittrex reset due to config upgrade, please enable the ones you would like again")
 
-			err = b.UpdateCurrencies(enabledPairs, true, true)
-			if err != nil {
-				log.Errorf("%s Failed to get config.", b.GetName())
-			}
+		err := b.UpdateCurrencies(enabledPairs, true, true)
+		if err != nil {
+			log.Errorf("%s Failed to get config.", b.GetName())
 		}
+	}
 
-		var newCurrencies currency.Pairs
-		for _, p := range currencies {
-			newCurrencies = append(newCurrencies,
-				currency.NewPairFromString(p))
-		}
+	err := b.UpdateTradablePairs(forceUpgrade)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", b.Name, err)
+	}
+}
 
-		err = b.UpdateCurrencies(newCurrencies, false, forceUpgrade)
-		if err != nil {
-			log.Errorf("%s Failed to get config.", b.GetName())
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (b *Bittrex) UpdateTradablePairs(forceUpdate bool) error {
+	exchangeProducts, err := b.GetMarkets()
+	if err != nil {
+		return err
+	}
+
+	var newCurrencies currency.Pairs
+	for x := range exchangeProducts.Result {
+		if !exchangeProducts.Result[x].IsActive ||
+			exchangeProducts.Result[x].MarketName == "" {
+			continue
 		}
+		newCurrencies = append(newCurrencies,
+			currency.NewPairFromString(exchangeProducts.Result[x].MarketName))
 	}
+
+	return b.UpdateCurrencies(newCurrencies, false, forceUpdate)
 }
 
 // GetAccountInfo Retrieves balances for all enabled currencies for the
@@ -86,11 +89,10 @@ func (b *Bittrex) GetAccountInfo() (exchange.AccountInfo, error) {
 
 	var currencies []exchange.AccountCurrencyInfo
 	for i := 0; i < len(accountBalance.Result); i++ {
-		var exchangeCurrency exchange.AccountCurrencyInfo
-		exchangeCurrency.CurrencyName = currency.NewCode(accountBalance.Result[i].Currency)
-		exchangeCurrency.TotalValue = accountBalance.Result[i].Balance
-		exchangeCurrency.Hold = accountBalance.Result[i].Balance - accountBalance.Result[i].Available
-		currencies = append(currencies, exchangeCurrency)
+		currencies = append(currencies, exchange.NewAccountCurrencyInfoFromTotalAvailable(
+			currency.NewCode(accountBalance.Result[i].Currency),
+			accountBalance.Result[i].Balance,
+			accountBalance.Result[i].Available))
 	}
 
 	response.Accounts = append(response.Accounts, exchange.Account{
@@ -197,8 +199,21 @@ func (b *Bittrex) GetExchangeHistory(p currency.Pair, assetType string) ([]excha
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive
+func (b *Bittrex) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (b *Bittrex) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order
 func (b *Bittrex) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if b.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	buy := side == exchange.BuyOrderSide
 	var response UUID
@@ -228,11 +243,17 @@ func (b *Bittrex) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderTyp
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Bittrex) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *Bittrex) CancelOrder(order *exchange.OrderCancellation) error {
+	if b.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	_, err := b.CancelExistingOrder(order.OrderID)
 
 	return err
@@ -240,9 +261,10 @@ func (b *Bittrex) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *Bittrex) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if b.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	openOrders, err := b.GetOpenOrders("")
 	if err != nil {
 		return cancelAllOrdersResponse, err
@@ -250,9 +272,7 @@ func (b *Bittrex) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.Cance
 
 	for i := range openOrders.Result {
 		_, err := b.CancelExistingOrder(openOrders.Result[i].OrderUUID)
-		if err != nil {
-			cancelAllOrdersResponse.OrderStatus[openOrders.Result[i].OrderUUID] = err.Error()
-		}
+		cancelAllOrdersResponse.Add(openOrders.Result[i].OrderUUID, err)
 	}
 
 	return cancelAllOrdersResponse, nil
@@ -274,9 +294,30 @@ func (b *Bittrex) GetDepositAddress(cryptocurrency currency.Code, _ string) (str
 	return depositAddr.Result.Address, nil
 }
 
+// GetDepositMethods returns the network a cryptocurrency is deposited on and
+// whether its wallet is active
+func (b *Bittrex) GetDepositMethods(c currency.Code) ([]exchange.DepositMethod, error) {
+	currencies, err := b.GetCurrencies()
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range currencies.Result {
+		if strings.EqualFold(result.Currency, c.String()) {
+			return []exchange.DepositMethod{{
+				Method:  result.CoinType,
+				Enabled: result.IsActive,
+			}}, nil
+		}
+	}
+	return nil, fmt.Errorf("currency %s not supported", c)
+}
+
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (b *Bittrex) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	uuid, err := b.Withdraw(withdrawRequest.Currency.String(), withdrawRequest.AddressTag, withdrawRequest.Address, withdrawRequest.Amount)
 	return fmt.Sprintf("%v", uuid), err
 }
@@ -284,12 +325,18 @@ func (b *Bittrex) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.Withdraw
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Bittrex) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Bittrex) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -344,6 +391,7 @@ func (b *Bittrex) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) (
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
@@ -377,6 +425,8 @@ func (b *Bittrex) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) (
 			b.ConfigCurrencyPairFormat.Delimiter)
 		orderType := exchange.OrderType(strings.ToUpper(resp.Result[i].Type))
 
+		// Commission is charged in the markets base currency, which is the
+		// first currency of the pair
 		orders = append(orders, exchange.OrderDetail{
 			Amount:          resp.Result[i].Quantity,
 			RemainingAmount: resp.Result[i].QuantityRemaining,
@@ -386,10 +436,12 @@ func (b *Bittrex) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) (
 			Exchange:        b.Name,
 			OrderType:       orderType,
 			Fee:             resp.Result[i].Commission,
+			FeeAsset:        pair.Base,
 			CurrencyPair:    pair,
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
@@ -28,6 +28,17 @@ type BTCC struct {
 	wsRequestMtx sync.Mutex
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "BTCC",
+		Creator: func() exchange.IBotExchange {
+			return new(BTCC)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability,
+	})
+}
+
 // SetDefaults sets default values for the exchange
 func (b *BTCC) SetDefaults() {
 	b.Name = "BTCC"
@@ -63,8 +74,10 @@ func (b *BTCC) Setup(exch *config.ExchangeConfig) {
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		b.SetHTTPClientTimeout(exch.HTTPTimeout)
 		b.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		b.SetHTTPHeaders(exch.HTTPHeaders)
 		b.RESTPollingDelay = exch.RESTPollingDelay
 		b.Verbose = exch.Verbose
+		b.ReadOnly = exch.ReadOnly
 		b.HTTPDebugging = exch.HTTPDebugging
 		b.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		b.BaseCurrencies = exch.BaseCurrencies
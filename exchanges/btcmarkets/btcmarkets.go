@@ -51,6 +51,16 @@ type BTCMarkets struct {
 	Ticker map[string]Ticker
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "BTC Markets",
+		Creator: func() exchange.IBotExchange {
+			return new(BTCMarkets)
+		},
+		Capabilities: exchange.SpotCapability,
+	})
+}
+
 // SetDefaults sets basic defaults
 func (b *BTCMarkets) SetDefaults() {
 	b.Name = "BTC Markets"
@@ -87,8 +97,10 @@ func (b *BTCMarkets) Setup(exch *config.ExchangeConfig) {
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, "", true)
 		b.SetHTTPClientTimeout(exch.HTTPTimeout)
 		b.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		b.SetHTTPHeaders(exch.HTTPHeaders)
 		b.RESTPollingDelay = exch.RESTPollingDelay
 		b.Verbose = exch.Verbose
+		b.ReadOnly = exch.ReadOnly
 		b.HTTPDebugging = exch.HTTPDebugging
 		b.BaseCurrencies = exch.BaseCurrencies
 		b.AvailablePairs = exch.AvailablePairs
@@ -438,8 +450,7 @@ func (b *BTCMarkets) SendHTTPRequest(path string, result interface{}) error {
 // SendAuthenticatedRequest sends an authenticated HTTP request
 func (b *BTCMarkets) SendAuthenticatedRequest(reqType, path string, data, result interface{}) (err error) {
 	if !b.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet,
-			b.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	n := b.Requester.GetNonce(true).String()[0:13]
@@ -522,7 +533,7 @@ func getOfflineTradeFee(price, amount float64) float64 {
 
 func calculateTradingFee(tradingFee TradingFee, purchasePrice, amount float64) (fee float64) {
 	fee = tradingFee.TradingFeeRate / 100000000
-	return fee * amount * purchasePrice
+	return exchange.CalculateTradingFee(fee, purchasePrice, amount)
 }
 
 func getCryptocurrencyWithdrawalFee(c currency.Code) float64 {
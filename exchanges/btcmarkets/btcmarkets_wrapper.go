@@ -11,6 +11,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -32,39 +33,46 @@ func (b *BTCMarkets) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", b.GetName(), len(b.EnabledPairs), b.EnabledPairs)
 	}
 
-	markets, err := b.GetMarkets()
-	if err != nil {
-		log.Errorf("%s failed to get active market. Err: %s", b.Name, err)
-	} else {
-		forceUpgrade := false
-		if !common.StringDataContains(b.EnabledPairs.Strings(), "-") ||
-			!common.StringDataContains(b.AvailablePairs.Strings(), "-") {
-			forceUpgrade = true
-		}
-
-		var currencies currency.Pairs
-		for x := range markets {
-			currencies = append(currencies,
-				currency.NewPairWithDelimiter(markets[x].Instrument,
-					markets[x].Currency, "-"))
-		}
+	forceUpgrade := false
+	if !common.StringDataContains(b.EnabledPairs.Strings(), "-") ||
+		!common.StringDataContains(b.AvailablePairs.Strings(), "-") {
+		forceUpgrade = true
+	}
 
-		if forceUpgrade {
-			enabledPairs := currency.Pairs{currency.Pair{Base: currency.BTC,
-				Quote: currency.AUD, Delimiter: "-"}}
+	if forceUpgrade {
+		enabledPairs := currency.Pairs{currency.Pair{Base: currency.BTC,
+			Quote: currency.AUD, Delimiter: "-"}}
 
-			log.Warn("Available pairs for BTC Makrets reset due to config upgrade, please enable the pairs you would like again.")
+		log.Warn("Available pairs for BTC Makrets reset due to config upgrade, please enable the pairs you would like again.")
 
-			err = b.UpdateCurrencies(enabledPairs, true, true)
-			if err != nil {
-				log.Errorf("%s failed to update currencies. Err: %s", b.Name, err)
-			}
-		}
-		err = b.UpdateCurrencies(currencies, false, forceUpgrade)
+		err := b.UpdateCurrencies(enabledPairs, true, true)
 		if err != nil {
 			log.Errorf("%s failed to update currencies. Err: %s", b.Name, err)
 		}
 	}
+
+	err := b.UpdateTradablePairs(forceUpgrade)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", b.Name, err)
+	}
+}
+
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (b *BTCMarkets) UpdateTradablePairs(forceUpdate bool) error {
+	markets, err := b.GetMarkets()
+	if err != nil {
+		return err
+	}
+
+	var currencies currency.Pairs
+	for x := range markets {
+		currencies = append(currencies,
+			currency.NewPairWithDelimiter(markets[x].Instrument,
+				markets[x].Currency, "-"))
+	}
+
+	return b.UpdateCurrencies(currencies, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -149,12 +157,10 @@ func (b *BTCMarkets) GetAccountInfo() (exchange.AccountInfo, error) {
 
 	var currencies []exchange.AccountCurrencyInfo
 	for i := 0; i < len(accountBalance); i++ {
-		var exchangeCurrency exchange.AccountCurrencyInfo
-		exchangeCurrency.CurrencyName = currency.NewCode(accountBalance[i].Currency)
-		exchangeCurrency.TotalValue = accountBalance[i].Balance
-		exchangeCurrency.Hold = accountBalance[i].PendingFunds
-
-		currencies = append(currencies, exchangeCurrency)
+		currencies = append(currencies, exchange.NewAccountCurrencyInfoFromTotal(
+			currency.NewCode(accountBalance[i].Currency),
+			accountBalance[i].Balance,
+			accountBalance[i].PendingFunds))
 	}
 
 	response.Accounts = append(response.Accounts, exchange.Account{
@@ -178,8 +184,38 @@ func (b *BTCMarkets) GetExchangeHistory(p currency.Pair, assetType string) ([]ex
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive
+func (b *BTCMarkets) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricTrades returns trades between start and end inclusive, oldest
+// first. Only the most recent trades are available and their side isn't
+// reported
+func (b *BTCMarkets) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	resp, err := b.GetTrades(p.Base.String(), p.Quote.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	trades := make([]exchange.TradeHistory, len(resp))
+	for x := range resp {
+		trades[x] = exchange.TradeHistory{
+			Timestamp: time.Unix(resp[x].Date, 0),
+			TID:       resp[x].TradeID,
+			Price:     resp[x].Price,
+			Amount:    resp[x].Amount,
+			Exchange:  b.Name,
+		}
+	}
+	return exchange.FilterTradeHistory(trades, start, end), nil
+}
+
 // SubmitOrder submits a new order
 func (b *BTCMarkets) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if b.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	response, err := b.NewOrder(p.Base.Upper().String(),
 		p.Quote.Upper().String(),
@@ -203,11 +239,17 @@ func (b *BTCMarkets) SubmitOrder(p currency.Pair, side exchange.OrderSide, order
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *BTCMarkets) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *BTCMarkets) CancelOrder(order *exchange.OrderCancellation) error {
+	if b.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 	if err != nil {
 		return err
@@ -219,9 +261,10 @@ func (b *BTCMarkets) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *BTCMarkets) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if b.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	openOrders, err := b.GetOpenOrders()
 	if err != nil {
 		return cancelAllOrdersResponse, err
@@ -231,7 +274,8 @@ func (b *BTCMarkets) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.Ca
 	for i := range openOrders {
 		orderIDInt, err := strconv.ParseInt(openOrders[i].ID, 10, 64)
 		if err != nil {
-			cancelAllOrdersResponse.OrderStatus[openOrders[i].ID] = err.Error()
+			cancelAllOrdersResponse.Add(openOrders[i].ID, err)
+			continue
 		}
 		orderList = append(orderList, orderIDInt)
 	}
@@ -243,9 +287,13 @@ func (b *BTCMarkets) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.Ca
 		}
 
 		for i := range orders {
-			if err != nil {
-				cancelAllOrdersResponse.OrderStatus[strconv.FormatInt(orders[i].ID, 10)] = err.Error()
+			orderID := strconv.FormatInt(orders[i].ID, 10)
+			if orders[i].Success {
+				cancelAllOrdersResponse.Add(orderID, nil)
+				continue
 			}
+			cancelAllOrdersResponse.AddFailure(orderID,
+				strconv.Itoa(orders[i].ErrorCode), orders[i].ErrorMessage)
 		}
 	}
 	return cancelAllOrdersResponse, nil
@@ -296,6 +344,7 @@ func (b *BTCMarkets) GetOrderInfo(orderID string) (exchange.OrderDetail, error)
 			orders[i].Currency,
 			b.ConfigCurrencyPairFormat.Delimiter)
 	}
+	OrderDetail.SetFillAmounts()
 
 	return OrderDetail, nil
 }
@@ -307,12 +356,18 @@ func (b *BTCMarkets) GetDepositAddress(cryptocurrency currency.Code, accountID s
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is submitted
 func (b *BTCMarkets) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return b.WithdrawCrypto(withdrawRequest.Amount, withdrawRequest.Currency.String(), withdrawRequest.Address)
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *BTCMarkets) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	if withdrawRequest.Currency != currency.AUD {
 		return "", errors.New("only AUD is supported for withdrawals")
 	}
@@ -322,6 +377,9 @@ func (b *BTCMarkets) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *BTCMarkets) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -381,13 +439,16 @@ func (b *BTCMarkets) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest
 				TID:         resp[i].Trades[j].ID,
 				Timestamp:   tradeDate,
 				Fee:         resp[i].Trades[j].Fee,
+				FeeAsset:    openOrder.CurrencyPair.Quote,
 				Description: resp[i].Trades[j].Description,
 			})
 		}
+		openOrder.AggregateTradeFees()
 
 		orders = append(orders, openOrder)
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
@@ -451,13 +512,16 @@ func (b *BTCMarkets) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest
 				TID:         respOrders[i].Trades[j].ID,
 				Timestamp:   tradeDate,
 				Fee:         respOrders[i].Trades[j].Fee,
+				FeeAsset:    openOrder.CurrencyPair.Quote,
 				Description: respOrders[i].Trades[j].Description,
 			})
 		}
+		openOrder.AggregateTradeFees()
 
 		orders = append(orders, openOrder)
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
@@ -46,6 +46,17 @@ const (
 	btseFills         = "fills"
 )
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "BTSE",
+		Creator: func() exchange.IBotExchange {
+			return new(BTSE)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability,
+	})
+}
+
 // SetDefaults sets the basic defaults for BTSE
 func (b *BTSE) SetDefaults() {
 	b.Name = "BTSE"
@@ -83,8 +94,10 @@ func (b *BTSE) Setup(exch *config.ExchangeConfig) {
 		b.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		b.SetHTTPClientTimeout(exch.HTTPTimeout)
 		b.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		b.SetHTTPHeaders(exch.HTTPHeaders)
 		b.RESTPollingDelay = exch.RESTPollingDelay
 		b.Verbose = exch.Verbose
+		b.ReadOnly = exch.ReadOnly
 		b.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		b.BaseCurrencies = exch.BaseCurrencies
 		b.AvailablePairs = exch.AvailablePairs
@@ -265,7 +278,7 @@ func (b *BTSE) SendHTTPRequest(method, endpoint string, result interface{}) erro
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request to the desired endpoint
 func (b *BTSE) SendAuthenticatedHTTPRequest(method, endpoint string, req map[string]interface{}, result interface{}) error {
 	if !b.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, b.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	payload, err := common.JSONEncode(req)
@@ -282,7 +295,7 @@ func (b *BTSE) SendAuthenticatedHTTPRequest(method, endpoint string, req map[str
 
 	p := fmt.Sprintf("%s/%s", btseAPIURL, endpoint)
 	if b.Verbose {
-		log.Debugf("Sending %s request to URL %s with params %s\n", method, p, string(payload))
+		log.Debugf("Sending %s request to URL %s with params %s\n", method, p, log.DebugPayload(b.Name, payload))
 	}
 	return b.SendPayload(method, p, headers, strings.NewReader(string(payload)),
 		&result, true, false, b.Verbose, b.HTTPDebugging)
@@ -10,6 +10,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -32,22 +33,28 @@ func (b *BTSE) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", b.GetName(), len(b.EnabledPairs), b.EnabledPairs)
 	}
 
+	err := b.UpdateTradablePairs(false)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", b.Name, err)
+	}
+}
+
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (b *BTSE) UpdateTradablePairs(forceUpdate bool) error {
 	markets, err := b.GetMarkets()
 	if err != nil {
-		log.Errorf("%s failed to get trading pairs. Err: %s", b.Name, err)
-	} else {
-		var currencies []string
-		for _, m := range *markets {
-			currencies = append(currencies, m.ID)
-		}
-		err = b.UpdateCurrencies(currency.NewPairsFromStrings(currencies),
-			false,
-			false)
-		if err != nil {
-			log.Errorf("%s Failed to update available currencies.\n", b.Name)
-		}
+		return err
+	}
+
+	var currencies []string
+	for _, m := range *markets {
+		currencies = append(currencies, m.ID)
 	}
 
+	return b.UpdateCurrencies(currency.NewPairsFromStrings(currencies),
+		false,
+		forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -115,12 +122,8 @@ func (b *BTSE) GetAccountInfo() (exchange.AccountInfo, error) {
 	var currencies []exchange.AccountCurrencyInfo
 	for _, b := range *balance {
 		currencies = append(currencies,
-			exchange.AccountCurrencyInfo{
-				CurrencyName: currency.NewCode(b.Currency),
-				TotalValue:   b.Total,
-				Hold:         b.Available,
-			},
-		)
+			exchange.NewAccountCurrencyInfoFromTotalAvailable(
+				currency.NewCode(b.Currency), b.Total, b.Available))
 	}
 	a.Exchange = b.Name
 	a.Accounts = []exchange.Account{
@@ -142,8 +145,21 @@ func (b *BTSE) GetExchangeHistory(p currency.Pair, assetType string) ([]exchange
 	return nil, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive
+func (b *BTSE) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (b *BTSE) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order
 func (b *BTSE) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if b.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var resp exchange.SubmitOrderResponse
 	r, err := b.CreateOrder(amount, price, side.ToString(),
 		orderType.ToString(), exchange.FormatExchangeCurrency(b.Name, p).String(), "GTC", clientID)
@@ -162,11 +178,17 @@ func (b *BTSE) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType e
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *BTSE) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *BTSE) CancelOrder(order *exchange.OrderCancellation) error {
+	if b.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	r, err := b.CancelExistingOrder(order.OrderID,
 		exchange.FormatExchangeCurrency(b.Name, order.CurrencyPair).String())
 	if err != nil {
@@ -187,6 +209,9 @@ func (b *BTSE) CancelOrder(order *exchange.OrderCancellation) error {
 // If product ID is sent, all orders of that specified market will be cancelled
 // If not specified, all orders of all markets will be cancelled
 func (b *BTSE) CancelAllOrders(orderCancellation *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if b.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
+	}
 	r, err := b.CancelOrders(exchange.FormatExchangeCurrency(b.Name,
 		orderCancellation.CurrencyPair).String())
 	if err != nil {
@@ -256,6 +281,8 @@ func (b *BTSE) GetOrderInfo(orderID string) (exchange.OrderDetail, error) {
 				Fee:       f.Fee,
 			})
 		}
+		od.AggregateTradeFees()
+		od.SetFillAmounts()
 	}
 	return od, nil
 }
@@ -268,18 +295,27 @@ func (b *BTSE) GetDepositAddress(cryptocurrency currency.Code, accountID string)
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (b *BTSE) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (b *BTSE) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a withdrawal is
 // submitted
 func (b *BTSE) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if b.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -335,9 +371,11 @@ func (b *BTSE) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([]e
 				Fee:       f.Fee,
 			})
 		}
+		openOrder.AggregateTradeFees()
 		orders = append(orders, openOrder)
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
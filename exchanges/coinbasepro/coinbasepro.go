@@ -54,6 +54,13 @@ const (
 	coinbaseproCoinbaseAccounts        = "coinbase-accounts"
 	coinbaseproTrailingVolume          = "users/self/trailing-volume"
 
+	// coinbaseproProductDelisted is the status of products being delisted
+	coinbaseproProductDelisted = "delisted"
+
+	// coinbaseproHistoricRatesLimit is the maximum number of candles returned
+	// per request
+	coinbaseproHistoricRatesLimit = 300
+
 	coinbaseproAuthRate   = 5
 	coinbaseproUnauthRate = 3
 )
@@ -65,6 +72,18 @@ type CoinbasePro struct {
 	wsRequestMtx  sync.Mutex
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "CoinbasePro",
+		Creator: func() exchange.IBotExchange {
+			return new(CoinbasePro)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability |
+			exchange.SandboxCapability,
+	})
+}
+
 // SetDefaults sets default values for the exchange
 func (c *CoinbasePro) SetDefaults() {
 	c.Name = "CoinbasePro"
@@ -73,6 +92,7 @@ func (c *CoinbasePro) SetDefaults() {
 	c.TakerFee = 0.25
 	c.MakerFee = 0
 	c.RESTPollingDelay = 10
+	c.RequiresAPIPassphrase = true
 	c.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission |
 		exchange.AutoWithdrawFiatWithAPIPermission
 	c.RequestCurrencyPairFormat.Delimiter = "-"
@@ -81,6 +101,7 @@ func (c *CoinbasePro) SetDefaults() {
 	c.ConfigCurrencyPairFormat.Uppercase = true
 	c.AssetTypes = []string{ticker.Spot}
 	c.SupportsAutoPairUpdating = true
+	c.SupportsKlines = true
 	c.SupportsRESTTickerBatching = false
 	c.Requester = request.New(c.Name,
 		request.NewRateLimit(time.Second, coinbaseproAuthRate),
@@ -102,19 +123,19 @@ func (c *CoinbasePro) Setup(exch *config.ExchangeConfig) {
 	} else {
 		c.Enabled = true
 		c.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
-		c.SetAPIKeys(exch.APIKey, exch.APISecret, exch.ClientID, true)
+		c.SetAPIKeys(exch.APIKey, exch.APISecret, "", true)
+		c.SetAPIPassphrase(exch.APIPassphrase)
 		c.SetHTTPClientTimeout(exch.HTTPTimeout)
 		c.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		c.SetHTTPHeaders(exch.HTTPHeaders)
 		c.RESTPollingDelay = exch.RESTPollingDelay
 		c.Verbose = exch.Verbose
+		c.ReadOnly = exch.ReadOnly
 		c.HTTPDebugging = exch.HTTPDebugging
 		c.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		c.BaseCurrencies = exch.BaseCurrencies
 		c.AvailablePairs = exch.AvailablePairs
 		c.EnabledPairs = exch.EnabledPairs
-		if exch.UseSandbox {
-			c.APIUrl = coinbaseproSandboxAPIURL
-		}
 		err := c.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
@@ -131,17 +152,22 @@ func (c *CoinbasePro) Setup(exch *config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		c.SetSandbox(exch, coinbaseproSandboxAPIURL)
 		err = c.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
 			log.Fatal(err)
 		}
+		wsURL := coinbaseproWebsocketURL
+		if c.IsSandbox() {
+			wsURL = coinbaseproSandboxWebsocketURL
+		}
 		err = c.WebsocketSetup(c.WsConnect,
 			c.Subscribe,
 			c.Unsubscribe,
 			exch.Name,
 			exch.Websocket,
 			exch.Verbose,
-			coinbaseproWebsocketURL,
+			wsURL,
 			exch.WebsocketURL)
 		if err != nil {
 			log.Fatal(err)
@@ -157,6 +183,13 @@ func (c *CoinbasePro) GetProducts() ([]Product, error) {
 	return products, c.SendHTTPRequest(c.APIUrl+coinbaseproProducts, &products)
 }
 
+// GetProduct returns the information and trading status of a currency pair
+func (c *CoinbasePro) GetProduct(productID string) (Product, error) {
+	var product Product
+
+	return product, c.SendHTTPRequest(fmt.Sprintf("%s/%s", c.APIUrl+coinbaseproProducts, productID), &product)
+}
+
 // GetOrderbook returns orderbook by currency pair and level
 func (c *CoinbasePro) GetOrderbook(symbol string, level int) (interface{}, error) {
 	orderbook := OrderbookResponse{}
@@ -249,18 +282,19 @@ func (c *CoinbasePro) GetTrades(currencyPair string) ([]Trade, error) {
 }
 
 // GetHistoricRates returns historic rates for a product. Rates are returned in
-// grouped buckets based on requested granularity.
+// grouped buckets based on requested granularity. Start and end are unix
+// timestamps
 func (c *CoinbasePro) GetHistoricRates(currencyPair string, start, end, granularity int64) ([]History, error) {
 	var resp [][]interface{}
 	var history []History
 	values := url.Values{}
 
 	if start > 0 {
-		values.Set("start", strconv.FormatInt(start, 10))
+		values.Set("start", time.Unix(start, 0).UTC().Format(time.RFC3339))
 	}
 
 	if end > 0 {
-		values.Set("end", strconv.FormatInt(end, 10))
+		values.Set("end", time.Unix(end, 0).UTC().Format(time.RFC3339))
 	}
 
 	if granularity > 0 {
@@ -806,8 +840,7 @@ func (c *CoinbasePro) SendHTTPRequest(path string, result interface{}) error {
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP reque
 func (c *CoinbasePro) SendAuthenticatedHTTPRequest(method, path string, params map[string]interface{}, result interface{}) (err error) {
 	if !c.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet,
-			c.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	payload := []byte("")
@@ -819,7 +852,7 @@ func (c *CoinbasePro) SendAuthenticatedHTTPRequest(method, path string, params m
 		}
 
 		if c.Verbose {
-			log.Debugf("Request JSON: %s\n", payload)
+			log.Debugf("Request JSON: %s\n", log.DebugPayload(c.Name, payload))
 		}
 	}
 
@@ -830,7 +863,7 @@ func (c *CoinbasePro) SendAuthenticatedHTTPRequest(method, path string, params m
 	headers["CB-ACCESS-SIGN"] = common.Base64Encode(hmac)
 	headers["CB-ACCESS-TIMESTAMP"] = n
 	headers["CB-ACCESS-KEY"] = c.APIKey
-	headers["CB-ACCESS-PASSPHRASE"] = c.ClientID
+	headers["CB-ACCESS-PASSPHRASE"] = c.APIPassphrase
 	headers["Content-Type"] = "application/json"
 
 	return c.SendPayload(method,
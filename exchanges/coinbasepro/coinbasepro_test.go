@@ -15,7 +15,7 @@ var c CoinbasePro
 const (
 	apiKey                  = ""
 	apiSecret               = ""
-	clientID                = "" // passphrase you made at API CREATION
+	passphrase              = "" // passphrase you made at API CREATION
 	canManipulateRealOrders = false
 )
 
@@ -33,6 +33,7 @@ func TestSetup(t *testing.T) {
 	}
 	gdxConfig.APIKey = apiKey
 	gdxConfig.APISecret = apiSecret
+	gdxConfig.APIPassphrase = passphrase
 	gdxConfig.AuthenticatedAPISupport = true
 	c.Setup(&gdxConfig)
 }
@@ -44,6 +45,29 @@ func TestGetProducts(t *testing.T) {
 	}
 }
 
+func TestGetTradingStatus(t *testing.T) {
+	p := currency.NewPairFromStrings("BTC", "USD")
+	status := getTradingStatus(c.Name, p, &Product{Status: "online"})
+	if status.Status != exchange.TradingStatusTrading || status.IsHalted() {
+		t.Errorf("Test failed - getTradingStatus() unexpected status %+v", status)
+	}
+	status = getTradingStatus(c.Name, p, &Product{Status: "online", PostOnly: true})
+	if status.Status != exchange.TradingStatusPostOnly || status.IsHalted() {
+		t.Errorf("Test failed - getTradingStatus() unexpected status %+v", status)
+	}
+	status = getTradingStatus(c.Name, p, &Product{Status: "delisted", CancelOnly: true,
+		StatusMessage: "Delisting"})
+	if status.Status != exchange.TradingStatusCancelOnly || !status.IsHalted() ||
+		status.Message != "delisted: Delisting" {
+		t.Errorf("Test failed - getTradingStatus() unexpected status %+v", status)
+	}
+	status = getTradingStatus(c.Name, p, &Product{Status: "online", TradingDisabled: true,
+		PostOnly: true})
+	if status.Status != exchange.TradingStatusHalted {
+		t.Errorf("Test failed - getTradingStatus() unexpected status %+v", status)
+	}
+}
+
 func TestGetTicker(t *testing.T) {
 	_, err := c.GetTicker("BTC-USD")
 	if err != nil {
@@ -88,7 +112,7 @@ func TestGetServerTime(t *testing.T) {
 
 func TestAuthRequests(t *testing.T) {
 
-	if c.APIKey != "" && c.APISecret != "" && c.ClientID != "" {
+	if c.APIKey != "" && c.APISecret != "" && c.APIPassphrase != "" {
 
 		_, err := c.GetAccounts()
 		if err == nil {
@@ -539,8 +563,8 @@ func TestCancelAllExchangeOrders(t *testing.T) {
 		t.Errorf("Could not cancel orders: %v", err)
 	}
 
-	if len(resp.OrderStatus) > 0 {
-		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
+	if len(resp.Failed()) > 0 {
+		t.Errorf("%v orders failed to cancel", len(resp.Failed()))
 	}
 }
 
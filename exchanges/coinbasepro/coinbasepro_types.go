@@ -4,13 +4,19 @@ import "time"
 
 // Product holds product information
 type Product struct {
-	ID             string      `json:"id"`
-	BaseCurrency   string      `json:"base_currency"`
-	QuoteCurrency  string      `json:"quote_currency"`
-	BaseMinSize    float64     `json:"base_min_size,string"`
-	BaseMaxSize    interface{} `json:"base_max_size"`
-	QuoteIncrement float64     `json:"quote_increment,string"`
-	DisplayName    string      `json:"string"`
+	ID              string      `json:"id"`
+	BaseCurrency    string      `json:"base_currency"`
+	QuoteCurrency   string      `json:"quote_currency"`
+	BaseMinSize     float64     `json:"base_min_size,string"`
+	BaseMaxSize     interface{} `json:"base_max_size"`
+	QuoteIncrement  float64     `json:"quote_increment,string"`
+	DisplayName     string      `json:"string"`
+	Status          string      `json:"status"`
+	StatusMessage   string      `json:"status_message"`
+	PostOnly        bool        `json:"post_only"`
+	CancelOnly      bool        `json:"cancel_only"`
+	LimitOnly       bool        `json:"limit_only"`
+	TradingDisabled bool        `json:"trading_disabled"`
 }
 
 // Ticker holds basic ticker information
@@ -40,6 +46,10 @@ type History struct {
 	Volume float64 `json:"volume"`
 }
 
+// klineGranularities are the candle durations in seconds the historic rates
+// endpoint accepts
+var klineGranularities = []int64{60, 300, 900, 3600, 21600, 86400}
+
 // Stats holds last 24 hr data for coinbasepro
 type Stats struct {
 	Open   float64 `json:"open,string"`
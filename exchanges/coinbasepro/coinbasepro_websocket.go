@@ -17,7 +17,8 @@ import (
 )
 
 const (
-	coinbaseproWebsocketURL = "wss://ws-feed.pro.coinbase.com"
+	coinbaseproWebsocketURL        = "wss://ws-feed.pro.coinbase.com"
+	coinbaseproSandboxWebsocketURL = "wss://ws-feed-public.sandbox.pro.coinbase.com"
 )
 
 // WsConnect initiates a websocket connection
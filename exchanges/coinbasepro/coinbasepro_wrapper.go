@@ -10,6 +10,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -27,33 +28,34 @@ func (c *CoinbasePro) Start(wg *sync.WaitGroup) {
 // Run implements the coinbasepro wrapper
 func (c *CoinbasePro) Run() {
 	if c.Verbose {
-		log.Debugf("%s Websocket: %s. (url: %s).\n", c.GetName(), common.IsEnabled(c.Websocket.IsEnabled()), coinbaseproWebsocketURL)
+		log.Debugf("%s Websocket: %s. (url: %s).\n", c.GetName(), common.IsEnabled(c.Websocket.IsEnabled()), c.Websocket.GetWebsocketURL())
 		log.Debugf("%s polling delay: %ds.\n", c.GetName(), c.RESTPollingDelay)
 		log.Debugf("%s %d currencies enabled: %s.\n", c.GetName(), len(c.EnabledPairs), c.EnabledPairs)
 	}
 
+	err := c.UpdateTradablePairs(false)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", c.Name, err)
+	}
+}
+
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (c *CoinbasePro) UpdateTradablePairs(forceUpdate bool) error {
 	exchangeProducts, err := c.GetProducts()
 	if err != nil {
-		log.Errorf("%s Failed to get available products.\n", c.GetName())
-	} else {
-		var currencies []string
-		for _, x := range exchangeProducts {
-			if x.ID != "BTC" && x.ID != "USD" && x.ID != "GBP" {
-				currencies = append(currencies, x.ID[0:3]+x.ID[4:])
-			}
-		}
+		return err
+	}
 
-		var newCurrencies currency.Pairs
-		for _, p := range currencies {
+	var newCurrencies currency.Pairs
+	for _, x := range exchangeProducts {
+		if x.ID != "BTC" && x.ID != "USD" && x.ID != "GBP" {
 			newCurrencies = append(newCurrencies,
-				currency.NewPairFromString(p))
-		}
-
-		err = c.UpdateCurrencies(newCurrencies, false, false)
-		if err != nil {
-			log.Errorf("%s Failed to update available currencies.\n", c.GetName())
+				currency.NewPairFromString(x.ID[0:3]+x.ID[4:]))
 		}
 	}
+
+	return c.UpdateCurrencies(newCurrencies, false, forceUpdate)
 }
 
 // GetAccountInfo retrieves balances for all enabled currencies for the
@@ -68,12 +70,10 @@ func (c *CoinbasePro) GetAccountInfo() (exchange.AccountInfo, error) {
 
 	var currencies []exchange.AccountCurrencyInfo
 	for i := 0; i < len(accountBalance); i++ {
-		var exchangeCurrency exchange.AccountCurrencyInfo
-		exchangeCurrency.CurrencyName = currency.NewCode(accountBalance[i].Currency)
-		exchangeCurrency.TotalValue = accountBalance[i].Available
-		exchangeCurrency.Hold = accountBalance[i].Hold
-
-		currencies = append(currencies, exchangeCurrency)
+		currencies = append(currencies, exchange.NewAccountCurrencyInfo(
+			currency.NewCode(accountBalance[i].Currency),
+			accountBalance[i].Available,
+			accountBalance[i].Hold))
 	}
 
 	response.Accounts = append(response.Accounts, exchange.Account{
@@ -173,8 +173,59 @@ func (c *CoinbasePro) GetExchangeHistory(p currency.Pair, assetType string) ([]e
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive,
+// requesting the range in windows of the maximum candles returned per request
+func (c *CoinbasePro) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	granularity := int64(interval / time.Second)
+	supported := false
+	for x := range klineGranularities {
+		if time.Duration(klineGranularities[x])*time.Second == interval {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, fmt.Errorf("%s unsupported kline interval %s", c.Name, interval)
+	}
+
+	var candles []kline.Candle
+	for windowStart := start; !windowStart.After(end); {
+		windowEnd := windowStart.Add(interval * (coinbaseproHistoricRatesLimit - 1))
+		if windowEnd.After(end) {
+			windowEnd = end
+		}
+		resp, err := c.GetHistoricRates(exchange.FormatExchangeCurrency(c.Name, p).String(),
+			windowStart.Unix(), windowEnd.Unix(), granularity)
+		if err != nil {
+			return nil, err
+		}
+
+		for x := range resp {
+			candles = append(candles, kline.Candle{
+				Time:   time.Unix(resp[x].Time, 0),
+				Open:   resp[x].Open,
+				High:   resp[x].High,
+				Low:    resp[x].Low,
+				Close:  resp[x].Close,
+				Volume: resp[x].Volume,
+			})
+		}
+		windowStart = windowEnd.Add(interval)
+	}
+	// Candles are returned newest first
+	return kline.FilterCandles(candles, start, end), nil
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (c *CoinbasePro) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order
 func (c *CoinbasePro) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if c.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var response string
 	var err error
@@ -215,19 +266,33 @@ func (c *CoinbasePro) SubmitOrder(p currency.Pair, side exchange.OrderSide, orde
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (c *CoinbasePro) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if c.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (c *CoinbasePro) CancelOrder(order *exchange.OrderCancellation) error {
+	if c.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	return c.CancelExistingOrder(order.OrderID)
 }
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (c *CoinbasePro) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	// CancellAllExisting orders returns a list of successful cancellations, we're only interested in failures
-	_, err := c.CancelAllExistingOrders("")
-	return exchange.CancelAllOrdersResponse{}, err
+	if c.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
+	}
+	// CancelAllExistingOrders returns the orders cancelled, any orders it
+	// failed to cancel are not reported
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
+	orderIDs, err := c.CancelAllExistingOrders("")
+	for i := range orderIDs {
+		cancelAllOrdersResponse.Add(orderIDs[i], nil)
+	}
+	return cancelAllOrdersResponse, err
 }
 
 // GetOrderInfo returns information on a current open order
@@ -244,6 +309,9 @@ func (c *CoinbasePro) GetDepositAddress(cryptocurrency currency.Code, accountID
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (c *CoinbasePro) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if c.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	resp, err := c.WithdrawCrypto(withdrawRequest.Amount, withdrawRequest.Currency.String(), withdrawRequest.Address)
 	return resp.ID, err
 }
@@ -251,6 +319,9 @@ func (c *CoinbasePro) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.With
 // WithdrawFiatFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (c *CoinbasePro) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if c.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	paymentMethods, err := c.GetPayMethods()
 	if err != nil {
 		return "", err
@@ -278,6 +349,9 @@ func (c *CoinbasePro) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawReques
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (c *CoinbasePro) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if c.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return c.WithdrawFiatFunds(withdrawRequest)
 }
 
@@ -331,6 +405,7 @@ func (c *CoinbasePro) GetActiveOrders(getOrdersRequest *exchange.GetOrdersReques
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
@@ -375,6 +450,7 @@ func (c *CoinbasePro) GetOrderHistory(getOrdersRequest *exchange.GetOrdersReques
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByType(&orders, getOrdersRequest.OrderType)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
@@ -383,6 +459,41 @@ func (c *CoinbasePro) GetOrderHistory(getOrdersRequest *exchange.GetOrdersReques
 	return orders, nil
 }
 
+// GetTradingStatus returns the trading status of a currency pair
+func (c *CoinbasePro) GetTradingStatus(p currency.Pair) (exchange.TradingStatus, error) {
+	product, err := c.GetProduct(exchange.FormatExchangeCurrency(c.Name, p).String())
+	if err != nil {
+		return exchange.TradingStatus{}, err
+	}
+	return getTradingStatus(c.Name, p, &product), nil
+}
+
+// getTradingStatus returns the trading status of a product, trading being
+// disabled taking precedence over the cancel-only and post-only modes
+func getTradingStatus(exchName string, p currency.Pair, product *Product) exchange.TradingStatus {
+	status := exchange.TradingStatus{
+		Exchange: exchName,
+		Pair:     p,
+		Status:   exchange.TradingStatusTrading,
+		Message:  product.Status,
+	}
+	if product.StatusMessage != "" {
+		status.Message += ": " + product.StatusMessage
+	}
+
+	switch {
+	case product.TradingDisabled:
+		status.Status = exchange.TradingStatusHalted
+	case product.CancelOnly:
+		status.Status = exchange.TradingStatusCancelOnly
+	case product.Status == coinbaseproProductDelisted:
+		status.Status = exchange.TradingStatusDelisting
+	case product.PostOnly:
+		status.Status = exchange.TradingStatusPostOnly
+	}
+	return status
+}
+
 // SubscribeToWebsocketChannels appends to ChannelsToSubscribe
 // which lets websocket.manageSubscriptions handle subscribing
 func (c *CoinbasePro) SubscribeToWebsocketChannels(channels []exchange.WebsocketChannelSubscription) error {
@@ -52,6 +52,17 @@ type COINUT struct {
 	wsRequestMtx  sync.Mutex
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "COINUT",
+		Creator: func() exchange.IBotExchange {
+			return new(COINUT)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability,
+	})
+}
+
 // SetDefaults sets current default values
 func (c *COINUT) SetDefaults() {
 	c.Name = "COINUT"
@@ -94,8 +105,10 @@ func (c *COINUT) Setup(exch *config.ExchangeConfig) {
 		c.SetAPIKeys(exch.APIKey, exch.APISecret, exch.ClientID, false)
 		c.SetHTTPClientTimeout(exch.HTTPTimeout)
 		c.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		c.SetHTTPHeaders(exch.HTTPHeaders)
 		c.RESTPollingDelay = exch.RESTPollingDelay
 		c.Verbose = exch.Verbose
+		c.ReadOnly = exch.ReadOnly
 		c.HTTPDebugging = exch.HTTPDebugging
 		c.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		c.BaseCurrencies = exch.BaseCurrencies
@@ -343,7 +356,7 @@ func (c *COINUT) GetOpenPositions(instrumentID int) ([]OpenPosition, error) {
 // SendHTTPRequest sends either an authenticated or unauthenticated HTTP request
 func (c *COINUT) SendHTTPRequest(apiRequest string, params map[string]interface{}, authenticated bool, result interface{}) (err error) {
 	if !c.AuthenticatedAPISupport && authenticated {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, c.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	n := c.Requester.GetNonce(false)
@@ -360,7 +373,7 @@ func (c *COINUT) SendHTTPRequest(apiRequest string, params map[string]interface{
 	}
 
 	if c.Verbose {
-		log.Debugf("Request JSON: %s", payload)
+		log.Debugf("Request JSON: %s", log.DebugPayload(c.Name, payload))
 	}
 
 	headers := make(map[string]string)
@@ -354,7 +354,7 @@ func (c *COINUT) wsSend(data interface{}) error {
 		return err
 	}
 	if c.Verbose {
-		log.Debugf("%v sending message to websocket %v", c.Name, string(json))
+		log.Debugf("%v sending message to websocket %v", c.Name, log.DebugPayload(c.Name, json))
 	}
 	// Basic rate limiter
 	time.Sleep(coinutWebsocketRateLimit)
@@ -10,7 +10,9 @@ import (
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/database"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -33,29 +35,29 @@ func (c *COINUT) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", c.GetName(), len(c.EnabledPairs), c.EnabledPairs)
 	}
 
+	err := c.UpdateTradablePairs(false)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", c.Name, err)
+	}
+}
+
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (c *COINUT) UpdateTradablePairs(forceUpdate bool) error {
 	exchangeProducts, err := c.GetInstruments()
 	if err != nil {
-		log.Debugf("%s Failed to get available products.\n", c.GetName())
-		return
+		return err
 	}
 
-	var currencies []string
+	var newCurrencies currency.Pairs
 	c.InstrumentMap = make(map[string]int)
 	for x, y := range exchangeProducts.Instruments {
 		c.InstrumentMap[x] = y[0].InstID
-		currencies = append(currencies, x)
-	}
-
-	var newCurrencies currency.Pairs
-	for _, p := range currencies {
 		newCurrencies = append(newCurrencies,
-			currency.NewPairFromString(p))
+			currency.NewPairFromString(x))
 	}
 
-	err = c.UpdateCurrencies(newCurrencies, false, false)
-	if err != nil {
-		log.Errorf("%s Failed to update available currencies.\n", c.GetName())
-	}
+	return c.UpdateCurrencies(newCurrencies, false, forceUpdate)
 }
 
 // GetAccountInfo retrieves balances for all enabled currencies for the
@@ -67,63 +69,23 @@ func (c *COINUT) GetAccountInfo() (exchange.AccountInfo, error) {
 		return info, err
 	}
 
+	// COINUT only reports the total balance of each currency, funds in open
+	// orders are not separated
 	var balances = []exchange.AccountCurrencyInfo{
-		{
-			CurrencyName: currency.BCH,
-			TotalValue:   bal.BCH,
-		},
-		{
-			CurrencyName: currency.BTC,
-			TotalValue:   bal.BTC,
-		},
-		{
-			CurrencyName: currency.BTG,
-			TotalValue:   bal.BTG,
-		},
-		{
-			CurrencyName: currency.CAD,
-			TotalValue:   bal.CAD,
-		},
-		{
-			CurrencyName: currency.ETC,
-			TotalValue:   bal.ETC,
-		},
-		{
-			CurrencyName: currency.ETH,
-			TotalValue:   bal.ETH,
-		},
-		{
-			CurrencyName: currency.LCH,
-			TotalValue:   bal.LCH,
-		},
-		{
-			CurrencyName: currency.LTC,
-			TotalValue:   bal.LTC,
-		},
-		{
-			CurrencyName: currency.MYR,
-			TotalValue:   bal.MYR,
-		},
-		{
-			CurrencyName: currency.SGD,
-			TotalValue:   bal.SGD,
-		},
-		{
-			CurrencyName: currency.USD,
-			TotalValue:   bal.USD,
-		},
-		{
-			CurrencyName: currency.USDT,
-			TotalValue:   bal.USDT,
-		},
-		{
-			CurrencyName: currency.XMR,
-			TotalValue:   bal.XMR,
-		},
-		{
-			CurrencyName: currency.ZEC,
-			TotalValue:   bal.ZEC,
-		},
+		exchange.NewAccountCurrencyInfoFromTotal(currency.BCH, bal.BCH, 0),
+		exchange.NewAccountCurrencyInfoFromTotal(currency.BTC, bal.BTC, 0),
+		exchange.NewAccountCurrencyInfoFromTotal(currency.BTG, bal.BTG, 0),
+		exchange.NewAccountCurrencyInfoFromTotal(currency.CAD, bal.CAD, 0),
+		exchange.NewAccountCurrencyInfoFromTotal(currency.ETC, bal.ETC, 0),
+		exchange.NewAccountCurrencyInfoFromTotal(currency.ETH, bal.ETH, 0),
+		exchange.NewAccountCurrencyInfoFromTotal(currency.LCH, bal.LCH, 0),
+		exchange.NewAccountCurrencyInfoFromTotal(currency.LTC, bal.LTC, 0),
+		exchange.NewAccountCurrencyInfoFromTotal(currency.MYR, bal.MYR, 0),
+		exchange.NewAccountCurrencyInfoFromTotal(currency.SGD, bal.SGD, 0),
+		exchange.NewAccountCurrencyInfoFromTotal(currency.USD, bal.USD, 0),
+		exchange.NewAccountCurrencyInfoFromTotal(currency.USDT, bal.USDT, 0),
+		exchange.NewAccountCurrencyInfoFromTotal(currency.XMR, bal.XMR, 0),
+		exchange.NewAccountCurrencyInfoFromTotal(currency.ZEC, bal.ZEC, 0),
 	}
 	info.Exchange = c.GetName()
 	info.Accounts = append(info.Accounts, exchange.Account{
@@ -217,8 +179,38 @@ func (c *COINUT) GetExchangeHistory(p currency.Pair, assetType string) ([]exchan
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive
+func (c *COINUT) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricTrades returns trades between start and end inclusive, oldest
+// first. Only the most recent trades are available
+func (c *COINUT) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	resp, err := c.GetTrades(c.InstrumentMap[p.String()])
+	if err != nil {
+		return nil, err
+	}
+
+	trades := make([]exchange.TradeHistory, len(resp.Trades))
+	for x := range resp.Trades {
+		trades[x] = exchange.TradeHistory{
+			Timestamp: time.Unix(0, int64(resp.Trades[x].Timestamp)*int64(time.Microsecond)),
+			TID:       resp.Trades[x].TransID,
+			Price:     resp.Trades[x].Price,
+			Amount:    resp.Trades[x].Quantity,
+			Exchange:  c.Name,
+			Type:      strings.ToUpper(resp.Trades[x].Side),
+		}
+	}
+	return exchange.FilterTradeHistory(trades, start, end), nil
+}
+
 // SubmitOrder submits a new order
 func (c *COINUT) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if c.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var err error
 	var APIresponse interface{}
@@ -270,11 +262,17 @@ func (c *COINUT) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (c *COINUT) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if c.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (c *COINUT) CancelOrder(order *exchange.OrderCancellation) error {
+	if c.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -297,20 +295,25 @@ func (c *COINUT) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (c *COINUT) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	// TODO, this is a terrible implementation. Requires DB to improve
-	// Coinut provides no way of retrieving orders without a currency
-	// So we need to retrieve all currencies, then retrieve orders for each currency
-	// Then cancel. Advisable to never use this until DB due to performance
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
-	}
+	if c.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
+	}
+	// Coinut provides no way of retrieving orders without a currency, so the
+	// open orders of each instrument are retrieved then cancelled. With a
+	// database connected only the instruments with open orders stored at the
+	// last sync are checked, which misses orders placed outside the bot since
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	instruments, err := c.GetInstruments()
 	if err != nil {
 		return cancelAllOrdersResponse, err
 	}
 
+	storedPairs := c.getStoredOpenOrderPairs()
 	var allTheOrders []OrderResponse
-	for _, allInstrumentData := range instruments.Instruments {
+	for symbol, allInstrumentData := range instruments.Instruments {
+		if storedPairs != nil && !storedPairs[symbol] {
+			continue
+		}
 		for _, instrumentData := range allInstrumentData {
 
 			openOrders, err := c.GetOpenOrders(instrumentData.InstID)
@@ -338,15 +341,41 @@ func (c *COINUT) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.Cancel
 		}
 
 		for _, order := range resp.Results {
-			if order.Status != "OK" {
-				cancelAllOrdersResponse.OrderStatus[strconv.FormatInt(order.OrderID, 10)] = order.Status
+			orderID := strconv.FormatInt(order.OrderID, 10)
+			if order.Status == "OK" {
+				cancelAllOrdersResponse.Add(orderID, nil)
+				continue
 			}
+			cancelAllOrdersResponse.AddFailure(orderID, order.Status,
+				"order cancellation rejected")
 		}
 	}
 
 	return cancelAllOrdersResponse, nil
 }
 
+// getStoredOpenOrderPairs returns the instrument symbols of the pairs with
+// open orders stored in the database, nil when it isn't connected
+func (c *COINUT) getStoredOpenOrderPairs() map[string]bool {
+	db, err := database.GetConnected()
+	if err != nil {
+		return nil
+	}
+	orders, err := db.QueryOrders(&database.Query{Exchange: c.Name}, true)
+	if err != nil {
+		log.Errorf("%s failed to query stored open orders, checking every instrument. Error: %s",
+			c.Name, err)
+		return nil
+	}
+
+	pairs := make(map[string]bool)
+	for x := range orders {
+		p := currency.NewPairFromStrings(orders[x].Base, orders[x].Quote)
+		pairs[exchange.FormatExchangeCurrency(c.Name, p).String()] = true
+	}
+	return pairs
+}
+
 // GetOrderInfo returns information on a current open order
 func (c *COINUT) GetOrderInfo(orderID string) (exchange.OrderDetail, error) {
 	var orderDetail exchange.OrderDetail
@@ -361,18 +390,27 @@ func (c *COINUT) GetDepositAddress(cryptocurrency currency.Code, accountID strin
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (c *COINUT) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if c.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (c *COINUT) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if c.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (c *COINUT) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if c.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -440,6 +478,7 @@ func (c *COINUT) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([
 		}
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 
@@ -497,6 +536,7 @@ func (c *COINUT) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) ([
 		}
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
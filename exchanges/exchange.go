@@ -13,6 +13,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/decimal"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
@@ -21,14 +23,29 @@ import (
 
 const (
 	warningBase64DecryptSecretKeyFailed = "exchange %s unable to base64 decode secret key.. Disabling Authenticated API support" // nolint:gosec
-	// WarningAuthenticatedRequestWithoutCredentialsSet error message for authenticated request without credentials set
-	WarningAuthenticatedRequestWithoutCredentialsSet = "exchange %s authenticated HTTP request called but not supported due to unset/default API keys"
+	warningAPIPassphraseNotSet          = "exchange %s requires an API passphrase which is not set. Disabling Authenticated API support"
 	// ErrExchangeNotFound is a stand for an error message
 	ErrExchangeNotFound = "exchange not found in dataset"
 	// DefaultHTTPTimeout is the default HTTP/HTTPS Timeout for exchange requests
 	DefaultHTTPTimeout = time.Second * 15
 )
 
+// ErrCredentialsNotSet is returned early by the authenticated requests of an
+// exchange without API credentials set, such as when only gathering public data
+var ErrCredentialsNotSet = errors.New("authenticated API credentials not set")
+
+// ErrReadOnly is returned by the wrapper methods which place, modify or cancel
+// orders or withdraw funds when the exchange is configured as read-only
+var ErrReadOnly = errors.New("exchange is read-only, order placement, cancellation and withdrawals are disabled")
+
+// ErrPostOnlyMarketOrder is returned for post-only market orders, which can
+// never add liquidity
+var ErrPostOnlyMarketOrder = errors.New("post-only orders must be limit orders")
+
+// ErrReduceOnlyUnsupported is returned for reduce-only orders submitted to an
+// exchange without positions to reduce
+var ErrReduceOnlyUnsupported = errors.New("reduce-only orders are not supported")
+
 // FeeType custom type for calculating fees based on method
 type FeeType uint8
 
@@ -69,10 +86,13 @@ const (
 )
 
 // SubmitOrderResponse is what is returned after submitting an order to an
-// exchange
+// exchange. InternalOrderID is set by the bots order manager when it tracks
+// the order
 type SubmitOrderResponse struct {
-	IsOrderPlaced bool
-	OrderID       string
+	IsOrderPlaced   bool
+	OrderID         string
+	InternalOrderID string
+	Sandbox         bool
 }
 
 // FeeBuilder is the type which holds all parameters required to calculate a fee
@@ -110,6 +130,9 @@ type WithdrawRequest struct {
 	Address    string
 	AddressTag string
 	FeeAmount  float64
+	// Network selects the transfer network of currencies supporting several,
+	// exchanges use their default network when empty
+	Network string
 	// FIAT related information
 	BankAccountName   string
 	BankAccountNumber float64
@@ -183,10 +206,20 @@ const (
 	UnknownWithdrawalTypeText string = "UNKNOWN"
 )
 
+// Credential permissions reported by exchanges able to describe the access
+// granted to their API credentials
+const (
+	CredentialPermissionRead     = "READ"
+	CredentialPermissionTrade    = "TRADE"
+	CredentialPermissionWithdraw = "WITHDRAW"
+	CredentialPermissionDeposit  = "DEPOSIT"
+)
+
 // AccountInfo is a Generic type to hold each exchange's holdings in
 // all enabled currencies
 type AccountInfo struct {
 	Exchange string
+	Sandbox  bool
 	Accounts []Account
 }
 
@@ -196,11 +229,17 @@ type Account struct {
 	Currencies []AccountCurrencyInfo
 }
 
-// AccountCurrencyInfo is a sub type to store currency name and value
+// AccountCurrencyInfo is a sub type to store the balance of a currency.
+// TotalValue is always the sum of the Available balance and the balance on
+// Hold in open orders or pending withdrawals, wrappers should build it with
+// the NewAccountCurrencyInfo functions so all three are set. The values stay
+// float64, the constructors derive the third value in decimal so it carries no
+// rounding error
 type AccountCurrencyInfo struct {
 	CurrencyName currency.Code
 	TotalValue   float64
 	Hold         float64
+	Available    float64
 }
 
 // TradeHistory holds exchange history data
@@ -212,25 +251,329 @@ type TradeHistory struct {
 	Exchange    string
 	Type        string
 	Fee         float64
+	FeeAsset    currency.Code
 	Description string
 }
 
-// OrderDetail holds order detail data
+// OrderDetail holds order detail data. AverageExecutedPrice is the average
+// price the executed amount filled at, for exchanges which report it
 type OrderDetail struct {
-	Exchange        string
-	AccountID       string
-	ID              string
-	CurrencyPair    currency.Pair
-	OrderSide       OrderSide
-	OrderType       OrderType
-	OrderDate       time.Time
-	Status          string
-	Price           float64
-	Amount          float64
-	ExecutedAmount  float64
-	RemainingAmount float64
-	Fee             float64
-	Trades          []TradeHistory
+	Exchange             string
+	AccountID            string
+	ID                   string
+	CurrencyPair         currency.Pair
+	OrderSide            OrderSide
+	OrderType            OrderType
+	OrderDate            time.Time
+	Status               string
+	Price                float64
+	Amount               float64
+	ExecutedAmount       float64
+	RemainingAmount      float64
+	AverageExecutedPrice float64
+	Fee                  float64
+	FeeAsset             currency.Code
+	Trades               []TradeHistory
+}
+
+// AggregateTradeFees sets the order fee to the sum of its trade fees when the
+// trades were charged in a single currency
+func (o *OrderDetail) AggregateTradeFees() {
+	fees := o.GetFeesByCurrency()
+	if len(o.Trades) == 0 || len(fees) != 1 {
+		return
+	}
+	for feeAsset, fee := range fees {
+		o.Fee = fee
+		o.FeeAsset = feeAsset
+	}
+}
+
+// GetAverageFillPrice returns the average price and amount an order filled at.
+// The orders trades are used when it has trades, otherwise the executed amount
+// at the average executed price or the order price. Zero is returned for
+// orders without fills
+func (o *OrderDetail) GetAverageFillPrice() (price, amount float64) {
+	if len(o.Trades) > 0 {
+		var cost, filled decimal.Decimal
+		for i := range o.Trades {
+			tradeAmount := decimal.NewFromFloat(o.Trades[i].Amount)
+			cost = cost.Add(tradeAmount.Mul(decimal.NewFromFloat(o.Trades[i].Price)))
+			filled = filled.Add(tradeAmount)
+		}
+		if filled.Sign() <= 0 {
+			return 0, 0
+		}
+		return cost.Float64() / filled.Float64(), filled.Float64()
+	}
+
+	if o.ExecutedAmount <= 0 {
+		return 0, 0
+	}
+	if o.AverageExecutedPrice > 0 {
+		return o.AverageExecutedPrice, o.ExecutedAmount
+	}
+	if o.Price > 0 {
+		return o.Price, o.ExecutedAmount
+	}
+	return 0, 0
+}
+
+// SetFillAmounts derives whichever of an orders executed and remaining amounts
+// the exchange didn't report from its amount, trades or the other. Orders
+// reporting neither are left unchanged as whether they filled is unknown
+func (o *OrderDetail) SetFillAmounts() {
+	if o.ExecutedAmount <= 0 && len(o.Trades) > 0 {
+		_, o.ExecutedAmount = o.GetAverageFillPrice()
+	}
+
+	executed := decimal.NewFromFloat(o.ExecutedAmount)
+	remaining := decimal.NewFromFloat(o.RemainingAmount)
+	switch {
+	case o.Amount <= 0:
+		if o.ExecutedAmount > 0 || o.RemainingAmount > 0 {
+			o.Amount = executed.Add(remaining).Float64()
+		}
+	case o.ExecutedAmount > 0 && o.RemainingAmount <= 0:
+		remaining = decimal.NewFromFloat(o.Amount).Sub(executed)
+		if remaining.Sign() > 0 {
+			o.RemainingAmount = remaining.Float64()
+		}
+	case o.ExecutedAmount <= 0 && o.RemainingAmount > 0:
+		executed = decimal.NewFromFloat(o.Amount).Sub(remaining)
+		if executed.Sign() > 0 {
+			o.ExecutedAmount = executed.Float64()
+		}
+	}
+}
+
+// SetOrderFillAmounts derives the executed and remaining amounts of orders
+// which only reported one of them
+func SetOrderFillAmounts(orders []OrderDetail) {
+	for i := range orders {
+		orders[i].SetFillAmounts()
+	}
+}
+
+// GetFeesByCurrency returns the fees paid on an order summed by fee currency.
+// Trade fees are used when the order has trades, trades without a fee
+// currency are assumed to be charged in the orders fee currency
+func (o *OrderDetail) GetFeesByCurrency() map[currency.Code]float64 {
+	fees := make(map[currency.Code]float64)
+	if len(o.Trades) == 0 {
+		if o.Fee != 0 {
+			fees[o.FeeAsset.Upper()] = o.Fee
+		}
+		return fees
+	}
+
+	for i := range o.Trades {
+		if o.Trades[i].Fee == 0 {
+			continue
+		}
+		feeAsset := o.Trades[i].FeeAsset
+		if feeAsset.IsEmpty() {
+			feeAsset = o.FeeAsset
+		}
+		fees[feeAsset.Upper()] += o.Trades[i].Fee
+	}
+	return fees
+}
+
+// OpenInterest holds the normalised open interest of a derivatives contract.
+// Amount is the number of open contracts and Value their notional value in
+// the contracts settlement currency where the exchange provides it
+type OpenInterest struct {
+	Exchange  string
+	Pair      currency.Pair
+	AssetType string
+	Amount    float64
+	Value     float64
+	Timestamp time.Time
+}
+
+// FuturesContract holds the listing and expiry of a dated futures contract.
+// Pair is the currency pair the contract is traded by and Underlying the pair
+// it settles against, Alias is the exchanges contract month alias if any
+type FuturesContract struct {
+	Exchange   string
+	Symbol     string
+	Pair       currency.Pair
+	Underlying currency.Pair
+	Alias      string
+	Listing    time.Time
+	Expiry     time.Time
+}
+
+// FuturesPosition holds an open futures contract position. Side is the buy
+// side for long positions and the sell side for short positions, Amount is the
+// number of contracts which can be closed
+type FuturesPosition struct {
+	Symbol      string
+	Pair        currency.Pair
+	Side        OrderSide
+	Amount      float64
+	AverageCost float64
+}
+
+// OrderLimits holds the minimum order size of a currency pair. MinAmount is in
+// the base currency and MinNotional in the quote currency, a zero value is not
+// enforced by the exchange. Amounts must be a multiple of AmountStep and prices
+// a multiple of PriceStep when set
+type OrderLimits struct {
+	MinAmount   float64
+	MinNotional float64
+	AmountStep  float64
+	PriceStep   float64
+}
+
+// TransferNetwork holds the deposit and withdrawal details of a network a
+// currency can be transferred on. Confirmations is the number of network
+// confirmations required before a deposit is credited
+type TransferNetwork struct {
+	Network         string
+	Default         bool
+	DepositEnabled  bool
+	WithdrawEnabled bool
+	WithdrawalFee   float64
+	MinWithdrawal   float64
+	Confirmations   int
+}
+
+// DepositMethod holds a method a currency can be deposited with, such as its
+// blockchain network. Fee is charged by the exchange on each deposit
+type DepositMethod struct {
+	Method  string
+	Enabled bool
+	Fee     float64
+}
+
+// DepositAddress holds a deposit address and the tag or memo required by
+// currencies sharing an address between accounts
+type DepositAddress struct {
+	Address string
+	Tag     string
+}
+
+// FeeTier holds the trading fee rates of an account, as fractions of the
+// traded value, and the 30 day trading volume which determines them
+type FeeTier struct {
+	Volume30D      float64
+	VolumeCurrency currency.Code
+	MakerFee       float64
+	TakerFee       float64
+}
+
+// Exchange system statuses
+const (
+	SystemStatusOperational = "operational"
+	SystemStatusMaintenance = "maintenance"
+)
+
+// SystemStatus holds the operational status an exchange publishes and any
+// accompanying announcement
+type SystemStatus struct {
+	Status  string
+	Message string
+}
+
+// Published rate limit types, request limits may be weighted by the exchange
+const (
+	RateLimitRequests = "requests"
+	RateLimitOrders   = "orders"
+)
+
+// RateLimit holds a request ceiling documented by an exchange, Limit requests
+// or orders are permitted per Interval
+type RateLimit struct {
+	Type     string
+	Interval time.Duration
+	Limit    int
+}
+
+// MarketMetadata holds human readable details of a currency pair market for
+// display. Status is as published by the exchange, Listed is zero when the
+// exchange doesn't publish a listing date. PostOnly markets only accept orders
+// which add liquidity, CancelOnly markets only accept cancellations and Halted
+// markets accept no order changes
+type MarketMetadata struct {
+	Exchange   string
+	Pair       currency.Pair
+	TradeURL   string
+	Status     string
+	Listed     time.Time
+	PostOnly   bool
+	CancelOnly bool
+	Halted     bool
+}
+
+// Currency pair trading statuses
+const (
+	TradingStatusTrading    = "trading"
+	TradingStatusHalted     = "halted"
+	TradingStatusCancelOnly = "cancel_only"
+	TradingStatusPostOnly   = "post_only"
+	TradingStatusDelisting  = "delisting"
+)
+
+// TradingStatus holds the trading status a venue reports for a currency pair.
+// Message is the status as published by the exchange
+type TradingStatus struct {
+	Exchange string
+	Pair     currency.Pair
+	Status   string
+	Message  string
+}
+
+// IsHalted returns whether the venue is rejecting new orders for the pair
+func (t *TradingStatus) IsHalted() bool {
+	return t.Status == TradingStatusHalted || t.Status == TradingStatusCancelOnly
+}
+
+// MarketHaltedError is returned when an order is routed to a currency pair
+// the venue isn't accepting new orders for
+type MarketHaltedError struct {
+	Exchange string
+	Pair     currency.Pair
+	Status   string
+}
+
+// Error returns the error message with the reported trading status
+func (e *MarketHaltedError) Error() string {
+	return fmt.Sprintf("%s %s market is %s, not accepting new orders",
+		e.Exchange, e.Pair, e.Status)
+}
+
+// IsMarketHalted returns whether an error is a MarketHaltedError
+func IsMarketHalted(err error) bool {
+	_, ok := err.(*MarketHaltedError)
+	return ok
+}
+
+// WhitelistedAddress holds an address the exchange accepts withdrawals of a
+// currency to. Tag and Network are empty when the exchange doesn't require them
+type WhitelistedAddress struct {
+	Currency currency.Code
+	Address  string
+	Tag      string
+	Network  string
+	Label    string
+}
+
+// Rebate types
+const (
+	RebateTypeMaker    = "maker"
+	RebateTypeReferral = "referral"
+)
+
+// Rebate is a maker rebate or referral kickback credited to an account. ID is
+// unique per exchange so a rebate reported twice is only counted once
+type Rebate struct {
+	ID        string
+	Type      string
+	Currency  currency.Code
+	Amount    float64
+	Timestamp time.Time
 }
 
 // FundHistory holds exchange funding history data
@@ -256,11 +599,15 @@ type Base struct {
 	Name                                       string
 	Enabled                                    bool
 	Verbose                                    bool
+	Sandbox                                    bool
+	ReadOnly                                   bool
 	RESTPollingDelay                           time.Duration
 	AuthenticatedAPISupport                    bool
 	APIWithdrawPermissions                     uint32
 	APIAuthPEMKeySupport                       bool
 	APISecret, APIKey, APIAuthPEMKey, ClientID string
+	APIPassphrase                              string
+	RequiresAPIPassphrase                      bool
 	TakerFee, MakerFee, Fee                    float64
 	BaseCurrencies                             currency.Currencies
 	AvailablePairs                             currency.Pairs
@@ -269,8 +616,11 @@ type Base struct {
 	PairsLastUpdated                           int64
 	SupportsAutoPairUpdating                   bool
 	SupportsRESTTickerBatching                 bool
+	SupportsKlines                             bool
+	SupportsTradeHistory                       bool
 	HTTPTimeout                                time.Duration
 	HTTPUserAgent                              string
+	HTTPHeaders                                map[string]string
 	HTTPDebugging                              bool
 	WebsocketURL                               string
 	APIUrl                                     string
@@ -291,6 +641,8 @@ type IBotExchange interface {
 	SetDefaults()
 	GetName() string
 	IsEnabled() bool
+	IsSandbox() bool
+	IsReadOnly() bool
 	SetEnabled(bool)
 	GetTickerPrice(currency currency.Pair, assetType string) (ticker.Price, error)
 	UpdateTicker(currency currency.Pair, assetType string) (ticker.Price, error)
@@ -303,8 +655,13 @@ type IBotExchange interface {
 	GetAuthenticatedAPISupport() bool
 	SetCurrencies(pairs []currency.Pair, enabledPairs bool) error
 	GetExchangeHistory(p currency.Pair, assetType string) ([]TradeHistory, error)
+	SupportsHistoricCandles() bool
+	GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error)
+	SupportsHistoricTrades() bool
+	GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]TradeHistory, error)
 	SupportsAutoPairUpdates() bool
 	GetLastPairsUpdateTime() int64
+	UpdateTradablePairs(forceUpdate bool) error
 	SupportsRESTTickerBatchUpdates() bool
 	GetFeeByType(feeBuilder *FeeBuilder) (float64, error)
 	GetWithdrawPermissions() uint32
@@ -325,6 +682,149 @@ type IBotExchange interface {
 	GetWebsocket() (*Websocket, error)
 	SubscribeToWebsocketChannels(channels []WebsocketChannelSubscription) error
 	UnsubscribeToWebsocketChannels(channels []WebsocketChannelSubscription) error
+	GetDefaultConfig() (*config.ExchangeConfig, error)
+}
+
+// IDerivativesExchange enforces standard functions for exchanges supporting
+// derivatives contracts
+type IDerivativesExchange interface {
+	GetOpenInterest(p currency.Pair) (OpenInterest, error)
+}
+
+// ITradeHistoryExchange enforces standard functions for exchanges which can
+// page through the complete authenticated trade history of a currency pair.
+// GetAccountTradeHistory returns the next page of trades with an ID greater
+// than fromID in ascending order, an empty page ends the history
+type ITradeHistoryExchange interface {
+	GetAccountTradeHistory(p currency.Pair, fromID int64) ([]TradeHistory, error)
+}
+
+// ICredentialPermissionsExchange enforces standard functions for exchanges
+// which can report the permissions granted to their API credentials
+type ICredentialPermissionsExchange interface {
+	GetCredentialPermissions() ([]string, error)
+}
+
+// IOrderLimitsExchange enforces standard functions for exchanges which can
+// report the minimum order size of their currency pairs
+type IOrderLimitsExchange interface {
+	GetOrderLimits(p currency.Pair) (OrderLimits, error)
+}
+
+// ITransferNetworksExchange enforces standard functions for exchanges which
+// can report the networks a currency can be deposited and withdrawn on
+type ITransferNetworksExchange interface {
+	GetTransferNetworks(c currency.Code) ([]TransferNetwork, error)
+	GetNetworkDepositAddress(c currency.Code, network string) (DepositAddress, error)
+}
+
+// IWithdrawalFeeExchange enforces standard functions for exchanges which can
+// report their current withdrawal fee and deposit methods of a currency, so
+// they can be used in place of static fee tables
+type IWithdrawalFeeExchange interface {
+	GetWithdrawalFee(c currency.Code) (float64, error)
+	GetDepositMethods(c currency.Code) ([]DepositMethod, error)
+}
+
+// IDustConversionExchange enforces standard functions for exchanges which can
+// convert balances below their minimum order size into a single currency.
+// ConvertDust returns the amount of the conversion currency received
+type IDustConversionExchange interface {
+	GetDustConversionCurrency() currency.Code
+	ConvertDust(currencies []currency.Code) (float64, error)
+}
+
+// IFeeTierExchange enforces standard functions for exchanges which can report
+// the fee tier an account qualifies for from its trading volume
+type IFeeTierExchange interface {
+	GetFeeTier(p currency.Pair) (FeeTier, error)
+}
+
+// IPlatformStatusExchange enforces standard functions for exchanges which can
+// report whether their platform is under maintenance and not accepting orders
+type IPlatformStatusExchange interface {
+	IsUnderMaintenance() (bool, error)
+}
+
+// ISystemStatusExchange enforces standard functions for exchanges which
+// publish their operational status
+type ISystemStatusExchange interface {
+	GetSystemStatus() (SystemStatus, error)
+}
+
+// IRateLimitsExchange enforces standard functions for exchanges which publish
+// the request ceilings they enforce
+type IRateLimitsExchange interface {
+	GetRateLimits() ([]RateLimit, error)
+}
+
+// IClientOrderIDExchange enforces standard functions for exchanges which can
+// cancel an order by the client order ID it was submitted with
+type IClientOrderIDExchange interface {
+	CancelOrderByClientID(clientID string, p currency.Pair) error
+}
+
+// IOrderFlagsExchange enforces standard functions for exchanges which accept
+// post-only or reduce-only orders natively. Flags an exchange doesn't support
+// are rejected
+type IOrderFlagsExchange interface {
+	SubmitFlaggedOrder(p currency.Pair, side OrderSide, orderType OrderType, amount, price float64, clientID string, flags OrderFlags) (SubmitOrderResponse, error)
+}
+
+// IQuoteAmountOrderExchange enforces standard functions for exchanges which
+// accept market orders sized by the quote currency amount to spend on a buy or
+// receive from a sell
+type IQuoteAmountOrderExchange interface {
+	SubmitQuoteAmountOrder(p currency.Pair, side OrderSide, quoteAmount float64, clientID string) (SubmitOrderResponse, error)
+}
+
+// IMarketMetadataExchange enforces standard functions for exchanges which can
+// link to the web trading page of a currency pair and report its listing
+// details from their instrument endpoints
+type IMarketMetadataExchange interface {
+	GetCurrencyTradeURL(p currency.Pair) string
+	GetMarketMetadata(p currency.Pair) (MarketMetadata, error)
+}
+
+// ITradingStatusExchange enforces standard functions for exchanges which
+// report the trading status of their currency pairs, such as halted,
+// cancel-only, post-only or delisting books
+type ITradingStatusExchange interface {
+	GetTradingStatus(p currency.Pair) (TradingStatus, error)
+}
+
+// IWithdrawalWhitelistExchange enforces standard functions for exchanges which
+// expose the withdrawal address whitelist configured on the account. An empty
+// whitelist means withdrawals aren't restricted to whitelisted addresses
+type IWithdrawalWhitelistExchange interface {
+	GetWithdrawalWhitelist() ([]WhitelistedAddress, error)
+}
+
+// IFuturesContractsExchange enforces standard functions for exchanges which
+// list dated futures contracts. Perpetual contracts aren't returned
+type IFuturesContractsExchange interface {
+	GetFuturesContracts() ([]FuturesContract, error)
+}
+
+// IFuturesPositionsExchange enforces standard functions for exchanges which
+// report open futures positions and close them by the side of the position
+type IFuturesPositionsExchange interface {
+	GetFuturesPositions() ([]FuturesPosition, error)
+	CloseFuturesPosition(p currency.Pair, positionSide OrderSide, orderType OrderType, amount, price float64, clientID string) (SubmitOrderResponse, error)
+}
+
+// IRebateExchange enforces standard functions for exchanges which report the
+// maker rebates and referral kickbacks credited to an account in their fee
+// endpoints or ledgers. Maker rebates already reported as negative trade fees
+// aren't returned so they aren't counted twice
+type IRebateExchange interface {
+	GetRebates(since time.Time) ([]Rebate, error)
+}
+
+// IWebsocketReplayExchange enforces standard functions for exchanges which can
+// feed a captured raw websocket message back through their parsing code
+type IWebsocketReplayExchange interface {
+	ReplayWebsocketMessage(raw []byte) error
 }
 
 // SupportsRESTTickerBatchUpdates returns whether or not the
@@ -333,6 +833,19 @@ func (e *Base) SupportsRESTTickerBatchUpdates() bool {
 	return e.SupportsRESTTickerBatching
 }
 
+// SupportsHistoricCandles returns whether or not the exchange returns historic
+// candles from a REST kline endpoint
+func (e *Base) SupportsHistoricCandles() bool {
+	return e.SupportsKlines
+}
+
+// SupportsHistoricTrades returns whether or not the exchange returns public
+// trades from any time range, exchanges without it only return their most
+// recent trades from GetHistoricTrades
+func (e *Base) SupportsHistoricTrades() bool {
+	return e.SupportsTradeHistory
+}
+
 // SetHTTPClientTimeout sets the timeout value for the exchanges
 // HTTP Client
 func (e *Base) SetHTTPClientTimeout(t time.Duration) {
@@ -384,6 +897,23 @@ func (e *Base) GetHTTPClientUserAgent() string {
 	return e.HTTPUserAgent
 }
 
+// SetHTTPHeaders sets custom HTTP headers sent with every exchange request
+func (e *Base) SetHTTPHeaders(headers map[string]string) {
+	if e.Requester == nil {
+		e.Requester = request.New(e.Name,
+			request.NewRateLimit(time.Second, 0),
+			request.NewRateLimit(time.Second, 0),
+			new(http.Client))
+	}
+	e.Requester.Headers = headers
+	e.HTTPHeaders = headers
+}
+
+// GetHTTPHeaders gets the exchanges custom HTTP headers
+func (e *Base) GetHTTPHeaders() map[string]string {
+	return e.HTTPHeaders
+}
+
 // SetClientProxyAddress sets a proxy address for REST and websocket requests
 func (e *Base) SetClientProxyAddress(addr string) error {
 	if addr != "" {
@@ -691,6 +1221,21 @@ func (e *Base) SetAPIKeys(apiKey, apiSecret, clientID string, b64Decode bool) {
 	}
 }
 
+// SetAPIPassphrase sets the API passphrase used by exchanges which sign
+// authenticated requests with one, disabling authenticated API support when
+// the exchange requires a passphrase and none is set
+func (e *Base) SetAPIPassphrase(passphrase string) {
+	if !e.AuthenticatedAPISupport {
+		return
+	}
+
+	e.APIPassphrase = passphrase
+	if e.RequiresAPIPassphrase && passphrase == "" {
+		e.AuthenticatedAPISupport = false
+		log.Warnf(warningAPIPassphraseNotSet, e.Name)
+	}
+}
+
 // SetCurrencies sets the exchange currency pairs for either enabledPairs or
 // availablePairs
 func (e *Base) SetCurrencies(pairs []currency.Pair, enabledPairs bool) error {
@@ -738,6 +1283,22 @@ func (e *Base) UpdateCurrencies(exchangeProducts currency.Pairs, enabled, force
 		products = append(products, exchangeProducts[x])
 	}
 
+	cfg := config.GetConfig()
+	exch, err := cfg.GetExchangeConfig(e.Name)
+	if err != nil {
+		return err
+	}
+
+	products, excluded := exch.FilterPairs(products)
+	if len(excluded) > 0 {
+		log.Debugf("%s Ignoring %d pairs excluded by the pair whitelist or blacklist.\n",
+			e.Name, len(excluded))
+	}
+	if len(products) == 0 {
+		return fmt.Errorf("%s UpdateCurrencies error - all exchangeProducts are excluded by the pair whitelist or blacklist",
+			e.Name)
+	}
+
 	var newPairs, removedPairs currency.Pairs
 	var updateType string
 
@@ -750,12 +1311,6 @@ func (e *Base) UpdateCurrencies(exchangeProducts currency.Pairs, enabled, force
 	}
 
 	if force || len(newPairs) > 0 || len(removedPairs) > 0 {
-		cfg := config.GetConfig()
-		exch, err := cfg.GetExchangeConfig(e.Name)
-		if err != nil {
-			return err
-		}
-
 		if force {
 			log.Debugf("%s forced update of %s pairs.", e.Name, updateType)
 		} else {
@@ -808,9 +1363,55 @@ type Format struct {
 	OrderSide    map[string]string
 }
 
-// CancelAllOrdersResponse returns the status from attempting to cancel all orders on an exchagne
+// CancelOrderResult holds the outcome of cancelling an order. Code is the
+// exchange error code of a failed cancellation when the exchange returns one
+// and Reason its error or status message
+type CancelOrderResult struct {
+	OrderID string `json:"orderID"`
+	Success bool   `json:"success"`
+	Code    string `json:"code,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// CancelAllOrdersResponse holds the result of cancelling each order when
+// cancelling all orders on an exchange. Exchanges cancelling every order in a
+// single request without reporting them return no results
 type CancelAllOrdersResponse struct {
-	OrderStatus map[string]string
+	Orders []CancelOrderResult `json:"orders"`
+}
+
+// Add records the result of cancelling an order from the error returned by
+// the cancellation, keeping the exchange error code of request errors
+func (c *CancelAllOrdersResponse) Add(orderID string, err error) {
+	if err == nil {
+		c.Orders = append(c.Orders, CancelOrderResult{OrderID: orderID, Success: true})
+		return
+	}
+	var code string
+	if reqErr, ok := err.(*request.Error); ok {
+		code = reqErr.Code
+	}
+	c.AddFailure(orderID, code, err.Error())
+}
+
+// AddFailure records an order the exchange failed to cancel
+func (c *CancelAllOrdersResponse) AddFailure(orderID, code, reason string) {
+	c.Orders = append(c.Orders, CancelOrderResult{
+		OrderID: orderID,
+		Code:    code,
+		Reason:  reason,
+	})
+}
+
+// Failed returns the orders which failed to cancel, so only they are retried
+func (c *CancelAllOrdersResponse) Failed() []CancelOrderResult {
+	var failed []CancelOrderResult
+	for x := range c.Orders {
+		if !c.Orders[x].Success {
+			failed = append(failed, c.Orders[x])
+		}
+	}
+	return failed
 }
 
 // Formatting contain a range of exchanges formatting
@@ -835,6 +1436,19 @@ func (o OrderType) ToString() string {
 	return fmt.Sprintf("%v", o)
 }
 
+// OrderFlags holds the execution instructions of an order. PostOnly orders are
+// only accepted when they add liquidity to the orderbook and ReduceOnly orders
+// when they only reduce an open position
+type OrderFlags struct {
+	PostOnly   bool `json:"postOnly,omitempty"`
+	ReduceOnly bool `json:"reduceOnly,omitempty"`
+}
+
+// IsSet returns whether any order flag is set
+func (o OrderFlags) IsSet() bool {
+	return o.PostOnly || o.ReduceOnly
+}
+
 // OrderSide enforces a standard for OrderSides across the code base
 type OrderSide string
 
@@ -858,14 +1472,79 @@ func (e *Base) SetAPIURL(ec *config.ExchangeConfig) error {
 		return errors.New("empty config API URLs")
 	}
 	if ec.APIURL != config.APIURLNonDefaultMessage {
+		err := config.ValidateURL(ec.APIURL, "http", "https")
+		if err != nil {
+			return err
+		}
 		e.APIUrl = ec.APIURL
 	}
 	if ec.APIURLSecondary != config.APIURLNonDefaultMessage {
+		err := config.ValidateURL(ec.APIURLSecondary, "http", "https")
+		if err != nil {
+			return err
+		}
 		e.APIUrlSecondary = ec.APIURLSecondary
 	}
 	return nil
 }
 
+// SetSandbox switches the exchange to its documented sandbox/testnet API URL
+// when enabled in config. An API URL overridden in config takes precedence
+func (e *Base) SetSandbox(ec *config.ExchangeConfig, sandboxAPIURL string) {
+	if !ec.UseSandbox {
+		return
+	}
+	e.Sandbox = true
+	if ec.APIURL == config.APIURLNonDefaultMessage {
+		e.APIUrl = sandboxAPIURL
+	}
+}
+
+// IsSandbox returns whether the exchange is using its sandbox/testnet
+// endpoints
+func (e *Base) IsSandbox() bool {
+	return e.Sandbox
+}
+
+// ApplyRateLimits lowers the authenticated and unauthenticated request rate
+// limiters to the strictest published request limit, limits which are less
+// strict than the current rate limiters are ignored. It returns whether a rate
+// limiter was changed
+func (e *Base) ApplyRateLimits(limits []RateLimit) bool {
+	if e.Requester == nil {
+		return false
+	}
+
+	var changed bool
+	for x := range limits {
+		if limits[x].Type != RateLimitRequests || limits[x].Limit <= 0 ||
+			limits[x].Interval <= 0 {
+			continue
+		}
+		for _, auth := range []bool{true, false} {
+			current := e.GetRateLimit(auth)
+			if current == nil {
+				continue
+			}
+			rate, duration := current.GetRate(), current.GetDuration()
+			if rate > 0 && duration > 0 &&
+				float64(limits[x].Limit)/limits[x].Interval.Seconds() >=
+					float64(rate)/duration.Seconds() {
+				continue
+			}
+			e.SetRateLimit(auth, limits[x].Interval, limits[x].Limit)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// IsReadOnly returns whether the exchange only permits market data and
+// account queries, blocking order placement, cancellation and withdrawals
+func (e *Base) IsReadOnly() bool {
+	return e.ReadOnly
+}
+
 // GetAPIURL returns the set API URL
 func (e *Base) GetAPIURL() string {
 	return e.APIUrl
@@ -954,6 +1633,47 @@ func (e *Base) FormatWithdrawPermissions() string {
 	return NoAPIWithdrawalMethodsText
 }
 
+// GetDefaultConfig returns an exchange config populated with the defaults set
+// by SetDefaults. Credentials and URL overrides are left as their unset
+// placeholders
+func (e *Base) GetDefaultConfig() (*config.ExchangeConfig, error) {
+	if e.Name == "" {
+		return nil, errors.New("exchange defaults not set")
+	}
+
+	httpTimeout := e.HTTPTimeout
+	if httpTimeout <= 0 {
+		httpTimeout = DefaultHTTPTimeout
+	}
+
+	requestFormat := e.RequestCurrencyPairFormat
+	configFormat := e.ConfigCurrencyPairFormat
+	return &config.ExchangeConfig{
+		Name:                      e.Name,
+		Enabled:                   e.Enabled,
+		Verbose:                   e.Verbose,
+		RESTPollingDelay:          e.RESTPollingDelay,
+		HTTPTimeout:               httpTimeout,
+		HTTPUserAgent:             e.HTTPUserAgent,
+		AuthenticatedAPISupport:   e.AuthenticatedAPISupport,
+		APIKey:                    config.DefaultUnsetAPIKey,
+		APISecret:                 config.DefaultUnsetAPISecret,
+		APIAuthPEMKeySupport:      e.APIAuthPEMKeySupport,
+		APIURL:                    config.APIURLNonDefaultMessage,
+		APIURLSecondary:           config.APIURLNonDefaultMessage,
+		WebsocketURL:              config.WebsocketURLNonDefaultMessage,
+		AvailablePairs:            e.AvailablePairs,
+		EnabledPairs:              e.EnabledPairs,
+		BaseCurrencies:            e.BaseCurrencies,
+		AssetTypes:                common.JoinStrings(e.AssetTypes, ","),
+		SupportsAutoPairUpdates:   e.SupportsAutoPairUpdating,
+		PairsLastUpdated:          e.PairsLastUpdated,
+		ConfigCurrencyPairFormat:  &configFormat,
+		RequestCurrencyPairFormat: &requestFormat,
+		BankAccounts:              []config.BankAccount{{}},
+	}, nil
+}
+
 // GetOrdersRequest used for GetOrderHistory and GetOpenOrders wrapper functions
 type GetOrdersRequest struct {
 	OrderType  OrderType
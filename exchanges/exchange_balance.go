@@ -0,0 +1,121 @@
+package exchange
+
+import (
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/decimal"
+)
+
+// Balance is the balance of a currency across an exchanges accounts, with the
+// balance held in each account
+type Balance struct {
+	Currency  currency.Code
+	Total     float64
+	Hold      float64
+	Available float64
+	Wallets   []WalletBalance
+}
+
+// WalletBalance is the balance of a currency in a single account, such as an
+// exchange, margin or funding wallet
+type WalletBalance struct {
+	Account   string
+	Total     float64
+	Hold      float64
+	Available float64
+}
+
+// NewAccountCurrencyInfo returns the balance of a currency from the amount
+// available and the amount on hold, for exchanges which report both
+func NewAccountCurrencyInfo(c currency.Code, available, hold float64) AccountCurrencyInfo {
+	a := decimal.NewFromFloat(available)
+	h := decimal.NewFromFloat(hold)
+	return AccountCurrencyInfo{
+		CurrencyName: c,
+		TotalValue:   a.Add(h).Float64(),
+		Hold:         hold,
+		Available:    available,
+	}
+}
+
+// NewAccountCurrencyInfoFromTotal returns the balance of a currency from the
+// total and the amount on hold, for exchanges which report both
+func NewAccountCurrencyInfoFromTotal(c currency.Code, total, hold float64) AccountCurrencyInfo {
+	t := decimal.NewFromFloat(total)
+	h := decimal.NewFromFloat(hold)
+	return AccountCurrencyInfo{
+		CurrencyName: c,
+		TotalValue:   total,
+		Hold:         hold,
+		Available:    t.Sub(h).Float64(),
+	}
+}
+
+// NewAccountCurrencyInfoFromTotalAvailable returns the balance of a currency
+// from the total and the amount available, for exchanges which report both
+func NewAccountCurrencyInfoFromTotalAvailable(c currency.Code, total, available float64) AccountCurrencyInfo {
+	t := decimal.NewFromFloat(total)
+	a := decimal.NewFromFloat(available)
+	return AccountCurrencyInfo{
+		CurrencyName: c,
+		TotalValue:   total,
+		Hold:         t.Sub(a).Float64(),
+		Available:    available,
+	}
+}
+
+// Add returns the sum of two balances of the same currency
+func (a AccountCurrencyInfo) Add(o AccountCurrencyInfo) AccountCurrencyInfo {
+	return AccountCurrencyInfo{
+		CurrencyName: a.CurrencyName,
+		TotalValue: decimal.NewFromFloat(a.TotalValue).
+			Add(decimal.NewFromFloat(o.TotalValue)).Float64(),
+		Hold: decimal.NewFromFloat(a.Hold).
+			Add(decimal.NewFromFloat(o.Hold)).Float64(),
+		Available: decimal.NewFromFloat(a.Available).
+			Add(decimal.NewFromFloat(o.Available)).Float64(),
+	}
+}
+
+// GetBalance returns the balance of a currency summed across the accounts,
+// with the balance held in each account which holds the currency
+func (a *AccountInfo) GetBalance(c currency.Code) Balance {
+	resp := Balance{Currency: c}
+	sum := AccountCurrencyInfo{CurrencyName: c}
+	for x := range a.Accounts {
+		for y := range a.Accounts[x].Currencies {
+			info := a.Accounts[x].Currencies[y]
+			if !info.CurrencyName.Match(c) {
+				continue
+			}
+			sum = sum.Add(info)
+			resp.Wallets = append(resp.Wallets, WalletBalance{
+				Account:   a.Accounts[x].ID,
+				Total:     info.TotalValue,
+				Hold:      info.Hold,
+				Available: info.Available,
+			})
+		}
+	}
+	resp.Total = sum.TotalValue
+	resp.Hold = sum.Hold
+	resp.Available = sum.Available
+	return resp
+}
+
+// GetBalances returns the balance of each currency held across the accounts,
+// in the order they were first reported
+func (a *AccountInfo) GetBalances() []Balance {
+	var resp []Balance
+	var seen currency.Currencies
+	for x := range a.Accounts {
+		for y := range a.Accounts[x].Currencies {
+			c := a.Accounts[x].Currencies[y].CurrencyName
+			if seen.Contains(c) {
+				continue
+			}
+			seen = append(seen, c)
+			resp = append(resp, a.GetBalance(c))
+		}
+	}
+	return resp
+}
@@ -0,0 +1,61 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+func TestNewAccountCurrencyInfo(t *testing.T) {
+	tests := []AccountCurrencyInfo{
+		NewAccountCurrencyInfo(currency.BTC, 0.1, 0.2),
+		NewAccountCurrencyInfoFromTotal(currency.BTC, 0.3, 0.2),
+		NewAccountCurrencyInfoFromTotalAvailable(currency.BTC, 0.3, 0.1),
+	}
+	for x := range tests {
+		if tests[x].TotalValue != 0.3 || tests[x].Hold != 0.2 ||
+			tests[x].Available != 0.1 {
+			t.Errorf("test failed - test %d expected 0.3 total, 0.2 hold and 0.1 available, got %+v",
+				x, tests[x])
+		}
+	}
+}
+
+func TestGetBalance(t *testing.T) {
+	info := AccountInfo{
+		Accounts: []Account{
+			{
+				ID: "exchange",
+				Currencies: []AccountCurrencyInfo{
+					NewAccountCurrencyInfo(currency.BTC, 0.1, 0.2),
+					NewAccountCurrencyInfo(currency.USD, 100, 0),
+				},
+			},
+			{
+				ID: "margin",
+				Currencies: []AccountCurrencyInfo{
+					NewAccountCurrencyInfoFromTotal(currency.BTC, 1, 0.5),
+				},
+			},
+		},
+	}
+
+	bal := info.GetBalance(currency.BTC)
+	if bal.Total != 1.3 || bal.Hold != 0.7 || bal.Available != 0.6 {
+		t.Errorf("test failed - unexpected BTC balance %+v", bal)
+	}
+	if len(bal.Wallets) != 2 || bal.Wallets[1].Account != "margin" ||
+		bal.Wallets[1].Available != 0.5 {
+		t.Errorf("test failed - unexpected BTC wallets %+v", bal.Wallets)
+	}
+
+	if bal = info.GetBalance(currency.LTC); bal.Total != 0 || len(bal.Wallets) != 0 {
+		t.Errorf("test failed - expected no LTC balance, got %+v", bal)
+	}
+
+	balances := info.GetBalances()
+	if len(balances) != 2 || !balances[0].Currency.Match(currency.BTC) ||
+		!balances[1].Currency.Match(currency.USD) {
+		t.Errorf("test failed - unexpected balances %+v", balances)
+	}
+}
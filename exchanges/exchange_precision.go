@@ -0,0 +1,32 @@
+package exchange
+
+import (
+	"github.com/thrasher-/gocryptotrader/decimal"
+)
+
+// OrderDecimalPlaces is the number of decimal places order amounts and prices
+// are rounded to, removing float rounding errors such as 0.30000000000000004
+// which exchanges reject as precision violations
+const OrderDecimalPlaces = 12
+
+// CalculateTradingFee returns the fee of trading an amount at a price for a
+// fee rate, such as 0.001 for 0.1%. It is calculated in decimal so the fee is
+// not offset by float rounding errors, including those of a rate converted
+// from a percentage. Wrappers return the result from GetFeeByType as float64
+func CalculateTradingFee(rate, price, amount float64) float64 {
+	return decimal.NewFromFloat(rate).Round(OrderDecimalPlaces).
+		Mul(decimal.NewFromFloat(price)).
+		Mul(decimal.NewFromFloat(amount)).
+		Float64()
+}
+
+// Normalise removes float rounding errors from an order amount and price,
+// then rounds the amount down to a multiple of AmountStep and the price to the
+// nearest multiple of PriceStep
+func (o OrderLimits) Normalise(amount, price float64) (normalisedAmount, normalisedPrice float64) {
+	a := decimal.NewFromFloat(amount).Round(OrderDecimalPlaces).
+		FloorToStep(decimal.NewFromFloat(o.AmountStep))
+	p := decimal.NewFromFloat(price).Round(OrderDecimalPlaces).
+		RoundToStep(decimal.NewFromFloat(o.PriceStep))
+	return a.Float64(), p.Float64()
+}
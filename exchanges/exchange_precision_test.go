@@ -0,0 +1,33 @@
+package exchange
+
+import "testing"
+
+func TestCalculateTradingFee(t *testing.T) {
+	if fee := CalculateTradingFee(0.001, 0.3, 3); fee != 0.0009 {
+		t.Errorf("test failed - expected fee 0.0009, got %v", fee)
+	}
+	if fee := CalculateTradingFee(0.1/100, 7, 3); fee != 0.021 {
+		t.Errorf("test failed - expected fee 0.021, got %v", fee)
+	}
+	if fee := CalculateTradingFee(-0.0003, 1000, 0.1); fee != -0.03 {
+		t.Errorf("test failed - expected rebate -0.03, got %v", fee)
+	}
+}
+
+func TestOrderLimitsNormalise(t *testing.T) {
+	amount, price := OrderLimits{}.Normalise(0.1+0.2, 0.3-0.1)
+	if amount != 0.3 || price != 0.2 {
+		t.Errorf("test failed - expected 0.3 @ 0.2, got %v @ %v", amount, price)
+	}
+
+	limits := OrderLimits{AmountStep: 0.001, PriceStep: 0.05}
+	amount, price = limits.Normalise(1.23456, 8000.074)
+	if amount != 1.234 || price != 8000.05 {
+		t.Errorf("test failed - expected 1.234 @ 8000.05, got %v @ %v", amount, price)
+	}
+
+	// Market orders without a price are unchanged
+	if _, price = limits.Normalise(1, 0); price != 0 {
+		t.Errorf("test failed - expected no price, got %v", price)
+	}
+}
@@ -0,0 +1,121 @@
+package exchange
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// Exchange capabilities declared when an exchange registers
+const (
+	SpotCapability uint32 = (1 << iota)
+	DerivativesCapability
+	WebsocketCapability
+	SandboxCapability
+
+	SpotCapabilityText        = "SPOT TRADING"
+	DerivativesCapabilityText = "DERIVATIVES"
+	WebsocketCapabilityText   = "WEBSOCKET"
+	SandboxCapabilityText     = "SANDBOX"
+	NoCapabilitiesText        = "NO CAPABILITIES"
+	UnknownCapabilityText     = "UNKNOWN"
+)
+
+// Creator returns a new exchange ready to have its defaults set
+type Creator func() IBotExchange
+
+// Registration holds the name, constructor and capabilities an exchange
+// package registers in its init function
+type Registration struct {
+	Name         string
+	Creator      Creator
+	Capabilities uint32
+}
+
+// registry holds the registered exchanges by lower case name
+var registry = struct {
+	exchanges map[string]Registration
+	m         sync.RWMutex
+}{exchanges: make(map[string]Registration)}
+
+// Register makes an exchange available to the engine by name, replacing an
+// existing registration of the same name. Exchange packages register
+// themselves in their init function, so importing a package is enough to
+// support its exchange. It panics when the name or creator is missing
+func Register(r Registration) {
+	if r.Name == "" {
+		panic("exchange registration name not set")
+	}
+	if r.Creator == nil {
+		panic(fmt.Sprintf("exchange %s registration creator not set", r.Name))
+	}
+
+	registry.m.Lock()
+	registry.exchanges[common.StringToLower(r.Name)] = r
+	registry.m.Unlock()
+}
+
+// Deregister removes the registration of an exchange
+func Deregister(name string) {
+	registry.m.Lock()
+	delete(registry.exchanges, common.StringToLower(name))
+	registry.m.Unlock()
+}
+
+// GetRegistration returns the registration of an exchange by name
+func GetRegistration(name string) (Registration, bool) {
+	registry.m.RLock()
+	defer registry.m.RUnlock()
+	r, ok := registry.exchanges[common.StringToLower(name)]
+	return r, ok
+}
+
+// GetRegistrations returns the registered exchanges sorted by name
+func GetRegistrations() []Registration {
+	registry.m.RLock()
+	defer registry.m.RUnlock()
+	var resp []Registration
+	for _, r := range registry.exchanges {
+		resp = append(resp, r)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return common.StringToLower(resp[i].Name) <
+			common.StringToLower(resp[j].Name)
+	})
+	return resp
+}
+
+// SupportsCapability returns whether the exchange registered a capability
+func (r *Registration) SupportsCapability(capability uint32) bool {
+	return r.Capabilities&capability == capability
+}
+
+// FormatCapabilities returns the registered capabilities as a string
+func (r *Registration) FormatCapabilities() string {
+	var capabilities []string
+	for i := 0; i < 32; i++ {
+		var check uint32 = 1 << uint32(i)
+		if r.Capabilities&check != 0 {
+			switch check {
+			case SpotCapability:
+				capabilities = append(capabilities, SpotCapabilityText)
+			case DerivativesCapability:
+				capabilities = append(capabilities, DerivativesCapabilityText)
+			case WebsocketCapability:
+				capabilities = append(capabilities, WebsocketCapabilityText)
+			case SandboxCapability:
+				capabilities = append(capabilities, SandboxCapabilityText)
+			default:
+				capabilities = append(capabilities,
+					fmt.Sprintf("%s[1<<%v]", UnknownCapabilityText, i))
+			}
+		}
+	}
+	if len(capabilities) > 0 {
+		return strings.Join(capabilities, " & ")
+	}
+	return NoCapabilitiesText
+}
@@ -0,0 +1,79 @@
+package exchange
+
+import "testing"
+
+type registryTestExchange struct {
+	IBotExchange
+}
+
+func TestRegister(t *testing.T) {
+	Register(Registration{
+		Name: "RegistryTest",
+		Creator: func() IBotExchange {
+			return &registryTestExchange{}
+		},
+		Capabilities: SpotCapability | WebsocketCapability,
+	})
+	defer Deregister("RegistryTest")
+
+	r, ok := GetRegistration("registrytest")
+	if !ok {
+		t.Fatal("Test Failed - registration not found")
+	}
+	if _, ok := r.Creator().(*registryTestExchange); !ok {
+		t.Error("Test Failed - unexpected exchange created")
+	}
+	if !r.SupportsCapability(WebsocketCapability) ||
+		r.SupportsCapability(DerivativesCapability) {
+		t.Error("Test Failed - unexpected capabilities")
+	}
+
+	var found bool
+	for _, r := range GetRegistrations() {
+		if r.Name == "RegistryTest" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Test Failed - registration not listed")
+	}
+
+	Deregister("REGISTRYTEST")
+	if _, ok := GetRegistration("RegistryTest"); ok {
+		t.Error("Test Failed - registration not removed")
+	}
+}
+
+func TestRegisterInvalid(t *testing.T) {
+	for _, r := range []Registration{
+		{Creator: func() IBotExchange { return nil }},
+		{Name: "RegistryTestInvalid"},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Test Failed - expected %+v to panic", r)
+				}
+			}()
+			Register(r)
+		}()
+	}
+}
+
+func TestFormatCapabilities(t *testing.T) {
+	r := Registration{
+		Capabilities: SpotCapability | DerivativesCapability |
+			WebsocketCapability | SandboxCapability | 1<<10,
+	}
+	expected := "SPOT TRADING & DERIVATIVES & WEBSOCKET & SANDBOX & UNKNOWN[1<<10]"
+	if r.FormatCapabilities() != expected {
+		t.Errorf("Test Failed - expected %s, got %s", expected,
+			r.FormatCapabilities())
+	}
+
+	r.Capabilities = 0
+	if r.FormatCapabilities() != NoCapabilitiesText {
+		t.Errorf("Test Failed - expected %s, got %s", NoCapabilitiesText,
+			r.FormatCapabilities())
+	}
+}
@@ -1,6 +1,7 @@
 package exchange
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 	"testing"
@@ -689,6 +690,34 @@ func TestSetAPIKeys(t *testing.T) {
 	SetAPIKeys.SetAPIKeys("RocketMan", "Digereedoo", "007", true)
 }
 
+func TestSetAPIPassphrase(t *testing.T) {
+	b := Base{Name: "TESTNAME", RequiresAPIPassphrase: true}
+	b.SetAPIPassphrase("passphrase")
+	if b.APIPassphrase != "" {
+		t.Error("Test Failed - SetAPIPassphrase() set value without authenticated API support enabled")
+	}
+
+	b.AuthenticatedAPISupport = true
+	b.SetAPIPassphrase("passphrase")
+	if b.APIPassphrase != "passphrase" || !b.AuthenticatedAPISupport {
+		t.Error("Test Failed - SetAPIPassphrase() did not set correct value")
+	}
+
+	b.SetAPIPassphrase("")
+	if b.AuthenticatedAPISupport {
+		t.Error("Test Failed - SetAPIPassphrase() should disable authenticated API support without a required passphrase")
+	}
+}
+
+func TestSetHTTPHeaders(t *testing.T) {
+	b := Base{Name: "TESTNAME"}
+	headers := map[string]string{"X-Custom": "value"}
+	b.SetHTTPHeaders(headers)
+	if b.GetHTTPHeaders()["X-Custom"] != "value" || b.Requester.Headers["X-Custom"] != "value" {
+		t.Error("Test Failed - SetHTTPHeaders() did not set the requester headers")
+	}
+}
+
 func TestSetCurrencies(t *testing.T) {
 	cfg := config.GetConfig()
 	err := cfg.LoadConfig(config.ConfigTestFile)
@@ -811,6 +840,40 @@ func TestUpdateCurrencies(t *testing.T) {
 	}
 }
 
+func TestUpdateCurrenciesPairFilters(t *testing.T) {
+	cfg := config.GetConfig()
+	err := cfg.LoadConfig(config.ConfigTestFile)
+	if err != nil {
+		t.Fatal("Test failed. TestUpdateCurrenciesPairFilters failed to load config")
+	}
+
+	exchCfg, err := cfg.GetExchangeConfig(defaultTestExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchCfg.PairBlacklist = currency.NewPairsFromStrings([]string{"DOGE_USD"})
+	err = cfg.UpdateExchangeConfig(&exchCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	UAC := Base{Name: defaultTestExchange}
+	err = UAC.UpdateCurrencies(currency.NewPairsFromStrings([]string{"BTC_USD", "DOGE_USD"}), false, false)
+	if err != nil {
+		t.Fatalf("Test Failed - Exchange UpdateCurrencies() error: %s", err)
+	}
+	if len(UAC.AvailablePairs) != 1 ||
+		UAC.AvailablePairs.Contains(currency.NewPairFromString("DOGE_USD"), true) {
+		t.Errorf("Test Failed - Expected blacklisted pair to be ignored, got %s",
+			UAC.AvailablePairs)
+	}
+
+	err = UAC.UpdateCurrencies(currency.NewPairsFromStrings([]string{"DOGE_USD"}), false, false)
+	if err == nil {
+		t.Error("Test Failed - Expected error when all pairs are excluded")
+	}
+}
+
 func TestSetAPIURL(t *testing.T) {
 	testURL := "https://api.something.com"
 	testURLSecondary := "https://api.somethingelse.com"
@@ -854,6 +917,48 @@ func TestSetAPIURL(t *testing.T) {
 	}
 }
 
+func TestSetAPIURLInvalid(t *testing.T) {
+	tester := Base{Name: "test"}
+	test := config.ExchangeConfig{
+		APIURL:          "ftp://api.something.com",
+		APIURLSecondary: config.APIURLNonDefaultMessage,
+	}
+
+	err := tester.SetAPIURL(&test)
+	if err == nil {
+		t.Error("test failed - invalid URL scheme accepted")
+	}
+	if tester.GetAPIURL() != "" {
+		t.Error("test failed - invalid URL set")
+	}
+}
+
+func TestSetSandbox(t *testing.T) {
+	testURL := "https://api.something.com"
+	testSandboxURL := "https://api.sandbox.something.com"
+
+	tester := Base{Name: "test", APIUrl: testURL}
+	test := config.ExchangeConfig{APIURL: config.APIURLNonDefaultMessage}
+
+	tester.SetSandbox(&test, testSandboxURL)
+	if tester.IsSandbox() || tester.GetAPIURL() != testURL {
+		t.Error("test failed - sandbox set when disabled in config")
+	}
+
+	test.UseSandbox = true
+	tester.SetSandbox(&test, testSandboxURL)
+	if !tester.IsSandbox() || tester.GetAPIURL() != testSandboxURL {
+		t.Error("test failed - sandbox URL not set")
+	}
+
+	test.APIURL = testURL
+	tester.APIUrl = testURL
+	tester.SetSandbox(&test, testSandboxURL)
+	if !tester.IsSandbox() || tester.GetAPIURL() != testURL {
+		t.Error("test failed - sandbox URL should not replace an overridden API URL")
+	}
+}
+
 func BenchmarkSetAPIURL(b *testing.B) {
 	tester := Base{Name: "test"}
 
@@ -1225,3 +1330,208 @@ func TestSortOrdersByOrderType(t *testing.T) {
 		t.Errorf("Test failed. Expected: '%v', received: '%v'", TrailingStopOrderType, orders[0].OrderType)
 	}
 }
+
+func TestGetFeesByCurrency(t *testing.T) {
+	o := OrderDetail{Fee: 1, FeeAsset: currency.USD}
+	fees := o.GetFeesByCurrency()
+	if len(fees) != 1 || fees[currency.USD.Upper()] != 1 {
+		t.Errorf("Test failed. Expected order fee of 1 USD, got %v", fees)
+	}
+
+	o.Trades = []TradeHistory{
+		{Fee: 0.5, FeeAsset: currency.BTC},
+		{Fee: 0.25, FeeAsset: currency.BTC},
+		{Fee: 2},
+		{},
+	}
+	fees = o.GetFeesByCurrency()
+	if len(fees) != 2 {
+		t.Fatalf("Test failed. Expected fees in 2 currencies, got %v", fees)
+	}
+	if fees[currency.BTC.Upper()] != 0.75 {
+		t.Errorf("Test failed. Expected 0.75 BTC fees, got %v", fees[currency.BTC.Upper()])
+	}
+	if fees[currency.USD.Upper()] != 2 {
+		t.Errorf("Test failed. Trades without a fee currency should use the order fee currency, got %v",
+			fees[currency.USD.Upper()])
+	}
+}
+
+func TestAggregateTradeFees(t *testing.T) {
+	o := OrderDetail{
+		Trades: []TradeHistory{
+			{Fee: 0.5, FeeAsset: currency.BTC},
+			{Fee: 0.25, FeeAsset: currency.BTC},
+		},
+	}
+	o.AggregateTradeFees()
+	if o.Fee != 0.75 || !o.FeeAsset.Match(currency.BTC) {
+		t.Errorf("Test failed. Expected aggregated fee of 0.75 BTC, got %v %s",
+			o.Fee, o.FeeAsset)
+	}
+
+	o = OrderDetail{
+		Fee:      1,
+		FeeAsset: currency.USD,
+		Trades: []TradeHistory{
+			{Fee: 0.5, FeeAsset: currency.BTC},
+			{Fee: 0.25, FeeAsset: currency.LTC},
+		},
+	}
+	o.AggregateTradeFees()
+	if o.Fee != 1 || !o.FeeAsset.Match(currency.USD) {
+		t.Error("Test failed. Fees in multiple currencies should not be aggregated")
+	}
+}
+
+func TestGetDefaultConfig(t *testing.T) {
+	var b Base
+	_, err := b.GetDefaultConfig()
+	if err == nil {
+		t.Error("Test Failed - expected an error without defaults set")
+	}
+
+	b = Base{
+		Name:                     defaultTestExchange,
+		RESTPollingDelay:         10,
+		AssetTypes:               []string{"SPOT", "FUTURES"},
+		SupportsAutoPairUpdating: true,
+	}
+	b.ConfigCurrencyPairFormat.Delimiter = "-"
+	b.ConfigCurrencyPairFormat.Uppercase = true
+
+	exchCfg, err := b.GetDefaultConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exchCfg.Name != defaultTestExchange || exchCfg.Enabled ||
+		exchCfg.RESTPollingDelay != 10 || !exchCfg.SupportsAutoPairUpdates {
+		t.Errorf("Test Failed - unexpected config %+v", exchCfg)
+	}
+	if exchCfg.AssetTypes != "SPOT,FUTURES" {
+		t.Errorf("Test Failed - unexpected asset types %s", exchCfg.AssetTypes)
+	}
+	if exchCfg.HTTPTimeout != DefaultHTTPTimeout {
+		t.Errorf("Test Failed - unexpected HTTP timeout %s", exchCfg.HTTPTimeout)
+	}
+	if exchCfg.APIKey != config.DefaultUnsetAPIKey ||
+		exchCfg.APIURL != config.APIURLNonDefaultMessage {
+		t.Error("Test Failed - expected unset placeholders")
+	}
+
+	exchCfg.ConfigCurrencyPairFormat.Delimiter = "_"
+	if b.ConfigCurrencyPairFormat.Delimiter != "-" {
+		t.Error("Test Failed - config shares the exchange pair format")
+	}
+}
+
+func TestGetAverageFillPrice(t *testing.T) {
+	o := OrderDetail{Price: 100, Amount: 2}
+	if price, amount := o.GetAverageFillPrice(); price != 0 || amount != 0 {
+		t.Errorf("Test failed. Expected no fill for an unexecuted order, got %v %v", price, amount)
+	}
+
+	o.ExecutedAmount = 1
+	if price, amount := o.GetAverageFillPrice(); price != 100 || amount != 1 {
+		t.Errorf("Test failed. Expected the order price, got %v %v", price, amount)
+	}
+
+	o.AverageExecutedPrice = 99.5
+	if price, _ := o.GetAverageFillPrice(); price != 99.5 {
+		t.Errorf("Test failed. Expected the average executed price, got %v", price)
+	}
+
+	o.Trades = []TradeHistory{
+		{Price: 101, Amount: 0.5},
+		{Price: 103, Amount: 1.5},
+	}
+	if price, amount := o.GetAverageFillPrice(); price != 102.5 || amount != 2 {
+		t.Errorf("Test failed. Expected the trades amount weighted price, got %v %v", price, amount)
+	}
+}
+
+func TestSetFillAmounts(t *testing.T) {
+	orders := []OrderDetail{
+		{Amount: 2, ExecutedAmount: 0.5},
+		{Amount: 2, RemainingAmount: 1.5},
+		{ExecutedAmount: 0.5, RemainingAmount: 1.5},
+		{Amount: 2, Trades: []TradeHistory{{Price: 100, Amount: 0.5}}},
+		{Amount: 2},
+		{Amount: 2, ExecutedAmount: 2},
+	}
+	SetOrderFillAmounts(orders)
+
+	for i := 0; i < 4; i++ {
+		if orders[i].Amount != 2 || orders[i].ExecutedAmount != 0.5 ||
+			orders[i].RemainingAmount != 1.5 {
+			t.Errorf("Test failed. Expected order %d amounts to be derived, got %+v",
+				i, orders[i])
+		}
+	}
+	if orders[4].ExecutedAmount != 0 || orders[4].RemainingAmount != 0 {
+		t.Errorf("Test failed. Expected unknown fills to be left unset, got %+v",
+			orders[4])
+	}
+	if orders[5].RemainingAmount != 0 {
+		t.Errorf("Test failed. Expected a filled order to have nothing remaining, got %v",
+			orders[5].RemainingAmount)
+	}
+}
+
+func TestApplyRateLimits(t *testing.T) {
+	b := Base{Name: "RateLimits"}
+	if b.ApplyRateLimits([]RateLimit{{Type: RateLimitRequests, Interval: time.Second, Limit: 1}}) {
+		t.Error("Test failed. ApplyRateLimits changed a missing requester")
+	}
+
+	b.Requester = request.New(b.Name,
+		request.NewRateLimit(time.Second, 10),
+		request.NewRateLimit(time.Second, 0),
+		new(http.Client))
+	changed := b.ApplyRateLimits([]RateLimit{
+		{Type: RateLimitOrders, Interval: time.Second, Limit: 1},
+		{Type: RateLimitRequests, Interval: time.Minute, Limit: 300},
+	})
+	if !changed {
+		t.Fatal("Test failed. ApplyRateLimits did not apply a stricter limit")
+	}
+	// 300 requests per minute is stricter than 10 per second and the
+	// unlimited unauthenticated limiter
+	for _, auth := range []bool{true, false} {
+		limit := b.GetRateLimit(auth)
+		if limit.GetRate() != 300 || limit.GetDuration() != time.Minute {
+			t.Errorf("Test failed. ApplyRateLimits unexpected limit %s",
+				limit.ToString())
+		}
+	}
+
+	if b.ApplyRateLimits([]RateLimit{{Type: RateLimitRequests, Interval: time.Second, Limit: 100}}) {
+		t.Error("Test failed. ApplyRateLimits applied a less strict limit")
+	}
+}
+
+func TestCancelAllOrdersResponse(t *testing.T) {
+	var resp CancelAllOrdersResponse
+	resp.Add("1", nil)
+	resp.Add("2", errors.New("order not found"))
+	resp.Add("3", &request.Error{Exchange: "Test", StatusCode: http.StatusBadRequest,
+		Code: "-2011", Err: errors.New("unknown order sent")})
+	resp.AddFailure("4", "REJECTED", "order cancellation rejected")
+
+	if len(resp.Orders) != 4 || !resp.Orders[0].Success {
+		t.Fatalf("Test failed. Unexpected cancel results %+v", resp.Orders)
+	}
+	failed := resp.Failed()
+	if len(failed) != 3 {
+		t.Fatalf("Test failed. Expected 3 failed cancellations, got %d", len(failed))
+	}
+	if failed[0].OrderID != "2" || failed[0].Code != "" || failed[0].Reason != "order not found" {
+		t.Errorf("Test failed. Unexpected failed cancellation %+v", failed[0])
+	}
+	if failed[1].Code != "-2011" {
+		t.Errorf("Test failed. Expected the request error code, got %+v", failed[1])
+	}
+	if failed[2].Code != "REJECTED" || failed[2].Success {
+		t.Errorf("Test failed. Unexpected failed cancellation %+v", failed[2])
+	}
+}
@@ -0,0 +1,72 @@
+package exchange
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// TimeSync holds the offset between the local clock and an exchanges server
+// clock so signed requests can be timestamped with the server time
+type TimeSync struct {
+	offset time.Duration
+	m      sync.RWMutex
+}
+
+// Now returns the current time adjusted by the measured server time offset
+func (t *TimeSync) Now() time.Time {
+	return time.Now().Add(t.Offset())
+}
+
+// Offset returns the measured server time offset
+func (t *TimeSync) Offset() time.Duration {
+	t.m.RLock()
+	defer t.m.RUnlock()
+	return t.offset
+}
+
+// Resync re-measures the server time offset. The server time is assumed to
+// have been taken halfway through the request
+func (t *TimeSync) Resync(exchName string, getServerTime func() (time.Time, error)) error {
+	sent := time.Now()
+	serverTime, err := getServerTime()
+	if err != nil {
+		return err
+	}
+	received := time.Now()
+
+	offset := serverTime.Sub(sent.Add(received.Sub(sent) / 2))
+	t.m.Lock()
+	t.offset = offset
+	t.m.Unlock()
+
+	log.Warnf("%s server time offset measured at %s", exchName, offset)
+	return nil
+}
+
+// RetryOnTimeSkew sends a signed request and when it is rejected because its
+// timestamp or nonce is outside the exchanges window, re-measures the server
+// time offset and retries the request once
+func (t *TimeSync) RetryOnTimeSkew(exchName string, isTimeSkewError func(error) bool, getServerTime func() (time.Time, error), sendRequest func() error) error {
+	err := sendRequest()
+	if err == nil || !isTimeSkewError(err) {
+		return err
+	}
+
+	log.Warnf("%s signed request rejected due to clock skew, resynchronising server time. Error: %s",
+		exchName, err)
+	resyncErr := t.Resync(exchName, getServerTime)
+	if resyncErr != nil {
+		return fmt.Errorf("%s signed request rejected due to clock skew and server time resync failed: %s",
+			exchName, resyncErr)
+	}
+
+	err = sendRequest()
+	if err != nil && isTimeSkewError(err) {
+		return fmt.Errorf("%s signed request rejected due to clock skew after resync with offset %s, check the system clock. Error: %s",
+			exchName, t.Offset(), err)
+	}
+	return err
+}
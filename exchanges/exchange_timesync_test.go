@@ -0,0 +1,86 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var errTestSkew = errors.New("timestamp outside of recv window")
+
+func isTestSkewError(err error) bool {
+	return err == errTestSkew
+}
+
+func TestTimeSyncResync(t *testing.T) {
+	var ts TimeSync
+	err := ts.Resync("test", func() (time.Time, error) {
+		return time.Now().Add(time.Minute), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	offset := ts.Offset()
+	if offset < time.Minute-time.Second || offset > time.Minute+time.Second {
+		t.Errorf("Test failed. Expected an offset of about 1m, got %s", offset)
+	}
+
+	if ts.Now().Sub(time.Now()) < time.Minute-time.Second {
+		t.Error("Test failed. Now should be adjusted by the offset")
+	}
+
+	err = ts.Resync("test", func() (time.Time, error) {
+		return time.Time{}, errors.New("unreachable")
+	})
+	if err == nil {
+		t.Error("Test failed. Expected error when the server time is unavailable")
+	}
+	if ts.Offset() != offset {
+		t.Error("Test failed. A failed resync should keep the previous offset")
+	}
+}
+
+func TestTimeSyncRetryOnTimeSkew(t *testing.T) {
+	var ts TimeSync
+	serverTime := func() (time.Time, error) {
+		return time.Now().Add(time.Minute), nil
+	}
+
+	attempts := 0
+	err := ts.RetryOnTimeSkew("test", isTestSkewError, serverTime, func() error {
+		attempts++
+		if ts.Offset() == 0 {
+			return errTestSkew
+		}
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if attempts != 2 {
+		t.Errorf("Test failed. Expected 2 attempts, got %d", attempts)
+	}
+
+	attempts = 0
+	err = ts.RetryOnTimeSkew("test", isTestSkewError, serverTime, func() error {
+		attempts++
+		return errTestSkew
+	})
+	if err == nil || err == errTestSkew {
+		t.Errorf("Test failed. Expected a descriptive skew error, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Test failed. Expected a single retry, got %d attempts", attempts)
+	}
+
+	attempts = 0
+	otherErr := errors.New("insufficient balance")
+	err = ts.RetryOnTimeSkew("test", isTestSkewError, serverTime, func() error {
+		attempts++
+		return otherErr
+	})
+	if err != otherErr || attempts != 1 {
+		t.Error("Test failed. Errors unrelated to clock skew should not be retried")
+	}
+}
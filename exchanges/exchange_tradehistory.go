@@ -0,0 +1,146 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// DefaultTradeHistoryChunk is the time range historic trades are downloaded in
+// when no chunk size is given
+const DefaultTradeHistoryChunk = time.Hour * 6
+
+// Rate limited historic trade requests are retried after a delay doubling
+// with each attempt
+const (
+	tradeHistoryRetryAttempts = 5
+	tradeHistoryRetryDelay    = time.Second
+)
+
+// TradeHistoryFetcher returns public trades from an exchanges REST trade
+// endpoint, it is satisfied by every exchange wrapper
+type TradeHistoryFetcher interface {
+	GetName() string
+	GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]TradeHistory, error)
+}
+
+// tradeHistoryKey returns the key a trade is deduplicated by, its ID when the
+// exchange has a numeric one
+func tradeHistoryKey(t *TradeHistory) string {
+	if t.TID != 0 {
+		return strconv.FormatInt(t.TID, 10)
+	}
+	return fmt.Sprintf("%d %v %v %s %s", t.Timestamp.UnixNano(), t.Price,
+		t.Amount, t.Type, t.Description)
+}
+
+// sortTradeHistory sorts trades by time and then ID, keeping the exchange
+// order of trades without one
+func sortTradeHistory(trades []TradeHistory) {
+	sort.SliceStable(trades, func(i, j int) bool {
+		if !trades[i].Timestamp.Equal(trades[j].Timestamp) {
+			return trades[i].Timestamp.Before(trades[j].Timestamp)
+		}
+		return trades[i].TID < trades[j].TID
+	})
+}
+
+// FilterTradeHistory returns the trades executed between start and end
+// inclusive, oldest first. Exchange trade endpoints returning a fixed number
+// of the latest trades are trimmed to the requested range with it
+func FilterTradeHistory(trades []TradeHistory, start, end time.Time) []TradeHistory {
+	var resp []TradeHistory
+	for x := range trades {
+		if trades[x].Timestamp.Before(start) || trades[x].Timestamp.After(end) {
+			continue
+		}
+		resp = append(resp, trades[x])
+	}
+	sortTradeHistory(resp)
+	return resp
+}
+
+// getHistoricTradesPage returns a page of trades, retrying the request while
+// the exchange is rate limiting them
+func getHistoricTradesPage(f TradeHistoryFetcher, p currency.Pair, assetType string, start, end time.Time) ([]TradeHistory, error) {
+	delay := tradeHistoryRetryDelay
+	for attempt := 0; ; attempt++ {
+		trades, err := f.GetHistoricTrades(p, assetType, start, end)
+		if err == nil || attempt == tradeHistoryRetryAttempts || !request.IsRateLimited(err) {
+			return trades, err
+		}
+		log.Warnf("%s historic trades request rate limited, retrying in %s",
+			f.GetName(), delay)
+		clock.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// DownloadHistoricTrades returns the public trades of a pair executed between
+// start and end inclusive, oldest first. The range is requested in chunks,
+// each paged through from the last trade returned until a page has no new
+// trades, so it works with exchanges returning a limited number of trades per
+// request. Trades returned by more than one page are only kept once
+func DownloadHistoricTrades(f TradeHistoryFetcher, p currency.Pair, assetType string, start, end time.Time, chunk time.Duration) ([]TradeHistory, error) {
+	if end.Before(start) {
+		return nil, errors.New("historic trades end is before start")
+	}
+	if chunk <= 0 {
+		chunk = DefaultTradeHistoryChunk
+	}
+
+	seen := make(map[string]bool)
+	var trades []TradeHistory
+	for chunkStart := start; ; {
+		chunkEnd := chunkStart.Add(chunk)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+
+		pageStart := chunkStart
+		for {
+			page, err := getHistoricTradesPage(f, p, assetType, pageStart, chunkEnd)
+			if err != nil {
+				return nil, fmt.Errorf("%s unable to get %s %s historic trades from %s: %s",
+					f.GetName(), p, assetType, pageStart.UTC(), err)
+			}
+
+			var added int
+			last := pageStart
+			for x := range page {
+				if page[x].Timestamp.Before(pageStart) || page[x].Timestamp.After(chunkEnd) {
+					continue
+				}
+				key := tradeHistoryKey(&page[x])
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				trades = append(trades, page[x])
+				added++
+				if page[x].Timestamp.After(last) {
+					last = page[x].Timestamp
+				}
+			}
+			if added == 0 {
+				break
+			}
+			pageStart = last
+		}
+
+		if !chunkEnd.Before(end) {
+			break
+		}
+		chunkStart = chunkEnd
+	}
+
+	sortTradeHistory(trades)
+	return trades, nil
+}
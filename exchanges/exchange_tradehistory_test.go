@@ -0,0 +1,140 @@
+package exchange
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+)
+
+// tradeHistoryTestFetcher returns up to three trades per request from a fixed
+// trade list, failing requests with the queued errors first
+type tradeHistoryTestFetcher struct {
+	trades   []TradeHistory
+	errs     []error
+	requests int
+}
+
+func (f *tradeHistoryTestFetcher) GetName() string {
+	return "TradeHistoryTest"
+}
+
+func (f *tradeHistoryTestFetcher) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]TradeHistory, error) {
+	f.requests++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return nil, err
+	}
+	var resp []TradeHistory
+	for x := range f.trades {
+		if f.trades[x].Timestamp.Before(start) || f.trades[x].Timestamp.After(end) {
+			continue
+		}
+		resp = append(resp, f.trades[x])
+		if len(resp) == 3 {
+			break
+		}
+	}
+	return resp, nil
+}
+
+func TestDownloadHistoricTrades(t *testing.T) {
+	t0 := time.Unix(1560000000, 0)
+	f := &tradeHistoryTestFetcher{}
+	for x := 0; x < 10; x++ {
+		// Trades 4 and 5 share a timestamp with the trades before them
+		offset := x
+		if x == 4 || x == 5 {
+			offset--
+		}
+		f.trades = append(f.trades, TradeHistory{
+			Timestamp: t0.Add(time.Minute * time.Duration(offset*10)),
+			TID:       int64(x + 1),
+		})
+	}
+
+	p := currency.NewPairFromString("BTCUSD")
+	trades, err := DownloadHistoricTrades(f, p, "SPOT", t0.Add(time.Minute*10),
+		t0.Add(time.Minute*80), time.Minute*30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 8 {
+		t.Fatalf("expected 8 trades, got %d", len(trades))
+	}
+	for x := range trades {
+		if trades[x].TID != int64(x+2) {
+			t.Errorf("expected trade %d to be %d, got %d", x, x+2, trades[x].TID)
+		}
+	}
+
+	if _, err = DownloadHistoricTrades(f, p, "SPOT", t0, t0.Add(-time.Minute), 0); err == nil {
+		t.Error("expected an error for an end before start")
+	}
+}
+
+func TestDownloadHistoricTradesRateLimited(t *testing.T) {
+	t0 := time.Unix(1560000000, 0)
+	sim := clock.NewSimulated(t0)
+	clock.Set(sim)
+	defer clock.Set(nil)
+
+	f := &tradeHistoryTestFetcher{
+		trades: []TradeHistory{{Timestamp: t0, TID: 1}},
+		errs: []error{
+			&request.Error{StatusCode: http.StatusTooManyRequests},
+			&request.Error{StatusCode: http.StatusTeapot},
+		},
+	}
+	p := currency.NewPairFromString("BTCUSD")
+	type result struct {
+		trades []TradeHistory
+		err    error
+	}
+	done := make(chan result)
+	go func() {
+		trades, err := DownloadHistoricTrades(f, p, "SPOT", t0, t0.Add(time.Hour), 0)
+		done <- result{trades, err}
+	}()
+
+	for _, delay := range []time.Duration{time.Second, time.Second * 2} {
+		for sim.Waiters() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		sim.Advance(delay)
+	}
+	r := <-done
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	if len(r.trades) != 1 {
+		t.Errorf("expected the trade after retrying, got %v", r.trades)
+	}
+
+	f.errs = []error{errors.New("invalid pair")}
+	f.requests = 0
+	if _, err := DownloadHistoricTrades(f, p, "SPOT", t0, t0.Add(time.Hour), 0); err == nil {
+		t.Error("expected the error to be returned")
+	}
+	if f.requests != 1 {
+		t.Errorf("expected errors other than rate limiting not to be retried, got %d requests", f.requests)
+	}
+}
+
+func TestFilterTradeHistory(t *testing.T) {
+	t0 := time.Unix(1560000000, 0)
+	trades := FilterTradeHistory([]TradeHistory{
+		{Timestamp: t0.Add(time.Minute * 3), TID: 4},
+		{Timestamp: t0.Add(time.Minute), TID: 3},
+		{Timestamp: t0.Add(time.Minute), TID: 2},
+		{Timestamp: t0, TID: 1},
+	}, t0.Add(time.Minute), t0.Add(time.Minute*2))
+	if len(trades) != 2 || trades[0].TID != 2 || trades[1].TID != 3 {
+		t.Errorf("expected trades 2 and 3, got %v", trades)
+	}
+}
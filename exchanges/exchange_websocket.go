@@ -9,6 +9,7 @@ import (
 
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/exchanges/faults"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	log "github.com/thrasher-/gocryptotrader/logger"
 )
@@ -75,6 +76,15 @@ func (w *Websocket) Connect() error {
 
 	w.connecting = true
 	w.ShutdownC = make(chan struct{}, 1)
+	fault := faults.Websocket(w.exchangeName)
+	if fault.Latency > 0 {
+		time.Sleep(fault.Latency)
+	}
+	if fault.Drop {
+		w.connecting = false
+		return fmt.Errorf("exchange_websocket.go connection error %s",
+			faults.ErrConnectionDropped)
+	}
 	err := w.connector()
 	if err != nil {
 		w.connecting = false
@@ -92,6 +102,7 @@ func (w *Websocket) Connect() error {
 	anotherWG.Add(1)
 	go w.trafficMonitor(&anotherWG)
 	anotherWG.Wait()
+	w.startHeartbeat()
 	if !w.connectionMonitorRunning {
 		go w.wsConnectionMonitor()
 	}
@@ -262,6 +273,10 @@ func (w *Websocket) trafficMonitor(wg *sync.WaitGroup) {
 			}
 			return
 		case <-w.TrafficAlert: // Resets timer on traffic
+			w.recordTraffic()
+			if w.injectTrafficFault() {
+				return
+			}
 			w.m.Lock()
 			if !w.connected {
 				w.Connected <- struct{}{}
@@ -301,6 +316,7 @@ func (w *Websocket) trafficMonitor(wg *sync.WaitGroup) {
 
 			case <-w.TrafficAlert: // If in this time response traffic comes through
 				trafficTimer.Reset(WebsocketTrafficLimitTime)
+				w.recordTraffic()
 				w.m.Lock()
 				if !w.connected {
 					// If not connected dive rt traffic from REST to websocket
@@ -316,6 +332,23 @@ func (w *Websocket) trafficMonitor(wg *sync.WaitGroup) {
 	}
 }
 
+// injectTrafficFault delays websocket traffic by an injected latency. When a
+// dropped connection is injected the websocket is reset and true is returned
+// so the traffic monitor exits
+func (w *Websocket) injectTrafficFault() bool {
+	fault := faults.Websocket(w.exchangeName)
+	if fault.Latency > 0 {
+		time.Sleep(fault.Latency)
+	}
+	if !fault.Drop {
+		return false
+	}
+	log.Warnf("%v websocket %s, reconnecting", w.exchangeName,
+		faults.ErrConnectionDropped)
+	go w.WebsocketReset()
+	return true
+}
+
 // SetWebsocketURL sets websocket URL
 func (w *Websocket) SetWebsocketURL(websocketURL string) {
 	if websocketURL == "" || websocketURL == config.WebsocketURLNonDefaultMessage {
@@ -627,6 +660,7 @@ func (w *WebsocketOrderbookLocal) UpdateUsingID(bidTargets, askTargets []orderbo
 func (w *WebsocketOrderbookLocal) FlushCache() {
 	w.m.Lock()
 	w.ob = nil
+	w.synced = nil
 	w.m.Unlock()
 }
 
@@ -673,6 +707,9 @@ func (w *Websocket) FormatFunctionality() string {
 			case WebsocketUnsubscribeSupported:
 				functionality = append(functionality, WebsocketUnsubscribeSupportedText)
 
+			case WebsocketSubmitOrderSupported:
+				functionality = append(functionality, WebsocketSubmitOrderSupportedText)
+
 			default:
 				functionality = append(functionality,
 					fmt.Sprintf("%s[1<<%v]", UnknownWebsocketFunctionality, i))
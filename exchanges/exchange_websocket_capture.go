@@ -0,0 +1,274 @@
+package exchange
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	// WebsocketCaptureRedacted replaces sensitive values in captured messages
+	WebsocketCaptureRedacted = "REDACTED"
+
+	// websocketReplayBuffer is the number of parsed items buffered per
+	// replayed message
+	websocketReplayBuffer = 1024
+)
+
+// websocketSensitiveFields are the lower case field names whose values are
+// redacted from captured messages
+var websocketSensitiveFields = map[string]bool{
+	"apikey":       true,
+	"api_key":      true,
+	"key":          true,
+	"secret":       true,
+	"passphrase":   true,
+	"password":     true,
+	"signature":    true,
+	"sign":         true,
+	"sig":          true,
+	"authsig":      true,
+	"authpayload":  true,
+	"authnonce":    true,
+	"authkey":      true,
+	"token":        true,
+	"access_token": true,
+}
+
+// WebsocketCaptureMessage holds a captured raw websocket message
+type WebsocketCaptureMessage struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Exchange  string          `json:"exchange"`
+	Raw       json.RawMessage `json:"raw"`
+}
+
+// WebsocketReplayResult holds the data an exchange parsed from a replayed
+// message, Error is set when parsing failed or panicked. Orderbooks holds a
+// snapshot of each local orderbook the message updated
+type WebsocketReplayResult struct {
+	Message    WebsocketCaptureMessage
+	Data       []interface{}
+	Orderbooks []orderbook.Base
+	Error      error
+}
+
+// StartCapture appends the authenticated order and fill messages the exchange
+// captures to a file
+func (w *Websocket) StartCapture(path string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	w.captureLock.Lock()
+	defer w.captureLock.Unlock()
+	if w.capture != nil {
+		w.capture.Close()
+	}
+	w.capture = f
+	return nil
+}
+
+// StopCapture stops capturing messages, closing the capture file
+func (w *Websocket) StopCapture() error {
+	w.captureLock.Lock()
+	defer w.captureLock.Unlock()
+	if w.capture == nil {
+		return nil
+	}
+	err := w.capture.Close()
+	w.capture = nil
+	return err
+}
+
+// IsCapturing returns whether messages are being captured
+func (w *Websocket) IsCapturing() bool {
+	w.captureLock.Lock()
+	defer w.captureLock.Unlock()
+	return w.capture != nil
+}
+
+// CaptureMessage writes a sanitised raw message to the capture file when
+// capturing is started
+func (w *Websocket) CaptureMessage(raw []byte) {
+	w.captureLock.Lock()
+	defer w.captureLock.Unlock()
+	if w.capture == nil {
+		return
+	}
+
+	sanitised, err := SanitiseWebsocketMessage(raw)
+	if err != nil {
+		log.Errorf("%v websocket unable to capture message: %s",
+			w.exchangeName, err)
+		return
+	}
+
+	data, err := common.JSONEncode(WebsocketCaptureMessage{
+		Timestamp: time.Now(),
+		Exchange:  w.exchangeName,
+		Raw:       sanitised,
+	})
+	if err != nil {
+		log.Errorf("%v websocket unable to capture message: %s",
+			w.exchangeName, err)
+		return
+	}
+
+	_, err = w.capture.Write(append(data, '\n'))
+	if err != nil {
+		log.Errorf("%v websocket unable to write captured message: %s",
+			w.exchangeName, err)
+	}
+}
+
+// SanitiseWebsocketMessage redacts credential and signature values from a raw
+// JSON message, numbers are preserved exactly
+func SanitiseWebsocketMessage(raw []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var message interface{}
+	err := decoder.Decode(&message)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(redactWebsocketFields(message))
+}
+
+func redactWebsocketFields(v interface{}) interface{} {
+	switch d := v.(type) {
+	case map[string]interface{}:
+		for k := range d {
+			if websocketSensitiveFields[common.StringToLower(k)] {
+				d[k] = WebsocketCaptureRedacted
+				continue
+			}
+			d[k] = redactWebsocketFields(d[k])
+		}
+	case []interface{}:
+		for x := range d {
+			d[x] = redactWebsocketFields(d[x])
+		}
+	}
+	return v
+}
+
+// ReadWebsocketCapture reads the messages of a capture file
+func ReadWebsocketCapture(path string) ([]WebsocketCaptureMessage, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var messages []WebsocketCaptureMessage
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var m WebsocketCaptureMessage
+		err = common.JSONDecode(scanner.Bytes(), &m)
+		if err != nil {
+			return nil, fmt.Errorf("capture %s line %d: %s", path, line, err)
+		}
+		messages = append(messages, m)
+	}
+	return messages, scanner.Err()
+}
+
+// ReplayWebsocketCapture feeds captured messages back through the websocket
+// parsing code of an exchange, returning the data parsed from each message
+func ReplayWebsocketCapture(exch IBotExchange, messages []WebsocketCaptureMessage) ([]WebsocketReplayResult, error) {
+	var results []WebsocketReplayResult
+	err := StreamWebsocketCapture(exch, messages, nil,
+		func(result WebsocketReplayResult) error {
+			results = append(results, result)
+			return nil
+		})
+	return results, err
+}
+
+// StreamWebsocketCapture replays captured messages in capture order, passing
+// the data parsed from each message to a handler such as a strategy being
+// backtested. When a simulated clock is given it is set to the capture time
+// of each message before it is parsed, so strategies and time dependent
+// subsystems observe the recorded timing. A handler error stops the replay
+func StreamWebsocketCapture(exch IBotExchange, messages []WebsocketCaptureMessage, sim *clock.Simulated, handler func(WebsocketReplayResult) error) error {
+	replayer, ok := exch.(IWebsocketReplayExchange)
+	if !ok {
+		return fmt.Errorf("%s does not support websocket replay",
+			exch.GetName())
+	}
+
+	ws, err := exch.GetWebsocket()
+	if err != nil {
+		return err
+	}
+	if ws == nil {
+		return errors.New("websocket not initialised")
+	}
+	ws.DataHandler = make(chan interface{}, websocketReplayBuffer)
+
+	for x := range messages {
+		if sim != nil {
+			sim.Set(messages[x].Timestamp)
+		}
+
+		result := WebsocketReplayResult{Message: messages[x]}
+		result.Error = replayWebsocketMessage(replayer, messages[x].Raw)
+		for len(ws.DataHandler) > 0 {
+			data := <-ws.DataHandler
+			if err, ok := data.(error); ok && result.Error == nil {
+				result.Error = err
+				continue
+			}
+			result.Data = append(result.Data, data)
+
+			update, ok := data.(WebsocketOrderbookUpdate)
+			if !ok {
+				continue
+			}
+			ob, err := orderbook.Get(update.Exchange, update.Pair, update.Asset)
+			if err != nil {
+				continue
+			}
+			result.Orderbooks = append(result.Orderbooks, copyOrderbook(ob))
+		}
+
+		err = handler(result)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyOrderbook returns a copy of an orderbook which later updates to the
+// local orderbook do not modify
+func copyOrderbook(ob orderbook.Base) orderbook.Base {
+	ob.Bids = append([]orderbook.Item(nil), ob.Bids...)
+	ob.Asks = append([]orderbook.Item(nil), ob.Asks...)
+	return ob
+}
+
+// replayWebsocketMessage replays a message, recovering a panic in the parsing
+// code as an error so the mapping bug can be reported
+func replayWebsocketMessage(replayer IWebsocketReplayExchange, raw []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("parsing panicked: %v", r)
+		}
+	}()
+	return replayer.ReplayWebsocketMessage(raw)
+}
@@ -0,0 +1,160 @@
+package exchange
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+type replayTestExchange struct {
+	IBotExchange
+	ws *Websocket
+}
+
+func (r *replayTestExchange) GetName() string {
+	return "ReplayTest"
+}
+
+func (r *replayTestExchange) GetWebsocket() (*Websocket, error) {
+	return r.ws, nil
+}
+
+// ReplayWebsocketMessage treats each message as the price of a trade, which
+// also sets the best bid of the local orderbook
+func (r *replayTestExchange) ReplayWebsocketMessage(raw []byte) error {
+	p := currency.NewPairFromStrings("BTC", "USD")
+	price := float64(len(raw))
+	ob := orderbook.Base{
+		Pair:         p,
+		Bids:         []orderbook.Item{{Price: price, Amount: 1}},
+		AssetType:    orderbook.Spot,
+		ExchangeName: r.GetName(),
+	}
+	err := ob.Process()
+	if err != nil {
+		return err
+	}
+	r.ws.DataHandler <- TradeData{Timestamp: clock.Now(), CurrencyPair: p, Price: price}
+	r.ws.DataHandler <- WebsocketOrderbookUpdate{Pair: p, Asset: orderbook.Spot, Exchange: r.GetName()}
+	return nil
+}
+
+func TestSanitiseWebsocketMessage(t *testing.T) {
+	raw := []byte(`{"event":"auth","apiKey":"abc","authSig":"def","data":[{"orderId":12345678901234567,"Signature":"ghi"}]}`)
+	sanitised, err := SanitiseWebsocketMessage(raw)
+	if err != nil {
+		t.Fatalf("Test Failed - SanitiseWebsocketMessage() error %s", err)
+	}
+
+	s := string(sanitised)
+	for _, secret := range []string{"abc", "def", "ghi"} {
+		if strings.Contains(s, secret) {
+			t.Errorf("Test Failed - SanitiseWebsocketMessage() expected %s to be redacted: %s",
+				secret, s)
+		}
+	}
+	if !strings.Contains(s, "12345678901234567") || !strings.Contains(s, `"event":"auth"`) {
+		t.Errorf("Test Failed - SanitiseWebsocketMessage() expected values to be preserved: %s",
+			s)
+	}
+
+	_, err = SanitiseWebsocketMessage([]byte("not json"))
+	if err == nil {
+		t.Error("Test Failed - SanitiseWebsocketMessage() expected error for invalid JSON")
+	}
+}
+
+func TestWebsocketCapture(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gct-capture")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	w := Websocket{exchangeName: "test"}
+	w.CaptureMessage([]byte(`[0,"on",[1]]`))
+
+	path := filepath.Join(dir, "test.jsonl")
+	err = w.StartCapture(path)
+	if err != nil {
+		t.Fatalf("Test Failed - StartCapture() error %s", err)
+	}
+	if !w.IsCapturing() {
+		t.Error("Test Failed - IsCapturing() expected capture to be started")
+	}
+
+	w.CaptureMessage([]byte(`[0,"on",[1]]`))
+	w.CaptureMessage([]byte(`{"event":"auth","key":"secret"}`))
+	w.CaptureMessage([]byte(`invalid`))
+	err = w.StopCapture()
+	if err != nil {
+		t.Fatalf("Test Failed - StopCapture() error %s", err)
+	}
+
+	messages, err := ReadWebsocketCapture(path)
+	if err != nil {
+		t.Fatalf("Test Failed - ReadWebsocketCapture() error %s", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("Test Failed - ReadWebsocketCapture() expected 2 messages, got %d",
+			len(messages))
+	}
+	if messages[0].Exchange != "test" || string(messages[0].Raw) != `[0,"on",[1]]` {
+		t.Errorf("Test Failed - ReadWebsocketCapture() unexpected message %+v",
+			messages[0])
+	}
+	if strings.Contains(string(messages[1].Raw), "secret") {
+		t.Error("Test Failed - CaptureMessage() expected message to be sanitised")
+	}
+}
+
+func TestStreamWebsocketCapture(t *testing.T) {
+	start := time.Unix(1000, 0)
+	sim := clock.NewSimulated(time.Unix(0, 0))
+	clock.Set(sim)
+	defer clock.Set(nil)
+
+	exch := &replayTestExchange{ws: &Websocket{}}
+	messages := []WebsocketCaptureMessage{
+		{Timestamp: start, Raw: []byte(`1`)},
+		{Timestamp: start.Add(time.Second), Raw: []byte(`22`)},
+		{Timestamp: start.Add(time.Minute), Raw: []byte(`333`)},
+	}
+
+	var results []WebsocketReplayResult
+	errStop := errors.New("stop")
+	err := StreamWebsocketCapture(exch, messages, sim,
+		func(result WebsocketReplayResult) error {
+			results = append(results, result)
+			if len(results) == 2 {
+				return errStop
+			}
+			return nil
+		})
+	if err != errStop {
+		t.Fatalf("Test Failed - StreamWebsocketCapture() expected handler error, got %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Test Failed - StreamWebsocketCapture() expected 2 results, got %d",
+			len(results))
+	}
+
+	trade, ok := results[1].Data[0].(TradeData)
+	if !ok || !trade.Timestamp.Equal(start.Add(time.Second)) || trade.Price != 2 {
+		t.Errorf("Test Failed - StreamWebsocketCapture() unexpected trade %+v",
+			results[1].Data[0])
+	}
+	if len(results[0].Orderbooks) != 1 || results[0].Orderbooks[0].Bids[0].Price != 1 ||
+		results[1].Orderbooks[0].Bids[0].Price != 2 {
+		t.Errorf("Test Failed - StreamWebsocketCapture() unexpected orderbook snapshots %+v %+v",
+			results[0].Orderbooks, results[1].Orderbooks)
+	}
+}
@@ -0,0 +1,135 @@
+package exchange
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// websocketHeartbeatTimeoutChecks is the number of times per heartbeat timeout
+// the time since the last received traffic is checked
+const websocketHeartbeatTimeoutChecks = 4
+
+// SetHeartbeat sets how the connection is kept alive, the heartbeat is started
+// with each connection and stopped on shutdown
+func (w *Websocket) SetHeartbeat(h WebsocketHeartbeat) {
+	w.heartbeatLock.Lock()
+	w.heartbeat = h
+	w.heartbeatLock.Unlock()
+}
+
+// HandleHeartbeat records received traffic and returns whether a message is a
+// pong or a server ping which has been replied to, neither of which need
+// further processing
+func (w *Websocket) HandleHeartbeat(raw []byte) bool {
+	if len(raw) == 0 {
+		return false
+	}
+
+	w.heartbeatLock.Lock()
+	h := w.heartbeat
+	w.lastTraffic = time.Now()
+	w.heartbeatLock.Unlock()
+
+	if h.IsPong != nil && h.IsPong(raw) {
+		if w.verbose {
+			log.Debugf("%v websocket pong received", w.exchangeName)
+		}
+		return true
+	}
+
+	if h.Reply == nil || h.Send == nil {
+		return false
+	}
+	reply := h.Reply(raw)
+	if reply == nil {
+		return false
+	}
+	if w.verbose {
+		log.Debugf("%v websocket ping received, sending pong", w.exchangeName)
+	}
+	err := h.Send(reply)
+	if err != nil {
+		w.DataHandler <- fmt.Errorf("%v websocket pong error: %v", w.exchangeName, err)
+	}
+	return true
+}
+
+// recordTraffic records that the connection received traffic
+func (w *Websocket) recordTraffic() {
+	w.heartbeatLock.Lock()
+	w.lastTraffic = time.Now()
+	w.heartbeatLock.Unlock()
+}
+
+// timeSinceTraffic returns the time since the connection last received traffic
+func (w *Websocket) timeSinceTraffic() time.Duration {
+	w.heartbeatLock.Lock()
+	defer w.heartbeatLock.Unlock()
+	return time.Since(w.lastTraffic)
+}
+
+// startHeartbeat starts the heartbeat of a new connection, it is called by
+// Connect with the shutdown channel of the connection set
+func (w *Websocket) startHeartbeat() {
+	w.heartbeatLock.Lock()
+	h := w.heartbeat
+	w.lastTraffic = time.Now()
+	w.heartbeatLock.Unlock()
+
+	sendsPing := h.Interval > 0 && h.Send != nil && len(h.Payload) > 0
+	if !sendsPing && h.Timeout <= 0 {
+		return
+	}
+	w.Wg.Add(1)
+	go w.heartbeatRoutine(h, sendsPing, w.ShutdownC)
+}
+
+// heartbeatRoutine sends the heartbeat payload every interval and resets the
+// connection once no traffic has been received within the timeout
+func (w *Websocket) heartbeatRoutine(h WebsocketHeartbeat, sendsPing bool, shutdown chan struct{}) {
+	defer w.Wg.Done()
+
+	var ping, check <-chan time.Time
+	if sendsPing {
+		pingTicker := time.NewTicker(h.Interval)
+		defer pingTicker.Stop()
+		ping = pingTicker.C
+	}
+	if h.Timeout > 0 {
+		checkTicker := time.NewTicker(h.Timeout / websocketHeartbeatTimeoutChecks)
+		defer checkTicker.Stop()
+		check = checkTicker.C
+	}
+
+	for {
+		select {
+		case <-shutdown:
+			return
+		case <-ping:
+			if w.verbose {
+				log.Debugf("%v sending websocket ping", w.exchangeName)
+			}
+			err := h.Send(h.Payload)
+			if err != nil {
+				w.DataHandler <- fmt.Errorf("%v websocket ping error: %v", w.exchangeName, err)
+			}
+		case <-check:
+			if w.timeSinceTraffic() < h.Timeout {
+				continue
+			}
+			w.m.Lock()
+			if w.connecting {
+				w.m.Unlock()
+				return
+			}
+			w.connecting = true
+			w.m.Unlock()
+			log.Warnf("%v websocket received no traffic for %v, reconnecting",
+				w.exchangeName, h.Timeout)
+			go w.WebsocketReset()
+			return
+		}
+	}
+}
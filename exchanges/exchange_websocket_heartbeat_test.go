@@ -0,0 +1,69 @@
+package exchange
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHandleHeartbeat(t *testing.T) {
+	var sent []string
+	w := Websocket{DataHandler: make(chan interface{}, 1)}
+	w.SetHeartbeat(WebsocketHeartbeat{
+		Send: func(payload []byte) error {
+			sent = append(sent, string(payload))
+			return nil
+		},
+		IsPong: func(raw []byte) bool {
+			return string(raw) == "pong"
+		},
+		Reply: func(raw []byte) []byte {
+			if string(raw) != "ping" {
+				return nil
+			}
+			return []byte("pong")
+		},
+	})
+
+	if !w.HandleHeartbeat([]byte("pong")) {
+		t.Error("test failed - pong should be handled")
+	}
+	if !w.HandleHeartbeat([]byte("ping")) {
+		t.Error("test failed - server ping should be handled")
+	}
+	if w.HandleHeartbeat([]byte(`{"channel":"ticker"}`)) {
+		t.Error("test failed - data should not be handled")
+	}
+	if len(sent) != 1 || sent[0] != "pong" {
+		t.Errorf("test failed - expected a pong reply, sent %v", sent)
+	}
+	if w.timeSinceTraffic() > time.Second {
+		t.Error("test failed - traffic should be recorded")
+	}
+}
+
+func TestHeartbeatRoutine(t *testing.T) {
+	sent := make(chan string, 10)
+	w := Websocket{DataHandler: make(chan interface{}, 1)}
+	w.SetHeartbeat(WebsocketHeartbeat{
+		Interval: time.Millisecond * 10,
+		Payload:  []byte("ping"),
+		Send: func(payload []byte) error {
+			sent <- string(payload)
+			return nil
+		},
+	})
+	w.ShutdownC = make(chan struct{})
+	w.startHeartbeat()
+
+	select {
+	case payload := <-sent:
+		if payload != "ping" {
+			t.Errorf("test failed - unexpected ping payload %s", payload)
+		}
+	case <-time.After(time.Second):
+		t.Error("test failed - ping not sent")
+	}
+
+	close(w.ShutdownC)
+	w.Wg.Wait()
+}
@@ -0,0 +1,252 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+// websocketOrderbookPendingLimit is the maximum number of deltas buffered per
+// orderbook while waiting for its snapshot, the oldest are dropped first
+const websocketOrderbookPendingLimit = 1000
+
+// WebsocketOrderbookDelta is an incremental update of an orderbook, a level
+// with a zero amount is removed. UpdateID is the sequence number of the update
+// and FirstUpdateID the first sequence number it covers when it spans several,
+// a delta without an UpdateID isn't sequence checked. The orderbook is checked
+// against Checksum when HasChecksum is set and a checksum func is set
+type WebsocketOrderbookDelta struct {
+	Pair          currency.Pair
+	AssetType     string
+	Bids          []orderbook.Item
+	Asks          []orderbook.Item
+	FirstUpdateID int64
+	UpdateID      int64
+	Checksum      uint32
+	HasChecksum   bool
+	Updated       time.Time
+}
+
+// websocketOrderbookSync holds the sync state of a locally maintained
+// orderbook. Deltas received before its snapshot is synced are pending
+type websocketOrderbookSync struct {
+	book     *orderbook.Base
+	updateID int64
+	pending  []WebsocketOrderbookDelta
+}
+
+func websocketOrderbookKey(p currency.Pair, assetType string) string {
+	return p.Base.Upper().String() + p.Quote.Upper().String() + assetType
+}
+
+// SetChecksum sets the func which calculates an exchanges orderbook checksum,
+// used to validate deltas with a checksum
+func (w *WebsocketOrderbookLocal) SetChecksum(checksum func(ob *orderbook.Base) uint32) {
+	w.m.Lock()
+	w.checksum = checksum
+	w.m.Unlock()
+}
+
+// SetResync sets the func called when an orderbook falls out of sync, which
+// should request a new snapshot to sync the orderbook from
+func (w *WebsocketOrderbookLocal) SetResync(resync func(p currency.Pair, assetType string) error) {
+	w.m.Lock()
+	w.resync = resync
+	w.m.Unlock()
+}
+
+// getSync returns the sync state of an orderbook, creating it if needed
+func (w *WebsocketOrderbookLocal) getSync(p currency.Pair, assetType string) *websocketOrderbookSync {
+	if w.synced == nil {
+		w.synced = make(map[string]*websocketOrderbookSync)
+	}
+	key := websocketOrderbookKey(p, assetType)
+	s, ok := w.synced[key]
+	if !ok {
+		s = &websocketOrderbookSync{}
+		w.synced[key] = s
+	}
+	return s
+}
+
+// SyncSnapshot replaces the local orderbook of a pair with a snapshot as of a
+// sequence number, zero when the exchange doesn't sequence its deltas. Pending
+// deltas after the snapshot are applied before the orderbook is stored
+func (w *WebsocketOrderbookLocal) SyncSnapshot(newOrderbook *orderbook.Base, updateID int64) error {
+	if len(newOrderbook.Asks) == 0 || len(newOrderbook.Bids) == 0 {
+		return errors.New("exchange.go websocket orderbook cache SyncSnapshot() error - snapshot ask and bids are nil")
+	}
+
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	book := *newOrderbook
+	book.Bids = append([]orderbook.Item(nil), newOrderbook.Bids...)
+	book.Asks = append([]orderbook.Item(nil), newOrderbook.Asks...)
+	sort.Slice(book.Bids, func(i, j int) bool { return book.Bids[i].Price > book.Bids[j].Price })
+	sort.Slice(book.Asks, func(i, j int) bool { return book.Asks[i].Price < book.Asks[j].Price })
+
+	s := w.getSync(book.Pair, book.AssetType)
+	w.removeBook(s.book)
+	s.book = &book
+	s.updateID = updateID
+	w.ob = append(w.ob, s.book)
+
+	pending := s.pending
+	s.pending = nil
+	for x := range pending {
+		err := w.applyDelta(s, &pending[x])
+		if err != nil {
+			w.invalidate(s)
+			return err
+		}
+	}
+	return w.publish(s.book)
+}
+
+// ApplyDelta applies a delta to the local orderbook of a pair and stores the
+// result. Deltas already covered by the orderbook are skipped and deltas for
+// an orderbook without a synced snapshot are held until one is. A delta which
+// doesn't follow the previous update or fails the checksum drops the local
+// orderbook, marks the stored one stale and calls the resync func
+func (w *WebsocketOrderbookLocal) ApplyDelta(d *WebsocketOrderbookDelta) error {
+	w.m.Lock()
+	s := w.getSync(d.Pair, d.AssetType)
+	if s.book == nil {
+		if len(s.pending) >= websocketOrderbookPendingLimit {
+			s.pending = s.pending[1:]
+		}
+		s.pending = append(s.pending, *d)
+		w.m.Unlock()
+		return nil
+	}
+
+	err := w.applyDelta(s, d)
+	if err == nil {
+		err = w.publish(s.book)
+		w.m.Unlock()
+		return err
+	}
+
+	exchName := s.book.ExchangeName
+	w.invalidate(s)
+	resync := w.resync
+	w.m.Unlock()
+
+	// The stored orderbook may not exist yet, in which case nothing is stale
+	_ = orderbook.SetStale(exchName, d.Pair, d.AssetType)
+	if resync != nil {
+		if resyncErr := resync(d.Pair, d.AssetType); resyncErr != nil {
+			return fmt.Errorf("%s, resync failed: %s", err, resyncErr)
+		}
+	}
+	return err
+}
+
+// applyDelta validates the sequence of a delta and applies its levels,
+// validating the checksum of the resulting orderbook
+func (w *WebsocketOrderbookLocal) applyDelta(s *websocketOrderbookSync, d *WebsocketOrderbookDelta) error {
+	if d.UpdateID != 0 && s.updateID != 0 {
+		if d.UpdateID <= s.updateID {
+			return nil
+		}
+		first := d.FirstUpdateID
+		if first == 0 {
+			first = d.UpdateID
+		}
+		if first > s.updateID+1 {
+			return fmt.Errorf("websocket orderbook out of sync, %s %s update %d does not follow %d",
+				d.Pair, d.AssetType, first, s.updateID)
+		}
+	}
+
+	s.book.Bids = applyOrderbookLevels(s.book.Bids, d.Bids, true)
+	s.book.Asks = applyOrderbookLevels(s.book.Asks, d.Asks, false)
+	if d.UpdateID != 0 {
+		s.updateID = d.UpdateID
+	}
+	s.book.LastUpdated = d.Updated
+	if s.book.LastUpdated.IsZero() {
+		s.book.LastUpdated = time.Now()
+	}
+
+	if d.HasChecksum && w.checksum != nil {
+		if checksum := w.checksum(s.book); checksum != d.Checksum {
+			return fmt.Errorf("websocket orderbook out of sync, %s %s checksum %d does not match %d",
+				d.Pair, d.AssetType, checksum, d.Checksum)
+		}
+	}
+	return nil
+}
+
+// applyOrderbookLevels applies level updates to levels sorted by price,
+// descending for bids, keeping them sorted
+func applyOrderbookLevels(levels, updates []orderbook.Item, descending bool) []orderbook.Item {
+	for x := range updates {
+		price := updates[x].Price
+		i := sort.Search(len(levels), func(i int) bool {
+			if descending {
+				return levels[i].Price <= price
+			}
+			return levels[i].Price >= price
+		})
+		found := i < len(levels) && levels[i].Price == price
+		switch {
+		case found && updates[x].Amount == 0:
+			levels = append(levels[:i], levels[i+1:]...)
+		case found:
+			levels[i].Amount = updates[x].Amount
+		case updates[x].Amount != 0:
+			levels = append(levels, orderbook.Item{})
+			copy(levels[i+1:], levels[i:])
+			levels[i] = updates[x]
+		}
+	}
+	return levels
+}
+
+// publish stores a copy of a local orderbook in the orderbook package, so
+// readers never see levels changed by later deltas
+func (w *WebsocketOrderbookLocal) publish(book *orderbook.Base) error {
+	published := *book
+	published.Bids = append([]orderbook.Item(nil), book.Bids...)
+	published.Asks = append([]orderbook.Item(nil), book.Asks...)
+	return published.Process()
+}
+
+// invalidate drops a local orderbook until its next snapshot is synced
+func (w *WebsocketOrderbookLocal) invalidate(s *websocketOrderbookSync) {
+	w.removeBook(s.book)
+	s.book = nil
+	s.updateID = 0
+	s.pending = nil
+}
+
+// removeBook removes an orderbook from the local cache
+func (w *WebsocketOrderbookLocal) removeBook(book *orderbook.Base) {
+	for i := range w.ob {
+		if w.ob[i] == book {
+			w.ob = append(w.ob[:i], w.ob[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetOrderbook returns a copy of the locally maintained orderbook of a pair
+func (w *WebsocketOrderbookLocal) GetOrderbook(p currency.Pair, assetType string) (orderbook.Base, error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	s, ok := w.synced[websocketOrderbookKey(p, assetType)]
+	if !ok || s.book == nil {
+		return orderbook.Base{}, fmt.Errorf("websocket orderbook %s %s not synced",
+			p, assetType)
+	}
+	book := *s.book
+	book.Bids = append([]orderbook.Item(nil), s.book.Bids...)
+	book.Asks = append([]orderbook.Item(nil), s.book.Asks...)
+	return book, nil
+}
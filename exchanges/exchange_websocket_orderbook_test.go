@@ -0,0 +1,154 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+func newSyncTestSnapshot(p currency.Pair) *orderbook.Base {
+	return &orderbook.Base{
+		Pair:         p,
+		AssetType:    "SPOT",
+		ExchangeName: "SyncTest",
+		Bids:         []orderbook.Item{{Price: 99, Amount: 1}, {Price: 100, Amount: 1}},
+		Asks:         []orderbook.Item{{Price: 102, Amount: 1}, {Price: 101, Amount: 1}},
+	}
+}
+
+func TestApplyOrderbookLevels(t *testing.T) {
+	bids := []orderbook.Item{{Price: 100, Amount: 1}, {Price: 98, Amount: 1}}
+	bids = applyOrderbookLevels(bids, []orderbook.Item{
+		{Price: 99, Amount: 2},   // Insert
+		{Price: 100, Amount: 3},  // Amend
+		{Price: 98, Amount: 0},   // Delete
+		{Price: 97, Amount: 0},   // Ghost delete
+		{Price: 101, Amount: 1},  // Insert at the top
+		{Price: 96.5, Amount: 4}, // Insert at the bottom
+	}, true)
+	expected := []orderbook.Item{{Price: 101, Amount: 1}, {Price: 100, Amount: 3},
+		{Price: 99, Amount: 2}, {Price: 96.5, Amount: 4}}
+	if len(bids) != len(expected) {
+		t.Fatalf("unexpected bids %v", bids)
+	}
+	for x := range expected {
+		if bids[x] != expected[x] {
+			t.Fatalf("unexpected bids %v", bids)
+		}
+	}
+
+	asks := applyOrderbookLevels([]orderbook.Item{{Price: 101, Amount: 1}},
+		[]orderbook.Item{{Price: 103, Amount: 1}, {Price: 102, Amount: 1}}, false)
+	if len(asks) != 3 || asks[0].Price != 101 || asks[1].Price != 102 || asks[2].Price != 103 {
+		t.Errorf("unexpected asks %v", asks)
+	}
+}
+
+func TestSyncSnapshotPendingDeltas(t *testing.T) {
+	var w WebsocketOrderbookLocal
+	p := currency.NewPairFromString("BTCUSD")
+
+	// Deltas arriving before the snapshot are held, those it covers skipped
+	for _, d := range []WebsocketOrderbookDelta{
+		{FirstUpdateID: 5, UpdateID: 9, Bids: []orderbook.Item{{Price: 100, Amount: 5}}},
+		{FirstUpdateID: 10, UpdateID: 12, Asks: []orderbook.Item{{Price: 101, Amount: 0}}},
+	} {
+		d.Pair, d.AssetType = p, "SPOT"
+		err := w.ApplyDelta(&d)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := w.GetOrderbook(p, "SPOT"); err == nil {
+		t.Fatal("expected the orderbook not to be synced before its snapshot")
+	}
+
+	err := w.SyncSnapshot(newSyncTestSnapshot(p), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	book, err := w.GetOrderbook(p, "SPOT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.Bids[0].Price != 100 || book.Bids[0].Amount != 1 {
+		t.Errorf("expected the covered delta to be skipped, got bids %v", book.Bids)
+	}
+	if len(book.Asks) != 1 || book.Asks[0].Price != 102 {
+		t.Errorf("expected the pending delta to be applied, got asks %v", book.Asks)
+	}
+
+	stored, err := orderbook.Get("SyncTest", p, "SPOT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Asks) != 1 || stored.Bids[0].Price != 100 {
+		t.Errorf("expected the synced orderbook to be stored, got %+v", stored)
+	}
+}
+
+func TestApplyDeltaSequenceGap(t *testing.T) {
+	var w WebsocketOrderbookLocal
+	p := currency.NewPairFromString("LTCUSD")
+	var resynced int
+	w.SetResync(func(rp currency.Pair, assetType string) error {
+		resynced++
+		return w.SyncSnapshot(newSyncTestSnapshot(rp), 20)
+	})
+
+	err := w.SyncSnapshot(newSyncTestSnapshot(p), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.ApplyDelta(&WebsocketOrderbookDelta{Pair: p, AssetType: "SPOT",
+		UpdateID: 11, Bids: []orderbook.Item{{Price: 100, Amount: 2}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.ApplyDelta(&WebsocketOrderbookDelta{Pair: p, AssetType: "SPOT",
+		UpdateID: 13, Bids: []orderbook.Item{{Price: 100, Amount: 3}}})
+	if err == nil {
+		t.Fatal("expected a sequence gap error")
+	}
+	if resynced != 1 {
+		t.Errorf("expected the orderbook to be resynced once, got %d", resynced)
+	}
+
+	book, err := w.GetOrderbook(p, "SPOT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.Bids[0].Amount != 1 {
+		t.Errorf("expected the resynced snapshot, got bids %v", book.Bids)
+	}
+	if len(w.ob) != 1 {
+		t.Errorf("expected one cached orderbook, got %d", len(w.ob))
+	}
+}
+
+func TestApplyDeltaChecksum(t *testing.T) {
+	var w WebsocketOrderbookLocal
+	p := currency.NewPairFromString("ETHUSD")
+	w.SetChecksum(func(ob *orderbook.Base) uint32 {
+		return uint32(ob.Bids[0].Amount + ob.Asks[0].Amount)
+	})
+
+	err := w.SyncSnapshot(newSyncTestSnapshot(p), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.ApplyDelta(&WebsocketOrderbookDelta{Pair: p, AssetType: "SPOT",
+		Bids: []orderbook.Item{{Price: 100, Amount: 2}}, Checksum: 3, HasChecksum: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.ApplyDelta(&WebsocketOrderbookDelta{Pair: p, AssetType: "SPOT",
+		Bids: []orderbook.Item{{Price: 100, Amount: 4}}, Checksum: 3, HasChecksum: true})
+	if err == nil {
+		t.Fatal("expected a checksum error")
+	}
+	if _, err = w.GetOrderbook(p, "SPOT"); err == nil {
+		t.Error("expected the orderbook to be dropped after a checksum mismatch")
+	}
+}
@@ -1,6 +1,7 @@
 package exchange
 
 import (
+	"os"
 	"sync"
 	"time"
 
@@ -19,6 +20,7 @@ const (
 	WebsocketAllowsRequests
 	WebsocketSubscribeSupported
 	WebsocketUnsubscribeSupported
+	WebsocketSubmitOrderSupported
 
 	WebsocketTickerSupportedText      = "TICKER STREAMING SUPPORTED"
 	WebsocketOrderbookSupportedText   = "ORDERBOOK STREAMING SUPPORTED"
@@ -30,6 +32,7 @@ const (
 	UnknownWebsocketFunctionality     = "UNKNOWN FUNCTIONALITY BITMASK"
 	WebsocketSubscribeSupportedText   = "WEBSOCKET SUBSCRIBE SUPPORTED"
 	WebsocketUnsubscribeSupportedText = "WEBSOCKET UNSUBSCRIBE SUPPORTED"
+	WebsocketSubmitOrderSupportedText = "WEBSOCKET ORDER ENTRY SUPPORTED"
 
 	// WebsocketNotEnabled alerts of a disabled websocket
 	WebsocketNotEnabled = "exchange_websocket_not_enabled"
@@ -89,6 +92,28 @@ type Websocket struct {
 	TrafficAlert chan struct{}
 	// Functionality defines websocket stream capabilities
 	Functionality uint32
+
+	capture     *os.File
+	captureLock sync.Mutex
+
+	heartbeat     WebsocketHeartbeat
+	lastTraffic   time.Time
+	heartbeatLock sync.Mutex
+}
+
+// WebsocketHeartbeat defines how an exchanges websocket connection is kept
+// alive. Payload is written through Send every Interval, an Interval of zero
+// sends nothing for exchanges which ping the client instead. IsPong matches
+// replies to the sent payload and Reply returns the response to a server ping,
+// or nil when a message is not one. A connection which receives no traffic
+// for Timeout is reset, a Timeout of zero disables the reset
+type WebsocketHeartbeat struct {
+	Interval time.Duration
+	Timeout  time.Duration
+	Payload  []byte
+	Send     func(payload []byte) error
+	IsPong   func(raw []byte) bool
+	Reply    func(raw []byte) []byte
 }
 
 // WebsocketChannelSubscription container for websocket subscriptions
@@ -104,6 +129,9 @@ type WebsocketChannelSubscription struct {
 type WebsocketOrderbookLocal struct {
 	ob          []*orderbook.Base
 	lastUpdated time.Time
+	synced      map[string]*websocketOrderbookSync
+	checksum    func(ob *orderbook.Base) uint32
+	resync      func(p currency.Pair, assetType string) error
 	m           sync.Mutex
 }
 
@@ -163,6 +191,21 @@ type KlineData struct {
 	Volume     float64
 }
 
+// LiquidationEvent defines a forced liquidation on a derivatives exchange.
+// Side is the side of the liquidation order, a liquidated long position
+// results in a sell. Amount is the number of contracts liquidated and Value
+// their notional value in the contracts quote currency
+type LiquidationEvent struct {
+	Timestamp time.Time
+	Pair      currency.Pair
+	AssetType string
+	Exchange  string
+	Side      string
+	Price     float64
+	Amount    float64
+	Value     float64
+}
+
 // WebsocketPositionUpdated reflects a change in orders/contracts on an exchange
 type WebsocketPositionUpdated struct {
 	Timestamp time.Time
@@ -52,6 +52,16 @@ type EXMO struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "EXMO",
+		Creator: func() exchange.IBotExchange {
+			return new(EXMO)
+		},
+		Capabilities: exchange.SpotCapability,
+	})
+}
+
 // SetDefaults sets the basic defaults for exmo
 func (e *EXMO) SetDefaults() {
 	e.Name = "EXMO"
@@ -87,8 +97,10 @@ func (e *EXMO) Setup(exch *config.ExchangeConfig) {
 		e.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		e.SetHTTPClientTimeout(exch.HTTPTimeout)
 		e.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		e.SetHTTPHeaders(exch.HTTPHeaders)
 		e.RESTPollingDelay = exch.RESTPollingDelay
 		e.Verbose = exch.Verbose
+		e.ReadOnly = exch.ReadOnly
 		e.BaseCurrencies = exch.BaseCurrencies
 		e.AvailablePairs = exch.AvailablePairs
 		e.EnabledPairs = exch.EnabledPairs
@@ -378,8 +390,7 @@ func (e *EXMO) SendHTTPRequest(path string, result interface{}) error {
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request
 func (e *EXMO) SendAuthenticatedHTTPRequest(method, endpoint string, vals url.Values, result interface{}) error {
 	if !e.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet,
-			e.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	n := e.Requester.GetNonce(true).String()
@@ -447,7 +458,7 @@ func getCryptocurrencyWithdrawalFee(c currency.Code) float64 {
 }
 
 func calculateTradingFee(price, amount float64) float64 {
-	return 0.002 * price * amount
+	return exchange.CalculateTradingFee(0.002, price, amount)
 }
 
 func getInternationalBankWithdrawalFee(c currency.Code, amount float64, bankTransactionType exchange.InternationalBankTransactionType) float64 {
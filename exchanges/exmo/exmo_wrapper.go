@@ -11,6 +11,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -32,26 +33,27 @@ func (e *EXMO) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", e.GetName(), len(e.EnabledPairs), e.EnabledPairs)
 	}
 
-	exchangeProducts, err := e.GetPairSettings()
+	err := e.UpdateTradablePairs(false)
 	if err != nil {
-		log.Errorf("%s Failed to get available products.\n", e.GetName())
-	} else {
-		var currencies []string
-		for x := range exchangeProducts {
-			currencies = append(currencies, x)
-		}
+		log.Errorf("%s failed to update tradable pairs. Err: %s", e.Name, err)
+	}
+}
 
-		var newCurrencies currency.Pairs
-		for _, p := range currencies {
-			newCurrencies = append(newCurrencies,
-				currency.NewPairFromString(p))
-		}
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (e *EXMO) UpdateTradablePairs(forceUpdate bool) error {
+	exchangeProducts, err := e.GetPairSettings()
+	if err != nil {
+		return err
+	}
 
-		err = e.UpdateCurrencies(newCurrencies, false, false)
-		if err != nil {
-			log.Errorf("%s Failed to update available currencies.\n", e.GetName())
-		}
+	var newCurrencies currency.Pairs
+	for x := range exchangeProducts {
+		newCurrencies = append(newCurrencies,
+			currency.NewPairFromString(x))
 	}
+
+	return e.UpdateCurrencies(newCurrencies, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -167,17 +169,10 @@ func (e *EXMO) GetAccountInfo() (exchange.AccountInfo, error) {
 
 	var currencies []exchange.AccountCurrencyInfo
 	for x, y := range result.Balances {
-		var exchangeCurrency exchange.AccountCurrencyInfo
-		exchangeCurrency.CurrencyName = currency.NewCode(x)
-		for z, w := range result.Reserved {
-			if z == x {
-				avail, _ := strconv.ParseFloat(y, 64)
-				reserved, _ := strconv.ParseFloat(w, 64)
-				exchangeCurrency.TotalValue = avail + reserved
-				exchangeCurrency.Hold = reserved
-			}
-		}
-		currencies = append(currencies, exchangeCurrency)
+		avail, _ := strconv.ParseFloat(y, 64)
+		reserved, _ := strconv.ParseFloat(result.Reserved[x], 64)
+		currencies = append(currencies,
+			exchange.NewAccountCurrencyInfo(currency.NewCode(x), avail, reserved))
 	}
 
 	response.Accounts = append(response.Accounts, exchange.Account{
@@ -201,8 +196,21 @@ func (e *EXMO) GetExchangeHistory(p currency.Pair, assetType string) ([]exchange
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive
+func (e *EXMO) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (e *EXMO) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order
 func (e *EXMO) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if e.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var oT string
 
@@ -234,11 +242,17 @@ func (e *EXMO) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType e
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (e *EXMO) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if e.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (e *EXMO) CancelOrder(order *exchange.OrderCancellation) error {
+	if e.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -250,9 +264,10 @@ func (e *EXMO) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (e *EXMO) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if e.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	openOrders, err := e.GetOpenOrders()
 	if err != nil {
 		return cancelAllOrdersResponse, err
@@ -260,9 +275,7 @@ func (e *EXMO) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAl
 
 	for _, order := range openOrders {
 		err = e.CancelExistingOrder(order.OrderID)
-		if err != nil {
-			cancelAllOrdersResponse.OrderStatus[strconv.FormatInt(order.OrderID, 10)] = err.Error()
-		}
+		cancelAllOrdersResponse.Add(strconv.FormatInt(order.OrderID, 10), err)
 	}
 
 	return cancelAllOrdersResponse, nil
@@ -292,6 +305,9 @@ func (e *EXMO) GetDepositAddress(cryptocurrency currency.Code, _ string) (string
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (e *EXMO) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if e.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	resp, err := e.WithdrawCryptocurrency(withdrawRequest.Currency.String(), withdrawRequest.Address, withdrawRequest.AddressTag, withdrawRequest.Amount)
 
 	return fmt.Sprintf("%v", resp), err
@@ -300,12 +316,18 @@ func (e *EXMO) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawReq
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (e *EXMO) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if e.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (e *EXMO) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if e.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -345,6 +367,7 @@ func (e *EXMO) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([]e
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
@@ -386,6 +409,7 @@ func (e *EXMO) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) ([]e
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
@@ -0,0 +1,160 @@
+// Package faults injects simulated network faults into the requests and
+// websocket connections of an exchange, so reconnection, retry and circuit
+// breaker logic can be exercised. Faults are only injected in builds with the
+// faultinjection tag, and are drawn from a seeded source so a sequence of
+// requests sees the same faults on every run
+package faults
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotSupported is returned when faults are set in a build without the
+// faultinjection tag
+var ErrNotSupported = errors.New("fault injection requires a build with the faultinjection tag")
+
+// ErrConnectionDropped is returned by requests and websocket connections with
+// an injected dropped connection
+var ErrConnectionDropped = errors.New("simulated fault: connection dropped")
+
+// DefaultStatusCodes are the HTTP status codes of injected error bursts when
+// none are configured
+var DefaultStatusCodes = []int{http.StatusTooManyRequests, http.StatusInternalServerError}
+
+// Config defines the faults injected into an exchange. Latency up to
+// MaxLatency is added to each request and websocket connection. DropRate is
+// the probability a request or websocket connection is dropped and ErrorRate
+// the probability a burst of BurstLength requests fail with one of the
+// StatusCodes
+type Config struct {
+	Seed        int64
+	MaxLatency  time.Duration
+	DropRate    float64
+	ErrorRate   float64
+	BurstLength int
+	StatusCodes []int
+}
+
+// Fault is a fault injected into a single request or websocket event. A zero
+// StatusCode is a successful response
+type Fault struct {
+	Latency    time.Duration
+	Drop       bool
+	StatusCode int
+}
+
+// injector draws the faults of an exchange. Requests and websocket events
+// have separate sources so the faults of one don't shift the other
+type injector struct {
+	cfg       Config
+	requests  *rand.Rand
+	websocket *rand.Rand
+	burst     int
+	burstCode int
+	m         sync.Mutex
+}
+
+var injectors = struct {
+	exchanges map[string]*injector
+	m         sync.RWMutex
+}{
+	exchanges: make(map[string]*injector),
+}
+
+// Set sets the faults injected into an exchange, restarting its fault
+// sequence
+func Set(exchName string, cfg Config) error {
+	if !Supported {
+		return ErrNotSupported
+	}
+	if cfg.DropRate < 0 || cfg.DropRate > 1 || cfg.ErrorRate < 0 || cfg.ErrorRate > 1 {
+		return errors.New("fault rates must be between 0 and 1")
+	}
+	if cfg.MaxLatency < 0 {
+		return errors.New("fault latency cannot be negative")
+	}
+	if cfg.BurstLength <= 0 {
+		cfg.BurstLength = 1
+	}
+	if len(cfg.StatusCodes) == 0 {
+		cfg.StatusCodes = DefaultStatusCodes
+	}
+
+	injectors.m.Lock()
+	injectors.exchanges[strings.ToLower(exchName)] = &injector{
+		cfg:       cfg,
+		requests:  rand.New(rand.NewSource(cfg.Seed)),
+		websocket: rand.New(rand.NewSource(cfg.Seed + 1)),
+	}
+	injectors.m.Unlock()
+	return nil
+}
+
+// Remove stops injecting faults into an exchange
+func Remove(exchName string) {
+	injectors.m.Lock()
+	delete(injectors.exchanges, strings.ToLower(exchName))
+	injectors.m.Unlock()
+}
+
+// get returns the fault injector of an exchange
+func get(exchName string) (*injector, bool) {
+	if !Supported {
+		return nil, false
+	}
+	injectors.m.RLock()
+	i, ok := injectors.exchanges[strings.ToLower(exchName)]
+	injectors.m.RUnlock()
+	return i, ok
+}
+
+// latency returns a random latency up to the configured maximum
+func (i *injector) latency(r *rand.Rand) time.Duration {
+	if i.cfg.MaxLatency <= 0 {
+		return 0
+	}
+	return time.Duration(r.Int63n(int64(i.cfg.MaxLatency) + 1))
+}
+
+// Request returns the fault injected into the next request of an exchange
+func Request(exchName string) Fault {
+	i, ok := get(exchName)
+	if !ok {
+		return Fault{}
+	}
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	fault := Fault{Latency: i.latency(i.requests)}
+	if i.burst == 0 && i.requests.Float64() < i.cfg.ErrorRate {
+		i.burst = i.cfg.BurstLength
+		i.burstCode = i.cfg.StatusCodes[i.requests.Intn(len(i.cfg.StatusCodes))]
+	}
+	if i.burst > 0 {
+		i.burst--
+		fault.StatusCode = i.burstCode
+		return fault
+	}
+	fault.Drop = i.requests.Float64() < i.cfg.DropRate
+	return fault
+}
+
+// Websocket returns the fault injected into the next websocket connection or
+// message of an exchange. Only latency and dropped connections are injected
+func Websocket(exchName string) Fault {
+	i, ok := get(exchName)
+	if !ok {
+		return Fault{}
+	}
+	i.m.Lock()
+	defer i.m.Unlock()
+	return Fault{
+		Latency: i.latency(i.websocket),
+		Drop:    i.websocket.Float64() < i.cfg.DropRate,
+	}
+}
@@ -0,0 +1,88 @@
+package faults
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSet(t *testing.T) {
+	err := Set("test", Config{DropRate: 0.5})
+	if !Supported {
+		if err != ErrNotSupported {
+			t.Fatalf("expected faults to be unsupported without the build tag, got %v", err)
+		}
+		if fault := Request("test"); fault.Drop || fault.StatusCode != 0 || fault.Latency != 0 {
+			t.Fatalf("expected no faults injected without the build tag, got %+v", fault)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Remove("test")
+
+	if Set("test", Config{DropRate: 2}) == nil || Set("test", Config{MaxLatency: -1}) == nil {
+		t.Fatal("expected invalid fault config to be rejected")
+	}
+	Remove("test")
+	if fault := Request("test"); fault.Drop {
+		t.Fatal("expected no faults injected once removed")
+	}
+}
+
+func TestRequest(t *testing.T) {
+	if !Supported {
+		t.Skip("fault injection requires the faultinjection build tag")
+	}
+	cfg := Config{
+		Seed:        1337,
+		MaxLatency:  time.Millisecond * 50,
+		DropRate:    0.2,
+		ErrorRate:   0.1,
+		BurstLength: 3,
+	}
+	sequence := func() []Fault {
+		err := Set("Test", cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		faults := make([]Fault, 200)
+		for x := range faults {
+			faults[x] = Request("test")
+		}
+		return faults
+	}
+	defer Remove("test")
+
+	first, second := sequence(), sequence()
+	var drops, errs int
+	for x := range first {
+		if first[x] != second[x] {
+			t.Fatalf("expected the same faults from the same seed, request %d got %+v and %+v",
+				x, first[x], second[x])
+		}
+		if first[x].Latency < 0 || first[x].Latency > cfg.MaxLatency {
+			t.Fatalf("unexpected latency %v", first[x].Latency)
+		}
+		if first[x].Drop {
+			drops++
+		}
+		if first[x].StatusCode != 0 {
+			errs++
+			if first[x].StatusCode != http.StatusTooManyRequests &&
+				first[x].StatusCode != http.StatusInternalServerError {
+				t.Fatalf("unexpected status code %d", first[x].StatusCode)
+			}
+		}
+	}
+	if drops == 0 || errs == 0 {
+		t.Errorf("expected dropped requests and error bursts, got %d drops and %d errors",
+			drops, errs)
+	}
+
+	ws := Websocket("test")
+	if ws.StatusCode != 0 {
+		t.Error("expected websocket faults without status codes")
+	}
+}
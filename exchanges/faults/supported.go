@@ -0,0 +1,8 @@
+//go:build faultinjection
+// +build faultinjection
+
+package faults
+
+// Supported is true in builds with the faultinjection tag, which inject
+// configured faults
+const Supported = true
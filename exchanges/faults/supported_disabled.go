@@ -0,0 +1,8 @@
+//go:build !faultinjection
+// +build !faultinjection
+
+package faults
+
+// Supported is false in builds without the faultinjection tag, which never
+// inject faults
+const Supported = false
@@ -39,6 +39,7 @@ const (
 	gateioTicker          = "ticker"
 	gateioTickers         = "tickers"
 	gateioOrderbook       = "orderBook"
+	gateioMarketTrades    = "tradeHistory"
 
 	gateioAuthRate   = 100
 	gateioUnauthRate = 100
@@ -53,6 +54,17 @@ type Gateio struct {
 	wsRequestMtx sync.Mutex
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "GateIO",
+		Creator: func() exchange.IBotExchange {
+			return new(Gateio)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability,
+	})
+}
+
 // SetDefaults sets default values for the exchange
 func (g *Gateio) SetDefaults() {
 	g.Name = "GateIO"
@@ -68,6 +80,7 @@ func (g *Gateio) SetDefaults() {
 	g.AssetTypes = []string{ticker.Spot}
 	g.SupportsAutoPairUpdating = true
 	g.SupportsRESTTickerBatching = true
+	g.SupportsKlines = true
 	g.Requester = request.New(g.Name,
 		request.NewRateLimit(time.Second*10, gateioAuthRate),
 		request.NewRateLimit(time.Second*10, gateioUnauthRate),
@@ -96,8 +109,10 @@ func (g *Gateio) Setup(exch *config.ExchangeConfig) {
 		g.APIAuthPEMKey = exch.APIAuthPEMKey
 		g.SetHTTPClientTimeout(exch.HTTPTimeout)
 		g.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		g.SetHTTPHeaders(exch.HTTPHeaders)
 		g.RESTPollingDelay = exch.RESTPollingDelay
 		g.Verbose = exch.Verbose
+		g.ReadOnly = exch.ReadOnly
 		g.BaseCurrencies = exch.BaseCurrencies
 		g.AvailablePairs = exch.AvailablePairs
 		g.EnabledPairs = exch.EnabledPairs
@@ -216,6 +231,25 @@ func (g *Gateio) GetTickers() (map[string]TickerResponse, error) {
 	return resp, nil
 }
 
+// GetMarketTrades returns the latest public trades of a symbol, or up to 1000
+// trades after a trade ID when fromTID is set
+func (g *Gateio) GetMarketTrades(symbol string, fromTID int64) ([]MarketTrade, error) {
+	urlPath := fmt.Sprintf("%s/%s/%s/%s", g.APIUrlSecondary, gateioAPIVersion, gateioMarketTrades, symbol)
+	if fromTID > 0 {
+		urlPath += "/" + strconv.FormatInt(fromTID, 10)
+	}
+
+	var resp MarketTradesResponse
+	err := g.SendHTTPRequest(urlPath, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Result != "true" {
+		return nil, errors.New("result was not true")
+	}
+	return resp.Data, nil
+}
+
 // GetOrderbook returns the orderbook data for a suppled symbol
 func (g *Gateio) GetOrderbook(symbol string) (Orderbook, error) {
 	urlPath := fmt.Sprintf("%s/%s/%s/%s", g.APIUrlSecondary, gateioAPIVersion, gateioOrderbook, symbol)
@@ -477,8 +511,7 @@ func (g *Gateio) GenerateSignature(message string) []byte {
 // To use this you must setup an APIKey and APISecret from the exchange
 func (g *Gateio) SendAuthenticatedHTTPRequest(method, endpoint, param string, result interface{}) error {
 	if !g.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet,
-			g.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	headers := make(map[string]string)
@@ -571,7 +604,7 @@ func getOfflineTradeFee(price, amount float64) float64 {
 }
 
 func calculateTradingFee(feeForPair, purchasePrice, amount float64) float64 {
-	return (feeForPair / 100) * purchasePrice * amount
+	return exchange.CalculateTradingFee(feeForPair/100, purchasePrice, amount)
 }
 
 func getCryptocurrencyWithdrawalFee(c currency.Code) float64 {
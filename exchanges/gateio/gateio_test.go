@@ -121,6 +121,14 @@ func TestGetTickers(t *testing.T) {
 	}
 }
 
+func TestGetMarketTrades(t *testing.T) {
+	t.Parallel()
+	_, err := g.GetMarketTrades("btc_usdt", 0)
+	if err != nil {
+		t.Errorf("Test failed - Gateio GetMarketTrades: %s", err)
+	}
+}
+
 func TestGetOrderbook(t *testing.T) {
 	t.Parallel()
 	_, err := g.GetOrderbook("btc_usdt")
@@ -381,8 +389,8 @@ func TestCancelAllExchangeOrders(t *testing.T) {
 		t.Errorf("Could not cancel orders: %v", err)
 	}
 
-	if len(resp.OrderStatus) > 0 {
-		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
+	if len(resp.Failed()) > 0 {
+		t.Errorf("%v orders failed to cancel", len(resp.Failed()))
 	}
 }
 
@@ -35,6 +35,21 @@ var (
 	TimeIntervalDay            = TimeInterval(60 * 60 * 24)
 )
 
+// klineIntervals are the candle durations in seconds the kline endpoint
+// accepts
+var klineIntervals = []TimeInterval{
+	TimeIntervalMinute,
+	TimeIntervalThreeMinutes,
+	TimeIntervalFiveMinutes,
+	TimeIntervalFifteenMinutes,
+	TimeIntervalThirtyMinutes,
+	TimeIntervalHour,
+	TimeIntervalTwoHours,
+	TimeIntervalFourHours,
+	TimeIntervalSixHours,
+	TimeIntervalDay,
+}
+
 // IDs for requests
 const (
 	IDGeneric    = 0000
@@ -108,6 +123,24 @@ type OrderbookResponse struct {
 	Bids    [][]string
 }
 
+// MarketTradesResponse holds the public trades of a symbol
+type MarketTradesResponse struct {
+	Result  string        `json:"result"`
+	Elapsed string        `json:"elapsed"`
+	Data    []MarketTrade `json:"data"`
+}
+
+// MarketTrade holds a public trade
+type MarketTrade struct {
+	TradeID   int64   `json:"tradeID,string"`
+	Date      string  `json:"date"`
+	Timestamp int64   `json:"timestamp,string"`
+	Type      string  `json:"type"`
+	Rate      float64 `json:"rate,string"`
+	Amount    float64 `json:"amount,string"`
+	Total     float64 `json:"total,string"`
+}
+
 // OrderbookItem stores an orderbook item
 type OrderbookItem struct {
 	Price  float64
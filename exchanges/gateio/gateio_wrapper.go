@@ -3,6 +3,7 @@ package gateio
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
@@ -11,6 +12,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -33,21 +35,27 @@ func (g *Gateio) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", g.GetName(), len(g.EnabledPairs), g.EnabledPairs)
 	}
 
+	err := g.UpdateTradablePairs(false)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", g.Name, err)
+	}
+}
+
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (g *Gateio) UpdateTradablePairs(forceUpdate bool) error {
 	symbols, err := g.GetSymbols()
 	if err != nil {
-		log.Errorf("%s Unable to fetch symbols.\n", g.GetName())
-	} else {
-		var newCurrencies currency.Pairs
-		for _, p := range symbols {
-			newCurrencies = append(newCurrencies,
-				currency.NewPairFromString(p))
-		}
+		return err
+	}
 
-		err = g.UpdateCurrencies(newCurrencies, false, false)
-		if err != nil {
-			log.Errorf("%s Failed to update available currencies.\n", g.GetName())
-		}
+	var newCurrencies currency.Pairs
+	for _, p := range symbols {
+		newCurrencies = append(newCurrencies,
+			currency.NewPairFromString(p))
 	}
+
+	return g.UpdateCurrencies(newCurrencies, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -138,6 +146,15 @@ func (g *Gateio) GetAccountInfo() (exchange.AccountInfo, error) {
 	}
 
 	var balances []exchange.AccountCurrencyInfo
+	add := func(detail exchange.AccountCurrencyInfo) {
+		for i := range balances {
+			if balances[i].CurrencyName == detail.CurrencyName {
+				balances[i] = balances[i].Add(detail)
+				return
+			}
+		}
+		balances = append(balances, detail)
+	}
 
 	switch l := balance.Locked.(type) {
 	case map[string]interface{}:
@@ -146,11 +163,7 @@ func (g *Gateio) GetAccountInfo() (exchange.AccountInfo, error) {
 			if err != nil {
 				return info, err
 			}
-
-			balances = append(balances, exchange.AccountCurrencyInfo{
-				CurrencyName: currency.NewCode(x),
-				Hold:         lockedF,
-			})
+			add(exchange.NewAccountCurrencyInfo(currency.NewCode(x), 0, lockedF))
 		}
 	default:
 		break
@@ -163,21 +176,7 @@ func (g *Gateio) GetAccountInfo() (exchange.AccountInfo, error) {
 			if err != nil {
 				return info, err
 			}
-
-			var updated bool
-			for i := range balances {
-				if balances[i].CurrencyName == currency.NewCode(x) {
-					balances[i].TotalValue = balances[i].Hold + availAmount
-					updated = true
-					break
-				}
-			}
-			if !updated {
-				balances = append(balances, exchange.AccountCurrencyInfo{
-					CurrencyName: currency.NewCode(x),
-					TotalValue:   availAmount,
-				})
-			}
+			add(exchange.NewAccountCurrencyInfo(currency.NewCode(x), availAmount, 0))
 		}
 	default:
 		break
@@ -206,9 +205,77 @@ func (g *Gateio) GetExchangeHistory(p currency.Pair, assetType string) ([]exchan
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive. The
+// kline endpoint returns the candles of the most recent hours, so the hours
+// back to start are requested and trimmed to the range
+func (g *Gateio) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	groupSec := TimeInterval(interval / time.Second)
+	supported := false
+	for x := range klineIntervals {
+		if time.Duration(klineIntervals[x])*time.Second == interval {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, fmt.Errorf("%s unsupported kline interval %s", g.Name, interval)
+	}
+
+	hours := int(math.Ceil(time.Since(start).Hours()))
+	if hours < 1 {
+		hours = 1
+	}
+	resp, err := g.GetSpotKline(KlinesRequestParams{
+		Symbol:   exchange.FormatExchangeCurrency(g.Name, p).String(),
+		HourSize: hours,
+		GroupSec: groupSec,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]kline.Candle, len(resp))
+	for x := range resp {
+		candles[x] = kline.Candle{
+			Time:   resp[x].KlineTime,
+			Open:   resp[x].Open,
+			High:   resp[x].High,
+			Low:    resp[x].Low,
+			Close:  resp[x].Close,
+			Volume: resp[x].Volume,
+		}
+	}
+	return kline.FilterCandles(candles, start, end), nil
+}
+
+// GetHistoricTrades returns trades between start and end inclusive, oldest
+// first. Only the most recent trades are available
+func (g *Gateio) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	resp, err := g.GetMarketTrades(exchange.FormatExchangeCurrency(g.Name, p).String(), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	trades := make([]exchange.TradeHistory, len(resp))
+	for x := range resp {
+		trades[x] = exchange.TradeHistory{
+			Timestamp: time.Unix(resp[x].Timestamp, 0),
+			TID:       resp[x].TradeID,
+			Price:     resp[x].Rate,
+			Amount:    resp[x].Amount,
+			Exchange:  g.Name,
+			Type:      strings.ToUpper(resp[x].Type),
+		}
+	}
+	return exchange.FilterTradeHistory(trades, start, end), nil
+}
+
 // SubmitOrder submits a new order
 // TODO: support multiple order types (IOC)
 func (g *Gateio) SubmitOrder(p currency.Pair, side exchange.OrderSide, _ exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if g.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var orderTypeFormat SpotNewOrderRequestParamsType
 
@@ -241,11 +308,17 @@ func (g *Gateio) SubmitOrder(p currency.Pair, side exchange.OrderSide, _ exchang
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (g *Gateio) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if g.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (g *Gateio) CancelOrder(order *exchange.OrderCancellation) error {
+	if g.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -258,23 +331,27 @@ func (g *Gateio) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (g *Gateio) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if g.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	openOrders, err := g.GetOpenOrders("")
 	if err != nil {
 		return cancelAllOrdersResponse, err
 	}
 
-	uniqueSymbols := make(map[string]string)
+	// Orders are cancelled per symbol, each order taking the result of its
+	// symbols cancellation
+	symbolOrders := make(map[string][]string)
 	for i := range openOrders.Orders {
-		uniqueSymbols[openOrders.Orders[i].CurrencyPair] = openOrders.Orders[i].CurrencyPair
+		symbol := openOrders.Orders[i].CurrencyPair
+		symbolOrders[symbol] = append(symbolOrders[symbol], openOrders.Orders[i].OrderNumber)
 	}
 
-	for unique := range uniqueSymbols {
-		err = g.CancelAllExistingOrders(-1, uniqueSymbols[unique])
-		if err != nil {
-			return cancelAllOrdersResponse, err
+	for symbol, orderIDs := range symbolOrders {
+		err = g.CancelAllExistingOrders(-1, symbol)
+		for i := range orderIDs {
+			cancelAllOrdersResponse.Add(orderIDs[i], err)
 		}
 	}
 
@@ -339,18 +416,27 @@ func (g *Gateio) GetDepositAddress(cryptocurrency currency.Code, _ string) (stri
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (g *Gateio) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if g.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return g.WithdrawCrypto(withdrawRequest.Currency.String(), withdrawRequest.Address, withdrawRequest.Amount)
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (g *Gateio) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if g.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (g *Gateio) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if g.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -404,6 +490,7 @@ func (g *Gateio) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 
@@ -439,6 +526,7 @@ func (g *Gateio) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) ([
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
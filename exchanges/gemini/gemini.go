@@ -99,6 +99,18 @@ func AddSession(g *Gemini, sessionID int, apiKey, apiSecret, role string, needsH
 	return nil
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "Gemini",
+		Creator: func() exchange.IBotExchange {
+			return new(Gemini)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability |
+			exchange.SandboxCapability,
+	})
+}
+
 // SetDefaults sets package defaults for gemini exchange
 func (g *Gemini) SetDefaults() {
 	g.Name = "Gemini"
@@ -136,8 +148,10 @@ func (g *Gemini) Setup(exch *config.ExchangeConfig) {
 		g.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		g.SetHTTPClientTimeout(exch.HTTPTimeout)
 		g.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		g.SetHTTPHeaders(exch.HTTPHeaders)
 		g.RESTPollingDelay = exch.RESTPollingDelay
 		g.Verbose = exch.Verbose
+		g.ReadOnly = exch.ReadOnly
 		g.HTTPDebugging = exch.HTTPDebugging
 		g.BaseCurrencies = exch.BaseCurrencies
 		g.AvailablePairs = exch.AvailablePairs
@@ -159,20 +173,22 @@ func (g *Gemini) Setup(exch *config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
-		if exch.UseSandbox {
-			g.APIUrl = geminiSandboxAPIURL
-		}
+		g.SetSandbox(exch, geminiSandboxAPIURL)
 		err = g.SetClientProxyAddress(exch.ProxyAddress)
 		if err != nil {
 			log.Fatal(err)
 		}
+		wsURL := geminiWebsocketEndpoint
+		if g.IsSandbox() {
+			wsURL = geminiSandboxWebsocketEndpoint
+		}
 		err = g.WebsocketSetup(g.WsConnect,
 			nil,
 			nil,
 			exch.Name,
 			exch.Websocket,
 			exch.Verbose,
-			geminiWebsocketEndpoint,
+			wsURL,
 			exch.WebsocketURL)
 		if err != nil {
 			log.Fatal(err)
@@ -277,11 +293,17 @@ func (g *Gemini) GetAuction(currencyPair string) (Auction, error) {
 //
 // currencyPair - example "btcusd"
 // params -- [optional]
-//          since - [timestamp] Only returns auction events after the specified
+//
+//	since - [timestamp] Only returns auction events after the specified
+//
 // timestamp.
-//          limit_auction_results - [integer] The maximum number of auction
+//
+//	limit_auction_results - [integer] The maximum number of auction
+//
 // events to return.
-//          include_indicative - [bool] Whether to include publication of
+//
+//	include_indicative - [bool] Whether to include publication of
+//
 // indicative prices and quantities.
 func (g *Gemini) GetAuctionHistory(currencyPair string, params url.Values) ([]AuctionHistory, error) {
 	path := common.EncodeURLValues(fmt.Sprintf("%s/v%s/%s/%s/%s", g.APIUrl, geminiAPIVersion, geminiAuction, currencyPair, geminiAuctionHistory), params)
@@ -501,7 +523,7 @@ func (g *Gemini) SendHTTPRequest(path string, result interface{}) error {
 // exchange and returns an error
 func (g *Gemini) SendAuthenticatedHTTPRequest(method, path string, params map[string]interface{}, result interface{}) (err error) {
 	if !g.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, g.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	headers := make(map[string]string)
@@ -519,7 +541,7 @@ func (g *Gemini) SendAuthenticatedHTTPRequest(method, path string, params map[st
 	}
 
 	if g.Verbose {
-		log.Debugf("Request JSON: %s", PayloadJSON)
+		log.Debugf("Request JSON: %s", log.DebugPayload(g.Name, PayloadJSON))
 	}
 
 	PayloadBase64 := common.Base64Encode(PayloadJSON)
@@ -573,5 +595,5 @@ func calculateTradingFee(notionVolume *NotionalVolume, purchasePrice, amount flo
 		volumeFee = (float64(notionVolume.TakerFee) / 100)
 	}
 
-	return volumeFee * amount * purchasePrice
+	return exchange.CalculateTradingFee(volumeFee, purchasePrice, amount)
 }
@@ -17,9 +17,10 @@ import (
 )
 
 const (
-	geminiWebsocketEndpoint = "wss://api.gemini.com/v1/marketdata/%s?%s"
-	geminiWsEvent           = "event"
-	geminiWsMarketData      = "marketdata"
+	geminiWebsocketEndpoint        = "wss://api.gemini.com/v1/marketdata/%s?%s"
+	geminiSandboxWebsocketEndpoint = "wss://api.sandbox.gemini.com/v1/marketdata/%s?%s"
+	geminiWsEvent                  = "event"
+	geminiWsMarketData             = "marketdata"
 )
 
 // Instantiates a communications channel between websocket connections
@@ -12,6 +12,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -33,21 +34,27 @@ func (g *Gemini) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", g.GetName(), len(g.EnabledPairs), g.EnabledPairs)
 	}
 
+	err := g.UpdateTradablePairs(false)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", g.Name, err)
+	}
+}
+
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (g *Gemini) UpdateTradablePairs(forceUpdate bool) error {
 	exchangeProducts, err := g.GetSymbols()
 	if err != nil {
-		log.Errorf("%s Failed to get available symbols.\n", g.GetName())
-	} else {
-		var newExchangeProducts currency.Pairs
-		for _, p := range exchangeProducts {
-			newExchangeProducts = append(newExchangeProducts,
-				currency.NewPairFromString(p))
-		}
+		return err
+	}
 
-		err = g.UpdateCurrencies(newExchangeProducts, false, false)
-		if err != nil {
-			log.Errorf("%s Failed to update available currencies.\n", g.GetName())
-		}
+	var newExchangeProducts currency.Pairs
+	for _, p := range exchangeProducts {
+		newExchangeProducts = append(newExchangeProducts,
+			currency.NewPairFromString(p))
 	}
+
+	return g.UpdateCurrencies(newExchangeProducts, false, forceUpdate)
 }
 
 // GetAccountInfo Retrieves balances for all enabled currencies for the
@@ -62,11 +69,10 @@ func (g *Gemini) GetAccountInfo() (exchange.AccountInfo, error) {
 
 	var currencies []exchange.AccountCurrencyInfo
 	for i := 0; i < len(accountBalance); i++ {
-		var exchangeCurrency exchange.AccountCurrencyInfo
-		exchangeCurrency.CurrencyName = currency.NewCode(accountBalance[i].Currency)
-		exchangeCurrency.TotalValue = accountBalance[i].Amount
-		exchangeCurrency.Hold = accountBalance[i].Available
-		currencies = append(currencies, exchangeCurrency)
+		currencies = append(currencies, exchange.NewAccountCurrencyInfoFromTotalAvailable(
+			currency.NewCode(accountBalance[i].Currency),
+			accountBalance[i].Amount,
+			accountBalance[i].Available))
 	}
 
 	response.Accounts = append(response.Accounts, exchange.Account{
@@ -157,8 +163,21 @@ func (g *Gemini) GetExchangeHistory(p currency.Pair, assetType string) ([]exchan
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive
+func (g *Gemini) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (g *Gemini) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order
 func (g *Gemini) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if g.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	response, err := g.NewOrder(p.String(),
 		amount,
@@ -180,11 +199,17 @@ func (g *Gemini) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (g *Gemini) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if g.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (g *Gemini) CancelOrder(order *exchange.OrderCancellation) error {
+	if g.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 	if err != nil {
 		return err
@@ -196,16 +221,20 @@ func (g *Gemini) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (g *Gemini) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if g.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	resp, err := g.CancelExistingOrders(false)
 	if err != nil {
 		return cancelAllOrdersResponse, err
 	}
 
+	for _, order := range resp.Details.CancelledOrders {
+		cancelAllOrdersResponse.Add(order, nil)
+	}
 	for _, order := range resp.Details.CancelRejects {
-		cancelAllOrdersResponse.OrderStatus[order] = "Could not cancel order"
+		cancelAllOrdersResponse.AddFailure(order, "", "Could not cancel order")
 	}
 
 	return cancelAllOrdersResponse, nil
@@ -229,6 +258,9 @@ func (g *Gemini) GetDepositAddress(cryptocurrency currency.Code, _ string) (stri
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (g *Gemini) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if g.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	resp, err := g.WithdrawCrypto(withdrawRequest.Address, withdrawRequest.Currency.String(), withdrawRequest.Amount)
 	if err != nil {
 		return "", err
@@ -243,12 +275,18 @@ func (g *Gemini) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawR
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (g *Gemini) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if g.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (g *Gemini) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if g.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -301,6 +339,7 @@ func (g *Gemini) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
@@ -344,6 +383,7 @@ func (g *Gemini) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) ([
 			OrderDate: orderDate,
 			OrderSide: side,
 			Fee:       trades[i].FeeAmount,
+			FeeAsset:  currency.NewCode(trades[i].FeeCurrency),
 			Price:     trades[i].Price,
 			CurrencyPair: currency.NewPairWithDelimiter(trades[i].BaseCurrency,
 				trades[i].QuoteCurrency,
@@ -351,6 +391,7 @@ func (g *Gemini) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) ([
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
@@ -49,6 +49,12 @@ const (
 
 	hitbtcAuthRate   = 0
 	hitbtcUnauthRate = 0
+
+	// hitbtcCandlesLimit is the maximum number of candles returned per request
+	hitbtcCandlesLimit = 1000
+
+	// hitbtcTradesLimit is the maximum number of trades returned per request
+	hitbtcTradesLimit = 1000
 )
 
 // HitBTC is the overarching type across the hitbtc package
@@ -58,6 +64,17 @@ type HitBTC struct {
 	wsRequestMtx  sync.Mutex
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "HitBTC",
+		Creator: func() exchange.IBotExchange {
+			return new(HitBTC)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability,
+	})
+}
+
 // SetDefaults sets default settings for hitbtc
 func (h *HitBTC) SetDefaults() {
 	h.Name = "HitBTC"
@@ -74,6 +91,8 @@ func (h *HitBTC) SetDefaults() {
 	h.AssetTypes = []string{ticker.Spot}
 	h.SupportsAutoPairUpdating = true
 	h.SupportsRESTTickerBatching = true
+	h.SupportsKlines = true
+	h.SupportsTradeHistory = true
 	h.Requester = request.New(h.Name,
 		request.NewRateLimit(time.Second, hitbtcAuthRate),
 		request.NewRateLimit(time.Second, hitbtcUnauthRate),
@@ -97,8 +116,10 @@ func (h *HitBTC) Setup(exch *config.ExchangeConfig) {
 		h.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		h.SetHTTPClientTimeout(exch.HTTPTimeout)
 		h.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		h.SetHTTPHeaders(exch.HTTPHeaders)
 		h.RESTPollingDelay = exch.RESTPollingDelay // Max 60000ms
 		h.Verbose = exch.Verbose
+		h.ReadOnly = exch.ReadOnly
 		h.HTTPDebugging = exch.HTTPDebugging
 		h.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		h.BaseCurrencies = exch.BaseCurrencies
@@ -605,8 +626,7 @@ func (h *HitBTC) SendHTTPRequest(path string, result interface{}) error {
 // SendAuthenticatedHTTPRequest sends an authenticated http request
 func (h *HitBTC) SendAuthenticatedHTTPRequest(method, endpoint string, values url.Values, result interface{}) error {
 	if !h.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet,
-			h.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 	headers := make(map[string]string)
 	headers["Authorization"] = "Basic " + common.Base64Encode([]byte(h.APIKey+":"+h.APISecret))
@@ -682,5 +702,5 @@ func calculateTradingFee(feeInfo Fee, purchasePrice, amount float64, isMaker boo
 		volumeFee = feeInfo.TakeLiquidityRate
 	}
 
-	return volumeFee * amount * purchasePrice
+	return exchange.CalculateTradingFee(volumeFee, purchasePrice, amount)
 }
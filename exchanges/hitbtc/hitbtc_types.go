@@ -80,6 +80,19 @@ type ChartData struct {
 	VolumeQuote float64   `json:"volumeQuote,string"` // Volume in quote currency
 }
 
+// klinePeriods maps candle periods to their durations
+var klinePeriods = map[string]time.Duration{
+	"M1":  time.Minute,
+	"M3":  time.Minute * 3,
+	"M5":  time.Minute * 5,
+	"M15": time.Minute * 15,
+	"M30": time.Minute * 30,
+	"H1":  time.Hour,
+	"H4":  time.Hour * 4,
+	"D1":  time.Hour * 24,
+	"D7":  time.Hour * 24 * 7,
+}
+
 // Currencies hold the full range of data for a specified currency
 type Currencies struct {
 	ID                 string `json:"id"`                 // Currency identifier.
@@ -11,9 +11,9 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
-
 	log "github.com/thrasher-/gocryptotrader/logger"
 )
 
@@ -34,43 +34,45 @@ func (h *HitBTC) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", h.GetName(), len(h.EnabledPairs), h.EnabledPairs)
 	}
 
-	exchangeProducts, err := h.GetSymbolsDetailed()
-	if err != nil {
-		log.Errorf("%s Failed to get available symbols.\n", h.GetName())
-	} else {
-		forceUpgrade := false
-		if !common.StringDataContains(h.EnabledPairs.Strings(), "-") ||
-			!common.StringDataContains(h.AvailablePairs.Strings(), "-") {
-			forceUpgrade = true
-		}
-		var currencies []string
-		for x := range exchangeProducts {
-			currencies = append(currencies, exchangeProducts[x].BaseCurrency+"-"+exchangeProducts[x].QuoteCurrency)
-		}
+	forceUpgrade := false
+	if !common.StringDataContains(h.EnabledPairs.Strings(), "-") ||
+		!common.StringDataContains(h.AvailablePairs.Strings(), "-") {
+		forceUpgrade = true
+	}
 
-		if forceUpgrade {
-			enabledPairs := currency.Pairs{currency.Pair{Base: currency.BTC,
-				Quote: currency.USD, Delimiter: "-"}}
+	if forceUpgrade {
+		enabledPairs := currency.Pairs{currency.Pair{Base: currency.BTC,
+			Quote: currency.USD, Delimiter: "-"}}
 
-			log.Warn("Available pairs for HitBTC reset due to config upgrade, please enable the ones you would like again.")
+		log.Warn("Available pairs for HitBTC reset due to config upgrade, please enable the ones you would like again.")
 
-			err = h.UpdateCurrencies(enabledPairs, true, true)
-			if err != nil {
-				log.Errorf("%s Failed to update enabled currencies.\n", h.GetName())
-			}
+		err := h.UpdateCurrencies(enabledPairs, true, true)
+		if err != nil {
+			log.Errorf("%s Failed to update enabled currencies.\n", h.GetName())
 		}
+	}
 
-		var newCurrencies currency.Pairs
-		for _, p := range currencies {
-			newCurrencies = append(newCurrencies,
-				currency.NewPairFromString(p))
-		}
+	err := h.UpdateTradablePairs(forceUpgrade)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", h.Name, err)
+	}
+}
 
-		err = h.UpdateCurrencies(newCurrencies, false, forceUpgrade)
-		if err != nil {
-			log.Errorf("%s Failed to update available currencies.\n", h.GetName())
-		}
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (h *HitBTC) UpdateTradablePairs(forceUpdate bool) error {
+	exchangeProducts, err := h.GetSymbolsDetailed()
+	if err != nil {
+		return err
+	}
+
+	var newCurrencies currency.Pairs
+	for x := range exchangeProducts {
+		newCurrencies = append(newCurrencies,
+			currency.NewPairFromString(exchangeProducts[x].BaseCurrency+"-"+exchangeProducts[x].QuoteCurrency))
 	}
+
+	return h.UpdateCurrencies(newCurrencies, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -159,11 +161,8 @@ func (h *HitBTC) GetAccountInfo() (exchange.AccountInfo, error) {
 
 	var currencies []exchange.AccountCurrencyInfo
 	for _, item := range accountBalance {
-		var exchangeCurrency exchange.AccountCurrencyInfo
-		exchangeCurrency.CurrencyName = currency.NewCode(item.Currency)
-		exchangeCurrency.TotalValue = item.Available
-		exchangeCurrency.Hold = item.Reserved
-		currencies = append(currencies, exchangeCurrency)
+		currencies = append(currencies, exchange.NewAccountCurrencyInfo(
+			currency.NewCode(item.Currency), item.Available, item.Reserved))
 	}
 
 	response.Accounts = append(response.Accounts, exchange.Account{
@@ -187,8 +186,83 @@ func (h *HitBTC) GetExchangeHistory(p currency.Pair, assetType string) ([]exchan
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive. The
+// candles endpoint returns a number of the most recent candles, so enough are
+// requested to reach back to start and trimmed to the range. Candles without
+// trades are not returned
+func (h *HitBTC) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	var period string
+	for k, v := range klinePeriods {
+		if v == interval {
+			period = k
+			break
+		}
+	}
+	if period == "" {
+		return nil, fmt.Errorf("%s unsupported kline interval %s", h.Name, interval)
+	}
+
+	limit := int(time.Since(start)/interval) + 1
+	if limit > hitbtcCandlesLimit {
+		limit = hitbtcCandlesLimit
+	}
+	resp, err := h.GetCandles(exchange.FormatExchangeCurrency(h.Name, p).String(),
+		strconv.Itoa(limit), period)
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]kline.Candle, len(resp))
+	for x := range resp {
+		candles[x] = kline.Candle{
+			Time:   resp[x].Timestamp,
+			Open:   resp[x].Open,
+			High:   resp[x].Max,
+			Low:    resp[x].Min,
+			Close:  resp[x].Close,
+			Volume: resp[x].Volume,
+		}
+	}
+	return kline.FilterCandles(candles, start, end), nil
+}
+
+// GetHistoricTrades returns trades between start and end inclusive, oldest
+// first
+func (h *HitBTC) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	resp, err := h.GetTrades(exchange.FormatExchangeCurrency(h.Name, p).String(),
+		start.UTC().Format(time.RFC3339Nano),
+		end.UTC().Format(time.RFC3339Nano),
+		strconv.Itoa(hitbtcTradesLimit),
+		"",
+		"timestamp",
+		"ASC")
+	if err != nil {
+		return nil, err
+	}
+
+	trades := make([]exchange.TradeHistory, len(resp))
+	for x := range resp {
+		timestamp, err := time.Parse(time.RFC3339, resp[x].Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		trades[x] = exchange.TradeHistory{
+			Timestamp: timestamp,
+			TID:       resp[x].ID,
+			Price:     resp[x].Price,
+			Amount:    resp[x].Quantity,
+			Exchange:  h.Name,
+			Type:      strings.ToUpper(resp[x].Side),
+		}
+	}
+	return trades, nil
+}
+
 // SubmitOrder submits a new order
 func (h *HitBTC) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if h.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	response, err := h.PlaceOrder(p.String(),
 		price,
@@ -210,11 +284,17 @@ func (h *HitBTC) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (h *HitBTC) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if h.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (h *HitBTC) CancelOrder(order *exchange.OrderCancellation) error {
+	if h.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -228,16 +308,24 @@ func (h *HitBTC) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (h *HitBTC) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if h.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	resp, err := h.CancelAllExistingOrders()
 	if err != nil {
 		return cancelAllOrdersResponse, err
 	}
 
+	// The orders cancelled are returned with their new status
 	for i := range resp {
-		cancelAllOrdersResponse.OrderStatus[strconv.FormatInt(resp[i].ID, 10)] = fmt.Sprintf("Could not cancel order %v. Status: %v", resp[i].ID, resp[i].Status)
+		orderID := strconv.FormatInt(resp[i].ID, 10)
+		if resp[i].Status == "canceled" {
+			cancelAllOrdersResponse.Add(orderID, nil)
+			continue
+		}
+		cancelAllOrdersResponse.AddFailure(orderID, "",
+			fmt.Sprintf("Could not cancel order %v. Status: %v", resp[i].ID, resp[i].Status))
 	}
 
 	return cancelAllOrdersResponse, nil
@@ -262,6 +350,9 @@ func (h *HitBTC) GetDepositAddress(currency currency.Code, _ string) (string, er
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (h *HitBTC) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if h.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	_, err := h.Withdraw(withdrawRequest.Currency.String(), withdrawRequest.Address, withdrawRequest.Amount)
 
 	return "", err
@@ -270,12 +361,18 @@ func (h *HitBTC) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawR
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (h *HitBTC) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if h.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (h *HitBTC) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if h.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -320,16 +417,18 @@ func (h *HitBTC) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([
 		}
 
 		orders = append(orders, exchange.OrderDetail{
-			ID:           allOrders[i].ID,
-			Amount:       allOrders[i].Quantity,
-			Exchange:     h.Name,
-			Price:        allOrders[i].Price,
-			OrderDate:    orderDate,
-			OrderSide:    side,
-			CurrencyPair: symbol,
+			ID:             allOrders[i].ID,
+			Amount:         allOrders[i].Quantity,
+			ExecutedAmount: allOrders[i].CumQuantity,
+			Exchange:       h.Name,
+			Price:          allOrders[i].Price,
+			OrderDate:      orderDate,
+			OrderSide:      side,
+			CurrencyPair:   symbol,
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
@@ -365,16 +464,18 @@ func (h *HitBTC) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) ([
 		}
 
 		orders = append(orders, exchange.OrderDetail{
-			ID:           allOrders[i].ID,
-			Amount:       allOrders[i].Quantity,
-			Exchange:     h.Name,
-			Price:        allOrders[i].Price,
-			OrderDate:    orderDate,
-			OrderSide:    side,
-			CurrencyPair: symbol,
+			ID:             allOrders[i].ID,
+			Amount:         allOrders[i].Quantity,
+			ExecutedAmount: allOrders[i].CumQuantity,
+			Exchange:       h.Name,
+			Price:          allOrders[i].Price,
+			OrderDate:      orderDate,
+			OrderSide:      side,
+			CurrencyPair:   symbol,
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 
@@ -62,6 +62,9 @@ const (
 
 	huobiAuthRate   = 100
 	huobiUnauthRate = 100
+
+	// huobiKlineLimit is the maximum number of candles returned per request
+	huobiKlineLimit = 2000
 )
 
 // HUOBI is the overarching type across this package
@@ -72,6 +75,17 @@ type HUOBI struct {
 	wsRequestMtx  sync.Mutex
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "Huobi",
+		Creator: func() exchange.IBotExchange {
+			return new(HUOBI)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability,
+	})
+}
+
 // SetDefaults sets default values for the exchange
 func (h *HUOBI) SetDefaults() {
 	h.Name = "Huobi"
@@ -87,6 +101,7 @@ func (h *HUOBI) SetDefaults() {
 	h.ConfigCurrencyPairFormat.Uppercase = true
 	h.AssetTypes = []string{ticker.Spot}
 	h.SupportsAutoPairUpdating = true
+	h.SupportsKlines = true
 	h.SupportsRESTTickerBatching = false
 	h.Requester = request.New(h.Name,
 		request.NewRateLimit(time.Second*10, huobiAuthRate),
@@ -114,8 +129,10 @@ func (h *HUOBI) Setup(exch *config.ExchangeConfig) {
 		h.APIAuthPEMKey = exch.APIAuthPEMKey
 		h.SetHTTPClientTimeout(exch.HTTPTimeout)
 		h.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		h.SetHTTPHeaders(exch.HTTPHeaders)
 		h.RESTPollingDelay = exch.RESTPollingDelay
 		h.Verbose = exch.Verbose
+		h.ReadOnly = exch.ReadOnly
 		h.HTTPDebugging = exch.HTTPDebugging
 		h.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		h.BaseCurrencies = exch.BaseCurrencies
@@ -152,6 +169,7 @@ func (h *HUOBI) Setup(exch *config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		h.Websocket.SetHeartbeat(h.wsHeartbeat())
 	}
 }
 
@@ -845,7 +863,7 @@ func (h *HUOBI) SendHTTPRequest(path string, result interface{}) error {
 // SendAuthenticatedHTTPRequest sends authenticated requests to the HUOBI API
 func (h *HUOBI) SendAuthenticatedHTTPRequest(method, endpoint string, values url.Values, data, result interface{}) error {
 	if !h.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, h.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	if values == nil {
@@ -934,7 +952,7 @@ func (h *HUOBI) GetFee(feeBuilder *exchange.FeeBuilder) (float64, error) {
 
 func calculateTradingFee(c currency.Pair, price, amount float64) float64 {
 	if c.IsCryptoFiatPair() {
-		return 0.001 * price * amount
+		return exchange.CalculateTradingFee(0.001, price, amount)
 	}
-	return 0.002 * price * amount
+	return exchange.CalculateTradingFee(0.002, price, amount)
 }
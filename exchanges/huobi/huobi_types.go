@@ -1,5 +1,7 @@
 package huobi
 
+import "time"
+
 // Response stores the Huobi response information
 type Response struct {
 	Status       string `json:"status"`
@@ -260,6 +262,17 @@ var (
 	TimeIntervalYear           = TimeInterval("1year")
 )
 
+// klineIntervals maps fixed length time intervals to their durations
+var klineIntervals = map[TimeInterval]time.Duration{
+	TimeIntervalMinute:         time.Minute,
+	TimeIntervalFiveMinutes:    time.Minute * 5,
+	TimeIntervalFifteenMinutes: time.Minute * 15,
+	TimeIntervalThirtyMinutes:  time.Minute * 30,
+	TimeIntervalHour:           time.Hour,
+	TimeIntervalDay:            time.Hour * 24,
+	TimeIntervalWeek:           time.Hour * 24 * 7,
+}
+
 // WsRequest defines a request data structure
 type WsRequest struct {
 	Topic             string `json:"req,omitempty"`
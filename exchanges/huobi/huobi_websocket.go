@@ -23,8 +23,32 @@ const (
 	wsMarketKline        = "market.%s.kline.1min"
 	wsMarketDepth        = "market.%s.depth.step0"
 	wsMarketTrade        = "market.%s.trade.detail"
+
+	// wsHeartbeatTimeout is how long without traffic, including the server
+	// pings sent every 5 seconds, before the connection is reset
+	wsHeartbeatTimeout = time.Second * 30
 )
 
+// wsHeartbeat returns the heartbeat keeping the websocket connection alive.
+// The server sends {"ping": timestamp} which is replied to with the timestamp
+// as {"pong": timestamp}
+func (h *HUOBI) wsHeartbeat() exchange.WebsocketHeartbeat {
+	return exchange.WebsocketHeartbeat{
+		Timeout: wsHeartbeatTimeout,
+		Send:    h.wsSend,
+		Reply: func(raw []byte) []byte {
+			var ping struct {
+				Ping int64 `json:"ping"`
+			}
+			err := common.JSONDecode(raw, &ping)
+			if err != nil || ping.Ping == 0 {
+				return nil
+			}
+			return []byte(fmt.Sprintf(`{"pong":%d}`, ping.Ping))
+		},
+	}
+}
+
 // WsConnect initiates a new websocket connection
 func (h *HUOBI) WsConnect() error {
 	if !h.Websocket.IsEnabled() || !h.IsEnabled() {
@@ -98,6 +122,10 @@ func (h *HUOBI) WsHandleData() {
 				return
 			}
 
+			if h.Websocket.HandleHeartbeat(resp.Raw) {
+				continue
+			}
+
 			var init WsResponse
 			err = common.JSONDecode(resp.Raw, &init)
 			if err != nil {
@@ -116,14 +144,6 @@ func (h *HUOBI) WsHandleData() {
 				continue
 			}
 
-			if init.Ping != 0 {
-				err = h.WebsocketConn.WriteJSON(`{"pong":1337}`)
-				if err != nil {
-					log.Error(err)
-				}
-				continue
-			}
-
 			switch {
 			case common.StringContains(init.Channel, "depth"):
 				var depth WsDepth
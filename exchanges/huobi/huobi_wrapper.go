@@ -12,6 +12,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -34,65 +35,65 @@ func (h *HUOBI) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", h.GetName(), len(h.EnabledPairs), h.EnabledPairs)
 	}
 
-	exchangeProducts, err := h.GetSymbols()
-	if err != nil {
-		log.Errorf("%s Failed to get available symbols.\n", h.GetName())
-	} else {
-		forceUpgrade := false
-		if common.StringDataContains(h.EnabledPairs.Strings(), "CNY") ||
-			common.StringDataContains(h.AvailablePairs.Strings(), "CNY") {
-			forceUpgrade = true
-		}
-
-		if common.StringDataContains(h.BaseCurrencies.Strings(), "CNY") {
-			cfg := config.GetConfig()
-			exchCfg, errCNY := cfg.GetExchangeConfig(h.Name)
-			if err != nil {
-				log.Errorf("%s failed to get exchange config. %s\n", h.Name, errCNY)
-				return
-			}
-			exchCfg.BaseCurrencies = currency.Currencies{currency.USD}
-			h.BaseCurrencies = currency.Currencies{currency.USD}
-
-			errCNY = cfg.UpdateExchangeConfig(&exchCfg)
-			if errCNY != nil {
-				log.Errorf("%s failed to update config. %s\n", h.Name, errCNY)
-				return
-			}
-		}
+	forceUpgrade := false
+	if common.StringDataContains(h.EnabledPairs.Strings(), "CNY") ||
+		common.StringDataContains(h.AvailablePairs.Strings(), "CNY") {
+		forceUpgrade = true
+	}
 
-		var currencies []string
-		for x := range exchangeProducts {
-			newCurrency := exchangeProducts[x].BaseCurrency + "-" + exchangeProducts[x].QuoteCurrency
-			currencies = append(currencies, newCurrency)
+	if common.StringDataContains(h.BaseCurrencies.Strings(), "CNY") {
+		cfg := config.GetConfig()
+		exchCfg, errCNY := cfg.GetExchangeConfig(h.Name)
+		if errCNY != nil {
+			log.Errorf("%s failed to get exchange config. %s\n", h.Name, errCNY)
+			return
 		}
+		exchCfg.BaseCurrencies = currency.Currencies{currency.USD}
+		h.BaseCurrencies = currency.Currencies{currency.USD}
 
-		if forceUpgrade {
-			enabledPairs := currency.Pairs{currency.Pair{
-				Base:      currency.BTC.Lower(),
-				Quote:     currency.USDT.Lower(),
-				Delimiter: "-",
-			},
-			}
-			log.Warn("Available and enabled pairs for Huobi reset due to config upgrade, please enable the ones you would like again")
-
-			err = h.UpdateCurrencies(enabledPairs, true, true)
-			if err != nil {
-				log.Errorf("%s Failed to update enabled currencies.\n", h.GetName())
-			}
+		errCNY = cfg.UpdateExchangeConfig(&exchCfg)
+		if errCNY != nil {
+			log.Errorf("%s failed to update config. %s\n", h.Name, errCNY)
+			return
 		}
+	}
 
-		var newCurrencies currency.Pairs
-		for _, p := range currencies {
-			newCurrencies = append(newCurrencies,
-				currency.NewPairFromString(p))
+	if forceUpgrade {
+		enabledPairs := currency.Pairs{currency.Pair{
+			Base:      currency.BTC.Lower(),
+			Quote:     currency.USDT.Lower(),
+			Delimiter: "-",
+		},
 		}
+		log.Warn("Available and enabled pairs for Huobi reset due to config upgrade, please enable the ones you would like again")
 
-		err = h.UpdateCurrencies(newCurrencies, false, forceUpgrade)
+		err := h.UpdateCurrencies(enabledPairs, true, true)
 		if err != nil {
-			log.Errorf("%s Failed to update available currencies.\n", h.GetName())
+			log.Errorf("%s Failed to update enabled currencies.\n", h.GetName())
 		}
 	}
+
+	err := h.UpdateTradablePairs(forceUpgrade)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", h.Name, err)
+	}
+}
+
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (h *HUOBI) UpdateTradablePairs(forceUpdate bool) error {
+	exchangeProducts, err := h.GetSymbols()
+	if err != nil {
+		return err
+	}
+
+	var newCurrencies currency.Pairs
+	for x := range exchangeProducts {
+		newCurrencies = append(newCurrencies,
+			currency.NewPairFromString(exchangeProducts[x].BaseCurrency+"-"+exchangeProducts[x].QuoteCurrency))
+	}
+
+	return h.UpdateCurrencies(newCurrencies, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -213,39 +214,26 @@ func (h *HUOBI) GetAccountInfo() (exchange.AccountInfo, error) {
 
 		var currencyDetails []exchange.AccountCurrencyInfo
 		for _, balance := range balances {
-			var frozen bool
+			var available, hold float64
 			if balance.Type == "frozen" {
-				frozen = true
+				hold = balance.Balance
+			} else {
+				available = balance.Balance
 			}
+			detail := exchange.NewAccountCurrencyInfo(currency.NewCode(balance.Currency),
+				available, hold)
 
 			var updated bool
 			for i := range currencyDetails {
-				if currencyDetails[i].CurrencyName == currency.NewCode(balance.Currency) {
-					if frozen {
-						currencyDetails[i].Hold = balance.Balance
-					} else {
-						currencyDetails[i].TotalValue = balance.Balance
-					}
+				if currencyDetails[i].CurrencyName == detail.CurrencyName {
+					currencyDetails[i] = currencyDetails[i].Add(detail)
 					updated = true
+					break
 				}
 			}
 
-			if updated {
-				continue
-			}
-
-			if frozen {
-				currencyDetails = append(currencyDetails,
-					exchange.AccountCurrencyInfo{
-						CurrencyName: currency.NewCode(balance.Currency),
-						Hold:         balance.Balance,
-					})
-			} else {
-				currencyDetails = append(currencyDetails,
-					exchange.AccountCurrencyInfo{
-						CurrencyName: currency.NewCode(balance.Currency),
-						TotalValue:   balance.Balance,
-					})
+			if !updated {
+				currencyDetails = append(currencyDetails, detail)
 			}
 		}
 
@@ -270,8 +258,58 @@ func (h *HUOBI) GetExchangeHistory(p currency.Pair, assetType string) ([]exchang
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive. The
+// kline endpoint returns a number of the most recent candles, so enough are
+// requested to reach back to start and trimmed to the range
+func (h *HUOBI) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	var period TimeInterval
+	for k, v := range klineIntervals {
+		if v == interval {
+			period = k
+			break
+		}
+	}
+	if period == "" {
+		return nil, fmt.Errorf("%s unsupported kline interval %s", h.Name, interval)
+	}
+
+	size := int(time.Since(start)/interval) + 1
+	if size > huobiKlineLimit {
+		size = huobiKlineLimit
+	}
+	resp, err := h.GetSpotKline(KlinesRequestParams{
+		Symbol: exchange.FormatExchangeCurrency(h.Name, p).String(),
+		Period: period,
+		Size:   size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]kline.Candle, len(resp))
+	for x := range resp {
+		candles[x] = kline.Candle{
+			Time:   time.Unix(resp[x].ID, 0),
+			Open:   resp[x].Open,
+			High:   resp[x].High,
+			Low:    resp[x].Low,
+			Close:  resp[x].Close,
+			Volume: resp[x].Amount,
+		}
+	}
+	return kline.FilterCandles(candles, start, end), nil
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (h *HUOBI) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order
 func (h *HUOBI) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if h.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	accountID, err := strconv.ParseInt(clientID, 10, 64)
 	if err != nil {
@@ -317,11 +355,17 @@ func (h *HUOBI) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (h *HUOBI) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if h.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (h *HUOBI) CancelOrder(order *exchange.OrderCancellation) error {
+	if h.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -335,9 +379,10 @@ func (h *HUOBI) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (h *HUOBI) CancelAllOrders(orderCancellation *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if h.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	for _, currency := range h.GetEnabledCurrencies() {
 		resp, err := h.CancelOpenOrdersBatch(orderCancellation.AccountID, exchange.FormatExchangeCurrency(h.Name, currency).String())
 		if err != nil {
@@ -370,6 +415,9 @@ func (h *HUOBI) GetDepositAddress(cryptocurrency currency.Code, accountID string
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (h *HUOBI) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if h.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	resp, err := h.Withdraw(withdrawRequest.Currency, withdrawRequest.Address, withdrawRequest.AddressTag, withdrawRequest.Amount, withdrawRequest.FeeAmount)
 	return fmt.Sprintf("%v", resp), err
 }
@@ -377,12 +425,18 @@ func (h *HUOBI) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRe
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (h *HUOBI) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if h.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (h *HUOBI) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if h.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -441,6 +495,7 @@ func (h *HUOBI) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([]
 		}
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 
@@ -489,6 +544,7 @@ func (h *HUOBI) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) ([]
 		}
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 
@@ -510,6 +566,12 @@ func setOrderSideAndType(requestType string, orderDetail *exchange.OrderDetail)
 		orderDetail.OrderSide = exchange.SellOrderSide
 		orderDetail.OrderType = exchange.LimitOrderType
 	}
+
+	// Fees are charged in the currency received
+	orderDetail.FeeAsset = orderDetail.CurrencyPair.Base
+	if orderDetail.OrderSide == exchange.SellOrderSide {
+		orderDetail.FeeAsset = orderDetail.CurrencyPair.Quote
+	}
 }
 
 // SubscribeToWebsocketChannels appends to ChannelsToSubscribe
@@ -58,6 +58,9 @@ const (
 
 	huobihadaxAuthRate   = 100
 	huobihadaxUnauthRate = 100
+
+	// huobihadaxKlineLimit is the maximum number of candles returned per request
+	huobihadaxKlineLimit = 2000
 )
 
 // HUOBIHADAX is the overarching type across this package
@@ -67,6 +70,17 @@ type HUOBIHADAX struct {
 	wsRequestMtx sync.Mutex
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "HuobiHadax",
+		Creator: func() exchange.IBotExchange {
+			return new(HUOBIHADAX)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability,
+	})
+}
+
 // SetDefaults sets default values for the exchange
 func (h *HUOBIHADAX) SetDefaults() {
 	h.Name = "HuobiHadax"
@@ -82,6 +96,7 @@ func (h *HUOBIHADAX) SetDefaults() {
 	h.ConfigCurrencyPairFormat.Uppercase = true
 	h.AssetTypes = []string{ticker.Spot}
 	h.SupportsAutoPairUpdating = true
+	h.SupportsKlines = true
 	h.SupportsRESTTickerBatching = false
 	h.Requester = request.New(h.Name,
 		request.NewRateLimit(time.Second*10, huobihadaxAuthRate),
@@ -109,8 +124,10 @@ func (h *HUOBIHADAX) Setup(exch *config.ExchangeConfig) {
 		h.APIAuthPEMKey = exch.APIAuthPEMKey
 		h.SetHTTPClientTimeout(exch.HTTPTimeout)
 		h.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		h.SetHTTPHeaders(exch.HTTPHeaders)
 		h.RESTPollingDelay = exch.RESTPollingDelay
 		h.Verbose = exch.Verbose
+		h.ReadOnly = exch.ReadOnly
 		h.HTTPDebugging = exch.HTTPDebugging
 		h.BaseCurrencies = exch.BaseCurrencies
 		h.AvailablePairs = exch.AvailablePairs
@@ -146,6 +163,7 @@ func (h *HUOBIHADAX) Setup(exch *config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		h.Websocket.SetHeartbeat(h.wsHeartbeat())
 	}
 }
 
@@ -840,7 +858,7 @@ func (h *HUOBIHADAX) SendHTTPRequest(path string, result interface{}) error {
 // SendAuthenticatedHTTPPostRequest sends authenticated requests to the HUOBI API
 func (h *HUOBIHADAX) SendAuthenticatedHTTPPostRequest(method, endpoint, postBodyValues string, result interface{}) error {
 	if !h.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, h.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	signatureParams := url.Values{}
@@ -867,7 +885,7 @@ func (h *HUOBIHADAX) SendAuthenticatedHTTPPostRequest(method, endpoint, postBody
 // SendAuthenticatedHTTPRequest sends authenticated requests to the HUOBI API
 func (h *HUOBIHADAX) SendAuthenticatedHTTPRequest(method, endpoint string, values url.Values, result interface{}) error {
 	if !h.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, h.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	values.Set("AccessKeyId", h.APIKey)
@@ -905,9 +923,9 @@ func (h *HUOBIHADAX) GetFee(feeBuilder *exchange.FeeBuilder) (float64, error) {
 
 func calculateTradingFee(c currency.Pair, price, amount float64) float64 {
 	if c.IsCryptoFiatPair() {
-		return 0.001 * price * amount
+		return exchange.CalculateTradingFee(0.001, price, amount)
 	}
-	return 0.002 * price * amount
+	return exchange.CalculateTradingFee(0.002, price, amount)
 }
 
 // GetDepositWithdrawalHistory returns deposit or withdrawal data
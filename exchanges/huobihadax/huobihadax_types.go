@@ -1,5 +1,7 @@
 package huobihadax
 
+import "time"
+
 // Response stores the Huobi response information
 type Response struct {
 	Status       string `json:"status"`
@@ -238,6 +240,17 @@ var (
 	TimeIntervalYear           = TimeInterval("1year")
 )
 
+// klineIntervals maps fixed length time intervals to their durations
+var klineIntervals = map[TimeInterval]time.Duration{
+	TimeIntervalMinute:         time.Minute,
+	TimeIntervalFiveMinutes:    time.Minute * 5,
+	TimeIntervalFifteenMinutes: time.Minute * 15,
+	TimeIntervalThirtyMinutes:  time.Minute * 30,
+	TimeIntervalHour:           time.Hour,
+	TimeIntervalDay:            time.Hour * 24,
+	TimeIntervalWeek:           time.Hour * 24 * 7,
+}
+
 // History defines currency deposit or withdrawal data
 type History struct {
 	ID        int64   `json:"id"`
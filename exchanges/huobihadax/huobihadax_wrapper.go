@@ -11,6 +11,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -33,23 +34,29 @@ func (h *HUOBIHADAX) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", h.GetName(), len(h.EnabledPairs), h.EnabledPairs)
 	}
 
+	err := h.UpdateTradablePairs(false)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", h.Name, err)
+	}
+}
+
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (h *HUOBIHADAX) UpdateTradablePairs(forceUpdate bool) error {
 	exchangeProducts, err := h.GetSymbols()
 	if err != nil {
-		log.Debugf("%s Failed to get available symbols.\n", h.GetName())
-	} else {
-		var currencies currency.Pairs
-		for x := range exchangeProducts {
-			currencies = append(currencies,
-				currency.NewPairWithDelimiter(exchangeProducts[x].BaseCurrency,
-					exchangeProducts[x].QuoteCurrency,
-					"-"))
-		}
+		return err
+	}
 
-		err = h.UpdateCurrencies(currencies, false, false)
-		if err != nil {
-			log.Debugf("%s Failed to update available currencies.\n", h.GetName())
-		}
+	var currencies currency.Pairs
+	for x := range exchangeProducts {
+		currencies = append(currencies,
+			currency.NewPairWithDelimiter(exchangeProducts[x].BaseCurrency,
+				exchangeProducts[x].QuoteCurrency,
+				"-"))
 	}
+
+	return h.UpdateCurrencies(currencies, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -167,39 +174,26 @@ func (h *HUOBIHADAX) GetAccountInfo() (exchange.AccountInfo, error) {
 
 		var currencyDetails []exchange.AccountCurrencyInfo
 		for _, balance := range balances {
-			var frozen bool
+			var available, hold float64
 			if balance.Type == "frozen" {
-				frozen = true
+				hold = balance.Balance
+			} else {
+				available = balance.Balance
 			}
+			detail := exchange.NewAccountCurrencyInfo(currency.NewCode(balance.Currency),
+				available, hold)
 
 			var updated bool
 			for i := range currencyDetails {
-				if currencyDetails[i].CurrencyName == currency.NewCode(balance.Currency) {
-					if frozen {
-						currencyDetails[i].Hold = balance.Balance
-					} else {
-						currencyDetails[i].TotalValue = balance.Balance
-					}
+				if currencyDetails[i].CurrencyName == detail.CurrencyName {
+					currencyDetails[i] = currencyDetails[i].Add(detail)
 					updated = true
+					break
 				}
 			}
 
-			if updated {
-				continue
-			}
-
-			if frozen {
-				currencyDetails = append(currencyDetails,
-					exchange.AccountCurrencyInfo{
-						CurrencyName: currency.NewCode(balance.Currency),
-						Hold:         balance.Balance,
-					})
-			} else {
-				currencyDetails = append(currencyDetails,
-					exchange.AccountCurrencyInfo{
-						CurrencyName: currency.NewCode(balance.Currency),
-						TotalValue:   balance.Balance,
-					})
+			if !updated {
+				currencyDetails = append(currencyDetails, detail)
 			}
 		}
 
@@ -224,8 +218,58 @@ func (h *HUOBIHADAX) GetExchangeHistory(p currency.Pair, assetType string) ([]ex
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive. The
+// kline endpoint returns a number of the most recent candles, so enough are
+// requested to reach back to start and trimmed to the range
+func (h *HUOBIHADAX) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	var period TimeInterval
+	for k, v := range klineIntervals {
+		if v == interval {
+			period = k
+			break
+		}
+	}
+	if period == "" {
+		return nil, fmt.Errorf("%s unsupported kline interval %s", h.Name, interval)
+	}
+
+	size := int(time.Since(start)/interval) + 1
+	if size > huobihadaxKlineLimit {
+		size = huobihadaxKlineLimit
+	}
+	resp, err := h.GetSpotKline(KlinesRequestParams{
+		Symbol: exchange.FormatExchangeCurrency(h.Name, p).String(),
+		Period: period,
+		Size:   size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]kline.Candle, len(resp))
+	for x := range resp {
+		candles[x] = kline.Candle{
+			Time:   time.Unix(resp[x].ID, 0),
+			Open:   resp[x].Open,
+			High:   resp[x].High,
+			Low:    resp[x].Low,
+			Close:  resp[x].Close,
+			Volume: resp[x].Amount,
+		}
+	}
+	return kline.FilterCandles(candles, start, end), nil
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (h *HUOBIHADAX) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order
 func (h *HUOBIHADAX) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if h.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	accountID, err := strconv.ParseInt(clientID, 0, 64)
 	if err != nil {
@@ -273,11 +317,17 @@ func (h *HUOBIHADAX) SubmitOrder(p currency.Pair, side exchange.OrderSide, order
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (h *HUOBIHADAX) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if h.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (h *HUOBIHADAX) CancelOrder(order *exchange.OrderCancellation) error {
+	if h.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -291,9 +341,10 @@ func (h *HUOBIHADAX) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (h *HUOBIHADAX) CancelAllOrders(orderCancellation *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if h.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	for _, currency := range h.GetEnabledCurrencies() {
 		resp, err := h.CancelOpenOrdersBatch(orderCancellation.AccountID, exchange.FormatExchangeCurrency(h.Name, currency).String())
 		if err != nil {
@@ -326,6 +377,9 @@ func (h *HUOBIHADAX) GetDepositAddress(cryptocurrency currency.Code, accountID s
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (h *HUOBIHADAX) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if h.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	resp, err := h.Withdraw(withdrawRequest.Currency, withdrawRequest.Address, withdrawRequest.AddressTag, withdrawRequest.Amount, withdrawRequest.FeeAmount)
 	return fmt.Sprintf("%v", resp), err
 }
@@ -333,12 +387,18 @@ func (h *HUOBIHADAX) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.Withd
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (h *HUOBIHADAX) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if h.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (h *HUOBIHADAX) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if h.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -399,6 +459,7 @@ func (h *HUOBIHADAX) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 
@@ -445,6 +506,7 @@ func (h *HUOBIHADAX) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 
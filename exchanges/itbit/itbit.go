@@ -42,6 +42,16 @@ type ItBit struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "ITBIT",
+		Creator: func() exchange.IBotExchange {
+			return new(ItBit)
+		},
+		Capabilities: exchange.SpotCapability,
+	})
+}
+
 // SetDefaults sets the defaults for the exchange
 func (i *ItBit) SetDefaults() {
 	i.Name = "ITBIT"
@@ -78,8 +88,10 @@ func (i *ItBit) Setup(exch *config.ExchangeConfig) {
 		i.SetAPIKeys(exch.APIKey, exch.APISecret, exch.ClientID, false)
 		i.SetHTTPClientTimeout(exch.HTTPTimeout)
 		i.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		i.SetHTTPHeaders(exch.HTTPHeaders)
 		i.RESTPollingDelay = exch.RESTPollingDelay
 		i.Verbose = exch.Verbose
+		i.ReadOnly = exch.ReadOnly
 		i.HTTPDebugging = exch.HTTPDebugging
 		i.BaseCurrencies = exch.BaseCurrencies
 		i.AvailablePairs = exch.AvailablePairs
@@ -350,7 +362,7 @@ func (i *ItBit) SendHTTPRequest(path string, result interface{}) error {
 // SendAuthenticatedHTTPRequest sends an authenticated request to itBit
 func (i *ItBit) SendAuthenticatedHTTPRequest(method, path string, params map[string]interface{}, result interface{}) error {
 	if !i.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, i.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	if i.ClientID == "" {
@@ -374,7 +386,7 @@ func (i *ItBit) SendAuthenticatedHTTPRequest(method, path string, params map[str
 		}
 
 		if i.Verbose {
-			log.Debugf("Request JSON: %s\n", PayloadJSON)
+			log.Debugf("Request JSON: %s\n", log.DebugPayload(i.Name, PayloadJSON))
 		}
 	}
 
@@ -452,7 +464,7 @@ func calculateTradingFee(purchasePrice, amount float64, isMaker bool) float64 {
 	if isMaker {
 		feePercent = -0.0003
 	}
-	return feePercent * purchasePrice * amount
+	return exchange.CalculateTradingFee(feePercent, purchasePrice, amount)
 }
 
 func getInternationalBankWithdrawalFee(c currency.Code, bankTransactionType exchange.InternationalBankTransactionType) float64 {
@@ -11,6 +11,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -33,6 +34,12 @@ func (i *ItBit) Run() {
 	}
 }
 
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (i *ItBit) UpdateTradablePairs(forceUpdate bool) error {
+	return common.ErrFunctionNotSupported
+}
+
 // UpdateTicker updates and returns the ticker for a currency pair
 func (i *ItBit) UpdateTicker(p currency.Pair, assetType string) (ticker.Price, error) {
 	var tickerPrice ticker.Price
@@ -49,6 +56,7 @@ func (i *ItBit) UpdateTicker(p currency.Pair, assetType string) (ticker.Price, e
 	tickerPrice.High = tick.High24h
 	tickerPrice.Low = tick.Low24h
 	tickerPrice.Volume = tick.Volume24h
+	tickerPrice.VWAP = tick.Vwap24h
 
 	err = ticker.ProcessTicker(i.GetName(), &tickerPrice, assetType)
 	if err != nil {
@@ -135,38 +143,19 @@ func (i *ItBit) GetAccountInfo() (exchange.AccountInfo, error) {
 		return info, err
 	}
 
-	type balance struct {
-		TotalValue float64
-		Hold       float64
-	}
-
-	var amounts = make(map[string]*balance)
-
 	for _, wallet := range wallets {
+		var balances []exchange.AccountCurrencyInfo
 		for _, cb := range wallet.Balances {
-			if _, ok := amounts[cb.Currency]; !ok {
-				amounts[cb.Currency] = &balance{}
-			}
-
-			amounts[cb.Currency].TotalValue += cb.TotalBalance
-			amounts[cb.Currency].Hold += cb.TotalBalance - cb.AvailableBalance
+			balances = append(balances, exchange.NewAccountCurrencyInfoFromTotalAvailable(
+				currency.NewCode(cb.Currency), cb.TotalBalance, cb.AvailableBalance))
 		}
-	}
-
-	var fullBalance []exchange.AccountCurrencyInfo
 
-	for key, data := range amounts {
-		fullBalance = append(fullBalance, exchange.AccountCurrencyInfo{
-			CurrencyName: currency.NewCode(key),
-			TotalValue:   data.TotalValue,
-			Hold:         data.Hold,
+		info.Accounts = append(info.Accounts, exchange.Account{
+			ID:         wallet.Name,
+			Currencies: balances,
 		})
 	}
 
-	info.Accounts = append(info.Accounts, exchange.Account{
-		Currencies: fullBalance,
-	})
-
 	return info, nil
 }
 
@@ -184,8 +173,21 @@ func (i *ItBit) GetExchangeHistory(p currency.Pair, assetType string) ([]exchang
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive
+func (i *ItBit) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (i *ItBit) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order
 func (i *ItBit) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if i.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var wallet string
 
@@ -234,19 +236,26 @@ func (i *ItBit) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (i *ItBit) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if i.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (i *ItBit) CancelOrder(order *exchange.OrderCancellation) error {
+	if i.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	return i.CancelExistingOrder(order.WalletAddress, order.OrderID)
 }
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (i *ItBit) CancelAllOrders(orderCancellation *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if i.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	openOrders, err := i.GetOrders(orderCancellation.WalletAddress, "", "open", 0, 0)
 	if err != nil {
 		return cancelAllOrdersResponse, err
@@ -254,9 +263,7 @@ func (i *ItBit) CancelAllOrders(orderCancellation *exchange.OrderCancellation) (
 
 	for j := range openOrders {
 		err = i.CancelExistingOrder(orderCancellation.WalletAddress, openOrders[j].ID)
-		if err != nil {
-			cancelAllOrdersResponse.OrderStatus[openOrders[j].ID] = err.Error()
-		}
+		cancelAllOrdersResponse.Add(openOrders[j].ID, err)
 	}
 
 	return cancelAllOrdersResponse, nil
@@ -279,18 +286,27 @@ func (i *ItBit) GetDepositAddress(cryptocurrency currency.Code, accountID string
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (i *ItBit) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if i.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (i *ItBit) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if i.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (i *ItBit) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if i.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -347,6 +363,7 @@ func (i *ItBit) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([]
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
@@ -399,6 +416,7 @@ func (i *ItBit) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) ([]
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
@@ -0,0 +1,279 @@
+package kline
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+// Const values for the kline package
+const (
+	ErrKlineForExchangeNotFound = "kline series for exchange does not exist"
+	ErrInvalidInterval          = "invalid kline interval"
+	ErrMonthlyInterval          = "monthly kline intervals are unsupported"
+)
+
+// Vars for the kline package
+var (
+	Items []Item
+	m     sync.Mutex
+)
+
+// Candle holds an individual candle, keyed by its open time
+type Candle struct {
+	Time   time.Time `json:"time"`
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close"`
+	Volume float64   `json:"volume"`
+}
+
+// Item holds a candle series for an exchange, currency pair, asset type and
+// interval
+type Item struct {
+	Exchange  string
+	Pair      currency.Pair
+	AssetType string
+	Interval  time.Duration
+	Candles   []Candle
+}
+
+// Gap defines a range of missing candles, Start being the open time of the
+// first missing candle and End the open time of the next stored candle
+type Gap struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Fetcher returns historic candles from an exchanges REST kline endpoint, it
+// is satisfied by every exchange wrapper
+type Fetcher interface {
+	GetName() string
+	GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]Candle, error)
+}
+
+// ParseInterval converts an exchange interval string (1m, 15m, 1h, 1d, 1w) to a
+// duration. Monthly intervals (1M) are unsupported as months vary in length and
+// candles are keyed by their open time truncated to a fixed interval
+func ParseInterval(interval string) (time.Duration, error) {
+	interval = strings.TrimSpace(interval)
+	if len(interval) < 2 {
+		return 0, errors.New(ErrInvalidInterval)
+	}
+
+	unit := interval[len(interval)-1:]
+	amount, err := strconv.Atoi(interval[:len(interval)-1])
+	if err != nil || amount <= 0 {
+		return 0, errors.New(ErrInvalidInterval)
+	}
+
+	switch unit {
+	case "s":
+		return time.Duration(amount) * time.Second, nil
+	case "m":
+		return time.Duration(amount) * time.Minute, nil
+	case "h", "H":
+		return time.Duration(amount) * time.Hour, nil
+	case "d", "D":
+		return time.Duration(amount) * time.Hour * 24, nil
+	case "w", "W":
+		return time.Duration(amount) * time.Hour * 24 * 7, nil
+	case "M":
+		return 0, errors.New(ErrMonthlyInterval)
+	}
+	return 0, errors.New(ErrInvalidInterval)
+}
+
+// FilterCandles returns the candles opening between start and end inclusive,
+// sorted by open time. Exchange kline endpoints returning a fixed number of
+// the latest candles are trimmed to the requested range with it
+func FilterCandles(candles []Candle, start, end time.Time) []Candle {
+	var resp []Candle
+	for x := range candles {
+		if candles[x].Time.Before(start) || candles[x].Time.After(end) {
+			continue
+		}
+		resp = append(resp, candles[x])
+	}
+	sort.Slice(resp, func(a, b int) bool {
+		return resp[a].Time.Before(resp[b].Time)
+	})
+	return resp
+}
+
+func (i *Item) matches(exchName string, p currency.Pair, assetType string, interval time.Duration) bool {
+	return i.Exchange == exchName &&
+		i.Pair.Equal(p) &&
+		i.AssetType == assetType &&
+		i.Interval == interval
+}
+
+// merge adds candles to the series, replacing any stored candle with the same
+// open time, and keeps the series sorted. Candles newer than the last stored
+// candle, as received over websockets, are appended without a search
+func (i *Item) merge(candles []Candle) {
+	for x := range candles {
+		candles[x].Time = candles[x].Time.Truncate(i.Interval)
+		if n := len(i.Candles); n == 0 || candles[x].Time.After(i.Candles[n-1].Time) {
+			i.Candles = append(i.Candles, candles[x])
+			continue
+		}
+
+		idx, found := i.search(candles[x].Time)
+		if found {
+			i.Candles[idx] = candles[x]
+			continue
+		}
+		i.insert(idx, candles[x])
+	}
+}
+
+// search returns the index of the stored candle opening at t, or the index a
+// candle opening at t is inserted at to keep the series sorted
+func (i *Item) search(t time.Time) (int, bool) {
+	idx := sort.Search(len(i.Candles), func(x int) bool {
+		return !i.Candles[x].Time.Before(t)
+	})
+	return idx, idx < len(i.Candles) && i.Candles[idx].Time.Equal(t)
+}
+
+// insert inserts a candle at idx
+func (i *Item) insert(idx int, c Candle) {
+	i.Candles = append(i.Candles, Candle{})
+	copy(i.Candles[idx+1:], i.Candles[idx:])
+	i.Candles[idx] = c
+}
+
+// FindGaps returns the ranges of missing candles between the first and last
+// stored candle
+func (i *Item) FindGaps() []Gap {
+	var gaps []Gap
+	for x := 1; x < len(i.Candles); x++ {
+		expected := i.Candles[x-1].Time.Add(i.Interval)
+		if i.Candles[x].Time.After(expected) {
+			gaps = append(gaps, Gap{
+				Start: expected,
+				End:   i.Candles[x].Time,
+			})
+		}
+	}
+	return gaps
+}
+
+// Process stores candles for an exchange, currency pair, asset type and
+// interval, creating the series if it does not exist
+func Process(exchName string, p currency.Pair, assetType string, interval time.Duration, candles []Candle) error {
+	if exchName == "" {
+		return errors.New("kline exchange name not set")
+	}
+
+	if p.IsEmpty() {
+		return errors.New("kline currency pair not populated")
+	}
+
+	if interval <= 0 {
+		return errors.New(ErrInvalidInterval)
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	getOrCreate(exchName, p, assetType, interval).merge(candles)
+	return nil
+}
+
+// getOrCreate returns a stored candle series, creating it if it does not
+// exist. The returned series is only valid while m is held
+func getOrCreate(exchName string, p currency.Pair, assetType string, interval time.Duration) *Item {
+	for x := range Items {
+		if Items[x].matches(exchName, p, assetType, interval) {
+			return &Items[x]
+		}
+	}
+
+	Items = append(Items, Item{
+		Exchange:  exchName,
+		Pair:      p,
+		AssetType: assetType,
+		Interval:  interval,
+	})
+	return &Items[len(Items)-1]
+}
+
+// Get returns a copy of a stored candle series
+func Get(exchName string, p currency.Pair, assetType string, interval time.Duration) (Item, error) {
+	m.Lock()
+	defer m.Unlock()
+	for x := range Items {
+		if Items[x].matches(exchName, p, assetType, interval) {
+			item := Items[x]
+			item.Candles = append([]Candle(nil), Items[x].Candles...)
+			return item, nil
+		}
+	}
+	return Item{}, errors.New(ErrKlineForExchangeNotFound)
+}
+
+// GetByExchange returns copies of all stored candle series for an exchange
+func GetByExchange(exchName string) []Item {
+	m.Lock()
+	defer m.Unlock()
+	var items []Item
+	for x := range Items {
+		if Items[x].Exchange != exchName {
+			continue
+		}
+		item := Items[x]
+		item.Candles = append([]Candle(nil), Items[x].Candles...)
+		items = append(items, item)
+	}
+	return items
+}
+
+// CheckIntegrity detects missing intervals in a stored candle series and
+// backfills them from the exchanges kline endpoint. Gaps the exchange could not
+// fill are returned as unrecoverable
+func CheckIntegrity(f Fetcher, p currency.Pair, assetType string, interval time.Duration) ([]Gap, error) {
+	item, err := Get(f.GetName(), p, assetType, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	gaps := item.FindGaps()
+	if len(gaps) == 0 {
+		return nil, nil
+	}
+
+	for x := range gaps {
+		candles, err := f.GetHistoricCandles(p, assetType, interval, gaps[x].Start, gaps[x].End.Add(-interval))
+		if err != nil {
+			return nil, fmt.Errorf("%s %s %s kline backfill failed: %s",
+				f.GetName(), p, interval, err)
+		}
+
+		var inGap []Candle
+		for y := range candles {
+			t := candles[y].Time.Truncate(interval)
+			if !t.Before(gaps[x].Start) && t.Before(gaps[x].End) {
+				inGap = append(inGap, candles[y])
+			}
+		}
+
+		err = Process(f.GetName(), p, assetType, interval, inGap)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item, err = Get(f.GetName(), p, assetType, interval)
+	if err != nil {
+		return nil, err
+	}
+	return item.FindGaps(), nil
+}
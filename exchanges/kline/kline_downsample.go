@@ -0,0 +1,60 @@
+package kline
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+// Range returns the candles of the series opening between start and end
+// inclusive, a zero start or end leaves that side of the range unbounded
+func (i *Item) Range(start, end time.Time) []Candle {
+	var candles []Candle
+	for x := range i.Candles {
+		if !start.IsZero() && i.Candles[x].Time.Before(start) {
+			continue
+		}
+		if !end.IsZero() && i.Candles[x].Time.After(end) {
+			break
+		}
+		candles = append(candles, i.Candles[x])
+	}
+	return candles
+}
+
+// Downsample aggregates candles of an interval into candles of the smallest
+// multiple of the interval which returns at most points candles, so a series
+// can be charted without transferring every stored candle. Downsampled candles
+// open at multiples of the new interval from the first candle. Candles are
+// returned unchanged with their interval when they already fit
+func Downsample(candles []Candle, interval time.Duration, points int) ([]Candle, time.Duration) {
+	if points <= 0 || len(candles) <= points || interval <= 0 {
+		return candles, interval
+	}
+
+	first := candles[0].Time
+	span := candles[len(candles)-1].Time.Sub(first) + interval
+	intervals := int64(span / interval)
+	multiple := (intervals + int64(points) - 1) / int64(points)
+	bucket := interval * time.Duration(multiple)
+	return aggregateBy(candles, func(t time.Time) time.Time {
+		return first.Add(t.Sub(first) / bucket * bucket)
+	}), bucket
+}
+
+// Query returns a copy of a stored candle series limited to the candles opening
+// between start and end, downsampled to at most points candles when points is
+// positive. The returned series interval is that of the downsampled candles
+func Query(exchName string, p currency.Pair, assetType string, interval time.Duration, start, end time.Time, points int) (Item, error) {
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return Item{}, errors.New("kline query end is before start")
+	}
+
+	item, err := Get(exchName, p, assetType, interval)
+	if err != nil {
+		return Item{}, err
+	}
+	item.Candles, item.Interval = Downsample(item.Range(start, end), interval, points)
+	return item, nil
+}
@@ -0,0 +1,75 @@
+package kline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+func TestDownsample(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	var candles []Candle
+	for x := 0; x < 10; x++ {
+		candles = append(candles, Candle{
+			Time:   start.Add(time.Minute * time.Duration(x)),
+			Open:   float64(x),
+			High:   float64(x) + 1,
+			Low:    float64(x) - 1,
+			Close:  float64(x) + 0.5,
+			Volume: 1,
+		})
+	}
+
+	resp, interval := Downsample(candles, time.Minute, 20)
+	if len(resp) != 10 || interval != time.Minute {
+		t.Errorf("Test Failed - expected candles which fit to be unchanged, got %d %v",
+			len(resp), interval)
+	}
+
+	resp, interval = Downsample(candles, time.Minute, 3)
+	if len(resp) != 3 || interval != time.Minute*4 {
+		t.Fatalf("Test Failed - expected 3 candles of 4 minutes, got %d %v",
+			len(resp), interval)
+	}
+	if resp[0].Open != 0 || resp[0].High != 4 || resp[0].Low != -1 ||
+		resp[0].Close != 3.5 || resp[0].Volume != 4 {
+		t.Errorf("Test Failed - unexpected first downsampled candle %+v", resp[0])
+	}
+	if !resp[2].Time.Equal(start.Add(time.Minute*8)) || resp[2].Volume != 2 {
+		t.Errorf("Test Failed - unexpected last downsampled candle %+v", resp[2])
+	}
+}
+
+func TestQuery(t *testing.T) {
+	p := currency.NewPairFromStrings("BTC", "USD")
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	var candles []Candle
+	for x := 0; x < 100; x++ {
+		candles = append(candles, Candle{
+			Time:  start.Add(time.Minute * time.Duration(x)),
+			Close: float64(x),
+		})
+	}
+	err := Process("QueryTest", p, "SPOT", time.Minute, candles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := Query("QueryTest", p, "SPOT", time.Minute,
+		start.Add(time.Minute*10), start.Add(time.Minute*59), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(item.Candles) != 5 || item.Interval != time.Minute*10 ||
+		!item.Candles[0].Time.Equal(start.Add(time.Minute*10)) ||
+		item.Candles[4].Close != 59 {
+		t.Errorf("Test Failed - unexpected queried candles %v %+v", item.Interval,
+			item.Candles)
+	}
+
+	_, err = Query("QueryTest", p, "SPOT", time.Minute, start.Add(time.Hour), start, 0)
+	if err == nil {
+		t.Error("Test Failed - expected an end before start to be rejected")
+	}
+}
@@ -0,0 +1,198 @@
+package kline
+
+import (
+	"time"
+)
+
+// DefaultMaintenanceInterval is the default delay between kline storage
+// maintenance runs
+const DefaultMaintenanceInterval = time.Hour
+
+// RetentionPolicy defines how long candles of an interval are kept before
+// being compacted into a larger interval
+type RetentionPolicy struct {
+	Interval  time.Duration `json:"interval"`
+	MaxAge    time.Duration `json:"maxAge"`
+	CompactTo time.Duration `json:"compactTo"`
+}
+
+// DefaultRetentionPolicies compacts minute candles into hourly candles after a
+// week and hourly candles into daily candles after 90 days
+var DefaultRetentionPolicies = []RetentionPolicy{
+	{Interval: time.Minute, MaxAge: time.Hour * 24 * 7, CompactTo: time.Hour},
+	{Interval: time.Hour, MaxAge: time.Hour * 24 * 90, CompactTo: time.Hour * 24},
+}
+
+// Usage holds storage statistics for a candle series
+type Usage struct {
+	Exchange  string        `json:"exchange"`
+	Pair      string        `json:"pair"`
+	AssetType string        `json:"assetType"`
+	Interval  time.Duration `json:"interval"`
+	Candles   int           `json:"candles"`
+	First     time.Time     `json:"first"`
+	Last      time.Time     `json:"last"`
+}
+
+// aggregate combines candles into candles of a larger interval
+func aggregate(candles []Candle, interval time.Duration) []Candle {
+	return aggregateBy(candles, func(t time.Time) time.Time {
+		return t.Truncate(interval)
+	})
+}
+
+// aggregateBy combines consecutive candles sharing the open time returned by
+// openTime into a single candle
+func aggregateBy(candles []Candle, openTime func(time.Time) time.Time) []Candle {
+	var resp []Candle
+	for x := range candles {
+		t := openTime(candles[x].Time)
+		if len(resp) == 0 || !resp[len(resp)-1].Time.Equal(t) {
+			resp = append(resp, Candle{
+				Time:   t,
+				Open:   candles[x].Open,
+				High:   candles[x].High,
+				Low:    candles[x].Low,
+				Close:  candles[x].Close,
+				Volume: candles[x].Volume,
+			})
+			continue
+		}
+
+		c := &resp[len(resp)-1]
+		if candles[x].High > c.High {
+			c.High = candles[x].High
+		}
+		if candles[x].Low < c.Low {
+			c.Low = candles[x].Low
+		}
+		c.Close = candles[x].Close
+		c.Volume += candles[x].Volume
+	}
+	return resp
+}
+
+// fold combines aggregated candles into the series. A stored candle with the
+// same open time keeps its open, widens its high and low, adds the volume and
+// takes the close of the candle folded into it, so candles compacted on
+// separate runs add up rather than replace each other
+func (i *Item) fold(candles []Candle) {
+	for x := range candles {
+		idx, found := i.search(candles[x].Time)
+		if !found {
+			i.insert(idx, candles[x])
+			continue
+		}
+
+		c := &i.Candles[idx]
+		if candles[x].High > c.High {
+			c.High = candles[x].High
+		}
+		if candles[x].Low < c.Low {
+			c.Low = candles[x].Low
+		}
+		c.Close = candles[x].Close
+		c.Volume += candles[x].Volume
+	}
+}
+
+// Compact moves candles older than a policies max age into a series of the
+// policies larger interval and returns the number of candles compacted. Only
+// complete larger intervals are compacted, candles compacted into an interval
+// already stored are folded into its candle
+func Compact(policies []RetentionPolicy, now time.Time) int {
+	var compacted int
+	for x := range policies {
+		if policies[x].Interval <= 0 || policies[x].CompactTo <= policies[x].Interval {
+			continue
+		}
+		cutoff := now.Add(-policies[x].MaxAge).Truncate(policies[x].CompactTo)
+
+		type pending struct {
+			item    Item
+			candles []Candle
+		}
+		var toFold []pending
+
+		m.Lock()
+		for y := range Items {
+			if Items[y].Interval != policies[x].Interval {
+				continue
+			}
+			var keep, old []Candle
+			for z := range Items[y].Candles {
+				if Items[y].Candles[z].Time.Before(cutoff) {
+					old = append(old, Items[y].Candles[z])
+					continue
+				}
+				keep = append(keep, Items[y].Candles[z])
+			}
+			if len(old) == 0 {
+				continue
+			}
+			Items[y].Candles = keep
+			compacted += len(old)
+			toFold = append(toFold, pending{item: Items[y], candles: old})
+		}
+
+		for y := range toFold {
+			item := getOrCreate(toFold[y].item.Exchange,
+				toFold[y].item.Pair,
+				toFold[y].item.AssetType,
+				policies[x].CompactTo)
+			item.fold(aggregate(toFold[y].candles, policies[x].CompactTo))
+		}
+		m.Unlock()
+	}
+	return compacted
+}
+
+// Prune removes candles opening before cutoff from every series, dropping
+// series left empty, and returns the number of candles removed
+func Prune(cutoff time.Time) int {
+	m.Lock()
+	defer m.Unlock()
+
+	var removed int
+	items := Items[:0]
+	for x := range Items {
+		var candles []Candle
+		for y := range Items[x].Candles {
+			if Items[x].Candles[y].Time.Before(cutoff) {
+				removed++
+				continue
+			}
+			candles = append(candles, Items[x].Candles[y])
+		}
+		if len(candles) == 0 {
+			continue
+		}
+		Items[x].Candles = candles
+		items = append(items, Items[x])
+	}
+	Items = items
+	return removed
+}
+
+// GetUsage returns storage statistics for all stored candle series
+func GetUsage() []Usage {
+	m.Lock()
+	defer m.Unlock()
+
+	var resp []Usage
+	for x := range Items {
+		u := Usage{
+			Exchange:  Items[x].Exchange,
+			Pair:      Items[x].Pair.String(),
+			AssetType: Items[x].AssetType,
+			Interval:  Items[x].Interval,
+			Candles:   len(Items[x].Candles),
+		}
+		if len(Items[x].Candles) > 0 {
+			u.First = Items[x].Candles[0].Time
+			u.Last = Items[x].Candles[len(Items[x].Candles)-1].Time
+		}
+		resp = append(resp, u)
+	}
+	return resp
+}
@@ -0,0 +1,145 @@
+package kline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+func TestCompact(t *testing.T) {
+	m.Lock()
+	Items = nil
+	m.Unlock()
+
+	p := currency.NewPairFromStrings("ETH", "USD")
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	var candles []Candle
+	for i := 0; i < 120; i++ {
+		candles = append(candles, Candle{
+			Time:   start.Add(time.Minute * time.Duration(i)),
+			Open:   float64(i),
+			High:   float64(i + 1),
+			Low:    float64(i),
+			Close:  float64(i),
+			Volume: 1,
+		})
+	}
+	err := Process("CompactTest", p, "SPOT", time.Minute, candles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policies := []RetentionPolicy{{Interval: time.Minute, MaxAge: time.Minute * 30, CompactTo: time.Hour}}
+	now := start.Add(time.Minute * 120)
+	if compacted := Compact(policies, now); compacted != 60 {
+		t.Errorf("Test Failed - expected 60 candles compacted, got %d", compacted)
+	}
+
+	minutes, err := Get("CompactTest", p, "SPOT", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(minutes.Candles) != 60 {
+		t.Errorf("Test Failed - expected 60 minute candles remaining, got %d",
+			len(minutes.Candles))
+	}
+
+	hours, err := Get("CompactTest", p, "SPOT", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hours.Candles) != 1 {
+		t.Fatalf("Test Failed - expected 1 compacted candle, got %d", len(hours.Candles))
+	}
+	c := hours.Candles[0]
+	if c.Open != 0 || c.Close != 59 || c.High != 60 || c.Low != 0 || c.Volume != 60 {
+		t.Errorf("Test Failed - unexpected compacted candle %v", c)
+	}
+
+	var found bool
+	for _, u := range GetUsage() {
+		if u.Exchange == "CompactTest" && u.Interval == time.Hour {
+			found = u.Candles == 1 && u.First.Equal(start)
+		}
+	}
+	if !found {
+		t.Error("Test Failed - expected usage for compacted series")
+	}
+}
+
+func TestCompactFold(t *testing.T) {
+	m.Lock()
+	Items = nil
+	m.Unlock()
+
+	p := currency.NewPairFromStrings("XRP", "USD")
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := Process("FoldTest", p, "SPOT", time.Hour, []Candle{
+		{Time: start, Open: 5, High: 8, Low: 4, Close: 6, Volume: 10},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Process("FoldTest", p, "SPOT", time.Minute, []Candle{
+		{Time: start.Add(time.Minute * 30), Open: 6, High: 9, Low: 5, Close: 7, Volume: 2},
+		{Time: start.Add(time.Minute * 31), Open: 7, High: 7, Low: 3, Close: 4, Volume: 3},
+		{Time: start.Add(time.Hour), Open: 4, High: 4, Low: 4, Close: 4, Volume: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policies := []RetentionPolicy{{Interval: time.Minute, MaxAge: time.Minute, CompactTo: time.Hour}}
+	if compacted := Compact(policies, start.Add(time.Hour*3)); compacted != 3 {
+		t.Errorf("Test Failed - expected 3 candles compacted, got %d", compacted)
+	}
+
+	hours, err := Get("FoldTest", p, "SPOT", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hours.Candles) != 2 {
+		t.Fatalf("Test Failed - expected 2 hourly candles, got %v", hours.Candles)
+	}
+	c := hours.Candles[0]
+	if c.Open != 5 || c.High != 9 || c.Low != 3 || c.Close != 4 || c.Volume != 15 {
+		t.Errorf("Test Failed - expected candles folded into the stored candle, got %v", c)
+	}
+	if !hours.Candles[1].Time.Equal(start.Add(time.Hour)) || hours.Candles[1].Volume != 1 {
+		t.Errorf("Test Failed - unexpected compacted candle %v", hours.Candles[1])
+	}
+}
+
+func TestPrune(t *testing.T) {
+	m.Lock()
+	Items = nil
+	m.Unlock()
+
+	p := currency.NewPairFromStrings("BTC", "USD")
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := Process("PruneTest", p, "SPOT", time.Hour, []Candle{
+		{Time: start}, {Time: start.Add(time.Hour)}, {Time: start.Add(time.Hour * 2)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Process("PruneTest", p, "SPOT", time.Minute, []Candle{{Time: start}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if removed := Prune(start.Add(time.Hour)); removed != 2 {
+		t.Errorf("Test Failed - expected 2 candles pruned, got %d", removed)
+	}
+	if _, err = Get("PruneTest", p, "SPOT", time.Minute); err == nil {
+		t.Error("Test Failed - expected the empty series to be removed")
+	}
+	item, err := Get("PruneTest", p, "SPOT", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(item.Candles) != 2 {
+		t.Errorf("Test Failed - expected 2 candles remaining, got %d", len(item.Candles))
+	}
+}
@@ -0,0 +1,164 @@
+package kline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+type testFetcher struct {
+	name    string
+	missing map[time.Time]bool
+}
+
+func (f *testFetcher) GetName() string {
+	return f.name
+}
+
+func (f *testFetcher) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]Candle, error) {
+	var candles []Candle
+	for t := start; !t.After(end); t = t.Add(interval) {
+		if f.missing[t] {
+			continue
+		}
+		candles = append(candles, Candle{Time: t, Open: 1, High: 1, Low: 1, Close: 1})
+	}
+	return candles, nil
+}
+
+func TestParseInterval(t *testing.T) {
+	tests := map[string]time.Duration{
+		"1m":  time.Minute,
+		"15m": time.Minute * 15,
+		"4h":  time.Hour * 4,
+		"1d":  time.Hour * 24,
+		"1w":  time.Hour * 24 * 7,
+	}
+	for interval, expected := range tests {
+		d, err := ParseInterval(interval)
+		if err != nil {
+			t.Error(err)
+		}
+		if d != expected {
+			t.Errorf("Test Failed - ParseInterval %s expected %s, got %s",
+				interval, expected, d)
+		}
+	}
+
+	for _, interval := range []string{"", "m", "0m", "1x"} {
+		if _, err := ParseInterval(interval); err == nil {
+			t.Errorf("Test Failed - ParseInterval %q should have errored", interval)
+		}
+	}
+
+	if _, err := ParseInterval("1M"); err == nil || err.Error() != ErrMonthlyInterval {
+		t.Errorf("Test Failed - ParseInterval 1M should be unsupported, got %v", err)
+	}
+}
+
+func TestProcessAndFindGaps(t *testing.T) {
+	p := currency.NewPairFromStrings("BTC", "USD")
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := []Candle{
+		{Time: start.Add(time.Minute * 4)},
+		{Time: start},
+		{Time: start.Add(time.Minute)},
+		{Time: start.Add(time.Minute), Close: 2},
+	}
+
+	err := Process("GapTest", p, "SPOT", time.Minute, candles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := Get("GapTest", p, "SPOT", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(item.Candles) != 3 {
+		t.Fatalf("Test Failed - expected duplicate candle to be replaced, got %d candles",
+			len(item.Candles))
+	}
+	if item.Candles[1].Close != 2 {
+		t.Error("Test Failed - expected latest candle to replace the stored candle")
+	}
+	if !item.Candles[0].Time.Equal(start) ||
+		!item.Candles[2].Time.Equal(start.Add(time.Minute*4)) {
+		t.Errorf("Test Failed - expected candles sorted by open time, got %v", item.Candles)
+	}
+
+	gaps := item.FindGaps()
+	if len(gaps) != 1 {
+		t.Fatalf("Test Failed - expected 1 gap, got %d", len(gaps))
+	}
+	if !gaps[0].Start.Equal(start.Add(time.Minute*2)) ||
+		!gaps[0].End.Equal(start.Add(time.Minute*4)) {
+		t.Errorf("Test Failed - unexpected gap %v", gaps[0])
+	}
+
+	err = Process("", p, "SPOT", time.Minute, candles)
+	if err == nil {
+		t.Error("Test Failed - expected error with no exchange name")
+	}
+}
+
+func TestCheckIntegrity(t *testing.T) {
+	p := currency.NewPairFromStrings("BTC", "USD")
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := Process("IntegrityTest", p, "SPOT", time.Minute, []Candle{
+		{Time: start},
+		{Time: start.Add(time.Minute * 5)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &testFetcher{
+		name:    "IntegrityTest",
+		missing: map[time.Time]bool{start.Add(time.Minute * 3): true},
+	}
+
+	unrecovered, err := CheckIntegrity(f, p, "SPOT", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unrecovered) != 1 || !unrecovered[0].Start.Equal(start.Add(time.Minute*3)) {
+		t.Errorf("Test Failed - expected 1 unrecoverable gap, got %v", unrecovered)
+	}
+
+	item, err := Get("IntegrityTest", p, "SPOT", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(item.Candles) != 5 {
+		t.Errorf("Test Failed - expected 5 candles after backfill, got %d",
+			len(item.Candles))
+	}
+
+	_, err = CheckIntegrity(&testFetcher{name: "NotStored"}, p, "SPOT", time.Minute)
+	if err == nil {
+		t.Error("Test Failed - expected error for a series which does not exist")
+	}
+}
+
+func TestFilterCandles(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := []Candle{
+		{Time: start.Add(time.Minute * 2), Close: 3},
+		{Time: start.Add(-time.Minute), Close: 0},
+		{Time: start, Close: 1},
+		{Time: start.Add(time.Minute * 3), Close: 4},
+		{Time: start.Add(time.Minute), Close: 2},
+	}
+	resp := FilterCandles(candles, start, start.Add(time.Minute*2))
+	if len(resp) != 3 {
+		t.Fatalf("Test Failed - FilterCandles expected 3 candles within the range, got %d", len(resp))
+	}
+	for x := range resp {
+		if resp[x].Close != float64(x+1) {
+			t.Errorf("Test Failed - FilterCandles expected candles sorted by open time, got %+v", resp)
+			break
+		}
+	}
+}
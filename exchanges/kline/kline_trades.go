@@ -0,0 +1,66 @@
+package kline
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+// ProcessTrade builds candles locally from a trade for exchanges without kline
+// endpoints or websocket candles. The trade is applied to the stored candle of
+// each interval it falls in, opening a new candle at the trade price when none
+// is stored. Trades are expected in time order as the last trade sets the close
+func ProcessTrade(exchName string, p currency.Pair, assetType string, intervals []time.Duration, t time.Time, price, amount float64) error {
+	if price <= 0 || amount < 0 {
+		return errors.New("kline trade price or amount invalid")
+	}
+
+	for _, interval := range intervals {
+		if interval <= 0 {
+			return errors.New(ErrInvalidInterval)
+		}
+
+		candle := Candle{
+			Time:   t.Truncate(interval),
+			Open:   price,
+			High:   price,
+			Low:    price,
+			Close:  price,
+			Volume: amount,
+		}
+		if stored, ok := getCandle(exchName, p, assetType, interval, candle.Time); ok {
+			candle.Open = stored.Open
+			if stored.High > candle.High {
+				candle.High = stored.High
+			}
+			if stored.Low < candle.Low {
+				candle.Low = stored.Low
+			}
+			candle.Volume += stored.Volume
+		}
+
+		err := Process(exchName, p, assetType, interval, []Candle{candle})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getCandle returns the stored candle of a series opening at a time
+func getCandle(exchName string, p currency.Pair, assetType string, interval time.Duration, open time.Time) (Candle, bool) {
+	m.Lock()
+	defer m.Unlock()
+	for x := range Items {
+		if !Items[x].matches(exchName, p, assetType, interval) {
+			continue
+		}
+		for y := len(Items[x].Candles) - 1; y >= 0; y-- {
+			if Items[x].Candles[y].Time.Equal(open) {
+				return Items[x].Candles[y], true
+			}
+		}
+	}
+	return Candle{}, false
+}
@@ -0,0 +1,58 @@
+package kline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+func TestProcessTrade(t *testing.T) {
+	p := currency.NewPairFromStrings("BTC", "USD")
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	intervals := []time.Duration{time.Minute, time.Minute * 5}
+	trades := []struct {
+		offset time.Duration
+		price  float64
+	}{
+		{time.Second * 10, 100},
+		{time.Second * 20, 105},
+		{time.Second * 30, 95},
+		{time.Second * 50, 101},
+		{time.Minute + time.Second, 102},
+	}
+	for x := range trades {
+		err := ProcessTrade("TradesTest", p, "SPOT", intervals,
+			start.Add(trades[x].offset), trades[x].price, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	item, err := Get("TradesTest", p, "SPOT", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(item.Candles) != 2 {
+		t.Fatalf("Test Failed - expected 2 one minute candles, got %d",
+			len(item.Candles))
+	}
+	first := item.Candles[0]
+	if !first.Time.Equal(start) || first.Open != 100 || first.High != 105 ||
+		first.Low != 95 || first.Close != 101 || first.Volume != 4 {
+		t.Errorf("Test Failed - unexpected first candle %+v", first)
+	}
+
+	item, err = Get("TradesTest", p, "SPOT", time.Minute*5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(item.Candles) != 1 || item.Candles[0].Close != 102 ||
+		item.Candles[0].Volume != 5 {
+		t.Errorf("Test Failed - unexpected five minute candles %+v", item.Candles)
+	}
+
+	if err := ProcessTrade("TradesTest", p, "SPOT", intervals, start, 0, 1); err == nil {
+		t.Error("Test Failed - expected an error for a trade without a price")
+	}
+}
@@ -63,6 +63,17 @@ type Kraken struct {
 	wsRequestMtx       sync.Mutex
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "Kraken",
+		Creator: func() exchange.IBotExchange {
+			return new(Kraken)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability,
+	})
+}
+
 // SetDefaults sets current default settings
 func (k *Kraken) SetDefaults() {
 	k.Name = "Kraken"
@@ -83,6 +94,8 @@ func (k *Kraken) SetDefaults() {
 	k.AssetTypes = []string{ticker.Spot}
 	k.SupportsAutoPairUpdating = true
 	k.SupportsRESTTickerBatching = true
+	k.SupportsKlines = true
+	k.SupportsTradeHistory = true
 	k.Requester = request.New(k.Name,
 		request.NewRateLimit(time.Second, krakenAuthRate),
 		request.NewRateLimit(time.Second, krakenUnauthRate),
@@ -110,8 +123,10 @@ func (k *Kraken) Setup(exch *config.ExchangeConfig) {
 		k.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		k.SetHTTPClientTimeout(exch.HTTPTimeout)
 		k.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		k.SetHTTPHeaders(exch.HTTPHeaders)
 		k.RESTPollingDelay = exch.RESTPollingDelay
 		k.Verbose = exch.Verbose
+		k.ReadOnly = exch.ReadOnly
 		k.HTTPDebugging = exch.HTTPDebugging
 		k.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		k.BaseCurrencies = exch.BaseCurrencies
@@ -148,6 +163,7 @@ func (k *Kraken) Setup(exch *config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		k.Websocket.SetHeartbeat(k.wsHeartbeat())
 	}
 }
 
@@ -279,9 +295,17 @@ func (k *Kraken) GetTickers(pairList string) (Tickers, error) {
 }
 
 // GetOHLC returns an array of open high low close values of a currency pair
-func (k *Kraken) GetOHLC(symbol string) ([]OpenHighLowClose, error) {
+// for an interval in minutes since a unix timestamp. A zero interval or since
+// uses the exchange defaults
+func (k *Kraken) GetOHLC(symbol string, interval int, since int64) ([]OpenHighLowClose, error) {
 	values := url.Values{}
 	values.Set("pair", symbol)
+	if interval > 0 {
+		values.Set("interval", strconv.Itoa(interval))
+	}
+	if since > 0 {
+		values.Set("since", strconv.FormatInt(since, 10))
+	}
 
 	type Response struct {
 		Error []interface{}          `json:"error"`
@@ -302,7 +326,21 @@ func (k *Kraken) GetOHLC(symbol string) ([]OpenHighLowClose, error) {
 		return OHLC, fmt.Errorf("getOHLC error: %s", result.Error)
 	}
 
-	for _, y := range result.Data[symbol].([]interface{}) {
+	// Results are keyed by the exchange pair name, which can differ from the
+	// requested symbol, alongside the last timestamp
+	var data []interface{}
+	for key, value := range result.Data {
+		if key == "last" {
+			continue
+		}
+		var ok bool
+		if data, ok = value.([]interface{}); !ok {
+			return OHLC, errors.New("getOHLC unable to type assert OHLC data")
+		}
+		break
+	}
+
+	for _, y := range data {
 		o := OpenHighLowClose{}
 		for i, x := range y.([]interface{}) {
 			switch i {
@@ -384,10 +422,15 @@ func (k *Kraken) GetDepth(symbol string) (Orderbook, error) {
 	return orderBook, err
 }
 
-// GetTrades returns current trades on Kraken
-func (k *Kraken) GetTrades(symbol string) ([]RecentTrades, error) {
+// GetTrades returns up to 1000 trades of a currency pair after a unix
+// nanosecond timestamp, oldest first. A zero since returns the most recent
+// trades
+func (k *Kraken) GetTrades(symbol string, since int64) ([]RecentTrades, error) {
 	values := url.Values{}
 	values.Set("pair", symbol)
+	if since > 0 {
+		values.Set("since", strconv.FormatInt(since, 10))
+	}
 
 	var recentTrades []RecentTrades
 	var result interface{}
@@ -938,8 +981,7 @@ func (k *Kraken) SendHTTPRequest(path string, result interface{}) error {
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request
 func (k *Kraken) SendAuthenticatedHTTPRequest(method string, params url.Values, result interface{}) (err error) {
 	if !k.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet,
-			k.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	path := fmt.Sprintf("/%s/private/%s", krakenAPIVersion, method)
@@ -1048,7 +1090,7 @@ func getCryptocurrencyDepositFee(c currency.Code) float64 {
 }
 
 func calculateTradingFee(currency string, feePair map[string]TradeVolumeFee, purchasePrice, amount float64) float64 {
-	return (feePair[currency].Fee / 100) * purchasePrice * amount
+	return exchange.CalculateTradingFee(feePair[currency].Fee/100, purchasePrice, amount)
 }
 
 // GetCryptoDepositAddress returns a deposit address for a cryptocurrency
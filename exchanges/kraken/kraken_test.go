@@ -91,7 +91,7 @@ func TestGetTickers(t *testing.T) {
 // TestGetOHLC API endpoint test
 func TestGetOHLC(t *testing.T) {
 	t.Parallel()
-	_, err := k.GetOHLC("BCHEUR")
+	_, err := k.GetOHLC("BCHEUR", 0, 0)
 	if err != nil {
 		t.Error("Test Failed - GetOHLC() error", err)
 	}
@@ -109,7 +109,7 @@ func TestGetDepth(t *testing.T) {
 // TestGetTrades API endpoint test
 func TestGetTrades(t *testing.T) {
 	t.Parallel()
-	_, err := k.GetTrades("BCHEUR")
+	_, err := k.GetTrades("BCHEUR", 0)
 	if err != nil {
 		t.Error("Test Failed - GetTrades() error", err)
 	}
@@ -229,6 +229,14 @@ func TestGetTradeVolume(t *testing.T) {
 	}
 }
 
+func TestGetFeeTier(t *testing.T) {
+	t.Parallel()
+	_, err := k.GetFeeTier(currency.NewPair(currency.XBT, currency.USD))
+	if err == nil {
+		t.Error("Test Failed - GetFeeTier() error", err)
+	}
+}
+
 // TestAddOrder API endpoint test
 func TestAddOrder(t *testing.T) {
 	t.Parallel()
@@ -490,8 +498,8 @@ func TestCancelAllExchangeOrders(t *testing.T) {
 		t.Errorf("Could not cancel orders: %v", err)
 	}
 
-	if len(resp.OrderStatus) > 0 {
-		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
+	if len(resp.Failed()) > 0 {
+		t.Errorf("%v orders failed to cancel", len(resp.Failed()))
 	}
 }
 
@@ -2,6 +2,9 @@ package kraken
 
 import "github.com/thrasher-/gocryptotrader/currency"
 
+// klineIntervals are the OHLC intervals in minutes the exchange supports
+var klineIntervals = []int{1, 5, 15, 30, 60, 240, 1440, 10080, 21600}
+
 // TimeResponse type
 type TimeResponse struct {
 	Unixtime int64  `json:"unixtime"`
@@ -30,6 +30,10 @@ const (
 	// If a checksum fails, then resubscribing to the channel fails, fatal after these attempts
 	krakenWsResubscribeFailureLimit   = 3
 	krakenWsResubscribeDelayInSeconds = 3
+	// krakenWsPingInterval keeps the connection alive, a connection without
+	// traffic for krakenWsHeartbeatTimeout is reset
+	krakenWsPingInterval     = time.Second * 27
+	krakenWsHeartbeatTimeout = time.Minute
 	// WS endpoints
 	krakenWsHeartbeat          = "heartbeat"
 	krakenWsPing               = "ping"
@@ -112,7 +116,6 @@ func (k *Kraken) WsConnect() error {
 			k.Websocket.GetWebsocketURL())
 	}
 	go k.WsHandleData()
-	go k.wsPingHandler()
 	if subscribeToDefaultChannels {
 		k.GenerateDefaultSubscriptions()
 	}
@@ -149,29 +152,19 @@ func (k *Kraken) WsReadData() (exchange.WebsocketResponse, error) {
 	return exchange.WebsocketResponse{Raw: standardMessage}, nil
 }
 
-// wsPingHandler sends a message "ping" every 27 to maintain the connection to the websocket
-func (k *Kraken) wsPingHandler() {
-	k.Websocket.Wg.Add(1)
-	defer k.Websocket.Wg.Done()
-	ticker := time.NewTicker(time.Second * 27)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-k.Websocket.ShutdownC:
-			return
-
-		case <-ticker.C:
-			pingEvent := fmt.Sprintf("{\"event\":\"%v\"}", krakenWsPing)
-			if k.Verbose {
-				log.Debugf("%v sending ping",
-					k.GetName())
-			}
-			err := k.writeToWebsocket([]byte(pingEvent))
-			if err != nil {
-				k.Websocket.DataHandler <- err
-			}
-		}
+// wsHeartbeat returns the heartbeat keeping the websocket connection alive,
+// Kraken replies to ping events with a pong event
+func (k *Kraken) wsHeartbeat() exchange.WebsocketHeartbeat {
+	return exchange.WebsocketHeartbeat{
+		Interval: krakenWsPingInterval,
+		Timeout:  krakenWsHeartbeatTimeout,
+		Payload:  []byte(fmt.Sprintf("{\"event\":\"%v\"}", krakenWsPing)),
+		Send:     k.writeToWebsocket,
+		IsPong: func(raw []byte) bool {
+			var event WebsocketEventResponse
+			return common.JSONDecode(raw, &event) == nil &&
+				event.Event == krakenWsPong
+		},
 	}
 }
 
@@ -194,6 +187,9 @@ func (k *Kraken) WsHandleData() {
 					err)
 				time.Sleep(time.Second)
 			}
+			if k.Websocket.HandleHeartbeat(resp.Raw) {
+				continue
+			}
 			// event response handling
 			var eventResponse WebsocketEventResponse
 			err = common.JSONDecode(resp.Raw, &eventResponse)
@@ -262,11 +258,6 @@ func (k *Kraken) WsHandleEventResponse(response *WebsocketEventResponse) {
 			log.Debugf("%v Websocket heartbeat data received",
 				k.GetName())
 		}
-	case krakenWsPong:
-		if k.Verbose {
-			log.Debugf("%v Websocket pong data received",
-				k.GetName())
-		}
 	case krakenWsSystemStatus:
 		if k.Verbose {
 			log.Debugf("%v Websocket status data received",
@@ -10,6 +10,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -31,56 +32,57 @@ func (k *Kraken) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", k.GetName(), len(k.EnabledPairs), k.EnabledPairs)
 	}
 
-	assetPairs, err := k.GetAssetPairs()
-	if err != nil {
-		log.Errorf("%s Failed to get available symbols.\n", k.GetName())
-	} else {
-		forceUpgrade := false
-		if !common.StringDataContains(k.EnabledPairs.Strings(), "-") ||
-			!common.StringDataContains(k.AvailablePairs.Strings(), "-") {
-			forceUpgrade = true
-		}
+	forceUpgrade := false
+	if !common.StringDataContains(k.EnabledPairs.Strings(), "-") ||
+		!common.StringDataContains(k.AvailablePairs.Strings(), "-") {
+		forceUpgrade = true
+	}
 
-		var exchangeProducts []string
-		for i := range assetPairs {
-			v := assetPairs[i]
-			if common.StringContains(v.Altname, ".d") {
-				continue
-			}
-			if v.Base[0] == 'X' {
-				if len(v.Base) > 3 {
-					v.Base = v.Base[1:]
-				}
-			}
-			if v.Quote[0] == 'Z' || v.Quote[0] == 'X' {
-				v.Quote = v.Quote[1:]
-			}
-			exchangeProducts = append(exchangeProducts, v.Base+"-"+v.Quote)
+	if forceUpgrade {
+		enabledPairs := currency.Pairs{currency.Pair{
+			Base: currency.XBT, Quote: currency.USD, Delimiter: "-"}}
+
+		log.Warn("Available pairs for Kraken reset due to config upgrade, please enable the ones you would like again")
+
+		err := k.UpdateCurrencies(enabledPairs, true, true)
+		if err != nil {
+			log.Errorf("%s Failed to get config.\n", k.GetName())
 		}
+	}
 
-		if forceUpgrade {
-			enabledPairs := currency.Pairs{currency.Pair{
-				Base: currency.XBT, Quote: currency.USD, Delimiter: "-"}}
+	err := k.UpdateTradablePairs(forceUpgrade)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", k.Name, err)
+	}
+}
 
-			log.Warn("Available pairs for Kraken reset due to config upgrade, please enable the ones you would like again")
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (k *Kraken) UpdateTradablePairs(forceUpdate bool) error {
+	assetPairs, err := k.GetAssetPairs()
+	if err != nil {
+		return err
+	}
 
-			err = k.UpdateCurrencies(enabledPairs, true, true)
-			if err != nil {
-				log.Errorf("%s Failed to get config.\n", k.GetName())
+	var exchangeProducts currency.Pairs
+	for i := range assetPairs {
+		v := assetPairs[i]
+		if common.StringContains(v.Altname, ".d") {
+			continue
+		}
+		if v.Base[0] == 'X' {
+			if len(v.Base) > 3 {
+				v.Base = v.Base[1:]
 			}
 		}
-
-		var newExchangeProducts currency.Pairs
-		for _, p := range exchangeProducts {
-			newExchangeProducts = append(newExchangeProducts,
-				currency.NewPairFromString(p))
-		}
-
-		err = k.UpdateCurrencies(newExchangeProducts, false, forceUpgrade)
-		if err != nil {
-			log.Errorf("%s Failed to get config.\n", k.GetName())
+		if v.Quote[0] == 'Z' || v.Quote[0] == 'X' {
+			v.Quote = v.Quote[1:]
 		}
+		exchangeProducts = append(exchangeProducts,
+			currency.NewPairFromString(v.Base+"-"+v.Quote))
 	}
+
+	return k.UpdateCurrencies(exchangeProducts, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -110,6 +112,7 @@ func (k *Kraken) UpdateTicker(p currency.Pair, assetType string) (ticker.Price,
 			tp.High = z.High
 			tp.Low = z.Low
 			tp.Volume = z.Volume
+			tp.VWAP = z.VWAP
 			ticker.ProcessTicker(k.GetName(), &tp, assetType)
 		}
 	}
@@ -175,10 +178,10 @@ func (k *Kraken) GetAccountInfo() (exchange.AccountInfo, error) {
 
 	var balances []exchange.AccountCurrencyInfo
 	for key, data := range bal {
-		balances = append(balances, exchange.AccountCurrencyInfo{
-			CurrencyName: currency.NewCode(key),
-			TotalValue:   data,
-		})
+		// Kraken only reports the total balance, funds in open orders
+		// are not separated
+		balances = append(balances,
+			exchange.NewAccountCurrencyInfoFromTotal(currency.NewCode(key), data, 0))
 	}
 
 	info.Accounts = append(info.Accounts, exchange.Account{
@@ -202,8 +205,77 @@ func (k *Kraken) GetExchangeHistory(p currency.Pair, assetType string) ([]exchan
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive. Only the
+// most recent 720 candles of an interval are available
+func (k *Kraken) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	supported := false
+	for x := range klineIntervals {
+		if time.Duration(klineIntervals[x])*time.Minute == interval {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, fmt.Errorf("%s unsupported kline interval %s", k.Name, interval)
+	}
+
+	// since is exclusive of the candle starting at it
+	resp, err := k.GetOHLC(exchange.FormatExchangeCurrency(k.Name, p).String(),
+		int(interval/time.Minute), start.Unix()-1)
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]kline.Candle, len(resp))
+	for x := range resp {
+		candles[x] = kline.Candle{
+			Time:   time.Unix(int64(resp[x].Time), 0),
+			Open:   resp[x].Open,
+			High:   resp[x].High,
+			Low:    resp[x].Low,
+			Close:  resp[x].Close,
+			Volume: resp[x].Volume,
+		}
+	}
+	return kline.FilterCandles(candles, start, end), nil
+}
+
+// GetHistoricTrades returns trades between start and end inclusive, oldest
+// first
+func (k *Kraken) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	// since is exclusive of trades executed at it
+	resp, err := k.GetTrades(exchange.FormatExchangeCurrency(k.Name, p).String(),
+		start.UnixNano()-1)
+	if err != nil {
+		return nil, err
+	}
+
+	var trades []exchange.TradeHistory
+	for x := range resp {
+		timestamp := time.Unix(0, int64(resp[x].Time*float64(time.Second))).Round(time.Microsecond * 100)
+		if timestamp.After(end) {
+			break
+		}
+		side := exchange.BuyOrderSide
+		if resp[x].BuyOrSell == "s" {
+			side = exchange.SellOrderSide
+		}
+		trades = append(trades, exchange.TradeHistory{
+			Timestamp: timestamp,
+			Price:     resp[x].Price,
+			Amount:    resp[x].Volume,
+			Exchange:  k.Name,
+			Type:      side.ToString(),
+		})
+	}
+	return trades, nil
+}
+
 // SubmitOrder submits a new order
 func (k *Kraken) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if k.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var args = AddOrderOptions{}
 
@@ -230,11 +302,17 @@ func (k *Kraken) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (k *Kraken) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if k.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (k *Kraken) CancelOrder(order *exchange.OrderCancellation) error {
+	if k.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	_, err := k.CancelExistingOrder(order.OrderID)
 
 	return err
@@ -242,9 +320,10 @@ func (k *Kraken) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (k *Kraken) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if k.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	var emptyOrderOptions OrderInfoOptions
 	openOrders, err := k.GetOpenOrders(emptyOrderOptions)
 	if err != nil {
@@ -254,9 +333,7 @@ func (k *Kraken) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.Cancel
 	if openOrders.Count > 0 {
 		for orderID := range openOrders.Open {
 			_, err = k.CancelExistingOrder(orderID)
-			if err != nil {
-				cancelAllOrdersResponse.OrderStatus[orderID] = err.Error()
-			}
+			cancelAllOrdersResponse.Add(orderID, err)
 		}
 	}
 
@@ -291,18 +368,27 @@ func (k *Kraken) GetDepositAddress(cryptocurrency currency.Code, _ string) (stri
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal
 // Populate exchange.WithdrawRequest.TradePassword with withdrawal key name, as set up on your account
 func (k *Kraken) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if k.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return k.Withdraw(withdrawRequest.Currency.String(), withdrawRequest.TradePassword, withdrawRequest.Amount)
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (k *Kraken) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if k.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return k.WithdrawCryptocurrencyFunds(withdrawRequest)
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (k *Kraken) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if k.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return k.WithdrawCryptocurrencyFunds(withdrawRequest)
 }
 
@@ -347,6 +433,7 @@ func (k *Kraken) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
@@ -391,12 +478,48 @@ func (k *Kraken) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) ([
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 	exchange.FilterOrdersByCurrencies(&orders, getOrdersRequest.Currencies)
 
 	return orders, nil
 }
 
+// GetFeeTier returns the maker and taker fees of a currency pair for the
+// account based on its 30 day trading volume
+func (k *Kraken) GetFeeTier(p currency.Pair) (exchange.FeeTier, error) {
+	symbol := exchange.FormatExchangeCurrency(k.GetName(), p).String()
+	volume, err := k.GetTradeVolume(true, symbol)
+	if err != nil {
+		return exchange.FeeTier{}, err
+	}
+
+	taker, ok := volume.Fees[symbol]
+	if !ok {
+		return exchange.FeeTier{}, fmt.Errorf("%s fee tier not returned for %s",
+			k.Name, symbol)
+	}
+
+	// Pairs without maker fees charge the taker fee for all orders
+	maker, ok := volume.FeesMaker[symbol]
+	if !ok {
+		maker = taker
+	}
+
+	// Kraken prefixes fiat assets with Z, such as ZUSD
+	volumeCurrency := volume.Currency
+	if len(volumeCurrency) == 4 && strings.HasPrefix(volumeCurrency, "Z") {
+		volumeCurrency = volumeCurrency[1:]
+	}
+
+	return exchange.FeeTier{
+		Volume30D:      volume.Volume,
+		VolumeCurrency: currency.NewCode(volumeCurrency),
+		MakerFee:       maker.Fee / 100,
+		TakerFee:       taker.Fee / 100,
+	}, nil
+}
+
 // SubscribeToWebsocketChannels appends to ChannelsToSubscribe
 // which lets websocket.manageSubscriptions handle subscribing
 func (k *Kraken) SubscribeToWebsocketChannels(channels []exchange.WebsocketChannelSubscription) error {
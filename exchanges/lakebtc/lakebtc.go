@@ -42,6 +42,16 @@ type LakeBTC struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "LakeBTC",
+		Creator: func() exchange.IBotExchange {
+			return new(LakeBTC)
+		},
+		Capabilities: exchange.SpotCapability,
+	})
+}
+
 // SetDefaults sets LakeBTC defaults
 func (l *LakeBTC) SetDefaults() {
 	l.Name = "LakeBTC"
@@ -78,8 +88,10 @@ func (l *LakeBTC) Setup(exch *config.ExchangeConfig) {
 		l.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		l.SetHTTPClientTimeout(exch.HTTPTimeout)
 		l.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		l.SetHTTPHeaders(exch.HTTPHeaders)
 		l.RESTPollingDelay = exch.RESTPollingDelay
 		l.Verbose = exch.Verbose
+		l.ReadOnly = exch.ReadOnly
 		l.HTTPDebugging = exch.HTTPDebugging
 		l.BaseCurrencies = exch.BaseCurrencies
 		l.AvailablePairs = exch.AvailablePairs
@@ -341,7 +353,7 @@ func (l *LakeBTC) SendHTTPRequest(path string, result interface{}) error {
 // SendAuthenticatedHTTPRequest sends an autheticated HTTP request to a LakeBTC
 func (l *LakeBTC) SendAuthenticatedHTTPRequest(method, params string, result interface{}) (err error) {
 	if !l.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, l.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	n := l.Requester.GetNonce(true).String()
@@ -409,7 +421,7 @@ func calculateTradingFee(purchasePrice, amount float64, isMaker bool) (fee float
 		fee = 0.002
 	}
 
-	return fee * amount * purchasePrice
+	return exchange.CalculateTradingFee(fee, purchasePrice, amount)
 }
 
 func getCryptocurrencyWithdrawalFee(c currency.Code) (fee float64) {
@@ -11,6 +11,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -32,21 +33,27 @@ func (l *LakeBTC) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", l.GetName(), len(l.EnabledPairs), l.EnabledPairs)
 	}
 
+	err := l.UpdateTradablePairs(false)
+	if err != nil {
+		log.Errorf("%s failed to update tradable pairs. Err: %s", l.Name, err)
+	}
+}
+
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (l *LakeBTC) UpdateTradablePairs(forceUpdate bool) error {
 	exchangeProducts, err := l.GetTradablePairs()
 	if err != nil {
-		log.Errorf("%s Failed to get available products.\n", l.GetName())
-	} else {
-		var newExchangeProducts currency.Pairs
-		for _, p := range exchangeProducts {
-			newExchangeProducts = append(newExchangeProducts,
-				currency.NewPairFromString(p))
-		}
+		return err
+	}
 
-		err = l.UpdateCurrencies(newExchangeProducts, false, false)
-		if err != nil {
-			log.Errorf("%s Failed to update available currencies.\n", l.GetName())
-		}
+	var newExchangeProducts currency.Pairs
+	for _, p := range exchangeProducts {
+		newExchangeProducts = append(newExchangeProducts,
+			currency.NewPairFromString(p))
 	}
+
+	return l.UpdateCurrencies(newExchangeProducts, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -137,11 +144,10 @@ func (l *LakeBTC) GetAccountInfo() (exchange.AccountInfo, error) {
 			if z != x {
 				continue
 			}
-			var exchangeCurrency exchange.AccountCurrencyInfo
-			exchangeCurrency.CurrencyName = currency.NewCode(x)
-			exchangeCurrency.TotalValue, _ = strconv.ParseFloat(y, 64)
-			exchangeCurrency.Hold, _ = strconv.ParseFloat(w, 64)
-			currencies = append(currencies, exchangeCurrency)
+			total, _ := strconv.ParseFloat(y, 64)
+			hold, _ := strconv.ParseFloat(w, 64)
+			currencies = append(currencies,
+				exchange.NewAccountCurrencyInfoFromTotal(currency.NewCode(x), total, hold))
 		}
 	}
 
@@ -166,8 +172,21 @@ func (l *LakeBTC) GetExchangeHistory(p currency.Pair, assetType string) ([]excha
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive
+func (l *LakeBTC) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (l *LakeBTC) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order
 func (l *LakeBTC) SubmitOrder(p currency.Pair, side exchange.OrderSide, _ exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if l.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	isBuyOrder := side == exchange.BuyOrderSide
 	response, err := l.Trade(isBuyOrder, amount, price, p.Lower().String())
@@ -186,11 +205,17 @@ func (l *LakeBTC) SubmitOrder(p currency.Pair, side exchange.OrderSide, _ exchan
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (l *LakeBTC) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if l.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (l *LakeBTC) CancelOrder(order *exchange.OrderCancellation) error {
+	if l.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -202,9 +227,10 @@ func (l *LakeBTC) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (l *LakeBTC) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if l.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	openOrders, err := l.GetOpenOrders()
 	if err != nil {
 		return cancelAllOrdersResponse, err
@@ -216,8 +242,11 @@ func (l *LakeBTC) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.Cance
 		ordersToCancel = append(ordersToCancel, orderIDString)
 	}
 
-	return cancelAllOrdersResponse, l.CancelExistingOrders(ordersToCancel)
-
+	err = l.CancelExistingOrders(ordersToCancel)
+	for i := range ordersToCancel {
+		cancelAllOrdersResponse.Add(ordersToCancel[i], err)
+	}
+	return cancelAllOrdersResponse, err
 }
 
 // GetOrderInfo returns information on a current open order
@@ -244,6 +273,9 @@ func (l *LakeBTC) GetDepositAddress(cryptocurrency currency.Code, _ string) (str
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (l *LakeBTC) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if l.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	if withdrawRequest.Currency != currency.BTC {
 		return "", errors.New("only BTC supported for withdrawals")
 	}
@@ -259,12 +291,18 @@ func (l *LakeBTC) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.Withdraw
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (l *LakeBTC) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if l.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (l *LakeBTC) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if l.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -307,6 +345,7 @@ func (l *LakeBTC) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) (
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 	exchange.FilterOrdersByCurrencies(&orders, getOrdersRequest.Currencies)
@@ -344,6 +383,7 @@ func (l *LakeBTC) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) (
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
@@ -113,6 +113,15 @@ type LocalBitcoins struct {
 	exchange.Base
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "LocalBitcoins",
+		Creator: func() exchange.IBotExchange {
+			return new(LocalBitcoins)
+		},
+	})
+}
+
 // SetDefaults sets the package defaults for localbitcoins
 func (l *LocalBitcoins) SetDefaults() {
 	l.Name = "LocalBitcoins"
@@ -147,8 +156,10 @@ func (l *LocalBitcoins) Setup(exch *config.ExchangeConfig) {
 		l.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		l.SetHTTPClientTimeout(exch.HTTPTimeout)
 		l.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		l.SetHTTPHeaders(exch.HTTPHeaders)
 		l.RESTPollingDelay = exch.RESTPollingDelay
 		l.Verbose = exch.Verbose
+		l.ReadOnly = exch.ReadOnly
 		l.HTTPDebugging = exch.HTTPDebugging
 		l.BaseCurrencies = exch.BaseCurrencies
 		l.AvailablePairs = exch.AvailablePairs
@@ -734,7 +745,7 @@ func (l *LocalBitcoins) SendHTTPRequest(path string, result interface{}) error {
 // localbitcoins
 func (l *LocalBitcoins) SendAuthenticatedHTTPRequest(method, path string, params url.Values, result interface{}) (err error) {
 	if !l.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, l.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	n := l.Requester.GetNonce(true).String()
@@ -12,6 +12,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -33,28 +34,27 @@ func (l *LocalBitcoins) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", l.GetName(), len(l.EnabledPairs), l.EnabledPairs)
 	}
 
-	currencies, err := l.GetTradableCurrencies()
+	err := l.UpdateTradablePairs(false)
 	if err != nil {
-		log.Errorf("%s failed to obtain available tradable currencies. Err: %s", l.Name, err)
-		return
+		log.Errorf("%s failed to update tradable pairs. Err: %s", l.Name, err)
 	}
+}
 
-	var pairs []string
-	for x := range currencies {
-		pairs = append(pairs, "BTC"+currencies[x])
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (l *LocalBitcoins) UpdateTradablePairs(forceUpdate bool) error {
+	currencies, err := l.GetTradableCurrencies()
+	if err != nil {
+		return err
 	}
 
 	var newExchangeProducts currency.Pairs
-	for _, p := range pairs {
+	for x := range currencies {
 		newExchangeProducts = append(newExchangeProducts,
-			currency.NewPairFromString(p))
-	}
-
-	err = l.UpdateCurrencies(newExchangeProducts, false, false)
-	if err != nil {
-		log.Errorf("%s failed to update available currencies. Err %s", l.Name, err)
+			currency.NewPairFromString("BTC"+currencies[x]))
 	}
 
+	return l.UpdateCurrencies(newExchangeProducts, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -138,9 +138,8 @@ func (l *LocalBitcoins) GetAccountInfo() (exchange.AccountInfo, error) {
 	if err != nil {
 		return response, err
 	}
-	var exchangeCurrency exchange.AccountCurrencyInfo
-	exchangeCurrency.CurrencyName = currency.BTC
-	exchangeCurrency.TotalValue = accountBalance.Total.Balance
+	exchangeCurrency := exchange.NewAccountCurrencyInfoFromTotalAvailable(currency.BTC,
+		accountBalance.Total.Balance, accountBalance.Total.Sendable)
 
 	response.Accounts = append(response.Accounts, exchange.Account{
 		Currencies: []exchange.AccountCurrencyInfo{exchangeCurrency},
@@ -162,8 +161,21 @@ func (l *LocalBitcoins) GetExchangeHistory(p currency.Pair, assetType string) ([
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive
+func (l *LocalBitcoins) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (l *LocalBitcoins) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order
 func (l *LocalBitcoins) SubmitOrder(p currency.Pair, side exchange.OrderSide, _ exchange.OrderType, amount, _ float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if l.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	// These are placeholder details
 	// TODO store a user's localbitcoin details to use here
@@ -232,19 +244,26 @@ func (l *LocalBitcoins) SubmitOrder(p currency.Pair, side exchange.OrderSide, _
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (l *LocalBitcoins) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if l.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (l *LocalBitcoins) CancelOrder(order *exchange.OrderCancellation) error {
+	if l.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	return l.DeleteAd(order.OrderID)
 }
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (l *LocalBitcoins) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if l.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	ads, err := l.Getads()
 	if err != nil {
 		return cancelAllOrdersResponse, err
@@ -253,9 +272,7 @@ func (l *LocalBitcoins) CancelAllOrders(_ *exchange.OrderCancellation) (exchange
 	for i := range ads.AdList {
 		adIDString := strconv.FormatInt(ads.AdList[i].Data.AdID, 10)
 		err = l.DeleteAd(adIDString)
-		if err != nil {
-			cancelAllOrdersResponse.OrderStatus[strconv.FormatInt(ads.AdList[i].Data.AdID, 10)] = err.Error()
-		}
+		cancelAllOrdersResponse.Add(strconv.FormatInt(ads.AdList[i].Data.AdID, 10), err)
 	}
 
 	return cancelAllOrdersResponse, nil
@@ -280,6 +297,9 @@ func (l *LocalBitcoins) GetDepositAddress(cryptocurrency currency.Code, _ string
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (l *LocalBitcoins) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if l.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	_, err := l.WalletSend(withdrawRequest.Address, withdrawRequest.Amount, withdrawRequest.PIN)
 	return "", err
 }
@@ -287,12 +307,18 @@ func (l *LocalBitcoins) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.Wi
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (l *LocalBitcoins) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if l.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (l *LocalBitcoins) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if l.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -341,6 +367,7 @@ func (l *LocalBitcoins) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequ
 			ID:        fmt.Sprintf("%v", resp[i].Data.Advertisement.ID),
 			OrderDate: orderDate,
 			Fee:       resp[i].Data.FeeBTC,
+			FeeAsset:  currency.BTC,
 			OrderSide: side,
 			CurrencyPair: currency.NewPairWithDelimiter(currency.BTC.String(),
 				resp[i].Data.Currency,
@@ -349,6 +376,7 @@ func (l *LocalBitcoins) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequ
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
@@ -413,6 +441,7 @@ func (l *LocalBitcoins) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequ
 			ID:        fmt.Sprintf("%v", allTrades[i].Data.Advertisement.ID),
 			OrderDate: orderDate,
 			Fee:       allTrades[i].Data.FeeBTC,
+			FeeAsset:  currency.BTC,
 			OrderSide: side,
 			Status:    status,
 			CurrencyPair: currency.NewPairWithDelimiter(currency.BTC.String(),
@@ -422,6 +451,7 @@ func (l *LocalBitcoins) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequ
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
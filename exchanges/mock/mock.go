@@ -0,0 +1,517 @@
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// DefaultName is the name of a mock exchange created without one
+const DefaultName = "Mock"
+
+// Exchange is an in memory exchange implementing the full wrapper method set
+// without network access, so the engine can be tested with fake exchanges.
+// Market data and balances are set by the test, orders and withdrawals are
+// recorded instead of being sent
+type Exchange struct {
+	exchange.Base
+	tickers          map[string]ticker.Price
+	orderbooks       map[string]orderbook.Base
+	trades           map[string][]exchange.TradeHistory
+	tradablePairs    currency.Pairs
+	accountInfo      exchange.AccountInfo
+	depositAddresses map[currency.Code]string
+	feeRate          float64
+	orders           []exchange.OrderDetail
+	withdrawals      []exchange.WithdrawRequest
+	errs             map[string]error
+	orderID          int64
+	m                sync.Mutex
+}
+
+// New returns a mock exchange with its defaults set
+func New(name string) *Exchange {
+	e := &Exchange{}
+	e.Name = name
+	e.SetDefaults()
+	return e
+}
+
+// SetDefaults sets the basic defaults for the mock exchange
+func (e *Exchange) SetDefaults() {
+	if e.Name == "" {
+		e.Name = DefaultName
+	}
+	e.Enabled = false
+	e.Verbose = false
+	e.APIWithdrawPermissions = exchange.AutoWithdrawCrypto |
+		exchange.AutoWithdrawFiat
+	e.RequestCurrencyPairFormat.Delimiter = "-"
+	e.RequestCurrencyPairFormat.Uppercase = true
+	e.ConfigCurrencyPairFormat.Delimiter = "-"
+	e.ConfigCurrencyPairFormat.Uppercase = true
+	e.AssetTypes = []string{ticker.Spot}
+	e.WebsocketInit()
+
+	// Market data set before the engine loads the exchange is kept
+	e.m.Lock()
+	defer e.m.Unlock()
+	if e.tickers == nil {
+		e.tickers = make(map[string]ticker.Price)
+		e.orderbooks = make(map[string]orderbook.Base)
+		e.trades = make(map[string][]exchange.TradeHistory)
+		e.depositAddresses = make(map[currency.Code]string)
+		e.errs = make(map[string]error)
+	}
+}
+
+// Setup takes in the supplied exchange configuration details and sets params,
+// the global config is not consulted so the mock works without one loaded
+func (e *Exchange) Setup(exch *config.ExchangeConfig) {
+	if !exch.Enabled {
+		e.SetEnabled(false)
+		return
+	}
+	e.Enabled = true
+	e.Name = exch.Name
+	e.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+	e.Verbose = exch.Verbose
+	e.ReadOnly = exch.ReadOnly
+	e.Sandbox = exch.UseSandbox
+	e.BaseCurrencies = exch.BaseCurrencies
+	e.AvailablePairs = exch.AvailablePairs
+	e.EnabledPairs = exch.EnabledPairs
+}
+
+// Start starts the mock exchange, there is nothing to run
+func (e *Exchange) Start(wg *sync.WaitGroup) {
+	wg.Add(1)
+	go wg.Done()
+}
+
+// SetError makes a wrapper method return an error, a nil error clears it
+func (e *Exchange) SetError(method string, err error) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	if err == nil {
+		delete(e.errs, method)
+		return
+	}
+	e.errs[method] = err
+}
+
+func (e *Exchange) getError(method string) error {
+	return e.errs[method]
+}
+
+// SetTicker sets the ticker returned for its currency pair
+func (e *Exchange) SetTicker(t ticker.Price) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	e.tickers[t.Pair.String()] = t
+}
+
+// SetOrderbook sets the orderbook returned for its currency pair
+func (e *Exchange) SetOrderbook(ob orderbook.Base) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	e.orderbooks[ob.Pair.String()] = ob
+}
+
+// SetTrades sets the public trades returned for a currency pair
+func (e *Exchange) SetTrades(p currency.Pair, trades []exchange.TradeHistory) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	e.trades[p.String()] = trades
+}
+
+// SetTradablePairs sets the pairs listed by the exchange, applied to its
+// available pairs by UpdateTradablePairs
+func (e *Exchange) SetTradablePairs(pairs currency.Pairs) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	e.tradablePairs = pairs
+}
+
+// SetAccountInfo sets the balances returned by GetAccountInfo
+func (e *Exchange) SetAccountInfo(info exchange.AccountInfo) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	e.accountInfo = info
+}
+
+// SetDepositAddress sets the deposit address of a currency
+func (e *Exchange) SetDepositAddress(c currency.Code, address string) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	e.depositAddresses[c.Upper()] = address
+}
+
+// SetFeeRate sets the fee charged as a fraction of the order value
+func (e *Exchange) SetFeeRate(rate float64) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	e.feeRate = rate
+}
+
+// GetSubmittedOrders returns the orders submitted to the mock exchange
+func (e *Exchange) GetSubmittedOrders() []exchange.OrderDetail {
+	e.m.Lock()
+	defer e.m.Unlock()
+	return append([]exchange.OrderDetail(nil), e.orders...)
+}
+
+// GetWithdrawals returns the withdrawals requested from the mock exchange
+func (e *Exchange) GetWithdrawals() []exchange.WithdrawRequest {
+	e.m.Lock()
+	defer e.m.Unlock()
+	return append([]exchange.WithdrawRequest(nil), e.withdrawals...)
+}
+
+// SetCurrencies sets the available or enabled currency pairs
+func (e *Exchange) SetCurrencies(pairs []currency.Pair, enabledPairs bool) error {
+	if len(pairs) == 0 {
+		return fmt.Errorf("%s SetCurrencies error - pairs is empty", e.Name)
+	}
+	if enabledPairs {
+		e.EnabledPairs = pairs
+	} else {
+		e.AvailablePairs = pairs
+	}
+	return nil
+}
+
+// UpdateTradablePairs sets the available pairs to the pairs listed by the
+// exchange
+func (e *Exchange) UpdateTradablePairs(forceUpdate bool) error {
+	e.m.Lock()
+	defer e.m.Unlock()
+	if err := e.getError("UpdateTradablePairs"); err != nil {
+		return err
+	}
+	return e.SetCurrencies(e.tradablePairs, false)
+}
+
+// GetTickerPrice returns the ticker for a currency pair
+func (e *Exchange) GetTickerPrice(p currency.Pair, assetType string) (ticker.Price, error) {
+	tickerNew, err := ticker.GetTicker(e.GetName(), p, assetType)
+	if err != nil {
+		return e.UpdateTicker(p, assetType)
+	}
+	return tickerNew, nil
+}
+
+// UpdateTicker processes and returns the ticker set for a currency pair
+func (e *Exchange) UpdateTicker(p currency.Pair, assetType string) (ticker.Price, error) {
+	e.m.Lock()
+	if err := e.getError("UpdateTicker"); err != nil {
+		e.m.Unlock()
+		return ticker.Price{}, err
+	}
+	tickerPrice, ok := e.tickers[p.String()]
+	e.m.Unlock()
+	if !ok {
+		return ticker.Price{}, fmt.Errorf("%s no ticker for %s", e.Name, p)
+	}
+
+	err := ticker.ProcessTicker(e.GetName(), &tickerPrice, assetType)
+	if err != nil {
+		return tickerPrice, err
+	}
+	return ticker.GetTicker(e.GetName(), p, assetType)
+}
+
+// GetOrderbookEx returns the orderbook for a currency pair
+func (e *Exchange) GetOrderbookEx(p currency.Pair, assetType string) (orderbook.Base, error) {
+	ob, err := orderbook.Get(e.GetName(), p, assetType)
+	if err != nil {
+		return e.UpdateOrderbook(p, assetType)
+	}
+	return ob, nil
+}
+
+// UpdateOrderbook processes and returns the orderbook set for a currency pair
+func (e *Exchange) UpdateOrderbook(p currency.Pair, assetType string) (orderbook.Base, error) {
+	e.m.Lock()
+	if err := e.getError("UpdateOrderbook"); err != nil {
+		e.m.Unlock()
+		return orderbook.Base{}, err
+	}
+	ob, ok := e.orderbooks[p.String()]
+	e.m.Unlock()
+	if !ok {
+		return orderbook.Base{}, fmt.Errorf("%s no orderbook for %s", e.Name, p)
+	}
+
+	ob.Pair = p
+	ob.ExchangeName = e.GetName()
+	ob.AssetType = assetType
+	err := ob.Process()
+	if err != nil {
+		return ob, err
+	}
+	return orderbook.Get(e.GetName(), p, assetType)
+}
+
+// GetAccountInfo returns the balances set for the mock exchange
+func (e *Exchange) GetAccountInfo() (exchange.AccountInfo, error) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	if err := e.getError("GetAccountInfo"); err != nil {
+		return exchange.AccountInfo{}, err
+	}
+	info := e.accountInfo
+	info.Exchange = e.GetName()
+	info.Sandbox = e.IsSandbox()
+	return info, nil
+}
+
+// GetExchangeHistory returns historic trade data since exchange opening
+func (e *Exchange) GetExchangeHistory(p currency.Pair, assetType string) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricCandles returns candles between start and end inclusive
+func (e *Exchange) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetHistoricTrades returns the trades set for a currency pair executed
+// between start and end inclusive, oldest first
+func (e *Exchange) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	if err := e.getError("GetHistoricTrades"); err != nil {
+		return nil, err
+	}
+	return exchange.FilterTradeHistory(e.trades[p.String()], start, end), nil
+}
+
+// GetFeeByType returns the fee rate set for the mock exchange applied to the
+// order value, withdrawals and deposits are free
+func (e *Exchange) GetFeeByType(feeBuilder *exchange.FeeBuilder) (float64, error) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	if err := e.getError("GetFeeByType"); err != nil {
+		return 0, err
+	}
+	if feeBuilder.FeeType != exchange.CryptocurrencyTradeFee {
+		return 0, nil
+	}
+	return feeBuilder.PurchasePrice * feeBuilder.Amount * e.feeRate, nil
+}
+
+// GetFundingHistory returns funding history, deposits and withdrawals
+func (e *Exchange) GetFundingHistory() ([]exchange.FundHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// SubmitOrder records an order as active on the mock exchange
+func (e *Exchange) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if e.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
+	var submitOrderResponse exchange.SubmitOrderResponse
+	if amount <= 0 {
+		return submitOrderResponse, errors.New("amount must be greater than zero")
+	}
+
+	e.m.Lock()
+	defer e.m.Unlock()
+	if err := e.getError("SubmitOrder"); err != nil {
+		return submitOrderResponse, err
+	}
+
+	e.orderID++
+	orderID := strconv.FormatInt(e.orderID, 10)
+	e.orders = append(e.orders, exchange.OrderDetail{
+		Exchange:        e.GetName(),
+		AccountID:       clientID,
+		ID:              orderID,
+		CurrencyPair:    p,
+		OrderSide:       side,
+		OrderType:       orderType,
+		OrderDate:       time.Now(),
+		Status:          string(exchange.ActiveOrderStatus),
+		Price:           price,
+		Amount:          amount,
+		RemainingAmount: amount,
+	})
+	submitOrderResponse.IsOrderPlaced = true
+	submitOrderResponse.OrderID = orderID
+	submitOrderResponse.Sandbox = e.IsSandbox()
+	return submitOrderResponse, nil
+}
+
+// ModifyOrder will allow of changing orderbook placement and limit to
+// market conversion
+func (e *Exchange) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if e.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
+	return "", common.ErrFunctionNotSupported
+}
+
+// CancelOrder cancels an order by its corresponding ID number
+func (e *Exchange) CancelOrder(order *exchange.OrderCancellation) error {
+	if e.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
+	e.m.Lock()
+	defer e.m.Unlock()
+	if err := e.getError("CancelOrder"); err != nil {
+		return err
+	}
+	for x := range e.orders {
+		if e.orders[x].ID != order.OrderID {
+			continue
+		}
+		if e.orders[x].Status != string(exchange.ActiveOrderStatus) {
+			return fmt.Errorf("order %s is not active", order.OrderID)
+		}
+		e.orders[x].Status = string(exchange.CancelledOrderStatus)
+		return nil
+	}
+	return fmt.Errorf("order %s not found", order.OrderID)
+}
+
+// CancelAllOrders cancels all active orders
+func (e *Exchange) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if e.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
+	}
+	e.m.Lock()
+	defer e.m.Unlock()
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
+	if err := e.getError("CancelAllOrders"); err != nil {
+		return cancelAllOrdersResponse, err
+	}
+	for x := range e.orders {
+		if e.orders[x].Status == string(exchange.ActiveOrderStatus) {
+			e.orders[x].Status = string(exchange.CancelledOrderStatus)
+			cancelAllOrdersResponse.Add(e.orders[x].ID, nil)
+		}
+	}
+	return cancelAllOrdersResponse, nil
+}
+
+// GetOrderInfo returns information on a current open order
+func (e *Exchange) GetOrderInfo(orderID string) (exchange.OrderDetail, error) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	for x := range e.orders {
+		if e.orders[x].ID == orderID {
+			return e.orders[x], nil
+		}
+	}
+	return exchange.OrderDetail{}, fmt.Errorf("order %s not found", orderID)
+}
+
+// GetDepositAddress returns the deposit address set for a currency
+func (e *Exchange) GetDepositAddress(cryptocurrency currency.Code, _ string) (string, error) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	address, ok := e.depositAddresses[cryptocurrency.Upper()]
+	if !ok {
+		return "", fmt.Errorf("%s no deposit address for %s", e.Name,
+			cryptocurrency)
+	}
+	return address, nil
+}
+
+// WithdrawCryptocurrencyFunds records a cryptocurrency withdrawal
+func (e *Exchange) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if e.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
+	return e.withdraw("WithdrawCryptocurrencyFunds", withdrawRequest)
+}
+
+// WithdrawFiatFunds records a fiat withdrawal
+func (e *Exchange) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if e.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
+	return e.withdraw("WithdrawFiatFunds", withdrawRequest)
+}
+
+// WithdrawFiatFundsToInternationalBank records an international bank
+// withdrawal
+func (e *Exchange) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if e.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
+	return e.withdraw("WithdrawFiatFundsToInternationalBank", withdrawRequest)
+}
+
+func (e *Exchange) withdraw(method string, withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	if err := e.getError(method); err != nil {
+		return "", err
+	}
+	e.withdrawals = append(e.withdrawals, *withdrawRequest)
+	return strconv.Itoa(len(e.withdrawals)), nil
+}
+
+// GetWebsocket returns a pointer to the exchange websocket
+func (e *Exchange) GetWebsocket() (*exchange.Websocket, error) {
+	return e.Websocket, nil
+}
+
+// GetActiveOrders retrieves the active orders matching the request pairs
+func (e *Exchange) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	return e.getOrders(getOrdersRequest, true)
+}
+
+// GetOrderHistory retrieves the inactive orders matching the request pairs
+func (e *Exchange) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	return e.getOrders(getOrdersRequest, false)
+}
+
+func (e *Exchange) getOrders(getOrdersRequest *exchange.GetOrdersRequest, active bool) ([]exchange.OrderDetail, error) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	var orders []exchange.OrderDetail
+	for x := range e.orders {
+		if (e.orders[x].Status == string(exchange.ActiveOrderStatus)) != active {
+			continue
+		}
+		if len(getOrdersRequest.Currencies) > 0 {
+			var match bool
+			for y := range getOrdersRequest.Currencies {
+				if getOrdersRequest.Currencies[y].Equal(e.orders[x].CurrencyPair) {
+					match = true
+					break
+				}
+			}
+			if !match {
+				continue
+			}
+		}
+		orders = append(orders, e.orders[x])
+	}
+	return orders, nil
+}
+
+// SubscribeToWebsocketChannels is not supported, the mock exchange has no
+// websocket connection
+func (e *Exchange) SubscribeToWebsocketChannels(channels []exchange.WebsocketChannelSubscription) error {
+	return common.ErrFunctionNotSupported
+}
+
+// UnsubscribeToWebsocketChannels is not supported, the mock exchange has no
+// websocket connection
+func (e *Exchange) UnsubscribeToWebsocketChannels(channels []exchange.WebsocketChannelSubscription) error {
+	return common.ErrFunctionNotSupported
+}
@@ -0,0 +1,232 @@
+package mock
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+var _ exchange.IBotExchange = (*Exchange)(nil)
+
+func TestSetDefaults(t *testing.T) {
+	e := &Exchange{}
+	e.SetDefaults()
+	if e.GetName() != DefaultName {
+		t.Errorf("Test Failed - expected %s, got %s", DefaultName, e.GetName())
+	}
+
+	e = New("MockSetDefaults")
+	e.SetFeeRate(0.001)
+	e.SetDefaults()
+	if e.GetName() != "MockSetDefaults" || e.feeRate != 0.001 {
+		t.Error("Test Failed - SetDefaults reset the mock exchange")
+	}
+}
+
+func TestSetup(t *testing.T) {
+	p := currency.NewPairFromStrings("BTC", "USD")
+	e := New("")
+	e.Setup(&config.ExchangeConfig{
+		Name:                    "MockSetup",
+		Enabled:                 true,
+		AuthenticatedAPISupport: true,
+		EnabledPairs:            currency.Pairs{p},
+	})
+	if !e.IsEnabled() || !e.GetAuthenticatedAPISupport() ||
+		e.GetName() != "MockSetup" || !e.GetEnabledCurrencies().Contains(p, true) {
+		t.Error("Test Failed - Setup did not apply the config")
+	}
+}
+
+func TestMarketData(t *testing.T) {
+	p := currency.NewPairFromStrings("BTC", "USD")
+	e := New("MockMarketData")
+
+	_, err := e.UpdateTicker(p, ticker.Spot)
+	if err == nil {
+		t.Error("Test Failed - expected an error without a ticker set")
+	}
+
+	e.SetTicker(ticker.Price{Pair: p, Last: 10000})
+	tick, err := e.GetTickerPrice(p, ticker.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tick.Last != 10000 {
+		t.Errorf("Test Failed - expected 10000, got %v", tick.Last)
+	}
+
+	e.SetOrderbook(orderbook.Base{
+		Pair: p,
+		Bids: []orderbook.Item{{Price: 9999, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 10001, Amount: 1}},
+	})
+	ob, err := e.GetOrderbookEx(p, orderbook.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ob.Asks) != 1 || ob.Asks[0].Price != 10001 {
+		t.Error("Test Failed - unexpected orderbook")
+	}
+
+	e.SetError("UpdateOrderbook", errors.New("unavailable"))
+	_, err = e.UpdateOrderbook(p, orderbook.Spot)
+	if err == nil {
+		t.Error("Test Failed - expected an injected error")
+	}
+	e.SetError("UpdateOrderbook", nil)
+	_, err = e.UpdateOrderbook(p, orderbook.Spot)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetFeeByType(t *testing.T) {
+	e := New("MockFee")
+	e.SetFeeRate(0.002)
+	fee, err := e.GetFeeByType(&exchange.FeeBuilder{
+		FeeType:       exchange.CryptocurrencyTradeFee,
+		PurchasePrice: 10000,
+		Amount:        2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee != 40 {
+		t.Errorf("Test Failed - expected 40, got %v", fee)
+	}
+
+	fee, err = e.GetFeeByType(&exchange.FeeBuilder{
+		FeeType: exchange.CryptocurrencyWithdrawalFee,
+		Amount:  2,
+	})
+	if err != nil || fee != 0 {
+		t.Errorf("Test Failed - expected no withdrawal fee, got %v %v", fee, err)
+	}
+}
+
+func TestOrders(t *testing.T) {
+	p := currency.NewPairFromStrings("BTC", "USD")
+	e := New("MockOrders")
+
+	_, err := e.SubmitOrder(p, exchange.BuyOrderSide, exchange.LimitOrderType,
+		0, 10000, "")
+	if err == nil {
+		t.Error("Test Failed - expected an error for a zero amount")
+	}
+
+	first, err := e.SubmitOrder(p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 1, 10000, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !first.IsOrderPlaced || first.OrderID != "1" {
+		t.Errorf("Test Failed - unexpected response %+v", first)
+	}
+	_, err = e.SubmitOrder(p, exchange.SellOrderSide, exchange.LimitOrderType,
+		1, 11000, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = e.CancelOrder(&exchange.OrderCancellation{OrderID: first.OrderID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = e.CancelOrder(&exchange.OrderCancellation{OrderID: first.OrderID})
+	if err == nil {
+		t.Error("Test Failed - expected an error cancelling a cancelled order")
+	}
+
+	active, err := e.GetActiveOrders(&exchange.GetOrdersRequest{
+		Currencies: []currency.Pair{p},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(active) != 1 || active[0].ID != "2" {
+		t.Errorf("Test Failed - expected order 2 active, got %+v", active)
+	}
+
+	history, err := e.GetOrderHistory(&exchange.GetOrdersRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].ID != first.OrderID {
+		t.Errorf("Test Failed - expected order 1 in history, got %+v", history)
+	}
+
+	e.SetError("SubmitOrder", errors.New("rejected"))
+	_, err = e.SubmitOrder(p, exchange.BuyOrderSide, exchange.LimitOrderType,
+		1, 10000, "")
+	if err == nil {
+		t.Error("Test Failed - expected an injected error")
+	}
+	if len(e.GetSubmittedOrders()) != 2 {
+		t.Error("Test Failed - rejected order was recorded")
+	}
+}
+
+func TestWithdraw(t *testing.T) {
+	e := New("MockWithdraw")
+	id, err := e.WithdrawCryptocurrencyFunds(&exchange.WithdrawRequest{
+		Currency: currency.BTC,
+		Amount:   1,
+		Address:  "1F5zVDgNjorJ51oGebSvNCrSAHpwGkUdDB",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "1" || len(e.GetWithdrawals()) != 1 {
+		t.Error("Test Failed - withdrawal not recorded")
+	}
+
+	_, err = e.GetDepositAddress(currency.BTC, "")
+	if err == nil {
+		t.Error("Test Failed - expected an error without a deposit address")
+	}
+	e.SetDepositAddress(currency.BTC, "1F5zVDgNjorJ51oGebSvNCrSAHpwGkUdDB")
+	address, err := e.GetDepositAddress(currency.NewCode("btc"), "")
+	if err != nil || address != "1F5zVDgNjorJ51oGebSvNCrSAHpwGkUdDB" {
+		t.Errorf("Test Failed - unexpected deposit address %s %v", address, err)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	p := currency.NewPairFromStrings("BTC", "USD")
+	e := New("")
+	e.Setup(&config.ExchangeConfig{
+		Name:         "MockReadOnly",
+		Enabled:      true,
+		ReadOnly:     true,
+		EnabledPairs: currency.Pairs{p},
+	})
+	if !e.IsReadOnly() {
+		t.Fatal("Test Failed - expected the exchange to be read-only")
+	}
+
+	_, err := e.SubmitOrder(p, exchange.BuyOrderSide, exchange.LimitOrderType, 1, 100, "")
+	if err != exchange.ErrReadOnly {
+		t.Errorf("Test Failed - expected order placement to be blocked, got %v", err)
+	}
+	err = e.CancelOrder(&exchange.OrderCancellation{OrderID: "1"})
+	if err != exchange.ErrReadOnly {
+		t.Errorf("Test Failed - expected cancellation to be blocked, got %v", err)
+	}
+	_, err = e.WithdrawCryptocurrencyFunds(&exchange.WithdrawRequest{
+		Currency: currency.BTC,
+		Amount:   1,
+	})
+	if err != exchange.ErrReadOnly || len(e.GetWithdrawals()) != 0 {
+		t.Errorf("Test Failed - expected withdrawals to be blocked, got %v", err)
+	}
+
+	if _, err = e.GetAccountInfo(); err != nil {
+		t.Errorf("Test Failed - expected account queries to remain available: %s", err)
+	}
+}
@@ -25,14 +25,25 @@ type OKCoin struct {
 	okgroup.OKGroup
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: okCoinExchangeName,
+		Creator: func() exchange.IBotExchange {
+			return new(OKCoin)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability,
+	})
+}
+
 // SetDefaults method assignes the default values for OKEX
 func (o *OKCoin) SetDefaults() {
 	o.SetErrorDefaults()
-	o.SetCheckVarDefaults()
 	o.Name = okCoinExchangeName
 	o.Enabled = false
 	o.Verbose = false
 	o.RESTPollingDelay = 10
+	o.RequiresAPIPassphrase = true
 	o.APIWithdrawPermissions = exchange.AutoWithdrawCrypto |
 		exchange.NoFiatWithdrawals
 	o.RequestCurrencyPairFormat.Delimiter = "_"
@@ -41,6 +52,7 @@ func (o *OKCoin) SetDefaults() {
 	o.ConfigCurrencyPairFormat.Uppercase = true
 	o.SupportsAutoPairUpdating = true
 	o.SupportsRESTTickerBatching = false
+	o.SupportsKlines = true
 	o.Requester = request.New(o.Name,
 		request.NewRateLimit(time.Second, okCoinAuthRate),
 		request.NewRateLimit(time.Second, okCoinUnauthRate),
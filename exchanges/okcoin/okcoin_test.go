@@ -54,7 +54,7 @@ func TestSetup(t *testing.T) {
 		return
 	}
 	if o.APIKey == apiKey && o.APISecret == apiSecret &&
-		o.ClientID == passphrase && apiKey != "" && apiSecret != "" && passphrase != "" {
+		o.APIPassphrase == passphrase && apiKey != "" && apiSecret != "" && passphrase != "" {
 		return
 	}
 	o.ExchangeName = OKGroupExchange
@@ -71,7 +71,7 @@ func TestSetup(t *testing.T) {
 	okcoinConfig.AuthenticatedAPISupport = true
 	okcoinConfig.APIKey = apiKey
 	okcoinConfig.APISecret = apiSecret
-	okcoinConfig.ClientID = passphrase
+	okcoinConfig.APIPassphrase = passphrase
 	okcoinConfig.WebsocketURL = o.WebsocketURL
 	o.Setup(&okcoinConfig)
 	testSetupRan = true
@@ -1091,8 +1091,8 @@ func TestCancelAllExchangeOrders(t *testing.T) {
 
 	resp, err := o.CancelAllOrders(&orderCancellation)
 	testStandardErrorHandling(t, err)
-	if len(resp.OrderStatus) > 0 {
-		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
+	if len(resp.Failed()) > 0 {
+		t.Errorf("%v orders failed to cancel", len(resp.Failed()))
 	}
 }
 
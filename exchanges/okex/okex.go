@@ -3,7 +3,6 @@ package okex
 import (
 	"fmt"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
@@ -50,14 +49,28 @@ type OKEX struct {
 	okgroup.OKGroup
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: okExExchangeName,
+		Creator: func() exchange.IBotExchange {
+			return new(OKEX)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.DerivativesCapability |
+			exchange.WebsocketCapability,
+	})
+}
+
 // SetDefaults method assignes the default values for OKEX
 func (o *OKEX) SetDefaults() {
 	o.SetErrorDefaults()
-	o.SetCheckVarDefaults()
+	o.FuturesInstrumentsFetcher = o.GetFuturesContractInformation
+	o.FuturesLiquidationsFetcher = o.GetFuturesForceLiquidatedOrders
 	o.Name = okExExchangeName
 	o.Enabled = false
 	o.Verbose = false
 	o.RESTPollingDelay = 10
+	o.RequiresAPIPassphrase = true
 	o.APIWithdrawPermissions = exchange.AutoWithdrawCrypto |
 		exchange.NoFiatWithdrawals
 	o.RequestCurrencyPairFormat.Delimiter = "_"
@@ -66,6 +79,7 @@ func (o *OKEX) SetDefaults() {
 	o.ConfigCurrencyPairFormat.Uppercase = true
 	o.SupportsAutoPairUpdating = true
 	o.SupportsRESTTickerBatching = false
+	o.SupportsKlines = true
 	o.Requester = request.New(o.Name,
 		request.NewRateLimit(time.Second, okExAuthRate),
 		request.NewRateLimit(time.Second, okExUnauthRate),
@@ -179,66 +193,9 @@ func (o *OKEX) GetFuturesContractInformation() (resp []okgroup.GetFuturesContrac
 }
 
 // GetFuturesOrderBook List all contracts. This request does not support pagination. The full list will be returned for a request.
-func (o *OKEX) GetFuturesOrderBook(request okgroup.GetFuturesOrderBookRequest) (resp okgroup.GetFuturesOrderBookResponse, err error) {
+func (o *OKEX) GetFuturesOrderBook(request okgroup.GetFuturesOrderBookRequest) (resp okgroup.GetFuturesOrderBookResponse, _ error) {
 	requestURL := fmt.Sprintf("%v/%v/%v%v", okgroup.OKGroupInstruments, request.InstrumentID, okgroup.OKGroupGetSpotOrderBook, okgroup.FormatParameters(request))
-
-	type tempOB struct {
-		Bids      [][]string `json:"bids"`
-		Asks      [][]string `json:"asks"`
-		Timestamp time.Time  `json:"timestamp"`
-	}
-
-	var tmpOB tempOB
-	err = o.SendHTTPRequest(http.MethodGet, okGroupFuturesSubsection, requestURL, nil, &tmpOB, true)
-	if err != nil {
-		return resp, err
-	}
-
-	processOB := func(ob [][]string) ([]okgroup.FuturesOrderbookItem, error) {
-		var processedOB []okgroup.FuturesOrderbookItem
-		for x := range ob {
-			price, convErr := strconv.ParseFloat(ob[x][0], 64)
-			if err != nil {
-				return nil, convErr
-			}
-
-			size, convErr := strconv.ParseInt(ob[x][1], 10, 64)
-			if err != nil {
-				return nil, convErr
-			}
-
-			liqOrders, convErr := strconv.ParseInt(ob[x][2], 10, 64)
-			if err != nil {
-				return nil, convErr
-			}
-
-			numOrders, convErr := strconv.ParseInt(ob[x][3], 10, 64)
-			if err != nil {
-				return nil, convErr
-			}
-
-			processedOB = append(processedOB, okgroup.FuturesOrderbookItem{
-				Price:                 price,
-				Size:                  size,
-				ForceLiquidatedOrders: liqOrders,
-				NumberOrders:          numOrders,
-			})
-		}
-		return processedOB, nil
-	}
-
-	resp.Bids, err = processOB(tmpOB.Bids)
-	if err != nil {
-		return
-	}
-
-	resp.Asks, err = processOB(tmpOB.Asks)
-	if err != nil {
-		return
-	}
-
-	resp.Timestamp = tmpOB.Timestamp
-	return resp, nil
+	return resp, o.SendHTTPRequest(http.MethodGet, okGroupFuturesSubsection, requestURL, nil, &resp, true)
 }
 
 // GetAllFuturesTokenInfo Get the last traded price, best bid/ask price, 24 hour trading volume and more info of all contracts.
@@ -435,7 +392,7 @@ func (o *OKEX) GetSwapFilledOrdersData(request *okgroup.GetSwapFilledOrdersDataR
 }
 
 // GetSwapMarketData Get the charts of the trading pairs.
-func (o *OKEX) GetSwapMarketData(request okgroup.GetSwapMarketDataRequest) (resp []okgroup.GetSwapMarketDataResponse, _ error) {
+func (o *OKEX) GetSwapMarketData(request okgroup.GetSwapMarketDataRequest) (resp okgroup.GetSwapMarketDataResponse, _ error) {
 	requestURL := fmt.Sprintf("%v/%v/%v%v", okgroup.OKGroupInstruments, request.InstrumentID, okgroup.OKGroupGetSpotMarketData, okgroup.FormatParameters(request))
 	return resp, o.SendHTTPRequest(http.MethodGet, okGroupSwapSubsection, requestURL, nil, &resp, false)
 }
@@ -14,6 +14,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
 	"github.com/thrasher-/gocryptotrader/exchanges/okgroup"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
 // Please supply you own test keys here for due diligence testing.
@@ -55,7 +57,7 @@ func TestSetup(t *testing.T) {
 		return
 	}
 	if o.APIKey == apiKey && o.APISecret == apiSecret &&
-		o.ClientID == passphrase && apiKey != "" && apiSecret != "" && passphrase != "" {
+		o.APIPassphrase == passphrase && apiKey != "" && apiSecret != "" && passphrase != "" {
 		return
 	}
 	o.ExchangeName = OKGroupExchange
@@ -72,7 +74,7 @@ func TestSetup(t *testing.T) {
 	okexConfig.AuthenticatedAPISupport = true
 	okexConfig.APIKey = apiKey
 	okexConfig.APISecret = apiSecret
-	okexConfig.ClientID = passphrase
+	okexConfig.APIPassphrase = passphrase
 	okexConfig.WebsocketURL = o.WebsocketURL
 	o.Setup(&okexConfig)
 	testSetupRan = true
@@ -504,6 +506,16 @@ func TestGetSpotTransactionDetails(t *testing.T) {
 	testStandardErrorHandling(t, err)
 }
 
+// TestGetServerTime API endpoint test
+func TestGetServerTime(t *testing.T) {
+	TestSetDefaults(t)
+	t.Parallel()
+	_, err := o.GetServerTime()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 // TestGetSpotTokenPairDetails API endpoint test
 func TestGetSpotTokenPairDetails(t *testing.T) {
 	TestSetDefaults(t)
@@ -514,6 +526,138 @@ func TestGetSpotTokenPairDetails(t *testing.T) {
 	}
 }
 
+// TestCheckSymbol API endpoint test
+func TestCheckSymbol(t *testing.T) {
+	TestSetDefaults(t)
+	err := o.CheckSymbol(spotCurrency)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = o.CheckSymbol("meow_wow")
+	if err == nil {
+		t.Error("Expecting an error with an unlisted symbol")
+	}
+}
+
+// TestCheckSpotSymbol API endpoint test
+func TestCheckSpotSymbol(t *testing.T) {
+	TestSetDefaults(t)
+	err := o.CheckSpotSymbol(spotCurrency)
+	if err != nil {
+		t.Error(err)
+	}
+
+	instrumentID, err := o.GetFuturesInstrumentID(currency.NewPairFromString("BTC-USD_QUARTER"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = o.CheckSpotSymbol(instrumentID)
+	if err == nil {
+		t.Error("Expecting an error with a futures symbol")
+	}
+
+	_, err = o.GetSpotOrderBook(okgroup.GetSpotOrderBookRequest{InstrumentID: "meow_wow"})
+	if err == nil {
+		t.Error("Expecting an error with an unlisted symbol")
+	}
+}
+
+// TestCheckContractType API endpoint test
+func TestCheckContractType(t *testing.T) {
+	TestSetDefaults(t)
+	err := o.CheckContractType("quarter")
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = o.CheckContractType("next_decade")
+	if err == nil {
+		t.Error("Expecting an error with an invalid contract type")
+	}
+}
+
+// TestIsFuturesPair logic test
+func TestIsFuturesPair(t *testing.T) {
+	t.Parallel()
+	if !okgroup.IsFuturesPair(currency.NewPairFromString("BTC-USD_QUARTER")) {
+		t.Error("Expected BTC-USD_QUARTER to be a futures pair")
+	}
+	if okgroup.IsFuturesPair(currency.NewPairFromString("BTC_USDT")) {
+		t.Error("Expected BTC_USDT to be a spot pair")
+	}
+}
+
+// TestGetFuturesInstrumentID API endpoint test
+func TestGetFuturesInstrumentID(t *testing.T) {
+	TestSetDefaults(t)
+	instrumentID, err := o.GetFuturesInstrumentID(currency.NewPairFromString("BTC-USD_QUARTER"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(instrumentID, "BTC-USD-") {
+		t.Errorf("Unexpected instrument ID %s", instrumentID)
+	}
+
+	_, err = o.GetFuturesInstrumentID(currency.NewPairFromString("BTC_USDT"))
+	if err == nil {
+		t.Error("Expecting an error with a spot pair")
+	}
+}
+
+// TestGetContractValue API endpoint test
+func TestGetContractValue(t *testing.T) {
+	TestSetDefaults(t)
+	instrumentID, err := o.GetFuturesInstrumentID(currency.NewPairFromString("BTC-USD_QUARTER"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := o.GetContractValue(instrumentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 100 {
+		t.Errorf("Expected a 100 USD BTC contract value, got %f", value)
+	}
+
+	_, err = o.GetContractValue("BTC-USDT")
+	if err == nil {
+		t.Error("Expecting an error with a spot instrument")
+	}
+}
+
+// TestUpdateFuturesTicker Wrapper test
+func TestUpdateFuturesTicker(t *testing.T) {
+	TestSetDefaults(t)
+	_, err := o.UpdateTicker(currency.NewPairFromString("BTC-USD_THISWEEK"), ticker.Futures)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+// TestUpdateFuturesOrderbook Wrapper test
+func TestUpdateFuturesOrderbook(t *testing.T) {
+	TestSetDefaults(t)
+	_, err := o.UpdateOrderbook(currency.NewPairFromString("BTC-USD_THISWEEK"), orderbook.Futures)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+// TestGetOpenInterest Wrapper test
+func TestGetOpenInterest(t *testing.T) {
+	TestSetDefaults(t)
+	_, err := o.GetOpenInterest(currency.NewPairFromString("BTC-USD_QUARTER"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = o.GetOpenInterest(currency.NewPairFromString("BTC_USDT"))
+	if err == nil {
+		t.Error("Expecting an error with a spot pair")
+	}
+}
+
 // TestGetSpotOrderBook API endpoint test
 func TestGetSpotOrderBook(t *testing.T) {
 	TestSetDefaults(t)
@@ -901,6 +1045,39 @@ func TestGetFuturesAccountOfACurrency(t *testing.T) {
 	testStandardErrorHandling(t, err)
 }
 
+// TestFuturesAccountResponseDecoding ensures futures account and position
+// responses decode into typed values
+func TestFuturesAccountResponseDecoding(t *testing.T) {
+	accountJSON := []byte(`{"info":{"btc":{"equity":"0.0012","margin":"0.0001","margin_mode":"crossed","margin_ratio":"12.5","realized_pnl":"-0.0001","total_avail_balance":"0.0011","unrealized_pnl":"0.0002"},"eos":{"contracts":[{"available_qty":"1.5","fixed_balance":"0.2","instrument_id":"EOS-USD-190628","margin_for_unfilled":"0","margin_frozen":"0.1","realized_pnl":"0","unrealized_pnl":"0.01"}],"equity":"3.2","margin_mode":"fixed","total_avail_balance":"3"}}}`)
+	var account okgroup.FuturesAccountForAllCurrenciesResponse
+	err := common.JSONDecode(accountJSON, &account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Info["btc"].Equity != 0.0012 || account.Info["btc"].MarginRatio != 12.5 {
+		t.Errorf("Test failed. Unexpected crossed margin account values %+v", account.Info["btc"])
+	}
+	if len(account.Info["eos"].Contracts) != 1 ||
+		account.Info["eos"].Contracts[0].FixedBalance != 0.2 {
+		t.Errorf("Test failed. Unexpected fixed margin account values %+v", account.Info["eos"])
+	}
+
+	positionJSON := []byte(`{"result":true,"holding":[[{"long_qty":"2","long_avail_qty":"2","long_avg_cost":"3500.5","long_settlement_price":"3500.5","realised_pnl":"0.001","short_qty":"0","short_avail_qty":"0","short_avg_cost":"0","short_settlement_price":"0","liquidation_price":"2100","instrument_id":"BTC-USD-190628","leverage":"10","created_at":"2019-03-20T09:29:16.000Z","updated_at":"2019-03-21T09:29:16.000Z","margin_mode":"crossed"}]]}`)
+	var positions okgroup.GetFuturesPositionsResponse
+	err = common.JSONDecode(positionJSON, &positions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(positions.Holding) != 1 || len(positions.Holding[0]) != 1 {
+		t.Fatal("Test failed. Expected a single position")
+	}
+	position := positions.Holding[0][0]
+	if position.LongQty != 2 || position.LongAvgCost != 3500.5 ||
+		position.Leverage != 10 || position.CreatedAt.IsZero() {
+		t.Errorf("Test failed. Unexpected position values %+v", position)
+	}
+}
+
 // TestGetFuturesLeverage API endpoint test
 func TestGetFuturesLeverage(t *testing.T) {
 	TestSetDefaults(t)
@@ -1027,6 +1204,66 @@ func TestGetFuturesContractInformation(t *testing.T) {
 	}
 }
 
+func TestGetFuturesContracts(t *testing.T) {
+	contracts := getFuturesContracts("OKEX", []okgroup.GetFuturesContractInformationResponse{
+		{InstrumentID: "BTC-USD-190628", UnderlyingIndex: "BTC", QuoteCurrency: "USD",
+			Alias: "quarter", Listing: "2019-06-14", Delivery: "2019-06-28"},
+		{InstrumentID: "BTC-USD-SWAP", UnderlyingIndex: "BTC", QuoteCurrency: "USD"},
+	})
+	if len(contracts) != 1 {
+		t.Fatalf("Expected one dated contract, got %+v", contracts)
+	}
+	if contracts[0].Pair.String() != "BTC-USD_190628" ||
+		contracts[0].Underlying.String() != "BTC-USD" ||
+		!contracts[0].Expiry.Equal(time.Date(2019, 6, 28, 8, 0, 0, 0, time.UTC)) ||
+		!contracts[0].Listing.Equal(time.Date(2019, 6, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected contract %+v", contracts[0])
+	}
+}
+
+func TestGetFuturesPositions(t *testing.T) {
+	positions := getFuturesPositions([]okgroup.GetFuturePostionsDetails{
+		{InstrumentID: "BTC-USD-190628", LongAvailQty: 5, LongAvgCost: 8000, ShortAvailQty: 2,
+			ShortAvgCost: 8100},
+		{InstrumentID: "ETH-USD-190628"},
+	})
+	if len(positions) != 2 {
+		t.Fatalf("Expected a long and short position, got %+v", positions)
+	}
+	if positions[0].Pair.String() != "BTC-USD_190628" || positions[0].Side != exchange.BuyOrderSide ||
+		positions[0].Amount != 5 || positions[1].Side != exchange.SellOrderSide ||
+		positions[1].AverageCost != 8100 {
+		t.Errorf("Unexpected positions %+v", positions)
+	}
+}
+
+func TestGetPositionLeverage(t *testing.T) {
+	holding := []okgroup.GetFuturePostionsDetails{
+		{InstrumentID: "BTC-USD-190628", LongQty: 5, LongLeverage: 20, ShortQty: 0,
+			ShortLeverage: 10},
+		{InstrumentID: "ETH-USD-190628", Leverage: 15, ShortQty: 3},
+	}
+	if leverage := getPositionLeverage(holding, "BTC-USD-190628", true); leverage != 20 {
+		t.Errorf("Expected the long position leverage of 20, got %v", leverage)
+	}
+	if leverage := getPositionLeverage(holding, "BTC-USD-190628", false); leverage != 0 {
+		t.Errorf("Expected no leverage without a short position, got %v", leverage)
+	}
+	if leverage := getPositionLeverage(holding, "ETH-USD-190628", false); leverage != 15 {
+		t.Errorf("Expected the crossed margin leverage of 15, got %v", leverage)
+	}
+}
+
+func TestSubmitFuturesOrderFractionalContracts(t *testing.T) {
+	var f OKEX
+	f.SetDefaults()
+	p := currency.NewPairWithDelimiter("BTC-USD", "190628", "_")
+	_, err := f.SubmitOrder(p, exchange.BuyOrderSide, exchange.MarketOrderType, 0.5, 0, "")
+	if err == nil {
+		t.Error("Expected a fractional contract amount to be rejected")
+	}
+}
+
 // TestGetFuturesContractInformation API endpoint test
 func TestGetFuturesOrderBook(t *testing.T) {
 	TestSetDefaults(t)
@@ -1868,8 +2105,8 @@ func TestCancelAllExchangeOrders(t *testing.T) {
 	resp, err := o.CancelAllOrders(&orderCancellation)
 	testStandardErrorHandling(t, err)
 
-	if len(resp.OrderStatus) > 0 {
-		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
+	if len(resp.Failed()) > 0 {
+		t.Errorf("%v orders failed to cancel", len(resp.Failed()))
 	}
 }
 
@@ -1928,3 +2165,78 @@ func TestWithdrawInternationalBank(t *testing.T) {
 		t.Errorf("Expected '%v', received: '%v'", common.ErrFunctionNotSupported, err)
 	}
 }
+
+// TestDecodeContractResponses logic test
+func TestDecodeContractResponses(t *testing.T) {
+	var book okgroup.GetSwapOrderBookResponse
+	err := common.JSONDecode([]byte(`{"asks":[["411.3","16",5,4]],"bids":[[410.9,12,"0","2"]],"timestamp":"2019-03-06T23:19:18.239Z"}`), &book)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(book.Asks) != 1 || book.Asks[0].Price != 411.3 || book.Asks[0].Size != 16 ||
+		book.Asks[0].ForceLiquidatedOrders != 5 || book.Asks[0].NumberOrders != 4 {
+		t.Errorf("unexpected asks %+v", book.Asks)
+	}
+	if len(book.Bids) != 1 || book.Bids[0].Price != 410.9 || book.Bids[0].NumberOrders != 2 {
+		t.Errorf("unexpected bids %+v", book.Bids)
+	}
+
+	var candles okgroup.GetFuturesMarketDataResponse
+	err = common.JSONDecode([]byte(`[["2019-03-19T08:00:00.000Z","3980.49","3982.3","3978.43","3980.11","1522","38.23"]]`), &candles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 1 || candles[0].Close != 3980.11 || candles[0].CurrencyVolume != 38.23 ||
+		candles[0].Time.Hour() != 8 {
+		t.Errorf("unexpected candles %+v", candles)
+	}
+
+	for _, malformed := range []string{`{"asks":[["411.3"]]}`, `{"asks":[[{}, "1", "0", "1"]]}`} {
+		if err = common.JSONDecode([]byte(malformed), &book); err == nil {
+			t.Errorf("expected an error decoding %s", malformed)
+		}
+	}
+	if err = common.JSONDecode([]byte(`[["bad time","1","1","1","1","1"]]`), &candles); err == nil {
+		t.Error("expected an error decoding a candle with an invalid time")
+	}
+}
+
+// TestDecodeErrorResponses logic test
+func TestDecodeErrorResponses(t *testing.T) {
+	TestSetDefaults(t)
+	var resp struct {
+		Code   okgroup.ErrorCode      `json:"error_code"`
+		Result okgroup.ResponseResult `json:"result"`
+	}
+	for _, data := range []string{`{"error_code":30008,"result":false}`, `{"error_code":"30008","result":"false"}`} {
+		err := common.JSONDecode([]byte(data), &resp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Code != "30008" || !resp.Code.IsError() || bool(resp.Result) {
+			t.Errorf("unexpected error response decoding %s: %+v", data, resp)
+		}
+		if o.GetErrorCode(resp.Code) != o.ErrorCodes["30008"] {
+			t.Errorf("unexpected error for code %s", resp.Code)
+		}
+	}
+	if err := common.JSONDecode([]byte(`{"error_code":{}}`), &resp); err == nil {
+		t.Error("expected an error decoding a malformed error code")
+	}
+	if o.GetErrorCode(true) == nil {
+		t.Error("expected an error for an unusual error code type")
+	}
+
+	var book okgroup.WebsocketOrderBooksData
+	err := common.JSONDecode([]byte(`{"asks":[["3.5196","0.1077",1]],"bids":[[3.5182,151.5343,6]]}`), &book)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.Asks[0].Price != 3.5196 || book.Asks[0].AmountString != "0.1077" ||
+		book.Bids[0].Amount != 151.5343 || book.Bids[0].PriceString != "3.5182" {
+		t.Errorf("unexpected websocket orderbook %+v", book)
+	}
+	if err = common.JSONDecode([]byte(`{"asks":[[null]]}`), &book); err == nil {
+		t.Error("expected an error decoding a malformed websocket orderbook item")
+	}
+}
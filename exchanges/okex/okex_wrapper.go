@@ -0,0 +1,429 @@
+package okex
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/okgroup"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	okExFuturesOrderbookDepth = 200
+	// Futures order types
+	okExFuturesOpenLong   = 1
+	okExFuturesOpenShort  = 2
+	okExFuturesCloseLong  = 3
+	okExFuturesCloseShort = 4
+	// Futures order status for open (pending, partially filled) orders
+	okExFuturesOpenOrders = 6
+	// Futures contracts are delivered at 08:00 UTC on their delivery date
+	okExFuturesDeliveryHour = 8
+	okExFuturesDateFormat   = "2006-01-02"
+)
+
+// UpdateTicker updates and returns the ticker for a currency pair. Futures
+// contract pairs are stored under the futures asset type
+func (o *OKEX) UpdateTicker(p currency.Pair, assetType string) (tickerData ticker.Price, err error) {
+	if !okgroup.IsFuturesPair(p) {
+		return o.OKGroup.UpdateTicker(p, assetType)
+	}
+
+	instrumentID, err := o.GetFuturesInstrumentID(p)
+	if err != nil {
+		return
+	}
+
+	resp, err := o.GetFuturesTokenInfoForCurrency(instrumentID)
+	if err != nil {
+		return
+	}
+	tickerData = ticker.Price{
+		Ask:         resp.BestAsk,
+		Bid:         resp.BestBid,
+		High:        resp.High24h,
+		Last:        resp.Last,
+		LastUpdated: resp.Timestamp,
+		Low:         resp.Low24h,
+		Pair:        p,
+		Volume:      float64(resp.Volume24h),
+	}
+
+	err = ticker.ProcessTicker(o.Name, &tickerData, ticker.Futures)
+	return
+}
+
+// GetTickerPrice returns the ticker for a currency pair
+func (o *OKEX) GetTickerPrice(p currency.Pair, assetType string) (tickerData ticker.Price, err error) {
+	if okgroup.IsFuturesPair(p) {
+		assetType = ticker.Futures
+	}
+
+	tickerData, err = ticker.GetTicker(o.GetName(), p, assetType)
+	if err != nil {
+		return o.UpdateTicker(p, assetType)
+	}
+	return
+}
+
+// GetOrderbookEx returns orderbook base on the currency pair
+func (o *OKEX) GetOrderbookEx(p currency.Pair, assetType string) (resp orderbook.Base, err error) {
+	if okgroup.IsFuturesPair(p) {
+		assetType = orderbook.Futures
+	}
+
+	ob, err := orderbook.Get(o.GetName(), p, assetType)
+	if err != nil {
+		return o.UpdateOrderbook(p, assetType)
+	}
+	return ob, nil
+}
+
+// UpdateOrderbook updates and returns the orderbook for a currency pair.
+// Futures contract pairs are stored under the futures asset type
+func (o *OKEX) UpdateOrderbook(p currency.Pair, assetType string) (resp orderbook.Base, err error) {
+	if !okgroup.IsFuturesPair(p) {
+		return o.OKGroup.UpdateOrderbook(p, assetType)
+	}
+
+	instrumentID, err := o.GetFuturesInstrumentID(p)
+	if err != nil {
+		return
+	}
+
+	orderbookNew, err := o.GetFuturesOrderBook(okgroup.GetFuturesOrderBookRequest{
+		InstrumentID: instrumentID,
+		Size:         okExFuturesOrderbookDepth,
+	})
+	if err != nil {
+		return
+	}
+
+	for x := range orderbookNew.Bids {
+		resp.Bids = append(resp.Bids, orderbook.Item{
+			Amount: float64(orderbookNew.Bids[x].Size),
+			Price:  orderbookNew.Bids[x].Price,
+		})
+	}
+
+	for x := range orderbookNew.Asks {
+		resp.Asks = append(resp.Asks, orderbook.Item{
+			Amount: float64(orderbookNew.Asks[x].Size),
+			Price:  orderbookNew.Asks[x].Price,
+		})
+	}
+
+	resp.Pair = p
+	resp.AssetType = orderbook.Futures
+	resp.ExchangeName = o.Name
+
+	err = resp.Process()
+	if err != nil {
+		return
+	}
+
+	return orderbook.Get(o.Name, p, orderbook.Futures)
+}
+
+// SubmitOrder submits a new order. Orders on futures contract pairs open a
+// long position when buying and a short position when selling, the amount
+// being the number of contracts
+func (o *OKEX) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (resp exchange.SubmitOrderResponse, err error) {
+	if o.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
+	if !okgroup.IsFuturesPair(p) {
+		return o.OKGroup.SubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
+	futuresType := int64(okExFuturesOpenLong)
+	if side == exchange.SellOrderSide {
+		futuresType = okExFuturesOpenShort
+	}
+	return o.submitFuturesOrder(p, futuresType, orderType, amount, price, clientID)
+}
+
+// submitFuturesOrder places an order of a futures order type (open or close,
+// long or short) on a futures contract pair, the amount being a whole number
+// of contracts. The order uses the leverage of the position held on its side
+// of the contract, or the accounts leverage when none is held
+func (o *OKEX) submitFuturesOrder(p currency.Pair, futuresType int64, orderType exchange.OrderType, amount, price float64, clientID string) (resp exchange.SubmitOrderResponse, err error) {
+	if math.Mod(amount, 1) != 0 {
+		return resp, errors.New("contract amount can not have decimals")
+	}
+
+	instrumentID, err := o.GetFuturesInstrumentID(p)
+	if err != nil {
+		return
+	}
+
+	leverage, err := o.getFuturesLeverage(instrumentID, futuresType)
+	if err != nil {
+		return
+	}
+
+	request := okgroup.PlaceFuturesOrderRequest{
+		ClientOid:    clientID,
+		InstrumentID: instrumentID,
+		Type:         futuresType,
+		Price:        price,
+		Size:         int64(amount),
+		Leverage:     leverage,
+	}
+	if orderType == exchange.MarketOrderType {
+		request.MatchPrice = 1
+	}
+
+	orderResponse, err := o.PlaceFuturesOrder(request)
+	if err != nil {
+		return
+	}
+	resp.IsOrderPlaced = orderResponse.Result
+	resp.OrderID = orderResponse.OrderID
+
+	return
+}
+
+// getFuturesLeverage returns the leverage of the position held on the side of
+// a contract a futures order type opens or closes, falling back to the
+// accounts leverage for the underlying in crossed margin mode. Fixed margin
+// accounts must hold a position in the contract or set its leverage first
+func (o *OKEX) getFuturesLeverage(instrumentID string, futuresType int64) (int64, error) {
+	long := futuresType == okExFuturesOpenLong || futuresType == okExFuturesCloseLong
+	positions, err := o.GetFuturesPostionsForCurrency(instrumentID)
+	if err != nil {
+		return 0, err
+	}
+	if leverage := getPositionLeverage(positions.Holding, instrumentID, long); leverage > 0 {
+		return int64(leverage), nil
+	}
+
+	underlying := strings.ToLower(strings.Split(instrumentID, "-")[0])
+	account, err := o.GetFuturesLeverage(underlying)
+	if err != nil {
+		return 0, err
+	}
+	if account.Leverage <= 0 {
+		return 0, fmt.Errorf("%s unable to determine the leverage of %s, set the contract leverage first",
+			o.Name, instrumentID)
+	}
+	return account.Leverage, nil
+}
+
+// getPositionLeverage returns the leverage of the position held on one side
+// of a contract, zero when no position is held. Crossed margin positions
+// share a single leverage for both sides
+func getPositionLeverage(holding []okgroup.GetFuturePostionsDetails, instrumentID string, long bool) float64 {
+	for x := range holding {
+		if holding[x].InstrumentID != instrumentID {
+			continue
+		}
+		qty, leverage := holding[x].ShortQty, holding[x].ShortLeverage
+		if long {
+			qty, leverage = holding[x].LongQty, holding[x].LongLeverage
+		}
+		if qty <= 0 {
+			continue
+		}
+		if leverage > 0 {
+			return leverage
+		}
+		return holding[x].Leverage
+	}
+	return 0
+}
+
+// GetFuturesPositions returns the open long and short futures positions,
+// traded by their delivery date pair such as BTC-USD_190628
+func (o *OKEX) GetFuturesPositions() ([]exchange.FuturesPosition, error) {
+	positions, err := o.GetFuturesPostions()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []exchange.FuturesPosition
+	for x := range positions.Holding {
+		resp = append(resp, getFuturesPositions(positions.Holding[x])...)
+	}
+	return resp, nil
+}
+
+// getFuturesPositions converts futures holdings into a position per side held
+func getFuturesPositions(holding []okgroup.GetFuturePostionsDetails) []exchange.FuturesPosition {
+	var resp []exchange.FuturesPosition
+	for x := range holding {
+		split := strings.Split(holding[x].InstrumentID, "-")
+		if len(split) < 3 {
+			continue
+		}
+		p := currency.NewPairWithDelimiter(strings.Join(split[:len(split)-1], "-"),
+			split[len(split)-1], "_")
+		if holding[x].LongAvailQty > 0 {
+			resp = append(resp, exchange.FuturesPosition{
+				Symbol:      holding[x].InstrumentID,
+				Pair:        p,
+				Side:        exchange.BuyOrderSide,
+				Amount:      holding[x].LongAvailQty,
+				AverageCost: holding[x].LongAvgCost,
+			})
+		}
+		if holding[x].ShortAvailQty > 0 {
+			resp = append(resp, exchange.FuturesPosition{
+				Symbol:      holding[x].InstrumentID,
+				Pair:        p,
+				Side:        exchange.SellOrderSide,
+				Amount:      holding[x].ShortAvailQty,
+				AverageCost: holding[x].ShortAvgCost,
+			})
+		}
+	}
+	return resp
+}
+
+// CloseFuturesPosition closes an amount of the long or short position held in
+// a futures contract pair
+func (o *OKEX) CloseFuturesPosition(p currency.Pair, positionSide exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if o.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
+	if !okgroup.IsFuturesPair(p) {
+		return exchange.SubmitOrderResponse{},
+			fmt.Errorf("%s %s is not a futures contract pair", o.Name, p)
+	}
+
+	futuresType := int64(okExFuturesCloseLong)
+	if positionSide == exchange.SellOrderSide {
+		futuresType = okExFuturesCloseShort
+	}
+	return o.submitFuturesOrder(p, futuresType, orderType, amount, price, clientID)
+}
+
+// GetActiveOrders retrieves any orders that are active/open. Spot and futures
+// contract pairs can be requested together
+func (o *OKEX) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) (resp []exchange.OrderDetail, err error) {
+	var spotPairs []currency.Pair
+	for _, p := range getOrdersRequest.Currencies {
+		if !okgroup.IsFuturesPair(p) {
+			spotPairs = append(spotPairs, p)
+			continue
+		}
+
+		instrumentID, err := o.GetFuturesInstrumentID(p)
+		if err != nil {
+			return resp, err
+		}
+
+		futuresOrders, err := o.GetFuturesOrderList(okgroup.GetFuturesOrdersListRequest{
+			InstrumentID: instrumentID,
+			Status:       okExFuturesOpenOrders,
+		})
+		if err != nil {
+			return resp, err
+		}
+		// Fees are charged in the underlying currency and returned as a
+		// negative amount
+		feeAsset := currency.NewCode(strings.Split(p.Base.String(), "-")[0])
+		for i := range futuresOrders.OrderInfo {
+			order := futuresOrders.OrderInfo[i]
+			side := exchange.BuyOrderSide
+			if order.Type == okExFuturesOpenShort || order.Type == okExFuturesCloseLong {
+				side = exchange.SellOrderSide
+			}
+			resp = append(resp, exchange.OrderDetail{
+				ID:              strconv.FormatInt(order.OrderID, 10),
+				Price:           order.Price,
+				Amount:          order.Size,
+				CurrencyPair:    p,
+				Exchange:        o.Name,
+				OrderSide:       side,
+				OrderType:       exchange.LimitOrderType,
+				ExecutedAmount:  order.FilledQty,
+				RemainingAmount: order.Size - order.FilledQty,
+				Fee:             -order.Fee,
+				FeeAsset:        feeAsset,
+				OrderDate:       order.Timestamp,
+				Status:          strconv.FormatInt(order.Status, 10),
+			})
+		}
+	}
+
+	if len(spotPairs) == 0 {
+		return resp, nil
+	}
+
+	spotOrders, err := o.OKGroup.GetActiveOrders(&exchange.GetOrdersRequest{
+		OrderType:  getOrdersRequest.OrderType,
+		OrderSide:  getOrdersRequest.OrderSide,
+		StartTicks: getOrdersRequest.StartTicks,
+		EndTicks:   getOrdersRequest.EndTicks,
+		Currencies: spotPairs,
+	})
+	if err != nil {
+		return resp, err
+	}
+	return append(resp, spotOrders...), nil
+}
+
+// GetOpenInterest returns the open interest of a futures contract pair
+func (o *OKEX) GetOpenInterest(p currency.Pair) (resp exchange.OpenInterest, err error) {
+	instrumentID, err := o.GetFuturesInstrumentID(p)
+	if err != nil {
+		return
+	}
+
+	openInterest, err := o.GetFuturesOpenInterests(instrumentID)
+	if err != nil {
+		return
+	}
+
+	return exchange.OpenInterest{
+		Exchange:  o.Name,
+		Pair:      p,
+		AssetType: ticker.Futures,
+		Amount:    openInterest.Amount,
+		Timestamp: openInterest.Timestamp,
+	}, nil
+}
+
+// GetFuturesContracts returns the futures contracts currently listed, traded
+// by their delivery date pair such as BTC-USD_190628
+func (o *OKEX) GetFuturesContracts() ([]exchange.FuturesContract, error) {
+	info, err := o.GetFuturesContractInformation()
+	if err != nil {
+		return nil, err
+	}
+	return getFuturesContracts(o.Name, info), nil
+}
+
+// getFuturesContracts converts futures contract information, skipping
+// contracts without a valid delivery date
+func getFuturesContracts(exchName string, info []okgroup.GetFuturesContractInformationResponse) []exchange.FuturesContract {
+	var contracts []exchange.FuturesContract
+	for x := range info {
+		delivery, err := time.Parse(okExFuturesDateFormat, info[x].Delivery)
+		if err != nil {
+			continue
+		}
+		listing, _ := time.Parse(okExFuturesDateFormat, info[x].Listing)
+		underlying := info[x].UnderlyingIndex + "-" + info[x].QuoteCurrency
+		contracts = append(contracts, exchange.FuturesContract{
+			Exchange: exchName,
+			Symbol:   info[x].InstrumentID,
+			Pair: currency.NewPairWithDelimiter(underlying,
+				delivery.Format("060102"), "_"),
+			Underlying: currency.NewPairWithDelimiter(info[x].UnderlyingIndex,
+				info[x].QuoteCurrency, "-"),
+			Alias:   info[x].Alias,
+			Listing: listing,
+			Expiry:  delivery.Add(time.Hour * okExFuturesDeliveryHour),
+		})
+	}
+	return contracts
+}
@@ -18,18 +18,23 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	log "github.com/thrasher-/gocryptotrader/logger"
 )
 
 const (
 	okGroupAuthRate   = 0
 	okGroupUnauthRate = 0
+	// okGroupCandlesLimit is the maximum number of candles returned per request
+	okGroupCandlesLimit = 200
 	// OKGroupAPIPath const to help with api url formatting
 	OKGroupAPIPath = "api/"
 	// API subsections
 	okGroupAccountSubsection       = "account"
 	okGroupTokenSubsection         = "spot"
 	okGroupMarginTradingSubsection = "margin"
+	okGroupGeneralSubsection       = "general"
 	// OKGroupAccounts common api endpoint
 	OKGroupAccounts = "accounts"
 	// OKGroupLedger common api endpoint
@@ -66,6 +71,8 @@ const (
 	OKGroupGetSpotMarketData = "candles"
 	// OKGroupPriceLimit common api endpoint
 	OKGroupPriceLimit = "price_limit"
+	// OKGroupServerTime common api endpoint
+	OKGroupServerTime = "time"
 	// Account based endpoints
 	okGroupGetAccountCurrencies        = "currencies"
 	okGroupGetAccountWalletInformation = "wallet"
@@ -81,6 +88,14 @@ const (
 	okGroupGetRepayment          = "repayment"
 )
 
+// okGroupErrTimestampExpired is returned when a signed request timestamp
+// differs from the server time by more than 30 seconds
+const okGroupErrTimestampExpired = "30008"
+
+// okGroupErrGeoRestricted is returned when a function isn't available in the
+// country or region the request is made from
+const okGroupErrGeoRestricted = "20038"
+
 var errMissValue = errors.New("warning - resp value is missing from exchange")
 
 // OKGroup is the overaching type across the all of OKEx's exchange methods
@@ -89,13 +104,16 @@ type OKGroup struct {
 	ExchangeName  string
 	WebsocketConn *websocket.Conn
 	wsRequestMtx  sync.Mutex
+	timeSync      exchange.TimeSync
 	// Spot and contract market error codes as per https://www.okex.com/rest_request.html
 	ErrorCodes map[string]error
-	// Stores for corresponding variable checks
-	ContractTypes    []string
-	CurrencyPairs    []string
-	ContractPosition []string
-	Types            []string
+	// FuturesInstrumentsFetcher is set by implementations which support
+	// futures so their instruments can be validated alongside spot
+	FuturesInstrumentsFetcher func() ([]GetFuturesContractInformationResponse, error)
+	// FuturesLiquidationsFetcher is set by implementations which support
+	// futures so liquidations can be sent to the websocket datahandler
+	FuturesLiquidationsFetcher func(GetFuturesForceLiquidatedOrdersRequest) ([]GetFuturesForceLiquidatedOrdersResponse, error)
+	instruments                instrumentStore
 	// URLs to be overridden by implementations of OKGroup
 	APIURL       string
 	APIVersion   string
@@ -110,11 +128,14 @@ func (o *OKGroup) Setup(exch *config.ExchangeConfig) {
 		o.Name = exch.Name
 		o.Enabled = true
 		o.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
-		o.SetAPIKeys(exch.APIKey, exch.APISecret, exch.ClientID, false)
+		o.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
+		o.SetAPIPassphrase(exch.APIPassphrase)
 		o.SetHTTPClientTimeout(exch.HTTPTimeout)
 		o.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		o.SetHTTPHeaders(exch.HTTPHeaders)
 		o.RESTPollingDelay = exch.RESTPollingDelay
 		o.Verbose = exch.Verbose
+		o.ReadOnly = exch.ReadOnly
 		o.HTTPDebugging = exch.HTTPDebugging
 		o.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		o.BaseCurrencies = exch.BaseCurrencies
@@ -151,6 +172,10 @@ func (o *OKGroup) Setup(exch *config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		o.Websocket.SetHeartbeat(o.wsHeartbeat())
+		o.Websocket.Orderbook.SetChecksum(func(ob *orderbook.Base) uint32 {
+			return uint32(o.CalculateUpdateOrderbookChecksum(ob))
+		})
 	}
 }
 
@@ -252,6 +277,10 @@ func (o *OKGroup) GetSpotBillDetailsForCurrency(request GetSpotBillDetailsForCur
 // You can place an order only if you have enough funds.
 // Once your order is placed, the amount will be put on hold.
 func (o *OKGroup) PlaceSpotOrder(request *PlaceSpotOrderRequest) (resp PlaceSpotOrderResponse, _ error) {
+	err := o.CheckSpotSymbol(request.InstrumentID)
+	if err != nil {
+		return resp, err
+	}
 	return resp, o.SendHTTPRequest(http.MethodPost, okGroupTokenSubsection, OKGroupOrders, request, &resp, true)
 }
 
@@ -262,6 +291,10 @@ func (o *OKGroup) PlaceMultipleSpotOrders(request []PlaceSpotOrderRequest) (map[
 	resp := make(map[string][]PlaceSpotOrderResponse)
 
 	for i := range request {
+		err := o.CheckSpotSymbol(request[i].InstrumentID)
+		if err != nil {
+			return resp, []error{err}
+		}
 		currencyPairOrders[request[i].InstrumentID]++
 	}
 
@@ -293,6 +326,10 @@ func (o *OKGroup) PlaceMultipleSpotOrders(request []PlaceSpotOrderRequest) (map[
 
 // CancelSpotOrder Cancelling an unfilled order.
 func (o *OKGroup) CancelSpotOrder(request CancelSpotOrderRequest) (resp CancelSpotOrderResponse, _ error) {
+	err := o.CheckSpotSymbol(request.InstrumentID)
+	if err != nil {
+		return resp, err
+	}
 	requestURL := fmt.Sprintf("%v/%v", OKGroupCancelOrders, request.OrderID)
 	return resp, o.SendHTTPRequest(http.MethodPost, okGroupTokenSubsection, requestURL, request, &resp, true)
 }
@@ -331,6 +368,12 @@ func (o *OKGroup) CancelMultipleSpotOrders(request CancelMultipleSpotOrdersReque
 // GetSpotOrders List your orders. Cursor pagination is used.
 // All paginated requests return the latest information (newest) as the first page sorted by newest (in chronological time) first.
 func (o *OKGroup) GetSpotOrders(request GetSpotOrdersRequest) (resp []GetSpotOrderResponse, _ error) {
+	if request.InstrumentID != "" {
+		err := o.CheckSpotSymbol(request.InstrumentID)
+		if err != nil {
+			return resp, err
+		}
+	}
 	requestURL := fmt.Sprintf("%v%v", OKGroupOrders, FormatParameters(request))
 	return resp, o.SendHTTPRequest(http.MethodGet, okGroupTokenSubsection, requestURL, nil, &resp, true)
 }
@@ -338,12 +381,22 @@ func (o *OKGroup) GetSpotOrders(request GetSpotOrdersRequest) (resp []GetSpotOrd
 // GetSpotOpenOrders List all your current open orders. Cursor pagination is used.
 // All paginated requests return the latest information (newest) as the first page sorted by newest (in chronological time) first.
 func (o *OKGroup) GetSpotOpenOrders(request GetSpotOpenOrdersRequest) (resp []GetSpotOrderResponse, _ error) {
+	if request.InstrumentID != "" {
+		err := o.CheckSpotSymbol(request.InstrumentID)
+		if err != nil {
+			return resp, err
+		}
+	}
 	requestURL := fmt.Sprintf("%v%v", OKGroupPendingOrders, FormatParameters(request))
 	return resp, o.SendHTTPRequest(http.MethodGet, okGroupTokenSubsection, requestURL, nil, &resp, true)
 }
 
 // GetSpotOrder Get order details by order ID.
 func (o *OKGroup) GetSpotOrder(request GetSpotOrderRequest) (resp GetSpotOrderResponse, _ error) {
+	err := o.CheckSpotSymbol(request.InstrumentID)
+	if err != nil {
+		return resp, err
+	}
 	requestURL := fmt.Sprintf("%v/%v%v", OKGroupOrders, request.OrderID, FormatParameters(request))
 	return resp, o.SendHTTPRequest(http.MethodGet, okGroupTokenSubsection, requestURL, request, &resp, true)
 }
@@ -351,6 +404,10 @@ func (o *OKGroup) GetSpotOrder(request GetSpotOrderRequest) (resp GetSpotOrderRe
 // GetSpotTransactionDetails Get details of the recent filled orders. Cursor pagination is used.
 // All paginated requests return the latest information (newest) as the first page sorted by newest (in chronological time) first.
 func (o *OKGroup) GetSpotTransactionDetails(request GetSpotTransactionDetailsRequest) (resp []GetSpotTransactionDetailsResponse, _ error) {
+	err := o.CheckSpotSymbol(request.InstrumentID)
+	if err != nil {
+		return resp, err
+	}
 	requestURL := fmt.Sprintf("%v%v", OKGroupGetSpotTransactionDetails, FormatParameters(request))
 	return resp, o.SendHTTPRequest(http.MethodGet, okGroupTokenSubsection, requestURL, nil, &resp, false)
 }
@@ -364,6 +421,10 @@ func (o *OKGroup) GetSpotTokenPairDetails() (resp []GetSpotTokenPairDetailsRespo
 // GetSpotOrderBook Getting the order book of a trading pair. Pagination is not supported here.
 // The whole book will be returned for one request. Websocket is recommended here.
 func (o *OKGroup) GetSpotOrderBook(request GetSpotOrderBookRequest) (resp GetSpotOrderBookResponse, _ error) {
+	err := o.CheckSpotSymbol(request.InstrumentID)
+	if err != nil {
+		return resp, err
+	}
 	requestURL := fmt.Sprintf("%v/%v/%v%v", OKGroupInstruments, request.InstrumentID, OKGroupGetSpotOrderBook, FormatParameters(request))
 	return resp, o.SendHTTPRequest(http.MethodGet, okGroupTokenSubsection, requestURL, nil, &resp, false)
 }
@@ -376,6 +437,10 @@ func (o *OKGroup) GetSpotAllTokenPairsInformation() (resp []GetSpotTokenPairsInf
 
 // GetSpotAllTokenPairsInformationForCurrency Get the last traded price, best bid/ask price, 24 hour trading volume and more info of a currency
 func (o *OKGroup) GetSpotAllTokenPairsInformationForCurrency(currency string) (resp GetSpotTokenPairsInformationResponse, _ error) {
+	err := o.CheckSpotSymbol(currency)
+	if err != nil {
+		return resp, err
+	}
 	requestURL := fmt.Sprintf("%v/%v/%v", OKGroupInstruments, currency, OKGroupTicker)
 	return resp, o.SendHTTPRequest(http.MethodGet, okGroupTokenSubsection, requestURL, nil, &resp, false)
 }
@@ -383,12 +448,20 @@ func (o *OKGroup) GetSpotAllTokenPairsInformationForCurrency(currency string) (r
 // GetSpotFilledOrdersInformation Get the recent 60 transactions of all trading pairs.
 // Cursor pagination is used. All paginated requests return the latest information (newest) as the first page sorted by newest (in chronological time) first.
 func (o *OKGroup) GetSpotFilledOrdersInformation(request GetSpotFilledOrdersInformationRequest) (resp []GetSpotFilledOrdersInformationResponse, _ error) {
+	err := o.CheckSpotSymbol(request.InstrumentID)
+	if err != nil {
+		return resp, err
+	}
 	requestURL := fmt.Sprintf("%v/%v/%v%v", OKGroupInstruments, request.InstrumentID, OKGroupTrades, FormatParameters(request))
 	return resp, o.SendHTTPRequest(http.MethodGet, okGroupTokenSubsection, requestURL, nil, &resp, false)
 }
 
 // GetSpotMarketData Get the charts of the trading pairs. Charts are returned in grouped buckets based on requested granularity.
 func (o *OKGroup) GetSpotMarketData(request GetSpotMarketDataRequest) (resp GetSpotMarketDataResponse, _ error) {
+	err := o.CheckSpotSymbol(request.InstrumentID)
+	if err != nil {
+		return resp, err
+	}
 	requestURL := fmt.Sprintf("%v/%v/%v%v", OKGroupInstruments, request.InstrumentID, OKGroupGetSpotMarketData, FormatParameters(request))
 	return resp, o.SendHTTPRequest(http.MethodGet, okGroupTokenSubsection, requestURL, nil, &resp, false)
 }
@@ -557,17 +630,23 @@ func FormatParameters(request interface{}) (parameters string) {
 	return
 }
 
-// GetErrorCode returns an error code
+// GetErrorCode returns the error for an exchange error code, returned as
+// either a string or a number
 func (o *OKGroup) GetErrorCode(code interface{}) error {
 	var assertedCode string
-
-	switch reflect.TypeOf(code).String() {
-	case "float64":
-		assertedCode = strconv.FormatFloat(code.(float64), 'f', -1, 64)
-	case "string":
-		assertedCode = code.(string)
+	switch c := code.(type) {
+	case ErrorCode:
+		assertedCode = string(c)
+	case string:
+		assertedCode = c
+	case json.Number:
+		assertedCode = c.String()
+	case float64:
+		assertedCode = strconv.FormatFloat(c, 'f', -1, 64)
+	case int64:
+		assertedCode = strconv.FormatInt(c, 10)
 	default:
-		return errors.New("unusual type returned")
+		return fmt.Errorf("unusual error code type %T returned", code)
 	}
 
 	if i, ok := o.ErrorCodes[assertedCode]; ok {
@@ -576,15 +655,42 @@ func (o *OKGroup) GetErrorCode(code interface{}) error {
 	return errors.New("unable to find SPOT error code")
 }
 
+// GetServerTime returns the OKGroup server time
+func (o *OKGroup) GetServerTime() (time.Time, error) {
+	var resp struct {
+		ISO time.Time `json:"iso"`
+	}
+	err := o.SendHTTPRequest(http.MethodGet, okGroupGeneralSubsection, OKGroupServerTime, nil, &resp, false)
+	return resp.ISO, err
+}
+
 // SendHTTPRequest sends an authenticated http request to a desired
 // path with a JSON payload (of present)
-// URL arguments must be in the request path and not as url.URL values
-func (o *OKGroup) SendHTTPRequest(httpMethod, requestType, requestPath string, data, result interface{}, authenticated bool) (err error) {
-	if authenticated && !o.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, o.Name)
+// URL arguments must be in the request path and not as url.URL values.
+// Authenticated requests rejected due to clock skew are retried once after
+// re-measuring the server time
+func (o *OKGroup) SendHTTPRequest(httpMethod, requestType, requestPath string, data, result interface{}, authenticated bool) error {
+	if !authenticated {
+		return o.sendHTTPRequest(httpMethod, requestType, requestPath, data, result, false)
+	}
+	if !o.AuthenticatedAPISupport {
+		return exchange.ErrCredentialsNotSet
 	}
 
-	utcTime := time.Now().UTC()
+	return o.timeSync.RetryOnTimeSkew(o.Name, isTimeSkewError, o.GetServerTime, func() error {
+		return o.sendHTTPRequest(httpMethod, requestType, requestPath, data, result, true)
+	})
+}
+
+// isTimeSkewError returns whether a request was rejected because its
+// timestamp has expired
+func isTimeSkewError(err error) bool {
+	reqErr, ok := err.(*request.Error)
+	return ok && reqErr.Code == okGroupErrTimestampExpired
+}
+
+func (o *OKGroup) sendHTTPRequest(httpMethod, requestType, requestPath string, data, result interface{}, authenticated bool) (err error) {
+	utcTime := o.timeSync.Now().UTC()
 	iso := utcTime.String()
 	isoBytes := []byte(iso)
 	iso = string(isoBytes[:10]) + "T" + string(isoBytes[11:23]) + "Z"
@@ -597,7 +703,7 @@ func (o *OKGroup) SendHTTPRequest(httpMethod, requestType, requestPath string, d
 		}
 
 		if o.Verbose {
-			log.Debugf("Request JSON: %s\n", payload)
+			log.Debugf("Request JSON: %s\n", log.DebugPayload(o.Name, payload))
 		}
 	}
 
@@ -615,34 +721,53 @@ func (o *OKGroup) SendHTTPRequest(httpMethod, requestType, requestPath string, d
 		headers["OK-ACCESS-KEY"] = o.APIKey
 		headers["OK-ACCESS-SIGN"] = base64
 		headers["OK-ACCESS-TIMESTAMP"] = iso
-		headers["OK-ACCESS-PASSPHRASE"] = o.ClientID
+		headers["OK-ACCESS-PASSPHRASE"] = o.APIPassphrase
 	}
 
 	var intermediary json.RawMessage
 	type errCapFormat struct {
-		Error        int64  `json:"error_code,omitempty"`
-		ErrorMessage string `json:"error_message,omitempty"`
-		Result       bool   `json:"result,string,omitempty"`
+		Error        ErrorCode      `json:"error_code,omitempty"`
+		ErrorMessage string         `json:"error_message,omitempty"`
+		Result       ResponseResult `json:"result,omitempty"`
 	}
 
 	errCap := errCapFormat{}
 	errCap.Result = true
 	err = o.SendPayload(strings.ToUpper(httpMethod), path, headers, bytes.NewBuffer(payload), &intermediary, authenticated, false, o.Verbose, o.HTTPDebugging)
 	if err != nil {
+		// Describe known error codes which are only otherwise returned
+		// as an unsuccessful HTTP status code
+		if reqErr, ok := err.(*request.Error); ok && reqErr.Code == okGroupErrGeoRestricted {
+			reqErr.Err = request.ErrGeoRestricted
+		} else if ok && o.ErrorCodes[reqErr.Code] != nil {
+			reqErr.Err = fmt.Errorf("%s - %s", reqErr.Err, o.ErrorCodes[reqErr.Code])
+		}
 		return err
 	}
 
 	err = common.JSONDecode(intermediary, &errCap)
 	if err == nil {
 		if errCap.ErrorMessage != "" {
-			return fmt.Errorf("error: %v", errCap.ErrorMessage)
+			reqErr := request.NewError(o.Name, httpMethod, path, fmt.Errorf("error: %v", errCap.ErrorMessage))
+			if errCap.Error.IsError() {
+				reqErr.Code = string(errCap.Error)
+			}
+			if reqErr.Code == okGroupErrGeoRestricted {
+				reqErr.Err = request.ErrGeoRestricted
+			}
+			return reqErr
 		}
-		if errCap.Error > 0 {
-			return fmt.Errorf("sendHTTPRequest error - %s",
-				o.ErrorCodes[strconv.FormatInt(errCap.Error, 10)])
+		if errCap.Error.IsError() {
+			reqErr := request.NewError(o.Name, httpMethod, path, fmt.Errorf("sendHTTPRequest error - %s",
+				o.GetErrorCode(errCap.Error)))
+			reqErr.Code = string(errCap.Error)
+			if reqErr.Code == okGroupErrGeoRestricted {
+				reqErr.Err = request.ErrGeoRestricted
+			}
+			return reqErr
 		}
 		if !errCap.Result {
-			return errors.New("unspecified error occurred")
+			return request.NewError(o.Name, httpMethod, path, errors.New("unspecified error occurred"))
 		}
 
 	}
@@ -650,17 +775,6 @@ func (o *OKGroup) SendHTTPRequest(httpMethod, requestType, requestPath string, d
 	return common.JSONDecode(intermediary, result)
 }
 
-// SetCheckVarDefaults sets main variables that will be used in requests because
-// api does not return an error if there are misspellings in strings. So better
-// to check on this, this end.
-func (o *OKGroup) SetCheckVarDefaults() {
-	o.ContractTypes = []string{"this_week", "next_week", "quarter"}
-	o.CurrencyPairs = []string{"btc_usd", "ltc_usd", "eth_usd", "etc_usd", "bch_usd"}
-	o.Types = []string{"1min", "3min", "5min", "15min", "30min", "1day", "3day",
-		"1week", "1hour", "2hour", "4hour", "6hour", "12hour"}
-	o.ContractPosition = []string{"1", "2", "3", "4"}
-}
-
 // GetFee returns an estimate of fee based on type of transaction
 func (o *OKGroup) GetFee(feeBuilder *exchange.FeeBuilder) (fee float64, _ error) {
 	switch feeBuilder.FeeType {
@@ -699,7 +813,7 @@ func calculateTradingFee(purchasePrice, amount float64, isMaker bool) (fee float
 	} else {
 		fee = 0.0015
 	}
-	return fee * amount * purchasePrice
+	return exchange.CalculateTradingFee(fee, purchasePrice, amount)
 }
 
 // SetErrorDefaults sets the full error default list
@@ -707,6 +821,7 @@ func (o *OKGroup) SetErrorDefaults() {
 	o.ErrorCodes = map[string]error{
 		"0":     errors.New("successful"),
 		"1":     errors.New("invalid parameter in url normally"),
+		"20038": request.ErrGeoRestricted,
 		"30001": errors.New("request header \"OK_ACCESS_KEY\" cannot be blank"),
 		"30002": errors.New("request header \"OK_ACCESS_SIGN\" cannot be blank"),
 		"30003": errors.New("request header \"OK_ACCESS_TIMESTAMP\" cannot be blank"),
@@ -0,0 +1,223 @@
+package okgroup
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+// InstrumentsRefreshInterval defines how long fetched instrument data is
+// considered valid before it is refreshed from the exchange
+const InstrumentsRefreshInterval = time.Hour
+
+// futuresContractAliases maps the contract alias used in futures currency
+// pairs (BTC-USD_QUARTER) to the alias returned by the exchange
+var futuresContractAliases = map[string]string{
+	"THISWEEK": "this_week",
+	"NEXTWEEK": "next_week",
+	"QUARTER":  "quarter",
+}
+
+// instrumentStore holds tradable instruments fetched from the instruments
+// endpoints, used to validate symbols and contract types before requests are
+// sent
+type instrumentStore struct {
+	m             sync.RWMutex
+	spot          map[string]bool
+	futures       map[string]bool
+	contractTypes map[string]bool
+	// contractValues holds the face value of each futures instrument in its
+	// quote currency
+	contractValues map[string]float64
+	// futuresAliases maps an underlying index and contract alias
+	// (BTC-USD|quarter) to the current instrument ID
+	futuresAliases map[string]string
+	lastUpdated    time.Time
+}
+
+// formatInstrumentID normalises instrument and symbol formats (btc_usdt,
+// BTC-USDT) to the instrument ID format returned by the exchange
+func formatInstrumentID(symbol string) string {
+	return strings.ToUpper(strings.Replace(symbol, "_", "-", -1))
+}
+
+// UpdateInstruments fetches the spot instruments and, where supported, the
+// futures instruments and stores them for symbol validation
+func (o *OKGroup) UpdateInstruments() ([]GetSpotTokenPairDetailsResponse, error) {
+	spot, err := o.GetSpotTokenPairDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	spotInstruments := make(map[string]bool)
+	for x := range spot {
+		spotInstruments[formatInstrumentID(spot[x].InstrumentID)] = true
+	}
+
+	futuresInstruments := make(map[string]bool)
+	contractTypes := make(map[string]bool)
+	futuresAliases := make(map[string]string)
+	contractValues := make(map[string]float64)
+	if o.FuturesInstrumentsFetcher != nil {
+		futures, err := o.FuturesInstrumentsFetcher()
+		if err != nil {
+			return nil, err
+		}
+		for x := range futures {
+			futuresInstruments[formatInstrumentID(futures[x].InstrumentID)] = true
+			contractValues[formatInstrumentID(futures[x].InstrumentID)] = float64(futures[x].ContractVal)
+			if futures[x].Alias != "" {
+				contractTypes[futures[x].Alias] = true
+				underlying := formatInstrumentID(futures[x].UnderlyingIndex + "-" + futures[x].QuoteCurrency)
+				futuresAliases[underlying+"|"+futures[x].Alias] = formatInstrumentID(futures[x].InstrumentID)
+			}
+		}
+	}
+
+	o.instruments.m.Lock()
+	o.instruments.spot = spotInstruments
+	o.instruments.futures = futuresInstruments
+	o.instruments.contractTypes = contractTypes
+	o.instruments.futuresAliases = futuresAliases
+	o.instruments.contractValues = contractValues
+	o.instruments.lastUpdated = time.Now()
+	o.instruments.m.Unlock()
+	return spot, nil
+}
+
+// checkInstruments refreshes the stored instruments if they have expired
+func (o *OKGroup) checkInstruments() error {
+	o.instruments.m.RLock()
+	expired := time.Since(o.instruments.lastUpdated) > InstrumentsRefreshInterval
+	o.instruments.m.RUnlock()
+	if !expired {
+		return nil
+	}
+	_, err := o.UpdateInstruments()
+	return err
+}
+
+// CheckSymbol checks a spot or futures symbol against the instruments listed
+// by the exchange
+func (o *OKGroup) CheckSymbol(symbol string) error {
+	err := o.checkInstruments()
+	if err != nil {
+		return err
+	}
+
+	instrumentID := formatInstrumentID(symbol)
+	o.instruments.m.RLock()
+	defer o.instruments.m.RUnlock()
+	if o.instruments.spot[instrumentID] || o.instruments.futures[instrumentID] {
+		return nil
+	}
+	return fmt.Errorf("%s invalid symbol %s", o.Name, symbol)
+}
+
+// CheckSpotSymbol checks a spot symbol against the spot instruments listed by
+// the exchange
+func (o *OKGroup) CheckSpotSymbol(symbol string) error {
+	err := o.checkInstruments()
+	if err != nil {
+		return err
+	}
+
+	o.instruments.m.RLock()
+	defer o.instruments.m.RUnlock()
+	if o.instruments.spot[formatInstrumentID(symbol)] {
+		return nil
+	}
+	return fmt.Errorf("%s invalid spot symbol %s", o.Name, symbol)
+}
+
+// CheckContractType checks a futures contract type (this_week, next_week,
+// quarter) against the contract aliases listed by the exchange
+func (o *OKGroup) CheckContractType(contractType string) error {
+	err := o.checkInstruments()
+	if err != nil {
+		return err
+	}
+
+	o.instruments.m.RLock()
+	defer o.instruments.m.RUnlock()
+	if o.instruments.contractTypes[contractType] {
+		return nil
+	}
+	return fmt.Errorf("%s invalid contract type %s", o.Name, contractType)
+}
+
+// GetContractValue returns the face value of a futures instrument in its quote
+// currency, such as 100 USD for BTC contracts
+func (o *OKGroup) GetContractValue(instrumentID string) (float64, error) {
+	err := o.checkInstruments()
+	if err != nil {
+		return 0, err
+	}
+
+	o.instruments.m.RLock()
+	defer o.instruments.m.RUnlock()
+	value, ok := o.instruments.contractValues[formatInstrumentID(instrumentID)]
+	if !ok || value <= 0 {
+		return 0, fmt.Errorf("%s no contract value listed for %s", o.Name, instrumentID)
+	}
+	return value, nil
+}
+
+// IsFuturesPair returns whether a currency pair denotes a futures contract.
+// Futures pairs use the underlying index as the base and a contract alias
+// (THISWEEK, NEXTWEEK, QUARTER) or delivery date as the quote, for example
+// BTC-USD_QUARTER or BTC-USD_190628
+func IsFuturesPair(p currency.Pair) bool {
+	return strings.Contains(p.Base.String(), "-")
+}
+
+// GetFuturesInstrumentID resolves a futures currency pair to the instrument ID
+// of the contract currently trading under that alias or delivery date
+func (o *OKGroup) GetFuturesInstrumentID(p currency.Pair) (string, error) {
+	if !IsFuturesPair(p) {
+		return "", fmt.Errorf("%s %s is not a futures contract pair", o.Name, p)
+	}
+
+	underlying := formatInstrumentID(p.Base.String())
+	contract := strings.ToUpper(p.Quote.String())
+	alias, ok := futuresContractAliases[contract]
+	if !ok {
+		instrumentID := underlying + "-" + contract
+		return instrumentID, o.CheckSymbol(instrumentID)
+	}
+
+	err := o.checkInstruments()
+	if err != nil {
+		return "", err
+	}
+
+	o.instruments.m.RLock()
+	defer o.instruments.m.RUnlock()
+	instrumentID, ok := o.instruments.futuresAliases[underlying+"|"+alias]
+	if !ok {
+		return "", fmt.Errorf("%s no %s contract listed for %s", o.Name, alias, underlying)
+	}
+	return instrumentID, nil
+}
+
+// GetFuturesPairs returns a futures currency pair for each listed contract
+// alias
+func (o *OKGroup) GetFuturesPairs() currency.Pairs {
+	o.instruments.m.RLock()
+	defer o.instruments.m.RUnlock()
+
+	var pairs currency.Pairs
+	for contract, alias := range futuresContractAliases {
+		for key := range o.instruments.futuresAliases {
+			split := strings.Split(key, "|")
+			if split[1] != alias {
+				continue
+			}
+			pairs = append(pairs, currency.NewPairWithDelimiter(split[0], contract, "_"))
+		}
+	}
+	return pairs
+}
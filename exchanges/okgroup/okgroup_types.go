@@ -1,6 +1,10 @@
 package okgroup
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -325,15 +329,132 @@ type GetSpotMarketDataRequest struct {
 	InstrumentID string `url:"-"`               // [required] trading pairs
 }
 
+// klineGranularities are the candle durations in seconds GetSpotMarketData
+// accepts
+var klineGranularities = []int64{60, 180, 300, 900, 1800, 3600, 7200, 14400,
+	21600, 43200, 86400, 604800}
+
 // GetSpotMarketDataResponse response data for GetSpotMarketData
+type GetSpotMarketDataResponse []MarketDataCandle
+
+// MarketDataCandle is a candle returned by the spot, futures and swap market
+// data endpoints
 // Return Parameters
-// time 	string 	Start time
-// open 	string 	Open price
-// high 	string 	Highest price
-// low 	string 	Lowest price
-// close 	string 	Close price
-// volume 	string 	Trading volume
-type GetSpotMarketDataResponse []interface{}
+// time 			string 	Start time
+// open 			string 	Open price
+// high 			string 	Highest price
+// low 				string 	Lowest price
+// close 			string 	Close price
+// volume 			string 	Trading volume
+// currency_volume 	string 	Volume in a specific token, contracts only
+type MarketDataCandle struct {
+	Time           time.Time
+	Open           float64
+	High           float64
+	Low            float64
+	Close          float64
+	Volume         float64
+	CurrencyVolume float64
+}
+
+// UnmarshalJSON decodes a candle from its array of values
+func (m *MarketDataCandle) UnmarshalJSON(data []byte) error {
+	values, err := decodeArrayValues(data, 6)
+	if err != nil {
+		return fmt.Errorf("unexpected candle %s: %s", data, err)
+	}
+	m.Time, err = time.Parse(time.RFC3339, values[0])
+	if err != nil {
+		return fmt.Errorf("unexpected candle %s: %s", data, err)
+	}
+	fields := []*float64{&m.Open, &m.High, &m.Low, &m.Close, &m.Volume,
+		&m.CurrencyVolume}
+	for x := 1; x < len(values) && x <= len(fields); x++ {
+		*fields[x-1], err = strconv.ParseFloat(values[x], 64)
+		if err != nil {
+			return fmt.Errorf("unexpected candle %s: %s", data, err)
+		}
+	}
+	return nil
+}
+
+// ErrorCode holds an exchange error code, which is returned as either a
+// string or a number
+type ErrorCode string
+
+// UnmarshalJSON decodes an error code from a string or number
+func (e *ErrorCode) UnmarshalJSON(data []byte) error {
+	var code json.Number
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		err := json.Unmarshal(data, &s)
+		if err != nil {
+			return err
+		}
+		code = json.Number(s)
+	} else {
+		err := json.Unmarshal(data, &code)
+		if err != nil {
+			return fmt.Errorf("unexpected error code %s: %s", data, err)
+		}
+	}
+	*e = ErrorCode(code)
+	return nil
+}
+
+// IsError returns whether the code reports an error, a zero code reporting
+// success
+func (e ErrorCode) IsError() bool {
+	return e != "" && e != "0"
+}
+
+// ResponseResult holds the result of a request, which is returned as either
+// a bool or a string
+type ResponseResult bool
+
+// UnmarshalJSON decodes a result from a bool or string
+func (r *ResponseResult) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+	result, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("unexpected result %s: %s", data, err)
+	}
+	*r = ResponseResult(result)
+	return nil
+}
+
+// decodeArrayValues decodes a JSON array of strings and numbers into their
+// string values, requiring at least min values
+func decodeArrayValues(data []byte, min int) ([]string, error) {
+	var raw []json.RawMessage
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) < min {
+		return nil, fmt.Errorf("expected %d values, got %d", min, len(raw))
+	}
+	values := make([]string, len(raw))
+	for x := range raw {
+		if len(raw[x]) > 0 && raw[x][0] == '"' {
+			err = json.Unmarshal(raw[x], &values[x])
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var n json.Number
+		err = json.Unmarshal(raw[x], &n)
+		if err != nil {
+			return nil, err
+		}
+		values[x] = n.String()
+	}
+	return values, nil
+}
 
 // GetMarginAccountsResponse response data for GetMarginAccounts
 type GetMarginAccountsResponse struct {
@@ -446,61 +567,69 @@ type GetFuturesPositionsForCurrencyResponse struct {
 	Result  bool                       `json:"result"`
 }
 
-// GetFuturePostionsDetails Futures details
+// GetFuturePostionsDetails Futures details. Fields prefixed with Long/Short
+// which are specific to a margin mode are only populated for that mode
 type GetFuturePostionsDetails struct {
-	CreatedAt            string `json:"created_at"`
-	InstrumentID         string `json:"instrument_id"`
-	Leverage             string `json:"leverage"`
-	LiquidationPrice     string `json:"liquidation_price"`
-	LongAvailQty         string `json:"long_avail_qty"`
-	LongAvgCost          string `json:"long_avg_cost"`
-	LongLeverage         string `json:"long_leverage"`
-	LongLiquiPrice       string `json:"long_liqui_price"`
-	LongMargin           string `json:"long_margin"`
-	LongPnlRatio         string `json:"long_pnl_ratio"`
-	LongQty              string `json:"long_qty"`
-	LongSettlementPrice  string `json:"long_settlement_price"`
-	MarginMode           string `json:"margin_mode"`
-	RealisedPnl          string `json:"realised_pnl"`
-	ShortAvailQty        string `json:"short_avail_qty"`
-	ShortAvgCost         string `json:"short_avg_cost"`
-	ShortLeverage        string `json:"short_leverage"`
-	ShortLiquiPrice      string `json:"short_liqui_price"`
-	ShortMargin          string `json:"short_margin"`
-	ShortPnlRatio        string `json:"short_pnl_ratio"`
-	ShortQty             string `json:"short_qty"`
-	ShortSettlementPrice string `json:"short_settlement_price"`
-	UpdatedAt            string `json:"updated_at"`
-}
-
-// FuturesAccountForAllCurrenciesResponse response data for FuturesAccountForAllCurrencies
+	CreatedAt            time.Time `json:"created_at"`
+	InstrumentID         string    `json:"instrument_id"`
+	Leverage             float64   `json:"leverage,string"`
+	LiquidationPrice     float64   `json:"liquidation_price,string"`
+	LongAvailQty         float64   `json:"long_avail_qty,string"`
+	LongAvgCost          float64   `json:"long_avg_cost,string"`
+	LongLeverage         float64   `json:"long_leverage,string"`
+	LongLiquiPrice       float64   `json:"long_liqui_price,string"`
+	LongMargin           float64   `json:"long_margin,string"`
+	LongPnl              float64   `json:"long_pnl,string"`
+	LongPnlRatio         float64   `json:"long_pnl_ratio,string"`
+	LongQty              float64   `json:"long_qty,string"`
+	LongSettlementPrice  float64   `json:"long_settlement_price,string"`
+	LongUnrealisedPnl    float64   `json:"long_unrealised_pnl,string"`
+	MarginMode           string    `json:"margin_mode"`
+	RealisedPnl          float64   `json:"realised_pnl,string"`
+	ShortAvailQty        float64   `json:"short_avail_qty,string"`
+	ShortAvgCost         float64   `json:"short_avg_cost,string"`
+	ShortLeverage        float64   `json:"short_leverage,string"`
+	ShortLiquiPrice      float64   `json:"short_liqui_price,string"`
+	ShortMargin          float64   `json:"short_margin,string"`
+	ShortPnl             float64   `json:"short_pnl,string"`
+	ShortPnlRatio        float64   `json:"short_pnl_ratio,string"`
+	ShortQty             float64   `json:"short_qty,string"`
+	ShortSettlementPrice float64   `json:"short_settlement_price,string"`
+	ShortUnrealisedPnl   float64   `json:"short_unrealised_pnl,string"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// FuturesAccountForAllCurrenciesResponse response data for
+// FuturesAccountForAllCurrencies, keyed by lower case currency
 type FuturesAccountForAllCurrenciesResponse struct {
-	Info struct {
-		Currency map[string]FuturesCurrencyData
-	} `json:"info"`
+	Info map[string]FuturesCurrencyData `json:"info"`
 }
 
-// FuturesCurrencyData Futures details
+// FuturesCurrencyData Futures account details for a currency. Contracts is
+// only populated when the account uses fixed margin
 type FuturesCurrencyData struct {
 	Contracts         []FuturesContractsData `json:"contracts,omitempty"`
-	Equity            string                 `json:"equity,omitempty"`
-	Margin            string                 `json:"margin,omitempty"`
+	Currency          string                 `json:"currency,omitempty"`
+	Equity            float64                `json:"equity,string,omitempty"`
+	Margin            float64                `json:"margin,string,omitempty"`
+	MarginForUnfilled float64                `json:"margin_for_unfilled,string,omitempty"`
+	MarginFrozen      float64                `json:"margin_frozen,string,omitempty"`
 	MarginMode        string                 `json:"margin_mode,omitempty"`
-	MarginRatio       string                 `json:"margin_ratio,omitempty"`
-	RealizedPnl       string                 `json:"realized_pnl,omitempty"`
-	TotalAvailBalance string                 `json:"total_avail_balance,omitempty"`
-	UnrealizedPnl     string                 `json:"unrealized_pnl,omitempty"`
+	MarginRatio       float64                `json:"margin_ratio,string,omitempty"`
+	RealizedPnl       float64                `json:"realized_pnl,string,omitempty"`
+	TotalAvailBalance float64                `json:"total_avail_balance,string,omitempty"`
+	UnrealizedPnl     float64                `json:"unrealized_pnl,string,omitempty"`
 }
 
-// FuturesContractsData Futures details
+// FuturesContractsData Futures fixed margin details for a contract
 type FuturesContractsData struct {
-	AvailableQty      string `json:"available_qty"`
-	FixedBalance      string `json:"fixed_balance"`
-	InstrumentID      string `json:"instrument_id"`
-	MarginForUnfilled string `json:"margin_for_unfilled"`
-	MarginFrozen      string `json:"margin_frozen"`
-	RealizedPnl       string `json:"realized_pnl"`
-	UnrealizedPnl     string `json:"unrealized_pnl"`
+	AvailableQty      float64 `json:"available_qty,string"`
+	FixedBalance      float64 `json:"fixed_balance,string"`
+	InstrumentID      string  `json:"instrument_id"`
+	MarginForUnfilled float64 `json:"margin_for_unfilled,string"`
+	MarginFrozen      float64 `json:"margin_frozen,string"`
+	RealizedPnl       float64 `json:"realized_pnl,string"`
+	UnrealizedPnl     float64 `json:"unrealized_pnl,string"`
 }
 
 // GetFuturesLeverageResponse response data for GetFuturesLeverage
@@ -666,6 +795,7 @@ type GetFuturesTransactionDetailsResponse struct {
 
 // GetFuturesContractInformationResponse individual contract details from  GetFuturesContractInformation
 type GetFuturesContractInformationResponse struct {
+	Alias           string  `json:"alias"`
 	ContractVal     int64   `json:"contract_val,string"`
 	Delivery        string  `json:"delivery"`
 	InstrumentID    string  `json:"instrument_id"`
@@ -682,7 +812,7 @@ type GetFuturesOrderBookRequest struct {
 	Size         int64  `url:"size,omitempty"` // [optional] The size of the price range (max: 200)
 }
 
-// FuturesOrderbookItem stores an individual futures orderbook item
+// FuturesOrderbookItem stores an individual futures or swap orderbook item
 type FuturesOrderbookItem struct {
 	Price                 float64
 	Size                  int64
@@ -690,11 +820,32 @@ type FuturesOrderbookItem struct {
 	NumberOrders          int64 // Number of orders on the price
 }
 
+// UnmarshalJSON decodes an orderbook item from its array of price, size,
+// force liquidated orders and number of orders
+func (f *FuturesOrderbookItem) UnmarshalJSON(data []byte) error {
+	values, err := decodeArrayValues(data, 4)
+	if err != nil {
+		return fmt.Errorf("unexpected orderbook item %s: %s", data, err)
+	}
+	f.Price, err = strconv.ParseFloat(values[0], 64)
+	if err != nil {
+		return fmt.Errorf("unexpected orderbook item %s: %s", data, err)
+	}
+	fields := []*int64{&f.Size, &f.ForceLiquidatedOrders, &f.NumberOrders}
+	for x := range fields {
+		*fields[x], err = strconv.ParseInt(values[x+1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("unexpected orderbook item %s: %s", data, err)
+		}
+	}
+	return nil
+}
+
 // GetFuturesOrderBookResponse response data for GetFuturesOrderBook
 type GetFuturesOrderBookResponse struct {
-	Asks      []FuturesOrderbookItem
-	Bids      []FuturesOrderbookItem
-	Timestamp time.Time
+	Asks      []FuturesOrderbookItem `json:"asks"`
+	Bids      []FuturesOrderbookItem `json:"bids"`
+	Timestamp time.Time              `json:"timestamp"`
 }
 
 // GetFuturesTokenInfoResponse response data for GetFuturesOrderBook
@@ -735,15 +886,7 @@ type GetFuturesMarketDateRequest struct {
 }
 
 // GetFuturesMarketDataResponse contains candle data from a GetSpotMarketDataRequest
-// Return Parameters
-// time 			string 	Start time
-// open 			string 	Open price
-// high 			string 	Highest price
-// low 				string 	Lowest price
-// close 			string 	Close price
-// volume 			string 	Trading volume
-// currencyvolume 	string 	The trading volume in a specific token
-type GetFuturesMarketDataResponse []interface{}
+type GetFuturesMarketDataResponse []MarketDataCandle
 
 // GetFuturesHoldAmountResponse response data for GetFuturesHoldAmount
 type GetFuturesHoldAmountResponse struct {
@@ -1050,9 +1193,9 @@ type GetSwapOrderBookRequest struct {
 
 // GetSwapOrderBookResponse response data for GetSwapOrderBook
 type GetSwapOrderBookResponse struct {
-	Asks      [][]interface{} `json:"asks"` // eg [["411.3","16",5,4]] [[0: Price, 1: Size price, 2: number of force liquidated orders, 3: number of orders on the price]]
-	Bids      [][]interface{} `json:"bids"` // eg [["411.3","16",5,4]] [[0: Price, 1: Size price, 2: number of force liquidated orders, 3: number of orders on the price]]
-	Timestamp time.Time       `json:"timestamp"`
+	Asks      []FuturesOrderbookItem `json:"asks"` // eg [["411.3","16",5,4]]
+	Bids      []FuturesOrderbookItem `json:"bids"` // eg [["411.3","16",5,4]]
+	Timestamp time.Time              `json:"timestamp"`
 }
 
 // GetAllSwapTokensInformationResponse response data for GetAllSwapTokensInformation
@@ -1093,15 +1236,7 @@ type GetSwapMarketDataRequest struct {
 }
 
 // GetSwapMarketDataResponse response data for GetSwapMarketData
-// Return Parameters
-// time 			string 	Start time
-// open 			string 	Open price
-// high 			string 	Highest price
-// low 				string 	Lowest price
-// close 			string 	Close price
-// volume 			string 	Trading volume
-// currency_volume 	string 	Volume in a specific token
-type GetSwapMarketDataResponse []interface{}
+type GetSwapMarketDataResponse []MarketDataCandle
 
 // GetSwapIndecesResponse response data for GetSwapIndeces
 type GetSwapIndecesResponse struct {
@@ -1385,9 +1520,37 @@ type WebsocketPriceRangeResponse struct {
 
 // WebsocketOrderBooksData contains orderbook data from  WebsocketOrderBooksResponse
 type WebsocketOrderBooksData struct {
-	Asks     [][]interface{} `json:"asks,omitempty"` // [0] Price, [1] Size, [2] Number of orders
-	Bids     [][]interface{} `json:"bids,omitempty"` // [0] Price, [1] Size, [2] Number of orders
-	Checksum int32           `json:"checksum,omitempty"`
+	Asks     []WebsocketOrderbookItem `json:"asks,omitempty"`
+	Bids     []WebsocketOrderbookItem `json:"bids,omitempty"`
+	Checksum int32                    `json:"checksum,omitempty"`
+}
+
+// WebsocketOrderbookItem stores an individual websocket orderbook item. The
+// price and amount are also kept as sent as they make up the checksum
+type WebsocketOrderbookItem struct {
+	Price        float64
+	Amount       float64
+	PriceString  string
+	AmountString string
+}
+
+// UnmarshalJSON decodes a websocket orderbook item from its array of price,
+// size and number of orders
+func (w *WebsocketOrderbookItem) UnmarshalJSON(data []byte) error {
+	values, err := decodeArrayValues(data, 2)
+	if err != nil {
+		return fmt.Errorf("unexpected websocket orderbook item %s: %s", data, err)
+	}
+	w.Price, err = strconv.ParseFloat(values[0], 64)
+	if err != nil {
+		return fmt.Errorf("unexpected websocket orderbook item %s: %s", data, err)
+	}
+	w.Amount, err = strconv.ParseFloat(values[1], 64)
+	if err != nil {
+		return fmt.Errorf("unexpected websocket orderbook item %s: %s", data, err)
+	}
+	w.PriceString, w.AmountString = values[0], values[1]
+	return nil
 }
 
 // WebsocketUserSwapPositionResponse contains formatted data for user position data
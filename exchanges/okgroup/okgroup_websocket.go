@@ -9,7 +9,6 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -145,6 +144,16 @@ const (
 	okGroupWsFuturesOrder          = okGroupWsFuturesSubsection + okGroupWsOrder
 
 	okGroupWsRateLimit = 30 * time.Millisecond
+	// okGroupWsPingInterval keeps the connection alive, a connection without
+	// traffic for okGroupWsHeartbeatTimeout is reset
+	okGroupWsPingInterval     = time.Second * 27
+	okGroupWsHeartbeatTimeout = time.Minute
+
+	// The v3 websocket API has no liquidation channel, filled force
+	// liquidated orders are polled from the REST API instead
+	okGroupWsLiquidationPollDelay   = 10 * time.Second
+	okGroupLiquidationFilled        = "1"
+	okGroupLiquidationTypeCloseLong = 3
 )
 
 // orderbookMutex Ensures if two entries arrive at once, only one can be processed at a time
@@ -194,9 +203,12 @@ func (o *OKGroup) WsConnect() error {
 			o.Websocket.GetWebsocketURL())
 	}
 	wg := sync.WaitGroup{}
-	wg.Add(2)
+	wg.Add(1)
 	go o.WsHandleData(&wg)
-	go o.wsPingHandler(&wg)
+	if o.FuturesLiquidationsFetcher != nil {
+		wg.Add(1)
+		go o.wsLiquidationHandler(&wg)
+	}
 	o.GenerateDefaultSubscriptions()
 
 	// Ensures that we start the routines and we dont race when shutdown occurs
@@ -232,30 +244,112 @@ func (o *OKGroup) WsReadData() (exchange.WebsocketResponse, error) {
 	return exchange.WebsocketResponse{Raw: standardMessage}, nil
 }
 
-// wsPingHandler sends a message "ping" every 27 to maintain the connection to the websocket
-func (o *OKGroup) wsPingHandler(wg *sync.WaitGroup) {
+// wsHeartbeat returns the heartbeat keeping the websocket connection alive,
+// OKGroup replies to a "ping" message with "pong"
+func (o *OKGroup) wsHeartbeat() exchange.WebsocketHeartbeat {
+	return exchange.WebsocketHeartbeat{
+		Interval: okGroupWsPingInterval,
+		Timeout:  okGroupWsHeartbeatTimeout,
+		Payload:  []byte("ping"),
+		Send: func(payload []byte) error {
+			return o.writeToWebsocket(string(payload))
+		},
+		IsPong: func(raw []byte) bool {
+			return string(raw) == "pong"
+		},
+	}
+}
+
+// wsLiquidationHandler polls the filled force liquidated orders of enabled
+// futures pairs and sends new liquidations to the datahandler
+func (o *OKGroup) wsLiquidationHandler(wg *sync.WaitGroup) {
 	o.Websocket.Wg.Add(1)
 	defer o.Websocket.Wg.Done()
 
-	ticker := time.NewTicker(time.Second * 27)
-	defer ticker.Stop()
+	poll := time.NewTicker(okGroupWsLiquidationPollDelay)
+	defer poll.Stop()
 
 	wg.Done()
 
+	lastSeen := make(map[string]time.Time)
 	for {
 		select {
 		case <-o.Websocket.ShutdownC:
 			return
 
-		case <-ticker.C:
-			err := o.writeToWebsocket("ping")
-			if o.Verbose {
-				log.Debugf("%v sending ping", o.GetName())
-			}
+		case <-poll.C:
+			o.wsProcessLiquidations(lastSeen)
+		}
+	}
+}
+
+// wsProcessLiquidations converts liquidations newer than the last seen
+// liquidation of each contract and sends them to the datahandler. The first
+// poll of a contract only records its latest liquidation so history is not
+// reported
+func (o *OKGroup) wsProcessLiquidations(lastSeen map[string]time.Time) {
+	enabledCurrencies := o.GetEnabledCurrencies()
+	for i := range enabledCurrencies {
+		if !IsFuturesPair(enabledCurrencies[i]) {
+			continue
+		}
+
+		instrumentID, err := o.GetFuturesInstrumentID(enabledCurrencies[i])
+		if err != nil {
+			o.Websocket.DataHandler <- err
+			continue
+		}
+
+		contractValue, err := o.GetContractValue(instrumentID)
+		if err != nil {
+			o.Websocket.DataHandler <- err
+			continue
+		}
+
+		liquidations, err := o.FuturesLiquidationsFetcher(GetFuturesForceLiquidatedOrdersRequest{
+			InstrumentID: instrumentID,
+			Status:       okGroupLiquidationFilled,
+		})
+		if err != nil {
+			o.Websocket.DataHandler <- err
+			continue
+		}
+
+		since, polled := lastSeen[instrumentID]
+		latest := since
+		// Liquidations are returned newest first, report them in order
+		for j := len(liquidations) - 1; j >= 0; j-- {
+			createdAt, err := time.Parse(time.RFC3339, liquidations[j].CreatedAt)
 			if err != nil {
 				o.Websocket.DataHandler <- err
+				continue
+			}
+			if !createdAt.After(since) {
+				continue
+			}
+			if createdAt.After(latest) {
+				latest = createdAt
+			}
+			if !polled {
+				continue
+			}
+
+			side := exchange.BuyOrderSide
+			if liquidations[j].Type == okGroupLiquidationTypeCloseLong {
+				side = exchange.SellOrderSide
+			}
+			o.Websocket.DataHandler <- exchange.LiquidationEvent{
+				Timestamp: createdAt,
+				Pair:      enabledCurrencies[i],
+				AssetType: orderbook.Futures,
+				Exchange:  o.GetName(),
+				Side:      side.ToString(),
+				Price:     liquidations[j].Price,
+				Amount:    float64(liquidations[j].Size),
+				Value:     float64(liquidations[j].Size) * contractValue,
 			}
 		}
+		lastSeen[instrumentID] = latest
 	}
 }
 
@@ -279,6 +373,9 @@ func (o *OKGroup) WsHandleData(wg *sync.WaitGroup) {
 				time.Sleep(time.Second)
 				o.Websocket.DataHandler <- err
 			}
+			if o.Websocket.HandleHeartbeat(resp.Raw) {
+				continue
+			}
 			var dataResponse WebsocketDataResponse
 			err = common.JSONDecode(resp.Raw, &dataResponse)
 			if err == nil && dataResponse.Table != "" {
@@ -310,14 +407,14 @@ func (o *OKGroup) WsHandleData(wg *sync.WaitGroup) {
 
 // WsLogin sends a login request to websocket to enable access to authenticated endpoints
 func (o *OKGroup) WsLogin() error {
-	utcTime := time.Now().UTC()
+	utcTime := o.timeSync.Now().UTC()
 	unixTime := utcTime.Unix()
 	signPath := "/users/self/verify"
 	hmac := common.GetHMAC(common.HashSHA256, []byte(fmt.Sprintf("%v", unixTime)+http.MethodGet+signPath), []byte(o.APISecret))
 	base64 := common.Base64Encode(hmac)
 	resp := WebsocketEventRequest{
 		Operation: "login",
-		Arguments: []string{o.APIKey, o.ClientID, fmt.Sprintf("%v", unixTime), base64},
+		Arguments: []string{o.APIKey, o.APIPassphrase, fmt.Sprintf("%v", unixTime), base64},
 	}
 	json, err := common.JSONEncode(resp)
 	if err != nil {
@@ -497,13 +594,11 @@ func (o *OKGroup) WsProcessOrderBook(response *WebsocketDataResponse) (err error
 }
 
 // AppendWsOrderbookItems adds websocket orderbook data bid/asks into an orderbook item array
-func (o *OKGroup) AppendWsOrderbookItems(entries [][]interface{}) (orderbookItems []orderbook.Item) {
+func (o *OKGroup) AppendWsOrderbookItems(entries []WebsocketOrderbookItem) (orderbookItems []orderbook.Item) {
 	for j := range entries {
-		amount, _ := strconv.ParseFloat(entries[j][1].(string), 64)
-		price, _ := strconv.ParseFloat(entries[j][0].(string), 64)
 		orderbookItems = append(orderbookItems, orderbook.Item{
-			Amount: amount,
-			Price:  price,
+			Amount: entries[j].Amount,
+			Price:  entries[j].Price,
 		})
 	}
 	return
@@ -530,7 +625,7 @@ func (o *OKGroup) WsProcessPartialOrderBook(wsEventData *WebsocketDataWrapper, i
 		ExchangeName: o.GetName(),
 	}
 
-	err := o.Websocket.Orderbook.LoadSnapshot(&newOrderBook, o.GetName(), true)
+	err := o.Websocket.Orderbook.SyncSnapshot(&newOrderBook, 0)
 	if err != nil {
 		return err
 	}
@@ -542,60 +637,34 @@ func (o *OKGroup) WsProcessPartialOrderBook(wsEventData *WebsocketDataWrapper, i
 	return nil
 }
 
-// WsProcessUpdateOrderbook updates an existing orderbook using websocket data
-// After merging WS data, it will sort, validate and finally update the existing orderbook
+// WsProcessUpdateOrderbook applies websocket orderbook data to the local
+// orderbook, validating the checksum of the merged orderbook
 func (o *OKGroup) WsProcessUpdateOrderbook(wsEventData *WebsocketDataWrapper, instrument currency.Pair, tableName string) error {
-	internalOrderbook, err := o.GetOrderbookEx(instrument, o.GetAssetTypeFromTableName(tableName))
+	err := o.Websocket.Orderbook.ApplyDelta(&exchange.WebsocketOrderbookDelta{
+		Pair:        instrument,
+		AssetType:   o.GetAssetTypeFromTableName(tableName),
+		Bids:        o.AppendWsOrderbookItems(wsEventData.Bids),
+		Asks:        o.AppendWsOrderbookItems(wsEventData.Asks),
+		Checksum:    uint32(wsEventData.Checksum),
+		HasChecksum: true,
+		Updated:     wsEventData.Timestamp,
+	})
 	if err != nil {
-		return errors.New("orderbook nil, could not load existing orderbook")
+		return fmt.Errorf("channel: %v. Orderbook update for %v failed: %s", tableName, instrument, err)
 	}
-	if internalOrderbook.LastUpdated.After(wsEventData.Timestamp) {
-		if o.Verbose {
-			log.Errorf("Orderbook update out of order. Existing: %v, Attempted: %v", internalOrderbook.LastUpdated.Unix(), wsEventData.Timestamp.Unix())
-		}
-		return errors.New("updated orderbook is older than existing")
-	}
-	internalOrderbook.Asks = o.WsUpdateOrderbookEntry(wsEventData.Asks, internalOrderbook.Asks)
-	internalOrderbook.Bids = o.WsUpdateOrderbookEntry(wsEventData.Bids, internalOrderbook.Bids)
-	sort.Slice(internalOrderbook.Asks, func(i, j int) bool {
-		return internalOrderbook.Asks[i].Price < internalOrderbook.Asks[j].Price
-	})
-	sort.Slice(internalOrderbook.Bids, func(i, j int) bool {
-		return internalOrderbook.Bids[i].Price > internalOrderbook.Bids[j].Price
-	})
-	checksum := o.CalculateUpdateOrderbookChecksum(&internalOrderbook)
-	if checksum == wsEventData.Checksum {
-		if o.Verbose {
-			log.Debug("Orderbook valid")
-		}
-		internalOrderbook.LastUpdated = wsEventData.Timestamp
-		if o.Verbose {
-			log.Debug("Internalising orderbook")
-		}
-
-		err := o.Websocket.Orderbook.LoadSnapshot(&internalOrderbook, o.GetName(), true)
-		if err != nil {
-			log.Error(err)
-		}
-		o.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
-			Exchange: o.GetName(),
-			Asset:    o.GetAssetTypeFromTableName(tableName),
-			Pair:     instrument,
-		}
-	} else {
-		if o.Verbose {
-			log.Debug("Orderbook invalid")
-		}
-		return fmt.Errorf("channel: %v. Orderbook update for %v checksum invalid. Received %v Calculated %v", tableName, instrument, wsEventData.Checksum, checksum)
+	o.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
+		Exchange: o.GetName(),
+		Asset:    o.GetAssetTypeFromTableName(tableName),
+		Pair:     instrument,
 	}
 	return nil
 }
 
 // WsUpdateOrderbookEntry takes WS bid or ask data and merges it with existing orderbook bid or ask data
-func (o *OKGroup) WsUpdateOrderbookEntry(wsEntries [][]interface{}, existingOrderbookEntries []orderbook.Item) []orderbook.Item {
+func (o *OKGroup) WsUpdateOrderbookEntry(wsEntries []WebsocketOrderbookItem, existingOrderbookEntries []orderbook.Item) []orderbook.Item {
 	for j := range wsEntries {
-		wsEntryPrice, _ := strconv.ParseFloat(wsEntries[j][0].(string), 64)
-		wsEntryAmount, _ := strconv.ParseFloat(wsEntries[j][1].(string), 64)
+		wsEntryPrice := wsEntries[j].Price
+		wsEntryAmount := wsEntries[j].Amount
 		matchFound := false
 		for k := 0; k < len(existingOrderbookEntries); k++ {
 			if existingOrderbookEntries[k].Price != wsEntryPrice {
@@ -631,10 +700,10 @@ func (o *OKGroup) CalculatePartialOrderbookChecksum(orderbookData *WebsocketData
 		bidsMessage := ""
 		askMessage := ""
 		if len(orderbookData.Bids)-1 >= i {
-			bidsMessage = fmt.Sprintf("%v:%v:", orderbookData.Bids[i][0], orderbookData.Bids[i][1])
+			bidsMessage = fmt.Sprintf("%v:%v:", orderbookData.Bids[i].PriceString, orderbookData.Bids[i].AmountString)
 		}
 		if len(orderbookData.Asks)-1 >= i {
-			askMessage = fmt.Sprintf("%v:%v:", orderbookData.Asks[i][0], orderbookData.Asks[i][1])
+			askMessage = fmt.Sprintf("%v:%v:", orderbookData.Asks[i].PriceString, orderbookData.Asks[i].AmountString)
 
 		}
 		if checksum == "" {
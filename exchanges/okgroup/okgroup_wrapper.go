@@ -5,10 +5,12 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -35,22 +37,27 @@ func (o *OKGroup) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", o.GetName(), len(o.EnabledPairs), o.EnabledPairs)
 	}
 
-	prods, err := o.GetSpotTokenPairDetails()
+	err := o.UpdateTradablePairs(false)
 	if err != nil {
-		log.Errorf("%v failed to obtain available spot instruments. Err: %s", o.Name, err)
-		return
+		log.Errorf("%s failed to update tradable pairs. Err: %s", o.Name, err)
+	}
+}
+
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (o *OKGroup) UpdateTradablePairs(forceUpdate bool) error {
+	prods, err := o.UpdateInstruments()
+	if err != nil {
+		return err
 	}
 
 	var pairs currency.Pairs
 	for x := range prods {
 		pairs = append(pairs, currency.NewPairFromString(prods[x].BaseCurrency+"_"+prods[x].QuoteCurrency))
 	}
+	pairs = append(pairs, o.GetFuturesPairs()...)
 
-	err = o.UpdateCurrencies(pairs, false, false)
-	if err != nil {
-		log.Errorf("%v failed to update available currencies. Err: %s", o.Name, err)
-		return
-	}
+	return o.UpdateCurrencies(pairs, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -147,6 +154,10 @@ func (o *OKGroup) UpdateOrderbook(p currency.Pair, assetType string) (resp order
 func (o *OKGroup) GetAccountInfo() (resp exchange.AccountInfo, err error) {
 	resp.Exchange = o.Name
 	currencies, err := o.GetSpotTradingAccounts()
+	if err != nil {
+		return
+	}
+
 	currencyAccount := exchange.Account{}
 
 	for _, curr := range currencies {
@@ -158,11 +169,9 @@ func (o *OKGroup) GetAccountInfo() (resp exchange.AccountInfo, err error) {
 		if err != nil {
 			log.Errorf("Could not convert %v to float64", curr.Balance)
 		}
-		currencyAccount.Currencies = append(currencyAccount.Currencies, exchange.AccountCurrencyInfo{
-			CurrencyName: currency.NewCode(curr.Currency),
-			Hold:         hold,
-			TotalValue:   totalValue,
-		})
+		currencyAccount.Currencies = append(currencyAccount.Currencies,
+			exchange.NewAccountCurrencyInfoFromTotal(currency.NewCode(curr.Currency),
+				totalValue, hold))
 	}
 
 	resp.Accounts = append(resp.Accounts, currencyAccount)
@@ -199,9 +208,13 @@ func (o *OKGroup) GetFundingHistory() (resp []exchange.FundHistory, err error) {
 	}
 	accountWithdrawlHistory, err := o.GetAccountWithdrawalHistory("")
 	for i := range accountWithdrawlHistory {
+		// The fee is reported alongside its currency, e.g. "0.0005BTC"
+		fee, _ := strconv.ParseFloat(strings.TrimRight(accountWithdrawlHistory[i].Fee,
+			"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"), 64)
 		resp = append(resp, exchange.FundHistory{
 			Amount:       accountWithdrawlHistory[i].Amount,
 			Currency:     accountWithdrawlHistory[i].Currency,
+			Fee:          fee,
 			ExchangeName: o.Name,
 			Status:       OrderStatus[accountWithdrawlHistory[i].Status],
 			Timestamp:    accountWithdrawlHistory[i].Timestamp,
@@ -217,8 +230,60 @@ func (o *OKGroup) GetExchangeHistory(p currency.Pair, assetType string) ([]excha
 	return nil, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive,
+// requesting windows of okGroupCandlesLimit candles
+func (o *OKGroup) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	granularity := int64(interval / time.Second)
+	supported := false
+	for x := range klineGranularities {
+		if klineGranularities[x] == granularity {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, fmt.Errorf("%s unsupported kline interval %s", o.Name, interval)
+	}
+
+	var candles []kline.Candle
+	for windowStart := start; !windowStart.After(end); windowStart = windowStart.Add(interval * okGroupCandlesLimit) {
+		windowEnd := windowStart.Add(interval * (okGroupCandlesLimit - 1))
+		if windowEnd.After(end) {
+			windowEnd = end
+		}
+		resp, err := o.GetSpotMarketData(GetSpotMarketDataRequest{
+			Start:        windowStart.UTC().Format(time.RFC3339),
+			End:          windowEnd.UTC().Format(time.RFC3339),
+			Granularity:  granularity,
+			InstrumentID: exchange.FormatExchangeCurrency(o.Name, p).String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for x := range resp {
+			candles = append(candles, kline.Candle{
+				Time:   resp[x].Time,
+				Open:   resp[x].Open,
+				High:   resp[x].High,
+				Low:    resp[x].Low,
+				Close:  resp[x].Close,
+				Volume: resp[x].Volume,
+			})
+		}
+	}
+	return kline.FilterCandles(candles, start, end), nil
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (o *OKGroup) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // SubmitOrder submits a new order
 func (o *OKGroup) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (resp exchange.SubmitOrderResponse, err error) {
+	if o.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	request := PlaceSpotOrderRequest{
 		ClientOID:    clientID,
 		InstrumentID: exchange.FormatExchangeCurrency(o.Name, p).String(),
@@ -243,11 +308,17 @@ func (o *OKGroup) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderTyp
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (o *OKGroup) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if o.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (o *OKGroup) CancelOrder(orderCancellation *exchange.OrderCancellation) (err error) {
+	if o.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderID, err := strconv.ParseInt(orderCancellation.OrderID, 10, 64)
 	if err != nil {
 		return
@@ -265,6 +336,9 @@ func (o *OKGroup) CancelOrder(orderCancellation *exchange.OrderCancellation) (er
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (o *OKGroup) CancelAllOrders(orderCancellation *exchange.OrderCancellation) (resp exchange.CancelAllOrdersResponse, _ error) {
+	if o.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
+	}
 	orderIDs := strings.Split(orderCancellation.OrderID, ",")
 	var orderIDNumbers []int64
 	for _, i := range orderIDs {
@@ -285,7 +359,7 @@ func (o *OKGroup) CancelAllOrders(orderCancellation *exchange.OrderCancellation)
 
 	for _, orderMap := range cancelOrdersResponse {
 		for _, cancelledOrder := range orderMap {
-			resp.OrderStatus[fmt.Sprintf("%v", cancelledOrder.OrderID)] = fmt.Sprintf("%v", cancelledOrder.Result)
+			resp.Add(strconv.FormatInt(cancelledOrder.OrderID, 10), cancelledOrder.Error)
 		}
 	}
 
@@ -308,6 +382,7 @@ func (o *OKGroup) GetOrderInfo(orderID string) (resp exchange.OrderDetail, err e
 		Status:         order.Status,
 		OrderSide:      exchange.OrderSide(order.Side),
 	}
+	resp.SetFillAmounts()
 	return
 }
 
@@ -323,6 +398,9 @@ func (o *OKGroup) GetDepositAddress(p currency.Code, accountID string) (_ string
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (o *OKGroup) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if o.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	withdrawal, err := o.AccountWithdraw(AccountWithdrawRequest{
 		Amount:      withdrawRequest.Amount,
 		Currency:    withdrawRequest.Currency.Lower().String(),
@@ -344,12 +422,18 @@ func (o *OKGroup) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.Withdraw
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (o *OKGroup) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if o.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (o *OKGroup) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if o.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -378,6 +462,7 @@ func (o *OKGroup) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) (
 		}
 	}
 
+	exchange.SetOrderFillAmounts(resp)
 	return
 }
 
@@ -408,6 +493,7 @@ func (o *OKGroup) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) (
 		}
 	}
 
+	exchange.SetOrderFillAmounts(resp)
 	return
 }
 
@@ -425,6 +511,39 @@ func (o *OKGroup) GetFeeByType(feeBuilder *exchange.FeeBuilder) (float64, error)
 	return o.GetFee(feeBuilder)
 }
 
+// GetWithdrawalFee returns the minimum network fee of withdrawing a
+// cryptocurrency
+func (o *OKGroup) GetWithdrawalFee(c currency.Code) (float64, error) {
+	fees, err := o.GetAccountWithdrawalFee(c.Upper().String())
+	if err != nil {
+		return 0, err
+	}
+	for x := range fees {
+		if strings.EqualFold(fees[x].Currency, c.String()) {
+			return fees[x].MinFee, nil
+		}
+	}
+	return 0, fmt.Errorf("no withdrawal fee reported for %s", c)
+}
+
+// GetDepositMethods returns whether a cryptocurrency can currently be
+// deposited
+func (o *OKGroup) GetDepositMethods(c currency.Code) ([]exchange.DepositMethod, error) {
+	currencies, err := o.GetAccountCurrencies()
+	if err != nil {
+		return nil, err
+	}
+	for x := range currencies {
+		if strings.EqualFold(currencies[x].Currency, c.String()) {
+			return []exchange.DepositMethod{{
+				Method:  currencies[x].Name,
+				Enabled: currencies[x].CanDeposit == 1,
+			}}, nil
+		}
+	}
+	return nil, fmt.Errorf("currency %s not supported", c)
+}
+
 // GetWithdrawCapabilities returns the types of withdrawal methods permitted by the exchange
 func (o *OKGroup) GetWithdrawCapabilities() uint32 {
 	return o.GetWithdrawPermissions()
@@ -14,7 +14,8 @@ const (
 	ErrPrimaryCurrencyNotFound      = "primary currency for orderbook not found"
 	ErrSecondaryCurrencyNotFound    = "secondary currency for orderbook not found"
 
-	Spot = "SPOT"
+	Spot    = "SPOT"
+	Futures = "FUTURES"
 )
 
 // Vars for the orderbook package
@@ -38,6 +39,7 @@ type Base struct {
 	LastUpdated  time.Time     `json:"lastUpdated"`
 	AssetType    string        `json:"assetType"`
 	ExchangeName string        `json:"exchangeName"`
+	Stale        bool          `json:"stale"`
 }
 
 // Orderbook holds the orderbook information for a currency pair and type
@@ -92,6 +94,25 @@ func Get(exchange string, p currency.Pair, orderbookType string) (Base, error) {
 	return orderbook.Orderbook[p.Base.Item][p.Quote.Item][orderbookType], nil
 }
 
+// SetStale marks a stored orderbook as stale, used when its source can no
+// longer be updated. The flag is cleared when the orderbook is next processed
+func SetStale(exchange string, p currency.Pair, orderbookType string) error {
+	orderbook, err := GetByExchange(exchange)
+	if err != nil {
+		return err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	ob, ok := orderbook.Orderbook[p.Base.Item][p.Quote.Item][orderbookType]
+	if !ok {
+		return errors.New(ErrSecondaryCurrencyNotFound)
+	}
+	ob.Stale = true
+	orderbook.Orderbook[p.Base.Item][p.Quote.Item][orderbookType] = ob
+	return nil
+}
+
 // GetByExchange returns an exchange orderbook
 func GetByExchange(exchange string) (*Orderbook, error) {
 	m.Lock()
@@ -166,6 +187,7 @@ func (o *Base) Process() error {
 	if o.LastUpdated.IsZero() {
 		o.LastUpdated = time.Now()
 	}
+	o.Stale = false
 
 	orderbook, err := GetByExchange(o.ExchangeName)
 	if err != nil {
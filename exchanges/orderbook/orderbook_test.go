@@ -108,6 +108,50 @@ func TestGetOrderbook(t *testing.T) {
 	}
 }
 
+func TestSetStale(t *testing.T) {
+	c := currency.NewPairFromStrings("BTC", "USD")
+	base := Base{
+		Pair:         c,
+		AssetType:    Spot,
+		ExchangeName: "StaleExchange",
+		Asks:         []Item{{Price: 100, Amount: 10}},
+	}
+	err := base.Process()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = SetStale("StaleExchange", c, Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Get("StaleExchange", c, Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Stale {
+		t.Error("Test failed. TestSetStale expected orderbook to be stale")
+	}
+
+	err = result.Process()
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = Get("StaleExchange", c, Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Stale {
+		t.Error("Test failed. TestSetStale expected processed orderbook to be fresh")
+	}
+
+	err = SetStale("StaleExchange", c, "FUTURES")
+	if err == nil {
+		t.Error("Test failed. TestSetStale expected error for non-existent orderbook")
+	}
+}
+
 func TestGetOrderbookByExchange(t *testing.T) {
 	currency := currency.NewPairFromStrings("BTC", "USD")
 	base := Base{
@@ -57,6 +57,11 @@ const (
 	poloniexUnauthRate = 6
 
 	poloniexDateLayout = "2006-01-02 15:04:05"
+
+	// poloniexTradeHistoryLimit is the most trades returned by the trade
+	// history endpoint, which only accepts ranges up to a month long
+	poloniexTradeHistoryLimit = 1000
+	poloniexTradeHistoryRange = time.Hour * 24 * 30
 )
 
 // Poloniex is the overarching type across the poloniex package
@@ -66,6 +71,17 @@ type Poloniex struct {
 	wsRequestMtx  sync.Mutex
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "Poloniex",
+		Creator: func() exchange.IBotExchange {
+			return new(Poloniex)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability,
+	})
+}
+
 // SetDefaults sets default settings for poloniex
 func (p *Poloniex) SetDefaults() {
 	p.Name = "Poloniex"
@@ -82,6 +98,8 @@ func (p *Poloniex) SetDefaults() {
 	p.AssetTypes = []string{ticker.Spot}
 	p.SupportsAutoPairUpdating = true
 	p.SupportsRESTTickerBatching = true
+	p.SupportsKlines = true
+	p.SupportsTradeHistory = true
 	p.Requester = request.New(p.Name,
 		request.NewRateLimit(time.Second, poloniexAuthRate),
 		request.NewRateLimit(time.Second, poloniexUnauthRate),
@@ -106,8 +124,10 @@ func (p *Poloniex) Setup(exch *config.ExchangeConfig) {
 		p.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		p.SetHTTPClientTimeout(exch.HTTPTimeout)
 		p.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		p.SetHTTPHeaders(exch.HTTPHeaders)
 		p.RESTPollingDelay = exch.RESTPollingDelay
 		p.Verbose = exch.Verbose
+		p.ReadOnly = exch.ReadOnly
 		p.HTTPDebugging = exch.HTTPDebugging
 		p.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		p.BaseCurrencies = exch.BaseCurrencies
@@ -880,8 +900,7 @@ func (p *Poloniex) SendHTTPRequest(path string, result interface{}) error {
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request
 func (p *Poloniex) SendAuthenticatedHTTPRequest(method, endpoint string, values url.Values, result interface{}) error {
 	if !p.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet,
-			p.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 	headers := make(map[string]string)
 	headers["Content-Type"] = "application/x-www-form-urlencoded"
@@ -948,7 +967,7 @@ func calculateTradingFee(feeInfo Fee, purchasePrice, amount float64, isMaker boo
 	} else {
 		fee = feeInfo.TakerFee
 	}
-	return fee * amount * purchasePrice
+	return exchange.CalculateTradingFee(fee, purchasePrice, amount)
 }
 
 func getWithdrawalFee(c currency.Code) float64 {
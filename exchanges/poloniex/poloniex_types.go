@@ -74,6 +74,10 @@ type ChartData struct {
 	Error           string  `json:"error"`
 }
 
+// klinePeriods are the candle durations in seconds the chart data endpoint
+// accepts
+var klinePeriods = []int64{300, 900, 1800, 7200, 14400, 86400}
+
 // Currencies contains currency information
 type Currencies struct {
 	ID                 int         `json:"id"`
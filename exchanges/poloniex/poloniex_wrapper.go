@@ -10,6 +10,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -32,28 +33,34 @@ func (p *Poloniex) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", p.GetName(), len(p.EnabledPairs), p.EnabledPairs)
 	}
 
-	exchangeCurrencies, err := p.GetExchangeCurrencies()
+	forceUpdate := false
+	if common.StringDataCompare(p.AvailablePairs.Strings(), "BTC_USDT") {
+		log.Warnf("%s contains invalid pair, forcing upgrade of available currencies.\n",
+			p.GetName())
+		forceUpdate = true
+	}
+
+	err := p.UpdateTradablePairs(forceUpdate)
 	if err != nil {
-		log.Errorf("%s Failed to get available symbols.\n", p.GetName())
-	} else {
-		forceUpdate := false
-		if common.StringDataCompare(p.AvailablePairs.Strings(), "BTC_USDT") {
-			log.Warnf("%s contains invalid pair, forcing upgrade of available currencies.\n",
-				p.GetName())
-			forceUpdate = true
-		}
+		log.Errorf("%s failed to update tradable pairs. Err: %s", p.Name, err)
+	}
+}
 
-		var newExchangeCurrencies currency.Pairs
-		for _, p := range exchangeCurrencies {
-			newExchangeCurrencies = append(newExchangeCurrencies,
-				currency.NewPairFromString(p))
-		}
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (p *Poloniex) UpdateTradablePairs(forceUpdate bool) error {
+	exchangeCurrencies, err := p.GetExchangeCurrencies()
+	if err != nil {
+		return err
+	}
 
-		err = p.UpdateCurrencies(newExchangeCurrencies, false, forceUpdate)
-		if err != nil {
-			log.Errorf("%s Failed to update available currencies %s.\n", p.GetName(), err)
-		}
+	var newExchangeCurrencies currency.Pairs
+	for x := range exchangeCurrencies {
+		newExchangeCurrencies = append(newExchangeCurrencies,
+			currency.NewPairFromString(exchangeCurrencies[x]))
 	}
+
+	return p.UpdateCurrencies(newExchangeCurrencies, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -156,10 +163,9 @@ func (p *Poloniex) GetAccountInfo() (exchange.AccountInfo, error) {
 
 	var currencies []exchange.AccountCurrencyInfo
 	for x, y := range accountBalance.Currency {
-		var exchangeCurrency exchange.AccountCurrencyInfo
-		exchangeCurrency.CurrencyName = currency.NewCode(x)
-		exchangeCurrency.TotalValue = y
-		currencies = append(currencies, exchangeCurrency)
+		// Poloniex balances exclude funds in open orders
+		currencies = append(currencies,
+			exchange.NewAccountCurrencyInfo(currency.NewCode(x), y, 0))
 	}
 
 	response.Accounts = append(response.Accounts, exchange.Account{
@@ -183,8 +189,92 @@ func (p *Poloniex) GetExchangeHistory(currencyPair currency.Pair, assetType stri
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive
+func (p *Poloniex) GetHistoricCandles(currencyPair currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	supported := false
+	for x := range klinePeriods {
+		if time.Duration(klinePeriods[x])*time.Second == interval {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, fmt.Errorf("%s unsupported kline interval %s", p.Name, interval)
+	}
+
+	resp, err := p.GetChartData(exchange.FormatExchangeCurrency(p.Name, currencyPair).String(),
+		strconv.FormatInt(start.Unix(), 10),
+		strconv.FormatInt(end.Unix(), 10),
+		strconv.FormatInt(int64(interval/time.Second), 10))
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]kline.Candle, len(resp))
+	for x := range resp {
+		// Volume is in the quote currency of the chart data, the currency
+		// being traded
+		candles[x] = kline.Candle{
+			Time:   time.Unix(int64(resp[x].Date), 0),
+			Open:   resp[x].Open,
+			High:   resp[x].High,
+			Low:    resp[x].Low,
+			Close:  resp[x].Close,
+			Volume: resp[x].QuoteVolume,
+		}
+	}
+	// Ranges without candles return a single empty candle
+	return kline.FilterCandles(candles, start, end), nil
+}
+
+// GetHistoricTrades returns trades between start and end inclusive, oldest
+// first. The trade history endpoint returns the newest trades of a range, so
+// the range is halved until all of its trades are returned
+func (p *Poloniex) GetHistoricTrades(currencyPair currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	symbol := exchange.FormatExchangeCurrency(p.Name, currencyPair).String()
+	rangeEnd := end
+	for {
+		if rangeEnd.Sub(start) > poloniexTradeHistoryRange {
+			rangeEnd = start.Add(poloniexTradeHistoryRange)
+		}
+		resp, err := p.GetTradeHistory(symbol, strconv.FormatInt(start.Unix(), 10),
+			strconv.FormatInt(rangeEnd.Unix(), 10))
+		if err != nil {
+			return nil, err
+		}
+		if len(resp) >= poloniexTradeHistoryLimit && rangeEnd.Sub(start) > time.Second {
+			rangeEnd = start.Add(rangeEnd.Sub(start) / 2)
+			continue
+		}
+		if len(resp) == 0 && rangeEnd.Before(end) {
+			start, rangeEnd = rangeEnd, end
+			continue
+		}
+
+		trades := make([]exchange.TradeHistory, 0, len(resp))
+		for x := len(resp) - 1; x >= 0; x-- {
+			tradeDate, err := time.Parse(poloniexDateLayout, resp[x].Date)
+			if err != nil {
+				return nil, err
+			}
+			trades = append(trades, exchange.TradeHistory{
+				Timestamp: tradeDate,
+				TID:       resp[x].GlobalTradeID,
+				Price:     resp[x].Rate,
+				Amount:    resp[x].Amount,
+				Exchange:  p.Name,
+				Type:      strings.ToUpper(resp[x].Type),
+			})
+		}
+		return trades, nil
+	}
+}
+
 // SubmitOrder submits a new order
 func (p *Poloniex) SubmitOrder(currencyPair currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if p.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	fillOrKill := orderType == exchange.MarketOrderType
 	isBuyOrder := side == exchange.BuyOrderSide
@@ -210,6 +300,9 @@ func (p *Poloniex) SubmitOrder(currencyPair currency.Pair, side exchange.OrderSi
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (p *Poloniex) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if p.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	oID, err := strconv.ParseInt(action.OrderID, 10, 64)
 	if err != nil {
 		return "", err
@@ -229,6 +322,9 @@ func (p *Poloniex) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
 
 // CancelOrder cancels an order by its corresponding ID number
 func (p *Poloniex) CancelOrder(order *exchange.OrderCancellation) error {
+	if p.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -242,9 +338,10 @@ func (p *Poloniex) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (p *Poloniex) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if p.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	openOrders, err := p.GetOpenOrdersForAllCurrencies()
 	if err != nil {
 		return cancelAllOrdersResponse, err
@@ -253,9 +350,7 @@ func (p *Poloniex) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.Canc
 	for _, openOrderPerCurrency := range openOrders.Data {
 		for _, openOrder := range openOrderPerCurrency {
 			_, err = p.CancelExistingOrder(openOrder.OrderNumber)
-			if err != nil {
-				cancelAllOrdersResponse.OrderStatus[strconv.FormatInt(openOrder.OrderNumber, 10)] = err.Error()
-			}
+			cancelAllOrdersResponse.Add(strconv.FormatInt(openOrder.OrderNumber, 10), err)
 		}
 	}
 
@@ -284,9 +379,42 @@ func (p *Poloniex) GetDepositAddress(cryptocurrency currency.Code, _ string) (st
 	return address, nil
 }
 
+// GetWithdrawalFee returns the current withdrawal fee of a cryptocurrency
+func (p *Poloniex) GetWithdrawalFee(c currency.Code) (float64, error) {
+	currencies, err := p.GetCurrencies()
+	if err != nil {
+		return 0, err
+	}
+	info, ok := currencies[c.Upper().String()]
+	if !ok {
+		return 0, fmt.Errorf("currency %s not supported", c)
+	}
+	return info.TxFee, nil
+}
+
+// GetDepositMethods returns whether a cryptocurrency can currently be
+// deposited, deposits are closed for disabled, delisted and frozen currencies
+func (p *Poloniex) GetDepositMethods(c currency.Code) ([]exchange.DepositMethod, error) {
+	currencies, err := p.GetCurrencies()
+	if err != nil {
+		return nil, err
+	}
+	info, ok := currencies[c.Upper().String()]
+	if !ok {
+		return nil, fmt.Errorf("currency %s not supported", c)
+	}
+	return []exchange.DepositMethod{{
+		Method:  info.Name,
+		Enabled: info.Disabled == 0 && info.Delisted == 0 && info.Frozen == 0,
+	}}, nil
+}
+
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (p *Poloniex) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if p.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	_, err := p.Withdraw(withdrawRequest.Currency.String(), withdrawRequest.Address, withdrawRequest.Amount)
 	return "", err
 }
@@ -294,12 +422,18 @@ func (p *Poloniex) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.Withdra
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (p *Poloniex) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if p.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (p *Poloniex) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if p.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -349,6 +483,7 @@ func (p *Poloniex) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest)
 		}
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
 	exchange.FilterOrdersByCurrencies(&orders, getOrdersRequest.Currencies)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
@@ -391,6 +526,7 @@ func (p *Poloniex) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest)
 		}
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByCurrencies(&orders, getOrdersRequest.Currencies)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
 
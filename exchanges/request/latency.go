@@ -0,0 +1,223 @@
+package request
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// DefaultLatencyWindow is how long request latencies are kept for the endpoint
+// latency percentiles
+const DefaultLatencyWindow = time.Minute * 15
+
+// latencyBuckets are the upper bounds of the latency histogram buckets,
+// requests slower than the last bound are counted in an overflow bucket
+var latencyBuckets = []time.Duration{
+	time.Millisecond * 10, time.Millisecond * 25, time.Millisecond * 50,
+	time.Millisecond * 75, time.Millisecond * 100, time.Millisecond * 150,
+	time.Millisecond * 200, time.Millisecond * 300, time.Millisecond * 500,
+	time.Millisecond * 750, time.Second, time.Millisecond * 1500,
+	time.Second * 2, time.Second * 3, time.Second * 5, time.Second * 10,
+	time.Second * 30,
+}
+
+// EndpointLatency holds the latency percentiles of the requests to an
+// exchange endpoint over the last one to two latency windows
+type EndpointLatency struct {
+	Exchange string        `json:"exchange"`
+	Method   string        `json:"method"`
+	Endpoint string        `json:"endpoint"`
+	Requests int64         `json:"requests"`
+	P50      time.Duration `json:"p50"`
+	P95      time.Duration `json:"p95"`
+	P99      time.Duration `json:"p99"`
+	Max      time.Duration `json:"max"`
+}
+
+// latencyHistogram counts request latencies into the latency buckets from a
+// start time
+type latencyHistogram struct {
+	start  time.Time
+	counts []int64
+	max    time.Duration
+}
+
+// endpointLatencies holds the current and previous window histograms of an
+// endpoint, so percentiles never cover less than a whole window
+type endpointLatencies struct {
+	exchange string
+	method   string
+	endpoint string
+	current  latencyHistogram
+	previous latencyHistogram
+}
+
+var latencies = struct {
+	window    time.Duration
+	endpoints map[string]*endpointLatencies
+	m         sync.Mutex
+}{
+	window:    DefaultLatencyWindow,
+	endpoints: make(map[string]*endpointLatencies),
+}
+
+// SetLatencyWindow sets how long request latencies are kept, clearing those
+// recorded so far
+func SetLatencyWindow(window time.Duration) {
+	if window <= 0 {
+		window = DefaultLatencyWindow
+	}
+	latencies.m.Lock()
+	latencies.window = window
+	latencies.endpoints = make(map[string]*endpointLatencies)
+	latencies.m.Unlock()
+}
+
+// isIDSegment returns whether a path segment looks like an order, account or
+// other identifier rather than part of the endpoint, such as a number or a
+// long string containing digits. Short versions like v1 are kept
+func isIDSegment(segment string) bool {
+	var digits int
+	for _, r := range segment {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	return digits > 0 && (digits == len(segment) || len(segment) > 8)
+}
+
+// LatencyEndpoint returns the endpoint a request URL is recorded under, its
+// path without the query and with identifier segments replaced by :id so
+// requests for different orders share an endpoint
+func LatencyEndpoint(path string) string {
+	segments := strings.Split(endpointPath(path), "/")
+	for x := range segments {
+		if isIDSegment(segments[x]) {
+			segments[x] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// rotate starts a new window once the current one has passed, keeping it as
+// the previous window unless it ended over a window ago
+func (e *endpointLatencies) rotate(window time.Duration, now time.Time) {
+	if now.Sub(e.current.start) < window {
+		return
+	}
+	e.previous = latencyHistogram{}
+	if now.Sub(e.current.start) < window*2 {
+		e.previous = e.current
+	}
+	e.current = latencyHistogram{
+		start:  now,
+		counts: make([]int64, len(latencyBuckets)+1),
+	}
+}
+
+// RecordLatency records how long a request to an exchange endpoint took
+func RecordLatency(exchName, method, path string, latency time.Duration, now time.Time) {
+	endpoint := LatencyEndpoint(path)
+	key := exchName + " " + method + " " + endpoint
+	bucket := sort.Search(len(latencyBuckets), func(i int) bool {
+		return latency <= latencyBuckets[i]
+	})
+
+	latencies.m.Lock()
+	defer latencies.m.Unlock()
+	e, ok := latencies.endpoints[key]
+	if !ok {
+		e = &endpointLatencies{
+			exchange: exchName,
+			method:   method,
+			endpoint: endpoint,
+		}
+		latencies.endpoints[key] = e
+	}
+	e.rotate(latencies.window, now)
+	e.current.counts[bucket]++
+	if latency > e.current.max {
+		e.current.max = latency
+	}
+}
+
+// getPercentile returns the latency at a percentile of the counted requests,
+// interpolated within its bucket and capped at the slowest request
+func getPercentile(counts []int64, total int64, percentile float64, max time.Duration) time.Duration {
+	rank := percentile / 100 * float64(total)
+	var cumulative int64
+	for x := range counts {
+		if counts[x] == 0 || float64(cumulative+counts[x]) < rank {
+			cumulative += counts[x]
+			continue
+		}
+		if x == len(latencyBuckets) {
+			return max
+		}
+		var lower time.Duration
+		if x > 0 {
+			lower = latencyBuckets[x-1]
+		}
+		fraction := (rank - float64(cumulative)) / float64(counts[x])
+		latency := lower + time.Duration(fraction*float64(latencyBuckets[x]-lower))
+		if latency > max {
+			return max
+		}
+		return latency
+	}
+	return max
+}
+
+// GetLatencies returns the latency percentiles of every endpoint requested
+// within the last two latency windows, sorted by exchange and endpoint
+func GetLatencies(now time.Time) []EndpointLatency {
+	latencies.m.Lock()
+	defer latencies.m.Unlock()
+
+	var resp []EndpointLatency
+	for key, e := range latencies.endpoints {
+		e.rotate(latencies.window, now)
+		counts := make([]int64, len(latencyBuckets)+1)
+		var total int64
+		for x := range counts {
+			if e.current.counts != nil {
+				counts[x] += e.current.counts[x]
+			}
+			if e.previous.counts != nil {
+				counts[x] += e.previous.counts[x]
+			}
+			total += counts[x]
+		}
+		if total == 0 {
+			delete(latencies.endpoints, key)
+			continue
+		}
+
+		max := e.current.max
+		if e.previous.max > max {
+			max = e.previous.max
+		}
+		resp = append(resp, EndpointLatency{
+			Exchange: e.exchange,
+			Method:   e.method,
+			Endpoint: e.endpoint,
+			Requests: total,
+			P50:      getPercentile(counts, total, 50, max),
+			P95:      getPercentile(counts, total, 95, max),
+			P99:      getPercentile(counts, total, 99, max),
+			Max:      max,
+		})
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].Exchange != resp[j].Exchange {
+			return resp[i].Exchange < resp[j].Exchange
+		}
+		if resp[i].Endpoint != resp[j].Endpoint {
+			return resp[i].Endpoint < resp[j].Endpoint
+		}
+		return resp[i].Method < resp[j].Method
+	})
+	return resp
+}
@@ -10,10 +10,13 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/thrasher-/gocryptotrader/clock"
 	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/exchanges/faults"
 	"github.com/thrasher-/gocryptotrader/exchanges/nonce"
 	log "github.com/thrasher-/gocryptotrader/logger"
 )
@@ -34,6 +37,7 @@ type Requester struct {
 	AuthLimit            *RateLimit
 	Name                 string
 	UserAgent            string
+	Headers              map[string]string
 	Cycle                time.Time
 	timeoutRetryAttempts int
 	m                    sync.Mutex
@@ -72,6 +76,119 @@ type Job struct {
 	HTTPDebugging bool
 }
 
+// Error wraps errors returned from exchange requests with the context needed
+// to diagnose a failure without verbose mode. RequestID is generated for each
+// failed request so log entries and API responses can be correlated
+type Error struct {
+	Exchange   string
+	Method     string
+	Path       string
+	StatusCode int
+	Code       string
+	RequestID  string
+	Response   []byte
+	Err        error
+}
+
+// ErrGeoRestricted is returned for requests rejected because of the location
+// of the client IP address or a compliance block. Retrying them risks the IP
+// address being banned
+var ErrGeoRestricted = errors.New("access is restricted from this location or by a compliance block")
+
+// IsGeoRestricted returns whether a request was rejected as geo-restricted,
+// either by its HTTP status or an exchange error code normalised by the
+// exchange
+func IsGeoRestricted(err error) bool {
+	if err == ErrGeoRestricted {
+		return true
+	}
+	reqErr, ok := err.(*Error)
+	return ok && reqErr.Err == ErrGeoRestricted
+}
+
+// IsRateLimited returns whether a request was rejected by the exchanges rate
+// limiting, which returns 429 or 418 once an IP address is temporarily banned
+func IsRateLimited(err error) bool {
+	reqErr, ok := err.(*Error)
+	if !ok {
+		return false
+	}
+	return reqErr.StatusCode == http.StatusTooManyRequests ||
+		reqErr.StatusCode == http.StatusTeapot
+}
+
+// NewError returns an Error for a failed request to an exchange endpoint. Any
+// query parameters are removed from the path as they can contain credentials
+func NewError(exchName, method, path string, err error) *Error {
+	return &Error{
+		Exchange:  exchName,
+		Method:    method,
+		Path:      endpointPath(path),
+		RequestID: newRequestID(),
+		Err:       err,
+	}
+}
+
+// Error returns the error message with its context
+func (e *Error) Error() string {
+	msg := e.Exchange + " " + e.Method + " " + e.Path
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(" status: %d", e.StatusCode)
+	}
+	if e.Code != "" {
+		msg += " code: " + e.Code
+	}
+	if e.RequestID != "" {
+		msg += " request ID: " + e.RequestID
+	}
+	if e.Err == nil {
+		return msg
+	}
+	return msg + " error: " + e.Err.Error()
+}
+
+// endpointPath returns the path of a request URL without its query
+func endpointPath(path string) string {
+	u, err := url.Parse(path)
+	if err != nil {
+		return path
+	}
+	return u.Path
+}
+
+// newRequestID returns a random identifier for a failed request
+func newRequestID() string {
+	id, err := common.GetRandomSalt(nil, 8)
+	if err != nil {
+		return ""
+	}
+	return common.HexEncodeToString(id)
+}
+
+// errorCodeFields are response fields which exchanges use for error codes
+var errorCodeFields = []string{"code", "error_code", "errorCode", "err-code"}
+
+// getErrorCode returns the exchange error code from an error response
+func getErrorCode(response []byte) string {
+	var fields map[string]interface{}
+	if common.JSONDecode(response, &fields) != nil {
+		return ""
+	}
+	for _, field := range errorCodeFields {
+		var code string
+		switch v := fields[field].(type) {
+		case float64:
+			code = strconv.FormatFloat(v, 'f', -1, 64)
+		case string:
+			code = v
+		}
+		if code != "" && code != "0" {
+			return code
+		}
+	}
+	return ""
+}
+
 // NewRateLimit creates a new RateLimit
 func NewRateLimit(d time.Duration, rate int) *RateLimit {
 	return &RateLimit{Duration: d, Rate: rate}
@@ -126,7 +243,7 @@ func (r *RateLimit) GetDuration() time.Duration {
 
 // StartCycle restarts the cycle time and requests counters
 func (r *Requester) StartCycle() {
-	r.Cycle = time.Now()
+	r.Cycle = clock.Now()
 	r.AuthLimit.SetRequests(0)
 	r.UnauthLimit.SetRequests(0)
 }
@@ -233,11 +350,11 @@ func IsValidMethod(method string) bool {
 // IsValidCycle checks to see whether the current request cycle is valid or not
 func (r *Requester) IsValidCycle(auth bool) bool {
 	if auth {
-		if time.Since(r.Cycle) < r.AuthLimit.GetDuration() {
+		if clock.Since(r.Cycle) < r.AuthLimit.GetDuration() {
 			return true
 		}
 	} else {
-		if time.Since(r.Cycle) < r.UnauthLimit.GetDuration() {
+		if clock.Since(r.Cycle) < r.UnauthLimit.GetDuration() {
 			return true
 		}
 	}
@@ -260,22 +377,54 @@ func (r *Requester) checkRequest(method, path string, body io.Reader, headers ma
 		req.Header.Add("User-Agent", r.UserAgent)
 	}
 
+	// Custom headers never override the headers an exchange sets, such as
+	// those used to authenticate a request
+	for k, v := range r.Headers {
+		if req.Header.Get(k) == "" {
+			req.Header.Add(k, v)
+		}
+	}
+
 	return req, nil
 }
 
 // DoRequest performs a HTTP/HTTPS request with the supplied params
 func (r *Requester) DoRequest(req *http.Request, path string, body io.Reader, result interface{}, authRequest, verbose, httpDebug bool) error {
 	if verbose {
-		log.Debugf("%s exchange request path: %s requires rate limiter: %v", r.Name, path, r.RequiresRateLimiter())
+		log.Debugf("%s exchange request path: %s requires rate limiter: %v", r.Name,
+			log.DebugPayload(r.Name, path), r.RequiresRateLimiter())
 		for k, d := range req.Header {
-			log.Debugf("%s exchange request header [%s]: %s", r.Name, k, d)
+			log.Debugf("%s exchange request header [%s]: %s", r.Name, k,
+				log.RedactHeader(r.Name, k, d))
+		}
+		if body != nil {
+			log.Debug(log.DebugPayload(r.Name, body))
 		}
-		log.Debug(body)
 	}
 
 	var timeoutError error
 	for i := 0; i < r.timeoutRetryAttempts+1; i++ {
+		start := clock.Now()
+		fault := faults.Request(r.Name)
+		if fault.Latency > 0 {
+			clock.Sleep(fault.Latency)
+		}
+		if fault.Drop {
+			if r.RequiresRateLimiter() {
+				r.DecrementRequests(authRequest)
+			}
+			return faults.ErrConnectionDropped
+		}
+		if fault.StatusCode != 0 {
+			return &Error{
+				StatusCode: fault.StatusCode,
+				Err: fmt.Errorf("unsuccessful HTTP status code: %d",
+					fault.StatusCode),
+			}
+		}
+
 		resp, err := r.HTTPClient.Do(req)
+		RecordLatency(r.Name, req.Method, path, clock.Since(start), clock.Now())
 		if err != nil {
 			if timeoutErr, ok := err.(net.Error); ok && timeoutErr.Timeout() {
 				if verbose {
@@ -330,12 +479,20 @@ func (r *Requester) DoRequest(req *http.Request, path string, body io.Reader, re
 
 		if resp.StatusCode != 200 && resp.StatusCode != 201 && resp.StatusCode != 202 {
 			err = fmt.Errorf("unsuccessful HTTP status code: %d", resp.StatusCode)
-			if verbose {
+			if resp.StatusCode == http.StatusUnavailableForLegalReasons {
+				err = ErrGeoRestricted
+			} else if verbose {
 				err = fmt.Errorf("%s\n%s", err.Error(),
-					fmt.Sprintf("%s exchange raw response: %s", r.Name, string(contents)))
+					fmt.Sprintf("%s exchange raw response: %s", r.Name,
+						log.DebugPayload(r.Name, contents)))
 			}
 
-			return err
+			return &Error{
+				StatusCode: resp.StatusCode,
+				Code:       getErrorCode(contents),
+				Response:   contents,
+				Err:        err,
+			}
 		}
 
 		if httpDebug {
@@ -343,15 +500,18 @@ func (r *Requester) DoRequest(req *http.Request, path string, body io.Reader, re
 			if err != nil {
 				log.Errorf("DumpResponse invalid response: %v:", err)
 			}
-			log.Debugf("DumpResponse Headers (%v):\n%s", path, dump)
-			log.Debugf("DumpResponse Body (%v):\n %s", path, string(contents))
+			log.Debugf("DumpResponse Headers (%v):\n%s", log.DebugPayload(r.Name, path),
+				log.DebugPayload(r.Name, dump))
+			log.Debugf("DumpResponse Body (%v):\n %s", log.DebugPayload(r.Name, path),
+				log.DebugPayload(r.Name, contents))
 		}
 
 		resp.Body.Close()
 		if verbose {
 			log.Debugf("HTTP status: %s, Code: %v", resp.Status, resp.StatusCode)
 			if !httpDebug {
-				log.Debugf("%s exchange raw response: %s", r.Name, string(contents))
+				log.Debugf("%s exchange raw response: %s", r.Name,
+					log.DebugPayload(r.Name, contents))
 			}
 		}
 
@@ -378,15 +538,15 @@ func (r *Requester) worker() {
 				}
 			} else {
 				limit := r.GetRateLimit(x.AuthRequest)
-				diff := limit.GetDuration() - time.Since(r.Cycle)
+				diff := limit.GetDuration() - clock.Since(r.Cycle)
 				if x.Verbose {
 					log.Debugf("%s request. Rate limited! Sleeping for %v", r.Name, diff)
 				}
-				time.Sleep(diff)
+				clock.Sleep(diff)
 
 				for {
 					if r.IsRateLimited(x.AuthRequest) {
-						time.Sleep(time.Millisecond)
+						clock.Sleep(time.Millisecond)
 						continue
 					}
 					r.IncrementRequests(x.AuthRequest)
@@ -407,8 +567,30 @@ func (r *Requester) worker() {
 	}
 }
 
-// SendPayload handles sending HTTP/HTTPS requests
+// SendPayload handles sending HTTP/HTTPS requests. Errors are returned as an
+// Error holding the exchange, endpoint and response context
 func (r *Requester) SendPayload(method, path string, headers map[string]string, body io.Reader, result interface{}, authRequest, nonceEnabled, verbose, httpDebugging bool) error {
+	err := r.sendPayload(method, path, headers, body, result, authRequest, nonceEnabled, verbose, httpDebugging)
+	if err == nil {
+		return nil
+	}
+
+	var exchName string
+	if r != nil {
+		exchName = r.Name
+	}
+	reqErr, ok := err.(*Error)
+	if !ok {
+		return NewError(exchName, method, path, err)
+	}
+	wrapped := NewError(exchName, method, path, reqErr.Err)
+	wrapped.StatusCode = reqErr.StatusCode
+	wrapped.Code = reqErr.Code
+	wrapped.Response = reqErr.Response
+	return wrapped
+}
+
+func (r *Requester) sendPayload(method, path string, headers map[string]string, body io.Reader, result interface{}, authRequest, nonceEnabled, verbose, httpDebugging bool) error {
 	if !nonceEnabled {
 		r.lock()
 	}
@@ -434,12 +616,15 @@ func (r *Requester) SendPayload(method, path string, headers map[string]string,
 		return err
 	}
 
+	// Sampling is decided once so a request is logged in full or not at all
+	verbose = verbose && log.Sampled(r.Name)
+
 	if httpDebugging {
 		dump, err := httputil.DumpRequestOut(req, true)
 		if err != nil {
 			log.Errorf("DumpRequest invalid response %v:", err)
 		}
-		log.Debugf("DumpRequest:\n%s", dump)
+		log.Debugf("DumpRequest:\n%s", log.DebugPayload(r.Name, dump))
 	}
 
 	if !r.RequiresRateLimiter() {
@@ -499,9 +684,9 @@ func (r *Requester) GetNonce(isNano bool) nonce.Value {
 	r.lock()
 	if r.Nonce.Get() == 0 {
 		if isNano {
-			r.Nonce.Set(time.Now().UnixNano())
+			r.Nonce.Set(clock.Now().UnixNano())
 		} else {
-			r.Nonce.Set(time.Now().Unix())
+			r.Nonce.Set(clock.Now().Unix())
 		}
 		return r.Nonce.Get()
 	}
@@ -514,7 +699,7 @@ func (r *Requester) GetNonce(isNano bool) nonce.Value {
 func (r *Requester) GetNonceMilli() nonce.Value {
 	r.lock()
 	if r.Nonce.Get() == 0 {
-		r.Nonce.Set(time.Now().UnixNano() / int64(time.Millisecond))
+		r.Nonce.Set(clock.Now().UnixNano() / int64(time.Millisecond))
 		return r.Nonce.Get()
 	}
 	r.Nonce.Inc()
@@ -0,0 +1,51 @@
+//go:build faultinjection
+// +build faultinjection
+
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/faults"
+)
+
+func TestSendPayloadFaults(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`)) // nolint:errcheck
+	}))
+	defer server.Close()
+
+	r := New("faultstest", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+	err := faults.Set(r.Name, faults.Config{ErrorRate: 1, StatusCodes: []int{http.StatusTooManyRequests}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer faults.Remove(r.Name)
+
+	err = r.SendPayload(http.MethodGet, server.URL, nil, nil, nil, false, false, false, false)
+	reqErr, ok := err.(*Error)
+	if !ok || reqErr.StatusCode != http.StatusTooManyRequests || requests != 0 {
+		t.Fatalf("expected an injected 429 without a request, got %v", err)
+	}
+
+	err = faults.Set(r.Name, faults.Config{DropRate: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.SendPayload(http.MethodGet, server.URL, nil, nil, nil, false, false, false, false)
+	if reqErr, ok = err.(*Error); !ok || reqErr.Err != faults.ErrConnectionDropped {
+		t.Fatalf("expected an injected dropped connection, got %v", err)
+	}
+
+	faults.Remove(r.Name)
+	err = r.SendPayload(http.MethodGet, server.URL, nil, nil, nil, false, false, false, false)
+	if err != nil || requests != 1 {
+		t.Fatalf("expected the request to succeed once faults were removed, got %v", err)
+	}
+}
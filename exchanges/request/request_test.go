@@ -1,10 +1,16 @@
 package request
 
 import (
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
 )
 
 func TestNewRateLimit(t *testing.T) {
@@ -190,12 +196,49 @@ func TestIsValidCycle(t *testing.T) {
 	}
 }
 
+func TestIsValidCycleSimulatedClock(t *testing.T) {
+	s := clock.NewSimulated(time.Unix(1560000000, 0))
+	clock.Set(s)
+	defer clock.Set(nil)
+
+	r := New("bitfinex", NewRateLimit(time.Second*10, 5), NewRateLimit(time.Second*20, 100), new(http.Client))
+	r.StartCycle()
+	r.AuthLimit.SetRequests(5)
+
+	s.Advance(time.Second * 9)
+	if !r.IsRateLimited(true) {
+		t.Fatal("unexpected values")
+	}
+
+	s.Advance(time.Second)
+	if r.IsRateLimited(true) {
+		t.Fatal("unexpected values")
+	}
+	if !r.Cycle.Equal(s.Now()) {
+		t.Fatal("unexpected values")
+	}
+}
+
 func TestCheckRequest(t *testing.T) {
 	r := New("", NewRateLimit(time.Second*10, 5), NewRateLimit(time.Second*20, 100), new(http.Client))
 	_, err := r.checkRequest("bad method, bad", "http://www.google.com", nil, nil)
 	if err == nil {
 		t.Fatal("unexpected values")
 	}
+
+	r.UserAgent = "gct"
+	r.Headers = map[string]string{"X-Custom": "custom", "X-Auth": "custom"}
+	req, err := r.checkRequest(http.MethodGet, "http://www.google.com", nil,
+		map[string]string{"X-Auth": "exchange"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Header.Get("User-Agent") != "gct" || req.Header.Get("X-Custom") != "custom" {
+		t.Error("custom headers should be applied to requests")
+	}
+	if req.Header.Get("X-Auth") != "exchange" {
+		t.Error("custom headers should not override exchange headers")
+	}
 }
 
 func TestDoRequest(t *testing.T) {
@@ -330,3 +373,117 @@ func BenchmarkRequestLockMech(b *testing.B) {
 		r.SendPayload(http.MethodGet, "127.0.0.1", nil, nil, &meep, false, false, false, false)
 	}
 }
+
+func TestSendPayloadError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":-1021,"msg":"Timestamp for this request is outside of the recvWindow."}`)) // nolint:errcheck
+	}))
+	defer server.Close()
+
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+	err := r.SendPayload(http.MethodGet, server.URL+"/api/v3/order?signature=secret", nil, nil, nil, true, false, false, false)
+	reqErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("unexpected error type %T", err)
+	}
+
+	if reqErr.Exchange != "test" || reqErr.Method != http.MethodGet ||
+		reqErr.Path != "/api/v3/order" || reqErr.StatusCode != http.StatusBadRequest ||
+		reqErr.Code != "-1021" || reqErr.RequestID == "" {
+		t.Fatalf("unexpected error context %+v", reqErr)
+	}
+
+	if strings.Contains(reqErr.Error(), "secret") {
+		t.Fatal("error should not contain query parameters")
+	}
+
+	err = r.SendPayload("BLAH", server.URL, nil, nil, nil, false, false, false, false)
+	if reqErr, ok = err.(*Error); !ok || reqErr.Err == nil || reqErr.StatusCode != 0 {
+		t.Fatal("unexpected values")
+	}
+}
+
+func TestSendPayloadGeoRestricted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnavailableForLegalReasons)
+	}))
+	defer server.Close()
+
+	r := New("test", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+	err := r.SendPayload(http.MethodGet, server.URL, nil, nil, nil, false, false, false, false)
+	if !IsGeoRestricted(err) {
+		t.Fatalf("expected a geo-restricted error, got %v", err)
+	}
+
+	if IsGeoRestricted(errors.New("unsuccessful HTTP status code: 403")) ||
+		IsGeoRestricted(NewError("test", http.MethodGet, server.URL, nil)) {
+		t.Fatal("unexpected geo-restricted error")
+	}
+}
+
+func TestGetErrorCode(t *testing.T) {
+	tests := map[string]string{
+		`{"code":30008,"message":"timestamp request expired"}`: "30008",
+		`{"code":0,"error_code":"33014"}`:                      "33014",
+		`{"err-code":"api-signature-not-valid"}`:               "api-signature-not-valid",
+		`{"message":"no code"}`:                                "",
+		`not json`:                                             "",
+	}
+	for response, expected := range tests {
+		if code := getErrorCode([]byte(response)); code != expected {
+			t.Errorf("unexpected code %s for %s", code, response)
+		}
+	}
+}
+
+func TestLatencyEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"https://api.test.com/v1/orders?symbol=btcusd": "/v1/orders",
+		"https://api.test.com/v1/order/12345/cancel":   "/v1/order/:id/cancel",
+		"/api/orders/3f2a1b9c7d8e4f60a1b2":             "/api/orders/:id",
+		"/api/v3/spot/instruments/BTC-USDT":            "/api/v3/spot/instruments/BTC-USDT",
+	}
+	for path, expected := range tests {
+		if endpoint := LatencyEndpoint(path); endpoint != expected {
+			t.Errorf("unexpected endpoint %s for %s", endpoint, path)
+		}
+	}
+}
+
+func TestGetLatencies(t *testing.T) {
+	SetLatencyWindow(time.Minute)
+	defer SetLatencyWindow(DefaultLatencyWindow)
+
+	now := time.Unix(1000, 0)
+	for x := 0; x < 100; x++ {
+		latency := time.Millisecond * 20
+		if x >= 90 {
+			latency = time.Second * 2
+		}
+		RecordLatency("test", http.MethodGet, "/v1/order/"+strconv.Itoa(x), latency, now)
+	}
+	RecordLatency("test", http.MethodGet, "/v1/ticker", time.Millisecond*5, now)
+
+	latencies := GetLatencies(now)
+	if len(latencies) != 2 || latencies[0].Endpoint != "/v1/order/:id" ||
+		latencies[1].Endpoint != "/v1/ticker" {
+		t.Fatalf("unexpected latencies %+v", latencies)
+	}
+	orders := latencies[0]
+	if orders.Requests != 100 || orders.Max != time.Second*2 ||
+		orders.P50 <= time.Millisecond*10 || orders.P50 > time.Millisecond*25 ||
+		orders.P95 <= time.Millisecond*1500 || orders.P99 > time.Second*2 {
+		t.Errorf("unexpected order latencies %+v", orders)
+	}
+
+	// The previous window is kept until it ended over a window ago
+	latencies = GetLatencies(now.Add(time.Minute + time.Second))
+	if len(latencies) != 2 || latencies[0].Requests != 100 {
+		t.Fatalf("expected the previous window to be kept, got %+v", latencies)
+	}
+	if latencies = GetLatencies(now.Add(time.Minute*2 + time.Second*2)); len(latencies) != 0 {
+		t.Errorf("expected expired latencies to be removed, got %+v", latencies)
+	}
+}
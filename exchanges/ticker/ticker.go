@@ -16,7 +16,8 @@ const (
 	ErrPrimaryCurrencyNotFound   = "primary currency for ticker not found"
 	ErrSecondaryCurrencyNotFound = "secondary currency for ticker not found"
 
-	Spot = "SPOT"
+	Spot    = "SPOT"
+	Futures = "FUTURES"
 )
 
 // Vars for the ticker package
@@ -25,7 +26,9 @@ var (
 	m       sync.Mutex
 )
 
-// Price struct stores the currency pair and pricing information
+// Price struct stores the currency pair and pricing information. VWAP is
+// supplied by the exchange when NativeVWAP is set, otherwise it's the rolling
+// VWAP computed from the exchanges trade stream
 type Price struct {
 	Pair        currency.Pair `json:"Pair"`
 	Last        float64       `json:"Last"`
@@ -35,6 +38,9 @@ type Price struct {
 	Ask         float64       `json:"Ask"`
 	Volume      float64       `json:"Volume"`
 	PriceATH    float64       `json:"PriceATH"`
+	VWAP        float64       `json:"VWAP"`
+	NativeVWAP  bool          `json:"NativeVWAP,omitempty"`
+	Stale       bool          `json:"Stale"`
 	LastUpdated time.Time
 }
 
@@ -63,6 +69,8 @@ func (t *Ticker) PriceToString(p currency.Pair, priceType, tickerType string) st
 		return strconv.FormatFloat(t.Price[p.Base.Upper().String()][p.Quote.Upper().String()][tickerType].Volume, 'f', -1, 64)
 	case "ath":
 		return strconv.FormatFloat(t.Price[p.Base.Upper().String()][p.Quote.Upper().String()][tickerType].PriceATH, 'f', -1, 64)
+	case "vwap":
+		return strconv.FormatFloat(t.Price[p.Base.Upper().String()][p.Quote.Upper().String()][tickerType].VWAP, 'f', -1, 64)
 	default:
 		return ""
 	}
@@ -86,6 +94,25 @@ func GetTicker(exchange string, p currency.Pair, tickerType string) (Price, erro
 	return ticker.Price[p.Base.Upper().String()][p.Quote.Upper().String()][tickerType], nil
 }
 
+// SetStale marks a stored ticker as stale, used when its source can no longer
+// be updated. The flag is cleared when the ticker is next processed
+func SetStale(exchange string, p currency.Pair, tickerType string) error {
+	ticker, err := GetTickerByExchange(exchange)
+	if err != nil {
+		return err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	price, ok := ticker.Price[p.Base.Upper().String()][p.Quote.Upper().String()][tickerType]
+	if !ok {
+		return errors.New(ErrSecondaryCurrencyNotFound)
+	}
+	price.Stale = true
+	ticker.Price[p.Base.Upper().String()][p.Quote.Upper().String()][tickerType] = price
+	return nil
+}
+
 // GetTickerByExchange returns an exchange Ticker
 func GetTickerByExchange(exchange string) (*Ticker, error) {
 	m.Lock()
@@ -154,6 +181,11 @@ func ProcessTicker(exchangeName string, tickerNew *Price, tickerType string) err
 	}
 
 	tickerNew.LastUpdated = time.Now()
+	tickerNew.Stale = false
+	tickerNew.NativeVWAP = tickerNew.VWAP > 0
+	if !tickerNew.NativeVWAP {
+		tickerNew.VWAP, _ = GetVWAP(exchangeName, tickerNew.Pair, tickerType)
+	}
 
 	ticker, err := GetTickerByExchange(exchangeName)
 	if err != nil {
@@ -113,6 +113,49 @@ func TestGetTicker(t *testing.T) {
 	}
 }
 
+func TestSetStale(t *testing.T) {
+	newPair := currency.NewPairFromStrings("BTC", "USD")
+	priceStruct := Price{
+		Pair: newPair,
+		Last: 1200,
+	}
+
+	err := ProcessTicker("StaleExchange", &priceStruct, Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = SetStale("StaleExchange", newPair, Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tickerPrice, err := GetTicker("StaleExchange", newPair, Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tickerPrice.Stale {
+		t.Error("Test Failed - ticker SetStale expected ticker to be stale")
+	}
+
+	err = ProcessTicker("StaleExchange", &tickerPrice, Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tickerPrice, err = GetTicker("StaleExchange", newPair, Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tickerPrice.Stale {
+		t.Error("Test Failed - ticker SetStale expected processed ticker to be fresh")
+	}
+
+	err = SetStale("StaleExchange", newPair, "FUTURES")
+	if err == nil {
+		t.Error("Test Failed - ticker SetStale expected error for non-existent ticker")
+	}
+}
+
 func TestGetTickerByExchange(t *testing.T) {
 	newPair := currency.NewPairFromStrings("BTC", "USD")
 	priceStruct := Price{
@@ -0,0 +1,141 @@
+package ticker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+const (
+	// DefaultVWAPWindow is the rolling window of the locally computed VWAP
+	DefaultVWAPWindow = time.Hour * 24
+
+	// vwapBuckets is the number of buckets trades are summed into across the
+	// window, bounding memory on busy pairs at the cost of the window edge
+	// rolling one bucket at a time
+	vwapBuckets = 96
+)
+
+// vwapBucket sums the traded notional and volume of a pair starting at a time
+type vwapBucket struct {
+	start    time.Time
+	notional float64
+	volume   float64
+}
+
+var vwaps = struct {
+	window  time.Duration
+	buckets map[string][]vwapBucket
+	m       sync.Mutex
+}{
+	window:  DefaultVWAPWindow,
+	buckets: make(map[string][]vwapBucket),
+}
+
+// SetVWAPWindow sets the rolling window of the locally computed VWAP,
+// clearing the trades recorded so far
+func SetVWAPWindow(window time.Duration) {
+	if window <= 0 {
+		window = DefaultVWAPWindow
+	}
+	vwaps.m.Lock()
+	vwaps.window = window
+	vwaps.buckets = make(map[string][]vwapBucket)
+	vwaps.m.Unlock()
+}
+
+// vwapKey returns the key of an exchange pair and ticker type
+func vwapKey(exchangeName string, p currency.Pair, tickerType string) string {
+	return common.StringToLower(exchangeName) + "|" + p.Base.Upper().String() + "|" +
+		p.Quote.Upper().String() + "|" + tickerType
+}
+
+// trimVWAPBuckets drops the buckets which started before the window
+func trimVWAPBuckets(buckets []vwapBucket, window time.Duration, now time.Time) []vwapBucket {
+	cutoff := now.Add(-window)
+	x := 0
+	for x < len(buckets) && !buckets[x].start.After(cutoff) {
+		x++
+	}
+	return buckets[x:]
+}
+
+// calculateVWAP returns the VWAP of the buckets
+func calculateVWAP(buckets []vwapBucket) (float64, bool) {
+	var notional, volume float64
+	for x := range buckets {
+		notional += buckets[x].notional
+		volume += buckets[x].volume
+	}
+	if volume <= 0 {
+		return 0, false
+	}
+	return notional / volume, true
+}
+
+// ProcessTrade records a trade in the rolling VWAP of an exchange pair and
+// updates the VWAP of its stored ticker. Trades older than the window are
+// ignored
+func ProcessTrade(exchangeName string, p currency.Pair, tickerType string, t time.Time, price, amount float64) {
+	if price <= 0 || amount <= 0 {
+		return
+	}
+	now := time.Now()
+
+	vwaps.m.Lock()
+	if t.Before(now.Add(-vwaps.window)) {
+		vwaps.m.Unlock()
+		return
+	}
+	key := vwapKey(exchangeName, p, tickerType)
+	bucketSize := vwaps.window / vwapBuckets
+	start := t.Truncate(bucketSize)
+	buckets := trimVWAPBuckets(vwaps.buckets[key], vwaps.window, now)
+
+	x := len(buckets)
+	for x > 0 && buckets[x-1].start.After(start) {
+		x--
+	}
+	if x == 0 || !buckets[x-1].start.Equal(start) {
+		buckets = append(buckets, vwapBucket{})
+		copy(buckets[x+1:], buckets[x:])
+		buckets[x] = vwapBucket{start: start}
+		x++
+	}
+	buckets[x-1].notional += price * amount
+	buckets[x-1].volume += amount
+	vwaps.buckets[key] = buckets
+	vwap, ok := calculateVWAP(buckets)
+	vwaps.m.Unlock()
+	if !ok {
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	for x := range Tickers {
+		if Tickers[x].ExchangeName != exchangeName {
+			continue
+		}
+		stored, ok := Tickers[x].Price[p.Base.Upper().String()][p.Quote.Upper().String()][tickerType]
+		if !ok || stored.NativeVWAP {
+			return
+		}
+		stored.VWAP = vwap
+		Tickers[x].Price[p.Base.Upper().String()][p.Quote.Upper().String()][tickerType] = stored
+		return
+	}
+}
+
+// GetVWAP returns the rolling VWAP of an exchange pair computed from its
+// recorded trades
+func GetVWAP(exchangeName string, p currency.Pair, tickerType string) (float64, bool) {
+	vwaps.m.Lock()
+	defer vwaps.m.Unlock()
+	key := vwapKey(exchangeName, p, tickerType)
+	buckets := trimVWAPBuckets(vwaps.buckets[key], vwaps.window, time.Now())
+	vwaps.buckets[key] = buckets
+	return calculateVWAP(buckets)
+}
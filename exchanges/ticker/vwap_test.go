@@ -0,0 +1,53 @@
+package ticker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+func TestProcessTrade(t *testing.T) {
+	SetVWAPWindow(time.Hour)
+	defer SetVWAPWindow(DefaultVWAPWindow)
+	p := currency.NewPairFromStrings("VWAP", "USD")
+	now := time.Now()
+
+	ProcessTrade("VWAPTest", p, Spot, now.Add(-time.Minute*2), 100, 1)
+	ProcessTrade("VWAPTest", p, Spot, now.Add(-time.Minute*30), 110, 3)
+	ProcessTrade("VWAPTest", p, Spot, now.Add(-time.Hour*2), 1000, 10)
+	ProcessTrade("VWAPTest", p, Spot, now, 0, 1)
+
+	vwap, ok := GetVWAP("VWAPTest", p, Spot)
+	if !ok || vwap != 107.5 {
+		t.Fatalf("Test Failed - expected VWAP 107.5 of trades within the window, got %f", vwap)
+	}
+
+	err := ProcessTicker("VWAPTest", &Price{Pair: p, Last: 105}, Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	price, err := GetTicker("VWAPTest", p, Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price.VWAP != 107.5 || price.NativeVWAP {
+		t.Errorf("Test Failed - expected the local VWAP in the ticker, got %+v", price)
+	}
+
+	ProcessTrade("VWAPTest", p, Spot, now, 120, 4)
+	price, _ = GetTicker("VWAPTest", p, Spot)
+	if price.VWAP != 113.75 {
+		t.Errorf("Test Failed - expected trades to update the ticker VWAP, got %f", price.VWAP)
+	}
+
+	err = ProcessTicker("VWAPTest", &Price{Pair: p, Last: 105, VWAP: 99}, Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ProcessTrade("VWAPTest", p, Spot, now, 120, 4)
+	price, _ = GetTicker("VWAPTest", p, Spot)
+	if price.VWAP != 99 || !price.NativeVWAP {
+		t.Errorf("Test Failed - expected an exchange supplied VWAP to be kept, got %+v", price)
+	}
+}
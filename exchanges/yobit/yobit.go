@@ -47,6 +47,16 @@ type Yobit struct {
 	Ticker map[string]Ticker
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "Yobit",
+		Creator: func() exchange.IBotExchange {
+			return new(Yobit)
+		},
+		Capabilities: exchange.SpotCapability,
+	})
+}
+
 // SetDefaults sets current default value for Yobit
 func (y *Yobit) SetDefaults() {
 	y.Name = "Yobit"
@@ -87,6 +97,7 @@ func (y *Yobit) Setup(exch *config.ExchangeConfig) {
 		y.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
 		y.RESTPollingDelay = exch.RESTPollingDelay
 		y.Verbose = exch.Verbose
+		y.ReadOnly = exch.ReadOnly
 		y.HTTPDebugging = exch.HTTPDebugging
 		y.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		y.BaseCurrencies = exch.BaseCurrencies
@@ -94,6 +105,7 @@ func (y *Yobit) Setup(exch *config.ExchangeConfig) {
 		y.EnabledPairs = exch.EnabledPairs
 		y.SetHTTPClientTimeout(exch.HTTPTimeout)
 		y.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		y.SetHTTPHeaders(exch.HTTPHeaders)
 		err := y.SetCurrencyPairFormat()
 		if err != nil {
 			log.Fatal(err)
@@ -345,8 +357,7 @@ func (y *Yobit) SendHTTPRequest(path string, result interface{}) error {
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request to Yobit
 func (y *Yobit) SendAuthenticatedHTTPRequest(path string, params url.Values, result interface{}) (err error) {
 	if !y.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet,
-			y.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	if params == nil {
@@ -412,7 +423,7 @@ func (y *Yobit) GetFee(feeBuilder *exchange.FeeBuilder) (float64, error) {
 }
 
 func calculateTradingFee(price, amount float64) (fee float64) {
-	return 0.002 * price * amount
+	return exchange.CalculateTradingFee(0.002, price, amount)
 }
 
 func getWithdrawalFee(c currency.Code) float64 {
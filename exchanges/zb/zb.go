@@ -42,6 +42,9 @@ const (
 
 	zbAuthRate   = 100
 	zbUnauthRate = 100
+
+	// zbKlineLimit is the maximum number of candles returned per request
+	zbKlineLimit = 1000
 )
 
 // ZB is the overarching type across this package
@@ -53,6 +56,17 @@ type ZB struct {
 	wsRequestMtx sync.Mutex
 }
 
+func init() {
+	exchange.Register(exchange.Registration{
+		Name: "ZB",
+		Creator: func() exchange.IBotExchange {
+			return new(ZB)
+		},
+		Capabilities: exchange.SpotCapability |
+			exchange.WebsocketCapability,
+	})
+}
+
 // SetDefaults sets default values for the exchange
 func (z *ZB) SetDefaults() {
 	z.Name = "ZB"
@@ -69,6 +83,7 @@ func (z *ZB) SetDefaults() {
 	z.AssetTypes = []string{ticker.Spot}
 	z.SupportsAutoPairUpdating = true
 	z.SupportsRESTTickerBatching = true
+	z.SupportsKlines = true
 	z.Requester = request.New(z.Name,
 		request.NewRateLimit(time.Second*10, zbAuthRate),
 		request.NewRateLimit(time.Second*10, zbUnauthRate),
@@ -95,8 +110,10 @@ func (z *ZB) Setup(exch *config.ExchangeConfig) {
 		z.APIAuthPEMKey = exch.APIAuthPEMKey
 		z.SetHTTPClientTimeout(exch.HTTPTimeout)
 		z.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		z.SetHTTPHeaders(exch.HTTPHeaders)
 		z.RESTPollingDelay = exch.RESTPollingDelay
 		z.Verbose = exch.Verbose
+		z.ReadOnly = exch.ReadOnly
 		z.HTTPDebugging = exch.HTTPDebugging
 		z.Websocket.SetWsStatusAndConnection(exch.Websocket)
 		z.BaseCurrencies = exch.BaseCurrencies
@@ -374,7 +391,7 @@ func (z *ZB) SendHTTPRequest(path string, result interface{}) error {
 // SendAuthenticatedHTTPRequest sends authenticated requests to the zb API
 func (z *ZB) SendAuthenticatedHTTPRequest(httpMethod string, params url.Values, result interface{}) error {
 	if !z.AuthenticatedAPISupport {
-		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, z.Name)
+		return exchange.ErrCredentialsNotSet
 	}
 
 	params.Set("accesskey", z.APIKey)
@@ -448,7 +465,7 @@ func getOfflineTradeFee(price, amount float64) float64 {
 
 func calculateTradingFee(purchasePrice, amount float64) (fee float64) {
 	fee = 0.002
-	return fee * amount * purchasePrice
+	return exchange.CalculateTradingFee(fee, purchasePrice, amount)
 }
 
 func getWithdrawalFee(c currency.Code) float64 {
@@ -166,6 +166,23 @@ var (
 	TimeIntervalWeek           = TimeInterval("1week")
 )
 
+// klineIntervals maps fixed length time intervals to their durations
+var klineIntervals = map[TimeInterval]time.Duration{
+	TimeIntervalMinute:         time.Minute,
+	TimeIntervalThreeMinutes:   time.Minute * 3,
+	TimeIntervalFiveMinutes:    time.Minute * 5,
+	TimeIntervalFifteenMinutes: time.Minute * 15,
+	TimeIntervalThirtyMinutes:  time.Minute * 30,
+	TimeIntervalHour:           time.Hour,
+	TimeIntervalTwoHours:       time.Hour * 2,
+	TimeIntervalFourHours:      time.Hour * 4,
+	TimeIntervalSixHours:       time.Hour * 6,
+	TimeIntervalTwelveHours:    time.Hour * 12,
+	TimeIntervalDay:            time.Hour * 24,
+	TimeIntervalThreeDays:      time.Hour * 24 * 3,
+	TimeIntervalWeek:           time.Hour * 24 * 7,
+}
+
 // WithdrawalFees the large list of predefined withdrawal fees
 // Prone to change, using highest value
 var WithdrawalFees = map[currency.Code]float64{
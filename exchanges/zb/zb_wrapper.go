@@ -10,6 +10,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	log "github.com/thrasher-/gocryptotrader/logger"
@@ -32,26 +33,27 @@ func (z *ZB) Run() {
 		log.Debugf("%s %d currencies enabled: %s.\n", z.GetName(), len(z.EnabledPairs), z.EnabledPairs)
 	}
 
-	markets, err := z.GetMarkets()
+	err := z.UpdateTradablePairs(false)
 	if err != nil {
-		log.Errorf("%s Unable to fetch symbols.\n", z.GetName())
-	} else {
-		var currencies []string
-		for x := range markets {
-			currencies = append(currencies, x)
-		}
+		log.Errorf("%s failed to update tradable pairs. Err: %s", z.Name, err)
+	}
+}
 
-		var newCurrencies currency.Pairs
-		for _, p := range currencies {
-			newCurrencies = append(newCurrencies,
-				currency.NewPairFromString(p))
-		}
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (z *ZB) UpdateTradablePairs(forceUpdate bool) error {
+	markets, err := z.GetMarkets()
+	if err != nil {
+		return err
+	}
 
-		err = z.UpdateCurrencies(newCurrencies, false, false)
-		if err != nil {
-			log.Errorf("%s Failed to update available currencies.\n", z.GetName())
-		}
+	var newCurrencies currency.Pairs
+	for x := range markets {
+		newCurrencies = append(newCurrencies,
+			currency.NewPairFromString(x))
 	}
+
+	return z.UpdateCurrencies(newCurrencies, false, forceUpdate)
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -156,11 +158,8 @@ func (z *ZB) GetAccountInfo() (exchange.AccountInfo, error) {
 			return info, err
 		}
 
-		balances = append(balances, exchange.AccountCurrencyInfo{
-			CurrencyName: currency.NewCode(data.EnName),
-			TotalValue:   hold + avail,
-			Hold:         hold,
-		})
+		balances = append(balances,
+			exchange.NewAccountCurrencyInfo(currency.NewCode(data.EnName), avail, hold))
 	}
 
 	info.Exchange = z.GetName()
@@ -185,8 +184,67 @@ func (z *ZB) GetExchangeHistory(p currency.Pair, assetType string) ([]exchange.T
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between start and end inclusive, paging
+// through the kline endpoint as needed
+func (z *ZB) GetHistoricCandles(p currency.Pair, assetType string, interval time.Duration, start, end time.Time) ([]kline.Candle, error) {
+	var timeInterval TimeInterval
+	for k, v := range klineIntervals {
+		if v == interval {
+			timeInterval = k
+			break
+		}
+	}
+	if timeInterval == "" {
+		return nil, fmt.Errorf("%s unsupported kline interval %s", z.Name, interval)
+	}
+
+	var candles []kline.Candle
+	for start.Before(end) || start.Equal(end) {
+		resp, err := z.GetSpotKline(KlinesRequestParams{
+			Symbol: exchange.FormatExchangeCurrency(z.Name, p).String(),
+			Type:   timeInterval,
+			Since:  strconv.FormatInt(start.UnixNano()/int64(time.Millisecond), 10),
+			Size:   zbKlineLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		inRange := kline.FilterCandles(getKlineCandles(resp.Data), start, end)
+		if len(inRange) == 0 {
+			break
+		}
+		candles = append(candles, inRange...)
+		start = inRange[len(inRange)-1].Time.Add(interval)
+	}
+	return candles, nil
+}
+
+// GetHistoricTrades returns trades between start and end inclusive
+func (z *ZB) GetHistoricTrades(p currency.Pair, assetType string, start, end time.Time) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// getKlineCandles converts kline response data to candles
+func getKlineCandles(data []*KLineResponseData) []kline.Candle {
+	candles := make([]kline.Candle, len(data))
+	for x := range data {
+		candles[x] = kline.Candle{
+			Time:   data[x].KlineTime,
+			Open:   data[x].Open,
+			High:   data[x].High,
+			Low:    data[x].Low,
+			Close:  data[x].Close,
+			Volume: data[x].Volume,
+		}
+	}
+	return candles
+}
+
 // SubmitOrder submits a new order
 func (z *ZB) SubmitOrder(p currency.Pair, side exchange.OrderSide, _ exchange.OrderType, amount, price float64, _ string) (exchange.SubmitOrderResponse, error) {
+	if z.IsReadOnly() {
+		return exchange.SubmitOrderResponse{}, exchange.ErrReadOnly
+	}
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var oT SpotNewOrderRequestParamsType
 
@@ -218,11 +276,17 @@ func (z *ZB) SubmitOrder(p currency.Pair, side exchange.OrderSide, _ exchange.Or
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (z *ZB) ModifyOrder(action *exchange.ModifyOrder) (string, error) {
+	if z.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (z *ZB) CancelOrder(order *exchange.OrderCancellation) error {
+	if z.IsReadOnly() {
+		return exchange.ErrReadOnly
+	}
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -234,9 +298,10 @@ func (z *ZB) CancelOrder(order *exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (z *ZB) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
-	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
-		OrderStatus: make(map[string]string),
+	if z.IsReadOnly() {
+		return exchange.CancelAllOrdersResponse{}, exchange.ErrReadOnly
 	}
+	var cancelAllOrdersResponse exchange.CancelAllOrdersResponse
 	var allOpenOrders []Order
 	for _, currency := range z.GetEnabledCurrencies() {
 		var pageNumber int64
@@ -258,9 +323,7 @@ func (z *ZB) CancelAllOrders(_ *exchange.OrderCancellation) (exchange.CancelAllO
 
 	for _, openOrder := range allOpenOrders {
 		err := z.CancelExistingOrder(openOrder.ID, openOrder.Currency)
-		if err != nil {
-			cancelAllOrdersResponse.OrderStatus[strconv.FormatInt(openOrder.ID, 10)] = err.Error()
-		}
+		cancelAllOrdersResponse.Add(strconv.FormatInt(openOrder.ID, 10), err)
 	}
 
 	return cancelAllOrdersResponse, nil
@@ -285,18 +348,27 @@ func (z *ZB) GetDepositAddress(cryptocurrency currency.Code, _ string) (string,
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (z *ZB) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if z.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return z.Withdraw(withdrawRequest.Currency.Lower().String(), withdrawRequest.Address, withdrawRequest.TradePassword, withdrawRequest.Amount, withdrawRequest.FeeAmount, false)
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (z *ZB) WithdrawFiatFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if z.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (z *ZB) WithdrawFiatFundsToInternationalBank(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	if z.IsReadOnly() {
+		return "", exchange.ErrReadOnly
+	}
 	return "", common.ErrFunctionNotSupported
 }
 
@@ -352,6 +424,7 @@ func (z *ZB) GetActiveOrders(getOrdersRequest *exchange.GetOrdersRequest) ([]exc
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks,
 		getOrdersRequest.EndTicks)
 	exchange.FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
@@ -409,6 +482,7 @@ func (z *ZB) GetOrderHistory(getOrdersRequest *exchange.GetOrdersRequest) ([]exc
 		})
 	}
 
+	exchange.SetOrderFillAmounts(orders)
 	exchange.FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
 
 	return orders, nil
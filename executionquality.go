@@ -0,0 +1,253 @@
+package main
+
+import (
+	"sort"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	// orderFillLookback is how long before the earliest unfilled order its
+	// exchanges order history is requested from, allowing for clock
+	// differences
+	orderFillLookback = time.Minute
+
+	orderFillInterval    = time.Minute
+	partialFillEventType = "PARTIAL_FILL"
+)
+
+// SlippageStats summarises the slippage of filled orders as a percentage of
+// their expected price. Positive slippage filled worse than expected
+type SlippageStats struct {
+	Exchange string  `json:"exchange,omitempty"`
+	Pair     string  `json:"pair,omitempty"`
+	Strategy string  `json:"strategy,omitempty"`
+	Orders   int     `json:"orders"`
+	Average  float64 `json:"average"`
+	Median   float64 `json:"median"`
+	Best     float64 `json:"best"`
+	Worst    float64 `json:"worst"`
+}
+
+// ExecutionQualityReport holds the slippage of the orders submitted by the bot
+// per exchange, exchange pair and strategy. Unfilled is the number of orders
+// without a known fill price which are not measured
+type ExecutionQualityReport struct {
+	Generated  time.Time       `json:"generated"`
+	Measured   int             `json:"measured"`
+	Unfilled   int             `json:"unfilled"`
+	Exchanges  []SlippageStats `json:"exchanges"`
+	Pairs      []SlippageStats `json:"pairs"`
+	Strategies []SlippageStats `json:"strategies"`
+}
+
+// getExpectedFillPrice returns the price an order is expected to fill at,
+// which is the limit price or for market orders the best opposing ticker
+// price. Zero is returned when the price is unknown
+func getExpectedFillPrice(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, price float64) float64 {
+	if price > 0 {
+		return price
+	}
+
+	t, err := ticker.GetTicker(exch.GetName(), p, ticker.Spot)
+	if err != nil {
+		return 0
+	}
+	switch side {
+	case exchange.BuyOrderSide, exchange.BidOrderSide:
+		if t.Ask > 0 {
+			return t.Ask
+		}
+	case exchange.SellOrderSide, exchange.AskOrderSide:
+		if t.Bid > 0 {
+			return t.Bid
+		}
+	}
+	return t.Last
+}
+
+// getSlippage returns the percentage an order filled worse than its expected
+// price
+func getSlippage(o *TaggedOrder) float64 {
+	slippage := (o.FillPrice - o.ExpectedPrice) / o.ExpectedPrice * 100
+	switch exchange.OrderSide(o.Side) {
+	case exchange.SellOrderSide, exchange.AskOrderSide:
+		return -slippage
+	}
+	return slippage
+}
+
+// OrderFillRoutine periodically updates the fills of the unfilled and
+// partially filled orders submitted by the bot
+func OrderFillRoutine() {
+	log.Debugln("Starting order fill routine.")
+	for {
+		now := clock.Now()
+		exchanges := GetExchanges()
+		for x := range exchanges {
+			if exchanges[x] == nil || !exchanges[x].IsEnabled() ||
+				!exchanges[x].GetAuthenticatedAPISupport() {
+				continue
+			}
+			updateOrderFills(exchanges[x], now)
+		}
+		clock.Sleep(orderFillInterval)
+	}
+}
+
+// updateOrderFills records the fills of an exchanges unfilled and partially
+// filled orders from its order history, notifying partial fills as their
+// remaining amount decreases
+func updateOrderFills(exch exchange.IBotExchange, now time.Time) {
+	unfilled := orderTags.GetUnfilled(exch.GetName())
+	if len(unfilled) == 0 {
+		return
+	}
+
+	req := exchange.GetOrdersRequest{
+		StartTicks: unfilled[0].Submitted.Add(-orderFillLookback),
+		EndTicks:   now,
+	}
+	tracked := make(map[string]TaggedOrder, len(unfilled))
+	var pairs currency.Pairs
+	for x := range unfilled {
+		tracked[unfilled[x].OrderID] = unfilled[x]
+		p := currency.NewPairFromString(unfilled[x].Pair)
+		if !pairs.Contains(p, true) {
+			pairs = append(pairs, p)
+		}
+	}
+	req.Currencies = pairs
+
+	orders, err := exch.GetOrderHistory(&req)
+	if err != nil {
+		log.Debugf("%s failed to get order history for fill prices. Error: %s",
+			exch.GetName(), err)
+		return
+	}
+	for x := range orders {
+		order, ok := tracked[orders[x].ID]
+		if !ok {
+			continue
+		}
+		price, amount := orders[x].GetAverageFillPrice()
+		remaining := orders[x].RemainingAmount
+		if amount <= 0 ||
+			(amount == order.FilledAmount && remaining == order.RemainingAmount) {
+			continue
+		}
+
+		orderTags.SetFill(exch.GetName(), orders[x].ID, price, amount, remaining)
+		if amount > order.FilledAmount && remaining > 0 {
+			notifyPartialFill(&order, amount, remaining)
+		}
+	}
+}
+
+// notifyPartialFill logs and pushes a partial fill of an order through the
+// communications package
+func notifyPartialFill(order *TaggedOrder, filled, remaining float64) {
+	message := i18n.T(i18n.MessagePartialFill, order.Exchange, order.OrderID,
+		filled, order.Amount, order.Pair, remaining)
+	if url := GetCurrencyTradeURL(order.Exchange, order.Pair); url != "" {
+		message += " " + url
+	}
+	log.Info(message)
+	pushEvent(partialFillEventType, message)
+}
+
+// getSlippageStats summarises a set of slippage percentages
+func getSlippageStats(slippage []float64) SlippageStats {
+	sort.Float64s(slippage)
+	var sum float64
+	for x := range slippage {
+		sum += slippage[x]
+	}
+
+	stats := SlippageStats{
+		Orders:  len(slippage),
+		Average: sum / float64(len(slippage)),
+		Best:    slippage[0],
+		Worst:   slippage[len(slippage)-1],
+	}
+	mid := len(slippage) / 2
+	if len(slippage)%2 == 0 {
+		stats.Median = (slippage[mid-1] + slippage[mid]) / 2
+	} else {
+		stats.Median = slippage[mid]
+	}
+	return stats
+}
+
+// getExecutionQualityReport measures the slippage of orders with both an
+// expected and a fill price
+func getExecutionQualityReport(orders []TaggedOrder, now time.Time) ExecutionQualityReport {
+	resp := ExecutionQualityReport{Generated: now}
+	type group struct{ exchange, pair, strategy string }
+	exchanges := make(map[group][]float64)
+	pairs := make(map[group][]float64)
+	strategies := make(map[group][]float64)
+	for x := range orders {
+		if orders[x].FilledAmount <= 0 || orders[x].FillPrice <= 0 {
+			resp.Unfilled++
+			continue
+		}
+		if orders[x].ExpectedPrice <= 0 {
+			continue
+		}
+		resp.Measured++
+		slippage := getSlippage(&orders[x])
+		exchanges[group{exchange: orders[x].Exchange}] = append(
+			exchanges[group{exchange: orders[x].Exchange}], slippage)
+		pair := group{exchange: orders[x].Exchange, pair: orders[x].Pair}
+		pairs[pair] = append(pairs[pair], slippage)
+		if strategy := orders[x].Tags[OrderTagStrategy]; strategy != "" {
+			strategies[group{strategy: strategy}] = append(
+				strategies[group{strategy: strategy}], slippage)
+		}
+	}
+
+	summarise := func(groups map[group][]float64) []SlippageStats {
+		stats := make([]SlippageStats, 0, len(groups))
+		for g, slippage := range groups {
+			s := getSlippageStats(slippage)
+			s.Exchange, s.Pair, s.Strategy = g.exchange, g.pair, g.strategy
+			stats = append(stats, s)
+		}
+		sort.Slice(stats, func(i, j int) bool {
+			if stats[i].Exchange != stats[j].Exchange {
+				return stats[i].Exchange < stats[j].Exchange
+			}
+			if stats[i].Pair != stats[j].Pair {
+				return stats[i].Pair < stats[j].Pair
+			}
+			return stats[i].Strategy < stats[j].Strategy
+		})
+		return stats
+	}
+	resp.Exchanges = summarise(exchanges)
+	resp.Pairs = summarise(pairs)
+	resp.Strategies = summarise(strategies)
+	return resp
+}
+
+// GetExecutionQuality updates the fill prices of unfilled orders from the
+// order history of exchanges with authenticated API support, then reports the
+// slippage of the orders submitted by the bot
+func GetExecutionQuality() ExecutionQualityReport {
+	now := clock.Now()
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		if exchanges[x] == nil || !exchanges[x].GetAuthenticatedAPISupport() {
+			continue
+		}
+		updateOrderFills(exchanges[x], now)
+	}
+	return getExecutionQualityReport(orderTags.GetAll(), now)
+}
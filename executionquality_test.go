@@ -0,0 +1,106 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type executionTestExchange struct {
+	accountInfoTestExchange
+	history []exchange.OrderDetail
+}
+
+func (e *executionTestExchange) GetOrderHistory(req *exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if len(req.Currencies) != 1 || req.Currencies[0].String() != "BTCUSD" {
+		return nil, nil
+	}
+	return e.history, nil
+}
+
+func TestUpdateOrderFills(t *testing.T) {
+	now := time.Unix(1000, 0)
+	orderTags.Restore([]TaggedOrder{
+		{Exchange: "Bitstamp", OrderID: "1", Pair: "BTCUSD", Submitted: now},
+		{Exchange: "Bitstamp", OrderID: "2", Pair: "BTCUSD", Submitted: now},
+		{Exchange: "Bitstamp", OrderID: "4", Pair: "BTCUSD", Amount: 2, Submitted: now},
+		{Exchange: "Bitstamp", Pair: "BTCUSD", Submitted: now},
+	})
+	defer orderTags.Restore(nil)
+
+	exch := &executionTestExchange{
+		accountInfoTestExchange: accountInfoTestExchange{name: "Bitstamp"},
+		history: []exchange.OrderDetail{
+			{ID: "1", ExecutedAmount: 1, AverageExecutedPrice: 101},
+			{ID: "2"},
+			{ID: "3", ExecutedAmount: 1, Price: 100},
+			{ID: "4", Amount: 2, ExecutedAmount: 0.5, RemainingAmount: 1.5, Price: 100},
+		},
+	}
+	updateOrderFills(exch, now)
+
+	if o, _ := orderTags.Get("Bitstamp", "1"); o.FillPrice != 101 || o.FilledAmount != 1 {
+		t.Errorf("Test failed. Expected order 1 fill to be recorded, got %+v", o)
+	}
+	if unfilled := orderTags.GetUnfilled("Bitstamp"); len(unfilled) != 2 ||
+		unfilled[0].OrderID != "2" || unfilled[1].RemainingAmount != 1.5 {
+		t.Errorf("Test failed. Expected orders 2 and partially filled 4 to remain unfilled, got %+v",
+			unfilled)
+	}
+
+	exch.history[3].ExecutedAmount = 2
+	exch.history[3].RemainingAmount = 0
+	updateOrderFills(exch, now)
+	if o, _ := orderTags.Get("Bitstamp", "4"); o.FilledAmount != 2 || o.RemainingAmount != 0 {
+		t.Errorf("Test failed. Expected order 4 to be filled, got %+v", o)
+	}
+	if unfilled := orderTags.GetUnfilled("Bitstamp"); len(unfilled) != 1 || unfilled[0].OrderID != "2" {
+		t.Errorf("Test failed. Expected order 2 to remain unfilled, got %+v", unfilled)
+	}
+}
+
+func TestGetExecutionQualityReport(t *testing.T) {
+	orders := []TaggedOrder{
+		{Exchange: "Bitstamp", Pair: "BTCUSD", Side: "BUY", ExpectedPrice: 100,
+			FillPrice: 101, FilledAmount: 1, Tags: OrderTags{OrderTagStrategy: "grid"}},
+		{Exchange: "Bitstamp", Pair: "BTCUSD", Side: "SELL", ExpectedPrice: 100,
+			FillPrice: 98, FilledAmount: 1, Tags: OrderTags{OrderTagStrategy: "grid"}},
+		{Exchange: "Bitstamp", Pair: "ETHUSD", Side: "BUY", ExpectedPrice: 200,
+			FillPrice: 199, FilledAmount: 1},
+		{Exchange: "Kraken", Pair: "BTCUSD", Side: "BUY", ExpectedPrice: 100},
+	}
+	report := getExecutionQualityReport(orders, time.Unix(1000, 0))
+	if report.Measured != 3 || report.Unfilled != 1 {
+		t.Fatalf("Test failed. Expected 3 measured and 1 unfilled order, got %d %d",
+			report.Measured, report.Unfilled)
+	}
+
+	if len(report.Exchanges) != 1 || report.Exchanges[0].Orders != 3 ||
+		report.Exchanges[0].Worst != 2 || report.Exchanges[0].Best != -0.5 ||
+		report.Exchanges[0].Median != 1 {
+		t.Errorf("Test failed. Unexpected exchange stats %+v", report.Exchanges)
+	}
+
+	if len(report.Pairs) != 2 || report.Pairs[0].Pair != "BTCUSD" ||
+		report.Pairs[0].Average != 1.5 {
+		t.Errorf("Test failed. Unexpected pair stats %+v", report.Pairs)
+	}
+
+	if len(report.Strategies) != 1 || report.Strategies[0].Strategy != "grid" ||
+		report.Strategies[0].Orders != 2 {
+		t.Errorf("Test failed. Unexpected strategy stats %+v", report.Strategies)
+	}
+}
+
+func TestGetExpectedFillPrice(t *testing.T) {
+	exch := &accountInfoTestExchange{name: "ExecutionTest"}
+	p := currency.NewPairFromStrings("BTC", "USD")
+	if price := getExpectedFillPrice(exch, p, exchange.BuyOrderSide, 50); price != 50 {
+		t.Errorf("Test failed. Expected the limit price, got %v", price)
+	}
+	if price := getExpectedFillPrice(exch, p, exchange.BuyOrderSide, 0); price != 0 {
+		t.Errorf("Test failed. Expected no price without a ticker, got %v", price)
+	}
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	expiryCalendarEventType     = "FUTURES_EXPIRY"
+	expiryCalendarCheckInterval = time.Minute
+	expiryCalendarTimeFormat    = "2006-01-02 15:04 MST"
+)
+
+// ContractExpiry is a futures contract on the expiry calendar. Alerted is set
+// once the pre-expiry alert has been sent
+type ContractExpiry struct {
+	Exchange   string        `json:"exchange"`
+	Symbol     string        `json:"symbol"`
+	Pair       currency.Pair `json:"pair"`
+	Underlying currency.Pair `json:"underlying"`
+	Alias      string        `json:"alias,omitempty"`
+	Listing    time.Time     `json:"listing"`
+	Expiry     time.Time     `json:"expiry"`
+	Alerted    bool          `json:"alerted"`
+}
+
+// expiryCalendar holds the listed futures contracts of each exchange keyed by
+// lower case exchange name then symbol
+type expiryCalendar struct {
+	contracts map[string]map[string]*ContractExpiry
+	m         sync.Mutex
+}
+
+var expiries = expiryCalendar{
+	contracts: make(map[string]map[string]*ContractExpiry),
+}
+
+// Update replaces the contracts of an exchange with those currently listed.
+// Contracts already alerted stay alerted
+func (e *expiryCalendar) Update(exchName string, listed []exchange.FuturesContract) {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	key := common.StringToLower(exchName)
+	previous := e.contracts[key]
+	contracts := make(map[string]*ContractExpiry, len(listed))
+	for x := range listed {
+		contracts[listed[x].Symbol] = &ContractExpiry{
+			Exchange:   exchName,
+			Symbol:     listed[x].Symbol,
+			Pair:       listed[x].Pair,
+			Underlying: listed[x].Underlying,
+			Alias:      listed[x].Alias,
+			Listing:    listed[x].Listing,
+			Expiry:     listed[x].Expiry,
+			Alerted:    previous[listed[x].Symbol] != nil && previous[listed[x].Symbol].Alerted,
+		}
+	}
+	e.contracts[key] = contracts
+}
+
+// Upcoming returns the contracts of an exchange, or of every exchange when
+// exchName is empty, which expire after now and within a duration ordered by
+// expiry. A zero duration returns every unexpired contract
+func (e *expiryCalendar) Upcoming(exchName string, within time.Duration, now time.Time) []ContractExpiry {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	var resp []ContractExpiry
+	for key, contracts := range e.contracts {
+		if exchName != "" && key != common.StringToLower(exchName) {
+			continue
+		}
+		for _, contract := range contracts {
+			if !contract.Expiry.After(now) ||
+				(within > 0 && contract.Expiry.After(now.Add(within))) {
+				continue
+			}
+			resp = append(resp, *contract)
+		}
+	}
+	sortContractExpiries(resp)
+	return resp
+}
+
+// Due marks the contracts which expire within the lead time and haven't been
+// alerted as alerted and returns them ordered by expiry
+func (e *expiryCalendar) Due(lead time.Duration, now time.Time) []ContractExpiry {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	var resp []ContractExpiry
+	for _, contracts := range e.contracts {
+		for _, contract := range contracts {
+			if contract.Alerted || !contract.Expiry.After(now) ||
+				contract.Expiry.After(now.Add(lead)) {
+				continue
+			}
+			contract.Alerted = true
+			resp = append(resp, *contract)
+		}
+	}
+	sortContractExpiries(resp)
+	return resp
+}
+
+// Get returns a contract of an exchange by symbol
+func (e *expiryCalendar) Get(exchName, symbol string) (ContractExpiry, bool) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	contract, ok := e.contracts[common.StringToLower(exchName)][symbol]
+	if !ok {
+		return ContractExpiry{}, false
+	}
+	return *contract, true
+}
+
+// Next returns the contract of an exchange on the same underlying which
+// expires soonest after a time, the contract positions are rolled into
+func (e *expiryCalendar) Next(exchName string, underlying currency.Pair, after time.Time) (ContractExpiry, bool) {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	var next *ContractExpiry
+	for _, contract := range e.contracts[common.StringToLower(exchName)] {
+		if !contract.Underlying.Equal(underlying) || !contract.Expiry.After(after) {
+			continue
+		}
+		if next == nil || contract.Expiry.Before(next.Expiry) {
+			next = contract
+		}
+	}
+	if next == nil {
+		return ContractExpiry{}, false
+	}
+	return *next, true
+}
+
+// sortContractExpiries orders contracts by expiry, then exchange and symbol
+func sortContractExpiries(contracts []ContractExpiry) {
+	sort.Slice(contracts, func(i, j int) bool {
+		if !contracts[i].Expiry.Equal(contracts[j].Expiry) {
+			return contracts[i].Expiry.Before(contracts[j].Expiry)
+		}
+		if contracts[i].Exchange != contracts[j].Exchange {
+			return contracts[i].Exchange < contracts[j].Exchange
+		}
+		return contracts[i].Symbol < contracts[j].Symbol
+	})
+}
+
+// ExpiryCalendarRoutine periodically refreshes the futures contracts listed on
+// exchanges and alerts contracts nearing expiry. Positions in expiring
+// contracts are rolled when auto roll is enabled
+func ExpiryCalendarRoutine() {
+	log.Debugln("Starting expiry calendar routine.")
+	var refreshed time.Time
+	for {
+		cfg := bot.config.ExpiryCalendar
+		if clock.Since(refreshed) >= cfg.RefreshInterval {
+			exchanges := GetExchanges()
+			for x := range exchanges {
+				if exchanges[x] == nil || !exchanges[x].IsEnabled() {
+					continue
+				}
+				err := refreshExpiryCalendar(exchanges[x])
+				if err != nil {
+					log.Debugf("%s failed to get futures contracts. Error: %s",
+						exchanges[x].GetName(), err)
+				}
+			}
+			refreshed = clock.Now()
+		}
+		if cfg.Enabled {
+			alertContractExpiries(&cfg, clock.Now())
+		}
+		if bot.config.AutoRoll.Enabled && tradingSupported {
+			rollExpiringPositions(GetExchanges(), &bot.config.AutoRoll, clock.Now())
+		}
+		clock.Sleep(expiryCalendarCheckInterval)
+	}
+}
+
+// refreshExpiryCalendar fetches the futures contracts listed on an exchange.
+// Exchanges which don't list dated futures are ignored
+func refreshExpiryCalendar(exch exchange.IBotExchange) error {
+	lister, ok := exch.(exchange.IFuturesContractsExchange)
+	if !ok {
+		return nil
+	}
+
+	contracts, err := lister.GetFuturesContracts()
+	if err != nil {
+		return err
+	}
+	expiries.Update(exch.GetName(), contracts)
+	return nil
+}
+
+// alertContractExpiries sends an alert for each contract expiring within the
+// lead time, naming the contract positions can be rolled into
+func alertContractExpiries(cfg *config.ExpiryCalendarConfig, now time.Time) {
+	due := expiries.Due(cfg.AlertLeadTime, now)
+	for x := range due {
+		expiry := due[x].Expiry.UTC().Format(expiryCalendarTimeFormat)
+		message := i18n.T(i18n.MessageContractExpiry, due[x].Exchange,
+			due[x].Symbol, expiry)
+		next, ok := expiries.Next(due[x].Exchange, due[x].Underlying, due[x].Expiry)
+		if ok {
+			message = i18n.T(i18n.MessageContractExpiryRoll, due[x].Exchange,
+				due[x].Symbol, expiry, next.Symbol)
+		}
+		log.Warn(message)
+		pushEvent(expiryCalendarEventType, message)
+	}
+}
+
+// GetUpcomingExpiries returns the futures contracts of an exchange, or of
+// every exchange when exchName is empty, expiring within a duration
+func GetUpcomingExpiries(exchName string, within time.Duration) []ContractExpiry {
+	return expiries.Upcoming(exchName, within, clock.Now())
+}
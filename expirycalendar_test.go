@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type expiryCalendarTestExchange struct {
+	accountInfoTestExchange
+	contracts []exchange.FuturesContract
+}
+
+func (e *expiryCalendarTestExchange) GetFuturesContracts() ([]exchange.FuturesContract, error) {
+	return e.contracts, nil
+}
+
+func expiryCalendarTestContracts(now time.Time) []exchange.FuturesContract {
+	underlying := currency.NewPairWithDelimiter("BTC", "USD", "-")
+	return []exchange.FuturesContract{
+		{Symbol: "BTC-USD-190607", Underlying: underlying, Alias: "this_week",
+			Expiry: now.Add(time.Hour * 12)},
+		{Symbol: "BTC-USD-190614", Underlying: underlying, Alias: "next_week",
+			Expiry: now.Add(time.Hour * 156)},
+		{Symbol: "BTC-USD-190628", Underlying: underlying, Alias: "quarter",
+			Expiry: now.Add(time.Hour * 516)},
+		{Symbol: "ETH-USD-190607", Underlying: currency.NewPairWithDelimiter("ETH", "USD", "-"),
+			Alias: "this_week", Expiry: now.Add(time.Hour * 12)},
+		{Symbol: "BTC-USD-190531", Underlying: underlying, Expiry: now.Add(-time.Hour)},
+	}
+}
+
+func TestExpiryCalendar(t *testing.T) {
+	calendar := expiryCalendar{contracts: make(map[string]map[string]*ContractExpiry)}
+	now := time.Date(2019, 6, 6, 20, 0, 0, 0, time.UTC)
+	calendar.Update("OKEX", expiryCalendarTestContracts(now))
+
+	upcoming := calendar.Upcoming("okex", time.Hour*24*7, now)
+	if len(upcoming) != 3 || upcoming[0].Symbol != "BTC-USD-190607" ||
+		upcoming[1].Symbol != "ETH-USD-190607" || upcoming[2].Symbol != "BTC-USD-190614" {
+		t.Errorf("Test failed. Unexpected upcoming expiries %+v", upcoming)
+	}
+	if all := calendar.Upcoming("", 0, now); len(all) != 4 {
+		t.Errorf("Test failed. Expected every unexpired contract, got %+v", all)
+	}
+
+	next, ok := calendar.Next("OKEX", currency.NewPairWithDelimiter("BTC", "USD", "-"),
+		now.Add(time.Hour*12))
+	if !ok || next.Symbol != "BTC-USD-190614" {
+		t.Errorf("Test failed. Expected the next week contract, got %+v", next)
+	}
+	_, ok = calendar.Next("OKEX", currency.NewPairWithDelimiter("ETH", "USD", "-"),
+		now.Add(time.Hour*12))
+	if ok {
+		t.Error("Test failed. Expected no contract after the last ETH contract")
+	}
+
+	due := calendar.Due(time.Hour*24, now)
+	if len(due) != 2 {
+		t.Fatalf("Test failed. Expected two contracts due, got %+v", due)
+	}
+	if due = calendar.Due(time.Hour*24, now); len(due) != 0 {
+		t.Errorf("Test failed. Expected alerted contracts to be skipped, got %+v", due)
+	}
+
+	calendar.Update("OKEX", expiryCalendarTestContracts(now))
+	if due = calendar.Due(time.Hour*24, now); len(due) != 0 {
+		t.Errorf("Test failed. Expected alerts to survive a refresh, got %+v", due)
+	}
+}
+
+func TestAlertContractExpiries(t *testing.T) {
+	now := time.Date(2019, 6, 6, 20, 0, 0, 0, time.UTC)
+	exch := &expiryCalendarTestExchange{
+		accountInfoTestExchange: accountInfoTestExchange{name: "ExpiryAlertTest"},
+		contracts:               expiryCalendarTestContracts(now),
+	}
+	err := refreshExpiryCalendar(exch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := clock.Now()
+	alertContractExpiries(&config.ExpiryCalendarConfig{AlertLeadTime: time.Hour * 24}, now)
+	events := notableEvents.Between(start, clock.Now())
+	var found bool
+	for x := range events {
+		if events[x].Type == expiryCalendarEventType &&
+			strings.Contains(events[x].Message, "BTC-USD-190607") &&
+			strings.Contains(events[x].Message, "BTC-USD-190614") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Test failed. Expected a roll alert for the expiring contract, got %+v", events)
+	}
+}
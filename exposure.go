@@ -0,0 +1,250 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+	"github.com/thrasher-/gocryptotrader/portfolio"
+)
+
+const exposureLimitEventType = "EXPOSURE_LIMIT"
+
+// CurrencyExposure is the value of a currency held across the portfolio in the
+// valuation currency and its percentage of the portfolio value. The maximums
+// are only set for currencies with a configured exposure limit
+type CurrencyExposure struct {
+	Currency   currency.Code `json:"currency"`
+	Balance    float64       `json:"balance"`
+	Value      float64       `json:"value"`
+	Percent    float64       `json:"percent"`
+	MaxValue   float64       `json:"maxValue,omitempty"`
+	MaxPercent float64       `json:"maxPercent,omitempty"`
+	Breached   bool          `json:"breached"`
+}
+
+// PortfolioExposure is the exposure to each currency of the portfolio at its
+// last valuation, largest first. Currencies without a price to the valuation
+// currency are listed as unvalued and excluded from the total
+type PortfolioExposure struct {
+	Currency    currency.Code      `json:"currency"`
+	TotalValue  float64            `json:"totalValue"`
+	Exposures   []CurrencyExposure `json:"exposures"`
+	Unvalued    []currency.Code    `json:"unvalued,omitempty"`
+	LastUpdated time.Time          `json:"lastUpdated"`
+}
+
+// exposureMonitor holds the last portfolio valuation, which orders are
+// checked against until the next valuation
+type exposureMonitor struct {
+	exposure PortfolioExposure
+	m        sync.Mutex
+}
+
+var portfolioExposure exposureMonitor
+
+// Update stores a portfolio valuation and returns the exposures whose limit
+// was breached or restored since the previous valuation
+func (e *exposureMonitor) Update(exposure *PortfolioExposure) []CurrencyExposure {
+	e.m.Lock()
+	defer e.m.Unlock()
+	breached := make(map[currency.Code]bool)
+	for x := range e.exposure.Exposures {
+		if e.exposure.Exposures[x].Breached {
+			breached[e.exposure.Exposures[x].Currency.Upper()] = true
+		}
+	}
+
+	var changed []CurrencyExposure
+	for x := range exposure.Exposures {
+		c := exposure.Exposures[x].Currency.Upper()
+		if exposure.Exposures[x].Breached != breached[c] {
+			changed = append(changed, exposure.Exposures[x])
+		}
+		delete(breached, c)
+	}
+	// Currencies no longer held are back within their limits
+	for x := range e.exposure.Exposures {
+		if breached[e.exposure.Exposures[x].Currency.Upper()] {
+			restored := e.exposure.Exposures[x]
+			restored.Balance, restored.Value, restored.Percent = 0, 0, 0
+			restored.Breached = false
+			changed = append(changed, restored)
+		}
+	}
+	e.exposure = *exposure
+	return changed
+}
+
+// Get returns the last portfolio valuation
+func (e *exposureMonitor) Get() PortfolioExposure {
+	e.m.Lock()
+	defer e.m.Unlock()
+	resp := e.exposure
+	resp.Exposures = append([]CurrencyExposure(nil), e.exposure.Exposures...)
+	return resp
+}
+
+// exceedsExposureLimit returns whether a value or percentage of the portfolio
+// is above a configured limit
+func exceedsExposureLimit(value, percent float64, limit *config.ExposureLimitConfig) bool {
+	return (limit.MaxValue > 0 && value > limit.MaxValue) ||
+		(limit.MaxPercent > 0 && percent > limit.MaxPercent)
+}
+
+// getExposureLimit returns the configured exposure limit of a currency
+func getExposureLimit(cfg *config.ExposureLimitsConfig, c currency.Code) (config.ExposureLimitConfig, bool) {
+	for x := range cfg.Limits {
+		if cfg.Limits[x].Currency.Match(c) {
+			return cfg.Limits[x], true
+		}
+	}
+	return config.ExposureLimitConfig{}, false
+}
+
+// getPortfolioExposure values the portfolio totals in the valuation currency
+// and checks the exposure to each currency against its limit
+func getPortfolioExposure(exchanges []exchange.IBotExchange, totals []portfolio.Coin, cfg *config.ExposureLimitsConfig, now time.Time) PortfolioExposure {
+	resp := PortfolioExposure{
+		Currency:    cfg.Currency,
+		LastUpdated: now,
+	}
+	for x := range totals {
+		if totals[x].Balance <= 0 {
+			continue
+		}
+		value, ok := getReportValue(exchanges, totals[x].Coin, totals[x].Balance,
+			cfg.Currency)
+		if !ok {
+			resp.Unvalued = appendUnvalued(resp.Unvalued, totals[x].Coin)
+			continue
+		}
+		resp.TotalValue += value
+		resp.Exposures = append(resp.Exposures, CurrencyExposure{
+			Currency: totals[x].Coin,
+			Balance:  totals[x].Balance,
+			Value:    value,
+		})
+	}
+
+	for x := range resp.Exposures {
+		if resp.TotalValue > 0 {
+			resp.Exposures[x].Percent = resp.Exposures[x].Value / resp.TotalValue * 100
+		}
+		limit, ok := getExposureLimit(cfg, resp.Exposures[x].Currency)
+		if !ok {
+			continue
+		}
+		resp.Exposures[x].MaxValue = limit.MaxValue
+		resp.Exposures[x].MaxPercent = limit.MaxPercent
+		resp.Exposures[x].Breached = exceedsExposureLimit(resp.Exposures[x].Value,
+			resp.Exposures[x].Percent, &limit)
+	}
+	sort.SliceStable(resp.Exposures, func(i, j int) bool {
+		return resp.Exposures[i].Value > resp.Exposures[j].Value
+	})
+	return resp
+}
+
+// ExposureLimitsRoutine periodically updates the portfolio with the exchange
+// balances, values it and alerts currencies breaching their exposure limit
+func ExposureLimitsRoutine() {
+	log.Debugln("Starting exposure limits routine.")
+	for {
+		SeedExchangeAccountInfo(GetAllEnabledExchangeAccountInfo(false).Data)
+		checkExposureLimits(GetExchanges(), portfolio.GetPortfolio(),
+			&bot.config.ExposureLimits, clock.Now())
+		clock.Sleep(bot.config.ExposureLimits.CheckInterval)
+	}
+}
+
+// checkExposureLimits values the portfolio, alerting currencies whose
+// exposure limit is breached or restored
+func checkExposureLimits(exchanges []exchange.IBotExchange, p *portfolio.Base, cfg *config.ExposureLimitsConfig, now time.Time) {
+	exposure := getPortfolioExposure(exchanges, p.GetPortfolioSummary().Totals, cfg, now)
+	changed := portfolioExposure.Update(&exposure)
+	for x := range changed {
+		var message string
+		if changed[x].Breached {
+			message = i18n.T(i18n.MessageExposureBreached, changed[x].Currency,
+				changed[x].Value, cfg.Currency, changed[x].Percent)
+			log.Warn(message)
+		} else {
+			message = i18n.T(i18n.MessageExposureRestored, changed[x].Currency,
+				changed[x].Value, cfg.Currency, changed[x].Percent)
+			log.Info(message)
+		}
+		pushEvent(exposureLimitEventType, message)
+	}
+}
+
+// checkOrderExposureLimit returns an error if an order would accumulate a
+// currency whose exposure limit is breached, or take it above its limit at
+// the last portfolio valuation
+func checkOrderExposureLimit(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, amount, price float64) error {
+	if bot.config == nil || !bot.config.ExposureLimits.Enabled {
+		return nil
+	}
+	cfg := &bot.config.ExposureLimits
+
+	var receive currency.Code
+	switch side {
+	case exchange.BuyOrderSide, exchange.BidOrderSide:
+		receive = p.Base
+	case exchange.SellOrderSide, exchange.AskOrderSide:
+		receive = p.Quote
+	default:
+		return nil
+	}
+	limit, ok := getExposureLimit(cfg, receive)
+	if !ok {
+		return nil
+	}
+
+	exposure := portfolioExposure.Get()
+	var current CurrencyExposure
+	for x := range exposure.Exposures {
+		if exposure.Exposures[x].Currency.Match(receive) {
+			current = exposure.Exposures[x]
+			break
+		}
+	}
+	if current.Breached {
+		return fmt.Errorf("%s exposure limit breached, blocking %s %s %s order",
+			receive, exch.GetName(), p, side)
+	}
+
+	_, _, _, received, err := getOrderFlows(exch, p, side, amount, price)
+	if err != nil {
+		return err
+	}
+	value, ok := getReportValue(GetExchanges(), receive, received.Float64(), cfg.Currency)
+	if !ok {
+		return fmt.Errorf("unable to value %s %s order against %s exposure limit",
+			exch.GetName(), p, receive)
+	}
+	// Trading between portfolio currencies leaves the total value unchanged
+	value += current.Value
+	var percent float64
+	if exposure.TotalValue > 0 {
+		percent = value / exposure.TotalValue * 100
+	}
+	if exceedsExposureLimit(value, percent, &limit) {
+		return fmt.Errorf("%s %s %s order would take %s exposure to %f %s (%.2f%% of portfolio) above its limit",
+			exch.GetName(), p, side, receive, value, cfg.Currency, percent)
+	}
+	return nil
+}
+
+// GetPortfolioExposure returns the exposure to each currency at the last
+// portfolio valuation
+func GetPortfolioExposure() PortfolioExposure {
+	return portfolioExposure.Get()
+}
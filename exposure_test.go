@@ -0,0 +1,113 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/portfolio"
+)
+
+func TestPortfolioExposure(t *testing.T) {
+	exch := &futuresBasisTestExchange{accountInfoTestExchange{name: "ExposureTest"}}
+	exchanges := []exchange.IBotExchange{exch}
+	btc := currency.NewCode("EXPBTC")
+	eth := currency.NewCode("EXPETH")
+	processFuturesBasisTestTicker(t, exch.GetName(), currency.NewPair(btc, currency.USD),
+		ticker.Spot, 100)
+	processFuturesBasisTestTicker(t, exch.GetName(), currency.NewPair(eth, currency.USD),
+		ticker.Spot, 10)
+
+	cfg := config.ExposureLimitsConfig{
+		Currency: currency.USD,
+		Limits: []config.ExposureLimitConfig{
+			{Currency: btc, MaxPercent: 50},
+			{Currency: eth, MaxValue: 500},
+		},
+	}
+	totals := []portfolio.Coin{
+		{Coin: btc, Balance: 6},
+		{Coin: eth, Balance: 30},
+		{Coin: currency.USD, Balance: 100},
+		{Coin: currency.NewCode("EXPUNVALUED"), Balance: 1},
+	}
+
+	var monitor exposureMonitor
+	exposure := getPortfolioExposure(exchanges, totals, &cfg, time.Now())
+	if exposure.TotalValue != 1000 || len(exposure.Exposures) != 3 ||
+		len(exposure.Unvalued) != 1 {
+		t.Fatalf("Test failed. Unexpected portfolio exposure %+v", exposure)
+	}
+	if !exposure.Exposures[0].Currency.Match(btc) || exposure.Exposures[0].Percent != 60 ||
+		!exposure.Exposures[0].Breached || exposure.Exposures[1].Breached {
+		t.Errorf("Test failed. Expected only the BTC limit to be breached, got %+v",
+			exposure.Exposures)
+	}
+	changed := monitor.Update(&exposure)
+	if len(changed) != 1 || !changed[0].Currency.Match(btc) {
+		t.Errorf("Test failed. Expected the BTC limit breach to be reported, got %+v", changed)
+	}
+	if changed = monitor.Update(&exposure); len(changed) != 0 {
+		t.Errorf("Test failed. Expected a breach to only be reported once, got %+v", changed)
+	}
+
+	totals[0].Balance = 0
+	exposure = getPortfolioExposure(exchanges, totals, &cfg, time.Now())
+	changed = monitor.Update(&exposure)
+	if len(changed) != 1 || !changed[0].Currency.Match(btc) || changed[0].Breached {
+		t.Errorf("Test failed. Expected the BTC limit to be restored, got %+v", changed)
+	}
+}
+
+func TestCheckOrderExposureLimit(t *testing.T) {
+	setupAccountInfoTest(t, time.Minute)
+	exch := &futuresBasisTestExchange{accountInfoTestExchange{name: "ExposureOrderTest"}}
+	ltc := currency.NewCode("EXPLTC")
+	p := currency.NewPair(ltc, currency.USD)
+	processFuturesBasisTestTicker(t, exch.GetName(), p, ticker.Spot, 10)
+
+	exchanges := bot.exchanges
+	bot.exchanges = []exchange.IBotExchange{exch}
+	previous := bot.config.ExposureLimits
+	bot.config.ExposureLimits = config.ExposureLimitsConfig{
+		Enabled:  true,
+		Currency: currency.USD,
+		Limits:   []config.ExposureLimitConfig{{Currency: ltc, MaxValue: 500}},
+	}
+	defer func() {
+		bot.exchanges = exchanges
+		bot.config.ExposureLimits = previous
+		portfolioExposure = exposureMonitor{}
+	}()
+
+	exposure := getPortfolioExposure(bot.exchanges, []portfolio.Coin{
+		{Coin: ltc, Balance: 40},
+		{Coin: currency.USD, Balance: 600},
+	}, &bot.config.ExposureLimits, time.Now())
+	portfolioExposure.Update(&exposure)
+
+	err := checkOrderExposureLimit(exch, p, exchange.BuyOrderSide, 5, 10)
+	if err != nil {
+		t.Errorf("Test failed. Expected an order within the limit, got %s", err)
+	}
+	err = checkOrderExposureLimit(exch, p, exchange.BuyOrderSide, 20, 0)
+	if err == nil {
+		t.Error("Test failed. Expected an order above the limit to be blocked")
+	}
+	err = checkOrderExposureLimit(exch, p, exchange.SellOrderSide, 20, 0)
+	if err != nil {
+		t.Errorf("Test failed. Expected orders reducing exposure to be allowed, got %s", err)
+	}
+
+	exposure = getPortfolioExposure(bot.exchanges, []portfolio.Coin{
+		{Coin: ltc, Balance: 60},
+	}, &bot.config.ExposureLimits, time.Now())
+	portfolioExposure.Update(&exposure)
+	err = checkOrderExposureLimit(exch, p, exchange.BuyOrderSide, 0.1, 10)
+	if err == nil {
+		t.Error("Test failed. Expected orders to be blocked while the limit is breached")
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/exchanges/faults"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// setupFaultInjection applies the fault injection config of an exchange,
+// removing any faults previously injected into it
+func setupFaultInjection(exchName string, cfg *config.FaultInjectionConfig) {
+	faults.Remove(exchName)
+	if cfg == nil || !cfg.Enabled {
+		return
+	}
+
+	err := faults.Set(exchName, faults.Config{
+		Seed:        cfg.Seed,
+		MaxLatency:  cfg.MaxLatency,
+		DropRate:    cfg.DropRate,
+		ErrorRate:   cfg.ErrorRate,
+		BurstLength: cfg.BurstLength,
+		StatusCodes: cfg.StatusCodes,
+	})
+	if err != nil {
+		log.Warnf("%s fault injection not enabled: %s", exchName, err)
+		return
+	}
+	log.Warnf("%s fault injection enabled, requests and websocket connections will fail.",
+		exchName)
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// fundingConversionBuffer is the fraction added to funding conversions so
+// fees and price movement do not leave the order short
+const fundingConversionBuffer = 0.005
+
+// FundedOrder holds an order submitted with funding selection. Pair is the
+// pair the order was placed on, which is an equivalent pair when the account
+// was funded in FundingCurrency rather than the requested quote currency.
+// ConversionOrderID is set when an equivalent balance was converted first
+type FundedOrder struct {
+	Pair              currency.Pair                `json:"pair"`
+	FundingCurrency   currency.Code                `json:"fundingCurrency"`
+	ConversionOrderID string                       `json:"conversionOrderId,omitempty"`
+	Order             exchange.SubmitOrderResponse `json:"order"`
+}
+
+// SubmitFundedOrder submits an order to an exchange. When the account lacks the
+// quote currency of a buy order the funding mode either places it on an
+// equivalent pair or converts an equivalent balance first. Orders are recorded
+// with their tags
+func SubmitFundedOrder(exchName, currencyPair, side, orderType string, amount, price float64, funding string, tags OrderTags) (FundedOrder, error) {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return FundedOrder{}, errors.New(exchange.ErrExchangeNotFound)
+	}
+
+	s, t, err := parseOrderRequest(side, orderType, amount)
+	if err != nil {
+		return FundedOrder{}, err
+	}
+
+	return submitFundedOrder(exch, currency.NewPairFromString(currencyPair), s,
+		t, amount, price, "", common.StringToLower(funding), tags)
+}
+
+// parseOrderRequest validates the side, type and amount of a requested order
+func parseOrderRequest(side, orderType string, amount float64) (exchange.OrderSide, exchange.OrderType, error) {
+	s := exchange.OrderSide(common.StringToUpper(side))
+	switch s {
+	case exchange.BuyOrderSide, exchange.SellOrderSide:
+	default:
+		return "", "", i18n.Errorf(i18n.MessageInvalidOrderSide, side)
+	}
+
+	t := exchange.OrderType(common.StringToUpper(orderType))
+	switch t {
+	case exchange.LimitOrderType, exchange.MarketOrderType:
+	default:
+		return "", "", i18n.Errorf(i18n.MessageInvalidOrderType, orderType)
+	}
+
+	if amount <= 0 {
+		return "", "", i18n.Errorf(i18n.MessageAmountNotPositive)
+	}
+	return s, t, nil
+}
+
+// submitFundedOrder selects the funding of an order. Sell orders and buy
+// orders the quote balance covers are submitted unchanged
+func submitFundedOrder(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID, funding string, tags OrderTags) (FundedOrder, error) {
+	resp := FundedOrder{Pair: p, FundingCurrency: p.Quote}
+	if funding == config.OrderFundingNone || side != exchange.BuyOrderSide {
+		var err error
+		resp.Order, err = SubmitTaggedOrder(exch, p, side, orderType, amount,
+			price, clientID, tags)
+		return resp, err
+	}
+
+	if funding != config.OrderFundingPair && funding != config.OrderFundingConvert {
+		return resp, fmt.Errorf("invalid funding mode %s", funding)
+	}
+
+	required, err := getOrderValue(exch, p, amount, price)
+	if err != nil {
+		return resp, err
+	}
+
+	info, err := GetExchangeAccountInfo(exch, false)
+	if err != nil {
+		return resp, err
+	}
+
+	available := getSpendableBalance(exch, &info, p.Quote)
+	if available < required {
+		switch funding {
+		case config.OrderFundingPair:
+			resp, err = selectFundingPair(exch, &info, p, amount, price)
+		case config.OrderFundingConvert:
+			resp, err = convertFunding(exch, &info, p, required-available, tags)
+		}
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	resp.Order, err = SubmitTaggedOrder(exch, resp.Pair, side, orderType, amount,
+		price, clientID, tags)
+	return resp, err
+}
+
+// getOrderValue returns the quote currency value of an order, market orders
+// are valued at the last ticker price
+func getOrderValue(exch exchange.IBotExchange, p currency.Pair, amount, price float64) (float64, error) {
+	if price > 0 {
+		return amount * price, nil
+	}
+
+	t, err := ticker.GetTicker(exch.GetName(), p, ticker.Spot)
+	if err != nil || t.Last <= 0 {
+		return 0, fmt.Errorf("unable to value %s %s order", exch.GetName(), p)
+	}
+	return amount * t.Last, nil
+}
+
+// getSpendableBalance returns the available balance of a currency above its
+// configured balance reserve
+func getSpendableBalance(exch exchange.IBotExchange, info *exchange.AccountInfo, c currency.Code) float64 {
+	return getAvailableBalance(info, c) - getBalanceReserve(exch.GetName(), c)
+}
+
+// getFundingEquivalents returns the configured equivalents of a quote
+// currency on an exchange
+func getFundingEquivalents(exch exchange.IBotExchange, c currency.Code) currency.Currencies {
+	if bot.config == nil {
+		return nil
+	}
+
+	exchCfg, err := bot.config.GetExchangeConfig(exch.GetName())
+	if err != nil {
+		return nil
+	}
+	return exchCfg.GetFundingEquivalents(c)
+}
+
+// selectFundingPair returns an enabled pair quoted in an equivalent currency
+// the account holds enough of to place the order
+func selectFundingPair(exch exchange.IBotExchange, info *exchange.AccountInfo, p currency.Pair, amount, price float64) (FundedOrder, error) {
+	pairs := exch.GetEnabledCurrencies()
+	equivalents := getFundingEquivalents(exch, p.Quote)
+	for x := range equivalents {
+		alt := currency.NewPairWithDelimiter(p.Base.String(),
+			equivalents[x].String(), p.Delimiter)
+		if !pairs.Contains(alt, true) {
+			continue
+		}
+
+		required, err := getOrderValue(exch, alt, amount, price)
+		if err != nil {
+			continue
+		}
+
+		if getSpendableBalance(exch, info, equivalents[x]) >= required {
+			log.Debugf("%s insufficient %s to buy %s, using %s", exch.GetName(),
+				p.Quote, p, alt)
+			return FundedOrder{Pair: alt, FundingCurrency: equivalents[x]}, nil
+		}
+	}
+	return FundedOrder{Pair: p, FundingCurrency: p.Quote},
+		fmt.Errorf("%s insufficient %s funds and no equivalent pair is funded",
+			exch.GetName(), p.Quote)
+}
+
+// convertFunding converts an equivalent balance into the shortfall of the
+// quote currency with a market order, tagged as a funding conversion of the
+// order it funds
+func convertFunding(exch exchange.IBotExchange, info *exchange.AccountInfo, p currency.Pair, shortfall float64, tags OrderTags) (FundedOrder, error) {
+	resp := FundedOrder{Pair: p, FundingCurrency: p.Quote}
+	pairs := exch.GetEnabledCurrencies()
+	shortfall *= 1 + fundingConversionBuffer
+
+	equivalents := getFundingEquivalents(exch, p.Quote)
+	for x := range equivalents {
+		for y := range pairs {
+			var side exchange.OrderSide
+			var amount, cost float64
+			switch {
+			case pairs[y].Base.Match(equivalents[x]) && pairs[y].Quote.Match(p.Quote):
+				// Sell the equivalent for the quote currency
+				t, err := ticker.GetTicker(exch.GetName(), pairs[y], ticker.Spot)
+				if err != nil || t.Last <= 0 {
+					continue
+				}
+				side = exchange.SellOrderSide
+				amount = shortfall / t.Last
+				cost = amount
+			case pairs[y].Base.Match(p.Quote) && pairs[y].Quote.Match(equivalents[x]):
+				// Buy the quote currency with the equivalent
+				var err error
+				cost, err = getOrderValue(exch, pairs[y], shortfall, 0)
+				if err != nil {
+					continue
+				}
+				side = exchange.BuyOrderSide
+				amount = shortfall
+			default:
+				continue
+			}
+
+			if getSpendableBalance(exch, info, equivalents[x]) < cost {
+				continue
+			}
+
+			order, err := SubmitTaggedOrder(exch, pairs[y], side,
+				exchange.MarketOrderType, amount, 0, "",
+				tags.With(OrderTagReason, "funding conversion"))
+			if err != nil {
+				return resp, fmt.Errorf("%s funding conversion via %s failed: %s",
+					exch.GetName(), pairs[y], err)
+			}
+			log.Debugf("%s converted %s into %f %s to fund %s order",
+				exch.GetName(), equivalents[x], shortfall, p.Quote, p)
+			resp.FundingCurrency = equivalents[x]
+			resp.ConversionOrderID = order.OrderID
+			return resp, nil
+		}
+	}
+	return resp, fmt.Errorf("%s insufficient %s funds and no equivalent balance can be converted",
+		exch.GetName(), p.Quote)
+}
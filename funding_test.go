@@ -0,0 +1,120 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+type fundingTestExchange struct {
+	accountInfoTestExchange
+	orders []currency.Pair
+	sides  []exchange.OrderSide
+}
+
+func (f *fundingTestExchange) GetAccountInfo() (exchange.AccountInfo, error) {
+	return exchange.AccountInfo{
+		Exchange: f.name,
+		Accounts: []exchange.Account{{
+			Currencies: []exchange.AccountCurrencyInfo{
+				exchange.NewAccountCurrencyInfoFromTotal(currency.USD, 100, 0),
+				exchange.NewAccountCurrencyInfoFromTotal(currency.USDT, 1000, 0),
+			},
+		}},
+	}, nil
+}
+
+func (f *fundingTestExchange) GetEnabledCurrencies() currency.Pairs {
+	return currency.Pairs{
+		currency.NewPairFromStrings("BTC", "USD"),
+		currency.NewPairFromStrings("BTC", "USDT"),
+		currency.NewPairFromStrings("USDT", "USD"),
+	}
+}
+
+func (f *fundingTestExchange) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	f.orders = append(f.orders, p)
+	f.sides = append(f.sides, side)
+	return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "1"}, nil
+}
+
+func setupFundingTest(t *testing.T) *fundingTestExchange {
+	exch := setupAccountInfoTest(t, time.Minute)
+	exchCfg, err := bot.config.GetExchangeConfig(exch.GetName())
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchCfg.FundingMode = config.OrderFundingPair
+	exchCfg.FundingEquivalents = nil
+	exchCfg.CheckOrderFunding()
+	err = bot.config.UpdateExchangeConfig(&exchCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ticker.ProcessTicker(exch.GetName(),
+		&ticker.Price{Pair: currency.NewPairFromStrings("USDT", "USD"), Last: 1},
+		ticker.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &fundingTestExchange{accountInfoTestExchange: *exch}
+}
+
+func TestSubmitFundedOrder(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+
+	exch := setupFundingTest(t)
+	p := currency.NewPairFromStrings("BTC", "USD")
+
+	resp, err := submitFundedOrder(exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 0.01, 5000, "", config.OrderFundingPair, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Pair.Equal(p) || len(exch.orders) != 1 {
+		t.Errorf("Test failed. Expected funded order on %s, got %s", p, resp.Pair)
+	}
+
+	resp, err = submitFundedOrder(exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 0.1, 5000, "", config.OrderFundingPair, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Pair.String() != "BTCUSDT" || !resp.FundingCurrency.Match(currency.USDT) {
+		t.Errorf("Test failed. Expected the USDT pair to be selected, got %s",
+			resp.Pair)
+	}
+
+	exch.orders, exch.sides = nil, nil
+	resp, err = submitFundedOrder(exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 0.1, 5000, "", config.OrderFundingConvert, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exch.orders) != 2 || exch.orders[0].String() != "USDTUSD" ||
+		exch.sides[0] != exchange.SellOrderSide || !exch.orders[1].Equal(p) ||
+		resp.ConversionOrderID == "" {
+		t.Errorf("Test failed. Expected USDT conversion before the order, got %v",
+			exch.orders)
+	}
+
+	_, err = submitFundedOrder(exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 1, 5000, "", config.OrderFundingPair, nil)
+	if err == nil {
+		t.Error("Test failed. Expected error when no funding is available")
+	}
+
+	exch.orders = nil
+	_, err = submitFundedOrder(exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 1, 5000, "", config.OrderFundingNone, nil)
+	if err != nil || len(exch.orders) != 1 {
+		t.Error("Test failed. Expected order to be submitted unchanged without funding")
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	futuresBasisEventType = "FUTURES_BASIS"
+	daysPerYear           = 365
+)
+
+// FuturesBasis is the premium of a futures contract over the spot index of
+// its underlying. The index is the average last spot price of the underlying
+// pair across enabled exchanges. Annualised is the basis percentage scaled to
+// a year over the time left to expiry, the return of a cash-and-carry trade
+type FuturesBasis struct {
+	Exchange     string        `json:"exchange"`
+	Symbol       string        `json:"symbol"`
+	Underlying   currency.Pair `json:"underlying"`
+	Expiry       time.Time     `json:"expiry"`
+	FuturesPrice float64       `json:"futuresPrice"`
+	IndexPrice   float64       `json:"indexPrice"`
+	Basis        float64       `json:"basis"`
+	Percent      float64       `json:"percent"`
+	Annualised   float64       `json:"annualised"`
+}
+
+// CalendarSpread is the premium of a far futures contract over a nearer
+// contract on the same underlying, which may be listed on another exchange.
+// Annualised scales the spread percentage over the time between expiries
+type CalendarSpread struct {
+	Underlying   currency.Pair `json:"underlying"`
+	NearExchange string        `json:"nearExchange"`
+	NearSymbol   string        `json:"nearSymbol"`
+	NearExpiry   time.Time     `json:"nearExpiry"`
+	NearPrice    float64       `json:"nearPrice"`
+	FarExchange  string        `json:"farExchange"`
+	FarSymbol    string        `json:"farSymbol"`
+	FarExpiry    time.Time     `json:"farExpiry"`
+	FarPrice     float64       `json:"farPrice"`
+	Spread       float64       `json:"spread"`
+	Percent      float64       `json:"percent"`
+	Annualised   float64       `json:"annualised"`
+}
+
+// futuresBasisMonitor holds the contracts trading above the annualised basis
+// threshold keyed by lower case exchange name and symbol
+type futuresBasisMonitor struct {
+	above map[string]bool
+	m     sync.Mutex
+}
+
+var futuresBasisAlerts = futuresBasisMonitor{
+	above: make(map[string]bool),
+}
+
+// Record records whether a contract trades above the threshold. It returns
+// true when the contract crosses the threshold in either direction
+func (f *futuresBasisMonitor) Record(exchName, symbol string, above bool) bool {
+	f.m.Lock()
+	defer f.m.Unlock()
+	key := common.StringToLower(exchName) + "|" + symbol
+	changed := f.above[key] != above
+	f.above[key] = above
+	return changed
+}
+
+// annualise scales a percentage earned over a duration to a year
+func annualise(percent float64, d time.Duration) float64 {
+	days := d.Hours() / 24
+	if days <= 0 {
+		return 0
+	}
+	return percent * daysPerYear / days
+}
+
+// getFuturesPrice returns the last stored ticker price of a futures contract
+func getFuturesPrice(contract *ContractExpiry) (float64, bool) {
+	for _, assetType := range []string{ticker.Futures, ticker.Spot} {
+		price, err := ticker.GetTicker(contract.Exchange, contract.Pair, assetType)
+		if err == nil && !price.Stale && price.Last > 0 {
+			return price.Last, true
+		}
+	}
+	return 0, false
+}
+
+// getSpotIndexPrice returns the average last spot price of a pair across the
+// enabled exchanges with a stored ticker
+func getSpotIndexPrice(exchanges []exchange.IBotExchange, p currency.Pair) (float64, bool) {
+	spot := currency.NewPair(p.Base, p.Quote)
+	var total float64
+	var count int
+	for _, exch := range exchanges {
+		if exch == nil || !exch.IsEnabled() {
+			continue
+		}
+		price, err := ticker.GetTicker(exch.GetName(), spot, ticker.Spot)
+		if err != nil || price.Stale || price.Last <= 0 {
+			continue
+		}
+		total += price.Last
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return total / float64(count), true
+}
+
+// getFuturesBases returns the basis of each unexpired contract on the expiry
+// calendar with a futures and spot index price, highest annualised first
+func getFuturesBases(exchanges []exchange.IBotExchange, contracts []ContractExpiry, now time.Time) []FuturesBasis {
+	var resp []FuturesBasis
+	for x := range contracts {
+		futuresPrice, ok := getFuturesPrice(&contracts[x])
+		if !ok {
+			continue
+		}
+		indexPrice, ok := getSpotIndexPrice(exchanges, contracts[x].Underlying)
+		if !ok {
+			continue
+		}
+
+		basis := FuturesBasis{
+			Exchange:     contracts[x].Exchange,
+			Symbol:       contracts[x].Symbol,
+			Underlying:   contracts[x].Underlying,
+			Expiry:       contracts[x].Expiry,
+			FuturesPrice: futuresPrice,
+			IndexPrice:   indexPrice,
+			Basis:        futuresPrice - indexPrice,
+		}
+		basis.Percent = basis.Basis / indexPrice * 100
+		basis.Annualised = annualise(basis.Percent, contracts[x].Expiry.Sub(now))
+		resp = append(resp, basis)
+	}
+	sort.SliceStable(resp, func(i, j int) bool {
+		return resp[i].Annualised > resp[j].Annualised
+	})
+	return resp
+}
+
+// getCalendarSpreads returns the spread between each pair of contracts on the
+// same underlying with different expiries and a futures price, ordered by
+// underlying then near and far expiry
+func getCalendarSpreads(contracts []ContractExpiry) []CalendarSpread {
+	sorted := append([]ContractExpiry(nil), contracts...)
+	sortContractExpiries(sorted)
+	prices := make([]float64, len(sorted))
+	for x := range sorted {
+		prices[x], _ = getFuturesPrice(&sorted[x])
+	}
+
+	var resp []CalendarSpread
+	for x := range sorted {
+		if prices[x] <= 0 {
+			continue
+		}
+		for y := x + 1; y < len(sorted); y++ {
+			if prices[y] <= 0 || !sorted[y].Underlying.Equal(sorted[x].Underlying) ||
+				!sorted[y].Expiry.After(sorted[x].Expiry) {
+				continue
+			}
+			spread := CalendarSpread{
+				Underlying:   sorted[x].Underlying,
+				NearExchange: sorted[x].Exchange,
+				NearSymbol:   sorted[x].Symbol,
+				NearExpiry:   sorted[x].Expiry,
+				NearPrice:    prices[x],
+				FarExchange:  sorted[y].Exchange,
+				FarSymbol:    sorted[y].Symbol,
+				FarExpiry:    sorted[y].Expiry,
+				FarPrice:     prices[y],
+				Spread:       prices[y] - prices[x],
+			}
+			spread.Percent = spread.Spread / prices[x] * 100
+			spread.Annualised = annualise(spread.Percent,
+				sorted[y].Expiry.Sub(sorted[x].Expiry))
+			resp = append(resp, spread)
+		}
+	}
+	sort.SliceStable(resp, func(i, j int) bool {
+		return resp[i].Underlying.String() < resp[j].Underlying.String()
+	})
+	return resp
+}
+
+// FuturesBasisRoutine periodically alerts futures contracts whose annualised
+// basis crosses the configured threshold
+func FuturesBasisRoutine() {
+	log.Debugln("Starting futures basis routine.")
+	for {
+		checkFuturesBasis(GetExchanges(), bot.config.FuturesBasis.AnnualisedThreshold,
+			clock.Now())
+		clock.Sleep(bot.config.FuturesBasis.CheckInterval)
+	}
+}
+
+// checkFuturesBasis alerts contracts crossing the annualised basis threshold
+func checkFuturesBasis(exchanges []exchange.IBotExchange, threshold float64, now time.Time) {
+	bases := getFuturesBases(exchanges, expiries.Upcoming("", 0, now), now)
+	for x := range bases {
+		above := bases[x].Annualised > threshold
+		if !futuresBasisAlerts.Record(bases[x].Exchange, bases[x].Symbol, above) {
+			continue
+		}
+
+		var message string
+		if above {
+			message = i18n.T(i18n.MessageBasisAbove, bases[x].Exchange, bases[x].Symbol,
+				bases[x].Annualised, threshold, bases[x].FuturesPrice, bases[x].IndexPrice)
+			log.Warn(message)
+		} else {
+			message = i18n.T(i18n.MessageBasisBelow, bases[x].Exchange, bases[x].Symbol,
+				bases[x].Annualised)
+			log.Info(message)
+		}
+		pushEvent(futuresBasisEventType, message)
+	}
+}
+
+// GetFuturesBases returns the current basis of the futures contracts on the
+// expiry calendar, highest annualised first
+func GetFuturesBases() []FuturesBasis {
+	now := clock.Now()
+	return getFuturesBases(GetExchanges(), expiries.Upcoming("", 0, now), now)
+}
+
+// GetCalendarSpreads returns the current calendar spreads between the futures
+// contracts on the expiry calendar
+func GetCalendarSpreads() []CalendarSpread {
+	return getCalendarSpreads(expiries.Upcoming("", 0, clock.Now()))
+}
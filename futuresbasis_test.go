@@ -0,0 +1,119 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+type futuresBasisTestExchange struct {
+	accountInfoTestExchange
+}
+
+func (f *futuresBasisTestExchange) IsEnabled() bool {
+	return true
+}
+
+func processFuturesBasisTestTicker(t *testing.T, exchName string, p currency.Pair, tickerType string, last float64) {
+	err := ticker.ProcessTicker(exchName, &ticker.Price{Pair: p, Last: last}, tickerType)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAnnualise(t *testing.T) {
+	if v := annualise(1, time.Hour*24*73); v < 5-1e-9 || v > 5+1e-9 {
+		t.Errorf("Test failed. Expected 5%% annualised, got %f", v)
+	}
+	if v := annualise(1, 0); v != 0 {
+		t.Errorf("Test failed. Expected no annualised return at expiry, got %f", v)
+	}
+}
+
+func TestFuturesBasis(t *testing.T) {
+	now := time.Date(2019, 6, 7, 8, 0, 0, 0, time.UTC)
+	underlying := currency.NewPairWithDelimiter("LTC", "USD", "-")
+	near := currency.NewPairWithDelimiter("LTC-USD", "190705", "_")
+	far := currency.NewPairWithDelimiter("LTC-USD", "190927", "_")
+	futuresExch := &futuresBasisTestExchange{accountInfoTestExchange{name: "BasisFuturesTest"}}
+	spotExch := &futuresBasisTestExchange{accountInfoTestExchange{name: "BasisSpotTest"}}
+	exchanges := []exchange.IBotExchange{futuresExch, spotExch}
+
+	contracts := []ContractExpiry{
+		{Exchange: futuresExch.GetName(), Symbol: "LTC-USD-190927", Pair: far,
+			Underlying: underlying, Expiry: now.Add(time.Hour * 24 * 112)},
+		{Exchange: futuresExch.GetName(), Symbol: "LTC-USD-190705", Pair: near,
+			Underlying: underlying, Expiry: now.Add(time.Hour * 24 * 28)},
+	}
+	processFuturesBasisTestTicker(t, futuresExch.GetName(), near, ticker.Futures, 101)
+	processFuturesBasisTestTicker(t, futuresExch.GetName(), far, ticker.Futures, 104)
+	processFuturesBasisTestTicker(t, spotExch.GetName(),
+		currency.NewPair(underlying.Base, underlying.Quote), ticker.Spot, 100)
+
+	bases := getFuturesBases(exchanges, contracts, now)
+	if len(bases) != 2 {
+		t.Fatalf("Test failed. Expected the basis of both contracts, got %+v", bases)
+	}
+	// 1% over 28 days and 4% over 112 days both annualise to 13.04%
+	for x := range bases {
+		if bases[x].IndexPrice != 100 || bases[x].Annualised < 13.03 ||
+			bases[x].Annualised > 13.04 {
+			t.Errorf("Test failed. Unexpected basis %+v", bases[x])
+		}
+	}
+
+	spreads := getCalendarSpreads(contracts)
+	if len(spreads) != 1 {
+		t.Fatalf("Test failed. Expected a single calendar spread, got %+v", spreads)
+	}
+	// 2.97% over the 84 days between expiries
+	if spreads[0].NearSymbol != "LTC-USD-190705" || spreads[0].FarSymbol != "LTC-USD-190927" ||
+		spreads[0].Spread != 3 || spreads[0].Annualised < 12.90 || spreads[0].Annualised > 12.91 {
+		t.Errorf("Test failed. Unexpected calendar spread %+v", spreads[0])
+	}
+}
+
+func TestCheckFuturesBasis(t *testing.T) {
+	now := clock.Now()
+	underlying := currency.NewPairWithDelimiter("XRP", "USD", "-")
+	pair := currency.NewPairWithDelimiter("XRP-USD", "190705", "_")
+	exch := &futuresBasisTestExchange{accountInfoTestExchange{name: "BasisAlertTest"}}
+	expiries.Update(exch.GetName(), []exchange.FuturesContract{
+		{Symbol: "XRP-USD-190705", Pair: pair, Underlying: underlying,
+			Expiry: now.Add(time.Hour * 24 * 10)},
+	})
+	processFuturesBasisTestTicker(t, exch.GetName(),
+		currency.NewPair(underlying.Base, underlying.Quote), ticker.Spot, 1)
+	processFuturesBasisTestTicker(t, exch.GetName(), pair, ticker.Futures, 1.01)
+
+	countEvents := func(start time.Time, contains string) int {
+		var count int
+		events := notableEvents.Between(start, clock.Now())
+		for x := range events {
+			if events[x].Type == futuresBasisEventType &&
+				strings.Contains(events[x].Message, "XRP-USD-190705") &&
+				strings.Contains(events[x].Message, contains) {
+				count++
+			}
+		}
+		return count
+	}
+
+	start := clock.Now()
+	checkFuturesBasis([]exchange.IBotExchange{exch}, 20, now)
+	checkFuturesBasis([]exchange.IBotExchange{exch}, 20, now)
+	if count := countEvents(start, "exceeds"); count != 1 {
+		t.Errorf("Test failed. Expected a single threshold alert, got %d", count)
+	}
+
+	processFuturesBasisTestTicker(t, exch.GetName(), pair, ticker.Futures, 1.001)
+	checkFuturesBasis([]exchange.IBotExchange{exch}, 20, now)
+	if count := countEvents(start, "fell back"); count != 1 {
+		t.Errorf("Test failed. Expected an alert once the basis fell back, got %d", count)
+	}
+}
@@ -6,6 +6,8 @@ require (
 	github.com/google/go-querystring v1.0.0
 	github.com/gorilla/mux v1.7.2
 	github.com/gorilla/websocket v1.4.0
+	github.com/lib/pq v1.2.0
+	github.com/mattn/go-sqlite3 v1.11.0
 	github.com/toorop/go-pusher v0.0.0-20180521062818-4521e2eb39fb
 	golang.org/x/crypto v0.0.0-20190513172903-22d7a77e9e5f
 )
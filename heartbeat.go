@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const heartbeatTimeout = time.Second * 10
+
+// HeartbeatStatus holds the summary status sent to external uptime monitors.
+// An exchange is up when none of its endpoints are degraded, LastError is the
+// most recent endpoint failure. GeoRestricted lists the enabled exchanges with
+// endpoints blocked for the location of the IP address
+type HeartbeatStatus struct {
+	Status              string        `json:"status"`
+	Uptime              time.Duration `json:"uptime"`
+	ExchangesEnabled    int           `json:"exchangesEnabled"`
+	ExchangesUp         int           `json:"exchangesUp"`
+	GeoRestricted       []string      `json:"geoRestricted,omitempty"`
+	WebsocketsEnabled   int           `json:"websocketsEnabled"`
+	WebsocketsConnected int           `json:"websocketsConnected"`
+	LastError           string        `json:"lastError,omitempty"`
+	LastErrorTime       time.Time     `json:"lastErrorTime,omitempty"`
+	Timestamp           time.Time     `json:"timestamp"`
+}
+
+// HeartbeatRoutine periodically sends the bot status to the configured uptime
+// monitor URLs, monitors alert when heartbeats stop arriving
+func HeartbeatRoutine() {
+	log.Debugln("Starting heartbeat routine.")
+	client := common.NewHTTPClientWithTimeout(heartbeatTimeout)
+	for {
+		cfg := bot.config.Heartbeat
+		status := getHeartbeatStatus(GetExchanges(), endpointHealth.GetAll(),
+			bot.started, clock.Now())
+		for x := range cfg.URLs {
+			err := sendHeartbeat(client, cfg.URLs[x], &status)
+			if err != nil {
+				log.Warn(i18n.T(i18n.MessageHeartbeatFailed, cfg.URLs[x], err))
+			}
+		}
+		clock.Sleep(cfg.Interval)
+	}
+}
+
+// getHeartbeatStatus summarises the enabled exchanges, their websocket
+// connections and endpoint health. The status is degraded when any exchange is
+// down or websocket disconnected
+func getHeartbeatStatus(exchanges []exchange.IBotExchange, endpoints []EndpointHealth, started, now time.Time) HeartbeatStatus {
+	status := HeartbeatStatus{
+		Status:    "ok",
+		Timestamp: now,
+	}
+	if !started.IsZero() {
+		status.Uptime = now.Sub(started)
+	}
+
+	degraded := make(map[string]bool)
+	restricted := make(map[string]bool)
+	for x := range endpoints {
+		if endpoints[x].Degraded {
+			degraded[endpoints[x].Exchange] = true
+		}
+		if endpoints[x].GeoRestricted {
+			restricted[endpoints[x].Exchange] = true
+		}
+		if endpoints[x].LastError != "" &&
+			endpoints[x].LastFailure.After(status.LastErrorTime) {
+			status.LastError = fmt.Sprintf("%s %s: %s", endpoints[x].Exchange,
+				endpoints[x].Endpoint, endpoints[x].LastError)
+			status.LastErrorTime = endpoints[x].LastFailure
+		}
+	}
+
+	for x := range exchanges {
+		if exchanges[x] == nil || !exchanges[x].IsEnabled() {
+			continue
+		}
+		status.ExchangesEnabled++
+		if !degraded[exchanges[x].GetName()] {
+			status.ExchangesUp++
+		}
+		if restricted[exchanges[x].GetName()] {
+			status.GeoRestricted = append(status.GeoRestricted,
+				exchanges[x].GetName())
+		}
+
+		ws, err := exchanges[x].GetWebsocket()
+		if err != nil || ws == nil || !ws.IsEnabled() {
+			continue
+		}
+		status.WebsocketsEnabled++
+		if ws.IsConnected() {
+			status.WebsocketsConnected++
+		}
+	}
+
+	if status.ExchangesUp < status.ExchangesEnabled ||
+		status.WebsocketsConnected < status.WebsocketsEnabled {
+		status.Status = "degraded"
+	}
+	return status
+}
+
+// sendHeartbeat posts the status to an uptime monitor URL
+func sendHeartbeat(client *http.Client, url string, status *HeartbeatStatus) error {
+	body, err := common.JSONEncode(status)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected HTTP status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type heartbeatTestExchange struct {
+	exchange.IBotExchange
+	name    string
+	enabled bool
+}
+
+func (h *heartbeatTestExchange) GetName() string {
+	return h.name
+}
+
+func (h *heartbeatTestExchange) IsEnabled() bool {
+	return h.enabled
+}
+
+func (h *heartbeatTestExchange) GetWebsocket() (*exchange.Websocket, error) {
+	return nil, errors.New("websocket not supported")
+}
+
+func TestGetHeartbeatStatus(t *testing.T) {
+	now := time.Now()
+	exchanges := []exchange.IBotExchange{
+		&heartbeatTestExchange{name: "a", enabled: true},
+		&heartbeatTestExchange{name: "b", enabled: true},
+		&heartbeatTestExchange{name: "c"},
+	}
+
+	status := getHeartbeatStatus(exchanges, nil, now.Add(-time.Hour), now)
+	if status.Status != "ok" || status.ExchangesEnabled != 2 ||
+		status.ExchangesUp != 2 || status.Uptime != time.Hour {
+		t.Errorf("Test failed. Unexpected status %+v", status)
+	}
+
+	endpoints := []EndpointHealth{
+		{Exchange: "a", Endpoint: "ticker", LastError: "old", LastFailure: now.Add(-time.Minute)},
+		{Exchange: "b", Endpoint: "orderbook", Degraded: true, LastError: "timeout", LastFailure: now},
+		{Exchange: "b", Endpoint: "ticker", Degraded: true, GeoRestricted: true},
+		{Exchange: "c", Endpoint: "ticker", Degraded: true, GeoRestricted: true},
+	}
+	status = getHeartbeatStatus(exchanges, endpoints, now, now)
+	if status.Status != "degraded" || status.ExchangesUp != 1 {
+		t.Errorf("Test failed. Expected degraded status with 1 exchange up, got %+v",
+			status)
+	}
+	if status.LastError != "b orderbook: timeout" || !status.LastErrorTime.Equal(now) {
+		t.Errorf("Test failed. Expected the most recent error, got %s",
+			status.LastError)
+	}
+	if len(status.GeoRestricted) != 1 || status.GeoRestricted[0] != "b" {
+		t.Errorf("Test failed. Expected the enabled exchange to be geo-restricted, got %v",
+			status.GeoRestricted)
+	}
+}
+
+func TestSendHeartbeat(t *testing.T) {
+	var received HeartbeatStatus
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err == nil {
+			err = common.JSONDecode(body, &received)
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer s.Close()
+
+	status := HeartbeatStatus{Status: "ok", ExchangesEnabled: 3}
+	err := sendHeartbeat(s.Client(), s.URL, &status)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if received.Status != "ok" || received.ExchangesEnabled != 3 {
+		t.Errorf("Test failed. Unexpected heartbeat received %+v", received)
+	}
+
+	err = sendHeartbeat(s.Client(), s.URL+"/fail", &status)
+	if err == nil {
+		t.Error("Test failed. Expected error for a non 2xx response")
+	}
+}
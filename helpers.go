@@ -239,10 +239,11 @@ func GetRelatableCurrencies(p currency.Pair, incOrig, incUSDT bool) currency.Pai
 func GetSpecificOrderbook(currencyPair, exchangeName, assetType string) (orderbook.Base, error) {
 	var specificOrderbook orderbook.Base
 	var err error
-	for x := range bot.exchanges {
-		if bot.exchanges[x] != nil {
-			if bot.exchanges[x].GetName() == exchangeName {
-				specificOrderbook, err = bot.exchanges[x].GetOrderbookEx(
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		if exchanges[x] != nil {
+			if exchanges[x].GetName() == exchangeName {
+				specificOrderbook, err = exchanges[x].GetOrderbookEx(
 					currency.NewPairFromString(currencyPair),
 					assetType,
 				)
@@ -258,10 +259,11 @@ func GetSpecificOrderbook(currencyPair, exchangeName, assetType string) (orderbo
 func GetSpecificTicker(currencyPair, exchangeName, assetType string) (ticker.Price, error) {
 	var specificTicker ticker.Price
 	var err error
-	for x := range bot.exchanges {
-		if bot.exchanges[x] != nil {
-			if bot.exchanges[x].GetName() == exchangeName {
-				specificTicker, err = bot.exchanges[x].GetTickerPrice(
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		if exchanges[x] != nil {
+			if exchanges[x].GetName() == exchangeName {
+				specificTicker, err = exchanges[x].GetTickerPrice(
 					currency.NewPairFromString(currencyPair),
 					assetType,
 				)
@@ -272,6 +274,61 @@ func GetSpecificTicker(currencyPair, exchangeName, assetType string) (ticker.Pri
 	return specificTicker, err
 }
 
+// GetSpecificOpenInterest returns the open interest of a derivatives contract
+// for an exchange supporting derivatives
+func GetSpecificOpenInterest(currencyPair, exchangeName string) (exchange.OpenInterest, error) {
+	exch := GetExchangeByName(exchangeName)
+	if exch == nil {
+		return exchange.OpenInterest{}, errors.New(exchange.ErrExchangeNotFound)
+	}
+
+	derivatives, ok := exch.(exchange.IDerivativesExchange)
+	if !ok {
+		return exchange.OpenInterest{}, fmt.Errorf("%s does not support derivatives", exchangeName)
+	}
+	return derivatives.GetOpenInterest(currency.NewPairFromString(currencyPair))
+}
+
+// GetCurrencyTradeURL returns the web trading page of a currency pair, empty
+// when the exchange isn't loaded or doesn't publish market metadata
+func GetCurrencyTradeURL(exchangeName, currencyPair string) string {
+	markets, ok := GetExchangeByName(exchangeName).(exchange.IMarketMetadataExchange)
+	if !ok {
+		return ""
+	}
+	return markets.GetCurrencyTradeURL(currency.NewPairFromString(currencyPair))
+}
+
+// GetSpecificMarketMetadata returns the trade URL, listing date and trading
+// status of a currency pair for an exchange publishing market metadata
+func GetSpecificMarketMetadata(currencyPair, exchangeName string) (exchange.MarketMetadata, error) {
+	exch := GetExchangeByName(exchangeName)
+	if exch == nil {
+		return exchange.MarketMetadata{}, errors.New(exchange.ErrExchangeNotFound)
+	}
+
+	markets, ok := exch.(exchange.IMarketMetadataExchange)
+	if !ok {
+		return exchange.MarketMetadata{}, fmt.Errorf("%s does not support market metadata", exchangeName)
+	}
+	return markets.GetMarketMetadata(currency.NewPairFromString(currencyPair))
+}
+
+// GetSpecificTradingStatus returns whether a currency pair is trading, halted,
+// cancel-only, post-only or delisting for an exchange reporting trading status
+func GetSpecificTradingStatus(currencyPair, exchangeName string) (exchange.TradingStatus, error) {
+	exch := GetExchangeByName(exchangeName)
+	if exch == nil {
+		return exchange.TradingStatus{}, errors.New(exchange.ErrExchangeNotFound)
+	}
+
+	reporter, ok := exch.(exchange.ITradingStatusExchange)
+	if !ok {
+		return exchange.TradingStatus{}, fmt.Errorf("%s does not support trading status", exchangeName)
+	}
+	return reporter.GetTradingStatus(currency.NewPairFromString(currencyPair))
+}
+
 // GetCollatedExchangeAccountInfoByCoin collates individual exchange account
 // information and turns into into a map string of
 // exchange.AccountCurrencyInfo
@@ -281,17 +338,11 @@ func GetCollatedExchangeAccountInfoByCoin(exchAccounts []exchange.AccountInfo) m
 		for _, account := range accounts.Accounts {
 			for _, accountCurrencyInfo := range account.Currencies {
 				currencyName := accountCurrencyInfo.CurrencyName
-				avail := accountCurrencyInfo.TotalValue
-				onHold := accountCurrencyInfo.Hold
-
 				info, ok := result[currencyName]
 				if !ok {
-					accountInfo := exchange.AccountCurrencyInfo{CurrencyName: currencyName, Hold: onHold, TotalValue: avail}
-					result[currencyName] = accountInfo
+					result[currencyName] = accountCurrencyInfo
 				} else {
-					info.Hold += onHold
-					info.TotalValue += avail
-					result[currencyName] = info
+					result[currencyName] = info.Add(accountCurrencyInfo)
 				}
 			}
 		}
@@ -349,8 +400,7 @@ func SeedExchangeAccountInfo(data []exchange.AccountInfo) {
 				var update bool
 				for i := range currencies {
 					if info.CurrencyName == currencies[i].CurrencyName {
-						currencies[i].Hold += info.Hold
-						currencies[i].TotalValue += info.TotalValue
+						currencies[i] = currencies[i].Add(info)
 						update = true
 					}
 				}
@@ -359,11 +409,7 @@ func SeedExchangeAccountInfo(data []exchange.AccountInfo) {
 					continue
 				}
 
-				currencies = append(currencies, exchange.AccountCurrencyInfo{
-					CurrencyName: info.CurrencyName,
-					TotalValue:   info.TotalValue,
-					Hold:         info.Hold,
-				})
+				currencies = append(currencies, info)
 			}
 		}
 
@@ -262,6 +262,7 @@ func TestGetExchangeNamesByCurrency(t *testing.T) {
 func TestGetSpecificOrderbook(t *testing.T) {
 	SetupTestHelpers(t)
 
+	skipUnregistered(t, "Bitstamp")
 	LoadExchange("Bitstamp", false, nil)
 
 	var bids []orderbook.Item
@@ -299,6 +300,7 @@ func TestGetSpecificOrderbook(t *testing.T) {
 func TestGetSpecificTicker(t *testing.T) {
 	SetupTestHelpers(t)
 
+	skipUnregistered(t, "Bitstamp")
 	LoadExchange("Bitstamp", false, nil)
 	p := currency.NewPairFromStrings("BTC", "USD")
 
@@ -326,6 +328,42 @@ func TestGetSpecificTicker(t *testing.T) {
 	UnloadExchange("Bitstamp")
 }
 
+func TestGetSpecificOpenInterest(t *testing.T) {
+	SetupTestHelpers(t)
+
+	_, err := GetSpecificOpenInterest("BTCUSD", "Bitstamp")
+	if err == nil {
+		t.Fatal("Unexpected result, exchange not loaded")
+	}
+
+	skipUnregistered(t, "Bitstamp")
+	LoadExchange("Bitstamp", false, nil)
+	_, err = GetSpecificOpenInterest("BTCUSD", "Bitstamp")
+	if err == nil {
+		t.Fatal("Unexpected result, exchange does not support derivatives")
+	}
+
+	UnloadExchange("Bitstamp")
+}
+
+func TestGetSpecificMarketMetadata(t *testing.T) {
+	SetupTestHelpers(t)
+
+	_, err := GetSpecificMarketMetadata("BTCUSD", "Bitstamp")
+	if err == nil {
+		t.Fatal("Unexpected result, exchange not loaded")
+	}
+
+	skipUnregistered(t, "Bitstamp")
+	LoadExchange("Bitstamp", false, nil)
+	_, err = GetSpecificMarketMetadata("BTCUSD", "Bitstamp")
+	if err == nil {
+		t.Fatal("Unexpected result, exchange does not support market metadata")
+	}
+
+	UnloadExchange("Bitstamp")
+}
+
 func TestGetCollatedExchangeAccountInfoByCoin(t *testing.T) {
 	SetupTestHelpers(t)
 
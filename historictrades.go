@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// HistoricTrades holds the public trades of an exchange pair downloaded for a
+// time range, Complete being false when the exchange only returns its most
+// recent trades so earlier trades in the range may be missing
+type HistoricTrades struct {
+	Exchange  string                  `json:"exchange"`
+	Pair      string                  `json:"pair"`
+	AssetType string                  `json:"assetType"`
+	Start     time.Time               `json:"start"`
+	End       time.Time               `json:"end"`
+	Complete  bool                    `json:"complete"`
+	Trades    []exchange.TradeHistory `json:"trades"`
+}
+
+// GetHistoricTrades downloads the public trades of an exchange pair executed
+// between start and end inclusive, oldest first, in chunks of the given
+// duration. A zero end is now and a zero start the default chunk before end,
+// the asset type defaults to spot
+func GetHistoricTrades(exchName, currencyPair, assetType string, start, end time.Time, chunk time.Duration) (HistoricTrades, error) {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return HistoricTrades{}, errors.New(exchange.ErrExchangeNotFound)
+	}
+	if assetType == "" {
+		assetType = ticker.Spot
+	}
+	if end.IsZero() {
+		end = clock.Now()
+	}
+	if start.IsZero() {
+		start = end.Add(-exchange.DefaultTradeHistoryChunk)
+	}
+
+	p := currency.NewPairFromString(currencyPair)
+	trades, err := exchange.DownloadHistoricTrades(exch, p, assetType, start, end, chunk)
+	if err != nil {
+		return HistoricTrades{}, err
+	}
+	log.Debugf("Downloaded %d %s %s %s historic trades", len(trades),
+		exch.GetName(), p, assetType)
+	return HistoricTrades{
+		Exchange:  exch.GetName(),
+		Pair:      p.String(),
+		AssetType: assetType,
+		Start:     start,
+		End:       end,
+		Complete:  exch.SupportsHistoricTrades(),
+		Trades:    trades,
+	}, nil
+}
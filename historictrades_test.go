@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/mock"
+)
+
+func TestGetHistoricTrades(t *testing.T) {
+	p := currency.NewPairFromStrings("BTC", "USD")
+	exch := mock.New("HistoricTradesTest")
+	exch.Setup(&config.ExchangeConfig{Name: "HistoricTradesTest", Enabled: true,
+		EnabledPairs: currency.Pairs{p}})
+	exch.SupportsTradeHistory = true
+	exchanges := bot.exchanges
+	bot.exchanges = []exchange.IBotExchange{exch}
+	defer func() { bot.exchanges = exchanges }()
+
+	start := time.Unix(1546300800, 0)
+	var trades []exchange.TradeHistory
+	for x := 0; x < 10; x++ {
+		trades = append(trades, exchange.TradeHistory{
+			Timestamp: start.Add(time.Hour * time.Duration(x)),
+			TID:       int64(x + 1),
+		})
+	}
+	exch.SetTrades(p, trades)
+
+	resp, err := GetHistoricTrades("HistoricTradesTest", "BTCUSD", "",
+		start.Add(time.Hour), start.Add(time.Hour*5), time.Hour*2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Trades) != 5 || resp.Trades[0].TID != 2 || resp.Trades[4].TID != 6 {
+		t.Errorf("Test failed. Expected trades 2 to 6, got %v", resp.Trades)
+	}
+	if resp.AssetType != "SPOT" || !resp.Complete {
+		t.Errorf("Test failed. Unexpected historic trades %+v", resp)
+	}
+
+	_, err = GetHistoricTrades("bad exchange", "BTCUSD", "", start, start, 0)
+	if err == nil {
+		t.Error("Test failed. Expected an error for an unknown exchange")
+	}
+}
@@ -0,0 +1,151 @@
+package i18n
+
+// Messages translated by the builtin catalogs
+const (
+	MessageEndpointDegraded     = "%s %s endpoint degraded, backing off. Error: %s"
+	MessageEndpointRecovered    = "%s %s endpoint recovered"
+	MessageEndpointRestricted   = "%s %s endpoint is geo-restricted or compliance blocked, requests suspended for %s"
+	MessageParityDeviation      = "%s %s deviated %.2f%% from parity at %f, exceeding the %.2f%% threshold"
+	MessageParityRestored       = "%s %s returned to parity at %f"
+	MessageTriangularArb        = "%s triangular arbitrage %s: %f %s returns %f, profit %.4f%%"
+	MessageLiquidation          = "%s %s %s liquidation %s %f @ %f"
+	MessageEventTriggered       = "Event triggered: %s"
+	MessageInvalidAmount        = "invalid amount"
+	MessageInvalidPrice         = "invalid price"
+	MessageExchangeNotFound     = "exchange not found in dataset"
+	MessageAmountNotPositive    = "amount must be greater than zero"
+	MessageInvalidOrderSide     = "invalid order side %s"
+	MessageInvalidOrderType     = "invalid order type %s"
+	MessageHeartbeatFailed      = "Heartbeat to %s failed: %s"
+	MessageScheduledTaskFailed  = "Scheduled task %s failed: %s"
+	MessageOrderQueued          = "%s %s %s order %f queued until %s as the exchange is unavailable: %s"
+	MessageQueuedOrderSubmitted = "%s %s %s queued order %f submitted, order ID %s"
+	MessageQueuedOrderExpired   = "%s %s %s queued order %f expired before the exchange recovered"
+	MessageQueuedOrderFailed    = "%s %s %s queued order %f failed: %s"
+	MessageCircuitBreakerMove   = "%s %s moved %.2f%% within %s, exceeding the %.2f%% limit. Automated orders suspended until %s"
+	MessageCircuitBreakerSpread = "%s %s spread widened to %.2f%%, exceeding the %.2f%% limit. Automated orders suspended until %s"
+	MessageCircuitBreakerReset  = "%s %s circuit breaker reset, automated orders resumed"
+	MessageSystemMaintenance    = "%s platform is under maintenance"
+	MessageSystemOperational    = "%s platform is operational again"
+	MessageCorrelationBelow     = "%s %s and %s correlation fell to %.2f, below the %.2f threshold"
+	MessageCorrelationRestored  = "%s %s and %s correlation recovered to %.2f"
+	MessageOpenInterestChanged  = "%s %s open interest changed %.2f%% to %v contracts, exceeding the %.2f%% threshold"
+	MessagePartialFill          = "%s order %s partially filled %v of %v %s, %v remaining"
+	MessageOrderFlagsInvalid    = "post-only and reduce-only orders must be sized by amount without a strategy"
+	MessageWhitelistMissing     = "%s %s withdrawal address %s is not whitelisted on the exchange, withdrawals to it will be blocked"
+	MessageAddressBookMissing   = "%s %s whitelisted withdrawal address %s is missing from the local address book"
+	MessageFeedSwitched         = "Strategy %s %s %s feed switched from %s to %s %s"
+	MessageContractExpiry       = "%s %s futures contract expires at %s"
+	MessageContractExpiryRoll   = "%s %s futures contract expires at %s, positions can be rolled into %s"
+	MessageFuturesRolled        = "%s rolled %s position of %v contracts from %s into %s, estimated cost %f"
+	MessageFuturesRollFailed    = "%s failed to roll %s position of %v contracts in %s: %s"
+	MessageBasisAbove           = "%s %s annualised basis of %.2f%% exceeds the %.2f%% threshold, futures %f against index %f"
+	MessageBasisBelow           = "%s %s annualised basis fell back to %.2f%%"
+	MessageExposureBreached     = "%s exposure of %f %s (%.2f%% of portfolio) breaches its limit, further accumulation is blocked"
+	MessageExposureRestored     = "%s exposure of %f %s (%.2f%% of portfolio) is back within its limit"
+	MessageEndpointSlow         = "%s %s %s endpoint is slow, p%d latency of %s exceeds the %s threshold"
+	MessageEndpointFast         = "%s %s %s endpoint latency recovered, p95 %s p99 %s"
+	MessagePairsUpdated         = "%s tradable pairs updated, listed: [%s] delisted: [%s]"
+	MessagePairsDisabled        = "%s delisted enabled pairs disabled: [%s]"
+)
+
+// builtinCatalogs returns the translations shipped with the bot, Korean and
+// Chinese cover the user bases of exchanges such as Bithumb and OKEX
+func builtinCatalogs() map[string]Catalog {
+	return map[string]Catalog{
+		"ko": {
+			MessageEndpointDegraded:     "%s %s 엔드포인트 성능 저하, 요청을 지연합니다. 오류: %s",
+			MessageEndpointRecovered:    "%s %s 엔드포인트가 복구되었습니다",
+			MessageEndpointRestricted:   "%s %s 엔드포인트가 지역 제한 또는 규정 준수로 차단되어 %s 동안 요청을 중단합니다",
+			MessageParityDeviation:      "%[1]s %[2]s 가격이 %[4]f로 페그에서 %.2[3]f%% 벗어나 임계값 %.2[5]f%%를 초과했습니다",
+			MessageParityRestored:       "%s %s 가격이 %f로 페그를 회복했습니다",
+			MessageTriangularArb:        "%s 삼각 차익거래 %s: %f %s 투입 시 %f 반환, 수익 %.4f%%",
+			MessageLiquidation:          "%s %s %s 청산 %s %f @ %f",
+			MessageEventTriggered:       "이벤트 발생: %s",
+			MessageInvalidAmount:        "잘못된 수량",
+			MessageInvalidPrice:         "잘못된 가격",
+			MessageExchangeNotFound:     "거래소를 찾을 수 없습니다",
+			MessageAmountNotPositive:    "수량은 0보다 커야 합니다",
+			MessageInvalidOrderSide:     "잘못된 주문 방향 %s",
+			MessageInvalidOrderType:     "잘못된 주문 유형 %s",
+			MessageHeartbeatFailed:      "%s 하트비트 전송 실패: %s",
+			MessageScheduledTaskFailed:  "예약 작업 %s 실패: %s",
+			MessageOrderQueued:          "거래소를 사용할 수 없어 %s %s %s 주문 %f을(를) %s까지 대기열에 추가했습니다: %s",
+			MessageQueuedOrderSubmitted: "%s %s %s 대기 주문 %f이(가) 제출되었습니다, 주문 ID %s",
+			MessageQueuedOrderExpired:   "거래소가 복구되기 전에 %s %s %s 대기 주문 %f이(가) 만료되었습니다",
+			MessageQueuedOrderFailed:    "%s %s %s 대기 주문 %f 제출 실패: %s",
+			MessageCircuitBreakerMove:   "%[1]s %[2]s 가격이 %[4]s 동안 %.2[3]f%% 변동하여 한도 %.2[5]f%%를 초과했습니다. %[6]s까지 자동 주문이 중단됩니다",
+			MessageCircuitBreakerSpread: "%s %s 스프레드가 %.2f%%로 확대되어 한도 %.2f%%를 초과했습니다. %s까지 자동 주문이 중단됩니다",
+			MessageCircuitBreakerReset:  "%s %s 서킷 브레이커가 해제되어 자동 주문을 재개합니다",
+			MessageSystemMaintenance:    "%s 플랫폼이 점검 중입니다",
+			MessageSystemOperational:    "%s 플랫폼이 정상 운영을 재개했습니다",
+			MessageCorrelationBelow:     "%s %s와 %s의 상관계수가 %.2f로 하락하여 임계값 %.2f 미만입니다",
+			MessageCorrelationRestored:  "%s %s와 %s의 상관계수가 %.2f로 회복되었습니다",
+			MessageOpenInterestChanged:  "%s %s 미결제약정이 %.2f%% 변동하여 %v 계약이 되었습니다, 임계값 %.2f%% 초과",
+			MessagePartialFill:          "%s 주문 %s이 %v / %v %s 부분 체결되었습니다, 잔량 %v",
+			MessageOrderFlagsInvalid:    "post-only 및 reduce-only 주문은 전략 없이 수량으로 지정해야 합니다",
+			MessageWhitelistMissing:     "%s %s 출금 주소 %s이(가) 거래소 화이트리스트에 없어 출금이 차단됩니다",
+			MessageAddressBookMissing:   "%s %s 화이트리스트 출금 주소 %s이(가) 로컬 주소록에 없습니다",
+			MessageFeedSwitched:         "전략 %s %s %s 피드가 %s에서 %s %s(으)로 전환되었습니다",
+			MessageContractExpiry:       "%s %s 선물 계약이 %s에 만기됩니다",
+			MessageContractExpiryRoll:   "%s %s 선물 계약이 %s에 만기됩니다, 포지션을 %s(으)로 롤오버할 수 있습니다",
+			MessageFuturesRolled:        "%s %s 포지션 %v 계약을 %s에서 %s(으)로 롤오버했습니다, 예상 비용 %f",
+			MessageFuturesRollFailed:    "%s %s 포지션 %v 계약을 %s에서 롤오버하지 못했습니다: %s",
+			MessageBasisAbove:           "%s %s 연환산 베이시스 %.2f%%가 임계값 %.2f%%를 초과했습니다, 선물 %f 지수 %f",
+			MessageBasisBelow:           "%s %s 연환산 베이시스가 %.2f%%로 하락했습니다",
+			MessageExposureBreached:     "%s 노출 %f %s(포트폴리오의 %.2f%%)가 한도를 초과했습니다, 추가 매수가 차단됩니다",
+			MessageExposureRestored:     "%s 노출 %f %s(포트폴리오의 %.2f%%)가 한도 이내로 돌아왔습니다",
+			MessageEndpointSlow:         "%s %s %s 엔드포인트가 느립니다, p%d 지연 시간 %s이(가) 임계값 %s을(를) 초과했습니다",
+			MessageEndpointFast:         "%s %s %s 엔드포인트 지연 시간이 회복되었습니다, p95 %s p99 %s",
+			MessagePairsUpdated:         "%s 거래 가능 페어가 업데이트되었습니다, 상장: [%s] 상장 폐지: [%s]",
+			MessagePairsDisabled:        "%s 상장 폐지된 활성 페어가 비활성화되었습니다: [%s]",
+		},
+		"zh": {
+			MessageEndpointDegraded:     "%s %s 接口异常，正在退避重试。错误：%s",
+			MessageEndpointRecovered:    "%s %s 接口已恢复",
+			MessageEndpointRestricted:   "%s %s 接口受地区限制或合规封锁，暂停请求 %s",
+			MessageParityDeviation:      "%[1]s %[2]s 价格为 %[4]f，偏离锚定 %.2[3]f%%，超过 %.2[5]f%% 阈值",
+			MessageParityRestored:       "%s %s 价格已回归锚定，当前为 %f",
+			MessageTriangularArb:        "%s 三角套利 %s：投入 %f %s 可得 %f，收益 %.4f%%",
+			MessageLiquidation:          "%s %s %s 强平 %s %f @ %f",
+			MessageEventTriggered:       "事件已触发：%s",
+			MessageInvalidAmount:        "无效的数量",
+			MessageInvalidPrice:         "无效的价格",
+			MessageExchangeNotFound:     "未找到交易所",
+			MessageAmountNotPositive:    "数量必须大于零",
+			MessageInvalidOrderSide:     "无效的订单方向 %s",
+			MessageInvalidOrderType:     "无效的订单类型 %s",
+			MessageHeartbeatFailed:      "向 %s 发送心跳失败：%s",
+			MessageScheduledTaskFailed:  "计划任务 %s 失败：%s",
+			MessageOrderQueued:          "交易所不可用，%s %s %s 订单 %f 已加入队列，有效期至 %s：%s",
+			MessageQueuedOrderSubmitted: "%s %s %s 队列订单 %f 已提交，订单号 %s",
+			MessageQueuedOrderExpired:   "%s %s %s 队列订单 %f 在交易所恢复前已过期",
+			MessageQueuedOrderFailed:    "%s %s %s 队列订单 %f 提交失败：%s",
+			MessageCircuitBreakerMove:   "%[1]s %[2]s 在 %[4]s 内波动 %.2[3]f%%，超过 %.2[5]f%% 上限，自动下单暂停至 %[6]s",
+			MessageCircuitBreakerSpread: "%s %s 买卖价差扩大至 %.2f%%，超过 %.2f%% 上限，自动下单暂停至 %s",
+			MessageCircuitBreakerReset:  "%s %s 熔断已解除，自动下单已恢复",
+			MessageSystemMaintenance:    "%s 平台正在维护",
+			MessageSystemOperational:    "%s 平台已恢复正常运行",
+			MessageCorrelationBelow:     "%s %s 与 %s 的相关系数降至 %.2f，低于 %.2f 阈值",
+			MessageCorrelationRestored:  "%s %s 与 %s 的相关系数已回升至 %.2f",
+			MessageOpenInterestChanged:  "%s %s 持仓量变动 %.2f%% 至 %v 张合约，超过 %.2f%% 阈值",
+			MessagePartialFill:          "%s 订单 %s 已部分成交 %v / %v %s，剩余 %v",
+			MessageOrderFlagsInvalid:    "只挂单和只减仓订单必须按数量下单且不能指定策略",
+			MessageWhitelistMissing:     "%s %s 提现地址 %s 不在交易所白名单中，向该地址的提现将被阻止",
+			MessageAddressBookMissing:   "%s %s 白名单提现地址 %s 不在本地地址簿中",
+			MessageFeedSwitched:         "策略 %s %s %s 数据源已从 %s 切换至 %s %s",
+			MessageContractExpiry:       "%s %s 期货合约将于 %s 到期",
+			MessageContractExpiryRoll:   "%s %s 期货合约将于 %s 到期，可将持仓展期至 %s",
+			MessageFuturesRolled:        "%s 已将 %s 持仓 %v 张合约从 %s 展期至 %s，预计成本 %f",
+			MessageFuturesRollFailed:    "%s 未能展期 %s 持仓 %v 张合约（%s）：%s",
+			MessageBasisAbove:           "%s %s 年化基差 %.2f%% 超过阈值 %.2f%%，期货 %f，指数 %f",
+			MessageBasisBelow:           "%s %s 年化基差已回落至 %.2f%%",
+			MessageExposureBreached:     "%s 敞口 %f %s（占投资组合 %.2f%%）超过限额，已阻止继续增持",
+			MessageExposureRestored:     "%s 敞口 %f %s（占投资组合 %.2f%%）已回到限额以内",
+			MessageEndpointSlow:         "%s %s %s 接口响应缓慢，p%d 延迟 %s 超过 %s 阈值",
+			MessageEndpointFast:         "%s %s %s 接口延迟已恢复，p95 %s p99 %s",
+			MessagePairsUpdated:         "%s 可交易币对已更新，上线：[%s] 下线：[%s]",
+			MessagePairsDisabled:        "%s 已停用下线的启用币对：[%s]",
+		},
+	}
+}
@@ -0,0 +1,157 @@
+// Package i18n translates user facing log, communications and REST messages.
+// Messages are looked up by their English format string, which is returned
+// unchanged when the selected locale has no translation.
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// DefaultLocale is the locale messages are written in
+const DefaultLocale = "en"
+
+// Catalog maps English message format strings to their translation. Format
+// verbs may use explicit argument indexes, such as %[2]s, when a translation
+// orders its arguments differently
+type Catalog map[string]string
+
+var locales = struct {
+	current  string
+	catalogs map[string]Catalog
+	m        sync.RWMutex
+}{
+	current:  DefaultLocale,
+	catalogs: builtinCatalogs(),
+}
+
+// SetLocale selects the locale messages are translated into
+func SetLocale(locale string) error {
+	locale = common.StringToLower(locale)
+	locales.m.Lock()
+	defer locales.m.Unlock()
+	if locale != DefaultLocale {
+		if _, ok := locales.catalogs[locale]; !ok {
+			return fmt.Errorf("unsupported locale %s", locale)
+		}
+	}
+	locales.current = locale
+	return nil
+}
+
+// GetLocale returns the selected locale
+func GetLocale() string {
+	locales.m.RLock()
+	defer locales.m.RUnlock()
+	return locales.current
+}
+
+// IsSupported returns whether a locale has a catalog
+func IsSupported(locale string) bool {
+	locale = common.StringToLower(locale)
+	if locale == DefaultLocale {
+		return true
+	}
+	locales.m.RLock()
+	defer locales.m.RUnlock()
+	_, ok := locales.catalogs[locale]
+	return ok
+}
+
+// GetSupportedLocales returns the locales messages can be translated into
+func GetSupportedLocales() []string {
+	locales.m.RLock()
+	defer locales.m.RUnlock()
+	resp := []string{DefaultLocale}
+	for locale := range locales.catalogs {
+		resp = append(resp, locale)
+	}
+	sort.Strings(resp)
+	return resp
+}
+
+// AddCatalog adds translations to a locale, replacing any existing
+// translations of the same messages
+func AddCatalog(locale string, catalog Catalog) error {
+	locale = common.StringToLower(locale)
+	if locale == "" || locale == DefaultLocale {
+		return fmt.Errorf("invalid catalog locale %q", locale)
+	}
+
+	locales.m.Lock()
+	defer locales.m.Unlock()
+	existing, ok := locales.catalogs[locale]
+	if !ok {
+		existing = make(Catalog)
+		locales.catalogs[locale] = existing
+	}
+	for message, translation := range catalog {
+		existing[message] = translation
+	}
+	return nil
+}
+
+// LoadCatalogs adds the catalogs in a directory, each file is a JSON object of
+// message translations named after its locale, such as ko.json
+func LoadCatalogs(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for x := range files {
+		data, err := ioutil.ReadFile(files[x])
+		if err != nil {
+			return err
+		}
+
+		var catalog Catalog
+		err = common.JSONDecode(data, &catalog)
+		if err != nil {
+			return fmt.Errorf("catalog %s: %s", files[x], err)
+		}
+
+		locale := strings.TrimSuffix(filepath.Base(files[x]), ".json")
+		err = AddCatalog(locale, catalog)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// T returns a message translated into the selected locale and formatted with
+// args
+func T(format string, args ...interface{}) string {
+	locales.m.RLock()
+	if translation, ok := locales.catalogs[locales.current][format]; ok {
+		format = translation
+	}
+	locales.m.RUnlock()
+
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Errorf returns an error with a translated message
+func Errorf(format string, args ...interface{}) error {
+	return errors.New(T(format, args...))
+}
+
+// Error returns the translated message of an error, errors without a
+// translation are returned unchanged
+func Error(err error) string {
+	if err == nil {
+		return ""
+	}
+	return T(err.Error())
+}
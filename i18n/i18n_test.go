@@ -0,0 +1,93 @@
+package i18n
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetLocale(t *testing.T) {
+	defer SetLocale(DefaultLocale)
+
+	if err := SetLocale("KO"); err != nil || GetLocale() != "ko" {
+		t.Errorf("Test Failed - SetLocale() expected ko, got %s %v", GetLocale(), err)
+	}
+
+	if err := SetLocale("xx"); err == nil {
+		t.Error("Test Failed - SetLocale() expected error for unsupported locale")
+	}
+	if GetLocale() != "ko" {
+		t.Error("Test Failed - SetLocale() unsupported locale should not change locale")
+	}
+
+	if !IsSupported("zh") || IsSupported("xx") || !IsSupported(DefaultLocale) {
+		t.Error("Test Failed - IsSupported() unexpected result")
+	}
+}
+
+func TestT(t *testing.T) {
+	defer SetLocale(DefaultLocale)
+
+	if s := T(MessageEndpointRecovered, "Bithumb", "ticker"); s != "Bithumb ticker endpoint recovered" {
+		t.Errorf("Test Failed - T() unexpected default locale message %s", s)
+	}
+
+	SetLocale("zh")
+	if s := T(MessageParityDeviation, "OKEX", "USDT-USD", 2.5, 0.975, 1.0); s != "OKEX USDT-USD 价格为 0.975000，偏离锚定 2.50%，超过 1.00% 阈值" {
+		t.Errorf("Test Failed - T() unexpected translated message %s", s)
+	}
+
+	if s := T("untranslated %s", "message"); s != "untranslated message" {
+		t.Errorf("Test Failed - T() expected untranslated message, got %s", s)
+	}
+
+	if s := Error(errors.New(MessageInvalidAmount)); s != "无效的数量" {
+		t.Errorf("Test Failed - Error() expected translated error, got %s", s)
+	}
+	if s := Error(errors.New("100% failure")); s != "100% failure" {
+		t.Errorf("Test Failed - Error() expected untranslated error, got %s", s)
+	}
+}
+
+func TestLoadCatalogs(t *testing.T) {
+	defer func() {
+		SetLocale(DefaultLocale)
+		locales.m.Lock()
+		delete(locales.catalogs, "de")
+		locales.m.Unlock()
+	}()
+
+	dir, err := ioutil.TempDir("", "gct-locales")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "de.json"),
+		[]byte(`{"invalid amount": "ungültige Menge"}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = LoadCatalogs(dir)
+	if err != nil {
+		t.Fatalf("Test Failed - LoadCatalogs() error %s", err)
+	}
+
+	if err = SetLocale("de"); err != nil {
+		t.Fatalf("Test Failed - SetLocale() error %s", err)
+	}
+	if s := T(MessageInvalidAmount); s != "ungültige Menge" {
+		t.Errorf("Test Failed - T() expected loaded translation, got %s", s)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "fr.json"), []byte(`invalid`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = LoadCatalogs(dir); err == nil {
+		t.Error("Test Failed - LoadCatalogs() expected error for invalid catalog")
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/i18n"
+)
+
+const liquidationEventType = "LIQUIDATION"
+
+// isNotableLiquidation returns whether the notional value of a liquidation is
+// large enough to be pushed through the communication mediums. Contract
+// amounts aren't compared as contract sizes differ between exchanges
+func isNotableLiquidation(d *exchange.LiquidationEvent, cfg *config.LiquidationAlertConfig) bool {
+	return cfg.Enabled && d.Value >= cfg.MinValue
+}
+
+// processLiquidation relays a websocket liquidation to websocket clients,
+// pushing it through the communication mediums when it passes the liquidation
+// alert value
+func processLiquidation(d *exchange.LiquidationEvent) {
+	if bot.config.Webserver.Enabled {
+		relayWebsocketEvent(d, "liquidation", d.AssetType, d.Exchange, d.Pair)
+	}
+	if isNotableLiquidation(d, &bot.config.LiquidationAlerts) {
+		pushEvent(liquidationEventType, i18n.T(i18n.MessageLiquidation, d.Exchange,
+			d.AssetType, d.Pair, d.Side, d.Amount, d.Price))
+	}
+}
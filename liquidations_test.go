@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+func TestIsNotableLiquidation(t *testing.T) {
+	cfg := config.LiquidationAlertConfig{MinValue: 1000}
+	d := exchange.LiquidationEvent{Exchange: "Bitmex", Amount: 50, Value: 5000}
+	if isNotableLiquidation(&d, &cfg) {
+		t.Error("Test failed. Expected no alerts when liquidation alerts are disabled")
+	}
+
+	cfg.Enabled = true
+	if !isNotableLiquidation(&d, &cfg) {
+		t.Error("Test failed. Expected a liquidation above the minimum value to be notable")
+	}
+	d.Value = 999
+	if isNotableLiquidation(&d, &cfg) {
+		t.Error("Test failed. Expected a liquidation below the minimum value not to be notable")
+	}
+	d.Amount = 5000
+	if isNotableLiquidation(&d, &cfg) {
+		t.Error("Test failed. Expected the contract amount not to be compared against the minimum value")
+	}
+}
@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// DefaultMaxPayloadSize is the number of bytes of a payload logged in verbose
+// mode when no limit is configured
+const DefaultMaxPayloadSize = 4096
+
+const redacted = "[REDACTED]"
+
+// DebugSettings controls the verbose output of an exchange or subsystem.
+// Payloads longer than MaxPayloadSize bytes are truncated, SampleRate is the
+// fraction of verbose messages logged and secrets are redacted from payloads
+// unless ShowSecrets is set
+type DebugSettings struct {
+	Enabled        bool
+	MaxPayloadSize int
+	SampleRate     float64
+	ShowSecrets    bool
+}
+
+var debugSettings = struct {
+	settings map[string]DebugSettings
+	m        sync.RWMutex
+}{
+	settings: make(map[string]DebugSettings),
+}
+
+var (
+	secretKeys = `api_?key|apikey|key|secret|api_?secret|sign|signature|authsig|authpayload|` +
+		`passphrase|password|token|access_?token|otp|totp`
+	jsonSecret  = regexp.MustCompile(`(?i)("(?:` + secretKeys + `)"\s*:\s*)("(?:[^"\\]|\\.)*"|[^,}\]\s]+)`)
+	querySecret = regexp.MustCompile(`(?i)((?:^|[?&\s])(?:` + secretKeys + `)=)[^&\s]*`)
+	headerLine  = regexp.MustCompile(`(?im)^([\w-]*(?:key|secret|sign|passphrase|token|auth)[\w-]*:[ \t]*)[^\r\n]*`)
+	secretName  = regexp.MustCompile(`(?i)(` + secretKeys + `|auth|sig)`)
+)
+
+// SetDebugSettings sets the verbose output settings of an exchange or
+// subsystem by name
+func SetDebugSettings(name string, settings DebugSettings) {
+	debugSettings.m.Lock()
+	debugSettings.settings[strings.ToLower(name)] = settings
+	debugSettings.m.Unlock()
+}
+
+// GetDebugSettings returns the verbose output settings of an exchange or
+// subsystem. Names without settings log every message in full with secrets
+// redacted
+func GetDebugSettings(name string) DebugSettings {
+	debugSettings.m.RLock()
+	settings, ok := debugSettings.settings[strings.ToLower(name)]
+	debugSettings.m.RUnlock()
+	if !ok {
+		return DebugSettings{Enabled: true, SampleRate: 1}
+	}
+	return settings
+}
+
+// Sampled returns whether a verbose message of an exchange or subsystem
+// should be logged after applying its sampling rate
+func Sampled(name string) bool {
+	settings := GetDebugSettings(name)
+	if !settings.Enabled {
+		return false
+	}
+	if settings.SampleRate <= 0 || settings.SampleRate >= 1 {
+		return true
+	}
+	return rand.Float64() < settings.SampleRate
+}
+
+// DebugPayload formats a payload of an exchange or subsystem for verbose
+// output, redacting secrets and truncating it to the payload size limit
+func DebugPayload(name string, payload interface{}) string {
+	settings := GetDebugSettings(name)
+	var s string
+	switch p := payload.(type) {
+	case []byte:
+		s = string(p)
+	case string:
+		s = p
+	default:
+		s = fmt.Sprintf("%v", p)
+	}
+	if !settings.ShowSecrets {
+		s = RedactSecrets(s)
+	}
+
+	limit := settings.MaxPayloadSize
+	if limit <= 0 {
+		limit = DefaultMaxPayloadSize
+	}
+	if len(s) > limit {
+		return fmt.Sprintf("%s... (%d bytes truncated)", s[:limit], len(s)-limit)
+	}
+	return s
+}
+
+// RedactSecrets replaces the values of JSON fields, query params and HTTP
+// header lines named like credentials or signatures
+func RedactSecrets(payload string) string {
+	payload = jsonSecret.ReplaceAllString(payload, `${1}"`+redacted+`"`)
+	payload = querySecret.ReplaceAllString(payload, "${1}"+redacted)
+	return headerLine.ReplaceAllString(payload, "${1}"+redacted)
+}
+
+// RedactHeader returns the value of a header for verbose output, redacting
+// headers named like credentials or signatures
+func RedactHeader(name, key string, value interface{}) string {
+	if !GetDebugSettings(name).ShowSecrets && secretName.MatchString(key) {
+		return redacted
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactSecrets(t *testing.T) {
+	payload := `{"apiKey":"abc","request":"/v1/order/new","nonce":"1","signature":"deadbeef","amount":1.5}`
+	redactedPayload := RedactSecrets(payload)
+	if strings.Contains(redactedPayload, "abc") || strings.Contains(redactedPayload, "deadbeef") {
+		t.Errorf("RedactSecrets left secrets in %s", redactedPayload)
+	}
+	if !strings.Contains(redactedPayload, `"amount":1.5`) ||
+		!strings.Contains(redactedPayload, `"request":"/v1/order/new"`) {
+		t.Errorf("RedactSecrets redacted non secret fields in %s", redactedPayload)
+	}
+
+	query := RedactSecrets("https://api.example.com/v1/balance?apikey=abc&signature=def&symbol=btcusd")
+	if strings.Contains(query, "abc") || strings.Contains(query, "def") ||
+		!strings.Contains(query, "symbol=btcusd") {
+		t.Errorf("RedactSecrets unexpected query redaction %s", query)
+	}
+
+	headers := RedactSecrets("POST /v1 HTTP/1.1\r\nX-Bfx-Apikey: abc\r\nContent-Type: application/json\r\n")
+	if strings.Contains(headers, "abc") || !strings.Contains(headers, "application/json") {
+		t.Errorf("RedactSecrets unexpected header redaction %s", headers)
+	}
+}
+
+func TestDebugPayload(t *testing.T) {
+	SetDebugSettings("DebugPayloadTest", DebugSettings{Enabled: true, MaxPayloadSize: 10})
+	payload := DebugPayload("debugpayloadtest", []byte(`{"secret":"abc","price":9000}`))
+	if strings.Contains(payload, "abc") || !strings.HasSuffix(payload, "bytes truncated)") {
+		t.Errorf("DebugPayload expected a redacted truncated payload, got %s", payload)
+	}
+
+	SetDebugSettings("DebugPayloadTest", DebugSettings{Enabled: true, ShowSecrets: true})
+	payload = DebugPayload("DebugPayloadTest", `{"secret":"abc"}`)
+	if payload != `{"secret":"abc"}` {
+		t.Errorf("DebugPayload expected secrets to be shown, got %s", payload)
+	}
+	if RedactHeader("DebugPayloadTest", "X-API-KEY", "abc") != "abc" {
+		t.Error("RedactHeader expected secrets to be shown")
+	}
+	if RedactHeader("unknown", "X-API-KEY", "abc") != redacted ||
+		RedactHeader("unknown", "Content-Type", "text/plain") != "text/plain" {
+		t.Error("RedactHeader unexpected header redaction")
+	}
+}
+
+func TestSampled(t *testing.T) {
+	if !Sampled("unknown") {
+		t.Error("Sampled expected names without settings to be logged")
+	}
+
+	SetDebugSettings("SampledTest", DebugSettings{})
+	if Sampled("SampledTest") {
+		t.Error("Sampled expected disabled output not to be logged")
+	}
+
+	SetDebugSettings("SampledTest", DebugSettings{Enabled: true, SampleRate: 0.1})
+	var sampled int
+	for i := 0; i < 10000; i++ {
+		if Sampled("SampledTest") {
+			sampled++
+		}
+	}
+	if sampled < 500 || sampled > 1500 {
+		t.Errorf("Sampled expected roughly 10%% of messages, got %d of 10000", sampled)
+	}
+}
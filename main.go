@@ -1,24 +1,30 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
-	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/thrasher-/gocryptotrader/clock"
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/communications"
+	"github.com/thrasher-/gocryptotrader/communications/base"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/connchecker"
 	"github.com/thrasher-/gocryptotrader/currency"
 	"github.com/thrasher-/gocryptotrader/currency/coinmarketcap"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/i18n"
 	log "github.com/thrasher-/gocryptotrader/logger"
 	"github.com/thrasher-/gocryptotrader/ntpclient"
 	"github.com/thrasher-/gocryptotrader/portfolio"
@@ -30,12 +36,14 @@ type Bot struct {
 	config       *config.Config
 	portfolio    *portfolio.Base
 	exchanges    []exchange.IBotExchange
+	exchangesMtx sync.RWMutex
 	comms        *communications.Communications
 	shutdown     chan bool
 	dryRun       bool
 	configFile   string
 	dataDir      string
 	connectivity *connchecker.Checker
+	started      time.Time
 	sync.Mutex
 }
 
@@ -48,10 +56,15 @@ const banner = `
                           /____//_/
 `
 
+// localeCatalogDir is the data directory sub directory translation catalogs
+// are loaded from
+const localeCatalogDir = "locales"
+
 var bot Bot
 
 func main() {
 	bot.shutdown = make(chan bool)
+	bot.started = clock.Now()
 	HandleInterrupt()
 
 	defaultPath, err := config.GetFilePath("")
@@ -65,6 +78,8 @@ func main() {
 	dryrun := flag.Bool("dryrun", false, "dry runs bot, doesn't save config file")
 	version := flag.Bool("version", false, "retrieves current GoCryptoTrader version")
 	verbosity := flag.Bool("verbose", false, "increases logging verbosity for GoCryptoTrader")
+	demo := flag.Bool("demo", false, "runs the engine against simulated exchanges using a generated config written to the data directory, no exchange accounts are needed")
+	genConfig := flag.String("genconfig", "", "writes a config template with the defaults of every supported exchange to the given path and exits, pairs are taken from the loaded config")
 
 	Coinmarketcap := flag.Bool("c", false, "overrides config and runs currency analaysis")
 	FxCurrencyConverter := flag.Bool("fxa", false, "overrides config and sets up foreign exchange Currency Converter")
@@ -86,6 +101,17 @@ func main() {
 	fmt.Println(banner)
 	fmt.Println(BuildVersion(false))
 
+	var demoSim *demoSimulator
+	if *demo {
+		bot.configFile, demoSim, err = setupDemo(bot.dataDir)
+		if err != nil {
+			log.Fatalf("Failed to set up demo mode. Err: %s", err)
+		}
+		bot.dryRun = true
+		log.Warnf("Demo mode enabled, exchanges are simulated and no orders are live. Webserver credentials are %s/%s.",
+			demoCredential, demoCredential)
+	}
+
 	bot.config = &config.Cfg
 	log.Debugf("Loading config file %s..\n", bot.configFile)
 	err = bot.config.LoadConfig(bot.configFile)
@@ -93,9 +119,143 @@ func main() {
 		log.Fatalf("Failed to load config. Err: %s", err)
 	}
 
-	err = common.CreateDir(bot.dataDir)
+	if *genConfig != "" {
+		err = writeConfigTemplate(*genConfig, bot.config)
+		if err != nil {
+			log.Fatalf("Failed to generate config template. Err: %s", err)
+		}
+		log.Debugf("Config template written to %s.\n", *genConfig)
+		os.Exit(0)
+	}
+
+	overrides := currency.BotOverrides{
+		Coinmarketcap:       *Coinmarketcap,
+		FxCurrencyConverter: *FxCurrencyConverter,
+		FxCurrencyLayer:     *FxCurrencyLayer,
+		FxFixer:             *FxFixer,
+		FxOpenExchangeRates: *FxOpenExchangeRates,
+	}
+	_, err = runStartupStages([]startupStage{
+		{name: "config", run: func() error { return setupEngineConfig(*verbosity) }},
+		{name: "currency", dependsOn: []string{"config"}, run: func() error {
+			return setupCurrencyStorage(overrides)
+		}},
+		{name: "comms", dependsOn: []string{"config"}, run: setupCommunications},
+		{name: "database", dependsOn: []string{"config"}, run: setupDatabase},
+		{name: "exchanges", dependsOn: []string{"currency", "comms"}, run: setupEngineExchanges},
+		{name: "state", dependsOn: []string{"exchanges"}, run: setupEngineState},
+		{name: "servers", dependsOn: []string{"state", "database"}, run: setupServers},
+	}, bot.config.Startup.Budget)
+	if err != nil {
+		log.Fatalf("Engine startup failed. Err: %s", err)
+	}
+
+	if bot.config.TokenRegistry.RemoteListURL != "" {
+		go portfolio.StartTokenRegistryUpdater(
+			bot.config.TokenRegistry.RemoteListURL,
+			bot.config.TokenRegistry.UpdateInterval)
+	}
+	go portfolio.StartPortfolioWatcher()
+
+	StartTaskQueue(bot.config.TaskQueue.Capacity, bot.config.TaskQueue.Workers,
+		bot.config.TaskQueue.MaxTaskAge)
+	go PollingSchedulerRoutine()
+	go WebsocketRoutine(bot.config.Debug.SubsystemEnabled(debugSubsystemWebsocket))
+	go KlineIntegrityRoutine()
+	go KlineMaintenanceRoutine()
+	go SystemStatusRoutine()
+	go AddressBookRoutine()
+	go RebateRoutine()
+
+	if len(bot.config.StrategyFeeds) > 0 {
+		go StrategyFeedRoutine()
+	}
+
+	if demoSim != nil {
+		go DemoRoutine(demoSim)
+	}
+	go OrderFillRoutine()
+
+	if bot.config.News.Enabled {
+		go NewsRoutine()
+	}
+
+	if bot.config.StablecoinMonitor.Enabled {
+		go StablecoinMonitorRoutine()
+	}
+
+	if bot.config.Arbitrage.Enabled {
+		go TriangularArbitrageRoutine()
+	}
+
+	if bot.config.Analytics.Enabled {
+		go AnalyticsRoutine()
+	}
+
+	if bot.config.DataRetention.Enabled {
+		go DataRetentionRoutine()
+	}
+
+	if bot.config.Heartbeat.Enabled {
+		go HeartbeatRoutine()
+	}
+
+	if bot.config.StatePersistence.Enabled {
+		go EngineStateRoutine()
+	}
+
+	if bot.config.Scheduler.Enabled {
+		go SchedulerRoutine()
+	}
+
+	if bot.config.ExpiryCalendar.Enabled || bot.config.AutoRoll.Enabled ||
+		bot.config.FuturesBasis.Enabled {
+		go ExpiryCalendarRoutine()
+	}
+
+	if bot.config.FuturesBasis.Enabled {
+		go FuturesBasisRoutine()
+	}
+
+	if bot.config.ExposureLimits.Enabled {
+		go ExposureLimitsRoutine()
+	}
+
+	if bot.config.OrderQueue.Enabled && tradingSupported {
+		go OrderQueueRoutine()
+	}
+
+	if bot.config.OrderManager.Enabled {
+		go OrderManagerRoutine()
+	}
+
+	if bot.config.MarketMaker.Enabled && tradingSupported {
+		go MarketMakerRoutine()
+	}
+
+	if bot.config.Database.Enabled {
+		go DatabaseRoutine()
+	}
+
+	if bot.config.LatencyMonitor.Enabled {
+		go LatencyMonitorRoutine()
+	}
+
+	if pairUpdatesEnabled(bot.config.Exchanges) {
+		go PairUpdaterRoutine()
+	}
+
+	<-bot.shutdown
+	Shutdown()
+}
+
+// setupEngineConfig applies the loaded config, setting up the data directory,
+// logger, locale, time sync check and connectivity monitor
+func setupEngineConfig(verbose bool) error {
+	err := common.CreateDir(bot.dataDir)
 	if err != nil {
-		log.Fatalf("Failed to open/create data directory: %s. Err: %s", bot.dataDir, err)
+		return fmt.Errorf("failed to open/create data directory: %s. Err: %s",
+			bot.dataDir, err)
 	}
 	log.Debugf("Using data directory: %s.\n", bot.dataDir)
 
@@ -109,6 +269,23 @@ func main() {
 		log.Errorf("Failed to setup logger reason: %s", err)
 	}
 
+	if verbose {
+		bot.config.Debug.Enabled = true
+	}
+	setupDebugSettings(&bot.config.Debug)
+	request.SetLatencyWindow(bot.config.LatencyMonitor.Window)
+
+	err = i18n.LoadCatalogs(filepath.Join(bot.dataDir, localeCatalogDir))
+	if err != nil {
+		log.Errorf("Failed to load locale catalogs: %s", err)
+	}
+	err = i18n.SetLocale(bot.config.Locale)
+	if err != nil {
+		log.Warnf("%s, supported locales: %s. Using %s", err,
+			common.JoinStrings(i18n.GetSupportedLocales(), ", "),
+			i18n.DefaultLocale)
+	}
+
 	if bot.config.NTPClient.Level != -1 {
 		bot.config.CheckNTPConfig()
 		NTPTime, errNTP := ntpclient.NTPClient(bot.config.NTPClient.Pool)
@@ -138,12 +315,13 @@ func main() {
 		bot.config.ConnectionMonitor.PublicDomainList,
 		bot.config.ConnectionMonitor.CheckInterval)
 	if err != nil {
-		log.Fatalf("Connectivity checker failure: %s", err)
+		return fmt.Errorf("connectivity checker failure: %s", err)
 	}
 
 	AdjustGoMaxProcs()
 	log.Debugf("Bot '%s' started.\n", bot.config.Name)
 	log.Debugf("Bot dry run mode: %v.\n", common.IsEnabled(bot.dryRun))
+	log.Debugf("Bot trading support: %v.\n", common.IsEnabled(tradingSupported))
 
 	log.Debugf("Available Exchanges: %d. Enabled Exchanges: %d.\n",
 		len(bot.config.Exchanges),
@@ -152,28 +330,19 @@ func main() {
 	common.HTTPClient = common.NewHTTPClientWithTimeout(bot.config.GlobalHTTPTimeout)
 	log.Debugf("Global HTTP request timeout: %v.\n", common.HTTPClient.Timeout)
 
-	SetupExchanges()
-	if len(bot.exchanges) == 0 {
-		log.Fatalf("No exchanges were able to be loaded. Exiting")
-	}
-
-	log.Debugf("Starting communication mediums..")
-	cfg := bot.config.GetCommunicationsConfig()
-	bot.comms = communications.NewComm(&cfg)
-	bot.comms.GetEnabledCommunicationMediums()
+	ticker.SetVWAPWindow(bot.config.VWAP.Window)
+	return nil
+}
 
+// setupCurrencyStorage starts the currency updater with the configured
+// providers and any command line overrides
+func setupCurrencyStorage(overrides currency.BotOverrides) error {
 	var newFxSettings []currency.FXSettings
 	for _, d := range bot.config.Currency.ForexProviders {
 		newFxSettings = append(newFxSettings, currency.FXSettings(d))
 	}
 
-	err = currency.RunStorageUpdater(currency.BotOverrides{
-		Coinmarketcap:       *Coinmarketcap,
-		FxCurrencyConverter: *FxCurrencyConverter,
-		FxCurrencyLayer:     *FxCurrencyLayer,
-		FxFixer:             *FxFixer,
-		FxOpenExchangeRates: *FxOpenExchangeRates,
-	},
+	err := currency.RunStorageUpdater(overrides,
 		&currency.MainConfiguration{
 			ForexProviders:         newFxSettings,
 			CryptocurrencyProvider: coinmarketcap.Settings(bot.config.Currency.CryptocurrencyProvider),
@@ -183,46 +352,67 @@ func main() {
 			FxRateDelay:            bot.config.Currency.ForeignExchangeUpdateDuration,
 		},
 		bot.dataDir,
-		*verbosity)
+		bot.config.Debug.SubsystemEnabled(debugSubsystemCurrency))
 	if err != nil {
-		log.Fatalf("currency updater system failed to start %v", err)
+		return fmt.Errorf("currency updater system failed to start %v", err)
+	}
+	return nil
+}
 
+// setupCommunications starts the enabled communication mediums
+func setupCommunications() error {
+	log.Debugf("Starting communication mediums..")
+	cfg := bot.config.GetCommunicationsConfig()
+	bot.comms = communications.NewComm(&cfg)
+	bot.comms.GetEnabledCommunicationMediums()
+	base.ExchangeCredentialsHandler = handleCommsExchangeCredentials
+	return nil
+}
+
+// setupEngineExchanges loads the enabled exchanges, failing when none could
+// be loaded
+func setupEngineExchanges() error {
+	SetupExchanges()
+	if len(GetExchanges()) == 0 {
+		return errors.New("no exchanges were able to be loaded")
+	}
+	if isPublicDataOnly(GetExchanges()) {
+		log.Info("No exchange API credentials set, running in public data-only mode.")
 	}
+	return nil
+}
 
+// setupEngineState seeds the portfolio and account balances, loads the
+// subsystems configured from the exchanges and restores any saved engine state
+func setupEngineState() error {
 	bot.portfolio = &portfolio.Portfolio
 	bot.portfolio.SeedPortfolio(bot.config.Portfolio)
-	SeedExchangeAccountInfo(GetAllEnabledExchangeAccountInfo().Data)
+	portfolio.SetTokens(bot.config.TokenRegistry.Tokens)
+	SeedExchangeAccountInfo(GetAllEnabledExchangeAccountInfo(true).Data)
+	strategyAllocations.Load(bot.config.StrategyAllocations)
+	strategyFeeds.Load(bot.config.StrategyFeeds)
+	orderMirrors.Load(bot.config.OrderMirrors)
+	orderbookHistory.Load(&bot.config.OrderbookHistory,
+		filepath.Join(bot.dataDir, orderbookHistoryDir))
+
+	if bot.config.StatePersistence.Enabled {
+		err := LoadEngineState(getEngineStatePath(),
+			bot.config.StatePersistence.MaxAge, clock.Now())
+		if err != nil {
+			log.Errorf("Failed to restore engine state: %s", err)
+		}
+	}
+	return nil
+}
 
+// setupServers starts the webserver when enabled
+func setupServers() error {
 	if bot.config.Webserver.Enabled {
-		listenAddr := bot.config.Webserver.ListenAddress
-		log.Debugf(
-			"HTTP Webserver support enabled. Listen URL: http://%s:%d/\n",
-			common.ExtractHost(listenAddr), common.ExtractPort(listenAddr),
-		)
-
-		router := NewRouter()
-		go func() {
-			err = http.ListenAndServe(listenAddr, router)
-			if err != nil {
-				log.Fatal(err)
-			}
-		}()
-
-		log.Debugln("HTTP Webserver started successfully.")
-		log.Debugln("Starting websocket handler.")
-		StartWebsocketHandler()
+		startWebserver()
 	} else {
 		log.Debugln("HTTP RESTful Webserver support disabled.")
 	}
-
-	go portfolio.StartPortfolioWatcher()
-
-	go TickerUpdaterRoutine()
-	go OrderbookUpdaterRoutine()
-	go WebsocketRoutine(*verbosity)
-
-	<-bot.shutdown
-	Shutdown()
+	return nil
 }
 
 // AdjustGoMaxProcs adjusts the maximum processes that the CPU can handle.
@@ -267,6 +457,22 @@ func Shutdown() {
 		bot.config.Portfolio = portfolio.Portfolio
 	}
 
+	if bot.config.MarketMaker.Enabled && tradingSupported {
+		CancelMarketMakerQuotes()
+	}
+
+	// Mirrored orders are tagged and persisted with the engine state
+	orderMirrorsPending.Wait()
+
+	if bot.config.StatePersistence.Enabled {
+		err := SaveEngineState(getEngineStatePath(), clock.Now())
+		if err != nil {
+			log.Warnf("Unable to save engine state: %s", err)
+		} else {
+			log.Debugln("Engine state saved successfully.")
+		}
+	}
+
 	if !bot.dryRun {
 		err := bot.config.SaveConfig(bot.configFile)
 
@@ -277,6 +483,7 @@ func Shutdown() {
 		}
 	}
 
+	closeDatabase()
 	log.Debugln("Exiting.")
 
 	log.CloseLogFile()
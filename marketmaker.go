@@ -0,0 +1,310 @@
+package main
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	marketMakerStrategy = "marketmaker"
+	marketMakerReason   = "market making quote"
+)
+
+// MarketMakerQuote is a limit order quoted by the market maker, tracked by the
+// order manager under its internal order ID
+type MarketMakerQuote struct {
+	InternalOrderID string             `json:"internalOrderId,omitempty"`
+	Side            exchange.OrderSide `json:"side"`
+	Price           float64            `json:"price"`
+	Amount          float64            `json:"amount"`
+}
+
+// MarketMakerPair is the state of a pair quoted by the market maker. Skew is
+// how far the inventory is from its target as a fraction of the max
+// inventory, positive when long
+type MarketMakerPair struct {
+	Exchange    string            `json:"exchange"`
+	Pair        currency.Pair     `json:"pair"`
+	Reference   float64           `json:"reference"`
+	Inventory   float64           `json:"inventory"`
+	Skew        float64           `json:"skew"`
+	Bid         *MarketMakerQuote `json:"bid,omitempty"`
+	Ask         *MarketMakerQuote `json:"ask,omitempty"`
+	LastUpdated time.Time         `json:"lastUpdated"`
+	Error       string            `json:"error,omitempty"`
+}
+
+// marketMakerBook holds the state of each quoted pair keyed by exchange and
+// pair
+type marketMakerBook struct {
+	pairs map[string]MarketMakerPair
+	m     sync.Mutex
+}
+
+var marketMakers marketMakerBook
+
+// marketMakerKey returns the key of an exchange pair
+func marketMakerKey(exchName string, p currency.Pair) string {
+	return common.StringToLower(exchName) + "|" + p.Base.Upper().String() +
+		p.Quote.Upper().String()
+}
+
+// Get returns the state of a quoted pair
+func (m *marketMakerBook) Get(exchName string, p currency.Pair) (MarketMakerPair, bool) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	pair, ok := m.pairs[marketMakerKey(exchName, p)]
+	return pair, ok
+}
+
+// Set stores the state of a quoted pair
+func (m *marketMakerBook) Set(pair *MarketMakerPair) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	if m.pairs == nil {
+		m.pairs = make(map[string]MarketMakerPair)
+	}
+	m.pairs[marketMakerKey(pair.Exchange, pair.Pair)] = *pair
+}
+
+// Remove drops the state of a pair no longer quoted
+func (m *marketMakerBook) Remove(exchName string, p currency.Pair) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	delete(m.pairs, marketMakerKey(exchName, p))
+}
+
+// GetAll returns the state of every quoted pair
+func (m *marketMakerBook) GetAll() []MarketMakerPair {
+	m.m.Lock()
+	defer m.m.Unlock()
+	pairs := make([]MarketMakerPair, 0, len(m.pairs))
+	for _, pair := range m.pairs {
+		pairs = append(pairs, pair)
+	}
+	return pairs
+}
+
+// getMarketMakerQuotes returns the skew of an inventory and the bid and ask to
+// quote around a reference price. Both quotes are shifted away from the side
+// which would grow the inventory further from its target, and that side's
+// amount is reduced in proportion to the skew. A side with no amount isn't
+// quoted
+func getMarketMakerQuotes(cfg *config.MarketMakerPairConfig, reference, inventory float64) (skew float64, bid, ask MarketMakerQuote) {
+	if cfg.MaxInventory > 0 {
+		skew = (inventory - cfg.TargetInventory) / cfg.MaxInventory
+		skew = math.Max(-1, math.Min(1, skew))
+	}
+
+	mid := reference * (1 - skew*cfg.SkewPercent/100)
+	half := reference * cfg.SpreadPercent / 200
+	bid = MarketMakerQuote{
+		Side:   exchange.BuyOrderSide,
+		Price:  mid - half,
+		Amount: cfg.OrderAmount * (1 - math.Max(skew, 0)),
+	}
+	ask = MarketMakerQuote{
+		Side:   exchange.SellOrderSide,
+		Price:  mid + half,
+		Amount: cfg.OrderAmount * (1 + math.Min(skew, 0)),
+	}
+	if bid.Price <= 0 {
+		bid.Amount = 0
+	}
+	return skew, bid, ask
+}
+
+// getMarketMakerReference returns the ticker mid price of a pair, falling back
+// to the last price when either side of the ticker is missing
+func getMarketMakerReference(exchName string, p currency.Pair) (float64, bool) {
+	t, err := ticker.GetTicker(exchName, p, ticker.Spot)
+	if err != nil {
+		return 0, false
+	}
+	if t.Bid > 0 && t.Ask > 0 {
+		return (t.Bid + t.Ask) / 2, true
+	}
+	return t.Last, t.Last > 0
+}
+
+// getMarketMakerInventory returns the total balance of a pairs base currency
+// across the exchanges accounts
+func getMarketMakerInventory(exch exchange.IBotExchange, p currency.Pair) (float64, error) {
+	info, err := GetExchangeAccountInfo(exch, false)
+	if err != nil {
+		return 0, err
+	}
+	var inventory float64
+	for x := range info.Accounts {
+		for y := range info.Accounts[x].Currencies {
+			if info.Accounts[x].Currencies[y].CurrencyName == p.Base {
+				inventory += info.Accounts[x].Currencies[y].TotalValue
+			}
+		}
+	}
+	return inventory, nil
+}
+
+// updateMarketMakerQuote keeps a resting quote which is still open and priced
+// within the requote threshold of the wanted quote, otherwise cancelling it
+// and submitting the wanted quote. The quote left resting is returned, nil if
+// none
+func updateMarketMakerQuote(exch exchange.IBotExchange, cfg *config.MarketMakerPairConfig, resting *MarketMakerQuote, wanted MarketMakerQuote) (*MarketMakerQuote, error) {
+	if resting != nil {
+		order, err := GetOrderByInternalID(resting.InternalOrderID)
+		if err != nil || !order.IsOpen() {
+			resting = nil
+		}
+	}
+
+	if resting != nil {
+		if wanted.Amount > 0 &&
+			math.Abs(wanted.Price-resting.Price)/resting.Price*100 <= cfg.RequoteThreshold {
+			return resting, nil
+		}
+		err := CancelByInternalID(resting.InternalOrderID)
+		if err != nil {
+			return resting, err
+		}
+	}
+
+	if wanted.Amount <= 0 {
+		return nil, nil
+	}
+	resp, err := SubmitStrategyOrder(marketMakerStrategy, exch, cfg.Pair, wanted.Side,
+		exchange.LimitOrderType, wanted.Amount, wanted.Price, "",
+		OrderTags{OrderTagReason: marketMakerReason})
+	if err != nil {
+		return nil, err
+	}
+	wanted.InternalOrderID = resp.InternalOrderID
+	return &wanted, nil
+}
+
+// cancelMarketMakerQuotes cancels the resting quotes of a pair, leaving the
+// quotes which failed to cancel in place
+func cancelMarketMakerQuotes(pair *MarketMakerPair) {
+	for _, quote := range []**MarketMakerQuote{&pair.Bid, &pair.Ask} {
+		if *quote == nil {
+			continue
+		}
+		order, err := GetOrderByInternalID((*quote).InternalOrderID)
+		if err == nil && order.IsOpen() {
+			err = CancelByInternalID((*quote).InternalOrderID)
+			if err != nil {
+				log.Errorf("Market maker failed to cancel %s %s %s quote. Error: %s",
+					pair.Exchange, pair.Pair, (*quote).Side, err)
+				continue
+			}
+		}
+		*quote = nil
+	}
+}
+
+// refreshMarketMakerPair requotes a pair around its reference price, skewed
+// by the exchanges inventory of the base currency. Quotes are pulled when the
+// reference price or inventory is unavailable or the pair isn't trading
+func refreshMarketMakerPair(exch exchange.IBotExchange, cfg *config.MarketMakerPairConfig, now time.Time) MarketMakerPair {
+	pair, _ := marketMakers.Get(exch.GetName(), cfg.Pair)
+	pair.Exchange = exch.GetName()
+	pair.Pair = cfg.Pair
+	pair.LastUpdated = now
+	pair.Error = ""
+
+	status, ok, err := getTradingStatus(exch, cfg.Pair, now)
+	if err == nil && ok && status.IsHalted() {
+		pair.Error = "pair is " + status.Status
+		cancelMarketMakerQuotes(&pair)
+		return pair
+	}
+
+	reference, ok := getMarketMakerReference(exch.GetName(), cfg.Pair)
+	if !ok {
+		pair.Error = "no reference price"
+		cancelMarketMakerQuotes(&pair)
+		return pair
+	}
+	inventory, err := getMarketMakerInventory(exch, cfg.Pair)
+	if err != nil {
+		pair.Error = err.Error()
+		cancelMarketMakerQuotes(&pair)
+		return pair
+	}
+
+	var bid, ask MarketMakerQuote
+	pair.Reference = reference
+	pair.Inventory = inventory
+	pair.Skew, bid, ask = getMarketMakerQuotes(cfg, reference, inventory)
+	pair.Bid, err = updateMarketMakerQuote(exch, cfg, pair.Bid, bid)
+	if err != nil {
+		pair.Error = err.Error()
+	}
+	pair.Ask, err = updateMarketMakerQuote(exch, cfg, pair.Ask, ask)
+	if err != nil {
+		pair.Error = err.Error()
+	}
+	return pair
+}
+
+// refreshMarketMakers requotes the enabled market maker pairs, pulling the
+// quotes of pairs which have since been disabled
+func refreshMarketMakers(cfg *config.MarketMakerConfig, now time.Time) {
+	for x := range cfg.Pairs {
+		if !cfg.Pairs[x].Enabled {
+			pair, ok := marketMakers.Get(cfg.Pairs[x].Exchange, cfg.Pairs[x].Pair)
+			if !ok {
+				continue
+			}
+			cancelMarketMakerQuotes(&pair)
+			if pair.Bid == nil && pair.Ask == nil {
+				marketMakers.Remove(pair.Exchange, pair.Pair)
+				continue
+			}
+			marketMakers.Set(&pair)
+			continue
+		}
+
+		exch := GetExchangeByName(cfg.Pairs[x].Exchange)
+		if exch == nil || !exch.IsEnabled() || !exch.GetAuthenticatedAPISupport() {
+			continue
+		}
+		pair := refreshMarketMakerPair(exch, &cfg.Pairs[x], now)
+		if pair.Error != "" {
+			log.Warnf("Market maker %s %s: %s", pair.Exchange, pair.Pair, pair.Error)
+		}
+		marketMakers.Set(&pair)
+	}
+}
+
+// MarketMakerRoutine quotes two sided limit orders on the enabled market maker
+// pairs on every refresh interval
+func MarketMakerRoutine() {
+	log.Debugln("Starting market maker routine.")
+	for {
+		refreshMarketMakers(&bot.config.MarketMaker, clock.Now())
+		clock.Sleep(bot.config.MarketMaker.RefreshInterval)
+	}
+}
+
+// CancelMarketMakerQuotes cancels every resting market maker quote
+func CancelMarketMakerQuotes() {
+	for _, pair := range marketMakers.GetAll() {
+		cancelMarketMakerQuotes(&pair)
+		marketMakers.Set(&pair)
+	}
+}
+
+// GetMarketMakerPairs returns the state of each pair quoted by the market
+// maker
+func GetMarketMakerPairs() []MarketMakerPair {
+	return marketMakers.GetAll()
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+type marketMakerTestExchange struct {
+	riskTestExchange
+	prices    []float64
+	cancelled []string
+}
+
+func (m *marketMakerTestExchange) IsEnabled() bool {
+	return true
+}
+
+func (m *marketMakerTestExchange) GetAuthenticatedAPISupport() bool {
+	return true
+}
+
+func (m *marketMakerTestExchange) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	m.prices = append(m.prices, price)
+	return exchange.SubmitOrderResponse{
+		IsOrderPlaced: true,
+		OrderID:       strconv.Itoa(len(m.prices)),
+	}, nil
+}
+
+func (m *marketMakerTestExchange) CancelOrder(order *exchange.OrderCancellation) error {
+	m.cancelled = append(m.cancelled, order.OrderID)
+	return nil
+}
+
+func TestGetMarketMakerQuotes(t *testing.T) {
+	cfg := config.MarketMakerPairConfig{
+		SpreadPercent:   1,
+		OrderAmount:     1,
+		TargetInventory: 1,
+		MaxInventory:    2,
+		SkewPercent:     1,
+	}
+	tests := []struct {
+		inventory, skew, bid, ask, bidAmount, askAmount float64
+	}{
+		{inventory: 1, skew: 0, bid: 99.5, ask: 100.5, bidAmount: 1, askAmount: 1},
+		{inventory: 2, skew: 0.5, bid: 99, ask: 100, bidAmount: 0.5, askAmount: 1},
+		{inventory: 0, skew: -0.5, bid: 100, ask: 101, bidAmount: 1, askAmount: 0.5},
+		{inventory: 5, skew: 1, bid: 98.5, ask: 99.5, bidAmount: 0, askAmount: 1},
+	}
+	for _, test := range tests {
+		skew, bid, ask := getMarketMakerQuotes(&cfg, 100, test.inventory)
+		if math.Abs(skew-test.skew) > 1e-9 ||
+			math.Abs(bid.Price-test.bid) > 1e-9 || math.Abs(ask.Price-test.ask) > 1e-9 ||
+			math.Abs(bid.Amount-test.bidAmount) > 1e-9 ||
+			math.Abs(ask.Amount-test.askAmount) > 1e-9 {
+			t.Errorf("Test failed. Inventory %v: unexpected skew %v bid %+v ask %+v",
+				test.inventory, skew, bid, ask)
+		}
+	}
+
+	cfg.MaxInventory = 0
+	skew, bid, ask := getMarketMakerQuotes(&cfg, 100, 5)
+	if skew != 0 || bid.Amount != 1 || ask.Amount != 1 {
+		t.Errorf("Test failed. Expected no skew without a max inventory, got %v", skew)
+	}
+}
+
+func TestRefreshMarketMakers(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+
+	exch := &marketMakerTestExchange{
+		riskTestExchange: riskTestExchange{
+			accountInfoTestExchange: *setupAccountInfoTest(t, time.Minute),
+		},
+	}
+	exchanges := bot.exchanges
+	bot.exchanges = []exchange.IBotExchange{exch}
+	managedOrders = orderManager{}
+	defer func() {
+		bot.exchanges = exchanges
+		managedOrders = orderManager{}
+		marketMakers = marketMakerBook{}
+	}()
+
+	p := currency.NewPairFromStrings("BTC", "EUR")
+	setTicker := func(bid, ask float64) {
+		err := ticker.ProcessTicker(exch.GetName(),
+			&ticker.Price{Pair: p, Bid: bid, Ask: ask, Last: bid}, ticker.Spot)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := config.MarketMakerConfig{
+		Pairs: []config.MarketMakerPairConfig{{
+			Enabled:          true,
+			Exchange:         exch.GetName(),
+			Pair:             p,
+			SpreadPercent:    2,
+			OrderAmount:      0.1,
+			TargetInventory:  1,
+			MaxInventory:     1,
+			SkewPercent:      1,
+			RequoteThreshold: 1,
+		}},
+	}
+
+	setTicker(99, 101)
+	refreshMarketMakers(&cfg, time.Now())
+	pairs := GetMarketMakerPairs()
+	if len(pairs) != 1 || pairs[0].Error != "" || pairs[0].Reference != 100 ||
+		pairs[0].Bid == nil || pairs[0].Ask == nil ||
+		pairs[0].Bid.Price != 99 || pairs[0].Ask.Price != 101 {
+		t.Fatalf("Test failed. Unexpected market maker state %+v", pairs)
+	}
+	if len(GetAllOpenOrders()) != 2 {
+		t.Errorf("Test failed. Expected quotes to be tracked by the order manager")
+	}
+
+	setTicker(99.5, 101.5)
+	refreshMarketMakers(&cfg, time.Now())
+	if len(exch.prices) != 2 || len(exch.cancelled) != 0 {
+		t.Errorf("Test failed. Expected quotes within the threshold to be kept, got %v",
+			exch.prices)
+	}
+
+	setTicker(108, 112)
+	refreshMarketMakers(&cfg, time.Now())
+	pairs = GetMarketMakerPairs()
+	if len(exch.prices) != 4 || len(exch.cancelled) != 2 ||
+		math.Abs(pairs[0].Bid.Price-108.9) > 1e-9 ||
+		math.Abs(pairs[0].Ask.Price-111.1) > 1e-9 {
+		t.Errorf("Test failed. Expected quotes to be replaced, got %+v", pairs)
+	}
+
+	cfg.Pairs[0].Enabled = false
+	refreshMarketMakers(&cfg, time.Now())
+	if len(GetMarketMakerPairs()) != 0 || len(exch.cancelled) != 4 ||
+		len(GetAllOpenOrders()) != 0 {
+		t.Errorf("Test failed. Expected quotes of a disabled pair to be cancelled, got %v",
+			exch.cancelled)
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	marketIndexTTL           = time.Minute
+	defaultMarketSearchLimit = 100
+)
+
+// Market search match ranks, best first
+const (
+	marketMatchExact = iota
+	marketMatchCurrency
+	marketMatchPrefix
+	marketMatchContains
+)
+
+var errMarketSearchQueryEmpty = errors.New("market search query cannot be empty")
+
+// MarketListing is a currency pair available on an exchange for an asset
+// type. The price and volume are from the last stored ticker and are unset
+// when the pair has no ticker
+type MarketListing struct {
+	Exchange    string        `json:"exchange"`
+	Pair        currency.Pair `json:"pair"`
+	AssetType   string        `json:"assetType"`
+	Enabled     bool          `json:"enabled"`
+	Last        float64       `json:"last,omitempty"`
+	Volume      float64       `json:"volume,omitempty"`
+	LastUpdated time.Time     `json:"lastUpdated,omitempty"`
+}
+
+// indexedMarket is a market listing with its normalised symbols for matching
+type indexedMarket struct {
+	listing MarketListing
+	symbol  string
+	base    string
+	quote   string
+}
+
+// marketIndex holds the available pairs of the loaded exchanges, rebuilt once
+// older than marketIndexTTL so pair updates and exchange reloads are picked up
+type marketIndex struct {
+	markets []indexedMarket
+	updated time.Time
+	m       sync.Mutex
+}
+
+var markets marketIndex
+
+// normaliseMarketSymbol upper cases a symbol and strips pair delimiters
+func normaliseMarketSymbol(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', '/', ' ':
+			return -1
+		}
+		return r
+	}, common.StringToUpper(s))
+}
+
+// buildMarketIndex returns the index of the available pairs of each asset
+// type of the exchanges
+func buildMarketIndex(exchanges []exchange.IBotExchange) []indexedMarket {
+	var resp []indexedMarket
+	for _, exch := range exchanges {
+		if exch == nil {
+			continue
+		}
+		enabled := exch.GetEnabledCurrencies()
+		pairs := exch.GetAvailableCurrencies()
+		for _, assetType := range exch.GetAssetTypes() {
+			for x := range pairs {
+				resp = append(resp, indexedMarket{
+					listing: MarketListing{
+						Exchange:  exch.GetName(),
+						Pair:      pairs[x],
+						AssetType: assetType,
+						Enabled:   enabled.Contains(pairs[x], true),
+					},
+					symbol: normaliseMarketSymbol(pairs[x].Base.String() +
+						pairs[x].Quote.String()),
+					base:  normaliseMarketSymbol(pairs[x].Base.String()),
+					quote: normaliseMarketSymbol(pairs[x].Quote.String()),
+				})
+			}
+		}
+	}
+	return resp
+}
+
+// Get returns the market index, rebuilding it from the exchanges when stale
+func (m *marketIndex) Get(exchanges []exchange.IBotExchange, now time.Time) []indexedMarket {
+	m.m.Lock()
+	defer m.m.Unlock()
+	if m.markets == nil || now.Sub(m.updated) > marketIndexTTL {
+		m.markets = buildMarketIndex(exchanges)
+		m.updated = now
+	}
+	return m.markets
+}
+
+// Invalidate forces the market index to be rebuilt on the next search
+func (m *marketIndex) Invalidate() {
+	m.m.Lock()
+	m.markets = nil
+	m.m.Unlock()
+}
+
+// matchMarket returns how well a normalised query matches a market
+func matchMarket(market *indexedMarket, query string) (int, bool) {
+	switch {
+	case market.symbol == query:
+		return marketMatchExact, true
+	case market.base == query || market.quote == query:
+		return marketMatchCurrency, true
+	case strings.HasPrefix(market.symbol, query):
+		return marketMatchPrefix, true
+	case strings.Contains(market.symbol, query):
+		return marketMatchContains, true
+	}
+	return 0, false
+}
+
+// searchMarkets returns up to limit markets matching a query with their last
+// ticker price and volume. Exact pair matches rank first, then pairs of a
+// matching currency, then pairs starting with or containing the query, each
+// ordered by volume
+func searchMarkets(index []indexedMarket, query string, limit int) ([]MarketListing, error) {
+	query = normaliseMarketSymbol(query)
+	if query == "" {
+		return nil, errMarketSearchQueryEmpty
+	}
+	if limit <= 0 {
+		limit = defaultMarketSearchLimit
+	}
+
+	type match struct {
+		listing MarketListing
+		rank    int
+	}
+	var matches []match
+	for x := range index {
+		rank, ok := matchMarket(&index[x], query)
+		if !ok {
+			continue
+		}
+		listing := index[x].listing
+		price, err := ticker.GetTicker(listing.Exchange, listing.Pair, listing.AssetType)
+		if err == nil {
+			listing.Last = price.Last
+			listing.Volume = price.Volume
+			listing.LastUpdated = price.LastUpdated
+		}
+		matches = append(matches, match{listing: listing, rank: rank})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		return matches[i].listing.Volume > matches[j].listing.Volume
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	resp := make([]MarketListing, len(matches))
+	for x := range matches {
+		resp[x] = matches[x].listing
+	}
+	return resp, nil
+}
+
+// SearchMarkets returns the exchange pairs and asset types matching a query
+// with their current price and volume, best match first
+func SearchMarkets(query string, limit int) ([]MarketListing, error) {
+	return searchMarkets(markets.Get(GetExchanges(), clock.Now()), query, limit)
+}
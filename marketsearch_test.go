@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+type marketSearchTestExchange struct {
+	accountInfoTestExchange
+	available currency.Pairs
+	enabled   currency.Pairs
+}
+
+func (m *marketSearchTestExchange) GetAvailableCurrencies() currency.Pairs {
+	return m.available
+}
+
+func (m *marketSearchTestExchange) GetEnabledCurrencies() currency.Pairs {
+	return m.enabled
+}
+
+func (m *marketSearchTestExchange) GetAssetTypes() []string {
+	return []string{ticker.Spot}
+}
+
+func TestSearchMarkets(t *testing.T) {
+	dogeBTC := currency.NewPairWithDelimiter("MKTDOGE", "BTC", "-")
+	dogeUSDT := currency.NewPairWithDelimiter("MKTDOGE", "USDT", "_")
+	dogeX := currency.NewPairWithDelimiter("MKTDOGEX", "BTC", "-")
+	first := &marketSearchTestExchange{
+		accountInfoTestExchange: accountInfoTestExchange{name: "MarketSearchTest"},
+		available:               currency.Pairs{dogeBTC, dogeX},
+		enabled:                 currency.Pairs{dogeBTC},
+	}
+	second := &marketSearchTestExchange{
+		accountInfoTestExchange: accountInfoTestExchange{name: "MarketSearchTest2"},
+		available:               currency.Pairs{dogeUSDT},
+	}
+	err := ticker.ProcessTicker(first.GetName(),
+		&ticker.Price{Pair: dogeBTC, Last: 0.0000003, Volume: 10}, ticker.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ticker.ProcessTicker(second.GetName(),
+		&ticker.Price{Pair: dogeUSDT, Last: 0.002, Volume: 500}, ticker.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var index marketIndex
+	markets := index.Get([]exchange.IBotExchange{first, second}, time.Now())
+	if len(markets) != 3 {
+		t.Fatalf("Test failed. Expected every available pair indexed, got %d", len(markets))
+	}
+
+	resp, err := searchMarkets(markets, "mktdoge", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 3 || resp[0].Exchange != second.GetName() || resp[0].Volume != 500 ||
+		resp[1].Exchange != first.GetName() || !resp[1].Enabled || resp[1].Last != 0.0000003 ||
+		!resp[2].Pair.Equal(dogeX) {
+		t.Errorf("Test failed. Expected currency matches by volume then prefix matches, got %+v",
+			resp)
+	}
+
+	resp, err = searchMarkets(markets, "mktdoge/btc", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 1 || !resp[0].Pair.Equal(dogeBTC) {
+		t.Errorf("Test failed. Expected the exact pair match first, got %+v", resp)
+	}
+
+	_, err = searchMarkets(markets, " - ", 0)
+	if err != errMarketSearchQueryEmpty {
+		t.Errorf("Test failed. Expected an empty query error, got %v", err)
+	}
+}
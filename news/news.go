@@ -0,0 +1,212 @@
+package news
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+// DefaultPollInterval is the default delay between polling announcement feeds
+const DefaultPollInterval = time.Minute * 5
+
+// Announcement categories
+const (
+	CategoryListing     = "LISTING"
+	CategoryDelisting   = "DELISTING"
+	CategoryMaintenance = "MAINTENANCE"
+	CategoryOther       = "OTHER"
+)
+
+// Feed types
+const (
+	FeedTypeRSS = "rss"
+)
+
+// Feed defines an exchange announcement feed to poll
+type Feed struct {
+	Exchange string `json:"exchange"`
+	URL      string `json:"url"`
+	Type     string `json:"type"`
+}
+
+// Item holds an individual announcement
+type Item struct {
+	Exchange   string
+	Title      string
+	Link       string
+	GUID       string
+	Published  time.Time
+	Category   string
+	Currencies []currency.Code
+}
+
+// String returns a summary of the announcement for the communications
+// package
+func (i *Item) String() string {
+	var currencies []string
+	for x := range i.Currencies {
+		currencies = append(currencies, i.Currencies[x].String())
+	}
+	return fmt.Sprintf("%s %s announcement: %s (%s) Currencies: %s",
+		i.Exchange,
+		i.Category,
+		i.Title,
+		i.Link,
+		common.JoinStrings(currencies, ","))
+}
+
+// rss defines the subset of an RSS 2.0 document read from feeds
+type rss struct {
+	Channel struct {
+		Items []struct {
+			Title       string `xml:"title"`
+			Link        string `xml:"link"`
+			GUID        string `xml:"guid"`
+			Description string `xml:"description"`
+			PubDate     string `xml:"pubDate"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+// ParseRSS parses an RSS document into announcement items
+func ParseRSS(exchName string, data []byte) ([]Item, error) {
+	var feed rss
+	err := xml.Unmarshal(data, &feed)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []Item
+	for _, x := range feed.Channel.Items {
+		item := Item{
+			Exchange: exchName,
+			Title:    strings.TrimSpace(x.Title),
+			Link:     strings.TrimSpace(x.Link),
+			GUID:     strings.TrimSpace(x.GUID),
+		}
+		if item.GUID == "" {
+			item.GUID = item.Link
+		}
+		for _, layout := range []string{time.RFC1123Z, time.RFC1123} {
+			item.Published, err = time.Parse(layout, strings.TrimSpace(x.PubDate))
+			if err == nil {
+				break
+			}
+		}
+		item.Category = Categorise(item.Title + " " + x.Description)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Categorise returns the announcement category based on keywords in its text
+func Categorise(text string) string {
+	text = strings.ToLower(text)
+	switch {
+	case strings.Contains(text, "delist"),
+		strings.Contains(text, "removal"),
+		strings.Contains(text, "will remove"):
+		return CategoryDelisting
+	case strings.Contains(text, "list"):
+		return CategoryListing
+	case strings.Contains(text, "maintenance"),
+		strings.Contains(text, "upgrade"),
+		strings.Contains(text, "suspend"):
+		return CategoryMaintenance
+	}
+	return CategoryOther
+}
+
+// Correlate sets the currencies of enabled pairs mentioned in the
+// announcement and returns whether the announcement is relevant to them.
+// Maintenance notices are always relevant as they affect all pairs
+func (i *Item) Correlate(enabledPairs currency.Pairs) bool {
+	words := strings.FieldsFunc(strings.ToUpper(i.Title), func(r rune) bool {
+		return !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+
+	mentioned := make(map[string]bool)
+	for x := range words {
+		mentioned[words[x]] = true
+	}
+
+	i.Currencies = nil
+	seen := make(map[string]bool)
+	for x := range enabledPairs {
+		for _, c := range []currency.Code{enabledPairs[x].Base, enabledPairs[x].Quote} {
+			code := c.Upper().String()
+			if mentioned[code] && !seen[code] {
+				seen[code] = true
+				i.Currencies = append(i.Currencies, c.Upper())
+			}
+		}
+	}
+
+	if i.Category == CategoryMaintenance {
+		return true
+	}
+	return len(i.Currencies) > 0 && i.Category != CategoryOther
+}
+
+// Fetch retrieves the announcements from a feed
+func Fetch(feed *Feed) ([]Item, error) {
+	switch feed.Type {
+	case FeedTypeRSS, "":
+		contents, err := common.SendHTTPRequest(http.MethodGet, feed.URL, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		return ParseRSS(feed.Exchange, []byte(contents))
+	}
+	return nil, fmt.Errorf("news feed %s unsupported type %s", feed.URL, feed.Type)
+}
+
+// Tracker keeps track of seen announcements so each is only reported once
+type Tracker struct {
+	seen map[string]bool
+	m    sync.Mutex
+}
+
+// NewTracker returns a new announcement tracker
+func NewTracker() *Tracker {
+	return &Tracker{seen: make(map[string]bool)}
+}
+
+// Filter returns the announcements which have not been seen before, marking
+// them as seen
+func (t *Tracker) Filter(items []Item) []Item {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	var unseen []Item
+	for x := range items {
+		key := items[x].Exchange + items[x].GUID
+		if t.seen[key] {
+			continue
+		}
+		t.seen[key] = true
+		unseen = append(unseen, items[x])
+	}
+	return unseen
+}
+
+// Validate checks a feed is usable
+func (f *Feed) Validate() error {
+	if f.Exchange == "" {
+		return errors.New("news feed exchange name not set")
+	}
+	if f.URL == "" {
+		return errors.New("news feed URL not set")
+	}
+	if f.Type != "" && f.Type != FeedTypeRSS {
+		return fmt.Errorf("news feed %s unsupported type %s", f.URL, f.Type)
+	}
+	return nil
+}
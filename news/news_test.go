@@ -0,0 +1,110 @@
+package news
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+const testRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+ <channel>
+  <title>Exchange Announcements</title>
+  <item>
+   <title>Exchange Will List Litecoin (LTC)</title>
+   <link>https://example.com/1</link>
+   <guid>1</guid>
+   <pubDate>Mon, 03 Jun 2019 10:00:00 +0000</pubDate>
+  </item>
+  <item>
+   <title>Notice on Delisting of XRP/BTC</title>
+   <link>https://example.com/2</link>
+   <pubDate>Mon, 03 Jun 2019 11:00:00 +0000</pubDate>
+  </item>
+  <item>
+   <title>Scheduled System Maintenance</title>
+   <link>https://example.com/3</link>
+  </item>
+  <item>
+   <title>Trading Competition Winners</title>
+   <link>https://example.com/4</link>
+  </item>
+ </channel>
+</rss>`
+
+func TestParseRSS(t *testing.T) {
+	items, err := ParseRSS("Binance", []byte(testRSS))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("Test failed. Expected 4 items, got %d", len(items))
+	}
+
+	expected := []string{CategoryListing, CategoryDelisting, CategoryMaintenance, CategoryOther}
+	for x := range items {
+		if items[x].Category != expected[x] {
+			t.Errorf("Test failed. Item %q expected category %s, got %s",
+				items[x].Title, expected[x], items[x].Category)
+		}
+	}
+
+	if items[1].GUID != "https://example.com/2" {
+		t.Error("Test failed. Expected GUID to default to link")
+	}
+	if items[0].Published.IsZero() {
+		t.Error("Test failed. Expected published time to be parsed")
+	}
+
+	_, err = ParseRSS("Binance", []byte("not xml"))
+	if err == nil {
+		t.Error("Test failed. Expected error parsing invalid RSS")
+	}
+}
+
+func TestCorrelate(t *testing.T) {
+	items, err := ParseRSS("Binance", []byte(testRSS))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pairs := currency.NewPairsFromStrings([]string{"LTC-BTC", "ETH-BTC"})
+	relevant := []bool{true, true, true, false}
+	for x := range items {
+		if items[x].Correlate(pairs) != relevant[x] {
+			t.Errorf("Test failed. Item %q expected relevance %v",
+				items[x].Title, relevant[x])
+		}
+	}
+
+	if len(items[0].Currencies) != 1 || items[0].Currencies[0] != currency.LTC {
+		t.Errorf("Test failed. Expected LTC to be correlated, got %v", items[0].Currencies)
+	}
+}
+
+func TestTrackerFilter(t *testing.T) {
+	items, err := ParseRSS("Binance", []byte(testRSS))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tracker := NewTracker()
+	if len(tracker.Filter(items)) != 4 {
+		t.Error("Test failed. Expected all items to be unseen")
+	}
+	if len(tracker.Filter(items)) != 0 {
+		t.Error("Test failed. Expected all items to be seen")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	f := Feed{Exchange: "Binance", URL: "https://example.com/rss"}
+	if err := f.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	f.Type = "atom"
+	if err := f.Validate(); err == nil {
+		t.Error("Test failed. Expected error with unsupported feed type")
+	}
+}
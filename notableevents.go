@@ -0,0 +1,67 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/communications/base"
+)
+
+// maxNotableEvents is the number of recent notable events kept for reports
+const maxNotableEvents = 100
+
+// NotableEvent is an event pushed to the communication mediums, such as an
+// endpoint degradation, stablecoin depeg or arbitrage opportunity
+type NotableEvent struct {
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Message string    `json:"message"`
+}
+
+// notableEventLog holds the most recent notable events
+type notableEventLog struct {
+	events []NotableEvent
+	m      sync.Mutex
+}
+
+var notableEvents notableEventLog
+
+// Record adds an event, dropping the oldest once the log is full
+func (n *notableEventLog) Record(eventType, message string, now time.Time) {
+	n.m.Lock()
+	defer n.m.Unlock()
+	n.events = append(n.events, NotableEvent{
+		Time:    now,
+		Type:    eventType,
+		Message: message,
+	})
+	if len(n.events) > maxNotableEvents {
+		n.events = n.events[len(n.events)-maxNotableEvents:]
+	}
+}
+
+// Between returns the events recorded within a period, oldest first
+func (n *notableEventLog) Between(from, to time.Time) []NotableEvent {
+	n.m.Lock()
+	defer n.m.Unlock()
+	var resp []NotableEvent
+	for x := range n.events {
+		if !n.events[x].Time.Before(from) && !n.events[x].Time.After(to) {
+			resp = append(resp, n.events[x])
+		}
+	}
+	return resp
+}
+
+// pushEvent records a notable event and pushes it through the communications
+// package
+func pushEvent(eventType, message string) {
+	notableEvents.Record(eventType, message, clock.Now())
+	if bot.comms != nil {
+		bot.comms.PushEvent(base.Event{
+			Type:         eventType,
+			TradeDetails: message,
+		})
+	}
+}
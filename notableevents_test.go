@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotableEventLog(t *testing.T) {
+	var n notableEventLog
+	start := time.Unix(0, 0)
+	for x := 0; x < maxNotableEvents+5; x++ {
+		n.Record("TEST", "event", start.Add(time.Minute*time.Duration(x)))
+	}
+
+	events := n.Between(start, start.Add(time.Hour*24))
+	if len(events) != maxNotableEvents {
+		t.Fatalf("Test failed. Expected %d events, got %d", maxNotableEvents,
+			len(events))
+	}
+	if !events[0].Time.Equal(start.Add(time.Minute * 5)) {
+		t.Errorf("Test failed. Expected the oldest events to be dropped, got %s",
+			events[0].Time)
+	}
+
+	events = n.Between(start.Add(time.Minute*10), start.Add(time.Minute*11))
+	if len(events) != 2 {
+		t.Errorf("Test failed. Expected 2 events within the period, got %d",
+			len(events))
+	}
+}
@@ -0,0 +1,130 @@
+//go:build !noweb
+// +build !noweb
+
+package main
+
+import (
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+// OrderbookDelta holds the orderbook levels which changed since an exchanges
+// previous orderbook update, a level with a zero amount has been removed.
+// Sequence increments with every delta per exchange, pair and asset type.
+// Snapshot deltas hold the complete orderbook and replace any orderbook held
+// by the client, deltas with a sequence at or below the snapshots are stale
+type OrderbookDelta struct {
+	Exchange  string           `json:"exchange"`
+	Pair      currency.Pair    `json:"pair"`
+	AssetType string           `json:"assetType"`
+	Sequence  uint64           `json:"sequence"`
+	Snapshot  bool             `json:"snapshot"`
+	Bids      []orderbook.Item `json:"bids"`
+	Asks      []orderbook.Item `json:"asks"`
+}
+
+type orderbookDeltaState struct {
+	exchange  string
+	pair      currency.Pair
+	assetType string
+	sequence  uint64
+	bids      []orderbook.Item
+	asks      []orderbook.Item
+}
+
+// orderbookDeltaTracker holds the last published orderbook levels so only the
+// levels which changed need to be sent to websocket clients
+type orderbookDeltaTracker struct {
+	books map[string]*orderbookDeltaState
+	m     sync.Mutex
+}
+
+var (
+	orderbookDeltas = orderbookDeltaTracker{
+		books: make(map[string]*orderbookDeltaState),
+	}
+	orderbookDeltaRelayMtx sync.Mutex
+)
+
+// Update records an orderbook and returns the levels which changed since its
+// previous update. The first update of an orderbook returns a snapshot. False
+// is returned when no levels changed
+func (o *orderbookDeltaTracker) Update(exchName string, p currency.Pair, assetType string, ob *orderbook.Base) (OrderbookDelta, bool) {
+	o.m.Lock()
+	defer o.m.Unlock()
+
+	key := exchName + p.Base.Upper().String() + p.Quote.Upper().String() + assetType
+	state, ok := o.books[key]
+	delta := OrderbookDelta{
+		Exchange:  exchName,
+		Pair:      p,
+		AssetType: assetType,
+	}
+	if !ok {
+		state = &orderbookDeltaState{
+			exchange:  exchName,
+			pair:      p,
+			assetType: assetType,
+		}
+		o.books[key] = state
+		delta.Snapshot = true
+		delta.Bids = ob.Bids
+		delta.Asks = ob.Asks
+	} else {
+		delta.Bids = diffOrderbookLevels(state.bids, ob.Bids)
+		delta.Asks = diffOrderbookLevels(state.asks, ob.Asks)
+		if len(delta.Bids) == 0 && len(delta.Asks) == 0 {
+			return delta, false
+		}
+	}
+
+	state.bids = append([]orderbook.Item(nil), ob.Bids...)
+	state.asks = append([]orderbook.Item(nil), ob.Asks...)
+	state.sequence++
+	delta.Sequence = state.sequence
+	return delta, true
+}
+
+// Snapshots returns snapshots of the tracked orderbooks matching a
+// subscription at their current sequence
+func (o *orderbookDeltaTracker) Snapshots(sub *websocketSubscription) []OrderbookDelta {
+	o.m.Lock()
+	defer o.m.Unlock()
+
+	var resp []OrderbookDelta
+	for _, state := range o.books {
+		if !sub.Match(state.exchange, state.pair, state.assetType) {
+			continue
+		}
+		resp = append(resp, OrderbookDelta{
+			Exchange:  state.exchange,
+			Pair:      state.pair,
+			AssetType: state.assetType,
+			Sequence:  state.sequence,
+			Snapshot:  true,
+			Bids:      state.bids,
+			Asks:      state.asks,
+		})
+	}
+	return resp
+}
+
+// relayOrderbookDelta publishes the levels of an orderbook which changed
+// since its previous update to subscribed websocket clients
+func relayOrderbookDelta(exchName string, p currency.Pair, assetType string, ob *orderbook.Base) {
+	if !wsHubStarted {
+		return
+	}
+
+	// Hold the lock until the delta is queued so deltas reach the hub in
+	// sequence order
+	orderbookDeltaRelayMtx.Lock()
+	defer orderbookDeltaRelayMtx.Unlock()
+	delta, ok := orderbookDeltas.Update(exchName, p, assetType, ob)
+	if !ok {
+		return
+	}
+	wsHub.orderbookDeltas <- delta
+}
@@ -0,0 +1,104 @@
+//go:build !noweb
+// +build !noweb
+
+package main
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+func TestOrderbookDeltaTracker(t *testing.T) {
+	o := orderbookDeltaTracker{books: make(map[string]*orderbookDeltaState)}
+	p := currency.NewPairFromStrings("BTC", "USD")
+	ob := orderbook.Base{
+		Bids: []orderbook.Item{{Price: 100, Amount: 1}, {Price: 99, Amount: 2}},
+		Asks: []orderbook.Item{{Price: 101, Amount: 1}},
+	}
+
+	delta, ok := o.Update("Bitstamp", p, orderbook.Spot, &ob)
+	if !ok || !delta.Snapshot || delta.Sequence != 1 || len(delta.Bids) != 2 {
+		t.Fatalf("Test failed. Expected initial snapshot, got %+v", delta)
+	}
+
+	_, ok = o.Update("Bitstamp", p, orderbook.Spot, &ob)
+	if ok {
+		t.Error("Test failed. Unchanged orderbook should not produce a delta")
+	}
+
+	ob = orderbook.Base{
+		Bids: []orderbook.Item{{Price: 100, Amount: 3}},
+		Asks: []orderbook.Item{{Price: 101, Amount: 1}, {Price: 102, Amount: 5}},
+	}
+	delta, ok = o.Update("Bitstamp", p, orderbook.Spot, &ob)
+	if !ok || delta.Snapshot || delta.Sequence != 2 {
+		t.Fatalf("Test failed. Expected delta sequence 2, got %+v", delta)
+	}
+	if len(delta.Bids) != 2 ||
+		delta.Bids[0] != (orderbook.Item{Price: 100, Amount: 3}) ||
+		delta.Bids[1] != (orderbook.Item{Price: 99}) {
+		t.Errorf("Test failed. Unexpected bid changes %v", delta.Bids)
+	}
+	if len(delta.Asks) != 1 || delta.Asks[0].Price != 102 {
+		t.Errorf("Test failed. Unexpected ask changes %v", delta.Asks)
+	}
+
+	sub, err := newWebsocketSubscription(&WebsocketSubscriptionRequest{
+		Event:    "orderbook_delta",
+		Exchange: "bitstamp",
+		Currency: "BTCUSD",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshots := o.Snapshots(&sub)
+	if len(snapshots) != 1 || snapshots[0].Sequence != 2 ||
+		!snapshots[0].Snapshot || len(snapshots[0].Asks) != 2 {
+		t.Errorf("Test failed. Unexpected snapshots %+v", snapshots)
+	}
+
+	sub, err = newWebsocketSubscription(&WebsocketSubscriptionRequest{
+		Event:    "orderbook_delta",
+		Currency: "LTCUSD",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Snapshots(&sub)) != 0 {
+		t.Error("Test failed. Expected no snapshots for an unmatched subscription")
+	}
+
+}
+
+func TestWebsocketHubOrderbookDeltas(t *testing.T) {
+	h := NewWebsocketHub()
+	subscribed := &WebsocketClient{Hub: h, Send: make(chan []byte, 10)}
+	other := &WebsocketClient{Hub: h, Send: make(chan []byte, 10)}
+	h.Clients[subscribed] = true
+	h.Clients[other] = true
+
+	p := currency.NewPairFromStrings("BTC", "USD")
+	h.updateSubscription(&websocketSubscriptionRequest{
+		client: subscribed,
+		sub:    websocketSubscription{Event: "orderbook_delta", Exchange: "Bitstamp"},
+	})
+
+	h.sendOrderbookDelta(&OrderbookDelta{Exchange: "Bitstamp", Pair: p, Sequence: 1})
+	h.sendOrderbookDelta(&OrderbookDelta{Exchange: "Kraken", Pair: p, Sequence: 1})
+	if len(subscribed.Send) != 1 || len(other.Send) != 0 {
+		t.Errorf("Test failed. Expected delta to reach only the subscribed client, got %d %d",
+			len(subscribed.Send), len(other.Send))
+	}
+
+	h.updateSubscription(&websocketSubscriptionRequest{
+		client:      subscribed,
+		sub:         websocketSubscription{Event: "orderbook_delta", Exchange: "Bitstamp"},
+		unsubscribe: true,
+	})
+	h.sendOrderbookDelta(&OrderbookDelta{Exchange: "Bitstamp", Pair: p, Sequence: 2})
+	if len(subscribed.Send) != 1 {
+		t.Error("Test failed. Unsubscribed client should not receive deltas")
+	}
+}
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	orderbookHistoryDir        = "orderbook_history"
+	orderbookHistoryDateFormat = "2006-01-02"
+)
+
+// OrderbookHistoryRecord holds a recorded orderbook update. Snapshot records
+// hold the complete orderbook, other records hold the levels which changed
+// since the previous record and a level with a zero amount has been removed
+type OrderbookHistoryRecord struct {
+	Timestamp time.Time        `json:"timestamp"`
+	Snapshot  bool             `json:"snapshot"`
+	Bids      []orderbook.Item `json:"bids"`
+	Asks      []orderbook.Item `json:"asks"`
+}
+
+// ReconstructedOrderbook holds an orderbook as it was at a past time, rebuilt
+// from the last recorded snapshot and the deltas recorded since
+type ReconstructedOrderbook struct {
+	Exchange     string           `json:"exchange"`
+	Pair         currency.Pair    `json:"pair"`
+	AssetType    string           `json:"assetType"`
+	Timestamp    time.Time        `json:"timestamp"`
+	SnapshotTime time.Time        `json:"snapshotTime"`
+	LastUpdated  time.Time        `json:"lastUpdated"`
+	Deltas       int              `json:"deltas"`
+	Bids         []orderbook.Item `json:"bids"`
+	Asks         []orderbook.Item `json:"asks"`
+}
+
+// orderbookHistoryBook holds the last recorded state of an orderbook
+type orderbookHistoryBook struct {
+	path         string
+	lastSnapshot time.Time
+	bids         []orderbook.Item
+	asks         []orderbook.Item
+}
+
+// orderbookHistoryRecorder records the websocket orderbook updates of the
+// configured exchanges to a file per orderbook and day. Each file starts with
+// a snapshot so it can be replayed on its own
+type orderbookHistoryRecorder struct {
+	dir              string
+	exchanges        map[string]bool
+	snapshotInterval time.Duration
+	books            map[string]*orderbookHistoryBook
+	m                sync.Mutex
+}
+
+var orderbookHistory = orderbookHistoryRecorder{
+	books: make(map[string]*orderbookHistoryBook),
+}
+
+// Load sets the exchanges whose orderbooks are recorded to dir, recording
+// nothing when the config is disabled
+func (o *orderbookHistoryRecorder) Load(cfg *config.OrderbookHistoryConfig, dir string) {
+	o.m.Lock()
+	defer o.m.Unlock()
+
+	o.dir = dir
+	o.snapshotInterval = cfg.SnapshotInterval
+	o.exchanges = make(map[string]bool)
+	o.books = make(map[string]*orderbookHistoryBook)
+	if !cfg.Enabled {
+		return
+	}
+	for x := range cfg.Exchanges {
+		o.exchanges[common.StringToLower(cfg.Exchanges[x])] = true
+	}
+}
+
+// IsRecording returns whether the orderbooks of an exchange are recorded
+func (o *orderbookHistoryRecorder) IsRecording(exchName string) bool {
+	o.m.Lock()
+	defer o.m.Unlock()
+	return o.exchanges[common.StringToLower(exchName)]
+}
+
+// Record appends the levels of an orderbook which changed since its previous
+// record, or a snapshot when the orderbook has no snapshot in the days file or
+// the snapshot interval has passed
+func (o *orderbookHistoryRecorder) Record(exchName string, p currency.Pair, assetType string, ob *orderbook.Base, now time.Time) error {
+	o.m.Lock()
+	defer o.m.Unlock()
+	if !o.exchanges[common.StringToLower(exchName)] {
+		return nil
+	}
+
+	path := getOrderbookHistoryPath(o.dir, exchName, p, assetType, now)
+	key := exchName + p.Base.Upper().String() + p.Quote.Upper().String() + assetType
+	book, ok := o.books[key]
+	record := OrderbookHistoryRecord{Timestamp: now}
+	if !ok || book.path != path || now.Sub(book.lastSnapshot) >= o.snapshotInterval {
+		record.Snapshot = true
+		record.Bids = ob.Bids
+		record.Asks = ob.Asks
+	} else {
+		record.Bids = diffOrderbookLevels(book.bids, ob.Bids)
+		record.Asks = diffOrderbookLevels(book.asks, ob.Asks)
+		if len(record.Bids) == 0 && len(record.Asks) == 0 {
+			return nil
+		}
+	}
+
+	err := appendOrderbookHistoryRecord(path, &record)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		book = &orderbookHistoryBook{}
+		o.books[key] = book
+	}
+	if record.Snapshot {
+		book.path = path
+		book.lastSnapshot = now
+	}
+	book.bids = append([]orderbook.Item(nil), ob.Bids...)
+	book.asks = append([]orderbook.Item(nil), ob.Asks...)
+	return nil
+}
+
+// recordOrderbookHistory records the current orderbook of a websocket
+// orderbook update when its exchange is recorded
+func recordOrderbookHistory(d *exchange.WebsocketOrderbookUpdate) {
+	if !orderbookHistory.IsRecording(d.Exchange) {
+		return
+	}
+
+	ob, err := orderbook.Get(d.Exchange, d.Pair, d.Asset)
+	if err != nil {
+		return
+	}
+	err = orderbookHistory.Record(d.Exchange, d.Pair, d.Asset, &ob, clock.Now())
+	if err != nil {
+		log.Errorf("%s unable to record %s %s orderbook history: %s",
+			d.Exchange, d.Pair, d.Asset, err)
+	}
+}
+
+// getOrderbookHistoryPath returns the file an orderbook is recorded to on the
+// UTC day of t
+func getOrderbookHistoryPath(dir, exchName string, p currency.Pair, assetType string, t time.Time) string {
+	return filepath.Join(dir, common.StringToLower(exchName),
+		fmt.Sprintf("%s%s_%s_%s.jsonl", p.Base.Upper(), p.Quote.Upper(),
+			common.StringToLower(assetType),
+			t.UTC().Format(orderbookHistoryDateFormat)))
+}
+
+func appendOrderbookHistoryRecord(path string, record *OrderbookHistoryRecord) error {
+	err := common.CreateDir(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	data, err := common.JSONEncode(record)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReconstructOrderbook returns an exchanges orderbook as it was at a past
+// time from its recorded orderbook history
+func ReconstructOrderbook(exchName, currencyPair, assetType string, at time.Time) (ReconstructedOrderbook, error) {
+	if exchName == "" || currencyPair == "" {
+		return ReconstructedOrderbook{}, errors.New("exchange and currency pair must be set")
+	}
+	if at.IsZero() {
+		return ReconstructedOrderbook{}, errors.New("timestamp must be set")
+	}
+	if assetType == "" {
+		assetType = ticker.Spot
+	}
+	return reconstructOrderbook(filepath.Join(bot.dataDir, orderbookHistoryDir),
+		exchName, currency.NewPairFromString(currencyPair), assetType, at)
+}
+
+// reconstructOrderbook replays the recorded orderbook history of the day
+// until a time. When no snapshot was recorded on that day before the time the
+// previous days history is replayed first
+func reconstructOrderbook(dir, exchName string, p currency.Pair, assetType string, at time.Time) (ReconstructedOrderbook, error) {
+	resp := ReconstructedOrderbook{
+		Exchange:  exchName,
+		Pair:      p,
+		AssetType: assetType,
+		Timestamp: at,
+	}
+
+	path := getOrderbookHistoryPath(dir, exchName, p, assetType, at)
+	err := replayOrderbookHistory(path, at, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.SnapshotTime.IsZero() {
+		previous := ReconstructedOrderbook{}
+		err = replayOrderbookHistory(getOrderbookHistoryPath(dir, exchName, p,
+			assetType, at.AddDate(0, 0, -1)), at, &previous)
+		if err != nil {
+			return resp, err
+		}
+		if previous.SnapshotTime.IsZero() {
+			return resp, fmt.Errorf("no %s %s %s orderbook history recorded before %s",
+				exchName, p, assetType, at.UTC().Format(time.RFC3339))
+		}
+		resp.SnapshotTime = previous.SnapshotTime
+		resp.LastUpdated = previous.LastUpdated
+		resp.Deltas = previous.Deltas
+		resp.Bids = previous.Bids
+		resp.Asks = previous.Asks
+	}
+
+	sort.Slice(resp.Bids, func(i, j int) bool {
+		return resp.Bids[i].Price > resp.Bids[j].Price
+	})
+	sort.Slice(resp.Asks, func(i, j int) bool {
+		return resp.Asks[i].Price < resp.Asks[j].Price
+	})
+	return resp, nil
+}
+
+// replayOrderbookHistory applies the records of a history file at or before
+// a time to an orderbook. Deltas before the first snapshot are skipped and a
+// missing file is treated as an empty history
+func replayOrderbookHistory(path string, at time.Time, ob *ReconstructedOrderbook) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record OrderbookHistoryRecord
+		err = common.JSONDecode(scanner.Bytes(), &record)
+		if err != nil {
+			return fmt.Errorf("orderbook history %s line %d: %s", path, line, err)
+		}
+		if record.Timestamp.After(at) {
+			break
+		}
+
+		if record.Snapshot {
+			ob.SnapshotTime = record.Timestamp
+			ob.Deltas = 0
+			ob.Bids = append([]orderbook.Item(nil), record.Bids...)
+			ob.Asks = append([]orderbook.Item(nil), record.Asks...)
+		} else {
+			if ob.SnapshotTime.IsZero() {
+				continue
+			}
+			ob.Deltas++
+			ob.Bids = applyOrderbookLevels(ob.Bids, record.Bids)
+			ob.Asks = applyOrderbookLevels(ob.Asks, record.Asks)
+		}
+		ob.LastUpdated = record.Timestamp
+	}
+	return scanner.Err()
+}
+
+// applyOrderbookLevels returns levels updated by changed levels, removing
+// levels changed to a zero amount
+func applyOrderbookLevels(levels, changes []orderbook.Item) []orderbook.Item {
+	for x := range changes {
+		found := false
+		for y := range levels {
+			if levels[y].Price != changes[x].Price {
+				continue
+			}
+			found = true
+			if changes[x].Amount == 0 {
+				levels = append(levels[:y], levels[y+1:]...)
+			} else {
+				levels[y] = changes[x]
+			}
+			break
+		}
+		if !found && changes[x].Amount != 0 {
+			levels = append(levels, changes[x])
+		}
+	}
+	return levels
+}
+
+// diffOrderbookLevels returns the levels which are new or have a different
+// amount, followed by removed levels with a zero amount
+func diffOrderbookLevels(prev, next []orderbook.Item) []orderbook.Item {
+	prevAmounts := make(map[float64]float64, len(prev))
+	for x := range prev {
+		prevAmounts[prev[x].Price] = prev[x].Amount
+	}
+
+	var changes []orderbook.Item
+	nextPrices := make(map[float64]bool, len(next))
+	for x := range next {
+		nextPrices[next[x].Price] = true
+		amount, ok := prevAmounts[next[x].Price]
+		if !ok || amount != next[x].Amount {
+			changes = append(changes, next[x])
+		}
+	}
+
+	var removed []orderbook.Item
+	for x := range prev {
+		if !nextPrices[prev[x].Price] {
+			removed = append(removed, orderbook.Item{Price: prev[x].Price})
+		}
+	}
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].Price < removed[j].Price
+	})
+	return append(changes, removed...)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+func TestApplyOrderbookLevels(t *testing.T) {
+	levels := []orderbook.Item{{Price: 100, Amount: 1}, {Price: 101, Amount: 2}}
+	levels = applyOrderbookLevels(levels, []orderbook.Item{
+		{Price: 100, Amount: 3},
+		{Price: 102, Amount: 4},
+		{Price: 101},
+		{Price: 103},
+	})
+	if len(levels) != 2 || levels[0].Amount != 3 || levels[1].Price != 102 {
+		t.Errorf("Test failed. Unexpected levels %v", levels)
+	}
+}
+
+func TestReconstructOrderbook(t *testing.T) {
+	dir, err := ioutil.TempDir("", "orderbookhistory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var recorder orderbookHistoryRecorder
+	recorder.Load(&config.OrderbookHistoryConfig{
+		Enabled:          true,
+		Exchanges:        []string{"HistoryTest"},
+		SnapshotInterval: time.Minute,
+	}, dir)
+
+	p := currency.NewPairFromStrings("BTC", "USD")
+	start := time.Date(2019, 6, 1, 23, 58, 0, 0, time.UTC)
+	updates := []orderbook.Base{
+		{Bids: []orderbook.Item{{Price: 99, Amount: 1}}, Asks: []orderbook.Item{{Price: 101, Amount: 1}}},
+		{Bids: []orderbook.Item{{Price: 99, Amount: 2}, {Price: 98, Amount: 1}}, Asks: []orderbook.Item{{Price: 101, Amount: 1}}},
+		{Bids: []orderbook.Item{{Price: 98, Amount: 1}}, Asks: []orderbook.Item{{Price: 101, Amount: 1}, {Price: 102, Amount: 5}}},
+	}
+	for x := range updates {
+		err = recorder.Record("HistoryTest", p, "SPOT", &updates[x],
+			start.Add(time.Second*time.Duration(x*10)))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = recorder.Record("Untracked", p, "SPOT", &updates[0], start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ob, err := reconstructOrderbook(dir, "HistoryTest", p, "SPOT", start.Add(time.Second*15))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ob.Deltas != 1 || !ob.SnapshotTime.Equal(start) ||
+		!ob.LastUpdated.Equal(start.Add(time.Second*10)) {
+		t.Errorf("Test failed. Unexpected reconstruction %+v", ob)
+	}
+	if len(ob.Bids) != 2 || ob.Bids[0].Price != 99 || ob.Bids[0].Amount != 2 ||
+		len(ob.Asks) != 1 {
+		t.Errorf("Test failed. Unexpected levels bids %v asks %v", ob.Bids, ob.Asks)
+	}
+
+	// The next day is reconstructed from the previous days history until its
+	// own snapshot is recorded
+	ob, err = reconstructOrderbook(dir, "HistoryTest", p, "SPOT", start.Add(time.Minute*3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ob.Deltas != 2 || len(ob.Bids) != 1 || len(ob.Asks) != 2 || ob.Asks[1].Price != 102 {
+		t.Errorf("Test failed. Unexpected reconstruction %+v", ob)
+	}
+
+	_, err = reconstructOrderbook(dir, "HistoryTest", p, "SPOT", start.Add(-time.Second))
+	if err == nil {
+		t.Error("Test failed. Expected error reconstructing before the recorded history")
+	}
+	_, err = reconstructOrderbook(dir, "Untracked", p, "SPOT", start.Add(time.Second))
+	if err == nil {
+		t.Error("Test failed. Expected error for an unrecorded exchange")
+	}
+}
+
+func TestOrderbookHistorySnapshotInterval(t *testing.T) {
+	dir, err := ioutil.TempDir("", "orderbookhistory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var recorder orderbookHistoryRecorder
+	recorder.Load(&config.OrderbookHistoryConfig{
+		Enabled:          true,
+		Exchanges:        []string{"HistoryTest"},
+		SnapshotInterval: time.Minute,
+	}, dir)
+
+	p := currency.NewPairFromStrings("BTC", "USD")
+	start := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	for x, amount := range []float64{1, 2, 3} {
+		ob := orderbook.Base{Bids: []orderbook.Item{{Price: 99, Amount: amount}}}
+		err = recorder.Record("HistoryTest", p, "SPOT", &ob,
+			start.Add(time.Second*time.Duration(x*40)))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ob, err := reconstructOrderbook(dir, "HistoryTest", p, "SPOT", start.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ob.SnapshotTime.Equal(start.Add(time.Second*80)) || ob.Deltas != 0 ||
+		ob.Bids[0].Amount != 3 {
+		t.Errorf("Test failed. Expected a snapshot after the interval, got %+v", ob)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// emulateOrderFlags returns the price of an order with flags submitted to an
+// exchange without native order flags. Post-only orders which would cross the
+// ticker spread are moved to the best price on their own side of the book so
+// they rest instead of taking liquidity. Reduce-only orders can't be emulated
+func emulateOrderFlags(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, price float64, flags exchange.OrderFlags) (float64, error) {
+	if flags.ReduceOnly {
+		return 0, exchange.ErrReduceOnlyUnsupported
+	}
+	if !flags.PostOnly {
+		return price, nil
+	}
+	if orderType != exchange.LimitOrderType {
+		return 0, exchange.ErrPostOnlyMarketOrder
+	}
+
+	t, err := ticker.GetTicker(exch.GetName(), p, ticker.Spot)
+	if err != nil || t.Bid <= 0 || t.Ask <= 0 {
+		return 0, fmt.Errorf("unable to emulate %s %s post-only order without the ticker spread",
+			exch.GetName(), p)
+	}
+
+	adjusted := price
+	switch side {
+	case exchange.BuyOrderSide, exchange.BidOrderSide:
+		if price >= t.Ask {
+			adjusted = t.Bid
+		}
+	case exchange.SellOrderSide, exchange.AskOrderSide:
+		if price <= t.Bid {
+			adjusted = t.Ask
+		}
+	}
+	if adjusted != price {
+		log.Debugf("%s %s post-only %s order price %v would cross the spread, adjusted to %v.",
+			exch.GetName(), p, side, price, adjusted)
+	}
+	return adjusted, nil
+}
+
+// SubmitFlaggedOrderRequest submits an order with post-only or reduce-only
+// flags. Flagged orders are neither funded nor queued
+func SubmitFlaggedOrderRequest(exchName, currencyPair, side, orderType string, amount, price float64, flags exchange.OrderFlags, tags OrderTags) (exchange.SubmitOrderResponse, error) {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return exchange.SubmitOrderResponse{}, errors.New(exchange.ErrExchangeNotFound)
+	}
+
+	s, t, err := parseOrderRequest(side, orderType, amount)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+
+	return SubmitTaggedFlaggedOrder(exch, currency.NewPairFromString(currencyPair),
+		s, t, amount, price, "", flags, tags)
+}
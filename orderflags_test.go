@@ -0,0 +1,111 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+type orderFlagsTestExchange struct {
+	fundingTestExchange
+	flags []exchange.OrderFlags
+}
+
+func (o *orderFlagsTestExchange) SubmitFlaggedOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string, flags exchange.OrderFlags) (exchange.SubmitOrderResponse, error) {
+	o.flags = append(o.flags, flags)
+	return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "3"}, nil
+}
+
+func TestEmulateOrderFlags(t *testing.T) {
+	exch := &tradeSimulationTestExchange{}
+	p := currency.NewPairFromStrings("ETH", "USD")
+	err := ticker.ProcessTicker(exch.GetName(),
+		&ticker.Price{Pair: p, Bid: 99, Ask: 101, Last: 100}, ticker.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	price, err := emulateOrderFlags(exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 102, exchange.OrderFlags{PostOnly: true})
+	if err != nil || price != 99 {
+		t.Errorf("Test failed. Expected a crossing buy moved to the bid, got %v %v",
+			price, err)
+	}
+
+	price, err = emulateOrderFlags(exch, p, exchange.SellOrderSide,
+		exchange.LimitOrderType, 98, exchange.OrderFlags{PostOnly: true})
+	if err != nil || price != 101 {
+		t.Errorf("Test failed. Expected a crossing sell moved to the ask, got %v %v",
+			price, err)
+	}
+
+	price, err = emulateOrderFlags(exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 95, exchange.OrderFlags{PostOnly: true})
+	if err != nil || price != 95 {
+		t.Errorf("Test failed. Expected a resting buy to keep its price, got %v %v",
+			price, err)
+	}
+
+	_, err = emulateOrderFlags(exch, p, exchange.BuyOrderSide,
+		exchange.MarketOrderType, 0, exchange.OrderFlags{PostOnly: true})
+	if err != exchange.ErrPostOnlyMarketOrder {
+		t.Errorf("Test failed. Expected a post-only market order to be rejected, got %v", err)
+	}
+
+	_, err = emulateOrderFlags(exch, p, exchange.SellOrderSide,
+		exchange.LimitOrderType, 100, exchange.OrderFlags{ReduceOnly: true})
+	if err != exchange.ErrReduceOnlyUnsupported {
+		t.Errorf("Test failed. Expected a reduce-only order to be rejected, got %v", err)
+	}
+
+	_, err = emulateOrderFlags(exch, currency.NewPairFromStrings("XRP", "USD"),
+		exchange.BuyOrderSide, exchange.LimitOrderType, 1, exchange.OrderFlags{PostOnly: true})
+	if err == nil {
+		t.Error("Test failed. Expected a pair without a ticker to be rejected")
+	}
+}
+
+func TestSubmitTaggedFlaggedOrder(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+
+	orderTags.Restore(nil)
+	defer orderTags.Restore(nil)
+	funding := fundingTestExchange{
+		accountInfoTestExchange: *setupAccountInfoTest(t, time.Minute),
+	}
+	p := currency.NewPairFromStrings("BTC", "USD")
+
+	native := &orderFlagsTestExchange{fundingTestExchange: funding}
+	_, err := SubmitTaggedFlaggedOrder(native, p, exchange.SellOrderSide,
+		exchange.LimitOrderType, 0.01, 5000, "", exchange.OrderFlags{ReduceOnly: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(native.flags) != 1 || !native.flags[0].ReduceOnly || len(native.orders) != 0 {
+		t.Errorf("Test failed. Expected the flags passed natively, got %v", native.flags)
+	}
+
+	_, err = SubmitTaggedFlaggedOrder(native, p, exchange.SellOrderSide,
+		exchange.LimitOrderType, 0.01, 5000, "", exchange.OrderFlags{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(native.flags) != 1 || len(native.orders) != 1 {
+		t.Error("Test failed. Expected an order without flags submitted normally")
+	}
+
+	_, err = SubmitTaggedFlaggedOrder(&funding, p, exchange.SellOrderSide,
+		exchange.LimitOrderType, 0.01, 5000, "", exchange.OrderFlags{ReduceOnly: true}, nil)
+	if err != exchange.ErrReduceOnlyUnsupported {
+		t.Errorf("Test failed. Expected reduce-only to be rejected without native flags, got %v",
+			err)
+	}
+	if len(GetTaggedOrders(funding.GetName(), nil)) != 2 {
+		t.Error("Test failed. Expected the submitted orders to be recorded")
+	}
+}
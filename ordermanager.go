@@ -0,0 +1,387 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	// maxManagedOrders is the number of orders kept by the order manager, the
+	// oldest closed orders are dropped first and open orders are always kept
+	maxManagedOrders = 1000
+
+	// orderManagerSettleTime is how long after submission an order missing
+	// from its exchanges open orders is still assumed open, as some exchanges
+	// list new orders late
+	orderManagerSettleTime = time.Second * 10
+)
+
+var (
+	errManagedOrderNotFound = errors.New("managed order not found")
+	managedOrderSeq         int64
+)
+
+// ManagedOrder is an order tracked by the order manager, identified by an
+// internal ID assigned when first tracked as exchange order IDs are only
+// unique per exchange. Orders found open on an exchange which weren't
+// submitted by the bot are tracked too
+type ManagedOrder struct {
+	InternalID      string               `json:"internalId"`
+	Exchange        string               `json:"exchange"`
+	OrderID         string               `json:"orderId"`
+	ClientID        string               `json:"clientId,omitempty"`
+	Pair            currency.Pair        `json:"pair"`
+	Side            exchange.OrderSide   `json:"side"`
+	OrderType       exchange.OrderType   `json:"orderType"`
+	Amount          float64              `json:"amount"`
+	Price           float64              `json:"price,omitempty"`
+	ExecutedAmount  float64              `json:"executedAmount"`
+	RemainingAmount float64              `json:"remainingAmount"`
+	Status          exchange.OrderStatus `json:"status"`
+	Submitted       time.Time            `json:"submitted"`
+	LastUpdated     time.Time            `json:"lastUpdated"`
+}
+
+// IsOpen returns whether the order is believed to be open on its exchange
+func (m *ManagedOrder) IsOpen() bool {
+	switch m.Status {
+	case exchange.NewOrderStatus, exchange.ActiveOrderStatus,
+		exchange.PartiallyFilledOrderStatus:
+		return true
+	}
+	return false
+}
+
+// orderManager holds the tracked orders oldest first
+type orderManager struct {
+	orders []ManagedOrder
+	m      sync.Mutex
+}
+
+var managedOrders orderManager
+
+// newManagedOrderID returns a unique internal order ID
+func newManagedOrderID() string {
+	return fmt.Sprintf("%x-%d", clock.Now().UnixNano(),
+		atomic.AddInt64(&managedOrderSeq, 1))
+}
+
+// prune drops the oldest closed orders once more than maxManagedOrders are
+// held
+func (o *orderManager) prune() {
+	excess := len(o.orders) - maxManagedOrders
+	if excess <= 0 {
+		return
+	}
+	kept := o.orders[:0]
+	for x := range o.orders {
+		if excess > 0 && !o.orders[x].IsOpen() {
+			excess--
+			continue
+		}
+		kept = append(kept, o.orders[x])
+	}
+	o.orders = kept
+}
+
+// find returns the index of an order by its exchange and order ID
+func (o *orderManager) find(exchName, orderID string) int {
+	for x := len(o.orders) - 1; x >= 0; x-- {
+		if strings.EqualFold(o.orders[x].Exchange, exchName) &&
+			o.orders[x].OrderID == orderID {
+			return x
+		}
+	}
+	return -1
+}
+
+// Add tracks an order and returns its internal ID. Orders without an exchange
+// order ID can't be followed and are tracked with an unknown status
+func (o *orderManager) Add(order ManagedOrder) string {
+	o.m.Lock()
+	defer o.m.Unlock()
+	return o.add(order)
+}
+
+// add tracks an order as Add does, the caller must hold the lock
+func (o *orderManager) add(order ManagedOrder) string {
+	order.InternalID = newManagedOrderID()
+	switch {
+	case order.OrderID == "":
+		order.Status = exchange.UnknownOrderStatus
+	case order.Status == "":
+		order.Status = exchange.NewOrderStatus
+	}
+	if order.LastUpdated.IsZero() {
+		order.LastUpdated = order.Submitted
+	}
+	o.orders = append(o.orders, order)
+	o.prune()
+	return order.InternalID
+}
+
+// Get returns an order by its internal ID
+func (o *orderManager) Get(id string) (ManagedOrder, bool) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	for x := range o.orders {
+		if o.orders[x].InternalID == id {
+			return o.orders[x], true
+		}
+	}
+	return ManagedOrder{}, false
+}
+
+// GetOpen returns the open orders on an exchange, or all exchanges when
+// exchName is empty, oldest first
+func (o *orderManager) GetOpen(exchName string) []ManagedOrder {
+	o.m.Lock()
+	defer o.m.Unlock()
+	var resp []ManagedOrder
+	for x := range o.orders {
+		if o.orders[x].IsOpen() &&
+			(exchName == "" || strings.EqualFold(o.orders[x].Exchange, exchName)) {
+			resp = append(resp, o.orders[x])
+		}
+	}
+	return resp
+}
+
+// GetAll returns every tracked order oldest first
+func (o *orderManager) GetAll() []ManagedOrder {
+	o.m.Lock()
+	defer o.m.Unlock()
+	return append([]ManagedOrder(nil), o.orders...)
+}
+
+// UpdateOpen records an order listed open on its exchange, tracking it when
+// it isn't already
+func (o *orderManager) UpdateOpen(exchName string, detail *exchange.OrderDetail, now time.Time) {
+	status := exchange.ActiveOrderStatus
+	if detail.ExecutedAmount > 0 {
+		status = exchange.PartiallyFilledOrderStatus
+	}
+
+	o.m.Lock()
+	defer o.m.Unlock()
+	x := o.find(exchName, detail.ID)
+	if x == -1 {
+		submitted := detail.OrderDate
+		if submitted.IsZero() {
+			submitted = now
+		}
+		o.add(ManagedOrder{
+			Exchange:        exchName,
+			OrderID:         detail.ID,
+			Pair:            detail.CurrencyPair,
+			Side:            detail.OrderSide,
+			OrderType:       detail.OrderType,
+			Amount:          detail.Amount,
+			Price:           detail.Price,
+			ExecutedAmount:  detail.ExecutedAmount,
+			RemainingAmount: detail.RemainingAmount,
+			Status:          status,
+			Submitted:       submitted,
+			LastUpdated:     now,
+		})
+		return
+	}
+	if detail.Amount > 0 {
+		o.orders[x].Amount = detail.Amount
+	}
+	o.orders[x].ExecutedAmount = detail.ExecutedAmount
+	o.orders[x].RemainingAmount = detail.RemainingAmount
+	o.orders[x].Status = status
+	o.orders[x].LastUpdated = now
+}
+
+// SetClosed records an order is no longer open on its exchange with the
+// status and amounts it closed with. A nil detail leaves the amounts unchanged
+func (o *orderManager) SetClosed(exchName, orderID string, status exchange.OrderStatus, detail *exchange.OrderDetail, now time.Time) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	x := o.find(exchName, orderID)
+	if x == -1 {
+		return
+	}
+	if detail != nil {
+		o.orders[x].ExecutedAmount = detail.ExecutedAmount
+		o.orders[x].RemainingAmount = detail.RemainingAmount
+	}
+	o.orders[x].Status = status
+	o.orders[x].LastUpdated = now
+}
+
+// SetCancelled records the cancellation of an open order by its exchange order
+// ID, or its client order ID when orderID is empty
+func (o *orderManager) SetCancelled(exchName, orderID, clientID string, now time.Time) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	for x := range o.orders {
+		if !strings.EqualFold(o.orders[x].Exchange, exchName) || !o.orders[x].IsOpen() {
+			continue
+		}
+		if (orderID != "" && o.orders[x].OrderID == orderID) ||
+			(orderID == "" && clientID != "" && o.orders[x].ClientID == clientID) {
+			o.orders[x].Status = exchange.CancelledOrderStatus
+			o.orders[x].LastUpdated = now
+		}
+	}
+}
+
+// Restore replaces the tracked orders with those saved in the engine state
+func (o *orderManager) Restore(orders []ManagedOrder) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	o.orders = append([]ManagedOrder(nil), orders...)
+	o.prune()
+}
+
+// trackSubmittedOrder tracks an order submitted by the bot and returns its
+// internal ID
+func trackSubmittedOrder(exch exchange.IBotExchange, orderID string, p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) string {
+	return managedOrders.Add(ManagedOrder{
+		Exchange:        exch.GetName(),
+		OrderID:         orderID,
+		ClientID:        clientID,
+		Pair:            p,
+		Side:            side,
+		OrderType:       orderType,
+		Amount:          amount,
+		Price:           price,
+		RemainingAmount: amount,
+		Submitted:       clock.Now(),
+	})
+}
+
+// getClosedOrderStatus returns the status of an order no longer open from its
+// executed and remaining amounts
+func getClosedOrderStatus(detail *exchange.OrderDetail) exchange.OrderStatus {
+	switch {
+	case detail.ExecutedAmount > 0 && detail.RemainingAmount <= 0:
+		return exchange.FilledOrderStatus
+	case detail.RemainingAmount > 0:
+		return exchange.CancelledOrderStatus
+	}
+	return exchange.UnknownOrderStatus
+}
+
+// updateManagedOrders polls an exchanges open orders, updating the tracked
+// orders listed and tracking those which aren't. Tracked open orders no longer
+// listed are closed with the status from their order info, or an unknown
+// status when the exchange can't provide it
+func updateManagedOrders(exch exchange.IBotExchange, now time.Time) {
+	tracked := managedOrders.GetOpen(exch.GetName())
+	pairs := exch.GetEnabledCurrencies()
+	for x := range tracked {
+		if !pairs.Contains(tracked[x].Pair, true) {
+			pairs = append(pairs, tracked[x].Pair)
+		}
+	}
+
+	active, err := exch.GetActiveOrders(&exchange.GetOrdersRequest{
+		Currencies: pairs,
+	})
+	if err != nil {
+		log.Debugf("%s failed to get open orders for the order manager. Error: %s",
+			exch.GetName(), err)
+		return
+	}
+
+	listed := make(map[string]bool, len(active))
+	for x := range active {
+		if active[x].ID == "" {
+			continue
+		}
+		active[x].SetFillAmounts()
+		listed[active[x].ID] = true
+		managedOrders.UpdateOpen(exch.GetName(), &active[x], now)
+	}
+
+	for x := range tracked {
+		if listed[tracked[x].OrderID] ||
+			now.Sub(tracked[x].Submitted) < orderManagerSettleTime {
+			continue
+		}
+		detail, err := exch.GetOrderInfo(tracked[x].OrderID)
+		if err != nil {
+			managedOrders.SetClosed(exch.GetName(), tracked[x].OrderID,
+				exchange.UnknownOrderStatus, nil, now)
+			continue
+		}
+		detail.SetFillAmounts()
+		status := getClosedOrderStatus(&detail)
+		managedOrders.SetClosed(exch.GetName(), tracked[x].OrderID, status,
+			&detail, now)
+		log.Debugf("%s order %s %s closed as %s", exch.GetName(),
+			tracked[x].InternalID, tracked[x].OrderID, status)
+	}
+}
+
+// OrderManagerRoutine periodically polls the open orders of every enabled
+// exchange with authenticated API support for the order manager
+func OrderManagerRoutine() {
+	log.Debugln("Starting order manager routine.")
+	for {
+		now := clock.Now()
+		exchanges := GetExchanges()
+		for x := range exchanges {
+			if exchanges[x] == nil || !exchanges[x].IsEnabled() ||
+				!exchanges[x].GetAuthenticatedAPISupport() {
+				continue
+			}
+			updateManagedOrders(exchanges[x], now)
+		}
+		clock.Sleep(bot.config.OrderManager.PollInterval)
+	}
+}
+
+// GetAllOpenOrders returns the open orders tracked by the order manager across
+// every exchange, oldest first
+func GetAllOpenOrders() []ManagedOrder {
+	return managedOrders.GetOpen("")
+}
+
+// GetManagedOrders returns every order tracked by the order manager, oldest
+// first
+func GetManagedOrders() []ManagedOrder {
+	return managedOrders.GetAll()
+}
+
+// GetOrderByInternalID returns an order tracked by the order manager by its
+// internal ID
+func GetOrderByInternalID(id string) (ManagedOrder, error) {
+	order, ok := managedOrders.Get(id)
+	if !ok {
+		return ManagedOrder{}, errManagedOrderNotFound
+	}
+	return order, nil
+}
+
+// CancelByInternalID cancels an open order tracked by the order manager by its
+// internal ID
+func CancelByInternalID(id string) error {
+	order, ok := managedOrders.Get(id)
+	if !ok {
+		return errManagedOrderNotFound
+	}
+	if !order.IsOpen() {
+		return fmt.Errorf("order %s is %s and can't be cancelled", id,
+			order.Status)
+	}
+
+	exch := GetExchangeByName(order.Exchange)
+	if exch == nil {
+		return errors.New(exchange.ErrExchangeNotFound)
+	}
+	return CancelExchangeOrder(exch, order.OrderID, order.Pair)
+}
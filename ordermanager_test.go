@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type orderManagerTestExchange struct {
+	exchange.IBotExchange
+	name      string
+	active    []exchange.OrderDetail
+	info      map[string]exchange.OrderDetail
+	cancelled []string
+}
+
+func (o *orderManagerTestExchange) GetName() string {
+	return o.name
+}
+
+func (o *orderManagerTestExchange) GetEnabledCurrencies() currency.Pairs {
+	return nil
+}
+
+func (o *orderManagerTestExchange) GetActiveOrders(req *exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	return append([]exchange.OrderDetail(nil), o.active...), nil
+}
+
+func (o *orderManagerTestExchange) GetOrderInfo(orderID string) (exchange.OrderDetail, error) {
+	detail, ok := o.info[orderID]
+	if !ok {
+		return exchange.OrderDetail{}, errors.New("order not found")
+	}
+	return detail, nil
+}
+
+func (o *orderManagerTestExchange) CancelOrder(order *exchange.OrderCancellation) error {
+	o.cancelled = append(o.cancelled, order.OrderID)
+	return nil
+}
+
+func TestUpdateManagedOrders(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+	setupAccountInfoTest(t, 0)
+	managedOrders = orderManager{}
+	exch := &orderManagerTestExchange{name: "OrderManagerTest"}
+	exchanges := bot.exchanges
+	bot.exchanges = []exchange.IBotExchange{exch}
+	defer func() {
+		bot.exchanges = exchanges
+		managedOrders = orderManager{}
+	}()
+
+	p := currency.NewPairFromStrings("BTC", "USD")
+	submitted := time.Now().Add(-time.Minute)
+	var ids []string
+	for _, orderID := range []string{"1", "2", "3", "4"} {
+		ids = append(ids, managedOrders.Add(ManagedOrder{
+			Exchange:  exch.GetName(),
+			OrderID:   orderID,
+			Pair:      p,
+			Side:      exchange.BuyOrderSide,
+			OrderType: exchange.LimitOrderType,
+			Amount:    2,
+			Price:     100,
+			Submitted: submitted,
+		}))
+	}
+	recent := managedOrders.Add(ManagedOrder{
+		Exchange:  exch.GetName(),
+		OrderID:   "5",
+		Pair:      p,
+		Amount:    1,
+		Submitted: time.Now(),
+	})
+
+	exch.active = []exchange.OrderDetail{
+		{ID: "1", CurrencyPair: p, Amount: 2, ExecutedAmount: 0.5},
+		{ID: "6", CurrencyPair: p, Amount: 3, OrderSide: exchange.SellOrderSide},
+	}
+	exch.info = map[string]exchange.OrderDetail{
+		"2": {ID: "2", Amount: 2, ExecutedAmount: 2},
+		"3": {ID: "3", Amount: 2, RemainingAmount: 2},
+	}
+	updateManagedOrders(exch, time.Now())
+
+	expected := map[string]exchange.OrderStatus{
+		ids[0]: exchange.PartiallyFilledOrderStatus,
+		ids[1]: exchange.FilledOrderStatus,
+		ids[2]: exchange.CancelledOrderStatus,
+		ids[3]: exchange.UnknownOrderStatus,
+		recent: exchange.NewOrderStatus,
+	}
+	for id, status := range expected {
+		order, err := GetOrderByInternalID(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.Status != status {
+			t.Errorf("Test failed. Expected order %s to be %s, got %s",
+				order.OrderID, status, order.Status)
+		}
+	}
+
+	open := GetAllOpenOrders()
+	if len(open) != 3 || open[2].OrderID != "6" || open[2].Side != exchange.SellOrderSide {
+		t.Errorf("Test failed. Expected untracked open orders to be tracked, got %+v", open)
+	}
+
+	err := CancelByInternalID(ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, _ := GetOrderByInternalID(ids[0])
+	if len(exch.cancelled) != 1 || exch.cancelled[0] != "1" ||
+		order.Status != exchange.CancelledOrderStatus {
+		t.Errorf("Test failed. Expected order to be cancelled, got %+v", order)
+	}
+	if err = CancelByInternalID(ids[1]); err == nil {
+		t.Error("Test failed. Expected cancelling a filled order to error")
+	}
+	if _, err = GetOrderByInternalID("missing"); err != errManagedOrderNotFound {
+		t.Errorf("Test failed. Expected a not found error, got %v", err)
+	}
+}
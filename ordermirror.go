@@ -0,0 +1,207 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// Order tags set on mirrored orders. Every order of a mirrored set shares the
+// mirror group tag, orders submitted onto a mirror exchange are also tagged
+// with the primary exchange
+const (
+	OrderTagMirrorGroup   = "mirrorGroup"
+	OrderTagMirrorPrimary = "mirrorPrimary"
+)
+
+// errOrderMirrorGroupNotFound is returned for a mirror group with no orders
+var errOrderMirrorGroupNotFound = errors.New("order mirror group not found")
+
+// OrderMirrorGroup holds the orders of a mirrored set, the primary order
+// followed by the mirrored orders
+type OrderMirrorGroup struct {
+	ID      string        `json:"id"`
+	Primary string        `json:"primary"`
+	Orders  []TaggedOrder `json:"orders"`
+}
+
+// OrderMirrorCancellation holds the result of cancelling a mirror group, the
+// error of each order which failed to cancel is keyed by its exchange
+type OrderMirrorCancellation struct {
+	ID        string            `json:"id"`
+	Cancelled int               `json:"cancelled"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+// orderMirrorManager holds the exchanges orders are mirrored onto keyed by
+// their lower case primary exchange
+type orderMirrorManager struct {
+	mirrors map[string][]config.OrderMirrorTargetConfig
+	m       sync.Mutex
+}
+
+var (
+	orderMirrors        orderMirrorManager
+	orderMirrorGroupSeq int64
+	// orderMirrorsPending tracks mirrored orders still being submitted
+	orderMirrorsPending sync.WaitGroup
+)
+
+// Load replaces the order mirrors with those configured
+func (o *orderMirrorManager) Load(cfgs []config.OrderMirrorConfig) {
+	o.m.Lock()
+	defer o.m.Unlock()
+
+	o.mirrors = make(map[string][]config.OrderMirrorTargetConfig)
+	for x := range cfgs {
+		o.mirrors[common.StringToLower(cfgs[x].Primary)] = cfgs[x].Mirrors
+	}
+}
+
+// Get returns the exchanges an order submitted on an exchange with tags is
+// mirrored onto. Orders which are already part of a mirror group and orders
+// submitted by the bot for a reason such as a dust sweep are not mirrored
+func (o *orderMirrorManager) Get(exchName string, tags OrderTags) []config.OrderMirrorTargetConfig {
+	if tags[OrderTagMirrorGroup] != "" || tags[OrderTagReason] != "" {
+		return nil
+	}
+
+	o.m.Lock()
+	defer o.m.Unlock()
+	return o.mirrors[common.StringToLower(exchName)]
+}
+
+// newOrderMirrorGroupID returns a unique mirror group ID
+func newOrderMirrorGroupID() string {
+	return fmt.Sprintf("%x-%d", clock.Now().UnixNano(),
+		atomic.AddInt64(&orderMirrorGroupSeq, 1))
+}
+
+// mirrorOrder submits an order placed on a primary exchange onto each of its
+// mirror exchanges in the background with the amount scaled, tagged with the
+// primary orders tags, so the primary order returns without waiting on them.
+// Mirror exchanges whose pair is suspended by the circuit breaker are skipped.
+// Mirrored orders which fail are logged and don't affect the primary order
+func mirrorOrder(primary string, mirrors []config.OrderMirrorTargetConfig, p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, flags exchange.OrderFlags, tags OrderTags) {
+	tags = tags.With(OrderTagMirrorPrimary, primary)
+	for x := range mirrors {
+		exch := GetExchangeByName(mirrors[x].Exchange)
+		if exch == nil || !exch.IsEnabled() {
+			log.Errorf("Order mirror of %s unable to submit onto %s: exchange not found or disabled",
+				primary, mirrors[x].Exchange)
+			continue
+		}
+
+		err := checkCircuitBreaker(exch.GetName(), p)
+		if err != nil {
+			log.Errorf("Order mirror of %s unable to submit onto %s: %s",
+				primary, exch.GetName(), err)
+			continue
+		}
+
+		orderMirrorsPending.Add(1)
+		go func(exch exchange.IBotExchange, scale float64) {
+			defer orderMirrorsPending.Done()
+			_, err := SubmitTaggedFlaggedOrder(exch, p, side, orderType,
+				amount*scale, price, "", flags, tags)
+			if err != nil {
+				log.Errorf("Order mirror of %s unable to submit %s %s order onto %s: %s",
+					primary, p, side, exch.GetName(), err)
+			}
+		}(exch, mirrors[x].Scale)
+	}
+}
+
+// GetOrderMirrorGroup returns the orders of a mirror group
+func GetOrderMirrorGroup(id string) (OrderMirrorGroup, error) {
+	orders := orderTags.Find("", OrderTags{OrderTagMirrorGroup: id})
+	if id == "" || len(orders) == 0 {
+		return OrderMirrorGroup{}, errOrderMirrorGroupNotFound
+	}
+
+	group := OrderMirrorGroup{ID: id}
+	for x := range orders {
+		if orders[x].Tags[OrderTagMirrorPrimary] == "" {
+			group.Primary = orders[x].Exchange
+			group.Orders = append([]TaggedOrder{orders[x]}, group.Orders...)
+			continue
+		}
+		group.Orders = append(group.Orders, orders[x])
+	}
+	return group, nil
+}
+
+// GetOrderMirrorGroups returns every mirror group with an order held by the
+// bot, oldest first
+func GetOrderMirrorGroups() []OrderMirrorGroup {
+	var groups []OrderMirrorGroup
+	seen := make(map[string]bool)
+	orders := orderTags.GetAll()
+	for x := range orders {
+		id := orders[x].Tags[OrderTagMirrorGroup]
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		group, err := GetOrderMirrorGroup(id)
+		if err == nil {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// CancelOrderMirrorGroup cancels every unfilled order of a mirror group.
+// Failing to cancel one order doesn't stop the others being cancelled
+func CancelOrderMirrorGroup(id string) (OrderMirrorCancellation, error) {
+	resp := OrderMirrorCancellation{ID: id}
+	group, err := GetOrderMirrorGroup(id)
+	if err != nil {
+		return resp, err
+	}
+
+	for x := range group.Orders {
+		order := group.Orders[x]
+		if order.OrderID == "" ||
+			(order.FilledAmount > 0 && order.RemainingAmount <= 0) {
+			continue
+		}
+
+		exch := GetExchangeByName(order.Exchange)
+		if exch == nil {
+			err = errors.New(exchange.ErrExchangeNotFound)
+		} else {
+			err = CancelExchangeOrder(exch, order.OrderID,
+				currency.NewPairFromString(order.Pair))
+		}
+		if err != nil {
+			if resp.Errors == nil {
+				resp.Errors = make(map[string]string)
+			}
+			resp.Errors[order.Exchange] = err.Error()
+			continue
+		}
+		resp.Cancelled++
+	}
+
+	if len(resp.Errors) > 0 {
+		failed := make([]string, 0, len(resp.Errors))
+		for exchName := range resp.Errors {
+			failed = append(failed, exchName)
+		}
+		sort.Strings(failed)
+		return resp, fmt.Errorf("order mirror group %s failed to cancel on %s",
+			id, strings.Join(failed, ", "))
+	}
+	return resp, nil
+}
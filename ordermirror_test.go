@@ -0,0 +1,175 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type orderMirrorTestExchange struct {
+	exchange.IBotExchange
+	name      string
+	submitErr error
+	cancelErr error
+	amounts   []float64
+	cancelled []string
+	m         sync.Mutex
+}
+
+func (o *orderMirrorTestExchange) GetName() string {
+	return o.name
+}
+
+func (o *orderMirrorTestExchange) IsEnabled() bool {
+	return true
+}
+
+func (o *orderMirrorTestExchange) IsSandbox() bool {
+	return false
+}
+
+func (o *orderMirrorTestExchange) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	if o.submitErr != nil {
+		return exchange.SubmitOrderResponse{}, o.submitErr
+	}
+	o.amounts = append(o.amounts, amount)
+	return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: o.name + "-1"}, nil
+}
+
+func (o *orderMirrorTestExchange) CancelOrder(order *exchange.OrderCancellation) error {
+	o.m.Lock()
+	defer o.m.Unlock()
+	if o.cancelErr != nil {
+		return o.cancelErr
+	}
+	o.cancelled = append(o.cancelled, order.OrderID)
+	return nil
+}
+
+func TestOrderMirrorGet(t *testing.T) {
+	var mirrors orderMirrorManager
+	mirrors.Load([]config.OrderMirrorConfig{
+		{Primary: "Primary", Mirrors: []config.OrderMirrorTargetConfig{{Exchange: "Mirror", Scale: 1}}},
+	})
+
+	if len(mirrors.Get("primary", nil)) != 1 {
+		t.Error("Test failed. Expected orders on the primary exchange to be mirrored")
+	}
+	if len(mirrors.Get("Mirror", nil)) != 0 {
+		t.Error("Test failed. Expected orders on other exchanges not to be mirrored")
+	}
+	if len(mirrors.Get("Primary", OrderTags{OrderTagReason: "dust sweep"})) != 0 ||
+		len(mirrors.Get("Primary", OrderTags{OrderTagMirrorGroup: "1"})) != 0 {
+		t.Error("Test failed. Expected bot and mirrored orders not to be mirrored")
+	}
+}
+
+func TestSubmitMirroredOrder(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+
+	primary := &orderMirrorTestExchange{name: "MirrorPrimary"}
+	scaled := &orderMirrorTestExchange{name: "MirrorScaled", cancelErr: errors.New("order not found")}
+	failing := &orderMirrorTestExchange{name: "MirrorFailing", submitErr: errors.New("insufficient funds")}
+	exchanges := bot.exchanges
+	bot.exchanges = []exchange.IBotExchange{primary, scaled, failing}
+	defer func() { bot.exchanges = exchanges }()
+	orderTags.Restore(nil)
+	defer orderTags.Restore(nil)
+	orderMirrors.Load([]config.OrderMirrorConfig{{
+		Primary: primary.GetName(),
+		Mirrors: []config.OrderMirrorTargetConfig{
+			{Exchange: scaled.GetName(), Scale: 0.5},
+			{Exchange: failing.GetName(), Scale: 1},
+			{Exchange: "MirrorMissing", Scale: 1},
+		},
+	}})
+	defer orderMirrors.Load(nil)
+
+	_, err := SubmitTaggedOrder(primary, currency.NewPairFromStrings("BTC", "USD"),
+		exchange.BuyOrderSide, exchange.LimitOrderType, 2, 5000, "",
+		OrderTags{OrderTagStrategy: "grid"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderMirrorsPending.Wait()
+	if len(scaled.amounts) != 1 || scaled.amounts[0] != 1 {
+		t.Errorf("Test failed. Expected a scaled mirrored order, got %v", scaled.amounts)
+	}
+
+	groups := GetOrderMirrorGroups()
+	if len(groups) != 1 {
+		t.Fatalf("Test failed. Expected 1 mirror group, got %d", len(groups))
+	}
+	group := groups[0]
+	if group.Primary != primary.GetName() || len(group.Orders) != 2 ||
+		group.Orders[0].Exchange != primary.GetName() ||
+		group.Orders[1].Tags[OrderTagMirrorPrimary] != primary.GetName() ||
+		group.Orders[1].Tags[OrderTagStrategy] != "grid" {
+		t.Errorf("Test failed. Unexpected mirror group %+v", group)
+	}
+
+	resp, err := CancelOrderMirrorGroup(group.ID)
+	if err == nil {
+		t.Error("Test failed. Expected error for the order which failed to cancel")
+	}
+	if resp.Cancelled != 1 || len(primary.cancelled) != 1 ||
+		resp.Errors[scaled.GetName()] == "" {
+		t.Errorf("Test failed. Unexpected cancellation %+v", resp)
+	}
+
+	_, err = CancelOrderMirrorGroup("unknown")
+	if err != errOrderMirrorGroupNotFound {
+		t.Errorf("Test failed. Expected %v, got %v", errOrderMirrorGroupNotFound, err)
+	}
+}
+
+func TestMirrorOrderCircuitBreaker(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+
+	primary := &orderMirrorTestExchange{name: "BreakerPrimary"}
+	tripped := &orderMirrorTestExchange{name: "BreakerTripped"}
+	exchanges := bot.exchanges
+	bot.exchanges = []exchange.IBotExchange{primary, tripped}
+	defer func() { bot.exchanges = exchanges }()
+	cfg := bot.config
+	bot.config = &config.Config{CircuitBreaker: config.CircuitBreakerConfig{
+		Enabled:      true,
+		Window:       time.Minute,
+		MaxPriceMove: 5,
+		Cooldown:     time.Hour,
+	}}
+	defer func() { bot.config = cfg }()
+	orderTags.Restore(nil)
+	defer orderTags.Restore(nil)
+
+	p := currency.NewPairFromStrings("BTC", "USD")
+	now := clock.Now()
+	circuitBreaker.Observe(&bot.config.CircuitBreaker, tripped.GetName(), p, 100, 0, 0, now)
+	if _, ok := circuitBreaker.Observe(&bot.config.CircuitBreaker, tripped.GetName(), p,
+		110, 0, 0, now); !ok {
+		t.Fatal("Test failed. Expected the mirror exchange pair to trip")
+	}
+	defer circuitBreaker.Reset(now.Add(time.Hour))
+
+	mirrorOrder(primary.GetName(), []config.OrderMirrorTargetConfig{
+		{Exchange: tripped.GetName(), Scale: 1},
+	}, p, exchange.BuyOrderSide, exchange.LimitOrderType, 1, 5000,
+		exchange.OrderFlags{}, OrderTags{OrderTagMirrorGroup: "1"})
+	orderMirrorsPending.Wait()
+	if len(tripped.amounts) != 0 {
+		t.Errorf("Test failed. Expected no order on the suspended mirror exchange, got %v",
+			tripped.amounts)
+	}
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// Queued order statuses
+const (
+	QueuedOrderPending    = "pending"
+	QueuedOrderSubmitting = "submitting"
+	QueuedOrderSubmitted  = "submitted"
+	QueuedOrderExpired    = "expired"
+	QueuedOrderFailed     = "failed"
+	QueuedOrderCancelled  = "cancelled"
+
+	// maxFinishedQueuedOrders is the number of orders which left the queue
+	// kept so their outcome can be looked up
+	maxFinishedQueuedOrders = 100
+
+	// platformStatusTTL is how long an exchanges platform status is reused
+	// before it is requested again
+	platformStatusTTL = time.Second * 30
+
+	orderQueueEventType = "ORDER_QUEUE"
+)
+
+var (
+	errExchangeUnderMaintenance = errors.New("exchange platform is under maintenance")
+	errExchangeRateLimited      = errors.New("exchange is rate limiting requests")
+	errQueuedOrderNotFound      = errors.New("queued order not found")
+	errQueuedOrderNotPending    = errors.New("queued order is no longer pending")
+)
+
+// QueuedOrder is an order held while its exchange is under maintenance or
+// rate limiting requests. Reason holds why the exchange was last unavailable
+type QueuedOrder struct {
+	ID        int64        `json:"id"`
+	Exchange  string       `json:"exchange"`
+	Pair      string       `json:"pair"`
+	Side      string       `json:"side"`
+	OrderType string       `json:"orderType"`
+	Amount    float64      `json:"amount"`
+	Price     float64      `json:"price"`
+	Funding   string       `json:"funding"`
+	Tags      OrderTags    `json:"tags,omitempty"`
+	Queued    time.Time    `json:"queued"`
+	Expires   time.Time    `json:"expires"`
+	Reason    string       `json:"reason"`
+	Status    string       `json:"status"`
+	Order     *FundedOrder `json:"order,omitempty"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// OrderSubmission is a submitted order, or the queued order when its exchange
+// was unavailable
+type OrderSubmission struct {
+	FundedOrder
+	Queued *QueuedOrder `json:"queued,omitempty"`
+}
+
+// orderQueueManager holds the queued orders in the order they were submitted
+type orderQueueManager struct {
+	orders []*QueuedOrder
+	nextID int64
+	m      sync.Mutex
+}
+
+var orderQueue orderQueueManager
+
+// Add queues an order, returning it with its ID and pending status set
+func (q *orderQueueManager) Add(order QueuedOrder) QueuedOrder {
+	q.m.Lock()
+	defer q.m.Unlock()
+	q.nextID++
+	order.ID = q.nextID
+	order.Status = QueuedOrderPending
+	q.orders = append(q.orders, &order)
+	return order
+}
+
+// Cancel removes a pending order from the queue
+func (q *orderQueueManager) Cancel(id int64) (QueuedOrder, error) {
+	q.m.Lock()
+	defer q.m.Unlock()
+	for _, order := range q.orders {
+		if order.ID != id {
+			continue
+		}
+		if order.Status != QueuedOrderPending {
+			return *order, errQueuedOrderNotPending
+		}
+		order.Status = QueuedOrderCancelled
+		q.prune()
+		return *order, nil
+	}
+	return QueuedOrder{}, errQueuedOrderNotFound
+}
+
+// GetAll returns the pending and recently finished orders
+func (q *orderQueueManager) GetAll() []QueuedOrder {
+	q.m.Lock()
+	defer q.m.Unlock()
+	resp := make([]QueuedOrder, len(q.orders))
+	for x := range q.orders {
+		resp[x] = *q.orders[x]
+	}
+	return resp
+}
+
+// prune drops the oldest finished orders once more than
+// maxFinishedQueuedOrders are held, it must be called with the lock held
+func (q *orderQueueManager) prune() {
+	var finished int
+	for x := range q.orders {
+		if q.orders[x].Status != QueuedOrderPending &&
+			q.orders[x].Status != QueuedOrderSubmitting {
+			finished++
+		}
+	}
+
+	var orders []*QueuedOrder
+	for x := range q.orders {
+		if finished > maxFinishedQueuedOrders &&
+			q.orders[x].Status != QueuedOrderPending &&
+			q.orders[x].Status != QueuedOrderSubmitting {
+			finished--
+			continue
+		}
+		orders = append(orders, q.orders[x])
+	}
+	q.orders = orders
+}
+
+// Process expires the pending orders past their expiry and submits the others
+// in the order they were queued once their exchange is available. Orders of an
+// exchange which is still or again unavailable stay queued
+func (q *orderQueueManager) Process(now time.Time, available func(exchName string) error, submit func(order *QueuedOrder) (FundedOrder, error)) {
+	q.m.Lock()
+	var pending []*QueuedOrder
+	for _, order := range q.orders {
+		if order.Status == QueuedOrderPending {
+			pending = append(pending, order)
+		}
+	}
+	q.m.Unlock()
+
+	unavailable := make(map[string]bool)
+	for _, order := range pending {
+		q.m.Lock()
+		if order.Status != QueuedOrderPending {
+			q.m.Unlock()
+			continue
+		}
+		if !now.Before(order.Expires) {
+			order.Status = QueuedOrderExpired
+			q.m.Unlock()
+			pushEvent(orderQueueEventType, i18n.T(i18n.MessageQueuedOrderExpired,
+				order.Exchange, order.Pair, order.Side, order.Amount))
+			continue
+		}
+		if unavailable[order.Exchange] {
+			q.m.Unlock()
+			continue
+		}
+		order.Status = QueuedOrderSubmitting
+		q.m.Unlock()
+
+		err := available(order.Exchange)
+		var resp FundedOrder
+		if err == nil {
+			resp, err = submit(order)
+			if isExchangeRateLimitedError(err) {
+				err = errExchangeRateLimited
+			}
+		}
+
+		q.m.Lock()
+		switch {
+		case err == errExchangeUnderMaintenance || err == errExchangeRateLimited:
+			unavailable[order.Exchange] = true
+			order.Status = QueuedOrderPending
+			order.Reason = err.Error()
+			q.m.Unlock()
+			log.Debugf("%s unavailable, keeping queued order %d. Reason: %s",
+				order.Exchange, order.ID, err)
+			continue
+		case err != nil:
+			order.Status = QueuedOrderFailed
+			order.Error = err.Error()
+		default:
+			order.Status = QueuedOrderSubmitted
+			order.Order = &resp
+		}
+		q.m.Unlock()
+
+		if err != nil {
+			pushEvent(orderQueueEventType, i18n.T(i18n.MessageQueuedOrderFailed,
+				order.Exchange, order.Pair, order.Side, order.Amount, err))
+			continue
+		}
+		pushEvent(orderQueueEventType, i18n.T(i18n.MessageQueuedOrderSubmitted,
+			order.Exchange, order.Pair, order.Side, order.Amount,
+			resp.Order.OrderID))
+	}
+
+	q.m.Lock()
+	q.prune()
+	q.m.Unlock()
+}
+
+// platformStatus is the last platform status reported by an exchange
+type platformStatus struct {
+	maintenance bool
+	checked     time.Time
+}
+
+// platformStatusCache caches exchange platform statuses so order submissions
+// don't each request them
+type platformStatusCache struct {
+	statuses map[string]platformStatus
+	m        sync.Mutex
+}
+
+var platformStatuses = platformStatusCache{
+	statuses: make(map[string]platformStatus),
+}
+
+// checkExchangeAvailable returns errExchangeUnderMaintenance when an exchange
+// reports its platform is under maintenance. Exchanges which cannot report
+// their platform status, or fail to, are assumed to be available
+func checkExchangeAvailable(exch exchange.IBotExchange, now time.Time) error {
+	reporter, ok := exch.(exchange.IPlatformStatusExchange)
+	if !ok {
+		return nil
+	}
+
+	platformStatuses.m.Lock()
+	defer platformStatuses.m.Unlock()
+	status, ok := platformStatuses.statuses[exch.GetName()]
+	if !ok || now.Sub(status.checked) >= platformStatusTTL {
+		maintenance, err := reporter.IsUnderMaintenance()
+		if err != nil {
+			log.Debugf("%s failed to get platform status. Error: %s",
+				exch.GetName(), err)
+			return nil
+		}
+		status = platformStatus{maintenance: maintenance, checked: now}
+		platformStatuses.statuses[exch.GetName()] = status
+	}
+
+	if status.maintenance {
+		return errExchangeUnderMaintenance
+	}
+	return nil
+}
+
+// isExchangeRateLimitedError returns whether an order was rejected as the
+// exchange is rate limiting or has banned the IP address. Service unavailable
+// responses are not included as the order may still have been placed
+func isExchangeRateLimitedError(err error) bool {
+	return request.IsRateLimited(err)
+}
+
+// SubmitOrQueueOrder submits an order to an exchange. When the order queue is
+// enabled and the exchange is under maintenance or rate limiting requests the
+// order is queued instead, to be submitted once the exchange recovers. Queued
+// orders keep their tags until submitted
+func SubmitOrQueueOrder(exchName, currencyPair, side, orderType string, amount, price float64, funding string, tags OrderTags) (OrderSubmission, error) {
+	if !bot.config.OrderQueue.Enabled {
+		order, err := SubmitFundedOrder(exchName, currencyPair, side, orderType,
+			amount, price, funding, tags)
+		return OrderSubmission{FundedOrder: order}, err
+	}
+
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return OrderSubmission{}, errors.New(exchange.ErrExchangeNotFound)
+	}
+
+	_, _, err := parseOrderRequest(side, orderType, amount)
+	if err != nil {
+		return OrderSubmission{}, err
+	}
+
+	err = checkExchangeAvailable(exch, clock.Now())
+	if err == nil {
+		var order FundedOrder
+		order, err = SubmitFundedOrder(exchName, currencyPair, side, orderType,
+			amount, price, funding, tags)
+		if !isExchangeRateLimitedError(err) {
+			return OrderSubmission{FundedOrder: order}, err
+		}
+		err = errExchangeRateLimited
+	}
+
+	now := clock.Now()
+	queued := orderQueue.Add(QueuedOrder{
+		Exchange:  exch.GetName(),
+		Pair:      currencyPair,
+		Side:      common.StringToUpper(side),
+		OrderType: common.StringToUpper(orderType),
+		Amount:    amount,
+		Price:     price,
+		Funding:   funding,
+		Tags:      tags,
+		Queued:    now,
+		Expires:   now.Add(bot.config.OrderQueue.Expiry),
+		Reason:    err.Error(),
+	})
+	pushEvent(orderQueueEventType, i18n.T(i18n.MessageOrderQueued,
+		queued.Exchange, queued.Pair, queued.Side, queued.Amount,
+		queued.Expires.UTC().Format(time.RFC3339), queued.Reason))
+	return OrderSubmission{Queued: &queued}, nil
+}
+
+// GetQueuedOrders returns the pending and recently finished queued orders
+func GetQueuedOrders() []QueuedOrder {
+	return orderQueue.GetAll()
+}
+
+// CancelQueuedOrder removes a pending order from the queue
+func CancelQueuedOrder(id int64) (QueuedOrder, error) {
+	return orderQueue.Cancel(id)
+}
+
+// OrderQueueRoutine submits queued orders once their exchange recovers and
+// expires those which could not be submitted in time
+func OrderQueueRoutine() {
+	log.Debugln("Starting order queue routine.")
+	for {
+		orderQueue.Process(clock.Now(), func(exchName string) error {
+			exch := GetExchangeByName(exchName)
+			if exch == nil {
+				return nil
+			}
+			return checkExchangeAvailable(exch, clock.Now())
+		}, func(order *QueuedOrder) (FundedOrder, error) {
+			return SubmitFundedOrder(order.Exchange, order.Pair, order.Side,
+				order.OrderType, order.Amount, order.Price, order.Funding, order.Tags)
+		})
+		clock.Sleep(bot.config.OrderQueue.CheckInterval)
+	}
+}
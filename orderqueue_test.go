@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+)
+
+type platformStatusTestExchange struct {
+	accountInfoTestExchange
+	maintenance bool
+	statusCalls int
+}
+
+func (p *platformStatusTestExchange) IsUnderMaintenance() (bool, error) {
+	p.statusCalls++
+	return p.maintenance, nil
+}
+
+func TestCheckExchangeAvailable(t *testing.T) {
+	now := time.Unix(1000, 0)
+	exch := &platformStatusTestExchange{
+		accountInfoTestExchange: accountInfoTestExchange{name: "PlatformStatusTest"},
+		maintenance:             true,
+	}
+
+	if err := checkExchangeAvailable(exch, now); err != errExchangeUnderMaintenance {
+		t.Errorf("Test failed. Expected maintenance error, got %v", err)
+	}
+
+	// The cached status is used until it expires
+	exch.maintenance = false
+	if err := checkExchangeAvailable(exch, now.Add(time.Second)); err != errExchangeUnderMaintenance {
+		t.Errorf("Test failed. Expected cached maintenance error, got %v", err)
+	}
+	if err := checkExchangeAvailable(exch, now.Add(platformStatusTTL)); err != nil {
+		t.Errorf("Test failed. Expected exchange to be available, got %v", err)
+	}
+	if exch.statusCalls != 2 {
+		t.Errorf("Test failed. Expected 2 platform status requests, got %d",
+			exch.statusCalls)
+	}
+
+	if err := checkExchangeAvailable(&accountInfoTestExchange{name: "NoStatus"}, now); err != nil {
+		t.Errorf("Test failed. Expected exchange without status to be available, got %v", err)
+	}
+}
+
+func TestIsExchangeRateLimitedError(t *testing.T) {
+	if !isExchangeRateLimitedError(&request.Error{StatusCode: http.StatusTooManyRequests}) {
+		t.Error("Test failed. Expected HTTP 429 to be rate limited")
+	}
+	if isExchangeRateLimitedError(&request.Error{StatusCode: http.StatusServiceUnavailable}) {
+		t.Error("Test failed. Expected HTTP 503 not to be rate limited")
+	}
+	if isExchangeRateLimitedError(errors.New("rate limited")) {
+		t.Error("Test failed. Expected plain error not to be rate limited")
+	}
+}
+
+func TestOrderQueueProcess(t *testing.T) {
+	now := time.Unix(1000, 0)
+	var q orderQueueManager
+	expired := q.Add(QueuedOrder{Exchange: "A", Expires: now})
+	first := q.Add(QueuedOrder{Exchange: "A", Expires: now.Add(time.Hour)})
+	second := q.Add(QueuedOrder{Exchange: "A", Expires: now.Add(time.Hour)})
+	limited := q.Add(QueuedOrder{Exchange: "B", Expires: now.Add(time.Hour)})
+	cancelled := q.Add(QueuedOrder{Exchange: "A", Expires: now.Add(time.Hour)})
+	if _, err := q.Cancel(cancelled.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.Cancel(cancelled.ID); err != errQueuedOrderNotPending {
+		t.Errorf("Test failed. Expected not pending error, got %v", err)
+	}
+
+	maintenance := true
+	var submitted []int64
+	available := func(exchName string) error {
+		if exchName == "A" && maintenance {
+			return errExchangeUnderMaintenance
+		}
+		return nil
+	}
+	submit := func(order *QueuedOrder) (FundedOrder, error) {
+		if order.Exchange == "B" {
+			return FundedOrder{}, &request.Error{StatusCode: http.StatusTooManyRequests}
+		}
+		submitted = append(submitted, order.ID)
+		return FundedOrder{}, nil
+	}
+
+	q.Process(now, available, submit)
+	statuses := make(map[int64]QueuedOrder)
+	for _, order := range q.GetAll() {
+		statuses[order.ID] = order
+	}
+	if statuses[expired.ID].Status != QueuedOrderExpired {
+		t.Errorf("Test failed. Expected order to expire, got %s",
+			statuses[expired.ID].Status)
+	}
+	if statuses[first.ID].Status != QueuedOrderPending ||
+		statuses[first.ID].Reason != errExchangeUnderMaintenance.Error() {
+		t.Errorf("Test failed. Unexpected queued order %+v", statuses[first.ID])
+	}
+	if statuses[limited.ID].Status != QueuedOrderPending ||
+		statuses[limited.ID].Reason != errExchangeRateLimited.Error() {
+		t.Errorf("Test failed. Unexpected rate limited order %+v", statuses[limited.ID])
+	}
+	if len(submitted) != 0 {
+		t.Errorf("Test failed. Orders submitted while unavailable %v", submitted)
+	}
+
+	maintenance = false
+	q.Process(now.Add(time.Minute), available, submit)
+	if len(submitted) != 2 || submitted[0] != first.ID || submitted[1] != second.ID {
+		t.Errorf("Test failed. Expected orders submitted in queue order, got %v",
+			submitted)
+	}
+	for _, order := range q.GetAll() {
+		if order.ID == second.ID && order.Status != QueuedOrderSubmitted {
+			t.Errorf("Test failed. Expected order to be submitted, got %s",
+				order.Status)
+		}
+	}
+
+	if _, err := q.Cancel(100); err != errQueuedOrderNotFound {
+		t.Errorf("Test failed. Expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// feeTierCacheTTL is how long an accounts fee tier is cached, tiers are based
+// on 30 day volume so change slowly
+const feeTierCacheTTL = time.Hour
+
+// tradingStatusCacheTTL is how long the trading status of a pair is cached
+const tradingStatusCacheTTL = time.Minute
+
+// VenueQuote holds the estimated cost of filling a market order on an
+// exchange. FeeTier is set when the fee rate is the accounts volume based tier
+// rather than the published fee. EffectiveCost is the quote currency paid
+// including fees for buys and received after fees for sells. TradingStatus is
+// set when the exchange reports the trading status of the pair, venues with
+// halted books aren't routed to
+type VenueQuote struct {
+	Exchange         string  `json:"exchange"`
+	TradingStatus    string  `json:"tradingStatus,omitempty"`
+	Halted           bool    `json:"halted,omitempty"`
+	AverageFillPrice float64 `json:"averageFillPrice"`
+	Cost             float64 `json:"cost"`
+	FeeRate          float64 `json:"feeRate"`
+	Fee              float64 `json:"fee"`
+	FeeTier          bool    `json:"feeTier"`
+	Volume30D        float64 `json:"volume30d,omitempty"`
+	EffectiveCost    float64 `json:"effectiveCost"`
+	Error            string  `json:"error,omitempty"`
+}
+
+// OrderRoute holds the venues a market order can be filled on ordered from the
+// best to worst effective cost. Best is nil when no venue can fill the order
+type OrderRoute struct {
+	Pair   currency.Pair      `json:"pair"`
+	Side   exchange.OrderSide `json:"side"`
+	Amount float64            `json:"amount"`
+	Venues []VenueQuote       `json:"venues"`
+	Best   *VenueQuote        `json:"best"`
+}
+
+type cachedFeeTier struct {
+	tier    exchange.FeeTier
+	updated time.Time
+}
+
+// feeTierCache caches account fee tiers per exchange and currency pair
+type feeTierCache struct {
+	tiers map[string]cachedFeeTier
+	m     sync.Mutex
+}
+
+var feeTiers = feeTierCache{
+	tiers: make(map[string]cachedFeeTier),
+}
+
+type cachedTradingStatus struct {
+	status  exchange.TradingStatus
+	updated time.Time
+}
+
+// tradingStatusCache caches the trading status of currency pairs per exchange
+type tradingStatusCache struct {
+	statuses map[string]cachedTradingStatus
+	m        sync.Mutex
+}
+
+var tradingStatuses = tradingStatusCache{
+	statuses: make(map[string]cachedTradingStatus),
+}
+
+// RouteOrder compares the enabled exchanges trading a currency pair, returning
+// the venues ordered by the effective cost of a market order after fees. A
+// MarketHaltedError is returned when every venue has halted the pair
+func RouteOrder(currencyPair, side string, amount float64) (OrderRoute, error) {
+	return routeOrder(GetExchanges(), currency.NewPairFromString(currencyPair),
+		side, amount, clock.Now())
+}
+
+func routeOrder(exchanges []exchange.IBotExchange, p currency.Pair, side string, amount float64, now time.Time) (OrderRoute, error) {
+	route := OrderRoute{
+		Pair:   p,
+		Amount: amount,
+	}
+
+	switch exchange.OrderSide(common.StringToUpper(side)) {
+	case exchange.BuyOrderSide, exchange.BidOrderSide:
+		route.Side = exchange.BuyOrderSide
+	case exchange.SellOrderSide, exchange.AskOrderSide:
+		route.Side = exchange.SellOrderSide
+	default:
+		return route, i18n.Errorf(i18n.MessageInvalidOrderSide, side)
+	}
+
+	if amount <= 0 {
+		return route, i18n.Errorf(i18n.MessageAmountNotPositive)
+	}
+
+	for x := range exchanges {
+		if exchanges[x] == nil || !exchanges[x].IsEnabled() ||
+			!exchanges[x].GetEnabledCurrencies().Contains(p, true) {
+			continue
+		}
+		route.Venues = append(route.Venues,
+			quoteVenue(exchanges[x], p, route.Side, amount, now))
+	}
+
+	if len(route.Venues) == 0 {
+		return route, fmt.Errorf("no enabled exchange trades %s", p)
+	}
+
+	sort.SliceStable(route.Venues, func(i, j int) bool {
+		a, b := route.Venues[i], route.Venues[j]
+		if (a.Error == "") != (b.Error == "") {
+			return a.Error == ""
+		}
+		if route.Side == exchange.BuyOrderSide {
+			return a.EffectiveCost < b.EffectiveCost
+		}
+		return a.EffectiveCost > b.EffectiveCost
+	})
+
+	if route.Venues[0].Error == "" {
+		route.Best = &route.Venues[0]
+		return route, nil
+	}
+
+	for x := range route.Venues {
+		if !route.Venues[x].Halted {
+			return route, nil
+		}
+	}
+	return route, &exchange.MarketHaltedError{
+		Exchange: route.Venues[0].Exchange,
+		Pair:     p,
+		Status:   route.Venues[0].TradingStatus,
+	}
+}
+
+// quoteVenue estimates a market order on an exchange. The accounts fee tier is
+// used when the exchange reports it, otherwise the published fee
+func quoteVenue(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, amount float64, now time.Time) VenueQuote {
+	return quoteAssetVenue(exch, p, orderbook.Spot, side, amount, now)
+}
+
+// quoteAssetVenue estimates a market order on an exchanges orderbook of an
+// asset type as quoteVenue does
+func quoteAssetVenue(exch exchange.IBotExchange, p currency.Pair, assetType string, side exchange.OrderSide, amount float64, now time.Time) VenueQuote {
+	quote := VenueQuote{Exchange: exch.GetName()}
+
+	status, ok, err := getTradingStatus(exch, p, now)
+	if err != nil {
+		log.Warnf("Unable to get %s %s trading status: %s", exch.GetName(), p, err)
+	}
+	if ok {
+		quote.TradingStatus = status.Status
+		if status.IsHalted() {
+			quote.Halted = true
+			quote.Error = (&exchange.MarketHaltedError{
+				Exchange: exch.GetName(),
+				Pair:     p,
+				Status:   status.Status,
+			}).Error()
+			return quote
+		}
+	}
+
+	sim, err := simulateAssetOrderbookFill(exch, p, assetType, string(side), amount)
+	if err != nil {
+		quote.Error = err.Error()
+		return quote
+	}
+	quote.AverageFillPrice = sim.AverageFillPrice
+	quote.Cost = sim.Cost
+
+	tier, ok, err := getFeeTier(exch, p, now)
+	if err != nil {
+		log.Warnf("Unable to get %s fee tier, using published fees: %s",
+			exch.GetName(), err)
+	}
+
+	if ok {
+		quote.FeeTier = true
+		quote.Volume30D = tier.Volume30D
+		quote.FeeRate = tier.TakerFee
+		quote.Fee = quote.Cost * quote.FeeRate
+	} else {
+		quote.Fee, err = exch.GetFeeByType(&exchange.FeeBuilder{
+			FeeType:       exchange.CryptocurrencyTradeFee,
+			PurchasePrice: sim.AverageFillPrice,
+			Amount:        amount,
+			Pair:          p,
+		})
+		if err != nil {
+			quote.Error = err.Error()
+			return quote
+		}
+		quote.FeeRate = quote.Fee / quote.Cost
+	}
+
+	if side == exchange.BuyOrderSide {
+		quote.EffectiveCost = quote.Cost + quote.Fee
+	} else {
+		quote.EffectiveCost = quote.Cost - quote.Fee
+	}
+	return quote
+}
+
+// getFeeTier returns the cached fee tier of an account, returning false when
+// the exchange does not report fee tiers, has no credentials set or the
+// request fails
+func getFeeTier(exch exchange.IBotExchange, p currency.Pair, now time.Time) (exchange.FeeTier, bool, error) {
+	tiered, ok := exch.(exchange.IFeeTierExchange)
+	if !ok || !exch.GetAuthenticatedAPISupport() {
+		return exchange.FeeTier{}, false, nil
+	}
+
+	key := exch.GetName() + p.Base.Upper().String() + p.Quote.Upper().String()
+	feeTiers.m.Lock()
+	cached, ok := feeTiers.tiers[key]
+	feeTiers.m.Unlock()
+	if ok && now.Sub(cached.updated) < feeTierCacheTTL {
+		return cached.tier, true, nil
+	}
+
+	tier, err := tiered.GetFeeTier(p)
+	if err != nil {
+		return exchange.FeeTier{}, false, err
+	}
+
+	feeTiers.m.Lock()
+	feeTiers.tiers[key] = cachedFeeTier{tier: tier, updated: now}
+	feeTiers.m.Unlock()
+	return tier, true, nil
+}
+
+// getTradingStatus returns the cached trading status of a currency pair,
+// returning false when the exchange does not report trading statuses or the
+// request fails
+func getTradingStatus(exch exchange.IBotExchange, p currency.Pair, now time.Time) (exchange.TradingStatus, bool, error) {
+	reporter, ok := exch.(exchange.ITradingStatusExchange)
+	if !ok {
+		return exchange.TradingStatus{}, false, nil
+	}
+
+	key := exch.GetName() + p.Base.Upper().String() + p.Quote.Upper().String()
+	tradingStatuses.m.Lock()
+	cached, ok := tradingStatuses.statuses[key]
+	tradingStatuses.m.Unlock()
+	if ok && now.Sub(cached.updated) < tradingStatusCacheTTL {
+		return cached.status, true, nil
+	}
+
+	status, err := reporter.GetTradingStatus(p)
+	if err != nil {
+		return exchange.TradingStatus{}, false, err
+	}
+
+	tradingStatuses.m.Lock()
+	tradingStatuses.statuses[key] = cachedTradingStatus{status: status, updated: now}
+	tradingStatuses.m.Unlock()
+	return status, true, nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+type routeTestExchange struct {
+	exchange.IBotExchange
+	name string
+}
+
+func (r *routeTestExchange) GetName() string {
+	return r.name
+}
+
+func (r *routeTestExchange) IsEnabled() bool {
+	return true
+}
+
+func (r *routeTestExchange) GetAuthenticatedAPISupport() bool {
+	return true
+}
+
+func (r *routeTestExchange) GetEnabledCurrencies() currency.Pairs {
+	return currency.Pairs{currency.NewPairFromStrings("BTC", "USD")}
+}
+
+func (r *routeTestExchange) GetFeeByType(feeBuilder *exchange.FeeBuilder) (float64, error) {
+	return feeBuilder.PurchasePrice * feeBuilder.Amount * 0.002, nil
+}
+
+type routeTestTieredExchange struct {
+	routeTestExchange
+	calls int
+}
+
+func (r *routeTestTieredExchange) GetFeeTier(p currency.Pair) (exchange.FeeTier, error) {
+	r.calls++
+	return exchange.FeeTier{Volume30D: 1000000, TakerFee: 0.0005}, nil
+}
+
+func processRouteTestOrderbook(t *testing.T, exchName string, bid, ask float64) {
+	ob := orderbook.Base{
+		Pair:         currency.NewPairFromStrings("BTC", "USD"),
+		ExchangeName: exchName,
+		AssetType:    orderbook.Spot,
+		Bids:         []orderbook.Item{{Price: bid, Amount: 10}},
+		Asks:         []orderbook.Item{{Price: ask, Amount: 10}},
+	}
+	err := ob.Process()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRouteOrder(t *testing.T) {
+	processRouteTestOrderbook(t, "RouteTestPublished", 9999, 10000)
+	processRouteTestOrderbook(t, "RouteTestTiered", 9970, 10010)
+
+	published := &routeTestExchange{name: "RouteTestPublished"}
+	tiered := &routeTestTieredExchange{
+		routeTestExchange: routeTestExchange{name: "RouteTestTiered"},
+	}
+	empty := &routeTestExchange{name: "RouteTestEmpty"}
+	exchanges := []exchange.IBotExchange{empty, published, tiered}
+	p := currency.NewPairFromStrings("BTC", "USD")
+	now := time.Now()
+
+	// The tiered venue has a worse price but a lower fee for the account
+	route, err := routeOrder(exchanges, p, "buy", 1, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route.Best == nil || route.Best.Exchange != "RouteTestTiered" ||
+		!route.Best.FeeTier || route.Best.EffectiveCost != 10010*1.0005 {
+		t.Errorf("Test failed. Expected the fee tier venue to be best, got %+v",
+			route.Best)
+	}
+	if len(route.Venues) != 3 || route.Venues[2].Error == "" {
+		t.Errorf("Test failed. Expected the venue without an orderbook last, got %+v",
+			route.Venues)
+	}
+
+	route, err = routeOrder(exchanges, p, "sell", 1, now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route.Best == nil || route.Best.Exchange != "RouteTestPublished" ||
+		route.Best.EffectiveCost != 9999*0.998 {
+		t.Errorf("Test failed. Expected the published fee venue to be best, got %+v",
+			route.Best)
+	}
+	if tiered.calls != 1 {
+		t.Errorf("Test failed. Expected the fee tier to be cached, got %d requests",
+			tiered.calls)
+	}
+
+	_, err = routeOrder(exchanges, p, "hold", 1, now)
+	if err == nil {
+		t.Error("Test failed. Expected error for an invalid order side")
+	}
+
+	_, err = routeOrder(exchanges, currency.NewPairFromStrings("LTC", "USD"), "buy", 1, now)
+	if err == nil {
+		t.Error("Test failed. Expected error when no exchange trades the pair")
+	}
+}
+
+type routeTestStatusExchange struct {
+	routeTestExchange
+	status string
+}
+
+func (r *routeTestStatusExchange) GetTradingStatus(p currency.Pair) (exchange.TradingStatus, error) {
+	return exchange.TradingStatus{Exchange: r.name, Pair: p, Status: r.status}, nil
+}
+
+func TestRouteOrderTradingStatus(t *testing.T) {
+	processRouteTestOrderbook(t, "RouteTestHalted", 10010, 9000)
+	processRouteTestOrderbook(t, "RouteTestPostOnly", 9999, 10000)
+
+	halted := &routeTestStatusExchange{
+		routeTestExchange: routeTestExchange{name: "RouteTestHalted"},
+		status:            exchange.TradingStatusHalted,
+	}
+	postOnly := &routeTestStatusExchange{
+		routeTestExchange: routeTestExchange{name: "RouteTestPostOnly"},
+		status:            exchange.TradingStatusPostOnly,
+	}
+	p := currency.NewPairFromStrings("BTC", "USD")
+	now := time.Now()
+
+	// The halted venue has the best price but isn't routed to
+	route, err := routeOrder([]exchange.IBotExchange{halted, postOnly}, p, "buy", 1, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route.Best == nil || route.Best.Exchange != "RouteTestPostOnly" ||
+		route.Best.TradingStatus != exchange.TradingStatusPostOnly {
+		t.Errorf("Test failed. Expected the trading venue to be best, got %+v", route.Best)
+	}
+	if !route.Venues[1].Halted || route.Venues[1].Error == "" {
+		t.Errorf("Test failed. Expected the halted venue to be refused, got %+v",
+			route.Venues[1])
+	}
+
+	_, err = routeOrder([]exchange.IBotExchange{halted}, p, "buy", 1, now)
+	if !exchange.IsMarketHalted(err) {
+		t.Errorf("Test failed. Expected a market halted error, got %v", err)
+	}
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Order tag keys set by the bot
+const (
+	OrderTagStrategy = "strategy"
+	OrderTagReason   = "reason"
+
+	// maxTaggedOrders is the number of submitted orders kept so their fills can
+	// be attributed, older orders are dropped first
+	maxTaggedOrders = 1000
+)
+
+// errClientOrderIDNotFound is returned when cancelling by a client order ID on
+// an exchange without client ID cancellation which the bot has no record of
+var errClientOrderIDNotFound = errors.New("no order submitted with client order ID")
+
+// OrderTags are free-form labels attached to an order at submission, such as
+// the strategy which generated it and why
+type OrderTags map[string]string
+
+// With returns a copy of the tags with a tag set
+func (o OrderTags) With(key, value string) OrderTags {
+	tags := make(OrderTags, len(o)+1)
+	for k, v := range o {
+		tags[k] = v
+	}
+	tags[key] = value
+	return tags
+}
+
+// String returns the tags as sorted key=value pairs separated by semicolons
+func (o OrderTags) String() string {
+	tags := make([]string, 0, len(o))
+	for k, v := range o {
+		tags = append(tags, k+"="+v)
+	}
+	sort.Strings(tags)
+	return strings.Join(tags, ";")
+}
+
+// ParseOrderTags parses tags given as key:value strings
+func ParseOrderTags(values []string) (OrderTags, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	tags := make(OrderTags, len(values))
+	for x := range values {
+		kv := strings.SplitN(values[x], ":", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid order tag %q, expected key:value",
+				values[x])
+		}
+		tags[kv[0]] = kv[1]
+	}
+	return tags, nil
+}
+
+// TaggedOrder is an order submitted by the bot with the tags it was submitted
+// with, so fills can be attributed to the logic which generated them.
+// ExpectedPrice is the price the order was expected to fill at when submitted
+// and FillPrice the average price it filled at once known. RemainingAmount is
+// the amount left to fill of a partially filled order
+type TaggedOrder struct {
+	Exchange        string    `json:"exchange"`
+	OrderID         string    `json:"orderId"`
+	ClientID        string    `json:"clientId,omitempty"`
+	Pair            string    `json:"pair"`
+	Side            string    `json:"side"`
+	OrderType       string    `json:"orderType"`
+	Amount          float64   `json:"amount"`
+	Price           float64   `json:"price"`
+	ExpectedPrice   float64   `json:"expectedPrice"`
+	FillPrice       float64   `json:"fillPrice,omitempty"`
+	FilledAmount    float64   `json:"filledAmount,omitempty"`
+	RemainingAmount float64   `json:"remainingAmount,omitempty"`
+	Submitted       time.Time `json:"submitted"`
+	Tags            OrderTags `json:"tags,omitempty"`
+}
+
+// orderTagStore holds the submitted orders oldest first
+type orderTagStore struct {
+	orders []TaggedOrder
+	m      sync.Mutex
+}
+
+var orderTags orderTagStore
+
+// Add records a submitted order, dropping the oldest once more than
+// maxTaggedOrders are held
+func (o *orderTagStore) Add(order TaggedOrder) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	o.orders = append(o.orders, order)
+	if len(o.orders) > maxTaggedOrders {
+		o.orders = o.orders[len(o.orders)-maxTaggedOrders:]
+	}
+}
+
+// Get returns a submitted order by its exchange and order ID
+func (o *orderTagStore) Get(exchName, orderID string) (TaggedOrder, bool) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	for x := len(o.orders) - 1; x >= 0; x-- {
+		if strings.EqualFold(o.orders[x].Exchange, exchName) &&
+			o.orders[x].OrderID == orderID {
+			return o.orders[x], true
+		}
+	}
+	return TaggedOrder{}, false
+}
+
+// GetByClientID returns a submitted order by its exchange and the client
+// order ID it was submitted with
+func (o *orderTagStore) GetByClientID(exchName, clientID string) (TaggedOrder, bool) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	for x := len(o.orders) - 1; x >= 0; x-- {
+		if strings.EqualFold(o.orders[x].Exchange, exchName) &&
+			o.orders[x].ClientID == clientID {
+			return o.orders[x], true
+		}
+	}
+	return TaggedOrder{}, false
+}
+
+// Find returns the submitted orders on an exchange, or all exchanges when
+// exchName is empty, which have every given tag
+func (o *orderTagStore) Find(exchName string, tags OrderTags) []TaggedOrder {
+	o.m.Lock()
+	defer o.m.Unlock()
+	var resp []TaggedOrder
+	for x := range o.orders {
+		if exchName != "" && !strings.EqualFold(o.orders[x].Exchange, exchName) {
+			continue
+		}
+		matched := true
+		for k, v := range tags {
+			if o.orders[x].Tags[k] != v {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			resp = append(resp, o.orders[x])
+		}
+	}
+	return resp
+}
+
+// GetUnfilled returns the orders submitted on an exchange which are not known
+// to have filled or are only partially filled
+func (o *orderTagStore) GetUnfilled(exchName string) []TaggedOrder {
+	o.m.Lock()
+	defer o.m.Unlock()
+	var resp []TaggedOrder
+	for x := range o.orders {
+		if strings.EqualFold(o.orders[x].Exchange, exchName) &&
+			o.orders[x].OrderID != "" &&
+			(o.orders[x].FilledAmount == 0 || o.orders[x].RemainingAmount > 0) {
+			resp = append(resp, o.orders[x])
+		}
+	}
+	return resp
+}
+
+// SetFill records the average price and amount an order filled at and the
+// amount it has remaining to fill
+func (o *orderTagStore) SetFill(exchName, orderID string, price, amount, remaining float64) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	for x := range o.orders {
+		if strings.EqualFold(o.orders[x].Exchange, exchName) &&
+			o.orders[x].OrderID == orderID {
+			o.orders[x].FillPrice = price
+			o.orders[x].FilledAmount = amount
+			o.orders[x].RemainingAmount = remaining
+		}
+	}
+}
+
+// GetAll returns every submitted order oldest first
+func (o *orderTagStore) GetAll() []TaggedOrder {
+	return o.Find("", nil)
+}
+
+// Restore replaces the submitted orders with those saved in the engine state
+func (o *orderTagStore) Restore(orders []TaggedOrder) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	o.orders = append([]TaggedOrder(nil), orders...)
+	if len(o.orders) > maxTaggedOrders {
+		o.orders = o.orders[len(o.orders)-maxTaggedOrders:]
+	}
+}
+
+// GetTaggedOrders returns the orders submitted by the bot on an exchange, or
+// all exchanges when exchName is empty, which have every given tag
+func GetTaggedOrders(exchName string, tags OrderTags) []TaggedOrder {
+	return orderTags.Find(exchName, tags)
+}
+
+// GetOrderTags returns the tags an order was submitted with
+func GetOrderTags(exchName, orderID string) OrderTags {
+	order, _ := orderTags.Get(exchName, orderID)
+	return order.Tags
+}
+
+// CancelOrderByClientIDRequest cancels an order on an exchange by the client
+// order ID it was submitted with, the currency pair is optional for orders the
+// bot submitted
+func CancelOrderByClientIDRequest(exchName, currencyPair, clientID string) error {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return errors.New(exchange.ErrExchangeNotFound)
+	}
+
+	var p currency.Pair
+	if currencyPair != "" {
+		p = currency.NewPairFromString(currencyPair)
+	}
+	return CancelExchangeOrderByClientID(exch, clientID, p)
+}
+
+// SubmitTaggedOrder submits an order through SubmitExchangeOrder and records
+// it with its tags and the price it is expected to fill at
+func SubmitTaggedOrder(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string, tags OrderTags) (exchange.SubmitOrderResponse, error) {
+	return SubmitTaggedFlaggedOrder(exch, p, side, orderType, amount, price,
+		clientID, exchange.OrderFlags{}, tags)
+}
+
+// SubmitTaggedFlaggedOrder submits an order with post-only or reduce-only flags
+// through SubmitExchangeFlaggedOrder and records it as SubmitTaggedOrder does.
+// Orders on an exchange with configured order mirrors are mirrored once
+// submitted, the set being tagged with a shared mirror group
+func SubmitTaggedFlaggedOrder(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string, flags exchange.OrderFlags, tags OrderTags) (exchange.SubmitOrderResponse, error) {
+	mirrors := orderMirrors.Get(exch.GetName(), tags)
+	if len(mirrors) > 0 {
+		tags = tags.With(OrderTagMirrorGroup, newOrderMirrorGroupID())
+	}
+
+	expected := getExpectedFillPrice(exch, p, side, price)
+	resp, err := SubmitExchangeFlaggedOrder(exch, p, side, orderType, amount,
+		price, clientID, flags)
+	if err != nil {
+		return resp, err
+	}
+
+	orderTags.Add(TaggedOrder{
+		Exchange:      exch.GetName(),
+		OrderID:       resp.OrderID,
+		ClientID:      clientID,
+		Pair:          p.String(),
+		Side:          string(side),
+		OrderType:     string(orderType),
+		Amount:        amount,
+		Price:         price,
+		ExpectedPrice: expected,
+		Submitted:     clock.Now(),
+		Tags:          tags,
+	})
+
+	if len(mirrors) > 0 {
+		mirrorOrder(exch.GetName(), mirrors, p, side, orderType, amount, price,
+			flags, tags)
+	}
+	return resp, nil
+}
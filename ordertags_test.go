@@ -0,0 +1,166 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+func TestParseOrderTags(t *testing.T) {
+	tags, err := ParseOrderTags([]string{"strategy:grid", "reason:rebalance:weekly"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags.String() != "reason=rebalance:weekly;strategy=grid" {
+		t.Errorf("Test failed. Unexpected tags %s", tags)
+	}
+
+	tags, err = ParseOrderTags(nil)
+	if err != nil || tags != nil {
+		t.Error("Test failed. Expected no tags")
+	}
+
+	for _, value := range []string{"strategy", ":grid"} {
+		if _, err = ParseOrderTags([]string{value}); err == nil {
+			t.Errorf("Test failed. Expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestOrderTagsWith(t *testing.T) {
+	tags := OrderTags{OrderTagReason: "manual"}
+	with := tags.With(OrderTagStrategy, "grid")
+	if len(tags) != 1 || with[OrderTagStrategy] != "grid" || with[OrderTagReason] != "manual" {
+		t.Errorf("Test failed. Expected a copy with the tag set, got %v %v", tags, with)
+	}
+
+	var none OrderTags
+	if none.With(OrderTagReason, "manual")[OrderTagReason] != "manual" {
+		t.Error("Test failed. Expected tags to be set on nil tags")
+	}
+}
+
+func TestSubmitTaggedOrder(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+
+	orderTags.Restore(nil)
+	defer orderTags.Restore(nil)
+	exch := &fundingTestExchange{
+		accountInfoTestExchange: *setupAccountInfoTest(t, time.Minute),
+	}
+	p := currency.NewPairFromStrings("BTC", "USD")
+
+	_, err := SubmitTaggedOrder(exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 0.01, 5000, "", OrderTags{OrderTagReason: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	strategyAllocations.Load([]config.StrategyAllocationConfig{
+		{Strategy: "grid", Exchange: exch.GetName(), Balances: map[string]float64{"USD": 100}},
+	})
+	defer strategyAllocations.Load(nil)
+	_, err = SubmitStrategyOrder("grid", exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 0.01, 5000, "", OrderTags{OrderTagReason: "signal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tags := GetOrderTags(exch.GetName(), "1"); tags[OrderTagStrategy] != "grid" {
+		t.Errorf("Test failed. Expected the latest order to be tagged with its strategy, got %v",
+			tags)
+	}
+
+	orders := GetTaggedOrders("", OrderTags{OrderTagStrategy: "grid"})
+	if len(orders) != 1 || orders[0].Tags[OrderTagReason] != "signal" ||
+		orders[0].Pair != "BTCUSD" || orders[0].Side != string(exchange.BuyOrderSide) {
+		t.Errorf("Test failed. Unexpected strategy orders %+v", orders)
+	}
+
+	if len(GetTaggedOrders(exch.GetName(), nil)) != 2 {
+		t.Error("Test failed. Expected both orders to be recorded")
+	}
+
+	if len(GetTaggedOrders("Kraken", nil)) != 0 {
+		t.Error("Test failed. Expected no orders for another exchange")
+	}
+}
+
+func TestOrderTagStoreLimit(t *testing.T) {
+	var store orderTagStore
+	for i := 0; i < maxTaggedOrders+10; i++ {
+		store.Add(TaggedOrder{Exchange: "Bitstamp"})
+	}
+	if len(store.GetAll()) != maxTaggedOrders {
+		t.Errorf("Test failed. Expected %d orders to be kept, got %d",
+			maxTaggedOrders, len(store.GetAll()))
+	}
+}
+
+type clientIDTestExchange struct {
+	accountInfoTestExchange
+	cancelled []exchange.OrderCancellation
+}
+
+func (c *clientIDTestExchange) CancelOrder(order *exchange.OrderCancellation) error {
+	c.cancelled = append(c.cancelled, *order)
+	return nil
+}
+
+type clientIDCancelTestExchange struct {
+	clientIDTestExchange
+	clientIDs []string
+}
+
+func (c *clientIDCancelTestExchange) CancelOrderByClientID(clientID string, p currency.Pair) error {
+	c.clientIDs = append(c.clientIDs, clientID)
+	return nil
+}
+
+func TestCancelExchangeOrderByClientID(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+
+	orderTags.Restore([]TaggedOrder{
+		{Exchange: "ClientIDTest", OrderID: "1", ClientID: "a", Pair: "BTCUSD"},
+		{Exchange: "ClientIDTest", OrderID: "2", ClientID: "b", Pair: "ETHUSD"},
+	})
+	defer orderTags.Restore(nil)
+
+	exch := &clientIDTestExchange{
+		accountInfoTestExchange: accountInfoTestExchange{name: "ClientIDTest"},
+	}
+	err := CancelExchangeOrderByClientID(exch, "b", currency.Pair{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exch.cancelled) != 1 || exch.cancelled[0].OrderID != "2" ||
+		exch.cancelled[0].CurrencyPair.String() != "ETHUSD" {
+		t.Errorf("Test failed. Expected order 2 to be cancelled by its order ID, got %+v",
+			exch.cancelled)
+	}
+
+	err = CancelExchangeOrderByClientID(exch, "c", currency.Pair{})
+	if err != errClientOrderIDNotFound {
+		t.Errorf("Test failed. Expected %v, got %v", errClientOrderIDNotFound, err)
+	}
+
+	native := &clientIDCancelTestExchange{
+		clientIDTestExchange: clientIDTestExchange{
+			accountInfoTestExchange: accountInfoTestExchange{name: "ClientIDTest"},
+		},
+	}
+	err = CancelExchangeOrderByClientID(native, "c", currency.Pair{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(native.clientIDs) != 1 || len(native.cancelled) != 0 {
+		t.Error("Test failed. Expected the exchange to cancel by client ID itself")
+	}
+}
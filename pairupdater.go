@@ -0,0 +1,151 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const pairUpdateEventType = "PAIR_UPDATE"
+
+// PairUpdate holds the changes to the available pairs of an exchange found by
+// a scheduled pair update. Disabled holds the enabled pairs which were
+// delisted and removed from the enabled pairs
+type PairUpdate struct {
+	Exchange string         `json:"exchange"`
+	Time     time.Time      `json:"time"`
+	Listed   currency.Pairs `json:"listed"`
+	Delisted currency.Pairs `json:"delisted"`
+	Disabled currency.Pairs `json:"disabled"`
+}
+
+// pairUpdateSchedule holds when each exchange is next due a pair update
+type pairUpdateSchedule struct {
+	next map[string]time.Time
+	m    sync.Mutex
+}
+
+var pairUpdates = pairUpdateSchedule{next: make(map[string]time.Time)}
+
+// Due returns whether an exchange is due a pair update, scheduling its next
+// update an interval later. The first update of an exchange is an interval
+// after it is first checked as its pairs are updated when it is started
+func (s *pairUpdateSchedule) Due(exchName string, interval time.Duration, now time.Time) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	next, ok := s.next[exchName]
+	if ok && now.Before(next) {
+		return false
+	}
+	s.next[exchName] = now.Add(interval)
+	return ok
+}
+
+// updateTradablePairs updates the available pairs of an exchange and disables
+// its enabled pairs which were delisted, unless that would leave it without
+// any enabled pairs. Listings are kept to the exchanges pair whitelist and
+// blacklist by the exchange when it updates its pairs
+func updateTradablePairs(exch exchange.IBotExchange, now time.Time) (PairUpdate, error) {
+	update := PairUpdate{Exchange: exch.GetName(), Time: now}
+	available := exch.GetAvailableCurrencies()
+	err := exch.UpdateTradablePairs(false)
+	if err != nil {
+		return update, err
+	}
+	update.Listed, update.Delisted = available.FindDifferences(exch.GetAvailableCurrencies())
+	if len(update.Delisted) == 0 {
+		return update, nil
+	}
+
+	var enabled currency.Pairs
+	enabledPairs := exch.GetEnabledCurrencies()
+	for x := range enabledPairs {
+		if update.Delisted.Contains(enabledPairs[x], true) {
+			update.Disabled = append(update.Disabled, enabledPairs[x])
+			continue
+		}
+		enabled = append(enabled, enabledPairs[x])
+	}
+	if len(update.Disabled) == 0 {
+		return update, nil
+	}
+	if len(enabled) == 0 {
+		log.Warnf("%s all enabled pairs were delisted, keeping them enabled: %s",
+			update.Exchange, update.Disabled)
+		update.Disabled = nil
+		return update, nil
+	}
+	return update, exch.SetCurrencies(enabled, true)
+}
+
+// notifyPairUpdate logs and pushes the listings and delistings found by a
+// pair update through the communications package
+func notifyPairUpdate(update *PairUpdate) {
+	if len(update.Listed) > 0 || len(update.Delisted) > 0 {
+		message := i18n.T(i18n.MessagePairsUpdated, update.Exchange,
+			update.Listed.Join(), update.Delisted.Join())
+		log.Info(message)
+		pushEvent(pairUpdateEventType, message)
+	}
+	if len(update.Disabled) > 0 {
+		message := i18n.T(i18n.MessagePairsDisabled, update.Exchange,
+			update.Disabled.Join())
+		log.Warn(message)
+		pushEvent(pairUpdateEventType, message)
+	}
+}
+
+// runPairUpdates updates the tradable pairs of each exchange due a scheduled
+// pair update
+func runPairUpdates(exchanges []exchange.IBotExchange, now time.Time) {
+	for x := range exchanges {
+		if exchanges[x] == nil || !exchanges[x].IsEnabled() ||
+			!exchanges[x].SupportsAutoPairUpdates() {
+			continue
+		}
+		exchCfg, err := bot.config.GetExchangeConfig(exchanges[x].GetName())
+		if err != nil || exchCfg.PairUpdateInterval <= 0 {
+			continue
+		}
+		if !pairUpdates.Due(exchCfg.Name, exchCfg.PairUpdateInterval, now) {
+			continue
+		}
+
+		update, err := updateTradablePairs(exchanges[x], now)
+		if err != nil {
+			log.Errorf("%s failed to update tradable pairs. Err: %s",
+				exchCfg.Name, err)
+		}
+		if len(update.Disabled) > 0 {
+			// Stop polling the delisted pairs
+			pollJobs.addExchange(exchanges[x], now)
+		}
+		notifyPairUpdate(&update)
+	}
+}
+
+// PairUpdaterRoutine keeps the tradable pairs of long running exchanges
+// current, updating them at each exchanges pair update interval
+func PairUpdaterRoutine() {
+	log.Debugln("Starting tradable pair updater routine.")
+	for {
+		runPairUpdates(GetExchanges(), clock.Now())
+		clock.Sleep(time.Minute)
+	}
+}
+
+// pairUpdatesEnabled returns whether any exchange has scheduled pair updates
+func pairUpdatesEnabled(exchanges []config.ExchangeConfig) bool {
+	for x := range exchanges {
+		if exchanges[x].Enabled && exchanges[x].PairUpdateInterval > 0 {
+			return true
+		}
+	}
+	return false
+}
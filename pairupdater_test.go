@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/mock"
+)
+
+func newPairUpdateTestExchange(name string) *mock.Exchange {
+	exch := mock.New(name)
+	exch.Setup(&config.ExchangeConfig{
+		Name:    name,
+		Enabled: true,
+		AvailablePairs: currency.NewPairsFromStrings([]string{"BTC-USD",
+			"LTC-USD", "ETH-USD"}),
+		EnabledPairs: currency.NewPairsFromStrings([]string{"BTC-USD", "LTC-USD"}),
+	})
+	exch.SupportsAutoPairUpdating = true
+	return exch
+}
+
+func TestUpdateTradablePairs(t *testing.T) {
+	exch := newPairUpdateTestExchange("PairUpdateTest")
+	exch.SetTradablePairs(currency.NewPairsFromStrings([]string{"BTC-USD",
+		"ETH-USD", "XRP-USD"}))
+
+	now := time.Unix(1560000000, 0)
+	update, err := updateTradablePairs(exch, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(update.Listed) != 1 || update.Listed[0].String() != "XRP-USD" {
+		t.Errorf("Test failed. Expected XRP-USD to be listed, got %s", update.Listed)
+	}
+	if len(update.Delisted) != 1 || update.Delisted[0].String() != "LTC-USD" {
+		t.Errorf("Test failed. Expected LTC-USD to be delisted, got %s", update.Delisted)
+	}
+	if len(update.Disabled) != 1 || update.Disabled[0].String() != "LTC-USD" {
+		t.Errorf("Test failed. Expected LTC-USD to be disabled, got %s", update.Disabled)
+	}
+	if enabled := exch.GetEnabledCurrencies(); len(enabled) != 1 ||
+		enabled[0].String() != "BTC-USD" {
+		t.Errorf("Test failed. Expected only BTC-USD to remain enabled, got %s", enabled)
+	}
+
+	// Delisting every enabled pair keeps them enabled
+	exch.SetTradablePairs(currency.NewPairsFromStrings([]string{"ETH-USD"}))
+	update, err = updateTradablePairs(exch, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(update.Disabled) != 0 || len(exch.GetEnabledCurrencies()) != 1 {
+		t.Errorf("Test failed. Expected the last enabled pair to be kept, got %+v", update)
+	}
+
+	exch.SetError("UpdateTradablePairs", errors.New("exchange unavailable"))
+	if _, err = updateTradablePairs(exch, now); err == nil {
+		t.Error("Test failed. Expected the update error to be returned")
+	}
+}
+
+func TestPairUpdateScheduleDue(t *testing.T) {
+	s := pairUpdateSchedule{next: make(map[string]time.Time)}
+	now := time.Unix(1560000000, 0)
+	if s.Due("Test", time.Hour, now) {
+		t.Error("Test failed. Expected the first check not to be due")
+	}
+	if s.Due("Test", time.Hour, now.Add(time.Minute)) {
+		t.Error("Test failed. Expected an update not to be due before its interval")
+	}
+	if !s.Due("Test", time.Hour, now.Add(time.Hour)) {
+		t.Error("Test failed. Expected an update to be due after its interval")
+	}
+	if s.Due("Test", time.Hour, now.Add(time.Hour+time.Minute)) {
+		t.Error("Test failed. Expected the next update to be rescheduled")
+	}
+}
+
+func TestRunPairUpdates(t *testing.T) {
+	setupAccountInfoTest(t, time.Minute)
+	exchCfg, err := bot.config.GetExchangeConfig("Bitstamp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchCfg.PairUpdateInterval = time.Hour
+	err = bot.config.UpdateExchangeConfig(&exchCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		exchCfg.PairUpdateInterval = 0
+		bot.config.UpdateExchangeConfig(&exchCfg)
+	}()
+
+	delete(pairUpdates.next, "Bitstamp")
+	exch := newPairUpdateTestExchange("Bitstamp")
+	exch.SetTradablePairs(currency.NewPairsFromStrings([]string{"BTC-USD",
+		"LTC-USD", "ETH-USD", "XRP-USD"}))
+
+	now := time.Unix(1560000000, 0)
+	exchanges := []exchange.IBotExchange{exch}
+	runPairUpdates(exchanges, now)
+	if len(exch.GetAvailableCurrencies()) != 3 {
+		t.Error("Test failed. Expected pairs not to be updated before the first interval")
+	}
+	runPairUpdates(exchanges, now.Add(time.Hour))
+	if len(exch.GetAvailableCurrencies()) != 4 {
+		t.Errorf("Test failed. Expected the scheduled update to list XRP-USD, got %s",
+			exch.GetAvailableCurrencies())
+	}
+
+	pollJobs.addExchange(exch, now)
+	defer pollJobs.removeExchange("Bitstamp")
+	exch.SetTradablePairs(currency.NewPairsFromStrings([]string{"BTC-USD",
+		"ETH-USD", "XRP-USD"}))
+	runPairUpdates(exchanges, now.Add(time.Hour*2))
+	pollJobs.m.Lock()
+	defer pollJobs.m.Unlock()
+	for x := range pollJobs.jobs {
+		if pollJobs.jobs[x].exch == exch && pollJobs.jobs[x].pair.String() == "LTC-USD" {
+			t.Error("Test failed. Expected the delisted pair to no longer be polled")
+			break
+		}
+	}
+}
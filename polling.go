@@ -0,0 +1,230 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// Polling job types
+const (
+	pollTicker    = "ticker"
+	pollOrderbook = "orderbook"
+	pollAccount   = "account"
+)
+
+// pollJob defines a recurring REST request for an exchange
+type pollJob struct {
+	exch      exchange.IBotExchange
+	kind      string
+	pair      currency.Pair
+	assetType string
+	interval  time.Duration
+	next      time.Time
+}
+
+// pollScheduler dispatches due polling jobs, running the jobs of each
+// exchange sequentially so that a slow exchange does not delay others
+type pollScheduler struct {
+	jobs []*pollJob
+	busy map[string]bool
+	m    sync.Mutex
+}
+
+// pollJobs holds the polling jobs of the loaded exchanges
+var pollJobs = &pollScheduler{busy: make(map[string]bool)}
+
+// newPollJobs creates polling jobs for the enabled pairs and asset types of an
+// exchange using the exchanges polling config
+func newPollJobs(exch exchange.IBotExchange, now time.Time) ([]*pollJob, error) {
+	exchName := exch.GetName()
+	exchCfg, err := bot.config.GetExchangeConfig(exchName)
+	if err != nil {
+		return nil, err
+	}
+	assetTypes, err := exchange.GetExchangeAssetTypes(exchName)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*pollJob
+	polling := exchCfg.Polling
+	enabledCurrencies := exch.GetEnabledCurrencies()
+	for y := range assetTypes {
+		for z := range enabledCurrencies {
+			jobs = append(jobs, &pollJob{
+				exch:      exch,
+				kind:      pollTicker,
+				pair:      enabledCurrencies[z],
+				assetType: assetTypes[y],
+				interval:  polling.GetPairInterval(polling.TickerInterval, enabledCurrencies[z]),
+				next:      now,
+			}, &pollJob{
+				exch:      exch,
+				kind:      pollOrderbook,
+				pair:      enabledCurrencies[z],
+				assetType: assetTypes[y],
+				interval:  polling.GetPairInterval(polling.OrderbookInterval, enabledCurrencies[z]),
+				next:      now,
+			})
+		}
+	}
+
+	if exch.GetAuthenticatedAPISupport() {
+		jobs = append(jobs, &pollJob{
+			exch:     exch,
+			kind:     pollAccount,
+			interval: polling.AccountInterval,
+			next:     now.Add(polling.AccountInterval),
+		})
+	}
+	return jobs, nil
+}
+
+// addExchange adds the polling jobs of an exchange, replacing any it already
+// has so that changes to its enabled pairs or authenticated API support are
+// picked up
+func (s *pollScheduler) addExchange(exch exchange.IBotExchange, now time.Time) {
+	if exch == nil {
+		return
+	}
+	jobs, err := newPollJobs(exch, now)
+	if err != nil {
+		log.Errorf("Polling scheduler failed to create %s jobs. Error: %s",
+			exch.GetName(), err)
+		return
+	}
+	s.removeExchange(exch.GetName())
+	for x := range jobs {
+		s.add(jobs[x])
+	}
+}
+
+// removeExchange removes the polling jobs of an exchange. Jobs already
+// dispatched finish running but are not rescheduled
+func (s *pollScheduler) removeExchange(exchName string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	var jobs []*pollJob
+	for x := range s.jobs {
+		if s.jobs[x].exch.GetName() != exchName {
+			jobs = append(jobs, s.jobs[x])
+		}
+	}
+	s.jobs = jobs
+}
+
+// add adds a job to the scheduler, ignoring jobs without an interval
+func (s *pollScheduler) add(job *pollJob) {
+	if job.interval <= 0 {
+		return
+	}
+	s.m.Lock()
+	s.jobs = append(s.jobs, job)
+	s.m.Unlock()
+}
+
+// due returns the due jobs of each idle exchange, ordered by how long they
+// have been due, and marks those exchanges as busy
+func (s *pollScheduler) due(now time.Time) map[string][]*pollJob {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	resp := make(map[string][]*pollJob)
+	for x := range s.jobs {
+		exchName := s.jobs[x].exch.GetName()
+		if s.busy[exchName] || s.jobs[x].next.After(now) {
+			continue
+		}
+		resp[exchName] = append(resp[exchName], s.jobs[x])
+	}
+
+	for exchName := range resp {
+		s.busy[exchName] = true
+		sort.Slice(resp[exchName], func(i, j int) bool {
+			return resp[exchName][i].next.Before(resp[exchName][j].next)
+		})
+	}
+	return resp
+}
+
+// complete reschedules finished jobs and marks the exchange as idle
+func (s *pollScheduler) complete(exchName string, jobs []*pollJob, now time.Time) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	for x := range jobs {
+		jobs[x].next = now.Add(jobs[x].interval)
+	}
+	s.busy[exchName] = false
+}
+
+// run executes an exchanges due jobs. Exchanges supporting ticker batching
+// only request the first ticker, the rest are read from the updated store.
+// Jobs for degraded endpoints are skipped while backing off and their stored
+// data is marked as stale
+func (s *pollScheduler) run(exchName string, jobs []*pollJob) {
+	tickerUpdated := false
+	for x := range jobs {
+		job := jobs[x]
+		if endpointHealth.ShouldSkip(exchName, job.kind, clock.Now()) {
+			setStale(job)
+			continue
+		}
+
+		var err error
+		switch job.kind {
+		case pollTicker:
+			update := !job.exch.SupportsRESTTickerBatchUpdates() || !tickerUpdated
+			err = updateTicker(job.exch, update, job.pair, job.assetType)
+			if err == nil && update {
+				tickerUpdated = true
+			}
+		case pollOrderbook:
+			err = updateOrderbook(job.exch, job.pair, job.assetType)
+		case pollAccount:
+			_, err = GetExchangeAccountInfo(job.exch, true)
+			if err != nil {
+				log.Errorf("Failed to update %s account info. Error: %s",
+					exchName, err)
+			}
+		}
+
+		if endpointHealth.Record(exchName, job.kind, err, clock.Now()) {
+			notifyEndpointHealth(exchName, job.kind, err)
+		}
+		if err != nil {
+			setStale(job)
+		}
+	}
+	s.complete(exchName, jobs, clock.Now())
+}
+
+// setStale marks the stored data of a failed or skipped job as stale
+func setStale(job *pollJob) {
+	switch job.kind {
+	case pollTicker:
+		ticker.SetStale(job.exch.GetName(), job.pair, job.assetType)
+	case pollOrderbook:
+		orderbook.SetStale(job.exch.GetName(), job.pair, job.assetType)
+	}
+}
+
+// PollingSchedulerRoutine polls tickers, orderbooks and account info for all
+// enabled exchanges at the intervals and pair priorities set in each
+// exchanges polling config
+func PollingSchedulerRoutine() {
+	log.Debugln("Starting REST polling scheduler routine.")
+	for {
+		for exchName, jobs := range pollJobs.due(clock.Now()) {
+			go pollJobs.run(exchName, jobs)
+		}
+		clock.Sleep(time.Second)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+func TestPollSchedulerDue(t *testing.T) {
+	now := time.Now()
+	exch := &accountInfoTestExchange{name: "Bitstamp"}
+	s := &pollScheduler{busy: make(map[string]bool)}
+	s.add(&pollJob{exch: exch, kind: pollTicker, interval: time.Second, next: now.Add(-time.Second)})
+	s.add(&pollJob{exch: exch, kind: pollOrderbook, interval: time.Second * 5, next: now.Add(-time.Second * 2)})
+	s.add(&pollJob{exch: exch, kind: pollAccount, interval: time.Minute, next: now.Add(time.Minute)})
+	s.add(&pollJob{exch: exch, kind: pollAccount, interval: 0, next: now})
+
+	if len(s.jobs) != 3 {
+		t.Fatalf("Test failed. Expected jobs without an interval to be ignored, got %d jobs", len(s.jobs))
+	}
+
+	due := s.due(now)
+	if len(due["Bitstamp"]) != 2 {
+		t.Fatalf("Test failed. Expected 2 due jobs, got %d", len(due["Bitstamp"]))
+	}
+	if due["Bitstamp"][0].kind != pollOrderbook {
+		t.Error("Test failed. Expected longest due job first")
+	}
+
+	if len(s.due(now)) != 0 {
+		t.Error("Test failed. Expected no jobs for a busy exchange")
+	}
+
+	s.complete("Bitstamp", due["Bitstamp"], now)
+	if len(s.due(now)) != 0 {
+		t.Error("Test failed. Expected completed jobs to be rescheduled")
+	}
+
+	due = s.due(now.Add(time.Second))
+	if len(due["Bitstamp"]) != 1 || due["Bitstamp"][0].kind != pollTicker {
+		t.Error("Test failed. Expected ticker job to be due after its interval")
+	}
+}
+
+func TestPollSchedulerExchanges(t *testing.T) {
+	setupAccountInfoTest(t, time.Minute)
+	exch := newPairUpdateTestExchange("Bitstamp")
+	now := time.Now()
+	s := &pollScheduler{busy: make(map[string]bool)}
+	s.add(&pollJob{exch: &accountInfoTestExchange{name: "Other"}, kind: pollAccount,
+		interval: time.Minute, next: now})
+
+	s.addExchange(exch, now)
+	if len(s.jobs) != 5 {
+		t.Fatalf("Test failed. Expected ticker and orderbook jobs for 2 pairs, got %d jobs",
+			len(s.jobs))
+	}
+
+	err := exch.SetCurrencies(currency.NewPairsFromStrings([]string{"BTC-USD"}), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.addExchange(exch, now)
+	if len(s.jobs) != 3 {
+		t.Errorf("Test failed. Expected jobs to be rebuilt for 1 pair, got %d jobs",
+			len(s.jobs))
+	}
+
+	s.removeExchange("Bitstamp")
+	if len(s.jobs) != 1 || s.jobs[0].exch.GetName() != "Other" {
+		t.Errorf("Test failed. Expected only the other exchanges jobs to remain, got %d jobs",
+			len(s.jobs))
+	}
+}
@@ -0,0 +1,489 @@
+package portfolio
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	log "github.com/thrasher-/gocryptotrader/logger"
+	"golang.org/x/crypto/ripemd160"
+)
+
+const (
+	// PortfolioAddressHDWallet is a label for an address derived from an
+	// extended public key
+	PortfolioAddressHDWallet = "HDWallet"
+
+	// DefaultGapLimit is the number of consecutive unfunded addresses derived
+	// before an extended key chain is considered fully scanned
+	DefaultGapLimit = 20
+	// maxDerivedAddresses caps the addresses derived per extended key chain
+	maxDerivedAddresses = 1000
+
+	extendedKeyLength = 78
+	hardenedKeyStart  = 0x80000000
+)
+
+// Address script types an extended public key derives
+const (
+	scriptP2PKH = iota
+	scriptP2SHP2WPKH
+	scriptP2WPKH
+)
+
+// extendedKeyVersions maps extended public key version bytes to the address
+// script type they derive
+var extendedKeyVersions = map[uint32]int{
+	0x0488b21e: scriptP2PKH,      // xpub
+	0x049d7cb2: scriptP2SHP2WPKH, // ypub
+	0x04b24746: scriptP2WPKH,     // zpub
+	0x019da462: scriptP2PKH,      // Ltub
+	0x01b26ef6: scriptP2SHP2WPKH, // Mtub
+}
+
+// addressParams holds the address encoding of a coin
+type addressParams struct {
+	pubKeyHash byte
+	scriptHash byte
+	bech32HRP  string
+}
+
+var hdAddressParams = map[string]addressParams{
+	"BTC": {pubKeyHash: 0x00, scriptHash: 0x05, bech32HRP: "bc"},
+	"LTC": {pubKeyHash: 0x30, scriptHash: 0x32, bech32HRP: "ltc"},
+}
+
+// ExtendedKey holds an extended public key of a HD wallet account whose
+// receive and change addresses are derived and watched. GapLimit is the number
+// of consecutive unfunded addresses derived before a chain is considered
+// fully scanned
+type ExtendedKey struct {
+	Key         string
+	CoinType    currency.Code
+	GapLimit    int
+	Description string `json:",omitempty"`
+}
+
+// extendedPublicKey holds a parsed extended public key
+type extendedPublicKey struct {
+	script    int
+	pubKey    []byte
+	chainCode []byte
+}
+
+// Validate checks an extended key is usable, defaulting its gap limit
+func (k *ExtendedKey) Validate() error {
+	if _, ok := hdAddressParams[k.CoinType.Upper().String()]; !ok {
+		return fmt.Errorf("extended key coin type %s unsupported", k.CoinType)
+	}
+	if _, err := parseExtendedKey(k.Key); err != nil {
+		return err
+	}
+	if k.GapLimit <= 0 || k.GapLimit > maxDerivedAddresses {
+		k.GapLimit = DefaultGapLimit
+	}
+	return nil
+}
+
+// secp256k1 curve parameters
+var (
+	secp256k1P, _  = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	secp256k1N, _  = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	secp256k1Gx, _ = new(big.Int).SetString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", 16)
+	secp256k1Gy, _ = new(big.Int).SetString("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8", 16)
+)
+
+// curveAdd adds two secp256k1 points, a nil x is the point at infinity
+func curveAdd(x1, y1, x2, y2 *big.Int) (x, y *big.Int) {
+	if x1 == nil {
+		return x2, y2
+	}
+	if x2 == nil {
+		return x1, y1
+	}
+
+	p := secp256k1P
+	var slope *big.Int
+	if x1.Cmp(x2) == 0 {
+		if new(big.Int).Add(y1, y2).Mod(new(big.Int).Add(y1, y2), p).Sign() == 0 {
+			return nil, nil
+		}
+		// slope = 3x^2 / 2y
+		num := new(big.Int).Mul(x1, x1)
+		num.Mul(num, big.NewInt(3))
+		den := new(big.Int).Lsh(y1, 1)
+		slope = num.Mul(num, den.ModInverse(den.Mod(den, p), p))
+	} else {
+		// slope = (y2 - y1) / (x2 - x1)
+		num := new(big.Int).Sub(y2, y1)
+		den := new(big.Int).Sub(x2, x1)
+		slope = num.Mul(num, den.ModInverse(den.Mod(den, p), p))
+	}
+	slope.Mod(slope, p)
+
+	x = new(big.Int).Mul(slope, slope)
+	x.Sub(x, x1).Sub(x, x2).Mod(x, p)
+	y = new(big.Int).Sub(x1, x)
+	y.Mul(y, slope).Sub(y, y1).Mod(y, p)
+	return x, y
+}
+
+// curveScalarBaseMult multiplies the secp256k1 generator by k
+func curveScalarBaseMult(k []byte) (x, y *big.Int) {
+	bx, by := secp256k1Gx, secp256k1Gy
+	scalar := new(big.Int).SetBytes(k)
+	for i := 0; i < scalar.BitLen(); i++ {
+		if scalar.Bit(i) == 1 {
+			x, y = curveAdd(x, y, bx, by)
+		}
+		bx, by = curveAdd(bx, by, bx, by)
+	}
+	return x, y
+}
+
+// decompressPubKey returns the point of a compressed public key
+func decompressPubKey(pubKey []byte) (x, y *big.Int, err error) {
+	if len(pubKey) != 33 || (pubKey[0] != 0x02 && pubKey[0] != 0x03) {
+		return nil, nil, errors.New("invalid compressed public key")
+	}
+
+	p := secp256k1P
+	x = new(big.Int).SetBytes(pubKey[1:])
+	// y^2 = x^3 + 7, p = 3 mod 4 so y = (y^2)^((p+1)/4)
+	y2 := new(big.Int).Exp(x, big.NewInt(3), p)
+	y2.Add(y2, big.NewInt(7)).Mod(y2, p)
+	exp := new(big.Int).Add(p, big.NewInt(1))
+	y = new(big.Int).Exp(y2, exp.Rsh(exp, 2), p)
+	if new(big.Int).Exp(y, big.NewInt(2), p).Cmp(y2) != 0 {
+		return nil, nil, errors.New("public key not on curve")
+	}
+	if y.Bit(0) != uint(pubKey[0]&1) {
+		y.Sub(p, y)
+	}
+	return x, y, nil
+}
+
+// compressPubKey returns the compressed encoding of a point
+func compressPubKey(x, y *big.Int) []byte {
+	pubKey := make([]byte, 33)
+	pubKey[0] = 0x02 + byte(y.Bit(0))
+	xBytes := x.Bytes()
+	copy(pubKey[33-len(xBytes):], xBytes)
+	return pubKey
+}
+
+// parseExtendedKey decodes an extended public key
+func parseExtendedKey(key string) (extendedPublicKey, error) {
+	data, err := base58CheckDecode(key)
+	if err != nil {
+		return extendedPublicKey{}, fmt.Errorf("invalid extended key: %s", err)
+	}
+	if len(data) != extendedKeyLength {
+		return extendedPublicKey{}, errors.New("invalid extended key length")
+	}
+
+	script, ok := extendedKeyVersions[binary.BigEndian.Uint32(data[:4])]
+	if !ok {
+		return extendedPublicKey{}, errors.New("unsupported extended key version, only public keys are accepted")
+	}
+
+	pubKey := data[45:]
+	if _, _, err := decompressPubKey(pubKey); err != nil {
+		return extendedPublicKey{}, err
+	}
+	return extendedPublicKey{
+		script:    script,
+		pubKey:    pubKey,
+		chainCode: data[13:45],
+	}, nil
+}
+
+// child derives the non hardened child public key at an index
+func (k *extendedPublicKey) child(index uint32) (extendedPublicKey, error) {
+	if index >= hardenedKeyStart {
+		return extendedPublicKey{}, errors.New("hardened keys cannot be derived from a public key")
+	}
+
+	data := make([]byte, 37)
+	copy(data, k.pubKey)
+	binary.BigEndian.PutUint32(data[33:], index)
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	i := mac.Sum(nil)
+
+	if new(big.Int).SetBytes(i[:32]).Cmp(secp256k1N) >= 0 {
+		return extendedPublicKey{}, fmt.Errorf("invalid child key at index %d", index)
+	}
+
+	px, py, err := decompressPubKey(k.pubKey)
+	if err != nil {
+		return extendedPublicKey{}, err
+	}
+	ix, iy := curveScalarBaseMult(i[:32])
+	x, y := curveAdd(ix, iy, px, py)
+	if x == nil {
+		return extendedPublicKey{}, fmt.Errorf("invalid child key at index %d", index)
+	}
+
+	return extendedPublicKey{
+		script:    k.script,
+		pubKey:    compressPubKey(x, y),
+		chainCode: i[32:],
+	}, nil
+}
+
+// address returns the address of a public key for a coin
+func (k *extendedPublicKey) address(coinType currency.Code) (string, error) {
+	params, ok := hdAddressParams[coinType.Upper().String()]
+	if !ok {
+		return "", fmt.Errorf("extended key coin type %s unsupported", coinType)
+	}
+
+	keyHash := hash160(k.pubKey)
+	switch k.script {
+	case scriptP2SHP2WPKH:
+		redeemScript := append([]byte{0x00, 0x14}, keyHash...)
+		return base58CheckEncode(params.scriptHash, hash160(redeemScript)), nil
+	case scriptP2WPKH:
+		return bech32Encode(params.bech32HRP, 0, keyHash)
+	default:
+		return base58CheckEncode(params.pubKeyHash, keyHash), nil
+	}
+}
+
+// deriveAddresses derives the addresses of a chain of an extended key from
+// the start index
+func deriveAddresses(key extendedPublicKey, coinType currency.Code, chain, start, count uint32) ([]string, error) {
+	chainKey, err := key.child(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []string
+	for i := start; i < start+count; i++ {
+		child, err := chainKey.child(i)
+		if err != nil {
+			// Invalid children are skipped as specified by BIP32
+			continue
+		}
+		address, err := child.address(coinType)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+// scanExtendedKey looks up the balances of the receive and change addresses
+// of an extended key, deriving addresses until the gap limit of consecutive
+// unfunded addresses is reached
+func scanExtendedKey(k *ExtendedKey) ([]addressLookup, error) {
+	key, err := parseExtendedKey(k.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	gapLimit := k.GapLimit
+	if gapLimit <= 0 {
+		gapLimit = DefaultGapLimit
+	}
+
+	var resp []addressLookup
+	for _, chain := range []uint32{0, 1} {
+		next, lastUsed := 0, -1
+		for next <= lastUsed+gapLimit && next < maxDerivedAddresses {
+			count := lastUsed + gapLimit + 1 - next
+			addresses, err := deriveAddresses(key, k.CoinType, chain,
+				uint32(next), uint32(count))
+			if err != nil {
+				return nil, err
+			}
+
+			lookups := make([]addressLookup, len(addresses))
+			for x := range addresses {
+				lookups[x] = addressLookup{address: addresses[x],
+					coinType: k.CoinType}
+			}
+			lookups = lookupBalances(lookups)
+			for x := range lookups {
+				if lookups[x].err != nil {
+					return nil, lookups[x].err
+				}
+				if lookups[x].balance > 0 {
+					lastUsed = next + x
+				}
+			}
+			resp = append(resp, lookups...)
+			next += count
+		}
+	}
+	return resp, nil
+}
+
+// updateExtendedKeys scans the extended keys of the portfolio, adding the
+// funded derived addresses and removing those since emptied. False is returned
+// if any key could not be scanned
+func (p *Base) updateExtendedKeys() bool {
+	success := true
+	for x := range p.ExtendedKeys {
+		lookups, err := scanExtendedKey(&p.ExtendedKeys[x])
+		if err != nil {
+			log.Warnf("PortfolioWatcher: Unable to scan %s extended key: %s",
+				p.ExtendedKeys[x].CoinType, err)
+			success = false
+			continue
+		}
+
+		for y := range lookups {
+			if lookups[y].balance > 0 {
+				p.setAddressBalance(lookups[y].address,
+					PortfolioAddressHDWallet, lookups[y].coinType,
+					lookups[y].balance)
+				continue
+			}
+			p.RemoveAddress(lookups[y].address, PortfolioAddressHDWallet,
+				lookups[y].coinType)
+		}
+	}
+	return success
+}
+
+// hash160 returns the RIPEMD160 hash of the SHA256 hash of data
+func hash160(data []byte) []byte {
+	sha := sha256.Sum256(data)
+	h := ripemd160.New()
+	h.Write(sha[:])
+	return h.Sum(nil)
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58CheckEncode encodes a payload with a version byte and checksum
+func base58CheckEncode(version byte, payload []byte) string {
+	data := append([]byte{version}, payload...)
+	checksum := doubleSHA256(data)
+	data = append(data, checksum[:4]...)
+
+	n := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var encoded []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+	for x := 0; x < len(data) && data[x] == 0; x++ {
+		encoded = append(encoded, base58Alphabet[0])
+	}
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
+
+// base58CheckDecode decodes a base58 string, verifying and removing its
+// checksum
+func base58CheckDecode(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, c := range s {
+		i := strings.IndexRune(base58Alphabet, c)
+		if i < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", c)
+		}
+		n.Mul(n, radix).Add(n, big.NewInt(int64(i)))
+	}
+
+	var zeros int
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	data := append(make([]byte, zeros), n.Bytes()...)
+	if len(data) < 5 {
+		return nil, errors.New("data too short")
+	}
+
+	payload, checksum := data[:len(data)-4], data[len(data)-4:]
+	expected := doubleSHA256(payload)
+	if !bytes.Equal(checksum, expected[:4]) {
+		return nil, errors.New("invalid checksum")
+	}
+	return payload, nil
+}
+
+func doubleSHA256(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// bech32Encode encodes a segregated witness program as a bech32 address
+func bech32Encode(hrp string, version byte, program []byte) (string, error) {
+	data := []byte{version}
+	// Convert the program from 8 bit to 5 bit groups
+	var acc, bits uint
+	for _, b := range program {
+		acc = acc<<8 | uint(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			data = append(data, byte(acc>>bits&31))
+		}
+	}
+	if bits > 0 {
+		data = append(data, byte(acc<<(5-bits)&31))
+	}
+
+	values := append(bech32HRPExpand(hrp), data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	polymod := bech32Polymod(values) ^ 1
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, d := range data {
+		sb.WriteByte(bech32Charset[d])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+	return sb.String(), nil
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	var resp []byte
+	for x := range hrp {
+		resp = append(resp, hrp[x]>>5)
+	}
+	resp = append(resp, 0)
+	for x := range hrp {
+		resp = append(resp, hrp[x]&31)
+	}
+	return resp
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := []uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd,
+		0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
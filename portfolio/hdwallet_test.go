@@ -0,0 +1,149 @@
+package portfolio
+
+import (
+	"encoding/hex"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+// Account extended public keys of the "abandon ... about" test mnemonic from
+// BIP44 and BIP84
+const (
+	testXPub = "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj"
+	testZPub = "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs"
+)
+
+func TestDeriveAddresses(t *testing.T) {
+	tests := []struct {
+		key      string
+		chain    uint32
+		expected []string
+	}{
+		{testXPub, 0, []string{"1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA"}},
+		{testZPub, 0, []string{"bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+			"bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g"}},
+		{testZPub, 1, []string{"bc1q8c6fshw2dlwun7ekn9qwf37cu2rn755upcp6el"}},
+	}
+
+	for x := range tests {
+		key, err := parseExtendedKey(tests[x].key)
+		if err != nil {
+			t.Fatalf("Test Failed - parseExtendedKey() error %s", err)
+		}
+		addresses, err := deriveAddresses(key, currency.BTC, tests[x].chain, 0,
+			uint32(len(tests[x].expected)))
+		if err != nil {
+			t.Fatalf("Test Failed - deriveAddresses() error %s", err)
+		}
+		for y := range tests[x].expected {
+			if addresses[y] != tests[x].expected[y] {
+				t.Errorf("Test Failed - deriveAddresses() expected %s, got %s",
+					tests[x].expected[y], addresses[y])
+			}
+		}
+	}
+}
+
+func TestP2SHP2WPKHAddress(t *testing.T) {
+	// BIP49 test vector public key and testnet address
+	pubKey, err := hex.DecodeString("03a1af804ac108a8a51782198c2d034b28bf90c8803f5a53f76276fa69a4eae77f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hdAddressParams["TESTNET"] = addressParams{scriptHash: 0xc4}
+	defer delete(hdAddressParams, "TESTNET")
+
+	key := extendedPublicKey{script: scriptP2SHP2WPKH, pubKey: pubKey}
+	address, err := key.address(currency.NewCode("TESTNET"))
+	if err != nil || address != "2Mww8dCYPUpKHofjgcXcBCEGmniw9CoaiD2" {
+		t.Errorf("Test Failed - address() expected 2Mww8dCYPUpKHofjgcXcBCEGmniw9CoaiD2, got %s %v",
+			address, err)
+	}
+}
+
+func TestExtendedKeyValidate(t *testing.T) {
+	k := ExtendedKey{Key: testZPub, CoinType: currency.BTC}
+	if err := k.Validate(); err != nil || k.GapLimit != DefaultGapLimit {
+		t.Errorf("Test Failed - Validate() expected default gap limit, got %d %v",
+			k.GapLimit, err)
+	}
+
+	invalid := []ExtendedKey{
+		{Key: testZPub, CoinType: currency.ETH},
+		{Key: testZPub[:len(testZPub)-1] + "t", CoinType: currency.BTC},
+		// Extended private key of the BIP32 test vector 1 master
+		{Key: "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+			CoinType: currency.BTC},
+	}
+	for x := range invalid {
+		if err := invalid[x].Validate(); err == nil {
+			t.Errorf("Test Failed - Validate() expected error for %s", invalid[x].Key)
+		}
+	}
+}
+
+func TestUpdateExtendedKeys(t *testing.T) {
+	p := providers[providerCryptoID]
+	fetch, interval := p.fetch, p.interval
+	defer func() {
+		p.fetch, p.interval = fetch, interval
+		p.metrics = ProviderMetrics{}
+	}()
+
+	key, err := parseExtendedKey(testXPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receive, err := deriveAddresses(key, currency.BTC, 0, 0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The third receive address is funded so the scan continues past the
+	// first gap, the change chain is unused
+	balances := map[string]float64{receive[0]: 1, receive[2]: 0.5}
+	var calls int
+	var m sync.Mutex
+	p.interval = time.Millisecond
+	p.fetch = func(address string, coinType currency.Code) (balanceResult, error) {
+		m.Lock()
+		defer m.Unlock()
+		calls++
+		return balanceResult{balance: balances[address]}, nil
+	}
+
+	var base Base
+	base.ExtendedKeys = []ExtendedKey{{Key: testXPub, CoinType: currency.BTC,
+		GapLimit: 3}}
+	if !base.updateExtendedKeys() {
+		t.Fatal("Test Failed - updateExtendedKeys() expected success")
+	}
+
+	// Receive addresses 0 to 5 and change addresses 0 to 2
+	if calls != 9 {
+		t.Errorf("Test Failed - updateExtendedKeys() expected 9 lookups, got %d",
+			calls)
+	}
+	if base.GetPersonalPortfolio()[currency.BTC] != 1.5 {
+		t.Errorf("Test Failed - updateExtendedKeys() expected 1.5 BTC, got %f",
+			base.GetPersonalPortfolio()[currency.BTC])
+	}
+	if len(base.GetPortfolioGroupedCoin()) != 0 {
+		t.Error("Test Failed - GetPortfolioGroupedCoin() expected derived addresses to be excluded")
+	}
+
+	balanceCache.m.Lock()
+	for address := range balances {
+		delete(balanceCache.balances, currency.BTC.String()+address)
+	}
+	balanceCache.m.Unlock()
+	balances[receive[0]] = 0
+	base.updateExtendedKeys()
+	if _, ok := base.GetAddressBalance(receive[0], PortfolioAddressHDWallet,
+		currency.BTC); ok {
+		t.Error("Test Failed - updateExtendedKeys() expected emptied address to be removed")
+	}
+}
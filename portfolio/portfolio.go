@@ -3,6 +3,7 @@ package portfolio
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
@@ -25,6 +26,36 @@ const (
 // Portfolio is variable store holding an array of portfolioAddress
 var Portfolio Base
 
+// parityRates holds observed stablecoin parity rates used to value
+// stablecoin holdings instead of assuming 1:1 parity
+var parityRates = struct {
+	rates map[currency.Code]float64
+	m     sync.RWMutex
+}{rates: make(map[currency.Code]float64)}
+
+// SetParityRate sets the observed parity rate used to value a stablecoin
+func SetParityRate(c currency.Code, rate float64) {
+	parityRates.m.Lock()
+	parityRates.rates[c.Upper()] = rate
+	parityRates.m.Unlock()
+}
+
+// ClearParityRates removes all observed parity rates so stablecoins are no
+// longer valued
+func ClearParityRates() {
+	parityRates.m.Lock()
+	parityRates.rates = make(map[currency.Code]float64)
+	parityRates.m.Unlock()
+}
+
+// GetParityRate returns the observed parity rate of a stablecoin
+func GetParityRate(c currency.Code) (float64, bool) {
+	parityRates.m.RLock()
+	defer parityRates.m.RUnlock()
+	rate, ok := parityRates.rates[c.Upper()]
+	return rate, ok
+}
+
 // GetEthereumBalance single or multiple address information as
 // EtherchainBalanceResponse
 func GetEthereumBalance(address string) (EthplorerResponse, error) {
@@ -95,6 +126,19 @@ func (p *Base) AddressExists(address string) bool {
 	return false
 }
 
+// personalAddressExists checks to see if there is an address associated with
+// the portfolio base, ignoring token balances and derived addresses
+func (p *Base) personalAddressExists(address string) bool {
+	for x := range p.Addresses {
+		if p.Addresses[x].Address == address &&
+			p.Addresses[x].Description != PortfolioAddressToken &&
+			p.Addresses[x].Description != PortfolioAddressHDWallet {
+			return true
+		}
+	}
+	return false
+}
+
 // ExchangeAddressExists checks to see if there is an exchange address
 // associated with the portfolio base
 func (p *Base) ExchangeAddressExists(exchangeName string, coinType currency.Code) bool {
@@ -118,10 +162,12 @@ func (p *Base) AddExchangeAddress(exchangeName string, coinType currency.Code, b
 	}
 }
 
-// UpdateAddressBalance updates the portfolio base balance
+// UpdateAddressBalance updates the portfolio base balance, token balances held
+// at the address are left unchanged
 func (p *Base) UpdateAddressBalance(address string, amount float64) {
 	for x := range p.Addresses {
-		if p.Addresses[x].Address == address {
+		if p.Addresses[x].Address == address &&
+			p.Addresses[x].Description != PortfolioAddressToken {
 			p.Addresses[x].Balance = amount
 		}
 	}
@@ -153,7 +199,7 @@ func (p *Base) AddAddress(address, description string, coinType currency.Code, b
 		p.AddExchangeAddress(address, coinType, balance)
 		return
 	}
-	if !p.AddressExists(address) {
+	if !p.personalAddressExists(address) {
 		p.Addresses = append(
 			p.Addresses, Address{Address: address, CoinType: coinType,
 				Balance: balance, Description: description},
@@ -167,6 +213,21 @@ func (p *Base) AddAddress(address, description string, coinType currency.Code, b
 	}
 }
 
+// setAddressBalance adds or updates the balance of an address with a
+// description and coin type
+func (p *Base) setAddressBalance(address, description string, coinType currency.Code, balance float64) {
+	for x := range p.Addresses {
+		if p.Addresses[x].Address == address &&
+			p.Addresses[x].Description == description &&
+			p.Addresses[x].CoinType.Match(coinType) {
+			p.Addresses[x].Balance = balance
+			return
+		}
+	}
+	p.Addresses = append(p.Addresses, Address{Address: address,
+		CoinType: coinType, Balance: balance, Description: description})
+}
+
 // RemoveAddress removes an address when checked against the correct address and
 // coinType
 func (p *Base) RemoveAddress(address, description string, coinType currency.Code) {
@@ -180,44 +241,40 @@ func (p *Base) RemoveAddress(address, description string, coinType currency.Code
 	}
 }
 
-// UpdatePortfolio adds to the portfolio addresses by coin type
+// UpdatePortfolio adds to the portfolio addresses by coin type, returning
+// false if any address balance could not be looked up
 func (p *Base) UpdatePortfolio(addresses []string, coinType currency.Code) bool {
 	if common.StringContains(common.JoinStrings(addresses, ","), PortfolioAddressExchange) ||
 		common.StringContains(common.JoinStrings(addresses, ","), PortfolioAddressPersonal) {
 		return true
 	}
 
-	numErrors := 0
-	if coinType == currency.ETH {
-		for x := range addresses {
-			result, err := GetEthereumBalance(addresses[x])
-			if err != nil {
-				numErrors++
-				continue
-			}
-
-			if result.Error.Message != "" {
-				numErrors++
-				continue
-			}
-			p.AddAddress(addresses[x],
-				PortfolioAddressPersonal,
-				coinType,
-				result.ETH.Balance)
-		}
-		return numErrors == 0
-	}
+	lookups := make([]addressLookup, len(addresses))
 	for x := range addresses {
-		result, err := GetCryptoIDAddress(addresses[x], coinType)
-		if err != nil {
-			return false
+		lookups[x] = addressLookup{address: addresses[x], coinType: coinType}
+	}
+	return p.applyLookups(lookupBalances(lookups))
+}
+
+// applyLookups updates the portfolio with the successful balance lookups and
+// the token balances held at Ethereum addresses, returning false if any lookup
+// failed
+func (p *Base) applyLookups(lookups []addressLookup) bool {
+	success := true
+	for x := range lookups {
+		if lookups[x].err != nil {
+			success = false
+			continue
 		}
-		p.AddAddress(addresses[x],
+		p.AddAddress(lookups[x].address,
 			PortfolioAddressPersonal,
-			coinType,
-			result)
+			lookups[x].coinType,
+			lookups[x].balance)
+		if lookups[x].coinType.Match(currency.ETH) {
+			p.updateTokenBalances(lookups[x].address, lookups[x].tokens)
+		}
 	}
-	return true
+	return success
 }
 
 // GetPortfolioByExchange returns currency portfolio amount by exchange
@@ -305,6 +362,9 @@ func (p *Base) GetPortfolioSummary() Summary {
 	var portfolioOutput Summary
 	for x, y := range totalCoins {
 		coins := Coin{Coin: x, Balance: y}
+		if rate, ok := GetParityRate(x); ok {
+			coins.Value = y * rate
+		}
 		portfolioOutput.Totals = append(portfolioOutput.Totals, coins)
 	}
 
@@ -380,7 +440,9 @@ func (p *Base) GetPortfolioSummary() Summary {
 func (p *Base) GetPortfolioGroupedCoin() map[currency.Code][]string {
 	result := make(map[currency.Code][]string)
 	for _, x := range p.Addresses {
-		if common.StringContains(x.Description, PortfolioAddressExchange) {
+		if common.StringContains(x.Description, PortfolioAddressExchange) ||
+			x.Description == PortfolioAddressToken ||
+			x.Description == PortfolioAddressHDWallet {
 			continue
 		}
 		result[x.CoinType] = append(result[x.CoinType], x.Address)
@@ -389,28 +451,48 @@ func (p *Base) GetPortfolioGroupedCoin() map[currency.Code][]string {
 }
 
 // SeedPortfolio appends a portfolio base object with another base portfolio
-// addresses
+// addresses and extended keys
 func (p *Base) SeedPortfolio(port Base) {
 	p.Addresses = port.Addresses
+	p.ExtendedKeys = port.ExtendedKeys
 }
 
-// StartPortfolioWatcher observes the portfolio object
+// StartPortfolioWatcher observes the portfolio object, looking up the balances
+// of all personal addresses concurrently within each providers rate limit
 func StartPortfolioWatcher() {
 	addrCount := len(Portfolio.Addresses)
 	log.Debugf(
 		"PortfolioWatcher started: Have %d entries in portfolio.\n", addrCount,
 	)
 	for {
+		var lookups []addressLookup
 		data := Portfolio.GetPortfolioGroupedCoin()
 		for key, value := range data {
-			success := Portfolio.UpdatePortfolio(value, key)
-			if success {
-				log.Debugf(
-					"PortfolioWatcher: Successfully updated address balance for %s address(es) %s\n",
-					key, value,
-				)
+			for x := range value {
+				lookups = append(lookups,
+					addressLookup{address: value[x], coinType: key})
 			}
 		}
+
+		lookups = lookupBalances(lookups)
+		for x := range lookups {
+			if lookups[x].err != nil {
+				log.Warnf("PortfolioWatcher: Unable to update %s address %s balance: %s",
+					lookups[x].coinType, lookups[x].address, lookups[x].err)
+			}
+		}
+		if Portfolio.applyLookups(lookups) {
+			log.Debugf(
+				"PortfolioWatcher: Successfully updated %d address balance(s)\n",
+				len(lookups),
+			)
+		}
+		if len(Portfolio.ExtendedKeys) > 0 && Portfolio.updateExtendedKeys() {
+			log.Debugf(
+				"PortfolioWatcher: Successfully scanned %d extended key(s)\n",
+				len(Portfolio.ExtendedKeys),
+			)
+		}
 		time.Sleep(time.Minute * 10)
 	}
 }
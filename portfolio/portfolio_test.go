@@ -379,6 +379,41 @@ func TestGetPortfolioSummary(t *testing.T) {
 	}
 }
 
+func TestGetPortfolioSummaryParityValue(t *testing.T) {
+	newbase := Base{}
+	newbase.AddExchangeAddress("Bitfinex", currency.USDT, 1000)
+	newbase.AddExchangeAddress("Bitfinex", currency.BTC, 1)
+
+	portfolio := GetPortfolio()
+	portfolio.SeedPortfolio(newbase)
+
+	SetParityRate(currency.USDT, 0.98)
+	defer ClearParityRates()
+	if rate, ok := GetParityRate(currency.USDT); !ok || rate != 0.98 {
+		t.Fatal("Test Failed - portfolio_test.go - GetParityRate error")
+	}
+
+	value := portfolio.GetPortfolioSummary()
+	for x := range value.Totals {
+		switch value.Totals[x].Coin {
+		case currency.USDT:
+			if value.Totals[x].Value != 980 {
+				t.Errorf("Test Failed - portfolio_test.go - expected USDT value 980, got %f",
+					value.Totals[x].Value)
+			}
+		case currency.BTC:
+			if value.Totals[x].Value != 0 {
+				t.Error("Test Failed - portfolio_test.go - non stablecoin should not be valued")
+			}
+		}
+	}
+
+	ClearParityRates()
+	if _, ok := GetParityRate(currency.USDT); ok {
+		t.Error("Test Failed - portfolio_test.go - ClearParityRates error")
+	}
+}
+
 func TestGetPortfolioGroupedCoin(t *testing.T) {
 	newbase := Base{}
 	newbase.AddAddress("someaddress", currency.LTC.String(), currency.LTC, 0.02)
@@ -1,10 +1,16 @@
 package portfolio
 
-import "github.com/thrasher-/gocryptotrader/currency"
+import (
+	"time"
 
-// Base holds the portfolio base addresses
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+// Base holds the portfolio base addresses and the extended public keys of HD
+// wallets whose derived addresses are watched
 type Base struct {
-	Addresses []Address
+	Addresses    []Address
+	ExtendedKeys []ExtendedKey `json:",omitempty"`
 }
 
 // Address sub type holding address information for portfolio
@@ -65,12 +71,23 @@ type EthplorerResponse struct {
 			Currency string `json:"currency"`
 		} `json:"price"`
 	} `json:"tokenInfo"`
-	Error struct {
+	Tokens []EthplorerTokenBalance `json:"tokens"`
+	Error  struct {
 		Code    int    `json:"code"`
 		Message string `json:"message"`
 	} `json:"error"`
 }
 
+// EthplorerTokenBalance holds an ERC-20 token balance of an address, Balance
+// is unscaled by the tokens decimals
+type EthplorerTokenBalance struct {
+	TokenInfo struct {
+		Address string `json:"address"`
+		Symbol  string `json:"symbol"`
+	} `json:"tokenInfo"`
+	Balance float64 `json:"balance"`
+}
+
 // ExchangeAccountInfo : Generic type to hold each exchange's holdings in all
 // enabled currencies
 type ExchangeAccountInfo struct {
@@ -86,12 +103,13 @@ type ExchangeAccountCurrencyInfo struct {
 }
 
 // Coin stores a coin type, balance, address and percentage relative to the total
-// amount.
+// amount. Value is only set for stablecoins with an observed parity rate
 type Coin struct {
 	Coin       currency.Code `json:"coin"`
 	Balance    float64       `json:"balance"`
 	Address    string        `json:"address,omitempty"`
 	Percentage float64       `json:"percentage,omitempty"`
+	Value      float64       `json:"value,omitempty"`
 }
 
 // OfflineCoinSummary stores a coin types address, balance and percentage
@@ -117,3 +135,19 @@ type Summary struct {
 	Online         []Coin                                         `json:"coins_online"`
 	OnlineSummary  map[string]map[currency.Code]OnlineCoinSummary `json:"online_summary"`
 }
+
+// ProviderMetrics holds the request, error and latency metrics of an address
+// balance provider. BackoffUntil is set while the provider is backing off after
+// consecutive errors
+type ProviderMetrics struct {
+	Provider            string        `json:"provider"`
+	Requests            int64         `json:"requests"`
+	Errors              int64         `json:"errors"`
+	ConsecutiveFailures int           `json:"consecutiveFailures"`
+	LastError           string        `json:"lastError,omitempty"`
+	BackoffUntil        time.Time     `json:"backoffUntil,omitempty"`
+	AverageLatency      time.Duration `json:"averageLatency"`
+	MaxLatency          time.Duration `json:"maxLatency"`
+	LastLatency         time.Duration `json:"lastLatency"`
+	TotalLatency        time.Duration `json:"-"`
+}
@@ -0,0 +1,240 @@
+package portfolio
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+const (
+	providerEthplorer = "ethplorer"
+	providerCryptoID  = "cryptoid"
+
+	// balanceCacheTTL is how long a looked up address balance is reused
+	balanceCacheTTL = time.Minute * 5
+	// lookupWorkers is the number of concurrent address balance lookups
+	lookupWorkers = 4
+
+	providerBackoffBase = time.Second * 10
+	providerBackoffMax  = time.Minute * 10
+)
+
+// provider rate limits a balance provider and backs off exponentially while
+// it returns errors, tracking its lookup latency
+type provider struct {
+	name     string
+	interval time.Duration
+	fetch    func(address string, coinType currency.Code) (balanceResult, error)
+
+	next    time.Time
+	metrics ProviderMetrics
+	m       sync.Mutex
+}
+
+// providers holds the balance providers, ethplorer allows two requests per
+// second on its free key and cryptoid one per second
+var providers = map[string]*provider{
+	providerEthplorer: {
+		name:     providerEthplorer,
+		interval: time.Millisecond * 500,
+		fetch:    getEthplorerBalance,
+	},
+	providerCryptoID: {
+		name:     providerCryptoID,
+		interval: time.Second,
+		fetch:    getCryptoIDBalance,
+	},
+}
+
+// balanceResult holds the balance of an address and the registered token
+// balances held at it
+type balanceResult struct {
+	balance float64
+	tokens  []tokenBalance
+}
+
+type cachedBalance struct {
+	result  balanceResult
+	updated time.Time
+}
+
+// balanceCache holds recently looked up address balances
+var balanceCache = struct {
+	balances map[string]cachedBalance
+	m        sync.Mutex
+}{balances: make(map[string]cachedBalance)}
+
+// addressLookup holds the result of an address balance lookup
+type addressLookup struct {
+	address  string
+	coinType currency.Code
+	balance  float64
+	tokens   []tokenBalance
+	err      error
+}
+
+func getEthplorerBalance(address string, _ currency.Code) (balanceResult, error) {
+	result, err := GetEthereumBalance(address)
+	if err != nil {
+		return balanceResult{}, err
+	}
+	if result.Error.Message != "" {
+		return balanceResult{}, errors.New(result.Error.Message)
+	}
+	return balanceResult{
+		balance: result.ETH.Balance,
+		tokens:  getTokenBalances(&result),
+	}, nil
+}
+
+func getCryptoIDBalance(address string, coinType currency.Code) (balanceResult, error) {
+	balance, err := GetCryptoIDAddress(address, coinType)
+	return balanceResult{balance: balance}, err
+}
+
+// getProvider returns the balance provider of a coin type
+func getProvider(coinType currency.Code) *provider {
+	if coinType.Match(currency.ETH) {
+		return providers[providerEthplorer]
+	}
+	return providers[providerCryptoID]
+}
+
+// reserve returns how long to wait before the next request to the provider,
+// reserving its slot. An error is returned while the provider is backing off
+func (p *provider) reserve(now time.Time) (time.Duration, error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	if now.Before(p.metrics.BackoffUntil) {
+		return 0, fmt.Errorf("provider %s backing off until %s", p.name,
+			p.metrics.BackoffUntil)
+	}
+
+	start := now
+	if p.next.After(now) {
+		start = p.next
+	}
+	p.next = start.Add(p.interval)
+	return start.Sub(now), nil
+}
+
+// record records the latency and result of a provider request, backing off
+// exponentially on consecutive errors
+func (p *provider) record(latency time.Duration, err error, now time.Time) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.metrics.Requests++
+	p.metrics.TotalLatency += latency
+	p.metrics.LastLatency = latency
+	if latency > p.metrics.MaxLatency {
+		p.metrics.MaxLatency = latency
+	}
+
+	if err == nil {
+		p.metrics.ConsecutiveFailures = 0
+		p.metrics.BackoffUntil = time.Time{}
+		return
+	}
+
+	p.metrics.Errors++
+	p.metrics.ConsecutiveFailures++
+	p.metrics.LastError = err.Error()
+	backoff := providerBackoffBase
+	for i := 1; i < p.metrics.ConsecutiveFailures && backoff < providerBackoffMax; i++ {
+		backoff *= 2
+	}
+	if backoff > providerBackoffMax {
+		backoff = providerBackoffMax
+	}
+	p.metrics.BackoffUntil = now.Add(backoff)
+}
+
+// lookupBalance returns the balance of an address, using a cached balance
+// when available and otherwise querying its provider within its rate limit
+func lookupBalance(address string, coinType currency.Code) (balanceResult, error) {
+	valid, err := common.IsValidCryptoAddress(address, coinType.String())
+	if err != nil || !valid {
+		return balanceResult{}, fmt.Errorf("invalid %s address %s", coinType,
+			address)
+	}
+
+	key := coinType.Upper().String() + address
+	balanceCache.m.Lock()
+	cached, ok := balanceCache.balances[key]
+	balanceCache.m.Unlock()
+	if ok && time.Since(cached.updated) < balanceCacheTTL {
+		return cached.result, nil
+	}
+
+	p := getProvider(coinType)
+	wait, err := p.reserve(time.Now())
+	if err != nil {
+		return balanceResult{}, err
+	}
+	time.Sleep(wait)
+
+	start := time.Now()
+	result, err := p.fetch(address, coinType)
+	p.record(time.Since(start), err, time.Now())
+	if err != nil {
+		return balanceResult{}, err
+	}
+
+	balanceCache.m.Lock()
+	balanceCache.balances[key] = cachedBalance{result: result, updated: time.Now()}
+	balanceCache.m.Unlock()
+	return result, nil
+}
+
+// lookupBalances looks up address balances concurrently with a pool of
+// workers, returning the results in the order of the lookups
+func lookupBalances(lookups []addressLookup) []addressLookup {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < lookupWorkers && i < len(lookups); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for x := range jobs {
+				result, err := lookupBalance(lookups[x].address,
+					lookups[x].coinType)
+				lookups[x].balance, lookups[x].tokens, lookups[x].err =
+					result.balance, result.tokens, err
+			}
+		}()
+	}
+
+	for x := range lookups {
+		jobs <- x
+	}
+	close(jobs)
+	wg.Wait()
+	return lookups
+}
+
+// GetProviderMetrics returns the request, error and latency metrics of the
+// address balance providers
+func GetProviderMetrics() []ProviderMetrics {
+	var resp []ProviderMetrics
+	for _, p := range providers {
+		p.m.Lock()
+		metrics := p.metrics
+		p.m.Unlock()
+
+		metrics.Provider = p.name
+		if metrics.Requests > 0 {
+			metrics.AverageLatency = metrics.TotalLatency /
+				time.Duration(metrics.Requests)
+		}
+		resp = append(resp, metrics)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Provider < resp[j].Provider
+	})
+	return resp
+}
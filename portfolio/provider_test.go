@@ -0,0 +1,108 @@
+package portfolio
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+func TestProviderReserve(t *testing.T) {
+	p := provider{name: "test", interval: time.Second}
+	now := time.Now()
+
+	wait, err := p.reserve(now)
+	if err != nil || wait != 0 {
+		t.Errorf("Test Failed - reserve() expected no wait, got %s %v", wait, err)
+	}
+
+	wait, err = p.reserve(now)
+	if err != nil || wait != time.Second {
+		t.Errorf("Test Failed - reserve() expected rate limited wait, got %s %v",
+			wait, err)
+	}
+
+	p.record(time.Millisecond, errors.New("timeout"), now)
+	p.record(time.Millisecond*3, errors.New("timeout"), now)
+	if p.metrics.BackoffUntil != now.Add(providerBackoffBase*2) {
+		t.Errorf("Test Failed - record() expected exponential backoff, got %s",
+			p.metrics.BackoffUntil)
+	}
+
+	_, err = p.reserve(now)
+	if err == nil {
+		t.Error("Test Failed - reserve() expected error while backing off")
+	}
+
+	p.record(time.Millisecond*2, nil, now)
+	if p.metrics.ConsecutiveFailures != 0 || !p.metrics.BackoffUntil.IsZero() {
+		t.Error("Test Failed - record() expected success to reset backoff")
+	}
+	if p.metrics.Requests != 3 || p.metrics.Errors != 2 ||
+		p.metrics.MaxLatency != time.Millisecond*3 {
+		t.Errorf("Test Failed - record() unexpected metrics %+v", p.metrics)
+	}
+}
+
+func TestLookupBalances(t *testing.T) {
+	p := providers[providerCryptoID]
+	fetch, interval := p.fetch, p.interval
+	defer func() {
+		p.fetch, p.interval = fetch, interval
+		p.metrics = ProviderMetrics{}
+	}()
+
+	var calls int
+	var m sync.Mutex
+	p.metrics = ProviderMetrics{}
+	p.interval = time.Millisecond
+	p.fetch = func(address string, coinType currency.Code) (balanceResult, error) {
+		m.Lock()
+		calls++
+		m.Unlock()
+		return balanceResult{balance: 1.5}, nil
+	}
+
+	addresses := []string{
+		"LdP8Qox1VAhCzLJNqrr74YovaWYyNBUWvL",
+		"LVa8wZ983PvWtdwXZ8viK6SocMENLCXkEy",
+		"Testy",
+	}
+	var lookups []addressLookup
+	for x := range addresses {
+		lookups = append(lookups, addressLookup{address: addresses[x],
+			coinType: currency.LTC})
+	}
+
+	lookups = lookupBalances(lookups)
+	if lookups[0].err != nil || lookups[0].balance != 1.5 || lookups[1].err != nil {
+		t.Errorf("Test Failed - lookupBalances() unexpected results %+v", lookups)
+	}
+	if lookups[2].err == nil {
+		t.Error("Test Failed - lookupBalances() expected invalid address error")
+	}
+
+	lookupBalances(lookups[:2])
+	if calls != 2 {
+		t.Errorf("Test Failed - lookupBalances() expected cached balances, got %d requests",
+			calls)
+	}
+
+	var base Base
+	if base.applyLookups(lookups) {
+		t.Error("Test Failed - applyLookups() expected failure with an invalid address")
+	}
+	if balance, ok := base.GetAddressBalance(addresses[0], PortfolioAddressPersonal,
+		currency.LTC); !ok || balance != 1.5 {
+		t.Error("Test Failed - applyLookups() expected balance to be updated")
+	}
+
+	for _, metrics := range GetProviderMetrics() {
+		if metrics.Provider == providerCryptoID && metrics.Requests != 2 {
+			t.Errorf("Test Failed - GetProviderMetrics() expected 2 requests, got %d",
+				metrics.Requests)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package portfolio
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	// PortfolioAddressToken is a label for an ERC-20 token balance held at a
+	// watched Ethereum address
+	PortfolioAddressToken = "Token"
+
+	// ethereumMainnetChainID is the chain ID of tokens accepted from a remote
+	// token list
+	ethereumMainnetChainID = 1
+	maxTokenDecimals       = 36
+)
+
+// Token holds an ERC-20 token contract and the number of decimals its balances
+// are scaled by
+type Token struct {
+	Symbol   string `json:"symbol"`
+	Contract string `json:"contract"`
+	Decimals int    `json:"decimals"`
+}
+
+// tokenList holds a remote token list in the common token list format
+type tokenList struct {
+	Tokens []struct {
+		ChainID  int    `json:"chainId"`
+		Address  string `json:"address"`
+		Symbol   string `json:"symbol"`
+		Decimals int    `json:"decimals"`
+	} `json:"tokens"`
+}
+
+// tokenBalance holds the scaled balance of a registered token
+type tokenBalance struct {
+	coinType currency.Code
+	balance  float64
+}
+
+// tokenRegistry holds the registered tokens by lower case contract address.
+// Configured tokens take precedence over tokens from a remote list
+var tokenRegistry = struct {
+	configured map[string]Token
+	remote     map[string]Token
+	m          sync.RWMutex
+}{
+	configured: make(map[string]Token),
+	remote:     make(map[string]Token),
+}
+
+// Validate checks a token is usable, normalising its symbol and contract
+func (t *Token) Validate() error {
+	if t.Symbol == "" {
+		return errors.New("token symbol not set")
+	}
+	if len(t.Contract) != 42 || t.Contract[:2] != "0x" {
+		return fmt.Errorf("token %s invalid contract address %s", t.Symbol,
+			t.Contract)
+	}
+	for _, c := range t.Contract[2:] {
+		if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'f') &&
+			!(c >= 'A' && c <= 'F') {
+			return fmt.Errorf("token %s invalid contract address %s", t.Symbol,
+				t.Contract)
+		}
+	}
+	if t.Decimals < 0 || t.Decimals > maxTokenDecimals {
+		return fmt.Errorf("token %s invalid decimals %d", t.Symbol, t.Decimals)
+	}
+	t.Symbol = common.StringToUpper(t.Symbol)
+	t.Contract = common.StringToLower(t.Contract)
+	return nil
+}
+
+// SetTokens replaces the configured tokens of the registry
+func SetTokens(tokens []Token) {
+	configured := make(map[string]Token)
+	for x := range tokens {
+		t := tokens[x]
+		if err := t.Validate(); err != nil {
+			log.Warnf("Token registry: %s, skipping", err)
+			continue
+		}
+		configured[t.Contract] = t
+	}
+
+	tokenRegistry.m.Lock()
+	tokenRegistry.configured = configured
+	tokenRegistry.m.Unlock()
+}
+
+// GetTokens returns the registered tokens
+func GetTokens() []Token {
+	tokenRegistry.m.RLock()
+	defer tokenRegistry.m.RUnlock()
+	var resp []Token
+	for contract, t := range tokenRegistry.remote {
+		if _, ok := tokenRegistry.configured[contract]; !ok {
+			resp = append(resp, t)
+		}
+	}
+	for _, t := range tokenRegistry.configured {
+		resp = append(resp, t)
+	}
+	return resp
+}
+
+// getToken returns the registered token of a contract address
+func getToken(contract string) (Token, bool) {
+	contract = common.StringToLower(contract)
+	tokenRegistry.m.RLock()
+	defer tokenRegistry.m.RUnlock()
+	if t, ok := tokenRegistry.configured[contract]; ok {
+		return t, true
+	}
+	t, ok := tokenRegistry.remote[contract]
+	return t, ok
+}
+
+// UpdateTokenRegistry replaces the remote tokens of the registry with the
+// Ethereum mainnet tokens of a token list
+func UpdateTokenRegistry(url string) error {
+	var list tokenList
+	err := common.SendHTTPGetRequest(url, true, false, &list)
+	if err != nil {
+		return err
+	}
+
+	remote := make(map[string]Token)
+	for x := range list.Tokens {
+		if list.Tokens[x].ChainID != ethereumMainnetChainID {
+			continue
+		}
+		t := Token{
+			Symbol:   list.Tokens[x].Symbol,
+			Contract: list.Tokens[x].Address,
+			Decimals: list.Tokens[x].Decimals,
+		}
+		if t.Validate() != nil {
+			continue
+		}
+		remote[t.Contract] = t
+	}
+
+	if len(remote) == 0 {
+		return fmt.Errorf("token list %s has no valid Ethereum tokens", url)
+	}
+
+	tokenRegistry.m.Lock()
+	tokenRegistry.remote = remote
+	tokenRegistry.m.Unlock()
+	return nil
+}
+
+// StartTokenRegistryUpdater refreshes the token registry from a remote token
+// list every interval
+func StartTokenRegistryUpdater(url string, interval time.Duration) {
+	log.Debugf("Token registry updater started: %s\n", url)
+	for {
+		err := UpdateTokenRegistry(url)
+		if err != nil {
+			log.Errorf("Token registry: Unable to update from %s: %s", url, err)
+		} else {
+			log.Debugf("Token registry: Updated from %s\n", url)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// getTokenBalances returns the scaled balances of the registered tokens held
+// at an address, tokens missing from the registry are ignored
+func getTokenBalances(result *EthplorerResponse) []tokenBalance {
+	var resp []tokenBalance
+	for x := range result.Tokens {
+		t, ok := getToken(result.Tokens[x].TokenInfo.Address)
+		if !ok {
+			continue
+		}
+		resp = append(resp, tokenBalance{
+			coinType: currency.NewCode(t.Symbol),
+			balance:  result.Tokens[x].Balance / math.Pow10(t.Decimals),
+		})
+	}
+	return resp
+}
+
+// updateTokenBalances sets the token balances held at an address, removing
+// tokens it no longer holds
+func (p *Base) updateTokenBalances(address string, tokens []tokenBalance) {
+	held := make(map[currency.Code]bool)
+	for x := range tokens {
+		if tokens[x].balance <= 0 {
+			continue
+		}
+		held[tokens[x].coinType.Upper()] = true
+		p.setAddressBalance(address, PortfolioAddressToken, tokens[x].coinType,
+			tokens[x].balance)
+	}
+
+	for x := len(p.Addresses) - 1; x >= 0; x-- {
+		if p.Addresses[x].Address == address &&
+			p.Addresses[x].Description == PortfolioAddressToken &&
+			!held[p.Addresses[x].CoinType.Upper()] {
+			p.Addresses = append(p.Addresses[:x], p.Addresses[x+1:]...)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+const (
+	testUSDCContract = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	testDAIContract  = "0x6b175474e89094c44da98b954eedeac495271d0f"
+)
+
+func TestTokenValidate(t *testing.T) {
+	tok := Token{Symbol: "usdc", Contract: testUSDCContract, Decimals: 6}
+	if err := tok.Validate(); err != nil {
+		t.Errorf("Test Failed - Validate() error %s", err)
+	}
+	if tok.Symbol != "USDC" || tok.Contract != "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48" {
+		t.Errorf("Test Failed - Validate() expected normalised token, got %+v", tok)
+	}
+
+	invalid := []Token{
+		{Contract: testUSDCContract},
+		{Symbol: "USDC", Contract: "0xZZb86991c6218b36c1d19D4a2e9Eb0cE3606eB48"},
+		{Symbol: "USDC", Contract: testUSDCContract[:40]},
+		{Symbol: "USDC", Contract: testUSDCContract, Decimals: 40},
+	}
+	for x := range invalid {
+		if err := invalid[x].Validate(); err == nil {
+			t.Errorf("Test Failed - Validate() expected error for %+v", invalid[x])
+		}
+	}
+}
+
+func TestTokenRegistry(t *testing.T) {
+	defer func() {
+		SetTokens(nil)
+		tokenRegistry.m.Lock()
+		tokenRegistry.remote = make(map[string]Token)
+		tokenRegistry.m.Unlock()
+	}()
+
+	tokenRegistry.m.Lock()
+	tokenRegistry.remote = map[string]Token{
+		"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48": {Symbol: "USDC",
+			Contract: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", Decimals: 18},
+		testDAIContract: {Symbol: "DAI", Contract: testDAIContract, Decimals: 18},
+	}
+	tokenRegistry.m.Unlock()
+	SetTokens([]Token{
+		{Symbol: "USDC", Contract: testUSDCContract, Decimals: 6},
+		{Symbol: "BAD", Contract: "0x1234"},
+	})
+
+	tok, ok := getToken(testUSDCContract)
+	if !ok || tok.Decimals != 6 {
+		t.Errorf("Test Failed - getToken() expected configured token to take precedence, got %+v",
+			tok)
+	}
+	if len(GetTokens()) != 2 {
+		t.Errorf("Test Failed - GetTokens() expected 2 tokens, got %d",
+			len(GetTokens()))
+	}
+
+	var result EthplorerResponse
+	result.Tokens = make([]EthplorerTokenBalance, 3)
+	result.Tokens[0].TokenInfo.Address = testUSDCContract
+	result.Tokens[0].Balance = 2500000
+	result.Tokens[1].TokenInfo.Address = testDAIContract
+	result.Tokens[1].Balance = 1.5e18
+	result.Tokens[2].TokenInfo.Address = "0x0000000000000000000000000000000000000001"
+	result.Tokens[2].Balance = 100
+
+	balances := getTokenBalances(&result)
+	if len(balances) != 2 {
+		t.Fatalf("Test Failed - getTokenBalances() expected unregistered token to be ignored, got %d",
+			len(balances))
+	}
+	if !balances[0].coinType.Match(currency.NewCode("USDC")) || balances[0].balance != 2.5 ||
+		balances[1].balance != 1.5 {
+		t.Errorf("Test Failed - getTokenBalances() expected scaled balances, got %+v",
+			balances)
+	}
+}
+
+func TestApplyTokenLookups(t *testing.T) {
+	address := "0xb794f5ea0ba39494ce839613fffba74279579268"
+	usdc := currency.NewCode("USDC")
+	dai := currency.NewCode("DAI")
+
+	var base Base
+	base.applyLookups([]addressLookup{{
+		address:  address,
+		coinType: currency.ETH,
+		balance:  2,
+		tokens: []tokenBalance{
+			{coinType: usdc, balance: 100},
+			{coinType: dai, balance: 50},
+		},
+	}})
+
+	if balance, ok := base.GetAddressBalance(address, PortfolioAddressToken,
+		usdc); !ok || balance != 100 {
+		t.Error("Test Failed - applyLookups() expected token balance to be added")
+	}
+	if len(base.GetPortfolioGroupedCoin()) != 1 {
+		t.Error("Test Failed - GetPortfolioGroupedCoin() expected token balances to be excluded")
+	}
+	if base.GetPersonalPortfolio()[dai] != 50 {
+		t.Error("Test Failed - GetPersonalPortfolio() expected token balances to be included")
+	}
+
+	base.applyLookups([]addressLookup{{
+		address:  address,
+		coinType: currency.ETH,
+		balance:  3,
+		tokens:   []tokenBalance{{coinType: usdc, balance: 75}},
+	}})
+
+	if balance, _ := base.GetAddressBalance(address, PortfolioAddressPersonal,
+		currency.ETH); balance != 3 {
+		t.Errorf("Test Failed - applyLookups() expected ETH balance of 3, got %f",
+			balance)
+	}
+	if balance, _ := base.GetAddressBalance(address, PortfolioAddressToken,
+		usdc); balance != 75 {
+		t.Errorf("Test Failed - applyLookups() expected USDC balance of 75, got %f",
+			balance)
+	}
+	if _, ok := base.GetAddressBalance(address, PortfolioAddressToken, dai); ok {
+		t.Error("Test Failed - applyLookups() expected token no longer held to be removed")
+	}
+}
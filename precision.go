@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// normaliseOrderAmounts removes float rounding errors from an order amount and
+// price before they are sent to an exchange. Exchanges which report their
+// order limits also have the amount rounded down to their lot size and the
+// price to their tick size. An error is returned when the amount is smaller
+// than a single lot
+func normaliseOrderAmounts(exch exchange.IBotExchange, p currency.Pair, amount, price float64) (normalisedAmount, normalisedPrice float64, err error) {
+	var limits exchange.OrderLimits
+	if limiter, ok := exch.(exchange.IOrderLimitsExchange); ok {
+		limits, err = limiter.GetOrderLimits(p)
+		if err != nil {
+			log.Debugf("%s failed to get %s order limits, only removing rounding errors. Error: %s",
+				exch.GetName(), p, err)
+			limits = exchange.OrderLimits{}
+		}
+	}
+
+	normalisedAmount, normalisedPrice = limits.Normalise(amount, price)
+	if amount > 0 && normalisedAmount <= 0 {
+		return 0, 0, fmt.Errorf("%s %s order amount %v is below the lot size of %v",
+			exch.GetName(), p, amount, limits.AmountStep)
+	}
+	return normalisedAmount, normalisedPrice, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type precisionTestExchange struct {
+	accountInfoTestExchange
+	limitsErr error
+}
+
+func (p *precisionTestExchange) GetOrderLimits(pair currency.Pair) (exchange.OrderLimits, error) {
+	return exchange.OrderLimits{AmountStep: 0.001, PriceStep: 0.5}, p.limitsErr
+}
+
+func TestNormaliseOrderAmounts(t *testing.T) {
+	p := currency.NewPairFromStrings("BTC", "USD")
+	exch := &precisionTestExchange{
+		accountInfoTestExchange: accountInfoTestExchange{name: "PrecisionTest"},
+	}
+
+	amount, price, err := normaliseOrderAmounts(exch, p, 0.1+0.2+0.0009, 8000.3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount != 0.3 || price != 8000.5 {
+		t.Errorf("Test failed. Expected 0.3 at 8000.5, got %v at %v", amount, price)
+	}
+
+	_, _, err = normaliseOrderAmounts(exch, p, 0.0005, 8000)
+	if err == nil {
+		t.Error("Test failed. Expected amount below the lot size to be rejected")
+	}
+
+	exch.limitsErr = errors.New("limits unavailable")
+	amount, price, err = normaliseOrderAmounts(exch, p, 0.1+0.2, 8000.3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount != 0.3 || price != 8000.3 {
+		t.Errorf("Test failed. Expected 0.3 at 8000.3, got %v at %v", amount, price)
+	}
+
+	amount, _, err = normaliseOrderAmounts(&accountInfoTestExchange{name: "NoLimits"},
+		p, 0.1+0.2, 0)
+	if err != nil || amount != 0.3 {
+		t.Errorf("Test failed. Expected 0.3 without order limits, got %v %v",
+			amount, err)
+	}
+}
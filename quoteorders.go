@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+// getQuoteOrderAmount converts the quote currency amount to spend on a buy or
+// receive from a sell into a base amount normalised to the exchanges
+// precision. Limit orders are converted at their price and market orders by
+// filling the local orderbook, falling back to the ticker when no orderbook is
+// held. Amounts are rounded down so the quote amount isn't exceeded
+func getQuoteOrderAmount(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, quoteAmount, price float64) (float64, error) {
+	if quoteAmount <= 0 {
+		return 0, errors.New("quote amount must be greater than zero")
+	}
+
+	var amount float64
+	if price > 0 {
+		amount = quoteAmount / price
+	} else if ob, err := orderbook.Get(exch.GetName(), p, orderbook.Spot); err == nil {
+		amount, err = fillQuoteAmount(getFillLevels(&ob, side), quoteAmount)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		fillPrice := getExpectedFillPrice(exch, p, side, 0)
+		if fillPrice <= 0 {
+			return 0, fmt.Errorf("unable to convert %s %s quote amount, no orderbook or ticker held",
+				exch.GetName(), p)
+		}
+		amount = quoteAmount / fillPrice
+	}
+
+	amount, _, err := normaliseOrderAmounts(exch, p, amount, price)
+	return amount, err
+}
+
+// fillQuoteAmount returns the base amount filled spending or receiving a quote
+// amount against orderbook levels sorted from best to worst price
+func fillQuoteAmount(levels []orderbook.Item, quoteAmount float64) (float64, error) {
+	if len(levels) == 0 {
+		return 0, errors.New("orderbook has no liquidity")
+	}
+
+	remaining := quoteAmount
+	var amount float64
+	for x := range levels {
+		if levels[x].Amount <= 0 || levels[x].Price <= 0 {
+			continue
+		}
+		if remaining <= levels[x].Amount*levels[x].Price {
+			return amount + remaining/levels[x].Price, nil
+		}
+		amount += levels[x].Amount
+		remaining -= levels[x].Amount * levels[x].Price
+	}
+	return 0, fmt.Errorf("insufficient orderbook depth, %f of %f quote amount unfilled",
+		remaining, quoteAmount)
+}
+
+// SubmitQuoteOrderRequest submits an order sized by the quote currency amount
+// to spend on a buy or receive from a sell. Quote amount orders are neither
+// funded nor queued
+func SubmitQuoteOrderRequest(exchName, currencyPair, side, orderType string, quoteAmount, price float64, tags OrderTags) (exchange.SubmitOrderResponse, error) {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return exchange.SubmitOrderResponse{}, errors.New(exchange.ErrExchangeNotFound)
+	}
+
+	s, t, err := parseOrderRequest(side, orderType, quoteAmount)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+
+	return SubmitTaggedQuoteOrder(exch, currency.NewPairFromString(currencyPair),
+		s, t, quoteAmount, price, "", tags)
+}
+
+// SubmitTaggedQuoteOrder submits an order sized by the quote currency amount
+// to spend on a buy or receive from a sell and records it with its tags. Market
+// orders use the exchanges native quote amount orders when supported and are
+// expected to fill at the average price of the converted amount, other orders
+// have the quote amount converted into a base amount first
+func SubmitTaggedQuoteOrder(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, quoteAmount, price float64, clientID string, tags OrderTags) (exchange.SubmitOrderResponse, error) {
+	amount, err := getQuoteOrderAmount(exch, p, side, quoteAmount, price)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+
+	_, native := exch.(exchange.IQuoteAmountOrderExchange)
+	if !native || orderType != exchange.MarketOrderType {
+		return SubmitTaggedOrder(exch, p, side, orderType, amount, price, clientID, tags)
+	}
+
+	resp, err := SubmitExchangeQuoteOrder(exch, p, side, quoteAmount, amount, clientID)
+	if err != nil {
+		return resp, err
+	}
+
+	orderTags.Add(TaggedOrder{
+		Exchange:      exch.GetName(),
+		OrderID:       resp.OrderID,
+		ClientID:      clientID,
+		Pair:          p.String(),
+		Side:          string(side),
+		OrderType:     string(orderType),
+		Amount:        amount,
+		ExpectedPrice: quoteAmount / amount,
+		Submitted:     clock.Now(),
+		Tags:          tags,
+	})
+	return resp, nil
+}
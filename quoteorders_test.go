@@ -0,0 +1,144 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+type quoteOrderTestExchange struct {
+	fundingTestExchange
+	quoteAmounts []float64
+}
+
+func (q *quoteOrderTestExchange) SubmitQuoteAmountOrder(p currency.Pair, side exchange.OrderSide, quoteAmount float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	q.quoteAmounts = append(q.quoteAmounts, quoteAmount)
+	return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "2"}, nil
+}
+
+func TestFillQuoteAmount(t *testing.T) {
+	levels := []orderbook.Item{{Price: 100, Amount: 1}, {Price: 102, Amount: 2}}
+	amount, err := fillQuoteAmount(levels, 50)
+	if err != nil || amount != 0.5 {
+		t.Errorf("Test failed. Expected 0.5 filled from the best level, got %v %v",
+			amount, err)
+	}
+
+	amount, err = fillQuoteAmount(levels, 304)
+	if err != nil || amount != 3 {
+		t.Errorf("Test failed. Expected 3 filled across both levels, got %v %v",
+			amount, err)
+	}
+
+	_, err = fillQuoteAmount(levels, 400)
+	if err == nil {
+		t.Error("Test failed. Expected insufficient depth to be rejected")
+	}
+
+	_, err = fillQuoteAmount(nil, 1)
+	if err == nil {
+		t.Error("Test failed. Expected an empty orderbook to be rejected")
+	}
+}
+
+func TestGetQuoteOrderAmount(t *testing.T) {
+	exch := &tradeSimulationTestExchange{}
+	p := currency.NewPairFromStrings("BTC", "USD")
+	ob := orderbook.Base{
+		Pair:         p,
+		ExchangeName: exch.GetName(),
+		AssetType:    orderbook.Spot,
+		Bids:         []orderbook.Item{{Price: 98, Amount: 1}, {Price: 99, Amount: 1}},
+		Asks:         []orderbook.Item{{Price: 102, Amount: 2}, {Price: 100, Amount: 1}},
+	}
+	err := ob.Process()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	amount, err := getQuoteOrderAmount(exch, p, exchange.BuyOrderSide, 100, 50)
+	if err != nil || amount != 2 {
+		t.Errorf("Test failed. Expected a limit order converted at its price, got %v %v",
+			amount, err)
+	}
+
+	amount, err = getQuoteOrderAmount(exch, p, exchange.BuyOrderSide, 304, 0)
+	if err != nil || amount != 3 {
+		t.Errorf("Test failed. Expected a buy converted against the asks, got %v %v",
+			amount, err)
+	}
+
+	amount, err = getQuoteOrderAmount(exch, p, exchange.SellOrderSide, 49.5, 0)
+	if err != nil || amount != 0.5 {
+		t.Errorf("Test failed. Expected a sell converted against the bids, got %v %v",
+			amount, err)
+	}
+
+	_, err = getQuoteOrderAmount(exch, currency.NewPairFromStrings("XRP", "USD"),
+		exchange.BuyOrderSide, 100, 0)
+	if err == nil {
+		t.Error("Test failed. Expected a pair without an orderbook or ticker to be rejected")
+	}
+
+	_, err = getQuoteOrderAmount(exch, p, exchange.BuyOrderSide, 0, 50)
+	if err == nil {
+		t.Error("Test failed. Expected a zero quote amount to be rejected")
+	}
+}
+
+func TestSubmitTaggedQuoteOrder(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+
+	orderTags.Restore(nil)
+	defer orderTags.Restore(nil)
+	exch := &quoteOrderTestExchange{
+		fundingTestExchange: fundingTestExchange{
+			accountInfoTestExchange: *setupAccountInfoTest(t, time.Minute),
+		},
+	}
+	p := currency.NewPairFromStrings("LTC", "USD")
+	ob := orderbook.Base{
+		Pair:         p,
+		ExchangeName: exch.GetName(),
+		AssetType:    orderbook.Spot,
+		Bids:         []orderbook.Item{{Price: 49, Amount: 10}},
+		Asks:         []orderbook.Item{{Price: 50, Amount: 10}},
+	}
+	err := ob.Process()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := SubmitTaggedQuoteOrder(exch, p, exchange.BuyOrderSide,
+		exchange.MarketOrderType, 100, 0, "", OrderTags{OrderTagReason: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.OrderID != "2" || len(exch.quoteAmounts) != 1 || exch.quoteAmounts[0] != 100 {
+		t.Errorf("Test failed. Expected a native quote amount order, got %+v %v",
+			resp, exch.quoteAmounts)
+	}
+	order, ok := orderTags.Get(exch.GetName(), "2")
+	if !ok || order.Amount != 2 || order.ExpectedPrice != 50 {
+		t.Errorf("Test failed. Expected the order recorded with its estimated amount, got %+v",
+			order)
+	}
+
+	_, err = SubmitTaggedQuoteOrder(exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 100, 40, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exch.orders) != 1 || len(exch.quoteAmounts) != 1 {
+		t.Error("Test failed. Expected a limit order submitted by its base amount")
+	}
+	if order, ok = orderTags.Get(exch.GetName(), "1"); !ok || order.Amount != 2.5 {
+		t.Errorf("Test failed. Expected the limit order amount converted at its price, got %+v",
+			order)
+	}
+}
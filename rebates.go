@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const rebateSyncInterval = time.Hour
+
+// RebateTotal is the cumulative amount of a rebate type credited in a currency
+// on an exchange since the bot started tracking it
+type RebateTotal struct {
+	Exchange     string        `json:"exchange"`
+	Currency     currency.Code `json:"currency"`
+	Type         string        `json:"type"`
+	Amount       float64       `json:"amount"`
+	Count        int           `json:"count"`
+	LastCredited time.Time     `json:"lastCredited"`
+}
+
+// rebateTracker holds the cumulative rebate totals of each exchange and the
+// rebates counted in them, keyed by lower case exchange name
+type rebateTracker struct {
+	totals  map[string][]RebateTotal
+	counted map[string]map[string]bool
+	synced  map[string]time.Time
+	m       sync.Mutex
+}
+
+var rebates = rebateTracker{
+	totals:  make(map[string][]RebateTotal),
+	counted: make(map[string]map[string]bool),
+	synced:  make(map[string]time.Time),
+}
+
+// Add adds the rebates of an exchange not yet counted to its totals and
+// returns how many were added
+func (r *rebateTracker) Add(exchName string, credited []exchange.Rebate) int {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	key := common.StringToLower(exchName)
+	if r.counted[key] == nil {
+		r.counted[key] = make(map[string]bool)
+	}
+
+	var added int
+	for x := range credited {
+		if credited[x].ID == "" || r.counted[key][credited[x].ID] {
+			continue
+		}
+		r.counted[key][credited[x].ID] = true
+		added++
+
+		totals := r.totals[key]
+		found := false
+		for y := range totals {
+			if totals[y].Type != credited[x].Type ||
+				!totals[y].Currency.Match(credited[x].Currency) {
+				continue
+			}
+			totals[y].Amount += credited[x].Amount
+			totals[y].Count++
+			if credited[x].Timestamp.After(totals[y].LastCredited) {
+				totals[y].LastCredited = credited[x].Timestamp
+			}
+			found = true
+			break
+		}
+		if !found {
+			r.totals[key] = append(totals, RebateTotal{
+				Exchange:     exchName,
+				Currency:     credited[x].Currency.Upper(),
+				Type:         credited[x].Type,
+				Amount:       credited[x].Amount,
+				Count:        1,
+				LastCredited: credited[x].Timestamp,
+			})
+		}
+	}
+	return added
+}
+
+// Synced records when an exchanges rebates were last fetched and returns the
+// previous time, which is zero when they haven't been fetched
+func (r *rebateTracker) Synced(exchName string, now time.Time) time.Time {
+	r.m.Lock()
+	defer r.m.Unlock()
+	key := common.StringToLower(exchName)
+	previous := r.synced[key]
+	r.synced[key] = now
+	return previous
+}
+
+// Get returns the rebate totals of an exchange, or of every exchange when
+// exchName is empty, ordered by exchange, currency then type
+func (r *rebateTracker) Get(exchName string) []RebateTotal {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	var resp []RebateTotal
+	for key, totals := range r.totals {
+		if exchName != "" && key != common.StringToLower(exchName) {
+			continue
+		}
+		resp = append(resp, totals...)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].Exchange != resp[j].Exchange {
+			return resp[i].Exchange < resp[j].Exchange
+		}
+		if !resp[i].Currency.Match(resp[j].Currency) {
+			return resp[i].Currency.String() < resp[j].Currency.String()
+		}
+		return resp[i].Type < resp[j].Type
+	})
+	return resp
+}
+
+// RebateRoutine periodically fetches the rebates credited on exchanges with
+// authenticated API support and adds them to the cumulative totals
+func RebateRoutine() {
+	log.Debugln("Starting rebate routine.")
+	for {
+		exchanges := GetExchanges()
+		for x := range exchanges {
+			if exchanges[x] == nil || !exchanges[x].IsEnabled() ||
+				!exchanges[x].GetAuthenticatedAPISupport() {
+				continue
+			}
+			err := syncRebates(exchanges[x], clock.Now())
+			if err != nil {
+				log.Debugf("%s failed to get rebates. Error: %s",
+					exchanges[x].GetName(), err)
+			}
+		}
+		clock.Sleep(rebateSyncInterval)
+	}
+}
+
+// syncRebates fetches the rebates credited on an exchange since its last sync
+// and adds them to the totals. The first sync fetches every rebate reported.
+// Exchanges which don't report rebates are ignored
+func syncRebates(exch exchange.IBotExchange, now time.Time) error {
+	reporter, ok := exch.(exchange.IRebateExchange)
+	if !ok {
+		return nil
+	}
+
+	since := rebates.Synced(exch.GetName(), now)
+	credited, err := reporter.GetRebates(since)
+	if err != nil {
+		rebates.Synced(exch.GetName(), since)
+		return err
+	}
+	if added := rebates.Add(exch.GetName(), credited); added > 0 {
+		log.Debugf("%s credited %d new rebates", exch.GetName(), added)
+	}
+	return nil
+}
+
+// GetRebateTotals returns the cumulative rebate totals of an exchange, or of
+// every exchange when exchName is empty
+func GetRebateTotals(exchName string) []RebateTotal {
+	return rebates.Get(exchName)
+}
+
+// getReportedRebates returns the rebates credited within a period by currency
+// on the exchanges which report them. Reported rebates are also added to the
+// cumulative totals
+func getReportedRebates(exchanges []exchange.IBotExchange, from, to time.Time) (map[currency.Code]float64, []string) {
+	earned := make(map[currency.Code]float64)
+	var errs []string
+	for _, exch := range exchanges {
+		if exch == nil || !exch.IsEnabled() || !exch.GetAuthenticatedAPISupport() {
+			continue
+		}
+		reporter, ok := exch.(exchange.IRebateExchange)
+		if !ok {
+			continue
+		}
+
+		credited, err := reporter.GetRebates(from)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s rebates: %s", exch.GetName(), err))
+			continue
+		}
+		rebates.Add(exch.GetName(), credited)
+		for x := range credited {
+			if credited[x].Timestamp.Before(from) || credited[x].Timestamp.After(to) {
+				continue
+			}
+			earned[credited[x].Currency.Upper()] += credited[x].Amount
+		}
+	}
+	return earned, errs
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type rebateTestExchange struct {
+	accountInfoTestExchange
+	credited []exchange.Rebate
+	since    []time.Time
+	err      error
+}
+
+func (r *rebateTestExchange) IsEnabled() bool {
+	return true
+}
+
+func (r *rebateTestExchange) GetAuthenticatedAPISupport() bool {
+	return true
+}
+
+func (r *rebateTestExchange) GetRebates(since time.Time) ([]exchange.Rebate, error) {
+	r.since = append(r.since, since)
+	return r.credited, r.err
+}
+
+func TestRebateTracker(t *testing.T) {
+	tracker := rebateTracker{
+		totals:  make(map[string][]RebateTotal),
+		counted: make(map[string]map[string]bool),
+		synced:  make(map[string]time.Time),
+	}
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	credited := []exchange.Rebate{
+		{ID: "1", Type: exchange.RebateTypeReferral, Currency: currency.BTC, Amount: 0.1, Timestamp: now},
+		{ID: "2", Type: exchange.RebateTypeReferral, Currency: currency.NewCode("btc"), Amount: 0.2, Timestamp: now.Add(time.Hour)},
+		{ID: "3", Type: exchange.RebateTypeMaker, Currency: currency.BTC, Amount: 0.05, Timestamp: now},
+		{Type: exchange.RebateTypeMaker, Currency: currency.BTC, Amount: 1},
+	}
+
+	if added := tracker.Add("Bitmex", credited); added != 3 {
+		t.Errorf("Test failed. Expected 3 rebates added, got %d", added)
+	}
+	if added := tracker.Add("bitmex", credited[:2]); added != 0 {
+		t.Errorf("Test failed. Expected counted rebates to be skipped, got %d added", added)
+	}
+	tracker.Add("Binance", []exchange.Rebate{
+		{ID: "1", Type: exchange.RebateTypeMaker, Currency: currency.BNB, Amount: 1},
+	})
+
+	totals := tracker.Get("BITMEX")
+	if len(totals) != 2 || totals[0].Type != exchange.RebateTypeMaker ||
+		totals[1].Type != exchange.RebateTypeReferral {
+		t.Fatalf("Test failed. Unexpected totals %+v", totals)
+	}
+	if totals[1].Count != 2 || totals[1].Amount < 0.3-1e-9 || totals[1].Amount > 0.3+1e-9 ||
+		!totals[1].LastCredited.Equal(now.Add(time.Hour)) {
+		t.Errorf("Test failed. Unexpected referral total %+v", totals[1])
+	}
+	if all := tracker.Get(""); len(all) != 3 || all[0].Exchange != "Binance" {
+		t.Errorf("Test failed. Expected the totals of every exchange, got %+v", all)
+	}
+}
+
+func TestSyncRebates(t *testing.T) {
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	exch := &rebateTestExchange{
+		accountInfoTestExchange: accountInfoTestExchange{name: "RebateSyncTest"},
+		credited: []exchange.Rebate{
+			{ID: "a", Type: exchange.RebateTypeReferral, Currency: currency.BTC, Amount: 0.5, Timestamp: now},
+		},
+	}
+
+	err := syncRebates(exch, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch.err = errors.New("unavailable")
+	err = syncRebates(exch, now.Add(time.Hour))
+	if err == nil {
+		t.Error("Test failed. Expected the rebate error to be returned")
+	}
+	exch.err = nil
+	err = syncRebates(exch, now.Add(time.Hour*2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(exch.since) != 3 || !exch.since[0].IsZero() || !exch.since[1].Equal(now) ||
+		!exch.since[2].Equal(now) {
+		t.Errorf("Test failed. Unexpected sync times %v", exch.since)
+	}
+	totals := GetRebateTotals("RebateSyncTest")
+	if len(totals) != 1 || totals[0].Amount != 0.5 || totals[0].Count != 1 {
+		t.Errorf("Test failed. Unexpected totals %+v", totals)
+	}
+}
+
+func TestGetReportedRebates(t *testing.T) {
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	exchanges := []exchange.IBotExchange{
+		&rebateTestExchange{
+			accountInfoTestExchange: accountInfoTestExchange{name: "RebateReportTest"},
+			credited: []exchange.Rebate{
+				{ID: "1", Type: exchange.RebateTypeReferral, Currency: currency.BTC, Amount: 0.1, Timestamp: now.Add(-time.Hour)},
+				{ID: "2", Type: exchange.RebateTypeReferral, Currency: currency.BTC, Amount: 0.2, Timestamp: now.Add(time.Hour)},
+			},
+		},
+		&rebateTestExchange{
+			accountInfoTestExchange: accountInfoTestExchange{name: "RebateReportErrorTest"},
+			err:                     errors.New("unavailable"),
+		},
+	}
+
+	earned, errs := getReportedRebates(exchanges, now.Add(-time.Hour*2), now)
+	if len(earned) != 1 || earned[currency.BTC] != 0.1 {
+		t.Errorf("Test failed. Expected the rebates within the period, got %v", earned)
+	}
+	if len(errs) != 1 {
+		t.Errorf("Test failed. Expected one error, got %v", errs)
+	}
+}
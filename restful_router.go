@@ -1,3 +1,6 @@
+//go:build !noweb
+// +build !noweb
+
 package main
 
 import (
@@ -41,7 +44,310 @@ type Route struct {
 // Routes is an array of all the registered routes
 type Routes []Route
 
-var routes = Routes{}
+// routes holds the routes served by every build, optional features add
+// theirs with registerRoutes
+var routes = Routes{
+	Route{
+		"",
+		http.MethodGet,
+		"/",
+		getIndex,
+	},
+	Route{
+		"GetAllSettings",
+		http.MethodGet,
+		"/config/all",
+		RESTGetAllSettings,
+	},
+	Route{
+		"SaveAllSettings",
+		http.MethodPost,
+		"/config/all/save",
+		RESTSaveAllSettings,
+	},
+	Route{
+		"AllEnabledAccountInfo",
+		http.MethodGet,
+		"/exchanges/enabled/accounts/all",
+		RESTGetAllEnabledAccountInfo,
+	},
+	Route{
+		"BalanceSnapshot",
+		http.MethodGet,
+		"/exchanges/enabled/accounts/snapshot",
+		RESTGetBalanceSnapshot,
+	},
+	Route{
+		"AllActiveExchangesAndCurrencies",
+		http.MethodGet,
+		"/exchanges/enabled/latest/all",
+		RESTGetAllActiveTickers,
+	},
+	Route{
+		"IndividualExchangeAndCurrency",
+		http.MethodGet,
+		"/exchanges/{exchangeName}/latest/{currency}",
+		RESTGetTicker,
+	},
+	Route{
+		"GetPortfolio",
+		http.MethodGet,
+		"/portfolio/all",
+		RESTGetPortfolio,
+	},
+	Route{
+		"GetPortfolioExposure",
+		http.MethodGet,
+		"/portfolio/exposure",
+		RESTGetPortfolioExposure,
+	},
+	Route{
+		"AllActiveExchangesAndOrderbooks",
+		http.MethodGet,
+		"/exchanges/orderbook/latest/all",
+		RESTGetAllActiveOrderbooks,
+	},
+	Route{
+		"IndividualExchangeOrderbook",
+		http.MethodGet,
+		"/exchanges/{exchangeName}/orderbook/latest/{currency}",
+		RESTGetOrderbook,
+	},
+	Route{
+		"IndividualExchangeOpenInterest",
+		http.MethodGet,
+		"/exchanges/{exchangeName}/openinterest/{currency}",
+		RESTGetOpenInterest,
+	},
+	Route{
+		"IndividualExchangeMarketMetadata",
+		http.MethodGet,
+		"/exchanges/{exchangeName}/market/{currency}",
+		RESTGetMarketMetadata,
+	},
+	Route{
+		"IndividualExchangeTradingStatus",
+		http.MethodGet,
+		"/exchanges/{exchangeName}/status/{currency}",
+		RESTGetTradingStatus,
+	},
+	Route{
+		"IndividualExchangeCandles",
+		http.MethodGet,
+		"/exchanges/{exchangeName}/candles/{currency}",
+		RESTGetCandles,
+	},
+	Route{
+		"IndividualExchangeHistoricTrades",
+		http.MethodGet,
+		"/exchanges/{exchangeName}/trades/history/{currency}",
+		RESTGetHistoricTrades,
+	},
+	Route{
+		"IndividualExchangeOrderbookHistory",
+		http.MethodGet,
+		"/exchanges/{exchangeName}/orderbook/history/{currency}",
+		RESTGetOrderbookHistory,
+	},
+	Route{
+		"SimulateTrade",
+		http.MethodGet,
+		"/exchanges/{exchangeName}/simulate/{currency}",
+		RESTSimulateTrade,
+	},
+	Route{
+		"RouteOrder",
+		http.MethodGet,
+		"/orders/route/{currency}",
+		RESTRouteOrder,
+	},
+	Route{
+		"DustBalances",
+		http.MethodGet,
+		"/exchanges/{exchangeName}/dust",
+		RESTGetDustBalances,
+	},
+	Route{
+		"ExportTrades",
+		http.MethodPost,
+		"/exchanges/{exchangeName}/trades/export",
+		RESTExportTrades,
+	},
+	Route{
+		"ValidateCredentials",
+		http.MethodPost,
+		"/exchanges/{exchangeName}/validate-credentials",
+		RESTValidateCredentials,
+	},
+	Route{
+		"SetExchangeCredentials",
+		http.MethodPost,
+		"/exchanges/{exchangeName}/credentials",
+		RESTSetExchangeCredentials,
+	},
+	Route{
+		"PlanTransfer",
+		http.MethodGet,
+		"/transfers/plan",
+		RESTPlanTransfer,
+	},
+	Route{
+		"StablecoinParity",
+		http.MethodGet,
+		"/stablecoins",
+		RESTGetStablecoinParity,
+	},
+	Route{
+		"TriangularArbitrage",
+		http.MethodGet,
+		"/arbitrage/triangular",
+		RESTGetTriangularArbitrage,
+	},
+	Route{
+		"ScheduledTasks",
+		http.MethodGet,
+		"/scheduler/tasks",
+		RESTGetScheduledTasks,
+	},
+	Route{
+		"CircuitBreaker",
+		http.MethodGet,
+		"/circuitbreaker",
+		RESTGetCircuitBreakerTrips,
+	},
+	Route{
+		"PairAnalytics",
+		http.MethodGet,
+		"/analytics/pairs",
+		RESTGetPairAnalytics,
+	},
+	Route{
+		"ExecutionQuality",
+		http.MethodGet,
+		"/orders/execution",
+		RESTGetExecutionQuality,
+	},
+	Route{
+		"TaggedOrders",
+		http.MethodGet,
+		"/orders/tagged",
+		RESTGetTaggedOrders,
+	},
+	Route{
+		"ManagedOrders",
+		http.MethodGet,
+		"/orders/managed",
+		RESTGetManagedOrders,
+	},
+	Route{
+		"ManagedOrder",
+		http.MethodGet,
+		"/orders/managed/{id}",
+		RESTGetManagedOrder,
+	},
+	Route{
+		"StoredTrades",
+		http.MethodGet,
+		"/history/trades",
+		RESTGetStoredTrades,
+	},
+	Route{
+		"StoredOrders",
+		http.MethodGet,
+		"/history/orders",
+		RESTGetStoredOrders,
+	},
+	Route{
+		"StoredBalances",
+		http.MethodGet,
+		"/history/balances",
+		RESTGetStoredBalances,
+	},
+	Route{
+		"StrategyAllocations",
+		http.MethodGet,
+		"/strategies/allocations",
+		RESTGetStrategyAllocations,
+	},
+	Route{
+		"StrategyFeeds",
+		http.MethodGet,
+		"/strategies/feeds",
+		RESTGetStrategyFeeds,
+	},
+	Route{
+		"MarketMaker",
+		http.MethodGet,
+		"/strategies/marketmaker",
+		RESTGetMarketMakerPairs,
+	},
+	Route{
+		"AddressBook",
+		http.MethodGet,
+		"/addressbook",
+		RESTGetAddressBook,
+	},
+	Route{
+		"Rebates",
+		http.MethodGet,
+		"/rebates",
+		RESTGetRebates,
+	},
+	Route{
+		"FuturesExpiries",
+		http.MethodGet,
+		"/futures/expiries",
+		RESTGetFuturesExpiries,
+	},
+	Route{
+		"FuturesRolls",
+		http.MethodGet,
+		"/futures/rolls",
+		RESTGetFuturesRolls,
+	},
+	Route{
+		"FuturesBasis",
+		http.MethodGet,
+		"/futures/basis",
+		RESTGetFuturesBasis,
+	},
+	Route{
+		"CalendarSpreads",
+		http.MethodGet,
+		"/futures/spreads",
+		RESTGetCalendarSpreads,
+	},
+	Route{
+		"SearchMarkets",
+		http.MethodGet,
+		"/markets/search",
+		RESTSearchMarkets,
+	},
+	Route{
+		"Health",
+		http.MethodGet,
+		"/health",
+		RESTGetHealth,
+	},
+	Route{
+		"EndpointLatencies",
+		http.MethodGet,
+		"/endpoints/latency",
+		RESTGetEndpointLatencies,
+	},
+	Route{
+		"ws",
+		http.MethodGet,
+		"/ws",
+		WebsocketClientHandler,
+	},
+}
+
+// registerRoutes adds routes to the router, it is called from the init
+// function of files only included in some builds
+func registerRoutes(r ...Route) {
+	routes = append(routes, r...)
+}
 
 // NewRouter takes in the exchange interfaces and returns a new multiplexor
 // router
@@ -56,69 +362,6 @@ func NewRouter() *mux.Router {
 			strconv.Itoa(common.ExtractPort(bot.config.Webserver.ListenAddress))}, ":")
 	}
 
-	routes = Routes{
-		Route{
-			"",
-			http.MethodGet,
-			"/",
-			getIndex,
-		},
-		Route{
-			"GetAllSettings",
-			http.MethodGet,
-			"/config/all",
-			RESTGetAllSettings,
-		},
-		Route{
-			"SaveAllSettings",
-			http.MethodPost,
-			"/config/all/save",
-			RESTSaveAllSettings,
-		},
-		Route{
-			"AllEnabledAccountInfo",
-			http.MethodGet,
-			"/exchanges/enabled/accounts/all",
-			RESTGetAllEnabledAccountInfo,
-		},
-		Route{
-			"AllActiveExchangesAndCurrencies",
-			http.MethodGet,
-			"/exchanges/enabled/latest/all",
-			RESTGetAllActiveTickers,
-		},
-		Route{
-			"IndividualExchangeAndCurrency",
-			http.MethodGet,
-			"/exchanges/{exchangeName}/latest/{currency}",
-			RESTGetTicker,
-		},
-		Route{
-			"GetPortfolio",
-			http.MethodGet,
-			"/portfolio/all",
-			RESTGetPortfolio,
-		},
-		Route{
-			"AllActiveExchangesAndOrderbooks",
-			http.MethodGet,
-			"/exchanges/orderbook/latest/all",
-			RESTGetAllActiveOrderbooks,
-		},
-		Route{
-			"IndividualExchangeOrderbook",
-			http.MethodGet,
-			"/exchanges/{exchangeName}/orderbook/latest/{currency}",
-			RESTGetOrderbook,
-		},
-		Route{
-			"ws",
-			http.MethodGet,
-			"/ws",
-			WebsocketClientHandler,
-		},
-	}
-
 	for _, route := range routes {
 		router.
 			Methods(route.Method).
@@ -1,15 +1,25 @@
+//go:build !noweb
+// +build !noweb
+
 package main
 
 import (
 	"encoding/json"
 	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/database"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/i18n"
 	log "github.com/thrasher-/gocryptotrader/logger"
+	"github.com/thrasher-/gocryptotrader/portfolio"
 )
 
 // AllEnabledExchangeOrderbooks holds the enabled exchange orderbooks
@@ -21,6 +31,7 @@ type AllEnabledExchangeOrderbooks struct {
 // orderbooks
 type EnabledExchangeOrderbooks struct {
 	ExchangeName   string           `json:"exchangeName"`
+	Sandbox        bool             `json:"sandbox,omitempty"`
 	ExchangeValues []orderbook.Base `json:"exchangeValues"`
 }
 
@@ -33,12 +44,28 @@ type AllEnabledExchangeCurrencies struct {
 // currencies
 type EnabledExchangeCurrencies struct {
 	ExchangeName   string         `json:"exchangeName"`
+	Sandbox        bool           `json:"sandbox,omitempty"`
 	ExchangeValues []ticker.Price `json:"exchangeValues"`
 }
 
-// AllEnabledExchangeAccounts holds all enabled accounts info
-type AllEnabledExchangeAccounts struct {
-	Data []exchange.AccountInfo `json:"data"`
+// HealthResponse holds the bot health status and storage usage
+type HealthResponse struct {
+	Status             string                      `json:"status"`
+	Exchanges          int                         `json:"exchanges"`
+	Endpoints          []EndpointHealth            `json:"endpoints"`
+	SystemStatus       []ExchangeSystemStatus      `json:"systemStatus"`
+	KlineStorage       KlineStorageHealth          `json:"klineStorage"`
+	DatabaseStorage    []database.TableUsage       `json:"databaseStorage,omitempty"`
+	DataRetention      []DataRetentionUsage        `json:"dataRetention"`
+	PortfolioProviders []portfolio.ProviderMetrics `json:"portfolioProviders"`
+	TaskQueue          TaskQueueStats              `json:"taskQueue"`
+}
+
+// KlineStorageHealth holds the stored candle usage
+type KlineStorageHealth struct {
+	Series  int           `json:"series"`
+	Candles int           `json:"candles"`
+	Usage   []kline.Usage `json:"usage"`
 }
 
 // RESTfulJSONResponse outputs a JSON response of the response interface
@@ -117,7 +144,8 @@ func RESTGetOrderbook(w http.ResponseWriter, r *http.Request) {
 func GetAllActiveOrderbooks() []EnabledExchangeOrderbooks {
 	var orderbookData []EnabledExchangeOrderbooks
 
-	for _, individualBot := range bot.exchanges {
+	exchanges := GetExchanges()
+	for _, individualBot := range exchanges {
 		if individualBot == nil || !individualBot.IsEnabled() {
 			continue
 		}
@@ -125,6 +153,7 @@ func GetAllActiveOrderbooks() []EnabledExchangeOrderbooks {
 		var individualExchange EnabledExchangeOrderbooks
 		exchangeName := individualBot.GetName()
 		individualExchange.ExchangeName = exchangeName
+		individualExchange.Sandbox = individualBot.IsSandbox()
 		currencies := individualBot.GetEnabledCurrencies()
 		assetTypes, err := exchange.GetExchangeAssetTypes(exchangeName)
 		if err != nil {
@@ -184,6 +213,41 @@ func RESTGetPortfolio(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// RESTGetPortfolioExposure via get request returns JSON response of the
+// exposure to each currency at the last portfolio valuation
+func RESTGetPortfolioExposure(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetPortfolioExposure())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTSearchMarkets via get request returns JSON response of the exchange
+// pairs and asset types matching the query parameter, limited to the optional
+// limit parameter
+func RESTSearchMarkets(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var limit int
+	if query.Get("limit") != "" {
+		var err error
+		limit, err = strconv.Atoi(query.Get("limit"))
+		if err != nil {
+			http.Error(w, i18n.Error(err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	response, err := SearchMarkets(query.Get("query"), limit)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
 // RESTGetTicker returns ticker info for a given currency, exchange and
 // asset type
 func RESTGetTicker(w http.ResponseWriter, r *http.Request) {
@@ -207,11 +271,337 @@ func RESTGetTicker(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// RESTGetOpenInterest returns the open interest of a derivatives contract for
+// a given currency and exchange
+func RESTGetOpenInterest(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	currency := vars["currency"]
+	exchangeName := vars["exchangeName"]
+
+	response, err := GetSpecificOpenInterest(currency, exchangeName)
+	if err != nil {
+		log.Errorf("Failed to fetch open interest for %s currency: %s. Error: %s",
+			exchangeName, currency, err)
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetMarketMetadata returns the trade URL, listing date and trading status
+// of a given currency and exchange
+func RESTGetMarketMetadata(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	currency := vars["currency"]
+	exchangeName := vars["exchangeName"]
+
+	response, err := GetSpecificMarketMetadata(currency, exchangeName)
+	if err != nil {
+		log.Errorf("Failed to fetch market metadata for %s currency: %s. Error: %s",
+			exchangeName, currency, err)
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetTradingStatus returns the trading status the exchange reports for a
+// given currency
+func RESTGetTradingStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	currency := vars["currency"]
+	exchangeName := vars["exchangeName"]
+
+	response, err := GetSpecificTradingStatus(currency, exchangeName)
+	if err != nil {
+		log.Errorf("Failed to fetch trading status for %s currency: %s. Error: %s",
+			exchangeName, currency, err)
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetCandles returns the stored candles of the interval query parameter
+// between the optional start and end unix timestamps. The points parameter
+// downsamples the candles server side so charts needn't fetch every candle
+func RESTGetCandles(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	currency := vars["currency"]
+	exchangeName := vars["exchangeName"]
+	query := r.URL.Query()
+
+	start, err := parseUnixQuery(query.Get("start"))
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	end, err := parseUnixQuery(query.Get("end"))
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+
+	var points int
+	if query.Get("points") != "" {
+		points, err = strconv.Atoi(query.Get("points"))
+		if err != nil {
+			http.Error(w, i18n.Error(err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	response, err := GetCandles(exchangeName, currency, query.Get("asset"),
+		query.Get("interval"), start, end, points)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetHistoricTrades downloads the public trades of a currency pair between
+// the start and end unix timestamp query parameters, in chunks of the chunk
+// query parameter duration
+func RESTGetHistoricTrades(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+
+	start, err := parseUnixQuery(query.Get("start"))
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	end, err := parseUnixQuery(query.Get("end"))
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+
+	var chunk time.Duration
+	if query.Get("chunk") != "" {
+		chunk, err = time.ParseDuration(query.Get("chunk"))
+		if err != nil {
+			http.Error(w, i18n.Error(err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	response, err := GetHistoricTrades(vars["exchangeName"], vars["currency"],
+		query.Get("asset"), start, end, chunk)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetOrderbookHistory returns the recorded orderbook of a currency pair
+// reconstructed as it was at the unix timestamp query parameter
+func RESTGetOrderbookHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+
+	at, err := parseUnixQuery(query.Get("timestamp"))
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+
+	response, err := ReconstructOrderbook(vars["exchangeName"], vars["currency"],
+		query.Get("asset"), at)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// parseUnixQuery parses a unix timestamp query parameter, an empty parameter
+// returning the zero time
+func parseUnixQuery(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	unix, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(unix, 0), nil
+}
+
+// RESTSimulateTrade estimates the fill price, slippage and fees of a market
+// order given by the side and amount query parameters
+func RESTSimulateTrade(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	currency := vars["currency"]
+	exchangeName := vars["exchangeName"]
+	query := r.URL.Query()
+
+	amount, err := strconv.ParseFloat(query.Get("amount"), 64)
+	if err != nil {
+		http.Error(w, i18n.T(i18n.MessageInvalidAmount), http.StatusBadRequest)
+		return
+	}
+
+	response, err := SimulateTrade(exchangeName, currency, query.Get("side"), amount)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTRouteOrder compares the venues a market order given by the side and
+// amount query parameters can be filled on, accounting for account fee tiers
+func RESTRouteOrder(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	currency := vars["currency"]
+	query := r.URL.Query()
+
+	amount, err := strconv.ParseFloat(query.Get("amount"), 64)
+	if err != nil {
+		http.Error(w, i18n.T(i18n.MessageInvalidAmount), http.StatusBadRequest)
+		return
+	}
+
+	response, err := RouteOrder(currency, query.Get("side"), amount)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetDustBalances returns an exchanges balances which are below its
+// minimum order size or the configured dust value threshold
+func RESTGetDustBalances(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	exchangeName := vars["exchangeName"]
+
+	response, err := GetDustBalances(exchangeName)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTPlanTransfer compares the networks an amount of a currency can be moved
+// between two exchanges on, given the from, to, currency and amount query
+// parameters
+func RESTPlanTransfer(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	amount, err := strconv.ParseFloat(query.Get("amount"), 64)
+	if err != nil {
+		http.Error(w, i18n.T(i18n.MessageInvalidAmount), http.StatusBadRequest)
+		return
+	}
+
+	response, err := PlanTransfer(query.Get("from"), query.Get("to"),
+		query.Get("currency"), amount)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTExportTrades exports an exchanges authenticated trade history to CSV
+// files in the data directory, resuming previous exports
+func RESTExportTrades(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	exchangeName := vars["exchangeName"]
+
+	response, err := ExportTrades(exchangeName, filepath.Join(bot.dataDir, "trades"))
+	if err != nil {
+		log.Errorf("Failed to export %s trades. Error: %s", exchangeName, err)
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTValidateCredentials performs a harmless authenticated request to report
+// whether an exchanges stored API credentials work
+func RESTValidateCredentials(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	exchangeName := vars["exchangeName"]
+
+	response, err := ValidateExchangeCredentials(exchangeName)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTSetExchangeCredentials brings an exchange online with the API
+// credentials in the request body and returns their validation
+func RESTSetExchangeCredentials(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	exchangeName := vars["exchangeName"]
+
+	var creds ExchangeCredentials
+	err := json.NewDecoder(r.Body).Decode(&creds)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+
+	response, err := EnableExchangeWithCredentials(exchangeName, &creds)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
 // GetAllActiveTickers returns all enabled exchange tickers
 func GetAllActiveTickers() []EnabledExchangeCurrencies {
 	var tickerData []EnabledExchangeCurrencies
 
-	for _, individualBot := range bot.exchanges {
+	exchanges := GetExchanges()
+	for _, individualBot := range exchanges {
 		if individualBot == nil || !individualBot.IsEnabled() {
 			continue
 		}
@@ -219,6 +609,7 @@ func GetAllActiveTickers() []EnabledExchangeCurrencies {
 		var individualExchange EnabledExchangeCurrencies
 		exchangeName := individualBot.GetName()
 		individualExchange.ExchangeName = exchangeName
+		individualExchange.Sandbox = individualBot.IsSandbox()
 		currencies := individualBot.GetEnabledCurrencies()
 		for _, x := range currencies {
 			pair := x
@@ -267,32 +658,344 @@ func RESTGetAllActiveTickers(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetAllEnabledExchangeAccountInfo returns all the current enabled exchanges
-func GetAllEnabledExchangeAccountInfo() AllEnabledExchangeAccounts {
-	var response AllEnabledExchangeAccounts
-	for _, individualBot := range bot.exchanges {
-		if individualBot != nil && individualBot.IsEnabled() {
-			if !individualBot.GetAuthenticatedAPISupport() {
-				log.Warnf("GetAllEnabledExchangeAccountInfo: Skippping %s due to disabled authenticated API support.", individualBot.GetName())
-				continue
-			}
-			individualExchange, err := individualBot.GetAccountInfo()
-			if err != nil {
-				log.Errorf("Error encountered retrieving exchange account info for %s. Error %s",
-					individualBot.GetName(), err)
-				continue
-			}
-			response.Data = append(response.Data, individualExchange)
+// RESTGetAllEnabledAccountInfo via get request returns JSON response of account
+// info. Cached account snapshots can be bypassed with the refresh query param
+func RESTGetAllEnabledAccountInfo(w http.ResponseWriter, r *http.Request) {
+	forceRefresh, _ := strconv.ParseBool(r.URL.Query().Get("refresh"))
+	response := GetAllEnabledExchangeAccountInfo(forceRefresh)
+	err := RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// GetHealth returns the bot health status and storage usage
+func GetHealth() HealthResponse {
+	usage := kline.GetUsage()
+	response := HealthResponse{
+		Status:       "ok",
+		Exchanges:    len(GetExchanges()),
+		Endpoints:    endpointHealth.GetAll(),
+		SystemStatus: systemStatuses.GetAll(),
+		KlineStorage: KlineStorageHealth{
+			Series: len(usage),
+			Usage:  usage,
+		},
+		DataRetention:      GetDataRetentionUsage(),
+		PortfolioProviders: portfolio.GetProviderMetrics(),
+		TaskQueue:          GetTaskQueueStats(),
+	}
+	for x := range usage {
+		response.KlineStorage.Candles += usage[x].Candles
+	}
+
+	if db, err := database.GetConnected(); err == nil {
+		response.DatabaseStorage, err = db.GetUsage()
+		if err != nil {
+			log.Errorf("Failed to get database storage usage. Error: %s", err)
 		}
 	}
 	return response
 }
 
-// RESTGetAllEnabledAccountInfo via get request returns JSON response of account
-// info
-func RESTGetAllEnabledAccountInfo(w http.ResponseWriter, r *http.Request) {
-	response := GetAllEnabledExchangeAccountInfo()
-	err := RESTfulJSONResponse(w, response)
+// RESTGetStablecoinParity returns the observed parity of monitored stablecoin
+// pairs across exchanges
+func RESTGetStablecoinParity(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, stablecoinParity.GetAll())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetTriangularArbitrage returns the profitable triangular arbitrage paths
+// found by the last scan of each exchange
+func RESTGetTriangularArbitrage(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, triangularArbitrage.GetAll())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetScheduledTasks returns the schedule, last run and result of each
+// scheduled task
+func RESTGetScheduledTasks(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetScheduledTasks())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetCircuitBreakerTrips via get request returns JSON response of the
+// pairs whose automated orders are suspended by the circuit breaker
+func RESTGetCircuitBreakerTrips(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetCircuitBreakerTrips())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetPairAnalytics via get request returns JSON response of the rolling
+// correlation and relative strength matrices between enabled pairs
+func RESTGetPairAnalytics(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetPairAnalytics())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetExecutionQuality via get request returns JSON response of the
+// slippage of orders submitted by the bot per exchange, pair and strategy
+func RESTGetExecutionQuality(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetExecutionQuality())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetTaggedOrders via get request returns JSON response of the orders
+// submitted by the bot, filtered by the exchange parameter and each tag
+// parameter given as key:value
+func RESTGetTaggedOrders(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	tags, err := ParseOrderTags(query["tag"])
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+
+	err = RESTfulJSONResponse(w, GetTaggedOrders(query.Get("exchange"), tags))
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetManagedOrders via get request returns JSON response of the open orders
+// tracked by the order manager, or every tracked order when the all parameter
+// is true
+func RESTGetManagedOrders(w http.ResponseWriter, r *http.Request) {
+	orders := GetAllOpenOrders()
+	if all, _ := strconv.ParseBool(r.URL.Query().Get("all")); all {
+		orders = GetManagedOrders()
+	}
+	err := RESTfulJSONResponse(w, orders)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetManagedOrder via get request returns JSON response of an order
+// tracked by the order manager by its internal ID
+func RESTGetManagedOrder(w http.ResponseWriter, r *http.Request) {
+	response, err := GetOrderByInternalID(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusNotFound)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// parseStoredQuery parses the exchange, base, quote, start, end and limit query
+// parameters filtering records stored in the database
+func parseStoredQuery(r *http.Request) (database.Query, error) {
+	query := r.URL.Query()
+	q := database.Query{
+		Exchange: query.Get("exchange"),
+		Base:     query.Get("base"),
+		Quote:    query.Get("quote"),
+	}
+	var err error
+	q.Start, err = parseUnixQuery(query.Get("start"))
+	if err != nil {
+		return q, err
+	}
+	q.End, err = parseUnixQuery(query.Get("end"))
+	if err != nil {
+		return q, err
+	}
+	if limit := query.Get("limit"); limit != "" {
+		q.Limit, err = strconv.Atoi(limit)
+	}
+	return q, err
+}
+
+// writeStoredResponse writes the JSON response of records queried from the
+// database, or the error querying them
+func writeStoredResponse(w http.ResponseWriter, r *http.Request, response interface{}, err error) {
+	switch {
+	case err == database.ErrNotConnected:
+		http.Error(w, i18n.Error(err), http.StatusServiceUnavailable)
+		return
+	case err != nil:
+		http.Error(w, i18n.Error(err), http.StatusInternalServerError)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetStoredTrades via get request returns JSON response of the trades
+// stored in the database
+func RESTGetStoredTrades(w http.ResponseWriter, r *http.Request) {
+	q, err := parseStoredQuery(r)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	response, err := GetStoredTrades(&q)
+	writeStoredResponse(w, r, response, err)
+}
+
+// RESTGetStoredOrders via get request returns JSON response of the orders
+// stored in the database, only those open when last synced with the open
+// query param
+func RESTGetStoredOrders(w http.ResponseWriter, r *http.Request) {
+	q, err := parseStoredQuery(r)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	open, _ := strconv.ParseBool(r.URL.Query().Get("open"))
+	response, err := GetStoredOrders(&q, open)
+	writeStoredResponse(w, r, response, err)
+}
+
+// RESTGetStoredBalances via get request returns JSON response of the balance
+// snapshots stored in the database, only those of a currency with the
+// currency query param
+func RESTGetStoredBalances(w http.ResponseWriter, r *http.Request) {
+	q, err := parseStoredQuery(r)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	response, err := GetStoredBalances(&q, r.URL.Query().Get("currency"))
+	writeStoredResponse(w, r, response, err)
+}
+
+// RESTGetStrategyAllocations via get request returns JSON response of each
+// strategies virtual balances and PnL
+func RESTGetStrategyAllocations(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetStrategyAllocations())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetStrategyFeeds via get request returns JSON response of each strategy
+// feed and the venue serving it
+func RESTGetStrategyFeeds(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetStrategyFeeds())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetMarketMakerPairs via get request returns JSON response of each pair
+// quoted by the market maker and its resting quotes
+func RESTGetMarketMakerPairs(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetMarketMakerPairs())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetBalanceSnapshot via get request returns JSON response of the account
+// info of every enabled exchange queried concurrently. Cached snapshots can be
+// bypassed with the refresh query param
+func RESTGetBalanceSnapshot(w http.ResponseWriter, r *http.Request) {
+	forceRefresh, _ := strconv.ParseBool(r.URL.Query().Get("refresh"))
+	err := RESTfulJSONResponse(w, GetBalanceSnapshot(forceRefresh))
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetAddressBook via get request returns JSON response of each exchanges
+// withdrawal whitelist and its mismatches with the local address book
+func RESTGetAddressBook(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetAddressBookSyncs())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetRebates via get request returns JSON response of the cumulative maker
+// rebate and referral totals of each exchange, which can be limited to an
+// exchange with the exchange query param
+func RESTGetRebates(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetRebateTotals(r.URL.Query().Get("exchange")))
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetFuturesExpiries via get request returns JSON response of the
+// upcoming futures contract expiries. The exchange query param limits them to
+// an exchange and the within query param, a duration such as 72h, limits how
+// far ahead they expire
+func RESTGetFuturesExpiries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var within time.Duration
+	if query.Get("within") != "" {
+		var err error
+		within, err = time.ParseDuration(query.Get("within"))
+		if err != nil {
+			http.Error(w, i18n.Error(err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	err := RESTfulJSONResponse(w, GetUpcomingExpiries(query.Get("exchange"), within))
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetFuturesRolls via get request returns JSON response of the most
+// recent futures positions rolled into the next contract and their cost
+func RESTGetFuturesRolls(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetFuturesRolls())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetFuturesBasis via get request returns JSON response of the basis of
+// each listed futures contract against the spot index, highest annualised first
+func RESTGetFuturesBasis(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetFuturesBases())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetCalendarSpreads via get request returns JSON response of the spreads
+// between listed futures contract months on the same underlying
+func RESTGetCalendarSpreads(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetCalendarSpreads())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetHealth via get request returns JSON response of the bot health status
+// and storage usage
+func RESTGetHealth(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetHealth())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetEndpointLatencies via get request returns JSON response of the latency
+// percentiles of every requested exchange endpoint
+func RESTGetEndpointLatencies(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetEndpointLatencies())
 	if err != nil {
 		RESTfulError(r.Method, err)
 	}
@@ -1,3 +1,6 @@
+//go:build !noweb
+// +build !noweb
+
 package main
 
 import (
@@ -81,3 +84,27 @@ func TestValidHostRequest(t *testing.T) {
 		t.Errorf("Test failed. Response returned wrong status code expected %v got %v", http.StatusOK, status)
 	}
 }
+
+func TestHealthRequest(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/health", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Host = "localhost:9050"
+
+	resp := httptest.NewRecorder()
+	NewRouter().ServeHTTP(resp, req)
+
+	if status := resp.Code; status != http.StatusOK {
+		t.Errorf("Test failed. Response returned wrong status code expected %v got %v", http.StatusOK, status)
+	}
+
+	var health HealthResponse
+	err = json.Unmarshal(resp.Body.Bytes(), &health)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if health.Status != "ok" {
+		t.Errorf("Test failed. Unexpected health status %s", health.Status)
+	}
+}
@@ -0,0 +1,339 @@
+//go:build !notrading && !noweb
+// +build !notrading,!noweb
+
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+func init() {
+	registerRoutes(
+		Route{
+			"SubmitOrder",
+			http.MethodPost,
+			"/exchanges/{exchangeName}/orders/{currency}",
+			RESTSubmitOrder,
+		},
+		Route{
+			"CancelOrderByClientID",
+			http.MethodDelete,
+			"/exchanges/{exchangeName}/orders/client/{clientID}",
+			RESTCancelOrderByClientID,
+		},
+		Route{
+			"QueuedOrders",
+			http.MethodGet,
+			"/orders/queue",
+			RESTGetQueuedOrders,
+		},
+		Route{
+			"CancelQueuedOrder",
+			http.MethodDelete,
+			"/orders/queue/{id}",
+			RESTCancelQueuedOrder,
+		},
+		Route{
+			"OrderMirrorGroups",
+			http.MethodGet,
+			"/orders/mirrors",
+			RESTGetOrderMirrorGroups,
+		},
+		Route{
+			"OrderMirrorGroup",
+			http.MethodGet,
+			"/orders/mirrors/{id}",
+			RESTGetOrderMirrorGroup,
+		},
+		Route{
+			"CancelOrderMirrorGroup",
+			http.MethodDelete,
+			"/orders/mirrors/{id}",
+			RESTCancelOrderMirrorGroup,
+		},
+		Route{
+			"CancelManagedOrder",
+			http.MethodDelete,
+			"/orders/managed/{id}",
+			RESTCancelManagedOrder,
+		},
+		Route{
+			"SweepDust",
+			http.MethodPost,
+			"/exchanges/{exchangeName}/dust/sweep",
+			RESTSweepDust,
+		},
+		Route{
+			"ExecuteTransfer",
+			http.MethodPost,
+			"/transfers",
+			RESTExecuteTransfer,
+		},
+	)
+}
+
+// RESTSubmitOrder submits an order given by the side, type, amount and price
+// query parameters. The funding parameter selects how buy orders are funded
+// when the quote currency is lacking, defaulting to the exchanges config.
+// Orders are queued while the exchange is unavailable when the order queue is
+// enabled. Orders given a strategy parameter are limited to the strategies
+// allocation and are neither funded nor queued. Each tag parameter given as
+// key:value is attached to the order. A quoteAmount parameter given in place of
+// the amount sizes orders without a strategy by the quote currency to spend or
+// receive, such orders are neither funded nor queued either. The postOnly and
+// reduceOnly parameters flag orders sized by amount without a strategy, which
+// are also neither funded nor queued
+func RESTSubmitOrder(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	currency := vars["currency"]
+	exchangeName := vars["exchangeName"]
+	query := r.URL.Query()
+
+	var price float64
+	var err error
+	if query.Get("price") != "" {
+		price, err = strconv.ParseFloat(query.Get("price"), 64)
+		if err != nil {
+			http.Error(w, i18n.T(i18n.MessageInvalidPrice), http.StatusBadRequest)
+			return
+		}
+	}
+
+	tags, err := ParseOrderTags(query["tag"])
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+
+	postOnly, _ := strconv.ParseBool(query.Get("postOnly"))
+	reduceOnly, _ := strconv.ParseBool(query.Get("reduceOnly"))
+	flags := exchange.OrderFlags{PostOnly: postOnly, ReduceOnly: reduceOnly}
+	_, quoteOrder := query["quoteAmount"]
+	if flags.IsSet() && (quoteOrder || query.Get("strategy") != "") {
+		http.Error(w, i18n.T(i18n.MessageOrderFlagsInvalid), http.StatusBadRequest)
+		return
+	}
+
+	if quoteOrder && query.Get("strategy") == "" {
+		quoteAmount, err := strconv.ParseFloat(query.Get("quoteAmount"), 64)
+		if err != nil {
+			http.Error(w, i18n.T(i18n.MessageInvalidAmount), http.StatusBadRequest)
+			return
+		}
+		response, err := SubmitQuoteOrderRequest(exchangeName, currency,
+			query.Get("side"), query.Get("type"), quoteAmount, price, tags)
+		if err != nil {
+			http.Error(w, i18n.Error(err), http.StatusBadRequest)
+			return
+		}
+		err = RESTfulJSONResponse(w, response)
+		if err != nil {
+			RESTfulError(r.Method, err)
+		}
+		return
+	}
+
+	amount, err := strconv.ParseFloat(query.Get("amount"), 64)
+	if err != nil {
+		http.Error(w, i18n.T(i18n.MessageInvalidAmount), http.StatusBadRequest)
+		return
+	}
+
+	if flags.IsSet() {
+		response, err := SubmitFlaggedOrderRequest(exchangeName, currency,
+			query.Get("side"), query.Get("type"), amount, price, flags, tags)
+		if err != nil {
+			http.Error(w, i18n.Error(err), http.StatusBadRequest)
+			return
+		}
+		err = RESTfulJSONResponse(w, response)
+		if err != nil {
+			RESTfulError(r.Method, err)
+		}
+		return
+	}
+
+	if strategy := query.Get("strategy"); strategy != "" {
+		response, err := SubmitStrategyOrderRequest(strategy, exchangeName,
+			currency, query.Get("side"), query.Get("type"), amount, price, tags)
+		if err != nil {
+			http.Error(w, i18n.Error(err), http.StatusBadRequest)
+			return
+		}
+		err = RESTfulJSONResponse(w, response)
+		if err != nil {
+			RESTfulError(r.Method, err)
+		}
+		return
+	}
+
+	funding := query.Get("funding")
+	if _, ok := query["funding"]; !ok {
+		exchCfg, err := bot.config.GetExchangeConfig(exchangeName)
+		if err != nil {
+			http.Error(w, i18n.Error(err), http.StatusBadRequest)
+			return
+		}
+		funding = exchCfg.FundingMode
+	}
+
+	response, err := SubmitOrQueueOrder(exchangeName, currency, query.Get("side"),
+		query.Get("type"), amount, price, funding, tags)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTCancelOrderByClientID cancels an order by the client order ID it was
+// submitted with. The currency query parameter gives the orders pair and is
+// optional for orders submitted by the bot
+func RESTCancelOrderByClientID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	exchangeName := vars["exchangeName"]
+
+	err := CancelOrderByClientIDRequest(exchangeName, r.URL.Query().Get("currency"),
+		vars["clientID"])
+	if err != nil {
+		log.Errorf("Failed to cancel %s order %s. Error: %s", exchangeName,
+			vars["clientID"], err)
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RESTGetQueuedOrders returns the pending and recently finished queued orders
+func RESTGetQueuedOrders(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetQueuedOrders())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTCancelQueuedOrder removes a pending order from the order queue
+func RESTCancelQueuedOrder(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+
+	response, err := CancelQueuedOrder(id)
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetOrderMirrorGroups returns every mirror group with orders held by the
+// bot
+func RESTGetOrderMirrorGroups(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, GetOrderMirrorGroups())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetOrderMirrorGroup returns the orders of a mirror group
+func RESTGetOrderMirrorGroup(w http.ResponseWriter, r *http.Request) {
+	response, err := GetOrderMirrorGroup(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, i18n.Error(err), http.StatusNotFound)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTCancelOrderMirrorGroup cancels every unfilled order of a mirror group,
+// returning the orders which failed to cancel
+func RESTCancelOrderMirrorGroup(w http.ResponseWriter, r *http.Request) {
+	response, err := CancelOrderMirrorGroup(mux.Vars(r)["id"])
+	if err == errOrderMirrorGroupNotFound {
+		http.Error(w, i18n.Error(err), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Errorf("Failed to cancel order mirror group: %s", err)
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTCancelManagedOrder cancels an open order tracked by the order manager by
+// its internal ID
+func RESTCancelManagedOrder(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	err := CancelByInternalID(id)
+	if err == errManagedOrderNotFound {
+		http.Error(w, i18n.Error(err), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Errorf("Failed to cancel managed order %s. Error: %s", id, err)
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RESTSweepDust converts an exchanges dust balances into the configured dust
+// sweep target currency
+func RESTSweepDust(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	exchangeName := vars["exchangeName"]
+
+	response, err := SweepDust(exchangeName)
+	if err != nil {
+		log.Errorf("Failed to sweep %s dust. Error: %s", exchangeName, err)
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTExecuteTransfer withdraws an amount of a currency from one exchange to
+// its deposit address on another, using the optional network query parameter
+// or the recommended route
+func RESTExecuteTransfer(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	amount, err := strconv.ParseFloat(query.Get("amount"), 64)
+	if err != nil {
+		http.Error(w, i18n.T(i18n.MessageInvalidAmount), http.StatusBadRequest)
+		return
+	}
+
+	response, err := ExecuteTransfer(query.Get("from"), query.Get("to"),
+		query.Get("currency"), amount, query.Get("network"))
+	if err != nil {
+		log.Errorf("Failed to execute transfer. Error: %s", err)
+		http.Error(w, i18n.Error(err), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
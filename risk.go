@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/decimal"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// getBalanceReserve returns the configured balance reserve of a currency on an
+// exchange
+func getBalanceReserve(exchName string, c currency.Code) float64 {
+	if bot.config == nil {
+		return 0
+	}
+
+	exchCfg, err := bot.config.GetExchangeConfig(exchName)
+	if err != nil {
+		return 0
+	}
+	return exchCfg.GetBalanceReserve(c)
+}
+
+// getAvailableBalance returns the total balance of a currency across an
+// exchanges accounts which is not on hold
+func getAvailableBalance(info *exchange.AccountInfo, c currency.Code) float64 {
+	return getAvailableBalanceDecimal(info, c).Float64()
+}
+
+// getAvailableBalanceDecimal sums the available balance of a currency in
+// decimal, so balances split across accounts don't gain rounding errors
+func getAvailableBalanceDecimal(info *exchange.AccountInfo, c currency.Code) decimal.Decimal {
+	var available decimal.Decimal
+	for x := range info.Accounts {
+		for y := range info.Accounts[x].Currencies {
+			if info.Accounts[x].Currencies[y].CurrencyName.Match(c) {
+				available = available.Add(
+					decimal.NewFromFloat(info.Accounts[x].Currencies[y].Available))
+			}
+		}
+	}
+	return available
+}
+
+// checkBalanceReserve returns an error if spending an amount of a currency on
+// an exchange would take its available balance below the configured reserve.
+// All order and withdrawal paths are checked here so a reserve is enforced
+// regardless of which component initiated the request
+func checkBalanceReserve(exch exchange.IBotExchange, c currency.Code, amount float64) error {
+	reserve := getBalanceReserve(exch.GetName(), c)
+	if reserve <= 0 {
+		return nil
+	}
+
+	info, err := GetExchangeAccountInfo(exch, false)
+	if err != nil {
+		return fmt.Errorf("unable to check %s %s balance reserve: %s",
+			exch.GetName(), c, err)
+	}
+
+	available := getAvailableBalanceDecimal(&info, c)
+	remaining := available.Sub(decimal.NewFromFloat(amount))
+	if remaining.Cmp(decimal.NewFromFloat(reserve)) < 0 {
+		return fmt.Errorf("%s %s balance reserve of %f breached: spending %f of %s available",
+			exch.GetName(), c, reserve, amount, available)
+	}
+	return nil
+}
+
+// checkOrderBalanceReserve checks an order against the balance reserve of the
+// currency it spends. Market buy orders are valued at the last ticker price
+func checkOrderBalanceReserve(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, amount, price float64) error {
+	switch side {
+	case exchange.SellOrderSide, exchange.AskOrderSide:
+		return checkBalanceReserve(exch, p.Base, amount)
+	case exchange.BuyOrderSide, exchange.BidOrderSide:
+		if getBalanceReserve(exch.GetName(), p.Quote) <= 0 {
+			return nil
+		}
+		if price <= 0 {
+			t, err := ticker.GetTicker(exch.GetName(), p, ticker.Spot)
+			if err != nil || t.Last <= 0 {
+				return fmt.Errorf("unable to value %s %s order against %s balance reserve",
+					exch.GetName(), p, p.Quote)
+			}
+			price = t.Last
+		}
+		cost := decimal.NewFromFloat(amount).Mul(decimal.NewFromFloat(price))
+		return checkBalanceReserve(exch, p.Quote, cost.Float64())
+	}
+	return nil
+}
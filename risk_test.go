@@ -0,0 +1,111 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type riskTestExchange struct {
+	accountInfoTestExchange
+	orders      int
+	withdrawals int
+}
+
+func (r *riskTestExchange) GetAccountInfo() (exchange.AccountInfo, error) {
+	return exchange.AccountInfo{
+		Exchange: r.name,
+		Accounts: []exchange.Account{{
+			Currencies: []exchange.AccountCurrencyInfo{
+				exchange.NewAccountCurrencyInfoFromTotal(currency.BTC, 1, 0.5),
+				exchange.NewAccountCurrencyInfoFromTotal(currency.USD, 1000, 0),
+			},
+		}},
+	}, nil
+}
+
+func (r *riskTestExchange) SubmitOrder(p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	r.orders++
+	return exchange.SubmitOrderResponse{IsOrderPlaced: true}, nil
+}
+
+func (r *riskTestExchange) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	r.withdrawals++
+	return "1", nil
+}
+
+func setupRiskTest(t *testing.T) *riskTestExchange {
+	exch := setupAccountInfoTest(t, time.Minute)
+	exchCfg, err := bot.config.GetExchangeConfig(exch.GetName())
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchCfg.BalanceReserves = map[string]float64{"BTC": 0.1, "USD": 100}
+	err = bot.config.UpdateExchangeConfig(&exchCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &riskTestExchange{accountInfoTestExchange: *exch}
+}
+
+func TestSubmitExchangeOrderBalanceReserve(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+
+	exch := setupRiskTest(t)
+	p := currency.NewPairFromStrings("BTC", "USD")
+
+	_, err := SubmitExchangeOrder(exch, p, exchange.SellOrderSide,
+		exchange.LimitOrderType, 0.3, 5000, "")
+	if err != nil {
+		t.Errorf("Test failed. Expected order within reserve to be placed: %s", err)
+	}
+
+	_, err = SubmitExchangeOrder(exch, p, exchange.SellOrderSide,
+		exchange.LimitOrderType, 0.45, 5000, "")
+	if err == nil {
+		t.Error("Test failed. Expected sell order breaching the BTC reserve to be rejected")
+	}
+
+	_, err = SubmitExchangeOrder(exch, p, exchange.BuyOrderSide,
+		exchange.LimitOrderType, 0.2, 5000, "")
+	if err == nil {
+		t.Error("Test failed. Expected buy order breaching the USD reserve to be rejected")
+	}
+
+	if exch.orders != 1 {
+		t.Errorf("Test failed. Expected 1 order to reach the exchange, got %d", exch.orders)
+	}
+}
+
+func TestWithdrawExchangeCryptocurrencyFundsBalanceReserve(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+
+	exch := setupRiskTest(t)
+
+	_, err := WithdrawExchangeCryptocurrencyFunds(exch, &exchange.WithdrawRequest{
+		Currency:  currency.BTC,
+		Amount:    0.35,
+		FeeAmount: 0.1,
+	})
+	if err == nil {
+		t.Error("Test failed. Expected withdrawal breaching the reserve to be rejected")
+	}
+
+	_, err = WithdrawExchangeCryptocurrencyFunds(exch, &exchange.WithdrawRequest{
+		Currency: currency.LTC,
+		Amount:   10,
+	})
+	if err != nil {
+		t.Errorf("Test failed. Expected withdrawal without reserve to be submitted: %s", err)
+	}
+
+	if exch.withdrawals != 1 {
+		t.Errorf("Test failed. Expected 1 withdrawal to reach the exchange, got %d", exch.withdrawals)
+	}
+}
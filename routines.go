@@ -3,16 +3,22 @@ package main
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"time"
 
+	"github.com/thrasher-/gocryptotrader/clock"
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/database"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/stats"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/i18n"
 	log "github.com/thrasher-/gocryptotrader/logger"
+	"github.com/thrasher-/gocryptotrader/news"
 )
 
 func printCurrencyFormat(price float64) string {
@@ -169,121 +175,135 @@ func printOrderbookSummary(result *orderbook.Base, p currency.Pair, assetType, e
 	}
 }
 
-func relayWebsocketEvent(result interface{}, event, assetType, exchangeName string) {
-	evt := WebsocketEvent{
-		Data:      result,
-		Event:     event,
-		AssetType: assetType,
-		Exchange:  exchangeName,
+// updateTicker fetches and relays the ticker for an exchange currency pair.
+// When update is false the stored ticker is used if available, allowing
+// exchanges supporting batching to update all pairs with one request
+func updateTicker(exch exchange.IBotExchange, update bool, c currency.Pair, assetType string) error {
+	var result ticker.Price
+	var err error
+	if update {
+		result, err = exch.UpdateTicker(c, assetType)
+	} else {
+		result, err = exch.GetTickerPrice(c, assetType)
+	}
+	printTickerSummary(&result, c, assetType, exch.GetName(), err)
+	if err != nil {
+		return err
 	}
-	err := BroadcastWebsocketMessage(evt)
+
+	observeCircuitBreaker(exch.GetName(), c, result.Last, result.Bid, result.Ask)
+	bot.comms.StageTickerData(exch.GetName(), assetType, &result)
+	if bot.config.Webserver.Enabled {
+		relayWebsocketEvent(result, "ticker_update", assetType, exch.GetName(), c)
+	}
+	return nil
+}
+
+// updateOrderbook fetches and relays the orderbook for an exchange currency
+// pair
+func updateOrderbook(exch exchange.IBotExchange, c currency.Pair, assetType string) error {
+	result, err := exch.UpdateOrderbook(c, assetType)
+	printOrderbookSummary(&result, c, assetType, exch.GetName(), err)
 	if err != nil {
-		log.Errorf("Failed to broadcast websocket event %v. Error: %s",
-			event, err)
+		return err
+	}
+
+	bot.comms.StageOrderbookData(exch.GetName(), assetType, &result)
+	if bot.config.Webserver.Enabled {
+		relayWebsocketEvent(result, "orderbook_update", assetType, exch.GetName(), c)
+		relayOrderbookDelta(exch.GetName(), c, assetType, &result)
 	}
+	return nil
 }
 
-// TickerUpdaterRoutine fetches and updates the ticker for all enabled
-// currency pairs and exchanges
-func TickerUpdaterRoutine() {
-	log.Debugf("Starting ticker updater routine.")
-	var wg sync.WaitGroup
+// KlineIntegrityRoutine periodically checks stored candle series for missing
+// intervals, backfilling them via the exchanges REST kline endpoint and
+// reporting any gaps which could not be recovered
+func KlineIntegrityRoutine() {
+	log.Debugln("Starting kline integrity routine.")
 	for {
-		wg.Add(len(bot.exchanges))
-		for x := range bot.exchanges {
-			go func(x int, wg *sync.WaitGroup) {
-				defer wg.Done()
-				if bot.exchanges[x] == nil {
-					return
-				}
-				exchangeName := bot.exchanges[x].GetName()
-				enabledCurrencies := bot.exchanges[x].GetEnabledCurrencies()
-				supportsBatching := bot.exchanges[x].SupportsRESTTickerBatchUpdates()
-				assetTypes, err := exchange.GetExchangeAssetTypes(exchangeName)
-				if err != nil {
-					log.Debugf("failed to get %s exchange asset types. Error: %s",
-						exchangeName, err)
-					return
-				}
+		clock.Sleep(time.Minute * 5)
+		exchanges := GetExchanges()
+		for x := range exchanges {
+			if exchanges[x] == nil || !exchanges[x].SupportsHistoricCandles() {
+				continue
+			}
 
-				processTicker := func(exch exchange.IBotExchange, update bool, c currency.Pair, assetType string) {
-					var result ticker.Price
-					var err error
-					if update {
-						result, err = exch.UpdateTicker(c, assetType)
-					} else {
-						result, err = exch.GetTickerPrice(c, assetType)
-					}
-					printTickerSummary(&result, c, assetType, exchangeName, err)
-					if err == nil {
-						bot.comms.StageTickerData(exchangeName, assetType, &result)
-						if bot.config.Webserver.Enabled {
-							relayWebsocketEvent(result, "ticker_update", assetType, exchangeName)
-						}
-					}
+			items := kline.GetByExchange(exchanges[x].GetName())
+			for y := range items {
+				gaps, err := kline.CheckIntegrity(exchanges[x], items[y].Pair, items[y].AssetType, items[y].Interval)
+				if err != nil {
+					log.Errorf("%s %s %s kline integrity check failed. Error: %s",
+						items[y].Exchange, items[y].Pair, items[y].Interval, err)
+					continue
 				}
-
-				for y := range assetTypes {
-					for z := range enabledCurrencies {
-						if supportsBatching && z > 0 {
-							processTicker(bot.exchanges[x], false, enabledCurrencies[z], assetTypes[y])
-							continue
-						}
-						processTicker(bot.exchanges[x], true, enabledCurrencies[z], assetTypes[y])
-					}
+				for z := range gaps {
+					log.Warnf("%s %s %s kline series has unrecoverable gap %s - %s",
+						items[y].Exchange, items[y].Pair, items[y].Interval,
+						gaps[z].Start, gaps[z].End)
 				}
-			}(x, &wg)
+			}
 		}
-		wg.Wait()
-		log.Debugln("All enabled currency tickers fetched.")
-		time.Sleep(time.Second * 10)
 	}
 }
 
-// OrderbookUpdaterRoutine fetches and updates the orderbooks for all enabled
-// currency pairs and exchanges
-func OrderbookUpdaterRoutine() {
-	log.Debugln("Starting orderbook updater routine.")
-	var wg sync.WaitGroup
+// KlineMaintenanceRoutine periodically compacts old high resolution candles per
+// the configured retention policies. The balance snapshots of a connected
+// database are maintained alongside them
+func KlineMaintenanceRoutine() {
+	log.Debugln("Starting kline maintenance routine.")
 	for {
-		wg.Add(len(bot.exchanges))
-		for x := range bot.exchanges {
-			go func(x int, wg *sync.WaitGroup) {
-				defer wg.Done()
+		clock.Sleep(bot.config.KlineStorage.MaintenanceInterval)
+		compacted := kline.Compact(bot.config.KlineStorage.RetentionPolicies, clock.Now())
+		log.Debugf("Kline maintenance compacted %d old candles.", compacted)
 
-				if bot.exchanges[x] == nil {
-					return
-				}
-				exchangeName := bot.exchanges[x].GetName()
-				enabledCurrencies := bot.exchanges[x].GetEnabledCurrencies()
-				assetTypes, err := exchange.GetExchangeAssetTypes(exchangeName)
-				if err != nil {
-					log.Errorf("failed to get %s exchange asset types. Error: %s",
-						exchangeName, err)
-					return
-				}
+		if db, err := database.GetConnected(); err == nil {
+			maintainDatabase(db, bot.config.KlineStorage.RetentionPolicies, clock.Now())
+		}
+	}
+}
 
-				processOrderbook := func(exch exchange.IBotExchange, c currency.Pair, assetType string) {
-					result, err := exch.UpdateOrderbook(c, assetType)
-					printOrderbookSummary(&result, c, assetType, exchangeName, err)
-					if err == nil {
-						bot.comms.StageOrderbookData(exchangeName, assetType, &result)
-						if bot.config.Webserver.Enabled {
-							relayWebsocketEvent(result, "orderbook_update", assetType, exchangeName)
-						}
-					}
-				}
+// NewsRoutine polls the configured exchange announcement feeds and pushes
+// listing, delisting and maintenance notices relevant to enabled pairs through
+// the communications package. Announcements present on the first poll are
+// treated as already seen
+func NewsRoutine() {
+	log.Debugln("Starting news routine.")
+	tracker := news.NewTracker()
+	// Feeds are seeded by their first successful fetch so that their backlog
+	// isn't pushed as new items
+	seeded := make(map[string]bool)
+	for {
+		for x := range bot.config.News.Feeds {
+			feed := bot.config.News.Feeds[x]
+			items, err := news.Fetch(&feed)
+			if err != nil {
+				log.Errorf("News feed %s for %s failed. Error: %s",
+					feed.URL, feed.Exchange, err)
+				continue
+			}
 
-				for y := range assetTypes {
-					for z := range enabledCurrencies {
-						processOrderbook(bot.exchanges[x], enabledCurrencies[z], assetTypes[y])
-					}
+			items = tracker.Filter(items)
+			feedKey := feed.Exchange + "|" + feed.URL
+			if !seeded[feedKey] {
+				seeded[feedKey] = true
+				continue
+			}
+
+			exch := GetExchangeByName(feed.Exchange)
+			if exch == nil {
+				continue
+			}
+			enabledPairs := exch.GetEnabledCurrencies()
+			for y := range items {
+				if !items[y].Correlate(enabledPairs) {
+					continue
 				}
-			}(x, &wg)
+				log.Infof("News: %s", items[y].String())
+				pushEvent("NEWS", items[y].String())
+			}
 		}
-		wg.Wait()
-		log.Debugln("All enabled currency orderbooks fetched.")
-		time.Sleep(time.Second * 10)
+		clock.Sleep(bot.config.News.PollInterval)
 	}
 }
 
@@ -291,36 +311,73 @@ func OrderbookUpdaterRoutine() {
 func WebsocketRoutine(verbose bool) {
 	log.Debugln("Connecting exchange websocket services...")
 
-	for i := range bot.exchanges {
-		go func(i int) {
-			if verbose {
-				log.Debugf("Establishing websocket connection for %s",
-					bot.exchanges[i].GetName())
-			}
+	exchanges := GetExchanges()
+	for i := range exchanges {
+		go connectExchangeWebsocket(exchanges[i], verbose)
+	}
+}
 
-			ws, err := bot.exchanges[i].GetWebsocket()
-			if err != nil {
-				log.Debugf("Websocket not enabled for %s",
-					bot.exchanges[i].GetName())
-				return
-			}
+// connectExchangeWebsocket starts the websocket data handler of an exchange
+// and connects its websocket feed
+func connectExchangeWebsocket(exch exchange.IBotExchange, verbose bool) {
+	if verbose {
+		log.Debugf("Establishing websocket connection for %s",
+			exch.GetName())
+	}
 
-			// Data handler routine
-			go WebsocketDataHandler(ws, verbose)
+	ws, err := exch.GetWebsocket()
+	if err != nil {
+		log.Debugf("Websocket not enabled for %s",
+			exch.GetName())
+		return
+	}
 
-			err = ws.Connect()
-			if err != nil {
-				switch err.Error() {
-				case exchange.WebsocketNotEnabled:
-					log.Warnf("%s - websocket disabled", bot.exchanges[i].GetName())
-				default:
-					log.Error(err)
-				}
-			}
-		}(i)
+	startWebsocketCapture(exch.GetName(), ws)
+
+	// Data handler routine
+	go WebsocketDataHandler(ws, verbose)
+
+	err = ws.Connect()
+	if err != nil {
+		switch err.Error() {
+		case exchange.WebsocketNotEnabled:
+			log.Warnf("%s - websocket disabled", exch.GetName())
+		default:
+			log.Error(err)
+		}
 	}
 }
 
+// websocketCaptureDir is the data directory sub directory websocket captures
+// are written to
+const websocketCaptureDir = "websocket_captures"
+
+// startWebsocketCapture starts capturing the authenticated order and fill
+// messages of an exchange to the data directory when configured, so they can
+// be replayed with the websocket_replay tool
+func startWebsocketCapture(exchName string, ws *exchange.Websocket) {
+	exchCfg, err := bot.config.GetExchangeConfig(exchName)
+	if err != nil || !exchCfg.WebsocketCapture {
+		return
+	}
+
+	dir := filepath.Join(bot.dataDir, websocketCaptureDir)
+	err = common.CreateDir(dir)
+	if err != nil {
+		log.Errorf("%s unable to create websocket capture directory: %s",
+			exchName, err)
+		return
+	}
+
+	path := filepath.Join(dir, common.StringToLower(exchName)+".jsonl")
+	err = ws.StartCapture(path)
+	if err != nil {
+		log.Errorf("%s unable to start websocket capture: %s", exchName, err)
+		return
+	}
+	log.Debugf("%s websocket order messages captured to %s", exchName, path)
+}
+
 var shutdowner = make(chan struct{}, 1)
 var wg sync.WaitGroup
 
@@ -332,6 +389,11 @@ func Websocketshutdown(ws *exchange.Websocket) error {
 		log.Errorf("routines.go error - failed to shutodwn %s", err)
 	}
 
+	err = ws.StopCapture()
+	if err != nil {
+		log.Errorf("routines.go error - failed to close websocket capture %s", err)
+	}
+
 	timer := time.NewTimer(5 * time.Second)
 	c := make(chan struct{}, 1)
 
@@ -375,8 +437,45 @@ func streamDiversion(ws *exchange.Websocket, verbose bool) {
 	}
 }
 
+// processWebsocketTrade records a trade received over a websocket connection
+// in the rolling VWAP of its ticker
+func processWebsocketTrade(d *exchange.TradeData) {
+	assetType := d.AssetType
+	if assetType == "" {
+		assetType = ticker.Spot
+	}
+	t := d.Timestamp
+	if t.IsZero() {
+		t = clock.Now()
+	}
+	ticker.ProcessTrade(d.Exchange, d.CurrencyPair, assetType, t, d.Price, d.Amount)
+}
+
+// processWebsocketKline stores a candle received over a websocket connection
+func processWebsocketKline(d *exchange.KlineData) {
+	tradeCandles.SetNative(d.Exchange)
+	interval, err := kline.ParseInterval(d.Interval)
+	if err != nil {
+		log.Errorf("routines.go exchange %s kline interval %s error - %s",
+			d.Exchange, d.Interval, err)
+		return
+	}
+	err = kline.Process(d.Exchange, d.Pair, d.AssetType, interval, []kline.Candle{{
+		Time:   d.StartTime,
+		Open:   d.OpenPrice,
+		High:   d.HighPrice,
+		Low:    d.LowPrice,
+		Close:  d.ClosePrice,
+		Volume: d.Volume,
+	}})
+	if err != nil {
+		log.Errorf("routines.go exchange %s kline error - %s", d.Exchange, err)
+	}
+}
+
 // WebsocketDataHandler handles websocket data coming from a websocket feed
-// associated with an exchange
+// associated with an exchange. Data is processed through the task queue so
+// bursts are bounded, with stale ticker and orderbook updates merged or dropped
 func WebsocketDataHandler(ws *exchange.Websocket, verbose bool) {
 	wg.Add(1)
 	defer wg.Done()
@@ -389,11 +488,13 @@ func WebsocketDataHandler(ws *exchange.Websocket, verbose bool) {
 			return
 
 		case data := <-ws.DataHandler:
+			// Sampling is decided per message to bound the output of busy feeds
+			logData := verbose && log.Sampled(debugSubsystemWebsocket)
 			switch d := data.(type) {
 			case string:
 				switch d {
 				case exchange.WebsocketNotEnabled:
-					if verbose {
+					if logData {
 						log.Warnf("routines.go warning - exchange %s weboscket not enabled",
 							ws.GetName())
 					}
@@ -413,28 +514,73 @@ func WebsocketDataHandler(ws *exchange.Websocket, verbose bool) {
 
 			case exchange.TradeData:
 				// Trade Data
-				if verbose {
-					log.Infoln("Websocket trades Updated:   ", d)
+				queueTask(TaskPriorityNormal, "", func() {
+					processWebsocketTrade(&d)
+					aggregateTrade(&d)
+				})
+				if logData {
+					log.Infoln("Websocket trades Updated:   ", log.DebugPayload(debugSubsystemWebsocket, d))
 				}
 
 			case exchange.TickerData:
-				// Ticker data
-				if verbose {
-					log.Infoln("Websocket Ticker Updated:   ", d)
+				// Ticker data, only the latest pending tick is processed
+				queueTask(TaskPriorityLow, "ticker|"+d.Exchange+"|"+d.Pair.String(), func() {
+					observeCircuitBreaker(d.Exchange, d.Pair, d.ClosePrice, 0, 0)
+				})
+				if logData {
+					log.Infoln("Websocket Ticker Updated:   ", log.DebugPayload(debugSubsystemWebsocket, d))
 				}
 			case exchange.KlineData:
 				// Kline data
-				if verbose {
-					log.Infoln("Websocket Kline Updated:    ", d)
+				queueTask(TaskPriorityNormal, "", func() {
+					processWebsocketKline(&d)
+				})
+				if logData {
+					log.Infoln("Websocket Kline Updated:    ", log.DebugPayload(debugSubsystemWebsocket, d))
 				}
 			case exchange.WebsocketOrderbookUpdate:
 				// Orderbook data
-				if verbose {
-					log.Infoln("Websocket Orderbook Updated:", d)
+				if logData {
+					log.Infoln("Websocket Orderbook Updated:", log.DebugPayload(debugSubsystemWebsocket, d))
 				}
+				if orderbookHistory.IsRecording(d.Exchange) {
+					queueTask(TaskPriorityLow, "orderbookhistory|"+d.Exchange+"|"+d.Pair.String()+"|"+d.Asset,
+						func() {
+							recordOrderbookHistory(&d)
+						})
+				}
+				if bot.config.Webserver.Enabled {
+					queueTask(TaskPriorityLow, "orderbook|"+d.Exchange+"|"+d.Pair.String()+"|"+d.Asset,
+						func() {
+							result, err := orderbook.Get(d.Exchange, d.Pair, d.Asset)
+							if err == nil {
+								relayOrderbookDelta(d.Exchange, d.Pair, d.Asset, &result)
+							}
+						})
+				}
+			case exchange.LiquidationEvent:
+				// Liquidation data
+				message := i18n.T(i18n.MessageLiquidation, d.Exchange, d.AssetType, d.Pair, d.Side, d.Amount, d.Price)
+				if logData {
+					log.Infoln("Websocket Liquidation:      ", message)
+				}
+				queueTask(TaskPriorityHigh, "", func() {
+					processLiquidation(&d)
+				})
+			case exchange.WebsocketPositionUpdated:
+				// Order fills and position changes alter account balances
+				if logData {
+					log.Infoln("Websocket Position Updated: ", log.DebugPayload(debugSubsystemWebsocket, d))
+				}
+				queueTask(TaskPriorityHigh, "", func() {
+					InvalidateExchangeAccountInfo(d.Exchange)
+					if bot.config.Webserver.Enabled {
+						relayWebsocketEvent(d, "position_update", d.AssetType, d.Exchange, d.Pair)
+					}
+				})
 			default:
-				if verbose {
-					log.Warnf("Websocket Unknown type:     %s", d)
+				if logData {
+					log.Warnf("Websocket Unknown type:     %s", log.DebugPayload(debugSubsystemWebsocket, d))
 				}
 			}
 		}
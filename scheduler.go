@@ -0,0 +1,340 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/cron"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+	"github.com/thrasher-/gocryptotrader/portfolio"
+)
+
+// Built in scheduled tasks
+const (
+	ScheduledTaskDustSweep       = "dust_sweep"
+	ScheduledTaskTradeExport     = "trade_export"
+	ScheduledTaskPortfolioReport = "portfolio_report"
+	ScheduledTaskSummaryReport   = "summary_report"
+
+	portfolioReportDir = "reports"
+)
+
+// ScheduledTaskFunc runs a scheduled task with its config
+type ScheduledTaskFunc func(task *config.ScheduledTaskConfig) error
+
+var (
+	scheduledTaskFuncs = map[string]ScheduledTaskFunc{
+		ScheduledTaskDustSweep:       runDustSweepTask,
+		ScheduledTaskTradeExport:     runTradeExportTask,
+		ScheduledTaskPortfolioReport: runPortfolioReportTask,
+		ScheduledTaskSummaryReport:   runSummaryReportTask,
+	}
+	scheduledTaskFuncsMtx sync.RWMutex
+
+	scheduler taskScheduler
+)
+
+// RegisterScheduledTask adds a task which can be scheduled by name, letting
+// strategies such as portfolio rebalancers run at fixed schedules. It must be
+// called before the scheduler loads its tasks
+func RegisterScheduledTask(name string, fn ScheduledTaskFunc) {
+	scheduledTaskFuncsMtx.Lock()
+	scheduledTaskFuncs[common.StringToLower(name)] = fn
+	scheduledTaskFuncsMtx.Unlock()
+}
+
+// getScheduledTaskFunc returns a registered task by name
+func getScheduledTaskFunc(name string) (ScheduledTaskFunc, bool) {
+	scheduledTaskFuncsMtx.RLock()
+	defer scheduledTaskFuncsMtx.RUnlock()
+	fn, ok := scheduledTaskFuncs[common.StringToLower(name)]
+	return fn, ok
+}
+
+// ScheduledTaskStatus holds the schedule and last run of a scheduled task.
+// Skipped counts the runs skipped as the previous run had not finished
+type ScheduledTaskStatus struct {
+	Name         string        `json:"name"`
+	Task         string        `json:"task"`
+	Schedule     string        `json:"schedule"`
+	Exchange     string        `json:"exchange,omitempty"`
+	Running      bool          `json:"running"`
+	NextRun      time.Time     `json:"nextRun"`
+	LastRun      time.Time     `json:"lastRun,omitempty"`
+	LastDuration time.Duration `json:"lastDuration"`
+	LastError    string        `json:"lastError,omitempty"`
+	Runs         int           `json:"runs"`
+	Skipped      int           `json:"skipped"`
+}
+
+// scheduledTask is a loaded scheduled task
+type scheduledTask struct {
+	cfg      config.ScheduledTaskConfig
+	schedule cron.Schedule
+	run      ScheduledTaskFunc
+	status   ScheduledTaskStatus
+}
+
+// taskScheduler runs the loaded tasks when they are due
+type taskScheduler struct {
+	tasks []*scheduledTask
+	wg    sync.WaitGroup
+	m     sync.Mutex
+}
+
+// Load replaces the scheduled tasks, tasks which are not registered or never
+// fire are skipped
+func (s *taskScheduler) Load(tasks []config.ScheduledTaskConfig, now time.Time) {
+	var loaded []*scheduledTask
+	for x := range tasks {
+		run, ok := getScheduledTaskFunc(tasks[x].Task)
+		if !ok {
+			log.Warnf("Scheduled task %s: unknown task %s, skipping",
+				tasks[x].Name, tasks[x].Task)
+			continue
+		}
+
+		schedule, err := cron.Parse(tasks[x].Schedule)
+		if err != nil {
+			log.Warnf("Scheduled task %s: %s, skipping", tasks[x].Name, err)
+			continue
+		}
+
+		next := schedule.Next(now)
+		if next.IsZero() {
+			log.Warnf("Scheduled task %s schedule %s never runs, skipping",
+				tasks[x].Name, tasks[x].Schedule)
+			continue
+		}
+
+		loaded = append(loaded, &scheduledTask{
+			cfg:      tasks[x],
+			schedule: schedule,
+			run:      run,
+			status: ScheduledTaskStatus{
+				Name:     tasks[x].Name,
+				Task:     tasks[x].Task,
+				Schedule: tasks[x].Schedule,
+				Exchange: tasks[x].Exchange,
+				NextRun:  next,
+			},
+		})
+	}
+
+	s.m.Lock()
+	s.tasks = loaded
+	s.m.Unlock()
+}
+
+// RunDue starts the tasks due at a time. A task still running from its
+// previous run is skipped, so slow tasks never overlap
+func (s *taskScheduler) RunDue(now time.Time) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, task := range s.tasks {
+		if now.Before(task.status.NextRun) {
+			continue
+		}
+		task.status.NextRun = task.schedule.Next(now)
+
+		if task.status.Running {
+			task.status.Skipped++
+			log.Warnf("Scheduled task %s is still running, skipping run",
+				task.status.Name)
+			continue
+		}
+		task.status.Running = true
+		s.wg.Add(1)
+		go s.execute(task)
+	}
+}
+
+// execute runs a task and records its result
+func (s *taskScheduler) execute(task *scheduledTask) {
+	defer s.wg.Done()
+	log.Debugf("Running scheduled task %s.", task.cfg.Name)
+	start := clock.Now()
+	err := task.run(&task.cfg)
+	duration := clock.Since(start)
+
+	s.m.Lock()
+	task.status.Running = false
+	task.status.LastRun = start
+	task.status.LastDuration = duration
+	task.status.Runs++
+	task.status.LastError = ""
+	if err != nil {
+		task.status.LastError = err.Error()
+	}
+	s.m.Unlock()
+
+	if err != nil {
+		log.Error(i18n.T(i18n.MessageScheduledTaskFailed, task.cfg.Name, err))
+		return
+	}
+	log.Debugf("Scheduled task %s completed in %s.", task.cfg.Name, duration)
+}
+
+// Wait blocks until the running tasks have finished
+func (s *taskScheduler) Wait() {
+	s.wg.Wait()
+}
+
+// NextRun returns the time the next task is due, or the zero time when no
+// tasks are loaded
+func (s *taskScheduler) NextRun() time.Time {
+	s.m.Lock()
+	defer s.m.Unlock()
+	var next time.Time
+	for _, task := range s.tasks {
+		if task.status.NextRun.IsZero() {
+			continue
+		}
+		if next.IsZero() || task.status.NextRun.Before(next) {
+			next = task.status.NextRun
+		}
+	}
+	return next
+}
+
+// GetStatus returns the status of the scheduled tasks
+func (s *taskScheduler) GetStatus() []ScheduledTaskStatus {
+	s.m.Lock()
+	defer s.m.Unlock()
+	resp := make([]ScheduledTaskStatus, len(s.tasks))
+	for x := range s.tasks {
+		resp[x] = s.tasks[x].status
+	}
+	return resp
+}
+
+// SchedulerRoutine runs the configured scheduled tasks when they are due
+func SchedulerRoutine() {
+	log.Debugln("Starting scheduler routine.")
+	scheduler.Load(bot.config.Scheduler.Tasks, clock.Now())
+	for {
+		next := scheduler.NextRun()
+		if next.IsZero() {
+			log.Warn("Scheduler has no tasks to run, stopping.")
+			return
+		}
+		<-clock.After(next.Sub(clock.Now()))
+		scheduler.RunDue(clock.Now())
+	}
+}
+
+// GetScheduledTasks returns the status of the scheduled tasks
+func GetScheduledTasks() []ScheduledTaskStatus {
+	return scheduler.GetStatus()
+}
+
+// getScheduledTaskExchanges returns the exchange set by a task or all enabled
+// exchanges with authenticated API support which pass a filter
+func getScheduledTaskExchanges(task *config.ScheduledTaskConfig, filter func(exchange.IBotExchange) bool) []string {
+	if task.Exchange != "" {
+		return []string{task.Exchange}
+	}
+
+	var resp []string
+	exchanges := GetExchanges()
+	for _, exch := range exchanges {
+		if exch == nil || !exch.IsEnabled() || !exch.GetAuthenticatedAPISupport() {
+			continue
+		}
+		if filter != nil && !filter(exch) {
+			continue
+		}
+		resp = append(resp, exch.GetName())
+	}
+	return resp
+}
+
+// runExchangeTask runs a task for each exchange, returning their combined
+// errors
+func runExchangeTask(exchanges []string, run func(exchName string) error) error {
+	var errs []string
+	for x := range exchanges {
+		err := run(exchanges[x])
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", exchanges[x], err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// runDustSweepTask sweeps the dust balances of the task exchanges
+func runDustSweepTask(task *config.ScheduledTaskConfig) error {
+	return runExchangeTask(getScheduledTaskExchanges(task, nil),
+		func(exchName string) error {
+			sweep, err := SweepDust(exchName)
+			if err != nil {
+				return err
+			}
+			if len(sweep.Errors) > 0 {
+				return errors.New(strings.Join(sweep.Errors, ", "))
+			}
+			return nil
+		})
+}
+
+// runTradeExportTask exports the trade history of the task exchanges to the
+// data directory
+func runTradeExportTask(task *config.ScheduledTaskConfig) error {
+	return runExchangeTask(getScheduledTaskExchanges(task,
+		func(exch exchange.IBotExchange) bool {
+			_, ok := exch.(exchange.ITradeHistoryExchange)
+			return ok
+		}),
+		func(exchName string) error {
+			_, err := ExportTrades(exchName, filepath.Join(bot.dataDir, "trades"))
+			return err
+		})
+}
+
+// PortfolioReport holds the portfolio summary at the time a report was
+// generated
+type PortfolioReport struct {
+	Generated time.Time         `json:"generated"`
+	Summary   portfolio.Summary `json:"summary"`
+}
+
+// runPortfolioReportTask writes a portfolio report to the data directory
+func runPortfolioReportTask(_ *config.ScheduledTaskConfig) error {
+	_, err := writePortfolioReport(filepath.Join(bot.dataDir, portfolioReportDir),
+		bot.portfolio, clock.Now())
+	return err
+}
+
+// writePortfolioReport writes the portfolio summary to a timestamped file in
+// dir, returning its path
+func writePortfolioReport(dir string, p *portfolio.Base, now time.Time) (string, error) {
+	err := common.CreateDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	report := PortfolioReport{
+		Generated: now,
+		Summary:   p.GetPortfolioSummary(),
+	}
+	payload, err := common.JSONEncode(report)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir,
+		fmt.Sprintf("portfolio_%s.json", now.UTC().Format("20060102T150405Z")))
+	return path, common.WriteFile(path, payload)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/portfolio"
+)
+
+func TestTaskSchedulerRunDue(t *testing.T) {
+	release := make(chan struct{})
+	calls := make(chan string, 10)
+	RegisterScheduledTask("Test_Task", func(task *config.ScheduledTaskConfig) error {
+		calls <- task.Name
+		<-release
+		return errors.New("task failed")
+	})
+	defer func() {
+		scheduledTaskFuncsMtx.Lock()
+		delete(scheduledTaskFuncs, "test_task")
+		scheduledTaskFuncsMtx.Unlock()
+	}()
+
+	start := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	var s taskScheduler
+	s.Load([]config.ScheduledTaskConfig{
+		{Name: "test", Task: "test_task", Schedule: "*/5 * * * *"},
+		{Name: "unknown", Task: "unknown_task", Schedule: "@hourly"},
+		{Name: "never", Task: "test_task", Schedule: "0 0 30 2 *"},
+	}, start)
+
+	status := s.GetStatus()
+	if len(status) != 1 {
+		t.Fatalf("Test failed. Expected 1 loaded task, got %d", len(status))
+	}
+	if next := s.NextRun(); !next.Equal(start.Add(time.Minute * 5)) {
+		t.Fatalf("Test failed. Unexpected next run %s", next)
+	}
+
+	s.RunDue(start.Add(time.Minute))
+	select {
+	case <-calls:
+		t.Fatal("Test failed. Task run before it was due")
+	default:
+	}
+
+	s.RunDue(start.Add(time.Minute * 5))
+	if name := <-calls; name != "test" {
+		t.Errorf("Test failed. Unexpected task %s run", name)
+	}
+	if !s.GetStatus()[0].Running {
+		t.Error("Test failed. Expected the task to be running")
+	}
+
+	// The previous run has not finished so the next is skipped
+	s.RunDue(start.Add(time.Minute * 10))
+	close(release)
+	s.Wait()
+	select {
+	case <-calls:
+		t.Error("Test failed. Overlapping run started")
+	default:
+	}
+
+	status = s.GetStatus()
+	if status[0].Running || status[0].Runs != 1 || status[0].Skipped != 1 {
+		t.Errorf("Test failed. Unexpected status %+v", status[0])
+	}
+	if status[0].LastRun.IsZero() || status[0].LastError != "task failed" {
+		t.Errorf("Test failed. Unexpected last run %+v", status[0])
+	}
+	if !status[0].NextRun.Equal(start.Add(time.Minute * 15)) {
+		t.Errorf("Test failed. Unexpected next run %s", status[0].NextRun)
+	}
+
+	s.RunDue(start.Add(time.Minute * 15))
+	s.Wait()
+	if status = s.GetStatus(); status[0].Runs != 2 {
+		t.Errorf("Test failed. Expected 2 runs, got %d", status[0].Runs)
+	}
+}
+
+func TestWritePortfolioReport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gct-reports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	path, err := writePortfolioReport(filepath.Join(dir, portfolioReportDir),
+		&portfolio.Base{}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != "portfolio_20190601T100000Z.json" {
+		t.Errorf("Test failed. Unexpected report path %s", path)
+	}
+	if _, err = os.Stat(path); err != nil {
+		t.Errorf("Test failed. Report not written: %s", err)
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+	"github.com/thrasher-/gocryptotrader/portfolio"
+)
+
+// StablecoinParity holds the observed parity of a stablecoin pair on an
+// exchange. Deviation is a percentage away from 1:1 parity
+type StablecoinParity struct {
+	Exchange    string        `json:"exchange"`
+	Pair        currency.Pair `json:"pair"`
+	Price       float64       `json:"price"`
+	Deviation   float64       `json:"deviation"`
+	Depegged    bool          `json:"depegged"`
+	LastUpdated time.Time     `json:"lastUpdated"`
+}
+
+// stablecoinMonitor tracks the observed parity of stablecoin pairs across
+// exchanges so deviations can be alerted on and used for valuation
+type stablecoinMonitor struct {
+	parities map[string]*StablecoinParity
+	m        sync.Mutex
+}
+
+var stablecoinParity = stablecoinMonitor{
+	parities: make(map[string]*StablecoinParity),
+}
+
+// Record records an observed stablecoin price. It returns true when the pair
+// crosses the deviation threshold in either direction so the caller can
+// surface the change
+func (s *stablecoinMonitor) Record(exchName string, p currency.Pair, price, threshold float64, now time.Time) (changed bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	key := exchName + p.Base.Upper().String() + p.Quote.Upper().String()
+	parity, ok := s.parities[key]
+	if !ok {
+		parity = &StablecoinParity{Exchange: exchName, Pair: p}
+		s.parities[key] = parity
+	}
+
+	parity.Price = price
+	parity.Deviation = math.Abs(price-1) * 100
+	parity.LastUpdated = now
+	depegged := parity.Deviation > threshold
+	changed = depegged != parity.Depegged
+	parity.Depegged = depegged
+	return changed
+}
+
+// Restore replaces the tracked parities with previously saved parities
+func (s *stablecoinMonitor) Restore(parities []StablecoinParity) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.parities = make(map[string]*StablecoinParity)
+	for x := range parities {
+		parity := parities[x]
+		key := parity.Exchange + parity.Pair.Base.Upper().String() +
+			parity.Pair.Quote.Upper().String()
+		s.parities[key] = &parity
+	}
+}
+
+// GetObservedRate returns the average price of a stablecoin pair across
+// exchanges observed since the given time
+func (s *stablecoinMonitor) GetObservedRate(p currency.Pair, since time.Time) (float64, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	var total float64
+	var count int
+	for _, parity := range s.parities {
+		if !parity.Pair.Equal(p) || parity.LastUpdated.Before(since) {
+			continue
+		}
+		total += parity.Price
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return total / float64(count), true
+}
+
+// GetAll returns the observed parity of all tracked stablecoin pairs
+func (s *stablecoinMonitor) GetAll() []StablecoinParity {
+	s.m.Lock()
+	defer s.m.Unlock()
+	var resp []StablecoinParity
+	for _, parity := range s.parities {
+		resp = append(resp, *parity)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].Pair.String() == resp[j].Pair.String() {
+			return resp[i].Exchange < resp[j].Exchange
+		}
+		return resp[i].Pair.String() < resp[j].Pair.String()
+	})
+	return resp
+}
+
+// StablecoinMonitorRoutine periodically checks the configured stablecoin pairs
+// against the latest tickers of every exchange they are enabled on
+func StablecoinMonitorRoutine() {
+	log.Debugln("Starting stablecoin monitor routine.")
+	for {
+		checkStablecoinParity(clock.Now())
+		clock.Sleep(bot.config.StablecoinMonitor.CheckInterval)
+	}
+}
+
+// checkStablecoinParity records the latest stablecoin prices, alerting on
+// threshold crossings and updating the portfolio valuation rates if enabled
+func checkStablecoinParity(now time.Time) {
+	cfg := bot.config.StablecoinMonitor
+	for x := range cfg.Pairs {
+		exchanges := GetExchanges()
+		for y := range exchanges {
+			if exchanges[y] == nil {
+				continue
+			}
+			if !exchanges[y].GetEnabledCurrencies().Contains(cfg.Pairs[x], true) {
+				continue
+			}
+
+			exchName := exchanges[y].GetName()
+			price, err := ticker.GetTicker(exchName, cfg.Pairs[x], ticker.Spot)
+			if err != nil || price.Stale || price.Last <= 0 {
+				continue
+			}
+
+			if stablecoinParity.Record(exchName, cfg.Pairs[x], price.Last,
+				cfg.DeviationThreshold, now) {
+				notifyStablecoinParity(exchName, cfg.Pairs[x], price.Last,
+					cfg.DeviationThreshold)
+			}
+		}
+
+		if !cfg.AdjustPortfolioValuation {
+			continue
+		}
+		rate, ok := stablecoinParity.GetObservedRate(cfg.Pairs[x], now)
+		if ok {
+			portfolio.SetParityRate(cfg.Pairs[x].Base, rate)
+		}
+	}
+}
+
+// notifyStablecoinParity logs and pushes a stablecoin depeg or recovery
+// through the communications package
+func notifyStablecoinParity(exchName string, p currency.Pair, price, threshold float64) {
+	var message string
+	deviation := math.Abs(price-1) * 100
+	if deviation > threshold {
+		message = i18n.T(i18n.MessageParityDeviation, exchName, p, deviation,
+			price, threshold)
+		log.Warn(message)
+	} else {
+		message = i18n.T(i18n.MessageParityRestored, exchName, p, price)
+		log.Info(message)
+	}
+
+	pushEvent("STABLECOIN_PARITY", message)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+func TestStablecoinMonitor(t *testing.T) {
+	s := stablecoinMonitor{parities: make(map[string]*StablecoinParity)}
+	now := time.Now()
+	p := currency.NewPairFromStrings("USDT", "USD")
+
+	if s.Record("Bitfinex", p, 0.999, 0.5, now) {
+		t.Error("Test failed. Price within threshold should not report a change")
+	}
+	if !s.Record("Bitfinex", p, 0.98, 0.5, now) {
+		t.Error("Test failed. Expected pair to deviate from parity")
+	}
+	if s.Record("Bitfinex", p, 0.97, 0.5, now) {
+		t.Error("Test failed. Already deviated pair should not report a change")
+	}
+	if !s.Record("Kraken", p, 0.99, 0.5, now.Add(-time.Hour)) {
+		t.Error("Test failed. Expected pair to deviate from parity")
+	}
+
+	rate, ok := s.GetObservedRate(p, now)
+	if !ok || rate != 0.97 {
+		t.Errorf("Test failed. Expected observed rate 0.97, got %f", rate)
+	}
+	rate, ok = s.GetObservedRate(p, now.Add(-time.Hour))
+	if !ok || rate != 0.98 {
+		t.Errorf("Test failed. Expected observed rate 0.98, got %f", rate)
+	}
+	_, ok = s.GetObservedRate(currency.NewPairFromStrings("DAI", "USD"), now)
+	if ok {
+		t.Error("Test failed. Expected no observed rate for an untracked pair")
+	}
+
+	if !s.Record("Bitfinex", p, 1.001, 0.5, now) {
+		t.Error("Test failed. Expected pair to return to parity")
+	}
+
+	all := s.GetAll()
+	if len(all) != 2 || all[0].Exchange != "Bitfinex" || all[0].Depegged {
+		t.Errorf("Test failed. Unexpected parities %v", all)
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// startupStage is a step of the engine startup. A stage runs once every stage
+// it depends on has finished, stages which don't depend on each other run
+// concurrently. Stages can only depend on stages listed before them
+type startupStage struct {
+	name      string
+	dependsOn []string
+	run       func() error
+}
+
+// startupStageTiming is how long a startup stage took to run. Stages skipped
+// as a dependency failed have no duration
+type startupStageTiming struct {
+	stage    string
+	duration time.Duration
+	err      error
+}
+
+// checkStartupStages returns an error if a stage name is reused or a stage
+// depends on one not listed before it, which could otherwise deadlock
+func checkStartupStages(stages []startupStage) error {
+	seen := make(map[string]bool, len(stages))
+	for x := range stages {
+		if seen[stages[x].name] {
+			return fmt.Errorf("duplicate startup stage %s", stages[x].name)
+		}
+		for _, dep := range stages[x].dependsOn {
+			if !seen[dep] {
+				return fmt.Errorf("startup stage %s depends on unknown or later stage %s",
+					stages[x].name, dep)
+			}
+		}
+		seen[stages[x].name] = true
+	}
+	return nil
+}
+
+// runStartupStages runs the startup stages in dependency order, logging how
+// long each took. A warning naming the unfinished stages is logged once
+// startup runs over budget, a zero budget is unlimited. The stage timings and
+// the error of the first failed stage are returned, stages depending on a
+// failed stage are skipped
+func runStartupStages(stages []startupStage, budget time.Duration) ([]startupStageTiming, error) {
+	err := checkStartupStages(stages)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(map[string]chan struct{}, len(stages))
+	for x := range stages {
+		done[stages[x].name] = make(chan struct{})
+	}
+	timings := make([]startupStageTiming, len(stages))
+	index := make(map[string]int, len(stages))
+	for x := range stages {
+		index[stages[x].name] = x
+	}
+
+	start := clock.Now()
+	var wg sync.WaitGroup
+	for x := range stages {
+		wg.Add(1)
+		go func(x int) {
+			defer wg.Done()
+			defer close(done[stages[x].name])
+			timings[x].stage = stages[x].name
+			for _, dep := range stages[x].dependsOn {
+				<-done[dep]
+				// Dependencies have finished so their errors are safe to read
+				if timings[index[dep]].err != nil {
+					timings[x].err = fmt.Errorf("startup stage %s skipped as %s failed",
+						stages[x].name, dep)
+					return
+				}
+			}
+
+			stageStart := clock.Now()
+			timings[x].err = stages[x].run()
+			timings[x].duration = clock.Since(stageStart)
+			if timings[x].err != nil {
+				log.Errorf("Startup stage %s failed after %s. Error: %s",
+					stages[x].name, timings[x].duration, timings[x].err)
+				return
+			}
+			log.Debugf("Startup stage %s finished in %s.", stages[x].name,
+				timings[x].duration)
+		}(x)
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	if budget > 0 {
+		select {
+		case <-finished:
+		case <-clock.After(budget):
+			var pending []string
+			for x := range stages {
+				select {
+				case <-done[stages[x].name]:
+				default:
+					pending = append(pending, stages[x].name)
+				}
+			}
+			log.Warnf("Startup has exceeded its budget of %s, waiting on stages: %s.",
+				budget, strings.Join(pending, ", "))
+		}
+	}
+	<-finished
+	log.Debugf("Startup finished in %s.", clock.Since(start))
+
+	for x := range timings {
+		if timings[x].err != nil {
+			return timings, timings[x].err
+		}
+	}
+	return timings, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRunStartupStages(t *testing.T) {
+	var order []string
+	var m sync.Mutex
+	record := func(name string) {
+		m.Lock()
+		order = append(order, name)
+		m.Unlock()
+	}
+
+	// The independent stages each wait on the other having started, so only
+	// finish when run concurrently
+	first, second := make(chan struct{}), make(chan struct{})
+	timings, err := runStartupStages([]startupStage{
+		{name: "config", run: func() error { record("config"); return nil }},
+		{name: "currency", dependsOn: []string{"config"}, run: func() error {
+			close(first)
+			<-second
+			record("currency")
+			return nil
+		}},
+		{name: "comms", dependsOn: []string{"config"}, run: func() error {
+			close(second)
+			<-first
+			record("comms")
+			return nil
+		}},
+		{name: "exchanges", dependsOn: []string{"currency", "comms"}, run: func() error {
+			record("exchanges")
+			return nil
+		}},
+	}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(order) != 4 || order[0] != "config" || order[3] != "exchanges" {
+		t.Errorf("Test failed. Expected stages to run in dependency order, got %v", order)
+	}
+	if len(timings) != 4 || timings[3].stage != "exchanges" {
+		t.Errorf("Test failed. Expected a timing per stage, got %+v", timings)
+	}
+
+	errStage := errors.New("stage failed")
+	var ran bool
+	timings, err = runStartupStages([]startupStage{
+		{name: "config", run: func() error { return errStage }},
+		{name: "servers", dependsOn: []string{"config"}, run: func() error {
+			ran = true
+			return nil
+		}},
+	}, 0)
+	if err != errStage || ran || timings[1].err == nil {
+		t.Errorf("Test failed. Expected stages depending on a failed stage to be skipped, got %v",
+			err)
+	}
+
+	_, err = runStartupStages([]startupStage{
+		{name: "servers", dependsOn: []string{"config"}, run: func() error { return nil }},
+		{name: "config", run: func() error { return nil }},
+	}, 0)
+	if err == nil {
+		t.Error("Test failed. Expected depending on a later stage to error")
+	}
+}
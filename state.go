@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	engineStateFile    = "state.json"
+	engineStateVersion = 1
+)
+
+// EngineState holds the runtime state of the engine which is saved to disk so
+// a restart resumes alerting and endpoint backoff where it left off, and keeps
+// the tags of submitted orders and the orders tracked by the order manager
+type EngineState struct {
+	Version             int                   `json:"version"`
+	Saved               time.Time             `json:"saved"`
+	StablecoinParity    []StablecoinParity    `json:"stablecoinParity"`
+	EndpointHealth      []EndpointHealth      `json:"endpointHealth"`
+	TriangularArbitrage []TriangularArbitrage `json:"triangularArbitrage"`
+	TaggedOrders        []TaggedOrder         `json:"taggedOrders"`
+	ManagedOrders       []ManagedOrder        `json:"managedOrders"`
+}
+
+// getEngineStatePath returns the path of the engine state file in the data
+// directory
+func getEngineStatePath() string {
+	return filepath.Join(bot.dataDir, engineStateFile)
+}
+
+// EngineStateRoutine periodically saves the engine state to the data directory
+func EngineStateRoutine() {
+	log.Debugln("Starting engine state routine.")
+	for {
+		clock.Sleep(bot.config.StatePersistence.SaveInterval)
+		err := SaveEngineState(getEngineStatePath(), clock.Now())
+		if err != nil {
+			log.Errorf("Failed to save engine state: %s", err)
+		}
+	}
+}
+
+// getEngineState returns a snapshot of the engine runtime state
+func getEngineState(now time.Time) EngineState {
+	return EngineState{
+		Version:             engineStateVersion,
+		Saved:               now,
+		StablecoinParity:    stablecoinParity.GetAll(),
+		EndpointHealth:      endpointHealth.GetAll(),
+		TriangularArbitrage: triangularArbitrage.GetAll(),
+		TaggedOrders:        orderTags.GetAll(),
+		ManagedOrders:       managedOrders.GetAll(),
+	}
+}
+
+// SaveEngineState writes the engine state to a file. The state is written to a
+// temporary file first so an interrupted save never corrupts the last state
+func SaveEngineState(path string, now time.Time) error {
+	state := getEngineState(now)
+	data, err := common.JSONEncode(&state)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	err = common.WriteFile(tmp, data)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadEngineState restores the engine state from a file. A missing file is not
+// an error, state saved longer than maxAge ago is discarded
+func LoadEngineState(path string, maxAge time.Duration, now time.Time) error {
+	data, err := common.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var state EngineState
+	err = common.JSONDecode(data, &state)
+	if err != nil {
+		return err
+	}
+
+	if state.Version != engineStateVersion {
+		return fmt.Errorf("unsupported engine state version %d", state.Version)
+	}
+
+	if now.Sub(state.Saved) > maxAge {
+		log.Warnf("Engine state saved %s exceeds max age of %s, discarding",
+			state.Saved, maxAge)
+		return nil
+	}
+
+	stablecoinParity.Restore(state.StablecoinParity)
+	endpointHealth.Restore(state.EndpointHealth)
+	triangularArbitrage.Restore(state.TriangularArbitrage)
+	orderTags.Restore(state.TaggedOrders)
+	managedOrders.Restore(state.ManagedOrders)
+	log.Debugf("Engine state saved %s restored", state.Saved)
+	return nil
+}
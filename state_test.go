@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+func TestSaveLoadEngineState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gct-state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, engineStateFile)
+
+	now := time.Now()
+	p := currency.NewPairFromStrings("USDT", "USD")
+	stablecoinParity.Record("StateTest", p, 0.98, 1, now)
+	for i := 0; i < endpointDegradedThreshold; i++ {
+		endpointHealth.Record("StateTest", "ticker", errors.New("timeout"), now)
+	}
+	triangularArbitrage.Update("StateTest", []TriangularArbitrage{{
+		Exchange: "StateTest",
+		Path:     []currency.Code{currency.BTC, currency.ETH, currency.USDT, currency.BTC},
+	}})
+
+	err = SaveEngineState(path, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stablecoinParity.Restore(nil)
+	endpointHealth.Restore(nil)
+	triangularArbitrage.Restore(nil)
+
+	err = LoadEngineState(path, time.Hour, now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parities := stablecoinParity.GetAll()
+	if len(parities) != 1 || !parities[0].Depegged {
+		t.Errorf("Test failed. Expected depegged parity to be restored, got %+v",
+			parities)
+	}
+	if !endpointHealth.ShouldSkip("StateTest", "ticker", now) {
+		t.Error("Test failed. Expected degraded endpoint backoff to be restored")
+	}
+	if found := triangularArbitrage.Update("StateTest", triangularArbitrage.GetAll()); len(found) != 0 {
+		t.Error("Test failed. Expected restored opportunities not to be alerted again")
+	}
+
+	stablecoinParity.Restore(nil)
+	err = LoadEngineState(path, time.Hour, now.Add(time.Hour*2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stablecoinParity.GetAll()) != 0 {
+		t.Error("Test failed. Expected state exceeding max age to be discarded")
+	}
+
+	err = LoadEngineState(filepath.Join(dir, "missing.json"), time.Hour, now)
+	if err != nil {
+		t.Errorf("Test failed. Expected missing state file to be ignored: %s", err)
+	}
+
+	endpointHealth.Restore(nil)
+	triangularArbitrage.Restore(nil)
+}
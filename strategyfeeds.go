@@ -0,0 +1,314 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	strategyFeedEventType     = "FEED_SWITCH"
+	strategyFeedCheckInterval = time.Second * 30
+)
+
+// StrategyFeed is a data feed a strategy depends on and the venue serving it.
+// Pair is the pair traded on the venue, which is quoted in an equivalent
+// stablecoin when the venue doesn't trade the requested pair. Feeds without a
+// healthy venue are unavailable and keep their last venue
+type StrategyFeed struct {
+	Strategy  string        `json:"strategy"`
+	Feed      string        `json:"feed"`
+	Requested currency.Pair `json:"requested"`
+	Preferred string        `json:"preferred"`
+	Exchange  string        `json:"exchange"`
+	Pair      currency.Pair `json:"pair"`
+	Available bool          `json:"available"`
+	Switched  time.Time     `json:"switched,omitempty"`
+	fallbacks []string
+}
+
+// feedSwitch is a change of venue serving a strategy feed
+type feedSwitch struct {
+	feed StrategyFeed
+	from string
+}
+
+// strategyFeedManager holds the strategy feeds in the order they were
+// configured
+type strategyFeedManager struct {
+	feeds []*StrategyFeed
+	m     sync.Mutex
+}
+
+var strategyFeeds strategyFeedManager
+
+// Load replaces the strategy feeds with those configured, they have no venue
+// until resolved
+func (s *strategyFeedManager) Load(cfgs []config.StrategyFeedConfig) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.feeds = nil
+	for x := range cfgs {
+		s.feeds = append(s.feeds, &StrategyFeed{
+			Strategy:  common.StringToLower(cfgs[x].Strategy),
+			Feed:      common.StringToLower(cfgs[x].Feed),
+			Requested: cfgs[x].Pair,
+			Preferred: cfgs[x].Exchange,
+			fallbacks: cfgs[x].Fallbacks,
+		})
+	}
+}
+
+// Resolve moves each feed to the first healthy venue trading its pair,
+// preferring its configured venue then its fallbacks. It returns the feeds
+// which switched venue
+func (s *strategyFeedManager) Resolve(exchanges []exchange.IBotExchange, now time.Time) []feedSwitch {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	var resp []feedSwitch
+	for x := range s.feeds {
+		f := s.feeds[x]
+		exch, p, ok := selectFeedVenue(f, exchanges)
+		f.Available = ok
+		if !ok || exch.GetName() == f.Exchange {
+			continue
+		}
+
+		from := f.Exchange
+		f.Exchange = exch.GetName()
+		f.Pair = p
+		if from != "" {
+			f.Switched = now
+			resp = append(resp, feedSwitch{feed: *f, from: from})
+		}
+	}
+	return resp
+}
+
+// Get returns the feed of a strategy for a pair
+func (s *strategyFeedManager) Get(strategy, feed string, p currency.Pair) (StrategyFeed, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	for x := range s.feeds {
+		if s.feeds[x].Strategy == common.StringToLower(strategy) &&
+			s.feeds[x].Feed == feed && s.feeds[x].Requested.Equal(p) {
+			if !s.feeds[x].Available {
+				return *s.feeds[x], fmt.Errorf("strategy %s %s %s feed has no available venue",
+					strategy, p, feed)
+			}
+			return *s.feeds[x], nil
+		}
+	}
+	return StrategyFeed{}, fmt.Errorf("strategy %s has no %s %s feed", strategy, p, feed)
+}
+
+// GetAll returns every strategy feed
+func (s *strategyFeedManager) GetAll() []StrategyFeed {
+	s.m.Lock()
+	defer s.m.Unlock()
+	resp := make([]StrategyFeed, 0, len(s.feeds))
+	for x := range s.feeds {
+		resp = append(resp, *s.feeds[x])
+	}
+	return resp
+}
+
+// selectFeedVenue returns the first healthy venue trading a feeds pair, or an
+// equivalent pair, and the pair it trades
+func selectFeedVenue(f *StrategyFeed, exchanges []exchange.IBotExchange) (exchange.IBotExchange, currency.Pair, bool) {
+	var candidates []string
+	if !strings.EqualFold(f.Preferred, config.StrategyFeedAnyExchange) {
+		candidates = append(candidates, f.Preferred)
+	}
+	candidates = append(candidates, f.fallbacks...)
+	for x := range exchanges {
+		if exchanges[x] != nil {
+			candidates = append(candidates, exchanges[x].GetName())
+		}
+	}
+
+	for x := range candidates {
+		var exch exchange.IBotExchange
+		for y := range exchanges {
+			if exchanges[y] != nil && strings.EqualFold(exchanges[y].GetName(), candidates[x]) {
+				exch = exchanges[y]
+				break
+			}
+		}
+		if exch == nil {
+			continue
+		}
+		p, ok := findFeedPair(exch, f.Requested)
+		if !ok || isFeedVenueDown(exch, f.Feed, p) {
+			continue
+		}
+		return exch, p, true
+	}
+	return nil, currency.Pair{}, false
+}
+
+// findFeedPair returns the enabled pair of an exchange matching a requested
+// pair. Pairs quoted in a currency the stablecoin monitor tracks against the
+// requested quote currency are matched when the pair itself isn't enabled
+func findFeedPair(exch exchange.IBotExchange, requested currency.Pair) (currency.Pair, bool) {
+	enabled := exch.GetEnabledCurrencies()
+	for x := range enabled {
+		if enabled[x].Equal(requested) {
+			return enabled[x], true
+		}
+	}
+	for x := range enabled {
+		if enabled[x].Base.Match(requested.Base) &&
+			getFeedQuoteRate(requested.Quote, enabled[x].Quote) > 0 {
+			return enabled[x], true
+		}
+	}
+	return currency.Pair{}, false
+}
+
+// getFeedQuoteRate returns the rate converting prices quoted in one currency
+// into another, from the stablecoin parity observed between them. It returns
+// zero when the currencies aren't tracked as equivalents, and assumes parity
+// until a rate has been observed
+func getFeedQuoteRate(requested, quote currency.Code) float64 {
+	if requested.Match(quote) {
+		return 1
+	}
+	if bot.config == nil {
+		return 0
+	}
+
+	pairs := bot.config.StablecoinMonitor.Pairs
+	for x := range pairs {
+		switch {
+		case pairs[x].Base.Match(quote) && pairs[x].Quote.Match(requested):
+			if rate, ok := stablecoinParity.GetObservedRate(pairs[x], time.Time{}); ok && rate > 0 {
+				return rate
+			}
+			return 1
+		case pairs[x].Base.Match(requested) && pairs[x].Quote.Match(quote):
+			if rate, ok := stablecoinParity.GetObservedRate(pairs[x], time.Time{}); ok && rate > 0 {
+				return 1 / rate
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// isFeedVenueDown returns whether an exchange can't serve a feed, as it is
+// disabled, under maintenance, its feed endpoint is degraded or its stored
+// data for the pair is stale
+func isFeedVenueDown(exch exchange.IBotExchange, feed string, p currency.Pair) bool {
+	if !exch.IsEnabled() ||
+		systemStatuses.IsUnderMaintenance(exch.GetName()) ||
+		endpointHealth.IsDegraded(exch.GetName(), feed) {
+		return true
+	}
+
+	switch feed {
+	case config.StrategyFeedOrderbook:
+		ob, err := orderbook.Get(exch.GetName(), p, orderbook.Spot)
+		return err == nil && ob.Stale
+	default:
+		t, err := ticker.GetTicker(exch.GetName(), p, ticker.Spot)
+		return err == nil && t.Stale
+	}
+}
+
+// resolveStrategyFeeds fails strategy feeds over from venues which went down,
+// notifying each switch
+func resolveStrategyFeeds(exchanges []exchange.IBotExchange, now time.Time) {
+	switches := strategyFeeds.Resolve(exchanges, now)
+	for x := range switches {
+		notifyFeedSwitch(&switches[x])
+	}
+}
+
+// notifyFeedSwitch logs and pushes a strategy feed switch through the
+// communications package
+func notifyFeedSwitch(s *feedSwitch) {
+	message := i18n.T(i18n.MessageFeedSwitched, s.feed.Strategy, s.feed.Requested,
+		s.feed.Feed, s.from, s.feed.Exchange, s.feed.Pair)
+	log.Warn(message)
+	pushEvent(strategyFeedEventType, message)
+}
+
+// StrategyFeedRoutine periodically checks the venues serving strategy feeds,
+// failing feeds over to alternative venues when they go down
+func StrategyFeedRoutine() {
+	log.Debugln("Starting strategy feed routine.")
+	for {
+		resolveStrategyFeeds(GetExchanges(), clock.Now())
+		clock.Sleep(strategyFeedCheckInterval)
+	}
+}
+
+// GetStrategyFeeds returns each strategy feed and the venue serving it
+func GetStrategyFeeds() []StrategyFeed {
+	return strategyFeeds.GetAll()
+}
+
+// GetStrategyTicker returns the ticker of a strategy ticker feed from the venue
+// serving it, with prices converted into the requested quote currency
+func GetStrategyTicker(strategy string, p currency.Pair) (ticker.Price, error) {
+	f, err := strategyFeeds.Get(strategy, config.StrategyFeedTicker, p)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+
+	t, err := ticker.GetTicker(f.Exchange, f.Pair, ticker.Spot)
+	if err != nil {
+		return t, err
+	}
+	rate := getFeedQuoteRate(f.Requested.Quote, f.Pair.Quote)
+	t.Pair = f.Requested
+	t.Last *= rate
+	t.High *= rate
+	t.Low *= rate
+	t.Bid *= rate
+	t.Ask *= rate
+	return t, nil
+}
+
+// GetStrategyOrderbook returns the orderbook of a strategy orderbook feed from
+// the venue serving it, with prices converted into the requested quote
+// currency
+func GetStrategyOrderbook(strategy string, p currency.Pair) (orderbook.Base, error) {
+	f, err := strategyFeeds.Get(strategy, config.StrategyFeedOrderbook, p)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+
+	ob, err := orderbook.Get(f.Exchange, f.Pair, orderbook.Spot)
+	if err != nil {
+		return ob, err
+	}
+	rate := getFeedQuoteRate(f.Requested.Quote, f.Pair.Quote)
+	resp := ob
+	resp.Pair = f.Requested
+	resp.Bids = make([]orderbook.Item, len(ob.Bids))
+	resp.Asks = make([]orderbook.Item, len(ob.Asks))
+	for x := range ob.Bids {
+		resp.Bids[x] = ob.Bids[x]
+		resp.Bids[x].Price *= rate
+	}
+	for x := range ob.Asks {
+		resp.Asks[x] = ob.Asks[x]
+		resp.Asks[x].Price *= rate
+	}
+	return resp, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/mock"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+func TestResolveStrategyFeeds(t *testing.T) {
+	cfg := bot.config
+	bot.config = &config.Config{}
+	bot.config.StablecoinMonitor.Pairs = currency.Pairs{currency.NewPairFromStrings("USDT", "USD")}
+	defer func() {
+		bot.config = cfg
+		strategyFeeds.Load(nil)
+		stablecoinParity.Restore(nil)
+	}()
+
+	p := currency.NewPairFromStrings("BTC", "USD")
+	tetherPair := currency.NewPairFromStrings("BTC", "USDT")
+	preferred := mock.New("FeedTestPreferred")
+	preferred.Setup(&config.ExchangeConfig{Name: "FeedTestPreferred", Enabled: true,
+		EnabledPairs: currency.Pairs{p}})
+	alternative := mock.New("FeedTestAlternative")
+	alternative.Setup(&config.ExchangeConfig{Name: "FeedTestAlternative", Enabled: true,
+		EnabledPairs: currency.Pairs{tetherPair}})
+	exchanges := []exchange.IBotExchange{alternative, preferred}
+
+	err := ticker.ProcessTicker(alternative.GetName(),
+		&ticker.Price{Pair: tetherPair, Last: 10000, Bid: 9990, Ask: 10010}, ticker.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	strategyFeeds.Load([]config.StrategyFeedConfig{
+		{Strategy: "Grid", Feed: config.StrategyFeedTicker, Pair: p, Exchange: preferred.GetName()},
+		{Strategy: "grid", Feed: config.StrategyFeedOrderbook, Pair: currency.NewPairFromStrings("XRP", "USD"),
+			Exchange: config.StrategyFeedAnyExchange},
+	})
+	now := time.Unix(1000, 0)
+	if switches := strategyFeeds.Resolve(exchanges, now); len(switches) != 0 {
+		t.Errorf("Test failed. Expected no switch when first resolving feeds, got %+v", switches)
+	}
+	feeds := GetStrategyFeeds()
+	if feeds[0].Exchange != preferred.GetName() || !feeds[0].Available || feeds[1].Available {
+		t.Errorf("Test failed. Unexpected resolved feeds %+v", feeds)
+	}
+
+	for x := 0; x < endpointDegradedThreshold; x++ {
+		endpointHealth.Record(preferred.GetName(), pollTicker, errors.New("timeout"), now)
+	}
+	defer endpointHealth.Record(preferred.GetName(), pollTicker, nil, now)
+
+	switches := strategyFeeds.Resolve(exchanges, now.Add(time.Minute))
+	if len(switches) != 1 || switches[0].from != preferred.GetName() ||
+		switches[0].feed.Exchange != alternative.GetName() ||
+		!switches[0].feed.Pair.Equal(tetherPair) {
+		t.Fatalf("Test failed. Expected the feed switched to the alternative venue, got %+v",
+			switches)
+	}
+
+	stablecoinParity.Record(alternative.GetName(), bot.config.StablecoinMonitor.Pairs[0],
+		0.99, 0.5, now)
+	tick, err := GetStrategyTicker("grid", p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tick.Pair.Equal(p) || tick.Last != 9900 || tick.Bid != 9890.1 {
+		t.Errorf("Test failed. Expected the ticker converted into USD, got %+v", tick)
+	}
+
+	_, err = GetStrategyOrderbook("grid", currency.NewPairFromStrings("XRP", "USD"))
+	if err == nil {
+		t.Error("Test failed. Expected a feed without a venue to be unavailable")
+	}
+}
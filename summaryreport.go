@@ -0,0 +1,420 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	log "github.com/thrasher-/gocryptotrader/logger"
+	"github.com/thrasher-/gocryptotrader/portfolio"
+)
+
+const (
+	summaryReportBaselineFile = "summary_baseline.json"
+	summaryReportTimeFormat   = "2006-01-02 15:04 MST"
+)
+
+// PairMove is the price change of an exchange currency pair over a report
+// period, Change is a percentage
+type PairMove struct {
+	Exchange string        `json:"exchange"`
+	Pair     currency.Pair `json:"pair"`
+	Open     float64       `json:"open"`
+	Close    float64       `json:"close"`
+	Change   float64       `json:"change"`
+}
+
+// SummaryReport summarises the portfolio and trading activity over a period.
+// Values are in Currency, the portfolio change is unknown for the first report
+// as there is no previous portfolio value to compare against. Realized PnL
+// includes the rebates earned
+type SummaryReport struct {
+	From                   time.Time                 `json:"from"`
+	To                     time.Time                 `json:"to"`
+	Currency               currency.Code             `json:"currency"`
+	PortfolioValue         float64                   `json:"portfolioValue"`
+	PortfolioChange        float64                   `json:"portfolioChange"`
+	PortfolioChangePercent float64                   `json:"portfolioChangePercent"`
+	HasPreviousValue       bool                      `json:"hasPreviousValue"`
+	RealizedPnL            float64                   `json:"realizedPnl"`
+	FeesPaid               float64                   `json:"feesPaid"`
+	RebatesEarned          float64                   `json:"rebatesEarned"`
+	TopMovers              []PairMove                `json:"topMovers"`
+	Events                 []NotableEvent            `json:"events"`
+	WithdrawalFees         []WithdrawalFeeSuggestion `json:"withdrawalFees,omitempty"`
+	Unvalued               []currency.Code           `json:"unvalued,omitempty"`
+	Errors                 []string                  `json:"errors,omitempty"`
+}
+
+// summaryReportBaseline holds the portfolio value and pair prices of the last
+// report, which the next report compares against
+type summaryReportBaseline struct {
+	Generated      time.Time          `json:"generated"`
+	Currency       currency.Code      `json:"currency"`
+	PortfolioValue float64            `json:"portfolioValue"`
+	Prices         map[string]float64 `json:"prices"`
+}
+
+// summaryReportPriceKey returns the key of an exchange pair price
+func summaryReportPriceKey(exchName string, p currency.Pair) string {
+	return exchName + " " + p.Upper().String()
+}
+
+// runSummaryReportTask generates a summary report covering the period since
+// the previous report and sends it to the communication mediums
+func runSummaryReportTask(_ *config.ScheduledTaskConfig) error {
+	path := filepath.Join(bot.dataDir, portfolioReportDir, summaryReportBaselineFile)
+	baseline, err := loadSummaryReportBaseline(path)
+	if err != nil {
+		log.Warnf("Unable to load the previous summary report, reporting without it: %s", err)
+	}
+
+	report, next := generateSummaryReport(GetExchanges(), bot.portfolio, baseline,
+		bot.config.SummaryReport, clock.Now())
+	if bot.comms != nil {
+		bot.comms.PushEvent(base.Event{
+			Type:         "SUMMARY_REPORT",
+			TradeDetails: report.String(),
+		})
+	}
+
+	err = common.CreateDir(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	payload, err := common.JSONEncode(next)
+	if err != nil {
+		return err
+	}
+	return common.WriteFile(path, payload)
+}
+
+// loadSummaryReportBaseline returns the baseline saved by the last report, or
+// nil when there has been no report
+func loadSummaryReportBaseline(path string) (*summaryReportBaseline, error) {
+	data, err := common.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var baseline summaryReportBaseline
+	err = common.JSONDecode(data, &baseline)
+	if err != nil {
+		return nil, err
+	}
+	return &baseline, nil
+}
+
+// generateSummaryReport returns the report of the period since the baseline
+// and the baseline of the next report. Without a baseline the report covers
+// the last day
+func generateSummaryReport(exchanges []exchange.IBotExchange, p *portfolio.Base, baseline *summaryReportBaseline, cfg config.SummaryReportConfig, now time.Time) (SummaryReport, summaryReportBaseline) {
+	report := SummaryReport{
+		From:     now.Add(-time.Hour * 24),
+		To:       now,
+		Currency: cfg.Currency,
+	}
+	if baseline != nil && !baseline.Currency.Match(cfg.Currency) {
+		// Values in another currency cannot be compared
+		baseline = nil
+	}
+	if baseline != nil {
+		report.From = baseline.Generated
+	}
+	next := summaryReportBaseline{
+		Generated: now,
+		Currency:  cfg.Currency,
+		Prices:    make(map[string]float64),
+	}
+
+	if p != nil {
+		totals := p.GetPortfolioSummary().Totals
+		for x := range totals {
+			value, ok := getReportValue(exchanges, totals[x].Coin,
+				totals[x].Balance, cfg.Currency)
+			if !ok {
+				report.Unvalued = appendUnvalued(report.Unvalued, totals[x].Coin)
+				continue
+			}
+			report.PortfolioValue += value
+		}
+	}
+	next.PortfolioValue = report.PortfolioValue
+	if baseline != nil {
+		report.HasPreviousValue = true
+		report.PortfolioChange = report.PortfolioValue - baseline.PortfolioValue
+		if baseline.PortfolioValue != 0 {
+			report.PortfolioChangePercent = report.PortfolioChange /
+				baseline.PortfolioValue * 100
+		}
+	}
+
+	for _, exch := range exchanges {
+		if exch == nil || !exch.IsEnabled() {
+			continue
+		}
+
+		pairs := exch.GetEnabledCurrencies()
+		for x := range pairs {
+			price, err := ticker.GetTicker(exch.GetName(), pairs[x], ticker.Spot)
+			if err != nil || price.Last <= 0 {
+				continue
+			}
+			key := summaryReportPriceKey(exch.GetName(), pairs[x])
+			next.Prices[key] = price.Last
+			if baseline == nil || baseline.Prices[key] <= 0 {
+				continue
+			}
+			open := baseline.Prices[key]
+			report.TopMovers = append(report.TopMovers, PairMove{
+				Exchange: exch.GetName(),
+				Pair:     pairs[x],
+				Open:     open,
+				Close:    price.Last,
+				Change:   (price.Last - open) / open * 100,
+			})
+		}
+
+		history, ok := exch.(exchange.ITradeHistoryExchange)
+		if !ok || !exch.GetAuthenticatedAPISupport() {
+			continue
+		}
+		for x := range pairs {
+			trades, err := getAccountTradeHistory(history, pairs[x])
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("%s %s trade history: %s",
+					exch.GetName(), pairs[x], err))
+				continue
+			}
+			pnl, fees, earned := getRealizedPnL(trades, report.From, report.To)
+			if pnl != 0 {
+				value, ok := getReportValue(exchanges, pairs[x].Quote, pnl, cfg.Currency)
+				if !ok {
+					report.Unvalued = appendUnvalued(report.Unvalued, pairs[x].Quote)
+				}
+				report.RealizedPnL += value
+			}
+			for c, amount := range fees {
+				value, ok := getReportValue(exchanges, c, amount, cfg.Currency)
+				if !ok {
+					report.Unvalued = appendUnvalued(report.Unvalued, c)
+				}
+				report.FeesPaid += value
+			}
+			for c, amount := range earned {
+				value, ok := getReportValue(exchanges, c, amount, cfg.Currency)
+				if !ok {
+					report.Unvalued = appendUnvalued(report.Unvalued, c)
+				}
+				report.RebatesEarned += value
+			}
+		}
+	}
+
+	earned, errs := getReportedRebates(exchanges, report.From, report.To)
+	for c, amount := range earned {
+		value, ok := getReportValue(exchanges, c, amount, cfg.Currency)
+		if !ok {
+			report.Unvalued = appendUnvalued(report.Unvalued, c)
+		}
+		report.RebatesEarned += value
+	}
+	report.RealizedPnL += report.RebatesEarned
+	report.Errors = append(report.Errors, errs...)
+
+	sort.Slice(report.TopMovers, func(i, j int) bool {
+		return math.Abs(report.TopMovers[i].Change) > math.Abs(report.TopMovers[j].Change)
+	})
+	if len(report.TopMovers) > cfg.TopMovers {
+		report.TopMovers = report.TopMovers[:cfg.TopMovers]
+	}
+
+	suggestions, errs := getWithdrawalFeeSuggestions(exchanges, report.From,
+		report.To, cfg.MinWithdrawalFeeSaving, cfg.Currency)
+	report.WithdrawalFees = suggestions
+	report.Errors = append(report.Errors, errs...)
+
+	report.Events = notableEvents.Between(report.From, report.To)
+	if len(report.Events) > cfg.MaxEvents {
+		report.Events = report.Events[len(report.Events)-cfg.MaxEvents:]
+	}
+	return report, next
+}
+
+// appendUnvalued adds a currency to the unvalued currencies once
+func appendUnvalued(unvalued []currency.Code, c currency.Code) []currency.Code {
+	if currency.Currencies(unvalued).Contains(c) {
+		return unvalued
+	}
+	return append(unvalued, c)
+}
+
+// getReportValue returns the value of an amount of a currency in the report
+// currency, using the last stored ticker price of a pair between them on any
+// enabled exchange
+func getReportValue(exchanges []exchange.IBotExchange, c currency.Code, amount float64, target currency.Code) (float64, bool) {
+	if c.Match(target) {
+		return amount, true
+	}
+	for _, exch := range exchanges {
+		if exch == nil || !exch.IsEnabled() {
+			continue
+		}
+		price, err := ticker.GetTicker(exch.GetName(),
+			currency.NewPair(c, target), ticker.Spot)
+		if err == nil && price.Last > 0 {
+			return amount * price.Last, true
+		}
+		price, err = ticker.GetTicker(exch.GetName(),
+			currency.NewPair(target, c), ticker.Spot)
+		if err == nil && price.Last > 0 {
+			return amount / price.Last, true
+		}
+	}
+	return 0, false
+}
+
+// getAccountTradeHistory returns the complete authenticated trade history of
+// a currency pair
+func getAccountTradeHistory(history exchange.ITradeHistoryExchange, p currency.Pair) ([]exchange.TradeHistory, error) {
+	var resp []exchange.TradeHistory
+	var fromID int64
+	for {
+		trades, err := history.GetAccountTradeHistory(p, fromID)
+		if err != nil {
+			return resp, err
+		}
+
+		pageStart := fromID
+		for x := range trades {
+			// Guard against exchanges ignoring the requested position
+			if trades[x].TID <= fromID {
+				continue
+			}
+			resp = append(resp, trades[x])
+			fromID = trades[x].TID
+		}
+		if fromID == pageStart {
+			return resp, nil
+		}
+		clock.Sleep(tradeExportPageDelay)
+	}
+}
+
+// getRealizedPnL returns the profit realised in the quote currency by the
+// sells of a pair within a period using the average cost of its earlier buys,
+// and the fees paid and maker rebates earned within the period by currency.
+// Negative trade fees are maker rebates
+func getRealizedPnL(trades []exchange.TradeHistory, from, to time.Time) (float64, map[currency.Code]float64, map[currency.Code]float64) {
+	sorted := append([]exchange.TradeHistory(nil), trades...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	var pnl, held, cost float64
+	fees := make(map[currency.Code]float64)
+	rebates := make(map[currency.Code]float64)
+	for x := range sorted {
+		if sorted[x].Timestamp.After(to) {
+			break
+		}
+		inPeriod := !sorted[x].Timestamp.Before(from)
+		if inPeriod && !sorted[x].FeeAsset.IsEmpty() {
+			if sorted[x].Fee > 0 {
+				fees[sorted[x].FeeAsset] += sorted[x].Fee
+			} else if sorted[x].Fee < 0 {
+				rebates[sorted[x].FeeAsset] -= sorted[x].Fee
+			}
+		}
+
+		switch exchange.OrderSide(strings.ToUpper(sorted[x].Type)) {
+		case exchange.BuyOrderSide:
+			held += sorted[x].Amount
+			cost += sorted[x].Amount * sorted[x].Price
+		case exchange.SellOrderSide:
+			// Sells without earlier buys, such as transferred in funds, have
+			// no known cost so only reduce the amount held
+			amount := math.Min(sorted[x].Amount, held)
+			if amount <= 0 {
+				continue
+			}
+			average := cost / held
+			if inPeriod {
+				pnl += (sorted[x].Price - average) * amount
+			}
+			held -= amount
+			cost -= average * amount
+		}
+	}
+	return pnl, fees, rebates
+}
+
+// String returns the report formatted for the communication mediums
+func (s *SummaryReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "GoCryptoTrader summary %s to %s\n",
+		s.From.Format(summaryReportTimeFormat), s.To.Format(summaryReportTimeFormat))
+
+	fmt.Fprintf(&b, "Portfolio value: %.2f %s", s.PortfolioValue, s.Currency)
+	if s.HasPreviousValue {
+		fmt.Fprintf(&b, " (%+.2f %s, %+.2f%%)", s.PortfolioChange, s.Currency,
+			s.PortfolioChangePercent)
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "Realized PnL: %+.2f %s\n", s.RealizedPnL, s.Currency)
+	fmt.Fprintf(&b, "Fees paid: %.2f %s\n", s.FeesPaid, s.Currency)
+	if s.RebatesEarned != 0 {
+		fmt.Fprintf(&b, "Rebates earned: %.2f %s\n", s.RebatesEarned, s.Currency)
+	}
+	if len(s.Unvalued) > 0 {
+		fmt.Fprintf(&b, "Not valued: %s\n", currency.Currencies(s.Unvalued).Join())
+	}
+
+	if len(s.TopMovers) > 0 {
+		b.WriteString("Top movers:\n")
+		for x := range s.TopMovers {
+			fmt.Fprintf(&b, "  %s %s %+.2f%% (%f -> %f)\n", s.TopMovers[x].Exchange,
+				s.TopMovers[x].Pair, s.TopMovers[x].Change, s.TopMovers[x].Open,
+				s.TopMovers[x].Close)
+		}
+	}
+
+	if len(s.WithdrawalFees) > 0 {
+		b.WriteString("Withdrawal fee suggestions:\n")
+		for x := range s.WithdrawalFees {
+			fmt.Fprintf(&b, "  %s", s.WithdrawalFees[x].String())
+			if s.WithdrawalFees[x].Valued {
+				fmt.Fprintf(&b, " (saving %.2f %s)", s.WithdrawalFees[x].Value, s.Currency)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(s.Events) > 0 {
+		b.WriteString("Notable events:\n")
+		for x := range s.Events {
+			fmt.Fprintf(&b, "  %s %s: %s\n",
+				s.Events[x].Time.Format(summaryReportTimeFormat), s.Events[x].Type,
+				s.Events[x].Message)
+		}
+	}
+
+	for x := range s.Errors {
+		fmt.Fprintf(&b, "Error: %s\n", s.Errors[x])
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/portfolio"
+)
+
+type summaryReportTestExchange struct {
+	accountInfoTestExchange
+	tradeHistoryTestExchange
+	pairs currency.Pairs
+}
+
+func (s *summaryReportTestExchange) IsEnabled() bool {
+	return true
+}
+
+func (s *summaryReportTestExchange) GetAuthenticatedAPISupport() bool {
+	return true
+}
+
+func (s *summaryReportTestExchange) GetEnabledCurrencies() currency.Pairs {
+	return s.pairs
+}
+
+func (s *summaryReportTestExchange) GetFundingHistory() ([]exchange.FundHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+func TestGetRealizedPnL(t *testing.T) {
+	start := time.Unix(1000, 0)
+	trades := []exchange.TradeHistory{
+		{Timestamp: start.Add(time.Minute * 3), Type: "SELL", Price: 300, Amount: 1,
+			Fee: 0.5, FeeAsset: currency.USDT},
+		{Timestamp: start.Add(time.Minute), Type: "BUY", Price: 100, Amount: 1,
+			Fee: 0.1, FeeAsset: currency.USDT},
+		{Timestamp: start.Add(time.Minute * 2), Type: "buy", Price: 200, Amount: 1},
+		// Only the held amount has a known cost
+		{Timestamp: start.Add(time.Minute * 4), Type: "SELL", Price: 400, Amount: 2,
+			Fee: 0.001, FeeAsset: currency.BNB},
+		// Negative fees are maker rebates
+		{Timestamp: start.Add(time.Minute * 5), Type: "BUY", Price: 450, Amount: 1,
+			Fee: -0.2, FeeAsset: currency.USDT},
+		{Timestamp: start.Add(time.Minute * 10), Type: "SELL", Price: 500, Amount: 1},
+	}
+
+	pnl, fees, earned := getRealizedPnL(trades, start.Add(time.Minute*3), start.Add(time.Minute*5))
+	if pnl != 400 {
+		t.Errorf("Test failed. Expected realized PnL 400, got %f", pnl)
+	}
+	if len(fees) != 2 || fees[currency.USDT] != 0.5 || fees[currency.BNB] != 0.001 {
+		t.Errorf("Test failed. Unexpected fees %v", fees)
+	}
+	if len(earned) != 1 || earned[currency.USDT] != 0.2 {
+		t.Errorf("Test failed. Unexpected rebates %v", earned)
+	}
+}
+
+func TestGenerateSummaryReport(t *testing.T) {
+	tradeExportPageDelay = 0
+	now := time.Date(2019, 6, 2, 0, 0, 0, 0, time.UTC)
+	btcusd := currency.NewPairFromStrings("BTC", "USD")
+	exch := &summaryReportTestExchange{
+		accountInfoTestExchange: accountInfoTestExchange{name: "SummaryTest"},
+		tradeHistoryTestExchange: tradeHistoryTestExchange{
+			pageSize: 10,
+			trades: []exchange.TradeHistory{
+				{TID: 1, Timestamp: now.Add(-time.Hour * 48), Type: "BUY", Price: 10000,
+					Amount: 1},
+				{TID: 2, Timestamp: now.Add(-time.Hour), Type: "SELL", Price: 11000,
+					Amount: 1, Fee: 5, FeeAsset: currency.USD},
+			},
+		},
+		pairs: currency.Pairs{btcusd},
+	}
+	err := ticker.ProcessTicker(exch.GetName(),
+		&ticker.Price{Pair: btcusd, Last: 11000}, ticker.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &portfolio.Base{}
+	p.AddAddress("1JCe8z4jJVNXSjohjM4i9Hh813dLCNx2Sy", "test", currency.BTC, 2)
+	p.AddAddress("0xb794f5ea0ba39494ce839613fffba74279579268", "test", currency.XRP, 1)
+
+	notableEvents.Record("TEST", "before the report period", now.Add(-time.Hour*48))
+	notableEvents.Record("TEST", "within the report period", now.Add(-time.Hour))
+
+	cfg := config.SummaryReportConfig{Currency: currency.USD, TopMovers: 5, MaxEvents: 10}
+	key := summaryReportPriceKey(exch.GetName(), btcusd)
+	baseline := &summaryReportBaseline{
+		Generated:      now.Add(-time.Hour * 24),
+		Currency:       currency.USD,
+		PortfolioValue: 20000,
+		Prices:         map[string]float64{key: 10000},
+	}
+
+	report, next := generateSummaryReport([]exchange.IBotExchange{exch}, p,
+		baseline, cfg, now)
+	if report.PortfolioValue != 22000 || report.PortfolioChange != 2000 ||
+		report.PortfolioChangePercent != 10 {
+		t.Errorf("Test failed. Unexpected portfolio value %f change %f %f%%",
+			report.PortfolioValue, report.PortfolioChange, report.PortfolioChangePercent)
+	}
+	if len(report.Unvalued) != 1 || report.Unvalued[0] != currency.XRP {
+		t.Errorf("Test failed. Expected XRP to be unvalued, got %v", report.Unvalued)
+	}
+	if report.RealizedPnL != 1000 || report.FeesPaid != 5 {
+		t.Errorf("Test failed. Unexpected realized PnL %f fees %f",
+			report.RealizedPnL, report.FeesPaid)
+	}
+	if len(report.TopMovers) != 1 || report.TopMovers[0].Change != 10 {
+		t.Errorf("Test failed. Unexpected top movers %v", report.TopMovers)
+	}
+	if len(report.Events) != 1 || report.Events[0].Message != "within the report period" {
+		t.Errorf("Test failed. Unexpected events %v", report.Events)
+	}
+	if next.PortfolioValue != 22000 || next.Prices[key] != 11000 || !next.Generated.Equal(now) {
+		t.Errorf("Test failed. Unexpected next baseline %+v", next)
+	}
+
+	s := report.String()
+	for _, expected := range []string{
+		"GoCryptoTrader summary 2019-06-01 00:00 UTC to 2019-06-02 00:00 UTC",
+		"Portfolio value: 22000.00 USD (+2000.00 USD, +10.00%)",
+		"Realized PnL: +1000.00 USD",
+		"SummaryTest BTCUSD +10.00%",
+		"TEST: within the report period",
+	} {
+		if !strings.Contains(s, expected) {
+			t.Errorf("Test failed. Report missing %q:\n%s", expected, s)
+		}
+	}
+
+	// A baseline in another currency cannot be compared against
+	cfg.Currency = currency.EUR
+	report, _ = generateSummaryReport([]exchange.IBotExchange{exch}, p, baseline,
+		cfg, now)
+	if report.HasPreviousValue || len(report.TopMovers) != 0 {
+		t.Error("Test failed. Expected the baseline to be ignored")
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/i18n"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const (
+	systemStatusEventType = "SYSTEM_STATUS"
+	systemStatusInterval  = time.Minute * 5
+)
+
+// ExchangeSystemStatus is the last system status published by an exchange
+type ExchangeSystemStatus struct {
+	Exchange string    `json:"exchange"`
+	Status   string    `json:"status"`
+	Message  string    `json:"message,omitempty"`
+	Checked  time.Time `json:"checked"`
+}
+
+// rateLimitApplier is implemented by exchanges whose request rate limiters can
+// be lowered to their published limits
+type rateLimitApplier interface {
+	ApplyRateLimits(limits []exchange.RateLimit) bool
+}
+
+// systemStatusMonitor holds the last system status of each exchange keyed by
+// exchange name
+type systemStatusMonitor struct {
+	statuses map[string]ExchangeSystemStatus
+	m        sync.Mutex
+}
+
+var systemStatuses systemStatusMonitor
+
+// Update records an exchanges system status. It returns true when the status
+// changed, exchanges are assumed operational until a status is known
+func (s *systemStatusMonitor) Update(status ExchangeSystemStatus) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.statuses == nil {
+		s.statuses = make(map[string]ExchangeSystemStatus)
+	}
+
+	previous, ok := s.statuses[status.Exchange]
+	if !ok {
+		previous.Status = exchange.SystemStatusOperational
+	}
+	s.statuses[status.Exchange] = status
+	return previous.Status != status.Status
+}
+
+// IsUnderMaintenance returns whether an exchanges last system status was
+// maintenance
+func (s *systemStatusMonitor) IsUnderMaintenance(exchName string) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.statuses[exchName].Status == exchange.SystemStatusMaintenance
+}
+
+// GetAll returns the last system status of each exchange ordered by exchange
+func (s *systemStatusMonitor) GetAll() []ExchangeSystemStatus {
+	s.m.Lock()
+	defer s.m.Unlock()
+	resp := make([]ExchangeSystemStatus, 0, len(s.statuses))
+	for _, status := range s.statuses {
+		resp = append(resp, status)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Exchange < resp[j].Exchange
+	})
+	return resp
+}
+
+// SystemStatusRoutine lowers the rate limiters of exchanges to their published
+// request limits, then periodically checks the system status they publish
+func SystemStatusRoutine() {
+	log.Debugln("Starting system status routine.")
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		if exchanges[x] == nil || !exchanges[x].IsEnabled() {
+			continue
+		}
+		applyPublishedRateLimits(exchanges[x])
+	}
+
+	for {
+		exchanges := GetExchanges()
+		for x := range exchanges {
+			if exchanges[x] == nil || !exchanges[x].IsEnabled() {
+				continue
+			}
+			checkSystemStatus(exchanges[x], clock.Now())
+		}
+		clock.Sleep(systemStatusInterval)
+	}
+}
+
+// applyPublishedRateLimits lowers an exchanges request rate limiters to the
+// request limits it publishes when they are stricter
+func applyPublishedRateLimits(exch exchange.IBotExchange) {
+	publisher, ok := exch.(exchange.IRateLimitsExchange)
+	if !ok {
+		return
+	}
+	applier, ok := exch.(rateLimitApplier)
+	if !ok {
+		return
+	}
+
+	limits, err := publisher.GetRateLimits()
+	if err != nil {
+		log.Debugf("%s failed to get published rate limits. Error: %s",
+			exch.GetName(), err)
+		return
+	}
+	if applier.ApplyRateLimits(limits) {
+		log.Infof("%s rate limiter lowered to its published request limits.",
+			exch.GetName())
+	}
+}
+
+// checkSystemStatus records the system status published by an exchange,
+// notifying when it enters or leaves maintenance
+func checkSystemStatus(exch exchange.IBotExchange, now time.Time) {
+	reporter, ok := exch.(exchange.ISystemStatusExchange)
+	if !ok {
+		return
+	}
+
+	published, err := reporter.GetSystemStatus()
+	if err != nil {
+		log.Debugf("%s failed to get system status. Error: %s",
+			exch.GetName(), err)
+		return
+	}
+
+	status := ExchangeSystemStatus{
+		Exchange: exch.GetName(),
+		Status:   published.Status,
+		Message:  published.Message,
+		Checked:  now,
+	}
+	if systemStatuses.Update(status) {
+		notifySystemStatus(&status)
+	}
+}
+
+// notifySystemStatus logs and pushes an exchange system status change through
+// the communications package
+func notifySystemStatus(status *ExchangeSystemStatus) {
+	maintenance := status.Status == exchange.SystemStatusMaintenance
+	message := i18n.T(i18n.MessageSystemOperational, status.Exchange)
+	if maintenance {
+		message = i18n.T(i18n.MessageSystemMaintenance, status.Exchange)
+	}
+	if status.Message != "" {
+		message += ": " + status.Message
+	}
+
+	if maintenance {
+		log.Warn(message)
+	} else {
+		log.Info(message)
+	}
+	pushEvent(systemStatusEventType, message)
+}
+
+// GetSystemStatuses returns the last system status published by each exchange
+func GetSystemStatuses() []ExchangeSystemStatus {
+	return systemStatuses.GetAll()
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type systemStatusTestExchange struct {
+	accountInfoTestExchange
+	status  exchange.SystemStatus
+	limits  []exchange.RateLimit
+	applied []exchange.RateLimit
+}
+
+func (s *systemStatusTestExchange) GetSystemStatus() (exchange.SystemStatus, error) {
+	return s.status, nil
+}
+
+func (s *systemStatusTestExchange) GetRateLimits() ([]exchange.RateLimit, error) {
+	return s.limits, nil
+}
+
+func (s *systemStatusTestExchange) ApplyRateLimits(limits []exchange.RateLimit) bool {
+	s.applied = limits
+	return len(limits) > 0
+}
+
+func TestCheckSystemStatus(t *testing.T) {
+	systemStatuses = systemStatusMonitor{}
+	defer func() { systemStatuses = systemStatusMonitor{} }()
+
+	exch := &systemStatusTestExchange{
+		accountInfoTestExchange: accountInfoTestExchange{name: "SystemStatusTest"},
+		status:                  exchange.SystemStatus{Status: exchange.SystemStatusOperational},
+	}
+	now := time.Unix(1000, 0)
+	checkSystemStatus(exch, now)
+
+	exch.status = exchange.SystemStatus{
+		Status:  exchange.SystemStatusMaintenance,
+		Message: "system upgrade",
+	}
+	checkSystemStatus(exch, now.Add(time.Minute))
+
+	statuses := GetSystemStatuses()
+	if len(statuses) != 1 || statuses[0].Status != exchange.SystemStatusMaintenance ||
+		statuses[0].Message != "system upgrade" || !statuses[0].Checked.Equal(now.Add(time.Minute)) {
+		t.Errorf("Test failed. Unexpected system statuses %+v", statuses)
+	}
+
+	// Exchanges which don't publish a status are not tracked
+	checkSystemStatus(&accountInfoTestExchange{name: "Unpublished"}, now)
+	if len(GetSystemStatuses()) != 1 {
+		t.Error("Test failed. Expected exchanges without a system status to be ignored")
+	}
+}
+
+func TestSystemStatusUpdate(t *testing.T) {
+	var s systemStatusMonitor
+	if s.Update(ExchangeSystemStatus{Exchange: "Bitstamp",
+		Status: exchange.SystemStatusOperational}) {
+		t.Error("Test failed. Expected an operational first status not to be a change")
+	}
+	if !s.Update(ExchangeSystemStatus{Exchange: "Bitstamp",
+		Status: exchange.SystemStatusMaintenance}) {
+		t.Error("Test failed. Expected maintenance to be a change")
+	}
+	if !s.Update(ExchangeSystemStatus{Exchange: "Bitstamp",
+		Status: exchange.SystemStatusOperational}) {
+		t.Error("Test failed. Expected recovering to be a change")
+	}
+}
+
+func TestApplyPublishedRateLimits(t *testing.T) {
+	exch := &systemStatusTestExchange{
+		accountInfoTestExchange: accountInfoTestExchange{name: "RateLimitTest"},
+		limits: []exchange.RateLimit{
+			{Type: exchange.RateLimitRequests, Interval: time.Minute, Limit: 1200},
+		},
+	}
+	applyPublishedRateLimits(exch)
+	if len(exch.applied) != 1 || exch.applied[0].Limit != 1200 {
+		t.Errorf("Test failed. Expected the published limits to be applied, got %+v",
+			exch.applied)
+	}
+}
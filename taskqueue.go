@@ -0,0 +1,223 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// Task priorities, higher priority tasks are processed first and evict lower
+// priority tasks when the queue is full
+const (
+	TaskPriorityLow = iota
+	TaskPriorityNormal
+	TaskPriorityHigh
+
+	taskPriorities = 3
+)
+
+// task is a unit of work queued by a data producer. Tasks with a key are merged
+// with the pending task of the same key, so only the latest data is processed.
+// Market data tasks are low priority and dropped once stale
+type task struct {
+	priority int
+	key      string
+	run      func()
+	enqueued time.Time
+}
+
+// TaskQueueStats holds the number of tasks pending at each priority and the
+// number of tasks queued, processed, merged and dropped since starting
+type TaskQueueStats struct {
+	Capacity  int    `json:"capacity"`
+	Pending   [3]int `json:"pending"`
+	Queued    int64  `json:"queued"`
+	Processed int64  `json:"processed"`
+	Merged    int64  `json:"merged"`
+	Dropped   int64  `json:"dropped"`
+}
+
+// taskQueue is a bounded priority queue between data producers and
+// consumers. When full, a task evicts the oldest pending task of a lower
+// priority, low priority tasks are dropped when there is none and higher
+// priority producers block until a consumer frees space
+type taskQueue struct {
+	pending  [taskPriorities][]*task
+	keyed    map[string]*task
+	capacity int
+	maxAge   time.Duration
+	stats    TaskQueueStats
+	cond     *sync.Cond
+	m        sync.Mutex
+}
+
+// internalTasks is the running task queue, tasks are run by their producer
+// when it isn't started
+var internalTasks *taskQueue
+
+// newTaskQueue returns a task queue bounded to capacity pending tasks. Low
+// priority tasks pending longer than maxAge are dropped
+func newTaskQueue(capacity int, maxAge time.Duration) *taskQueue {
+	q := &taskQueue{
+		keyed:    make(map[string]*task),
+		capacity: capacity,
+		maxAge:   maxAge,
+	}
+	q.stats.Capacity = capacity
+	q.cond = sync.NewCond(&q.m)
+	return q
+}
+
+// size returns the number of pending tasks. Must be called with the lock held
+func (q *taskQueue) size() int {
+	var resp int
+	for x := range q.pending {
+		resp += len(q.pending[x])
+	}
+	return resp
+}
+
+// evict drops the oldest pending task below a priority, returning false when
+// there is none. Must be called with the lock held
+func (q *taskQueue) evict(priority int) bool {
+	for x := 0; x < priority; x++ {
+		if len(q.pending[x]) == 0 {
+			continue
+		}
+		q.remove(q.pending[x][0])
+		q.pending[x] = q.pending[x][1:]
+		q.stats.Dropped++
+		return true
+	}
+	return false
+}
+
+// remove forgets the key of a task leaving the queue. Must be called with the
+// lock held
+func (q *taskQueue) remove(t *task) {
+	if t.key != "" && q.keyed[t.key] == t {
+		delete(q.keyed, t.key)
+	}
+}
+
+// Push queues a task. A task with the key of a pending task replaces its work
+// and keeps its place in the queue. It returns false when the task was dropped
+func (q *taskQueue) Push(priority int, key string, run func()) bool {
+	if priority < TaskPriorityLow || priority > TaskPriorityHigh {
+		priority = TaskPriorityNormal
+	}
+
+	q.m.Lock()
+	defer q.m.Unlock()
+	now := clock.Now()
+	if key != "" {
+		if pending, ok := q.keyed[key]; ok && pending.priority == priority {
+			pending.run = run
+			pending.enqueued = now
+			q.stats.Merged++
+			return true
+		}
+	}
+
+	for q.size() >= q.capacity {
+		if q.evict(priority) {
+			continue
+		}
+		if priority == TaskPriorityLow {
+			q.stats.Dropped++
+			return false
+		}
+		q.cond.Wait()
+	}
+
+	t := &task{priority: priority, key: key, run: run, enqueued: now}
+	q.pending[priority] = append(q.pending[priority], t)
+	if key != "" {
+		q.keyed[key] = t
+	}
+	q.stats.Queued++
+	q.cond.Broadcast()
+	return true
+}
+
+// Pop blocks until a task is pending and returns the oldest task of the
+// highest priority. Stale low priority tasks are dropped
+func (q *taskQueue) Pop() *task {
+	q.m.Lock()
+	defer q.m.Unlock()
+	for {
+		for x := TaskPriorityHigh; x >= TaskPriorityLow; x-- {
+			for len(q.pending[x]) > 0 {
+				t := q.pending[x][0]
+				q.pending[x] = q.pending[x][1:]
+				q.remove(t)
+				q.cond.Broadcast()
+				if x == TaskPriorityLow && q.maxAge > 0 &&
+					clock.Now().Sub(t.enqueued) > q.maxAge {
+					q.stats.Dropped++
+					continue
+				}
+				return t
+			}
+		}
+		q.cond.Wait()
+	}
+}
+
+// done records a processed task
+func (q *taskQueue) done() {
+	q.m.Lock()
+	q.stats.Processed++
+	q.m.Unlock()
+}
+
+// GetStats returns the pending tasks and totals of the queue
+func (q *taskQueue) GetStats() TaskQueueStats {
+	q.m.Lock()
+	defer q.m.Unlock()
+	stats := q.stats
+	for x := range q.pending {
+		stats.Pending[x] = len(q.pending[x])
+	}
+	return stats
+}
+
+// work runs queued tasks until the process exits
+func (q *taskQueue) work() {
+	for {
+		t := q.Pop()
+		t.run()
+		q.done()
+	}
+}
+
+// StartTaskQueue starts the task queue and its consumers
+func StartTaskQueue(capacity, workers int, maxAge time.Duration) {
+	log.Debugf("Starting task queue with %d workers.", workers)
+	q := newTaskQueue(capacity, maxAge)
+	for x := 0; x < workers; x++ {
+		go q.work()
+	}
+	internalTasks = q
+}
+
+// queueTask queues a task on the running task queue, running it immediately
+// when the queue isn't started
+func queueTask(priority int, key string, run func()) {
+	if internalTasks == nil {
+		run()
+		return
+	}
+	internalTasks.Push(priority, key, run)
+}
+
+// GetTaskQueueStats returns the task queue stats, which are empty when the
+// queue isn't started
+func GetTaskQueueStats() TaskQueueStats {
+	if internalTasks == nil {
+		return TaskQueueStats{}
+	}
+	return internalTasks.GetStats()
+}
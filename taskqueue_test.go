@@ -0,0 +1,123 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+)
+
+func TestTaskQueuePriority(t *testing.T) {
+	q := newTaskQueue(10, 0)
+	var order []string
+	q.Push(TaskPriorityLow, "", func() { order = append(order, "low") })
+	q.Push(TaskPriorityNormal, "", func() { order = append(order, "normal") })
+	q.Push(TaskPriorityHigh, "", func() { order = append(order, "high") })
+
+	for x := 0; x < 3; x++ {
+		q.Pop().run()
+	}
+	if order[0] != "high" || order[1] != "normal" || order[2] != "low" {
+		t.Errorf("Test failed. Expected tasks in priority order, got %v", order)
+	}
+}
+
+func TestTaskQueueMerge(t *testing.T) {
+	q := newTaskQueue(10, 0)
+	var last int
+	for x := 1; x <= 3; x++ {
+		price := x
+		q.Push(TaskPriorityLow, "ticker|Test|BTCUSD", func() { last = price })
+	}
+	q.Push(TaskPriorityLow, "ticker|Test|ETHUSD", func() {})
+
+	stats := q.GetStats()
+	if stats.Pending[TaskPriorityLow] != 2 || stats.Merged != 2 {
+		t.Fatalf("Test failed. Expected 2 pending and 2 merged tasks, got %+v", stats)
+	}
+	q.Pop().run()
+	if last != 3 {
+		t.Errorf("Test failed. Expected the latest merged task to run, got %d", last)
+	}
+
+	// Once processed, a key is queued again rather than merged
+	q.Push(TaskPriorityLow, "ticker|Test|BTCUSD", func() {})
+	if q.GetStats().Pending[TaskPriorityLow] != 2 {
+		t.Error("Test failed. Expected a processed key to be queued again")
+	}
+}
+
+func TestTaskQueueFull(t *testing.T) {
+	q := newTaskQueue(2, 0)
+	q.Push(TaskPriorityLow, "", func() {})
+	q.Push(TaskPriorityNormal, "", func() {})
+
+	if q.Push(TaskPriorityLow, "", func() {}) {
+		t.Error("Test failed. Expected a low priority task to be dropped when full")
+	}
+	if !q.Push(TaskPriorityHigh, "", func() {}) {
+		t.Error("Test failed. Expected a high priority task to evict a lower priority task")
+	}
+
+	stats := q.GetStats()
+	if stats.Pending[TaskPriorityLow] != 0 || stats.Pending[TaskPriorityNormal] != 1 ||
+		stats.Pending[TaskPriorityHigh] != 1 || stats.Dropped != 2 {
+		t.Fatalf("Test failed. Unexpected stats %+v", stats)
+	}
+
+	// Without lower priority tasks to evict, producers block until a task is
+	// consumed
+	q.Push(TaskPriorityHigh, "", func() {})
+	pushed := make(chan struct{})
+	go func() {
+		q.Push(TaskPriorityHigh, "", func() {})
+		close(pushed)
+	}()
+	select {
+	case <-pushed:
+		t.Fatal("Test failed. Expected the producer to block while the queue is full")
+	case <-time.After(time.Millisecond * 50):
+	}
+	q.Pop()
+	select {
+	case <-pushed:
+	case <-time.After(time.Second):
+		t.Fatal("Test failed. Expected the producer to resume once a task was consumed")
+	}
+}
+
+func TestTaskQueueStale(t *testing.T) {
+	sim := clock.NewSimulated(time.Unix(1000, 0))
+	clock.Set(sim)
+	defer clock.Set(nil)
+
+	q := newTaskQueue(10, time.Second)
+	var ran string
+	q.Push(TaskPriorityLow, "", func() { ran = "stale" })
+	q.Push(TaskPriorityNormal, "", func() { ran = "normal" })
+	sim.Advance(time.Second * 2)
+	q.Push(TaskPriorityLow, "", func() { ran = "fresh" })
+
+	q.Pop().run()
+	if ran != "normal" {
+		t.Errorf("Test failed. Expected normal priority tasks never to go stale, got %s", ran)
+	}
+	q.Pop().run()
+	if ran != "fresh" {
+		t.Errorf("Test failed. Expected the stale task to be dropped, got %s", ran)
+	}
+	if q.GetStats().Dropped != 1 {
+		t.Error("Test failed. Expected the stale task to be counted as dropped")
+	}
+}
+
+func TestQueueTask(t *testing.T) {
+	var ran bool
+	queueTask(TaskPriorityNormal, "", func() { ran = true })
+	if !ran {
+		t.Error("Test failed. Expected tasks to run inline without a task queue")
+	}
+	if GetTaskQueueStats().Capacity != 0 {
+		t.Error("Test failed. Expected empty stats without a task queue")
+	}
+}
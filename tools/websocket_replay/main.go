@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	// Registers the supported exchanges
+	_ "github.com/thrasher-/gocryptotrader/exchanges/all"
+)
+
+// supportsReplay returns whether a registered exchange supports websocket
+// replay
+func supportsReplay(r *exchange.Registration) bool {
+	_, ok := r.Creator().(exchange.IWebsocketReplayExchange)
+	return ok
+}
+
+func supportedExchanges() []string {
+	var resp []string
+	registrations := exchange.GetRegistrations()
+	for x := range registrations {
+		if supportsReplay(&registrations[x]) {
+			resp = append(resp, common.StringToLower(registrations[x].Name))
+		}
+	}
+	sort.Strings(resp)
+	return resp
+}
+
+func main() {
+	var exchName, captureFile string
+	var verbose bool
+
+	flag.StringVar(&exchName, "exchange", "", "The exchange the capture was recorded from.")
+	flag.StringVar(&captureFile, "capture", "", "The websocket capture file to replay.")
+	flag.BoolVar(&verbose, "verbose", false, "Print the data parsed from every message.")
+	flag.Parse()
+
+	log.Println("GoCryptoTrader: websocket replay tool.")
+
+	registration, ok := exchange.GetRegistration(exchName)
+	if !ok || !supportsReplay(&registration) {
+		log.Fatalf("Exchange %q does not support replay, supported exchanges: %s",
+			exchName, common.JoinStrings(supportedExchanges(), ", "))
+	}
+
+	messages, err := exchange.ReadWebsocketCapture(captureFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Loaded %d captured messages.", len(messages))
+
+	exch := registration.Creator()
+	exch.SetDefaults()
+	results, err := exchange.ReplayWebsocketCapture(exch, messages)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var failures int
+	for x := range results {
+		if results[x].Error != nil {
+			failures++
+			log.Printf("Message %d captured %s failed: %s\n\t%s", x+1,
+				results[x].Message.Timestamp, results[x].Error,
+				results[x].Message.Raw)
+			continue
+		}
+
+		if verbose {
+			log.Printf("Message %d captured %s parsed:", x+1,
+				results[x].Message.Timestamp)
+			for y := range results[x].Data {
+				log.Printf("\t%T %+v", results[x].Data[y], results[x].Data[y])
+			}
+			for y := range results[x].Orderbooks {
+				log.Printf("\torderbook snapshot %s %d bids %d asks",
+					results[x].Orderbooks[y].Pair, len(results[x].Orderbooks[y].Bids),
+					len(results[x].Orderbooks[y].Asks))
+			}
+		}
+	}
+
+	log.Printf("Replayed %d messages, %d failed.", len(results), failures)
+	if failures > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// tradeCandleAggregator tracks the exchanges which stream their own websocket
+// candles, trades are only aggregated into candles for exchanges without them
+type tradeCandleAggregator struct {
+	native map[string]bool
+	m      sync.Mutex
+}
+
+var tradeCandles tradeCandleAggregator
+
+// SetNative records that an exchange streams its own candles
+func (t *tradeCandleAggregator) SetNative(exchName string) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if t.native == nil {
+		t.native = make(map[string]bool)
+	}
+	t.native[exchName] = true
+}
+
+// IsNative returns whether an exchange streams its own candles
+func (t *tradeCandleAggregator) IsNative(exchName string) bool {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return t.native[exchName]
+}
+
+// aggregateTrade builds candles at the configured trade intervals from a
+// websocket trade of an exchange without kline endpoints or websocket candles
+func aggregateTrade(d *exchange.TradeData) {
+	intervals := bot.config.KlineStorage.TradeIntervals
+	if len(intervals) == 0 || tradeCandles.IsNative(d.Exchange) {
+		return
+	}
+	exch := GetExchangeByName(d.Exchange)
+	if exch != nil && exch.SupportsHistoricCandles() {
+		return
+	}
+
+	t := d.Timestamp
+	if t.IsZero() {
+		t = clock.Now()
+	}
+	err := kline.ProcessTrade(d.Exchange, d.CurrencyPair, d.AssetType, intervals,
+		t, d.Price, d.Amount)
+	if err != nil {
+		log.Debugf("%s %s trade candle aggregation failed. Error: %s",
+			d.Exchange, d.CurrencyPair, err)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+)
+
+func TestAggregateTrade(t *testing.T) {
+	setupAccountInfoTest(t, 0)
+	bot.config.KlineStorage.TradeIntervals = []time.Duration{time.Minute}
+	defer func() { bot.config.KlineStorage.TradeIntervals = nil }()
+
+	p := currency.NewPairFromStrings("BTC", "USD")
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	for x, price := range []float64{100, 110, 90} {
+		aggregateTrade(&exchange.TradeData{
+			Timestamp:    start.Add(time.Second * time.Duration(x)),
+			CurrencyPair: p,
+			AssetType:    "SPOT",
+			Exchange:     "TradeCandleTest",
+			Price:        price,
+			Amount:       2,
+		})
+	}
+
+	item, err := kline.Get("TradeCandleTest", p, "SPOT", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(item.Candles) != 1 || item.Candles[0].Open != 100 ||
+		item.Candles[0].High != 110 || item.Candles[0].Low != 90 ||
+		item.Candles[0].Close != 90 || item.Candles[0].Volume != 6 {
+		t.Errorf("Test failed. Unexpected trade candles %+v", item.Candles)
+	}
+
+	// Exchanges streaming their own candles are not aggregated
+	tradeCandles.SetNative("TradeCandleNative")
+	aggregateTrade(&exchange.TradeData{
+		Timestamp:    start,
+		CurrencyPair: p,
+		AssetType:    "SPOT",
+		Exchange:     "TradeCandleNative",
+		Price:        100,
+		Amount:       1,
+	})
+	if _, err := kline.Get("TradeCandleNative", p, "SPOT", time.Minute); err == nil {
+		t.Error("Test failed. Expected trades of exchanges with candles to be ignored")
+	}
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// tradeExportPageDelay is the delay between trade history pages, keeping
+// exports within exchange limits where the requester has none configured
+var tradeExportPageDelay = time.Second
+
+// tradeExportMtx serialises writing export files with pruning them
+var tradeExportMtx sync.Mutex
+
+var tradeExportHeader = []string{
+	"exchange",
+	"pair",
+	"trade_id",
+	"timestamp",
+	"side",
+	"price",
+	"amount",
+	"fee",
+	"fee_asset",
+	"description",
+}
+
+var orderExportHeader = []string{
+	"exchange",
+	"order_id",
+	"submitted",
+	"pair",
+	"side",
+	"order_type",
+	"price",
+	"amount",
+	"tags",
+}
+
+// TradeExport holds the result of exporting an exchanges trade history
+type TradeExport struct {
+	Exchange string   `json:"exchange"`
+	Trades   int      `json:"trades"`
+	Orders   int      `json:"orders"`
+	Files    []string `json:"files"`
+}
+
+// ExportTrades downloads the complete authenticated trade history of an
+// exchanges enabled currency pairs to a CSV file per pair in dir. Existing
+// exports are resumed from their last exported trade ID. Orders submitted by
+// the bot are exported with their tags to a separate file so fills can be
+// attributed to the logic which generated them
+func ExportTrades(exchName, dir string) (TradeExport, error) {
+	resp := TradeExport{Exchange: exchName}
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return resp, fmt.Errorf("exchange %s not found", exchName)
+	}
+
+	exporter, ok := exch.(exchange.ITradeHistoryExchange)
+	if !ok {
+		return resp, fmt.Errorf("exchange %s does not support trade history export",
+			exchName)
+	}
+
+	if !exch.GetAuthenticatedAPISupport() {
+		return resp, fmt.Errorf("exchange %s authenticated API support disabled",
+			exchName)
+	}
+
+	err := common.CreateDir(dir)
+	if err != nil {
+		return resp, err
+	}
+
+	tradeExportMtx.Lock()
+	defer tradeExportMtx.Unlock()
+	pairs := exch.GetEnabledCurrencies()
+	for x := range pairs {
+		path := filepath.Join(dir, fmt.Sprintf("%s_%s.csv", exchName, pairs[x].String()))
+		count, err := exportPairTrades(exporter, exchName, pairs[x], path)
+		resp.Trades += count
+		if _, statErr := os.Stat(path); statErr == nil {
+			resp.Files = append(resp.Files, path)
+		}
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s_orders.csv", exchName))
+	count, err := exportTaggedOrders(exchName, path)
+	resp.Orders = count
+	if _, statErr := os.Stat(path); statErr == nil {
+		resp.Files = append(resp.Files, path)
+	}
+	return resp, err
+}
+
+// exportTaggedOrders appends the orders submitted on an exchange after the
+// last exported order to a CSV file, returning the number of orders written
+func exportTaggedOrders(exchName, path string) (int, error) {
+	last, err := getLastExportedRecord(path, orderExportHeader)
+	if err != nil {
+		return 0, err
+	}
+	var after time.Time
+	if last != nil {
+		after, err = time.Parse(time.RFC3339Nano, last[2])
+		if err != nil {
+			return 0, fmt.Errorf("unable to resume order export %s: %s", path, err)
+		}
+	}
+
+	var orders []TaggedOrder
+	for _, order := range GetTaggedOrders(exchName, nil) {
+		if order.Submitted.After(after) {
+			orders = append(orders, order)
+		}
+	}
+	if len(orders) == 0 {
+		return 0, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if last == nil {
+		err = w.Write(orderExportHeader)
+		if err != nil {
+			return 0, err
+		}
+	}
+	for x := range orders {
+		err = w.Write([]string{
+			orders[x].Exchange,
+			orders[x].OrderID,
+			orders[x].Submitted.UTC().Format(time.RFC3339Nano),
+			orders[x].Pair,
+			orders[x].Side,
+			orders[x].OrderType,
+			strconv.FormatFloat(orders[x].Price, 'f', -1, 64),
+			strconv.FormatFloat(orders[x].Amount, 'f', -1, 64),
+			orders[x].Tags.String(),
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	w.Flush()
+	if err = w.Error(); err != nil {
+		return 0, err
+	}
+	log.Debugf("Exported %d %s orders to %s", len(orders), exchName, path)
+	return len(orders), nil
+}
+
+// exportPairTrades appends the trades of a currency pair after the last
+// exported trade to a CSV file, returning the number of trades written
+func exportPairTrades(exporter exchange.ITradeHistoryExchange, exchName string, p currency.Pair, path string) (int, error) {
+	fromID, err := getLastExportedTradeID(path)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	w := csv.NewWriter(f)
+	if info.Size() == 0 {
+		err = w.Write(tradeExportHeader)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	var count int
+	for {
+		trades, err := exporter.GetAccountTradeHistory(p, fromID)
+		if err != nil {
+			w.Flush()
+			return count, err
+		}
+		if len(trades) == 0 {
+			break
+		}
+
+		pageStart := fromID
+		for x := range trades {
+			// Guard against exchanges ignoring the requested position
+			if trades[x].TID <= fromID {
+				continue
+			}
+			err = w.Write(tradeExportRecord(exchName, p, &trades[x]))
+			if err != nil {
+				return count, err
+			}
+			fromID = trades[x].TID
+			count++
+		}
+		w.Flush()
+		if err = w.Error(); err != nil {
+			return count, err
+		}
+
+		if fromID == pageStart {
+			break
+		}
+		clock.Sleep(tradeExportPageDelay)
+	}
+
+	if count > 0 {
+		log.Debugf("Exported %d %s %s trades to %s", count, exchName, p, path)
+	}
+	return count, nil
+}
+
+func tradeExportRecord(exchName string, p currency.Pair, t *exchange.TradeHistory) []string {
+	return []string{
+		exchName,
+		p.String(),
+		strconv.FormatInt(t.TID, 10),
+		t.Timestamp.UTC().Format(time.RFC3339Nano),
+		t.Type,
+		strconv.FormatFloat(t.Price, 'f', -1, 64),
+		strconv.FormatFloat(t.Amount, 'f', -1, 64),
+		strconv.FormatFloat(t.Fee, 'f', -1, 64),
+		t.FeeAsset.String(),
+		t.Description,
+	}
+}
+
+// getLastExportedTradeID returns the trade ID of the last record in an
+// export, or zero when there is no export to resume
+func getLastExportedTradeID(path string) (int64, error) {
+	record, err := getLastExportedRecord(path, tradeExportHeader)
+	if err != nil || record == nil {
+		return 0, err
+	}
+	lastID, err := strconv.ParseInt(record[2], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to resume trade export %s: %s", path, err)
+	}
+	return lastID, nil
+}
+
+// getLastExportedRecord returns the last record of an export with the given
+// header, or nil when there is no export or it has no records
+func getLastExportedRecord(path string, header []string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = len(header)
+	var last []string
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to resume export %s: %s", path, err)
+		}
+		if record[0] == header[0] {
+			continue
+		}
+		last = record
+	}
+	return last, nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type tradeHistoryTestExchange struct {
+	trades   []exchange.TradeHistory
+	pageSize int
+	failAt   int64
+}
+
+func (e *tradeHistoryTestExchange) GetAccountTradeHistory(p currency.Pair, fromID int64) ([]exchange.TradeHistory, error) {
+	if e.failAt != 0 && fromID >= e.failAt {
+		return nil, errors.New("rate limited")
+	}
+	var resp []exchange.TradeHistory
+	for x := range e.trades {
+		if e.trades[x].TID > fromID && len(resp) < e.pageSize {
+			resp = append(resp, e.trades[x])
+		}
+	}
+	return resp, nil
+}
+
+func TestExportPairTrades(t *testing.T) {
+	tradeExportPageDelay = 0
+	dir, err := ioutil.TempDir("", "tradeexport")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "Binance_BTCUSDT.csv")
+	p := currency.NewPairFromStrings("BTC", "USDT")
+	e := &tradeHistoryTestExchange{pageSize: 2, failAt: 3}
+	for x := int64(1); x <= 5; x++ {
+		e.trades = append(e.trades, exchange.TradeHistory{
+			TID:       x,
+			Timestamp: time.Unix(x, 0),
+			Price:     100,
+			Amount:    1,
+			Type:      "BUY",
+			Fee:       0.1,
+			FeeAsset:  currency.BNB,
+		})
+	}
+
+	count, err := exportPairTrades(e, "Binance", p, path)
+	if err == nil {
+		t.Fatal("Test failed. Expected error from the exchange")
+	}
+	if count != 4 {
+		t.Fatalf("Test failed. Expected 4 trades before the error, got %d", count)
+	}
+
+	lastID, err := getLastExportedTradeID(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastID != 4 {
+		t.Fatalf("Test failed. Expected to resume from trade 4, got %d", lastID)
+	}
+
+	e.failAt = 0
+	count, err = exportPairTrades(e, "Binance", p, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("Test failed. Expected 1 resumed trade, got %d", count)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("Test failed. Expected a header and 5 trades, got %d lines", len(lines))
+	}
+	if lines[5] != "Binance,BTCUSDT,5,1970-01-01T00:00:05Z,BUY,100,1,0.1,BNB," {
+		t.Errorf("Test failed. Unexpected record %s", lines[5])
+	}
+
+	count, err = exportPairTrades(e, "Binance", p, path)
+	if err != nil || count != 0 {
+		t.Errorf("Test failed. Expected no new trades, got %d %v", count, err)
+	}
+}
+
+func TestGetLastExportedTradeID(t *testing.T) {
+	lastID, err := getLastExportedTradeID(filepath.Join(os.TempDir(), "tradeexport_missing.csv"))
+	if err != nil || lastID != 0 {
+		t.Errorf("Test failed. Expected a missing export to start from 0, got %d %v", lastID, err)
+	}
+
+	f, err := ioutil.TempFile("", "tradeexport")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.WriteString("exchange,pair,trade_id\nBinance,BTCUSDT,abc\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	_, err = getLastExportedTradeID(f.Name())
+	if err == nil {
+		t.Error("Test failed. Expected error for a malformed export")
+	}
+}
+
+func TestExportTaggedOrders(t *testing.T) {
+	orderTags.Restore([]TaggedOrder{
+		{Exchange: "Binance", OrderID: "1", Pair: "BTCUSDT", Side: "BUY",
+			OrderType: "LIMIT", Price: 100, Amount: 1, Submitted: time.Unix(1, 0),
+			Tags: OrderTags{OrderTagStrategy: "grid", OrderTagReason: "signal"}},
+		{Exchange: "Bitstamp", OrderID: "2", Submitted: time.Unix(2, 0)},
+	})
+	defer orderTags.Restore(nil)
+
+	dir, err := ioutil.TempDir("", "orderexport")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "Binance_orders.csv")
+
+	count, err := exportTaggedOrders("Binance", path)
+	if err != nil || count != 1 {
+		t.Fatalf("Test failed. Expected 1 exported order, got %d %v", count, err)
+	}
+
+	orderTags.Add(TaggedOrder{Exchange: "Binance", OrderID: "3", Submitted: time.Unix(3, 0)})
+	count, err = exportTaggedOrders("Binance", path)
+	if err != nil || count != 1 {
+		t.Fatalf("Test failed. Expected 1 resumed order, got %d %v", count, err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Test failed. Expected a header and 2 orders, got %d lines", len(lines))
+	}
+	if lines[1] != "Binance,1,1970-01-01T00:00:01Z,BTCUSDT,BUY,LIMIT,100,1,reason=signal;strategy=grid" {
+		t.Errorf("Test failed. Unexpected record %s", lines[1])
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+// TradeSimulation holds the estimated cost of a market order filled against
+// the local orderbook. Slippage is the percentage difference between the
+// average fill price and the best price, fees are in the quote currency
+type TradeSimulation struct {
+	Exchange         string             `json:"exchange"`
+	Pair             currency.Pair      `json:"pair"`
+	Side             exchange.OrderSide `json:"side"`
+	Amount           float64            `json:"amount"`
+	BestPrice        float64            `json:"bestPrice"`
+	AverageFillPrice float64            `json:"averageFillPrice"`
+	WorstFillPrice   float64            `json:"worstFillPrice"`
+	Slippage         float64            `json:"slippage"`
+	Cost             float64            `json:"cost"`
+	Fee              float64            `json:"fee"`
+	OrderbookUpdated time.Time          `json:"orderbookUpdated"`
+	Stale            bool               `json:"stale"`
+}
+
+// SimulateTrade estimates the fill price, slippage and fees of a market order
+// by walking an exchanges local orderbook, without placing an order
+func SimulateTrade(exchName, currencyPair, side string, amount float64) (TradeSimulation, error) {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return TradeSimulation{}, errors.New(exchange.ErrExchangeNotFound)
+	}
+	return simulateTrade(exch, currency.NewPairFromString(currencyPair), side, amount)
+}
+
+func simulateTrade(exch exchange.IBotExchange, p currency.Pair, side string, amount float64) (TradeSimulation, error) {
+	resp, err := simulateOrderbookFill(exch, p, side, amount)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Fee, err = exch.GetFeeByType(&exchange.FeeBuilder{
+		FeeType:       exchange.CryptocurrencyTradeFee,
+		PurchasePrice: resp.AverageFillPrice,
+		Amount:        amount,
+		Pair:          p,
+	})
+	return resp, err
+}
+
+// simulateOrderbookFill fills a market order against an exchanges local
+// orderbook without estimating its fee
+func simulateOrderbookFill(exch exchange.IBotExchange, p currency.Pair, side string, amount float64) (TradeSimulation, error) {
+	return simulateAssetOrderbookFill(exch, p, orderbook.Spot, side, amount)
+}
+
+// simulateAssetOrderbookFill fills a market order against an exchanges local
+// orderbook of an asset type, such as a futures contract orderbook
+func simulateAssetOrderbookFill(exch exchange.IBotExchange, p currency.Pair, assetType, side string, amount float64) (TradeSimulation, error) {
+	resp := TradeSimulation{
+		Exchange: exch.GetName(),
+		Pair:     p,
+		Amount:   amount,
+	}
+
+	if amount <= 0 {
+		return resp, errors.New("amount must be greater than zero")
+	}
+
+	ob, err := orderbook.Get(exch.GetName(), p, assetType)
+	if err != nil {
+		return resp, err
+	}
+
+	switch exchange.OrderSide(common.StringToUpper(side)) {
+	case exchange.BuyOrderSide, exchange.BidOrderSide:
+		resp.Side = exchange.BuyOrderSide
+	case exchange.SellOrderSide, exchange.AskOrderSide:
+		resp.Side = exchange.SellOrderSide
+	default:
+		return resp, fmt.Errorf("invalid order side %s", side)
+	}
+	resp.OrderbookUpdated = ob.LastUpdated
+	resp.Stale = ob.Stale
+
+	return resp, simulateFill(&resp, getFillLevels(&ob, resp.Side))
+}
+
+// getFillLevels returns the orderbook levels a market order of side fills
+// against, sorted from best to worst price
+func getFillLevels(ob *orderbook.Base, side exchange.OrderSide) []orderbook.Item {
+	var levels []orderbook.Item
+	switch side {
+	case exchange.BuyOrderSide, exchange.BidOrderSide:
+		levels = append(levels, ob.Asks...)
+		sort.Slice(levels, func(i, j int) bool {
+			return levels[i].Price < levels[j].Price
+		})
+	case exchange.SellOrderSide, exchange.AskOrderSide:
+		levels = append(levels, ob.Bids...)
+		sort.Slice(levels, func(i, j int) bool {
+			return levels[i].Price > levels[j].Price
+		})
+	}
+	return levels
+}
+
+// simulateFill fills the simulation amount against orderbook levels sorted
+// from best to worst price
+func simulateFill(resp *TradeSimulation, levels []orderbook.Item) error {
+	if len(levels) == 0 {
+		return errors.New("orderbook has no liquidity")
+	}
+
+	remaining := resp.Amount
+	for x := range levels {
+		if remaining <= 0 {
+			break
+		}
+		if levels[x].Amount <= 0 {
+			continue
+		}
+		if resp.BestPrice == 0 {
+			resp.BestPrice = levels[x].Price
+		}
+		filled := math.Min(remaining, levels[x].Amount)
+		resp.Cost += filled * levels[x].Price
+		resp.WorstFillPrice = levels[x].Price
+		remaining -= filled
+	}
+
+	if remaining > 0 {
+		return fmt.Errorf("insufficient orderbook depth, %f of %f unfilled",
+			remaining, resp.Amount)
+	}
+
+	resp.AverageFillPrice = resp.Cost / resp.Amount
+	resp.Slippage = math.Abs(resp.AverageFillPrice-resp.BestPrice) /
+		resp.BestPrice * 100
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+type tradeSimulationTestExchange struct {
+	exchange.IBotExchange
+}
+
+func (e *tradeSimulationTestExchange) GetName() string {
+	return "TradeSimulationTest"
+}
+
+func (e *tradeSimulationTestExchange) GetFeeByType(feeBuilder *exchange.FeeBuilder) (float64, error) {
+	return feeBuilder.PurchasePrice * feeBuilder.Amount * 0.001, nil
+}
+
+func TestSimulateTrade(t *testing.T) {
+	p := currency.NewPairFromStrings("BTC", "USD")
+	ob := orderbook.Base{
+		Pair:         p,
+		ExchangeName: "TradeSimulationTest",
+		AssetType:    orderbook.Spot,
+		Bids:         []orderbook.Item{{Price: 98, Amount: 1}, {Price: 99, Amount: 1}},
+		Asks:         []orderbook.Item{{Price: 102, Amount: 2}, {Price: 100, Amount: 1}},
+	}
+	err := ob.Process()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exch := &tradeSimulationTestExchange{}
+	resp, err := simulateTrade(exch, p, "buy", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Side != exchange.BuyOrderSide || resp.BestPrice != 100 ||
+		resp.WorstFillPrice != 102 || resp.AverageFillPrice != 101 ||
+		resp.Cost != 202 || resp.Slippage != 1 {
+		t.Errorf("Test failed. Unexpected buy simulation %+v", resp)
+	}
+	if resp.Fee != 0.202 {
+		t.Errorf("Test failed. Expected fee 0.202, got %f", resp.Fee)
+	}
+
+	resp, err = simulateTrade(exch, p, "SELL", 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.BestPrice != 99 || resp.AverageFillPrice != 99 || resp.Slippage != 0 {
+		t.Errorf("Test failed. Unexpected sell simulation %+v", resp)
+	}
+
+	_, err = simulateTrade(exch, p, "sell", 3)
+	if err == nil {
+		t.Error("Test failed. Expected error for insufficient orderbook depth")
+	}
+
+	_, err = simulateTrade(exch, p, "hold", 1)
+	if err == nil {
+		t.Error("Test failed. Expected error for an invalid order side")
+	}
+
+	_, err = simulateTrade(exch, p, "buy", 0)
+	if err == nil {
+		t.Error("Test failed. Expected error for a zero amount")
+	}
+}
@@ -0,0 +1,223 @@
+//go:build !notrading
+// +build !notrading
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/clock"
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/decimal"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// tradingSupported is whether orders, withdrawals and dust conversions can be
+// submitted, builds made with the notrading tag replace this file with
+// trading_disabled.go to only collect data
+const tradingSupported = true
+
+// SubmitExchangeOrder submits an order to an exchange and invalidates its
+// cached account info as balances are expected to change. The amount and price
+// are normalised to the exchanges precision first. Orders which would breach a
+// configured balance reserve are rejected
+func SubmitExchangeOrder(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	return SubmitExchangeFlaggedOrder(exch, p, side, orderType, amount, price,
+		clientID, exchange.OrderFlags{})
+}
+
+// SubmitExchangeFlaggedOrder submits an order with post-only or reduce-only
+// flags as SubmitExchangeOrder does. Exchanges without native order flags have
+// post-only orders priced so they can't cross the spread and reject
+// reduce-only orders
+func SubmitExchangeFlaggedOrder(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string, flags exchange.OrderFlags) (exchange.SubmitOrderResponse, error) {
+	amount, price, err := normaliseOrderAmounts(exch, p, amount, price)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+
+	flagged, native := exch.(exchange.IOrderFlagsExchange)
+	if flags.IsSet() && !native {
+		price, err = emulateOrderFlags(exch, p, side, orderType, price, flags)
+		if err != nil {
+			return exchange.SubmitOrderResponse{}, err
+		}
+	}
+
+	err = checkOrderBalanceReserve(exch, p, side, amount, price)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+
+	err = checkOrderExposureLimit(exch, p, side, amount, price)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+
+	var resp exchange.SubmitOrderResponse
+	if flags.IsSet() && native {
+		resp, err = flagged.SubmitFlaggedOrder(p, side, orderType, amount, price,
+			clientID, flags)
+	} else {
+		resp, err = exch.SubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+	if err != nil {
+		return resp, err
+	}
+	resp.Sandbox = exch.IsSandbox()
+	resp.InternalOrderID = trackSubmittedOrder(exch, resp.OrderID, p, side,
+		orderType, amount, price, clientID)
+	storeSubmittedOrder(exch.GetName(), resp.OrderID, p, side, orderType, amount,
+		price)
+
+	InvalidateExchangeAccountInfo(exch.GetName())
+	return resp, nil
+}
+
+// SubmitExchangeQuoteOrder submits a market order sized by the quote currency
+// amount to spend on a buy or receive from a sell to an exchange supporting
+// them and invalidates its cached account info. The amount is the estimated
+// base amount, used to check sells against a configured balance reserve
+func SubmitExchangeQuoteOrder(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, quoteAmount, amount float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	native, ok := exch.(exchange.IQuoteAmountOrderExchange)
+	if !ok {
+		return exchange.SubmitOrderResponse{},
+			fmt.Errorf("%s does not support quote amount orders", exch.GetName())
+	}
+
+	var err error
+	switch side {
+	case exchange.SellOrderSide, exchange.AskOrderSide:
+		err = checkBalanceReserve(exch, p.Base, amount)
+	default:
+		err = checkBalanceReserve(exch, p.Quote, quoteAmount)
+	}
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+
+	var price float64
+	if amount > 0 {
+		price = quoteAmount / amount
+	}
+	err = checkOrderExposureLimit(exch, p, side, amount, price)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+
+	resp, err := native.SubmitQuoteAmountOrder(p, side, quoteAmount, clientID)
+	if err != nil {
+		return resp, err
+	}
+	resp.Sandbox = exch.IsSandbox()
+	resp.InternalOrderID = trackSubmittedOrder(exch, resp.OrderID, p, side,
+		exchange.MarketOrderType, amount, 0, clientID)
+	storeSubmittedOrder(exch.GetName(), resp.OrderID, p, side,
+		exchange.MarketOrderType, amount, 0)
+
+	InvalidateExchangeAccountInfo(exch.GetName())
+	return resp, nil
+}
+
+// CancelExchangeOrderByClientID cancels an order by the client order ID it was
+// submitted with. Exchanges without client ID cancellation have the exchange
+// order ID looked up from the orders submitted by the bot, using the pair it
+// was submitted with when p is empty
+func CancelExchangeOrderByClientID(exch exchange.IBotExchange, clientID string, p currency.Pair) error {
+	if canceller, ok := exch.(exchange.IClientOrderIDExchange); ok {
+		err := canceller.CancelOrderByClientID(clientID, p)
+		if err != nil {
+			return err
+		}
+		managedOrders.SetCancelled(exch.GetName(), "", clientID, clock.Now())
+		InvalidateExchangeAccountInfo(exch.GetName())
+		return nil
+	}
+
+	order, ok := orderTags.GetByClientID(exch.GetName(), clientID)
+	if !ok || order.OrderID == "" {
+		return errClientOrderIDNotFound
+	}
+	if p.IsEmpty() {
+		p = currency.NewPairFromString(order.Pair)
+	}
+
+	err := exch.CancelOrder(&exchange.OrderCancellation{
+		OrderID:      order.OrderID,
+		CurrencyPair: p,
+	})
+	if err != nil {
+		return err
+	}
+	managedOrders.SetCancelled(exch.GetName(), order.OrderID, "", clock.Now())
+	InvalidateExchangeAccountInfo(exch.GetName())
+	return nil
+}
+
+// CancelExchangeOrder cancels an order by its exchange order ID and
+// invalidates the exchanges cached account info
+func CancelExchangeOrder(exch exchange.IBotExchange, orderID string, p currency.Pair) error {
+	err := exch.CancelOrder(&exchange.OrderCancellation{
+		OrderID:      orderID,
+		CurrencyPair: p,
+	})
+	if err != nil {
+		return err
+	}
+	managedOrders.SetCancelled(exch.GetName(), orderID, "", clock.Now())
+	InvalidateExchangeAccountInfo(exch.GetName())
+	return nil
+}
+
+// CloseExchangeFuturesPosition closes an amount of a long or short futures
+// position and invalidates the exchanges cached account info
+func CloseExchangeFuturesPosition(closer exchange.IFuturesPositionsExchange, exchName string, p currency.Pair, positionSide exchange.OrderSide, orderType exchange.OrderType, amount, price float64) (exchange.SubmitOrderResponse, error) {
+	resp, err := closer.CloseFuturesPosition(p, positionSide, orderType, amount,
+		price, "")
+	if err != nil {
+		return resp, err
+	}
+	InvalidateExchangeAccountInfo(exchName)
+	return resp, nil
+}
+
+// WithdrawExchangeCryptocurrencyFunds submits a cryptocurrency withdrawal to
+// an exchange and invalidates its cached account info. Withdrawals which would
+// breach a configured balance reserve or are to an address missing from the
+// exchanges withdrawal whitelist are rejected. The configured trade password
+// and TOTP one-time password are supplied when the request has none
+func WithdrawExchangeCryptocurrencyFunds(exch exchange.IBotExchange, withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	err := checkWithdrawalWhitelisted(exch, withdrawRequest)
+	if err != nil {
+		return "", err
+	}
+
+	total := decimal.NewFromFloat(withdrawRequest.Amount).
+		Add(decimal.NewFromFloat(withdrawRequest.FeeAmount))
+	err = checkBalanceReserve(exch, withdrawRequest.Currency, total.Float64())
+	if err != nil {
+		return "", err
+	}
+
+	err = setWithdrawalCredentials(exch.GetName(), withdrawRequest)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := exch.WithdrawCryptocurrencyFunds(withdrawRequest)
+	if err != nil {
+		return id, err
+	}
+
+	log.Debugf("%s withdrawal %s submitted, invalidating cached account info",
+		exch.GetName(), id)
+	InvalidateExchangeAccountInfo(exch.GetName())
+	return id, nil
+}
+
+// convertExchangeDust converts dust balances into the exchanges dust conversion
+// currency
+func convertExchangeDust(converter exchange.IDustConversionExchange, codes []currency.Code) (float64, error) {
+	return converter.ConvertDust(codes)
+}
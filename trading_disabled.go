@@ -0,0 +1,66 @@
+//go:build notrading
+// +build notrading
+
+package main
+
+import (
+	"errors"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// tradingSupported is whether orders, withdrawals and dust conversions can be
+// submitted
+const tradingSupported = false
+
+// ErrTradingDisabled is returned for orders, withdrawals and dust conversions
+// by builds made with the notrading tag
+var ErrTradingDisabled = errors.New("trading is disabled in this build")
+
+// SubmitExchangeOrder rejects orders as trading is disabled in this build
+func SubmitExchangeOrder(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	return exchange.SubmitOrderResponse{}, ErrTradingDisabled
+}
+
+// SubmitExchangeFlaggedOrder rejects orders as trading is disabled in this
+// build
+func SubmitExchangeFlaggedOrder(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string, flags exchange.OrderFlags) (exchange.SubmitOrderResponse, error) {
+	return exchange.SubmitOrderResponse{}, ErrTradingDisabled
+}
+
+// SubmitExchangeQuoteOrder rejects quote amount orders as trading is disabled
+// in this build
+func SubmitExchangeQuoteOrder(exch exchange.IBotExchange, p currency.Pair, side exchange.OrderSide, quoteAmount, amount float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	return exchange.SubmitOrderResponse{}, ErrTradingDisabled
+}
+
+// CancelExchangeOrderByClientID rejects cancellations as trading is disabled in
+// this build
+func CancelExchangeOrderByClientID(exch exchange.IBotExchange, clientID string, p currency.Pair) error {
+	return ErrTradingDisabled
+}
+
+// CancelExchangeOrder rejects cancellations as trading is disabled in this
+// build
+func CancelExchangeOrder(exch exchange.IBotExchange, orderID string, p currency.Pair) error {
+	return ErrTradingDisabled
+}
+
+// CloseExchangeFuturesPosition rejects closing positions as trading is
+// disabled in this build
+func CloseExchangeFuturesPosition(closer exchange.IFuturesPositionsExchange, exchName string, p currency.Pair, positionSide exchange.OrderSide, orderType exchange.OrderType, amount, price float64) (exchange.SubmitOrderResponse, error) {
+	return exchange.SubmitOrderResponse{}, ErrTradingDisabled
+}
+
+// WithdrawExchangeCryptocurrencyFunds rejects withdrawals as trading is
+// disabled in this build
+func WithdrawExchangeCryptocurrencyFunds(exch exchange.IBotExchange, withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	return "", ErrTradingDisabled
+}
+
+// convertExchangeDust rejects dust conversions as trading is disabled in this
+// build
+func convertExchangeDust(converter exchange.IDustConversionExchange, codes []currency.Code) (float64, error) {
+	return 0, ErrTradingDisabled
+}
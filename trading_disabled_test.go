@@ -0,0 +1,39 @@
+//go:build notrading
+// +build notrading
+
+package main
+
+import (
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+func TestTradingDisabled(t *testing.T) {
+	exch := &riskTestExchange{}
+	_, err := SubmitExchangeOrder(exch, currency.NewPairFromString("BTCUSD"),
+		exchange.BuyOrderSide, exchange.MarketOrderType, 1, 0, "")
+	if err != ErrTradingDisabled {
+		t.Errorf("Test failed. Expected %v, got %v", ErrTradingDisabled, err)
+	}
+
+	_, err = WithdrawExchangeCryptocurrencyFunds(exch, &exchange.WithdrawRequest{})
+	if err != ErrTradingDisabled {
+		t.Errorf("Test failed. Expected %v, got %v", ErrTradingDisabled, err)
+	}
+
+	err = CancelExchangeOrderByClientID(exch, "a", currency.Pair{})
+	if err != ErrTradingDisabled {
+		t.Errorf("Test failed. Expected %v, got %v", ErrTradingDisabled, err)
+	}
+
+	err = CancelExchangeOrder(exch, "a", currency.Pair{})
+	if err != ErrTradingDisabled {
+		t.Errorf("Test failed. Expected %v, got %v", ErrTradingDisabled, err)
+	}
+
+	if exch.orders != 0 || exch.withdrawals != 0 {
+		t.Error("Test failed. Expected nothing to be sent to the exchange")
+	}
+}
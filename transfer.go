@@ -0,0 +1,334 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// transferNetworkTiming holds the block time of a network and the number of
+// confirmations deposits typically require
+type transferNetworkTiming struct {
+	blockTime     time.Duration
+	confirmations int
+}
+
+// transferNetworkTimings are used to estimate how long a transfer takes to be
+// credited, networks without an entry have an unknown confirmation time
+var transferNetworkTimings = map[string]transferNetworkTiming{
+	"BTC":  {blockTime: time.Minute * 10, confirmations: 2},
+	"BCH":  {blockTime: time.Minute * 10, confirmations: 6},
+	"LTC":  {blockTime: time.Second * 150, confirmations: 6},
+	"DASH": {blockTime: time.Second * 150, confirmations: 6},
+	"ZEC":  {blockTime: time.Second * 75, confirmations: 10},
+	"XMR":  {blockTime: time.Minute * 2, confirmations: 10},
+	"DOGE": {blockTime: time.Minute, confirmations: 20},
+	"ETH":  {blockTime: time.Second * 15, confirmations: 12},
+	"ETC":  {blockTime: time.Second * 15, confirmations: 120},
+	"BSC":  {blockTime: time.Second * 3, confirmations: 15},
+	"TRX":  {blockTime: time.Second * 3, confirmations: 1},
+	"XRP":  {blockTime: time.Second * 4, confirmations: 1},
+	"XLM":  {blockTime: time.Second * 5, confirmations: 1},
+	"EOS":  {blockTime: time.Millisecond * 500, confirmations: 1},
+}
+
+// TransferRoute holds the cost and expected confirmation time of moving a
+// currency between exchanges on a network. ExpectedTime is zero when unknown
+type TransferRoute struct {
+	Network        string        `json:"network"`
+	WithdrawalFee  float64       `json:"withdrawalFee"`
+	ReceivedAmount float64       `json:"receivedAmount"`
+	Confirmations  int           `json:"confirmations"`
+	ExpectedTime   time.Duration `json:"expectedTime"`
+	Viable         bool          `json:"viable"`
+	Reason         string        `json:"reason,omitempty"`
+}
+
+// TransferPlan compares the networks a currency can be moved between two
+// exchanges on. Recommended is the viable route with the lowest fee, ties
+// preferring the fastest known confirmation time
+type TransferPlan struct {
+	From        string          `json:"from"`
+	To          string          `json:"to"`
+	Currency    currency.Code   `json:"currency"`
+	Amount      float64         `json:"amount"`
+	Routes      []TransferRoute `json:"routes"`
+	Recommended *TransferRoute  `json:"recommended"`
+}
+
+// TransferResult holds a submitted inter-exchange transfer
+type TransferResult struct {
+	Plan         TransferPlan  `json:"plan"`
+	Route        TransferRoute `json:"route"`
+	Address      string        `json:"address"`
+	AddressTag   string        `json:"addressTag,omitempty"`
+	WithdrawalID string        `json:"withdrawalId"`
+}
+
+// PlanTransfer returns a plan comparing the routes for moving an amount of a
+// currency from one exchange to another
+func PlanTransfer(from, to, c string, amount float64) (TransferPlan, error) {
+	src, dst, err := getTransferExchanges(from, to)
+	if err != nil {
+		return TransferPlan{}, err
+	}
+	return planTransfer(src, dst, currency.NewCode(c), amount)
+}
+
+// ExecuteTransfer plans and submits a transfer, withdrawing from the source
+// exchange to its deposit address on the destination exchange. An empty
+// network uses the recommended route
+func ExecuteTransfer(from, to, c string, amount float64, network string) (TransferResult, error) {
+	src, dst, err := getTransferExchanges(from, to)
+	if err != nil {
+		return TransferResult{}, err
+	}
+	return executeTransfer(src, dst, currency.NewCode(c), amount, network)
+}
+
+func getTransferExchanges(from, to string) (src, dst exchange.IBotExchange, err error) {
+	if strings.EqualFold(from, to) {
+		return nil, nil, errors.New("source and destination exchanges must differ")
+	}
+
+	src = GetExchangeByName(from)
+	if src == nil {
+		return nil, nil, fmt.Errorf("exchange %s not found", from)
+	}
+
+	dst = GetExchangeByName(to)
+	if dst == nil {
+		return nil, nil, fmt.Errorf("exchange %s not found", to)
+	}
+	return src, dst, nil
+}
+
+// getTransferNetworks returns the networks a currency can be transferred on.
+// Exchanges which do not report networks are limited to the currencies
+// native network, avoiding transfers between incompatible token networks
+func getTransferNetworks(exch exchange.IBotExchange, c currency.Code) ([]exchange.TransferNetwork, error) {
+	if networks, ok := exch.(exchange.ITransferNetworksExchange); ok {
+		return networks.GetTransferNetworks(c)
+	}
+
+	fee, err := getWithdrawalFee(exch, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return []exchange.TransferNetwork{{
+		Network:         c.Upper().String(),
+		Default:         true,
+		DepositEnabled:  isDepositEnabled(exch, c),
+		WithdrawEnabled: true,
+		WithdrawalFee:   fee,
+	}}, nil
+}
+
+// getWithdrawalFee returns the withdrawal fee of a currency, using the live
+// fee of exchanges which report it and falling back to their fee estimate
+func getWithdrawalFee(exch exchange.IBotExchange, c currency.Code) (float64, error) {
+	if reporter, ok := exch.(exchange.IWithdrawalFeeExchange); ok {
+		fee, err := reporter.GetWithdrawalFee(c)
+		if err == nil {
+			return fee, nil
+		}
+		log.Debugf("%s failed to get %s withdrawal fee, using fee estimate. Error: %s",
+			exch.GetName(), c, err)
+	}
+
+	return exch.GetFeeByType(&exchange.FeeBuilder{
+		FeeType: exchange.CryptocurrencyWithdrawalFee,
+		Pair:    currency.NewPair(c, currency.Code{}),
+	})
+}
+
+// isDepositEnabled returns whether an exchange accepts deposits of a currency.
+// Exchanges which cannot report their deposit methods, or fail to, are assumed
+// to accept deposits
+func isDepositEnabled(exch exchange.IBotExchange, c currency.Code) bool {
+	reporter, ok := exch.(exchange.IWithdrawalFeeExchange)
+	if !ok {
+		return true
+	}
+
+	methods, err := reporter.GetDepositMethods(c)
+	if err != nil {
+		log.Debugf("%s failed to get %s deposit methods. Error: %s",
+			exch.GetName(), c, err)
+		return true
+	}
+	for x := range methods {
+		if methods[x].Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+func planTransfer(src, dst exchange.IBotExchange, c currency.Code, amount float64) (TransferPlan, error) {
+	plan := TransferPlan{
+		From:     src.GetName(),
+		To:       dst.GetName(),
+		Currency: c,
+		Amount:   amount,
+	}
+
+	if amount <= 0 {
+		return plan, errors.New("amount must be greater than zero")
+	}
+
+	withdrawNetworks, err := getTransferNetworks(src, c)
+	if err != nil {
+		return plan, fmt.Errorf("unable to get %s %s withdrawal networks: %s",
+			src.GetName(), c, err)
+	}
+
+	depositNetworks, err := getTransferNetworks(dst, c)
+	if err != nil {
+		return plan, fmt.Errorf("unable to get %s %s deposit networks: %s",
+			dst.GetName(), c, err)
+	}
+
+	for x := range withdrawNetworks {
+		var deposit *exchange.TransferNetwork
+		for y := range depositNetworks {
+			if strings.EqualFold(withdrawNetworks[x].Network, depositNetworks[y].Network) {
+				deposit = &depositNetworks[y]
+				break
+			}
+		}
+		plan.Routes = append(plan.Routes,
+			newTransferRoute(&withdrawNetworks[x], deposit, amount))
+	}
+
+	sort.SliceStable(plan.Routes, func(i, j int) bool {
+		a, b := plan.Routes[i], plan.Routes[j]
+		if a.Viable != b.Viable {
+			return a.Viable
+		}
+		if a.WithdrawalFee != b.WithdrawalFee {
+			return a.WithdrawalFee < b.WithdrawalFee
+		}
+		if (a.ExpectedTime == 0) != (b.ExpectedTime == 0) {
+			return a.ExpectedTime != 0
+		}
+		return a.ExpectedTime < b.ExpectedTime
+	})
+
+	if len(plan.Routes) > 0 && plan.Routes[0].Viable {
+		plan.Recommended = &plan.Routes[0]
+	}
+	return plan, nil
+}
+
+// newTransferRoute returns the route of a withdrawal network, deposit is nil
+// when the destination exchange does not support the network
+func newTransferRoute(withdraw, deposit *exchange.TransferNetwork, amount float64) TransferRoute {
+	route := TransferRoute{
+		Network:        withdraw.Network,
+		WithdrawalFee:  withdraw.WithdrawalFee,
+		ReceivedAmount: amount - withdraw.WithdrawalFee,
+	}
+
+	timing, ok := transferNetworkTimings[strings.ToUpper(withdraw.Network)]
+	route.Confirmations = timing.confirmations
+	if deposit != nil && deposit.Confirmations > 0 {
+		route.Confirmations = deposit.Confirmations
+	}
+	if ok {
+		route.ExpectedTime = timing.blockTime * time.Duration(route.Confirmations)
+	}
+
+	switch {
+	case !withdraw.WithdrawEnabled:
+		route.Reason = "withdrawals disabled"
+	case deposit == nil:
+		route.Reason = "network not supported by destination"
+	case !deposit.DepositEnabled:
+		route.Reason = "deposits disabled"
+	case amount < withdraw.MinWithdrawal:
+		route.Reason = fmt.Sprintf("amount below minimum withdrawal of %f",
+			withdraw.MinWithdrawal)
+	case route.ReceivedAmount <= 0:
+		route.Reason = "amount does not cover the withdrawal fee"
+	default:
+		route.Viable = true
+	}
+	return route
+}
+
+func executeTransfer(src, dst exchange.IBotExchange, c currency.Code, amount float64, network string) (TransferResult, error) {
+	var result TransferResult
+	var err error
+	result.Plan, err = planTransfer(src, dst, c, amount)
+	if err != nil {
+		return result, err
+	}
+
+	var route *TransferRoute
+	if network == "" {
+		route = result.Plan.Recommended
+	} else {
+		for x := range result.Plan.Routes {
+			if strings.EqualFold(result.Plan.Routes[x].Network, network) {
+				route = &result.Plan.Routes[x]
+				break
+			}
+		}
+	}
+	if route == nil {
+		return result, fmt.Errorf("no route available to transfer %s from %s to %s",
+			c, src.GetName(), dst.GetName())
+	}
+	if !route.Viable {
+		return result, fmt.Errorf("%s route unavailable: %s", route.Network, route.Reason)
+	}
+	result.Route = *route
+
+	// Only request network specific addresses and withdrawals from exchanges
+	// which report networks, others are limited to their default network
+	var address exchange.DepositAddress
+	if networks, ok := dst.(exchange.ITransferNetworksExchange); ok {
+		address, err = networks.GetNetworkDepositAddress(c, route.Network)
+	} else {
+		address.Address, err = dst.GetDepositAddress(c, "")
+	}
+	if err != nil {
+		return result, fmt.Errorf("unable to get %s %s deposit address: %s",
+			dst.GetName(), c, err)
+	}
+	if address.Address == "" {
+		return result, fmt.Errorf("%s returned an empty %s deposit address",
+			dst.GetName(), c)
+	}
+	result.Address = address.Address
+	result.AddressTag = address.Tag
+
+	withdrawRequest := exchange.WithdrawRequest{
+		Description: fmt.Sprintf("Transfer to %s", dst.GetName()),
+		Amount:      amount,
+		Currency:    c,
+		Address:     address.Address,
+		AddressTag:  address.Tag,
+		FeeAmount:   route.WithdrawalFee,
+	}
+	if _, ok := src.(exchange.ITransferNetworksExchange); ok {
+		withdrawRequest.Network = route.Network
+	}
+
+	result.WithdrawalID, err = WithdrawExchangeCryptocurrencyFunds(src, &withdrawRequest)
+	if err != nil {
+		return result, err
+	}
+
+	log.Debugf("Transfer of %f %s from %s to %s submitted via %s, withdrawal ID %s",
+		amount, c, src.GetName(), dst.GetName(), route.Network, result.WithdrawalID)
+	return result, nil
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type transferTestExchange struct {
+	accountInfoTestExchange
+	withdrawals []exchange.WithdrawRequest
+}
+
+func (e *transferTestExchange) GetFeeByType(feeBuilder *exchange.FeeBuilder) (float64, error) {
+	return 0.0005, nil
+}
+
+func (e *transferTestExchange) GetDepositAddress(c currency.Code, accountID string) (string, error) {
+	return "default-" + c.String(), nil
+}
+
+func (e *transferTestExchange) WithdrawCryptocurrencyFunds(withdrawRequest *exchange.WithdrawRequest) (string, error) {
+	e.withdrawals = append(e.withdrawals, *withdrawRequest)
+	return "1", nil
+}
+
+type transferNetworksTestExchange struct {
+	transferTestExchange
+	networks []exchange.TransferNetwork
+}
+
+func (e *transferNetworksTestExchange) GetTransferNetworks(c currency.Code) ([]exchange.TransferNetwork, error) {
+	return e.networks, nil
+}
+
+func (e *transferNetworksTestExchange) GetNetworkDepositAddress(c currency.Code, network string) (exchange.DepositAddress, error) {
+	return exchange.DepositAddress{Address: network + "-address", Tag: "memo"}, nil
+}
+
+type withdrawalFeeTestExchange struct {
+	transferTestExchange
+	fee        float64
+	feeErr     error
+	depositsOK bool
+}
+
+func (e *withdrawalFeeTestExchange) GetWithdrawalFee(c currency.Code) (float64, error) {
+	return e.fee, e.feeErr
+}
+
+func (e *withdrawalFeeTestExchange) GetDepositMethods(c currency.Code) ([]exchange.DepositMethod, error) {
+	return []exchange.DepositMethod{{Method: "bitcoin", Enabled: e.depositsOK}}, nil
+}
+
+func TestPlanTransferWithdrawalFees(t *testing.T) {
+	src := &withdrawalFeeTestExchange{
+		transferTestExchange: transferTestExchange{accountInfoTestExchange: accountInfoTestExchange{name: "Bitfinex"}},
+		fee:                  0.0004,
+	}
+	dst := &withdrawalFeeTestExchange{
+		transferTestExchange: transferTestExchange{accountInfoTestExchange: accountInfoTestExchange{name: "Bittrex"}},
+		depositsOK:           true,
+	}
+
+	plan, err := planTransfer(src, dst, currency.BTC, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Recommended == nil || plan.Recommended.WithdrawalFee != 0.0004 {
+		t.Fatalf("Test failed. Expected live withdrawal fee, got %+v", plan.Recommended)
+	}
+
+	// The fee estimate is used when the live fee is unavailable
+	src.feeErr = errors.New("fees unavailable")
+	plan, err = planTransfer(src, dst, currency.BTC, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Recommended == nil || plan.Recommended.WithdrawalFee != 0.0005 {
+		t.Fatalf("Test failed. Expected estimated withdrawal fee, got %+v", plan.Recommended)
+	}
+
+	dst.depositsOK = false
+	plan, err = planTransfer(src, dst, currency.BTC, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Recommended != nil || plan.Routes[0].Reason != "deposits disabled" {
+		t.Errorf("Test failed. Expected no route with deposits disabled, got %+v",
+			plan.Routes)
+	}
+}
+
+func TestPlanTransfer(t *testing.T) {
+	src := &transferNetworksTestExchange{
+		transferTestExchange: transferTestExchange{accountInfoTestExchange: accountInfoTestExchange{name: "Binance"}},
+		networks: []exchange.TransferNetwork{
+			{Network: "ETH", WithdrawEnabled: true, WithdrawalFee: 5, Confirmations: 12},
+			{Network: "TRX", WithdrawEnabled: true, WithdrawalFee: 1},
+			{Network: "BSC", WithdrawEnabled: true, WithdrawalFee: 1, MinWithdrawal: 20},
+			{Network: "OMNI", WithdrawEnabled: false, WithdrawalFee: 0.1},
+		},
+	}
+	dst := &transferNetworksTestExchange{
+		transferTestExchange: transferTestExchange{accountInfoTestExchange: accountInfoTestExchange{name: "Huobi"}},
+		networks: []exchange.TransferNetwork{
+			{Network: "ETH", DepositEnabled: true, Confirmations: 30},
+			{Network: "TRX", DepositEnabled: true},
+			{Network: "BSC", DepositEnabled: true},
+			{Network: "OMNI", DepositEnabled: true},
+		},
+	}
+
+	plan, err := planTransfer(src, dst, currency.USDT, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Recommended == nil || plan.Recommended.Network != "TRX" ||
+		plan.Recommended.ReceivedAmount != 9 ||
+		plan.Recommended.ExpectedTime != time.Second*3 {
+		t.Fatalf("Test failed. Expected TRX route to be recommended, got %+v", plan.Recommended)
+	}
+	if len(plan.Routes) != 4 || plan.Routes[1].Network != "ETH" ||
+		plan.Routes[1].ExpectedTime != time.Second*15*30 {
+		t.Errorf("Test failed. Unexpected routes %+v", plan.Routes)
+	}
+	for x := range plan.Routes[2:] {
+		if plan.Routes[2+x].Viable || plan.Routes[2+x].Reason == "" {
+			t.Errorf("Test failed. Expected %s route to be unavailable",
+				plan.Routes[2+x].Network)
+		}
+	}
+
+	plan, err = planTransfer(src, dst, currency.USDT, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Recommended != nil {
+		t.Error("Test failed. Expected no route when the amount does not cover fees")
+	}
+
+	// Destinations without network support are limited to the native network
+	legacy := &transferTestExchange{accountInfoTestExchange: accountInfoTestExchange{name: "Bitstamp"}}
+	plan, err = planTransfer(src, legacy, currency.USDT, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Recommended != nil {
+		t.Error("Test failed. Expected no route to a destination without the token network")
+	}
+}
+
+func TestExecuteTransfer(t *testing.T) {
+	if !tradingSupported {
+		t.Skip("trading is not included in this build")
+	}
+
+	setupAccountInfoTest(t, time.Minute)
+	src := &transferTestExchange{accountInfoTestExchange: accountInfoTestExchange{name: "Bitstamp"}}
+	dst := &transferNetworksTestExchange{
+		transferTestExchange: transferTestExchange{accountInfoTestExchange: accountInfoTestExchange{name: "Binance"}},
+		networks: []exchange.TransferNetwork{
+			{Network: "BTC", DepositEnabled: true, Confirmations: 1},
+			{Network: "BSC", DepositEnabled: true},
+		},
+	}
+
+	result, err := executeTransfer(src, dst, currency.BTC, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Route.Network != "BTC" || result.Address != "BTC-address" ||
+		result.Route.ExpectedTime != time.Minute*10 {
+		t.Errorf("Test failed. Unexpected transfer %+v", result)
+	}
+	if len(src.withdrawals) != 1 || src.withdrawals[0].Address != "BTC-address" ||
+		src.withdrawals[0].AddressTag != "memo" || src.withdrawals[0].Network != "" ||
+		src.withdrawals[0].FeeAmount != 0.0005 {
+		t.Errorf("Test failed. Unexpected withdrawal %+v", src.withdrawals)
+	}
+
+	_, err = executeTransfer(src, dst, currency.BTC, 1, "BSC")
+	if err == nil {
+		t.Error("Test failed. Expected error for a network unsupported by the source")
+	}
+	if len(src.withdrawals) != 1 {
+		t.Error("Test failed. Failed transfer should not withdraw")
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+const urlProbeTimeout = time.Second * 5
+
+// probeURL checks whether the host of a URL accepts connections
+func probeURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https", "wss":
+			port = "443"
+		default:
+			port = "80"
+		}
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(u.Hostname(), port),
+		urlProbeTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// checkURLOverrides probes the API and websocket URLs overridden in an
+// exchanges config. Unreachable overrides are reset to the exchanges default
+// URLs when fallback is enabled, otherwise an error is returned
+func checkURLOverrides(exchCfg *config.ExchangeConfig) error {
+	overrides := []struct {
+		name       string
+		url        *string
+		defaultURL string
+	}{
+		{"apiUrl", &exchCfg.APIURL, config.APIURLNonDefaultMessage},
+		{"apiUrlSecondary", &exchCfg.APIURLSecondary, config.APIURLNonDefaultMessage},
+		{"websocketUrl", &exchCfg.WebsocketURL, config.WebsocketURLNonDefaultMessage},
+	}
+
+	for x := range overrides {
+		if *overrides[x].url == "" || *overrides[x].url == overrides[x].defaultURL {
+			continue
+		}
+
+		err := probeURL(*overrides[x].url)
+		if err == nil {
+			continue
+		}
+
+		if !exchCfg.FallbackToDefaultURLs {
+			return fmt.Errorf("exchange %s %s override %s is unreachable: %s",
+				exchCfg.Name, overrides[x].name, *overrides[x].url, err)
+		}
+		log.Warnf("Exchange %s %s override %s is unreachable, falling back to the default URL. Error: %s",
+			exchCfg.Name, overrides[x].name, *overrides[x].url, err)
+		*overrides[x].url = overrides[x].defaultURL
+	}
+	return nil
+}
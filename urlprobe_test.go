@@ -0,0 +1,67 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/config"
+)
+
+func getUnreachableURL(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+	return "http://" + addr
+}
+
+func TestProbeURL(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer s.Close()
+
+	err := probeURL(s.URL)
+	if err != nil {
+		t.Errorf("Test failed. Expected %s to be reachable: %s", s.URL, err)
+	}
+
+	err = probeURL(getUnreachableURL(t))
+	if err == nil {
+		t.Error("Test failed. Expected closed port to be unreachable")
+	}
+}
+
+func TestCheckURLOverrides(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer s.Close()
+
+	exchCfg := config.ExchangeConfig{
+		Name:            "Bitstamp",
+		APIURL:          s.URL,
+		APIURLSecondary: config.APIURLNonDefaultMessage,
+		WebsocketURL:    config.WebsocketURLNonDefaultMessage,
+	}
+	err := checkURLOverrides(&exchCfg)
+	if err != nil || exchCfg.APIURL != s.URL {
+		t.Errorf("Test failed. Expected reachable override to be kept: %v", err)
+	}
+
+	exchCfg.APIURLSecondary = getUnreachableURL(t)
+	err = checkURLOverrides(&exchCfg)
+	if err == nil {
+		t.Error("Test failed. Expected error for an unreachable override")
+	}
+
+	exchCfg.FallbackToDefaultURLs = true
+	err = checkURLOverrides(&exchCfg)
+	if err != nil {
+		t.Error("Test failed. Expected fallback for an unreachable override:", err)
+	}
+	if exchCfg.APIURLSecondary != config.APIURLNonDefaultMessage ||
+		exchCfg.APIURL != s.URL {
+		t.Error("Test failed. Expected only the unreachable override to fall back")
+	}
+}
@@ -0,0 +1,48 @@
+//go:build !noweb
+// +build !noweb
+
+package main
+
+import (
+	"net/http"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// startWebserver serves the RESTful interface and starts the websocket handler
+// relaying data to connected clients
+func startWebserver() {
+	listenAddr := bot.config.Webserver.ListenAddress
+	log.Debugf(
+		"HTTP Webserver support enabled. Listen URL: http://%s:%d/\n",
+		common.ExtractHost(listenAddr), common.ExtractPort(listenAddr),
+	)
+
+	router := NewRouter()
+	go func() {
+		err := http.ListenAndServe(listenAddr, router)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	log.Debugln("HTTP Webserver started successfully.")
+	log.Debugln("Starting websocket handler.")
+	StartWebsocketHandler()
+}
+
+func relayWebsocketEvent(result interface{}, event, assetType, exchangeName string, p currency.Pair) {
+	evt := WebsocketEvent{
+		Data:      result,
+		Event:     event,
+		AssetType: assetType,
+		Exchange:  exchangeName,
+	}
+	err := BroadcastWebsocketMessage(evt, p)
+	if err != nil {
+		log.Errorf("Failed to broadcast websocket event %v. Error: %s",
+			event, err)
+	}
+}
@@ -0,0 +1,21 @@
+//go:build noweb
+// +build noweb
+
+package main
+
+import (
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// startWebserver warns that the webserver is enabled in the config of a build
+// made with the noweb tag, which leaves out the RESTful and websocket servers
+func startWebserver() {
+	log.Warn("HTTP Webserver support is enabled but not included in this build.")
+}
+
+func relayWebsocketEvent(result interface{}, event, assetType, exchangeName string, p currency.Pair) {
+}
+
+func relayOrderbookDelta(exchName string, p currency.Pair, assetType string, ob *orderbook.Base) {}
@@ -1,10 +1,15 @@
+//go:build !noweb
+// +build !noweb
+
 package main
 
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/clock"
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency"
@@ -37,6 +42,11 @@ var wsHandlers = map[string]wsCommandHandler{
 	"getorderbook":     {authRequired: false, handler: wsGetOrderbook},
 	"getexchangerates": {authRequired: false, handler: wsGetExchangeRates},
 	"getportfolio":     {authRequired: true, handler: wsGetPortfolio},
+
+	"subscribe":            {authRequired: false, handler: wsSubscribe},
+	"unsubscribe":          {authRequired: false, handler: wsUnsubscribe},
+	"subscribeorderbook":   {authRequired: false, handler: wsSubscribeOrderbook},
+	"unsubscribeorderbook": {authRequired: false, handler: wsUnsubscribeOrderbook},
 }
 
 // WebsocketClient stores information related to the websocket client
@@ -46,14 +56,22 @@ type WebsocketClient struct {
 	Authenticated bool
 	authFailures  int
 	Send          chan []byte
+	requestWindow time.Time
+	requests      int
 }
 
 // WebsocketHub stores the data for managing websocket clients
 type WebsocketHub struct {
 	Clients    map[*WebsocketClient]bool
-	Broadcast  chan []byte
+	Broadcast  chan websocketBroadcast
 	Register   chan *WebsocketClient
 	Unregister chan *WebsocketClient
+
+	orderbookDeltas chan OrderbookDelta
+	subscriptions   map[*WebsocketClient][]websocketSubscription
+	subscribe       chan websocketSubscriptionRequest
+	authenticated   map[*WebsocketClient]bool
+	authenticate    chan *WebsocketClient
 }
 
 // WebsocketEvent is the struct used for websocket events
@@ -88,10 +106,16 @@ type WebsocketAuth struct {
 // NewWebsocketHub Creates a new websocket hub
 func NewWebsocketHub() *WebsocketHub {
 	return &WebsocketHub{
-		Broadcast:  make(chan []byte),
+		Broadcast:  make(chan websocketBroadcast),
 		Register:   make(chan *WebsocketClient),
 		Unregister: make(chan *WebsocketClient),
 		Clients:    make(map[*WebsocketClient]bool),
+
+		orderbookDeltas: make(chan OrderbookDelta),
+		subscriptions:   make(map[*WebsocketClient][]websocketSubscription),
+		subscribe:       make(chan websocketSubscriptionRequest),
+		authenticated:   make(map[*WebsocketClient]bool),
+		authenticate:    make(chan *WebsocketClient),
 	}
 }
 
@@ -103,23 +127,40 @@ func (h *WebsocketHub) run() {
 		case client := <-h.Unregister:
 			if _, ok := h.Clients[client]; ok {
 				log.Debugln("websocket: disconnected client")
-				delete(h.Clients, client)
-				close(client.Send)
+				h.removeClient(client)
 			}
 		case message := <-h.Broadcast:
-			for client := range h.Clients {
-				select {
-				case client.Send <- message:
-				default:
-					log.Debugln("websocket: disconnected client")
-					close(client.Send)
-					delete(h.Clients, client)
-				}
+			h.broadcast(&message)
+		case req := <-h.subscribe:
+			h.updateSubscription(&req)
+		case client := <-h.authenticate:
+			if _, ok := h.Clients[client]; ok {
+				h.authenticated[client] = true
 			}
+		case delta := <-h.orderbookDeltas:
+			h.sendOrderbookDelta(&delta)
 		}
 	}
 }
 
+// send queues a message for a client, disconnecting clients which are unable
+// to keep up
+func (h *WebsocketHub) send(client *WebsocketClient, message []byte) {
+	select {
+	case client.Send <- message:
+	default:
+		log.Debugln("websocket: disconnected client")
+		h.removeClient(client)
+	}
+}
+
+func (h *WebsocketHub) removeClient(client *WebsocketClient) {
+	close(client.Send)
+	delete(h.Clients, client)
+	delete(h.subscriptions, client)
+	delete(h.authenticated, client)
+}
+
 // SendWebsocketMessage sends a websocket event to the client
 func (c *WebsocketClient) SendWebsocketMessage(evt interface{}) error {
 	data, err := common.JSONEncode(evt)
@@ -166,6 +207,12 @@ func (c *WebsocketClient) read() {
 				break
 			}
 
+			if !c.allowRequest(clock.Now(), bot.config.Webserver.WebsocketRequestRateLimit) {
+				log.Warnf("websocket: request %s rejected, client exceeded the request rate limit", evt.Event)
+				c.SendWebsocketMessage(WebsocketEventResponse{Event: evt.Event, Error: "rate limit exceeded"})
+				continue
+			}
+
 			req := common.StringToLower(evt.Event)
 			log.Debugf("websocket: request received: %s", req)
 
@@ -234,8 +281,9 @@ func StartWebsocketHandler() {
 	}
 }
 
-// BroadcastWebsocketMessage meow
-func BroadcastWebsocketMessage(evt WebsocketEvent) error {
+// BroadcastWebsocketMessage sends an event to the websocket clients subscribed
+// to its event type, exchange, currency pair and asset type
+func BroadcastWebsocketMessage(evt WebsocketEvent, p currency.Pair) error {
 	if !wsHubStarted {
 		return errors.New("websocket service not started")
 	}
@@ -245,7 +293,13 @@ func BroadcastWebsocketMessage(evt WebsocketEvent) error {
 		return err
 	}
 
-	wsHub.Broadcast <- data
+	wsHub.Broadcast <- websocketBroadcast{
+		event:     evt.Event,
+		exchange:  evt.Exchange,
+		pair:      p,
+		assetType: evt.AssetType,
+		data:      data,
+	}
 	return nil
 }
 
@@ -309,6 +363,7 @@ func wsAuth(client *WebsocketClient, data interface{}) error {
 
 	if auth.Username == bot.config.Webserver.AdminUsername && auth.Password == hashPW {
 		client.Authenticated = true
+		client.Hub.authenticate <- client
 		wsResp.Data = WebsocketResponseSuccess
 		log.Debugf("websocket: client authenticated successfully")
 		return client.SendWebsocketMessage(wsResp)
@@ -362,11 +417,18 @@ func wsSaveConfig(client *WebsocketClient, data interface{}) error {
 }
 
 func wsGetAccountInfo(client *WebsocketClient, data interface{}) error {
-	accountInfo := GetAllEnabledExchangeAccountInfo()
 	wsResp := WebsocketEventResponse{
 		Event: "GetAccountInfo",
-		Data:  accountInfo,
 	}
+	// An optional boolean payload bypasses the cached account snapshots
+	var forceRefresh bool
+	err := common.JSONDecode(data.([]byte), &forceRefresh)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+	wsResp.Data = GetAllEnabledExchangeAccountInfo(forceRefresh)
 	return client.SendWebsocketMessage(wsResp)
 }
 
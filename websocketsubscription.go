@@ -0,0 +1,268 @@
+//go:build !noweb
+// +build !noweb
+
+package main
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+	log "github.com/thrasher-/gocryptotrader/logger"
+)
+
+// wsSubscriptionEvents holds the events websocket clients can subscribe to and
+// whether they carry private data only sent to authenticated clients
+var wsSubscriptionEvents = map[string]bool{
+	"ticker_update":    false,
+	"orderbook_update": false,
+	"orderbook_delta":  false,
+	"position_update":  true,
+}
+
+// WebsocketSubscriptionRequest is used to subscribe to a websocket event,
+// empty exchange, currency and asset type fields match all
+type WebsocketSubscriptionRequest struct {
+	Event     string `json:"event"`
+	Exchange  string `json:"exchangeName"`
+	Currency  string `json:"currency"`
+	AssetType string `json:"assetType"`
+}
+
+// websocketSubscription filters the events sent to a websocket client
+type websocketSubscription struct {
+	Event     string
+	Exchange  string
+	Pair      currency.Pair
+	AssetType string
+}
+
+// websocketSubscriptionRequest adds or removes a clients subscription
+type websocketSubscriptionRequest struct {
+	client      *WebsocketClient
+	sub         websocketSubscription
+	unsubscribe bool
+}
+
+// websocketBroadcast holds an encoded websocket event and the fields it is
+// filtered by
+type websocketBroadcast struct {
+	event     string
+	exchange  string
+	pair      currency.Pair
+	assetType string
+	data      []byte
+}
+
+// newWebsocketSubscription parses a websocket client subscription request
+func newWebsocketSubscription(req *WebsocketSubscriptionRequest) (websocketSubscription, error) {
+	sub := websocketSubscription{
+		Event:     common.StringToLower(req.Event),
+		Exchange:  req.Exchange,
+		AssetType: req.AssetType,
+	}
+	if _, ok := wsSubscriptionEvents[sub.Event]; !ok {
+		return sub, errors.New("unsupported subscription event")
+	}
+	if req.Currency != "" {
+		if len(req.Currency) < 3 {
+			return sub, errors.New("invalid currency pair")
+		}
+		sub.Pair = currency.NewPairFromString(req.Currency)
+	}
+	return sub, nil
+}
+
+// Match returns whether an exchange, pair and asset type match the
+// subscription
+func (s *websocketSubscription) Match(exchName string, p currency.Pair, assetType string) bool {
+	if s.Exchange != "" && !strings.EqualFold(s.Exchange, exchName) {
+		return false
+	}
+	if !s.Pair.IsEmpty() && !s.Pair.Equal(p) {
+		return false
+	}
+	return s.AssetType == "" || strings.EqualFold(s.AssetType, assetType)
+}
+
+// allowRequest returns whether a client request is within the per connection
+// rate limit of requests per second
+func (c *WebsocketClient) allowRequest(now time.Time, limit int) bool {
+	if now.Sub(c.requestWindow) >= time.Second {
+		c.requestWindow = now
+		c.requests = 0
+	}
+	c.requests++
+	return c.requests <= limit
+}
+
+// updateSubscription adds or removes a clients subscription. Clients which
+// have subscribed only receive matching events rather than all broadcasts.
+// New orderbook delta subscriptions are sent a snapshot of each matching
+// orderbook so subsequent deltas can be applied
+func (h *WebsocketHub) updateSubscription(req *websocketSubscriptionRequest) {
+	if _, ok := h.Clients[req.client]; !ok {
+		return
+	}
+
+	if req.unsubscribe {
+		var subs []websocketSubscription
+		for _, sub := range h.subscriptions[req.client] {
+			if sub != req.sub {
+				subs = append(subs, sub)
+			}
+		}
+		h.subscriptions[req.client] = subs
+		return
+	}
+
+	h.subscriptions[req.client] = append(h.subscriptions[req.client], req.sub)
+	if req.sub.Event != "orderbook_delta" {
+		return
+	}
+
+	snapshots := orderbookDeltas.Snapshots(&req.sub)
+	for x := range snapshots {
+		message, err := encodeOrderbookDelta(&snapshots[x])
+		if err != nil {
+			log.Errorf("websocket: failed to encode orderbook snapshot: %s", err)
+			continue
+		}
+		h.send(req.client, message)
+		if _, ok := h.Clients[req.client]; !ok {
+			return
+		}
+	}
+}
+
+// isSubscribed returns whether a client should receive an event. Clients
+// without subscriptions receive all events except orderbook deltas
+func (h *WebsocketHub) isSubscribed(client *WebsocketClient, event, exchName string, p currency.Pair, assetType string) bool {
+	if wsSubscriptionEvents[event] && !h.authenticated[client] {
+		return false
+	}
+
+	subs, ok := h.subscriptions[client]
+	if !ok {
+		return event != "orderbook_delta"
+	}
+	for x := range subs {
+		if subs[x].Event == event && subs[x].Match(exchName, p, assetType) {
+			return true
+		}
+	}
+	return false
+}
+
+// broadcast sends an event to each subscribed client
+func (h *WebsocketHub) broadcast(msg *websocketBroadcast) {
+	for client := range h.Clients {
+		if h.isSubscribed(client, msg.event, msg.exchange, msg.pair, msg.assetType) {
+			h.send(client, msg.data)
+		}
+	}
+}
+
+// sendOrderbookDelta sends an orderbook delta to each subscribed client
+func (h *WebsocketHub) sendOrderbookDelta(delta *OrderbookDelta) {
+	var message []byte
+	for client := range h.Clients {
+		if !h.isSubscribed(client, "orderbook_delta", delta.Exchange, delta.Pair, delta.AssetType) {
+			continue
+		}
+		if message == nil {
+			var err error
+			message, err = encodeOrderbookDelta(delta)
+			if err != nil {
+				log.Errorf("websocket: failed to encode orderbook delta: %s", err)
+				return
+			}
+		}
+		h.send(client, message)
+	}
+}
+
+func encodeOrderbookDelta(delta *OrderbookDelta) ([]byte, error) {
+	return common.JSONEncode(WebsocketEvent{
+		Exchange:  delta.Exchange,
+		AssetType: delta.AssetType,
+		Event:     "orderbook_delta",
+		Data:      delta,
+	})
+}
+
+func wsSubscribe(client *WebsocketClient, data interface{}) error {
+	var req WebsocketSubscriptionRequest
+	err := common.JSONDecode(data.([]byte), &req)
+	if err != nil {
+		client.SendWebsocketMessage(WebsocketEventResponse{Event: "Subscribe", Error: err.Error()})
+		return err
+	}
+	return wsUpdateSubscription(client, "Subscribe", &req, false)
+}
+
+func wsUnsubscribe(client *WebsocketClient, data interface{}) error {
+	var req WebsocketSubscriptionRequest
+	err := common.JSONDecode(data.([]byte), &req)
+	if err != nil {
+		client.SendWebsocketMessage(WebsocketEventResponse{Event: "Unsubscribe", Error: err.Error()})
+		return err
+	}
+	return wsUpdateSubscription(client, "Unsubscribe", &req, true)
+}
+
+func wsSubscribeOrderbook(client *WebsocketClient, data interface{}) error {
+	var req WebsocketSubscriptionRequest
+	err := common.JSONDecode(data.([]byte), &req)
+	if err != nil {
+		client.SendWebsocketMessage(WebsocketEventResponse{Event: "SubscribeOrderbook", Error: err.Error()})
+		return err
+	}
+	req.Event = "orderbook_delta"
+	return wsUpdateSubscription(client, "SubscribeOrderbook", &req, false)
+}
+
+func wsUnsubscribeOrderbook(client *WebsocketClient, data interface{}) error {
+	var req WebsocketSubscriptionRequest
+	err := common.JSONDecode(data.([]byte), &req)
+	if err != nil {
+		client.SendWebsocketMessage(WebsocketEventResponse{Event: "UnsubscribeOrderbook", Error: err.Error()})
+		return err
+	}
+	req.Event = "orderbook_delta"
+	return wsUpdateSubscription(client, "UnsubscribeOrderbook", &req, true)
+}
+
+func wsUpdateSubscription(client *WebsocketClient, event string, req *WebsocketSubscriptionRequest, unsubscribe bool) error {
+	wsResp := WebsocketEventResponse{
+		Event: event,
+	}
+
+	sub, err := newWebsocketSubscription(req)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	if wsSubscriptionEvents[sub.Event] && !client.Authenticated {
+		wsResp.Error = "unauthorised subscription to private event"
+		client.SendWebsocketMessage(wsResp)
+		return errors.New(wsResp.Error)
+	}
+
+	wsResp.Data = WebsocketResponseSuccess
+	err = client.SendWebsocketMessage(wsResp)
+	if err != nil {
+		return err
+	}
+
+	client.Hub.subscribe <- websocketSubscriptionRequest{
+		client:      client,
+		sub:         sub,
+		unsubscribe: unsubscribe,
+	}
+	return nil
+}
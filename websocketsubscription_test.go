@@ -0,0 +1,105 @@
+//go:build !noweb
+// +build !noweb
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+)
+
+func TestNewWebsocketSubscription(t *testing.T) {
+	sub, err := newWebsocketSubscription(&WebsocketSubscriptionRequest{
+		Event:    "Ticker_Update",
+		Exchange: "Bitstamp",
+		Currency: "BTCUSD",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.Event != "ticker_update" || !sub.Pair.Equal(currency.NewPairFromStrings("BTC", "USD")) {
+		t.Errorf("Test failed. Unexpected subscription %+v", sub)
+	}
+
+	_, err = newWebsocketSubscription(&WebsocketSubscriptionRequest{Event: "trades"})
+	if err == nil {
+		t.Error("Test failed. Expected error for an unsupported event")
+	}
+
+	_, err = newWebsocketSubscription(&WebsocketSubscriptionRequest{
+		Event:    "ticker_update",
+		Currency: "BT",
+	})
+	if err == nil {
+		t.Error("Test failed. Expected error for an invalid currency pair")
+	}
+}
+
+func TestWebsocketHubSubscriptionFilters(t *testing.T) {
+	h := NewWebsocketHub()
+	legacy := &WebsocketClient{Hub: h, Send: make(chan []byte, 10)}
+	filtered := &WebsocketClient{Hub: h, Send: make(chan []byte, 10)}
+	private := &WebsocketClient{Hub: h, Send: make(chan []byte, 10)}
+	h.Clients[legacy] = true
+	h.Clients[filtered] = true
+	h.Clients[private] = true
+	h.authenticated[private] = true
+
+	btc := currency.NewPairFromStrings("BTC", "USD")
+	ltc := currency.NewPairFromStrings("LTC", "USD")
+	h.updateSubscription(&websocketSubscriptionRequest{
+		client: filtered,
+		sub:    websocketSubscription{Event: "ticker_update", Exchange: "Bitstamp", Pair: btc},
+	})
+	h.updateSubscription(&websocketSubscriptionRequest{
+		client: private,
+		sub:    websocketSubscription{Event: "position_update"},
+	})
+
+	h.broadcast(&websocketBroadcast{event: "ticker_update", exchange: "Bitstamp", pair: btc})
+	h.broadcast(&websocketBroadcast{event: "ticker_update", exchange: "Bitstamp", pair: ltc})
+	h.broadcast(&websocketBroadcast{event: "orderbook_update", exchange: "Bitstamp", pair: btc})
+	if len(legacy.Send) != 3 {
+		t.Errorf("Test failed. Expected unsubscribed client to receive all events, got %d",
+			len(legacy.Send))
+	}
+	if len(filtered.Send) != 1 {
+		t.Errorf("Test failed. Expected subscribed client to receive 1 event, got %d",
+			len(filtered.Send))
+	}
+	if len(private.Send) != 0 {
+		t.Errorf("Test failed. Expected no public events for the private subscription, got %d",
+			len(private.Send))
+	}
+
+	h.broadcast(&websocketBroadcast{event: "position_update", exchange: "Bitstamp", pair: btc})
+	if len(legacy.Send) != 3 {
+		t.Error("Test failed. Unauthenticated client should not receive private events")
+	}
+	if len(private.Send) != 1 {
+		t.Error("Test failed. Expected authenticated client to receive private event")
+	}
+
+	h.removeClient(private)
+	if h.authenticated[private] || h.subscriptions[private] != nil {
+		t.Error("Test failed. Expected removed client state to be cleared")
+	}
+}
+
+func TestWebsocketClientAllowRequest(t *testing.T) {
+	var c WebsocketClient
+	now := time.Now()
+	for x := 0; x < 3; x++ {
+		if !c.allowRequest(now, 3) {
+			t.Fatalf("Test failed. Request %d should be allowed", x+1)
+		}
+	}
+	if c.allowRequest(now.Add(time.Millisecond*500), 3) {
+		t.Error("Test failed. Expected request over the limit to be rejected")
+	}
+	if !c.allowRequest(now.Add(time.Second), 3) {
+		t.Error("Test failed. Expected request in a new window to be allowed")
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// withdrawalFeeTransferType is the funding history transfer type of
+// withdrawals
+const withdrawalFeeTransferType = "withdrawal"
+
+// WithdrawalFeeSuggestion compares the average fee paid withdrawing a currency
+// from an exchange against the cheapest route currently available on any
+// enabled exchange. Saving is the percentage of the average fee the route
+// would have saved, Value the total saving over the withdrawals in the report
+// currency
+type WithdrawalFeeSuggestion struct {
+	Exchange       string        `json:"exchange"`
+	Currency       currency.Code `json:"currency"`
+	Withdrawals    int           `json:"withdrawals"`
+	AverageFee     float64       `json:"averageFee"`
+	Alternative    string        `json:"alternative"`
+	Network        string        `json:"network"`
+	AlternativeFee float64       `json:"alternativeFee"`
+	Saving         float64       `json:"saving"`
+	Value          float64       `json:"value"`
+	Valued         bool          `json:"valued"`
+}
+
+// paidWithdrawalFees holds the fees paid withdrawing a currency from an
+// exchange
+type paidWithdrawalFees struct {
+	exchange    string
+	currency    currency.Code
+	withdrawals int
+	total       float64
+}
+
+// withdrawalRoute is the live withdrawal fee of a currency on an exchange
+// network
+type withdrawalRoute struct {
+	exchange string
+	network  string
+	fee      float64
+}
+
+// getWithdrawalFeeSuggestions returns the cheaper withdrawal routes for the
+// withdrawals made within a period, which would have saved at least minSaving
+// percent of the average fee paid. Only exchanges reporting live fees or
+// networks are compared against, as fee estimates are often outdated
+func getWithdrawalFeeSuggestions(exchanges []exchange.IBotExchange, from, to time.Time, minSaving float64, target currency.Code) ([]WithdrawalFeeSuggestion, []string) {
+	var paid []paidWithdrawalFees
+	var errs []string
+	for _, exch := range exchanges {
+		if exch == nil || !exch.IsEnabled() || !exch.GetAuthenticatedAPISupport() {
+			continue
+		}
+
+		history, err := exch.GetFundingHistory()
+		if err != nil {
+			if err != common.ErrFunctionNotSupported &&
+				err != common.ErrNotYetImplemented {
+				errs = append(errs, fmt.Sprintf("%s funding history: %s",
+					exch.GetName(), err))
+			}
+			continue
+		}
+		paid = append(paid, sumWithdrawalFees(exch.GetName(), history, from, to)...)
+	}
+
+	var suggestions []WithdrawalFeeSuggestion
+	routes := make(map[currency.Code][]withdrawalRoute)
+	for x := range paid {
+		c := paid[x].currency
+		if _, ok := routes[c]; !ok {
+			routes[c] = getLiveWithdrawalRoutes(exchanges, c)
+		}
+
+		suggestion, ok := suggestWithdrawalRoute(&paid[x], routes[c], minSaving)
+		if !ok {
+			continue
+		}
+		suggestion.Value, suggestion.Valued = getReportValue(exchanges, c,
+			(suggestion.AverageFee-suggestion.AlternativeFee)*float64(suggestion.Withdrawals),
+			target)
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Valued != suggestions[j].Valued {
+			return suggestions[i].Valued
+		}
+		if suggestions[i].Value != suggestions[j].Value {
+			return suggestions[i].Value > suggestions[j].Value
+		}
+		return suggestions[i].Saving > suggestions[j].Saving
+	})
+	return suggestions, errs
+}
+
+// sumWithdrawalFees returns the fees paid by currency for the withdrawals in
+// an exchanges funding history made within a period
+func sumWithdrawalFees(exchName string, history []exchange.FundHistory, from, to time.Time) []paidWithdrawalFees {
+	var paid []paidWithdrawalFees
+	for x := range history {
+		if !strings.EqualFold(history[x].TransferType, withdrawalFeeTransferType) ||
+			history[x].Fee <= 0 || history[x].Currency == "" ||
+			history[x].Timestamp.Before(from) || history[x].Timestamp.After(to) {
+			continue
+		}
+
+		c := currency.NewCode(history[x].Currency).Upper()
+		found := false
+		for y := range paid {
+			if paid[y].currency.Match(c) {
+				paid[y].withdrawals++
+				paid[y].total += history[x].Fee
+				found = true
+				break
+			}
+		}
+		if !found {
+			paid = append(paid, paidWithdrawalFees{
+				exchange:    exchName,
+				currency:    c,
+				withdrawals: 1,
+				total:       history[x].Fee,
+			})
+		}
+	}
+	return paid
+}
+
+// getLiveWithdrawalRoutes returns the enabled withdrawal networks of a
+// currency on the exchanges which report their current fees
+func getLiveWithdrawalRoutes(exchanges []exchange.IBotExchange, c currency.Code) []withdrawalRoute {
+	var routes []withdrawalRoute
+	for _, exch := range exchanges {
+		if exch == nil || !exch.IsEnabled() {
+			continue
+		}
+
+		if networks, ok := exch.(exchange.ITransferNetworksExchange); ok {
+			available, err := networks.GetTransferNetworks(c)
+			if err != nil {
+				continue
+			}
+			for x := range available {
+				if !available[x].WithdrawEnabled {
+					continue
+				}
+				routes = append(routes, withdrawalRoute{
+					exchange: exch.GetName(),
+					network:  available[x].Network,
+					fee:      available[x].WithdrawalFee,
+				})
+			}
+			continue
+		}
+
+		if reporter, ok := exch.(exchange.IWithdrawalFeeExchange); ok {
+			fee, err := reporter.GetWithdrawalFee(c)
+			if err != nil {
+				continue
+			}
+			routes = append(routes, withdrawalRoute{
+				exchange: exch.GetName(),
+				network:  c.Upper().String(),
+				fee:      fee,
+			})
+		}
+	}
+	return routes
+}
+
+// suggestWithdrawalRoute returns the cheapest route for withdrawals when it
+// would have saved at least minSaving percent of their average fee. Routes on
+// the exchange withdrawn from are preferred on equal fees
+func suggestWithdrawalRoute(paid *paidWithdrawalFees, routes []withdrawalRoute, minSaving float64) (WithdrawalFeeSuggestion, bool) {
+	average := paid.total / float64(paid.withdrawals)
+	var cheapest *withdrawalRoute
+	for x := range routes {
+		if routes[x].fee < 0 {
+			continue
+		}
+		if cheapest == nil || routes[x].fee < cheapest.fee ||
+			(routes[x].fee == cheapest.fee && routes[x].exchange == paid.exchange &&
+				cheapest.exchange != paid.exchange) {
+			cheapest = &routes[x]
+		}
+	}
+	if cheapest == nil {
+		return WithdrawalFeeSuggestion{}, false
+	}
+
+	saving := (average - cheapest.fee) / average * 100
+	if saving < minSaving {
+		return WithdrawalFeeSuggestion{}, false
+	}
+	return WithdrawalFeeSuggestion{
+		Exchange:       paid.exchange,
+		Currency:       paid.currency,
+		Withdrawals:    paid.withdrawals,
+		AverageFee:     average,
+		Alternative:    cheapest.exchange,
+		Network:        cheapest.network,
+		AlternativeFee: cheapest.fee,
+		Saving:         saving,
+	}, true
+}
+
+// String returns the suggestion formatted for the communication mediums
+func (w *WithdrawalFeeSuggestion) String() string {
+	return fmt.Sprintf("%s %s: paid %f average over %d withdrawals, %s %s route would have cost %.0f%% less",
+		w.Exchange, w.Currency, w.AverageFee, w.Withdrawals, w.Alternative,
+		w.Network, w.Saving)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+type feeSuggestionTestExchange struct {
+	exchange.IBotExchange
+	name     string
+	history  []exchange.FundHistory
+	networks []exchange.TransferNetwork
+}
+
+func (w *feeSuggestionTestExchange) GetName() string {
+	return w.name
+}
+
+func (w *feeSuggestionTestExchange) IsEnabled() bool {
+	return true
+}
+
+func (w *feeSuggestionTestExchange) GetAuthenticatedAPISupport() bool {
+	return true
+}
+
+func (w *feeSuggestionTestExchange) GetFundingHistory() ([]exchange.FundHistory, error) {
+	return w.history, nil
+}
+
+func (w *feeSuggestionTestExchange) GetTransferNetworks(_ currency.Code) ([]exchange.TransferNetwork, error) {
+	return w.networks, nil
+}
+
+func (w *feeSuggestionTestExchange) GetNetworkDepositAddress(_ currency.Code, _ string) (exchange.DepositAddress, error) {
+	return exchange.DepositAddress{}, nil
+}
+
+func TestSumWithdrawalFees(t *testing.T) {
+	from := time.Unix(1000, 0)
+	to := from.Add(time.Hour)
+	history := []exchange.FundHistory{
+		{TransferType: "withdrawal", Currency: "btc", Fee: 0.0004, Timestamp: from.Add(time.Minute)},
+		{TransferType: "Withdrawal", Currency: "BTC", Fee: 0.0006, Timestamp: from.Add(time.Minute * 2)},
+		{TransferType: "deposit", Currency: "BTC", Fee: 0.1, Timestamp: from.Add(time.Minute)},
+		{TransferType: "withdrawal", Currency: "BTC", Fee: 0.1, Timestamp: to.Add(time.Minute)},
+		{TransferType: "withdrawal", Currency: "ETH", Timestamp: from.Add(time.Minute)},
+	}
+
+	paid := sumWithdrawalFees("FeeTest", history, from, to)
+	if len(paid) != 1 {
+		t.Fatalf("Test failed. Expected 1 currency, got %d", len(paid))
+	}
+	if !paid[0].currency.Match(currency.BTC) || paid[0].withdrawals != 2 ||
+		paid[0].total != 0.001 {
+		t.Errorf("Test failed. Unexpected paid fees %+v", paid[0])
+	}
+}
+
+func TestSuggestWithdrawalRoute(t *testing.T) {
+	paid := paidWithdrawalFees{exchange: "A", currency: currency.USDT,
+		withdrawals: 2, total: 20}
+	routes := []withdrawalRoute{
+		{exchange: "B", network: "TRX", fee: 1},
+		{exchange: "A", network: "ETH", fee: 10},
+		{exchange: "A", network: "TRX", fee: 1},
+	}
+
+	suggestion, ok := suggestWithdrawalRoute(&paid, routes, 25)
+	if !ok {
+		t.Fatal("Test failed. Expected a suggestion")
+	}
+	if suggestion.Alternative != "A" || suggestion.Network != "TRX" ||
+		suggestion.AverageFee != 10 || suggestion.Saving != 90 {
+		t.Errorf("Test failed. Unexpected suggestion %+v", suggestion)
+	}
+
+	_, ok = suggestWithdrawalRoute(&paid, routes, 95)
+	if ok {
+		t.Error("Test failed. Expected no suggestion below the minimum saving")
+	}
+
+	_, ok = suggestWithdrawalRoute(&paid, nil, 25)
+	if ok {
+		t.Error("Test failed. Expected no suggestion without routes")
+	}
+}
+
+func TestGetWithdrawalFeeSuggestions(t *testing.T) {
+	from := time.Unix(1000, 0)
+	to := from.Add(time.Hour)
+	src := &feeSuggestionTestExchange{
+		name: "FeeTestSource",
+		history: []exchange.FundHistory{
+			{TransferType: "withdrawal", Currency: "USDT", Fee: 5, Timestamp: from.Add(time.Minute)},
+			{TransferType: "withdrawal", Currency: "USDT", Fee: 5, Timestamp: from.Add(time.Minute * 2)},
+		},
+		networks: []exchange.TransferNetwork{
+			{Network: "ETH", WithdrawEnabled: true, WithdrawalFee: 5},
+		},
+	}
+	dst := &feeSuggestionTestExchange{
+		name: "FeeTestOther",
+		networks: []exchange.TransferNetwork{
+			{Network: "TRX", WithdrawEnabled: true, WithdrawalFee: 1},
+			{Network: "BSC", WithdrawalFee: 0.1},
+		},
+	}
+	err := ticker.ProcessTicker(src.GetName(),
+		&ticker.Price{Pair: currency.NewPair(currency.USDT, currency.USD), Last: 1},
+		ticker.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	suggestions, errs := getWithdrawalFeeSuggestions(
+		[]exchange.IBotExchange{src, dst}, from, to, 25, currency.USD)
+	if len(errs) != 0 {
+		t.Errorf("Test failed. Unexpected errors %v", errs)
+	}
+	if len(suggestions) != 1 {
+		t.Fatalf("Test failed. Expected 1 suggestion, got %d", len(suggestions))
+	}
+	s := suggestions[0]
+	if s.Alternative != "FeeTestOther" || s.Network != "TRX" || s.Saving != 80 ||
+		!s.Valued || s.Value != 8 {
+		t.Errorf("Test failed. Unexpected suggestion %+v", s)
+	}
+	if !strings.Contains(s.String(), "FeeTestOther TRX route would have cost 80% less") {
+		t.Errorf("Test failed. Unexpected suggestion string %q", s.String())
+	}
+}